@@ -15,6 +15,9 @@ import (
 // Storage is the interface for beads storage operations
 type Storage = beads.Storage
 
+// CloseOptions describes how Storage.CloseIssue closes an issue
+type CloseOptions = beads.CloseOptions
+
 // NewSQLiteStorage creates a new SQLite storage instance at the given path
 func NewSQLiteStorage(dbPath string) (Storage, error) {
 	return beads.NewSQLiteStorage(dbPath)
@@ -25,6 +28,11 @@ func FindDatabasePath() string {
 	return beads.FindDatabasePath()
 }
 
+// FindDatabaseInBeadsDir finds the database in a specific .beads directory
+func FindDatabaseInBeadsDir(beadsDir string) string {
+	return beads.FindDatabaseInBeadsDir(beadsDir)
+}
+
 // FindBeadsDir finds the .beads/ directory in the current directory tree
 // Returns empty string if not found. Supports both database and JSONL-only mode.
 func FindBeadsDir() string {
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive [id...]",
+	Short: "Hide issues from normal views without deleting them",
+	Long: `Archive issues by setting their archived_at timestamp.
+
+Archived issues are left out of bd list, bd search and bd ready unless
+--include-archived is given, but they are still exported to JSONL, shown by
+bd show, and resolvable by ID. bd unarchive brings them back.
+
+Instead of IDs, --closed-before archives every closed issue closed before a
+date. The matching count is confirmed before anything changes unless --yes
+is given. To have the daemon do this on a schedule, set
+retention_archive_after (see 'bd config list --all').
+
+Examples:
+  bd archive bd-42 bd-43
+  bd archive --closed-before 2025-01-01 --dry-run
+  bd archive --closed-before 180d --yes`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		closedBeforeStr, _ := cmd.Flags().GetString("closed-before")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		if closedBeforeStr != "" && len(args) > 0 {
+			fmt.Fprintf(os.Stderr, "Error: issue IDs cannot be combined with --closed-before\n")
+			os.Exit(1)
+		}
+		if closedBeforeStr == "" && len(args) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: requires at least one issue ID, or --closed-before\n")
+			os.Exit(1)
+		}
+
+		var ids []string
+		if closedBeforeStr != "" {
+			closedBefore, err := parseTimeFlag(closedBeforeStr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing --closed-before: %v\n", err)
+				os.Exit(1)
+			}
+			ids, err = findArchiveCandidates(ctx, closedBefore)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if len(ids) == 0 {
+				if jsonOutput {
+					outputJSON([]*types.Issue{})
+				} else {
+					fmt.Println("No issues match")
+				}
+				return
+			}
+		} else {
+			ids = resolveIssueIDs(ctx, args)
+		}
+
+		if dryRun {
+			if jsonOutput {
+				outputJSON(map[string]interface{}{
+					"dry_run":       true,
+					"count":         len(ids),
+					"would_archive": ids,
+				})
+			} else {
+				fmt.Printf("Would archive %d issue(s):\n", len(ids))
+				for _, id := range ids {
+					fmt.Printf("  %s\n", id)
+				}
+			}
+			return
+		}
+		if closedBeforeStr != "" && !yes {
+			// Prompt on stderr so --json output stays parseable
+			fmt.Fprintf(os.Stderr, "Archive %d issue(s)? [y/N] ", len(ids))
+			var response string
+			_, _ = fmt.Scanln(&response)
+			if strings.ToLower(strings.TrimSpace(response)) != "y" {
+				fmt.Fprintln(os.Stderr, "Canceled.")
+				return
+			}
+		}
+
+		setArchived(ctx, ids, true)
+	},
+}
+
+var unarchiveCmd = &cobra.Command{
+	Use:   "unarchive [id...]",
+	Short: "Return archived issues to normal views",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		setArchived(ctx, resolveIssueIDs(ctx, args), false)
+	},
+}
+
+// findArchiveCandidates returns the IDs of unarchived closed issues closed
+// before closedBefore
+func findArchiveCandidates(ctx context.Context, closedBefore time.Time) ([]string, error) {
+	status := types.StatusClosed
+	var issues []*types.Issue
+	if daemonClient != nil {
+		resp, err := daemonClient.List(&rpc.ListArgs{
+			Status:       string(status),
+			ClosedBefore: closedBefore.Format(time.RFC3339),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(resp.Data, &issues); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+	} else {
+		if store == nil {
+			return nil, fmt.Errorf("database not initialized")
+		}
+		return archiveCandidatesIn(ctx, store, closedBefore)
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids, nil
+}
+
+// archiveCandidatesIn returns the IDs of unarchived closed issues in s closed
+// before closedBefore
+func archiveCandidatesIn(ctx context.Context, s storage.Storage, closedBefore time.Time) ([]string, error) {
+	status := types.StatusClosed
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{
+		Status:       &status,
+		ClosedBefore: &closedBefore,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids, nil
+}
+
+// setArchivedIn archives (archived_at = now) or unarchives one issue in s
+func setArchivedIn(ctx context.Context, s storage.Storage, id string, archive bool, actor string) error {
+	var archivedAt interface{}
+	if archive {
+		archivedAt = time.Now()
+	}
+	return s.UpdateIssue(ctx, id, map[string]interface{}{"archived_at": archivedAt}, actor)
+}
+
+// setArchived archives or unarchives each issue, skipping ones already in
+// that state, and reports the result
+func setArchived(ctx context.Context, ids []string, archive bool) {
+	verb := "Archived"
+	if !archive {
+		verb = "Unarchived"
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	results := []*types.Issue{}
+	changed := 0
+	for _, id := range ids {
+		issue, err := getIssueForArchive(ctx, id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			continue
+		}
+		if (issue.ArchivedAt != nil) == archive {
+			if !jsonOutput {
+				state := "archived"
+				if !archive {
+					state = "not archived"
+				}
+				fmt.Printf("%s %s is already %s\n", yellow("⚠"), id, state)
+			}
+			continue
+		}
+
+		if daemonClient != nil {
+			if _, err := daemonClient.Update(&rpc.UpdateArgs{ID: id, Archived: &archive}); err != nil {
+				fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
+				continue
+			}
+		} else {
+			if err := setArchivedIn(ctx, store, id, archive, actor); err != nil {
+				fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
+				continue
+			}
+		}
+		changed++
+
+		if jsonOutput {
+			if updated, err := getIssueForArchive(ctx, id); err == nil {
+				results = append(results, updated)
+			}
+		} else {
+			fmt.Printf("%s %s %s: %s\n", green("✓"), verb, id, issue.Title)
+		}
+	}
+
+	if changed > 0 && daemonClient == nil {
+		markDirtyAndScheduleFlush()
+	}
+	if jsonOutput {
+		outputJSON(results)
+	}
+}
+
+// getIssueForArchive fetches an issue in either daemon or direct mode
+func getIssueForArchive(ctx context.Context, id string) (*types.Issue, error) {
+	if daemonClient != nil {
+		resp, err := daemonClient.Show(&rpc.ShowArgs{ID: id})
+		if err != nil {
+			return nil, err
+		}
+		var issue types.Issue
+		if err := json.Unmarshal(resp.Data, &issue); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", id, err)
+		}
+		return &issue, nil
+	}
+	issue, err := store.GetIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue %s not found", id)
+	}
+	return issue, nil
+}
+
+func init() {
+	archiveCmd.Flags().String("closed-before", "", "Archive closed issues closed before this date (YYYY-MM-DD, RFC3339, or a duration like 90d)")
+	archiveCmd.Flags().Bool("dry-run", false, "List the issues that would be archived without archiving them")
+	archiveCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt when archiving by --closed-before")
+	rootCmd.AddCommand(archiveCmd)
+	rootCmd.AddCommand(unarchiveCmd)
+}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package main
+
+import "os"
+
+// renameFile atomically replaces dst with src
+func renameFile(src, dst string) error {
+	return os.Rename(src, dst)
+}
@@ -0,0 +1,29 @@
+//go:build windows
+
+package main
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// renameFile replaces dst with src. os.Rename uses MoveFileEx with
+// MOVEFILE_REPLACE_EXISTING on Windows, but it fails with a sharing violation
+// while another process (watcher, editor, antivirus) briefly holds dst open,
+// so retry a few times before giving up.
+func renameFile(src, dst string) error {
+	var err error
+	for attempt := 0; attempt < 5; attempt++ {
+		if err = os.Rename(src, dst); err == nil {
+			return nil
+		}
+		if !errors.Is(err, windows.ERROR_ACCESS_DENIED) && !errors.Is(err, windows.ERROR_SHARING_VIOLATION) {
+			return err
+		}
+		time.Sleep(time.Duration(attempt+1) * 20 * time.Millisecond)
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// writeFileAtomic writes a file by streaming into a temporary file in the same
+// directory and renaming it over path only after write succeeds and the data
+// is flushed and fsynced. If write returns an error, or anything fails before
+// the rename, the temp file is removed and any existing file at path is left
+// untouched, so readers (and git) never observe a partially written file, and
+// a crash leaves either the old file or the complete new one.
+func writeFileAtomic(path string, perm os.FileMode, write func(w io.Writer) error) error {
+	dir := filepath.Dir(path)
+	base := filepath.Base(path)
+	tempFile, err := os.CreateTemp(dir, base+".tmp.*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tempPath := tempFile.Name()
+
+	// Remove the temp file on any failure path; cleared once the rename succeeds
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tempFile.Close()
+			_ = os.Remove(tempPath)
+		}
+	}()
+
+	buf := bufio.NewWriter(tempFile)
+	if err := write(buf); err != nil {
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := tempFile.Sync(); err != nil {
+		return fmt.Errorf("failed to sync temp file: %w", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err := os.Chmod(tempPath, perm); err != nil {
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	if err := renameFile(tempPath, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	committed = true
+	syncDir(dir)
+	return nil
+}
+
+// syncDir fsyncs a directory so a rename into it survives a crash. It's best
+// effort: some platforms (Windows) can't sync directories.
+func syncDir(dir string) {
+	// #nosec G304 - directory of a path chosen by the caller
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	_ = d.Close()
+}
+
+// appendToFile appends to path, creating it with perm if needed. If write
+// returns an error, or anything fails before the data is flushed, the file is
+// truncated back to its original length, so a failed append never leaves a
+// partial record behind. Appending to a file whose last line is unterminated
+// (e.g. cut short by an interrupted write) is refused rather than gluing the
+// new records onto it.
+func appendToFile(path string, perm os.FileMode, write func(w io.Writer) error) error {
+	// #nosec G304 - controlled path from caller
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, perm)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	size := info.Size()
+	if size > 0 {
+		last := make([]byte, 1)
+		if _, err := file.ReadAt(last, size-1); err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if last[0] != '\n' {
+			return fmt.Errorf("%s does not end with a newline (truncated write?); repair it before appending", path)
+		}
+	}
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		return fmt.Errorf("failed to seek to end of %s: %w", path, err)
+	}
+
+	buf := bufio.NewWriter(file)
+	err = write(buf)
+	if err == nil {
+		if err = buf.Flush(); err != nil {
+			err = fmt.Errorf("failed to append to %s: %w", path, err)
+		}
+	}
+	if err != nil {
+		_ = file.Truncate(size)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", path, err)
+	}
+	return nil
+}
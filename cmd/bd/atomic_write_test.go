@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.jsonl")
+
+	if err := writeFileAtomic(path, 0600, func(w io.Writer) error {
+		_, err := io.WriteString(w, "{\"id\":\"bd-1\"}\n")
+		return err
+	}); err != nil {
+		t.Fatalf("writeFileAtomic failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	if string(data) != "{\"id\":\"bd-1\"}\n" {
+		t.Errorf("unexpected content: %q", data)
+	}
+}
+
+func TestWriteFileAtomic_WriteErrorLeavesOriginalIntact(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.jsonl")
+	original := "{\"id\":\"bd-1\"}\n{\"id\":\"bd-2\"}\n"
+	if err := os.WriteFile(path, []byte(original), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	writeErr := errors.New("simulated disk failure")
+	err := writeFileAtomic(path, 0600, func(w io.Writer) error {
+		// Write part of the output before failing, as an interrupted export would
+		if _, err := io.WriteString(w, "{\"id\":\"bd-1\"}\n{\"id\""); err != nil {
+			return err
+		}
+		return writeErr
+	})
+	if !errors.Is(err, writeErr) {
+		t.Fatalf("expected simulated write error, got %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("original file missing after failed write: %v", err)
+	}
+	if string(data) != original {
+		t.Errorf("original file was modified: %q", data)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		t.Errorf("expected temp file to be cleaned up, found %v", names)
+	}
+}
+
+// shortWriter fails once n bytes have been written, like a full disk
+type shortWriter struct {
+	w io.Writer
+	n int
+}
+
+func (s *shortWriter) Write(p []byte) (int, error) {
+	if len(p) > s.n {
+		written, _ := s.w.Write(p[:s.n])
+		s.n = 0
+		return written, errors.New("no space left on device")
+	}
+	s.n -= len(p)
+	return s.w.Write(p)
+}
+
+func TestWriteJSONLExportFailureLeavesOriginalIntact(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.jsonl")
+	original := []*types.Issue{{ID: "bd-1", Title: "One", Status: types.StatusOpen, IssueType: types.TypeTask}}
+	if _, err := writeJSONLAtomic(path, original); err != nil {
+		t.Fatalf("writeJSONLAtomic failed: %v", err)
+	}
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated := []*types.Issue{
+		{ID: "bd-1", Title: "One, renamed", Status: types.StatusOpen, IssueType: types.TypeTask},
+		{ID: "bd-2", Title: "Two", Status: types.StatusOpen, IssueType: types.TypeTask},
+	}
+	err = writeFileAtomic(path, 0644, func(w io.Writer) error {
+		_, err := encodeIssuesJSONL(&shortWriter{w: w, n: 30}, updated)
+		return err
+	})
+	if err == nil {
+		t.Fatal("expected the export to fail")
+	}
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("JSONL missing after failed export: %v", err)
+	}
+	if string(after) != string(before) {
+		t.Errorf("JSONL changed by failed export:\n%s", after)
+	}
+	if n, err := countIssuesInJSONL(path); err != nil || n != 1 {
+		t.Errorf("countIssuesInJSONL = %d, %v; want the original issue", n, err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected the temp file to be removed, found %d entries", len(entries))
+	}
+}
+
+func TestAppendToFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.jsonl")
+	original := "{\"id\":\"bd-1\"}\n"
+	if err := os.WriteFile(path, []byte(original), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := appendToFile(path, 0600, func(w io.Writer) error {
+		_, err := io.WriteString(w, "{\"id\":\"bd-2\"}\n")
+		return err
+	}); err != nil {
+		t.Fatalf("appendToFile failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != original+"{\"id\":\"bd-2\"}\n" {
+		t.Errorf("unexpected content: %q", data)
+	}
+
+	// A failed append is rolled back
+	err := appendToFile(path, 0600, func(w io.Writer) error {
+		_, _ = io.WriteString(w, "{\"id\":\"bd-3\"")
+		return errors.New("simulated disk failure")
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if after, _ := os.ReadFile(path); string(after) != string(data) {
+		t.Errorf("failed append changed the file: %q", after)
+	}
+
+	// An unterminated last line is refused
+	if err := os.WriteFile(path, []byte("{\"id\":\"bd-1\""), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := appendToFile(path, 0600, func(w io.Writer) error { return nil }); err == nil {
+		t.Error("expected an error appending after an unterminated line")
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var attachCmd = &cobra.Command{
+	Use:   "attach",
+	Short: "Manage issue attachments (URLs and repository files)",
+	Long: `Attach references to an issue: URLs, or paths to files in the repository.
+Only the reference is stored, never the file itself, and it travels with the
+issue through the JSONL. 'bd show' lists an issue's attachments and
+'bd export --format md' renders them as links.
+
+Paths are resolved against the current directory and stored relative to the
+repository root (the directory holding .beads), with forward slashes. A path
+outside the repository is refused, so the shared JSONL never records
+absolute local paths. The file doesn't have to exist.`,
+}
+
+var attachAddCmd = &cobra.Command{
+	Use:   "add <id> <ref...>",
+	Short: "Attach URLs or repository files to an issue",
+	Long: `Attach one or more URLs or repository paths to an issue. References that
+are already attached are left as they are.
+
+Examples:
+  bd attach add bd-42 https://example.com/spec.pdf
+  bd attach add bd-42 docs/design/login.png ../screenshots/error.png`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		runAttach(args[0], args[1:], false)
+	},
+}
+
+var attachRmCmd = &cobra.Command{
+	Use:     "rm <id> <ref...>",
+	Aliases: []string{"remove"},
+	Short:   "Remove attachments from an issue",
+	Long: `Remove one or more attachments from an issue. Paths are resolved as for
+'bd attach add', so they can be given relative to the current directory.
+
+Examples:
+  bd attach rm bd-42 docs/design/login.png`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		runAttach(args[0], args[1:], true)
+	},
+}
+
+// runAttach adds refs to, or removes them from, an issue's attachments
+func runAttach(idArg string, refArgs []string, remove bool) {
+	ctx := context.Background()
+	root := attachmentRoot()
+	refs := make([]string, 0, len(refArgs))
+	for _, arg := range refArgs {
+		ref, err := normalizeAttachment(arg, root)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		refs = append(refs, ref)
+	}
+
+	id, err := resolveLabelIssueID(ctx, idArg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", idArg, err)
+		os.Exit(1)
+	}
+	issue, err := getIssueForArchive(ctx, id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var add, rm []string
+	if remove {
+		for _, ref := range refs {
+			if !containsAttachment(issue.Attachments, ref) {
+				fmt.Fprintf(os.Stderr, "Error: %s has no attachment %s\n", id, ref)
+				os.Exit(1)
+			}
+		}
+		rm = refs
+	} else {
+		add = refs
+	}
+	merged := types.MergeAttachments(issue.Attachments, add, rm)
+
+	if len(merged) != len(issue.Attachments) {
+		if daemonClient != nil {
+			_, err = daemonClient.Update(&rpc.UpdateArgs{ID: id, AddAttachments: add, RemoveAttachments: rm})
+		} else {
+			err = store.UpdateIssue(ctx, id, map[string]interface{}{"attachments": merged}, actor)
+			if err == nil {
+				markDirtyAndScheduleFlush()
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
+			os.Exit(1)
+		}
+	}
+
+	if jsonOutput {
+		if merged == nil {
+			merged = []string{}
+		}
+		outputJSON(map[string]interface{}{
+			"id":          id,
+			"attachments": merged,
+		})
+		return
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	for _, ref := range refs {
+		if remove {
+			fmt.Printf("%s Removed attachment %s from %s\n", green("✓"), ref, id)
+		} else if containsAttachment(issue.Attachments, ref) {
+			fmt.Printf("%s already has attachment %s\n", id, ref)
+		} else {
+			fmt.Printf("%s Attached %s to %s\n", green("✓"), ref, id)
+		}
+	}
+}
+
+// attachmentRoot returns the repository root attachment paths are relative
+// to: the directory holding the .beads directory of the database in use
+func attachmentRoot() string {
+	beadsDir := ""
+	if dbPath != "" {
+		beadsDir = filepath.Dir(dbPath)
+	} else {
+		beadsDir = findBeadsDir()
+	}
+	if beadsDir == "" {
+		return ""
+	}
+	root, err := filepath.Abs(filepath.Dir(beadsDir))
+	if err != nil {
+		return ""
+	}
+	return root
+}
+
+// normalizeAttachment turns a bd attach argument into the stored reference:
+// a URL as given, or a path made relative to root with forward slashes.
+// Paths outside root are refused.
+func normalizeAttachment(arg, root string) (string, error) {
+	arg = strings.TrimSpace(arg)
+	if arg == "" {
+		return "", fmt.Errorf("attachment cannot be empty")
+	}
+	if types.IsAttachmentURL(arg) {
+		return arg, types.ValidateAttachment(arg)
+	}
+	if root == "" {
+		return "", fmt.Errorf("cannot attach %s: no repository root found (no .beads directory)", arg)
+	}
+	abs, err := filepath.Abs(arg)
+	if err != nil {
+		return "", fmt.Errorf("cannot resolve %s: %w", arg, err)
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return "", fmt.Errorf("%s is outside the repository (%s)", arg, root)
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("%s is outside the repository (%s)", arg, root)
+	}
+	return rel, types.ValidateAttachment(rel)
+}
+
+// containsAttachment reports whether refs includes ref
+func containsAttachment(refs []string, ref string) bool {
+	for _, r := range refs {
+		if r == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// printIssueAttachments prints an issue's attachments in order
+func printIssueAttachments(attachments []string) {
+	if len(attachments) == 0 {
+		return
+	}
+	fmt.Printf("Attachments:\n")
+	for _, ref := range attachments {
+		fmt.Printf("  %s\n", ref)
+	}
+}
+
+func init() {
+	attachCmd.AddCommand(attachAddCmd)
+	attachCmd.AddCommand(attachRmCmd)
+	rootCmd.AddCommand(attachCmd)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestNormalizeAttachment(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+	if err := os.Chdir(filepath.Join(root, "docs")); err != nil {
+		t.Fatal(err)
+	}
+	// The temp dir may sit behind a symlink, as on macOS
+	if cwd, err := os.Getwd(); err == nil {
+		root = filepath.Dir(cwd)
+	}
+
+	for arg, want := range map[string]string{
+		"https://example.com/spec.pdf":       "https://example.com/spec.pdf",
+		"design.png":                         "docs/design.png",
+		"./img/../design.png":                "docs/design.png",
+		"../README.md":                       "README.md",
+		filepath.Join(root, "notes", "a.md"): "notes/a.md",
+	} {
+		got, err := normalizeAttachment(arg, root)
+		if err != nil || got != want {
+			t.Errorf("normalizeAttachment(%q) = %q, %v; want %q", arg, got, err, want)
+		}
+	}
+	for _, arg := range []string{"", "../../outside.md", "/etc/passwd", ".."} {
+		if got, err := normalizeAttachment(arg, root); err == nil {
+			t.Errorf("normalizeAttachment(%q) = %q, want an error", arg, got)
+		}
+	}
+	if _, err := normalizeAttachment("design.png", ""); err == nil {
+		t.Error("expected an error resolving a path without a repository root")
+	}
+}
+
+func TestWriteMarkdownIssuesAttachments(t *testing.T) {
+	issues := []*markdownIssue{
+		{Issue: &types.Issue{ID: "bd-1", Title: "First", Status: types.StatusOpen, IssueType: types.TypeTask,
+			Attachments: []string{"https://example.com/spec.pdf", "docs/login flow.png"}}},
+		{Issue: &types.Issue{ID: "bd-2", Title: "Second", Status: types.StatusOpen, IssueType: types.TypeTask}},
+	}
+	var buf bytes.Buffer
+	if err := writeMarkdownIssues(&buf, newMarkdownRenderer("", "bd"), issues); err != nil {
+		t.Fatalf("writeMarkdownIssues failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"## Attachments\n\n- [https://example.com/spec.pdf](https://example.com/spec.pdf)\n- [docs/login flow.png](docs/login%20flow.png)\n",
+		"\n---\n\n# bd-2: Second\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "## Attachments") != 1 {
+		t.Errorf("expected only the first issue to list attachments:\n%s", out)
+	}
+}
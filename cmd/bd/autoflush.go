@@ -8,27 +8,125 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
-// outputJSON outputs data as pretty-printed JSON
+// outputJSON outputs data as compact single-line JSON, or indented JSON
+// when --format json-pretty was given
 func outputJSON(v interface{}) {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
+	outputJSONTo(os.Stdout, v)
+}
+
+// outputJSONTo is outputJSON writing to w
+func outputJSONTo(w io.Writer, v interface{}) {
+	encoder := json.NewEncoder(w)
+	if jsonPretty {
+		encoder.SetIndent("", "  ")
+	}
 	if err := encoder.Encode(v); err != nil {
 		fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
 		os.Exit(1)
 	}
 }
 
+// jsonArrayWriter writes a JSON array an element at a time, formatted as
+// outputJSON formats the whole slice, so long lists print as they arrive
+type jsonArrayWriter struct {
+	w     *bufio.Writer
+	count int
+}
+
+func newJSONArrayWriter(w io.Writer) *jsonArrayWriter {
+	return &jsonArrayWriter{w: bufio.NewWriter(w)}
+}
+
+// write appends v to the array
+func (a *jsonArrayWriter) write(v interface{}) error {
+	sep := ","
+	if a.count == 0 {
+		sep = "["
+	}
+	var data []byte
+	var err error
+	if jsonPretty {
+		sep += "\n  "
+		data, err = json.MarshalIndent(v, "  ", "  ")
+	} else {
+		data, err = json.Marshal(v)
+	}
+	if err != nil {
+		return err
+	}
+	a.count++
+	if _, err := a.w.WriteString(sep); err != nil {
+		return err
+	}
+	_, err = a.w.Write(data)
+	return err
+}
+
+// flush writes out the elements so far
+func (a *jsonArrayWriter) flush() error {
+	return a.w.Flush()
+}
+
+// close ends the array and flushes it
+func (a *jsonArrayWriter) close() error {
+	end := "]\n"
+	if a.count == 0 {
+		end = "[]\n"
+	} else if jsonPretty {
+		end = "\n]\n"
+	}
+	if _, err := a.w.WriteString(end); err != nil {
+		return err
+	}
+	return a.w.Flush()
+}
+
+// JSON values accepted by --format on list, show and stats
+const (
+	formatJSON        = "json"         // Compact, one line (same as --json)
+	formatJSONCompact = "json-compact" // Alias for json
+	formatJSONPretty  = "json-pretty"  // Indented for humans
+)
+
+// applyJSONFormat enables JSON output if format names a JSON variant and
+// reports whether it did
+func applyJSONFormat(format string) bool {
+	switch format {
+	case formatJSON, formatJSONCompact:
+		jsonOutput = true
+		return true
+	case formatJSONPretty:
+		jsonOutput = true
+		jsonPretty = true
+		return true
+	}
+	return false
+}
+
+// applyJSONFormatFlag handles --format on commands whose only formats are the
+// JSON variants, exiting on anything else
+func applyJSONFormatFlag(format string) {
+	if format != "" && !applyJSONFormat(format) {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (valid: json, json-pretty)\n", format)
+		os.Exit(1)
+	}
+}
+
 // findJSONLPath finds the JSONL file path for the current database
 // findJSONLPath discovers the JSONL file path for the current database and ensures
 // the parent directory exists. Uses beads.FindJSONLPath() for discovery (checking
@@ -238,8 +336,39 @@ func autoImportIfNewer() {
 }
 
 
+// autoFlushConfigKey turns auto-flush off for a database when set to false.
+// --no-auto-flush (or BD_NO_AUTO_FLUSH) turns it off regardless.
+const autoFlushConfigKey = "auto_flush"
+
+// parseAutoFlush parses auto_flush, which is true when empty
+func parseAutoFlush(value string) (bool, error) {
+	if value == "" {
+		return true, nil
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: must be true or false", autoFlushConfigKey, value)
+	}
+	return enabled, nil
+}
+
+// autoFlushFromConfig reports whether the database's auto_flush config
+// allows auto-flush. Unreadable or invalid values leave it on, so changes
+// still reach the JSONL.
+func autoFlushFromConfig(ctx context.Context, s storage.Storage) bool {
+	value, err := s.GetConfig(ctx, autoFlushConfigKey)
+	if err != nil {
+		debug.Logf("failed to read %s: %v", autoFlushConfigKey, err)
+		return true
+	}
+	enabled, err := parseAutoFlush(value)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; auto-flush stays on\n", err)
+		return true
+	}
+	return enabled
+}
 
-// markDirtyAndScheduleFlush marks the database as dirty and schedules a flush
 // markDirtyAndScheduleFlush marks the database as dirty and schedules a debounced
 // export to JSONL. Uses a timer that resets on each call - flush occurs 5 seconds
 // after the LAST database modification (not the first).
@@ -252,7 +381,7 @@ func autoImportIfNewer() {
 // before the command exits, ensuring no data is lost even if the timer hasn't fired.
 //
 // Thread-safe: Protected by flushMutex. Safe to call from multiple goroutines.
-// No-op if auto-flush is disabled via --no-auto-flush flag.
+// No-op if auto-flush is disabled via --no-auto-flush or auto_flush=false.
 func markDirtyAndScheduleFlush() {
 	if !autoFlushEnabled {
 		return
@@ -383,63 +512,19 @@ func validateJSONLIntegrity(ctx context.Context, jsonlPath string) (bool, error)
 }
 
 func writeJSONLAtomic(jsonlPath string, issues []*types.Issue) ([]string, error) {
-	// Sort issues by ID for consistent output
-	sort.Slice(issues, func(i, j int) bool {
-		return issues[i].ID < issues[j].ID
-	})
-
-	// Create temp file with PID suffix to avoid collisions (bd-306)
-	tempPath := fmt.Sprintf("%s.tmp.%d", jsonlPath, os.Getpid())
-	f, err := os.Create(tempPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create temp file: %w", err)
-	}
-
-	// Ensure cleanup on failure
-	defer func() {
-		if f != nil {
-			_ = f.Close()
-			_ = os.Remove(tempPath)
-		}
-	}()
+	utils.SortIssuesForExport(issues)
 
 	// Write all issues as JSONL (timestamp-only deduplication DISABLED - bd-160)
-	encoder := json.NewEncoder(f)
-	skippedCount := 0
-	exportedIDs := make([]string, 0, len(issues))
-	
-	for _, issue := range issues {
-		if err := encoder.Encode(issue); err != nil {
-		 return nil, fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
-		}
-		
-		exportedIDs = append(exportedIDs, issue.ID)
-	}
-	
-	// Report skipped issues if any (helps debugging bd-159)
-	if skippedCount > 0 {
-		debug.Logf("auto-flush skipped %d issue(s) with timestamp-only changes", skippedCount)
-	}
-
-	// Close temp file before renaming
-	if err := f.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close temp file: %w", err)
-	}
-	f = nil // Prevent defer cleanup
-
-	// Atomic rename
-	if err := os.Rename(tempPath, jsonlPath); err != nil {
-		_ = os.Remove(tempPath) // Clean up on rename failure
-		return nil, fmt.Errorf("failed to rename file: %w", err)
+	// into a uniquely named temp file (bd-306), renamed into place once synced.
+	// 0644 (rw-r--r--): JSONL needs to be readable by other tools
+	var exportedIDs []string
+	if err := writeFileAtomic(jsonlPath, 0644, func(w io.Writer) error {
+		var err error
+		exportedIDs, err = encodeIssuesJSONL(w, issues)
+		return err
+	}); err != nil {
+		return nil, err
 	}
-
-	// Set appropriate file permissions (0644: rw-r--r--)
-	// nolint:gosec // G302: JSONL needs to be readable by other tools
-	if err := os.Chmod(jsonlPath, 0644); err != nil {
-		// Non-fatal - file is already written
-		debug.Logf("failed to set file permissions: %v", err)
-	}
-
 	return exportedIDs, nil
 }
 
@@ -563,7 +648,7 @@ func flushToJSONL() {
 
 	if fullExport {
 		// Full export: get ALL issues (needed after ID-changing operations like renumber)
-		allIssues, err2 := store.SearchIssues(ctx, "", types.IssueFilter{})
+		allIssues, err2 := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 		if err2 != nil {
 			recordFailure(fmt.Errorf("failed to get all issues: %w", err2))
 			return
@@ -642,6 +727,14 @@ func flushToJSONL() {
 		}
 		issue.Dependencies = deps
 
+		// Get comments for this issue
+		comments, err := store.GetIssueComments(ctx, issueID)
+		if err != nil {
+			recordFailure(fmt.Errorf("failed to get comments for %s: %w", issueID, err))
+			return
+		}
+		issue.Comments = comments
+
 		// Update map
 		issueMap[issueID] = issue
 	}
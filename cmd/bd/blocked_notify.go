@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// blockingNotifyEvent is the X-Beads-Event of a bd blocked --notify request
+const blockingNotifyEvent types.EventType = "blocking"
+
+// blockingNotice tells one owner which blocked issues their blockers hold up
+type blockingNotice struct {
+	Event    types.EventType   `json:"event"`
+	Owner    string            `json:"owner"`
+	Actor    string            `json:"actor"`
+	Message  string            `json:"message"`
+	Blocking []*blockedByOwner `json:"blocking"`
+}
+
+// blockedByOwner is a blocked issue and the owner's issues blocking it
+type blockedByOwner struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Priority int      `json:"priority"`
+	URL      string   `json:"url,omitempty"`
+	Via      []string `json:"via"`
+}
+
+// blockingNotifyResult is the output of bd blocked --notify
+type blockingNotifyResult struct {
+	Notifications []*blockingNotice `json:"notifications"`
+	// UnassignedBlockers block something but have nobody to notify
+	UnassignedBlockers []string `json:"unassigned_blockers"`
+	// Sent: the notifications were POSTed to webhook_url
+	Sent bool `json:"sent"`
+}
+
+// buildBlockingNotices groups the unclosed blockers of each blocked issue by
+// assignee: one notice per owner, listing what they hold up and through
+// which of their issues. Owners are sorted; blocked issues keep their order.
+func buildBlockingNotices(ctx context.Context, s storage.Storage, blocked []*types.BlockedIssue) (*blockingNotifyResult, error) {
+	result := &blockingNotifyResult{Notifications: []*blockingNotice{}, UnassignedBlockers: []string{}}
+	assignees := make(map[string]string)
+	byOwner := make(map[string]*blockingNotice)
+	unassigned := make(map[string]bool)
+	for _, issue := range blocked {
+		entries := make(map[string]*blockedByOwner)
+		for _, blocker := range issue.Blockers {
+			owner, ok := assignees[blocker.ID]
+			if !ok {
+				b, err := s.GetIssue(ctx, blocker.ID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get blocker %s: %w", blocker.ID, err)
+				}
+				if b != nil {
+					owner = b.Assignee
+				}
+				assignees[blocker.ID] = owner
+			}
+			if owner == "" {
+				if !unassigned[blocker.ID] {
+					unassigned[blocker.ID] = true
+					result.UnassignedBlockers = append(result.UnassignedBlockers, blocker.ID)
+				}
+				continue
+			}
+			notice := byOwner[owner]
+			if notice == nil {
+				notice = &blockingNotice{Event: blockingNotifyEvent, Owner: owner, Actor: actor}
+				byOwner[owner] = notice
+			}
+			entry := entries[owner]
+			if entry == nil {
+				entry = &blockedByOwner{ID: issue.ID, Title: issue.Title, Priority: issue.Priority, URL: utils.IssueURL(ctx, s, issue.ID)}
+				entries[owner] = entry
+				notice.Blocking = append(notice.Blocking, entry)
+			}
+			entry.Via = append(entry.Via, blocker.ID)
+		}
+	}
+	for _, notice := range byOwner {
+		parts := make([]string, 0, len(notice.Blocking))
+		for _, entry := range notice.Blocking {
+			parts = append(parts, fmt.Sprintf("%s via %s", entry.ID, strings.Join(entry.Via, ", ")))
+		}
+		notice.Message = "You're blocking " + strings.Join(parts, "; ")
+		result.Notifications = append(result.Notifications, notice)
+	}
+	sort.Slice(result.Notifications, func(i, j int) bool {
+		return result.Notifications[i].Owner < result.Notifications[j].Owner
+	})
+	sort.Strings(result.UnassignedBlockers)
+	return result, nil
+}
+
+// runBlockedNotify works out who to notify about blocked (all blocked issues,
+// or just ids) and POSTs each notice to webhook_url when one is set. Nothing
+// is changed in the database.
+func runBlockedNotify(ctx context.Context, blocked []*types.BlockedIssue, ids []string) error {
+	if len(ids) > 0 {
+		byID := make(map[string]*types.BlockedIssue, len(blocked))
+		for _, issue := range blocked {
+			byID[issue.ID] = issue
+		}
+		selected := make([]*types.BlockedIssue, 0, len(ids))
+		for _, input := range ids {
+			id, err := utils.ResolvePartialID(ctx, store, input)
+			if err != nil {
+				return err
+			}
+			if byID[id] == nil {
+				return fmt.Errorf("%s is not blocked", id)
+			}
+			selected = append(selected, byID[id])
+		}
+		blocked = selected
+	}
+
+	result, err := buildBlockingNotices(ctx, store, blocked)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadWebhookConfig(ctx, store)
+	if err != nil {
+		return err
+	}
+	var failed int
+	if cfg.URL != "" && len(result.Notifications) > 0 {
+		sender := &webhookNotifier{client: &http.Client{Timeout: 10 * time.Second}, retries: 3, backoff: time.Second}
+		for _, notice := range result.Notifications {
+			if err := sender.send(ctx, cfg, blockingNotifyEvent, notice); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: webhook for %s failed: %v\n", notice.Owner, err)
+				failed++
+			}
+		}
+		result.Sent = failed < len(result.Notifications)
+	}
+
+	if jsonOutput {
+		outputJSON(result)
+	} else {
+		printBlockingNotices(result, cfg.URL != "")
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d notifications could not be sent", failed, len(result.Notifications))
+	}
+	return nil
+}
+
+// printBlockingNotices lists each owner's notice and whether it was sent
+func printBlockingNotices(result *blockingNotifyResult, webhook bool) {
+	if len(result.Notifications) == 0 {
+		fmt.Printf("\nNo assigned blockers to notify\n")
+	} else {
+		cyan := color.New(color.FgCyan).SprintFunc()
+		fmt.Printf("\n%s Owners of blockers (%d):\n\n", cyan("🔔"), len(result.Notifications))
+		for _, notice := range result.Notifications {
+			fmt.Printf("  %s: %s\n", notice.Owner, notice.Message)
+		}
+	}
+	if len(result.UnassignedBlockers) > 0 {
+		fmt.Printf("\nNo assignee to notify for: %s\n", strings.Join(result.UnassignedBlockers, ", "))
+	}
+	switch {
+	case result.Sent:
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("\n%s Sent to %s\n\n", green("✓"), webhookURLConfigKey)
+	case !webhook && len(result.Notifications) > 0:
+		fmt.Printf("\n%s is not set, so nothing was sent (bd config set %s <url>)\n\n", webhookURLConfigKey, webhookURLConfigKey)
+	default:
+		fmt.Println()
+	}
+}
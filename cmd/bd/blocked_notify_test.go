@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildBlockingNotices(t *testing.T) {
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	ctx := context.Background()
+
+	assignees := map[string]string{"test-2": "alice", "test-3": "alice", "test-4": "bob", "test-6": "alice"}
+	for _, id := range []string{"test-1", "test-2", "test-3", "test-4", "test-5", "test-6"} {
+		issue := &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Assignee: assignees[id]}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	// test-1 waits on 2, 3 (alice) and 4 (bob); test-6 waits on 2 (alice) and 5 (nobody)
+	for _, edge := range [][2]string{{"test-1", "test-2"}, {"test-1", "test-3"}, {"test-1", "test-4"}, {"test-6", "test-2"}, {"test-6", "test-5"}} {
+		dep := &types.Dependency{IssueID: edge[0], DependsOnID: edge[1], Type: types.DepBlocks}
+		if err := s.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	blocked, err := s.GetBlockedIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetBlockedIssues failed: %v", err)
+	}
+	result, err := buildBlockingNotices(ctx, s, blocked)
+	if err != nil {
+		t.Fatalf("buildBlockingNotices failed: %v", err)
+	}
+	if len(result.Notifications) != 2 {
+		t.Fatalf("Expected notices for alice and bob, got %+v", result.Notifications)
+	}
+	alice, bob := result.Notifications[0], result.Notifications[1]
+	if alice.Owner != "alice" || bob.Owner != "bob" {
+		t.Fatalf("Expected owners alice, bob; got %s, %s", alice.Owner, bob.Owner)
+	}
+	if len(alice.Blocking) != 2 {
+		t.Fatalf("Expected alice to block 2 issues, got %+v", alice.Blocking)
+	}
+	via := map[string][]string{}
+	for _, entry := range alice.Blocking {
+		via[entry.ID] = entry.Via
+	}
+	if !reflect.DeepEqual(via, map[string][]string{"test-1": {"test-2", "test-3"}, "test-6": {"test-2"}}) {
+		t.Errorf("Unexpected blockers for alice: %v", via)
+	}
+	if bob.Message != "You're blocking test-1 via test-4" {
+		t.Errorf("bob's message = %q", bob.Message)
+	}
+	if bob.Event != blockingNotifyEvent {
+		t.Errorf("event = %q, want %q", bob.Event, blockingNotifyEvent)
+	}
+	if !reflect.DeepEqual(result.UnassignedBlockers, []string{"test-5"}) {
+		t.Errorf("UnassignedBlockers = %v, want [test-5]", result.UnassignedBlockers)
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// blockerNode is an issue in the tree of what blocks an issue, for bd
+// blocked --why. Blockers are its unclosed 'blocks' dependencies.
+type blockerNode struct {
+	ID       string         `json:"id"`
+	Title    string         `json:"title"`
+	Status   types.Status   `json:"status"`
+	Priority int            `json:"priority"`
+	Blockers []*blockerNode `json:"blockers,omitempty"`
+	// Leaf: nothing unclosed blocks it, so it can be worked on now
+	Leaf bool `json:"leaf,omitempty"`
+	// Cycle: already further up this chain; its blockers aren't walked again
+	Cycle bool `json:"cycle,omitempty"`
+	// Truncated: it has blockers, but --depth stopped the walk here
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// buildBlockerTree walks the unclosed blockers of id recursively, down to
+// the issues nothing blocks. maxDepth limits how many levels below id are
+// walked (0 for no limit). An issue that reappears on its own chain is
+// marked as a cycle instead of being followed.
+func buildBlockerTree(ctx context.Context, s storage.Storage, id string, maxDepth int) (*blockerNode, error) {
+	issues := make(map[string]*types.Issue)
+	getIssue := func(id string) (*types.Issue, error) {
+		if issue, ok := issues[id]; ok {
+			return issue, nil
+		}
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", id, err)
+		}
+		issues[id] = issue
+		return issue, nil
+	}
+
+	onPath := make(map[string]bool)
+	var walk func(issue *types.Issue, depth int) (*blockerNode, error)
+	walk = func(issue *types.Issue, depth int) (*blockerNode, error) {
+		node := &blockerNode{ID: issue.ID, Title: issue.Title, Status: issue.Status, Priority: issue.Priority}
+		if onPath[issue.ID] {
+			node.Cycle = true
+			return node, nil
+		}
+
+		deps, err := s.GetDependencyRecords(ctx, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dependencies of %s: %w", issue.ID, err)
+		}
+		var blockers []*types.Issue
+		for _, dep := range deps {
+			if dep.Type != types.DepBlocks {
+				continue
+			}
+			blocker, err := getIssue(dep.DependsOnID)
+			if err != nil {
+				return nil, err
+			}
+			if blocker != nil && blocker.Status != types.StatusClosed {
+				blockers = append(blockers, blocker)
+			}
+		}
+		if len(blockers) == 0 {
+			node.Leaf = true
+			return node, nil
+		}
+		if maxDepth > 0 && depth >= maxDepth {
+			node.Truncated = true
+			return node, nil
+		}
+
+		onPath[issue.ID] = true
+		defer delete(onPath, issue.ID)
+		for _, blocker := range blockers {
+			child, err := walk(blocker, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			node.Blockers = append(node.Blockers, child)
+		}
+		return node, nil
+	}
+
+	root, err := getIssue(id)
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, fmt.Errorf("issue %s not found", id)
+	}
+	return walk(root, 0)
+}
+
+// blockerChains renders every path from the tree's root to the end of a
+// chain, e.g. "bd-1 ← bd-2 ← bd-3 (open, no blockers)", with actionable
+// leaves highlighted
+func blockerChains(root *blockerNode) []string {
+	var chains []string
+	var walk func(node *blockerNode, path []string)
+	walk = func(node *blockerNode, path []string) {
+		path = append(path, node.ID)
+		if len(node.Blockers) > 0 {
+			for _, blocker := range node.Blockers {
+				walk(blocker, path)
+			}
+			return
+		}
+		chain := strings.Join(path, " ← ")
+		switch {
+		case node.Cycle:
+			chain += " " + color.YellowString("(cycle)")
+		case node.Truncated:
+			chain += " " + color.YellowString("(%s, more blockers beyond --depth)", node.Status)
+		default:
+			chain += " " + color.GreenString("(%s, no blockers)", node.Status)
+		}
+		chains = append(chains, chain)
+	}
+	walk(root, nil)
+	return chains
+}
+
+// blockerLeaves returns the actionable issues at the ends of the chains,
+// once each, in the order they're first reached
+func blockerLeaves(root *blockerNode) []*blockerNode {
+	var leaves []*blockerNode
+	seen := make(map[string]bool)
+	var walk func(node *blockerNode)
+	walk = func(node *blockerNode) {
+		if node.Leaf && !seen[node.ID] {
+			seen[node.ID] = true
+			leaves = append(leaves, node)
+		}
+		for _, blocker := range node.Blockers {
+			walk(blocker)
+		}
+	}
+	for _, blocker := range root.Blockers {
+		walk(blocker)
+	}
+	return leaves
+}
+
+// runBlockedWhy prints why id is blocked, as bd blocked --why
+func runBlockedWhy(ctx context.Context, id string, maxDepth int) error {
+	root, err := buildBlockerTree(ctx, store, id, maxDepth)
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		outputJSON(root)
+		return nil
+	}
+	if root.Leaf {
+		fmt.Printf("%s is not blocked (%s)\n", root.ID, root.Status)
+		return nil
+	}
+
+	fmt.Printf("\n%s %s: %q is blocked by:\n\n", color.RedString("🚫"), root.ID, root.Title)
+	for _, chain := range blockerChains(root) {
+		fmt.Printf("  %s\n", chain)
+	}
+	if leaves := blockerLeaves(root); len(leaves) > 0 {
+		fmt.Printf("\nActionable now:\n")
+		for _, leaf := range leaves {
+			fmt.Printf("  %s %s %s (%s)\n", leaf.ID, priorityTag(leaf.Priority), leaf.Title, leaf.Status)
+		}
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildBlockerTree(t *testing.T) {
+	defer func(noColor bool) { color.NoColor = noColor }(color.NoColor)
+	color.NoColor = true
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	ctx := context.Background()
+
+	for _, id := range []string{"test-1", "test-2", "test-3", "test-4", "test-5", "test-done"} {
+		issue := &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := s.CloseIssue(ctx, "test-done", storage.CloseOptions{Reason: "done"}, "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	// 1 ← 2 ← 3, 1 ← 4 ← done (closed, so 4 is actionable), 3 ← 5
+	for _, edge := range [][2]string{{"test-1", "test-2"}, {"test-2", "test-3"}, {"test-1", "test-4"}, {"test-4", "test-done"}, {"test-3", "test-5"}} {
+		dep := &types.Dependency{IssueID: edge[0], DependsOnID: edge[1], Type: types.DepBlocks}
+		if err := s.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	root, err := buildBlockerTree(ctx, s, "test-1", 0)
+	if err != nil {
+		t.Fatalf("buildBlockerTree failed: %v", err)
+	}
+	got := strings.Join(blockerChains(root), "\n")
+	want := "test-1 ← test-2 ← test-3 ← test-5 (open, no blockers)\ntest-1 ← test-4 (open, no blockers)"
+	if got != want {
+		t.Errorf("chains:\n%s\nwant:\n%s", got, want)
+	}
+	var leaves []string
+	for _, leaf := range blockerLeaves(root) {
+		leaves = append(leaves, leaf.ID)
+	}
+	if strings.Join(leaves, ",") != "test-5,test-4" {
+		t.Errorf("leaves = %v, want [test-5 test-4]", leaves)
+	}
+
+	root, err = buildBlockerTree(ctx, s, "test-1", 2)
+	if err != nil {
+		t.Fatalf("buildBlockerTree failed: %v", err)
+	}
+	if chains := blockerChains(root); chains[0] != "test-1 ← test-2 ← test-3 (open, more blockers beyond --depth)" {
+		t.Errorf("expected the walk cut at depth 2, got %v", chains)
+	}
+
+	// AddDependency refuses cycles, but imported data can still hold one
+	if _, err := s.UnderlyingDB().ExecContext(ctx, `INSERT INTO dependencies (issue_id, depends_on_id, type, created_by) VALUES ('test-5', 'test-2', 'blocks', 'test')`); err != nil {
+		t.Fatalf("failed to insert cycle: %v", err)
+	}
+	root, err = buildBlockerTree(ctx, s, "test-1", 0)
+	if err != nil {
+		t.Fatalf("buildBlockerTree failed: %v", err)
+	}
+	if chains := blockerChains(root); chains[0] != "test-1 ← test-2 ← test-3 ← test-5 ← test-2 (cycle)" {
+		t.Errorf("expected the cycle annotated, got %v", chains)
+	}
+}
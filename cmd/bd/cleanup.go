@@ -122,7 +122,7 @@ SAFETY:
 		}
 
 		// Use the existing batch deletion logic
-		deleteBatch(cmd, issueIDs, force, dryRun, cascade, jsonOutput)
+		deleteBatch(cmd, issueIDs, force, dryRun, cascade, false, jsonOutput)
 	},
 }
 
@@ -392,6 +392,62 @@ func TestCLI_Show(t *testing.T) {
 	}
 }
 
+func TestCLI_ShowTree(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow CLI test in short mode")
+	}
+	// Note: Not using t.Parallel() because inProcessMutex serializes execution anyway
+	tmpDir := setupCLITestDB(t)
+	createID := func(args ...string) string {
+		out := runBDInProcess(t, tmpDir, append([]string{"create"}, append(args, "--json")...)...)
+		var issue map[string]interface{}
+		if err := json.Unmarshal([]byte(out), &issue); err != nil {
+			t.Fatalf("Failed to parse JSON: %v\nOutput: %s", err, out)
+		}
+		return issue["id"].(string)
+	}
+	// Flags keep their values between in-process runs, so set them every time
+	// and put show's back for later tests
+	t.Cleanup(func() {
+		_ = showCmd.Flags().Set("tree", "false")
+		_ = showCmd.Flags().Set("depth", "50")
+		_ = showCmd.Flags().Set("json", "false")
+	})
+	epic := createID("Tree epic", "-t", "epic", "--parent", "")
+	child := createID("Tree child", "-t", "task", "--parent", epic)
+	grandchild := createID("Tree grandchild", "-t", "task", "--parent", child)
+	blocker := createID("Outside blocker", "-t", "task", "--parent", "")
+	runBDInProcess(t, tmpDir, "dep", "add", child, blocker)
+
+	out := runBDInProcess(t, tmpDir, "show", epic, "--tree", "--json=false")
+	for _, want := range []string{"Tree epic", "Hierarchy:", child + ": Tree child", grandchild + ": Tree grandchild", "↳ blocked by " + blocker} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output, got: %s", want, out)
+		}
+	}
+
+	out = runBDInProcess(t, tmpDir, "show", epic, "--tree", "--depth", "1", "--json=false")
+	if strings.Contains(out, grandchild) {
+		t.Errorf("--depth 1 should hide %s, got: %s", grandchild, out)
+	}
+
+	out = runBDInProcess(t, tmpDir, "show", epic, "--tree", "--depth", "50", "--json")
+	var trees []childTreeNode
+	if err := json.Unmarshal([]byte(out), &trees); err != nil {
+		t.Fatalf("Failed to parse JSON: %v\nOutput: %s", err, out)
+	}
+	if len(trees) != 1 || trees[0].ID != epic || len(trees[0].Children) != 1 {
+		t.Fatalf("Expected %s with one child, got: %s", epic, out)
+	}
+	kid := trees[0].Children[0]
+	if kid.ID != child || len(kid.BlockedBy) != 1 || kid.BlockedBy[0] != blocker {
+		t.Errorf("Expected %s blocked by %s, got: %+v", child, blocker, kid)
+	}
+	if len(kid.Children) != 1 || kid.Children[0].ID != grandchild {
+		t.Errorf("Expected %s under %s, got: %s", grandchild, child, out)
+	}
+}
+
 func TestCLI_Export(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping slow CLI test in short mode")
@@ -598,8 +654,17 @@ func TestCLI_Reopen(t *testing.T) {
 	// Close it
 	runBDInProcess(t, tmpDir, "close", id)
 	
-	// Reopen it
-	runBDInProcess(t, tmpDir, "reopen", id)
+	// Reopen it, recording the reason as a comment
+	out = runBDInProcess(t, tmpDir, "reopen", id, "--reason", "Regressed", "--json")
+	var results []map[string]interface{}
+	json.Unmarshal([]byte(out[strings.Index(out, "["):]), &results)
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 reopen result, got: %s", out)
+	}
+	comment, _ := results[0]["reason_comment"].(map[string]interface{})
+	if comment == nil || comment["text"] != "Regressed" {
+		t.Errorf("Expected reason_comment with the reason, got: %v", results[0]["reason_comment"])
+	}
 	
 	out = runBDInProcess(t, tmpDir, "show", id, "--json")
 	var reopened []map[string]interface{}
@@ -607,6 +672,11 @@ func TestCLI_Reopen(t *testing.T) {
 	if reopened[0]["status"] != "open" {
 		t.Errorf("Expected status 'open', got: %v", reopened[0]["status"])
 	}
+	
+	out = runBDInProcess(t, tmpDir, "comments", id, "--json")
+	if !strings.Contains(out, "Regressed") {
+		t.Errorf("Expected reopen reason in comments, got: %s", out)
+	}
 }
 
 
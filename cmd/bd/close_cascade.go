@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// closePlanItem is an issue bd close will close. CascadeFrom names the issue
+// given on the command line whose --cascade reached it.
+type closePlanItem struct {
+	*types.Issue
+	CascadeFrom string `json:"cascade_from,omitempty"`
+}
+
+// parentChildChildren maps each issue to its direct children, sorted, from
+// dependency records keyed by issue ID
+func parentChildChildren(allDeps map[string][]*types.Dependency) map[string][]string {
+	children := make(map[string][]string)
+	for childID, deps := range allDeps {
+		for _, dep := range deps {
+			if dep.Type == types.DepParentChild {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], childID)
+			}
+		}
+	}
+	for _, kids := range children {
+		sort.Strings(kids)
+	}
+	return children
+}
+
+// cascadeDescendants lists every issue below rootID in the parent-child
+// hierarchy, deepest first so children close before their parents. Each
+// issue is visited once, so a cycle imported around AddDependency's checks
+// ends the walk instead of looping.
+func cascadeDescendants(rootID string, children map[string][]string) []string {
+	seen := map[string]bool{rootID: true}
+	var order []string
+	queue := []string{rootID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, childID := range children[id] {
+			if !seen[childID] {
+				seen[childID] = true
+				order = append(order, childID)
+				queue = append(queue, childID)
+			}
+		}
+	}
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order
+}
+
+// buildClosePlan returns the issues closing ids will close: with cascade,
+// each one's open descendants first, then the issue itself. An issue
+// reachable from several targets is closed once.
+func buildClosePlan(ctx context.Context, s storage.Storage, ids []string, cascade bool) ([]closePlanItem, error) {
+	var children map[string][]string
+	if cascade {
+		allDeps, err := s.GetAllDependencyRecords(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dependencies: %w", err)
+		}
+		children = parentChildChildren(allDeps)
+	}
+
+	planned := make(map[string]bool)
+	var plan []closePlanItem
+	add := func(id, cascadeFrom string) error {
+		if planned[id] {
+			return nil
+		}
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get %s: %w", id, err)
+		}
+		if issue == nil {
+			return fmt.Errorf("issue %s not found", id)
+		}
+		// Closed descendants are left alone, but their children still count
+		if cascadeFrom != "" && issue.Status == types.StatusClosed {
+			return nil
+		}
+		planned[id] = true
+		plan = append(plan, closePlanItem{Issue: issue, CascadeFrom: cascadeFrom})
+		return nil
+	}
+
+	for _, id := range ids {
+		if cascade {
+			for _, descendant := range cascadeDescendants(id, children) {
+				if err := add(descendant, id); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err := add(id, ""); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// cascadeCloseNote is the status-change note recorded on an issue closed
+// because its ancestor was, followed by any --note given
+func cascadeCloseNote(rootID, note string) string {
+	cascadeNote := fmt.Sprintf("Cascade-closed from parent %s", rootID)
+	if note != "" {
+		cascadeNote += ": " + note
+	}
+	return cascadeNote
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCascadeDescendants(t *testing.T) {
+	children := parentChildChildren(map[string][]*types.Dependency{
+		"bd-1.1":   {{IssueID: "bd-1.1", DependsOnID: "bd-1", Type: types.DepParentChild}},
+		"bd-1.2":   {{IssueID: "bd-1.2", DependsOnID: "bd-1", Type: types.DepParentChild}, {IssueID: "bd-1.2", DependsOnID: "bd-9", Type: types.DepBlocks}},
+		"bd-1.1.1": {{IssueID: "bd-1.1.1", DependsOnID: "bd-1.1", Type: types.DepParentChild}},
+	})
+	if got := strings.Join(cascadeDescendants("bd-1", children), ","); got != "bd-1.1.1,bd-1.2,bd-1.1" {
+		t.Errorf("cascadeDescendants = %s, want deepest first", got)
+	}
+	if got := cascadeDescendants("bd-9", children); len(got) != 0 {
+		t.Errorf("blocks edges shouldn't cascade, got %v", got)
+	}
+
+	// A cycle ends the walk instead of looping
+	cyclic := map[string][]string{"bd-1": {"bd-2"}, "bd-2": {"bd-3"}, "bd-3": {"bd-1"}}
+	if got := strings.Join(cascadeDescendants("bd-1", cyclic), ","); got != "bd-3,bd-2" {
+		t.Errorf("cascadeDescendants on a cycle = %s, want bd-3,bd-2", got)
+	}
+}
+
+func TestBuildClosePlan(t *testing.T) {
+	tmpDir := t.TempDir()
+	testStore := newTestStore(t, filepath.Join(tmpDir, ".beads", "beads.db"))
+	ctx := context.Background()
+
+	newIssue := func(title string, status types.Status, parent string) *types.Issue {
+		t.Helper()
+		issue := &types.Issue{Title: title, Status: status, Priority: 2, IssueType: types.TypeTask}
+		if status == types.StatusClosed {
+			issue.Status = types.StatusOpen
+		}
+		if err := testStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		if parent != "" {
+			dep := &types.Dependency{IssueID: issue.ID, DependsOnID: parent, Type: types.DepParentChild}
+			if err := testStore.AddDependency(ctx, dep, "test"); err != nil {
+				t.Fatalf("AddDependency failed: %v", err)
+			}
+		}
+		if status == types.StatusClosed {
+			if err := testStore.CloseIssue(ctx, issue.ID, storage.CloseOptions{Reason: "done"}, "test"); err != nil {
+				t.Fatalf("CloseIssue failed: %v", err)
+			}
+		}
+		return issue
+	}
+	epic := newIssue("Epic", types.StatusOpen, "")
+	done := newIssue("Done already", types.StatusClosed, epic.ID)
+	grandchild := newIssue("Under a closed child", types.StatusInProgress, done.ID)
+	child := newIssue("Open child", types.StatusOpen, epic.ID)
+
+	plan, err := buildClosePlan(ctx, testStore, []string{epic.ID}, true)
+	if err != nil {
+		t.Fatalf("buildClosePlan failed: %v", err)
+	}
+	var got []string
+	for _, item := range plan {
+		got = append(got, item.ID+"<"+item.CascadeFrom)
+	}
+	// Closed children are skipped, but what's under them is still closed;
+	// deeper issues go first and the epic last
+	want := []string{grandchild.ID + "<" + epic.ID, child.ID + "<" + epic.ID, epic.ID + "<"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("plan = %v, want %v", got, want)
+	}
+
+	// Naming a descendant too doesn't close it twice
+	plan, err = buildClosePlan(ctx, testStore, []string{epic.ID, child.ID}, true)
+	if err != nil || len(plan) != 3 {
+		t.Errorf("expected 3 issues, got %d (err %v)", len(plan), err)
+	}
+	// Without --cascade only the named issues are planned
+	if plan, err := buildClosePlan(ctx, testStore, []string{epic.ID}, false); err != nil || len(plan) != 1 {
+		t.Errorf("expected just the epic, got %d (err %v)", len(plan), err)
+	}
+
+	if note := cascadeCloseNote(epic.ID, "sprint over"); note != "Cascade-closed from parent "+epic.ID+": sprint over" {
+		t.Errorf("unexpected note %q", note)
+	}
+}
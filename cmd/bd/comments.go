@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/user"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -30,7 +31,15 @@ Examples:
   bd comments add bd-123 "This is a comment"
 
   # Add a comment from a file
-  bd comments add bd-123 -f notes.txt`,
+  bd comments add bd-123 -f notes.txt
+
+  # Reply to comment #12, then resolve the thread
+  bd comments add bd-123 "Fixed in abc123" --reply-to 12
+  bd comments resolve 12
+
+  # Fix a typo in comment #12, or delete it
+  bd comments edit 12 "Fixed in abc1234"
+  bd comments rm 12`,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		issueID := args[0]
@@ -95,9 +104,16 @@ Examples:
 			return
 		}
 
+		threads := threadComments(comments)
+		if len(threads) == 0 {
+			fmt.Printf("No comments on %s\n", issueID)
+			return
+		}
+
 		fmt.Printf("\nComments on %s:\n\n", issueID)
-		for _, comment := range comments {
-			fmt.Printf("[%s] %s at %s\n", comment.Author, comment.Text, comment.CreatedAt.Format("2006-01-02 15:04"))
+		for _, tc := range threads {
+			indent := strings.Repeat("  ", tc.Depth)
+			fmt.Printf("%s#%d [%s] %s at %s%s\n", indent, tc.ID, tc.Author, tc.displayText(), tc.CreatedAt.Format("2006-01-02 15:04"), formatThreadStatus(tc.Status))
 			fmt.Println()
 		}
 	},
@@ -113,10 +129,14 @@ Examples:
   bd comments add bd-123 "Working on this now"
 
   # Add a comment from a file
-  bd comments add bd-123 -f notes.txt`,
+  bd comments add bd-123 -f notes.txt
+
+  # Reply to comment #12 on the same issue
+  bd comments add bd-123 "Agreed" --reply-to 12`,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		issueID := args[0]
+		replyTo, _ := cmd.Flags().GetInt64("reply-to")
 
 		// Get comment text from flag or argument
 		commentText, _ := cmd.Flags().GetString("file")
@@ -135,28 +155,26 @@ Examples:
 			commentText = args[1]
 		}
 
-		// Get author from author flag, BD_ACTOR var, or system USER var
+		// Get author from author flag, or the resolved --actor/env/USER actor
 		author, _ := cmd.Flags().GetString("author")
 		if author == "" {
-			author = os.Getenv("BD_ACTOR")
-			if author == "" {
-				author = os.Getenv("USER")
-			}
-			if author == "" {
-				if u, err := user.Current(); err == nil {
-					author = u.Username
-				} else {
-					author = "unknown"
-				}
+			author = actor
+		}
+		if author == "" {
+			if u, err := user.Current(); err == nil {
+				author = u.Username
+			} else {
+				author = "unknown"
 			}
 		}
 
 		var comment *types.Comment
 		if daemonClient != nil {
 			resp, err := daemonClient.AddComment(&rpc.CommentAddArgs{
-				ID:     issueID,
-				Author: author,
-				Text:   commentText,
+				ID:       issueID,
+				Author:   author,
+				Text:     commentText,
+				ParentID: replyTo,
 			})
 			if err != nil {
 				if isUnknownOperationError(err) {
@@ -192,11 +210,16 @@ Examples:
 			}
 			issueID = fullID
 			
-			comment, err = store.AddIssueComment(ctx, issueID, author, commentText)
+			if replyTo != 0 {
+				comment, err = store.AddCommentReply(ctx, issueID, replyTo, author, commentText)
+			} else {
+				comment, err = store.AddIssueComment(ctx, issueID, author, commentText)
+			}
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error adding comment: %v\n", err)
 				os.Exit(1)
 			}
+			markDirtyAndScheduleFlush()
 		}
 
 		if jsonOutput {
@@ -209,10 +232,346 @@ Examples:
 			return
 		}
 
-		fmt.Printf("Comment added to %s\n", issueID)
+		if replyTo != 0 {
+			fmt.Printf("Reply #%d added to comment #%d on %s\n", comment.ID, replyTo, issueID)
+			return
+		}
+		fmt.Printf("Comment #%d added to %s\n", comment.ID, issueID)
+	},
+}
+
+var commentsResolveCmd = &cobra.Command{
+	Use:   "resolve [comment-id]",
+	Short: "Mark a comment thread as resolved",
+	Long: `Mark the discussion thread containing a comment as resolved.
+
+Resolution applies to the whole thread, so resolving a reply resolves the
+comment it replies to. Comment IDs are shown as #N by 'bd comments'.
+
+Examples:
+  bd comments resolve 12
+  bd comments resolve 12 --unresolve`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		commentID, err := parseCommentID(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		unresolve, _ := cmd.Flags().GetBool("unresolve")
+
+		var root *types.Comment
+		if daemonClient != nil {
+			resp, err := daemonClient.ResolveComment(&rpc.CommentResolveArgs{CommentID: commentID, Resolved: !unresolve})
+			if err != nil {
+				if isUnknownOperationError(err) {
+					if err := fallbackToDirectMode("daemon does not support comment_resolve RPC"); err != nil {
+						fmt.Fprintf(os.Stderr, "Error resolving comment: %v\n", err)
+						os.Exit(1)
+					}
+				} else {
+					fmt.Fprintf(os.Stderr, "Error resolving comment: %v\n", err)
+					os.Exit(1)
+				}
+			} else {
+				var parsed types.Comment
+				if err := json.Unmarshal(resp.Data, &parsed); err != nil {
+					fmt.Fprintf(os.Stderr, "Error decoding comment: %v\n", err)
+					os.Exit(1)
+				}
+				root = &parsed
+			}
+		}
+
+		if root == nil {
+			if err := ensureStoreActive(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error resolving comment: %v\n", err)
+				os.Exit(1)
+			}
+			root, err = store.ResolveComment(context.Background(), commentID, !unresolve)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error resolving comment: %v\n", err)
+				os.Exit(1)
+			}
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(root)
+			return
+		}
+
+		if unresolve {
+			fmt.Printf("Thread #%d on %s marked unresolved\n", root.ID, root.IssueID)
+		} else {
+			fmt.Printf("Thread #%d on %s marked resolved\n", root.ID, root.IssueID)
+		}
+	},
+}
+
+var commentsEditCmd = &cobra.Command{
+	Use:   "edit [comment-id] [text]",
+	Short: "Edit a comment's text",
+	Long: `Replace the text of a comment. Comment IDs are shown as #N by 'bd comments'.
+
+The text as first written is kept with the comment, and the edit is
+recorded in the issue's history ('bd log'), so nothing is lost. Edited
+comments are marked "(edited)" when listed.
+
+Examples:
+  bd comments edit 12 "Fixed in abc1234"
+  bd comments edit 12 -f notes.txt`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		commentID, err := parseCommentID(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		text, _ := cmd.Flags().GetString("file")
+		if text != "" {
+			data, err := os.ReadFile(text) // #nosec G304 - user-provided file path is intentional
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+				os.Exit(1)
+			}
+			text = string(data)
+		} else if len(args) < 2 {
+			fmt.Fprintf(os.Stderr, "Error: comment text required (use -f to read from file)\n")
+			os.Exit(1)
+		} else {
+			text = args[1]
+		}
+		if strings.TrimSpace(text) == "" {
+			fmt.Fprintf(os.Stderr, "Error: comment text cannot be empty (use 'bd comments rm' to delete it)\n")
+			os.Exit(1)
+		}
+
+		comment := mutateComment("edit", func() (*rpc.Response, error) {
+			return daemonClient.EditComment(&rpc.CommentEditArgs{CommentID: commentID, Text: text})
+		}, func(ctx context.Context) (*types.Comment, error) {
+			return store.EditComment(ctx, commentID, text, actor)
+		})
+
+		if jsonOutput {
+			outputJSON(comment)
+			return
+		}
+		fmt.Printf("Comment #%d on %s edited\n", comment.ID, comment.IssueID)
+	},
+}
+
+var commentsDeleteCmd = &cobra.Command{
+	Use:     "rm [comment-id]",
+	Aliases: []string{"delete"},
+	Short:   "Delete a comment",
+	Long: `Delete a comment. Comment IDs are shown as #N by 'bd comments'.
+
+The comment is kept as a tombstone rather than removed, so syncing with a
+clone that still has it doesn't bring it back. Deleted comments are hidden
+when listed, except as a "[deleted]" placeholder for a thread with replies.
+The deletion is recorded in the issue's history ('bd log').
+
+Examples:
+  bd comments rm 12`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		commentID, err := parseCommentID(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		comment := mutateComment("delete", func() (*rpc.Response, error) {
+			return daemonClient.DeleteComment(&rpc.CommentDeleteArgs{CommentID: commentID})
+		}, func(ctx context.Context) (*types.Comment, error) {
+			return store.DeleteComment(ctx, commentID, actor)
+		})
+
+		if jsonOutput {
+			outputJSON(comment)
+			return
+		}
+		fmt.Printf("Comment #%d on %s deleted\n", comment.ID, comment.IssueID)
 	},
 }
 
+// mutateComment changes a comment through the daemon if one is running,
+// falling back to direct mode if it doesn't support the operation, and
+// returns the updated comment. Errors are fatal.
+func mutateComment(verb string, viaDaemon func() (*rpc.Response, error), direct func(ctx context.Context) (*types.Comment, error)) *types.Comment {
+	if daemonClient != nil {
+		resp, err := viaDaemon()
+		if err == nil {
+			var comment types.Comment
+			if err := json.Unmarshal(resp.Data, &comment); err != nil {
+				fmt.Fprintf(os.Stderr, "Error decoding comment: %v\n", err)
+				os.Exit(1)
+			}
+			return &comment
+		}
+		if !isUnknownOperationError(err) {
+			fmt.Fprintf(os.Stderr, "Error: failed to %s comment: %v\n", verb, err)
+			os.Exit(1)
+		}
+		if err := fallbackToDirectMode(fmt.Sprintf("daemon does not support comment_%s RPC", verb)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to %s comment: %v\n", verb, err)
+			os.Exit(1)
+		}
+	}
+
+	if err := ensureStoreActive(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to %s comment: %v\n", verb, err)
+		os.Exit(1)
+	}
+	comment, err := direct(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to %s comment: %v\n", verb, err)
+		os.Exit(1)
+	}
+	markDirtyAndScheduleFlush()
+	return comment
+}
+
+// parseCommentID parses a comment ID as shown by bd comments ("12" or "#12")
+func parseCommentID(arg string) (int64, error) {
+	commentID, err := strconv.ParseInt(strings.TrimPrefix(arg, "#"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid comment ID %q", arg)
+	}
+	return commentID, nil
+}
+
+// commentListCmd is an alias for 'bd comments [issue-id]' under 'bd comment'
+var commentListCmd = &cobra.Command{
+	Use:     "list [issue-id]",
+	Aliases: []string{"ls"},
+	Short:   "List comments on an issue (alias for 'comments')",
+	Args:  cobra.ExactArgs(1),
+	Run:   commentsCmd.Run,
+}
+
+// commentResolveCmd is an alias for 'bd comments resolve' under 'bd comment'
+var commentResolveCmd = &cobra.Command{
+	Use:   commentsResolveCmd.Use,
+	Short: commentsResolveCmd.Short,
+	Long:  commentsResolveCmd.Long,
+	Args:  cobra.ExactArgs(1),
+	Run:   commentsResolveCmd.Run,
+}
+
+// commentEditCmd and commentDeleteCmd are aliases for 'bd comments edit'
+// and 'bd comments rm' under 'bd comment'
+var commentEditCmd = &cobra.Command{
+	Use:   commentsEditCmd.Use,
+	Short: commentsEditCmd.Short,
+	Long:  commentsEditCmd.Long,
+	Args:  commentsEditCmd.Args,
+	Run:   commentsEditCmd.Run,
+}
+
+var commentDeleteCmd = &cobra.Command{
+	Use:     commentsDeleteCmd.Use,
+	Aliases: commentsDeleteCmd.Aliases,
+	Short:   commentsDeleteCmd.Short,
+	Long:    commentsDeleteCmd.Long,
+	Args:    commentsDeleteCmd.Args,
+	Run:     commentsDeleteCmd.Run,
+}
+
+// threadedComment is a comment positioned within its discussion thread
+type threadedComment struct {
+	*types.Comment
+	Depth  int
+	Status string // "resolved" or "unresolved" on thread roots with a state to show, else ""
+}
+
+// displayText is the comment's text as listed: a placeholder once deleted,
+// and marked when edited
+func (tc threadedComment) displayText() string {
+	switch {
+	case tc.IsDeleted():
+		return "[deleted]"
+	case tc.EditedAt != nil:
+		return tc.Text + " (edited)"
+	}
+	return tc.Text
+}
+
+// threadComments orders comments so replies follow their parent, keeping
+// creation order among siblings. Replies whose parent is missing are shown as
+// thread roots. Deleted comments are left out unless they still have replies,
+// which keeps those threads in one piece.
+func threadComments(comments []*types.Comment) []threadedComment {
+	comments = withoutDeletedLeaves(comments)
+	byID := make(map[int64]bool, len(comments))
+	for _, c := range comments {
+		byID[c.ID] = true
+	}
+	replies := make(map[int64][]*types.Comment)
+	var roots []*types.Comment
+	for _, c := range comments {
+		if c.ParentCommentID != nil && byID[*c.ParentCommentID] && *c.ParentCommentID != c.ID {
+			replies[*c.ParentCommentID] = append(replies[*c.ParentCommentID], c)
+		} else {
+			roots = append(roots, c)
+		}
+	}
+
+	result := make([]threadedComment, 0, len(comments))
+	var walk func(c *types.Comment, depth int)
+	walk = func(c *types.Comment, depth int) {
+		result = append(result, threadedComment{Comment: c, Depth: depth})
+		for _, reply := range replies[c.ID] {
+			walk(reply, depth+1)
+		}
+	}
+	for _, root := range roots {
+		rootIdx := len(result)
+		walk(root, 0)
+		switch {
+		case root.Resolved:
+			result[rootIdx].Status = "resolved"
+		case len(replies[root.ID]) > 0:
+			result[rootIdx].Status = "unresolved"
+		}
+	}
+	return result
+}
+
+// withoutDeletedLeaves drops deleted comments that have no remaining replies
+func withoutDeletedLeaves(comments []*types.Comment) []*types.Comment {
+	hasReplies := make(map[int64]bool)
+	kept := make([]*types.Comment, len(comments))
+	// Replies are created after their parents, so walk newest first
+	for i := len(comments) - 1; i >= 0; i-- {
+		c := comments[i]
+		if c.IsDeleted() && !hasReplies[c.ID] {
+			continue
+		}
+		kept[i] = c
+		if c.ParentCommentID != nil {
+			hasReplies[*c.ParentCommentID] = true
+		}
+	}
+	result := make([]*types.Comment, 0, len(comments))
+	for _, c := range kept {
+		if c != nil {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// formatThreadStatus renders a thread status as a suffix for display
+func formatThreadStatus(status string) string {
+	if status == "" {
+		return ""
+	}
+	return " (" + status + ")"
+}
+
 // commentCmd is a top-level alias for commentsAddCmd
 var commentCmd = &cobra.Command{
 	Use:   "comment [issue-id] [text]",
@@ -231,12 +590,25 @@ Examples:
 
 func init() {
 	commentsCmd.AddCommand(commentsAddCmd)
+	commentsCmd.AddCommand(commentsResolveCmd)
+	commentsCmd.AddCommand(commentsEditCmd)
+	commentsCmd.AddCommand(commentsDeleteCmd)
 	commentsAddCmd.Flags().StringP("file", "f", "", "Read comment text from file")
 	commentsAddCmd.Flags().StringP("author", "a", "", "Add author to comment")
+	commentsAddCmd.Flags().Int64("reply-to", 0, "Reply to the comment with this ID")
+	commentsResolveCmd.Flags().Bool("unresolve", false, "Mark the thread unresolved instead")
+	commentsEditCmd.Flags().StringP("file", "f", "", "Read the new comment text from file")
 	
 	// Add the same flags to the alias
 	commentCmd.Flags().StringP("file", "f", "", "Read comment text from file")
 	commentCmd.Flags().StringP("author", "a", "", "Add author to comment")
+	commentCmd.Flags().Int64("reply-to", 0, "Reply to the comment with this ID")
+	commentCmd.AddCommand(commentListCmd)
+	commentCmd.AddCommand(commentResolveCmd)
+	commentResolveCmd.Flags().Bool("unresolve", false, "Mark the thread unresolved instead")
+	commentCmd.AddCommand(commentEditCmd)
+	commentCmd.AddCommand(commentDeleteCmd)
+	commentEditCmd.Flags().StringP("file", "f", "", "Read the new comment text from file")
 	
 	rootCmd.AddCommand(commentsCmd)
 	rootCmd.AddCommand(commentCmd)
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -201,3 +202,49 @@ func TestIsUnknownOperationError(t *testing.T) {
 		})
 	}
 }
+
+func TestThreadComments(t *testing.T) {
+	id := func(n int64) *int64 { return &n }
+	deletedAt := time.Now()
+	comments := []*types.Comment{
+		{ID: 1, Text: "root a", Resolved: true},
+		{ID: 2, Text: "root b"},
+		{ID: 3, Text: "reply to a", ParentCommentID: id(1)},
+		{ID: 4, Text: "reply to b", ParentCommentID: id(2)},
+		{ID: 5, Text: "reply to reply", ParentCommentID: id(3)},
+		{ID: 6, Text: "plain root"},
+		{ID: 7, Text: "orphan", ParentCommentID: id(99)},
+		{ID: 8, Text: "deleted", DeletedAt: &deletedAt},
+		{ID: 9, Text: "deleted with a deleted reply", DeletedAt: &deletedAt},
+		{ID: 10, Text: "deleted reply", ParentCommentID: id(9), DeletedAt: &deletedAt},
+		{ID: 11, Text: "deleted with a reply", DeletedAt: &deletedAt},
+		{ID: 12, Text: "reply to deleted", ParentCommentID: id(11)},
+	}
+
+	got := threadComments(comments)
+
+	want := []struct {
+		id     int64
+		depth  int
+		status string
+	}{
+		{1, 0, "resolved"},
+		{3, 1, ""},
+		{5, 2, ""},
+		{2, 0, "unresolved"},
+		{4, 1, ""},
+		{6, 0, ""},
+		{7, 0, ""},
+		{11, 0, "unresolved"},
+		{12, 1, ""},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d comments, got %d", len(want), len(got))
+	}
+	for i, w := range want {
+		if got[i].ID != w.id || got[i].Depth != w.depth || got[i].Status != w.status {
+			t.Errorf("Position %d: got #%d depth %d status %q, want #%d depth %d status %q",
+				i, got[i].ID, got[i].Depth, got[i].Status, w.id, w.depth, w.status)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const (
+	// commitCloseModeConfigKey sets what bd sync does with issues named by
+	// "fixes <id>" directives in new commit messages
+	commitCloseModeConfigKey = "commit_close.mode"
+
+	// commitCloseKeywordsConfigKey lists the directive keywords, comma-separated
+	commitCloseKeywordsConfigKey = "commit_close.keywords"
+
+	// lastCommitScanMetadataKey holds the HEAD the last commit scan reached
+	lastCommitScanMetadataKey = "last_commit_scan"
+
+	defaultCommitCloseKeywords = "fixes,closes"
+)
+
+// Commit scan modes
+const (
+	commitCloseModeClose   = "close"   // Close the issue, recording the commit
+	commitCloseModeComment = "comment" // Comment on the issue, leaving it open
+	commitCloseModeOff     = "off"     // Don't scan commits
+)
+
+// parseCommitCloseMode parses a commit_close.mode value; empty means close
+func parseCommitCloseMode(value string) (string, error) {
+	switch mode := strings.ToLower(strings.TrimSpace(value)); mode {
+	case "":
+		return commitCloseModeClose, nil
+	case commitCloseModeClose, commitCloseModeComment, commitCloseModeOff:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid %s %q: must be close, comment or off", commitCloseModeConfigKey, value)
+}
+
+// commitKeywordPattern limits directive keywords to single words
+var commitKeywordPattern = regexp.MustCompile(`^[a-z]+$`)
+
+// parseCommitCloseKeywords parses a commit_close.keywords value; empty
+// means fixes and closes
+func parseCommitCloseKeywords(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		value = defaultCommitCloseKeywords
+	}
+	var keywords []string
+	for _, kw := range strings.Split(value, ",") {
+		kw = strings.ToLower(strings.TrimSpace(kw))
+		if !commitKeywordPattern.MatchString(kw) {
+			return nil, fmt.Errorf("invalid %s %q: keywords must be comma-separated words", commitCloseKeywordsConfigKey, value)
+		}
+		keywords = append(keywords, kw)
+	}
+	return keywords, nil
+}
+
+// commitIssueIDPattern matches an issue ID in a commit message
+const commitIssueIDPattern = `[a-z][a-z0-9]*-[0-9a-z]+(?:\.[0-9]+)*`
+
+// newCommitDirectivePattern matches a keyword followed by one or more issue
+// IDs, as in "fixes bd-a1" or "Closes: bd-a1, bd-b2 and bd-c3"
+func newCommitDirectivePattern(keywords []string) *regexp.Regexp {
+	quoted := make([]string, len(keywords))
+	for i, kw := range keywords {
+		quoted[i] = regexp.QuoteMeta(kw)
+	}
+	id := commitIssueIDPattern
+	return regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `):?\s+(` + id + `(?:(?:\s*,\s*|\s+and\s+)` + id + `)*)\b`)
+}
+
+// commitDirective is an issue a commit message says it resolves
+type commitDirective struct {
+	Keyword string
+	IssueID string
+}
+
+// parseCommitDirectives returns the directives in a commit message, in
+// order, each issue once
+func parseCommitDirectives(pattern *regexp.Regexp, message string) []commitDirective {
+	idPattern := regexp.MustCompile(`(?i)` + commitIssueIDPattern)
+	seen := make(map[string]bool)
+	var directives []commitDirective
+	for _, m := range pattern.FindAllStringSubmatch(message, -1) {
+		for _, id := range idPattern.FindAllString(m[2], -1) {
+			id = strings.ToLower(id)
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			directives = append(directives, commitDirective{Keyword: strings.ToLower(m[1]), IssueID: id})
+		}
+	}
+	return directives
+}
+
+// gitCommitInfo is a commit's SHA and message
+type gitCommitInfo struct {
+	SHA     string
+	Message string
+}
+
+// subject returns the first line of the commit message
+func (c gitCommitInfo) subject() string {
+	subject, _, _ := strings.Cut(strings.TrimSpace(c.Message), "\n")
+	return subject
+}
+
+// gitResolveCommit returns the full SHA of the commit rev names
+func gitResolveCommit(ctx context.Context, rev string) (string, error) {
+	out, err := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", rev+"^{commit}").Output()
+	if err != nil {
+		return "", fmt.Errorf("%s is not a commit in this repository", rev)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// gitCommitsSince returns the commits reachable from HEAD but not from
+// since, oldest first
+func gitCommitsSince(ctx context.Context, since string) ([]gitCommitInfo, error) {
+	out, err := exec.CommandContext(ctx, "git", "log", "--reverse", "--format=%H%x1f%B%x1e", since+"..HEAD").Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log %s..HEAD failed: %w", since, err)
+	}
+	var commits []gitCommitInfo
+	for _, record := range strings.Split(string(out), "\x1e") {
+		sha, message, ok := strings.Cut(strings.TrimLeft(record, "\n"), "\x1f")
+		if !ok {
+			continue
+		}
+		commits = append(commits, gitCommitInfo{SHA: sha, Message: message})
+	}
+	return commits, nil
+}
+
+// commitCloseAction is what a commit scan did, or would do, with one issue
+type commitCloseAction struct {
+	IssueID string `json:"issue_id"`
+	Commit  string `json:"commit"`
+	Subject string `json:"subject"`
+	Keyword string `json:"keyword"`
+	Action  string `json:"action"`           // closed, commented or skipped
+	Reason  string `json:"reason,omitempty"` // Why it was skipped
+}
+
+// applyCommitDirectives closes (or comments on) the issues the commits'
+// directives name. Missing and already-closed issues are skipped, and each
+// issue is handled once, for the first commit naming it.
+func applyCommitDirectives(ctx context.Context, s storage.Storage, commits []gitCommitInfo, pattern *regexp.Regexp, mode string, dryRun bool) ([]*commitCloseAction, error) {
+	var actions []*commitCloseAction
+	handled := make(map[string]bool)
+	for _, commit := range commits {
+		for _, d := range parseCommitDirectives(pattern, commit.Message) {
+			if handled[d.IssueID] {
+				continue
+			}
+			handled[d.IssueID] = true
+			action := &commitCloseAction{IssueID: d.IssueID, Commit: commit.SHA, Subject: commit.subject(), Keyword: d.Keyword}
+			actions = append(actions, action)
+
+			issue, err := s.GetIssue(ctx, d.IssueID)
+			if err != nil {
+				return actions, fmt.Errorf("failed to get issue %s: %w", d.IssueID, err)
+			}
+			switch {
+			case issue == nil:
+				action.Action, action.Reason = "skipped", "not found"
+				continue
+			case issue.Status == types.StatusClosed:
+				action.Action, action.Reason = "skipped", "already closed"
+				continue
+			}
+
+			short := commit.SHA[:min(len(commit.SHA), 7)]
+			note := fmt.Sprintf("%s in commit %s: %s", d.Keyword, commit.SHA, action.Subject)
+			if mode == commitCloseModeComment {
+				action.Action = "commented"
+				if !dryRun {
+					if _, err := s.AddIssueComment(ctx, d.IssueID, actor, "Referenced by commit "+commit.SHA+": "+action.Subject); err != nil {
+						return actions, fmt.Errorf("failed to comment on %s: %w", d.IssueID, err)
+					}
+				}
+				continue
+			}
+			action.Action = "closed"
+			if !dryRun {
+				opts := storage.CloseOptions{
+					Reason:     "Closed by commit " + short,
+					Note:       note,
+					Resolution: types.ResolutionFixed,
+					Commit:     commit.SHA,
+				}
+				if err := s.CloseIssue(ctx, d.IssueID, opts, actor); err != nil {
+					return actions, fmt.Errorf("failed to close %s: %w", d.IssueID, err)
+				}
+			}
+		}
+	}
+	return actions, nil
+}
+
+// scanCommitsForClosures applies the directives in the commits made since
+// the last scan, then records HEAD as scanned. The first scan only records
+// HEAD, so enabling it never acts on old history. Returns nil when
+// commit_close.mode is off or there is nothing new.
+func scanCommitsForClosures(ctx context.Context, s storage.Storage, dryRun bool) ([]*commitCloseAction, error) {
+	modeValue, err := s.GetConfig(ctx, commitCloseModeConfigKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", commitCloseModeConfigKey, err)
+	}
+	mode, err := parseCommitCloseMode(modeValue)
+	if err != nil {
+		return nil, err
+	}
+	if mode == commitCloseModeOff {
+		return nil, nil
+	}
+	keywordsValue, err := s.GetConfig(ctx, commitCloseKeywordsConfigKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", commitCloseKeywordsConfigKey, err)
+	}
+	keywords, err := parseCommitCloseKeywords(keywordsValue)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := gitResolveCommit(ctx, "HEAD")
+	if err != nil {
+		return nil, nil // No commits yet
+	}
+	last, err := s.GetMetadata(ctx, lastCommitScanMetadataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", lastCommitScanMetadataKey, err)
+	}
+	if last == head {
+		return nil, nil
+	}
+
+	var actions []*commitCloseAction
+	if last != "" {
+		commits, err := gitCommitsSince(ctx, last)
+		if err != nil {
+			// The last scanned commit is gone, e.g. rewritten history
+			fmt.Fprintf(os.Stderr, "Warning: skipping commit scan, restarting from HEAD: %v\n", err)
+		} else if actions, err = applyCommitDirectives(ctx, s, commits, newCommitDirectivePattern(keywords), mode, dryRun); err != nil {
+			return actions, err
+		}
+	}
+	if dryRun {
+		return actions, nil
+	}
+	if err := s.SetMetadata(ctx, lastCommitScanMetadataKey, head); err != nil {
+		return actions, fmt.Errorf("failed to save %s: %w", lastCommitScanMetadataKey, err)
+	}
+	return actions, nil
+}
+
+// printCommitCloseActions reports a commit scan's results, as bd sync's steps
+func printCommitCloseActions(actions []*commitCloseAction, dryRun bool) {
+	for _, a := range actions {
+		short := a.Commit[:min(len(a.Commit), 7)]
+		switch {
+		case a.Action == "skipped":
+			fmt.Printf("→ Skipping %s from commit %s: %s\n", a.IssueID, short, a.Reason)
+		case a.Action == "commented" && dryRun:
+			fmt.Printf("→ [DRY RUN] Would comment on %s for commit %s: %s\n", a.IssueID, short, a.Subject)
+		case a.Action == "commented":
+			fmt.Printf("→ Commented on %s for commit %s: %s\n", a.IssueID, short, a.Subject)
+		case dryRun:
+			fmt.Printf("→ [DRY RUN] Would close %s by commit %s: %s\n", a.IssueID, short, a.Subject)
+		default:
+			fmt.Printf("→ Closed %s by commit %s: %s\n", a.IssueID, short, a.Subject)
+		}
+	}
+}
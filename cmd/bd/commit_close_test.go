@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseCommitDirectives(t *testing.T) {
+	pattern := newCommitDirectivePattern([]string{"fixes", "closes"})
+	for message, want := range map[string][]commitDirective{
+		"Handle empty files (fixes bd-a1)":             {{"fixes", "bd-a1"}},
+		"Refactor\n\nCloses: BD-A1, bd-b2 and bd-c3.1": {{"closes", "bd-a1"}, {"closes", "bd-b2"}, {"closes", "bd-c3.1"}},
+		"fixes bd-a1\ncloses bd-a1":                    {{"fixes", "bd-a1"}},
+		"Prefixes bd-a1 are fine":                      nil,
+		"fixes the bd-a1 bug":                          nil,
+		"resolves bd-a1":                               nil,
+	} {
+		if got := parseCommitDirectives(pattern, message); !reflect.DeepEqual(got, want) {
+			t.Errorf("parseCommitDirectives(%q) = %v, want %v", message, got, want)
+		}
+	}
+}
+
+func TestParseCommitCloseConfig(t *testing.T) {
+	if got, err := parseCommitCloseKeywords(""); err != nil || !reflect.DeepEqual(got, []string{"fixes", "closes"}) {
+		t.Errorf("default keywords = %v, %v", got, err)
+	}
+	if got, err := parseCommitCloseKeywords(" Resolves, fixes "); err != nil || !reflect.DeepEqual(got, []string{"resolves", "fixes"}) {
+		t.Errorf("keywords = %v, %v", got, err)
+	}
+	for _, value := range []string{"fixes,", "fix(es)", "fixes closes"} {
+		if _, err := parseCommitCloseKeywords(value); err == nil {
+			t.Errorf("parseCommitCloseKeywords(%q) should fail", value)
+		}
+	}
+	if mode, err := parseCommitCloseMode(""); err != nil || mode != commitCloseModeClose {
+		t.Errorf("default mode = %q, %v", mode, err)
+	}
+	if _, err := parseCommitCloseMode("reopen"); err == nil {
+		t.Error("parseCommitCloseMode(reopen) should fail")
+	}
+}
+
+func TestApplyCommitDirectives(t *testing.T) {
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	ctx := context.Background()
+	open := &types.Issue{ID: "test-1", Title: "Open", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	if err := s.CreateIssue(ctx, open, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CreateIssue(ctx, &types.Issue{ID: "test-2", Title: "Done", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CloseIssue(ctx, "test-2", storage.CloseOptions{Reason: "Done"}, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	first := "1111111111111111111111111111111111111111"
+	commits := []gitCommitInfo{
+		{SHA: first, Message: "Fix parsing\n\nfixes test-1, test-2 and test-9\n"},
+		{SHA: "2222222222222222222222222222222222222222", Message: "Follow-up, closes test-1\n"},
+	}
+	pattern := newCommitDirectivePattern([]string{"fixes", "closes"})
+
+	actions, err := applyCommitDirectives(ctx, s, commits, pattern, commitCloseModeClose, true)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	var got []string
+	for _, a := range actions {
+		got = append(got, a.IssueID+":"+a.Action)
+	}
+	if want := []string{"test-1:closed", "test-2:skipped", "test-9:skipped"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("actions = %v, want %v", got, want)
+	}
+	if issue, _ := s.GetIssue(ctx, "test-1"); issue.Status != types.StatusOpen {
+		t.Errorf("dry run closed test-1")
+	}
+
+	if _, err := applyCommitDirectives(ctx, s, commits, pattern, commitCloseModeClose, false); err != nil {
+		t.Fatalf("applyCommitDirectives failed: %v", err)
+	}
+	issue, err := s.GetIssue(ctx, "test-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.Status != types.StatusClosed || issue.ResolvedBy != first || issue.Resolution != types.ResolutionFixed {
+		t.Errorf("expected test-1 fixed by %s, got status=%s resolution=%q resolved_by=%q", first, issue.Status, issue.Resolution, issue.ResolvedBy)
+	}
+}
@@ -31,7 +31,7 @@ var (
 )
 
 var compactCmd = &cobra.Command{
-	Use:   "compact",
+	Use:   "compact [file.jsonl]",
 	Short: "Compact old closed issues to save space",
 	Long: `Compact old closed issues using semantic summarization.
 
@@ -43,6 +43,12 @@ Modes:
   - Apply: Accept agent-provided summary (no API key needed)
   - Auto: AI-powered compaction (requires ANTHROPIC_API_KEY, legacy)
 
+With a JSONL file instead of a mode, compacts the file: only the newest
+record for each issue ID is kept (latest updated_at, then the later line),
+as when a file has accumulated appended deltas from 'bd export --append'.
+The file is rewritten sorted by ID through a temp file and rename. Malformed
+lines are reported and dropped; --dry-run reports what would be removed.
+
 Tiers:
   - Tier 1: Semantic compression (30 days closed, 70% reduction)
   - Tier 2: Ultra compression (90 days closed, 95% reduction)
@@ -60,10 +66,24 @@ Examples:
   
   # Statistics
   bd compact --stats                       # Show statistics
+
+  # Deduplicate a JSONL file by issue ID
+  bd compact .beads/issues.jsonl --dry-run
+  bd compact .beads/issues.jsonl
 `,
-	Run: func(_ *cobra.Command, _ []string) {
+	Args: cobra.MaximumNArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
 		ctx := context.Background()
 
+		if len(args) == 1 {
+			if compactStats || compactAnalyze || compactApply || compactAuto {
+				fmt.Fprintf(os.Stderr, "Error: a JSONL file cannot be combined with --stats, --analyze, --apply or --auto\n")
+				os.Exit(1)
+			}
+			runCompactJSONL(args[0])
+			return
+		}
+
 		// Handle compact stats first
 		if compactStats {
 			if daemonClient != nil {
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// jsonlCompaction reports what compactJSONL removed from a JSONL file
+type jsonlCompaction struct {
+	Path           string `json:"path"`
+	Records        int    `json:"records"`            // Valid records read
+	Kept           int    `json:"kept"`               // One per issue ID
+	Duplicates     int    `json:"duplicates_removed"` // Older records of a repeated ID
+	MalformedLines []int  `json:"malformed_lines,omitempty"`
+	DryRun         bool   `json:"dry_run"`
+}
+
+// jsonlRecord is one line of a JSONL file, kept verbatim so compaction never
+// drops fields this version of bd doesn't know about
+type jsonlRecord struct {
+	id        string
+	updatedAt time.Time
+	line      []byte
+}
+
+// compactJSONL rewrites path with only the newest record for each issue ID,
+// sorted in export order. The newest record has the latest updated_at; on a
+// tie the later line wins, as when replaying appended deltas. Lines that
+// don't parse, or have no ID, are reported and left out. The file is
+// replaced atomically; with dryRun it is only read.
+func compactJSONL(path string, dryRun bool) (*jsonlCompaction, error) {
+	// #nosec G304 - user-provided path to their own JSONL
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &jsonlCompaction{Path: path, DryRun: dryRun}
+	newest := make(map[string]*jsonlRecord)
+	reader := bufio.NewReader(file)
+	for lineNum := 1; ; lineNum++ {
+		line, readErr := reader.ReadBytes('\n')
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			var header struct {
+				ID        string    `json:"id"`
+				UpdatedAt time.Time `json:"updated_at"`
+			}
+			if err := json.Unmarshal(trimmed, &header); err != nil || header.ID == "" {
+				result.MalformedLines = append(result.MalformedLines, lineNum)
+			} else {
+				result.Records++
+				if current, ok := newest[header.ID]; !ok || !current.updatedAt.After(header.UpdatedAt) {
+					newest[header.ID] = &jsonlRecord{id: header.ID, updatedAt: header.UpdatedAt, line: trimmed}
+				}
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read line %d: %w", lineNum, readErr)
+		}
+	}
+	result.Kept = len(newest)
+	result.Duplicates = result.Records - result.Kept
+
+	if result.Records == 0 && len(result.MalformedLines) > 0 {
+		return nil, fmt.Errorf("no valid records in %s; refusing to rewrite it", path)
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	records := make([]*jsonlRecord, 0, len(newest))
+	for _, record := range newest {
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return utils.CompareIssueIDs(records[i].id, records[j].id) < 0
+	})
+	err = writeFileAtomic(path, info.Mode().Perm(), func(w io.Writer) error {
+		for _, record := range records {
+			if _, err := w.Write(record.line); err != nil {
+				return err
+			}
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// runCompactJSONL handles bd compact <file.jsonl>
+func runCompactJSONL(path string) {
+	result, err := compactJSONL(path, compactDryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		outputJSON(result)
+		return
+	}
+	if len(result.MalformedLines) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: skipped %d malformed line(s) in %s (lines %s)\n",
+			len(result.MalformedLines), path, formatLineNumbers(result.MalformedLines))
+	}
+	if result.DryRun {
+		fmt.Printf("Would remove %d duplicate record(s) from %s, keeping %d issue(s)\n",
+			result.Duplicates, path, result.Kept)
+		return
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Compacted %s: removed %d duplicate record(s), kept %d issue(s)\n",
+		green("✓"), path, result.Duplicates, result.Kept)
+	if len(result.MalformedLines) > 0 {
+		fmt.Printf("  Dropped %d malformed line(s)\n", len(result.MalformedLines))
+	}
+}
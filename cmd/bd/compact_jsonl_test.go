@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCompactJSONL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	lines := `{"id":"bd-2","title":"old","updated_at":"2025-01-01T00:00:00Z"}
+{"id":"bd-10","title":"ten","updated_at":"2025-01-01T00:00:00Z"}
+{"id":"bd-2","title":"new","updated_at":"2025-01-02T00:00:00Z","future_field":1}
+{"id":"bd-1","title":"first","updated_at":"2025-01-03T00:00:00Z"}
+{"id":"bd-1","title":"stale","updated_at":"2025-01-02T00:00:00Z"}
+{"id":"bd-10","title":"same time, later line","updated_at":"2025-01-01T00:00:00Z"}
+{"id":"bd-3","title":"cut short
+`
+	if err := os.WriteFile(path, []byte(lines), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := compactJSONL(path, true)
+	if err != nil {
+		t.Fatalf("compactJSONL dry run failed: %v", err)
+	}
+	if result.Records != 6 || result.Kept != 3 || result.Duplicates != 3 || !reflect.DeepEqual(result.MalformedLines, []int{7}) {
+		t.Errorf("dry run = %+v, want 6 records, 3 kept, 3 duplicates, line 7 malformed", result)
+	}
+	if data, _ := os.ReadFile(path); string(data) != lines {
+		t.Fatal("dry run changed the file")
+	}
+
+	if _, err := compactJSONL(path, false); err != nil {
+		t.Fatalf("compactJSONL failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	// Export order: top-level IDs compare as plain strings
+	want := `{"id":"bd-1","title":"first","updated_at":"2025-01-03T00:00:00Z"}
+{"id":"bd-10","title":"same time, later line","updated_at":"2025-01-01T00:00:00Z"}
+{"id":"bd-2","title":"new","updated_at":"2025-01-02T00:00:00Z","future_field":1}
+`
+	if string(data) != want {
+		t.Errorf("compacted file:\n%s\nwant:\n%s", data, want)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600 kept", info.Mode().Perm())
+	}
+
+	// Nothing valid: refuse rather than truncate
+	if err := os.WriteFile(path, []byte("not json\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := compactJSONL(path, false); err == nil {
+		t.Error("expected an error for a file with no valid records")
+	}
+}
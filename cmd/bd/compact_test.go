@@ -334,8 +334,8 @@ func TestCompactInitCommand(t *testing.T) {
 		t.Fatal("compactCmd should be initialized")
 	}
 	
-	if compactCmd.Use != "compact" {
-		t.Errorf("Expected Use='compact', got %q", compactCmd.Use)
+	if compactCmd.Use != "compact [file.jsonl]" {
+		t.Errorf("Expected Use='compact [file.jsonl]', got %q", compactCmd.Use)
 	}
 	
 	if len(compactCmd.Long) == 0 {
@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
@@ -18,6 +20,10 @@ var configCmd = &cobra.Command{
 
 Configuration is stored per-project in .beads/*.db and is version-control-friendly.
 
+Keys bd reads itself, such as issue_prefix, min_hash_length or
+watch_debounce_ms, are validated when set; 'bd config list --all' shows them
+all with their defaults.
+
 Common namespaces:
   - jira.*     Jira integration settings
   - linear.*   Linear integration settings
@@ -27,8 +33,10 @@ Common namespaces:
 Examples:
   bd config set jira.url "https://company.atlassian.net"
   bd config set jira.project "PROJ"
+  bd config set max_collision_prob 0.1
   bd config get jira.url
   bd config list
+  bd config ls --all
   bd config unset jira.url`,
 }
 
@@ -55,6 +63,10 @@ var configSetCmd = &cobra.Command{
 				os.Exit(1)
 			}
 		} else {
+			if err := validateConfigValue(key, value); err != nil {
+				fmt.Fprintf(os.Stderr, "Error setting config: %v\n", err)
+				os.Exit(1)
+			}
 			if err := store.SetConfig(ctx, key, value); err != nil {
 				fmt.Fprintf(os.Stderr, "Error setting config: %v\n", err)
 				os.Exit(1)
@@ -107,7 +119,9 @@ var configGetCmd = &cobra.Command{
 				"value": value,
 			})
 		} else {
-			if value == "" {
+			if info, ok := lookupConfigKey(key); value == "" && ok && info.Default != "" {
+				fmt.Printf("%s (not set, default: %s)\n", key, info.Default)
+			} else if value == "" {
 				fmt.Printf("%s (not set)\n", key)
 			} else {
 				fmt.Printf("%s\n", value)
@@ -117,8 +131,14 @@ var configGetCmd = &cobra.Command{
 }
 
 var configListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List all configuration",
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List all configuration",
+	Long: `List all configuration values that are set. Secrets such as webhook_secret
+are masked; use 'bd config get' to read one.
+
+With --all, every key bd reads is listed too, with its default if unset and
+a short description.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Config operations work in direct mode only
 		if err := ensureDirectMode("config list requires direct database access"); err != nil {
@@ -132,6 +152,12 @@ var configListCmd = &cobra.Command{
 			fmt.Fprintf(os.Stderr, "Error listing config: %v\n", err)
 			os.Exit(1)
 		}
+		config = maskSecretConfig(config)
+
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			printAllConfig(config)
+			return
+		}
 
 		if jsonOutput {
 			outputJSON(config)
@@ -186,10 +212,222 @@ var configUnsetCmd = &cobra.Command{
 	},
 }
 
+var configExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export configuration as JSON",
+	Long: `Export configuration as a JSON object of key/value pairs.
+
+Use --prefix to export a single namespace, e.g. saved searches, so it can be
+committed and shared with 'bd config import'. Secrets such as webhook_secret
+are never exported; set them on each machine with 'bd config set'.
+
+Examples:
+  bd config export --prefix search. -o .beads/searches.json
+  bd config export > config-backup.json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Config operations work in direct mode only
+		if err := ensureDirectMode("config export requires direct database access"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		prefix, _ := cmd.Flags().GetString("prefix")
+		output, _ := cmd.Flags().GetString("output")
+
+		ctx := context.Background()
+		config, err := store.GetAllConfig(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing config: %v\n", err)
+			os.Exit(1)
+		}
+		exported := exportableConfig(config, prefix)
+
+		// Map keys encode in sorted order, so exports diff cleanly
+		data, err := json.MarshalIndent(exported, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding config: %v\n", err)
+			os.Exit(1)
+		}
+		data = append(data, '\n')
+
+		if output == "" {
+			_, _ = os.Stdout.Write(data)
+			return
+		}
+		if err := os.WriteFile(output, data, 0600); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", output, err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d config key(s) to %s\n", len(exported), output)
+	},
+}
+
+var configImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import configuration from JSON",
+	Long: `Import configuration from a JSON object of key/value pairs, as written by
+'bd config export'. Reads from stdin unless -i is given.
+
+Imported keys overwrite existing values; keys not in the input are left alone.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Config operations work in direct mode only
+		if err := ensureDirectMode("config import requires direct database access"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		input, _ := cmd.Flags().GetString("input")
+
+		var data []byte
+		var err error
+		if input == "" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(input) // #nosec G304 - user-provided import path
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading config: %v\n", err)
+			os.Exit(1)
+		}
+
+		var imported map[string]string
+		if err := json.Unmarshal(data, &imported); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: config must be a JSON object of string values: %v\n", err)
+			os.Exit(1)
+		}
+
+		keys := make([]string, 0, len(imported))
+		for k := range imported {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		// Check every value before writing any
+		for _, key := range keys {
+			if err := validateConfigValue(key, imported[key]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		ctx := context.Background()
+		for _, key := range keys {
+			value := imported[key]
+			if strings.TrimSpace(key) == syncbranch.ConfigKey {
+				err = syncbranch.Set(ctx, store, value)
+			} else {
+				err = store.SetConfig(ctx, key, value)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error setting %s: %v\n", key, err)
+				os.Exit(1)
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"imported": len(keys),
+				"keys":     keys,
+			})
+		} else {
+			fmt.Printf("Imported %d config key(s)\n", len(keys))
+		}
+	},
+}
+
+// maskedConfigValue stands in for a secret's value in bd config list
+const maskedConfigValue = "********"
+
+// maskSecretConfig returns config with the values of secret keys masked
+func maskSecretConfig(config map[string]string) map[string]string {
+	masked := make(map[string]string, len(config))
+	for k, v := range config {
+		if isSecretConfigKey(k) && v != "" {
+			v = maskedConfigValue
+		}
+		masked[k] = v
+	}
+	return masked
+}
+
+// exportableConfig returns the keys of config starting with prefix, leaving
+// out secrets
+func exportableConfig(config map[string]string, prefix string) map[string]string {
+	exported := make(map[string]string, len(config))
+	for k, v := range config {
+		if strings.HasPrefix(k, prefix) && !isSecretConfigKey(k) {
+			exported[k] = v
+		}
+	}
+	return exported
+}
+
+// configListEntry is one key in bd config list --all
+type configListEntry struct {
+	Key         string `json:"key"`
+	Value       string `json:"value,omitempty"`
+	Set         bool   `json:"set"`
+	Default     string `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// allConfigEntries merges the set config with knownConfigKeys, sorted by key
+func allConfigEntries(config map[string]string) []configListEntry {
+	entries := make(map[string]configListEntry, len(config)+len(knownConfigKeys))
+	for _, info := range knownConfigKeys {
+		entries[info.Key] = configListEntry{Key: info.Key, Default: info.Default, Description: info.Description}
+	}
+	for key, value := range config {
+		entry := entries[key]
+		entry.Key, entry.Value, entry.Set = key, value, true
+		entries[key] = entry
+	}
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	list := make([]configListEntry, len(keys))
+	for i, key := range keys {
+		list[i] = entries[key]
+	}
+	return list
+}
+
+// printAllConfig prints bd config list --all
+func printAllConfig(config map[string]string) {
+	entries := allConfigEntries(config)
+	if jsonOutput {
+		outputJSON(entries)
+		return
+	}
+	fmt.Println("\nConfiguration:")
+	for _, entry := range entries {
+		switch {
+		case entry.Set:
+			fmt.Printf("  %s = %s\n", entry.Key, entry.Value)
+		case entry.Default != "":
+			fmt.Printf("  %s (default: %s)\n", entry.Key, entry.Default)
+		default:
+			fmt.Printf("  %s (not set)\n", entry.Key)
+		}
+		if entry.Description != "" {
+			fmt.Printf("      %s\n", entry.Description)
+		}
+	}
+}
+
 func init() {
+	configListCmd.Flags().Bool("all", false, "Also list unset keys bd reads, with defaults and descriptions")
+	configExportCmd.Flags().String("prefix", "", "Only export keys starting with this prefix (e.g. search.)")
+	configExportCmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
+	configImportCmd.Flags().StringP("input", "i", "", "Input file (default: stdin)")
+
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configListCmd)
 	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configExportCmd)
+	configCmd.AddCommand(configImportCmd)
 	rootCmd.AddCommand(configCmd)
 }
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/syncbranch"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// configKeyInfo describes a config key bd itself reads, for bd config list
+// --all and for validating bd config set/import
+type configKeyInfo struct {
+	Key         string
+	Default     string // Shown when unset; empty means unset
+	Description string
+	// Validate rejects bad values before they're stored; nil accepts anything
+	Validate func(value string) error
+	// Secret values are masked by bd config list and left out of bd config
+	// export, whose output is meant to be committed
+	Secret bool
+}
+
+// knownConfigKeys are the config keys bd reads, sorted by key.
+// Integration namespaces (jira.*, linear.*, ...) are free-form and not listed.
+var knownConfigKeys = []configKeyInfo{
+	{Key: autoFlushConfigKey, Default: "true", Description: "Export to JSONL after each change; when false, run bd export yourself", Validate: func(v string) error {
+		_, err := parseAutoFlush(v)
+		return err
+	}},
+	{Key: commitCloseKeywordsConfigKey, Default: defaultCommitCloseKeywords, Description: "Commit message keywords bd sync acts on, as in \"fixes bd-42\"", Validate: func(v string) error {
+		_, err := parseCommitCloseKeywords(v)
+		return err
+	}},
+	{Key: commitCloseModeConfigKey, Default: commitCloseModeClose, Description: "What bd sync does with issues named in new commits: close, comment or off", Validate: func(v string) error {
+		_, err := parseCommitCloseMode(v)
+		return err
+	}},
+	{Key: "compact_tier1_days", Default: "30", Description: "Days an issue must be closed before tier 1 compaction", Validate: nonNegativeIntValidator("compact_tier1_days")},
+	{Key: "compact_tier1_dep_levels", Default: "2", Description: "Dependency depth checked for open dependents before tier 1 compaction", Validate: nonNegativeIntValidator("compact_tier1_dep_levels")},
+	{Key: "compact_tier2_commits", Default: "100", Description: "Commits since tier 1 compaction before tier 2", Validate: nonNegativeIntValidator("compact_tier2_commits")},
+	{Key: "compact_tier2_days", Default: "90", Description: "Days an issue must be closed before tier 2 compaction", Validate: nonNegativeIntValidator("compact_tier2_days")},
+	{Key: utils.DisplayHidePrefixConfigKey, Default: "false", Description: "Show IDs without the issue_prefix in text output; JSON and exports keep full IDs", Validate: func(v string) error {
+		_, err := utils.ParseDisplayHidePrefix(v)
+		return err
+	}},
+	{Key: utils.DuplicateCheckConfigKey, Default: "true", Description: "Make bd create refuse titles similar to an open issue's without --force", Validate: func(v string) error {
+		_, err := utils.ParseDuplicateCheck(v)
+		return err
+	}},
+	{Key: types.EventKeepPerIssueConfigKey, Default: "0", Description: "Most recent events per issue that automatic pruning keeps", Validate: func(v string) error {
+		_, err := types.ParseEventKeepPerIssue(v)
+		return err
+	}},
+	{Key: types.EventRetentionConfigKey, Description: "Days of events the daemon keeps (unset keeps everything)", Validate: func(v string) error {
+		_, err := types.ParseEventRetention(v)
+		return err
+	}},
+	{Key: sqlite.IDBlocklistConfigKey, Description: "Comma-separated sequences generated hash IDs must not contain", Validate: func(v string) error {
+		_, err := sqlite.ParseIDBlocklist(v)
+		return err
+	}},
+	{Key: idLengthWarningConfigKey, Default: "true", Description: "Warn once when new IDs are about to grow a character", Validate: func(v string) error {
+		_, err := parseIDLengthWarning(v)
+		return err
+	}},
+	{Key: sqlite.ImportBatchSizeConfigKey, Default: strconv.Itoa(sqlite.DefaultImportBatchSize), Description: "Issues bd import creates per transaction", Validate: func(v string) error {
+		_, err := sqlite.ParseImportBatchSize(v)
+		return err
+	}},
+	{Key: sqlite.OrphanHandlingConfigKey, Default: string(sqlite.OrphanAllow), Description: "Import handling of children with missing parents: strict, resurrect, skip or allow", Validate: func(v string) error {
+		_, err := sqlite.ParseOrphanHandling(v)
+		return err
+	}},
+	{Key: "issue_prefix", Description: "Prefix of new issue IDs (set by bd init)", Validate: types.ValidateIssuePrefix},
+	{Key: utils.IssueURLTemplateConfigKey, Description: "Link for each issue, with {id} standing for the issue ID", Validate: utils.ValidateIssueURLTemplate},
+	{Key: sqlite.MaxCollisionProbConfigKey, Default: "0.25", Description: "Collision probability at which adaptive hash IDs grow longer", Validate: func(v string) error {
+		_, err := sqlite.ParseMaxCollisionProb(v)
+		return err
+	}},
+	{Key: sqlite.MaxHashLengthConfigKey, Default: "8", Description: "Longest adaptive hash ID length", Validate: hashLengthValidator(sqlite.MaxHashLengthConfigKey)},
+	{Key: metricsEnabledConfigKey, Default: "false", Description: "Serve Prometheus metrics from the daemon", Validate: func(v string) error {
+		_, err := parseMetricsEnabled(v)
+		return err
+	}},
+	{Key: metricsPortConfigKey, Default: strconv.Itoa(defaultMetricsPort), Description: "Localhost port of the daemon's /metrics endpoint", Validate: func(v string) error {
+		_, err := parseMetricsPort(v)
+		return err
+	}},
+	{Key: sqlite.MinHashLengthConfigKey, Default: "3", Description: "Shortest adaptive hash ID length", Validate: hashLengthValidator(sqlite.MinHashLengthConfigKey)},
+	{Key: types.PrefixByTypeConfigKey, Description: "JSON object mapping issue types to ID prefixes", Validate: func(v string) error {
+		_, err := types.ParsePrefixByType(v)
+		return err
+	}},
+	{Key: types.PriorityPropagationConfigKey, Default: types.PriorityPropagationOff, Description: "How bd dep add adjusts a dependent's priority: off, bump or inherit", Validate: func(v string) error {
+		_, err := types.PriorityPropagationWeight(v)
+		return err
+	}},
+	{Key: retentionArchiveAfterConfigKey, Default: "0", Description: "Age past closing at which the daemon archives closed issues, e.g. 90d (0 for never)", Validate: func(v string) error {
+		_, err := parseRetentionArchiveAfter(v)
+		return err
+	}},
+	{Key: retentionDryRunConfigKey, Default: "false", Description: "Only log what retention_archive_after would archive", Validate: func(v string) error {
+		_, err := parseRetentionDryRun(v)
+		return err
+	}},
+	{Key: retentionIntervalConfigKey, Default: defaultRetentionInterval.String(), Description: "How often the daemon checks retention_archive_after (read at startup)", Validate: func(v string) error {
+		_, err := parseRetentionInterval(v)
+		return err
+	}},
+	{Key: syncbranch.ConfigKey, Description: "Branch bd sync commits issues to"},
+	{Key: syncCommitTemplateKey, Default: defaultSyncCommitTemplate, Description: "Message for commits bd sync makes without --message", Validate: validateSyncCommitTemplate},
+	{Key: syncPushBackoffConfigKey, Default: "500", Description: "Milliseconds before bd sync retries a failed pull or push, doubling each time", Validate: func(v string) error {
+		return validateSyncRetryConfig(syncPushBackoffConfigKey, v)
+	}},
+	{Key: syncPushRetriesConfigKey, Default: "3", Description: "Times bd sync retries a pull or push that failed transiently", Validate: func(v string) error {
+		return validateSyncRetryConfig(syncPushRetriesConfigKey, v)
+	}},
+	{Key: syncSignCommitsKey, Default: "false", Description: "Sign the commits bd sync and the daemon make (git commit -S)", Validate: func(v string) error {
+		_, err := parseSyncSignCommits(v)
+		return err
+	}},
+	{Key: syncSigningKeyKey, Description: "Key to sign sync commits with, overriding git's user.signingkey"},
+	{Key: watchDebounceConfigKey, Default: "500", Description: "Milliseconds the daemon waits after a file change before importing", Validate: positiveIntValidator(watchDebounceConfigKey)},
+	{Key: watchPollConfigKey, Default: "5000", Description: "Milliseconds between checks when the daemon watches by polling", Validate: positiveIntValidator(watchPollConfigKey)},
+	{Key: webhookEventsConfigKey, Default: defaultWebhookEvents, Description: "Event types the daemon sends to webhook_url", Validate: func(v string) error {
+		_, err := parseWebhookEvents(v)
+		return err
+	}},
+	{Key: webhookSecretConfigKey, Description: "HMAC key signing webhook requests", Secret: true},
+	{Key: webhookURLConfigKey, Description: "Where the daemon POSTs issue changes", Validate: validateWebhookURL},
+}
+
+// lookupConfigKey returns the known config key named key, if any
+func lookupConfigKey(key string) (configKeyInfo, bool) {
+	key = strings.TrimSpace(key)
+	for _, info := range knownConfigKeys {
+		if info.Key == key {
+			return info, true
+		}
+	}
+	return configKeyInfo{}, false
+}
+
+// isSecretConfigKey reports whether key holds a credential
+func isSecretConfigKey(key string) bool {
+	info, ok := lookupConfigKey(key)
+	return ok && info.Secret
+}
+
+// validateConfigValue runs key's validator, if it has one
+func validateConfigValue(key, value string) error {
+	info, ok := lookupConfigKey(key)
+	if !ok || info.Validate == nil {
+		return nil
+	}
+	return info.Validate(value)
+}
+
+// nonNegativeIntValidator accepts whole numbers from 0 up
+func nonNegativeIntValidator(key string) func(string) error {
+	return func(value string) error {
+		if n, err := strconv.Atoi(strings.TrimSpace(value)); err != nil || n < 0 {
+			return fmt.Errorf("%s must be a non-negative integer, got %q", key, value)
+		}
+		return nil
+	}
+}
+
+// positiveIntValidator accepts whole numbers from 1 up
+func positiveIntValidator(key string) func(string) error {
+	return func(value string) error {
+		if n, err := strconv.Atoi(strings.TrimSpace(value)); err != nil || n <= 0 {
+			return fmt.Errorf("%s must be a positive integer, got %q", key, value)
+		}
+		return nil
+	}
+}
+
+// hashLengthValidator accepts the hash lengths IDs can be generated with
+func hashLengthValidator(key string) func(string) error {
+	return func(value string) error {
+		_, err := sqlite.ParseHashLength(key, value)
+		return err
+	}
+}
@@ -177,3 +177,85 @@ func setupTestDB(t *testing.T) (*sqlite.SQLiteStorage, func()) {
 
 	return store, cleanup
 }
+
+func TestKnownConfigKeys(t *testing.T) {
+	for i, info := range knownConfigKeys {
+		if i > 0 && knownConfigKeys[i-1].Key >= info.Key {
+			t.Errorf("knownConfigKeys not sorted at %s", info.Key)
+		}
+		if info.Validate != nil && info.Default != "" {
+			if err := info.Validate(info.Default); err != nil {
+				t.Errorf("default %q of %s fails its own validation: %v", info.Default, info.Key, err)
+			}
+		}
+	}
+
+	rejected := map[string]string{
+		"issue_prefix":           "my-proj",
+		"max_collision_prob":     "1.5",
+		"min_hash_length":        "2",
+		"max_hash_length":        "nine",
+		"events.retention_days":  "-1",
+		"watch_poll_ms":          "0",
+		"import.orphan_handling": "ignore",
+		"priority_propagation":   "always",
+	}
+	for key, value := range rejected {
+		if err := validateConfigValue(key, value); err == nil {
+			t.Errorf("validateConfigValue(%s, %q) accepted", key, value)
+		}
+	}
+	if err := validateConfigValue("jira.url", "anything goes"); err != nil {
+		t.Errorf("free-form keys shouldn't be validated: %v", err)
+	}
+	if err := validateConfigValue("min_hash_length", "4"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAllConfigEntries(t *testing.T) {
+	entries := allConfigEntries(map[string]string{"min_hash_length": "5", "jira.url": "https://jira.example.com"})
+	byKey := make(map[string]configListEntry)
+	for _, entry := range entries {
+		byKey[entry.Key] = entry
+	}
+	if e := byKey["min_hash_length"]; !e.Set || e.Value != "5" || e.Default != "3" {
+		t.Errorf("unexpected min_hash_length entry: %+v", e)
+	}
+	if e := byKey["max_collision_prob"]; e.Set || e.Default != "0.25" {
+		t.Errorf("expected an unset max_collision_prob with its default: %+v", e)
+	}
+	if e := byKey["jira.url"]; !e.Set || e.Description != "" {
+		t.Errorf("expected the free-form jira.url as set: %+v", e)
+	}
+}
+
+func TestSecretConfig(t *testing.T) {
+	config := map[string]string{
+		"webhook_secret": "hunter2",
+		"webhook_url":    "https://hooks.example.com/beads",
+		"search.mine":    "assignee:me",
+	}
+
+	masked := maskSecretConfig(config)
+	if masked["webhook_secret"] != maskedConfigValue {
+		t.Errorf("webhook_secret listed as %q, want it masked", masked["webhook_secret"])
+	}
+	if masked["webhook_url"] != config["webhook_url"] {
+		t.Errorf("webhook_url listed as %q, want it unmasked", masked["webhook_url"])
+	}
+	if config["webhook_secret"] != "hunter2" {
+		t.Error("maskSecretConfig modified its input")
+	}
+
+	exported := exportableConfig(config, "")
+	if _, ok := exported["webhook_secret"]; ok {
+		t.Error("webhook_secret was exported")
+	}
+	if len(exported) != 2 {
+		t.Errorf("expected the two other keys exported, got %v", exported)
+	}
+	if exported := exportableConfig(config, "search."); len(exported) != 1 || exported["search.mine"] == "" {
+		t.Errorf("expected only search.mine with --prefix search., got %v", exported)
+	}
+}
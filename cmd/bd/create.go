@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -14,6 +15,7 @@ import (
 	"github.com/steveyegge/beads/internal/routing"
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
 var createCmd = &cobra.Command{
@@ -24,6 +26,27 @@ var createCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		file, _ := cmd.Flags().GetString("file")
 		fromTemplate, _ := cmd.Flags().GetString("from-template")
+		if templateName, _ := cmd.Flags().GetString("template"); templateName != "" {
+			if fromTemplate != "" && fromTemplate != templateName {
+				fmt.Fprintf(os.Stderr, "Error: --template and --from-template name different templates\n")
+				os.Exit(1)
+			}
+			fromTemplate = templateName
+		}
+
+		// With --stdin, create the JSON or JSONL records piped in
+		if stdin, _ := cmd.Flags().GetBool("stdin"); stdin {
+			if len(args) > 0 || file != "" {
+				fmt.Fprintf(os.Stderr, "Error: --stdin cannot be combined with a title or --file\n")
+				os.Exit(1)
+			}
+			skipInvalid, _ := cmd.Flags().GetBool("skip-invalid")
+			if err := createIssuesFromStdin(os.Stdin, skipInvalid); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
 
 		// If file flag is provided, parse markdown and create multiple issues
 		if file != "" {
@@ -74,6 +97,7 @@ var createCmd = &cobra.Command{
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
 			}
+			tmpl = tmpl.expandPlaceholders(title, time.Now())
 		}
 
 		// Get field values, preferring explicit flags over template defaults
@@ -96,7 +120,7 @@ var createCmd = &cobra.Command{
 		priorityStr, _ := cmd.Flags().GetString("priority")
 		priority := parsePriority(priorityStr)
 		if priority == -1 {
-			fmt.Fprintf(os.Stderr, "Error: invalid priority %q (expected 0-4 or P0-P4)\n", priorityStr)
+			fmt.Fprintf(os.Stderr, "Error: invalid priority %q (expected 0-4, P0-P4 or %s)\n", priorityStr, strings.Join(priorityNames(), ", "))
 			os.Exit(1)
 		}
 		if cmd.Flags().Changed("priority") == false && tmpl != nil {
@@ -126,6 +150,7 @@ var createCmd = &cobra.Command{
 		deps, _ := cmd.Flags().GetStringSlice("deps")
 		forceCreate, _ := cmd.Flags().GetBool("force")
 		repoOverride, _ := cmd.Flags().GetString("repo")
+		idLength, _ := cmd.Flags().GetInt("id-length")
 		// Use global jsonOutput set by PersistentPreRun
 
 		// Determine target repository using routing logic
@@ -162,18 +187,27 @@ var createCmd = &cobra.Command{
 			fmt.Fprintf(os.Stderr, "Error: cannot specify both --id and --parent flags\n")
 			os.Exit(1)
 		}
+		if idLength != 0 {
+			if explicitID != "" || parentID != "" {
+				fmt.Fprintf(os.Stderr, "Error: --id-length cannot be combined with --id or --parent\n")
+				os.Exit(1)
+			}
+			if idLength < 3 || idLength > 8 {
+				fmt.Fprintf(os.Stderr, "Error: invalid --id-length %d: must be from 3 to 8\n", idLength)
+				os.Exit(1)
+			}
+		}
 
-		// If parent is specified, generate child ID
-		// In daemon mode, the parent will be sent to the RPC handler
-		// In direct mode, we generate the child ID here
-		if parentID != "" && daemonClient == nil {
-			ctx := context.Background()
-			childID, err := store.GetNextChildID(ctx, parentID)
+		// A child is created with its parent's next hierarchical ID and a
+		// parent-child dependency, in one transaction (by the daemon's
+		// handler in daemon mode)
+		if parentID != "" {
+			resolved, err := resolveCreateParent(context.Background(), parentID)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
 			}
-			explicitID = childID // Set as explicit ID for the rest of the flow
+			parentID = resolved
 		}
 
 		// Validate explicit ID format if provided
@@ -195,17 +229,23 @@ var createCmd = &cobra.Command{
 
 				// Get database prefix from config
 				var dbPrefix string
+				var prefixes types.IDPrefixes
 				if daemonClient != nil {
 					// Using daemon - need to get config via RPC
 					// For now, skip validation in daemon mode (needs RPC enhancement)
 				} else {
 					// Direct mode - check config
 					dbPrefix, _ = store.GetConfig(ctx, "issue_prefix")
+					prefixes = utils.GetIDPrefixes(ctx, store)
+					if os.Getenv(types.IssuePrefixEnvVar) != "" {
+						dbPrefix = prefixes.Default
+					}
 				}
 
-				if dbPrefix != "" && dbPrefix != requestedPrefix {
+				// With prefix_by_type, any configured prefix is accepted
+				if dbPrefix != "" && dbPrefix != requestedPrefix && (len(prefixes.ByType) == 0 || prefixes.Match(explicitID) == "") {
 					fmt.Fprintf(os.Stderr, "Error: prefix mismatch detected\n")
-					fmt.Fprintf(os.Stderr, "  This database uses prefix '%s', but you specified '%s'\n", dbPrefix, requestedPrefix)
+					fmt.Fprintf(os.Stderr, "  This database uses prefix '%s', but you specified '%s'\n", strings.Join(prefixes.All(), "', '"), requestedPrefix)
 					fmt.Fprintf(os.Stderr, "  Use --force to create with mismatched prefix anyway\n")
 					os.Exit(1)
 				}
@@ -222,6 +262,7 @@ var createCmd = &cobra.Command{
 			createArgs := &rpc.CreateArgs{
 				ID:                 explicitID,
 				Parent:             parentID,
+				IDLength:           idLength,
 				Title:              title,
 				Description:        description,
 				IssueType:          issueType,
@@ -232,15 +273,24 @@ var createCmd = &cobra.Command{
 				ExternalRef:        externalRef,
 				Labels:             labels,
 				Dependencies:       deps,
+				CheckDuplicates:    !forceCreate,
+				AllowDuplicates:    jsonOutput,
 			}
 
 			resp, err := daemonClient.Create(createArgs)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				if strings.Contains(err.Error(), "possible duplicate") {
+					fmt.Fprintf(os.Stderr, "  Use --force to create it anyway\n")
+				}
 				os.Exit(1)
 			}
 
 			if jsonOutput {
+				var created rpc.CreatedIssue
+				if err := json.Unmarshal(resp.Data, &created); err == nil && len(created.PossibleDuplicates) > 0 {
+					fmt.Fprintf(os.Stderr, "Warning: possible duplicate of %s\n", strings.Join(created.PossibleDuplicates, ", "))
+				}
 				fmt.Println(string(resp.Data))
 			} else {
 				var issue types.Issue
@@ -251,7 +301,7 @@ var createCmd = &cobra.Command{
 				green := color.New(color.FgGreen).SprintFunc()
 				fmt.Printf("%s Created issue: %s\n", green("✓"), issue.ID)
 				fmt.Printf("  Title: %s\n", issue.Title)
-				fmt.Printf("  Priority: P%d\n", issue.Priority)
+				fmt.Printf("  Priority: %s\n", formatPriority(issue.Priority))
 				fmt.Printf("  Status: %s\n", issue.Status)
 			}
 			return
@@ -269,6 +319,7 @@ var createCmd = &cobra.Command{
 			IssueType:          types.IssueType(issueType),
 			Assignee:           assignee,
 			ExternalRef:        externalRefPtr,
+			IDLength:           idLength,
 		}
 
 		ctx := context.Background()
@@ -308,7 +359,32 @@ var createCmd = &cobra.Command{
 			// If error getting parent or parent has no source_repo, continue with default
 		}
 		
-		if err := store.CreateIssue(ctx, issue, actor); err != nil {
+		// Refuse near-duplicates of open issues unless --force; with --json
+		// the issue is created and the matches reported instead
+		var similar []*types.Issue
+		if !forceCreate {
+			var err error
+			similar, err = utils.FindSimilarOpenIssues(ctx, store, title)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if len(similar) > 0 {
+				printPossibleDuplicates(similar)
+				if !jsonOutput {
+					fmt.Fprintf(os.Stderr, "  Use --force to create it anyway\n")
+					os.Exit(1)
+				}
+			}
+		}
+
+		var err error
+		if parentID != "" {
+			err = store.CreateChildIssue(ctx, issue, parentID, actor)
+		} else {
+			err = store.CreateIssue(ctx, issue, actor)
+		}
+		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -367,35 +443,88 @@ var createCmd = &cobra.Command{
 		markDirtyAndScheduleFlush()
 
 		if jsonOutput {
-			outputJSON(issue)
+			outputJSON(rpc.CreatedIssue{Issue: issue, PossibleDuplicates: utils.IssueIDs(similar)})
 		} else {
 			green := color.New(color.FgGreen).SprintFunc()
 			fmt.Printf("%s Created issue: %s\n", green("✓"), issue.ID)
 			fmt.Printf("  Title: %s\n", issue.Title)
-			fmt.Printf("  Priority: P%d\n", issue.Priority)
+			fmt.Printf("  Priority: %s\n", formatPriority(issue.Priority))
 			fmt.Printf("  Status: %s\n", issue.Status)
 		}
 	},
 }
 
+// printPossibleDuplicates warns that open issues with a similar title exist
+func printPossibleDuplicates(similar []*types.Issue) {
+	yellow := color.New(color.FgYellow).SprintFunc()
+	fmt.Fprintf(os.Stderr, "%s Possible duplicate of open issues with a similar title:\n", yellow("⚠"))
+	for _, issue := range similar {
+		fmt.Fprintf(os.Stderr, "  %s: %s\n", issue.ID, issue.Title)
+	}
+}
+
+// resolveCreateParent resolves the --parent of a new issue to a full ID,
+// warning if the parent is closed
+func resolveCreateParent(ctx context.Context, parentID string) (string, error) {
+	var parent types.Issue
+	if daemonClient != nil {
+		resp, err := daemonClient.ResolveID(&rpc.ResolveIDArgs{ID: parentID})
+		if err != nil {
+			return "", fmt.Errorf("parent %s: %w", parentID, err)
+		}
+		if err := json.Unmarshal(resp.Data, &parentID); err != nil {
+			return "", fmt.Errorf("failed to parse resolved parent ID: %w", err)
+		}
+		resp, err = daemonClient.Show(&rpc.ShowArgs{ID: parentID})
+		if err != nil {
+			return "", fmt.Errorf("parent %s: %w", parentID, err)
+		}
+		if err := json.Unmarshal(resp.Data, &parent); err != nil {
+			return "", fmt.Errorf("failed to parse parent %s: %w", parentID, err)
+		}
+	} else {
+		resolved, err := utils.ResolvePartialID(ctx, store, parentID)
+		if err != nil {
+			return "", fmt.Errorf("parent %s: %w", parentID, err)
+		}
+		issue, err := store.GetIssue(ctx, resolved)
+		if err != nil {
+			return "", fmt.Errorf("failed to get parent %s: %w", resolved, err)
+		}
+		if issue == nil {
+			return "", fmt.Errorf("parent issue %s not found", resolved)
+		}
+		parent = *issue
+	}
+
+	if parent.Status == types.StatusClosed {
+		fmt.Fprintf(os.Stderr, "Warning: parent %s is closed\n", parent.ID)
+	}
+	return parent.ID, nil
+}
+
 func init() {
 	createCmd.Flags().StringP("file", "f", "", "Create multiple issues from markdown file")
 	createCmd.Flags().String("from-template", "", "Create issue from template (e.g., 'epic', 'bug', 'feature')")
+	createCmd.Flags().String("template", "", "Create issue from template (same as --from-template)")
 	createCmd.Flags().String("title", "", "Issue title (alternative to positional argument)")
 	createCmd.Flags().StringP("description", "d", "", "Issue description")
 	createCmd.Flags().String("design", "", "Design notes")
 	createCmd.Flags().String("acceptance", "", "Acceptance criteria")
-	createCmd.Flags().StringP("priority", "p", "2", "Priority (0-4 or P0-P4, 0=highest)")
+	createCmd.Flags().StringP("priority", "p", "2", "Priority (0-4, P0-P4 or a name: critical, high, medium, low, backlog)")
 	createCmd.Flags().StringP("type", "t", "task", "Issue type (bug|feature|task|epic|chore)")
 	createCmd.Flags().StringP("assignee", "a", "", "Assignee")
 	createCmd.Flags().StringSliceP("labels", "l", []string{}, "Labels (comma-separated)")
 	createCmd.Flags().StringSlice("label", []string{}, "Alias for --labels")
 	_ = createCmd.Flags().MarkHidden("label")
 	createCmd.Flags().String("id", "", "Explicit issue ID (e.g., 'bd-42' for partitioning)")
+	createCmd.Flags().Bool("stdin", false, "Create issues from JSON (an array) or JSONL read from stdin, printing their IDs as JSONL")
+	createCmd.Flags().Bool("skip-invalid", false, "With --stdin, report invalid records and create the valid ones instead of failing")
 	createCmd.Flags().String("parent", "", "Parent issue ID for hierarchical child (e.g., 'bd-a3f8e9')")
 	createCmd.Flags().String("external-ref", "", "External reference (e.g., 'gh-9', 'jira-ABC')")
 	createCmd.Flags().StringSlice("deps", []string{}, "Dependencies in format 'type:id' or 'id' (e.g., 'discovered-from:bd-20,blocks:bd-15' or 'bd-20')")
-	createCmd.Flags().Bool("force", false, "Force creation even if prefix doesn't match database prefix")
+	createCmd.Flags().Int("id-length", 0, "Hash length (3-8) for this issue's ID, overriding the adaptive length")
+	createCmd.Flags().Bool("force", false, "Force creation even if prefix doesn't match database prefix or an open issue has a similar title")
 	createCmd.Flags().String("repo", "", "Target repository for issue (overrides auto-routing)")
 	// Note: --json flag is defined as a persistent flag in main.go, not here
 	rootCmd.AddCommand(createCmd)
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// createRecord is one issue read by bd create --stdin. Fields that are left
+// out get bd create's defaults: priority 2, type task, status open.
+type createRecord struct {
+	ID                 string   `json:"id,omitempty"`
+	Title              string   `json:"title"`
+	Description        string   `json:"description,omitempty"`
+	Design             string   `json:"design,omitempty"`
+	AcceptanceCriteria string   `json:"acceptance_criteria,omitempty"`
+	Notes              string   `json:"notes,omitempty"`
+	Status             string   `json:"status,omitempty"`
+	Priority           *int     `json:"priority,omitempty"`
+	IssueType          string   `json:"issue_type,omitempty"`
+	Assignee           string   `json:"assignee,omitempty"`
+	Labels             []string `json:"labels,omitempty"`
+	ExternalRef        string   `json:"external_ref,omitempty"`
+}
+
+// createStdinResult is one line of bd create --stdin output, for the input
+// record at Index: the ID it was given, or why it was skipped
+type createStdinResult struct {
+	Index int    `json:"index"`
+	ID    string `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// parseCreateRecords reads a JSON array of issues, or JSONL with one issue
+// per line, returning an issue or an error for each record in input order.
+// Blank lines don't count as records. The error return is for input that
+// can't be split into records at all, such as a malformed array.
+func parseCreateRecords(data []byte) ([]*types.Issue, []error, error) {
+	var raws []json.RawMessage
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &raws); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse JSON array: %w", err)
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), 2*1024*1024)
+		for scanner.Scan() {
+			if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+				raws = append(raws, json.RawMessage(bytes.Clone(line)))
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, nil, fmt.Errorf("failed to read input: %w", err)
+		}
+	}
+
+	issues := make([]*types.Issue, len(raws))
+	errs := make([]error, len(raws))
+	for i, raw := range raws {
+		issues[i], errs[i] = issueFromCreateRecord(raw)
+	}
+	return issues, errs, nil
+}
+
+// issueFromCreateRecord decodes and validates one record. Unknown fields are
+// rejected so a misspelled field isn't silently dropped.
+func issueFromCreateRecord(raw json.RawMessage) (*types.Issue, error) {
+	var record createRecord
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&record); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	issue := &types.Issue{
+		ID:                 record.ID,
+		Title:              record.Title,
+		Description:        record.Description,
+		Design:             record.Design,
+		AcceptanceCriteria: record.AcceptanceCriteria,
+		Notes:              record.Notes,
+		Status:             types.StatusOpen,
+		Priority:           2,
+		IssueType:          types.TypeTask,
+		Assignee:           record.Assignee,
+		Labels:             record.Labels,
+	}
+	if record.Status != "" {
+		issue.Status = types.Status(record.Status)
+	}
+	if record.Priority != nil {
+		issue.Priority = *record.Priority
+	}
+	if record.IssueType != "" {
+		issue.IssueType = types.IssueType(record.IssueType)
+	}
+	if record.ExternalRef != "" {
+		issue.ExternalRef = &record.ExternalRef
+	}
+	if issue.Status == types.StatusClosed {
+		return nil, fmt.Errorf("cannot create a closed issue")
+	}
+	if err := issue.Validate(); err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
+
+// createIssuesFromStdin is bd create --stdin: it creates the issues read from
+// r in one transaction and prints a createStdinResult line per record. An
+// invalid record fails the whole run before anything is created, unless
+// skipInvalid is set, in which case it's reported and the rest are created.
+func createIssuesFromStdin(r io.Reader, skipInvalid bool) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("failed to read stdin: %w", err)
+	}
+	issues, errs, err := parseCreateRecords(data)
+	if err != nil {
+		return err
+	}
+
+	invalid := 0
+	for i, recordErr := range errs {
+		if recordErr == nil {
+			continue
+		}
+		invalid++
+		if skipInvalid {
+			fmt.Fprintf(os.Stderr, "Warning: skipping record %d: %v\n", i, recordErr)
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: record %d: %v\n", i, recordErr)
+		}
+	}
+	if invalid > 0 && !skipInvalid {
+		return fmt.Errorf("%d invalid record(s), nothing was created (use --skip-invalid to create the valid ones)", invalid)
+	}
+
+	valid := make([]*types.Issue, 0, len(issues)-invalid)
+	for _, issue := range issues {
+		if issue != nil {
+			valid = append(valid, issue)
+		}
+	}
+	if len(valid) > 0 {
+		if err := ensureDirectMode("create --stdin requires direct database access"); err != nil {
+			return err
+		}
+		ctx := context.Background()
+		if sqliteStore, ok := store.(*sqlite.SQLiteStorage); ok {
+			err = sqliteStore.CreateIssuesBatchWithOptions(ctx, valid, actor, sqlite.BatchOptions{
+				BatchSize:      len(valid),
+				OrphanHandling: sqlite.OrphanResurrect,
+			})
+		} else {
+			err = createIssuesWithLabels(ctx, valid)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create issues: %w", err)
+		}
+		markDirtyAndScheduleFlush()
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	for i, issue := range issues {
+		result := createStdinResult{Index: i}
+		if issue != nil {
+			result.ID = issue.ID
+		} else {
+			result.Error = errs[i].Error()
+		}
+		if err := encoder.Encode(result); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+	return nil
+}
+
+// createIssuesWithLabels creates issues and their labels through the generic
+// storage interface, for backends without a batched label insert
+func createIssuesWithLabels(ctx context.Context, issues []*types.Issue) error {
+	if err := store.CreateIssues(ctx, issues, actor); err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		for _, label := range issue.Labels {
+			if err := store.AddLabel(ctx, issue.ID, label, actor); err != nil {
+				return fmt.Errorf("failed to add label %s to %s: %w", label, issue.ID, err)
+			}
+		}
+	}
+	return nil
+}
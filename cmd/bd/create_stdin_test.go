@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseCreateRecords(t *testing.T) {
+	jsonl := []byte(`{"title":"First","labels":["backend"]}
+
+{"title":"Second","priority":0,"issue_type":"bug","external_ref":"gh-9"}
+{"titel":"Typo"}
+{"title":"Bad priority","priority":7}
+`)
+	issues, errs, err := parseCreateRecords(jsonl)
+	if err != nil {
+		t.Fatalf("parseCreateRecords failed: %v", err)
+	}
+	if len(issues) != 4 {
+		t.Fatalf("expected 4 records (blank line skipped), got %d", len(issues))
+	}
+	first, second := issues[0], issues[1]
+	if errs[0] != nil || first.Title != "First" || first.Priority != 2 || first.IssueType != types.TypeTask ||
+		first.Status != types.StatusOpen || len(first.Labels) != 1 {
+		t.Errorf("expected defaults for record 0, got %+v (err %v)", first, errs[0])
+	}
+	if errs[1] != nil || second.Priority != 0 || second.IssueType != types.TypeBug || *second.ExternalRef != "gh-9" {
+		t.Errorf("unexpected record 1: %+v (err %v)", second, errs[1])
+	}
+	if issues[2] != nil || errs[2] == nil {
+		t.Errorf("expected an unknown-field error for record 2, got %+v", issues[2])
+	}
+	if issues[3] != nil || errs[3] == nil {
+		t.Errorf("expected a validation error for record 3, got %+v", issues[3])
+	}
+
+	issues, errs, err = parseCreateRecords([]byte(` [{"title":"A"}, {"title":""}]`))
+	if err != nil {
+		t.Fatalf("parseCreateRecords failed on an array: %v", err)
+	}
+	if len(issues) != 2 || errs[0] != nil || errs[1] == nil {
+		t.Errorf("expected one valid and one untitled record, got %v", errs)
+	}
+
+	if _, _, err := parseCreateRecords([]byte(`[{"title":"A"}`)); err == nil {
+		t.Error("expected an error for a malformed array")
+	}
+}
@@ -31,8 +31,12 @@ The daemon will:
 - Auto-import when remote changes detected
 
 Use --stop to stop a running daemon.
-Use --status to check if daemon is running.
-Use --health to check daemon health and metrics.`,
+Use --status to check if daemon is running, or 'bd daemon status' for what
+it is watching and whether it is using native file events.
+Use --health to check daemon health and metrics.
+
+On Unix, send SIGHUP to an event-driven daemon to reload its config and
+re-arm the file watcher without restarting it.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		stop, _ := cmd.Flags().GetBool("stop")
 		status, _ := cmd.Flags().GetBool("status")
@@ -177,6 +181,8 @@ func init() {
 	daemonCmd.Flags().String("log", "", "Log file path (default: .beads/daemon.log)")
 	daemonCmd.Flags().Bool("global", false, "Run as global daemon (socket at ~/.beads/bd.sock)")
 	daemonCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output JSON format")
+	daemonStatusCmd.Flags().Bool("global", false, "Query the global daemon")
+	daemonCmd.AddCommand(daemonStatusCmd)
 	rootCmd.AddCommand(daemonCmd)
 }
 func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, pidFile string, global bool) {
@@ -377,6 +383,14 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, p
 		}
 	}
 
+	// Reconcile with JSONL changes made while the daemon was down (e.g. git pull)
+	// before serving any requests
+	if jsonlPath := beads.FindJSONLPath(daemonDBPath); jsonlPath != "" {
+		if _, err := reconcileJSONLOnStartup(versionCtx, store, jsonlPath, daemonDBPath, log); err != nil {
+			log.log("Warning: startup reconciliation failed: %v", err)
+		}
+	}
+
 	// Get workspace path (.beads directory) - beadsDir already defined above
 	// Get actual workspace root (parent of .beads)
 	workspacePath := filepath.Dir(beadsDir)
@@ -388,6 +402,7 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, p
 	if err != nil {
 		return
 	}
+	startMetricsServer(ctx, server, store, log)
 
 	// Register daemon in global registry
 	registry, err := daemon.NewRegistry()
@@ -438,7 +453,7 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, p
 		if jsonlPath == "" {
 			log.log("Error: JSONL path not found, cannot use event-driven mode")
 			log.log("Falling back to polling mode")
-			runEventLoop(ctx, cancel, ticker, doSync, server, serverErrChan, parentPID, log)
+			runEventLoop(ctx, cancel, ticker, doSync, server, serverErrChan, store, parentPID, log)
 		} else {
 			// Event-driven mode uses separate export-only and import-only functions
 			doExport := createExportFunc(ctx, store, autoCommit, autoPush, log)
@@ -447,9 +462,9 @@ func runDaemonLoop(interval time.Duration, autoCommit, autoPush bool, logPath, p
 		}
 	case "poll":
 		log.log("Using polling mode (interval: %v)", interval)
-		runEventLoop(ctx, cancel, ticker, doSync, server, serverErrChan, parentPID, log)
+		runEventLoop(ctx, cancel, ticker, doSync, server, serverErrChan, store, parentPID, log)
 	default:
 		log.log("Unknown BEADS_DAEMON_MODE: %s (valid: poll, events), defaulting to poll", daemonMode)
-		runEventLoop(ctx, cancel, ticker, doSync, server, serverErrChan, parentPID, log)
+		runEventLoop(ctx, cancel, ticker, doSync, server, serverErrChan, store, parentPID, log)
 	}
 }
@@ -64,3 +64,28 @@ func (d *Debouncer) Cancel() {
 		d.timer = nil
 	}
 }
+
+// Flush runs a pending action now instead of waiting out the debounce,
+// reporting whether there was one. The action runs on the caller's
+// goroutine, so it has finished when Flush returns.
+func (d *Debouncer) Flush() bool {
+	d.mu.Lock()
+	if d.timer == nil {
+		d.mu.Unlock()
+		return false
+	}
+	d.timer.Stop()
+	d.timer = nil
+	d.seq++ // A timer that already fired must not run the action again
+	d.mu.Unlock()
+
+	d.action()
+	return true
+}
+
+// Pending reports whether an action is scheduled and hasn't started yet.
+func (d *Debouncer) Pending() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.timer != nil
+}
@@ -190,3 +190,26 @@ func TestDebouncer_CancelImmediatelyAfterTrigger(t *testing.T) {
 		t.Errorf("action should not fire after immediate cancel: got %d, want 0", got)
 	}
 }
+
+func TestDebouncer_FlushRunsPendingAction(t *testing.T) {
+	var count int32
+	debouncer := NewDebouncer(time.Hour, func() {
+		atomic.AddInt32(&count, 1)
+	})
+	t.Cleanup(debouncer.Cancel)
+
+	if debouncer.Flush() {
+		t.Error("Flush with nothing pending reported an action")
+	}
+
+	debouncer.Trigger()
+	if !debouncer.Flush() {
+		t.Error("Flush with a pending action reported none")
+	}
+	if got := atomic.LoadInt32(&count); got != 1 {
+		t.Errorf("action should have run once by the time Flush returned: got %d, want 1", got)
+	}
+	if debouncer.Pending() {
+		t.Error("action still pending after Flush")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/beads/internal/rpc"
@@ -32,6 +34,11 @@ func runEventDrivenLoop(
 	signal.Notify(sigChan, daemonSignals...)
 	defer signal.Stop(sigChan)
 
+	// Webhooks follow the events table, so they see both RPC mutations and
+	// changes imported from JSONL
+	webhooks := newWebhookNotifier(ctx, store, log)
+	go webhooks.Run(ctx)
+
 	// Debounced sync actions
 	exportDebouncer := NewDebouncer(500*time.Millisecond, func() {
 		log.log("Export triggered by mutation events")
@@ -42,24 +49,31 @@ func runEventDrivenLoop(
 	importDebouncer := NewDebouncer(500*time.Millisecond, func() {
 		log.log("Import triggered by file change")
 		doAutoImport()
+		server.RecordImport()
+		webhooks.Trigger()
 	})
 	defer importDebouncer.Cancel()
 
 	// Start file watcher for JSONL changes
-	watcher, err := NewFileWatcher(jsonlPath, func() {
-		importDebouncer.Trigger()
-	})
+	watchConfig := eventLoopWatchConfig{JSONLPath: jsonlPath, Options: watcherOptionsFromConfig(ctx, store, log)}
+	watcher, err := startEventLoopWatcher(ctx, watchConfig, importDebouncer.Trigger, log)
 	var fallbackTicker *time.Ticker
 	if err != nil {
 		log.log("WARNING: File watcher unavailable (%v), using 60s polling fallback", err)
-		watcher = nil
 		// Fallback ticker to check for remote changes when watcher unavailable
 		fallbackTicker = time.NewTicker(60 * time.Second)
-		defer fallbackTicker.Stop()
-	} else {
-		watcher.Start(ctx, log)
-		defer func() { _ = watcher.Close() }()
 	}
+	var lastReload time.Time
+	server.SetWatchState(eventLoopWatchState(watcher, watchConfig, lastReload))
+	// A reload may replace either, so close whichever is current on exit
+	defer func() {
+		if watcher != nil {
+			_ = watcher.Close()
+		}
+		if fallbackTicker != nil {
+			fallbackTicker.Stop()
+		}
+	}()
 
 	// Handle mutation events from RPC server
 	mutationChan := server.MutationChan()
@@ -74,6 +88,7 @@ func runEventDrivenLoop(
 				}
 				log.log("Mutation detected: %s %s", event.Type, event.IssueID)
 				exportDebouncer.Trigger()
+				webhooks.Trigger()
 
 			case <-ctx.Done():
 				return
@@ -93,6 +108,12 @@ func runEventDrivenLoop(
 	droppedEventsTicker := time.NewTicker(1 * time.Second)
 	defer droppedEventsTicker.Stop()
 
+	// Retention passes archive old closed issues; the first runs shortly
+	// after startup, then every retention_interval
+	retentionInterval := retentionIntervalFromConfig(ctx, store, log)
+	retentionTicker := time.NewTicker(retentionPostponeDelay)
+	defer retentionTicker.Stop()
+
 	for {
 		select {
 		case <-droppedEventsTicker.C:
@@ -103,6 +124,23 @@ func runEventDrivenLoop(
 				exportDebouncer.Trigger()
 			}
 
+		case <-retentionTicker.C:
+			// An import waiting to run may change the same issues, and
+			// exporting first would overwrite what it brings in
+			if importDebouncer.Pending() || (watcher != nil && watcher.hasPendingChanges()) {
+				log.log("Retention: import pending, trying again in %v", retentionPostponeDelay)
+				retentionTicker.Reset(retentionPostponeDelay)
+				continue
+			}
+			retentionTicker.Reset(retentionInterval)
+			if runRetentionPass(ctx, store, time.Now(), log) > 0 {
+				webhooks.Trigger()
+				// Exported like mutations, unless auto_flush is off
+				if autoFlushFromConfig(ctx, store) {
+					exportDebouncer.Trigger()
+				}
+			}
+
 		case <-healthTicker.C:
 			// Periodic health validation (not sync)
 			checkDaemonHealth(ctx, store, log)
@@ -130,10 +168,39 @@ func runEventDrivenLoop(
 
 		case sig := <-sigChan:
 			if isReloadSignal(sig) {
-				log.log("Received reload signal, ignoring")
+				server.RecordReload()
+				// Only the file watch is rebuilt; the RPC server keeps its
+				// listener and in-flight requests
+				newConfig := loadEventLoopWatchConfig(ctx, store, watchConfig, log)
+				newWatcher, err := startEventLoopWatcher(ctx, newConfig, importDebouncer.Trigger, log)
+				if err != nil {
+					log.log("Reload failed, keeping the current file watch: %v", err)
+					continue
+				}
+				// Closing the old watcher cancels its debounce timers, so a
+				// change it hadn't reported yet is imported now
+				pending := watcher != nil && watcher.hasPendingChanges()
+				if watcher != nil {
+					_ = watcher.Close()
+				}
+				if fallbackTicker != nil {
+					fallbackTicker.Stop()
+					fallbackTicker = nil
+				}
+				watcher = newWatcher
+				if pending {
+					importDebouncer.Trigger()
+				}
+				log.log("reloaded %s", describeWatchConfig(watchConfig, newConfig))
+				watchConfig = newConfig
+				lastReload = time.Now()
+				server.SetWatchState(eventLoopWatchState(watcher, watchConfig, lastReload))
 				continue
 			}
 			log.log("Received signal %v, shutting down...", sig)
+			// Export pending mutations first; the export needs ctx, so this
+			// comes before cancel
+			flushExportOnShutdown(exportDebouncer, shutdownFlushTimeout, log)
 			cancel()
 			if err := server.Stop(); err != nil {
 				log.log("Error stopping server: %v", err)
@@ -164,6 +231,23 @@ func runEventDrivenLoop(
 	}
 }
 
+// flushExportOnShutdown runs a debounced export that hasn't fired yet, giving
+// up after timeout so a stuck export can't block shutdown
+func flushExportOnShutdown(exportDebouncer *Debouncer, timeout time.Duration, log daemonLogger) {
+	done := make(chan bool, 1)
+	go func() {
+		done <- exportDebouncer.Flush()
+	}()
+	select {
+	case flushed := <-done:
+		if flushed {
+			log.log("Exported pending changes before shutdown")
+		}
+	case <-time.After(timeout):
+		log.log("WARNING: export of pending changes timed out after %v; shutting down anyway", timeout)
+	}
+}
+
 // checkDaemonHealth performs periodic health validation.
 // Separate from sync operations - just validates state.
 func checkDaemonHealth(ctx context.Context, store storage.Storage, log daemonLogger) {
@@ -173,3 +257,93 @@ func checkDaemonHealth(ctx context.Context, store storage.Storage, log daemonLog
 	// - Memory usage check
 	// For now, this is a no-op placeholder
 }
+
+// watcherOptionsFromConfig reads the watch_debounce_ms and watch_poll_ms
+// config keys, falling back to the defaults for unset or invalid values
+func watcherOptionsFromConfig(ctx context.Context, store storage.Storage, log daemonLogger) FileWatcherOptions {
+	readMillis := func(key string) time.Duration {
+		value, err := store.GetConfig(ctx, key)
+		if err != nil || value == "" {
+			return 0
+		}
+		ms, err := strconv.Atoi(value)
+		if err != nil || ms <= 0 {
+			log.log("Ignoring invalid %s %q (must be a positive number of milliseconds)", key, value)
+			return 0
+		}
+		return time.Duration(ms) * time.Millisecond
+	}
+	return FileWatcherOptions{
+		Debounce:     readMillis(watchDebounceConfigKey),
+		PollInterval: readMillis(watchPollConfigKey),
+	}
+}
+
+// eventLoopWatchConfig is what the event-driven loop watches and how. A
+// reload signal re-reads it.
+type eventLoopWatchConfig struct {
+	JSONLPath string
+	Options   FileWatcherOptions
+}
+
+// loadEventLoopWatchConfig re-reads the watch config: the JSONL path is
+// rediscovered next to the database (keeping current's if none is found) and
+// the timings are read from config
+func loadEventLoopWatchConfig(ctx context.Context, store storage.Storage, current eventLoopWatchConfig, log daemonLogger) eventLoopWatchConfig {
+	next := eventLoopWatchConfig{JSONLPath: findJSONLPath(), Options: watcherOptionsFromConfig(ctx, store, log)}
+	if next.JSONLPath == "" {
+		next.JSONLPath = current.JSONLPath
+	}
+	return next
+}
+
+// startEventLoopWatcher creates and starts a file watcher for watchConfig
+// that calls onChanged after each debounced change
+func startEventLoopWatcher(ctx context.Context, watchConfig eventLoopWatchConfig, onChanged func(), log daemonLogger) (*FileWatcher, error) {
+	watcher, err := NewFileWatcherWithOptions(watchConfig.JSONLPath, onChanged, watchConfig.Options)
+	if err != nil {
+		return nil, err
+	}
+	watcher.Start(ctx, log)
+	return watcher, nil
+}
+
+// eventLoopWatchState describes the loop's file watch for the status
+// endpoint. Without a watcher the loop falls back to a periodic check.
+func eventLoopWatchState(watcher *FileWatcher, watchConfig eventLoopWatchConfig, lastReload time.Time) rpc.WatchState {
+	state := rpc.WatchState{
+		WatchedPaths: []string{watchConfig.JSONLPath},
+		WatchBackend: rpc.WatchBackendInterval,
+		PollingMode:  true,
+	}
+	if watcher != nil {
+		state.WatchedPaths = watcher.paths()
+		state.PollingMode = watcher.pollingMode
+		state.WatchBackend = rpc.WatchBackendFSNotify
+		if watcher.pollingMode {
+			state.WatchBackend = rpc.WatchBackendPolling
+		}
+	}
+	if !lastReload.IsZero() {
+		state.LastReloadTime = lastReload.Format(time.RFC3339)
+	}
+	return state
+}
+
+// describeWatchConfig renders next as key=value pairs, marking each value
+// that differs from prev with what it was
+func describeWatchConfig(prev, next eventLoopWatchConfig) string {
+	prevOpts, nextOpts := prev.Options.withDefaults(), next.Options.withDefaults()
+	var fields []string
+	field := func(key, was, now string) {
+		if was != now {
+			now += " (was " + was + ")"
+		}
+		fields = append(fields, key+"="+now)
+	}
+	millis := func(d time.Duration) string { return strconv.FormatInt(d.Milliseconds(), 10) }
+	field("jsonl", prev.JSONLPath, next.JSONLPath)
+	field(watchDebounceConfigKey, millis(prevOpts.Debounce), millis(nextOpts.Debounce))
+	field(watchPollConfigKey, millis(prevOpts.PollInterval), millis(nextOpts.PollInterval))
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatcherOptionsFromConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := newTestStore(t, filepath.Join(tmpDir, ".beads", "beads.db"))
+	ctx := context.Background()
+	jsonlPath := filepath.Join(tmpDir, ".beads", "issues.jsonl")
+
+	// Unset keys keep the defaults
+	fw, err := NewFileWatcherWithOptions(jsonlPath, func() {}, watcherOptionsFromConfig(ctx, store, newReconcileTestLogger()))
+	if err != nil {
+		t.Fatalf("failed to create watcher: %v", err)
+	}
+	if fw.debounce != defaultWatchDebounce || fw.pollInterval != defaultWatchPollInterval {
+		t.Errorf("expected default timings, got debounce=%v poll=%v", fw.debounce, fw.pollInterval)
+	}
+	_ = fw.Close()
+
+	if err := store.SetConfig(ctx, watchDebounceConfigKey, "1500"); err != nil {
+		t.Fatalf("failed to set config: %v", err)
+	}
+	if err := store.SetConfig(ctx, watchPollConfigKey, "-5"); err != nil {
+		t.Fatalf("failed to set config: %v", err)
+	}
+	var logged []string
+	log := daemonLogger{logFunc: func(format string, args ...interface{}) { logged = append(logged, format) }}
+
+	opts := watcherOptionsFromConfig(ctx, store, log)
+	if opts.Debounce != 1500*time.Millisecond {
+		t.Errorf("expected 1.5s debounce, got %v", opts.Debounce)
+	}
+	if opts.PollInterval != 0 || len(logged) != 1 {
+		t.Errorf("expected invalid poll interval to be ignored and logged, got %v (%d log lines)", opts.PollInterval, len(logged))
+	}
+
+	fw, err = NewFileWatcherWithOptions(jsonlPath, func() {}, opts)
+	if err != nil {
+		t.Fatalf("failed to create watcher: %v", err)
+	}
+	defer fw.Close()
+	if fw.debounce != 1500*time.Millisecond || fw.pollInterval != defaultWatchPollInterval {
+		t.Errorf("expected configured debounce and default poll, got debounce=%v poll=%v", fw.debounce, fw.pollInterval)
+	}
+}
@@ -0,0 +1,129 @@
+//go:build unix
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+func TestEventDrivenLoopReloadsOnSIGHUP(t *testing.T) {
+	tmpDir := t.TempDir()
+	testDBPath := filepath.Join(tmpDir, ".beads", "beads.db")
+	store := newTestStore(t, testDBPath)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	oldDBPath := dbPath
+	dbPath = testDBPath
+	defer func() { dbPath = oldDBPath }()
+	jsonlPath := filepath.Join(tmpDir, ".beads", "issues.jsonl")
+	if err := os.WriteFile(jsonlPath, []byte("{}\n"), 0600); err != nil {
+		t.Fatalf("failed to write JSONL: %v", err)
+	}
+
+	// Keep a stray SIGHUP from killing the test binary before the loop
+	// has registered its handler
+	hold := make(chan os.Signal, 1)
+	signal.Notify(hold, syscall.SIGHUP)
+	defer signal.Stop(hold)
+
+	// Changes would take 10s to be noticed before the reload
+	if err := store.SetConfig(ctx, watchDebounceConfigKey, "10000"); err != nil {
+		t.Fatalf("failed to set config: %v", err)
+	}
+
+	var mu sync.Mutex
+	var reloaded []string
+	log := daemonLogger{logFunc: func(format string, args ...interface{}) {
+		if msg := fmt.Sprintf(format, args...); strings.HasPrefix(msg, "reloaded ") {
+			mu.Lock()
+			reloaded = append(reloaded, msg)
+			mu.Unlock()
+		}
+	}}
+
+	socketPath := filepath.Join(tmpDir, "bd.sock")
+	server, serverErrChan, err := startRPCServer(ctx, socketPath, store, tmpDir, testDBPath, log)
+	if err != nil {
+		t.Fatalf("failed to start RPC server: %v", err)
+	}
+
+	imports := make(chan struct{}, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		runEventDrivenLoop(ctx, cancel, server, serverErrChan, store, jsonlPath, func() {}, func() {
+			imports <- struct{}{}
+		}, 0, log)
+	}()
+
+	// reload sends SIGHUP until the loop logs a new reload, resending in
+	// case its handler isn't installed yet, and returns the new reload lines
+	reload := func() string {
+		mu.Lock()
+		seen := len(reloaded)
+		mu.Unlock()
+		for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); {
+			if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+				t.Fatalf("failed to send SIGHUP: %v", err)
+			}
+			time.Sleep(50 * time.Millisecond)
+			mu.Lock()
+			got := reloaded[seen:]
+			mu.Unlock()
+			if len(got) > 0 {
+				return strings.Join(got, "\n")
+			}
+		}
+		t.Fatal("daemon did not log a reload after SIGHUP")
+		return ""
+	}
+	if line := reload(); !strings.Contains(line, "watch_debounce_ms=10000 ") || strings.Contains(line, "(was") {
+		t.Errorf("expected an unchanged reload, got %q", line)
+	}
+
+	if err := store.SetConfig(ctx, watchDebounceConfigKey, "50"); err != nil {
+		t.Fatalf("failed to set config: %v", err)
+	}
+	if line := reload(); !strings.Contains(line, "watch_debounce_ms=50 (was 10000)") || !strings.Contains(line, "jsonl="+jsonlPath+" ") {
+		t.Errorf("reload line doesn't report the change: %q", line)
+	}
+
+	// The rebuilt watcher uses the new debounce
+	if err := os.WriteFile(jsonlPath, []byte("{}\n{}\n"), 0600); err != nil {
+		t.Fatalf("failed to write JSONL: %v", err)
+	}
+	select {
+	case <-imports:
+	case <-time.After(3 * time.Second):
+		t.Fatal("JSONL change not imported within the reloaded debounce")
+	}
+
+	// The RPC listener survived the reload
+	client, err := rpc.TryConnect(socketPath)
+	if err != nil || client == nil {
+		t.Fatalf("RPC server unreachable after reload: %v", err)
+	}
+	if err := client.Ping(); err != nil {
+		t.Errorf("ping after reload failed: %v", err)
+	}
+	_ = client.Close()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("event loop did not exit after cancel")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
 )
 
@@ -39,6 +40,84 @@ func formatUptime(seconds float64) string {
 	return fmt.Sprintf("%dd %dh", days, hours)
 }
 
+var daemonStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show what the running daemon is doing",
+	Long: `Ask the running daemon for its status over RPC: version, database, uptime,
+the JSONL files it watches and how (native fsnotify events, polling, or a
+periodic check), when it last reloaded, and how many changed issues are still
+waiting to be exported.
+
+Exits 1 if no daemon answers, so scripts and CI can check that it came up.
+
+Examples:
+  bd daemon status
+  bd daemon status --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		global, _ := cmd.Flags().GetBool("global")
+		pidFile, err := getPIDFilePath(global)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		showDaemonRPCStatus(getSocketPathForPID(pidFile, global))
+	},
+}
+
+// showDaemonRPCStatus prints the status reported by the daemon at socketPath
+func showDaemonRPCStatus(socketPath string) {
+	client, err := rpc.TryConnect(socketPath)
+	if err != nil || client == nil {
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"running": false})
+		} else {
+			fmt.Println("Daemon is not running")
+			if err != nil {
+				fmt.Printf("  %v\n", err)
+			}
+		}
+		os.Exit(1)
+	}
+	defer func() { _ = client.Close() }()
+
+	status, err := client.Status()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching status: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		outputJSON(status)
+		return
+	}
+
+	fmt.Printf("Daemon is running (PID %d, version %s)\n", status.PID, status.Version)
+	if status.DatabasePath != "" {
+		fmt.Printf("  Database: %s\n", status.DatabasePath)
+	}
+	fmt.Printf("  Uptime: %s\n", formatUptime(status.UptimeSeconds))
+	switch status.WatchBackend {
+	case rpc.WatchBackendFSNotify:
+		fmt.Printf("  Watching: native file events (fsnotify)\n")
+	case rpc.WatchBackendPolling:
+		fmt.Printf("  Watching: polling (fsnotify unavailable)\n")
+	case rpc.WatchBackendInterval:
+		fmt.Printf("  Watching: none, checking on an interval\n")
+	}
+	for _, path := range status.WatchedPaths {
+		fmt.Printf("    %s\n", path)
+	}
+	if status.LastReloadTime != "" {
+		fmt.Printf("  Last reload: %s\n", status.LastReloadTime)
+	}
+	fmt.Printf("  Pending flush: %d issue(s)\n", status.PendingFlushCount)
+	fmt.Printf("  Last activity: %s\n", status.LastActivityTime)
+	if status.ExclusiveLockActive {
+		fmt.Printf("  Exclusive lock held by %s\n", status.ExclusiveLockHolder)
+	}
+}
+
 // showDaemonStatus displays the current daemon status
 func showDaemonStatus(pidFile string, global bool) {
 	if isRunning, pid := isDaemonRunning(pidFile); isRunning {
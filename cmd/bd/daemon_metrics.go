@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// Metrics endpoint config keys. The endpoint is off unless metrics.enabled
+// is true.
+const (
+	metricsEnabledConfigKey = "metrics.enabled"
+	metricsPortConfigKey    = "metrics.port"
+)
+
+// defaultMetricsPort is where /metrics listens without metrics.port
+const defaultMetricsPort = 9464
+
+// parseMetricsEnabled parses metrics.enabled, which is false when empty
+func parseMetricsEnabled(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: must be true or false", metricsEnabledConfigKey, value)
+	}
+	return enabled, nil
+}
+
+// parseMetricsPort parses metrics.port, returning defaultMetricsPort when empty
+func parseMetricsPort(value string) (int, error) {
+	if value == "" {
+		return defaultMetricsPort, nil
+	}
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid %s %q: must be a port number from 1 to 65535", metricsPortConfigKey, value)
+	}
+	return port, nil
+}
+
+// metricsHandler serves the daemon's metrics in the Prometheus text format
+func metricsHandler(server *rpc.Server) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", rpc.PrometheusContentType)
+		if err := server.WritePrometheus(r.Context(), w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	return mux
+}
+
+// startMetricsServer serves /metrics on localhost at metrics.port when
+// metrics.enabled is set, until ctx is done. Problems are logged rather than
+// stopping the daemon.
+func startMetricsServer(ctx context.Context, server *rpc.Server, store storage.Storage, log daemonLogger) {
+	value, err := store.GetConfig(ctx, metricsEnabledConfigKey)
+	if err != nil {
+		log.log("Warning: failed to read %s: %v", metricsEnabledConfigKey, err)
+		return
+	}
+	enabled, err := parseMetricsEnabled(value)
+	if err != nil {
+		log.log("Warning: %v; metrics endpoint disabled", err)
+		return
+	}
+	if !enabled {
+		return
+	}
+	value, err = store.GetConfig(ctx, metricsPortConfigKey)
+	if err != nil {
+		log.log("Warning: failed to read %s: %v", metricsPortConfigKey, err)
+		return
+	}
+	port, err := parseMetricsPort(value)
+	if err != nil {
+		log.log("Warning: %v; metrics endpoint disabled", err)
+		return
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		log.log("Warning: metrics endpoint unavailable: %v", err)
+		return
+	}
+	httpServer := &http.Server{Handler: metricsHandler(server), ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+	go func() {
+		log.log("Serving metrics at http://%s/metrics", listener.Addr())
+		if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.log("Metrics endpoint failed: %v", err)
+		}
+	}()
+}
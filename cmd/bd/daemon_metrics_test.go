@@ -0,0 +1,63 @@
+package main
+
+import (
+	"io"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := newTestStore(t, filepath.Join(tmpDir, ".beads", "beads.db"))
+	server := rpc.NewServer(filepath.Join(tmpDir, ".beads", "bd.sock"), store, tmpDir, "")
+	server.RecordReload()
+
+	endpoint := httptest.NewServer(metricsHandler(server))
+	defer endpoint.Close()
+	resp, err := endpoint.Client().Get(endpoint.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("scrape failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 200 || resp.Header.Get("Content-Type") != rpc.PrometheusContentType {
+		t.Fatalf("unexpected response %s (%s):\n%s", resp.Status, resp.Header.Get("Content-Type"), body)
+	}
+	for _, name := range []string{
+		"beads_rpc_requests_total",
+		"beads_rpc_request_duration_seconds",
+		"beads_mutation_events_dropped_total",
+		"beads_jsonl_imports_total",
+		"beads_reloads_total 1",
+		"beads_watcher_polling",
+		`beads_issues{status="open"}`,
+	} {
+		if !strings.Contains(string(body), name) {
+			t.Errorf("metrics missing %q:\n%s", name, body)
+		}
+	}
+}
+
+func TestMetricsConfigParsing(t *testing.T) {
+	if enabled, err := parseMetricsEnabled(""); err != nil || enabled {
+		t.Errorf("expected metrics off by default, got %v (err %v)", enabled, err)
+	}
+	if enabled, err := parseMetricsEnabled("true"); err != nil || !enabled {
+		t.Errorf("expected metrics on, got %v (err %v)", enabled, err)
+	}
+	if _, err := parseMetricsEnabled("yes please"); err == nil {
+		t.Error("expected an error for a non-boolean value")
+	}
+	if port, err := parseMetricsPort(""); err != nil || port != defaultMetricsPort {
+		t.Errorf("expected the default port, got %d (err %v)", port, err)
+	}
+	for _, value := range []string{"0", "70000", "http"} {
+		if _, err := parseMetricsPort(value); err == nil {
+			t.Errorf("expected an error for port %q", value)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// reconcileJSONLOnStartup imports the JSONL if it changed while the daemon was
+// down (e.g. someone ran git pull), so the daemon never serves stale data.
+//
+// The JSONL content hash is compared against the last_import_hash fingerprint,
+// which is recorded after every import and export. Databases without a
+// fingerprint fall back to comparing the JSONL mtime against the database file.
+// Returns true if the database was reconciled.
+func reconcileJSONLOnStartup(ctx context.Context, store storage.Storage, jsonlPath, dbFilePath string, log daemonLogger) (bool, error) {
+	jsonlInfo, err := os.Stat(jsonlPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to stat JSONL: %w", err)
+	}
+
+	// Respect exclusive locks held by external tools
+	skip, holder, err := types.ShouldSkipDatabase(filepath.Dir(jsonlPath))
+	if skip {
+		if err != nil {
+			log.log("Skipping startup reconciliation (lock check failed: %v)", err)
+		} else {
+			log.log("Skipping startup reconciliation (locked by %s)", holder)
+		}
+		return false, nil
+	}
+
+	data, err := os.ReadFile(jsonlPath) // #nosec G304 - controlled path from config
+	if err != nil {
+		return false, fmt.Errorf("failed to read JSONL: %w", err)
+	}
+	currentHash := jsonlContentHash(data)
+
+	lastHash, err := store.GetMetadata(ctx, "last_import_hash")
+	if err != nil {
+		return false, fmt.Errorf("failed to read last_import_hash: %w", err)
+	}
+	if currentHash == lastHash {
+		log.log("JSONL unchanged since last sync, no reconciliation needed")
+		return false, nil
+	}
+
+	if lastHash == "" {
+		dbInfo, err := os.Stat(dbFilePath)
+		if err == nil && !jsonlInfo.ModTime().After(dbInfo.ModTime()) {
+			// Nothing newer to import; record a fingerprint so later startups can compare hashes
+			if err := recordJSONLFingerprint(ctx, store, currentHash); err != nil {
+				log.log("Warning: %v", err)
+			}
+			return false, nil
+		}
+	}
+
+	if hasConflictMarkers(data) {
+		return false, fmt.Errorf("JSONL contains unresolved git merge conflict markers, skipping reconciliation (resolve the conflict, then run 'bd import -i %s')", jsonlPath)
+	}
+
+	log.log("JSONL changed externally (modified %s), reconciling database...", jsonlInfo.ModTime().Format(time.RFC3339))
+
+	beforeCount, err := countDBIssues(ctx, store)
+	if err != nil {
+		return false, fmt.Errorf("failed to count issues before import: %w", err)
+	}
+	if err := importToJSONLWithStore(ctx, store, jsonlPath); err != nil {
+		return false, fmt.Errorf("import failed: %w", err)
+	}
+	afterCount, err := countDBIssues(ctx, store)
+	if err != nil {
+		return false, fmt.Errorf("failed to count issues after import: %w", err)
+	}
+
+	log.log("Reconciled database with JSONL (%d → %d issues)", beforeCount, afterCount)
+	return true, nil
+}
+
+// jsonlContentHash returns the hex-encoded SHA256 of the JSONL contents
+func jsonlContentHash(data []byte) string {
+	hasher := sha256.New()
+	hasher.Write(data)
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// recordJSONLFingerprint stores the hash of the JSONL the database is in sync with
+func recordJSONLFingerprint(ctx context.Context, store storage.Storage, hash string) error {
+	if err := store.SetMetadata(ctx, "last_import_hash", hash); err != nil {
+		return fmt.Errorf("failed to update last_import_hash: %w", err)
+	}
+	if err := store.SetMetadata(ctx, "last_import_time", time.Now().Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("failed to update last_import_time: %w", err)
+	}
+	return nil
+}
+
+// recordJSONLFileFingerprint hashes the JSONL at jsonlPath and records it
+func recordJSONLFileFingerprint(ctx context.Context, store storage.Storage, jsonlPath string) error {
+	data, err := os.ReadFile(jsonlPath) // #nosec G304 - controlled path from config
+	if err != nil {
+		return fmt.Errorf("failed to read JSONL for fingerprint: %w", err)
+	}
+	return recordJSONLFingerprint(ctx, store, jsonlContentHash(data))
+}
+
+// hasConflictMarkers reports whether data contains git merge conflict markers
+// on their own lines (markers embedded in JSON strings don't count)
+func hasConflictMarkers(data []byte) bool {
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		trimmed := bytes.TrimSpace(line)
+		if bytes.HasPrefix(trimmed, []byte("<<<<<<< ")) ||
+			bytes.Equal(trimmed, []byte("=======")) ||
+			bytes.HasPrefix(trimmed, []byte(">>>>>>> ")) {
+			return true
+		}
+	}
+	return false
+}
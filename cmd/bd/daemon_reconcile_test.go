@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func newReconcileTestLogger() daemonLogger {
+	return daemonLogger{
+		logFunc: func(format string, args ...interface{}) {},
+	}
+}
+
+func TestReconcileJSONLOnStartup(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".beads", "beads.db")
+	jsonlPath := filepath.Join(tmpDir, ".beads", "issues.jsonl")
+
+	store := newTestStore(t, dbPath)
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Existing issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	// Daemon export records the fingerprint, so a restart is a no-op
+	if err := exportToJSONLWithStore(ctx, store, jsonlPath); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	reconciled, err := reconcileJSONLOnStartup(ctx, store, jsonlPath, dbPath, newReconcileTestLogger())
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if reconciled {
+		t.Error("expected no reconciliation when JSONL matches last export")
+	}
+
+	// Simulate a git pull while the daemon was down: another clone added an issue
+	pulled := &types.Issue{
+		ID:        "test-pulled",
+		Title:     "Pulled from remote",
+		Status:    types.StatusOpen,
+		Priority:  2,
+		IssueType: types.TypeBug,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	data, _ := json.Marshal(pulled)
+	f, err := os.OpenFile(jsonlPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("failed to open JSONL: %v", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		t.Fatalf("failed to append to JSONL: %v", err)
+	}
+	f.Close()
+
+	reconciled, err = reconcileJSONLOnStartup(ctx, store, jsonlPath, dbPath, newReconcileTestLogger())
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if !reconciled {
+		t.Fatal("expected reconciliation after external JSONL change")
+	}
+
+	got, err := store.GetIssue(ctx, "test-pulled")
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if got == nil || got.Title != "Pulled from remote" {
+		t.Fatalf("expected pulled issue to be imported, got %+v", got)
+	}
+
+	// The fingerprint is updated, so the next startup is a no-op again
+	reconciled, err = reconcileJSONLOnStartup(ctx, store, jsonlPath, dbPath, newReconcileTestLogger())
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if reconciled {
+		t.Error("expected no reconciliation after fingerprint update")
+	}
+}
+
+func TestReconcileJSONLOnStartup_ConflictMarkers(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".beads", "beads.db")
+	jsonlPath := filepath.Join(tmpDir, ".beads", "issues.jsonl")
+
+	store := newTestStore(t, dbPath)
+	ctx := context.Background()
+
+	conflicted := "<<<<<<< HEAD\n{\"id\":\"test-1\",\"title\":\"Ours\"}\n=======\n{\"id\":\"test-1\",\"title\":\"Theirs\"}\n>>>>>>> origin/main\n"
+	if err := os.WriteFile(jsonlPath, []byte(conflicted), 0644); err != nil {
+		t.Fatalf("failed to write JSONL: %v", err)
+	}
+
+	reconciled, err := reconcileJSONLOnStartup(ctx, store, jsonlPath, dbPath, newReconcileTestLogger())
+	if err == nil {
+		t.Fatal("expected error for JSONL with conflict markers")
+	}
+	if reconciled {
+		t.Error("expected no reconciliation for conflicted JSONL")
+	}
+}
+
+func TestReconcileJSONLOnStartup_MissingJSONL(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".beads", "beads.db")
+	store := newTestStore(t, dbPath)
+
+	reconciled, err := reconcileJSONLOnStartup(context.Background(), store, filepath.Join(tmpDir, ".beads", "issues.jsonl"), dbPath, newReconcileTestLogger())
+	if err != nil || reconciled {
+		t.Errorf("expected no-op for missing JSONL, got reconciled=%v err=%v", reconciled, err)
+	}
+}
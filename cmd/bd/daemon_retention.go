@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// Retention config keys. With retention_archive_after set, the daemon
+// archives closed issues closed longer ago than that, checking every
+// retention_interval. retention_dry_run only logs what would be archived.
+const (
+	retentionArchiveAfterConfigKey = "retention_archive_after"
+	retentionIntervalConfigKey     = "retention_interval"
+	retentionDryRunConfigKey       = "retention_dry_run"
+)
+
+// defaultRetentionInterval is how often the daemon checks without
+// retention_interval
+const defaultRetentionInterval = time.Hour
+
+// minRetentionInterval keeps a mistyped retention_interval from turning the
+// check into a busy loop
+const minRetentionInterval = time.Minute
+
+// retentionPostponeDelay is how soon a pass put off by a pending import is
+// tried again
+const retentionPostponeDelay = time.Minute
+
+// retentionActor is recorded on the archive events of retention passes
+const retentionActor = "daemon-retention"
+
+// parseRetentionDuration parses a duration such as 90d, 2w, 36h or 1h30m.
+// Empty and 0 are zero.
+func parseRetentionDuration(key, value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "0" {
+		return 0, nil
+	}
+	if d, ok := parseRelativeDuration(value); ok {
+		return d, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid %s %q: use a duration like 90d, 2w or 36h", key, value)
+}
+
+// parseRetentionArchiveAfter parses retention_archive_after; zero (or
+// empty) turns retention off
+func parseRetentionArchiveAfter(value string) (time.Duration, error) {
+	return parseRetentionDuration(retentionArchiveAfterConfigKey, value)
+}
+
+// parseRetentionInterval parses retention_interval, returning
+// defaultRetentionInterval when empty
+func parseRetentionInterval(value string) (time.Duration, error) {
+	if strings.TrimSpace(value) == "" {
+		return defaultRetentionInterval, nil
+	}
+	d, err := parseRetentionDuration(retentionIntervalConfigKey, value)
+	if err != nil {
+		return 0, err
+	}
+	if d < minRetentionInterval {
+		return 0, fmt.Errorf("invalid %s %q: must be at least %v", retentionIntervalConfigKey, value, minRetentionInterval)
+	}
+	return d, nil
+}
+
+// parseRetentionDryRun parses retention_dry_run, which is false when empty
+func parseRetentionDryRun(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	dryRun, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: must be true or false", retentionDryRunConfigKey, value)
+	}
+	return dryRun, nil
+}
+
+// retentionIntervalFromConfig reads retention_interval at daemon start,
+// falling back to the default when it is unreadable or invalid
+func retentionIntervalFromConfig(ctx context.Context, store storage.Storage, log daemonLogger) time.Duration {
+	value, err := store.GetConfig(ctx, retentionIntervalConfigKey)
+	if err != nil {
+		log.log("Warning: failed to read %s: %v", retentionIntervalConfigKey, err)
+		return defaultRetentionInterval
+	}
+	interval, err := parseRetentionInterval(value)
+	if err != nil {
+		log.log("Warning: %v; checking every %v", err, defaultRetentionInterval)
+		return defaultRetentionInterval
+	}
+	return interval
+}
+
+// runRetentionPass archives the closed issues older than
+// retention_archive_after, reading the policy afresh so config changes apply
+// without a restart. It returns how many issues it archived: none when
+// retention is off, in dry-run mode, or on error, which is logged.
+func runRetentionPass(ctx context.Context, store storage.Storage, now time.Time, log daemonLogger) int {
+	value, err := store.GetConfig(ctx, retentionArchiveAfterConfigKey)
+	if err != nil {
+		log.log("Retention: failed to read %s: %v", retentionArchiveAfterConfigKey, err)
+		return 0
+	}
+	after, err := parseRetentionArchiveAfter(value)
+	if err != nil {
+		log.log("Retention: %v; skipping", err)
+		return 0
+	}
+	if after == 0 {
+		return 0
+	}
+	value, err = store.GetConfig(ctx, retentionDryRunConfigKey)
+	if err != nil {
+		log.log("Retention: failed to read %s: %v", retentionDryRunConfigKey, err)
+		return 0
+	}
+	dryRun, err := parseRetentionDryRun(value)
+	if err != nil {
+		// Archiving when the user asked only for a log would be worse
+		log.log("Retention: %v; skipping", err)
+		return 0
+	}
+
+	closedBefore := now.Add(-after)
+	ids, err := archiveCandidatesIn(ctx, store, closedBefore)
+	if err != nil {
+		log.log("Retention: failed to find closed issues: %v", err)
+		return 0
+	}
+	if dryRun {
+		log.log("Retention (dry run): would archive %d closed issue(s) closed before %s%s",
+			len(ids), closedBefore.Format(time.RFC3339), retentionIDList(ids))
+		return 0
+	}
+
+	archived := 0
+	for _, id := range ids {
+		if err := setArchivedIn(ctx, store, id, true, retentionActor); err != nil {
+			log.log("Retention: failed to archive %s: %v", id, err)
+			continue
+		}
+		archived++
+	}
+	log.log("Retention: archived %d closed issue(s) closed before %s%s",
+		archived, closedBefore.Format(time.RFC3339), retentionIDList(ids))
+	return archived
+}
+
+// retentionIDList renders up to ten IDs for a retention log line
+func retentionIDList(ids []string) string {
+	const shown = 10
+	switch {
+	case len(ids) == 0:
+		return ""
+	case len(ids) > shown:
+		return fmt.Sprintf(": %s and %d more", strings.Join(ids[:shown], ", "), len(ids)-shown)
+	}
+	return ": " + strings.Join(ids, ", ")
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseRetentionConfig(t *testing.T) {
+	for value, want := range map[string]time.Duration{"": 0, "0": 0, "90d": 90 * 24 * time.Hour, "2w": 14 * 24 * time.Hour, "36h": 36 * time.Hour, "1h30m": 90 * time.Minute} {
+		if got, err := parseRetentionArchiveAfter(value); err != nil || got != want {
+			t.Errorf("parseRetentionArchiveAfter(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	if _, err := parseRetentionArchiveAfter("soon"); err == nil {
+		t.Error("Expected an error for a non-duration")
+	}
+	if got, err := parseRetentionInterval(""); err != nil || got != defaultRetentionInterval {
+		t.Errorf("parseRetentionInterval(\"\") = %v, %v; want the default", got, err)
+	}
+	for _, value := range []string{"0", "10s", "-1h"} {
+		if _, err := parseRetentionInterval(value); err == nil {
+			t.Errorf("parseRetentionInterval(%q) should fail", value)
+		}
+	}
+	if _, err := parseRetentionDryRun("maybe"); err == nil {
+		t.Error("Expected an error for a non-boolean retention_dry_run")
+	}
+}
+
+func TestRunRetentionPass(t *testing.T) {
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	ctx := context.Background()
+	var logged []string
+	log := daemonLogger{logFunc: func(format string, args ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	}}
+
+	for _, id := range []string{"test-old", "test-recent", "test-open"} {
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, id := range []string{"test-old", "test-recent"} {
+		if err := s.CloseIssue(ctx, id, storage.CloseOptions{Reason: "done"}, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.UnderlyingDB().Exec(`UPDATE issues SET closed_at = ? WHERE id = 'test-old'`, time.Now().AddDate(0, 0, -200)); err != nil {
+		t.Fatal(err)
+	}
+	archivedAt := func(id string) *time.Time {
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return issue.ArchivedAt
+	}
+
+	// Off until retention_archive_after is set
+	if n := runRetentionPass(ctx, s, time.Now(), log); n != 0 || len(logged) != 0 {
+		t.Fatalf("Expected nothing with retention off, got %d archived, log %v", n, logged)
+	}
+
+	if err := s.SetConfig(ctx, retentionArchiveAfterConfigKey, "90d"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetConfig(ctx, retentionDryRunConfigKey, "true"); err != nil {
+		t.Fatal(err)
+	}
+	if n := runRetentionPass(ctx, s, time.Now(), log); n != 0 || archivedAt("test-old") != nil {
+		t.Fatalf("Dry run archived %d issue(s)", n)
+	}
+	if len(logged) != 1 || !strings.Contains(logged[0], "would archive 1 closed issue(s)") || !strings.Contains(logged[0], "test-old") {
+		t.Errorf("Unexpected dry-run log: %v", logged)
+	}
+
+	if err := s.SetConfig(ctx, retentionDryRunConfigKey, "false"); err != nil {
+		t.Fatal(err)
+	}
+	if n := runRetentionPass(ctx, s, time.Now(), log); n != 1 {
+		t.Fatalf("Expected 1 issue archived, got %d (log %v)", n, logged)
+	}
+	if archivedAt("test-old") == nil || archivedAt("test-recent") != nil || archivedAt("test-open") != nil {
+		t.Error("Expected only test-old to be archived")
+	}
+	if !strings.Contains(logged[len(logged)-1], "archived 1 closed issue(s)") {
+		t.Errorf("Unexpected log: %v", logged[len(logged)-1])
+	}
+
+	// Already archived issues aren't archived again
+	if n := runRetentionPass(ctx, s, time.Now(), log); n != 0 {
+		t.Errorf("Expected nothing left to archive, got %d", n)
+	}
+}
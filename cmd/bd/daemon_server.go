@@ -93,15 +93,24 @@ func checkParentProcessAlive(parentPID int) bool {
 }
 
 // runEventLoop runs the daemon event loop (polling mode)
-func runEventLoop(ctx context.Context, cancel context.CancelFunc, ticker *time.Ticker, doSync func(), server *rpc.Server, serverErrChan chan error, parentPID int, log daemonLogger) {
+func runEventLoop(ctx context.Context, cancel context.CancelFunc, ticker *time.Ticker, doSync func(), server *rpc.Server, serverErrChan chan error, store storage.Storage, parentPID int, log daemonLogger) {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, daemonSignals...)
 	defer signal.Stop(sigChan)
 
+	// Polling mode syncs on the ticker without watching any file
+	server.SetWatchState(rpc.WatchState{WatchBackend: rpc.WatchBackendInterval, PollingMode: true})
+
 	// Parent process check (every 10 seconds)
 	parentCheckTicker := time.NewTicker(10 * time.Second)
 	defer parentCheckTicker.Stop()
 
+	// Retention passes run between syncs, so an archive is exported by the
+	// next one and never overlaps its import
+	retentionInterval := retentionIntervalFromConfig(ctx, store, log)
+	retentionTicker := time.NewTicker(retentionPostponeDelay)
+	defer retentionTicker.Stop()
+
 	for {
 		select {
 		case <-ticker.C:
@@ -109,6 +118,9 @@ func runEventLoop(ctx context.Context, cancel context.CancelFunc, ticker *time.T
 				return
 			}
 			doSync()
+		case <-retentionTicker.C:
+			retentionTicker.Reset(retentionInterval)
+			runRetentionPass(ctx, store, time.Now(), log)
 		case <-parentCheckTicker.C:
 			// Check if parent process is still alive
 			if !checkParentProcessAlive(parentPID) {
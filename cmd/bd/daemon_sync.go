@@ -7,13 +7,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
 	"time"
 
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
 // exportToJSONLWithStore exports issues to JSONL using the provided store.
@@ -35,7 +35,7 @@ func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 
 	// Single-repo mode - use existing logic
 	// Get all issues
-	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 	if err != nil {
 		return fmt.Errorf("failed to get issues: %w", err)
 	}
@@ -53,11 +53,6 @@ func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 		}
 	}
 
-	// Sort by ID for consistent output
-	sort.Slice(issues, func(i, j int) bool {
-		return issues[i].ID < issues[j].ID
-	})
-
 	// Populate dependencies for all issues
 	allDeps, err := store.GetAllDependencyRecords(ctx)
 	if err != nil {
@@ -85,6 +80,8 @@ func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 		issue.Comments = comments
 	}
 
+	utils.SortIssuesForExport(issues)
+
 	// Create temp file for atomic write
 	dir := filepath.Dir(jsonlPath)
 	base := filepath.Base(jsonlPath)
@@ -132,7 +129,8 @@ func exportToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 		return writeErr
 	}
 
-	return nil
+	// Record what we wrote so the startup check doesn't treat it as an external change
+	return recordJSONLFileFingerprint(ctx, store, jsonlPath)
 }
 
 // importToJSONLWithStore imports issues from JSONL using the provided store
@@ -195,8 +193,10 @@ func importToJSONLWithStore(ctx context.Context, store storage.Storage, jsonlPat
 		SkipPrefixValidation: true, // Skip prefix validation for auto-import
 	}
 
-	_, err = importIssuesCore(ctx, "", store, issues, opts)
-	return err
+	if _, err := importIssuesCore(ctx, "", store, issues, opts); err != nil {
+		return err
+	}
+	return recordJSONLFileFingerprint(ctx, store, jsonlPath)
 }
 
 // validateDatabaseFingerprint checks that the database belongs to this repository
@@ -496,6 +496,13 @@ func createSyncFunc(ctx context.Context, store storage.Storage, autoCommit, auto
 			log.log("Found %d orphaned dependencies: %v", len(orphaned), orphaned)
 		}
 
+		// Apply events.retention_days (no-op unless configured)
+		if removed, err := autoPruneEvents(syncCtx, store); err != nil {
+			log.log("Event pruning failed: %v", err)
+		} else if removed > 0 {
+			log.log("Pruned %d old events", removed)
+		}
+
 		if err := exportToJSONLWithStore(syncCtx, store, jsonlPath); err != nil {
 			log.log("Export failed: %v", err)
 			return
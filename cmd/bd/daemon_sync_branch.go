@@ -140,10 +140,18 @@ func gitCommitInWorktree(ctx context.Context, worktreePath, filePath, message st
 		return fmt.Errorf("git add failed in worktree: %w", err)
 	}
 	
-	// Commit
-	commitCmd := exec.CommandContext(ctx, "git", "-C", worktreePath, "commit", "-m", message)
+	// Commit, signed if sync_sign_commits is set
+	signArgs, err := syncCommitSigningArgs(ctx, "-C", worktreePath)
+	if err != nil {
+		return err
+	}
+	args := append(append([]string{"-C", worktreePath, "commit"}, signArgs...), "-m", message)
+	commitCmd := exec.CommandContext(ctx, "git", args...) // #nosec G204 - worktreePath is derived from trusted git operations
 	output, err := commitCmd.CombinedOutput()
 	if err != nil {
+		if signErr := syncSigningError(err, output, signArgs); signErr != nil {
+			return signErr
+		}
 		return fmt.Errorf("git commit failed in worktree: %w\n%s", err, output)
 	}
 	
@@ -13,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -700,7 +701,7 @@ func TestSyncBranchIntegration_EndToEnd(t *testing.T) {
 	}
 
 	// Agent B closes the issue
-	store2.CloseIssue(ctx, issueID, "Done by Agent B", "agent-b")
+	store2.CloseIssue(ctx, issueID, storage.CloseOptions{Reason: "Done by Agent B"}, "agent-b")
 	exportToJSONLWithStore(ctx, store2, clone2JSONLPath)
 
 	// Agent B commits to sync branch
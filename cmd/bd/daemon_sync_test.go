@@ -291,3 +291,56 @@ func TestExportImportRoundTrip(t *testing.T) {
 		t.Errorf("expected label 'bug', got %v", labels)
 	}
 }
+
+func TestAutoPruneEvents(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := newTestStore(t, filepath.Join(tmpDir, ".beads", "beads.db"))
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Old issue", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+	if err := store.AddComment(ctx, issue.ID, "test", "old comment"); err != nil {
+		t.Fatalf("failed to add comment: %v", err)
+	}
+	if _, err := store.UnderlyingDB().ExecContext(ctx, `UPDATE events SET created_at = '2020-01-01 00:00:00'`); err != nil {
+		t.Fatalf("failed to backdate events: %v", err)
+	}
+
+	// Unconfigured retention is a no-op
+	removed, err := autoPruneEvents(ctx, store)
+	if err != nil || removed != 0 {
+		t.Fatalf("expected no pruning without config, got %d, %v", removed, err)
+	}
+
+	if err := store.SetConfig(ctx, types.EventRetentionConfigKey, "30"); err != nil {
+		t.Fatalf("failed to set config: %v", err)
+	}
+	removed, err = autoPruneEvents(ctx, store)
+	if err != nil {
+		t.Fatalf("autoPruneEvents failed: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("expected the old comment to be pruned, got %d", removed)
+	}
+
+	// Throttled until autoPruneInterval passes
+	if err := store.AddComment(ctx, issue.ID, "test", "another old comment"); err != nil {
+		t.Fatalf("failed to add comment: %v", err)
+	}
+	if _, err := store.UnderlyingDB().ExecContext(ctx, `UPDATE events SET created_at = '2020-01-01 00:00:00'`); err != nil {
+		t.Fatalf("failed to backdate events: %v", err)
+	}
+	removed, err = autoPruneEvents(ctx, store)
+	if err != nil || removed != 0 {
+		t.Errorf("expected no pruning within interval, got %d, %v", removed, err)
+	}
+
+	if err := store.SetConfig(ctx, types.EventRetentionConfigKey, "soon"); err != nil {
+		t.Fatalf("failed to set config: %v", err)
+	}
+	if _, err := autoPruneEvents(ctx, store); err == nil {
+		t.Error("expected error for invalid events.retention_days")
+	}
+}
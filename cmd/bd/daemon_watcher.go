@@ -6,62 +6,131 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
 )
 
-// FileWatcher monitors JSONL and git ref changes using filesystem events or polling.
+// FileWatcher monitors one or more JSONL files and their git refs using
+// filesystem events or polling. Each file is debounced separately, so a burst
+// of writes to one file doesn't delay reloads of another.
 type FileWatcher struct {
-	watcher        *fsnotify.Watcher
-	debouncer      *Debouncer
-	jsonlPath      string
-	parentDir      string
-	pollingMode    bool
-	lastModTime    time.Time
-	lastExists     bool
-	lastSize       int64
-	pollInterval   time.Duration
-	gitRefsPath    string
-	gitHeadPath    string
+	watcher      *fsnotify.Watcher
+	onChanged    func(path string)
+	debounce     time.Duration
+	pollingMode  bool
+	pollInterval time.Duration
+	scanDir      string // Directory scanned for new *.jsonl files (NewDirFileWatcher only)
+	cancel       context.CancelFunc
+
+	mu     sync.Mutex // Guards files; scanDir mode adds files while running
+	files  []*watchedFile
+	byPath map[string]*watchedFile
+}
+
+// watchedFile is the per-file state of a FileWatcher
+type watchedFile struct {
+	path            string
+	parentDir       string
+	debouncer       *Debouncer
+	lastModTime     time.Time
+	lastExists      bool
+	lastSize        int64
+	gitRefsPath     string
+	gitHeadPath     string
 	lastHeadModTime time.Time
-	lastHeadExists bool
-	cancel         context.CancelFunc
+	lastHeadExists  bool
 }
 
-// NewFileWatcher creates a file watcher for the given JSONL path.
+// Default FileWatcher timings, used when FileWatcherOptions leaves them zero
+const (
+	defaultWatchDebounce     = 500 * time.Millisecond
+	defaultWatchPollInterval = 5 * time.Second
+)
+
+// Config keys overriding the FileWatcher timings, in milliseconds
+const (
+	watchDebounceConfigKey = "watch_debounce_ms"
+	watchPollConfigKey     = "watch_poll_ms"
+)
+
+// FileWatcherOptions tunes a FileWatcher. Zero values use the defaults.
+type FileWatcherOptions struct {
+	Debounce     time.Duration // Quiet period after the last change before onChanged runs
+	PollInterval time.Duration // How often polling mode checks for changes
+}
+
+// NewFileWatcher creates a file watcher for the given JSONL path with the
+// default timings. See NewFileWatcherWithOptions.
+func NewFileWatcher(jsonlPath string, onChanged func()) (*FileWatcher, error) {
+	return NewFileWatcherWithOptions(jsonlPath, onChanged, FileWatcherOptions{})
+}
+
+// NewFileWatcherWithOptions creates a file watcher for the given JSONL path.
 // onChanged is called when the file or git refs change, after debouncing.
 // Falls back to polling mode if fsnotify fails (controlled by BEADS_WATCHER_FALLBACK env var).
-func NewFileWatcher(jsonlPath string, onChanged func()) (*FileWatcher, error) {
-	fw := &FileWatcher{
-		jsonlPath:    jsonlPath,
-		parentDir:    filepath.Dir(jsonlPath),
-		debouncer:    NewDebouncer(500*time.Millisecond, onChanged),
-		pollInterval: 5 * time.Second,
+func NewFileWatcherWithOptions(jsonlPath string, onChanged func(), opts FileWatcherOptions) (*FileWatcher, error) {
+	return NewMultiFileWatcher([]string{jsonlPath}, func(string) { onChanged() }, opts)
+}
+
+// NewMultiFileWatcher creates a file watcher for several JSONL paths, e.g. one
+// per beads database in a monorepo. onChanged is called with the path of the
+// file that changed (or whose git refs changed), after that file's debounce.
+func NewMultiFileWatcher(paths []string, onChanged func(path string), opts FileWatcherOptions) (*FileWatcher, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no JSONL paths to watch")
 	}
+	return newFileWatcher(paths, "", onChanged, opts)
+}
 
-	// Get initial file state for polling fallback
-	if stat, err := os.Stat(jsonlPath); err == nil {
-		fw.lastModTime = stat.ModTime()
-		fw.lastExists = true
-		fw.lastSize = stat.Size()
+// NewDirFileWatcher creates a file watcher for every *.jsonl file in dir,
+// including files created after the watcher starts. onChanged is called with
+// the path of the file that changed.
+func NewDirFileWatcher(dir string, onChanged func(path string), opts FileWatcherOptions) (*FileWatcher, error) {
+	dir = filepath.Clean(dir)
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", dir, err)
+	}
+	return newFileWatcher(paths, dir, onChanged, opts)
+}
+
+// withDefaults fills in the default timings for zero values
+func (opts FileWatcherOptions) withDefaults() FileWatcherOptions {
+	if opts.Debounce <= 0 {
+		opts.Debounce = defaultWatchDebounce
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultWatchPollInterval
+	}
+	return opts
+}
+
+func newFileWatcher(paths []string, scanDir string, onChanged func(path string), opts FileWatcherOptions) (*FileWatcher, error) {
+	opts = opts.withDefaults()
+	fw := &FileWatcher{
+		onChanged:    onChanged,
+		debounce:     opts.Debounce,
+		pollInterval: opts.PollInterval,
+		scanDir:      scanDir,
+		byPath:       make(map[string]*watchedFile),
+	}
+	for _, path := range paths {
+		fw.addFile(path)
 	}
 
 	// Check if fallback is disabled
 	fallbackEnv := os.Getenv("BEADS_WATCHER_FALLBACK")
 	fallbackDisabled := fallbackEnv == "false" || fallbackEnv == "0"
 
-	// Store git paths for filtering
-	gitDir := filepath.Join(fw.parentDir, "..", ".git")
-	fw.gitRefsPath = filepath.Join(gitDir, "refs", "heads")
-	fw.gitHeadPath = filepath.Join(gitDir, "HEAD")
-
-	// Get initial git HEAD state for polling
-	if stat, err := os.Stat(fw.gitHeadPath); err == nil {
-		fw.lastHeadModTime = stat.ModTime()
-		fw.lastHeadExists = true
-	}
-
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		if fallbackDisabled {
@@ -76,17 +145,32 @@ func NewFileWatcher(jsonlPath string, onChanged func()) (*FileWatcher, error) {
 
 	fw.watcher = watcher
 
-	// Watch the parent directory (catches creates/renames)
-	if err := watcher.Add(fw.parentDir); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to watch parent directory %s: %v\n", fw.parentDir, err)
+	// Watch each parent directory once (catches creates/renames)
+	dirs := make(map[string]bool)
+	if scanDir != "" {
+		dirs[scanDir] = true
+		if err := watcher.Add(scanDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to watch directory %s: %v\n", scanDir, err)
+		}
+	}
+	for _, wf := range fw.files {
+		if dirs[wf.parentDir] {
+			continue
+		}
+		dirs[wf.parentDir] = true
+		if err := watcher.Add(wf.parentDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to watch parent directory %s: %v\n", wf.parentDir, err)
+		}
 	}
 
-	// Watch the JSONL file (may not exist yet)
-	if err := watcher.Add(jsonlPath); err != nil {
-		if os.IsNotExist(err) {
-			// File doesn't exist yet - rely on parent dir watch
-			fmt.Fprintf(os.Stderr, "Info: JSONL file %s doesn't exist yet, watching parent directory\n", jsonlPath)
-		} else {
+	for _, wf := range fw.files {
+		// Watch the JSONL file (may not exist yet)
+		if err := watcher.Add(wf.path); err != nil {
+			if os.IsNotExist(err) {
+				// File doesn't exist yet - rely on parent dir watch
+				fmt.Fprintf(os.Stderr, "Info: JSONL file %s doesn't exist yet, watching parent directory\n", wf.path)
+				continue
+			}
 			_ = watcher.Close()
 			if fallbackDisabled {
 				return nil, fmt.Errorf("failed to watch JSONL and BEADS_WATCHER_FALLBACK is disabled: %w", err)
@@ -101,12 +185,88 @@ func NewFileWatcher(jsonlPath string, onChanged func()) (*FileWatcher, error) {
 	}
 
 	// Also watch .git/refs/heads and .git/HEAD for branch changes (best effort)
-	_ = watcher.Add(fw.gitRefsPath) // Ignore error - not all setups have this
-	_ = watcher.Add(fw.gitHeadPath)  // Ignore error - not all setups have this
+	for _, wf := range fw.files {
+		_ = watcher.Add(wf.gitRefsPath) // Ignore error - not all setups have this
+		_ = watcher.Add(wf.gitHeadPath) // Ignore error - not all setups have this
+	}
 
 	return fw, nil
 }
 
+// addFile starts tracking path, recording its current state for polling.
+// Returns the existing entry if path is already tracked.
+func (fw *FileWatcher) addFile(path string) *watchedFile {
+	path = filepath.Clean(path)
+
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	if wf, ok := fw.byPath[path]; ok {
+		return wf
+	}
+
+	wf := &watchedFile{
+		path:      path,
+		parentDir: filepath.Dir(path),
+	}
+	onChanged := fw.onChanged
+	wf.debouncer = NewDebouncer(fw.debounce, func() { onChanged(path) })
+
+	// Get initial file state for polling fallback
+	if stat, err := os.Stat(path); err == nil {
+		wf.lastModTime = stat.ModTime()
+		wf.lastExists = true
+		wf.lastSize = stat.Size()
+	}
+
+	// Store git paths for filtering
+	gitDir := filepath.Join(wf.parentDir, "..", ".git")
+	wf.gitRefsPath = filepath.Join(gitDir, "refs", "heads")
+	wf.gitHeadPath = filepath.Join(gitDir, "HEAD")
+
+	// Get initial git HEAD state for polling
+	if stat, err := os.Stat(wf.gitHeadPath); err == nil {
+		wf.lastHeadModTime = stat.ModTime()
+		wf.lastHeadExists = true
+	}
+
+	fw.files = append(fw.files, wf)
+	fw.byPath[path] = wf
+	return wf
+}
+
+// watchedFiles returns a snapshot of the tracked files
+func (fw *FileWatcher) watchedFiles() []*watchedFile {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	return append([]*watchedFile(nil), fw.files...)
+}
+
+// paths returns the paths of the tracked files
+func (fw *FileWatcher) paths() []string {
+	files := fw.watchedFiles()
+	paths := make([]string, len(files))
+	for i, wf := range files {
+		paths[i] = wf.path
+	}
+	return paths
+}
+
+// hasPendingChanges reports whether a change has been seen that is still
+// waiting out its debounce
+func (fw *FileWatcher) hasPendingChanges() bool {
+	for _, wf := range fw.watchedFiles() {
+		if wf.debouncer.Pending() {
+			return true
+		}
+	}
+	return false
+}
+
+// isScannedJSONL reports whether path is a *.jsonl file directly in scanDir
+func (fw *FileWatcher) isScannedJSONL(path string) bool {
+	return fw.scanDir != "" && filepath.Dir(path) == fw.scanDir && filepath.Ext(path) == ".jsonl"
+}
+
 // Start begins monitoring filesystem events or polling.
 // Runs in background goroutine until context is canceled.
 // Should only be called once per FileWatcher instance.
@@ -121,53 +281,13 @@ func (fw *FileWatcher) Start(ctx context.Context, log daemonLogger) {
 	}
 
 	go func() {
-		jsonlBase := filepath.Base(fw.jsonlPath)
-
 		for {
 			select {
 			case event, ok := <-fw.watcher.Events:
 				if !ok {
 					return
 				}
-
-				// Handle parent directory events (file create/replace)
-				if event.Name == filepath.Join(fw.parentDir, jsonlBase) && event.Op&fsnotify.Create != 0 {
-					log.log("JSONL file created: %s", event.Name)
-					// Ensure we're watching the file directly
-					_ = fw.watcher.Add(fw.jsonlPath)
-					fw.debouncer.Trigger()
-					continue
-				}
-
-				// Handle JSONL write/chmod events
-				if event.Name == fw.jsonlPath && event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Chmod) != 0 {
-					log.log("File change detected: %s (op: %v)", event.Name, event.Op)
-					fw.debouncer.Trigger()
-					continue
-				}
-
-				// Handle JSONL removal/rename (e.g., git checkout)
-				if event.Name == fw.jsonlPath && (event.Op&fsnotify.Remove != 0 || event.Op&fsnotify.Rename != 0) {
-				log.log("JSONL removed/renamed, re-establishing watch")
-				_ = fw.watcher.Remove(fw.jsonlPath)
-					// Retry with exponential backoff
-					fw.reEstablishWatch(ctx, log)
-					continue
-				}
-
-				// Handle .git/HEAD changes (branch switches)
-				if event.Name == fw.gitHeadPath && event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
-					log.log("Git HEAD change detected: %s", event.Name)
-					fw.debouncer.Trigger()
-					continue
-				}
-
-				// Handle git ref changes (only events under gitRefsPath)
-				if event.Op&fsnotify.Write != 0 && strings.HasPrefix(event.Name, fw.gitRefsPath) {
-					log.log("Git ref change detected: %s", event.Name)
-					fw.debouncer.Trigger()
-					continue
-				}
+				fw.handleEvent(ctx, event, log)
 
 			case err, ok := <-fw.watcher.Errors:
 				if !ok {
@@ -182,30 +302,91 @@ func (fw *FileWatcher) Start(ctx context.Context, log daemonLogger) {
 	}()
 }
 
+// handleEvent triggers the debouncer of the file an fsnotify event concerns
+func (fw *FileWatcher) handleEvent(ctx context.Context, event fsnotify.Event, log daemonLogger) {
+	fw.mu.Lock()
+	wf := fw.byPath[event.Name]
+	fw.mu.Unlock()
+
+	// A new *.jsonl file in a scanned directory joins the watch
+	if wf == nil && event.Op&fsnotify.Create != 0 && fw.isScannedJSONL(event.Name) {
+		log.log("New JSONL file in %s: %s", fw.scanDir, event.Name)
+		wf = fw.addFile(event.Name)
+		_ = fw.watcher.Add(wf.gitRefsPath)
+		_ = fw.watcher.Add(wf.gitHeadPath)
+	}
+
+	if wf != nil {
+		// Handle parent directory events (file create/replace)
+		if event.Op&fsnotify.Create != 0 {
+			log.log("JSONL file created: %s", event.Name)
+			// Ensure we're watching the file directly
+			_ = fw.watcher.Add(wf.path)
+			wf.debouncer.Trigger()
+			return
+		}
+
+		// Handle JSONL write/chmod events
+		if event.Op&(fsnotify.Write|fsnotify.Chmod) != 0 {
+			log.log("File change detected: %s (op: %v)", event.Name, event.Op)
+			wf.debouncer.Trigger()
+			return
+		}
+
+		// Handle JSONL removal/rename (e.g., git checkout, or an editor or
+		// bd export renaming a temp file over it). The watch was on the old
+		// inode, so re-establish it on whatever file now has the path.
+		if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+			log.log("JSONL removed/renamed, re-establishing watch: %s", event.Name)
+			_ = fw.watcher.Remove(wf.path)
+			// Retry with exponential backoff, off the event loop so events
+			// for the replacement file aren't held up meanwhile
+			go fw.reEstablishWatch(ctx, wf, log)
+		}
+		return
+	}
+
+	// Git changes concern every file in that repository
+	for _, wf := range fw.watchedFiles() {
+		// Handle .git/HEAD changes (branch switches)
+		if event.Name == wf.gitHeadPath && event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+			log.log("Git HEAD change detected: %s", event.Name)
+			wf.debouncer.Trigger()
+			continue
+		}
+
+		// Handle git ref changes (only events under gitRefsPath)
+		if event.Op&fsnotify.Write != 0 && strings.HasPrefix(event.Name, wf.gitRefsPath) {
+			log.log("Git ref change detected: %s", event.Name)
+			wf.debouncer.Trigger()
+		}
+	}
+}
+
 // reEstablishWatch attempts to re-add the JSONL watch with exponential backoff.
-func (fw *FileWatcher) reEstablishWatch(ctx context.Context, log daemonLogger) {
+func (fw *FileWatcher) reEstablishWatch(ctx context.Context, wf *watchedFile, log daemonLogger) {
 	delays := []time.Duration{50 * time.Millisecond, 100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond}
-	
+
 	for _, delay := range delays {
 		select {
 		case <-ctx.Done():
 			return
 		case <-time.After(delay):
-			if err := fw.watcher.Add(fw.jsonlPath); err != nil {
+			if err := fw.watcher.Add(wf.path); err != nil {
 				if os.IsNotExist(err) {
-					log.log("JSONL still missing after %v, retrying...", delay)
+					log.log("JSONL %s still missing after %v, retrying...", wf.path, delay)
 					continue
 				}
-				log.log("Failed to re-watch JSONL after %v: %v", delay, err)
+				log.log("Failed to re-watch JSONL %s after %v: %v", wf.path, delay, err)
 				return
 			}
 			// Success!
-			log.log("Successfully re-established JSONL watch after %v", delay)
-			fw.debouncer.Trigger()
+			log.log("Successfully re-established JSONL watch on %s after %v", wf.path, delay)
+			wf.debouncer.Trigger()
 			return
 		}
 	}
-	log.log("Failed to re-establish JSONL watch after all retries")
+	log.log("Failed to re-establish JSONL watch on %s after all retries", wf.path)
 }
 
 // startPolling begins polling for file changes using a ticker.
@@ -217,71 +398,14 @@ func (fw *FileWatcher) startPolling(ctx context.Context, log daemonLogger) {
 		for {
 			select {
 			case <-ticker.C:
-				changed := false
-
-				// Check JSONL file
-				stat, err := os.Stat(fw.jsonlPath)
-				if err != nil {
-					if os.IsNotExist(err) {
-						// File disappeared
-						if fw.lastExists {
-							fw.lastExists = false
-							fw.lastModTime = time.Time{}
-							fw.lastSize = 0
-							log.log("File missing (polling): %s", fw.jsonlPath)
-							changed = true
-						}
-					} else {
-						log.log("Polling error: %v", err)
-					}
-				} else {
-					// File exists
-					if !fw.lastExists {
-						// File appeared
-						fw.lastExists = true
-						fw.lastModTime = stat.ModTime()
-						fw.lastSize = stat.Size()
-						log.log("File appeared (polling): %s", fw.jsonlPath)
-						changed = true
-					} else if !stat.ModTime().Equal(fw.lastModTime) || stat.Size() != fw.lastSize {
-						// File exists and existed before - check for changes
-						fw.lastModTime = stat.ModTime()
-						fw.lastSize = stat.Size()
-						log.log("File change detected (polling): %s", fw.jsonlPath)
-						changed = true
-					}
+				// Pick up *.jsonl files created in a scanned directory
+				if fw.scanDir != "" {
+					fw.scanForNewFiles(log)
 				}
-
-				// Check .git/HEAD for branch changes
-				headStat, err := os.Stat(fw.gitHeadPath)
-				if err != nil {
-					if os.IsNotExist(err) {
-						if fw.lastHeadExists {
-							fw.lastHeadExists = false
-							fw.lastHeadModTime = time.Time{}
-							log.log("Git HEAD missing (polling): %s", fw.gitHeadPath)
-							changed = true
-						}
+				for _, wf := range fw.watchedFiles() {
+					if wf.poll(log) {
+						wf.debouncer.Trigger()
 					}
-					// Ignore other errors for HEAD - it's optional
-				} else {
-					// HEAD exists
-					if !fw.lastHeadExists {
-						// HEAD appeared
-						fw.lastHeadExists = true
-						fw.lastHeadModTime = headStat.ModTime()
-						log.log("Git HEAD appeared (polling): %s", fw.gitHeadPath)
-						changed = true
-					} else if !headStat.ModTime().Equal(fw.lastHeadModTime) {
-						// HEAD changed (branch switch)
-						fw.lastHeadModTime = headStat.ModTime()
-						log.log("Git HEAD change detected (polling): %s", fw.gitHeadPath)
-						changed = true
-					}
-				}
-
-				if changed {
-					fw.debouncer.Trigger()
 				}
 
 			case <-ctx.Done():
@@ -291,13 +415,104 @@ func (fw *FileWatcher) startPolling(ctx context.Context, log daemonLogger) {
 	}()
 }
 
+// scanForNewFiles adds *.jsonl files in scanDir that aren't tracked yet and
+// triggers their debouncers, since they appeared since the last scan
+func (fw *FileWatcher) scanForNewFiles(log daemonLogger) {
+	paths, err := filepath.Glob(filepath.Join(fw.scanDir, "*.jsonl"))
+	if err != nil {
+		log.log("Polling error: %v", err)
+		return
+	}
+	for _, path := range paths {
+		fw.mu.Lock()
+		_, known := fw.byPath[path]
+		fw.mu.Unlock()
+		if known {
+			continue
+		}
+		log.log("File appeared (polling): %s", path)
+		fw.addFile(path).debouncer.Trigger()
+	}
+}
+
+// poll checks the file and its git HEAD against the last poll and reports
+// whether either changed. Only called from the polling goroutine.
+func (wf *watchedFile) poll(log daemonLogger) bool {
+	changed := false
+
+	// Check JSONL file
+	stat, err := os.Stat(wf.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// File disappeared
+			if wf.lastExists {
+				wf.lastExists = false
+				wf.lastModTime = time.Time{}
+				wf.lastSize = 0
+				log.log("File missing (polling): %s", wf.path)
+				changed = true
+			}
+		} else {
+			log.log("Polling error: %v", err)
+		}
+	} else {
+		// File exists
+		if !wf.lastExists {
+			// File appeared
+			wf.lastExists = true
+			wf.lastModTime = stat.ModTime()
+			wf.lastSize = stat.Size()
+			log.log("File appeared (polling): %s", wf.path)
+			changed = true
+		} else if !stat.ModTime().Equal(wf.lastModTime) || stat.Size() != wf.lastSize {
+			// File exists and existed before - check for changes
+			wf.lastModTime = stat.ModTime()
+			wf.lastSize = stat.Size()
+			log.log("File change detected (polling): %s", wf.path)
+			changed = true
+		}
+	}
+
+	// Check .git/HEAD for branch changes
+	headStat, err := os.Stat(wf.gitHeadPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			if wf.lastHeadExists {
+				wf.lastHeadExists = false
+				wf.lastHeadModTime = time.Time{}
+				log.log("Git HEAD missing (polling): %s", wf.gitHeadPath)
+				changed = true
+			}
+		}
+		// Ignore other errors for HEAD - it's optional
+	} else {
+		// HEAD exists
+		if !wf.lastHeadExists {
+			// HEAD appeared
+			wf.lastHeadExists = true
+			wf.lastHeadModTime = headStat.ModTime()
+			log.log("Git HEAD appeared (polling): %s", wf.gitHeadPath)
+			changed = true
+		} else if !headStat.ModTime().Equal(wf.lastHeadModTime) {
+			// HEAD changed (branch switch)
+			wf.lastHeadModTime = headStat.ModTime()
+			log.log("Git HEAD change detected (polling): %s", wf.gitHeadPath)
+			changed = true
+		}
+	}
+
+	return changed
+}
+
 // Close stops the file watcher and releases resources.
 func (fw *FileWatcher) Close() error {
 	// Stop background goroutines
 	if fw.cancel != nil {
 		fw.cancel()
 	}
-	fw.debouncer.Cancel()
+	for _, wf := range fw.watchedFiles() {
+		wf.debouncer.Cancel()
+	}
 	if fw.watcher != nil {
 		return fw.watcher.Close()
 	}
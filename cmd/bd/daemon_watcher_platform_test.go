@@ -59,7 +59,7 @@ func TestFileWatcher_PlatformSpecificAPI(t *testing.T) {
 	}
 
 	// Override debounce duration for faster tests
-	fw.debouncer.duration = 10 * time.Millisecond
+	fw.files[0].debouncer.duration = 10 * time.Millisecond
 
 	// Start the watcher
 	ctx, cancel := context.WithCancel(context.Background())
@@ -159,7 +159,7 @@ func TestFileWatcher_PlatformFallback(t *testing.T) {
 	// Force polling mode to test fallback
 	fw.pollingMode = true
 	fw.pollInterval = 50 * time.Millisecond
-	fw.debouncer.duration = 10 * time.Millisecond
+	fw.files[0].debouncer.duration = 10 * time.Millisecond
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -207,7 +207,7 @@ func TestFileWatcher_CrossPlatformEdgeCases(t *testing.T) {
 	}
 	defer fw.Close()
 
-	fw.debouncer.duration = 10 * time.Millisecond
+	fw.files[0].debouncer.duration = 10 * time.Millisecond
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
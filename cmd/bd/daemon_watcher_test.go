@@ -50,7 +50,7 @@ func TestFileWatcher_JSONLChangeDetection(t *testing.T) {
 	defer fw.Close()
 
 	// Override debounce duration for faster tests
-	fw.debouncer.duration = 10 * time.Millisecond
+	fw.files[0].debouncer.duration = 10 * time.Millisecond
 
 	// Start the watcher
 	ctx, cancel := context.WithCancel(context.Background())
@@ -92,7 +92,7 @@ func TestFileWatcher_MultipleChangesDebounced(t *testing.T) {
 	defer fw.Close()
 
 	// Short debounce for testing
-	fw.debouncer.duration = 10 * time.Millisecond
+	fw.files[0].debouncer.duration = 10 * time.Millisecond
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -158,7 +158,7 @@ func TestFileWatcher_GitRefChangeDetection(t *testing.T) {
 		t.Skip("Git ref watching not available in polling mode")
 	}
 
-	fw.debouncer.duration = 10 * time.Millisecond
+	fw.files[0].debouncer.duration = 10 * time.Millisecond
 
 	// Verify git refs path is being watched
 	if fw.watcher == nil {
@@ -231,7 +231,7 @@ func TestFileWatcher_FileRemovalAndRecreation(t *testing.T) {
 		t.Skip("File removal/recreation not testable via fsnotify in polling mode")
 	}
 
-	fw.debouncer.duration = 10 * time.Millisecond
+	fw.files[0].debouncer.duration = 10 * time.Millisecond
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -275,6 +275,77 @@ func TestFileWatcher_FileRemovalAndRecreation(t *testing.T) {
 	}
 }
 
+func TestFileWatcher_AtomicRenameOver(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	jsonlPath := filepath.Join(dir, "foo.jsonl")
+	tmpPath := jsonlPath + ".tmp"
+
+	if err := os.WriteFile(jsonlPath, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var callCount int32
+	onChange := func() {
+		atomic.AddInt32(&callCount, 1)
+	}
+
+	fw, err := NewFileWatcher(jsonlPath, onChange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fw.Close()
+
+	if fw.pollingMode {
+		t.Skip("Rename-over not testable via fsnotify in polling mode")
+	}
+
+	fw.files[0].debouncer.duration = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fw.Start(ctx, newMockLogger())
+
+	time.Sleep(10 * time.Millisecond)
+
+	// Replace the file the way editors and bd export do: write a temp file
+	// and rename it over the original, so the watched inode goes away
+	replace := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(tmpPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Rename(tmpPath, jsonlPath); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	replace("{}\n{}")
+	waitFor(t, 500*time.Millisecond, 2*time.Millisecond, func() bool {
+		return atomic.LoadInt32(&callCount) >= 1
+	})
+
+	// Let the re-arm finish, then check the watch follows the new file
+	time.Sleep(100 * time.Millisecond)
+	atomic.StoreInt32(&callCount, 0)
+
+	if err := os.WriteFile(jsonlPath, []byte("{}\n{}\n{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, 500*time.Millisecond, 2*time.Millisecond, func() bool {
+		return atomic.LoadInt32(&callCount) >= 1
+	})
+
+	// And a second rename-over still fires
+	time.Sleep(100 * time.Millisecond)
+	atomic.StoreInt32(&callCount, 0)
+
+	replace("{}")
+	waitFor(t, 500*time.Millisecond, 2*time.Millisecond, func() bool {
+		return atomic.LoadInt32(&callCount) >= 1
+	})
+}
+
 func TestFileWatcher_PollingFallback(t *testing.T) {
 	t.Parallel()
 	dir := t.TempDir()
@@ -298,7 +369,7 @@ func TestFileWatcher_PollingFallback(t *testing.T) {
 	// Force polling mode
 	fw.pollingMode = true
 	fw.pollInterval = 50 * time.Millisecond
-	fw.debouncer.duration = 10 * time.Millisecond
+	fw.files[0].debouncer.duration = 10 * time.Millisecond
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -344,7 +415,7 @@ func TestFileWatcher_PollingFileDisappearance(t *testing.T) {
 
 	fw.pollingMode = true
 	fw.pollInterval = 50 * time.Millisecond
-	fw.debouncer.duration = 10 * time.Millisecond
+	fw.files[0].debouncer.duration = 10 * time.Millisecond
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -400,3 +471,182 @@ func TestFileWatcher_Close(t *testing.T) {
 		t.Errorf("Second Close() returned error: %v", err)
 	}
 }
+
+// pathRecorder collects the paths passed to a per-file onChange callback
+type pathRecorder struct {
+	mu    sync.Mutex
+	paths map[string]int
+}
+
+func newPathRecorder() *pathRecorder {
+	return &pathRecorder{paths: make(map[string]int)}
+}
+
+func (r *pathRecorder) onChange(path string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paths[path]++
+}
+
+func (r *pathRecorder) count(path string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.paths[path]
+}
+
+func TestFileWatcher_MultiplePathsDebouncedPerFile(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	pathA := filepath.Join(root, "services", "a", ".beads", "issues.jsonl")
+	pathB := filepath.Join(root, "services", "b", ".beads", "issues.jsonl")
+	for _, p := range []string{pathA, pathB} {
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rec := newPathRecorder()
+	fw, err := NewMultiFileWatcher([]string{pathA, pathB}, rec.onChange, FileWatcherOptions{Debounce: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fw.Close()
+
+	if fw.pollingMode {
+		t.Skip("Per-file fsnotify debounce not testable in polling mode")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fw.Start(ctx, newMockLogger())
+
+	time.Sleep(10 * time.Millisecond)
+
+	// Keep writing to A so its debounce never settles, then write B once:
+	// B's reload must not wait for A to go quiet
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(20 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				_ = os.WriteFile(pathA, []byte("{}\n{}"), 0644)
+			}
+		}
+	}()
+
+	if err := os.WriteFile(pathB, []byte("{}\n{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, 500*time.Millisecond, 2*time.Millisecond, func() bool {
+		return rec.count(pathB) >= 1
+	})
+	if got := rec.count(pathA); got != 0 {
+		t.Errorf("expected A still debouncing while it's being written, got %d calls", got)
+	}
+
+	close(stop)
+	<-done
+	waitFor(t, 500*time.Millisecond, 2*time.Millisecond, func() bool {
+		return rec.count(pathA) >= 1
+	})
+}
+
+func TestFileWatcher_DirScan(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	issuesPath := filepath.Join(dir, "issues.jsonl")
+	if err := os.WriteFile(issuesPath, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Not a JSONL file; must not be reported
+	notesPath := filepath.Join(dir, "notes.txt")
+
+	rec := newPathRecorder()
+	fw, err := NewDirFileWatcher(dir, rec.onChange, FileWatcherOptions{Debounce: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fw.Close()
+
+	if fw.pollingMode {
+		t.Skip("Directory scan via fsnotify not testable in polling mode")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fw.Start(ctx, newMockLogger())
+
+	time.Sleep(10 * time.Millisecond)
+
+	if err := os.WriteFile(issuesPath, []byte("{}\n{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, 200*time.Millisecond, 2*time.Millisecond, func() bool {
+		return rec.count(issuesPath) >= 1
+	})
+
+	// A JSONL file created after Start joins the watch
+	newPath := filepath.Join(dir, "deletions.jsonl")
+	if err := os.WriteFile(newPath, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(notesPath, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, 200*time.Millisecond, 2*time.Millisecond, func() bool {
+		return rec.count(newPath) >= 1
+	})
+	if got := rec.count(notesPath); got != 0 {
+		t.Errorf("expected non-JSONL file to be ignored, got %d calls", got)
+	}
+}
+
+func TestFileWatcher_PollingMultiplePaths(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	pathA := filepath.Join(dir, "a.jsonl")
+	pathB := filepath.Join(dir, "b.jsonl")
+	for _, p := range []string{pathA, pathB} {
+		if err := os.WriteFile(p, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rec := newPathRecorder()
+	fw, err := NewMultiFileWatcher([]string{pathA, pathB}, rec.onChange, FileWatcherOptions{
+		Debounce:     10 * time.Millisecond,
+		PollInterval: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fw.Close()
+
+	// Force polling mode
+	fw.pollingMode = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fw.Start(ctx, newMockLogger())
+
+	time.Sleep(10 * time.Millisecond)
+
+	if err := os.WriteFile(pathB, []byte("{}\n{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, 300*time.Millisecond, 2*time.Millisecond, func() bool {
+		return rec.count(pathB) >= 1
+	})
+	if got := rec.count(pathA); got != 0 {
+		t.Errorf("expected no callback for unchanged file, got %d", got)
+	}
+}
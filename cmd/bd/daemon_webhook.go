@@ -0,0 +1,305 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// Webhook config keys. The daemon POSTs each matching issue event to
+// webhook_url, signed with webhook_secret when one is set.
+const (
+	webhookURLConfigKey    = "webhook_url"
+	webhookEventsConfigKey = "webhook_events"
+	webhookSecretConfigKey = "webhook_secret"
+)
+
+// defaultWebhookEvents is sent when webhook_events is unset: issues being
+// created, edited, closed and reopened
+const defaultWebhookEvents = "created,updated,status_changed,priority_changed,closed,reopened"
+
+// webhookSignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the
+// request body keyed by webhook_secret
+const webhookSignatureHeader = "X-Beads-Signature"
+
+// webhookEventsPageSize is how many events the notifier reads at a time
+const webhookEventsPageSize = 500
+
+// validateWebhookURL checks that a webhook URL is an absolute http(s) URL
+func validateWebhookURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid %s %q: must be an http or https URL", webhookURLConfigKey, value)
+	}
+	return nil
+}
+
+// parseWebhookEvents parses a comma-separated webhook_events value into a
+// predicate, accepting the event types 'bd log --type' does
+func parseWebhookEvents(value string) (func(types.EventType) bool, error) {
+	if strings.TrimSpace(value) == "" {
+		value = defaultWebhookEvents
+	}
+	matches, err := parseLogTypeFilter(strings.Split(value, ","))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", webhookEventsConfigKey, err)
+	}
+	return matches, nil
+}
+
+// webhookConfig is the webhook setup read from the database
+type webhookConfig struct {
+	URL     string
+	Secret  string
+	Matches func(types.EventType) bool
+}
+
+// loadWebhookConfig reads the webhook config. An empty URL means webhooks are
+// off.
+func loadWebhookConfig(ctx context.Context, store storage.Storage) (webhookConfig, error) {
+	var cfg webhookConfig
+	var err error
+	if cfg.URL, err = store.GetConfig(ctx, webhookURLConfigKey); err != nil || cfg.URL == "" {
+		return cfg, err
+	}
+	if err := validateWebhookURL(cfg.URL); err != nil {
+		return webhookConfig{}, err
+	}
+	if cfg.Secret, err = store.GetConfig(ctx, webhookSecretConfigKey); err != nil {
+		return webhookConfig{}, err
+	}
+	events, err := store.GetConfig(ctx, webhookEventsConfigKey)
+	if err != nil {
+		return webhookConfig{}, err
+	}
+	if cfg.Matches, err = parseWebhookEvents(events); err != nil {
+		return webhookConfig{}, err
+	}
+	return cfg, nil
+}
+
+// webhookPayload is the JSON body POSTed for one issue event
+type webhookPayload struct {
+	ID        string                   `json:"id"`
+	Event     types.EventType          `json:"event"`
+	Actor     string                   `json:"actor"`
+	Timestamp time.Time                `json:"timestamp"`
+	Title     string                   `json:"title,omitempty"`
+	URL       string                   `json:"url,omitempty"`
+	Changes   map[string]webhookChange `json:"changes,omitempty"`
+	Comment   string                   `json:"comment,omitempty"`
+	Note      string                   `json:"note,omitempty"`
+}
+
+// webhookChange is a changed field's value before and after the event
+type webhookChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// webhookChanges works out which fields an event changed. Update events store
+// the old issue and the applied updates as JSON; fields whose value didn't
+// change are left out. Other events carry no field changes.
+func webhookChanges(event *types.Event) map[string]webhookChange {
+	if event.EventType == types.EventCreated || event.OldValue == nil || event.NewValue == nil {
+		return nil
+	}
+	var before, after map[string]interface{}
+	if json.Unmarshal([]byte(*event.OldValue), &before) != nil || json.Unmarshal([]byte(*event.NewValue), &after) != nil {
+		return nil
+	}
+	changes := make(map[string]webhookChange)
+	for field, value := range after {
+		old := before[field]
+		if oldJSON, err := json.Marshal(old); err == nil {
+			if newJSON, err := json.Marshal(value); err == nil && bytes.Equal(oldJSON, newJSON) {
+				continue
+			}
+		}
+		changes[field] = webhookChange{Before: old, After: value}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return changes
+}
+
+// webhookNotifier follows the events table and POSTs matching events to the
+// configured webhook. It runs on its own goroutine, so a slow or failing
+// endpoint delays notifications but never the daemon; events stay in the
+// database until they are sent or given up on.
+type webhookNotifier struct {
+	store       storage.Storage
+	log         daemonLogger
+	client      *http.Client
+	retries     int           // Attempts after the first failed one
+	backoff     time.Duration // Delay before the first retry, doubling each time
+	lastEventID int64         // Newest event already handled
+	wake        chan struct{}
+}
+
+// newWebhookNotifier creates a notifier that starts after the newest existing
+// event, so only changes made while the daemon runs are sent
+func newWebhookNotifier(ctx context.Context, store storage.Storage, log daemonLogger) *webhookNotifier {
+	n := &webhookNotifier{
+		store:   store,
+		log:     log,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		retries: 3,
+		backoff: time.Second,
+		wake:    make(chan struct{}, 1),
+	}
+	for {
+		events, err := store.GetEventsSince(ctx, n.lastEventID, webhookEventsPageSize)
+		if err != nil {
+			log.log("Warning: webhook notifier could not read events: %v", err)
+			break
+		}
+		if len(events) == 0 {
+			break
+		}
+		n.lastEventID = events[len(events)-1].ID
+	}
+	return n
+}
+
+// Trigger asks the notifier to look for new events. Never blocks; triggers
+// arriving while it is busy are coalesced.
+func (n *webhookNotifier) Trigger() {
+	select {
+	case n.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run handles triggers until ctx is done
+func (n *webhookNotifier) Run(ctx context.Context) {
+	for {
+		select {
+		case <-n.wake:
+			n.notify(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// notify sends every event since the last one handled that the config asks
+// for. Events are handled even while webhooks are off, so turning them on
+// doesn't replay history.
+func (n *webhookNotifier) notify(ctx context.Context) {
+	cfg, err := loadWebhookConfig(ctx, n.store)
+	if err != nil {
+		n.log.log("Warning: webhook config: %v", err)
+	}
+	for ctx.Err() == nil {
+		events, err := n.store.GetEventsSince(ctx, n.lastEventID, webhookEventsPageSize)
+		if err != nil {
+			n.log.log("Warning: webhook notifier could not read events: %v", err)
+			return
+		}
+		if len(events) == 0 {
+			return
+		}
+		for _, event := range events {
+			if cfg.URL != "" && cfg.Matches(event.EventType) {
+				if err := n.send(ctx, cfg, event.EventType, n.payload(ctx, event)); err != nil {
+					n.log.log("Webhook for %s %s failed: %v", event.EventType, event.IssueID, err)
+				}
+			}
+			n.lastEventID = event.ID
+		}
+	}
+}
+
+// payload builds the webhook body for an event
+func (n *webhookNotifier) payload(ctx context.Context, event *types.Event) *webhookPayload {
+	p := &webhookPayload{
+		ID:        event.IssueID,
+		Event:     event.EventType,
+		Actor:     event.Actor,
+		Timestamp: event.CreatedAt,
+		URL:       utils.IssueURL(ctx, n.store, event.IssueID),
+		Changes:   webhookChanges(event),
+	}
+	if issue, err := n.store.GetIssue(ctx, event.IssueID); err == nil && issue != nil {
+		p.Title = issue.Title
+	}
+	if event.Comment != nil {
+		p.Comment = *event.Comment
+	}
+	if event.Note != nil {
+		p.Note = *event.Note
+	}
+	return p
+}
+
+// send POSTs payload as JSON for event, retrying with backoff on network
+// errors and 5xx or 429 responses
+func (n *webhookNotifier) send(ctx context.Context, cfg webhookConfig, event types.EventType, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+	delay := n.backoff
+	for attempt := 0; ; attempt++ {
+		retryable, err := n.post(ctx, cfg, event, body)
+		if err == nil || !retryable || attempt >= n.retries {
+			return err
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		delay *= 2
+	}
+}
+
+// post makes one delivery attempt, reporting whether a failure is worth
+// retrying
+func (n *webhookNotifier) post(ctx context.Context, cfg webhookConfig, event types.EventType, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "bd/"+Version)
+	req.Header.Set("X-Beads-Event", string(event))
+	if cfg.Secret != "" {
+		req.Header.Set(webhookSignatureHeader, signWebhookBody(cfg.Secret, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	_ = resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retryable := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retryable, fmt.Errorf("endpoint returned %s", resp.Status)
+}
+
+// signWebhookBody returns the signature header value for body: "sha256=" and
+// the hex HMAC-SHA256 keyed by secret
+func signWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
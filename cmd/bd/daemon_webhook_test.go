@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWebhookNotifierDelivers(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := newTestStore(t, filepath.Join(tmpDir, ".beads", "beads.db"))
+	ctx := context.Background()
+
+	// Existing history isn't replayed
+	old := &types.Issue{Title: "Before the daemon", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, old, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	var mu sync.Mutex
+	var received []webhookPayload
+	var eventHeaders []string
+	take := func() ([]webhookPayload, []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		payloads, headers := received, eventHeaders
+		received, eventHeaders = nil, nil
+		return payloads, headers
+	}
+	failures := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var p webhookPayload
+		if err := json.Unmarshal(body, &p); err != nil {
+			t.Errorf("bad payload %s: %v", body, err)
+		}
+		if r.Header.Get(webhookSignatureHeader) != signWebhookBody("s3cret", body) {
+			t.Errorf("bad signature %q", r.Header.Get(webhookSignatureHeader))
+		}
+		received = append(received, p)
+		eventHeaders = append(eventHeaders, r.Header.Get("X-Beads-Event"))
+	}))
+	defer server.Close()
+
+	for key, value := range map[string]string{
+		webhookURLConfigKey:    server.URL,
+		webhookSecretConfigKey: "s3cret",
+		webhookEventsConfigKey: "created,closed",
+	} {
+		if err := store.SetConfig(ctx, key, value); err != nil {
+			t.Fatalf("SetConfig failed: %v", err)
+		}
+	}
+
+	n := newWebhookNotifier(ctx, store, newReconcileTestLogger())
+	n.backoff = time.Millisecond
+
+	issue := &types.Issue{Title: "Login broken", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "Login broken again"}, "bob"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.CloseIssue(ctx, issue.ID, storage.CloseOptions{Reason: "Fixed"}, "bob"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	n.notify(ctx)
+
+	payloads, events := take()
+	if len(payloads) != 2 {
+		t.Fatalf("expected created and closed webhooks (after one retry), got %+v", payloads)
+	}
+	if payloads[0].ID != issue.ID || payloads[0].Event != types.EventCreated || payloads[0].Actor != "alice" || events[0] != "created" {
+		t.Errorf("unexpected created payload: %+v", payloads[0])
+	}
+	closed := payloads[1]
+	if closed.Event != types.EventClosed || closed.Actor != "bob" || closed.Title != "Login broken again" || closed.Comment != "Fixed" {
+		t.Errorf("unexpected closed payload: %+v", closed)
+	}
+	if change, ok := closed.Changes["status"]; !ok || change.Before != "open" || change.After != "closed" {
+		t.Errorf("expected status open → closed, got %+v", closed.Changes)
+	}
+
+	// Nothing new, nothing sent
+	n.notify(ctx)
+	if again, _ := take(); len(again) != 0 {
+		t.Errorf("expected no repeat deliveries, got %+v", again)
+	}
+}
+
+func TestWebhookChanges(t *testing.T) {
+	oldValue := `{"id":"bd-1","title":"Old","priority":2,"assignee":"alice"}`
+	newValue := `{"title":"New","priority":2,"assignee":""}`
+	changes := webhookChanges(&types.Event{EventType: types.EventUpdated, OldValue: &oldValue, NewValue: &newValue})
+	if len(changes) != 2 || changes["title"].Before != "Old" || changes["title"].After != "New" || changes["assignee"].After != "" {
+		t.Errorf("unexpected changes: %+v", changes)
+	}
+	if _, ok := changes["priority"]; ok {
+		t.Errorf("unchanged priority should be left out: %+v", changes)
+	}
+	created := `{"id":"bd-1"}`
+	if changes := webhookChanges(&types.Event{EventType: types.EventCreated, NewValue: &created}); changes != nil {
+		t.Errorf("expected no changes for a created event, got %+v", changes)
+	}
+}
+
+func TestWebhookConfigValidation(t *testing.T) {
+	if err := validateWebhookURL("https://hooks.example.com/beads"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, value := range []string{"", "hooks.example.com", "ftp://hooks.example.com/x"} {
+		if err := validateWebhookURL(value); err == nil {
+			t.Errorf("expected an error for %q", value)
+		}
+	}
+
+	matches, err := parseWebhookEvents("")
+	if err != nil || !matches(types.EventClosed) || matches(types.EventLabelAdded) {
+		t.Errorf("unexpected default events (err %v)", err)
+	}
+	matches, err = parseWebhookEvents("label_added, status")
+	if err != nil || !matches(types.EventLabelAdded) || !matches(types.EventReopened) || matches(types.EventUpdated) {
+		t.Errorf("unexpected events for label_added,status (err %v)", err)
+	}
+	if _, err := parseWebhookEvents("created,bogus"); err == nil {
+		t.Error("expected an error for an unknown event type")
+	}
+}
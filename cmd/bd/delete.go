@@ -9,6 +9,7 @@ import (
 	"strings"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -34,13 +35,22 @@ Default: Fails if any issue has dependents not in deletion set
 Cascade: Recursively delete all dependents
   bd delete bd-1 --cascade --force
 Force: Delete and orphan dependents
-  bd delete bd-1 --force`,
+  bd delete bd-1 --force
+RENUMBERING CHILDREN:
+Deleting bd-a3f8.2 of three children leaves bd-a3f8.1 and bd-a3f8.3. With
+--renumber, the following siblings are renamed down to close the gap
+(bd-a3f8.3 becomes bd-a3f8.2, its children move with it), references to
+them are rewritten in all issues and comments, and the parent's child
+counter is reset. The renames run in one transaction. Since they change
+IDs others may have written down, this is opt-in:
+  bd delete bd-a3f8.2 --renumber --force`,
 	Args: cobra.MinimumNArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
 		fromFile, _ := cmd.Flags().GetString("from-file")
 		force, _ := cmd.Flags().GetBool("force")
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 		cascade, _ := cmd.Flags().GetBool("cascade")
+		renumber, _ := cmd.Flags().GetBool("renumber")
 		// Use global jsonOutput set by PersistentPreRun
 		// Collect issue IDs from args and/or file
 		issueIDs := make([]string, 0, len(args))
@@ -62,7 +72,7 @@ Force: Delete and orphan dependents
 		issueIDs = uniqueStrings(issueIDs)
 		// Handle batch deletion
 		if len(issueIDs) > 1 {
-			deleteBatch(cmd, issueIDs, force, dryRun, cascade, jsonOutput)
+			deleteBatch(cmd, issueIDs, force, dryRun, cascade, renumber, jsonOutput)
 			return
 		}
 		// Single issue deletion (legacy behavior)
@@ -156,8 +166,15 @@ Force: Delete and orphan dependents
 					fmt.Printf("  (none have text references)\n")
 				}
 			}
+			if renumber {
+				printRenumberPreview(ctx, []string{issueID})
+			}
 			fmt.Printf("\n%s\n", yellow("This operation cannot be undone!"))
-			fmt.Printf("To proceed, run: %s\n\n", yellow("bd delete "+issueID+" --force"))
+			proceed := "bd delete " + issueID + " --force"
+			if renumber {
+				proceed += " --renumber"
+			}
+			fmt.Printf("To proceed, run: %s\n\n", yellow(proceed))
 			return
 		}
 		// Actually delete
@@ -213,8 +230,14 @@ Force: Delete and orphan dependents
 				inboundRemoved++
 			}
 		}
-		// 4. Delete the issue itself from database
-		if err := deleteIssue(ctx, issueID); err != nil {
+		// 4. Delete the issue itself from database, with --renumber in the
+		// same transaction as renaming its following siblings
+		var renumbered map[string]string
+		if renumber {
+			renumbered = deleteWithRenumber(ctx, func(tx storage.Transaction) ([]string, error) {
+				return []string{issueID}, tx.DeleteIssue(ctx, issueID)
+			})
+		} else if err := deleteIssue(ctx, issueID); err != nil {
 			fmt.Fprintf(os.Stderr, "Error deleting issue: %v\n", err)
 			os.Exit(1)
 		}
@@ -226,16 +249,24 @@ Force: Delete and orphan dependents
 		markDirtyAndScheduleFlush()
 		totalDepsRemoved := outgoingRemoved + inboundRemoved
 		if jsonOutput {
-			outputJSON(map[string]interface{}{
+			result := map[string]interface{}{
 				"deleted":              issueID,
 				"dependencies_removed": totalDepsRemoved,
 				"references_updated":   updatedIssueCount,
-			})
+			}
+			if renumber {
+				result["renumbered"] = renumbered
+			}
+			outputJSON(result)
 		} else {
 			green := color.New(color.FgGreen).SprintFunc()
 			fmt.Printf("%s Deleted %s\n", green("✓"), issueID)
 			fmt.Printf("  Removed %d dependency link(s)\n", totalDepsRemoved)
 			fmt.Printf("  Updated text references in %d issue(s)\n", updatedIssueCount)
+			if len(renumbered) > 0 {
+				fmt.Printf("  Renumbered %d issue(s):\n", len(renumbered))
+				printRenumberMapping(renumbered)
+			}
 		}
 	},
 }
@@ -319,7 +350,7 @@ func removeIssueFromJSONL(issueID string) error {
 }
 // deleteBatch handles deletion of multiple issues
 //nolint:unparam // cmd parameter required for potential future use
-func deleteBatch(_ *cobra.Command, issueIDs []string, force bool, dryRun bool, cascade bool, jsonOutput bool) {
+func deleteBatch(_ *cobra.Command, issueIDs []string, force bool, dryRun bool, cascade bool, renumber bool, jsonOutput bool) {
 	// Ensure we have a direct store when daemon lacks delete support
 	if daemonClient != nil {
 		if err := ensureDirectMode("daemon does not support delete command"); err != nil {
@@ -373,17 +404,24 @@ func deleteBatch(_ *cobra.Command, issueIDs []string, force bool, dryRun bool, c
 		if len(result.OrphanedIssues) > 0 {
 			fmt.Printf("Would orphan: %d issues\n", len(result.OrphanedIssues))
 		}
+		if renumber {
+			printRenumberPreview(ctx, issueIDs)
+		}
 		if dryRun {
 			fmt.Printf("\n(Dry-run mode - no changes made)\n")
 		} else {
 			yellow := color.New(color.FgYellow).SprintFunc()
 			fmt.Printf("\n%s\n", yellow("This operation cannot be undone!"))
+			renumberFlag := ""
+			if renumber {
+				renumberFlag = " --renumber"
+			}
 			if cascade {
 				fmt.Printf("To proceed with cascade deletion, run: %s\n",
-					yellow("bd delete "+strings.Join(issueIDs, " ")+" --cascade --force"))
+					yellow("bd delete "+strings.Join(issueIDs, " ")+" --cascade --force"+renumberFlag))
 			} else {
 				fmt.Printf("To proceed, run: %s\n",
-					yellow("bd delete "+strings.Join(issueIDs, " ")+" --force"))
+					yellow("bd delete "+strings.Join(issueIDs, " ")+" --force"+renumberFlag))
 			}
 		}
 		return
@@ -414,11 +452,31 @@ func deleteBatch(_ *cobra.Command, issueIDs []string, force bool, dryRun bool, c
 			}
 		}
 	}
-	// Actually delete
-	result, err := d.DeleteIssues(ctx, issueIDs, cascade, force, false)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	// Actually delete, with --renumber in the same transaction as renaming
+	// the following siblings
+	var result *sqlite.DeleteIssuesResult
+	var renumbered map[string]string
+	if renumber {
+		type batchDeleter interface {
+			DeleteIssues(ctx context.Context, ids []string, cascade bool, force bool) (*sqlite.DeleteIssuesResult, error)
+		}
+		renumbered = deleteWithRenumber(ctx, func(tx storage.Transaction) ([]string, error) {
+			td, ok := tx.(batchDeleter)
+			if !ok {
+				return nil, fmt.Errorf("batch delete not supported by this storage backend")
+			}
+			var err error
+			if result, err = td.DeleteIssues(ctx, issueIDs, cascade, force); err != nil {
+				return nil, err
+			}
+			return result.DeletedIDs, nil
+		})
+	} else {
+		var err error
+		if result, err = d.DeleteIssues(ctx, issueIDs, cascade, force, false); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 	}
 	// Update text references in connected issues (using pre-collected issues)
 	updatedCount := updateTextReferencesInIssues(ctx, issueIDs, connectedIssues)
@@ -432,7 +490,7 @@ func deleteBatch(_ *cobra.Command, issueIDs []string, force bool, dryRun bool, c
 	markDirtyAndScheduleFlush()
 	// Output results
 	if jsonOutput {
-		outputJSON(map[string]interface{}{
+		output := map[string]interface{}{
 			"deleted":              issueIDs,
 			"deleted_count":        result.DeletedCount,
 			"dependencies_removed": result.DependenciesCount,
@@ -440,7 +498,11 @@ func deleteBatch(_ *cobra.Command, issueIDs []string, force bool, dryRun bool, c
 			"events_removed":       result.EventsCount,
 			"references_updated":   updatedCount,
 			"orphaned_issues":      result.OrphanedIssues,
-		})
+		}
+		if renumber {
+			output["renumbered"] = renumbered
+		}
+		outputJSON(output)
 	} else {
 		green := color.New(color.FgGreen).SprintFunc()
 		fmt.Printf("%s Deleted %d issue(s)\n", green("✓"), result.DeletedCount)
@@ -453,6 +515,10 @@ func deleteBatch(_ *cobra.Command, issueIDs []string, force bool, dryRun bool, c
 			fmt.Printf("  %s Orphaned %d issue(s): %s\n",
 				yellow("⚠"), len(result.OrphanedIssues), strings.Join(result.OrphanedIssues, ", "))
 		}
+		if len(renumbered) > 0 {
+			fmt.Printf("  Renumbered %d issue(s):\n", len(renumbered))
+			printRenumberMapping(renumbered)
+		}
 	}
 }
 // showDeletionPreview shows what would be deleted
@@ -558,5 +624,6 @@ func init() {
 	deleteCmd.Flags().String("from-file", "", "Read issue IDs from file (one per line)")
 	deleteCmd.Flags().Bool("dry-run", false, "Preview what would be deleted without making changes")
 	deleteCmd.Flags().Bool("cascade", false, "Recursively delete all dependent issues")
+	deleteCmd.Flags().Bool("renumber", false, "Rename the following siblings of deleted children down to close the gap")
 	rootCmd.AddCommand(deleteCmd)
 }
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// splitChildID splits a hierarchical child ID like bd-a3f8.2 into its parent
+// and child number. ok is false for IDs that aren't numbered children.
+func splitChildID(id string) (parent string, num int, ok bool) {
+	dot := strings.LastIndex(id, ".")
+	if dot <= 0 {
+		return "", 0, false
+	}
+	num, err := strconv.Atoi(id[dot+1:])
+	if err != nil || num <= 0 {
+		return "", 0, false
+	}
+	return id[:dot], num, true
+}
+
+// renumberParents returns the parents whose children deleting ids leaves gaps
+// in, ancestors before descendants
+func renumberParents(ids []string) []string {
+	seen := make(map[string]bool)
+	var parents []string
+	for _, id := range ids {
+		if parent, _, ok := splitChildID(id); ok && !seen[parent] {
+			seen[parent] = true
+			parents = append(parents, parent)
+		}
+	}
+	sort.Slice(parents, func(i, j int) bool {
+		return compareChildIDs(parents[i], parents[j]) < 0
+	})
+	return parents
+}
+
+// buildRenumberMapping closes the gaps in each parent's child numbers: the
+// remaining children are renumbered 1..n in their current order, and their
+// descendants follow them. Ancestors go first, so a parent renamed
+// by an earlier one is looked up under its new ID. Returns old → new IDs for
+// the issues that move.
+func buildRenumberMapping(parentIDs []string, issues []*types.Issue) map[string]string {
+	current := make(map[string]string, len(issues)) // old ID → ID after the renames so far
+	for _, issue := range issues {
+		current[issue.ID] = issue.ID
+	}
+
+	for _, parentID := range parentIDs {
+		if newParent, ok := current[parentID]; ok {
+			parentID = newParent
+		} else {
+			continue // Parent deleted too; its children have nowhere to close up
+		}
+
+		type child struct {
+			oldID string
+			num   int
+		}
+		var children []child
+		for oldID, id := range current {
+			if parent, num, ok := splitChildID(id); ok && parent == parentID {
+				children = append(children, child{oldID: oldID, num: num})
+			}
+		}
+		sort.Slice(children, func(i, j int) bool { return children[i].num < children[j].num })
+
+		for i, c := range children {
+			if c.num == i+1 {
+				continue
+			}
+			from := current[c.oldID] + "."
+			to := fmt.Sprintf("%s.%d", parentID, i+1)
+			for oldID, id := range current {
+				if oldID == c.oldID {
+					current[oldID] = to
+				} else if strings.HasPrefix(id, from) {
+					current[oldID] = to + "." + id[len(from):]
+				}
+			}
+		}
+	}
+
+	mapping := make(map[string]string)
+	for oldID, id := range current {
+		if id != oldID {
+			mapping[oldID] = id
+		}
+	}
+	return mapping
+}
+
+// compareChildIDs orders IDs by their dot-separated segments, comparing
+// numeric segments as numbers, so bd-a.2 sorts before bd-a.10
+func compareChildIDs(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil && an != bn:
+			if an < bn {
+				return -1
+			}
+			return 1
+		case (aErr != nil || bErr != nil) && as[i] != bs[i]:
+			return strings.Compare(as[i], bs[i])
+		}
+	}
+	return len(as) - len(bs)
+}
+
+// deleteAndRenumber deletes issues with del, which returns the IDs it deleted,
+// then closes the gaps that leaves in their parents' child numbers: the
+// following siblings and their descendants are renamed down, references to
+// them in every issue and comment are rewritten, and each parent's child
+// counter is reset. Both run in one transaction, so a failed renumber rolls
+// the deletion back too. Returns the old → new IDs renamed.
+func deleteAndRenumber(ctx context.Context, st storage.Storage, del func(tx storage.Transaction) ([]string, error), actorName string) (map[string]string, error) {
+	// Listed up front, since the transaction must make all its calls through tx
+	issues, err := st.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	var mapping map[string]string
+	if err := st.WithTx(ctx, func(tx storage.Transaction) error {
+		deletedIDs, err := del(tx)
+		if err != nil {
+			return err
+		}
+		mapping, err = renumberChildrenIn(ctx, tx, renumberParents(deletedIDs), withoutIssues(issues, deletedIDs), actorName)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return mapping, nil
+}
+
+// renumberChildrenIn closes the gaps in parentIDs' child numbers among
+// issues, through tx. Returns the old → new IDs renamed.
+func renumberChildrenIn(ctx context.Context, tx storage.Transaction, parentIDs []string, issues []*types.Issue, actorName string) (map[string]string, error) {
+	mapping := buildRenumberMapping(parentIDs, issues)
+	if len(mapping) == 0 {
+		return mapping, nil
+	}
+	refPattern := idRefPattern(mapping, `[0-9a-z]+`)
+
+	// Numbers only move down, so whatever holds an issue's new ID is itself
+	// moving to a smaller one; renaming in new-ID order frees each target first
+	var renamed, others []*types.Issue
+	for _, issue := range issues {
+		if _, ok := mapping[issue.ID]; ok {
+			issue.Title = replaceIDReferencesMatching(refPattern, issue.Title, mapping)
+			renamed = append(renamed, issue)
+		} else {
+			others = append(others, issue)
+		}
+	}
+	sort.Slice(renamed, func(i, j int) bool {
+		return compareChildIDs(mapping[renamed[i].ID], mapping[renamed[j].ID]) < 0
+	})
+
+	if err := applyIDMapping(ctx, tx, renamed, mapping, refPattern); err != nil {
+		return nil, err
+	}
+	for _, issue := range others {
+		if err := rewriteIDReferencesIn(ctx, tx, issue, mapping, refPattern, actorName); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, parentID := range parentIDs {
+		if newID, ok := mapping[parentID]; ok {
+			parentID = newID
+		}
+		if err := tx.ResetChildCounter(ctx, parentID); err != nil {
+			return nil, err
+		}
+	}
+	return mapping, nil
+}
+
+// withoutIssues returns issues minus those with the given ids
+func withoutIssues(issues []*types.Issue, ids []string) []*types.Issue {
+	deleted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		deleted[id] = true
+	}
+	var remaining []*types.Issue
+	for _, issue := range issues {
+		if !deleted[issue.ID] {
+			remaining = append(remaining, issue)
+		}
+	}
+	return remaining
+}
+
+// planRenumber returns the renames deleteAndRenumber would make after ids are
+// deleted, for previews. Only ids count as deleted, so with --cascade the
+// preview may still list issues the cascade would delete.
+func planRenumber(ctx context.Context, st storage.Storage, ids []string) (map[string]string, error) {
+	issues, err := st.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+	return buildRenumberMapping(renumberParents(ids), withoutIssues(issues, ids)), nil
+}
+
+// printRenumberMapping lists renames, in the order they're applied
+func printRenumberMapping(mapping map[string]string) {
+	entries := sortedMappingEntries(mapping)
+	sort.Slice(entries, func(i, j int) bool { return compareChildIDs(entries[i].NewID, entries[j].NewID) < 0 })
+	for _, entry := range entries {
+		fmt.Printf("  %s → %s\n", entry.OldID, entry.NewID)
+	}
+}
+
+// rewriteIDReferencesIn rewrites references to renamed IDs in an issue that
+// keeps its own ID, updating only the fields and comments that change
+func rewriteIDReferencesIn(ctx context.Context, tx storage.Transaction, issue *types.Issue, mapping map[string]string, refPattern *regexp.Regexp, actorName string) error {
+	updates := make(map[string]interface{})
+	for field, text := range map[string]string{
+		"title":               issue.Title,
+		"description":         issue.Description,
+		"design":              issue.Design,
+		"notes":               issue.Notes,
+		"acceptance_criteria": issue.AcceptanceCriteria,
+	} {
+		if updated := replaceIDReferencesMatching(refPattern, text, mapping); updated != text {
+			updates[field] = updated
+		}
+	}
+	if issue.ExternalRef != nil {
+		if updated := replaceExternalRefIDs(refPattern, *issue.ExternalRef, mapping); updated != *issue.ExternalRef {
+			updates["external_ref"] = updated
+		}
+	}
+	if len(updates) > 0 {
+		if err := tx.UpdateIssue(ctx, issue.ID, updates, actorName); err != nil {
+			return fmt.Errorf("failed to update references in %s: %w", issue.ID, err)
+		}
+	}
+
+	comments, err := tx.GetIssueComments(ctx, issue.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get comments for %s: %w", issue.ID, err)
+	}
+	for _, comment := range comments {
+		if updated := replaceIDReferencesMatching(refPattern, comment.Text, mapping); updated != comment.Text {
+			if _, err := tx.UpdateComment(ctx, comment.ID, updated); err != nil {
+				return fmt.Errorf("failed to update comment %d on %s: %w", comment.ID, issue.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// printRenumberPreview lists the renames --renumber would make after ids are
+// deleted
+func printRenumberPreview(ctx context.Context, ids []string) {
+	mapping, err := planRenumber(ctx, store, ids)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	if len(mapping) == 0 {
+		fmt.Printf("\nNo siblings to renumber\n")
+		return
+	}
+	fmt.Printf("\nIssues to renumber: %d\n", len(mapping))
+	printRenumberMapping(mapping)
+}
+
+// deleteWithRenumber deletes issues with del and closes the gaps that leaves
+// among their parents' children, exiting on failure. Nothing is deleted if
+// either step fails.
+func deleteWithRenumber(ctx context.Context, del func(tx storage.Transaction) ([]string, error)) map[string]string {
+	mapping, err := deleteAndRenumber(ctx, store, del, actor)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v (nothing was deleted or renumbered)\n", err)
+		os.Exit(1)
+	}
+	if len(mapping) > 0 {
+		// IDs changed, incremental export would keep the old ones
+		markDirtyAndScheduleFullExport()
+	}
+	return mapping
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRenumberChildren(t *testing.T) {
+	tests := []struct {
+		name    string
+		deleted string
+		want    map[string]string // old → new for the issues that move
+	}{
+		{
+			name:    "first child",
+			deleted: "test-1.1",
+			want: map[string]string{
+				"test-1.2":   "test-1.1",
+				"test-1.3":   "test-1.2",
+				"test-1.3.1": "test-1.2.1",
+			},
+		},
+		{
+			name:    "middle child",
+			deleted: "test-1.2",
+			want: map[string]string{
+				"test-1.3":   "test-1.2",
+				"test-1.3.1": "test-1.2.1",
+			},
+		},
+		{
+			name:    "last child",
+			deleted: "test-1.3",
+			want:    map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			testStore := newTestStore(t, filepath.Join(tmpDir, ".beads", "beads.db"))
+			ctx := context.Background()
+
+			create := func(id, description string) {
+				t.Helper()
+				// The assignee records the original ID; renaming leaves it alone
+				issue := &types.Issue{ID: id, Title: id, Description: description, Assignee: id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+				if err := testStore.CreateIssue(ctx, issue, "test"); err != nil {
+					t.Fatalf("CreateIssue %s failed: %v", id, err)
+				}
+			}
+			createChild := func(parentID string) string {
+				t.Helper()
+				id, err := testStore.GetNextChildID(ctx, parentID)
+				if err != nil {
+					t.Fatalf("GetNextChildID failed: %v", err)
+				}
+				create(id, "")
+				if err := testStore.AddDependency(ctx, &types.Dependency{IssueID: id, DependsOnID: parentID, Type: types.DepParentChild}, "test"); err != nil {
+					t.Fatalf("AddDependency failed: %v", err)
+				}
+				return id
+			}
+			create("test-1", "")
+			createChild("test-1")
+			createChild("test-1")
+			last := createChild("test-1")
+			createChild(last)
+			// Deleting test-1.3 in the last-child case drops its own child too
+			create("test-2", "Follow-up to test-1.3 and test-1.3.1")
+			if _, err := testStore.AddIssueComment(ctx, "test-2", "test", "Blocked on test-1.3"); err != nil {
+				t.Fatalf("AddComment failed: %v", err)
+			}
+
+			if tt.deleted == last {
+				if err := testStore.DeleteIssue(ctx, last+".1"); err != nil {
+					t.Fatalf("DeleteIssue failed: %v", err)
+				}
+			}
+			mapping, err := deleteAndRenumber(ctx, testStore, func(tx storage.Transaction) ([]string, error) {
+				return []string{tt.deleted}, tx.DeleteIssue(ctx, tt.deleted)
+			}, "test")
+			if err != nil {
+				t.Fatalf("deleteAndRenumber failed: %v", err)
+			}
+			if len(mapping) != len(tt.want) {
+				t.Fatalf("mapping = %v, want %v", mapping, tt.want)
+			}
+			for oldID, newID := range tt.want {
+				if mapping[oldID] != newID {
+					t.Errorf("mapping[%s] = %q, want %q", oldID, mapping[oldID], newID)
+				}
+				if issue, err := testStore.GetIssue(ctx, newID); err != nil || issue == nil || issue.Assignee != oldID {
+					t.Errorf("%s after renumbering = %+v, %v; want the issue that was %s", newID, issue, err, oldID)
+				}
+			}
+
+			// The moved child keeps its parent; the renamed grandchild keeps it too
+			if tt.deleted != last {
+				deps, err := testStore.GetDependencyRecords(ctx, "test-1.2.1")
+				if err != nil || len(deps) != 1 || deps[0].DependsOnID != "test-1.2" {
+					t.Errorf("test-1.2.1 dependencies = %+v, %v; want parent test-1.2", deps, err)
+				}
+			}
+
+			issue, err := testStore.GetIssue(ctx, "test-2")
+			if err != nil {
+				t.Fatalf("GetIssue failed: %v", err)
+			}
+			wantDesc := "Follow-up to test-1.3 and test-1.3.1"
+			wantComment := "Blocked on test-1.3"
+			if tt.deleted != last {
+				wantDesc = "Follow-up to test-1.2 and test-1.2.1"
+				wantComment = "Blocked on test-1.2"
+			}
+			if issue.Description != wantDesc {
+				t.Errorf("description = %q, want %q", issue.Description, wantDesc)
+			}
+			comments, err := testStore.GetIssueComments(ctx, "test-2")
+			if err != nil || len(comments) != 1 || comments[0].Text != wantComment {
+				t.Errorf("comments = %+v, %v; want %q", comments, err, wantComment)
+			}
+
+			// The counter comes back down, so the next child fills the end
+			next, err := testStore.GetNextChildID(ctx, "test-1")
+			if err != nil {
+				t.Fatalf("GetNextChildID failed: %v", err)
+			}
+			if next != "test-1.3" {
+				t.Errorf("next child = %s, want test-1.3", next)
+			}
+		})
+	}
+}
+
+// failingRenameStore is a store whose transactions fail to rename issues
+type failingRenameStore struct {
+	storage.Storage
+}
+
+func (s failingRenameStore) WithTx(ctx context.Context, fn func(tx storage.Transaction) error) error {
+	return s.Storage.WithTx(ctx, func(tx storage.Transaction) error {
+		return fn(failingRenameTx{tx})
+	})
+}
+
+type failingRenameTx struct {
+	storage.Transaction
+}
+
+func (failingRenameTx) UpdateIssueID(context.Context, string, string, *types.Issue, string) error {
+	return errors.New("rename failed")
+}
+
+func TestDeleteAndRenumberRollsBackDelete(t *testing.T) {
+	testStore := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	ctx := context.Background()
+	for _, id := range []string{"test-1", "test-1.1", "test-1.2"} {
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := testStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue %s failed: %v", id, err)
+		}
+	}
+
+	_, err := deleteAndRenumber(ctx, failingRenameStore{testStore}, func(tx storage.Transaction) ([]string, error) {
+		return []string{"test-1.1"}, tx.DeleteIssue(ctx, "test-1.1")
+	}, "test")
+	if err == nil || !strings.Contains(err.Error(), "rename failed") {
+		t.Fatalf("deleteAndRenumber error = %v, want the failed rename", err)
+	}
+	for _, id := range []string{"test-1.1", "test-1.2"} {
+		if issue, err := testStore.GetIssue(ctx, id); err != nil || issue == nil {
+			t.Errorf("%s after the failed renumber = %v, %v; want it kept", id, issue, err)
+		}
+	}
+}
+
+func TestBuildRenumberMappingNested(t *testing.T) {
+	var issues []*types.Issue
+	for _, id := range []string{"bd-a", "bd-a.1", "bd-a.3", "bd-a.3.2", "bd-a.3.3", "bd-a.10"} {
+		issues = append(issues, &types.Issue{ID: id})
+	}
+	// bd-a.2 and bd-a.3.1 were deleted; bd-a.3 moves first, then its children
+	got := buildRenumberMapping(renumberParents([]string{"bd-a.3.1", "bd-a.2"}), issues)
+	want := map[string]string{
+		"bd-a.3":   "bd-a.2",
+		"bd-a.3.2": "bd-a.2.1",
+		"bd-a.3.3": "bd-a.2.2",
+		"bd-a.10":  "bd-a.3",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("mapping = %v, want %v", got, want)
+	}
+	for oldID, newID := range want {
+		if got[oldID] != newID {
+			t.Errorf("mapping[%s] = %q, want %q", oldID, got[oldID], newID)
+		}
+	}
+}
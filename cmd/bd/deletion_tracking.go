@@ -59,7 +59,7 @@ func merge3WayAndPruneDeletions(ctx context.Context, store storage.Storage, json
 	}()
 
 	if err := merge.Merge3Way(tmpMerged, basePath, leftPath, jsonlPath, false); err != nil {
-		// Merge error (including conflicts) is returned as error
+		// Unparseable input is returned as an error
 		return false, fmt.Errorf("3-way merge failed: %w", err)
 	}
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/steveyegge/beads/internal/config"
@@ -174,7 +175,7 @@ func TestMultiWorkspaceDeletionSync(t *testing.T) {
 }
 
 // TestDeletionWithLocalModification tests the conflict scenario:
-// Remote deletes an issue, but local has modified it
+// Remote deletes an issue, but local has modified it; the edit is kept
 func TestDeletionWithLocalModification(t *testing.T) {
 	dir := t.TempDir()
 	jsonlPath := filepath.Join(dir, "beads.jsonl")
@@ -235,16 +236,22 @@ func TestDeletionWithLocalModification(t *testing.T) {
 		t.Fatalf("Failed to simulate remote deletion: %v", err)
 	}
 
-	// Try to merge - this should detect a conflict (modified locally, deleted remotely)
-	_, err = merge3WayAndPruneDeletions(ctx, store, jsonlPath)
-	if err == nil {
-		t.Error("Expected merge conflict error, but got nil")
+	// Merge - the local edit wins over the remote deletion
+	if _, err := merge3WayAndPruneDeletions(ctx, store, jsonlPath); err != nil {
+		t.Fatalf("merge3WayAndPruneDeletions failed: %v", err)
 	}
 
-	// The issue should still exist in the database (conflict not auto-resolved)
+	// The issue should still exist in the database and the merged JSONL
 	conflictIssue, err := store.GetIssue(ctx, "bd-conflict")
 	if err != nil || conflictIssue == nil {
-		t.Error("Issue should still exist after conflict")
+		t.Error("Issue should still exist after its remote deletion")
+	}
+	merged, err := os.ReadFile(jsonlPath)
+	if err != nil {
+		t.Fatalf("Failed to read merged JSONL: %v", err)
+	}
+	if !strings.Contains(string(merged), "Modified title locally") {
+		t.Errorf("Expected the local edit in the merged JSONL, got:\n%s", merged)
 	}
 }
 
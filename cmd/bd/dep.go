@@ -22,11 +22,51 @@ var depCmd = &cobra.Command{
 }
 
 var depAddCmd = &cobra.Command{
-	Use:   "add [issue-id] [depends-on-id]",
+	Use:   "add [issue-id] [depends-on-id] | --bulk-blocks [issue-id] [blocker-id...]",
 	Short: "Add a dependency",
-	Args:  cobra.ExactArgs(2),
+	Long: `Add a dependency: [issue-id] depends on [depends-on-id].
+
+For blocks dependencies, the dependent can be moved toward a more urgent
+blocker's priority. This is off by default; enable it per project with:
+
+  bd config set priority_propagation bump      # Raise one level, e.g. P3 → P2
+  bd config set priority_propagation inherit   # Raise to the blocker's priority
+
+or per dependency with --weight N to raise the dependent at most N levels
+(--weight 0 disables propagation). Priorities are never lowered, and
+adjustments are recorded as priority_changed events.
+
+--bulk-blocks sets up all of an issue's blockers at once, in one
+transaction: each blocker ID may be partial, blockers it already depends on
+are skipped, and if any edge would create a cycle none are added.
+
+  bd dep add --bulk-blocks bd-42 bd-7 bd-9 bd-12`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if cmd.Flags().Changed("bulk-blocks") {
+			return cobra.MinimumNArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		depType, _ := cmd.Flags().GetString("type")
+		var weight *int
+		if cmd.Flags().Changed("weight") {
+			w, _ := cmd.Flags().GetInt("weight")
+			if w < 0 {
+				fmt.Fprintf(os.Stderr, "Error: --weight must be 0 or more\n")
+				os.Exit(1)
+			}
+			weight = &w
+		}
+		if cmd.Flags().Changed("bulk-blocks") {
+			if depType != string(types.DepBlocks) {
+				fmt.Fprintf(os.Stderr, "Error: --bulk-blocks only adds blocks dependencies, not %s\n", depType)
+				os.Exit(1)
+			}
+			target, _ := cmd.Flags().GetString("bulk-blocks")
+			runDepBulkBlocks(target, args, weight)
+			return
+		}
 
 		ctx := context.Background()
 		
@@ -75,6 +115,7 @@ var depAddCmd = &cobra.Command{
 				FromID:  fromID,
 				ToID:    toID,
 				DepType: depType,
+				Weight:  weight,
 			}
 
 			resp, err := daemonClient.AddDependency(depArgs)
@@ -83,14 +124,23 @@ var depAddCmd = &cobra.Command{
 				os.Exit(1)
 			}
 
+			var change *types.PriorityChange
+			if len(resp.Data) > 0 {
+				if err := json.Unmarshal(resp.Data, &change); err != nil {
+					fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
 			if jsonOutput {
-				fmt.Println(string(resp.Data))
+				outputJSON(depAddedJSON(fromID, toID, depType, change))
 				return
 			}
 
 			green := color.New(color.FgGreen).SprintFunc()
 			fmt.Printf("%s Added dependency: %s depends on %s (%s)\n",
 				green("✓"), args[0], args[1], depType)
+			printPriorityChange(change)
 			return
 		}
 
@@ -106,6 +156,20 @@ var depAddCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		// Propagate a more urgent blocker's priority to the dependent
+		var change *types.PriorityChange
+		if dep.Type == types.DepBlocks {
+			w := -1
+			if weight != nil {
+				w = *weight
+			}
+			var err error
+			change, err = store.PropagatePriority(ctx, fromID, toID, w, actor)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: dependency added but priority propagation failed: %v\n", err)
+			}
+		}
+
 		// Schedule auto-flush
 		markDirtyAndScheduleFlush()
 
@@ -135,24 +199,45 @@ var depAddCmd = &cobra.Command{
 		}
 
 		if jsonOutput {
-			outputJSON(map[string]interface{}{
-				"status":        "added",
-				"issue_id":      fromID,
-				"depends_on_id": toID,
-				"type":          depType,
-			})
+			outputJSON(depAddedJSON(fromID, toID, depType, change))
 			return
 		}
 
 		green := color.New(color.FgGreen).SprintFunc()
 		fmt.Printf("%s Added dependency: %s depends on %s (%s)\n",
 			green("✓"), fromID, toID, depType)
+		printPriorityChange(change)
 	},
 }
 
+// depAddedJSON builds the JSON result of dep add, including any priority change
+func depAddedJSON(fromID, toID, depType string, change *types.PriorityChange) map[string]interface{} {
+	result := map[string]interface{}{
+		"status":        "added",
+		"issue_id":      fromID,
+		"depends_on_id": toID,
+		"type":          depType,
+	}
+	if change != nil {
+		result["priority_change"] = change
+	}
+	return result
+}
+
+// printPriorityChange reports a priority adjusted by propagation, if any
+func printPriorityChange(change *types.PriorityChange) {
+	if change == nil {
+		return
+	}
+	cyan := color.New(color.FgCyan).SprintFunc()
+	fmt.Printf("%s Raised %s priority P%d → P%d (blocked by %s)\n",
+		cyan("↑"), change.IssueID, change.OldPriority, change.NewPriority, change.BlockerID)
+}
+
 var depRemoveCmd = &cobra.Command{
-	Use:   "remove [issue-id] [depends-on-id]",
-	Short: "Remove a dependency",
+	Use:     "remove [issue-id] [depends-on-id]",
+	Aliases: []string{"rm"},
+	Short:   "Remove a dependency",
 	Args:  cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
@@ -247,9 +332,122 @@ var depRemoveCmd = &cobra.Command{
 	},
 }
 
+var depListCmd = &cobra.Command{
+	Use:   "list [issue-id]",
+	Short: "List an issue's dependency edges",
+	Long: `List the issues [issue-id] depends on and the issues that depend on it,
+with the type of each edge.
+
+Examples:
+  bd dep list bd-42
+  bd dep list bd-42 --type blocks --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		depTypeStr, _ := cmd.Flags().GetString("type")
+		depType := types.DependencyType(depTypeStr)
+		if depType != "" && !depType.IsValid() {
+			fmt.Fprintf(os.Stderr, "Error: invalid dependency type '%s'. Valid values: blocks, related, parent-child, discovered-from\n", depTypeStr)
+			os.Exit(1)
+		}
+		ctx := context.Background()
+
+		var issue *types.Issue
+		var deps, dependents []*types.IssueWithDependencyMetadata
+		if daemonClient != nil {
+			resp, err := daemonClient.Show(&rpc.ShowArgs{ID: args[0]})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			var details struct {
+				types.Issue
+				Dependencies []*types.IssueWithDependencyMetadata `json:"dependencies"`
+				Dependents   []*types.IssueWithDependencyMetadata `json:"dependents"`
+			}
+			if err := json.Unmarshal(resp.Data, &details); err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+				os.Exit(1)
+			}
+			issue, deps, dependents = &details.Issue, details.Dependencies, details.Dependents
+		} else {
+			id, err := utils.ResolvePartialID(ctx, store, args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error resolving issue ID %s: %v\n", args[0], err)
+				os.Exit(1)
+			}
+			sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "Error: dep list requires SQLite storage\n")
+				os.Exit(1)
+			}
+			if issue, err = store.GetIssue(ctx, id); err != nil || issue == nil {
+				fmt.Fprintf(os.Stderr, "Error: issue %s not found\n", id)
+				os.Exit(1)
+			}
+			if deps, err = sqliteStore.GetDependenciesWithMetadata(ctx, id); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if dependents, err = sqliteStore.GetDependentsWithMetadata(ctx, id); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		deps = filterDepsByType(deps, depType)
+		dependents = filterDepsByType(dependents, depType)
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"issue_id":     issue.ID,
+				"dependencies": deps,
+				"dependents":   dependents,
+			})
+			return
+		}
+
+		cyan := color.New(color.FgCyan).SprintFunc()
+		fmt.Printf("\n%s %s: %s\n", cyan("🔗"), displayID(issue.ID), issue.Title)
+		printDepEdges(fmt.Sprintf("Depends on (%d):", len(deps)), deps)
+		printDepEdges(fmt.Sprintf("Dependents (%d):", len(dependents)), dependents)
+		fmt.Println()
+	},
+}
+
+// filterDepsByType keeps the edges of depType, or all of them if it's empty
+func filterDepsByType(deps []*types.IssueWithDependencyMetadata, depType types.DependencyType) []*types.IssueWithDependencyMetadata {
+	filtered := []*types.IssueWithDependencyMetadata{}
+	for _, dep := range deps {
+		if depType == "" || dep.DependencyType == depType {
+			filtered = append(filtered, dep)
+		}
+	}
+	return filtered
+}
+
+func printDepEdges(heading string, deps []*types.IssueWithDependencyMetadata) {
+	fmt.Printf("\n%s\n", heading)
+	if len(deps) == 0 {
+		fmt.Println("  (none)")
+		return
+	}
+	for _, dep := range deps {
+		fmt.Printf("  %s [%s] [P%d] %s - %s\n", displayID(dep.ID), dep.DependencyType, dep.Priority, dep.Status, dep.Title)
+	}
+}
+
 var depTreeCmd = &cobra.Command{
 	Use:   "tree [issue-id]",
 	Short: "Show dependency tree",
+	Long: `Show the dependency tree of an issue: what it depends on, or with
+--reverse, what depends on it.
+
+With --children, show the parent-child hierarchy below the issue instead
+(e.g. an epic's tasks and their subtasks), with each issue's 'blocks'
+edges listed under it as ↳ leaves. --json then returns a nested structure.
+
+EXAMPLES:
+  bd dep tree bd-a3f8
+  bd dep tree bd-a3f8 --children --depth 2`,
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
@@ -279,7 +477,7 @@ var depTreeCmd = &cobra.Command{
 		// If daemon is running but doesn't support this command, use direct storage
 		if daemonClient != nil && store == nil {
 			var err error
-			store, err = sqlite.New(dbPath)
+			store, err = openStore(dbPath)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
 				os.Exit(1)
@@ -291,11 +489,24 @@ var depTreeCmd = &cobra.Command{
 		maxDepth, _ := cmd.Flags().GetInt("max-depth")
 		reverse, _ := cmd.Flags().GetBool("reverse")
 		formatStr, _ := cmd.Flags().GetString("format")
+		children, _ := cmd.Flags().GetBool("children")
+		if cmd.Flags().Changed("depth") {
+			maxDepth, _ = cmd.Flags().GetInt("depth")
+		}
 
 		if maxDepth < 1 {
 			fmt.Fprintf(os.Stderr, "Error: --max-depth must be >= 1\n")
 			os.Exit(1)
 		}
+
+		if children {
+			if reverse || formatStr != "" {
+				fmt.Fprintf(os.Stderr, "Error: --children can't be combined with --reverse or --format\n")
+				os.Exit(1)
+			}
+			showChildTree(ctx, fullID, maxDepth)
+			return
+		}
 		
 		tree, err := store.GetDependencyTree(ctx, fullID, maxDepth, showAllPaths, reverse)
 		if err != nil {
@@ -341,7 +552,7 @@ var depTreeCmd = &cobra.Command{
 				indent += "  "
 			}
 			line := fmt.Sprintf("%s→ %s: %s [P%d] (%s)",
-				indent, node.ID, node.Title, node.Priority, node.Status)
+				indent, displayID(node.ID), node.Title, node.Priority, node.Status)
 			if node.Truncated {
 				line += " … [truncated]"
 				hasTruncation = true
@@ -361,11 +572,24 @@ var depTreeCmd = &cobra.Command{
 var depCyclesCmd = &cobra.Command{
 	Use:   "cycles",
 	Short: "Detect dependency cycles",
+	Long: `Detect every dependency cycle in the graph.
+
+Runs strongly connected component analysis over all dependency records and
+lists each cycle with its member issues and the edges that form it.
+
+Exits with status 1 if any cycle is found, so it can be used in CI.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		depTypeStr, _ := cmd.Flags().GetString("type")
+		depType := types.DependencyType(depTypeStr)
+		if depType != "" && !depType.IsValid() {
+			fmt.Fprintf(os.Stderr, "Error: invalid dependency type '%s'. Valid values: blocks, related, parent-child, discovered-from\n", depTypeStr)
+			os.Exit(1)
+		}
+
 		// If daemon is running but doesn't support this command, use direct storage
 		if daemonClient != nil && store == nil {
 			var err error
-			store, err = sqlite.New(dbPath)
+			store, err = openStore(dbPath)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
 				os.Exit(1)
@@ -374,18 +598,35 @@ var depCyclesCmd = &cobra.Command{
 		}
 
 		ctx := context.Background()
-		cycles, err := store.DetectCycles(ctx)
+		records, err := store.GetAllDependencyRecords(ctx)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
-		if jsonOutput {
-			// Always output array, even if empty
-			if cycles == nil {
-				cycles = [][]*types.Issue{}
+		cycles := []*depCycle{}
+		for _, members := range findCycleComponents(records, depType) {
+			cycle := &depCycle{Edges: cycleEdges(records, members, depType)}
+			for _, id := range members {
+				issue, err := store.GetIssue(ctx, id)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: failed to get issue %s: %v\n", id, err)
+					os.Exit(1)
+				}
+				if issue == nil {
+					// Edge points at an issue that no longer exists
+					issue = &types.Issue{ID: id}
+				}
+				cycle.Members = append(cycle.Members, issue)
 			}
+			cycles = append(cycles, cycle)
+		}
+
+		if jsonOutput {
 			outputJSON(cycles)
+			if len(cycles) > 0 {
+				os.Exit(1)
+			}
 			return
 		}
 
@@ -399,14 +640,161 @@ var depCyclesCmd = &cobra.Command{
 		fmt.Printf("\n%s Found %d dependency cycles:\n\n", red("⚠"), len(cycles))
 		for i, cycle := range cycles {
 			fmt.Printf("%d. Cycle involving:\n", i+1)
-			for _, issue := range cycle {
-				fmt.Printf("   - %s: %s\n", issue.ID, issue.Title)
+			for _, issue := range cycle.Members {
+				fmt.Printf("   - %s: %s\n", displayID(issue.ID), issue.Title)
+			}
+			fmt.Printf("   Edges:\n")
+			for _, edge := range cycle.Edges {
+				fmt.Printf("   %s → %s (%s)\n", displayID(edge.IssueID), displayID(edge.DependsOnID), string(edge.Type))
 			}
 			fmt.Println()
 		}
+		os.Exit(1)
+	},
+}
+
+var depWhyCmd = &cobra.Command{
+	Use:   "why <from> <to>",
+	Short: "Explain how one issue depends on another",
+	Long: `Show the shortest dependency path from <from> to <to>, with the type of
+each edge along it, or report that they are not connected. The search
+follows every dependency type unless --type restricts it, so it explains
+blocks chains as well as parent-child and other links.
+
+If <from> doesn't depend on <to> but <to> depends on <from>, that path is
+shown instead. Cycles in the graph are safe; --max-depth bounds the
+search.
+
+Useful for finding out why 'bd ready' won't list an issue: 'bd dep why'
+from it to a suspected blocker shows the chain that links them.
+
+Examples:
+  bd dep why bd-a3f8 bd-91cc
+  bd dep why bd-a3f8 bd-91cc --type blocks,parent-child --json`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		typeFlags, _ := cmd.Flags().GetStringSlice("type")
+		maxDepth, _ := cmd.Flags().GetInt("max-depth")
+		var depTypes []types.DependencyType
+		for _, t := range typeFlags {
+			depType := types.DependencyType(strings.TrimSpace(t))
+			if !depType.IsValid() {
+				fmt.Fprintf(os.Stderr, "Error: invalid dependency type '%s'. Valid values: blocks, related, parent-child, discovered-from\n", t)
+				os.Exit(1)
+			}
+			depTypes = append(depTypes, depType)
+		}
+		if maxDepth < 1 {
+			fmt.Fprintf(os.Stderr, "Error: --max-depth must be at least 1\n")
+			os.Exit(1)
+		}
+
+		// If daemon is running but doesn't support this command, use direct storage
+		if daemonClient != nil && store == nil {
+			var err error
+			store, err = openStore(dbPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+				os.Exit(1)
+			}
+			defer func() { _ = store.Close() }()
+		}
+
+		ctx := context.Background()
+		var ids [2]string
+		for i, arg := range args {
+			id, err := utils.ResolvePartialID(ctx, store, arg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", arg, err)
+				os.Exit(1)
+			}
+			ids[i] = id
+		}
+		if ids[0] == ids[1] {
+			fmt.Fprintf(os.Stderr, "Error: %s and %s are the same issue\n", args[0], args[1])
+			os.Exit(1)
+		}
+
+		records, err := store.GetAllDependencyRecords(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		why := explainDependency(records, ids[0], ids[1], depTypes, maxDepth)
+
+		if jsonOutput {
+			outputJSON(why)
+			return
+		}
+
+		if !why.Connected {
+			fmt.Printf("\n%s and %s are not connected (searched %d levels)\n\n", why.From, why.To, maxDepth)
+			return
+		}
+		cyan := color.New(color.FgCyan).SprintFunc()
+		from, to := why.From, why.To
+		if why.Reverse {
+			from, to = to, from
+			fmt.Printf("\n%s does not depend on %s, but the reverse holds:\n", why.From, why.To)
+		}
+		hops := "hops"
+		if len(why.Path) == 1 {
+			hops = "hop"
+		}
+		fmt.Printf("\n%s %s depends on %s (%d %s):\n\n", cyan("🔗"), from, to, len(why.Path), hops)
+		for _, dep := range why.Path {
+			title := ""
+			if issue, err := store.GetIssue(ctx, dep.DependsOnID); err == nil && issue != nil {
+				title = issue.Title
+			}
+			fmt.Printf("  %s → %s (%s)  %s\n", dep.IssueID, dep.DependsOnID, string(dep.Type), title)
+		}
+		fmt.Println()
 	},
 }
 
+// showChildTree prints the parent-child hierarchy below rootID
+func showChildTree(ctx context.Context, rootID string, maxDepth int) {
+	issues, allDeps, err := loadChildTreeData(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	root := buildChildTree(rootID, issues, allDeps, maxDepth)
+	if root == nil {
+		fmt.Fprintf(os.Stderr, "Error: issue %s not found\n", rootID)
+		os.Exit(1)
+	}
+	if jsonOutput {
+		outputJSON(root)
+		return
+	}
+
+	cyan := color.New(color.FgCyan).SprintFunc()
+	fmt.Printf("\n%s Hierarchy for %s:\n\n", cyan("🌲"), rootID)
+	renderChildTree(os.Stdout, root, issues)
+	fmt.Println()
+}
+
+// loadChildTreeData reads the issues and dependency records buildChildTree
+// walks
+func loadChildTreeData(ctx context.Context) (map[string]*types.Issue, map[string][]*types.Dependency, error) {
+	allIssues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil, nil, err
+	}
+	issues := make(map[string]*types.Issue, len(allIssues))
+	for _, issue := range allIssues {
+		issues[issue.ID] = issue
+	}
+	allDeps, err := store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return issues, allDeps, nil
+}
+
 // outputMermaidTree outputs a dependency tree in Mermaid.js flowchart format
 func outputMermaidTree(tree []*types.TreeNode, rootID string) {
 	if len(tree) == 0 {
@@ -460,6 +848,8 @@ func getStatusEmoji(status types.Status) string {
 
 func init() {
 	depAddCmd.Flags().StringP("type", "t", "blocks", "Dependency type (blocks|related|parent-child|discovered-from)")
+	depAddCmd.Flags().Int("weight", 0, "Raise the dependent up to N priority levels toward the blocker (default: priority_propagation config)")
+	depAddCmd.Flags().String("bulk-blocks", "", "Make this issue depend on every blocker ID given as an argument, in one transaction")
 	// Note: --json flag is defined as a persistent flag in main.go, not here
 
 	// Note: --json flag is defined as a persistent flag in main.go, not here
@@ -468,13 +858,22 @@ func init() {
 	depTreeCmd.Flags().IntP("max-depth", "d", 50, "Maximum tree depth to display (safety limit)")
 	depTreeCmd.Flags().Bool("reverse", false, "Show dependent tree (what was discovered from this) instead of dependency tree (what blocks this)")
 	depTreeCmd.Flags().String("format", "", "Output format: 'mermaid' for Mermaid.js flowchart")
+	depTreeCmd.Flags().Bool("children", false, "Show the parent-child hierarchy below the issue, with blocking edges as annotations")
+	depTreeCmd.Flags().Int("depth", 0, "Limit recursion to N levels (same as --max-depth)")
+
+	depListCmd.Flags().StringP("type", "t", "", "Only list dependencies of this type (blocks|related|parent-child|discovered-from)")
+	depCyclesCmd.Flags().StringP("type", "t", "", "Only consider dependencies of this type (blocks|related|parent-child|discovered-from)")
+	depWhyCmd.Flags().StringSliceP("type", "t", nil, "Only follow dependencies of these types (comma-separated: blocks,related,parent-child,discovered-from)")
+	depWhyCmd.Flags().Int("max-depth", 50, "Maximum path length to search")
 	// Note: --json flag is defined as a persistent flag in main.go, not here
 
 	// Note: --json flag is defined as a persistent flag in main.go, not here
 
 	depCmd.AddCommand(depAddCmd)
 	depCmd.AddCommand(depRemoveCmd)
+	depCmd.AddCommand(depListCmd)
 	depCmd.AddCommand(depTreeCmd)
 	depCmd.AddCommand(depCyclesCmd)
+	depCmd.AddCommand(depWhyCmd)
 	rootCmd.AddCommand(depCmd)
 }
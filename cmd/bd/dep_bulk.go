@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// depBulkBlocksResult is what bd dep add --bulk-blocks added
+type depBulkBlocksResult struct {
+	*depImportResult
+	PriorityChanges []*types.PriorityChange `json:"priority_changes,omitempty"`
+}
+
+// addBulkBlocks makes target depend on every blocker through blocks edges,
+// in one transaction as bd dep import does: existing edges are skipped, and
+// an invalid edge, such as one closing a cycle, adds none of them. Priority
+// then propagates from each new blocker as with bd dep add; weight < 0 uses
+// the priority_propagation config.
+func addBulkBlocks(ctx context.Context, s storage.Storage, target string, blockers []string, weight int) (*depBulkBlocksResult, error) {
+	edges := make([]depImportEdge, 0, len(blockers))
+	for _, blocker := range blockers {
+		edges = append(edges, depImportEdge{
+			From:  target,
+			To:    blocker,
+			Type:  string(types.DepBlocks),
+			Where: fmt.Sprintf("blocker %s", blocker),
+		})
+	}
+	imported, err := importDependencies(ctx, s, edges, false)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &depBulkBlocksResult{depImportResult: imported}
+	for _, dep := range imported.Added {
+		change, err := s.PropagatePriority(ctx, dep.IssueID, dep.DependsOnID, weight, actor)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: dependency added but priority propagation failed: %v\n", err)
+			continue
+		}
+		if change != nil {
+			result.PriorityChanges = append(result.PriorityChanges, change)
+		}
+	}
+	return result, nil
+}
+
+// runDepBulkBlocks is bd dep add --bulk-blocks, exiting on failure
+func runDepBulkBlocks(target string, blockers []string, weight *int) {
+	// One transaction across all the edges needs the database itself
+	if err := ensureDirectMode("dep add --bulk-blocks adds every edge in one transaction"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	ctx := context.Background()
+
+	w := -1
+	if weight != nil {
+		w = *weight
+	}
+	result, err := addBulkBlocks(ctx, store, target, blockers, w)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "No dependencies were added.\n")
+		os.Exit(1)
+	}
+	if len(result.Added) > 0 {
+		markDirtyAndScheduleFlush()
+	}
+
+	if jsonOutput {
+		outputJSON(result)
+		return
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	for _, dep := range result.Added {
+		fmt.Printf("%s Added dependency: %s depends on %s (blocks)\n", green("✓"), dep.IssueID, dep.DependsOnID)
+	}
+	for _, dep := range result.Skipped {
+		fmt.Printf("  Skipped %s: %s already depends on it\n", dep.DependsOnID, dep.IssueID)
+	}
+	for _, change := range result.PriorityChanges {
+		printPriorityChange(change)
+	}
+	fmt.Printf("Added %d blocker(s), skipped %d that already exist\n", len(result.Added), len(result.Skipped))
+}
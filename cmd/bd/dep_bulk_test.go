@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestAddBulkBlocks(t *testing.T) {
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	ctx := context.Background()
+	for i := 1; i <= 5; i++ {
+		issue := &types.Issue{ID: fmt.Sprintf("test-%d", i), Title: "Task", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: time.Now()}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, dep := range []*types.Dependency{
+		{IssueID: "test-1", DependsOnID: "test-2", Type: types.DepBlocks},
+		{IssueID: "test-5", DependsOnID: "test-1", Type: types.DepBlocks},
+	} {
+		if err := s.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	blockersOf := func(id string) int {
+		deps, err := s.GetDependencyRecords(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(deps)
+	}
+
+	t.Run("cycle rolls back", func(t *testing.T) {
+		_, err := addBulkBlocks(ctx, s, "test-1", []string{"test-3", "test-5"}, 0)
+		if err == nil || !strings.Contains(err.Error(), "blocker test-5") || !strings.Contains(err.Error(), "cycle") {
+			t.Fatalf("Expected a cycle error naming blocker test-5, got %v", err)
+		}
+		if n := blockersOf("test-1"); n != 1 {
+			t.Errorf("Failed batch left dependencies behind: test-1 has %d, want 1", n)
+		}
+	})
+
+	t.Run("add", func(t *testing.T) {
+		result, err := addBulkBlocks(ctx, s, "1", []string{"test-2", "3", "test-4"}, 0) // Partial IDs
+		if err != nil {
+			t.Fatalf("addBulkBlocks failed: %v", err)
+		}
+		if len(result.Added) != 2 || len(result.Skipped) != 1 || result.Skipped[0].DependsOnID != "test-2" {
+			t.Errorf("Expected test-3 and test-4 added and test-2 skipped, got %+v", result.depImportResult)
+		}
+		for _, dep := range result.Added {
+			if dep.IssueID != "test-1" || dep.Type != types.DepBlocks {
+				t.Errorf("Expected test-1 blocks dependencies, got %+v", dep)
+			}
+		}
+		if n := blockersOf("test-1"); n != 3 {
+			t.Errorf("Expected test-1 to have 3 blockers, have %d", n)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// depCycle is one strongly connected component of the dependency graph that
+// contains a cycle, along with the edges that form it.
+type depCycle struct {
+	Members []*types.Issue      `json:"members"`
+	Edges   []*types.Dependency `json:"edges"`
+}
+
+// findCycleComponents runs Tarjan's strongly connected components algorithm
+// over the dependency records and returns every component that contains a cycle.
+// A component contains a cycle if it has more than one member, or if its single
+// member depends on itself. If depType is non-empty, only edges of that type are
+// considered. Member IDs within each component, and the components themselves,
+// are sorted so output is deterministic.
+func findCycleComponents(records map[string][]*types.Dependency, depType types.DependencyType) [][]string {
+	adj := make(map[string][]string)
+	var nodes []string
+	seenNode := make(map[string]bool)
+	addNode := func(id string) {
+		if !seenNode[id] {
+			seenNode[id] = true
+			nodes = append(nodes, id)
+		}
+	}
+	selfLoop := make(map[string]bool)
+
+	for _, deps := range records {
+		for _, dep := range deps {
+			if depType != "" && dep.Type != depType {
+				continue
+			}
+			addNode(dep.IssueID)
+			addNode(dep.DependsOnID)
+			adj[dep.IssueID] = append(adj[dep.IssueID], dep.DependsOnID)
+			if dep.IssueID == dep.DependsOnID {
+				selfLoop[dep.IssueID] = true
+			}
+		}
+	}
+	sort.Strings(nodes)
+	for id := range adj {
+		sort.Strings(adj[id])
+	}
+
+	index := 0
+	indices := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+
+	var strongConnect func(v string)
+	strongConnect = func(v string) {
+		indices[v] = index
+		lowlink[v] = index
+		index++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range adj[v] {
+			if _, visited := indices[w]; !visited {
+				strongConnect(w)
+				if lowlink[w] < lowlink[v] {
+					lowlink[v] = lowlink[w]
+				}
+			} else if onStack[w] && indices[w] < lowlink[v] {
+				lowlink[v] = indices[w]
+			}
+		}
+
+		if lowlink[v] == indices[v] {
+			var component []string
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				component = append(component, w)
+				if w == v {
+					break
+				}
+			}
+			if len(component) > 1 || selfLoop[v] {
+				sort.Strings(component)
+				components = append(components, component)
+			}
+		}
+	}
+
+	for _, v := range nodes {
+		if _, visited := indices[v]; !visited {
+			strongConnect(v)
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		return components[i][0] < components[j][0]
+	})
+	return components
+}
+
+// cycleEdges returns the dependency records whose endpoints are both members of
+// the given component, restricted to depType if non-empty.
+func cycleEdges(records map[string][]*types.Dependency, members []string, depType types.DependencyType) []*types.Dependency {
+	inComponent := make(map[string]bool, len(members))
+	for _, id := range members {
+		inComponent[id] = true
+	}
+
+	var edges []*types.Dependency
+	for _, id := range members {
+		for _, dep := range records[id] {
+			if depType != "" && dep.Type != depType {
+				continue
+			}
+			if inComponent[dep.DependsOnID] {
+				edges = append(edges, dep)
+			}
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].IssueID != edges[j].IssueID {
+			return edges[i].IssueID < edges[j].IssueID
+		}
+		return edges[i].DependsOnID < edges[j].DependsOnID
+	})
+	return edges
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFindCycleComponents(t *testing.T) {
+	records := map[string][]*types.Dependency{
+		"bd-1": {
+			{IssueID: "bd-1", DependsOnID: "bd-2", Type: types.DepBlocks},
+		},
+		"bd-2": {
+			{IssueID: "bd-2", DependsOnID: "bd-3", Type: types.DepBlocks},
+		},
+		"bd-3": {
+			{IssueID: "bd-3", DependsOnID: "bd-1", Type: types.DepRelated},
+			{IssueID: "bd-3", DependsOnID: "bd-4", Type: types.DepBlocks},
+		},
+		"bd-5": {
+			{IssueID: "bd-5", DependsOnID: "bd-6", Type: types.DepBlocks},
+		},
+		"bd-6": {
+			{IssueID: "bd-6", DependsOnID: "bd-5", Type: types.DepBlocks},
+		},
+		"bd-7": {
+			{IssueID: "bd-7", DependsOnID: "bd-7", Type: types.DepBlocks},
+		},
+	}
+
+	t.Run("all types", func(t *testing.T) {
+		got := findCycleComponents(records, "")
+		want := [][]string{{"bd-1", "bd-2", "bd-3"}, {"bd-5", "bd-6"}, {"bd-7"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("findCycleComponents() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("blocks only", func(t *testing.T) {
+		got := findCycleComponents(records, types.DepBlocks)
+		want := [][]string{{"bd-5", "bd-6"}, {"bd-7"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("findCycleComponents() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("no cycles", func(t *testing.T) {
+		acyclic := map[string][]*types.Dependency{
+			"bd-1": {{IssueID: "bd-1", DependsOnID: "bd-2", Type: types.DepBlocks}},
+		}
+		if got := findCycleComponents(acyclic, ""); len(got) != 0 {
+			t.Errorf("expected no cycles, got %v", got)
+		}
+	})
+}
+
+func TestCycleEdges(t *testing.T) {
+	records := map[string][]*types.Dependency{
+		"bd-1": {{IssueID: "bd-1", DependsOnID: "bd-2", Type: types.DepBlocks}},
+		"bd-2": {
+			{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepRelated},
+			{IssueID: "bd-2", DependsOnID: "bd-9", Type: types.DepBlocks},
+		},
+	}
+
+	edges := cycleEdges(records, []string{"bd-1", "bd-2"}, "")
+	if len(edges) != 2 {
+		t.Fatalf("expected 2 edges inside the cycle, got %d", len(edges))
+	}
+	if edges[0].IssueID != "bd-1" || edges[1].Type != types.DepRelated {
+		t.Errorf("unexpected edges: %+v %+v", edges[0], edges[1])
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// childTreeNode is an issue in the parent-child hierarchy shown by
+// bd dep tree --children, with its blocking edges attached as annotations
+type childTreeNode struct {
+	*types.Issue
+	Blocks    []string         `json:"blocks,omitempty"`     // Issues this one blocks
+	BlockedBy []string         `json:"blocked_by,omitempty"` // Issues blocking this one
+	Children  []*childTreeNode `json:"children"`
+	Truncated bool             `json:"truncated,omitempty"` // Children hidden by --max-depth
+	Repeated  bool             `json:"repeated,omitempty"`  // Already shown elsewhere in the tree; children omitted
+}
+
+// buildChildTree walks the parent-child hierarchy below rootID, down to
+// maxDepth levels of children. allDeps maps issue IDs to their dependency
+// records. An issue reached a second time (several parents, or a cycle
+// imported around AddDependency's checks) is marked Repeated and not expanded.
+func buildChildTree(rootID string, issues map[string]*types.Issue, allDeps map[string][]*types.Dependency, maxDepth int) *childTreeNode {
+	children := make(map[string][]string)
+	blocks := make(map[string][]string)
+	for issueID, deps := range allDeps {
+		for _, dep := range deps {
+			switch dep.Type {
+			case types.DepParentChild:
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], issueID)
+			case types.DepBlocks:
+				blocks[dep.DependsOnID] = append(blocks[dep.DependsOnID], issueID)
+			}
+		}
+	}
+
+	visited := make(map[string]bool)
+	var walk func(id string, depth int) *childTreeNode
+	walk = func(id string, depth int) *childTreeNode {
+		issue, ok := issues[id]
+		if !ok {
+			return nil
+		}
+		node := &childTreeNode{Issue: issue, Children: []*childTreeNode{}}
+		for _, dep := range allDeps[id] {
+			if dep.Type == types.DepBlocks {
+				node.BlockedBy = append(node.BlockedBy, dep.DependsOnID)
+			}
+		}
+		node.Blocks = append(node.Blocks, blocks[id]...)
+		sort.Strings(node.Blocks)
+		sort.Strings(node.BlockedBy)
+
+		if visited[id] {
+			node.Repeated = true
+			return node
+		}
+		visited[id] = true
+
+		kids := append([]string(nil), children[id]...)
+		sort.Strings(kids)
+		if len(kids) > 0 && depth >= maxDepth {
+			node.Truncated = true
+			return node
+		}
+		for _, kid := range kids {
+			if child := walk(kid, depth+1); child != nil {
+				node.Children = append(node.Children, child)
+			}
+		}
+		return node
+	}
+	return walk(rootID, 0)
+}
+
+// renderChildTree prints the hierarchy as an indented ASCII tree with status
+// glyphs, listing each issue's blocking edges as ↳ leaves under it
+func renderChildTree(w io.Writer, root *childTreeNode, issues map[string]*types.Issue) {
+	var render func(node *childTreeNode, linePrefix, childPrefix string)
+	render = func(node *childTreeNode, linePrefix, childPrefix string) {
+		line := fmt.Sprintf("%s%s %s: %s [P%d]", linePrefix, getStatusEmoji(node.Status), node.ID, node.Title, node.Priority)
+		if node.Repeated {
+			line += " (shown above)"
+		}
+		if node.Truncated {
+			line += " … [truncated]"
+		}
+		fmt.Fprintln(w, line)
+
+		// Annotations sit above the children, continuing the branch line
+		annotationPrefix := childPrefix
+		if len(node.Children) > 0 {
+			annotationPrefix += "│ "
+		} else {
+			annotationPrefix += "  "
+		}
+		for _, id := range node.Blocks {
+			fmt.Fprintf(w, "%s↳ blocks %s\n", annotationPrefix, describeTreeRef(id, issues))
+		}
+		for _, id := range node.BlockedBy {
+			fmt.Fprintf(w, "%s↳ blocked by %s\n", annotationPrefix, describeTreeRef(id, issues))
+		}
+
+		for i, child := range node.Children {
+			if i == len(node.Children)-1 {
+				render(child, childPrefix+"└── ", childPrefix+"    ")
+			} else {
+				render(child, childPrefix+"├── ", childPrefix+"│   ")
+			}
+		}
+	}
+	render(root, "", "")
+}
+
+// describeTreeRef renders an annotated edge target as "id (status)"
+func describeTreeRef(id string, issues map[string]*types.Issue) string {
+	if issue, ok := issues[id]; ok {
+		return fmt.Sprintf("%s (%s)", id, issue.Status)
+	}
+	return id
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func hierarchyFixture() (map[string]*types.Issue, map[string][]*types.Dependency) {
+	issues := map[string]*types.Issue{}
+	for _, issue := range []*types.Issue{
+		{ID: "bd-1", Title: "Epic", Status: types.StatusOpen, Priority: 1},
+		{ID: "bd-1.1", Title: "Backend", Status: types.StatusClosed, Priority: 2},
+		{ID: "bd-1.2", Title: "Frontend", Status: types.StatusInProgress, Priority: 2},
+		{ID: "bd-1.1.1", Title: "Schema", Status: types.StatusClosed, Priority: 3},
+	} {
+		issues[issue.ID] = issue
+	}
+	deps := map[string][]*types.Dependency{
+		"bd-1.1":   {{IssueID: "bd-1.1", DependsOnID: "bd-1", Type: types.DepParentChild}},
+		"bd-1.2":   {{IssueID: "bd-1.2", DependsOnID: "bd-1", Type: types.DepParentChild}, {IssueID: "bd-1.2", DependsOnID: "bd-1.1", Type: types.DepBlocks}},
+		"bd-1.1.1": {{IssueID: "bd-1.1.1", DependsOnID: "bd-1.1", Type: types.DepParentChild}},
+	}
+	return issues, deps
+}
+
+func TestBuildChildTree(t *testing.T) {
+	issues, deps := hierarchyFixture()
+
+	root := buildChildTree("bd-1", issues, deps, 50)
+	if root == nil || len(root.Children) != 2 {
+		t.Fatalf("expected epic with 2 children, got %+v", root)
+	}
+	backend, frontend := root.Children[0], root.Children[1]
+	if backend.ID != "bd-1.1" || len(backend.Children) != 1 || backend.Children[0].ID != "bd-1.1.1" {
+		t.Errorf("unexpected backend subtree: %+v", backend)
+	}
+	if len(backend.Blocks) != 1 || backend.Blocks[0] != "bd-1.2" {
+		t.Errorf("expected backend to block bd-1.2, got %v", backend.Blocks)
+	}
+	if len(frontend.BlockedBy) != 1 || frontend.BlockedBy[0] != "bd-1.1" {
+		t.Errorf("expected frontend blocked by bd-1.1, got %v", frontend.BlockedBy)
+	}
+
+	// --depth 1 stops below the epic's direct children
+	root = buildChildTree("bd-1", issues, deps, 1)
+	if backend := root.Children[0]; len(backend.Children) != 0 || !backend.Truncated {
+		t.Errorf("expected backend truncated at depth 1, got %+v", backend)
+	}
+
+	if buildChildTree("bd-missing", issues, deps, 50) != nil {
+		t.Error("expected nil tree for unknown root")
+	}
+}
+
+func TestBuildChildTreeCycleSafe(t *testing.T) {
+	issues, deps := hierarchyFixture()
+	// A cycle that bypassed AddDependency (e.g. from a hand-edited JSONL)
+	deps["bd-1"] = []*types.Dependency{{IssueID: "bd-1", DependsOnID: "bd-1.1.1", Type: types.DepParentChild}}
+
+	root := buildChildTree("bd-1", issues, deps, 50)
+	schema := root.Children[0].Children[0]
+	if len(schema.Children) != 1 || !schema.Children[0].Repeated || len(schema.Children[0].Children) != 0 {
+		t.Fatalf("expected the epic to reappear once as a repeated leaf, got %+v", schema.Children)
+	}
+}
+
+func TestRenderChildTree(t *testing.T) {
+	issues, deps := hierarchyFixture()
+	var buf bytes.Buffer
+	renderChildTree(&buf, buildChildTree("bd-1", issues, deps, 50), issues)
+
+	want := strings.Join([]string{
+		"☐ bd-1: Epic [P1]",
+		"├── ☑ bd-1.1: Backend [P2]",
+		"│   │ ↳ blocks bd-1.2 (in_progress)",
+		"│   └── ☑ bd-1.1.1: Schema [P3]",
+		"└── ◧ bd-1.2: Frontend [P2]",
+		"      ↳ blocked by bd-1.1 (closed)",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("renderChildTree output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
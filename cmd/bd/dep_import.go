@@ -0,0 +1,298 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var depImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Add dependencies in bulk from a CSV or JSON edge list",
+	Long: `Add every dependency listed in a file, in one transaction: either all are
+added or, if any row is invalid, none are.
+
+CSV rows are from,to[,type]: from depends on to, and type defaults to
+blocks. A header row naming the columns (from, to, type, or issue_id,
+depends_on_id, type) is optional, and lines starting with # are skipped.
+JSON is an array of {"from", "to", "type"} objects; issue_id and
+depends_on_id work too, so 'bd dep list --json' output can be re-imported.
+The format follows the file extension unless --format is given; use - to
+read standard input.
+
+Both ends may be partial IDs. Each edge is checked as 'bd dep add' checks
+it, including that it wouldn't create a cycle with the existing graph or
+with the edges before it. Edges that already exist are skipped. Priority
+propagation (priority_propagation) is not applied.
+
+Examples:
+  bd dep import plan.csv --dry-run
+  bd dep import edges.json --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		// One transaction across all the edges needs the database itself
+		if err := ensureDirectMode("dep import adds every edge in one transaction"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		ctx := context.Background()
+
+		edges, err := readDepImportFile(args[0], format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		result, err := importDependencies(ctx, store, edges, dryRun)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			fmt.Fprintf(os.Stderr, "No dependencies were added.\n")
+			os.Exit(1)
+		}
+		if !dryRun && len(result.Added) > 0 {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+		if dryRun {
+			fmt.Println(color.YellowString("DRY RUN - no changes will be made"))
+			for _, dep := range result.Added {
+				fmt.Printf("  %s depends on %s (%s)\n", dep.IssueID, dep.DependsOnID, dep.Type)
+			}
+			fmt.Printf("Would add %d dependencies, skipping %d that already exist\n", len(result.Added), len(result.Skipped))
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Added %d dependencies, skipped %d that already exist\n", green("✓"), len(result.Added), len(result.Skipped))
+	},
+}
+
+// depImportEdge is one edge read from a dependency import file
+type depImportEdge struct {
+	Row  int // 1-based line (CSV) or array element (JSON), for errors
+	From string
+	To   string
+	Type string
+	// Where names the edge in errors instead of Row, e.g. "blocker 2"
+	Where string
+}
+
+// where names the edge in errors, e.g. "row 3"
+func (e depImportEdge) where() string {
+	if e.Where != "" {
+		return e.Where
+	}
+	return fmt.Sprintf("row %d", e.Row)
+}
+
+// depImportResult is what bd dep import added, or would add with --dry-run
+type depImportResult struct {
+	Added   []*types.Dependency `json:"added"`
+	Skipped []*types.Dependency `json:"skipped"` // Already present
+	DryRun  bool                `json:"dry_run,omitempty"`
+}
+
+// readDepImportFile reads the edges in path ("-" for stdin) as format, or
+// as the file extension suggests: JSON for .json, CSV otherwise
+func readDepImportFile(path, format string) ([]depImportEdge, error) {
+	if format == "" {
+		format = "csv"
+		if strings.EqualFold(filepath.Ext(path), ".json") {
+			format = "json"
+		}
+	}
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		// #nosec G304 - user-provided import file
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+	switch format {
+	case "csv":
+		return parseDepImportCSV(r)
+	case "json":
+		return parseDepImportJSON(r)
+	}
+	return nil, fmt.Errorf("invalid --format %q (use csv or json)", format)
+}
+
+// parseDepImportCSV reads from,to[,type] rows, with an optional header row
+// naming the columns
+func parseDepImportCSV(r io.Reader) ([]depImportEdge, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	from, to, typ := 0, 1, 2
+	var edges []depImportEdge
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if first && isDepImportHeader(record) {
+			from, to, typ = -1, -1, -1
+			for i, name := range record {
+				switch strings.ToLower(strings.TrimSpace(name)) {
+				case "from", "issue_id":
+					from = i
+				case "to", "depends_on_id":
+					to = i
+				case "type":
+					typ = i
+				}
+			}
+			if from < 0 || to < 0 {
+				return nil, fmt.Errorf("line %d: header must name from and to columns", line)
+			}
+			continue
+		}
+		if from >= len(record) || to >= len(record) {
+			return nil, fmt.Errorf("line %d: expected from,to[,type], got %d field(s)", line, len(record))
+		}
+		edge := depImportEdge{Row: line, From: strings.TrimSpace(record[from]), To: strings.TrimSpace(record[to])}
+		if typ >= 0 && typ < len(record) {
+			edge.Type = strings.TrimSpace(record[typ])
+		}
+		edges = append(edges, edge)
+	}
+	return edges, nil
+}
+
+// isDepImportHeader reports whether a CSV row names columns rather than issues
+func isDepImportHeader(record []string) bool {
+	for _, cell := range record {
+		switch strings.ToLower(strings.TrimSpace(cell)) {
+		case "from", "to", "issue_id", "depends_on_id":
+			return true
+		}
+	}
+	return false
+}
+
+// parseDepImportJSON reads an array of edge objects
+func parseDepImportJSON(r io.Reader) ([]depImportEdge, error) {
+	var rows []struct {
+		From        string `json:"from"`
+		To          string `json:"to"`
+		IssueID     string `json:"issue_id"`
+		DependsOnID string `json:"depends_on_id"`
+		Type        string `json:"type"`
+	}
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		return nil, fmt.Errorf("invalid JSON (expected an array of {\"from\", \"to\", \"type\"} objects): %w", err)
+	}
+	edges := make([]depImportEdge, 0, len(rows))
+	for i, row := range rows {
+		edge := depImportEdge{Row: i + 1, From: row.From, To: row.To, Type: row.Type}
+		if edge.From == "" {
+			edge.From = row.IssueID
+		}
+		if edge.To == "" {
+			edge.To = row.DependsOnID
+		}
+		edges = append(edges, edge)
+	}
+	return edges, nil
+}
+
+// errDepImportDryRun rolls back a dry run's transaction once every edge
+// has been checked
+var errDepImportDryRun = errors.New("dry run")
+
+// importDependencies resolves and adds edges in one transaction, skipping
+// those already present (or repeated in edges). Any invalid edge fails the
+// whole import. With dryRun every edge is still checked, then rolled back.
+func importDependencies(ctx context.Context, s storage.Storage, edges []depImportEdge, dryRun bool) (*depImportResult, error) {
+	result := &depImportResult{Added: []*types.Dependency{}, Skipped: []*types.Dependency{}, DryRun: dryRun}
+	existing, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dependencies: %w", err)
+	}
+	present := make(map[string]bool)
+	for _, deps := range existing {
+		for _, dep := range deps {
+			present[dep.IssueID+"\x00"+dep.DependsOnID] = true
+		}
+	}
+
+	type rowDep struct {
+		where string
+		dep   *types.Dependency
+	}
+	var toAdd []rowDep
+	for _, edge := range edges {
+		if edge.From == "" || edge.To == "" {
+			return nil, fmt.Errorf("%s: from and to are both required", edge.where())
+		}
+		fromID, err := utils.ResolvePartialID(ctx, s, edge.From)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", edge.where(), err)
+		}
+		toID, err := utils.ResolvePartialID(ctx, s, edge.To)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", edge.where(), err)
+		}
+		depType := types.DepBlocks
+		if edge.Type != "" {
+			depType = types.DependencyType(strings.ToLower(edge.Type))
+		}
+		dep := &types.Dependency{IssueID: fromID, DependsOnID: toID, Type: depType}
+		key := fromID + "\x00" + toID
+		if present[key] {
+			result.Skipped = append(result.Skipped, dep)
+			continue
+		}
+		present[key] = true
+		toAdd = append(toAdd, rowDep{edge.where(), dep})
+	}
+
+	err = s.WithTx(ctx, func(tx storage.Transaction) error {
+		for _, rd := range toAdd {
+			if err := tx.AddDependency(ctx, rd.dep, actor); err != nil {
+				return fmt.Errorf("%s (%s → %s): %w", rd.where, rd.dep.IssueID, rd.dep.DependsOnID, err)
+			}
+			result.Added = append(result.Added, rd.dep)
+		}
+		if dryRun {
+			return errDepImportDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDepImportDryRun) {
+		return nil, err
+	}
+	return result, nil
+}
+
+func init() {
+	depImportCmd.Flags().String("format", "", "Input format: csv or json (default: from the file extension)")
+	depImportCmd.Flags().Bool("dry-run", false, "Check every edge and report what would be added, without adding anything")
+	depCmd.AddCommand(depImportCmd)
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseDepImportCSV(t *testing.T) {
+	input := "from, to, type\n# comment\ntest-1,test-2,blocks\ntest-2, test-3\n"
+	edges, err := parseDepImportCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseDepImportCSV failed: %v", err)
+	}
+	want := []depImportEdge{
+		{Row: 3, From: "test-1", To: "test-2", Type: "blocks"},
+		{Row: 4, From: "test-2", To: "test-3"},
+	}
+	if !reflect.DeepEqual(edges, want) {
+		t.Errorf("got %+v, want %+v", edges, want)
+	}
+
+	// Header columns may come in any order
+	edges, err = parseDepImportCSV(strings.NewReader("type,depends_on_id,issue_id\nrelated,test-2,test-1\n"))
+	if err != nil {
+		t.Fatalf("parseDepImportCSV failed: %v", err)
+	}
+	if len(edges) != 1 || edges[0].From != "test-1" || edges[0].To != "test-2" || edges[0].Type != "related" {
+		t.Errorf("Expected columns mapped by header, got %+v", edges)
+	}
+
+	if _, err := parseDepImportCSV(strings.NewReader("test-1\n")); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("Expected a line 1 error for a one-field row, got %v", err)
+	}
+}
+
+func TestParseDepImportJSON(t *testing.T) {
+	input := `[{"from": "test-1", "to": "test-2"}, {"issue_id": "test-2", "depends_on_id": "test-3", "type": "related"}]`
+	edges, err := parseDepImportJSON(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseDepImportJSON failed: %v", err)
+	}
+	want := []depImportEdge{
+		{Row: 1, From: "test-1", To: "test-2"},
+		{Row: 2, From: "test-2", To: "test-3", Type: "related"},
+	}
+	if !reflect.DeepEqual(edges, want) {
+		t.Errorf("got %+v, want %+v", edges, want)
+	}
+}
+
+func TestImportDependencies(t *testing.T) {
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	ctx := context.Background()
+	for i := 1; i <= 4; i++ {
+		issue := &types.Issue{ID: fmt.Sprintf("test-%d", i), Title: "Task", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: time.Now()}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	existing := &types.Dependency{IssueID: "test-1", DependsOnID: "test-2", Type: types.DepBlocks}
+	if err := s.AddDependency(ctx, existing, "test"); err != nil {
+		t.Fatal(err)
+	}
+	depCount := func() int {
+		all, err := s.GetAllDependencyRecords(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := 0
+		for _, deps := range all {
+			n += len(deps)
+		}
+		return n
+	}
+
+	edges := []depImportEdge{
+		{Row: 1, From: "test-1", To: "test-2"},
+		{Row: 2, From: "2", To: "test-3"}, // Partial ID
+		{Row: 3, From: "test-3", To: "test-4", Type: "related"},
+		{Row: 4, From: "test-2", To: "test-3"}, // Repeated in the file
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		result, err := importDependencies(ctx, s, edges, true)
+		if err != nil {
+			t.Fatalf("importDependencies failed: %v", err)
+		}
+		if len(result.Added) != 2 || len(result.Skipped) != 2 {
+			t.Errorf("Expected 2 added and 2 skipped, got %d and %d", len(result.Added), len(result.Skipped))
+		}
+		if n := depCount(); n != 1 {
+			t.Errorf("Dry run added dependencies: have %d, want 1", n)
+		}
+	})
+
+	t.Run("cycle rolls back", func(t *testing.T) {
+		cyclic := append(edges[1:3:3], depImportEdge{Row: 5, From: "test-3", To: "test-1"})
+		_, err := importDependencies(ctx, s, cyclic, false)
+		if err == nil || !strings.Contains(err.Error(), "row 5") || !strings.Contains(err.Error(), "cycle") {
+			t.Fatalf("Expected a row 5 cycle error, got %v", err)
+		}
+		if n := depCount(); n != 1 {
+			t.Errorf("Failed import left dependencies behind: have %d, want 1", n)
+		}
+	})
+
+	t.Run("unknown issue", func(t *testing.T) {
+		_, err := importDependencies(ctx, s, []depImportEdge{{Row: 7, From: "test-1", To: "test-99"}}, false)
+		if err == nil || !strings.Contains(err.Error(), "row 7") {
+			t.Errorf("Expected a row 7 error, got %v", err)
+		}
+	})
+
+	t.Run("import", func(t *testing.T) {
+		result, err := importDependencies(ctx, s, edges, false)
+		if err != nil {
+			t.Fatalf("importDependencies failed: %v", err)
+		}
+		if len(result.Added) != 2 || len(result.Skipped) != 2 {
+			t.Errorf("Expected 2 added and 2 skipped, got %d and %d", len(result.Added), len(result.Skipped))
+		}
+		if n := depCount(); n != 3 {
+			t.Errorf("Expected 3 dependencies, have %d", n)
+		}
+		deps, err := s.GetDependencyRecords(ctx, "test-3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(deps) != 1 || deps[0].Type != types.DepRelated {
+			t.Errorf("Expected test-3 to have a related dependency, got %+v", deps)
+		}
+	})
+}
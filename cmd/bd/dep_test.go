@@ -207,6 +207,32 @@ func TestDepCommandsInit(t *testing.T) {
 	if depRemoveCmd == nil {
 		t.Fatal("depRemoveCmd should be initialized")
 	}
+
+	if depListCmd == nil {
+		t.Fatal("depListCmd should be initialized")
+	}
+
+	if len(depRemoveCmd.Aliases) == 0 || depRemoveCmd.Aliases[0] != "rm" {
+		t.Errorf("Expected depRemoveCmd to have alias rm, got %v", depRemoveCmd.Aliases)
+	}
+}
+
+func TestFilterDepsByType(t *testing.T) {
+	deps := []*types.IssueWithDependencyMetadata{
+		{Issue: types.Issue{ID: "bd-1"}, DependencyType: types.DepBlocks},
+		{Issue: types.Issue{ID: "bd-2"}, DependencyType: types.DepRelated},
+		{Issue: types.Issue{ID: "bd-3"}, DependencyType: types.DepBlocks},
+	}
+
+	if got := filterDepsByType(deps, ""); len(got) != 3 {
+		t.Errorf("no type: got %d edges, want 3", len(got))
+	}
+	if got := filterDepsByType(deps, types.DepBlocks); len(got) != 2 || got[1].ID != "bd-3" {
+		t.Errorf("blocks: got %d edges, want bd-1 and bd-3", len(got))
+	}
+	if got := filterDepsByType(deps, types.DepDiscoveredFrom); got == nil || len(got) != 0 {
+		t.Errorf("discovered-from: got %v, want an empty list", got)
+	}
 }
 
 func TestDepRemove(t *testing.T) {
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// depWhy is the answer to 'bd dep why': the shortest chain of dependency
+// records from From to To, or from To to From when Reverse is set
+type depWhy struct {
+	From      string              `json:"from"`
+	To        string              `json:"to"`
+	Connected bool                `json:"connected"`
+	Reverse   bool                `json:"reverse,omitempty"` // To depends on From, not the other way round
+	Path      []*types.Dependency `json:"path"`
+}
+
+// findDependencyPath returns the shortest chain of dependency records leading
+// from fromID to toID, breadth-first over every edge type (or only those in
+// depTypes, if any), or nil if toID isn't reachable within maxDepth hops.
+// Each issue is visited once, so cycles can't make the search loop. Edges
+// are followed in ID order, so ties between equally short paths resolve the
+// same way every time.
+func findDependencyPath(records map[string][]*types.Dependency, fromID, toID string, depTypes []types.DependencyType, maxDepth int) []*types.Dependency {
+	allowed := make(map[types.DependencyType]bool, len(depTypes))
+	for _, t := range depTypes {
+		allowed[t] = true
+	}
+
+	via := map[string]*types.Dependency{fromID: nil} // The edge each issue was reached by
+	frontier := []string{fromID}
+	for depth := 0; depth < maxDepth && len(frontier) > 0; depth++ {
+		var next []string
+		for _, id := range frontier {
+			deps := append([]*types.Dependency(nil), records[id]...)
+			sort.Slice(deps, func(i, j int) bool {
+				if deps[i].DependsOnID != deps[j].DependsOnID {
+					return deps[i].DependsOnID < deps[j].DependsOnID
+				}
+				return deps[i].Type < deps[j].Type
+			})
+			for _, dep := range deps {
+				if len(allowed) > 0 && !allowed[dep.Type] {
+					continue
+				}
+				if _, seen := via[dep.DependsOnID]; seen {
+					continue
+				}
+				via[dep.DependsOnID] = dep
+				if dep.DependsOnID == toID {
+					var path []*types.Dependency
+					for at := via[toID]; at != nil; at = via[at.IssueID] {
+						path = append([]*types.Dependency{at}, path...)
+					}
+					return path
+				}
+				next = append(next, dep.DependsOnID)
+			}
+		}
+		frontier = next
+	}
+	return nil
+}
+
+// explainDependency looks for a path from fromID to toID, and failing that
+// from toID to fromID
+func explainDependency(records map[string][]*types.Dependency, fromID, toID string, depTypes []types.DependencyType, maxDepth int) *depWhy {
+	why := &depWhy{From: fromID, To: toID, Path: []*types.Dependency{}}
+	if path := findDependencyPath(records, fromID, toID, depTypes, maxDepth); path != nil {
+		why.Connected, why.Path = true, path
+	} else if path := findDependencyPath(records, toID, fromID, depTypes, maxDepth); path != nil {
+		why.Connected, why.Reverse, why.Path = true, true, path
+	}
+	return why
+}
+
+// Directions reachableIssues can walk dependency records in
+const (
+	walkDown = "down" // From dependent to dependency: what the root depends on
+	walkUp   = "up"   // From dependency to dependent: what depends on the root
+	walkBoth = "both" // Either way: the root's connected component
+)
+
+// reachableIssues returns the issues reachable from rootID over dependency
+// records walked in direction (walkDown, walkUp or walkBoth), mapped to the
+// number of hops to each; rootID itself is at 0. Only edges of depTypes are
+// followed, if any are given, and maxDepth <= 0 means no limit. Like
+// findDependencyPath, it visits each issue once, so cycles end the walk
+// along that edge instead of looping.
+func reachableIssues(records map[string][]*types.Dependency, rootID, direction string, depTypes []types.DependencyType, maxDepth int) map[string]int {
+	allowed := make(map[types.DependencyType]bool, len(depTypes))
+	for _, t := range depTypes {
+		allowed[t] = true
+	}
+
+	neighbors := make(map[string][]string)
+	for _, deps := range records {
+		for _, dep := range deps {
+			if len(allowed) > 0 && !allowed[dep.Type] {
+				continue
+			}
+			if direction != walkUp {
+				neighbors[dep.IssueID] = append(neighbors[dep.IssueID], dep.DependsOnID)
+			}
+			if direction != walkDown {
+				neighbors[dep.DependsOnID] = append(neighbors[dep.DependsOnID], dep.IssueID)
+			}
+		}
+	}
+
+	depth := map[string]int{rootID: 0}
+	frontier := []string{rootID}
+	for hops := 1; len(frontier) > 0 && (maxDepth <= 0 || hops <= maxDepth); hops++ {
+		var next []string
+		for _, id := range frontier {
+			for _, neighbor := range neighbors[id] {
+				if _, seen := depth[neighbor]; seen {
+					continue
+				}
+				depth[neighbor] = hops
+				next = append(next, neighbor)
+			}
+		}
+		frontier = next
+	}
+	return depth
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFindDependencyPath(t *testing.T) {
+	// bd-1 → bd-2 → bd-3 → bd-4 via blocks and parent-child, a related
+	// shortcut bd-1 → bd-4, and a cycle bd-3 → bd-1
+	records := map[string][]*types.Dependency{
+		"bd-1": {
+			{IssueID: "bd-1", DependsOnID: "bd-4", Type: types.DepRelated},
+			{IssueID: "bd-1", DependsOnID: "bd-2", Type: types.DepBlocks},
+		},
+		"bd-2": {{IssueID: "bd-2", DependsOnID: "bd-3", Type: types.DepParentChild}},
+		"bd-3": {
+			{IssueID: "bd-3", DependsOnID: "bd-1", Type: types.DepBlocks},
+			{IssueID: "bd-3", DependsOnID: "bd-4", Type: types.DepBlocks},
+		},
+	}
+	hops := func(path []*types.Dependency) []string {
+		var got []string
+		for _, dep := range path {
+			got = append(got, dep.IssueID+">"+dep.DependsOnID+":"+string(dep.Type))
+		}
+		return got
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		depTypes []types.DependencyType
+		maxDepth int
+		want     []string
+	}{
+		{"shortest across all types", "bd-1", "bd-4", nil, 50, []string{"bd-1>bd-4:related"}},
+		{"restricted types", "bd-1", "bd-4", []types.DependencyType{types.DepBlocks, types.DepParentChild}, 50,
+			[]string{"bd-1>bd-2:blocks", "bd-2>bd-3:parent-child", "bd-3>bd-4:blocks"}},
+		{"too deep", "bd-1", "bd-4", []types.DependencyType{types.DepBlocks, types.DepParentChild}, 2, nil},
+		{"through a cycle", "bd-2", "bd-1", nil, 50, []string{"bd-2>bd-3:parent-child", "bd-3>bd-1:blocks"}},
+		{"not connected", "bd-4", "bd-1", nil, 50, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hops(findDependencyPath(records, tt.from, tt.to, tt.depTypes, tt.maxDepth))
+			if len(got) != len(tt.want) {
+				t.Fatalf("path = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("path = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	why := explainDependency(records, "bd-4", "bd-3", []types.DependencyType{types.DepBlocks}, 50)
+	if !why.Connected || !why.Reverse || len(why.Path) != 1 || why.Path[0].IssueID != "bd-3" {
+		t.Errorf("explainDependency(bd-4, bd-3) = %+v, want the reverse edge bd-3 → bd-4", why)
+	}
+	why = explainDependency(records, "bd-4", "bd-9", nil, 50)
+	if why.Connected || why.Path == nil {
+		t.Errorf("explainDependency(bd-4, bd-9) = %+v, want not connected with an empty path", why)
+	}
+}
+
+func TestReachableIssues(t *testing.T) {
+	// bd-1 → bd-2 → bd-3 → bd-1 is a cycle; bd-4 depends on bd-3
+	records := map[string][]*types.Dependency{
+		"bd-1": {{IssueID: "bd-1", DependsOnID: "bd-2", Type: types.DepBlocks}},
+		"bd-2": {{IssueID: "bd-2", DependsOnID: "bd-3", Type: types.DepBlocks}},
+		"bd-3": {{IssueID: "bd-3", DependsOnID: "bd-1", Type: types.DepRelated}},
+		"bd-4": {{IssueID: "bd-4", DependsOnID: "bd-3", Type: types.DepParentChild}},
+	}
+
+	down := reachableIssues(records, "bd-1", walkDown, nil, 0)
+	if len(down) != 3 || down["bd-1"] != 0 || down["bd-2"] != 1 || down["bd-3"] != 2 {
+		t.Errorf("down from bd-1 = %v, want bd-1:0 bd-2:1 bd-3:2", down)
+	}
+	if up := reachableIssues(records, "bd-3", walkUp, nil, 1); len(up) != 3 || up["bd-2"] != 1 || up["bd-4"] != 1 {
+		t.Errorf("up from bd-3 within 1 = %v, want bd-3, bd-2 and bd-4", up)
+	}
+	if both := reachableIssues(records, "bd-4", walkBoth, nil, 0); len(both) != 4 || both["bd-1"] != 2 {
+		t.Errorf("both from bd-4 = %v, want all four with bd-1 at 2", both)
+	}
+	if blocks := reachableIssues(records, "bd-1", walkDown, []types.DependencyType{types.DepBlocks}, 0); len(blocks) != 3 {
+		t.Errorf("blocks down from bd-1 = %v, want bd-1, bd-2 and bd-3", blocks)
+	}
+	if lone := reachableIssues(records, "bd-9", walkBoth, nil, 0); len(lone) != 1 {
+		t.Errorf("unknown root = %v, want only itself", lone)
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var depgraphCmd = &cobra.Command{
+	Use:   "depgraph",
+	Short: "Check the dependency graph",
+}
+
+var depgraphValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Find dependencies that reference missing issues",
+	Long: `Find dependency records whose issue or depends-on issue no longer exists.
+
+Imports and merges can leave such dangling edges behind, and a blocker that
+doesn't exist can keep an issue out of 'bd ready'. Parent-child edges are
+checked too, so children whose parent is gone show up as orphaned.
+
+--fix removes every dangling edge in a single transaction.
+
+Exits with status 1 if dangling dependencies are found and --fix wasn't
+given, so it can be used in CI. This is a fast, standalone subset of the
+checks 'bd doctor' runs.
+
+Examples:
+  bd depgraph validate
+  bd depgraph validate --fix --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fix, _ := cmd.Flags().GetBool("fix")
+
+		if err := ensureDirectMode("depgraph validate reads from the database directly"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		ctx := context.Background()
+		dangling, err := store.PruneDanglingDependencies(ctx, !fix)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		result, err := describeDanglingDependencies(ctx, store, dangling)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if fix && len(dangling) > 0 {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"dangling": result,
+				"fixed":    fix && len(dangling) > 0,
+			})
+			if !fix && len(dangling) > 0 {
+				os.Exit(1)
+			}
+			return
+		}
+
+		if len(dangling) == 0 {
+			green := color.New(color.FgGreen).SprintFunc()
+			fmt.Printf("\n%s No dangling dependencies found\n\n", green("✓"))
+			return
+		}
+
+		yellow := color.New(color.FgYellow).SprintFunc()
+		fmt.Printf("\n%s Found %d dangling dependencies:\n\n", yellow("⚠"), len(dangling))
+		for i, d := range result {
+			note := strings.Join(d.Missing, ", ") + " does not exist"
+			if d.OrphanedChild {
+				note += "; orphaned child"
+			}
+			fmt.Printf("%d. %s → %s (%s) [%s]\n", i+1, d.IssueID, d.DependsOnID, d.Type, note)
+		}
+		fmt.Println()
+
+		if fix {
+			green := color.New(color.FgGreen).SprintFunc()
+			fmt.Printf("%s Removed %d dangling dependencies\n\n", green("✓"), len(dangling))
+			return
+		}
+		fmt.Printf("Run with --fix to remove them\n\n")
+		os.Exit(1)
+	},
+}
+
+// danglingDependency is a dependency record with the issue IDs it references
+// that no longer exist
+type danglingDependency struct {
+	*types.Dependency
+	Missing       []string `json:"missing"`
+	OrphanedChild bool     `json:"orphaned_child,omitempty"` // A parent-child edge whose child still exists
+}
+
+// describeDanglingDependencies works out which ends of each dangling
+// record are missing
+func describeDanglingDependencies(ctx context.Context, s storage.Storage, dangling []*types.Dependency) ([]*danglingDependency, error) {
+	exists := make(map[string]bool)
+	issueExists := func(id string) (bool, error) {
+		if found, ok := exists[id]; ok {
+			return found, nil
+		}
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil {
+			return false, fmt.Errorf("failed to get issue %s: %w", id, err)
+		}
+		exists[id] = issue != nil
+		return exists[id], nil
+	}
+
+	result := make([]*danglingDependency, 0, len(dangling))
+	for _, dep := range dangling {
+		d := &danglingDependency{Dependency: dep, Missing: []string{}}
+		for _, id := range []string{dep.IssueID, dep.DependsOnID} {
+			found, err := issueExists(id)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				d.Missing = append(d.Missing, id)
+			}
+		}
+		childExists, err := issueExists(dep.IssueID)
+		if err != nil {
+			return nil, err
+		}
+		d.OrphanedChild = dep.Type == types.DepParentChild && childExists
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+func init() {
+	depgraphValidateCmd.Flags().Bool("fix", false, "Remove the dangling dependencies")
+	depgraphCmd.AddCommand(depgraphValidateCmd)
+	rootCmd.AddCommand(depgraphCmd)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDescribeDanglingDependencies(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".beads", "beads.db")
+	testStore := newTestStore(t, dbPath)
+	ctx := context.Background()
+
+	child := &types.Issue{ID: "test-child", Title: "Child", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := testStore.CreateIssue(ctx, child, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	dangling := []*types.Dependency{
+		{IssueID: "test-child", DependsOnID: "test-parent", Type: types.DepParentChild},
+		{IssueID: "test-gone", DependsOnID: "test-missing", Type: types.DepBlocks},
+	}
+	result, err := describeDanglingDependencies(ctx, testStore, dangling)
+	if err != nil {
+		t.Fatalf("describeDanglingDependencies failed: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("got %d results, want 2", len(result))
+	}
+	if !reflect.DeepEqual(result[0].Missing, []string{"test-parent"}) || !result[0].OrphanedChild {
+		t.Errorf("parent-child edge: missing %v, orphaned child %v; want [test-parent], true", result[0].Missing, result[0].OrphanedChild)
+	}
+	if !reflect.DeepEqual(result[1].Missing, []string{"test-gone", "test-missing"}) || result[1].OrphanedChild {
+		t.Errorf("blocks edge: missing %v, orphaned child %v; want both ends, false", result[1].Missing, result[1].OrphanedChild)
+	}
+}
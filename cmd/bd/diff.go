@@ -0,0 +1,345 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// jsonlDiff is the result of comparing the database with a JSONL file
+type jsonlDiff struct {
+	File           string       `json:"file"`
+	OnlyInDB       []string     `json:"only_in_db"`
+	OnlyInFile     []string     `json:"only_in_file"`
+	Changed        []*issueDiff `json:"changed"`
+	Identical      int          `json:"identical"`
+	MalformedLines []int        `json:"malformed_lines,omitempty"`
+}
+
+// issueDiff lists the fields that differ for an issue present on both sides
+type issueDiff struct {
+	ID     string       `json:"id"`
+	Fields []*fieldDiff `json:"fields"`
+}
+
+// fieldDiff is one differing field, keyed by its JSONL name. A side that
+// doesn't have the field is null.
+type fieldDiff struct {
+	Field string      `json:"field"`
+	DB    interface{} `json:"db"`
+	File  interface{} `json:"file"`
+}
+
+// inSync reports whether the database and file agree
+func (d *jsonlDiff) inSync() bool {
+	return len(d.OnlyInDB) == 0 && len(d.OnlyInFile) == 0 && len(d.Changed) == 0
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare the database with the JSONL file",
+	Long: `Compare the issues in the database with those in the JSONL file.
+
+Reports issues only in the database, issues only in the file, and the fields
+that differ for issues in both. Labels, dependencies and comments are
+compared as part of each issue; comment IDs are ignored, since they differ
+between databases. Timestamps compare by instant, so a change of time zone
+alone is not a difference.
+
+Malformed lines in the file are reported and skipped rather than aborting,
+and when an ID appears more than once the last record wins, as on import.
+Run this before deciding whether to 'bd export' or 'bd import'.
+
+Examples:
+  bd diff
+  bd diff --file backup.jsonl --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		path, _ := cmd.Flags().GetString("file")
+		if path == "" {
+			path = findJSONLPath()
+		}
+
+		if err := ensureDirectMode("diff requires direct database access"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		dbIssues, err := loadIssuesForDiff(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fileIssues, malformed, err := readJSONLLenient(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to read %s: %v\n", path, err)
+			os.Exit(1)
+		}
+
+		diff := diffIssues(dbIssues, fileIssues)
+		diff.File = path
+		diff.MalformedLines = malformed
+
+		if jsonOutput {
+			outputJSON(diff)
+			return
+		}
+		printJSONLDiff(diff)
+	},
+}
+
+// loadIssuesForDiff reads every issue from the database with its
+// dependencies, labels and comments, as export writes them
+func loadIssuesForDiff(ctx context.Context) ([]*types.Issue, error) {
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issues: %w", err)
+	}
+	allDeps, err := store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Dependencies = allDeps[issue.ID]
+		labels, err := store.GetLabels(ctx, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get labels for %s: %w", issue.ID, err)
+		}
+		issue.Labels = labels
+		comments, err := store.GetIssueComments(ctx, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get comments for %s: %w", issue.ID, err)
+		}
+		issue.Comments = comments
+	}
+	return issues, nil
+}
+
+// diffIssues compares two sets of issues by ID. A repeated ID in fileIssues
+// is represented by its last record.
+func diffIssues(dbIssues, fileIssues []*types.Issue) *jsonlDiff {
+	diff := &jsonlDiff{OnlyInDB: []string{}, OnlyInFile: []string{}, Changed: []*issueDiff{}}
+	fileByID := make(map[string]*types.Issue, len(fileIssues))
+	for _, issue := range fileIssues {
+		fileByID[issue.ID] = issue
+	}
+	dbByID := make(map[string]*types.Issue, len(dbIssues))
+	for _, issue := range dbIssues {
+		dbByID[issue.ID] = issue
+	}
+
+	for id, dbIssue := range dbByID {
+		fileIssue, ok := fileByID[id]
+		if !ok {
+			diff.OnlyInDB = append(diff.OnlyInDB, id)
+			continue
+		}
+		fields := diffIssueFields(dbIssue, fileIssue)
+		if len(fields) == 0 {
+			diff.Identical++
+			continue
+		}
+		diff.Changed = append(diff.Changed, &issueDiff{ID: id, Fields: fields})
+	}
+	for id := range fileByID {
+		if _, ok := dbByID[id]; !ok {
+			diff.OnlyInFile = append(diff.OnlyInFile, id)
+		}
+	}
+
+	sortIDs := func(ids []string) {
+		sort.Slice(ids, func(i, j int) bool { return utils.CompareIssueIDs(ids[i], ids[j]) < 0 })
+	}
+	sortIDs(diff.OnlyInDB)
+	sortIDs(diff.OnlyInFile)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return utils.CompareIssueIDs(diff.Changed[i].ID, diff.Changed[j].ID) < 0
+	})
+	return diff
+}
+
+// diffIssueFields returns the JSONL fields that differ between the two
+// versions of an issue, sorted by field name
+func diffIssueFields(dbIssue, fileIssue *types.Issue) []*fieldDiff {
+	dbFields := comparableFields(dbIssue)
+	fileFields := comparableFields(fileIssue)
+	names := make(map[string]bool, len(dbFields)+len(fileFields))
+	for name := range dbFields {
+		names[name] = true
+	}
+	for name := range fileFields {
+		names[name] = true
+	}
+
+	var diffs []*fieldDiff
+	for name := range names {
+		if !jsonValuesEqual(dbFields[name], fileFields[name]) {
+			diffs = append(diffs, &fieldDiff{Field: name, DB: dbFields[name], File: fileFields[name]})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Field < diffs[j].Field })
+	return diffs
+}
+
+// comparableFields returns issue's fields as they appear in JSONL, with
+// collections in export order. content_hash is dropped as derived, and each
+// comment loses its database IDs: replies name their parent by its text.
+func comparableFields(issue *types.Issue) map[string]interface{} {
+	normalized := *issue
+	normalized.ContentHash = ""
+	normalized.Dependencies = append([]*types.Dependency(nil), issue.Dependencies...)
+	normalized.Labels = append([]string(nil), issue.Labels...)
+	normalized.Comments = append([]*types.Comment(nil), issue.Comments...)
+	utils.SortIssuesForExport([]*types.Issue{&normalized})
+
+	fields := jsonFields(&normalized)
+	if len(normalized.Comments) > 0 {
+		firstText := make(map[int64]string, len(normalized.Comments))
+		for _, comment := range normalized.Comments {
+			firstText[comment.ID] = comment.FirstText()
+		}
+		comments := make([]interface{}, 0, len(normalized.Comments))
+		for _, comment := range normalized.Comments {
+			c := jsonFields(comment)
+			delete(c, "id")
+			delete(c, "issue_id")
+			delete(c, "parent_comment_id")
+			if comment.ParentCommentID != nil {
+				c["reply_to"] = firstText[*comment.ParentCommentID]
+			}
+			comments = append(comments, c)
+		}
+		fields["comments"] = comments
+	}
+	return fields
+}
+
+// jsonFields decodes v's JSON encoding into a generic map
+func jsonFields(v interface{}) map[string]interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var fields map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+// jsonValuesEqual compares decoded JSON values, treating strings that are
+// both RFC3339 timestamps as equal when they name the same instant
+func jsonValuesEqual(a, b interface{}) bool {
+	switch av := a.(type) {
+	case string:
+		bv, ok := b.(string)
+		if !ok {
+			return false
+		}
+		if av == bv {
+			return true
+		}
+		at, aErr := time.Parse(time.RFC3339Nano, av)
+		bt, bErr := time.Parse(time.RFC3339Nano, bv)
+		return aErr == nil && bErr == nil && at.Equal(bt)
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !jsonValuesEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, value := range av {
+			other, ok := bv[key]
+			if !ok || !jsonValuesEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// printJSONLDiff summarizes a diff for humans
+func printJSONLDiff(diff *jsonlDiff) {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	if len(diff.MalformedLines) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: skipped %d malformed line(s) in %s (lines %s)\n",
+			len(diff.MalformedLines), diff.File, formatLineNumbers(diff.MalformedLines))
+	}
+	if diff.inSync() {
+		fmt.Printf("%s Database and %s agree (%d issues)\n", green("✓"), diff.File, diff.Identical)
+		return
+	}
+
+	fmt.Printf("%s Database and %s differ\n", yellow("⚠"), diff.File)
+	if len(diff.OnlyInDB) > 0 {
+		fmt.Printf("\nOnly in database (%d):\n", len(diff.OnlyInDB))
+		for _, id := range diff.OnlyInDB {
+			fmt.Printf("  %s\n", id)
+		}
+	}
+	if len(diff.OnlyInFile) > 0 {
+		fmt.Printf("\nOnly in file (%d):\n", len(diff.OnlyInFile))
+		for _, id := range diff.OnlyInFile {
+			fmt.Printf("  %s\n", id)
+		}
+	}
+	if len(diff.Changed) > 0 {
+		fmt.Printf("\nDiffering (%d):\n", len(diff.Changed))
+		for _, issue := range diff.Changed {
+			fmt.Printf("  %s\n", issue.ID)
+			for _, field := range issue.Fields {
+				fmt.Printf("    %s: db %s, file %s\n", field.Field, summarizeJSONValue(field.DB), summarizeJSONValue(field.File))
+			}
+		}
+	}
+	fmt.Printf("\n%d issue(s) identical\n", diff.Identical)
+}
+
+// summarizeJSONValue renders a field value on one line, truncated
+func summarizeJSONValue(v interface{}) string {
+	if v == nil {
+		return "(absent)"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	text := []rune(strings.Join(strings.Fields(string(data)), " "))
+	const maxLen = 60
+	if len(text) > maxLen {
+		return string(text[:maxLen-3]) + "..."
+	}
+	return string(text)
+}
+
+func init() {
+	diffCmd.Flags().String("file", "", "JSONL file to compare (default: the workspace's JSONL)")
+	rootCmd.AddCommand(diffCmd)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDiffIssues(t *testing.T) {
+	created := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	issue := func(id, title string, labels ...string) *types.Issue {
+		return &types.Issue{ID: id, Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+			CreatedAt: created, UpdatedAt: created, Labels: labels}
+	}
+	withComments := func(base *types.Issue, rootID, replyID int64) *types.Issue {
+		root := rootID
+		base.Comments = []*types.Comment{
+			{ID: rootID, IssueID: base.ID, Author: "alice", Text: "Root", CreatedAt: created},
+			{ID: replyID, IssueID: base.ID, ParentCommentID: &root, Author: "bob", Text: "Reply", CreatedAt: created.Add(time.Minute)},
+		}
+		return base
+	}
+
+	sameInOtherZone := issue("bd-same", "Same", "b", "a")
+	sameInOtherZone.CreatedAt = created.In(time.FixedZone("UTC+2", 2*60*60))
+	sameInOtherZone.ContentHash = "stale"
+	db := []*types.Issue{
+		issue("bd-same", "Same", "a", "b"),
+		withComments(issue("bd-comments", "Comments"), 1, 2),
+		issue("bd-changed", "Old title", "ui"),
+		issue("bd-db-only", "Only in DB"),
+	}
+	file := []*types.Issue{
+		sameInOtherZone,
+		withComments(issue("bd-comments", "Comments"), 7, 8), // IDs differ after import
+		issue("bd-changed", "Stale", "ui"),
+		issue("bd-changed", "New title"), // Last record wins
+		issue("bd-file-only", "Only in file"),
+	}
+
+	diff := diffIssues(db, file)
+	if !reflect.DeepEqual(diff.OnlyInDB, []string{"bd-db-only"}) || !reflect.DeepEqual(diff.OnlyInFile, []string{"bd-file-only"}) {
+		t.Errorf("only in db %v, only in file %v", diff.OnlyInDB, diff.OnlyInFile)
+	}
+	if diff.Identical != 2 {
+		t.Errorf("identical = %d, want 2", diff.Identical)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].ID != "bd-changed" {
+		t.Fatalf("changed = %+v, want only bd-changed", diff.Changed)
+	}
+	var fields []string
+	for _, field := range diff.Changed[0].Fields {
+		fields = append(fields, field.Field)
+	}
+	if !reflect.DeepEqual(fields, []string{"labels", "title"}) {
+		t.Errorf("differing fields = %v, want [labels title]", fields)
+	}
+	if title := diff.Changed[0].Fields[1]; title.DB != "Old title" || title.File != "New title" {
+		t.Errorf("title diff = %+v", title)
+	}
+	if labels := diff.Changed[0].Fields[0]; labels.File != nil {
+		t.Errorf("labels absent from the file should diff as nil, got %v", labels.File)
+	}
+}
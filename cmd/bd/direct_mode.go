@@ -5,7 +5,6 @@ import (
 
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/debug"
-	"github.com/steveyegge/beads/internal/storage/sqlite"
 )
 
 // ensureDirectMode makes sure the CLI is operating in direct-storage mode.
@@ -65,7 +64,7 @@ func ensureStoreActive() error {
 		}
 	}
 
-	sqlStore, err := sqlite.New(dbPath)
+	sqlStore, err := openStore(dbPath)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
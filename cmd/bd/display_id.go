@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var (
+	displayPrefixOnce sync.Once
+	displayPrefix     string // Prefix displayID drops, "" when display_hide_prefix is off
+)
+
+// displayID renders an issue ID for human-readable output, without the
+// issue_prefix when display_hide_prefix is set. JSON output, exports and
+// anything meant to be parsed keep the full ID.
+func displayID(id string) string {
+	if jsonOutput {
+		return id
+	}
+	displayPrefixOnce.Do(func() { displayPrefix = loadDisplayPrefix() })
+	return utils.DisplayID(id, displayPrefix)
+}
+
+// loadDisplayPrefix reads display_hide_prefix and the issue prefix it hides.
+// In daemon mode the database is opened just to read the config.
+func loadDisplayPrefix() string {
+	s := store
+	if store == nil {
+		if dbPath == "" {
+			return ""
+		}
+		sqlStore, err := openStore(dbPath)
+		if err != nil {
+			return ""
+		}
+		defer func() { _ = sqlStore.Close() }()
+		s = sqlStore
+	}
+	ctx := context.Background()
+	value, err := s.GetConfig(ctx, utils.DisplayHidePrefixConfigKey)
+	if err != nil {
+		return ""
+	}
+	if hide, err := utils.ParseDisplayHidePrefix(value); err != nil || !hide {
+		return ""
+	}
+	return utils.GetIDPrefixes(ctx, s).Default
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/steveyegge/beads/internal/beads"
 	"github.com/steveyegge/beads/internal/configfile"
 	"github.com/steveyegge/beads/internal/daemon"
+	"github.com/steveyegge/beads/internal/types"
 	_ "github.com/ncruces/go-sqlite3/driver"
 	_ "github.com/ncruces/go-sqlite3/embed"
 )
@@ -65,6 +66,11 @@ This command checks:
   - Database-JSONL sync status
   - File permissions
   - Circular dependencies
+  - Referential integrity: dependencies, labels, comments, events and other
+    per-issue rows pointing at missing issues
+  - Hierarchy: child issues whose parent is missing
+  - Child counters behind their highest child (next child ID would collide)
+  - Issue ID format (configured prefix, base36 hash, .N child numbers)
   - Git hooks (pre-commit, post-merge, pre-push)
   - .beads/.gitignore up to date
 
@@ -72,7 +78,12 @@ Examples:
   bd doctor              # Check current directory
   bd doctor /path/to/repo # Check specific repository
   bd doctor --json       # Machine-readable output
-  bd doctor --fix        # Automatically fix issues`,
+  bd doctor --fix        # Automatically fix issues
+
+--fix deletes rows and dependencies that reference missing issues and raises
+stale child counters, in one transaction after backing the database up to
+.beads/<name>.doctor-<timestamp>.backup.db. Missing parents and malformed IDs
+are reported but left alone.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Use global jsonOutput set by PersistentPreRun
 
@@ -118,9 +129,24 @@ func init() {
 }
 
 func applyFixes(result doctorResult) {
+	integrityFixed := false
 	for _, check := range result.Checks {
 		if check.Status == statusWarning || check.Status == statusError {
 			switch check.Name {
+			case "Orphan Dependencies", "Orphan Rows", "Child Counters":
+				// One backup and transaction repairs all three
+				if integrityFixed {
+					continue
+				}
+				integrityFixed = true
+				fmt.Println("Repairing database integrity...")
+				fixed, err := fixIntegrity(result.Path)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "  Error: %v\n", err)
+					continue
+				}
+				fmt.Printf("  ✓ Backed up database to %s\n", fixed.BackupPath)
+				fmt.Printf("  ✓ Deleted %d orphan row(s), raised %d child counter(s)\n", fixed.OrphanRows, fixed.Counters)
 			case "Gitignore":
 				fmt.Println("Fixing .beads/.gitignore...")
 				if err := doctor.FixGitignore(); err != nil {
@@ -225,6 +251,46 @@ func runDiagnostics(path string) doctorResult{
 		result.OverallOK = false
 	}
 
+	// Check 10a: Dependents less urgent than their blockers (priority_propagation)
+	priorityCheck := checkPriorityPropagation(path)
+	result.Checks = append(result.Checks, priorityCheck)
+	// Don't fail overall check for priority mismatches, just warn
+
+	// Check 10b: Dependencies pointing at issues that no longer exist
+	orphanCheck := checkOrphanDependencies(path)
+	result.Checks = append(result.Checks, orphanCheck)
+	if orphanCheck.Status == statusError || orphanCheck.Status == statusWarning {
+		result.OverallOK = false
+	}
+
+	// Check 10c: Labels, comments, events and other rows of missing issues
+	orphanRowsCheck := checkOrphanRows(path)
+	result.Checks = append(result.Checks, orphanRowsCheck)
+	if orphanRowsCheck.Status == statusError || orphanRowsCheck.Status == statusWarning {
+		result.OverallOK = false
+	}
+
+	// Check 10d: Child issues whose parent is missing
+	hierarchyCheck := checkHierarchy(path)
+	result.Checks = append(result.Checks, hierarchyCheck)
+	if hierarchyCheck.Status == statusError || hierarchyCheck.Status == statusWarning {
+		result.OverallOK = false
+	}
+
+	// Check 10e: Child counters behind their highest child
+	countersCheck := checkChildCounters(path)
+	result.Checks = append(result.Checks, countersCheck)
+	if countersCheck.Status == statusError || countersCheck.Status == statusWarning {
+		result.OverallOK = false
+	}
+
+	// Check 10f: Malformed issue IDs
+	idValidityCheck := checkIDValidity(path)
+	result.Checks = append(result.Checks, idValidityCheck)
+	if idValidityCheck.Status == statusError || idValidityCheck.Status == statusWarning {
+		result.OverallOK = false
+	}
+
 	// Check 11: Claude integration
 	claudeCheck := convertDoctorCheck(doctor.CheckClaude())
 	result.Checks = append(result.Checks, claudeCheck)
@@ -1162,6 +1228,179 @@ func checkDependencyCycles(path string) doctorCheck {
 	}
 }
 
+// checkPriorityPropagation flags open dependents with lower priority than an
+// open blocker. Only runs when priority_propagation is enabled.
+func checkPriorityPropagation(path string) doctorCheck {
+	beadsDir := filepath.Join(path, ".beads")
+	dbPath := filepath.Join(beadsDir, beads.CanonicalDatabaseName)
+
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return doctorCheck{
+			Name:    "Priority Propagation",
+			Status:  statusOK,
+			Message: "N/A (no database)",
+		}
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+dbPath+"?mode=ro")
+	if err != nil {
+		return doctorCheck{
+			Name:    "Priority Propagation",
+			Status:  statusWarning,
+			Message: "Unable to open database",
+			Detail:  err.Error(),
+		}
+	}
+	defer func() { _ = db.Close() }()
+
+	var mode string
+	err = db.QueryRow("SELECT value FROM config WHERE key = ?", types.PriorityPropagationConfigKey).Scan(&mode)
+	if err != nil && err != sql.ErrNoRows {
+		return doctorCheck{
+			Name:    "Priority Propagation",
+			Status:  statusWarning,
+			Message: "Unable to read config",
+			Detail:  err.Error(),
+		}
+	}
+	if weight, err := types.PriorityPropagationWeight(mode); err != nil {
+		return doctorCheck{
+			Name:    "Priority Propagation",
+			Status:  statusWarning,
+			Message: "Invalid configuration",
+			Detail:  err.Error(),
+			Fix:     fmt.Sprintf("Run 'bd config set %s off|bump|inherit'", types.PriorityPropagationConfigKey),
+		}
+	} else if weight == 0 {
+		return doctorCheck{
+			Name:    "Priority Propagation",
+			Status:  statusOK,
+			Message: "N/A (priority_propagation is off)",
+		}
+	}
+
+	rows, err := db.Query(`
+		SELECT d.issue_id, i.priority, d.depends_on_id, b.priority
+		FROM dependencies d
+		JOIN issues i ON i.id = d.issue_id
+		JOIN issues b ON b.id = d.depends_on_id
+		WHERE d.type = 'blocks'
+		  AND i.status != 'closed' AND b.status != 'closed'
+		  AND i.priority > b.priority
+		ORDER BY d.issue_id`)
+	if err != nil {
+		return doctorCheck{
+			Name:    "Priority Propagation",
+			Status:  statusWarning,
+			Message: "Unable to check priorities",
+			Detail:  err.Error(),
+		}
+	}
+	defer rows.Close()
+
+	var mismatches []string
+	for rows.Next() {
+		var issueID, blockerID string
+		var priority, blockerPriority int
+		if err := rows.Scan(&issueID, &priority, &blockerID, &blockerPriority); err != nil {
+			continue
+		}
+		mismatches = append(mismatches, fmt.Sprintf("%s (P%d) blocked by %s (P%d)", issueID, priority, blockerID, blockerPriority))
+	}
+
+	if len(mismatches) == 0 {
+		return doctorCheck{
+			Name:    "Priority Propagation",
+			Status:  statusOK,
+			Message: "No dependents less urgent than their blockers",
+		}
+	}
+
+	detail := strings.Join(mismatches, ", ")
+	if len(mismatches) > 5 {
+		detail = strings.Join(mismatches[:5], ", ") + fmt.Sprintf(", and %d more", len(mismatches)-5)
+	}
+	return doctorCheck{
+		Name:    "Priority Propagation",
+		Status:  statusWarning,
+		Message: fmt.Sprintf("%d dependent(s) have lower priority than their blockers", len(mismatches)),
+		Detail:  detail,
+		Fix:     "Raise them with 'bd update <id> --priority N'",
+	}
+}
+
+// checkOrphanDependencies flags dependency edges whose issue or target is
+// missing from the database (e.g. left behind by a deletion)
+func checkOrphanDependencies(path string) doctorCheck {
+	beadsDir := filepath.Join(path, ".beads")
+	dbPath := filepath.Join(beadsDir, beads.CanonicalDatabaseName)
+
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return doctorCheck{
+			Name:    "Orphan Dependencies",
+			Status:  statusOK,
+			Message: "N/A (no database)",
+		}
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+dbPath+"?mode=ro")
+	if err != nil {
+		return doctorCheck{
+			Name:    "Orphan Dependencies",
+			Status:  statusWarning,
+			Message: "Unable to open database",
+			Detail:  err.Error(),
+		}
+	}
+	defer func() { _ = db.Close() }()
+
+	rows, err := db.Query(`
+		SELECT d.issue_id, d.depends_on_id
+		FROM dependencies d
+		LEFT JOIN issues i ON i.id = d.issue_id
+		LEFT JOIN issues t ON t.id = d.depends_on_id
+		WHERE i.id IS NULL OR t.id IS NULL
+		ORDER BY d.issue_id, d.depends_on_id`)
+	if err != nil {
+		return doctorCheck{
+			Name:    "Orphan Dependencies",
+			Status:  statusWarning,
+			Message: "Unable to check dependencies",
+			Detail:  err.Error(),
+		}
+	}
+	defer rows.Close()
+
+	var orphans []string
+	for rows.Next() {
+		var issueID, dependsOnID string
+		if err := rows.Scan(&issueID, &dependsOnID); err != nil {
+			continue
+		}
+		orphans = append(orphans, fmt.Sprintf("%s → %s", issueID, dependsOnID))
+	}
+
+	if len(orphans) == 0 {
+		return doctorCheck{
+			Name:    "Orphan Dependencies",
+			Status:  statusOK,
+			Message: "All dependencies point at existing issues",
+		}
+	}
+
+	detail := strings.Join(orphans, ", ")
+	if len(orphans) > 5 {
+		detail = strings.Join(orphans[:5], ", ") + fmt.Sprintf(", and %d more", len(orphans)-5)
+	}
+	return doctorCheck{
+		Name:    "Orphan Dependencies",
+		Status:  statusWarning,
+		Message: fmt.Sprintf("%d dependency edge(s) reference missing issues", len(orphans)),
+		Detail:  detail,
+		Fix:     "Run 'bd doctor --fix' or 'bd repair-deps --fix' to remove them, or 'bd export --prune-orphan-deps' to leave them out of an export",
+	}
+}
+
 func checkGitHooks(path string) doctorCheck {
 	// Check if we're in a git repository
 	gitDir := filepath.Join(path, ".git")
@@ -0,0 +1,473 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// issueRowTables are the tables whose rows belong to an issue, and the
+// column naming it. Foreign keys normally delete these rows with the issue,
+// but manual JSONL edits, partial migrations and imports with foreign keys
+// off can leave them behind.
+var issueRowTables = []struct{ table, column string }{
+	{"labels", "issue_id"},
+	{"comments", "issue_id"},
+	{"events", "issue_id"},
+	{"dirty_issues", "issue_id"},
+	{"export_hashes", "issue_id"},
+	{"locks", "issue_id"},
+	{"issue_snapshots", "issue_id"},
+	{"compaction_snapshots", "issue_id"},
+	{"child_counters", "parent_id"},
+}
+
+// hashSuffixPattern is the part of a top-level ID after its prefix: a hash,
+// or a number for sequential IDs
+var hashSuffixPattern = regexp.MustCompile(`^[0-9a-z]+$`)
+
+// childSuffixPattern is one .N segment of a hierarchical ID
+var childSuffixPattern = regexp.MustCompile(`^[1-9][0-9]*$`)
+
+// doctorDatabasePath returns the database bd doctor checks in path's .beads
+// directory: the one metadata.json names, or the canonical one
+func doctorDatabasePath(path string) string {
+	beadsDir := filepath.Join(path, ".beads")
+	if cfg, err := configfile.Load(beadsDir); err == nil && cfg != nil && cfg.Database != "" {
+		return cfg.DatabasePath(beadsDir)
+	}
+	return filepath.Join(beadsDir, beads.CanonicalDatabaseName)
+}
+
+// dbQuerier is the part of *sql.DB and *sql.Tx the integrity scans need
+type dbQuerier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// readIssueIDs returns every issue ID in the database, mapped to its
+// source_repo
+func readIssueIDs(q dbQuerier) (map[string]string, error) {
+	rows, err := q.Query(`SELECT id, COALESCE(source_repo, '') FROM issues`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issue IDs: %w", err)
+	}
+	defer rows.Close()
+	ids := make(map[string]string)
+	for rows.Next() {
+		var id, sourceRepo string
+		if err := rows.Scan(&id, &sourceRepo); err != nil {
+			return nil, fmt.Errorf("failed to scan issue ID: %w", err)
+		}
+		ids[id] = sourceRepo
+	}
+	return ids, rows.Err()
+}
+
+func tableExists(q dbQuerier, table string) (bool, error) {
+	var count int
+	err := q.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, table).Scan(&count)
+	return count > 0, err
+}
+
+// findOrphanRows returns, for each table in issueRowTables, the sorted IDs
+// of missing issues its rows belong to
+func findOrphanRows(q dbQuerier) (map[string][]string, error) {
+	orphans := make(map[string][]string)
+	for _, t := range issueRowTables {
+		if ok, err := tableExists(q, t.table); err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+		// #nosec G201 - table and column names come from issueRowTables
+		rows, err := q.Query(fmt.Sprintf(`
+			SELECT DISTINCT r.%[2]s FROM %[1]s r
+			WHERE NOT EXISTS (SELECT 1 FROM issues i WHERE i.id = r.%[2]s)
+			ORDER BY r.%[2]s`, t.table, t.column))
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", t.table, err)
+		}
+		var ids []string
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("failed to scan %s: %w", t.table, err)
+			}
+			ids = append(ids, id)
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		if len(ids) > 0 {
+			orphans[t.table] = ids
+		}
+	}
+	return orphans, nil
+}
+
+// staleChildCounter is a parent whose child_counters row is missing or
+// below its highest existing child number, so its next child ID would
+// collide
+type staleChildCounter struct {
+	ParentID string
+	Counter  int // 0 if there's no row
+	Highest  int
+}
+
+// findStaleChildCounters compares each existing parent's child counter to
+// its highest child number. A counter above the highest child is fine: it
+// keeps the numbers of deleted children from being reused.
+func findStaleChildCounters(q dbQuerier, ids map[string]string) ([]staleChildCounter, error) {
+	highest := make(map[string]int)
+	for id := range ids {
+		dot := strings.LastIndex(id, ".")
+		if dot < 0 {
+			continue
+		}
+		num, err := strconv.Atoi(id[dot+1:])
+		if err != nil || num <= 0 {
+			continue
+		}
+		if parent := id[:dot]; num > highest[parent] {
+			if _, exists := ids[parent]; exists {
+				highest[parent] = num
+			}
+		}
+	}
+
+	counters := make(map[string]int)
+	rows, err := q.Query(`SELECT parent_id, last_child FROM child_counters`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read child_counters: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var parentID string
+		var last int
+		if err := rows.Scan(&parentID, &last); err != nil {
+			return nil, fmt.Errorf("failed to scan child_counters: %w", err)
+		}
+		counters[parentID] = last
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var stale []staleChildCounter
+	for parentID, num := range highest {
+		if counters[parentID] < num {
+			stale = append(stale, staleChildCounter{ParentID: parentID, Counter: counters[parentID], Highest: num})
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i].ParentID < stale[j].ParentID })
+	return stale, nil
+}
+
+// issueIDProblem explains what's wrong with id, or returns "" if it's a
+// well-formed ID with one of prefixes. An empty prefixes skips the prefix
+// check, for issues from other repos.
+func issueIDProblem(id string, prefixes types.IDPrefixes) string {
+	top, children, hierarchical := strings.Cut(id, ".")
+	var prefix string
+	if prefixes.Default == "" {
+		if dash := strings.LastIndex(top, "-"); dash > 0 {
+			prefix = top[:dash]
+		} else {
+			return "has no prefix"
+		}
+	} else if prefix = prefixes.Match(top); prefix == "" {
+		return fmt.Sprintf("doesn't start with a configured prefix (%s)", strings.Join(prefixes.All(), ", "))
+	}
+	if suffix := strings.TrimPrefix(top, prefix+"-"); !hashSuffixPattern.MatchString(suffix) {
+		return fmt.Sprintf("hash %q isn't lowercase base36", suffix)
+	}
+	if !hierarchical {
+		return ""
+	}
+	for _, segment := range strings.Split(children, ".") {
+		if !childSuffixPattern.MatchString(segment) {
+			return fmt.Sprintf("child number %q isn't a positive integer", segment)
+		}
+	}
+	return ""
+}
+
+// summarizeIDs joins up to five entries, noting how many more there are
+func summarizeIDs(entries []string) string {
+	if len(entries) > 5 {
+		return strings.Join(entries[:5], ", ") + fmt.Sprintf(", and %d more", len(entries)-5)
+	}
+	return strings.Join(entries, ", ")
+}
+
+// openDoctorDatabase opens path's database read-only for an integrity
+// check, or returns the check to report if there isn't one
+func openDoctorDatabase(path, name string) (*sql.DB, *doctorCheck) {
+	dbPath := doctorDatabasePath(path)
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return nil, &doctorCheck{Name: name, Status: statusOK, Message: "N/A (no database)"}
+	}
+	db, err := sql.Open("sqlite3", "file:"+dbPath+"?mode=ro")
+	if err != nil {
+		return nil, &doctorCheck{Name: name, Status: statusWarning, Message: "Unable to open database", Detail: err.Error()}
+	}
+	return db, nil
+}
+
+// checkOrphanRows flags labels, comments, events and other per-issue rows
+// whose issue is missing from the database
+func checkOrphanRows(path string) doctorCheck {
+	db, check := openDoctorDatabase(path, "Orphan Rows")
+	if check != nil {
+		return *check
+	}
+	defer func() { _ = db.Close() }()
+
+	orphans, err := findOrphanRows(db)
+	if err != nil {
+		return doctorCheck{Name: "Orphan Rows", Status: statusWarning, Message: "Unable to check for orphan rows", Detail: err.Error()}
+	}
+	if len(orphans) == 0 {
+		return doctorCheck{
+			Name:    "Orphan Rows",
+			Status:  statusOK,
+			Message: "Labels, comments and events all belong to existing issues",
+		}
+	}
+
+	var parts []string
+	missing := make(map[string]bool)
+	for _, t := range issueRowTables {
+		if ids := orphans[t.table]; len(ids) > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %s", t.table, summarizeIDs(ids)))
+			for _, id := range ids {
+				missing[id] = true
+			}
+		}
+	}
+	return doctorCheck{
+		Name:    "Orphan Rows",
+		Status:  statusWarning,
+		Message: fmt.Sprintf("Rows reference %d missing issue(s)", len(missing)),
+		Detail:  strings.Join(parts, "; "),
+		Fix:     "Run 'bd doctor --fix' to delete them (the database is backed up first)",
+	}
+}
+
+// checkHierarchy flags hierarchical child IDs whose parent issue is missing
+func checkHierarchy(path string) doctorCheck {
+	db, check := openDoctorDatabase(path, "Hierarchy")
+	if check != nil {
+		return *check
+	}
+	defer func() { _ = db.Close() }()
+
+	ids, err := readIssueIDs(db)
+	if err != nil {
+		return doctorCheck{Name: "Hierarchy", Status: statusWarning, Message: "Unable to check hierarchy", Detail: err.Error()}
+	}
+	var orphaned []string
+	for id := range ids {
+		if _, parentID, depth := types.ParseHierarchicalID(id); depth > 0 {
+			if _, ok := ids[parentID]; !ok {
+				orphaned = append(orphaned, fmt.Sprintf("%s (parent %s)", id, parentID))
+			}
+		}
+	}
+	if len(orphaned) == 0 {
+		return doctorCheck{
+			Name:    "Hierarchy",
+			Status:  statusOK,
+			Message: "Every child issue's parent exists",
+		}
+	}
+	sort.Strings(orphaned)
+	return doctorCheck{
+		Name:    "Hierarchy",
+		Status:  statusWarning,
+		Message: fmt.Sprintf("%d child issue(s) have a missing parent", len(orphaned)),
+		Detail:  summarizeIDs(orphaned),
+		Fix:     "Restore the parent from git history and re-import it, or move the children under an existing parent with 'bd move <child-id> <new-parent-id>'",
+	}
+}
+
+// checkChildCounters flags parents whose child counter would hand out the
+// number of an existing child
+func checkChildCounters(path string) doctorCheck {
+	db, check := openDoctorDatabase(path, "Child Counters")
+	if check != nil {
+		return *check
+	}
+	defer func() { _ = db.Close() }()
+
+	ids, err := readIssueIDs(db)
+	if err != nil {
+		return doctorCheck{Name: "Child Counters", Status: statusWarning, Message: "Unable to check child counters", Detail: err.Error()}
+	}
+	stale, err := findStaleChildCounters(db, ids)
+	if err != nil {
+		return doctorCheck{Name: "Child Counters", Status: statusWarning, Message: "Unable to check child counters", Detail: err.Error()}
+	}
+	if len(stale) == 0 {
+		return doctorCheck{
+			Name:    "Child Counters",
+			Status:  statusOK,
+			Message: "Child counters are ahead of every existing child",
+		}
+	}
+	entries := make([]string, len(stale))
+	for i, s := range stale {
+		entries[i] = fmt.Sprintf("%s (counter %d, highest child .%d)", s.ParentID, s.Counter, s.Highest)
+	}
+	return doctorCheck{
+		Name:    "Child Counters",
+		Status:  statusWarning,
+		Message: fmt.Sprintf("%d parent(s) have a child counter behind their children", len(stale)),
+		Detail:  summarizeIDs(entries),
+		Fix:     "Run 'bd doctor --fix' to raise them to the highest child number",
+	}
+}
+
+// checkIDValidity flags issue IDs that aren't a configured prefix, a dash,
+// a base36 hash and optional .N child numbers. Issues from other repos
+// aren't held to this repo's prefixes.
+func checkIDValidity(path string) doctorCheck {
+	db, check := openDoctorDatabase(path, "ID Validity")
+	if check != nil {
+		return *check
+	}
+	defer func() { _ = db.Close() }()
+
+	var prefixes types.IDPrefixes
+	var byType string
+	_ = db.QueryRow(`SELECT value FROM config WHERE key = 'issue_prefix'`).Scan(&prefixes.Default)
+	_ = db.QueryRow(`SELECT value FROM config WHERE key = ?`, types.PrefixByTypeConfigKey).Scan(&byType)
+	prefixes.ByType, _ = types.ParsePrefixByType(byType)
+
+	ids, err := readIssueIDs(db)
+	if err != nil {
+		return doctorCheck{Name: "ID Validity", Status: statusWarning, Message: "Unable to check issue IDs", Detail: err.Error()}
+	}
+	var invalid []string
+	for id, sourceRepo := range ids {
+		idPrefixes := prefixes
+		if sourceRepo != "" && sourceRepo != "." {
+			idPrefixes = types.IDPrefixes{}
+		}
+		if problem := issueIDProblem(id, idPrefixes); problem != "" {
+			invalid = append(invalid, fmt.Sprintf("%s (%s)", id, problem))
+		}
+	}
+	if len(invalid) == 0 {
+		return doctorCheck{
+			Name:    "ID Validity",
+			Status:  statusOK,
+			Message: "All issue IDs are well-formed",
+		}
+	}
+	sort.Strings(invalid)
+	return doctorCheck{
+		Name:    "ID Validity",
+		Status:  statusWarning,
+		Message: fmt.Sprintf("%d issue ID(s) are malformed", len(invalid)),
+		Detail:  summarizeIDs(invalid),
+		Fix:     "Run 'bd rename-prefix' for IDs with the wrong prefix; fix other IDs in the JSONL and re-import",
+	}
+}
+
+// integrityFixResult reports what fixIntegrity changed
+type integrityFixResult struct {
+	BackupPath string
+	OrphanRows int64 // Per-issue rows and dependency edges deleted
+	Counters   int   // Child counters raised
+}
+
+// fixIntegrity repairs what the integrity checks can fix safely: it deletes
+// rows and dependency edges that reference missing issues and raises child
+// counters to their highest child. The database is backed up next to
+// itself first, and the repairs run in one transaction.
+func fixIntegrity(path string) (*integrityFixResult, error) {
+	dbPath := doctorDatabasePath(path)
+	db, err := sql.Open("sqlite3", "file:"+dbPath+"?_pragma=busy_timeout(30000)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	// VACUUM INTO takes a consistent copy even with a WAL that hasn't been
+	// checkpointed. The .backup.db suffix keeps it out of the database
+	// files check.
+	result := &integrityFixResult{
+		BackupPath: strings.TrimSuffix(dbPath, ".db") + ".doctor-" + time.Now().Format("20060102-150405") + ".backup.db",
+	}
+	if _, err := db.Exec(`VACUUM INTO ?`, result.BackupPath); err != nil {
+		return nil, fmt.Errorf("failed to back up database: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, t := range issueRowTables {
+		if ok, err := tableExists(tx, t.table); err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+		// #nosec G201 - table and column names come from issueRowTables
+		res, err := tx.Exec(fmt.Sprintf(`
+			DELETE FROM %[1]s WHERE NOT EXISTS (SELECT 1 FROM issues i WHERE i.id = %[1]s.%[2]s)`, t.table, t.column))
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete orphan %s: %w", t.table, err)
+		}
+		n, _ := res.RowsAffected()
+		result.OrphanRows += n
+	}
+	res, err := tx.Exec(`
+		DELETE FROM dependencies
+		WHERE NOT EXISTS (SELECT 1 FROM issues i WHERE i.id = dependencies.issue_id)
+		   OR NOT EXISTS (SELECT 1 FROM issues i WHERE i.id = dependencies.depends_on_id)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete orphan dependencies: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	result.OrphanRows += n
+
+	ids, err := readIssueIDs(tx)
+	if err != nil {
+		return nil, err
+	}
+	stale, err := findStaleChildCounters(tx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range stale {
+		if _, err := tx.Exec(`
+			INSERT INTO child_counters (parent_id, last_child) VALUES (?, ?)
+			ON CONFLICT(parent_id) DO UPDATE SET last_child = MAX(last_child, excluded.last_child)
+		`, s.ParentID, s.Highest); err != nil {
+			return nil, fmt.Errorf("failed to set child counter for %s: %w", s.ParentID, err)
+		}
+	}
+	result.Counters = len(stale)
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit repairs: %w", err)
+	}
+	return result, nil
+}
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/types"
 )
 
 func TestDoctorNoBeadsDir(t *testing.T) {
@@ -434,3 +438,169 @@ func TestCheckGitHooks(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckPriorityPropagation(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".beads", beads.CanonicalDatabaseName)
+	store := newTestStore(t, dbPath)
+	ctx := context.Background()
+
+	blocker := &types.Issue{Title: "Blocker", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeBug}
+	dependent := &types.Issue{Title: "Dependent", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{blocker, dependent} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	dep := &types.Dependency{IssueID: dependent.ID, DependsOnID: blocker.ID, Type: types.DepBlocks}
+	if err := store.AddDependency(ctx, dep, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	// Not flagged while propagation is off
+	if check := checkPriorityPropagation(tmpDir); check.Status != statusOK {
+		t.Errorf("Expected ok with propagation off, got %s: %s", check.Status, check.Message)
+	}
+
+	if err := store.SetConfig(ctx, types.PriorityPropagationConfigKey, types.PriorityPropagationInherit); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	check := checkPriorityPropagation(tmpDir)
+	if check.Status != statusWarning {
+		t.Fatalf("Expected warning, got %s: %s", check.Status, check.Message)
+	}
+	if !strings.Contains(check.Detail, dependent.ID) {
+		t.Errorf("Expected detail to mention %s, got %q", dependent.ID, check.Detail)
+	}
+}
+
+func TestCheckOrphanDependencies(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".beads", beads.CanonicalDatabaseName)
+	store := newTestStore(t, dbPath)
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Survivor", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if check := checkOrphanDependencies(tmpDir); check.Status != statusOK {
+		t.Errorf("Expected ok without orphans, got %s: %s", check.Status, check.Message)
+	}
+
+	// Foreign keys normally prevent orphans; simulate one left behind by an old import
+	conn, err := store.UnderlyingConn(ctx)
+	if err != nil {
+		t.Fatalf("UnderlyingConn failed: %v", err)
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`); err != nil {
+		t.Fatalf("failed to disable foreign keys: %v", err)
+	}
+	if _, err := conn.ExecContext(ctx, `INSERT INTO dependencies (issue_id, depends_on_id, type, created_by) VALUES (?, 'test-deleted', 'blocks', 'test')`, issue.ID); err != nil {
+		t.Fatalf("failed to insert orphan dependency: %v", err)
+	}
+
+	check := checkOrphanDependencies(tmpDir)
+	if check.Status != statusWarning {
+		t.Fatalf("Expected warning, got %s: %s", check.Status, check.Message)
+	}
+	if !strings.Contains(check.Detail, issue.ID+" → test-deleted") {
+		t.Errorf("Expected detail to mention the orphan edge, got %q", check.Detail)
+	}
+}
+
+func TestDoctorIntegrityChecksAndFix(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".beads", beads.CanonicalDatabaseName)
+	store := newTestStore(t, dbPath)
+	ctx := context.Background()
+
+	parent := &types.Issue{Title: "Parent", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeEpic}
+	if err := store.CreateIssue(ctx, parent, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		child := &types.Issue{Title: "Child", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateChildIssue(ctx, child, parent.ID, "test"); err != nil {
+			t.Fatalf("CreateChildIssue failed: %v", err)
+		}
+	}
+	if err := store.AddLabel(ctx, parent.ID, "keep", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	for _, check := range []doctorCheck{checkOrphanRows(tmpDir), checkHierarchy(tmpDir), checkChildCounters(tmpDir), checkIDValidity(tmpDir)} {
+		if check.Status != statusOK {
+			t.Errorf("%s: expected ok on a healthy database, got %s: %s (%s)", check.Name, check.Status, check.Message, check.Detail)
+		}
+	}
+
+	// Simulate the damage a manual JSONL edit or an import with foreign keys
+	// off leaves behind
+	conn, err := store.UnderlyingConn(ctx)
+	if err != nil {
+		t.Fatalf("UnderlyingConn failed: %v", err)
+	}
+	defer conn.Close()
+	for _, stmt := range []string{
+		`PRAGMA foreign_keys = OFF`,
+		`INSERT INTO labels (issue_id, label) VALUES ('test-gone', 'stale')`,
+		`INSERT INTO events (issue_id, event_type, actor) VALUES ('test-gone', 'created', 'test')`,
+		`INSERT INTO child_counters (parent_id, last_child) VALUES ('test-gone', 4)`,
+		`UPDATE child_counters SET last_child = 1 WHERE parent_id = '` + parent.ID + `'`,
+		`INSERT INTO issues (id, title, status, priority, issue_type) VALUES ('test-nope.1', 'Orphan child', 'open', 2, 'task')`,
+		`INSERT INTO issues (id, title, status, priority, issue_type) VALUES ('other-Bad_ID', 'Malformed', 'open', 2, 'task')`,
+	} {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	check := checkOrphanRows(tmpDir)
+	if check.Status != statusWarning || !strings.Contains(check.Detail, "labels: test-gone") || !strings.Contains(check.Detail, "events: test-gone") {
+		t.Errorf("Orphan Rows = %s: %s (%s)", check.Status, check.Message, check.Detail)
+	}
+	if check := checkHierarchy(tmpDir); check.Status != statusWarning || !strings.Contains(check.Detail, "test-nope.1 (parent test-nope)") {
+		t.Errorf("Hierarchy = %s: %s (%s)", check.Status, check.Message, check.Detail)
+	}
+	if check := checkChildCounters(tmpDir); check.Status != statusWarning || !strings.Contains(check.Detail, parent.ID+" (counter 1, highest child .2)") {
+		t.Errorf("Child Counters = %s: %s (%s)", check.Status, check.Message, check.Detail)
+	}
+	if check := checkIDValidity(tmpDir); check.Status != statusWarning || !strings.Contains(check.Detail, "other-Bad_ID") || strings.Contains(check.Detail, "test-nope.1") {
+		t.Errorf("ID Validity = %s: %s (%s)", check.Status, check.Message, check.Detail)
+	}
+
+	fixed, err := fixIntegrity(tmpDir)
+	if err != nil {
+		t.Fatalf("fixIntegrity failed: %v", err)
+	}
+	if fixed.OrphanRows != 3 || fixed.Counters != 1 {
+		t.Errorf("fixIntegrity = %+v, want 3 orphan rows and 1 counter", fixed)
+	}
+	if _, err := os.Stat(fixed.BackupPath); err != nil {
+		t.Errorf("expected a backup at %s: %v", fixed.BackupPath, err)
+	}
+	if check := checkMultipleDatabases(tmpDir); check.Status != statusOK {
+		t.Errorf("expected the backup not to count as a second database, got %s: %s", check.Message, check.Detail)
+	}
+	for _, check := range []doctorCheck{checkOrphanRows(tmpDir), checkChildCounters(tmpDir)} {
+		if check.Status != statusOK {
+			t.Errorf("%s: expected ok after --fix, got %s: %s (%s)", check.Name, check.Status, check.Message, check.Detail)
+		}
+	}
+
+	// Real data survives, and the next child doesn't collide
+	labels, err := store.GetLabels(ctx, parent.ID)
+	if err != nil || len(labels) != 1 {
+		t.Errorf("expected the parent's label to survive, got %v (%v)", labels, err)
+	}
+	child := &types.Issue{Title: "Third", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateChildIssue(ctx, child, parent.ID, "test"); err != nil {
+		t.Fatalf("CreateChildIssue after fix failed: %v", err)
+	}
+	if child.ID != parent.ID+".3" {
+		t.Errorf("next child = %s, want %s.3", child.ID, parent.ID)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
 var duplicatesCmd = &cobra.Command{
@@ -63,7 +64,7 @@ Example:
 		refCounts := countReferences(allIssues)
 		// Prepare output
 		var mergeCommands []string
-		var mergeResults []map[string]interface{}
+		var mergeResults []*sqlite.IssueMerge
 		for _, group := range duplicateGroups {
 			target := chooseMergeTarget(group, refCounts)
 			sources := make([]string, 0, len(group)-1)
@@ -72,15 +73,14 @@ Example:
 					sources = append(sources, issue.ID)
 				}
 			}
-			// TODO: performMerge implementation pending
-			// For now, just generate the command suggestion
-			cmd := fmt.Sprintf("bd merge %s --into %s", strings.Join(sources, " "), target.ID)
+			cmd := fmt.Sprintf("bd merge-issues %s --into %s", strings.Join(sources, " "), target.ID)
 			mergeCommands = append(mergeCommands, cmd)
 			
-			if autoMerge || dryRun {
-				if !dryRun {
-					// TODO: Call performMerge when implemented
-					fmt.Fprintf(os.Stderr, "Auto-merge not yet fully implemented. Use suggested commands instead.\n")
+			if autoMerge && !dryRun {
+				results, err := performMerge(ctx, target.ID, sources, false)
+				mergeResults = append(mergeResults, results...)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				}
 			}
 		}
@@ -124,7 +124,7 @@ Example:
 						sources = append(sources, issue.ID)
 					}
 				}
-				fmt.Printf("  %s bd merge %s --into %s\n\n",
+				fmt.Printf("  %s bd merge-issues %s --into %s\n\n",
 					cyan("Suggested:"), strings.Join(sources, " "), target.ID)
 			}
 			if autoMerge {
@@ -239,7 +239,7 @@ func formatDuplicateGroupsJSON(groups [][]*types.Issue, refCounts map[string]int
 			"issues":              issues,
 			"suggested_target":    target.ID,
 			"suggested_sources":   sources,
-			"suggested_merge_cmd": fmt.Sprintf("bd merge %s --into %s", strings.Join(sources, " "), target.ID),
+			"suggested_merge_cmd": fmt.Sprintf("bd merge-issues %s --into %s", strings.Join(sources, " "), target.ID),
 		})
 	}
 	return result
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// unassignedGroup labels issues with no assignee in effort rollups
+const unassignedGroup = "(unassigned)"
+
+// parseMinutes parses an effort value such as "2h", "1h30m" or "45m", or a
+// bare number of minutes, rounding durations to the nearest minute
+func parseMinutes(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if minutes, err := strconv.Atoi(value); err == nil {
+		if minutes < 0 {
+			return 0, fmt.Errorf("%q is negative", value)
+		}
+		return minutes, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a duration like 2h, 30m or 1h30m", value)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("%q is negative", value)
+	}
+	return int(d.Round(time.Minute) / time.Minute), nil
+}
+
+// formatMinutes renders minutes as "1h30m", "2h" or "45m"
+func formatMinutes(minutes int) string {
+	h, m := minutes/60, minutes%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh%dm", h, m)
+	}
+}
+
+// EffortTotals sums estimated and spent effort over a group of issues.
+// Remaining is what's left of each open issue's estimate; closed issues have
+// none left.
+type EffortTotals struct {
+	Count     int `json:"count"`
+	Estimated int `json:"estimated_minutes"`
+	Spent     int `json:"spent_minutes"`
+	Remaining int `json:"remaining_minutes"`
+}
+
+func (t *EffortTotals) add(issue *types.Issue) {
+	t.Count++
+	var estimated, spent int
+	if issue.EstimatedMinutes != nil {
+		estimated = *issue.EstimatedMinutes
+	}
+	if issue.SpentMinutes != nil {
+		spent = *issue.SpentMinutes
+	}
+	t.Estimated += estimated
+	t.Spent += spent
+	if issue.Status != types.StatusClosed && estimated > spent {
+		t.Remaining += estimated - spent
+	}
+}
+
+// EpicEffort is an epic's effort rolled up over the epic and everything
+// under it through parent-child dependencies
+type EpicEffort struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	EffortTotals
+}
+
+// EffortStats is the effort rollup shown by bd stats --effort
+type EffortStats struct {
+	Total      EffortTotals             `json:"total"`
+	ByStatus   map[string]*EffortTotals `json:"by_status"`
+	ByType     map[string]*EffortTotals `json:"by_type"`
+	ByAssignee map[string]*EffortTotals `json:"by_assignee"`
+	Epics      []*EpicEffort            `json:"epics"`
+}
+
+// computeEffortStats rolls up effort over issues, using the parent-child
+// edges in deps (keyed by child ID) to total each epic's descendants
+func computeEffortStats(issues []*types.Issue, deps map[string][]*types.Dependency) *EffortStats {
+	stats := &EffortStats{
+		ByStatus:   make(map[string]*EffortTotals),
+		ByType:     make(map[string]*EffortTotals),
+		ByAssignee: make(map[string]*EffortTotals),
+		Epics:      []*EpicEffort{},
+	}
+	group := func(groups map[string]*EffortTotals, key string) *EffortTotals {
+		if groups[key] == nil {
+			groups[key] = &EffortTotals{}
+		}
+		return groups[key]
+	}
+
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+		stats.Total.add(issue)
+		group(stats.ByStatus, string(issue.Status)).add(issue)
+		group(stats.ByType, string(issue.IssueType)).add(issue)
+		assignee := issue.Assignee
+		if assignee == "" {
+			assignee = unassignedGroup
+		}
+		group(stats.ByAssignee, assignee).add(issue)
+	}
+
+	children := make(map[string][]string)
+	for childID, childDeps := range deps {
+		for _, dep := range childDeps {
+			if dep.Type == types.DepParentChild {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], childID)
+			}
+		}
+	}
+	for _, issue := range issues {
+		if issue.IssueType != types.TypeEpic {
+			continue
+		}
+		epic := &EpicEffort{ID: issue.ID, Title: issue.Title}
+		// Walk the subtree once per issue, even if edges form a cycle
+		seen := map[string]bool{issue.ID: true}
+		queue := []string{issue.ID}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			if member := byID[id]; member != nil {
+				epic.add(member)
+			}
+			for _, childID := range children[id] {
+				if !seen[childID] {
+					seen[childID] = true
+					queue = append(queue, childID)
+				}
+			}
+		}
+		stats.Epics = append(stats.Epics, epic)
+	}
+	sort.Slice(stats.Epics, func(i, j int) bool { return stats.Epics[i].ID < stats.Epics[j].ID })
+	return stats
+}
+
+func runEffortStats() {
+	if err := ensureDirectMode("stats --effort requires direct database access"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	ctx := context.Background()
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	deps, err := store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	stats := computeEffortStats(issues, deps)
+	if jsonOutput {
+		outputJSON(stats)
+		return
+	}
+	printEffortStats(stats)
+}
+
+func printEffortStats(stats *EffortStats) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	row := func(name string, t *EffortTotals) {
+		_, _ = fmt.Fprintf(w, "  %s\t%d\t%s\t%s\t%s\n", name, t.Count,
+			formatMinutes(t.Estimated), formatMinutes(t.Spent), formatMinutes(t.Remaining))
+	}
+	section := func(title string, groups map[string]*EffortTotals) {
+		_, _ = fmt.Fprintf(w, "%s\tISSUES\tESTIMATE\tSPENT\tREMAINING\n", strings.ToUpper(title))
+		keys := make([]string, 0, len(groups))
+		for key := range groups {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			row(key, groups[key])
+		}
+		_, _ = fmt.Fprintln(w, "\t\t\t\t")
+	}
+
+	_, _ = fmt.Fprintf(w, "TOTAL\tISSUES\tESTIMATE\tSPENT\tREMAINING\n")
+	row("all", &stats.Total)
+	_, _ = fmt.Fprintln(w, "\t\t\t\t")
+	section("status", stats.ByStatus)
+	section("type", stats.ByType)
+	section("assignee", stats.ByAssignee)
+	if len(stats.Epics) > 0 {
+		_, _ = fmt.Fprintf(w, "EPIC\tISSUES\tESTIMATE\tSPENT\tREMAINING\n")
+		for _, epic := range stats.Epics {
+			row(fmt.Sprintf("%s %s", epic.ID, epic.Title), &epic.EffortTotals)
+		}
+	}
+	_ = w.Flush()
+}
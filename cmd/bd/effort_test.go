@@ -0,0 +1,86 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseMinutes(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{"2h", 120, false},
+		{"30m", 30, false},
+		{"1h30m", 90, false},
+		{"45", 45, false},
+		{"0", 0, false},
+		{"90s", 2, false},
+		{"-1h", 0, true},
+		{"-5", 0, true},
+		{"soon", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseMinutes(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseMinutes(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseMinutes(%q) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+
+	for minutes, want := range map[int]string{0: "0m", 45: "45m", 120: "2h", 90: "1h30m"} {
+		if got := formatMinutes(minutes); got != want {
+			t.Errorf("formatMinutes(%d) = %q, want %q", minutes, got, want)
+		}
+	}
+}
+
+func TestComputeEffortStats(t *testing.T) {
+	intPtr := func(n int) *int { return &n }
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Epic", Status: types.StatusOpen, IssueType: types.TypeEpic, EstimatedMinutes: intPtr(60)},
+		{ID: "bd-2", Status: types.StatusInProgress, IssueType: types.TypeTask, Assignee: "alice", EstimatedMinutes: intPtr(120), SpentMinutes: intPtr(30)},
+		{ID: "bd-3", Status: types.StatusClosed, IssueType: types.TypeTask, Assignee: "alice", EstimatedMinutes: intPtr(60), SpentMinutes: intPtr(20)},
+		{ID: "bd-4", Status: types.StatusOpen, IssueType: types.TypeBug, EstimatedMinutes: intPtr(30), SpentMinutes: intPtr(45)},
+		{ID: "bd-5", Status: types.StatusOpen, IssueType: types.TypeTask},
+	}
+	// bd-2 is under the epic and bd-3 under bd-2; the cycle back to the
+	// epic must not count anything twice
+	deps := map[string][]*types.Dependency{
+		"bd-2": {{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepParentChild}},
+		"bd-3": {{IssueID: "bd-3", DependsOnID: "bd-2", Type: types.DepParentChild}},
+		"bd-1": {{IssueID: "bd-1", DependsOnID: "bd-3", Type: types.DepParentChild}},
+		"bd-4": {{IssueID: "bd-4", DependsOnID: "bd-1", Type: types.DepBlocks}},
+	}
+
+	stats := computeEffortStats(issues, deps)
+
+	if want := (EffortTotals{Count: 5, Estimated: 270, Spent: 95, Remaining: 150}); stats.Total != want {
+		t.Errorf("total = %+v, want %+v", stats.Total, want)
+	}
+	if got := stats.ByStatus[string(types.StatusClosed)]; got == nil || got.Remaining != 0 || got.Spent != 20 {
+		t.Errorf("closed = %+v, want no remaining effort and 20 spent", got)
+	}
+	if got := stats.ByType[string(types.TypeBug)]; got == nil || got.Remaining != 0 {
+		t.Errorf("bug = %+v, want overspent estimate to leave nothing remaining", got)
+	}
+	if got := stats.ByAssignee[unassignedGroup]; got == nil || got.Count != 3 {
+		t.Errorf("unassigned = %+v, want 3 issues", got)
+	}
+	if got := stats.ByAssignee["alice"]; got == nil || got.Count != 2 || got.Estimated != 180 {
+		t.Errorf("alice = %+v, want 2 issues estimated at 180", got)
+	}
+
+	if len(stats.Epics) != 1 {
+		t.Fatalf("got %d epics, want 1", len(stats.Epics))
+	}
+	epic := stats.Epics[0]
+	if want := (EffortTotals{Count: 3, Estimated: 240, Spent: 50, Remaining: 150}); epic.ID != "bd-1" || epic.EffortTotals != want {
+		t.Errorf("epic = %s %+v, want bd-1 %+v", epic.ID, epic.EffortTotals, want)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 var epicCmd = &cobra.Command{
@@ -86,7 +87,7 @@ var epicStatusCmd = &cobra.Command{
 			} else {
 				statusIcon = "○"
 			}
-			fmt.Printf("%s %s %s\n", statusIcon, cyan(epic.ID), bold(epic.Title))
+			fmt.Printf("%s %s %s\n", statusIcon, cyan(displayID(epic.ID)), bold(epic.Title))
 			fmt.Printf("   Progress: %d/%d children closed (%d%%)\n",
 				epicStatus.ClosedChildren, epicStatus.TotalChildren, percentage)
 			if epicStatus.EligibleForClose {
@@ -151,7 +152,7 @@ var closeEligibleEpicsCmd = &cobra.Command{
 			} else {
 				fmt.Printf("Would close %d epic(s):\n", len(eligibleEpics))
 				for _, epicStatus := range eligibleEpics {
-					fmt.Printf("  - %s: %s\n", epicStatus.Epic.ID, epicStatus.Epic.Title)
+					fmt.Printf("  - %s: %s\n", displayID(epicStatus.Epic.ID), epicStatus.Epic.Title)
 				}
 			}
 			return
@@ -176,7 +177,7 @@ var closeEligibleEpicsCmd = &cobra.Command{
 				}
 			} else {
 				ctx := context.Background()
-				err := store.CloseIssue(ctx, epicStatus.Epic.ID, "All children completed", "system")
+				err := store.CloseIssue(ctx, epicStatus.Epic.ID, storage.CloseOptions{Reason: "All children completed"}, "system")
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", epicStatus.Epic.ID, err)
 					continue
@@ -197,7 +198,7 @@ var closeEligibleEpicsCmd = &cobra.Command{
 		} else {
 			fmt.Printf("✓ Closed %d epic(s)\n", len(closedIDs))
 			for _, id := range closedIDs {
-				fmt.Printf("  - %s\n", id)
+				fmt.Printf("  - %s\n", displayID(id))
 			}
 		}
 	},
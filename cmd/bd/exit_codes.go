@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// Exit statuses scripts can rely on; see docs/CLI_REFERENCE.md. Anything
+// else that fails exits 1.
+const (
+	exitNoDatabase = 2 // No beads database found
+	exitNotFound   = 3 // No issue matches the ID or title
+	exitAmbiguous  = 4 // A partial ID or title matches several issues
+	exitConflict   = 5 // The issue changed after it was read
+	exitValidation = 6 // An invalid flag or field value
+	exitReadOnly   = 7 // A command that changes data was run with --read-only
+)
+
+// exitCodes maps each error code to its exit status
+var exitCodes = map[string]int{
+	types.ErrCodeNoDatabase: exitNoDatabase,
+	types.ErrCodeNotFound:   exitNotFound,
+	types.ErrCodeAmbiguous:  exitAmbiguous,
+	types.ErrCodeConflict:   exitConflict,
+	types.ErrCodeValidation: exitValidation,
+	types.ErrCodeReadOnly:   exitReadOnly,
+}
+
+// jsonError is the --json output of a command that fails: code is one of
+// the types.ErrCode* constants, or "error" when the failure isn't classified
+type jsonError struct {
+	Error      string   `json:"error"`
+	Message    string   `json:"message"`
+	Candidates []string `json:"candidates,omitempty"` // IDs an ambiguous ID or title matched
+}
+
+// exitCodeFor returns the exit status for err: the one for its error code,
+// or 1
+func exitCodeFor(err error) int {
+	if code, ok := exitCodes[types.ErrorCode(err)]; ok {
+		return code
+	}
+	return 1
+}
+
+// newJSONError builds the --json error output for err
+func newJSONError(err error) jsonError {
+	out := jsonError{Error: types.ErrorCode(err), Message: err.Error()}
+	if out.Error == "" {
+		out.Error = "error"
+	}
+	var ambiguous *utils.AmbiguousIDError
+	if errors.As(err, &ambiguous) {
+		for _, issue := range ambiguous.Candidates {
+			out.Candidates = append(out.Candidates, issue.ID)
+		}
+	}
+	return out
+}
+
+// exitWithError prints a message built from format and args (without the
+// trailing newline), plus err as JSON with --json, and exits with err's
+// exit status
+func exitWithError(err error, format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	if jsonOutput {
+		outputJSON(newJSONError(err))
+	}
+	os.Exit(exitCodeFor(err))
+}
+
+// exitInvalid reports an invalid flag or argument and exits with
+// exitValidation
+func exitInvalid(format string, args ...interface{}) {
+	err := types.WithCode(types.ErrCodeValidation, fmt.Errorf(format, args...))
+	exitWithError(err, "Error: %v", err)
+}
+
+// exitStatus is the status bd exits with once the command and its cleanup
+// (such as the final auto-flush) have run, set by exitIfFailed
+var exitStatus int
+
+// exitIfFailed makes bd exit with the status of firstErr, the first failure
+// of a command that carries on through the rest of its issues, if there was
+// one. With --json and nothing else printed, firstErr is printed as JSON.
+func exitIfFailed(firstErr error, printedJSON bool) {
+	if firstErr == nil {
+		return
+	}
+	if jsonOutput && !printedJSON {
+		outputJSON(newJSONError(firstErr))
+	}
+	exitStatus = exitCodeFor(firstErr)
+}
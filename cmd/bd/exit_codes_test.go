@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+func TestExitCodeFor(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"unclassified", errors.New("disk full"), 1},
+		{"not found", fmt.Errorf("resolving: %w", types.WithCode(types.ErrCodeNotFound, errors.New("no issue found"))), exitNotFound},
+		{"ambiguous", &utils.AmbiguousIDError{Input: "a3", Candidates: []*types.Issue{{ID: "bd-a3f"}, {ID: "bd-a3c"}}}, exitAmbiguous},
+		{"conflict", &storage.ConflictError{ID: "bd-1", Expected: time.Unix(1, 0), Actual: time.Unix(2, 0)}, exitConflict},
+		{"validation", types.WithCode(types.ErrCodeValidation, errors.New("invalid status: bogus")), exitValidation},
+		{"daemon conflict", &rpc.ResponseError{Message: "failed to update issue", Code: types.ErrCodeConflict}, exitConflict},
+		{"daemon unclassified", &rpc.ResponseError{Message: "failed to update issue"}, 1},
+		{"read-only", types.WithCode(types.ErrCodeReadOnly, errors.New("'bd create' is not allowed with --read-only")), exitReadOnly},
+	}
+	for _, tt := range tests {
+		if got := exitCodeFor(tt.err); got != tt.want {
+			t.Errorf("%s: exitCodeFor = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNewJSONError(t *testing.T) {
+	ambiguous := &utils.AmbiguousIDError{Input: "a3", Candidates: []*types.Issue{{ID: "bd-a3c"}, {ID: "bd-a3f"}}}
+	got := newJSONError(ambiguous)
+	if got.Error != types.ErrCodeAmbiguous || got.Message != ambiguous.Error() || strings.Join(got.Candidates, ",") != "bd-a3c,bd-a3f" {
+		t.Errorf("newJSONError(ambiguous) = %+v", got)
+	}
+	if got := newJSONError(errors.New("disk full")); got.Error != "error" || got.Candidates != nil {
+		t.Errorf("newJSONError(unclassified) = %+v, want code \"error\"", got)
+	}
+}
+
+var (
+	exitCodeBDOnce sync.Once
+	exitCodeBD     string
+	exitCodeBDErr  error
+)
+
+// buildExitCodeBD builds bd from this tree once; the bd binary at the repo
+// root may predate the exit codes under test
+func buildExitCodeBD(t *testing.T) string {
+	t.Helper()
+	exitCodeBDOnce.Do(func() {
+		dir, err := os.MkdirTemp("", "bd-exit-codes-*")
+		if err != nil {
+			exitCodeBDErr = err
+			return
+		}
+		exitCodeBD = filepath.Join(dir, "bd")
+		if out, err := exec.Command("go", "build", "-o", exitCodeBD, ".").CombinedOutput(); err != nil {
+			exitCodeBDErr = fmt.Errorf("go build failed: %v\n%s", err, out)
+		}
+	})
+	if exitCodeBDErr != nil {
+		t.Fatal(exitCodeBDErr)
+	}
+	return exitCodeBD
+}
+
+func TestCLI_ExitCodes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow CLI test in short mode")
+	}
+	bd := buildExitCodeBD(t)
+	env := []string{"BEADS_NO_DAEMON=1"}
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, "BEADS_DIR=") && !strings.HasPrefix(kv, "BEADS_DB=") {
+			env = append(env, kv)
+		}
+	}
+	run := func(dir string, args ...string) (int, string) {
+		t.Helper()
+		cmd := exec.Command(bd, append([]string{"--no-daemon"}, args...)...)
+		cmd.Dir = dir
+		cmd.Env = env
+		out, err := cmd.Output()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), string(out)
+		}
+		if err != nil {
+			t.Fatalf("bd %v failed to run: %v", args, err)
+		}
+		return 0, string(out)
+	}
+
+	emptyDir := createTempDirWithCleanup(t)
+	if code, out := run(emptyDir, "show", "test-1", "--json"); code != exitNoDatabase || !strings.Contains(out, `"error":"no_database"`) {
+		t.Errorf("show without a database: exit %d, output %q; want %d with a no_database error", code, out, exitNoDatabase)
+	}
+
+	dir := createTempDirWithCleanup(t)
+	if code, out := run(dir, "init", "--prefix", "test", "--quiet"); code != 0 {
+		t.Fatalf("init failed with exit %d: %s", code, out)
+	}
+	for _, id := range []string{"test-abc1", "test-abc2"} {
+		if code, out := run(dir, "create", "Issue "+id, "--id", id, "--json"); code != 0 {
+			t.Fatalf("create %s failed with exit %d: %s", id, code, out)
+		}
+	}
+
+	tests := []struct {
+		args []string
+		want int
+		code string
+	}{
+		{[]string{"show", "zzzz"}, exitNotFound, types.ErrCodeNotFound},
+		{[]string{"close", "zzzz"}, exitNotFound, types.ErrCodeNotFound},
+		{[]string{"reopen", "zzzz"}, exitNotFound, types.ErrCodeNotFound},
+		{[]string{"update", "zzzz", "--title", "x"}, exitNotFound, types.ErrCodeNotFound},
+		{[]string{"show", "abc"}, exitAmbiguous, types.ErrCodeAmbiguous},
+		{[]string{"close", "abc"}, exitAmbiguous, types.ErrCodeAmbiguous},
+		{[]string{"update", "test-abc1", "--status", "bogus"}, exitValidation, types.ErrCodeValidation},
+		{[]string{"update", "test-abc1", "--priority", "9"}, exitValidation, types.ErrCodeValidation},
+		{[]string{"close", "test-abc1", "--resolution", "nah"}, exitValidation, types.ErrCodeValidation},
+		{[]string{"reopen", "--status", "bogus"}, exitValidation, types.ErrCodeValidation},
+		{[]string{"show", "test-abc1", "--depth", "0"}, exitValidation, types.ErrCodeValidation},
+		{[]string{"update", "test-abc1", "--title", "Renamed"}, 0, ""},
+	}
+	for _, tt := range tests {
+		code, out := run(dir, append(tt.args, "--json")...)
+		if code != tt.want {
+			t.Errorf("bd %v: exit %d, want %d (output %q)", tt.args, code, tt.want, out)
+			continue
+		}
+		if tt.code == "" {
+			continue
+		}
+		var payload jsonError
+		if err := json.Unmarshal([]byte(out), &payload); err != nil || payload.Error != tt.code {
+			t.Errorf("bd %v --json: output %q, want an error payload with code %q", tt.args, out, tt.code)
+		}
+	}
+}
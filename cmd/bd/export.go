@@ -1,20 +1,26 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/debug"
-	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
 // countIssuesInJSONL counts the number of issues in a JSONL file
@@ -46,6 +52,64 @@ func countIssuesInJSONL(path string) (int, error) {
 	return count, nil
 }
 
+// countIssuesInJSONLLenient counts the valid issues in a JSONL file, skipping
+// lines that don't parse (e.g. a line cut short by an interrupted write).
+// Returns the count and the 1-based numbers of the skipped lines; blank lines
+// are ignored.
+func countIssuesInJSONLLenient(path string) (int, []int, error) {
+	issues, badLines, err := readJSONLLenient(path)
+	return len(issues), badLines, err
+}
+
+// readJSONLLenient reads the issues in a JSONL file, skipping lines that
+// don't parse. Returns the issues and the 1-based numbers of the skipped
+// lines; blank lines are ignored.
+func readJSONLLenient(path string) ([]*types.Issue, []int, error) {
+	// #nosec G304 - controlled path from config
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	var issues []*types.Issue
+	var badLines []int
+	reader := bufio.NewReader(file)
+	for lineNum := 1; ; lineNum++ {
+		line, readErr := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var issue types.Issue
+			if err := json.Unmarshal(line, &issue); err != nil {
+				badLines = append(badLines, lineNum)
+			} else {
+				issues = append(issues, &issue)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return issues, badLines, fmt.Errorf("failed to read line %d: %w", lineNum, readErr)
+		}
+	}
+	return issues, badLines, nil
+}
+
+// formatLineNumbers renders line numbers for a corruption report, listing at
+// most the first 10
+func formatLineNumbers(lines []int) string {
+	const maxShown = 10
+	parts := make([]string, 0, maxShown)
+	for i, n := range lines {
+		if i == maxShown {
+			parts = append(parts, fmt.Sprintf("and %d more", len(lines)-maxShown))
+			break
+		}
+		parts = append(parts, strconv.Itoa(n))
+	}
+	return strings.Join(parts, ", ")
+}
+
 // getIssueIDsFromJSONL reads a JSONL file and returns a set of issue IDs
 func getIssueIDsFromJSONL(path string) (map[string]bool, error) {
 	// #nosec G304 - controlled path from config
@@ -77,6 +141,20 @@ func getIssueIDsFromJSONL(path string) (map[string]bool, error) {
 	return ids, nil
 }
 
+// encodeIssuesJSONL writes issues one JSON object per line, returning the IDs
+// written, in order
+func encodeIssuesJSONL(w io.Writer, issues []*types.Issue) ([]string, error) {
+	encoder := json.NewEncoder(w)
+	ids := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		if err := encoder.Encode(issue); err != nil {
+			return ids, fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	return ids, nil
+}
+
 // validateExportPath checks if the output path is safe to write to
 func validateExportPath(path string) error {
 	// Get absolute path to normalize it
@@ -109,25 +187,338 @@ func validateExportPath(path string) error {
 	return nil
 }
 
+// prunedDep is a dependency edge dropped from an export by --prune-orphan-deps
+type prunedDep struct {
+	IssueID     string
+	DependsOnID string
+	Type        types.DependencyType
+	Reason      string
+}
+
+// pruneOrphanDeps removes dependencies whose target isn't among issues and
+// returns the dropped edges. inDB reports whether a missing target still exists
+// in the database, which only changes the logged reason.
+func pruneOrphanDeps(issues []*types.Issue, inDB func(id string) bool) []prunedDep {
+	exported := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		exported[issue.ID] = true
+	}
+
+	var pruned []prunedDep
+	for _, issue := range issues {
+		var kept []*types.Dependency
+		for _, dep := range issue.Dependencies {
+			if exported[dep.DependsOnID] {
+				kept = append(kept, dep)
+				continue
+			}
+			reason := "target not in database"
+			if inDB(dep.DependsOnID) {
+				reason = "target not in export"
+			}
+			pruned = append(pruned, prunedDep{
+				IssueID:     issue.ID,
+				DependsOnID: dep.DependsOnID,
+				Type:        dep.Type,
+				Reason:      reason,
+			})
+		}
+		issue.Dependencies = kept
+	}
+	return pruned
+}
+
+// filterIssuesByID keeps the issues whose IDs are in ids, preserving order
+func filterIssuesByID(issues []*types.Issue, ids []string) []*types.Issue {
+	keep := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		keep[id] = true
+	}
+	filtered := make([]*types.Issue, 0, len(ids))
+	for _, issue := range issues {
+		if keep[issue.ID] {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// exportFilterFromFlags builds the search filter for export's list-style
+// flags. filtered reports whether any of them narrows the export, making it
+// a subset rather than a full snapshot.
+func exportFilterFromFlags(cmd *cobra.Command) (filter types.IssueFilter, filtered bool, err error) {
+	filter = types.IssueFilter{IncludeArchived: true}
+
+	if status, _ := cmd.Flags().GetString("status"); status != "" {
+		s := types.Status(status)
+		filter.Status = &s
+		filtered = true
+	}
+	if issueType, _ := cmd.Flags().GetString("type"); issueType != "" {
+		t := types.IssueType(issueType)
+		if !t.IsValid() {
+			return filter, false, fmt.Errorf("invalid --type '%s'. Valid values: bug, feature, task, epic, chore", issueType)
+		}
+		filter.IssueType = &t
+		filtered = true
+	}
+	if cmd.Flags().Changed("assignee") {
+		// An empty assignee matches unassigned issues, as in bd list
+		assignee, _ := cmd.Flags().GetString("assignee")
+		filter.Assignee = &assignee
+		filtered = true
+	}
+	if cmd.Flags().Changed("priority") {
+		value, _ := cmd.Flags().GetString("priority")
+		priority, err := priorityNames().Parse(value)
+		if err != nil {
+			return filter, false, err
+		}
+		filter.Priority = &priority
+		filtered = true
+	}
+	if labels, _ := cmd.Flags().GetStringSlice("label"); len(labels) > 0 {
+		filter.Labels = labels
+		filtered = true
+	}
+	if labelsAny, _ := cmd.Flags().GetStringSlice("label-any"); len(labelsAny) > 0 {
+		filter.LabelsAny = labelsAny
+		filtered = true
+	}
+
+	dates := []struct {
+		flag   string
+		target **time.Time
+	}{
+		{"created-after", &filter.CreatedAfter},
+		{"created-before", &filter.CreatedBefore},
+		{"updated-after", &filter.UpdatedAfter},
+		{"updated-before", &filter.UpdatedBefore},
+		{"closed-after", &filter.ClosedAfter},
+		{"closed-before", &filter.ClosedBefore},
+	}
+	for _, d := range dates {
+		value, _ := cmd.Flags().GetString(d.flag)
+		if value == "" {
+			continue
+		}
+		t, err := parseTimeFlag(value)
+		if err != nil {
+			return filter, false, fmt.Errorf("parsing --%s: %w", d.flag, err)
+		}
+		*d.target = &t
+		filtered = true
+	}
+	return filter, filtered, nil
+}
+
+// addExportFilterFlags registers the flags exportFilterFromFlags reads
+func addExportFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("status", "s", "", "Filter by status")
+	cmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL)")
+	cmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE)")
+	cmd.Flags().StringP("type", "t", "", "Filter by type (bug, feature, task, epic, chore)")
+	cmd.Flags().StringP("assignee", "a", "", "Filter by assignee (\"\" for unassigned)")
+	cmd.Flags().StringP("priority", "p", "", "Filter by priority (0-4, P0-P4 or a name: critical, high, medium, low, backlog)")
+	cmd.Flags().String("created-after", "", "Filter issues created after date (YYYY-MM-DD, RFC3339, or a duration like 7d)")
+	cmd.Flags().String("created-before", "", "Filter issues created before date (YYYY-MM-DD, RFC3339, or a duration like 7d)")
+	cmd.Flags().String("updated-after", "", "Filter issues updated after date (YYYY-MM-DD, RFC3339, or a duration like 7d)")
+	cmd.Flags().String("updated-before", "", "Filter issues updated before date (YYYY-MM-DD, RFC3339, or a duration like 7d)")
+	cmd.Flags().String("closed-after", "", "Filter issues closed after date (YYYY-MM-DD, RFC3339, or a duration like 7d)")
+	cmd.Flags().String("closed-before", "", "Filter issues closed before date (YYYY-MM-DD, RFC3339, or a duration like 7d)")
+}
+
+// isCanonicalJSONL reports whether path is the database's own JSONL file
+func isCanonicalJSONL(path string) bool {
+	canonical := findJSONLPath()
+	if canonical == "" {
+		return false
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	absCanonical, err := filepath.Abs(canonical)
+	if err != nil {
+		return false
+	}
+	return absPath == absCanonical
+}
+
 var exportCmd = &cobra.Command{
 	Use:   "export",
 	Short: "Export issues to JSONL format",
 	Long: `Export all issues to JSON Lines format (one JSON object per line).
 Issues are sorted by ID for consistent diffs.
 
-Output to stdout by default, or use -o flag for file output.`,
+Output to stdout by default, or use -o flag for file output.
+
+Use --prune-orphan-deps to drop dependency references whose target isn't part
+of the export (because it was deleted, or filtered out by --status), so the
+exported graph is self-contained. Each pruned edge is logged to stderr; the
+database itself is not changed (see 'bd repair-deps').
+
+Formats:
+  jsonl   beads JSONL (default), suitable for 'bd import'
+  github  a JSON array shaped like GitHub's issues API (title, body,
+          labels, state, state_reason, assignees) plus beads_id, for a
+          script to create the issues in a GitHub repository. The body
+          combines the description, design and acceptance criteria, with
+          dependencies as a task list. Priority, type and in_progress/blocked
+          status become labels; resolutions become state_reason. 'bd
+          import-github' maps all of it back.
+  dot     Graphviz digraph of the dependency graph. Nodes are colored by
+          status; edges point from dependent to dependency and are styled
+          by type (blocks red, parent-child blue, discovered-from green,
+          related gray).
+  mermaid the same graph as a Mermaid flowchart, for Markdown docs.
+  csv     a header row and one row per issue, quoted per RFC 4180, for
+          spreadsheets. --columns picks the columns (default id, title,
+          status, priority, type, assignee, created_at, closed_at, labels);
+          labels are joined by ";". Also available: updated_at,
+          description, design, acceptance_criteria, notes, resolution,
+          external_ref, estimated_minutes.
+  yaml    a YAML list of the same records as jsonl, with multi-line text as
+          block scalars. 'bd import' reads it back losslessly.
+  md      one Markdown document with each issue rendered as by
+          'bd show --format md --with-comments', attachments as a list of
+          links. For reading and publishing; it can't be imported.
+
+Filtering:
+  The list-style filters --status, --type, --assignee, --priority, --label,
+  --label-any and the --created-/--updated-/--closed-after/before dates work
+  as in 'bd list' and, like --since, apply to every format.
+
+  A filtered export is NOT a full snapshot: it leaves out every issue the
+  filters don't match. So it is never written to the database's own JSONL
+  (write it to stdout or another -o file), skips the checks that guard a full
+  JSONL against losing issues, and leaves dirty issues pending for the next
+  auto-flush.
+
+Graph formats only draw edges between exported issues. Use --label to keep
+issues with all the given labels, and --root to export one issue and its
+parent-child descendants. With --direction, --root instead follows every
+dependency edge: down to what the root depends on, up to what depends on it,
+or both ways for its whole connected component. --depth N stops N edges
+from the root. Each issue is visited once, so a cycle can't keep the walk
+going.
+
+Incremental export:
+  --since <time|duration> exports only issues changed after the cutoff: those
+  whose updated_at is later, or with an event (label, comment, dependency or
+  status change) recorded after it. The cutoff takes the same forms as
+  'bd list --updated-after' (2025-06-01, RFC3339, or a duration like 36h or
+  7d). With --append, the records are appended to the -o file instead of
+  replacing it, and verification expects the old count plus the new records.
+
+  A file built from appended deltas can hold several records for one issue.
+  Consumers replaying it must dedup by ID, keeping the LAST record for each ID
+  (later lines are newer); 'bd import' does this. Deltas don't carry
+  deletions: issues deleted since the cutoff are simply absent, so pair them
+  with an occasional full export. Pick the next
+  cutoff from before the previous export started, so changes made while it
+  ran aren't missed; replaying an issue twice is harmless.
+
+Splitting:
+  --split-by label|type|assignee --output-dir dir writes dir/<group>.jsonl
+  for each distinct value instead of one file, groups sorted by name and
+  issues in the usual order within each file. An issue with several labels
+  is written to EVERY one of their files, so with --split-by label the files
+  together can hold more records than there are issues; don't concatenate
+  them expecting a snapshot. Issues with no labels (or no assignee) go to
+  _none.jsonl. Characters other than letters, digits, ".", "_" and "-" in a
+  group name become "_" in its file name. The filters above apply first, and
+  the files written are listed with their counts.
+
+Examples:
+  bd export --format github --label backend | jq -c '.[]' | while read -r issue; do
+    echo "$issue" | jq '{title, body, labels, assignees}' | gh api repos/OWNER/REPO/issues --input -
+  done
+  bd export --format dot --root bd-a3f8 | dot -Tsvg -o epic.svg
+  bd export --format mermaid --root bd-a3f8 --direction both --depth 2
+  bd export --format mermaid --label frontend -o docs/deps.mmd
+  bd export --format csv --status closed --since 30d -o closed.csv
+  bd export --status open --label sprint-7 --type bug > subset.jsonl
+  bd export --format csv --columns id,title,status,labels
+  bd export --format yaml -o issues.yaml
+  bd export --format md --label release-2.0 -o docs/release-2.0.md
+  bd export --since 1h --append -o delta.jsonl
+  bd export --split-by label --status open --output-dir teams/`,
 	Run: func(cmd *cobra.Command, args []string) {
 		format, _ := cmd.Flags().GetString("format")
 		output, _ := cmd.Flags().GetString("output")
-		statusFilter, _ := cmd.Flags().GetString("status")
 		force, _ := cmd.Flags().GetBool("force")
+		pruneOrphans, _ := cmd.Flags().GetBool("prune-orphan-deps")
+		rootID, _ := cmd.Flags().GetString("root")
+		direction, _ := cmd.Flags().GetString("direction")
+		depth, _ := cmd.Flags().GetInt("depth")
+		sinceStr, _ := cmd.Flags().GetString("since")
+		appendMode, _ := cmd.Flags().GetBool("append")
+		columnsSpec, _ := cmd.Flags().GetString("columns")
+		splitBy, _ := cmd.Flags().GetString("split-by")
+		outputDir, _ := cmd.Flags().GetString("output-dir")
 		
 		debug.Logf("Debug: export flags - output=%q, force=%v\n", output, force)
 
-		if format != "jsonl" {
-			fmt.Fprintf(os.Stderr, "Error: only 'jsonl' format is currently supported\n")
+		switch format {
+		case "jsonl", "github", "dot", "mermaid", "csv", "yaml", "md":
+		default:
+			fmt.Fprintf(os.Stderr, "Error: unsupported format %q (supported: jsonl, github, dot, mermaid, csv, yaml, md)\n", format)
+			os.Exit(1)
+		}
+		if columnsSpec != "" && format != "csv" {
+			fmt.Fprintf(os.Stderr, "Error: --columns is only supported with --format csv\n")
+			os.Exit(1)
+		}
+		columns, err := parseCSVColumns(columnsSpec)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --columns: %v\n", err)
+			os.Exit(1)
+		}
+		isGraph := format == "dot" || format == "mermaid"
+		if rootID != "" && !isGraph {
+			fmt.Fprintf(os.Stderr, "Error: --root is only supported with --format dot or mermaid\n")
+			os.Exit(1)
+		}
+		if (direction != "" || depth != 0) && rootID == "" {
+			fmt.Fprintf(os.Stderr, "Error: --direction and --depth require --root\n")
+			os.Exit(1)
+		}
+		switch direction {
+		case "", walkDown, walkUp, walkBoth:
+		default:
+			fmt.Fprintf(os.Stderr, "Error: invalid --direction %q (valid: down, up, both)\n", direction)
+			os.Exit(1)
+		}
+		if depth < 0 {
+			fmt.Fprintf(os.Stderr, "Error: --depth can't be negative\n")
+			os.Exit(1)
+		}
+		if appendMode && (format != "jsonl" || output == "") {
+			fmt.Fprintf(os.Stderr, "Error: --append requires --format jsonl and an output file (-o)\n")
+			os.Exit(1)
+		}
+		if splitBy != "" {
+			if !slices.Contains(splitExportFields, splitBy) {
+				fmt.Fprintf(os.Stderr, "Error: invalid --split-by %q (valid: %s)\n", splitBy, strings.Join(splitExportFields, ", "))
+				os.Exit(1)
+			}
+			if format != "jsonl" || outputDir == "" || output != "" || appendMode {
+				fmt.Fprintf(os.Stderr, "Error: --split-by requires --format jsonl and --output-dir, and can't be combined with -o or --append\n")
+				os.Exit(1)
+			}
+		} else if outputDir != "" {
+			fmt.Fprintf(os.Stderr, "Error: --output-dir requires --split-by\n")
 			os.Exit(1)
 		}
+		var since time.Time
+		if sinceStr != "" {
+			var err error
+			if since, err = parseTimeFlag(sinceStr); err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing --since: %v\n", err)
+				os.Exit(1)
+			}
+		}
 
 		// Export command requires direct database access for consistent snapshot
 		// If daemon is connected, close it and open direct connection
@@ -148,7 +539,7 @@ Output to stdout by default, or use -o flag for file output.`,
 				fmt.Fprintf(os.Stderr, "Error: no database path found\n")
 				os.Exit(1)
 			}
-			store, err = sqlite.New(dbPath)
+			store, err = openStore(dbPath)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
 				os.Exit(1)
@@ -156,11 +547,19 @@ Output to stdout by default, or use -o flag for file output.`,
 			defer func() { _ = store.Close() }()
 		}
 
-		// Build filter
-		filter := types.IssueFilter{}
-		if statusFilter != "" {
-			status := types.Status(statusFilter)
-			filter.Status = &status
+		// Build filter; archived issues are still part of the JSONL
+		filter, filtered, err := exportFilterFromFlags(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		// A filtered export is not a full snapshot, so it must not replace
+		// the canonical JSONL (or clear its dirty tracking)
+		if filtered && format == "jsonl" && output != "" && isCanonicalJSONL(output) {
+			fmt.Fprintf(os.Stderr, "Error: refusing to write a filtered export to %s\n", output)
+			fmt.Fprintf(os.Stderr, "  A filtered export is not a full snapshot; it would drop every issue the filters leave out\n")
+			fmt.Fprintf(os.Stderr, "Hint: write it somewhere else with -o, e.g. -o subset.jsonl\n")
+			os.Exit(1)
 		}
 
 		// Get all issues
@@ -170,9 +569,48 @@ Output to stdout by default, or use -o flag for file output.`,
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+		if sinceStr != "" {
+			changedIDs, err := store.GetIssueIDsChangedSince(ctx, since)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			issues = filterIssuesByID(issues, changedIDs)
+		}
+
+		if format == "github" {
+			runGitHubExport(ctx, issues, output)
+			return
+		}
+		if format == "csv" {
+			runCSVExport(ctx, issues, output, columns)
+			return
+		}
+		if format == "yaml" {
+			runYAMLExport(ctx, issues, output)
+			return
+		}
+		if format == "md" {
+			runMarkdownExport(ctx, issues, output)
+			return
+		}
+		if isGraph {
+			if rootID != "" {
+				resolved, err := utils.ResolvePartialID(ctx, store, rootID)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error resolving issue ID %s: %v\n", rootID, err)
+					os.Exit(1)
+				}
+				rootID = resolved
+			}
+			runGraphExport(ctx, issues, format, output, rootID, direction, depth)
+			return
+		}
 
-		// Safety check: prevent exporting empty database over non-empty JSONL
-		if len(issues) == 0 && output != "" && !force {
+		// Safety check: prevent exporting empty database over non-empty JSONL.
+		// An empty delta is normal; the staleness check below still guards
+		// against replacing a full JSONL with one.
+		if len(issues) == 0 && output != "" && !force && sinceStr == "" && !appendMode && !filtered {
 			existingCount, err := countIssuesInJSONL(output)
 			if err != nil {
 				// If we can't read the file, it might not exist yet, which is fine
@@ -191,7 +629,9 @@ Output to stdout by default, or use -o flag for file output.`,
 		}
 
 		// Safety check: prevent exporting stale database that would lose issues
-		if output != "" && !force {
+		// (appending keeps every existing record, so nothing can be lost, and
+		// a filtered export leaves issues out on purpose)
+		if output != "" && !force && !appendMode && !filtered {
 			debug.Logf("Debug: checking staleness - output=%s, force=%v\n", output, force)
 			
 			// Read existing JSONL to get issue IDs
@@ -249,11 +689,6 @@ Output to stdout by default, or use -o flag for file output.`,
 			}
 		}
 
-		// Sort by ID for consistent output
-		sort.Slice(issues, func(i, j int) bool {
-			return issues[i].ID < issues[j].ID
-		})
-
 		// Populate dependencies for all issues in one query (avoids N+1 problem)
 		allDeps, err := store.GetAllDependencyRecords(ctx)
 		if err != nil {
@@ -264,6 +699,17 @@ Output to stdout by default, or use -o flag for file output.`,
 			issue.Dependencies = allDeps[issue.ID]
 		}
 
+		var pruned []prunedDep
+		if pruneOrphans {
+			pruned = pruneOrphanDeps(issues, func(id string) bool {
+				target, err := store.GetIssue(ctx, id)
+				return err == nil && target != nil
+			})
+			for _, p := range pruned {
+				fmt.Fprintf(os.Stderr, "Pruned dependency %s → %s (%s): %s\n", p.IssueID, p.DependsOnID, p.Type, p.Reason)
+			}
+		}
+
 		// Populate labels for all issues
 		for _, issue := range issues {
 			labels, err := store.GetLabels(ctx, issue.ID)
@@ -274,52 +720,79 @@ Output to stdout by default, or use -o flag for file output.`,
 			issue.Labels = labels
 		}
 
-		// Open output
-		out := os.Stdout
-		var tempFile *os.File
-		var tempPath string
-		var finalPath string
-		if output != "" {
-			// Validate output path before creating files
-			if err := validateExportPath(output); err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
-			}
-
-			// Create temporary file in same directory for atomic rename
-			dir := filepath.Dir(output)
-			base := filepath.Base(output)
-			var err error
-			tempFile, err = os.CreateTemp(dir, base+".tmp.*")
+		// Populate comments for all issues (carries threading and resolution)
+		for _, issue := range issues {
+			comments, err := store.GetIssueComments(ctx, issue.ID)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error creating temporary file: %v\n", err)
+				fmt.Fprintf(os.Stderr, "Error getting comments for %s: %v\n", issue.ID, err)
 				os.Exit(1)
 			}
-			tempPath = tempFile.Name()
-			finalPath = output
-
-			// Ensure cleanup on failure
-			defer func() {
-				if tempFile != nil {
-					_ = tempFile.Close()
-					_ = os.Remove(tempPath) // Clean up temp file if we haven't renamed it
-				}
-			}()
+			issue.Comments = comments
+		}
+
+		utils.SortIssuesForExport(issues)
 
-			out = tempFile
+		// Split files are never the canonical JSONL, so dirty issues stay
+		// pending for the next auto-flush
+		if splitBy != "" {
+			runSplitExport(issues, splitBy, outputDir)
+			return
 		}
 
 		// Write JSONL (timestamp-only deduplication DISABLED due to bd-160)
-		encoder := json.NewEncoder(out)
-		exportedIDs := make([]string, 0, len(issues))
+		var exportedIDs []string
 		skippedCount := 0
-		for _, issue := range issues {
-			if err := encoder.Encode(issue); err != nil {
-			 fmt.Fprintf(os.Stderr, "Error encoding issue %s: %v\n", issue.ID, err)
-			 os.Exit(1)
+		writeIssues := func(w io.Writer) error {
+			var err error
+			exportedIDs, err = encodeIssuesJSONL(w, issues)
+			return err
+		}
+
+		if output == "" {
+			if err := writeIssues(os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			// Validate output path before creating files
+			if err := validateExportPath(output); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
 			}
 
-			exportedIDs = append(exportedIDs, issue.ID)
+			// Records already in the file when appending, for verification
+			existingCount := 0
+			if appendMode {
+				var err error
+				existingCount, err = countIssuesInJSONL(output)
+				if err != nil && !os.IsNotExist(err) {
+					fmt.Fprintf(os.Stderr, "Error: cannot append to %s: %v\n", output, err)
+					os.Exit(1)
+				}
+				if err := appendToFile(output, 0600, writeIssues); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+			} else if err := writeFileAtomic(output, 0600, writeIssues); err != nil {
+				// Write to a temp file and rename into place so an interrupted
+				// export never leaves a truncated JSONL behind (0600: rw-------)
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			// Verify JSONL file integrity after export
+			actualCount, err := countIssuesInJSONL(output)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: Export verification failed: %v\n", err)
+				os.Exit(1)
+			}
+			if actualCount != existingCount+len(exportedIDs) {
+				fmt.Fprintf(os.Stderr, "Error: Export verification failed\n")
+				fmt.Fprintf(os.Stderr, "  Expected: %d issues\n", existingCount+len(exportedIDs))
+				fmt.Fprintf(os.Stderr, "  JSONL file: %d lines\n", actualCount)
+				fmt.Fprintf(os.Stderr, "  Mismatch indicates export failed to write all issues\n")
+				os.Exit(1)
+			}
 		}
 
 		// Report skipped issues if any (helps debugging bd-159)
@@ -329,64 +802,37 @@ Output to stdout by default, or use -o flag for file output.`,
 
 		// Only clear dirty issues and auto-flush state if exporting to the default JSONL path
 		// This prevents clearing dirty flags when exporting to custom paths (e.g., bd export -o backup.jsonl)
-		if output == "" || output == findJSONLPath() {
+		// A filtered export only holds some of the issues, so it leaves them dirty
+		// A read-only database keeps its dirty flags and hash as they are
+		if !filtered && !readOnlyMode && (output == "" || output == findJSONLPath()) {
 			// Clear only the issues that were actually exported (fixes bd-52 race condition)
 			if err := store.ClearDirtyIssuesByID(ctx, exportedIDs); err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: failed to clear dirty issues: %v\n", err)
 			}
 
 			// Clear auto-flush state since we just manually exported
-			// This cancels any pending auto-flush timer and marks DB as clean
-			clearAutoFlushState()
+			// This cancels any pending auto-flush timer and marks DB as clean.
+			// A delta may leave older dirty issues behind, so keep it pending.
+			if sinceStr == "" {
+				clearAutoFlushState()
+			}
 
 			// Store JSONL file hash for integrity validation (bd-160)
-			// nolint:gosec // G304: finalPath is validated JSONL export path
-			jsonlData, err := os.ReadFile(finalPath)
-			if err == nil {
-				hasher := sha256.New()
-				hasher.Write(jsonlData)
-				fileHash := hex.EncodeToString(hasher.Sum(nil))
-				if err := store.SetJSONLFileHash(ctx, fileHash); err != nil {
-					fmt.Fprintf(os.Stderr, "Warning: failed to update jsonl_file_hash: %v\n", err)
+			// Read after the rename so the hash reflects the file we just wrote
+			if output != "" {
+				// nolint:gosec // G304: output is validated JSONL export path
+				jsonlData, err := os.ReadFile(output)
+				if err == nil {
+					hasher := sha256.New()
+					hasher.Write(jsonlData)
+					fileHash := hex.EncodeToString(hasher.Sum(nil))
+					if err := store.SetJSONLFileHash(ctx, fileHash); err != nil {
+						fmt.Fprintf(os.Stderr, "Warning: failed to update jsonl_file_hash: %v\n", err)
+					}
 				}
 			}
 		}
 
-		// If writing to file, atomically replace the target file
-		if tempFile != nil {
-			// Close the temp file before renaming
-			if err := tempFile.Close(); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to close temporary file: %v\n", err)
-			}
-			tempFile = nil // Prevent cleanup
-
-			// Atomically replace the target file
-			if err := os.Rename(tempPath, finalPath); err != nil {
-				_ = os.Remove(tempPath) // Clean up on failure
-				fmt.Fprintf(os.Stderr, "Error replacing output file: %v\n", err)
-				os.Exit(1)
-			}
-
-			// Set appropriate file permissions (0600: rw-------)
-			if err := os.Chmod(finalPath, 0600); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to set file permissions: %v\n", err)
-			}
-
-		// Verify JSONL file integrity after export
-			 actualCount, err := countIssuesInJSONL(finalPath)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Export verification failed: %v\n", err)
-			os.Exit(1)
-		}
-		if actualCount != len(exportedIDs) {
-			fmt.Fprintf(os.Stderr, "Error: Export verification failed\n")
-			fmt.Fprintf(os.Stderr, "  Expected: %d issues\n", len(exportedIDs))
-			fmt.Fprintf(os.Stderr, "  JSONL file: %d lines\n", actualCount)
-			fmt.Fprintf(os.Stderr, "  Mismatch indicates export failed to write all issues\n")
-			os.Exit(1)
-		}
-	}
-
 	// Output statistics if JSON format requested
 		if jsonOutput {
 			stats := map[string]interface{}{
@@ -395,9 +841,18 @@ Output to stdout by default, or use -o flag for file output.`,
 				"skipped":      skippedCount,
 				"total_issues": len(issues),
 			}
+			if pruneOrphans {
+				stats["pruned_deps"] = len(pruned)
+			}
 			if output != "" {
 				stats["output_file"] = output
 			}
+			if sinceStr != "" {
+				stats["since"] = since.Format(time.RFC3339)
+			}
+			if appendMode {
+				stats["appended"] = true
+			}
 			data, _ := json.MarshalIndent(stats, "", "  ")
 			fmt.Fprintln(os.Stderr, string(data))
 		}
@@ -405,10 +860,19 @@ Output to stdout by default, or use -o flag for file output.`,
 }
 
 func init() {
-	exportCmd.Flags().StringP("format", "f", "jsonl", "Export format (jsonl)")
+	exportCmd.Flags().StringP("format", "f", "jsonl", "Export format (jsonl, github, dot, mermaid, csv, yaml, md)")
 	exportCmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
-	exportCmd.Flags().StringP("status", "s", "", "Filter by status")
+	addExportFilterFlags(exportCmd)
+	exportCmd.Flags().String("columns", "", "Comma-separated columns for --format csv (default id,title,status,priority,type,assignee,created_at,closed_at,labels)")
+	exportCmd.Flags().String("root", "", "Export only this issue and its parent-child descendants (dot, mermaid)")
+	exportCmd.Flags().String("direction", "", "With --root, export the issues reachable over any dependency edge instead: down (its dependencies), up (its dependents) or both")
+	exportCmd.Flags().Int("depth", 0, "With --root, only go this many edges out from the root (default: no limit)")
+	exportCmd.Flags().String("since", "", "Export only issues changed after this time (YYYY-MM-DD, RFC3339, or a duration like 7d)")
+	exportCmd.Flags().String("split-by", "", "Write one JSONL file per label, type or assignee into --output-dir")
+	exportCmd.Flags().String("output-dir", "", "Directory for the files written by --split-by")
+	exportCmd.Flags().Bool("append", false, "Append to the output file instead of replacing it (jsonl format, with -o)")
 	exportCmd.Flags().Bool("force", false, "Force export even if database is empty")
+	exportCmd.Flags().Bool("prune-orphan-deps", false, "Drop dependencies whose target isn't in the exported set (jsonl format)")
 	exportCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output export statistics in JSON format")
 	rootCmd.AddCommand(exportCmd)
 }
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// defaultCSVColumns are the columns written when --columns isn't given
+var defaultCSVColumns = []string{"id", "title", "status", "priority", "type", "assignee", "created_at", "closed_at", "labels"}
+
+// csvColumns renders each exportable column of an issue. Times are RFC3339
+// in UTC and missing values are empty cells.
+var csvColumns = map[string]func(issue *types.Issue) string{
+	"id":                  func(issue *types.Issue) string { return issue.ID },
+	"title":               func(issue *types.Issue) string { return issue.Title },
+	"status":              func(issue *types.Issue) string { return string(issue.Status) },
+	"priority":            func(issue *types.Issue) string { return strconv.Itoa(issue.Priority) },
+	"type":                func(issue *types.Issue) string { return string(issue.IssueType) },
+	"assignee":            func(issue *types.Issue) string { return issue.Assignee },
+	"created_at":          func(issue *types.Issue) string { return csvTime(&issue.CreatedAt) },
+	"updated_at":          func(issue *types.Issue) string { return csvTime(&issue.UpdatedAt) },
+	"closed_at":           func(issue *types.Issue) string { return csvTime(issue.ClosedAt) },
+	"labels":              func(issue *types.Issue) string { return strings.Join(issue.Labels, ";") },
+	"description":         func(issue *types.Issue) string { return issue.Description },
+	"design":              func(issue *types.Issue) string { return issue.Design },
+	"acceptance_criteria": func(issue *types.Issue) string { return issue.AcceptanceCriteria },
+	"notes":               func(issue *types.Issue) string { return issue.Notes },
+	"resolution":          func(issue *types.Issue) string { return string(issue.Resolution) },
+	"external_ref": func(issue *types.Issue) string {
+		if issue.ExternalRef == nil {
+			return ""
+		}
+		return *issue.ExternalRef
+	},
+	"estimated_minutes": func(issue *types.Issue) string {
+		if issue.EstimatedMinutes == nil {
+			return ""
+		}
+		return strconv.Itoa(*issue.EstimatedMinutes)
+	},
+}
+
+// csvTime formats a timestamp for a CSV cell, empty when unset
+func csvTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// parseCSVColumns splits a comma-separated --columns value, rejecting
+// unknown and repeated names. An empty spec selects the default columns.
+func parseCSVColumns(spec string) ([]string, error) {
+	if strings.TrimSpace(spec) == "" {
+		return defaultCSVColumns, nil
+	}
+	var columns []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := csvColumns[name]; !ok {
+			return nil, fmt.Errorf("unknown column %q (available: %s)", name, strings.Join(csvColumnNames(), ", "))
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("column %q given more than once", name)
+		}
+		seen[name] = true
+		columns = append(columns, name)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns given")
+	}
+	return columns, nil
+}
+
+// csvColumnNames lists every exportable column, sorted
+func csvColumnNames() []string {
+	names := make([]string, 0, len(csvColumns))
+	for name := range csvColumns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// writeCSVIssues writes a header row of columns and then one row per issue,
+// quoted per RFC 4180
+func writeCSVIssues(w io.Writer, issues []*types.Issue, columns []string) error {
+	writer := csv.NewWriter(w)
+	writer.UseCRLF = true
+	if err := writer.Write(columns); err != nil {
+		return err
+	}
+	row := make([]string, len(columns))
+	for _, issue := range issues {
+		for i, column := range columns {
+			row[i] = csvColumns[column](issue)
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write issue %s: %w", issue.ID, err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// runCSVExport writes issues as CSV to output, or stdout if empty. Like the
+// GitHub format this is a one-way report, so it skips the JSONL safety checks
+// and leaves dirty tracking and the JSONL hash untouched.
+func runCSVExport(ctx context.Context, issues []*types.Issue, output string, columns []string) {
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].ID < issues[j].ID
+	})
+
+	for _, column := range columns {
+		if column != "labels" {
+			continue
+		}
+		for _, issue := range issues {
+			labels, err := store.GetLabels(ctx, issue.ID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting labels for %s: %v\n", issue.ID, err)
+				os.Exit(1)
+			}
+			issue.Labels = labels
+		}
+	}
+
+	write := func(w io.Writer) error {
+		return writeCSVIssues(w, issues, columns)
+	}
+	var err error
+	if output == "" {
+		err = write(os.Stdout)
+	} else if err = validateExportPath(output); err == nil {
+		err = writeFileAtomic(output, 0600, write)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		stats := map[string]interface{}{
+			"success":  true,
+			"format":   "csv",
+			"exported": len(issues),
+			"columns":  columns,
+		}
+		if output != "" {
+			stats["output_file"] = output
+		}
+		data, _ := json.MarshalIndent(stats, "", "  ")
+		fmt.Fprintln(os.Stderr, string(data))
+	}
+}
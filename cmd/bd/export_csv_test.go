@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWriteCSVIssues(t *testing.T) {
+	created := time.Date(2025, 6, 1, 9, 30, 0, 0, time.FixedZone("EST", -5*3600))
+	closed := created.Add(48 * time.Hour)
+	issues := []*types.Issue{
+		{
+			ID:        "bd-1",
+			Title:     `Fix "login", again`,
+			Status:    types.StatusClosed,
+			Priority:  1,
+			IssueType: types.TypeBug,
+			Assignee:  "alice",
+			CreatedAt: created,
+			ClosedAt:  &closed,
+			Labels:    []string{"auth", "urgent"},
+		},
+		{
+			ID:          "bd-2",
+			Title:       "Plain",
+			Description: "line one\nline two",
+			Status:      types.StatusOpen,
+			Priority:    2,
+			IssueType:   types.TypeTask,
+			CreatedAt:   created,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeCSVIssues(&buf, issues, defaultCSVColumns); err != nil {
+		t.Fatalf("writeCSVIssues failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"id,title,status,priority,type,assignee,created_at,closed_at,labels\r\n",
+		`bd-1,"Fix ""login"", again",closed,1,bug,alice,2025-06-01T14:30:00Z,2025-06-03T14:30:00Z,auth;urgent` + "\r\n",
+		"bd-2,Plain,open,2,task,,2025-06-01T14:30:00Z,,\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	// Embedded newlines survive a round trip through a CSV reader
+	buf.Reset()
+	if err := writeCSVIssues(&buf, issues, []string{"id", "description"}); err != nil {
+		t.Fatalf("writeCSVIssues failed: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output isn't valid CSV: %v", err)
+	}
+	if len(records) != 3 || strings.ReplaceAll(records[2][1], "\r\n", "\n") != "line one\nline two" {
+		t.Errorf("unexpected records: %q", records)
+	}
+}
+
+func TestParseCSVColumns(t *testing.T) {
+	columns, err := parseCSVColumns(" id, Title ,labels")
+	if err != nil || strings.Join(columns, ",") != "id,title,labels" {
+		t.Errorf("parseCSVColumns = %v (err %v), want [id title labels]", columns, err)
+	}
+	if columns, _ := parseCSVColumns(""); strings.Join(columns, ",") != strings.Join(defaultCSVColumns, ",") {
+		t.Errorf("expected the default columns for an empty spec, got %v", columns)
+	}
+	for _, spec := range []string{"id,bogus", "id,id", ","} {
+		if _, err := parseCSVColumns(spec); err == nil {
+			t.Errorf("expected an error for %q", spec)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// githubIssue is the JSON shape of GitHub's issues API. Title, body, labels
+// and assignees are what the create-issue API takes (POST
+// /repos/{owner}/{repo}/issues); state and state_reason are set by a follow-up
+// update, and beads_id is ours, for scripts matching issues back.
+type githubIssue struct {
+	Title       string   `json:"title"`
+	Body        string   `json:"body"`
+	Labels      []string `json:"labels"`
+	State       string   `json:"state"`
+	StateReason string   `json:"state_reason,omitempty"`
+	Assignees   []string `json:"assignees,omitempty"`
+	BeadsID     string   `json:"beads_id"`
+}
+
+// Field mapping between beads and GitHub labels. This is the inverse of the
+// mapping in examples/github-import/gh2jsonl.py, so an exported issue imports
+// back with the same priority, type and status. Defaults (P2, task, open) get
+// no label, matching the importer's fallbacks.
+var (
+	githubPriorityLabels = map[int]string{
+		0: "critical",
+		1: "high",
+		3: "low",
+		4: "backlog",
+	}
+	githubTypeLabels = map[types.IssueType]string{
+		types.TypeBug:     "bug",
+		types.TypeFeature: "feature",
+		types.TypeEpic:    "epic",
+		types.TypeChore:   "chore",
+	}
+	githubStatusLabels = map[types.Status]string{
+		types.StatusInProgress: "in-progress",
+		types.StatusBlocked:    "blocked",
+	}
+	// Closed issues' resolutions become GitHub's state_reason. GitHub has no
+	// obsolete, so those also get githubObsoleteLabel to tell them from wontfix.
+	githubStateReasons = map[types.Resolution]string{
+		types.ResolutionFixed:     "completed",
+		types.ResolutionWontFix:   "not_planned",
+		types.ResolutionObsolete:  "not_planned",
+		types.ResolutionDuplicate: "duplicate",
+	}
+)
+
+const githubObsoleteLabel = "obsolete"
+
+// githubDepRef describes a dependency target for rendering in the issue body
+type githubDepRef struct {
+	Title  string
+	Closed bool
+}
+
+// toGitHubIssue converts a beads issue into GitHub's issue shape. refs maps
+// dependency target IDs to their title and state; unknown targets are rendered
+// by ID only.
+func toGitHubIssue(issue *types.Issue, refs map[string]githubDepRef) githubIssue {
+	gh := githubIssue{
+		Title:   issue.Title,
+		Body:    githubIssueBody(issue, refs),
+		Labels:  []string{},
+		State:   "open",
+		BeadsID: issue.ID,
+	}
+	if issue.Status == types.StatusClosed {
+		gh.State = "closed"
+		gh.StateReason = githubStateReasons[issue.Resolution]
+	}
+	if issue.Assignee != "" {
+		gh.Assignees = []string{issue.Assignee}
+	}
+
+	if label, ok := githubTypeLabels[issue.IssueType]; ok {
+		gh.Labels = append(gh.Labels, label)
+	}
+	if label, ok := githubPriorityLabels[issue.Priority]; ok {
+		gh.Labels = append(gh.Labels, label)
+	}
+	if label, ok := githubStatusLabels[issue.Status]; ok {
+		gh.Labels = append(gh.Labels, label)
+	}
+	if issue.Status == types.StatusClosed && issue.Resolution == types.ResolutionObsolete {
+		gh.Labels = append(gh.Labels, githubObsoleteLabel)
+	}
+	gh.Labels = append(gh.Labels, issue.Labels...)
+	return gh
+}
+
+// githubIssueBody assembles the description, design and acceptance criteria
+// into a markdown body, with dependencies rendered as a task list
+func githubIssueBody(issue *types.Issue, refs map[string]githubDepRef) string {
+	var sections []string
+	if issue.Description != "" {
+		sections = append(sections, issue.Description)
+	}
+	if issue.Design != "" {
+		sections = append(sections, "## Design\n\n"+issue.Design)
+	}
+	if issue.AcceptanceCriteria != "" {
+		sections = append(sections, "## Acceptance Criteria\n\n"+issue.AcceptanceCriteria)
+	}
+
+	if len(issue.Dependencies) > 0 {
+		var b strings.Builder
+		b.WriteString("## Dependencies\n")
+		for _, dep := range issue.Dependencies {
+			ref, known := refs[dep.DependsOnID]
+			check := " "
+			if ref.Closed {
+				check = "x"
+			}
+			fmt.Fprintf(&b, "\n- [%s] %s", check, dep.DependsOnID)
+			if known && ref.Title != "" {
+				fmt.Fprintf(&b, ": %s", ref.Title)
+			}
+			fmt.Fprintf(&b, " (%s)", dep.Type)
+		}
+		sections = append(sections, b.String())
+	}
+
+	// Hidden marker so the issue can be matched back to beads on import
+	sections = append(sections, fmt.Sprintf("<!-- beads-id: %s -->", issue.ID))
+	return strings.Join(sections, "\n\n")
+}
+
+// githubDepRefs collects title and state for every dependency target of the
+// given issues, looking up targets that aren't part of the export
+func githubDepRefs(ctx context.Context, s storage.Storage, issues []*types.Issue) (map[string]githubDepRef, error) {
+	refs := make(map[string]githubDepRef, len(issues))
+	for _, issue := range issues {
+		refs[issue.ID] = githubDepRef{Title: issue.Title, Closed: issue.Status == types.StatusClosed}
+	}
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if _, ok := refs[dep.DependsOnID]; ok {
+				continue
+			}
+			target, err := s.GetIssue(ctx, dep.DependsOnID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get dependency %s: %w", dep.DependsOnID, err)
+			}
+			if target != nil {
+				refs[target.ID] = githubDepRef{Title: target.Title, Closed: target.Status == types.StatusClosed}
+			}
+		}
+	}
+	return refs, nil
+}
+
+// writeGitHubIssues writes the issues as a JSON array of GitHub issues
+func writeGitHubIssues(w io.Writer, issues []*types.Issue, refs map[string]githubDepRef) error {
+	ghIssues := make([]githubIssue, 0, len(issues))
+	for _, issue := range issues {
+		ghIssues = append(ghIssues, toGitHubIssue(issue, refs))
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false) // Keep markdown bodies readable
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(ghIssues); err != nil {
+		return fmt.Errorf("failed to encode issues: %w", err)
+	}
+	return nil
+}
+
+// runGitHubExport writes issues in GitHub format to output, or stdout if empty.
+// Unlike JSONL export this is a one-way conversion, so it skips the JSONL
+// safety checks and leaves dirty tracking and the JSONL hash untouched.
+func runGitHubExport(ctx context.Context, issues []*types.Issue, output string) {
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].ID < issues[j].ID
+	})
+
+	allDeps, err := store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting dependencies: %v\n", err)
+		os.Exit(1)
+	}
+	for _, issue := range issues {
+		issue.Dependencies = allDeps[issue.ID]
+		issue.Labels, err = store.GetLabels(ctx, issue.ID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting labels for %s: %v\n", issue.ID, err)
+			os.Exit(1)
+		}
+	}
+
+	refs, err := githubDepRefs(ctx, store, issues)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	write := func(w io.Writer) error {
+		return writeGitHubIssues(w, issues, refs)
+	}
+	if output == "" {
+		err = write(os.Stdout)
+	} else if err = validateExportPath(output); err == nil {
+		err = writeFileAtomic(output, 0600, write)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		stats := map[string]interface{}{
+			"success":  true,
+			"format":   "github",
+			"exported": len(issues),
+		}
+		if output != "" {
+			stats["output_file"] = output
+		}
+		data, _ := json.MarshalIndent(stats, "", "  ")
+		fmt.Fprintln(os.Stderr, string(data))
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestToGitHubIssue(t *testing.T) {
+	issue := &types.Issue{
+		ID:                 "bd-1",
+		Title:              "Fix login",
+		Description:        "Login fails",
+		Design:             "Retry the request",
+		AcceptanceCriteria: "Login works",
+		Status:             types.StatusInProgress,
+		Priority:           1,
+		IssueType:          types.TypeBug,
+		Assignee:           "alice",
+		Labels:             []string{"auth"},
+		Dependencies: []*types.Dependency{
+			{IssueID: "bd-1", DependsOnID: "bd-2", Type: types.DepBlocks},
+			{IssueID: "bd-1", DependsOnID: "bd-9", Type: types.DepRelated},
+		},
+	}
+	refs := map[string]githubDepRef{
+		"bd-2": {Title: "Add retries", Closed: true},
+	}
+
+	gh := toGitHubIssue(issue, refs)
+
+	if gh.Title != "Fix login" {
+		t.Errorf("Title = %q, want %q", gh.Title, "Fix login")
+	}
+	if gh.State != "open" {
+		t.Errorf("State = %q, want open", gh.State)
+	}
+	wantLabels := []string{"bug", "high", "in-progress", "auth"}
+	if strings.Join(gh.Labels, ",") != strings.Join(wantLabels, ",") {
+		t.Errorf("Labels = %v, want %v", gh.Labels, wantLabels)
+	}
+	if len(gh.Assignees) != 1 || gh.Assignees[0] != "alice" {
+		t.Errorf("Assignees = %v, want [alice]", gh.Assignees)
+	}
+
+	for _, want := range []string{
+		"Login fails",
+		"## Design\n\nRetry the request",
+		"## Acceptance Criteria\n\nLogin works",
+		"- [x] bd-2: Add retries (blocks)",
+		"- [ ] bd-9 (related)",
+		"<!-- beads-id: bd-1 -->",
+	} {
+		if !strings.Contains(gh.Body, want) {
+			t.Errorf("Body missing %q:\n%s", want, gh.Body)
+		}
+	}
+}
+
+func TestToGitHubIssueDefaults(t *testing.T) {
+	issue := &types.Issue{
+		ID:        "bd-3",
+		Title:     "Closed task",
+		Status:    types.StatusClosed,
+		Priority:  2,
+		IssueType: types.TypeTask,
+	}
+
+	gh := toGitHubIssue(issue, nil)
+
+	if gh.State != "closed" {
+		t.Errorf("State = %q, want closed", gh.State)
+	}
+	if len(gh.Labels) != 0 {
+		t.Errorf("Expected no labels for default priority/type, got %v", gh.Labels)
+	}
+	if gh.Body != "<!-- beads-id: bd-3 -->" {
+		t.Errorf("Body = %q, want only the beads-id marker", gh.Body)
+	}
+
+	var buf bytes.Buffer
+	if err := writeGitHubIssues(&buf, []*types.Issue{issue}, nil); err != nil {
+		t.Fatalf("writeGitHubIssues failed: %v", err)
+	}
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || len(decoded) != 1 {
+		t.Fatalf("Output is not a JSON array of one issue: %v\n%s", err, buf.String())
+	}
+	if labels, ok := decoded[0]["labels"].([]interface{}); !ok || len(labels) != 0 {
+		t.Errorf("Expected labels to encode as an empty array, got %v", decoded[0]["labels"])
+	}
+	if decoded[0]["beads_id"] != "bd-3" {
+		t.Errorf("beads_id = %v, want bd-3", decoded[0]["beads_id"])
+	}
+}
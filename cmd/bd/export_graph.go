@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// dotStatusColors maps issue status to DOT fill and font colors
+var dotStatusColors = map[types.Status][2]string{
+	types.StatusOpen:       {"white", "black"},
+	types.StatusInProgress: {"lightyellow", "black"},
+	types.StatusBlocked:    {"lightcoral", "black"},
+	types.StatusClosed:     {"lightgray", "dimgray"},
+}
+
+// dotEdgeStyles maps dependency type to DOT edge color and style
+var dotEdgeStyles = map[types.DependencyType][2]string{
+	types.DepBlocks:         {"red", "bold"},
+	types.DepParentChild:    {"blue", "solid"},
+	types.DepDiscoveredFrom: {"green", "dashed"},
+	types.DepRelated:        {"gray", "dashed"},
+}
+
+// runGraphExport writes the dependency graph of issues as Graphviz DOT or
+// Mermaid. With rootID set, only that issue and the issues subgraphIssues
+// reaches from it are exported.
+func runGraphExport(ctx context.Context, issues []*types.Issue, format, output, rootID, direction string, depth int) {
+	allDeps, err := store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting dependencies: %v\n", err)
+		os.Exit(1)
+	}
+
+	if rootID != "" {
+		issues = subgraphIssues(issues, allDeps, rootID, direction, depth)
+		if len(issues) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: root issue %s is not in the exported set\n", rootID)
+			os.Exit(1)
+		}
+	}
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].ID < issues[j].ID
+	})
+
+	write := func(w io.Writer) error {
+		bw := bufio.NewWriter(w)
+		if format == "mermaid" {
+			writeMermaidGraph(bw, issues, allDeps)
+		} else {
+			writeDOTGraph(bw, issues, allDeps)
+		}
+		return bw.Flush()
+	}
+	if output == "" {
+		err = write(os.Stdout)
+	} else if err = validateExportPath(output); err == nil {
+		err = writeFileAtomic(output, 0600, write)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// subgraphIssues returns rootID and the issues reachable from it among
+// issues, at most depth hops away (no limit if depth <= 0). With direction
+// empty, those are its parent-child descendants; otherwise every dependency
+// edge is walked in that direction (see reachableIssues).
+func subgraphIssues(issues []*types.Issue, allDeps map[string][]*types.Dependency, rootID, direction string, depth int) []*types.Issue {
+	var keep map[string]int
+	if direction == "" {
+		// Children depend on their parent, so descendants are up the edges
+		keep = reachableIssues(allDeps, rootID, walkUp, []types.DependencyType{types.DepParentChild}, depth)
+	} else {
+		keep = reachableIssues(allDeps, rootID, direction, nil, depth)
+	}
+
+	var result []*types.Issue
+	for _, issue := range issues {
+		if _, ok := keep[issue.ID]; ok {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
+
+// graphEdges returns the dependencies between issues in the set, ordered by
+// source, then target, then type
+func graphEdges(issues []*types.Issue, allDeps map[string][]*types.Dependency) []*types.Dependency {
+	inSet := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		inSet[issue.ID] = true
+	}
+	var edges []*types.Dependency
+	for _, issue := range issues {
+		for _, dep := range allDeps[issue.ID] {
+			if inSet[dep.DependsOnID] {
+				edges = append(edges, dep)
+			}
+		}
+	}
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].IssueID != edges[j].IssueID {
+			return edges[i].IssueID < edges[j].IssueID
+		}
+		if edges[i].DependsOnID != edges[j].DependsOnID {
+			return edges[i].DependsOnID < edges[j].DependsOnID
+		}
+		return edges[i].Type < edges[j].Type
+	})
+	return edges
+}
+
+// writeDOTGraph writes issues as Graphviz nodes colored by status, and the
+// dependencies between them as edges styled by type (dependent → dependency)
+func writeDOTGraph(w io.Writer, issues []*types.Issue, allDeps map[string][]*types.Dependency) {
+	fmt.Fprintln(w, "digraph dependencies {")
+	fmt.Fprintln(w, "  rankdir=TB;")
+	fmt.Fprintln(w, "  node [shape=box, style=rounded];")
+	fmt.Fprintln(w)
+
+	for _, issue := range issues {
+		label := fmt.Sprintf("%s\n[%s P%d]\n%s\n(%s)", issue.ID, issue.IssueType, issue.Priority, issue.Title, issue.Status)
+		colors, ok := dotStatusColors[issue.Status]
+		if !ok {
+			colors = dotStatusColors[types.StatusOpen]
+		}
+		fmt.Fprintf(w, "  %s [label=%s, style=\"rounded,filled\", fillcolor=%q, fontcolor=%q];\n",
+			dotQuote(issue.ID), dotQuote(label), colors[0], colors[1])
+	}
+	fmt.Fprintln(w)
+
+	for _, dep := range graphEdges(issues, allDeps) {
+		style, ok := dotEdgeStyles[dep.Type]
+		if !ok {
+			style = [2]string{"black", "solid"}
+		}
+		fmt.Fprintf(w, "  %s -> %s [label=%s, color=%s, style=%s];\n",
+			dotQuote(dep.IssueID), dotQuote(dep.DependsOnID), dotQuote(string(dep.Type)), style[0], style[1])
+	}
+
+	fmt.Fprintln(w, "}")
+}
+
+// dotQuote renders s as a DOT double-quoted string. DOT only understands \"
+// and \\ escapes (plus \n as a centered line break in labels), so Go's %q
+// escapes can't be used for arbitrary titles.
+func dotQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r < ' ' || r == 0x7f:
+			b.WriteByte(' ')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// mermaidEdgeArrows maps dependency type to a Mermaid flowchart arrow
+var mermaidEdgeArrows = map[types.DependencyType]string{
+	types.DepBlocks:         "==>",
+	types.DepParentChild:    "-->",
+	types.DepDiscoveredFrom: "-.->",
+	types.DepRelated:        "-.->",
+}
+
+// writeMermaidGraph writes the same graph as writeDOTGraph as a Mermaid
+// flowchart, with a class per status for coloring
+func writeMermaidGraph(w io.Writer, issues []*types.Issue, allDeps map[string][]*types.Dependency) {
+	fmt.Fprintln(w, "flowchart TD")
+	fmt.Fprintln(w, "  classDef open fill:#ffffff,stroke:#333333,color:#000000;")
+	fmt.Fprintln(w, "  classDef in_progress fill:#fff7c2,stroke:#333333,color:#000000;")
+	fmt.Fprintln(w, "  classDef blocked fill:#f4a4a4,stroke:#333333,color:#000000;")
+	fmt.Fprintln(w, "  classDef closed fill:#dddddd,stroke:#888888,color:#666666;")
+
+	byStatus := make(map[types.Status][]string)
+	for _, issue := range issues {
+		node := mermaidNodeID(issue.ID)
+		label := fmt.Sprintf("%s %s: %s", getStatusEmoji(issue.Status), issue.ID, issue.Title)
+		fmt.Fprintf(w, "  %s[\"%s\"]\n", node, mermaidEscape(label))
+		byStatus[issue.Status] = append(byStatus[issue.Status], node)
+	}
+
+	for _, dep := range graphEdges(issues, allDeps) {
+		arrow, ok := mermaidEdgeArrows[dep.Type]
+		if !ok {
+			arrow = "-->"
+		}
+		fmt.Fprintf(w, "  %s %s|%s| %s\n", mermaidNodeID(dep.IssueID), arrow, dep.Type, mermaidNodeID(dep.DependsOnID))
+	}
+
+	for _, status := range []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusBlocked, types.StatusClosed} {
+		if nodes := byStatus[status]; len(nodes) > 0 {
+			fmt.Fprintf(w, "  class %s %s;\n", strings.Join(nodes, ","), status)
+		}
+	}
+}
+
+// mermaidNodeID maps an issue ID to a Mermaid node ID, which can't contain
+// dots or dashes
+func mermaidNodeID(id string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, id)
+}
+
+// mermaidEscape makes s safe inside a quoted Mermaid label, which ends at
+// the first double quote and has no backslash escapes
+func mermaidEscape(s string) string {
+	s = strings.ReplaceAll(s, "\"", "#quot;")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return s
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func graphFixture() ([]*types.Issue, map[string][]*types.Dependency) {
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: `Epic "one"`, Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic},
+		{ID: "bd-1.1", Title: `Back\end`, Status: types.StatusClosed, Priority: 2, IssueType: types.TypeTask},
+		{ID: "bd-1.2", Title: "Front\nend", Status: types.StatusInProgress, Priority: 2, IssueType: types.TypeTask},
+		{ID: "bd-9", Title: "Unrelated", Status: types.StatusBlocked, Priority: 3, IssueType: types.TypeBug},
+	}
+	deps := map[string][]*types.Dependency{
+		"bd-1.1": {{IssueID: "bd-1.1", DependsOnID: "bd-1", Type: types.DepParentChild}},
+		"bd-1.2": {
+			{IssueID: "bd-1.2", DependsOnID: "bd-1", Type: types.DepParentChild},
+			{IssueID: "bd-1.2", DependsOnID: "bd-1.1", Type: types.DepBlocks},
+		},
+		"bd-9": {{IssueID: "bd-9", DependsOnID: "bd-1.2", Type: types.DepRelated}},
+	}
+	return issues, deps
+}
+
+func TestDotQuote(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"bd-1", `"bd-1"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`C:\path`, `"C:\\path"`},
+		{"two\nlines", `"two\nlines"`},
+		{"tab\there", `"tab here"`},
+		{"ünïcode ✓", `"ünïcode ✓"`},
+	}
+	for _, tt := range tests {
+		if got := dotQuote(tt.in); got != tt.want {
+			t.Errorf("dotQuote(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteDOTGraph(t *testing.T) {
+	issues, deps := graphFixture()
+	var buf bytes.Buffer
+	writeDOTGraph(&buf, issues, deps)
+	out := buf.String()
+
+	for _, want := range []string{
+		"digraph dependencies {",
+		`"bd-1" [label="bd-1\n[epic P1]\nEpic \"one\"\n(open)", style="rounded,filled", fillcolor="white", fontcolor="black"];`,
+		`label="bd-1.1\n[task P2]\nBack\\end\n(closed)"`,
+		`fillcolor="lightgray", fontcolor="dimgray"`,
+		`"bd-1.2" -> "bd-1" [label="parent-child", color=blue, style=solid];`,
+		`"bd-1.2" -> "bd-1.1" [label="blocks", color=red, style=bold];`,
+		`"bd-9" -> "bd-1.2" [label="related", color=gray, style=dashed];`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("DOT output missing %s\n%s", want, out)
+		}
+	}
+	if !strings.HasSuffix(out, "}\n") {
+		t.Errorf("DOT output not closed:\n%s", out)
+	}
+}
+
+func TestSubgraphIssues(t *testing.T) {
+	issues, deps := graphFixture()
+	sub := subgraphIssues(issues, deps, "bd-1", "", 0)
+	var ids []string
+	for _, issue := range sub {
+		ids = append(ids, issue.ID)
+	}
+	if got := strings.Join(ids, ","); got != "bd-1,bd-1.1,bd-1.2" {
+		t.Errorf("subgraphIssues(bd-1) = %s, want bd-1,bd-1.1,bd-1.2", got)
+	}
+
+	// Edges to issues outside the subgraph are dropped
+	var buf bytes.Buffer
+	writeDOTGraph(&buf, sub, deps)
+	if strings.Contains(buf.String(), "bd-9") {
+		t.Errorf("expected bd-9 and its edge to be excluded:\n%s", buf.String())
+	}
+
+	if len(subgraphIssues(issues, deps, "bd-missing", "", 0)) != 0 {
+		t.Error("expected no issues for unknown root")
+	}
+}
+
+func TestSubgraphIssuesDirection(t *testing.T) {
+	issues, deps := graphFixture()
+	ids := func(direction string, depth int) string {
+		var ids []string
+		for _, issue := range subgraphIssues(issues, deps, "bd-1.2", direction, depth) {
+			ids = append(ids, issue.ID)
+		}
+		return strings.Join(ids, ",")
+	}
+	tests := []struct {
+		direction string
+		depth     int
+		want      string
+	}{
+		{walkDown, 0, "bd-1,bd-1.1,bd-1.2"},
+		{walkUp, 0, "bd-1.2,bd-9"},
+		{walkBoth, 0, "bd-1,bd-1.1,bd-1.2,bd-9"},
+		{walkBoth, 1, "bd-1,bd-1.1,bd-1.2,bd-9"},
+		{walkDown, 1, "bd-1,bd-1.1,bd-1.2"},
+		{"", 0, "bd-1.2"}, // No children
+	}
+	for _, tt := range tests {
+		if got := ids(tt.direction, tt.depth); got != tt.want {
+			t.Errorf("subgraphIssues(bd-1.2, %q, %d) = %s, want %s", tt.direction, tt.depth, got, tt.want)
+		}
+	}
+	// bd-9 is two hops from bd-1 (via bd-1.2) either way
+	var got []string
+	for _, issue := range subgraphIssues(issues, deps, "bd-1", walkBoth, 1) {
+		got = append(got, issue.ID)
+	}
+	if strings.Join(got, ",") != "bd-1,bd-1.1,bd-1.2" {
+		t.Errorf("subgraphIssues(bd-1, both, 1) = %v, want bd-1,bd-1.1,bd-1.2", got)
+	}
+}
+
+func TestWriteMermaidGraph(t *testing.T) {
+	issues, deps := graphFixture()
+	var buf bytes.Buffer
+	writeMermaidGraph(&buf, issues, deps)
+	out := buf.String()
+
+	for _, want := range []string{
+		"flowchart TD",
+		`bd_1["☐ bd-1: Epic #quot;one#quot;"]`,
+		`bd_1_2["◧ bd-1.2: Front end"]`,
+		"bd_1_2 ==>|blocks| bd_1_1",
+		"bd_1_2 -->|parent-child| bd_1",
+		"bd_9 -.->|related| bd_1_2",
+		"class bd_1 open;",
+		"class bd_9 blocked;",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Mermaid output missing %s\n%s", want, out)
+		}
+	}
+}
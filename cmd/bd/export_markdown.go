@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// writeMarkdownIssues renders issues one after another as 'bd show --format
+// md' does, separated by horizontal rules
+func writeMarkdownIssues(w io.Writer, r *markdownRenderer, issues []*markdownIssue) error {
+	for i, md := range issues {
+		if i > 0 {
+			if _, err := fmt.Fprint(w, "\n---\n\n"); err != nil {
+				return err
+			}
+		}
+		r.render(w, md)
+	}
+	return nil
+}
+
+// runMarkdownExport writes issues as one Markdown document, with their
+// comments, in export order
+func runMarkdownExport(ctx context.Context, issues []*types.Issue, output string) {
+	utils.SortIssuesForExport(issues)
+	docs := make([]*markdownIssue, 0, len(issues))
+	for _, issue := range issues {
+		md, err := loadMarkdownIssue(ctx, store, issue.ID, true)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading %s: %v\n", issue.ID, err)
+			os.Exit(1)
+		}
+		if md != nil {
+			docs = append(docs, md)
+		}
+	}
+	urlTemplate, _ := store.GetConfig(ctx, utils.IssueURLTemplateConfigKey)
+	prefix, _ := store.GetConfig(ctx, "issue_prefix")
+	renderer := newMarkdownRenderer(urlTemplate, prefix)
+
+	write := func(w io.Writer) error {
+		return writeMarkdownIssues(w, renderer, docs)
+	}
+	var err error
+	if output == "" {
+		err = write(os.Stdout)
+	} else if err = validateExportPath(output); err == nil {
+		err = writeFileAtomic(output, 0600, write)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		stats := map[string]interface{}{
+			"success":  true,
+			"format":   "md",
+			"exported": len(docs),
+		}
+		if output != "" {
+			stats["output_file"] = output
+		}
+		data, _ := json.MarshalIndent(stats, "", "  ")
+		fmt.Fprintln(os.Stderr, string(data))
+	}
+}
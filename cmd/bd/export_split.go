@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// splitNoneGroup holds issues with no value for the --split-by field
+const splitNoneGroup = "_none"
+
+// splitExportFields are the values --split-by accepts
+var splitExportFields = []string{"label", "type", "assignee"}
+
+// splitFileNameUnsafe matches characters replaced in group file names, so a
+// label like "team/backend" can't write outside the output directory
+var splitFileNameUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// splitExportFile is one file written by --split-by
+type splitExportFile struct {
+	Group string `json:"group"`
+	Path  string `json:"path"`
+	Count int    `json:"count"`
+}
+
+// splitGroupsFor returns the groups an issue belongs to under field: one per
+// label, its type or its assignee, or splitNoneGroup when it has none
+func splitGroupsFor(issue *types.Issue, field string) []string {
+	var groups []string
+	switch field {
+	case "label":
+		groups = issue.Labels
+	case "type":
+		if issue.IssueType != "" {
+			groups = []string{string(issue.IssueType)}
+		}
+	case "assignee":
+		if issue.Assignee != "" {
+			groups = []string{issue.Assignee}
+		}
+	}
+	if len(groups) == 0 {
+		return []string{splitNoneGroup}
+	}
+	return groups
+}
+
+// splitIssuesBy buckets issues by field, keeping their order within each
+// bucket. An issue with several labels lands in each of their buckets.
+func splitIssuesBy(issues []*types.Issue, field string) map[string][]*types.Issue {
+	buckets := make(map[string][]*types.Issue)
+	for _, issue := range issues {
+		seen := make(map[string]bool)
+		for _, group := range splitGroupsFor(issue, field) {
+			if !seen[group] {
+				seen[group] = true
+				buckets[group] = append(buckets[group], issue)
+			}
+		}
+	}
+	return buckets
+}
+
+// splitExportFiles maps each group to its file in dir, sorted by group.
+// Groups whose names only differ in replaced characters would share a file,
+// so that is an error rather than one overwriting the other.
+func splitExportFiles(dir string, buckets map[string][]*types.Issue) ([]splitExportFile, error) {
+	groups := make([]string, 0, len(buckets))
+	for group := range buckets {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	files := make([]splitExportFile, 0, len(groups))
+	owner := make(map[string]string, len(groups))
+	for _, group := range groups {
+		name := splitFileNameUnsafe.ReplaceAllString(group, "_")
+		if name == "." || name == ".." {
+			name = "_" + name
+		}
+		if other, ok := owner[name]; ok {
+			return nil, fmt.Errorf("groups %q and %q would both be written to %s.jsonl", other, group, name)
+		}
+		owner[name] = group
+		files = append(files, splitExportFile{
+			Group: group,
+			Path:  filepath.Join(dir, name+".jsonl"),
+			Count: len(buckets[group]),
+		})
+	}
+	return files, nil
+}
+
+// runSplitExport writes issues as JSONL, one file per distinct value of field
+// in dir, and reports the files written
+func runSplitExport(issues []*types.Issue, field, dir string) {
+	buckets := splitIssuesBy(issues, field)
+	files, err := splitExportFiles(dir, buckets)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := validateExportPath(dir); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to create %s: %v\n", dir, err)
+		os.Exit(1)
+	}
+
+	for _, file := range files {
+		group := buckets[file.Group]
+		if err := writeFileAtomic(file.Path, 0600, func(w io.Writer) error {
+			_, err := encodeIssuesJSONL(w, group)
+			return err
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		actualCount, err := countIssuesInJSONL(file.Path)
+		if err != nil || actualCount != file.Count {
+			fmt.Fprintf(os.Stderr, "Error: Export verification failed for %s: wrote %d of %d issues (%v)\n", file.Path, actualCount, file.Count, err)
+			os.Exit(1)
+		}
+	}
+
+	if jsonOutput {
+		stats := map[string]interface{}{
+			"success":      true,
+			"split_by":     field,
+			"output_dir":   dir,
+			"total_issues": len(issues),
+			"files":        files,
+		}
+		data, _ := json.MarshalIndent(stats, "", "  ")
+		fmt.Fprintln(os.Stderr, string(data))
+		return
+	}
+	fmt.Printf("Wrote %d file(s) to %s from %d issue(s):\n", len(files), dir, len(issues))
+	for _, file := range files {
+		fmt.Printf("  %s  %d\n", filepath.Base(file.Path), file.Count)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSplitIssuesBy(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-1", IssueType: types.TypeBug, Assignee: "alice", Labels: []string{"backend", "frontend"}},
+		{ID: "bd-2", IssueType: types.TypeTask, Labels: []string{"backend", "backend"}},
+		{ID: "bd-3", IssueType: types.TypeBug, Assignee: "alice"},
+	}
+
+	ids := func(issues []*types.Issue) string {
+		var out []string
+		for _, issue := range issues {
+			out = append(out, issue.ID)
+		}
+		return strings.Join(out, ",")
+	}
+	tests := []struct {
+		field string
+		want  map[string]string
+	}{
+		{"label", map[string]string{"backend": "bd-1,bd-2", "frontend": "bd-1", "_none": "bd-3"}},
+		{"type", map[string]string{"bug": "bd-1,bd-3", "task": "bd-2"}},
+		{"assignee", map[string]string{"alice": "bd-1,bd-3", "_none": "bd-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			got := splitIssuesBy(issues, tt.field)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d groups, want %d: %v", len(got), len(tt.want), got)
+			}
+			for group, want := range tt.want {
+				if ids(got[group]) != want {
+					t.Errorf("group %s = %s, want %s", group, ids(got[group]), want)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitExportFiles(t *testing.T) {
+	buckets := map[string][]*types.Issue{
+		"team/backend": {{ID: "bd-1"}},
+		"_none":        {{ID: "bd-2"}, {ID: "bd-3"}},
+		"..":           {{ID: "bd-4"}},
+	}
+	files, err := splitExportFiles("out", buckets)
+	if err != nil {
+		t.Fatalf("splitExportFiles failed: %v", err)
+	}
+	want := []splitExportFile{
+		{Group: "..", Path: filepath.Join("out", "_...jsonl"), Count: 1},
+		{Group: "_none", Path: filepath.Join("out", "_none.jsonl"), Count: 2},
+		{Group: "team/backend", Path: filepath.Join("out", "team_backend.jsonl"), Count: 1},
+	}
+	if len(files) != len(want) {
+		t.Fatalf("files = %+v, want %+v", files, want)
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Errorf("files[%d] = %+v, want %+v", i, files[i], want[i])
+		}
+	}
+
+	buckets["team_backend"] = []*types.Issue{{ID: "bd-5"}}
+	if _, err := splitExportFiles("out", buckets); err == nil {
+		t.Error("expected an error for groups sharing a file name")
+	}
+}
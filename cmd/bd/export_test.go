@@ -6,8 +6,13 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -321,3 +326,190 @@ func TestExportCommand(t *testing.T) {
 		}
 	})
 }
+
+func TestExportDeterministic(t *testing.T) {
+	tmpDir := t.TempDir()
+	s := newTestStore(t, filepath.Join(tmpDir, "test.db"))
+	defer s.Close()
+	ctx := context.Background()
+
+	// Created out of order, with children numbered past 9
+	for _, id := range []string{"test-b2", "test-a1", "test-a1.10", "test-a1.2", "test-a1.1"} {
+		issue := &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := s.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("Failed to create %s: %v", id, err)
+		}
+	}
+	for _, dep := range []*types.Dependency{
+		{IssueID: "test-b2", DependsOnID: "test-a1.2", Type: types.DepBlocks},
+		{IssueID: "test-b2", DependsOnID: "test-a1.10", Type: types.DepRelated},
+		{IssueID: "test-b2", DependsOnID: "test-a1", Type: types.DepBlocks},
+	} {
+		if err := s.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("Failed to add dependency: %v", err)
+		}
+	}
+	for _, label := range []string{"zeta", "alpha"} {
+		if err := s.AddLabel(ctx, "test-b2", label, "test-user"); err != nil {
+			t.Fatalf("Failed to add label: %v", err)
+		}
+	}
+
+	first := filepath.Join(tmpDir, "first.jsonl")
+	second := filepath.Join(tmpDir, "second.jsonl")
+	for _, path := range []string{first, second} {
+		if err := exportToJSONLWithStore(ctx, s, path); err != nil {
+			t.Fatalf("Export failed: %v", err)
+		}
+	}
+	firstData, err := os.ReadFile(first)
+	if err != nil {
+		t.Fatalf("Failed to read export: %v", err)
+	}
+	secondData, err := os.ReadFile(second)
+	if err != nil {
+		t.Fatalf("Failed to read export: %v", err)
+	}
+	if string(firstData) != string(secondData) {
+		t.Fatalf("Exports differ:\n%s\n---\n%s", firstData, secondData)
+	}
+
+	var ids []string
+	var b2 types.Issue
+	scanner := bufio.NewScanner(strings.NewReader(string(firstData)))
+	for scanner.Scan() {
+		var issue types.Issue
+		if err := json.Unmarshal(scanner.Bytes(), &issue); err != nil {
+			t.Fatalf("Failed to parse line: %v", err)
+		}
+		ids = append(ids, issue.ID)
+		if issue.ID == "test-b2" {
+			b2 = issue
+		}
+	}
+	if got := strings.Join(ids, ","); got != "test-a1,test-a1.1,test-a1.2,test-a1.10,test-b2" {
+		t.Errorf("Export order = %s, want parents before children in numeric order", got)
+	}
+	var targets []string
+	for _, dep := range b2.Dependencies {
+		targets = append(targets, dep.DependsOnID)
+	}
+	if got := strings.Join(targets, ","); got != "test-a1,test-a1.2,test-a1.10" {
+		t.Errorf("Dependency order = %s, want test-a1,test-a1.2,test-a1.10", got)
+	}
+}
+
+func TestPruneOrphanDeps(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-1", Dependencies: []*types.Dependency{
+			{IssueID: "bd-1", DependsOnID: "bd-2", Type: types.DepBlocks},
+			{IssueID: "bd-1", DependsOnID: "bd-gone", Type: types.DepBlocks},
+		}},
+		{ID: "bd-2", Dependencies: []*types.Dependency{
+			{IssueID: "bd-2", DependsOnID: "bd-closed", Type: types.DepRelated},
+		}},
+	}
+	inDB := func(id string) bool { return id == "bd-closed" }
+
+	pruned := pruneOrphanDeps(issues, inDB)
+
+	if len(pruned) != 2 {
+		t.Fatalf("Expected 2 pruned edges, got %+v", pruned)
+	}
+	if pruned[0].DependsOnID != "bd-gone" || pruned[0].Reason != "target not in database" {
+		t.Errorf("Unexpected first pruned edge: %+v", pruned[0])
+	}
+	if pruned[1].DependsOnID != "bd-closed" || pruned[1].Reason != "target not in export" {
+		t.Errorf("Unexpected second pruned edge: %+v", pruned[1])
+	}
+	if len(issues[0].Dependencies) != 1 || issues[0].Dependencies[0].DependsOnID != "bd-2" {
+		t.Errorf("Expected bd-1 to keep only its bd-2 dependency, got %+v", issues[0].Dependencies)
+	}
+	if len(issues[1].Dependencies) != 0 {
+		t.Errorf("Expected bd-2 dependencies to be pruned, got %+v", issues[1].Dependencies)
+	}
+}
+
+func TestExportFilterFromFlags(t *testing.T) {
+	tmpDir := t.TempDir()
+	testStore := newTestStore(t, filepath.Join(tmpDir, ".beads", "beads.db"))
+	ctx := context.Background()
+
+	issues := []*types.Issue{
+		{ID: "test-bug", Title: "Open bug", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug, Assignee: "alice"},
+		{ID: "test-task", Title: "Open task", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+		{ID: "test-closed", Title: "Closed bug", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeBug},
+	}
+	for _, issue := range issues {
+		if err := testStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+		if err := testStore.AddLabel(ctx, issue.ID, "sprint-7", "test"); err != nil {
+			t.Fatalf("Failed to add label: %v", err)
+		}
+	}
+	if err := testStore.CloseIssue(ctx, "test-closed", storage.CloseOptions{Reason: "done"}, "test"); err != nil {
+		t.Fatalf("Failed to close issue: %v", err)
+	}
+
+	tests := []struct {
+		args     []string
+		filtered bool
+		want     []string
+	}{
+		{nil, false, []string{"test-bug", "test-closed", "test-task"}},
+		{[]string{"--status", "open", "--label", "sprint-7", "--type", "bug"}, true, []string{"test-bug"}},
+		{[]string{"--assignee", ""}, true, []string{"test-closed", "test-task"}},
+		{[]string{"--priority", "P3"}, true, []string{"test-closed"}},
+		{[]string{"--closed-after", "1h"}, true, []string{"test-closed"}},
+	}
+	for _, tt := range tests {
+		cmd := &cobra.Command{}
+		addExportFilterFlags(cmd)
+		if err := cmd.ParseFlags(tt.args); err != nil {
+			t.Fatalf("ParseFlags(%v) failed: %v", tt.args, err)
+		}
+		filter, filtered, err := exportFilterFromFlags(cmd)
+		if err != nil {
+			t.Fatalf("exportFilterFromFlags(%v) failed: %v", tt.args, err)
+		}
+		if filtered != tt.filtered {
+			t.Errorf("exportFilterFromFlags(%v) filtered = %v, want %v", tt.args, filtered, tt.filtered)
+		}
+		found, err := testStore.SearchIssues(ctx, "", filter)
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		var got []string
+		for _, issue := range found {
+			got = append(got, issue.ID)
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("export %v matched %v, want %v", tt.args, got, tt.want)
+		}
+	}
+
+	cmd := &cobra.Command{}
+	addExportFilterFlags(cmd)
+	if err := cmd.ParseFlags([]string{"--type", "widget"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := exportFilterFromFlags(cmd); err == nil {
+		t.Error("Expected an invalid --type to be rejected")
+	}
+}
+
+func TestIsCanonicalJSONL(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldDBPath := dbPath
+	dbPath = filepath.Join(tmpDir, ".beads", "beads.db")
+	t.Cleanup(func() { dbPath = oldDBPath })
+
+	if !isCanonicalJSONL(findJSONLPath()) {
+		t.Errorf("isCanonicalJSONL(%s) = false, want true", findJSONLPath())
+	}
+	if isCanonicalJSONL(filepath.Join(tmpDir, "subset.jsonl")) {
+		t.Error("isCanonicalJSONL(subset.jsonl) = true, want false")
+	}
+}
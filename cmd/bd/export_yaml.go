@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// writeYAMLIssues writes issues as a YAML list. Each issue goes through its
+// JSON encoding, so the YAML has exactly the fields and names of a JSONL
+// record; multi-line strings become literal block scalars.
+func writeYAMLIssues(w io.Writer, issues []*types.Issue) error {
+	data, err := json.Marshal(issues)
+	if err != nil {
+		return fmt.Errorf("failed to encode issues: %w", err)
+	}
+	// JSON is YAML, so this yields the same structure as nodes to restyle
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to convert issues to YAML: %w", err)
+	}
+	restyleYAMLNode(&doc)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to write YAML: %w", err)
+	}
+	return encoder.Close()
+}
+
+// restyleYAMLNode turns JSON-styled nodes into block YAML: collections in
+// block style, multi-line strings as literal blocks and other scalars plain.
+// The encoder still quotes strings that would otherwise read as another type.
+func restyleYAMLNode(node *yaml.Node) {
+	node.Style = 0
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" && strings.Contains(node.Value, "\n") {
+		node.Style = yaml.LiteralStyle
+	}
+	for _, child := range node.Content {
+		restyleYAMLNode(child)
+	}
+}
+
+// readYAMLIssues parses a YAML list of issues as written by writeYAMLIssues.
+// Each entry is decoded through JSON into the same struct as a JSONL line.
+func readYAMLIssues(r io.Reader) ([]*types.Issue, error) {
+	var records []interface{}
+	if err := yaml.NewDecoder(r).Decode(&records); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("expected a YAML list of issues: %w", err)
+	}
+	issues := make([]*types.Issue, 0, len(records))
+	for i, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return nil, fmt.Errorf("issue %d: %w", i+1, err)
+		}
+		var issue types.Issue
+		if err := json.Unmarshal(data, &issue); err != nil {
+			return nil, fmt.Errorf("issue %d: %w", i+1, err)
+		}
+		issues = append(issues, &issue)
+	}
+	return issues, nil
+}
+
+// isYAMLInput reports whether import input is YAML rather than JSONL: a
+// .yaml or .yml file, or, for other names and stdin, content whose first
+// significant line starts a YAML list instead of a JSON object. Only peeks
+// at r, so nothing is consumed.
+func isYAMLInput(path string, r *bufio.Reader) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	case ".jsonl", ".json":
+		return false
+	}
+	peek, _ := r.Peek(4096)
+	for _, line := range bytes.Split(peek, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		return line[0] == '-' || line[0] == '['
+	}
+	return false
+}
+
+// runYAMLExport writes issues as YAML to output, or stdout if empty, with
+// the labels, dependencies and comments a JSONL export carries. YAML isn't
+// the sync format, so this skips the JSONL safety checks and leaves dirty
+// tracking and the JSONL hash untouched.
+func runYAMLExport(ctx context.Context, issues []*types.Issue, output string) {
+	allDeps, err := store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting dependencies: %v\n", err)
+		os.Exit(1)
+	}
+	for _, issue := range issues {
+		issue.Dependencies = allDeps[issue.ID]
+		if issue.Labels, err = store.GetLabels(ctx, issue.ID); err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting labels for %s: %v\n", issue.ID, err)
+			os.Exit(1)
+		}
+		if issue.Comments, err = store.GetIssueComments(ctx, issue.ID); err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting comments for %s: %v\n", issue.ID, err)
+			os.Exit(1)
+		}
+	}
+	utils.SortIssuesForExport(issues)
+
+	write := func(w io.Writer) error {
+		return writeYAMLIssues(w, issues)
+	}
+	if output == "" {
+		err = write(os.Stdout)
+	} else if err = validateExportPath(output); err == nil {
+		err = writeFileAtomic(output, 0600, write)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		stats := map[string]interface{}{
+			"success":  true,
+			"format":   "yaml",
+			"exported": len(issues),
+		}
+		if output != "" {
+			stats["output_file"] = output
+		}
+		data, _ := json.MarshalIndent(stats, "", "  ")
+		fmt.Fprintln(os.Stderr, string(data))
+	}
+}
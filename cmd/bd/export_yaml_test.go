@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestYAMLIssuesRoundTrip(t *testing.T) {
+	created := time.Date(2025, 6, 1, 9, 30, 0, 123456789, time.UTC)
+	closed := created.Add(time.Hour)
+	estimate := 30
+	issues := []*types.Issue{
+		{
+			ID:               "bd-1",
+			Title:            "true",
+			Description:      "First line\n\n  indented: with colon\nlast line\n",
+			Notes:            "no trailing newline\nsecond",
+			Design:           "trailing spaces   \nhere",
+			Status:           types.StatusClosed,
+			Priority:         0,
+			IssueType:        types.TypeBug,
+			Assignee:         "alice",
+			CreatedAt:        created,
+			UpdatedAt:        closed,
+			ClosedAt:         &closed,
+			EstimatedMinutes: &estimate,
+			Labels:           []string{"auth", "2025"},
+			Metadata:         map[string]string{"sprint": "7"},
+			Dependencies: []*types.Dependency{
+				{IssueID: "bd-1", DependsOnID: "bd-2", Type: types.DepBlocks, CreatedAt: created, CreatedBy: "alice"},
+			},
+			Comments: []*types.Comment{
+				{ID: 1, IssueID: "bd-1", Author: "bob", Text: "Looks good\n- a list\n- in a comment", CreatedAt: created},
+			},
+		},
+		{ID: "bd-2", Title: "Plain: with colon", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, CreatedAt: created, UpdatedAt: created},
+	}
+
+	var buf bytes.Buffer
+	if err := writeYAMLIssues(&buf, issues); err != nil {
+		t.Fatalf("writeYAMLIssues failed: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "description: |") {
+		t.Errorf("expected a literal block scalar for the description:\n%s", out)
+	}
+	if !strings.HasPrefix(out, "- id: bd-1\n") {
+		t.Errorf("expected a block list of issues:\n%s", out)
+	}
+
+	got, err := readYAMLIssues(&buf)
+	if err != nil {
+		t.Fatalf("readYAMLIssues failed: %v", err)
+	}
+	if len(got) != len(issues) {
+		t.Fatalf("read %d issues, want %d", len(got), len(issues))
+	}
+	for i := range issues {
+		want, _ := json.Marshal(issues[i])
+		have, _ := json.Marshal(got[i])
+		if !bytes.Equal(want, have) {
+			t.Errorf("issue %d changed in the round trip:\nwant %s\ngot  %s", i, want, have)
+		}
+	}
+
+	// No issues is an empty list, and reads back as none
+	buf.Reset()
+	if err := writeYAMLIssues(&buf, nil); err != nil {
+		t.Fatalf("writeYAMLIssues failed: %v", err)
+	}
+	if got, err := readYAMLIssues(&buf); err != nil || len(got) != 0 {
+		t.Errorf("expected no issues, got %d (err %v)", len(got), err)
+	}
+	if _, err := readYAMLIssues(strings.NewReader("id: bd-1\n")); err == nil {
+		t.Error("expected an error for YAML that isn't a list")
+	}
+}
+
+func TestIsYAMLInput(t *testing.T) {
+	tests := []struct {
+		path    string
+		content string
+		want    bool
+	}{
+		{"issues.yaml", `{"id":"bd-1"}`, true},
+		{"issues.YML", "", true},
+		{"issues.jsonl", "- id: bd-1\n", false},
+		{"", "# exported\n\n- id: bd-1\n", true},
+		{"", "[]\n", true},
+		{"", "\n{\"id\":\"bd-1\"}\n", false},
+		{"backup.txt", "<<<<<<< HEAD\n", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		reader := bufio.NewReader(strings.NewReader(tt.content))
+		if got := isYAMLInput(tt.path, reader); got != tt.want {
+			t.Errorf("isYAMLInput(%q, %q) = %v, want %v", tt.path, tt.content, got, tt.want)
+		}
+		// Detection leaves the content to be read
+		if rest, _ := reader.ReadString(0); rest != tt.content {
+			t.Errorf("isYAMLInput consumed input: %q left of %q", rest, tt.content)
+		}
+	}
+}
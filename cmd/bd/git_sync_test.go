@@ -13,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -71,7 +72,7 @@ func TestGitPullSyncIntegration(t *testing.T) {
 	issueID := issue.ID
 	
 	// Close the issue
-	if err := clone1Store.CloseIssue(ctx, issueID, "Test completed", "test-user"); err != nil {
+	if err := clone1Store.CloseIssue(ctx, issueID, storage.CloseOptions{Reason: "Test completed"}, "test-user"); err != nil {
 		t.Fatalf("Failed to close issue: %v", err)
 	}
 	
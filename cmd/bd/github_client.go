@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultGitHubAPIURL = "https://api.github.com"
+
+// githubAPIIssue is the part of GitHub's issue JSON that bd import-github
+// reads (GET /repos/{owner}/{repo}/issues)
+type githubAPIIssue struct {
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	Body        string `json:"body"`
+	State       string `json:"state"`
+	StateReason string `json:"state_reason"`
+	Labels      []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Assignee *struct {
+		Login string `json:"login"`
+	} `json:"assignee"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	ClosedAt    *time.Time       `json:"closed_at"`
+	PullRequest *json.RawMessage `json:"pull_request"` // Set on pull requests, which the issues API also lists
+}
+
+// githubClient reads from the GitHub REST API, following pagination and
+// waiting out rate limits
+type githubClient struct {
+	baseURL string
+	token   string // Optional; unauthenticated requests get a much lower rate limit
+	client  *http.Client
+	retries int           // Attempts after the first failed one
+	backoff time.Duration // Delay before the first retry of a server or network error, doubling each time
+	maxWait time.Duration // Longest rate limit wait before giving up
+	// sleep waits for d or until ctx is done; replaced in tests
+	sleep func(ctx context.Context, d time.Duration) error
+	// log reports rate limit waits and retries; nil stays quiet
+	log func(format string, args ...interface{})
+}
+
+func newGitHubClient(baseURL, token string) *githubClient {
+	if baseURL == "" {
+		baseURL = defaultGitHubAPIURL
+	}
+	return &githubClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: 30 * time.Second},
+		retries: 5,
+		backoff: time.Second,
+		maxWait: time.Hour,
+		sleep:   sleepContext,
+	}
+}
+
+// ListIssues returns every issue in repo ("owner/name") updated at or after
+// since (all issues if since is zero), oldest update first. Pull requests
+// are left out.
+func (c *githubClient) ListIssues(ctx context.Context, repo string, since time.Time) ([]githubAPIIssue, error) {
+	query := url.Values{}
+	query.Set("state", "all")
+	query.Set("sort", "updated")
+	query.Set("direction", "asc")
+	query.Set("per_page", "100")
+	if !since.IsZero() {
+		query.Set("since", since.UTC().Format(time.RFC3339))
+	}
+	next := fmt.Sprintf("%s/repos/%s/issues?%s", c.baseURL, repo, query.Encode())
+
+	var issues []githubAPIIssue
+	for next != "" {
+		body, header, err := c.get(ctx, next)
+		if err != nil {
+			return nil, err
+		}
+		var page []githubAPIIssue
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse GitHub response: %w", err)
+		}
+		for _, issue := range page {
+			if issue.PullRequest == nil {
+				issues = append(issues, issue)
+			}
+		}
+		next = githubNextPage(header.Get("Link"))
+	}
+	return issues, nil
+}
+
+// get fetches url, retrying server and network errors with backoff and
+// waiting until the rate limit resets when GitHub says it's exhausted
+func (c *githubClient) get(ctx context.Context, url string) ([]byte, http.Header, error) {
+	delay := c.backoff
+	for attempt := 0; ; attempt++ {
+		body, resp, err := c.do(ctx, url)
+		if err == nil && resp.StatusCode == http.StatusOK {
+			return body, resp.Header, nil
+		}
+		if attempt >= c.retries {
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, nil, githubStatusError(resp, body)
+		}
+
+		if err == nil {
+			if wait, limited := githubRateLimitWait(resp, time.Now()); limited {
+				if err := c.waitRateLimit(ctx, wait); err != nil {
+					return nil, nil, err
+				}
+				continue
+			}
+			if resp.StatusCode < 500 {
+				return nil, nil, githubStatusError(resp, body)
+			}
+			err = githubStatusError(resp, body)
+		}
+		c.logf("GitHub request failed (%v), retrying in %s", err, delay)
+		if err := c.sleep(ctx, delay); err != nil {
+			return nil, nil, err
+		}
+		delay *= 2
+	}
+}
+
+// do makes one request, reading the whole body
+func (c *githubClient) do(ctx context.Context, url string) ([]byte, *http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("User-Agent", "bd/"+Version)
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read GitHub response: %w", err)
+	}
+	return body, resp, nil
+}
+
+// waitRateLimit sleeps until the rate limit resets, unless that's longer
+// than maxWait
+func (c *githubClient) waitRateLimit(ctx context.Context, wait time.Duration) error {
+	if wait > c.maxWait {
+		return fmt.Errorf("GitHub rate limit exceeded; it resets in %s", wait.Round(time.Second))
+	}
+	c.logf("GitHub rate limit reached, waiting %s", wait.Round(time.Second))
+	return c.sleep(ctx, wait)
+}
+
+func (c *githubClient) logf(format string, args ...interface{}) {
+	if c.log != nil {
+		c.log(format, args...)
+	}
+}
+
+// githubRateLimitWait reports whether resp is rate limited and how long to
+// wait: Retry-After for secondary limits, otherwise until X-RateLimit-Reset
+// once X-RateLimit-Remaining runs out. A 429 without either header waits a
+// minute, as GitHub's docs suggest.
+func githubRateLimitWait(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			wait := time.Unix(reset, 0).Sub(now) + time.Second // Reset is whole seconds; don't wake early
+			if wait < time.Second {
+				wait = time.Second
+			}
+			return wait, true
+		}
+		return time.Minute, true
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return time.Minute, true
+	}
+	return 0, false
+}
+
+// githubStatusError describes a failed response, using GitHub's message
+// when the body has one
+func githubStatusError(resp *http.Response, body []byte) error {
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+		return fmt.Errorf("GitHub API returned %s: %s", resp.Status, apiErr.Message)
+	}
+	return fmt.Errorf("GitHub API returned %s", resp.Status)
+}
+
+var githubLinkNextPattern = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="next"`)
+
+// githubNextPage returns the rel="next" URL from a Link header, or ""
+func githubNextPage(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		if m := githubLinkNextPattern.FindStringSubmatch(part); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
@@ -148,7 +148,14 @@ var hooksInstallCmd = &cobra.Command{
 	Long: `Install git hooks for automatic bd sync.
 
 Hooks are installed to .git/hooks/ in the current repository.
-Existing hooks are backed up with a .backup suffix.
+Running install again updates bd's hooks in place. An existing hook that
+bd did not write is moved to a .backup suffix and chained: bd's hook runs
+it first and stops the git operation if it fails. Use --force to replace
+existing hooks without chaining.
+
+The pre-commit hook runs 'bd sync --flush-only' and stages the JSONL, so
+commits always carry the current database. When auto_flush is on and no
+changes are pending, the JSONL is already current and is not rewritten.
 
 Installed hooks:
   - pre-commit: Flush changes to JSONL before commit
@@ -206,7 +213,10 @@ Installed hooks:
 var hooksUninstallCmd = &cobra.Command{
 	Use:   "uninstall",
 	Short: "Uninstall bd git hooks",
-	Long:  `Remove bd git hooks from .git/hooks/ directory.`,
+	Long: `Remove bd git hooks from .git/hooks/ directory.
+
+Hooks that bd did not write are left alone. Hooks that bd chained are
+restored from their .backup copies.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := uninstallHooks(); err != nil {
 			if jsonOutput {
@@ -292,18 +302,29 @@ func installHooks(embeddedHooks map[string]string, force bool) error {
 	// Install each hook
 	for hookName, hookContent := range embeddedHooks {
 		hookPath := filepath.Join(hooksDir, hookName)
+		backupPath := hookPath + ".backup"
 		
 		// Check if hook already exists
-		if _, err := os.Stat(hookPath); err == nil {
-			// Hook exists - back it up unless force is set
-			if !force {
-				backupPath := hookPath + ".backup"
+		// #nosec G304 - controlled path from git directory
+		if existing, err := os.ReadFile(hookPath); err == nil && !force {
+			if !isBdHook(string(existing)) {
+				// Someone else's hook - keep it and run it from ours
+				if _, err := os.Stat(backupPath); err == nil {
+					return fmt.Errorf("cannot chain %s: %s already exists (move it aside or use --force)", hookName, filepath.Base(backupPath))
+				}
 				if err := os.Rename(hookPath, backupPath); err != nil {
 					return fmt.Errorf("failed to backup %s: %w", hookName, err)
 				}
 			}
 		}
 		
+		// Chain to the hook we replaced, on first install or on reinstall
+		if !force {
+			if _, err := os.Stat(backupPath); err == nil {
+				hookContent = chainHook(hookContent, filepath.Base(backupPath))
+			}
+		}
+		
 		// Write hook file
 		if err := os.WriteFile(hookPath, []byte(hookContent), 0755); err != nil {
 			return fmt.Errorf("failed to write %s: %w", hookName, err)
@@ -313,6 +334,32 @@ func installHooks(embeddedHooks map[string]string, force bool) error {
 	return nil
 }
 
+// isBdHook reports whether hook content was written by bd
+func isBdHook(content string) bool {
+	return strings.Contains(content, "bd (beads)") || strings.Contains(content, hookVersionPrefix)
+}
+
+// chainHook returns hook content that first runs the named hook from the
+// same directory, failing with its exit code if it fails. The call goes
+// right after the version marker so getHookVersion still finds it.
+func chainHook(content, previous string) string {
+	chain := fmt.Sprintf(`#
+# Chained: runs the hook that was here before bd was installed
+if [ -x "$(dirname "$0")/%[1]s" ]; then
+    "$(dirname "$0")/%[1]s" "$@" || exit $?
+fi
+`, previous)
+	
+	lines := strings.SplitAfter(content, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, hookVersionPrefix) {
+			return strings.Join(lines[:i+1], "") + chain + strings.Join(lines[i+1:], "")
+		}
+	}
+	// No version marker: put it after the shebang
+	return lines[0] + chain + strings.Join(lines[1:], "")
+}
+
 func uninstallHooks() error {
 	hooksDir := filepath.Join(".git", "hooks")
 	hookNames := []string{"pre-commit", "post-merge", "pre-push", "post-checkout"}
@@ -320,8 +367,13 @@ func uninstallHooks() error {
 	for _, hookName := range hookNames {
 		hookPath := filepath.Join(hooksDir, hookName)
 		
-		// Check if hook exists
-		if _, err := os.Stat(hookPath); os.IsNotExist(err) {
+		// Only remove hooks bd wrote
+		// #nosec G304 - controlled path from git directory
+		content, err := os.ReadFile(hookPath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err == nil && !isBdHook(string(content)) {
 			continue
 		}
 		
@@ -344,7 +396,7 @@ func uninstallHooks() error {
 }
 
 func init() {
-	hooksInstallCmd.Flags().Bool("force", false, "Overwrite existing hooks without backup")
+	hooksInstallCmd.Flags().Bool("force", false, "Overwrite existing hooks without backing up or chaining them")
 	
 	hooksCmd.AddCommand(hooksInstallCmd)
 	hooksCmd.AddCommand(hooksUninstallCmd)
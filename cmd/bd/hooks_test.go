@@ -3,6 +3,7 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -118,6 +119,109 @@ func TestInstallHooksBackup(t *testing.T) {
 	}
 }
 
+func TestInstallHooksIdempotentChain(t *testing.T) {
+	// Create temp directory with fake .git
+	tmpDir := t.TempDir()
+	gitDir := filepath.Join(tmpDir, ".git", "hooks")
+	if err := os.MkdirAll(gitDir, 0755); err != nil {
+		t.Fatalf("Failed to create test git dir: %v", err)
+	}
+
+	// Change to temp directory
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
+	// Create an existing user hook
+	existingHook := filepath.Join(gitDir, "pre-commit")
+	existingContent := "#!/bin/sh\necho user hook\n"
+	if err := os.WriteFile(existingHook, []byte(existingContent), 0755); err != nil {
+		t.Fatalf("Failed to create existing hook: %v", err)
+	}
+
+	hooks, err := getEmbeddedHooks()
+	if err != nil {
+		t.Fatalf("getEmbeddedHooks() failed: %v", err)
+	}
+
+	// Installing twice must not back up bd's own hook over the user's
+	for i := 0; i < 2; i++ {
+		if err := installHooks(hooks, false); err != nil {
+			t.Fatalf("installHooks() run %d failed: %v", i+1, err)
+		}
+	}
+
+	backupContent, err := os.ReadFile(existingHook + ".backup")
+	if err != nil {
+		t.Fatalf("Failed to read backup: %v", err)
+	}
+	if string(backupContent) != existingContent {
+		t.Errorf("Backup content mismatch: got %q, want %q", string(backupContent), existingContent)
+	}
+
+	content, err := os.ReadFile(existingHook)
+	if err != nil {
+		t.Fatalf("Failed to read hook: %v", err)
+	}
+	if !isBdHook(string(content)) {
+		t.Errorf("pre-commit is not a bd hook")
+	}
+	if n := strings.Count(string(content), "pre-commit.backup\" \"$@\""); n != 1 {
+		t.Errorf("pre-commit should call the user hook once, got %d calls:\n%s", n, content)
+	}
+	version, err := getHookVersion(existingHook)
+	if err != nil || version != Version {
+		t.Errorf("Chained hook version = %q, %v; want %q", version, err, Version)
+	}
+
+	// Hooks without a previous hook are not chained
+	postMerge, err := os.ReadFile(filepath.Join(gitDir, "post-merge"))
+	if err != nil {
+		t.Fatalf("Failed to read post-merge: %v", err)
+	}
+	if string(postMerge) != hooks["post-merge"] {
+		t.Errorf("post-merge should match the embedded hook")
+	}
+
+	// Uninstall restores the user hook
+	if err := uninstallHooks(); err != nil {
+		t.Fatalf("uninstallHooks() failed: %v", err)
+	}
+	restored, err := os.ReadFile(existingHook)
+	if err != nil {
+		t.Fatalf("Failed to read restored hook: %v", err)
+	}
+	if string(restored) != existingContent {
+		t.Errorf("Restored content mismatch: got %q, want %q", string(restored), existingContent)
+	}
+}
+
+func TestUninstallHooksKeepsForeignHooks(t *testing.T) {
+	// Create temp directory with fake .git
+	tmpDir := t.TempDir()
+	gitDir := filepath.Join(tmpDir, ".git", "hooks")
+	if err := os.MkdirAll(gitDir, 0755); err != nil {
+		t.Fatalf("Failed to create test git dir: %v", err)
+	}
+
+	// Change to temp directory
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
+	hookPath := filepath.Join(gitDir, "post-merge")
+	if err := os.WriteFile(hookPath, []byte("#!/bin/sh\necho mine\n"), 0755); err != nil {
+		t.Fatalf("Failed to create hook: %v", err)
+	}
+
+	if err := uninstallHooks(); err != nil {
+		t.Fatalf("uninstallHooks() failed: %v", err)
+	}
+	if _, err := os.Stat(hookPath); err != nil {
+		t.Errorf("Non-bd hook was removed: %v", err)
+	}
+}
+
 func TestInstallHooksForce(t *testing.T) {
 	// Create temp directory with fake .git
 	tmpDir := t.TempDir()
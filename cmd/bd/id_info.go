@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var idInfoCmd = &cobra.Command{
+	Use:   "id-info [issue-id]",
+	Short: "Explain how issue IDs are generated",
+	Long: `Show how bd picks the hash length for new issue IDs.
+
+The length grows with the number of top-level issues so the chance of two
+new IDs colliding stays under max_collision_prob. This shows the current
+issue count, the max_collision_prob, min_hash_length and max_hash_length
+config, the hash length new issues start at, the collision probability
+at that length, and how many more issues can be created before new IDs
+grow a character.
+
+When fewer than 10% of those issues are left, commands print a one-time
+warning on stderr, e.g. "approaching 5-char IDs: ~120 issues until length
+increases". Set id_length_warning to false to turn it off.
+
+With an issue ID, it shows the exact content string that was hashed to
+produce that ID and the resulting SHA-256 digest, so ID generation can be
+reproduced. New IDs hash "<title>|<description>|<nonce>", where the nonce
+comes from a per-database counter and is stored with the issue (id_nonce),
+so any machine can re-derive them. IDs from before id_nonce was stored also
+hashed the creator and creation time. Renamed or edited issues may not
+reproduce.
+
+Examples:
+  bd id-info
+  bd id-info bd-a3f8
+  bd id-info bd-a3f8 --json`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := ensureDirectMode("id-info requires direct database access"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: id-info requires SQLite storage\n")
+			os.Exit(1)
+		}
+		ctx := context.Background()
+		prefix, err := store.GetConfig(ctx, "issue_prefix")
+		if err != nil || prefix == "" {
+			fmt.Fprintf(os.Stderr, "Error: issue_prefix config is missing (run 'bd init --prefix <prefix>' first)\n")
+			os.Exit(1)
+		}
+
+		if len(args) == 1 {
+			showIssueIDInfo(ctx, args[0], prefix)
+			return
+		}
+
+		info, err := sqliteStore.GetAdaptiveIDInfo(ctx, prefix)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if jsonOutput {
+			outputJSON(info)
+			return
+		}
+		fmt.Printf("Prefix:                %s\n", info.Prefix)
+		fmt.Printf("Top-level issues:      %d\n", info.IssueCount)
+		fmt.Printf("max_collision_prob:    %g\n", info.MaxCollisionProbability)
+		fmt.Printf("min_hash_length:       %d\n", info.MinLength)
+		fmt.Printf("max_hash_length:       %d\n", info.MaxLength)
+		fmt.Printf("Hash length:           %d\n", info.Length)
+		fmt.Printf("Collision probability: %.4f%% at length %d\n", info.CollisionProbability*100, info.Length)
+		if info.NextLength > 0 {
+			fmt.Printf("Next length:           %d after ~%d more issues\n", info.NextLength, info.IssuesUntilNextLength)
+		} else {
+			fmt.Printf("Next length:           none (at max_hash_length)\n")
+		}
+	},
+}
+
+// idLengthWarningConfigKey turns off the warning that new IDs are about to
+// grow a character when set to false
+const idLengthWarningConfigKey = "id_length_warning"
+
+// idLengthWarnedMetadataKey records the length the warning was last shown
+// for, so it's shown once per length
+const idLengthWarnedMetadataKey = "id_length_warned"
+
+// idLengthWarningFraction is the share of a length's capacity left at which
+// the warning is shown
+const idLengthWarningFraction = 0.1
+
+// parseIDLengthWarning parses id_length_warning, which is true when empty
+func parseIDLengthWarning(value string) (bool, error) {
+	if value == "" {
+		return true, nil
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: must be true or false", idLengthWarningConfigKey, value)
+	}
+	return enabled, nil
+}
+
+// shouldWarnIDLength reports whether info is close enough to its next length
+// to warn: fewer than idLengthWarningFraction of the issues the current
+// length holds are left
+func shouldWarnIDLength(info *sqlite.AdaptiveIDInfo) bool {
+	if info.NextLength == 0 {
+		return false
+	}
+	capacity := info.IssueCount + info.IssuesUntilNextLength
+	return float64(info.IssuesUntilNextLength) <= float64(capacity)*idLengthWarningFraction
+}
+
+// warnApproachingIDLength prints a one-time warning when new issue IDs are
+// about to grow a character. ID generation is unaffected; failures are only
+// logged, since this runs on every database open.
+func warnApproachingIDLength(ctx context.Context, s *sqlite.SQLiteStorage) {
+	value, err := s.GetConfig(ctx, idLengthWarningConfigKey)
+	if err != nil {
+		debug.Logf("failed to read %s: %v", idLengthWarningConfigKey, err)
+		return
+	}
+	if enabled, err := parseIDLengthWarning(value); err != nil || !enabled {
+		return
+	}
+	prefix, err := s.GetConfig(ctx, "issue_prefix")
+	if err != nil || prefix == "" {
+		return
+	}
+	info, err := s.GetAdaptiveIDInfo(ctx, prefix)
+	if err != nil {
+		debug.Logf("failed to forecast ID length: %v", err)
+		return
+	}
+	if !shouldWarnIDLength(info) {
+		return
+	}
+	warned, _ := s.GetMetadata(ctx, idLengthWarnedMetadataKey)
+	if warned == strconv.Itoa(info.NextLength) {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Warning: approaching %d-char IDs: ~%d issues until length increases (see 'bd id-info'; set %s to false to hide)\n",
+		info.NextLength, info.IssuesUntilNextLength, idLengthWarningConfigKey)
+	if err := s.SetMetadata(ctx, idLengthWarnedMetadataKey, strconv.Itoa(info.NextLength)); err != nil {
+		debug.Logf("failed to record %s: %v", idLengthWarnedMetadataKey, err)
+	}
+}
+
+// issueIDInfo is the JSON output of bd id-info <id>
+type issueIDInfo struct {
+	ID         string                   `json:"id"`
+	Prefix     string                   `json:"prefix"`
+	Root       string                   `json:"root,omitempty"`   // Set for hierarchical child IDs
+	Parent     string                   `json:"parent,omitempty"` // Set for hierarchical child IDs
+	Creator    string                   `json:"creator,omitempty"`
+	Derivation *sqlite.HashIDDerivation `json:"derivation,omitempty"`
+	Reproduced bool                     `json:"reproduced"`
+}
+
+func showIssueIDInfo(ctx context.Context, id, prefix string) {
+	fullID, err := utils.ResolvePartialID(ctx, store, id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", id, err)
+		os.Exit(1)
+	}
+	issue, err := store.GetIssue(ctx, fullID)
+	if err != nil || issue == nil {
+		fmt.Fprintf(os.Stderr, "Error: issue %s not found\n", fullID)
+		os.Exit(1)
+	}
+	if match := utils.GetIDPrefixes(ctx, store).Match(fullID); match != "" {
+		prefix = match
+	} else if !strings.HasPrefix(fullID, prefix+"-") {
+		prefix = utils.ExtractIssuePrefix(fullID)
+	}
+	result := issueIDInfo{ID: fullID, Prefix: prefix}
+
+	if rootID, parentID, depth := types.ParseHierarchicalID(fullID); depth > 0 {
+		// Child suffixes come from the parent's counter, not a hash
+		result.Root, result.Parent = rootID, parentID
+	} else {
+		if issue.IDNonce == nil {
+			// Legacy IDs hashed the creator, which isn't stored on the issue,
+			// only on its created event
+			events, err := store.GetEvents(ctx, fullID, 0)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to get events: %v\n", err)
+				os.Exit(1)
+			}
+			for _, event := range events {
+				if event.EventType == types.EventCreated {
+					result.Creator = event.Actor
+				}
+			}
+		}
+		result.Derivation, result.Reproduced = sqlite.DeriveHashID(issue, prefix, result.Creator)
+	}
+
+	if jsonOutput {
+		outputJSON(result)
+		return
+	}
+	fmt.Printf("Issue:    %s\n", result.ID)
+	fmt.Printf("Prefix:   %s\n", result.Prefix)
+	if result.Parent != "" {
+		fmt.Printf("Parent:   %s\n", result.Parent)
+		fmt.Printf("\n%s is a hierarchical child ID: its suffix is the parent's next child\nnumber, not a hash. Run 'bd id-info %s' to see how the root ID was made.\n",
+			result.ID, result.Root)
+		return
+	}
+	d := result.Derivation
+	if result.Creator != "" {
+		fmt.Printf("Creator:  %s\n", result.Creator)
+	}
+	fmt.Printf("Content:  %q\n", d.Content)
+	fmt.Printf("SHA-256:  %s\n", d.Digest)
+	fmt.Printf("Length:   %d\n", d.Length)
+	fmt.Printf("Nonce:    %d\n", d.Nonce)
+	if result.Reproduced {
+		fmt.Printf("\n%s Content reproduces %s\n", color.GreenString("✓"), result.ID)
+	} else {
+		fmt.Printf("\n%s Content produces %s, not %s (the issue may have been\n  renamed or edited since it was created)\n",
+			color.YellowString("!"), d.ID, result.ID)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(idInfoCmd)
+}
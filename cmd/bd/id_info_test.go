@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestShouldWarnIDLength(t *testing.T) {
+	tests := []struct {
+		info *sqlite.AdaptiveIDInfo
+		want bool
+	}{
+		{&sqlite.AdaptiveIDInfo{IssueCount: 5800, NextLength: 6, IssuesUntilNextLength: 120}, true},
+		{&sqlite.AdaptiveIDInfo{IssueCount: 100, NextLength: 4, IssuesUntilNextLength: 60}, false},
+		{&sqlite.AdaptiveIDInfo{IssueCount: 9, NextLength: 4, IssuesUntilNextLength: 1}, true},
+		{&sqlite.AdaptiveIDInfo{IssueCount: 2000000, Length: 8}, false}, // At max_hash_length
+	}
+	for _, tt := range tests {
+		if got := shouldWarnIDLength(tt.info); got != tt.want {
+			t.Errorf("shouldWarnIDLength(%+v) = %v, want %v", tt.info, got, tt.want)
+		}
+	}
+}
+
+func TestWarnApproachingIDLengthOnce(t *testing.T) {
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	ctx := context.Background()
+
+	// At 0.001, 3-char IDs hold 9 issues
+	if err := s.SetConfig(ctx, "max_collision_prob", "0.001"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 9; i++ {
+		issue := &types.Issue{Title: fmt.Sprintf("Issue %d", i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := s.SetConfig(ctx, idLengthWarningConfigKey, "false"); err != nil {
+		t.Fatal(err)
+	}
+	warnApproachingIDLength(ctx, s)
+	if warned, _ := s.GetMetadata(ctx, idLengthWarnedMetadataKey); warned != "" {
+		t.Errorf("Expected no warning with %s=false, recorded %q", idLengthWarningConfigKey, warned)
+	}
+
+	if err := s.SetConfig(ctx, idLengthWarningConfigKey, "true"); err != nil {
+		t.Fatal(err)
+	}
+	warnApproachingIDLength(ctx, s)
+	if warned, _ := s.GetMetadata(ctx, idLengthWarnedMetadataKey); warned != "4" {
+		t.Errorf("Expected the warning for 4-char IDs to be recorded, got %q", warned)
+	}
+}
@@ -15,6 +15,8 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/importer"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -24,7 +26,9 @@ var importCmd = &cobra.Command{
 	Short: "Import issues from JSONL format",
 	Long: `Import issues from JSON Lines format (one JSON object per line).
 
-Reads from stdin by default, or use -i flag for file input.
+Reads from stdin by default, or use -i flag for file input. YAML written by
+'bd export --format yaml' is also accepted: a .yaml or .yml file, or input
+that starts with a YAML list, is read as YAML.
 
 Behavior:
   - Existing issues (same ID) are updated
@@ -32,6 +36,28 @@ Behavior:
   - Collisions (same ID, different content) are detected and reported
   - Use --dedupe-after to find and merge content duplicates after import
   - Use --dry-run to preview changes without applying them
+  - Every line is checked (required fields, status, type, priority) before
+    anything is written; one invalid line aborts the import unless
+    --skip-invalid is given, which imports the rest
+
+Merge mode (--merge) upserts keyed on issue ID, so re-importing a file that
+overlaps the database is idempotent: new IDs are inserted, and existing ones
+are updated to match the file, including their labels and dependencies
+(missing ones added, extra ones removed). --merge-strategy decides which
+version wins for an existing ID:
+  theirs  Take the incoming record (default)
+  ours    Keep the database version and skip the incoming one
+  newest  Take whichever has the later updated_at
+
+Streaming mode (--stream, or --from-stdin for stdin) is for very large
+JSONL files. It reads one line at a time and imports in batches of
+import.batch_size, so memory stays flat, and prints a progress line with the
+rate and ETA to stderr every --progress-every records (not with --json).
+Lines that don't parse or validate are skipped and reported by line number
+(bd exits 1 afterwards unless --skip-invalid); batches already imported
+stay imported if a later one fails. The summary lists inserted, updated,
+unchanged, skipped and failed records. --dry-run, --dedupe-after and YAML
+aren't supported.
 
 NOTE: Import requires direct database access and does not work with daemon mode.
       The command automatically uses --no-daemon when executed.`,
@@ -70,6 +96,43 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 		dedupeAfter, _ := cmd.Flags().GetBool("dedupe-after")
 		clearDuplicateExternalRefs, _ := cmd.Flags().GetBool("clear-duplicate-external-refs")
 		orphanHandling, _ := cmd.Flags().GetString("orphan-handling")
+		merge, _ := cmd.Flags().GetBool("merge")
+		mergeStrategy, _ := cmd.Flags().GetString("merge-strategy")
+		stream, _ := cmd.Flags().GetBool("stream")
+		fromStdin, _ := cmd.Flags().GetBool("from-stdin")
+
+		if cmd.Flags().Changed("merge-strategy") && !merge {
+			fmt.Fprintf(os.Stderr, "Error: --merge-strategy requires --merge\n")
+			os.Exit(1)
+		}
+		if merge {
+			if _, err := importer.ParseMergeStrategy(mergeStrategy); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if dryRun || skipUpdate || renameOnImport {
+				fmt.Fprintf(os.Stderr, "Error: --merge cannot be combined with --dry-run, --skip-existing or --rename-on-import\n")
+				os.Exit(1)
+			}
+		}
+
+		if fromStdin {
+			if input != "" {
+				fmt.Fprintf(os.Stderr, "Error: --from-stdin cannot be combined with --input\n")
+				os.Exit(1)
+			}
+			stream = true
+		}
+		if stream {
+			if dryRun || dedupeAfter {
+				fmt.Fprintf(os.Stderr, "Error: --stream cannot be combined with --dry-run or --dedupe-after\n")
+				os.Exit(1)
+			}
+			if ext := strings.ToLower(filepath.Ext(input)); ext == ".yaml" || ext == ".yml" {
+				fmt.Fprintf(os.Stderr, "Error: --stream reads JSONL only\n")
+				os.Exit(1)
+			}
+		}
 
 		// Open input
 		in := os.Stdin
@@ -88,14 +151,49 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 			in = f
 		}
 
-		// Phase 1: Read and parse all JSONL
 		ctx := context.Background()
-		scanner := bufio.NewScanner(in)
+		skipInvalid, _ := cmd.Flags().GetBool("skip-invalid")
+		if stream {
+			progressEvery, _ := cmd.Flags().GetInt("progress-every")
+			runStreamImport(ctx, in, input, ImportOptions{
+				SkipUpdate:                 skipUpdate,
+				Strict:                     strict,
+				RenameOnImport:             renameOnImport,
+				ClearDuplicateExternalRefs: clearDuplicateExternalRefs,
+				OrphanHandling:             orphanHandling,
+				Merge:                      merge,
+				MergeStrategy:              mergeStrategy,
+			}, progressEvery, skipInvalid)
+			return
+		}
+
+		// Phase 1: Read and parse all JSONL (or YAML)
+		reader := bufio.NewReader(in)
+		scanner := bufio.NewScanner(reader)
 
 		var allIssues []*types.Issue
+		var lineErrors []importLineError
 		lineNum := 0
 
-		for scanner.Scan() {
+		yamlInput := isYAMLInput(input, reader)
+		if yamlInput {
+			var err error
+			if allIssues, err = readYAMLIssues(reader); err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing YAML: %v\n", err)
+				os.Exit(1)
+			}
+			valid := allIssues[:0]
+			for i, issue := range allIssues {
+				if err := validateImportIssue(issue); err != nil {
+					lineErrors = append(lineErrors, importLineError{Where: fmt.Sprintf("issue %d", i+1), Err: err})
+					continue
+				}
+				valid = append(valid, issue)
+			}
+			allIssues = valid
+		}
+
+		for !yamlInput && scanner.Scan() {
 		lineNum++
 		rawLine := scanner.Bytes()
 		line := string(rawLine)
@@ -148,8 +246,9 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 				}()
 				in = f
 				scanner = bufio.NewScanner(in)
-				allIssues = nil // Reset issues list
-				lineNum = 0     // Reset line counter
+				allIssues = nil  // Reset issues list
+				lineErrors = nil // Reset line errors
+				lineNum = 0      // Reset line counter
 				continue        // Restart parsing from beginning
 			} else {
 				// Can't retry stdin - should not happen since git conflicts only in files
@@ -158,11 +257,15 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 			}
 		}
 
-		// Parse JSON
+		// Parse and validate JSON, collecting bad lines so each one is reported
 		var issue types.Issue
 		if err := json.Unmarshal([]byte(line), &issue); err != nil {
-			fmt.Fprintf(os.Stderr, "Error parsing line %d: %v\n", lineNum, err)
-			os.Exit(1)
+			lineErrors = append(lineErrors, importLineError{Where: fmt.Sprintf("line %d", lineNum), Err: fmt.Errorf("invalid JSON: %w", err)})
+			continue
+		}
+		if err := validateImportIssue(&issue); err != nil {
+			lineErrors = append(lineErrors, importLineError{Where: fmt.Sprintf("line %d", lineNum), Err: err})
+			continue
 		}
 
 		allIssues = append(allIssues, &issue)
@@ -173,30 +276,14 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 			os.Exit(1)
 		}
 
+		// Nothing is written while any record is invalid, unless --skip-invalid
+		reportImportLineErrors(lineErrors, skipInvalid)
+
 		// Check if database needs initialization (prefix not set)
 		// Detect prefix from the imported issues
-		initCtx := context.Background()
-		configuredPrefix, err2 := store.GetConfig(initCtx, "issue_prefix")
-		if err2 != nil || strings.TrimSpace(configuredPrefix) == "" {
-			// Database exists but not initialized - detect prefix from issues
-			detectedPrefix := detectPrefixFromIssues(allIssues)
-			if detectedPrefix == "" {
-				// No issues to import or couldn't detect prefix, use directory name
-				cwd, err := os.Getwd()
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error: failed to get current directory: %v\n", err)
-					os.Exit(1)
-				}
-				detectedPrefix = filepath.Base(cwd)
-			}
-			detectedPrefix = strings.TrimRight(detectedPrefix, "-")
-			
-			if err := store.SetConfig(initCtx, "issue_prefix", detectedPrefix); err != nil {
-				fmt.Fprintf(os.Stderr, "Error: failed to set issue prefix: %v\n", err)
-				os.Exit(1)
-			}
-			
-			fmt.Fprintf(os.Stderr, "✓ Initialized database with prefix '%s' (detected from issues)\n", detectedPrefix)
+		if err := ensureImportPrefix(ctx, store, allIssues); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
 
 		// Phase 2: Use shared import logic
@@ -207,6 +294,8 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 			RenameOnImport:             renameOnImport,
 			ClearDuplicateExternalRefs: clearDuplicateExternalRefs,
 			OrphanHandling:             orphanHandling,
+			Merge:                      merge,
+			MergeStrategy:              mergeStrategy,
 		}
 
 		result, err := importIssuesCore(ctx, dbPath, store, allIssues, opts)
@@ -319,7 +408,11 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 		}
 
 		// Print summary
-		fmt.Fprintf(os.Stderr, "Import complete: %d created, %d updated", result.Created, result.Updated)
+		if merge {
+			fmt.Fprintf(os.Stderr, "Merge complete: %d inserted, %d updated", result.Created, result.Updated)
+		} else {
+			fmt.Fprintf(os.Stderr, "Import complete: %d created, %d updated", result.Created, result.Updated)
+		}
 		if result.Unchanged > 0 {
 			fmt.Fprintf(os.Stderr, ", %d unchanged", result.Unchanged)
 		}
@@ -372,7 +465,7 @@ NOTE: Import requires direct database access and does not work with daemon mode.
 						sources = append(sources, issue.ID)
 					}
 				}
-				fmt.Fprintf(os.Stderr, "  Suggested: bd merge %s --into %s\n\n",
+				fmt.Fprintf(os.Stderr, "  Suggested: bd merge-issues %s --into %s\n\n",
 					strings.Join(sources, " "), target.ID)
 			}
 
@@ -597,13 +690,6 @@ func attemptAutoMerge(conflictedPath string) error {
 	mergeCmd := exec.Command(exe, "merge", outputPath, basePath, leftPath, rightPath)
 	mergeOutput, err := mergeCmd.CombinedOutput()
 	if err != nil {
-		// Check exit code - bd merge returns 1 if there are conflicts, 2 for errors
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			if exitErr.ExitCode() == 1 {
-				// Conflicts exist - merge tool did its best but couldn't resolve everything
-				return fmt.Errorf("merge conflicts could not be automatically resolved:\n%s", mergeOutput)
-			}
-		}
 		return fmt.Errorf("merge command failed: %w\n%s", err, mergeOutput)
 	}
 
@@ -628,6 +714,33 @@ func attemptAutoMerge(conflictedPath string) error {
 	return nil
 }
 
+// ensureImportPrefix sets issue_prefix from the issues being imported (or
+// the directory name) when the database doesn't have one yet
+func ensureImportPrefix(ctx context.Context, s storage.Storage, issues []*types.Issue) error {
+	configuredPrefix, err := s.GetConfig(ctx, "issue_prefix")
+	if err == nil && strings.TrimSpace(configuredPrefix) != "" {
+		return nil
+	}
+	// Database exists but not initialized - detect prefix from issues
+	detectedPrefix := detectPrefixFromIssues(issues)
+	if detectedPrefix == "" {
+		// No issues to import or couldn't detect prefix, use directory name
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		detectedPrefix = filepath.Base(cwd)
+	}
+	detectedPrefix = strings.TrimRight(detectedPrefix, "-")
+
+	if err := s.SetConfig(ctx, "issue_prefix", detectedPrefix); err != nil {
+		return fmt.Errorf("failed to set issue prefix: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "✓ Initialized database with prefix '%s' (detected from issues)\n", detectedPrefix)
+	return nil
+}
+
 // detectPrefixFromIssues extracts the common prefix from issue IDs
 // Only considers the first hyphen, so "vc-baseline-test" -> "vc"
 func detectPrefixFromIssues(issues []*types.Issue) string {
@@ -661,12 +774,18 @@ func detectPrefixFromIssues(issues []*types.Issue) string {
 func init() {
 	importCmd.Flags().StringP("input", "i", "", "Input file (default: stdin)")
 	importCmd.Flags().BoolP("skip-existing", "s", false, "Skip existing issues instead of updating them")
+	importCmd.Flags().Bool("skip-invalid", false, "Import the valid issues when some lines fail validation, instead of aborting")
 	importCmd.Flags().Bool("strict", false, "Fail on dependency errors instead of treating them as warnings")
 	importCmd.Flags().Bool("dedupe-after", false, "Detect and report content duplicates after import")
 	importCmd.Flags().Bool("dry-run", false, "Preview collision detection without making changes")
 	importCmd.Flags().Bool("rename-on-import", false, "Rename imported issues to match database prefix (updates all references)")
 	importCmd.Flags().Bool("clear-duplicate-external-refs", false, "Clear duplicate external_ref values (keeps first occurrence)")
+	importCmd.Flags().Bool("merge", false, "Upsert keyed on issue ID, reconciling labels and dependencies")
+	importCmd.Flags().String("merge-strategy", "theirs", "Which version wins for existing IDs with --merge: ours/theirs/newest")
 	importCmd.Flags().String("orphan-handling", "", "How to handle missing parent issues: strict/resurrect/skip/allow (default: use config or 'allow')")
+	importCmd.Flags().Bool("stream", false, "Import JSONL line by line in batches with progress, keeping memory flat (for very large files)")
+	importCmd.Flags().Bool("from-stdin", false, "Stream JSONL from stdin (--stream without --input)")
+	importCmd.Flags().Int("progress-every", 10000, "With --stream, print a progress line every N records (0 to disable)")
 	importCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output import statistics in JSON format")
 	rootCmd.AddCommand(importCmd)
 }
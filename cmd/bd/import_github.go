@@ -0,0 +1,553 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var importGitHubCmd = &cobra.Command{
+	Use:   "import-github",
+	Short: "Import issues from a GitHub repository",
+	Long: `Import a GitHub repository's issues through the GitHub API.
+
+Each issue's title, body, state, labels and assignee are mapped onto a bd
+issue, and its GitHub number is kept in external_ref as owner/name#N.
+Running the import again updates those issues instead of duplicating them,
+as long as GitHub has the newer change. Pull requests are skipped.
+
+Labels map the same way bd export --format github writes them:
+  critical, high, low, backlog (or p0-p4)   priority (default P2)
+  bug, feature, epic, chore                 type (default task)
+  in-progress, blocked                      status of open issues
+Other labels are copied as bd labels.
+
+Relationships are recreated from references in issue bodies:
+  - [ ] #12            task list item: #12 becomes a child of this issue
+  Blocked by #12       (or "Depends on #12"): #12 blocks this issue
+
+Bodies written by bd export --format github are split back into
+description, design, acceptance criteria and dependencies, and a
+closed issue's state_reason becomes its resolution, so exported issues
+round-trip. An issue whose body names an ID bd has, and that isn't linked to
+GitHub yet, is updated instead of duplicated.
+
+The token comes from --token or GITHUB_TOKEN. Without one only public
+repositories can be read, at a much lower rate limit. Pagination is followed
+to the end, and when the rate limit runs out the import waits for it to
+reset (up to an hour).
+
+Examples:
+  bd import-github --repo owner/name --dry-run
+  bd import-github --repo owner/name --since 7d
+  bd import-github --repo owner/name --api-url https://github.example.com/api/v3`,
+	Run: func(cmd *cobra.Command, args []string) {
+		repo, _ := cmd.Flags().GetString("repo")
+		token, _ := cmd.Flags().GetString("token")
+		sinceStr, _ := cmd.Flags().GetString("since")
+		apiURL, _ := cmd.Flags().GetString("api-url")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		if !githubRepoPattern.MatchString(repo) {
+			fmt.Fprintf(os.Stderr, "Error: --repo must be owner/name, got %q\n", repo)
+			os.Exit(1)
+		}
+		var since time.Time
+		if sinceStr != "" {
+			var err error
+			since, err = parseTimeFlag(sinceStr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing --since: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		if token == "" {
+			token = os.Getenv("GITHUB_TOKEN")
+		}
+
+		if err := ensureDirectMode("import-github requires direct database access"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: import-github requires SQLite storage\n")
+			os.Exit(1)
+		}
+
+		ctx := context.Background()
+		client := newGitHubClient(apiURL, token)
+		client.log = func(format string, args ...interface{}) {
+			fmt.Fprintf(os.Stderr, format+"\n", args...)
+		}
+		ghIssues, err := client.ListIssues(ctx, repo, since)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error fetching issues from %s: %v\n", repo, err)
+			os.Exit(1)
+		}
+
+		plan, err := planGitHubImport(ctx, sqliteStore, repo, ghIssues)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if dryRun {
+			if jsonOutput {
+				outputJSON(map[string]interface{}{
+					"dry_run":   true,
+					"fetched":   len(ghIssues),
+					"created":   len(plan.creates),
+					"updated":   len(plan.updates),
+					"unchanged": plan.unchanged,
+				})
+				return
+			}
+			fmt.Println(color.YellowString("DRY RUN - no changes will be made"))
+			for _, issue := range plan.creates {
+				fmt.Printf("  create %s %s\n", *issue.ExternalRef, issue.Title)
+			}
+			for _, u := range plan.updates {
+				fmt.Printf("  update %s (%s) %s\n", u.id, *u.incoming.ExternalRef, u.incoming.Title)
+			}
+			fmt.Printf("\nWould create %d, update %d, leave %d unchanged (%d fetched from %s)\n",
+				len(plan.creates), len(plan.updates), plan.unchanged, len(ghIssues), repo)
+			return
+		}
+
+		result, err := applyGitHubImport(ctx, sqliteStore, repo, ghIssues, plan)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"fetched":       len(ghIssues),
+				"created":       len(plan.creates),
+				"updated":       len(plan.updates),
+				"unchanged":     plan.unchanged,
+				"relationships": result.relationships,
+			})
+			return
+		}
+		fmt.Printf("%s Imported %d issue(s) from %s: %d created, %d updated, %d unchanged, %d relationship(s) added\n",
+			color.GreenString("✓"), len(ghIssues), repo, len(plan.creates), len(plan.updates), plan.unchanged, result.relationships)
+	},
+}
+
+var githubRepoPattern = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+
+// githubExternalRef is the external_ref of GitHub issue number in repo
+func githubExternalRef(repo string, number int) string {
+	return fmt.Sprintf("%s#%d", repo, number)
+}
+
+// Label mappings for import: the inverse of the export maps, plus p0-p4
+var (
+	githubLabelPriorities = invertGitHubLabels(githubPriorityLabels)
+	githubLabelTypes      = invertGitHubLabels(githubTypeLabels)
+	githubLabelStatuses   = invertGitHubLabels(githubStatusLabels)
+)
+
+func invertGitHubLabels[K comparable](m map[K]string) map[string]K {
+	inverted := make(map[string]K, len(m))
+	for k, label := range m {
+		inverted[label] = k
+	}
+	return inverted
+}
+
+// githubResolutions maps GitHub's state_reason for closed issues
+var githubResolutions = map[string]types.Resolution{
+	"completed":   types.ResolutionFixed,
+	"not_planned": types.ResolutionWontFix,
+	"duplicate":   types.ResolutionDuplicate,
+}
+
+// githubBeadsIDPattern matches the marker bd export --format github leaves
+// at the end of each body
+var githubBeadsIDPattern = regexp.MustCompile(`\s*<!-- beads-id: (\S+) -->\s*$`)
+
+// githubExportedDepPattern matches a dependency line bd export --format
+// github writes, e.g. "- [x] bd-2: Add retries (blocks)"
+var githubExportedDepPattern = regexp.MustCompile(`(?m)^- \[[ x]\] ([^\s:]+)(?:: .*)? \(([a-z-]+)\)$`)
+
+// githubExportedBody is an issue body split back into the parts bd export
+// --format github assembled it from
+type githubExportedBody struct {
+	BeadsID            string // Empty if bd didn't write the body
+	Description        string
+	Design             string
+	AcceptanceCriteria string
+	Dependencies       []*types.Dependency // DependsOnID is the exported beads ID
+}
+
+// parseGitHubBody splits a body bd exported into description, design,
+// acceptance criteria and dependencies. Bodies without the beads-id marker
+// weren't written by bd and are kept whole as the description.
+func parseGitHubBody(body string) githubExportedBody {
+	m := githubBeadsIDPattern.FindStringSubmatchIndex(body)
+	if m == nil {
+		return githubExportedBody{Description: body}
+	}
+	parsed := githubExportedBody{BeadsID: body[m[2]:m[3]]}
+	rest := body[:m[0]]
+
+	// Sections are written in order, so take them off the end
+	cut := func(heading string) string {
+		h := "## " + heading + "\n"
+		if strings.HasPrefix(rest, h) {
+			section := rest[len(h):]
+			rest = ""
+			return strings.TrimPrefix(section, "\n")
+		}
+		if i := strings.LastIndex(rest, "\n\n"+h); i >= 0 {
+			section := rest[i+2+len(h):]
+			rest = rest[:i]
+			return strings.TrimPrefix(section, "\n")
+		}
+		return ""
+	}
+	for _, dm := range githubExportedDepPattern.FindAllStringSubmatch(cut("Dependencies"), -1) {
+		parsed.Dependencies = append(parsed.Dependencies, &types.Dependency{DependsOnID: dm[1], Type: types.DependencyType(dm[2])})
+	}
+	parsed.AcceptanceCriteria = cut("Acceptance Criteria")
+	parsed.Design = cut("Design")
+	parsed.Description = rest
+	return parsed
+}
+
+// fromGitHubIssue converts a GitHub issue into a bd issue without an ID
+func fromGitHubIssue(gh githubAPIIssue, repo string) *types.Issue {
+	ref := githubExternalRef(repo, gh.Number)
+	body := parseGitHubBody(gh.Body)
+	issue := &types.Issue{
+		Title:              gh.Title,
+		Description:        body.Description,
+		Design:             body.Design,
+		AcceptanceCriteria: body.AcceptanceCriteria,
+		Status:             types.StatusOpen,
+		Priority:           2,
+		IssueType:          types.TypeTask,
+		CreatedAt:          gh.CreatedAt,
+		UpdatedAt:          gh.UpdatedAt,
+		ExternalRef:        &ref,
+	}
+	if gh.Assignee != nil {
+		issue.Assignee = gh.Assignee.Login
+	}
+
+	status := types.StatusOpen
+	obsolete := false
+	for _, label := range gh.Labels {
+		name := strings.ToLower(strings.TrimSpace(label.Name))
+		if p, ok := githubLabelPriorities[name]; ok {
+			issue.Priority = p
+		} else if p, ok := parseGitHubPriorityLabel(name); ok {
+			issue.Priority = p
+		} else if t, ok := githubLabelTypes[name]; ok {
+			issue.IssueType = t
+		} else if s, ok := githubLabelStatuses[name]; ok {
+			status = s
+		} else if name == githubObsoleteLabel && gh.State == "closed" {
+			obsolete = true
+		} else {
+			issue.Labels = append(issue.Labels, label.Name)
+		}
+	}
+	issue.Status = status
+
+	if gh.State == "closed" {
+		issue.Status = types.StatusClosed
+		closedAt := gh.UpdatedAt
+		if gh.ClosedAt != nil {
+			closedAt = *gh.ClosedAt
+		}
+		issue.ClosedAt = &closedAt
+		issue.Resolution = githubResolutions[gh.StateReason]
+		if obsolete {
+			issue.Resolution = types.ResolutionObsolete
+		}
+	}
+	return issue
+}
+
+// parseGitHubPriorityLabel reads "p0" through "p4"
+func parseGitHubPriorityLabel(name string) (int, bool) {
+	if len(name) != 2 || name[0] != 'p' {
+		return 0, false
+	}
+	p, err := strconv.Atoi(name[1:])
+	return p, err == nil && p >= 0 && p <= 4
+}
+
+var (
+	githubTaskRefPattern  = regexp.MustCompile(`(?m)^\s*[-*]\s+\[[ xX]\]\s+#(\d+)\b`)
+	githubBlockedByLine   = regexp.MustCompile(`(?im)^\W*(?:blocked by|depends on)\b.*$`)
+	githubIssueRefPattern = regexp.MustCompile(`(?:^|[^\w/#])#(\d+)\b`)
+)
+
+// githubBodyRefs finds the relationships an issue body spells out: task
+// list items referencing another issue are its children, and issues on a
+// "Blocked by" or "Depends on" line block it. Only same-repository
+// references (#N) count.
+func githubBodyRefs(body string) (children, blockers []int) {
+	for _, m := range githubTaskRefPattern.FindAllStringSubmatch(body, -1) {
+		if n, err := strconv.Atoi(m[1]); err == nil {
+			children = append(children, n)
+		}
+	}
+	for _, line := range githubBlockedByLine.FindAllString(body, -1) {
+		for _, m := range githubIssueRefPattern.FindAllStringSubmatch(line, -1) {
+			if n, err := strconv.Atoi(m[1]); err == nil {
+				blockers = append(blockers, n)
+			}
+		}
+	}
+	return children, blockers
+}
+
+// githubImportPlan is what an import will change
+type githubImportPlan struct {
+	creates   []*types.Issue
+	updates   []githubImportUpdate
+	unchanged int
+	// ids maps GitHub numbers to the bd issues already holding them
+	ids map[int]string
+}
+
+type githubImportUpdate struct {
+	id        string
+	incoming  *types.Issue
+	fields    map[string]interface{}
+	newLabels []string
+}
+
+// planGitHubImport matches each GitHub issue to a bd issue, by external_ref
+// or by the beads-id marker of an issue bd exported, and works out what to
+// create and update. An existing issue is only updated when GitHub changed
+// it more recently than bd did.
+func planGitHubImport(ctx context.Context, s *sqlite.SQLiteStorage, repo string, ghIssues []githubAPIIssue) (*githubImportPlan, error) {
+	existing, err := s.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issues: %w", err)
+	}
+	byRef := make(map[string]*types.Issue)
+	byID := make(map[string]*types.Issue, len(existing))
+	plan := &githubImportPlan{ids: make(map[int]string)}
+	prefix := repo + "#"
+	for _, issue := range existing {
+		byID[issue.ID] = issue
+		if issue.ExternalRef == nil || *issue.ExternalRef == "" {
+			continue
+		}
+		byRef[*issue.ExternalRef] = issue
+		if n, err := strconv.Atoi(strings.TrimPrefix(*issue.ExternalRef, prefix)); err == nil && strings.HasPrefix(*issue.ExternalRef, prefix) {
+			plan.ids[n] = issue.ID
+		}
+	}
+
+	for _, gh := range ghIssues {
+		incoming := fromGitHubIssue(gh, repo)
+		match := byRef[*incoming.ExternalRef]
+		if match == nil {
+			if m := githubBeadsIDPattern.FindStringSubmatch(gh.Body); m != nil {
+				if candidate := byID[m[1]]; candidate != nil && (candidate.ExternalRef == nil || *candidate.ExternalRef == "") {
+					match = candidate
+				}
+			}
+		}
+		if match == nil {
+			plan.creates = append(plan.creates, incoming)
+			continue
+		}
+		plan.ids[gh.Number] = match.ID
+		if !gh.UpdatedAt.After(match.UpdatedAt) {
+			plan.unchanged++
+			continue
+		}
+
+		labels, err := s.GetLabels(ctx, match.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get labels for %s: %w", match.ID, err)
+		}
+		update := githubImportUpdate{id: match.ID, incoming: incoming, fields: githubIssueUpdates(match, incoming)}
+		for _, label := range incoming.Labels {
+			if !slices.Contains(labels, label) {
+				update.newLabels = append(update.newLabels, label)
+			}
+		}
+		if len(update.fields) == 0 && len(update.newLabels) == 0 {
+			plan.unchanged++
+			continue
+		}
+		plan.updates = append(plan.updates, update)
+	}
+	return plan, nil
+}
+
+// githubIssueUpdates returns the GitHub-mapped fields that differ between
+// an existing issue and its incoming version
+func githubIssueUpdates(existing, incoming *types.Issue) map[string]interface{} {
+	updates := make(map[string]interface{})
+	if existing.Title != incoming.Title {
+		updates["title"] = incoming.Title
+	}
+	if existing.Description != incoming.Description {
+		updates["description"] = incoming.Description
+	}
+	if existing.Design != incoming.Design {
+		updates["design"] = incoming.Design
+	}
+	if existing.AcceptanceCriteria != incoming.AcceptanceCriteria {
+		updates["acceptance_criteria"] = incoming.AcceptanceCriteria
+	}
+	if existing.Priority != incoming.Priority {
+		updates["priority"] = incoming.Priority
+	}
+	if existing.IssueType != incoming.IssueType {
+		updates["issue_type"] = incoming.IssueType
+	}
+	if existing.Assignee != incoming.Assignee {
+		updates["assignee"] = incoming.Assignee
+	}
+	if existing.ExternalRef == nil || *existing.ExternalRef != *incoming.ExternalRef {
+		updates["external_ref"] = *incoming.ExternalRef
+	}
+	if existing.Status != incoming.Status {
+		updates["status"] = incoming.Status
+		// Explicit so closed_at keeps GitHub's time rather than now
+		updates["closed_at"] = incoming.ClosedAt
+	}
+	if incoming.Status == types.StatusClosed && existing.Resolution != incoming.Resolution {
+		updates["resolution"] = string(incoming.Resolution)
+	}
+	return updates
+}
+
+type githubImportResult struct {
+	relationships int
+}
+
+// applyGitHubImport makes the planned changes, then adds the relationships
+// found in the imported bodies: task lists and "Blocked by" lines, and the
+// dependency lists of bodies bd exported, whose beads IDs resolve to the
+// issues imported from them or to issues bd has with those IDs.
+// Relationships bd already has, or that would close a dependency cycle, are
+// skipped.
+func applyGitHubImport(ctx context.Context, s *sqlite.SQLiteStorage, repo string, ghIssues []githubAPIIssue, plan *githubImportPlan) (*githubImportResult, error) {
+	if err := s.CreateIssuesBatch(ctx, plan.creates, actor); err != nil {
+		return nil, fmt.Errorf("failed to create issues: %w", err)
+	}
+	prefix := repo + "#"
+	for _, issue := range plan.creates {
+		n, _ := strconv.Atoi(strings.TrimPrefix(*issue.ExternalRef, prefix))
+		plan.ids[n] = issue.ID
+	}
+	for _, u := range plan.updates {
+		if len(u.fields) > 0 {
+			if err := s.UpdateIssue(ctx, u.id, u.fields, actor); err != nil {
+				return nil, fmt.Errorf("failed to update %s: %w", u.id, err)
+			}
+		}
+		for _, label := range u.newLabels {
+			if err := s.AddLabel(ctx, u.id, label, actor); err != nil {
+				return nil, fmt.Errorf("failed to add label %q to %s: %w", label, u.id, err)
+			}
+		}
+	}
+
+	allDeps, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	has := make(map[[2]string]bool)
+	for _, deps := range allDeps {
+		for _, dep := range deps {
+			has[[2]string{dep.IssueID, dep.DependsOnID}] = true
+		}
+	}
+
+	beadsIDs := make(map[string]string)
+	for _, gh := range ghIssues {
+		if body := parseGitHubBody(gh.Body); body.BeadsID != "" {
+			beadsIDs[body.BeadsID] = plan.ids[gh.Number]
+		}
+	}
+	resolveBeadsID := func(beadsID string) (string, error) {
+		if id, ok := beadsIDs[beadsID]; ok {
+			return id, nil
+		}
+		issue, err := s.GetIssue(ctx, beadsID)
+		if err != nil || issue == nil {
+			return "", err
+		}
+		return issue.ID, nil
+	}
+
+	var wanted []*types.Dependency
+	for _, gh := range ghIssues {
+		id := plan.ids[gh.Number]
+		for _, dep := range parseGitHubBody(gh.Body).Dependencies {
+			target, err := resolveBeadsID(dep.DependsOnID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to look up %s: %w", dep.DependsOnID, err)
+			}
+			if target != "" && target != id {
+				wanted = append(wanted, &types.Dependency{IssueID: id, DependsOnID: target, Type: dep.Type})
+			}
+		}
+		children, blockers := githubBodyRefs(gh.Body)
+		for _, n := range children {
+			if child, ok := plan.ids[n]; ok && child != id {
+				wanted = append(wanted, &types.Dependency{IssueID: child, DependsOnID: id, Type: types.DepParentChild})
+			}
+		}
+		for _, n := range blockers {
+			if blocker, ok := plan.ids[n]; ok && blocker != id {
+				wanted = append(wanted, &types.Dependency{IssueID: id, DependsOnID: blocker, Type: types.DepBlocks})
+			}
+		}
+	}
+	sort.SliceStable(wanted, func(i, j int) bool {
+		return wanted[i].IssueID < wanted[j].IssueID
+	})
+
+	result := &githubImportResult{}
+	for _, dep := range wanted {
+		key := [2]string{dep.IssueID, dep.DependsOnID}
+		if has[key] || has[[2]string{dep.DependsOnID, dep.IssueID}] {
+			continue
+		}
+		if err := s.AddDependency(ctx, dep, actor); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s relationship %s → %s: %v\n", dep.Type, dep.IssueID, dep.DependsOnID, err)
+			continue
+		}
+		has[key] = true
+		result.relationships++
+	}
+	return result, nil
+}
+
+func init() {
+	importGitHubCmd.Flags().String("repo", "", "GitHub repository to import from (owner/name)")
+	importGitHubCmd.Flags().String("token", "", "GitHub token (default: $GITHUB_TOKEN)")
+	importGitHubCmd.Flags().String("since", "", "Only import issues updated since this date or duration (e.g. 2025-01-01, 7d)")
+	importGitHubCmd.Flags().String("api-url", defaultGitHubAPIURL, "GitHub API base URL (for GitHub Enterprise)")
+	importGitHubCmd.Flags().Bool("dry-run", false, "Show what would be imported without changing anything")
+	_ = importGitHubCmd.MarkFlagRequired("repo")
+	rootCmd.AddCommand(importGitHubCmd)
+}
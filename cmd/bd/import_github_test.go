@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestGitHubClientListIssues(t *testing.T) {
+	var requests int
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		switch r.URL.Query().Get("page") {
+		case "":
+			if r.URL.Query().Get("since") != "2025-01-02T00:00:00Z" || r.URL.Query().Get("state") != "all" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/o/r/issues?page=2>; rel="next", <%s/repos/o/r/issues?page=2>; rel="last"`, server.URL, server.URL))
+			fmt.Fprint(w, `[{"number":1,"title":"One"},{"number":2,"title":"A PR","pull_request":{}}]`)
+		case "2":
+			// Rate limited once, then served
+			if requests == 2 {
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(30*time.Second).Unix(), 10))
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, `{"message":"API rate limit exceeded"}`)
+				return
+			}
+			fmt.Fprint(w, `[{"number":3,"title":"Three"}]`)
+		}
+	}))
+	defer server.Close()
+
+	client := newGitHubClient(server.URL, "secret")
+	var waits []time.Duration
+	client.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	issues, err := client.ListIssues(context.Background(), "o/r", time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ListIssues failed: %v", err)
+	}
+	if len(issues) != 2 || issues[0].Number != 1 || issues[1].Number != 3 {
+		t.Errorf("expected issues 1 and 3 (pull request skipped), got %+v", issues)
+	}
+	if len(waits) != 1 || waits[0] < 20*time.Second || waits[0] > 32*time.Second {
+		t.Errorf("expected one wait until the rate limit reset, got %v", waits)
+	}
+
+	// Client errors other than rate limits fail without retrying
+	requests = 0
+	notFound := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	}))
+	defer notFound.Close()
+	client.baseURL = notFound.URL
+	if _, err := client.ListIssues(context.Background(), "o/r", time.Time{}); err == nil || requests != 1 {
+		t.Errorf("expected a single failed request, got %d requests and error %v", requests, err)
+	}
+}
+
+func TestGitHubBodyRefs(t *testing.T) {
+	body := "Tracking issue\n\n- [ ] #4\n- [x] #5 done already\n* [ ] other/repo#6\n\nBlocked by #7 and #8\nSee #9 for context\n"
+	children, blockers := githubBodyRefs(body)
+	if fmt.Sprint(children) != "[4 5]" {
+		t.Errorf("children = %v, want [4 5]", children)
+	}
+	if fmt.Sprint(blockers) != "[7 8]" {
+		t.Errorf("blockers = %v, want [7 8]", blockers)
+	}
+}
+
+func TestFromGitHubIssue(t *testing.T) {
+	closedAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	gh := githubAPIIssue{
+		Number:      12,
+		Title:       "Crash on start",
+		Body:        "It crashes\n\n<!-- beads-id: bd-1 -->",
+		State:       "closed",
+		StateReason: "not_planned",
+		ClosedAt:    &closedAt,
+	}
+	gh.Labels = append(gh.Labels, struct {
+		Name string `json:"name"`
+	}{"Bug"}, struct {
+		Name string `json:"name"`
+	}{"p1"}, struct {
+		Name string `json:"name"`
+	}{"ui"})
+
+	issue := fromGitHubIssue(gh, "o/r")
+	if issue.IssueType != types.TypeBug || issue.Priority != 1 || issue.Status != types.StatusClosed {
+		t.Errorf("unexpected mapping: type %s, priority %d, status %s", issue.IssueType, issue.Priority, issue.Status)
+	}
+	if issue.Description != "It crashes" {
+		t.Errorf("expected the beads-id marker stripped, got %q", issue.Description)
+	}
+	if *issue.ExternalRef != "o/r#12" || issue.Resolution != types.ResolutionWontFix || !issue.ClosedAt.Equal(closedAt) {
+		t.Errorf("unexpected external ref %q, resolution %q or closed_at %v", *issue.ExternalRef, issue.Resolution, issue.ClosedAt)
+	}
+	if len(issue.Labels) != 1 || issue.Labels[0] != "ui" {
+		t.Errorf("expected only unmapped labels kept, got %v", issue.Labels)
+	}
+}
+
+func TestGitHubImportPlanAndApply(t *testing.T) {
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	ctx := context.Background()
+
+	created := time.Now().Add(-48 * time.Hour)
+	ghIssues := []githubAPIIssue{
+		{Number: 1, Title: "Epic", Body: "- [ ] #2\n- [ ] #3", State: "open", CreatedAt: created, UpdatedAt: created},
+		{Number: 2, Title: "First", State: "open", CreatedAt: created, UpdatedAt: created},
+		{Number: 3, Title: "Second", Body: "Depends on #2", State: "open", CreatedAt: created, UpdatedAt: created},
+	}
+	plan, err := planGitHubImport(ctx, s, "o/r", ghIssues)
+	if err != nil {
+		t.Fatalf("planGitHubImport failed: %v", err)
+	}
+	if len(plan.creates) != 3 || len(plan.updates) != 0 {
+		t.Fatalf("expected 3 creates, got %d creates and %d updates", len(plan.creates), len(plan.updates))
+	}
+	result, err := applyGitHubImport(ctx, s, "o/r", ghIssues, plan)
+	if err != nil {
+		t.Fatalf("applyGitHubImport failed: %v", err)
+	}
+	if result.relationships != 3 {
+		t.Errorf("expected 3 relationships, got %d", result.relationships)
+	}
+
+	first, err := s.GetIssueByExternalRef(ctx, "o/r#2")
+	if err != nil || first == nil {
+		t.Fatalf("expected o/r#2 imported, got %v", err)
+	}
+	second, _ := s.GetIssueByExternalRef(ctx, "o/r#3")
+	epic, _ := s.GetIssueByExternalRef(ctx, "o/r#1")
+	deps, _ := s.GetDependencyRecords(ctx, second.ID)
+	var sawParent, sawBlocker bool
+	for _, dep := range deps {
+		sawParent = sawParent || (dep.DependsOnID == epic.ID && dep.Type == types.DepParentChild)
+		sawBlocker = sawBlocker || (dep.DependsOnID == first.ID && dep.Type == types.DepBlocks)
+	}
+	if !sawParent || !sawBlocker {
+		t.Errorf("expected #3 to be a child of #1 blocked by #2, got %+v", deps)
+	}
+
+	// A second pull of the same data changes nothing; a newer GitHub edit updates
+	ghIssues[1].Title = "First, renamed"
+	ghIssues[1].UpdatedAt = time.Now().Add(time.Hour)
+	plan, err = planGitHubImport(ctx, s, "o/r", ghIssues)
+	if err != nil {
+		t.Fatalf("planGitHubImport failed: %v", err)
+	}
+	if len(plan.creates) != 0 || len(plan.updates) != 1 || plan.unchanged != 2 {
+		t.Fatalf("expected 1 update and 2 unchanged, got %d creates, %d updates, %d unchanged", len(plan.creates), len(plan.updates), plan.unchanged)
+	}
+	if _, err := applyGitHubImport(ctx, s, "o/r", ghIssues, plan); err != nil {
+		t.Fatalf("applyGitHubImport failed: %v", err)
+	}
+	if renamed, _ := s.GetIssue(ctx, first.ID); renamed.Title != "First, renamed" {
+		t.Errorf("expected the title updated, got %q", renamed.Title)
+	}
+}
+
+func TestGitHubExportRoundTrip(t *testing.T) {
+	closedAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	original := &types.Issue{
+		ID:                 "bd-1",
+		Title:              "Drop the old API",
+		Description:        "It is unused.\n\n## Notes inside the description",
+		Design:             "Delete handlers",
+		AcceptanceCriteria: "Nothing calls it",
+		Status:             types.StatusClosed,
+		Resolution:         types.ResolutionObsolete,
+		ClosedAt:           &closedAt,
+		Priority:           3,
+		IssueType:          types.TypeChore,
+		Assignee:           "alice",
+		Labels:             []string{"api"},
+		Dependencies: []*types.Dependency{
+			{IssueID: "bd-1", DependsOnID: "bd-2", Type: types.DepBlocks},
+			{IssueID: "bd-1", DependsOnID: "bd-3", Type: types.DepParentChild},
+		},
+	}
+	exported := toGitHubIssue(original, map[string]githubDepRef{"bd-2": {Title: "Migrate (v2) callers", Closed: true}})
+	if exported.StateReason != "not_planned" || exported.BeadsID != "bd-1" {
+		t.Errorf("unexpected state_reason %q or beads_id %q", exported.StateReason, exported.BeadsID)
+	}
+
+	// What GitHub would hand back for the created issue
+	gh := githubAPIIssue{Number: 7, Title: exported.Title, Body: exported.Body, State: exported.State, StateReason: exported.StateReason, ClosedAt: &closedAt}
+	gh.Assignee = &struct {
+		Login string `json:"login"`
+	}{exported.Assignees[0]}
+	for _, label := range exported.Labels {
+		gh.Labels = append(gh.Labels, struct {
+			Name string `json:"name"`
+		}{label})
+	}
+
+	imported := fromGitHubIssue(gh, "o/r")
+	if imported.Title != original.Title || imported.Description != original.Description ||
+		imported.Design != original.Design || imported.AcceptanceCriteria != original.AcceptanceCriteria {
+		t.Errorf("text fields changed in the round trip: %+v", imported)
+	}
+	if imported.Status != original.Status || imported.Resolution != original.Resolution ||
+		imported.Priority != original.Priority || imported.IssueType != original.IssueType || imported.Assignee != original.Assignee {
+		t.Errorf("mapped fields changed in the round trip: %+v", imported)
+	}
+	if fmt.Sprint(imported.Labels) != "[api]" {
+		t.Errorf("labels = %v, want [api]", imported.Labels)
+	}
+
+	body := parseGitHubBody(gh.Body)
+	if body.BeadsID != "bd-1" || len(body.Dependencies) != 2 ||
+		body.Dependencies[0].DependsOnID != "bd-2" || body.Dependencies[0].Type != types.DepBlocks ||
+		body.Dependencies[1].DependsOnID != "bd-3" || body.Dependencies[1].Type != types.DepParentChild {
+		t.Errorf("unexpected parsed body %+v", body)
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"time"
 
 	"github.com/steveyegge/beads/internal/importer"
 	"github.com/steveyegge/beads/internal/storage"
@@ -111,6 +112,70 @@ func (fc *fieldComparator) equalPriority(existing int, newVal interface{}) bool
 	return existing == int(p)
 }
 
+// equalPtrInt compares an optional integer field such as estimated_minutes
+func (fc *fieldComparator) equalPtrInt(existing *int, newVal interface{}) bool {
+	if newVal == nil {
+		return existing == nil
+	}
+	n, ok := fc.intFrom(newVal)
+	if !ok || existing == nil {
+		return false
+	}
+	return int64(*existing) == n
+}
+
+// equalMetadata compares the custom metadata map, treating nil and empty alike
+// equalPtrTime compares an optional timestamp such as archived_at
+func (fc *fieldComparator) equalPtrTime(existing *time.Time, newVal interface{}) bool {
+	incoming, _ := newVal.(*time.Time)
+	if existing == nil || incoming == nil {
+		return existing == nil && incoming == nil
+	}
+	return existing.Equal(*incoming)
+}
+
+// equalAttachments compares attachment references in order, treating nil
+// and empty alike
+func (fc *fieldComparator) equalAttachments(existing []string, newVal interface{}) bool {
+	var incoming []string
+	if newVal != nil {
+		refs, ok := newVal.([]string)
+		if !ok {
+			return false
+		}
+		incoming = refs
+	}
+	if len(existing) != len(incoming) {
+		return false
+	}
+	for i := range incoming {
+		if existing[i] != incoming[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (fc *fieldComparator) equalMetadata(existing map[string]string, newVal interface{}) bool {
+	var incoming map[string]string
+	if newVal != nil {
+		m, ok := newVal.(map[string]string)
+		if !ok {
+			return false
+		}
+		incoming = m
+	}
+	if len(existing) != len(incoming) {
+		return false
+	}
+	for key, value := range incoming {
+		if got, ok := existing[key]; !ok || got != value {
+			return false
+		}
+	}
+	return true
+}
+
 // checkFieldChanged checks if a specific field has changed
 func (fc *fieldComparator) checkFieldChanged(key string, existing *types.Issue, newVal interface{}) bool {
 	switch key {
@@ -134,6 +199,20 @@ func (fc *fieldComparator) checkFieldChanged(key string, existing *types.Issue,
 		return !fc.equalStr(existing.Assignee, newVal)
 	case "external_ref":
 		return !fc.equalPtrStr(existing.ExternalRef, newVal)
+	case "resolution":
+		return !fc.equalStr(string(existing.Resolution), newVal)
+	case "resolved_by":
+		return !fc.equalStr(existing.ResolvedBy, newVal)
+	case "estimated_minutes":
+		return !fc.equalPtrInt(existing.EstimatedMinutes, newVal)
+	case "spent_minutes":
+		return !fc.equalPtrInt(existing.SpentMinutes, newVal)
+	case "metadata":
+		return !fc.equalMetadata(existing.Metadata, newVal)
+	case "attachments":
+		return !fc.equalAttachments(existing.Attachments, newVal)
+	case "archived_at":
+		return !fc.equalPtrTime(existing.ArchivedAt, newVal)
 	default:
 		// Unknown field - treat as changed to be conservative
 		// This prevents skipping updates when new fields are added
@@ -165,6 +244,8 @@ type ImportOptions struct {
 	SkipPrefixValidation       bool   // Skip prefix validation (for auto-import)
 	ClearDuplicateExternalRefs bool   // Clear duplicate external_ref values instead of erroring
 	OrphanHandling             string // Orphan handling mode: strict/resurrect/skip/allow (empty = use config)
+	Merge                      bool   // Upsert keyed on issue ID instead of matching on content
+	MergeStrategy              string // Merge mode winner: ours/theirs/newest (empty = theirs)
 }
 
 // ImportResult contains statistics about the import operation
@@ -218,6 +299,8 @@ func importIssuesCore(ctx context.Context, dbPath string, store storage.Storage,
 		SkipPrefixValidation:       opts.SkipPrefixValidation,
 		ClearDuplicateExternalRefs: opts.ClearDuplicateExternalRefs,
 		OrphanHandling:             importer.OrphanHandling(orphanHandling),
+		Merge:                      opts.Merge,
+		MergeStrategy:              importer.MergeStrategy(opts.MergeStrategy),
 	}
 
 	// Delegate to the importer package
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// maxFailedLinesKept caps the failing line numbers a streaming import keeps,
+// so a file of garbage can't grow memory without bound
+const maxFailedLinesKept = 1000
+
+// maxLineErrorsShown is how many failing lines a streaming import describes
+// on stderr; the rest are only counted
+const maxLineErrorsShown = 10
+
+// streamImportOptions configures streamImport
+type streamImportOptions struct {
+	ImportOptions
+	DBPath        string
+	BatchSize     int       // Records per importIssuesCore call
+	ProgressEvery int       // Print a progress line every this many records
+	Progress      io.Writer // Where progress and line errors go; nil for none
+	TotalBytes    int64     // Input size for the ETA, 0 if unknown
+}
+
+// streamImportResult is the summary of a streaming import
+type streamImportResult struct {
+	Records     int   `json:"records"`
+	Inserted    int   `json:"inserted"`
+	Updated     int   `json:"updated"`
+	Unchanged   int   `json:"unchanged"`
+	Skipped     int   `json:"skipped"`
+	Remapped    int   `json:"remapped,omitempty"`
+	Failed      int   `json:"failed"`
+	FailedLines []int `json:"failed_lines,omitempty"` // The first maxFailedLinesKept
+	// Dependencies whose target was never imported (dropped unless --strict)
+	MissingDependencies int `json:"missing_dependencies,omitempty"`
+}
+
+func (r *streamImportResult) changed() bool {
+	return r.Inserted > 0 || r.Updated > 0 || r.Remapped > 0
+}
+
+// streamImporter reads JSONL a line at a time and imports it in batches, so
+// only one batch of issues is in memory however large the input is
+type streamImporter struct {
+	ctx    context.Context
+	store  storage.Storage
+	opts   streamImportOptions
+	result streamImportResult
+
+	batch          []*types.Issue
+	firstLine      int                 // Line number of batch[0]
+	pendingDeps    []*types.Dependency // Dependencies on issues not imported yet
+	prefixChecked  bool
+	start          time.Time
+	bytesRead      int64
+	nextProgressAt int
+}
+
+// streamImport imports the JSONL issues read from r in batches. Lines that
+// don't parse or validate are skipped and counted as failed, as the lenient
+// JSONL readers do. Dependencies pointing at issues later in the input are
+// held back and added once everything is in.
+func streamImport(ctx context.Context, s storage.Storage, r io.Reader, opts streamImportOptions) (*streamImportResult, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = sqlite.DefaultImportBatchSize
+	}
+	si := &streamImporter{ctx: ctx, store: s, opts: opts, start: time.Now(), nextProgressAt: opts.ProgressEvery}
+
+	reader := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, readErr := reader.ReadBytes('\n')
+		si.bytesRead += int64(len(line))
+		if err := si.addLine(lineNum, line); err != nil {
+			return &si.result, err
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return &si.result, fmt.Errorf("failed to read line %d: %w", lineNum, readErr)
+		}
+	}
+	if err := si.flush(); err != nil {
+		return &si.result, err
+	}
+	if err := si.addPendingDependencies(); err != nil {
+		return &si.result, err
+	}
+	return &si.result, nil
+}
+
+// addLine parses one input line into the current batch, importing the batch
+// once it's full
+func (si *streamImporter) addLine(lineNum int, line []byte) error {
+	trimmed := bytes.TrimSpace(line)
+	if len(trimmed) == 0 {
+		return nil
+	}
+	if bytes.HasPrefix(trimmed, []byte("<<<<<<< ")) ||
+		bytes.Equal(trimmed, []byte("=======")) ||
+		bytes.HasPrefix(trimmed, []byte(">>>>>>> ")) {
+		return fmt.Errorf("git conflict markers at line %d; resolve them first ('bd import' without --stream attempts a 3-way merge)", lineNum)
+	}
+
+	si.result.Records++
+	var issue types.Issue
+	if err := json.Unmarshal(trimmed, &issue); err != nil {
+		si.fail(lineNum, fmt.Errorf("invalid JSON: %w", err))
+		return nil
+	}
+	if err := validateImportIssue(&issue); err != nil {
+		si.fail(lineNum, err)
+		return nil
+	}
+
+	if len(si.batch) == 0 {
+		si.firstLine = lineNum
+	}
+	si.batch = append(si.batch, &issue)
+	if len(si.batch) >= si.opts.BatchSize {
+		return si.flush()
+	}
+	return nil
+}
+
+// fail records a line that can't be imported
+func (si *streamImporter) fail(lineNum int, err error) {
+	si.result.Failed++
+	if len(si.result.FailedLines) < maxFailedLinesKept {
+		si.result.FailedLines = append(si.result.FailedLines, lineNum)
+	}
+	if si.opts.Progress != nil && si.result.Failed <= maxLineErrorsShown {
+		fmt.Fprintf(si.opts.Progress, "%s\n", importLineError{Where: fmt.Sprintf("line %d", lineNum), Err: err}.Error())
+	}
+}
+
+// flush imports the current batch
+func (si *streamImporter) flush() error {
+	if !si.prefixChecked {
+		// The first batch stands in for the whole input when detecting the prefix
+		if err := ensureImportPrefix(si.ctx, si.store, si.batch); err != nil {
+			return err
+		}
+		si.prefixChecked = true
+	}
+	if len(si.batch) == 0 {
+		return nil
+	}
+	if err := si.holdForwardDependencies(); err != nil {
+		return err
+	}
+
+	result, err := importIssuesCore(si.ctx, si.opts.DBPath, si.store, si.batch, si.opts.ImportOptions)
+	if err != nil {
+		return fmt.Errorf("importing lines %d-%d: %w", si.firstLine, si.firstLine+len(si.batch)-1, err)
+	}
+	si.result.Inserted += result.Created
+	si.result.Updated += result.Updated
+	si.result.Unchanged += result.Unchanged
+	si.result.Skipped += result.Skipped
+	si.result.Remapped += len(result.IDMapping)
+
+	si.batch = si.batch[:0]
+	si.reportProgress()
+	return nil
+}
+
+// holdForwardDependencies moves dependencies on issues that are neither in
+// the batch nor already in the database to pendingDeps, so they don't fail
+// (or, with --strict, abort) before their target has been read
+func (si *streamImporter) holdForwardDependencies() error {
+	inBatch := make(map[string]bool, len(si.batch))
+	for _, issue := range si.batch {
+		inBatch[issue.ID] = true
+	}
+	for _, issue := range si.batch {
+		if len(issue.Dependencies) == 0 {
+			continue
+		}
+		kept := issue.Dependencies[:0]
+		for _, dep := range issue.Dependencies {
+			if inBatch[dep.DependsOnID] {
+				kept = append(kept, dep)
+				continue
+			}
+			target, err := si.store.GetIssue(si.ctx, dep.DependsOnID)
+			if err != nil {
+				return fmt.Errorf("checking dependency %s → %s: %w", dep.IssueID, dep.DependsOnID, err)
+			}
+			if target != nil {
+				kept = append(kept, dep)
+			} else {
+				si.pendingDeps = append(si.pendingDeps, dep)
+			}
+		}
+		issue.Dependencies = kept
+	}
+	return nil
+}
+
+// addPendingDependencies adds the held-back dependencies once every issue
+// has been imported
+func (si *streamImporter) addPendingDependencies() error {
+	for _, dep := range si.pendingDeps {
+		existing, err := si.store.GetDependencyRecords(si.ctx, dep.IssueID)
+		if err != nil {
+			return fmt.Errorf("error checking dependencies for %s: %w", dep.IssueID, err)
+		}
+		present := false
+		for _, e := range existing {
+			if e.DependsOnID == dep.DependsOnID && e.Type == dep.Type {
+				present = true
+				break
+			}
+		}
+		if present {
+			continue
+		}
+		if err := si.store.AddDependency(si.ctx, dep, "import"); err != nil {
+			if si.opts.Strict {
+				return fmt.Errorf("error adding dependency %s → %s: %w", dep.IssueID, dep.DependsOnID, err)
+			}
+			si.result.MissingDependencies++
+		}
+	}
+	si.pendingDeps = nil
+	return nil
+}
+
+// reportProgress prints a progress line each time another ProgressEvery
+// records have been imported
+func (si *streamImporter) reportProgress() {
+	if si.opts.Progress == nil || si.opts.ProgressEvery <= 0 || si.result.Records < si.nextProgressAt {
+		return
+	}
+	for si.nextProgressAt <= si.result.Records {
+		si.nextProgressAt += si.opts.ProgressEvery
+	}
+
+	elapsed := time.Since(si.start)
+	rate := float64(si.result.Records) / elapsed.Seconds()
+	line := fmt.Sprintf("Imported %d records (%.0f/s", si.result.Records, rate)
+	if si.opts.TotalBytes > 0 && si.bytesRead > 0 && si.bytesRead <= si.opts.TotalBytes {
+		remaining := time.Duration(float64(elapsed) * float64(si.opts.TotalBytes-si.bytesRead) / float64(si.bytesRead))
+		line += fmt.Sprintf(", %d%%, ETA %s", si.bytesRead*100/si.opts.TotalBytes, remaining.Round(time.Second))
+	}
+	fmt.Fprintf(si.opts.Progress, "%s)\n", line)
+}
+
+// runStreamImport runs 'bd import --stream' on in, which is the file named
+// input or stdin when input is empty, and prints the summary
+func runStreamImport(ctx context.Context, in *os.File, input string, importOpts ImportOptions, progressEvery int, skipInvalid bool) {
+	opts := streamImportOptions{
+		ImportOptions: importOpts,
+		DBPath:        dbPath,
+		BatchSize:     sqlite.DefaultImportBatchSize,
+		ProgressEvery: progressEvery,
+	}
+	if sqliteStore, ok := store.(*sqlite.SQLiteStorage); ok {
+		opts.BatchSize = sqliteStore.GetImportBatchSize(ctx)
+	}
+	if !jsonOutput {
+		opts.Progress = os.Stderr
+	}
+	if info, err := in.Stat(); err == nil && info.Mode().IsRegular() {
+		opts.TotalBytes = info.Size()
+	}
+
+	result, err := streamImport(ctx, store, in, opts)
+	if result.changed() {
+		// Flush even after a failure: the batches before it are committed
+		flushToJSONL()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Import failed: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Committed before the failure: %d inserted, %d updated\n", result.Inserted, result.Updated)
+		os.Exit(1)
+	}
+	if err := touchDatabaseFile(dbPath, input); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to update database mtime: %v\n", err)
+	}
+
+	if jsonOutput {
+		outputJSON(result)
+	} else {
+		fmt.Fprintf(os.Stderr, "Import complete: %d inserted, %d updated, %d unchanged, %d skipped, %d failed",
+			result.Inserted, result.Updated, result.Unchanged, result.Skipped, result.Failed)
+		if result.Remapped > 0 {
+			fmt.Fprintf(os.Stderr, ", %d issues remapped", result.Remapped)
+		}
+		fmt.Fprintf(os.Stderr, "\n")
+		if result.Failed > 0 {
+			fmt.Fprintf(os.Stderr, "Failed lines: %s\n", formatLineNumbers(result.FailedLines))
+		}
+		if result.MissingDependencies > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: %d dependencies point at issues that don't exist and were not added\n", result.MissingDependencies)
+		}
+	}
+	if result.Failed > 0 && !skipInvalid {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStreamImport(t *testing.T) {
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	ctx := context.Background()
+
+	input := strings.Join([]string{
+		`{"id":"test-1","title":"First","status":"open","priority":2,"issue_type":"task"}`,
+		// test-2 depends on test-4, which is two batches later
+		`{"id":"test-2","title":"Second","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"test-2","depends_on_id":"test-4","type":"blocks"}]}`,
+		`{"id":"test-3","title":"Cut sh`,
+		``,
+		`{"id":"test-3","title":"Third","status":"bogus","priority":2,"issue_type":"task"}`,
+		`{"id":"test-4","title":"Fourth","status":"open","priority":1,"issue_type":"bug"}`,
+		`{"id":"test-1","title":"First, renamed","status":"open","priority":2,"issue_type":"task","updated_at":"2099-01-01T00:00:00Z"}`,
+		`{"id":"test-5","title":"Fifth","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"test-5","depends_on_id":"test-99","type":"blocks"}]}`,
+	}, "\n")
+
+	var progress bytes.Buffer
+	result, err := streamImport(ctx, s, strings.NewReader(input), streamImportOptions{
+		BatchSize:     2,
+		ProgressEvery: 3,
+		Progress:      &progress,
+		TotalBytes:    int64(len(input)),
+	})
+	if err != nil {
+		t.Fatalf("streamImport failed: %v", err)
+	}
+
+	if result.Records != 7 || result.Inserted != 4 || result.Updated != 1 || result.Failed != 2 {
+		t.Errorf("Expected 7 records, 4 inserted, 1 updated, 2 failed, got %+v", result)
+	}
+	if len(result.FailedLines) != 2 || result.FailedLines[0] != 3 || result.FailedLines[1] != 5 {
+		t.Errorf("Expected failed lines [3 5], got %v", result.FailedLines)
+	}
+	if result.MissingDependencies != 1 {
+		t.Errorf("Expected the dependency on test-99 to be reported missing, got %d", result.MissingDependencies)
+	}
+
+	deps, err := s.GetDependencyRecords(ctx, "test-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != "test-4" {
+		t.Errorf("Expected the forward dependency test-2 → test-4, got %+v", deps)
+	}
+	issue, err := s.GetIssue(ctx, "test-1")
+	if err != nil || issue == nil || issue.Title != "First, renamed" {
+		t.Errorf("Expected test-1 updated by its later record, got %+v (%v)", issue, err)
+	}
+
+	out := progress.String()
+	for _, want := range []string{"line 3: invalid JSON", "line 5: invalid status", "Imported 6 records", "ETA"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected progress output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestStreamImportConflictMarkers(t *testing.T) {
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	input := "<<<<<<< HEAD\n" + `{"id":"test-1","title":"First","status":"open","priority":2,"issue_type":"task"}` + "\n"
+	if _, err := streamImport(context.Background(), s, strings.NewReader(input), streamImportOptions{}); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("Expected a conflict marker error at line 1, got %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// importLineError is an import record that can't be imported. Where is
+// "line N" for JSONL and "issue N" for YAML input.
+type importLineError struct {
+	Where string
+	Err   error
+}
+
+func (e importLineError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Where, e.Err)
+}
+
+// validateImportIssue checks a parsed issue against what the database
+// accepts, so a bad record is reported by line before anything is written
+func validateImportIssue(issue *types.Issue) error {
+	if strings.TrimSpace(issue.ID) == "" {
+		return fmt.Errorf("id is required")
+	}
+	if !issue.Status.IsValid() {
+		return fmt.Errorf("invalid status %q (expected open, in_progress, blocked or closed)", issue.Status)
+	}
+	if !issue.IssueType.IsValid() {
+		return fmt.Errorf("invalid issue_type %q (expected bug, feature, task, epic or chore)", issue.IssueType)
+	}
+	if issue.Priority < 0 || issue.Priority > 4 {
+		return fmt.Errorf("invalid priority %d (expected 0-4)", issue.Priority)
+	}
+	return issue.Validate()
+}
+
+// reportImportLineErrors prints invalid input lines and, unless skipInvalid
+// is set, aborts the import
+func reportImportLineErrors(lineErrors []importLineError, skipInvalid bool) {
+	if len(lineErrors) == 0 {
+		return
+	}
+	for _, e := range lineErrors {
+		fmt.Fprintf(os.Stderr, "%s\n", e.Error())
+	}
+	if !skipInvalid {
+		fmt.Fprintf(os.Stderr, "Error: %d invalid issue(s) in input, nothing imported (use --skip-invalid to import the rest)\n", len(lineErrors))
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "Warning: skipped %d invalid issue(s)\n", len(lineErrors))
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestValidateImportIssue(t *testing.T) {
+	closedAt := time.Now()
+	valid := types.Issue{ID: "bd-1", Title: "Fix", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+
+	tests := []struct {
+		name    string
+		mutate  func(*types.Issue)
+		wantErr string
+	}{
+		{"valid", func(*types.Issue) {}, ""},
+		{"missing id", func(i *types.Issue) { i.ID = "" }, "id is required"},
+		{"missing title", func(i *types.Issue) { i.Title = "" }, "title is required"},
+		{"bad status", func(i *types.Issue) { i.Status = "done" }, `invalid status "done"`},
+		{"bad type", func(i *types.Issue) { i.IssueType = "story" }, `invalid issue_type "story"`},
+		{"bad priority", func(i *types.Issue) { i.Priority = 7 }, "invalid priority 7"},
+		{"closed without closed_at", func(i *types.Issue) { i.Status = types.StatusClosed }, "closed_at"},
+		{"closed", func(i *types.Issue) { i.Status = types.StatusClosed; i.ClosedAt = &closedAt }, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := valid
+			tt.mutate(&issue)
+			err := validateImportIssue(&issue)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -58,6 +58,10 @@ With --no-db: creates .beads/ directory and issues.jsonl file instead of SQLite
 			prefix = config.GetString("issue-prefix")
 		}
 
+		// Prefixes read from existing issues are kept as they are, even if
+		// they predate prefix validation
+		validate := prefix != ""
+
 		// auto-detect prefix from first issue in JSONL file
 		if prefix == "" {
 			issueCount, jsonlPath := checkGitForIssues()
@@ -77,12 +81,18 @@ With --no-db: creates .beads/ directory and issues.jsonl file instead of SQLite
 				fmt.Fprintf(os.Stderr, "Error: failed to get current directory: %v\n", err)
 				os.Exit(1)
 			}
-			prefix = filepath.Base(cwd)
+			prefix = prefixFromDirName(filepath.Base(cwd))
 		}
 
 		// Normalize prefix: strip trailing hyphens
 		// The hyphen is added automatically during ID generation
 		prefix = strings.TrimRight(prefix, "-")
+		if validate {
+			if err := types.ValidateIssuePrefix(prefix); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
 
 		// Create database
 		// Use global dbPath if set via --db flag or BEADS_DB env var, otherwise default to .beads/beads.db
@@ -887,6 +897,22 @@ func migrateOldDatabases(targetPath string, quiet bool) error {
 	return nil
 }
 
+// prefixFromDirName derives a valid issue prefix from a directory name by
+// lowercasing it and dropping everything but letters and digits, along with
+// leading digits ("My-Project" → "myproject"). Falls back to "bd".
+func prefixFromDirName(name string) string {
+	var b strings.Builder
+	for _, c := range strings.ToLower(name) {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9' && b.Len() > 0) {
+			b.WriteRune(c)
+		}
+	}
+	if b.Len() == 0 {
+		return "bd"
+	}
+	return b.String()
+}
+
 // createConfigYaml creates the config.yaml template in the specified directory
 func createConfigYaml(beadsDir string, noDbMode bool) error {
 	configYamlPath := filepath.Join(beadsDir, "config.yaml")
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
 )
 
 func TestInitCommand(t *testing.T) {
@@ -167,7 +169,7 @@ func TestInitCommand(t *testing.T) {
 
 			expectedPrefix := tt.prefix
 			if expectedPrefix == "" {
-				expectedPrefix = filepath.Base(tmpDir)
+				expectedPrefix = prefixFromDirName(filepath.Base(tmpDir))
 			} else {
 				expectedPrefix = strings.TrimRight(expectedPrefix, "-")
 			}
@@ -879,3 +881,22 @@ func TestReadFirstIssueFromJSONL_EmptyFile(t *testing.T) {
 		t.Errorf("Expected nil issue for empty file, got %+v", issue)
 	}
 }
+
+func TestPrefixFromDirName(t *testing.T) {
+	tests := map[string]string{
+		"beads":      "beads",
+		"My-Project": "myproject",
+		"2024_notes": "notes",
+		"001":        "bd",
+		"v1.2":       "v12",
+	}
+	for name, want := range tests {
+		got := prefixFromDirName(name)
+		if got != want {
+			t.Errorf("prefixFromDirName(%q) = %q, want %q", name, got, want)
+		}
+		if err := types.ValidateIssuePrefix(got); err != nil {
+			t.Errorf("prefixFromDirName(%q) gave an invalid prefix: %v", name, err)
+		}
+	}
+}
@@ -38,7 +38,11 @@ func validatePreExport(ctx context.Context, store storage.Storage, jsonlPath str
 	// Get JSONL issue count
 	jsonlCount := 0
 	if jsonlStatErr == nil {
-		jsonlCount, err = countIssuesInJSONL(jsonlPath)
+		var badLines []int
+		jsonlCount, badLines, err = countIssuesInJSONLLenient(jsonlPath)
+		if err == nil && len(badLines) > 0 {
+			err = fmt.Errorf("%d malformed line(s) (line %s)", len(badLines), formatLineNumbers(badLines))
+		}
 		if err != nil {
 			// Conservative: if JSONL exists with content but we can't count it,
 			// and DB is empty, refuse to export (potential data loss)
@@ -46,7 +50,7 @@ func validatePreExport(ctx context.Context, store storage.Storage, jsonlPath str
 				return fmt.Errorf("refusing to export empty DB over existing JSONL whose contents couldn't be verified: %w", err)
 			}
 			// Warning for other cases
-			fmt.Fprintf(os.Stderr, "WARNING: Failed to count issues in JSONL: %v\n", err)
+			fmt.Fprintf(os.Stderr, "WARNING: JSONL is partially corrupted or unreadable: %v\n", err)
 		}
 	}
 
@@ -217,7 +221,7 @@ func countDBIssuesFast(ctx context.Context, store storage.Storage) (int, error)
 	}
 
 	// Fallback: load all issues and count them (slow but always works)
-	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 	if err != nil {
 		return 0, fmt.Errorf("failed to count database issues: %w", err)
 	}
@@ -256,11 +260,19 @@ func dbNeedsExport(ctx context.Context, store storage.Storage, jsonlPath string)
 		return false, fmt.Errorf("failed to count database issues: %w", err)
 	}
 
-	jsonlCount, err := countIssuesInJSONL(jsonlPath)
+	jsonlCount, badLines, err := countIssuesInJSONLLenient(jsonlPath)
 	if err != nil {
 		return false, fmt.Errorf("failed to count JSONL issues: %w", err)
 	}
 
+	// A partially-corrupted JSONL (e.g. from an interrupted write) is out of
+	// sync by definition; re-exporting from the database repairs it
+	if len(badLines) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %s has %d malformed line(s) (line %s); re-exporting from database\n",
+			jsonlPath, len(badLines), formatLineNumbers(badLines))
+		return true, nil
+	}
+
 	// If counts don't match, we need to export
 	if dbCount != jsonlCount {
 		return true, nil
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/merge"
+)
+
+// resolveJSONLMergeConflict finishes a git pull that stopped on conflicts in
+// jsonlPath alone: it merges the base, ours and theirs versions with
+// merge.MergeJSONL, stages the result and concludes the merge (or continues the
+// rebase). Returns false without changing anything if the pull stopped for
+// any other reason.
+func resolveJSONLMergeConflict(ctx context.Context, jsonlPath string) (bool, error) {
+	// A rebase replays one commit at a time and can stop on each of them
+	for attempt := 0; attempt < 100; attempt++ {
+		unmerged, err := gitUnmergedPaths(ctx)
+		if err != nil {
+			return false, err
+		}
+		if len(unmerged) == 0 {
+			return attempt > 0, nil
+		}
+		if len(unmerged) != 1 || !sameGitPath(ctx, unmerged[0], jsonlPath) {
+			return false, nil
+		}
+
+		stages, err := gitConflictStages(ctx, jsonlPath)
+		if err != nil {
+			return false, err
+		}
+		if stages[2] == nil || stages[3] == nil {
+			return false, nil // Deleted on one side; not a content conflict
+		}
+
+		merged, notes, err := merge.MergeJSONL(stages[1], stages[2], stages[3])
+		if err != nil {
+			return false, fmt.Errorf("failed to merge %s: %w", filepath.Base(jsonlPath), err)
+		}
+		for _, note := range notes {
+			fmt.Fprintf(os.Stderr, "  merge: %s\n", note)
+		}
+		if err := writeFileAtomic(jsonlPath, 0600, func(w io.Writer) error {
+			_, err := w.Write(merged)
+			return err
+		}); err != nil {
+			return false, err
+		}
+		if output, err := exec.CommandContext(ctx, "git", "add", jsonlPath).CombinedOutput(); err != nil {
+			return false, fmt.Errorf("git add failed: %w\n%s", err, output)
+		}
+
+		// Conclude whichever operation the pull left in progress, signed
+		// like bd sync's own commits
+		signArgs, err := syncCommitSigningArgs(ctx)
+		if err != nil {
+			return false, err
+		}
+		var cmd *exec.Cmd
+		if exec.CommandContext(ctx, "git", "rev-parse", "-q", "--verify", "MERGE_HEAD").Run() == nil {
+			cmd = exec.CommandContext(ctx, "git", append(append([]string{"commit"}, signArgs...), "--no-edit")...)
+		} else if gitRebaseInProgress(ctx) {
+			args := append(signingConfigArgs(signArgs), "-c", "core.editor=true", "rebase", "--continue")
+			cmd = exec.CommandContext(ctx, "git", args...)
+		} else {
+			return true, nil
+		}
+		if output, err := cmd.CombinedOutput(); err != nil && !gitRebaseInProgress(ctx) {
+			if signErr := syncSigningError(err, output, signArgs); signErr != nil {
+				return false, signErr
+			}
+			return false, fmt.Errorf("failed to conclude merge: %w\n%s", err, output)
+		}
+	}
+	return false, fmt.Errorf("gave up resolving %s after repeated conflicts", filepath.Base(jsonlPath))
+}
+
+// gitUnmergedPaths lists paths with unresolved conflicts, relative to the
+// repository root
+func gitUnmergedPaths(ctx context.Context) ([]string, error) {
+	output, err := exec.CommandContext(ctx, "git", "diff", "--name-only", "--diff-filter=U", "-z").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unmerged paths: %w", err)
+	}
+	var paths []string
+	for _, p := range strings.Split(string(output), "\x00") {
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
+
+// sameGitPath reports whether repoRelPath (relative to the repository root)
+// names the file at path
+func sameGitPath(ctx context.Context, repoRelPath, path string) bool {
+	output, err := exec.CommandContext(ctx, "git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return false
+	}
+	a, errA := filepath.EvalSymlinks(filepath.Join(strings.TrimSpace(string(output)), repoRelPath))
+	b, errB := filepath.EvalSymlinks(path)
+	return errA == nil && errB == nil && a == b
+}
+
+// gitConflictStages reads the base (1), ours (2) and theirs (3) versions of
+// a conflicted file from the index. Missing stages are nil.
+func gitConflictStages(ctx context.Context, path string) (map[int][]byte, error) {
+	output, err := exec.CommandContext(ctx, "git", "ls-files", "-u", "-z", "--", path).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read conflict stages: %w", err)
+	}
+	stages := make(map[int][]byte)
+	for _, entry := range strings.Split(string(output), "\x00") {
+		// <mode> <object> <stage>\t<path>
+		meta, _, ok := strings.Cut(entry, "\t")
+		parts := strings.Fields(meta)
+		if !ok || len(parts) != 3 {
+			continue
+		}
+		var stage int
+		if _, err := fmt.Sscanf(parts[2], "%d", &stage); err != nil {
+			continue
+		}
+		blob, err := exec.CommandContext(ctx, "git", "cat-file", "blob", parts[1]).Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stage %d of %s: %w", stage, path, err)
+		}
+		stages[stage] = blob
+	}
+	return stages, nil
+}
+
+// gitRebaseInProgress reports whether a rebase is stopped mid-way
+func gitRebaseInProgress(ctx context.Context) bool {
+	for _, dir := range []string{"rebase-merge", "rebase-apply"} {
+		output, err := exec.CommandContext(ctx, "git", "rev-parse", "--git-path", dir).Output()
+		if err != nil {
+			continue
+		}
+		if info, err := os.Stat(strings.TrimSpace(string(output))); err == nil && info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
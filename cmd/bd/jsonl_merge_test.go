@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+var mergeT0 = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// mergeTestIssue returns an issue last updated minutes after mergeT0
+func mergeTestIssue(id, title string, minutes int) *types.Issue {
+	return &types.Issue{
+		ID:        id,
+		Title:     title,
+		Status:    types.StatusOpen,
+		Priority:  2,
+		IssueType: types.TypeTask,
+		CreatedAt: mergeT0,
+		UpdatedAt: mergeT0.Add(time.Duration(minutes) * time.Minute),
+	}
+}
+
+func toJSONL(t *testing.T, issues ...*types.Issue) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, issue := range issues {
+		if err := encoder.Encode(issue); err != nil {
+			t.Fatalf("failed to encode %s: %v", issue.ID, err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func fromJSONL(t *testing.T, data []byte) map[string]*types.Issue {
+	t.Helper()
+	issues := make(map[string]*types.Issue)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		var issue types.Issue
+		if err := json.Unmarshal([]byte(line), &issue); err != nil {
+			t.Fatalf("merged output has invalid line %q: %v", line, err)
+		}
+		issues[issue.ID] = &issue
+	}
+	return issues
+}
+
+func TestResolveJSONLMergeConflict(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(tmpDir)
+
+	git := func(args ...string) {
+		t.Helper()
+		if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	git("init", "-q", "-b", "main")
+	git("config", "user.email", "test@test.com")
+	git("config", "user.name", "Test User")
+	git("config", "pull.rebase", "false")
+
+	jsonlPath := filepath.Join(tmpDir, "issues.jsonl")
+	write := func(issues ...*types.Issue) {
+		t.Helper()
+		if err := os.WriteFile(jsonlPath, toJSONL(t, issues...), 0644); err != nil {
+			t.Fatalf("failed to write JSONL: %v", err)
+		}
+	}
+	write(mergeTestIssue("bd-1", "Original", 0))
+	git("add", "issues.jsonl")
+	git("commit", "-q", "-m", "base")
+
+	git("checkout", "-q", "-b", "other")
+	write(mergeTestIssue("bd-1", "Their title", 5))
+	git("commit", "-q", "-am", "theirs")
+
+	git("checkout", "-q", "main")
+	ourIssue := mergeTestIssue("bd-1", "Original", 3)
+	ourIssue.Assignee = "alice"
+	write(ourIssue)
+	git("commit", "-q", "-am", "ours")
+
+	if err := exec.Command("git", "merge", "other").Run(); err == nil {
+		t.Fatal("expected the merge to conflict")
+	}
+
+	resolved, err := resolveJSONLMergeConflict(ctx, jsonlPath)
+	if err != nil {
+		t.Fatalf("resolveJSONLMergeConflict failed: %v", err)
+	}
+	if !resolved {
+		t.Fatal("expected the conflict to be resolved")
+	}
+
+	data, err := os.ReadFile(jsonlPath)
+	if err != nil {
+		t.Fatalf("failed to read JSONL: %v", err)
+	}
+	issue := fromJSONL(t, data)["bd-1"]
+	if issue.Title != "Their title" || issue.Assignee != "alice" {
+		t.Errorf("expected both edits, got title %q assignee %q", issue.Title, issue.Assignee)
+	}
+	if paths, err := gitUnmergedPaths(ctx); err != nil || len(paths) > 0 {
+		t.Errorf("expected the merge to be concluded (unmerged=%v, err=%v)", paths, err)
+	}
+	if err := exec.Command("git", "rev-parse", "-q", "--verify", "MERGE_HEAD").Run(); err == nil {
+		t.Error("expected the merge commit to be created")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 	"github.com/steveyegge/beads/internal/utils"
 )
 var labelCmd = &cobra.Command{
@@ -18,36 +19,38 @@ var labelCmd = &cobra.Command{
 	Short: "Manage issue labels",
 }
 // Helper function to process label operations for multiple issues
-func processBatchLabelOperation(issueIDs []string, label string, operation string, jsonOut bool,
+func processBatchLabelOperation(issueIDs []string, labels []string, operation string, jsonOut bool,
 	daemonFunc func(string, string) error, storeFunc func(context.Context, string, string, string) error) {
 	ctx := context.Background()
 	results := []map[string]interface{}{}
 	for _, issueID := range issueIDs {
-		var err error
-		if daemonClient != nil {
-			err = daemonFunc(issueID, label)
-		} else {
-			err = storeFunc(ctx, issueID, label, actor)
-		}
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error %s label %s %s: %v\n", operation, operation, issueID, err)
-			continue
-		}
-		if jsonOut {
-			results = append(results, map[string]interface{}{
-				"status":   operation,
-				"issue_id": issueID,
-				"label":    label,
-			})
-		} else {
-			green := color.New(color.FgGreen).SprintFunc()
-			verb := "Added"
-			prep := "to"
-			if operation == "removed" {
-				verb = "Removed"
-				prep = "from"
+		for _, label := range labels {
+			var err error
+			if daemonClient != nil {
+				err = daemonFunc(issueID, label)
+			} else {
+				err = storeFunc(ctx, issueID, label, actor)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error %s label %s %s: %v\n", operation, label, issueID, err)
+				continue
+			}
+			if jsonOut {
+				results = append(results, map[string]interface{}{
+					"status":   operation,
+					"issue_id": issueID,
+					"label":    label,
+				})
+			} else {
+				green := color.New(color.FgGreen).SprintFunc()
+				verb := "Added"
+				prep := "to"
+				if operation == "removed" {
+					verb = "Removed"
+					prep = "from"
+				}
+				fmt.Printf("%s %s label '%s' %s %s\n", green("✓"), verb, label, prep, issueID)
 			}
-			fmt.Printf("%s %s label '%s' %s %s\n", green("✓"), verb, label, prep, issueID)
 		}
 	}
 	if len(issueIDs) > 0 && daemonClient == nil {
@@ -57,47 +60,76 @@ func processBatchLabelOperation(issueIDs []string, label string, operation strin
 		outputJSON(results)
 	}
 }
-func parseLabelArgs(args []string) (issueIDs []string, label string) {
-	label = args[len(args)-1]
-	issueIDs = args[:len(args)-1]
-	return
+// parseLabelArgs splits "<id> [<id>...] <label> [<label>...]" into issue IDs
+// and normalized labels. The first argument is always an issue (partial IDs
+// are resolved). Following arguments are further issues while they name an
+// existing issue exactly; the last argument is always a label.
+func parseLabelArgs(ctx context.Context, args []string) (issueIDs []string, labels []string, err error) {
+	firstID, err := resolveLabelIssueID(ctx, args[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolving %s: %w", args[0], err)
+	}
+	issueIDs = []string{firstID}
+	rest := args[1:]
+	for len(rest) > 1 && labelArgIsIssue(ctx, rest[0]) {
+		issueIDs = append(issueIDs, rest[0])
+		rest = rest[1:]
+	}
+	for _, label := range rest {
+		labels = append(labels, util.NormalizeLabel(label))
+	}
+	labels = util.NormalizeLabels(labels)
+	if len(labels) == 0 {
+		return nil, nil, fmt.Errorf("no labels given")
+	}
+	return issueIDs, labels, nil
+}
+// resolveLabelIssueID resolves a partial issue ID through the daemon or store
+func resolveLabelIssueID(ctx context.Context, id string) (string, error) {
+	if daemonClient == nil {
+		return utils.ResolvePartialID(ctx, store, id)
+	}
+	resp, err := daemonClient.ResolveID(&rpc.ResolveIDArgs{ID: id})
+	if err != nil {
+		return "", err
+	}
+	var fullID string
+	if err := json.Unmarshal(resp.Data, &fullID); err != nil {
+		return "", fmt.Errorf("unmarshaling resolved ID: %w", err)
+	}
+	return fullID, nil
+}
+// labelArgIsIssue reports whether arg is the full ID of an existing issue
+func labelArgIsIssue(ctx context.Context, arg string) bool {
+	if daemonClient != nil {
+		_, err := daemonClient.Show(&rpc.ShowArgs{ID: arg})
+		return err == nil
+	}
+	issue, err := store.GetIssue(ctx, arg)
+	return err == nil && issue != nil
 }
 //nolint:dupl // labelAddCmd and labelRemoveCmd are similar but serve different operations
 var labelAddCmd = &cobra.Command{
-	Use:   "add [issue-id...] [label]",
-	Short: "Add a label to one or more issues",
-	Args:  cobra.MinimumNArgs(2),
+	Use:   "add [issue-id...] [label...]",
+	Short: "Add labels to one or more issues",
+	Long: `Add one or more labels to one or more issues.
+
+The first argument is an issue ID; following arguments are more issues as
+long as they are full IDs of existing issues, and the rest are labels.
+Labels are trimmed and lowercased, so "Bug" and "bug" are the same label.
+
+Examples:
+  bd label add bd-a1b2 bug ui
+  bd label add bd-a1b2 bd-c3d4 urgent`,
+	Args: cobra.MinimumNArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		// Use global jsonOutput set by PersistentPreRun
-		issueIDs, label := parseLabelArgs(args)
-		// Resolve partial IDs
-		ctx := context.Background()
-		resolvedIDs := make([]string, 0, len(issueIDs))
-		for _, id := range issueIDs {
-			var fullID string
-			var err error
-			if daemonClient != nil {
-				resolveArgs := &rpc.ResolveIDArgs{ID: id}
-				resp, err := daemonClient.ResolveID(resolveArgs)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", id, err)
-					continue
-				}
-				if err := json.Unmarshal(resp.Data, &fullID); err != nil {
-					fmt.Fprintf(os.Stderr, "Error unmarshaling resolved ID: %v\n", err)
-					continue
-				}
-			} else {
-				fullID, err = utils.ResolvePartialID(ctx, store, id)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", id, err)
-					continue
-				}
-			}
-			resolvedIDs = append(resolvedIDs, fullID)
+		issueIDs, labels, err := parseLabelArgs(context.Background(), args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
-		issueIDs = resolvedIDs
-		processBatchLabelOperation(issueIDs, label, "added", jsonOutput,
+		processBatchLabelOperation(issueIDs, labels, "added", jsonOutput,
 			func(issueID, lbl string) error {
 				_, err := daemonClient.AddLabel(&rpc.LabelAddArgs{ID: issueID, Label: lbl})
 				return err
@@ -109,40 +141,25 @@ var labelAddCmd = &cobra.Command{
 }
 //nolint:dupl // labelRemoveCmd and labelAddCmd are similar but serve different operations
 var labelRemoveCmd = &cobra.Command{
-	Use:   "remove [issue-id...] [label]",
-	Short: "Remove a label from one or more issues",
-	Args:  cobra.MinimumNArgs(2),
+	Use:     "remove [issue-id...] [label...]",
+	Aliases: []string{"rm"},
+	Short:   "Remove labels from one or more issues",
+	Long: `Remove one or more labels from one or more issues.
+
+Arguments are read as for 'bd label add'. Labels match regardless of case.
+
+Examples:
+  bd label rm bd-a1b2 bug ui
+  bd label rm bd-a1b2 bd-c3d4 urgent`,
+	Args: cobra.MinimumNArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		// Use global jsonOutput set by PersistentPreRun
-		issueIDs, label := parseLabelArgs(args)
-		// Resolve partial IDs
-		ctx := context.Background()
-		resolvedIDs := make([]string, 0, len(issueIDs))
-		for _, id := range issueIDs {
-			var fullID string
-			var err error
-			if daemonClient != nil {
-				resolveArgs := &rpc.ResolveIDArgs{ID: id}
-				resp, err := daemonClient.ResolveID(resolveArgs)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", id, err)
-					continue
-				}
-				if err := json.Unmarshal(resp.Data, &fullID); err != nil {
-					fmt.Fprintf(os.Stderr, "Error unmarshaling resolved ID: %v\n", err)
-					continue
-				}
-			} else {
-				fullID, err = utils.ResolvePartialID(ctx, store, id)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", id, err)
-					continue
-				}
-			}
-			resolvedIDs = append(resolvedIDs, fullID)
+		issueIDs, labels, err := parseLabelArgs(context.Background(), args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
-		issueIDs = resolvedIDs
-		processBatchLabelOperation(issueIDs, label, "removed", jsonOutput,
+		processBatchLabelOperation(issueIDs, labels, "removed", jsonOutput,
 			func(issueID, lbl string) error {
 				_, err := daemonClient.RemoveLabel(&rpc.LabelRemoveArgs{ID: issueID, Label: lbl})
 				return err
@@ -153,32 +170,24 @@ var labelRemoveCmd = &cobra.Command{
 	},
 }
 var labelListCmd = &cobra.Command{
-	Use:   "list [issue-id]",
-	Short: "List labels for an issue",
-	Args:  cobra.ExactArgs(1),
+	Use:     "list [issue-id]",
+	Aliases: []string{"ls"},
+	Short:   "List labels for an issue, or all labels",
+	Long: `List the labels on an issue. Without an issue ID, list every label in
+use with the number of issues carrying it (same as 'bd label list-all').`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			labelListAllCmd.Run(cmd, args)
+			return
+		}
 		// Use global jsonOutput set by PersistentPreRun
 		ctx := context.Background()
 		// Resolve partial ID first
-		var issueID string
-		if daemonClient != nil {
-			resolveArgs := &rpc.ResolveIDArgs{ID: args[0]}
-			resp, err := daemonClient.ResolveID(resolveArgs)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error resolving issue ID %s: %v\n", args[0], err)
-				os.Exit(1)
-			}
-			if err := json.Unmarshal(resp.Data, &issueID); err != nil {
-				fmt.Fprintf(os.Stderr, "Error unmarshaling resolved ID: %v\n", err)
-				os.Exit(1)
-			}
-		} else {
-			var err error
-			issueID, err = utils.ResolvePartialID(ctx, store, args[0])
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", args[0], err)
-				os.Exit(1)
-			}
+		issueID, err := resolveLabelIssueID(ctx, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", args[0], err)
+			os.Exit(1)
 		}
 		var labels []string
 		// Use daemon if available
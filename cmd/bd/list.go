@@ -5,7 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
@@ -15,10 +17,16 @@ import (
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/util"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
-// parseTimeFlag parses time strings in multiple formats
+// parseTimeFlag parses time strings in multiple formats, or a duration
+// before now such as 36h, 7d or 2w
 func parseTimeFlag(s string) (time.Time, error) {
+	if d, ok := parseRelativeDuration(s); ok {
+		return time.Now().Add(-d), nil
+	}
+
 	formats := []string{
 		time.RFC3339,
 		"2006-01-02",
@@ -32,23 +40,103 @@ func parseTimeFlag(s string) (time.Time, error) {
 		}
 	}
 	
-	return time.Time{}, fmt.Errorf("unable to parse time %q (try formats: 2006-01-02, 2006-01-02T15:04:05, or RFC3339)", s)
+	return time.Time{}, fmt.Errorf("unable to parse time %q (try formats: 2006-01-02, 2006-01-02T15:04:05, RFC3339, or a duration like 7d)", s)
+}
+
+// parseRelativeDuration parses N followed by h (hours), d (days) or w (weeks)
+func parseRelativeDuration(s string) (time.Duration, bool) {
+	if len(s) < 2 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	switch s[len(s)-1] {
+	case 'h':
+		return time.Duration(n) * time.Hour, true
+	case 'd':
+		return time.Duration(n) * 24 * time.Hour, true
+	case 'w':
+		return time.Duration(n) * 7 * 24 * time.Hour, true
+	}
+	return 0, false
 }
 
 var listCmd = &cobra.Command{
-	Use:   "list",
+	Use:   "list [query]",
 	Short: "List issues",
+	Long: `List issues matching the given filters.
+
+All filters combine with AND: an issue must match every filter given.
+
+--mine is shorthand for "--assignee <you>" restricted to issues that are not
+closed, where <you> is the actor resolved from --actor, BD_ACTOR (or the
+actor config key), then $USER. It combines with other filters like any other
+flag, and an explicit --status overrides the not-closed default.
+
+--ready and --blocked filter by dependency state, like 'bd ready' and
+'bd blocked' but combinable with every other filter: --ready keeps open and
+in-progress issues with no open blocker (including a blocked parent), and
+--blocked keeps unclosed issues with at least one open blocker. They can't
+be used together.
+
+--sort orders the matches by comma-separated field[:asc|desc] keys, from
+priority, created, updated, status (open, in_progress, blocked, closed) and
+id. Direction defaults to asc. Without --sort, issues are listed by priority,
+newest first; ties always break by ID.
+
+A query argument searches issue titles, descriptions and IDs like
+'bd search', including its field:value terms: title:, description:,
+status:, type:, assignee: and label: (repeatable; all must match). Field
+terms combine with the flags, so they can't contradict them.
+
+--limit and --offset page through the matches, which are always in the same
+order, and a "showing X–Y of N" line follows the page. JSON output is just
+the page.
+
+--count prints only how many issues match (or {"count": N} with --json),
+counted in the database without reading the issues. Every filter applies
+as it would to the list.
+
+--format ndjson-stream writes each matching issue as one line of JSON (the
+issue with its labels, as in the JSONL export) as soon as it is read, so
+memory stays flat over any number of issues. It always reads the database
+directly. If the stream breaks partway, an error goes to stderr and bd exits
+1; a complete stream exits 0.
+
+Examples:
+  bd list --mine                 # My open, in-progress, and blocked issues
+  bd list --mine --type bug      # My unfinished bugs
+  bd list --mine --status closed # Issues I closed
+  bd list --ready --label backend --assignee alice
+  bd list --blocked --type bug   # Bugs waiting on other work
+  bd list --closed-after 14d     # Closed in the last two weeks
+  bd list --created-before 2025-01-01 --status open
+  bd list --limit 50 --offset 50 # The second page of 50
+  bd list --sort status,updated:desc
+  bd list --format ndjson-stream | jq -r 'select(.priority < 2) | .id'
+  bd list --ready --label backend --count
+  bd list "title:login status:open label:urgent"`,
 	Run: func(cmd *cobra.Command, args []string) {
 		status, _ := cmd.Flags().GetString("status")
 		assignee, _ := cmd.Flags().GetString("assignee")
+		mine, _ := cmd.Flags().GetBool("mine")
 		issueType, _ := cmd.Flags().GetString("type")
 		limit, _ := cmd.Flags().GetInt("limit")
+		offset, _ := cmd.Flags().GetInt("offset")
+		sortSpec, _ := cmd.Flags().GetString("sort")
 		formatStr, _ := cmd.Flags().GetString("format")
 		labels, _ := cmd.Flags().GetStringSlice("label")
 		labelsAny, _ := cmd.Flags().GetStringSlice("label-any")
+		labelMatch, _ := cmd.Flags().GetString("label-match")
 		titleSearch, _ := cmd.Flags().GetString("title")
 		idFilter, _ := cmd.Flags().GetString("id")
 		longFormat, _ := cmd.Flags().GetBool("long")
+		countOnly, _ := cmd.Flags().GetBool("count")
+		if applyJSONFormat(formatStr) {
+			formatStr = ""
+		}
 		
 		// Pattern matching flags
 		titleContains, _ := cmd.Flags().GetString("title-contains")
@@ -66,8 +154,13 @@ var listCmd = &cobra.Command{
 		// Empty/null check flags
 		emptyDesc, _ := cmd.Flags().GetBool("empty-description")
 		noAssignee, _ := cmd.Flags().GetBool("no-assignee")
+		unassigned, _ := cmd.Flags().GetBool("unassigned")
 		noLabels, _ := cmd.Flags().GetBool("no-labels")
 		
+		// Dependency state flags
+		ready, _ := cmd.Flags().GetBool("ready")
+		blocked, _ := cmd.Flags().GetBool("blocked")
+		
 		// Priority range flags
 		priorityMin, _ := cmd.Flags().GetInt("priority-min")
 		priorityMax, _ := cmd.Flags().GetInt("priority-max")
@@ -78,16 +171,77 @@ var listCmd = &cobra.Command{
 		labels = util.NormalizeLabels(labels)
 	labelsAny = util.NormalizeLabels(labelsAny)
 
+		// --label-match any makes --label values alternatives, like --label-any
+		switch labelMatch {
+		case "all":
+		case "any":
+			labelsAny = util.NormalizeLabels(append(labelsAny, labels...))
+			labels = nil
+		default:
+			fmt.Fprintf(os.Stderr, "Error: invalid --label-match %q (use all or any)\n", labelMatch)
+			os.Exit(1)
+		}
+
+		// --mine expands to --assignee <actor> plus "not closed"
+		if mine {
+			if cmd.Flags().Changed("assignee") {
+				fmt.Fprintf(os.Stderr, "Error: --mine cannot be combined with --assignee\n")
+				os.Exit(1)
+			}
+			if actor == "" || actor == "unknown" {
+				fmt.Fprintf(os.Stderr, "Error: --mine requires an actor, but none is configured\n")
+				fmt.Fprintf(os.Stderr, "Hint: set BD_ACTOR, pass --actor, or add 'actor: <name>' to .beads/config.yaml\n")
+				fmt.Fprintf(os.Stderr, "      run 'bd whoami' to see which actor bd resolves\n")
+				os.Exit(1)
+			}
+			assignee = actor
+		}
+
+		// --unassigned and --assignee "" are spellings of --no-assignee
+		if unassigned || (cmd.Flags().Changed("assignee") && assignee == "") {
+			if assignee != "" {
+				fmt.Fprintf(os.Stderr, "Error: --unassigned cannot be combined with --assignee or --mine\n")
+				os.Exit(1)
+			}
+			noAssignee = true
+		}
+
+		if ready && blocked {
+			fmt.Fprintf(os.Stderr, "Error: --ready and --blocked are mutually exclusive\n")
+			os.Exit(1)
+		}
+
+		if limit < 0 || offset < 0 {
+			fmt.Fprintf(os.Stderr, "Error: --limit and --offset cannot be negative\n")
+			os.Exit(1)
+		}
+		paginated := limit > 0 || offset > 0
+		if countOnly && (paginated || formatStr != "" || longFormat) {
+			fmt.Fprintf(os.Stderr, "Error: --count cannot be combined with --limit, --offset, --format or --long\n")
+			os.Exit(1)
+		}
+
+		sortBy, err := types.ParseSortKeys(sortSpec)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --sort: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Hint: use field[:asc|desc] keys separated by commas, e.g. --sort priority:asc,created:desc\n")
+			os.Exit(1)
+		}
+
 		filter := types.IssueFilter{
-			Limit: limit,
+			Limit:  limit,
+			Offset: offset,
+			SortBy: sortBy,
 		}
 		if status != "" && status != "all" {
 			s := types.Status(status)
 			filter.Status = &s
+		} else if mine && status == "" {
+			filter.ExcludeStatus = []types.Status{types.StatusClosed}
 		}
 		// Use Changed() to properly handle P0 (priority=0)
 		if cmd.Flags().Changed("priority") {
-			priority, _ := cmd.Flags().GetInt("priority")
+			priority := getPriorityFlag(cmd)
 			filter.Priority = &priority
 		}
 		if assignee != "" {
@@ -184,6 +338,16 @@ var listCmd = &cobra.Command{
 		if noLabels {
 			filter.NoLabels = true
 		}
+		filter.Ready = ready
+		filter.Blocked = blocked
+		filter.IncludeArchived, _ = cmd.Flags().GetBool("include-archived")
+		metadataFlags, _ := cmd.Flags().GetStringArray("metadata")
+		metadata, err := parseMetadataPairs(metadataFlags)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		filter.Metadata = metadata
 		
 		// Priority ranges
 		if cmd.Flags().Changed("priority-min") {
@@ -193,6 +357,43 @@ var listCmd = &cobra.Command{
 			filter.PriorityMax = &priorityMax
 		}
 
+		// The query's field terms join the filter; the rest is a text match
+		query, err := utils.ParseSearchQuery(strings.Join(args, " "), &filter)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if query != "" && titleSearch != "" {
+			fmt.Fprintf(os.Stderr, "Error: a query argument cannot be combined with --title\n")
+			os.Exit(1)
+		}
+		if filter.Status != nil {
+			// An explicit status overrides the --mine not-closed default
+			filter.ExcludeStatus = nil
+			status = string(*filter.Status)
+		}
+		if filter.IssueType != nil {
+			issueType = string(*filter.IssueType)
+		}
+		if filter.Assignee != nil {
+			assignee = *filter.Assignee
+		}
+		labels, titleContains, descContains = filter.Labels, filter.TitleContains, filter.DescriptionContains
+
+		if formatStr == formatNDJSONStream {
+			if err := ensureDirectMode("list --format ndjson-stream reads issues straight from the database"); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if n, err := streamIssuesNDJSON(context.Background(), store, query, filter, os.Stdout); err != nil {
+				// Lines already written are complete; the exit status tells a
+				// cut-off stream from a clean end
+				fmt.Fprintf(os.Stderr, "Error: stream stopped after %d issues: %v\n", n, err)
+				os.Exit(1)
+			}
+			return
+		}
+
 	// If daemon is running, use RPC
 		if daemonClient != nil {
 			listArgs := &rpc.ListArgs{
@@ -200,9 +401,14 @@ var listCmd = &cobra.Command{
 				IssueType: issueType,
 				Assignee:  assignee,
 				Limit:     limit,
+				Offset:    offset,
+				Sort:      sortSpec,
+			}
+			for _, s := range filter.ExcludeStatus {
+				listArgs.ExcludeStatus = append(listArgs.ExcludeStatus, string(s))
 			}
 			if cmd.Flags().Changed("priority") {
-				priority, _ := cmd.Flags().GetInt("priority")
+				priority := getPriorityFlag(cmd)
 				listArgs.Priority = &priority
 			}
 			if len(labels) > 0 {
@@ -214,6 +420,9 @@ var listCmd = &cobra.Command{
 			// Forward title search via Query field (searches title/description/id)
 			if titleSearch != "" {
 			 listArgs.Query = titleSearch
+			}
+			if query != "" {
+				listArgs.Query = query
 			}
 			 if len(filter.IDs) > 0 {
 			listArgs.IDs = filter.IDs
@@ -248,80 +457,135 @@ var listCmd = &cobra.Command{
 			listArgs.EmptyDescription = filter.EmptyDescription
 			listArgs.NoAssignee = filter.NoAssignee
 			listArgs.NoLabels = filter.NoLabels
+			listArgs.Ready = filter.Ready
+			listArgs.Blocked = filter.Blocked
+			listArgs.Metadata = filter.Metadata
+			listArgs.IncludeArchived = filter.IncludeArchived
 			
 			// Priority range
 			listArgs.PriorityMin = filter.PriorityMin
 			listArgs.PriorityMax = filter.PriorityMax
 
-			 resp, err := daemonClient.List(listArgs)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
-			}
-
-			if jsonOutput {
-				// For JSON output, preserve the full response with counts
-				var issuesWithCounts []*types.IssueWithCounts
-				if err := json.Unmarshal(resp.Data, &issuesWithCounts); err != nil {
+			if countOnly {
+				resp, err := daemonClient.Count(listArgs)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+				var result rpc.CountResult
+				if err := json.Unmarshal(resp.Data, &result); err != nil {
 					fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
 					os.Exit(1)
 				}
-				outputJSON(issuesWithCounts)
+				printIssueCount(result.Count)
 				return
 			}
 
-			var issues []*types.Issue
-			if err := json.Unmarshal(resp.Data, &issues); err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
-				os.Exit(1)
-			}
-
-			if longFormat {
-				// Long format: multi-line with details
-				fmt.Printf("\nFound %d issues:\n\n", len(issues))
-				for _, issue := range issues {
-					fmt.Printf("%s [P%d] [%s] %s\n", issue.ID, issue.Priority, issue.IssueType, issue.Status)
-					fmt.Printf("  %s\n", issue.Title)
-					if issue.Assignee != "" {
-						fmt.Printf("  Assignee: %s\n", issue.Assignee)
+			// Issues arrive in chunks and are printed as they come
+			streamArgs := &rpc.ListStreamArgs{ListArgs: *listArgs}
+			shown := 0
+			var printChunk func([]*types.IssueWithCounts) error
+			finish := func() error { return nil }
+			switch {
+			case jsonOutput:
+				// For JSON output, preserve the full response with counts
+				array := newJSONArrayWriter(os.Stdout)
+				printChunk = func(issues []*types.IssueWithCounts) error {
+					for _, issue := range issues {
+						if err := array.write(issue); err != nil {
+							return err
+						}
 					}
-					if len(issue.Labels) > 0 {
-						fmt.Printf("  Labels: %v\n", issue.Labels)
+					return array.flush()
+				}
+				finish = array.close
+			case longFormat:
+				// The header needs the count, so long output waits for every chunk
+				var issues []*types.Issue
+				printChunk = func(chunk []*types.IssueWithCounts) error {
+					for _, issue := range chunk {
+						issues = append(issues, issue.Issue)
 					}
-					fmt.Println()
+					return nil
 				}
-			} else {
-				// Compact format: one line per issue
-				for _, issue := range issues {
-					labelsStr := ""
-					if len(issue.Labels) > 0 {
-						labelsStr = fmt.Sprintf(" %v", issue.Labels)
+				finish = func() error {
+					// Long format: multi-line with details
+					fmt.Printf("\nFound %d issues:\n\n", len(issues))
+					for _, issue := range issues {
+						fmt.Printf("%s %s [%s] %s\n", displayID(issue.ID), priorityTag(issue.Priority), issue.IssueType, issue.Status)
+						fmt.Printf("  %s\n", issue.Title)
+						if issue.Assignee != "" {
+							fmt.Printf("  Assignee: %s\n", issue.Assignee)
+						}
+						if len(issue.Labels) > 0 {
+							fmt.Printf("  Labels: %v\n", issue.Labels)
+						}
+						fmt.Println()
 					}
-					assigneeStr := ""
-					if issue.Assignee != "" {
-						assigneeStr = fmt.Sprintf(" @%s", issue.Assignee)
+					return nil
+				}
+			default:
+				// Compact format: one line per issue
+				printChunk = func(issues []*types.IssueWithCounts) error {
+					for _, issue := range issues {
+						labelsStr := ""
+						if len(issue.Labels) > 0 {
+							labelsStr = fmt.Sprintf(" %v", issue.Labels)
+						}
+						assigneeStr := ""
+						if issue.Assignee != "" {
+							assigneeStr = fmt.Sprintf(" @%s", issue.Assignee)
+						}
+						fmt.Printf("%s %s [%s] %s%s%s - %s\n",
+							displayID(issue.ID), priorityTag(issue.Priority), issue.IssueType, issue.Status,
+							assigneeStr, labelsStr, issue.Title)
 					}
-					fmt.Printf("%s [P%d] [%s] %s%s%s - %s\n",
-						issue.ID, issue.Priority, issue.IssueType, issue.Status,
-						assigneeStr, labelsStr, issue.Title)
+					return nil
 				}
 			}
+
+			total, err := daemonClient.ListStream(streamArgs, func(issues []*types.IssueWithCounts) error {
+				shown += len(issues)
+				return printChunk(issues)
+			})
+			if err == nil {
+				err = finish()
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if paginated && !jsonOutput {
+				printPageSummary(offset, shown, total)
+			}
 			return
 		}
 
 		// Direct mode
 		ctx := context.Background()
-		issues, err := store.SearchIssues(ctx, "", filter)
+		if countOnly {
+			count, err := store.CountIssues(ctx, query, filter)
+			if err == nil && count == 0 && checkAndAutoImport(ctx, store) {
+				count, err = store.CountIssues(ctx, query, filter)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			printIssueCount(count)
+			return
+		}
+		issues, err := store.SearchIssues(ctx, query, filter)
 		if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 		}
 
 	// If no issues found, check if git has issues and auto-import
-	if len(issues) == 0 {
+	if len(issues) == 0 && offset == 0 {
 		if checkAndAutoImport(ctx, store) {
 			// Re-run the query after import
-			issues, err = store.SearchIssues(ctx, "", filter)
+			issues, err = store.SearchIssues(ctx, query, filter)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
@@ -329,6 +593,14 @@ var listCmd = &cobra.Command{
 		}
 	}
 
+		total := len(issues)
+		if paginated {
+			if total, err = store.CountIssues(ctx, query, filter); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
 		// Handle format flag
 		if formatStr != "" {
 			if err := outputFormattedList(ctx, store, issues, formatStr); err != nil {
@@ -375,7 +647,7 @@ var listCmd = &cobra.Command{
 				// Load labels for display
 				labels, _ := store.GetLabels(ctx, issue.ID)
 
-				fmt.Printf("%s [P%d] [%s] %s\n", issue.ID, issue.Priority, issue.IssueType, issue.Status)
+				fmt.Printf("%s %s [%s] %s\n", displayID(issue.ID), priorityTag(issue.Priority), issue.IssueType, issue.Status)
 				fmt.Printf("  %s\n", issue.Title)
 				if issue.Assignee != "" {
 					fmt.Printf("  Assignee: %s\n", issue.Assignee)
@@ -399,27 +671,58 @@ var listCmd = &cobra.Command{
 				if issue.Assignee != "" {
 					assigneeStr = fmt.Sprintf(" @%s", issue.Assignee)
 				}
-				fmt.Printf("%s [P%d] [%s] %s%s%s - %s\n",
-					issue.ID, issue.Priority, issue.IssueType, issue.Status,
+				fmt.Printf("%s %s [%s] %s%s%s - %s\n",
+					displayID(issue.ID), priorityTag(issue.Priority), issue.IssueType, issue.Status,
 					assigneeStr, labelsStr, issue.Title)
 			}
 		}
+		if paginated {
+			printPageSummary(offset, len(issues), total)
+		}
 	},
 }
 
+// printIssueCount prints bd list --count's result
+func printIssueCount(count int) {
+	if jsonOutput {
+		outputJSON(rpc.CountResult{Count: count})
+		return
+	}
+	fmt.Println(count)
+}
+
+// printPageSummary reports which of the matching issues a page showed, as
+// offset+1 through offset+shown of total. A negative total is unknown.
+func printPageSummary(offset, shown, total int) {
+	of := ""
+	if total >= 0 {
+		of = fmt.Sprintf(" of %d", total)
+	}
+	if shown == 0 {
+		fmt.Printf("\nshowing 0%s\n", of)
+		return
+	}
+	fmt.Printf("\nshowing %d–%d%s\n", offset+1, offset+shown, of)
+}
+
 func init() {
 	listCmd.Flags().StringP("status", "s", "", "Filter by status (open, in_progress, blocked, closed)")
-	listCmd.Flags().IntP("priority", "p", 0, "Filter by priority (0-4: 0=critical, 1=high, 2=medium, 3=low, 4=backlog)")
-	listCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
+	listCmd.Flags().StringP("priority", "p", "", "Filter by priority (0-4, P0-P4 or a name: critical, high, medium, low, backlog)")
+	listCmd.Flags().StringP("assignee", "a", "", "Filter by assignee (\"\" for unassigned)")
+	listCmd.Flags().Bool("mine", false, "Show issues assigned to you that are not closed (see 'bd whoami')")
 	listCmd.Flags().StringP("type", "t", "", "Filter by type (bug, feature, task, epic, chore)")
 	listCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL). Can combine with --label-any")
 	listCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE). Can combine with --label")
+	listCmd.Flags().String("label-match", "all", "How --label values combine: all (must have ALL) or any (AT LEAST ONE)")
 	listCmd.Flags().String("title", "", "Filter by title text (case-insensitive substring match)")
 	listCmd.Flags().String("id", "", "Filter by specific issue IDs (comma-separated, e.g., bd-1,bd-5,bd-10)")
 	listCmd.Flags().IntP("limit", "n", 0, "Limit results")
-	listCmd.Flags().String("format", "", "Output format: 'digraph' (for golang.org/x/tools/cmd/digraph), 'dot' (Graphviz), or Go template")
+	listCmd.Flags().Int("offset", 0, "Skip this many matching issues (with --limit, pages through results)")
+	listCmd.Flags().String("sort", "", "Sort by comma-separated field[:asc|desc] keys: priority, created, updated, status, id (default priority:asc,created:desc)")
+	listCmd.Flags().String("format", "", "Output format: 'json' (compact, same as --json), 'json-pretty', 'digraph' (for golang.org/x/tools/cmd/digraph), 'dot' (Graphviz), 'ndjson-stream' (one JSON issue per line, written as read), or Go template")
 	listCmd.Flags().Bool("all", false, "Show all issues (default behavior; flag provided for CLI familiarity)")
 	listCmd.Flags().Bool("long", false, "Show detailed multi-line output for each issue")
+	listCmd.Flags().Bool("count", false, "Print only the number of matching issues")
 	
 	// Pattern matching
 	listCmd.Flags().String("title-contains", "", "Filter by title substring (case-insensitive)")
@@ -427,17 +730,24 @@ func init() {
 	listCmd.Flags().String("notes-contains", "", "Filter by notes substring (case-insensitive)")
 	
 	// Date ranges
-	listCmd.Flags().String("created-after", "", "Filter issues created after date (YYYY-MM-DD or RFC3339)")
-	listCmd.Flags().String("created-before", "", "Filter issues created before date (YYYY-MM-DD or RFC3339)")
-	listCmd.Flags().String("updated-after", "", "Filter issues updated after date (YYYY-MM-DD or RFC3339)")
-	listCmd.Flags().String("updated-before", "", "Filter issues updated before date (YYYY-MM-DD or RFC3339)")
-	listCmd.Flags().String("closed-after", "", "Filter issues closed after date (YYYY-MM-DD or RFC3339)")
-	listCmd.Flags().String("closed-before", "", "Filter issues closed before date (YYYY-MM-DD or RFC3339)")
+	listCmd.Flags().String("created-after", "", "Filter issues created after date (YYYY-MM-DD, RFC3339, or a duration like 7d)")
+	listCmd.Flags().String("created-before", "", "Filter issues created before date (YYYY-MM-DD, RFC3339, or a duration like 7d)")
+	listCmd.Flags().String("updated-after", "", "Filter issues updated after date (YYYY-MM-DD, RFC3339, or a duration like 7d)")
+	listCmd.Flags().String("updated-before", "", "Filter issues updated before date (YYYY-MM-DD, RFC3339, or a duration like 7d)")
+	listCmd.Flags().String("closed-after", "", "Filter issues closed after date (YYYY-MM-DD, RFC3339, or a duration like 7d)")
+	listCmd.Flags().String("closed-before", "", "Filter issues closed before date (YYYY-MM-DD, RFC3339, or a duration like 7d)")
 	
 	// Empty/null checks
 	listCmd.Flags().Bool("empty-description", false, "Filter issues with empty or missing description")
 	listCmd.Flags().Bool("no-assignee", false, "Filter issues with no assignee")
+	listCmd.Flags().Bool("unassigned", false, "Filter issues with no assignee (same as --no-assignee or --assignee \"\")")
 	listCmd.Flags().Bool("no-labels", false, "Filter issues with no labels")
+	listCmd.Flags().Bool("include-archived", false, "Include archived issues (see bd archive)")
+	listCmd.Flags().StringArray("metadata", nil, "Filter by custom metadata as key=value (repeatable, AND)")
+	
+	// Dependency state
+	listCmd.Flags().Bool("ready", false, "Filter to open and in-progress issues with no open blockers (as in 'bd ready')")
+	listCmd.Flags().Bool("blocked", false, "Filter to unclosed issues with at least one open blocker (as in 'bd blocked')")
 	
 	// Priority ranges
 	listCmd.Flags().Int("priority-min", 0, "Filter by minimum priority (inclusive)")
@@ -447,80 +757,33 @@ func init() {
 	rootCmd.AddCommand(listCmd)
 }
 
+// formatNDJSONStream is the bd list --format that writes each issue as a
+// JSON line as soon as it is read
+const formatNDJSONStream = "ndjson-stream"
+
+// streamIssuesNDJSON writes each issue the search finds to w as one line of
+// JSON as it comes back from the store, without holding the results, and
+// returns how many lines were written
+func streamIssuesNDJSON(ctx context.Context, s storage.Storage, query string, filter types.IssueFilter, w io.Writer) (int, error) {
+	enc := json.NewEncoder(w)
+	n := 0
+	err := s.StreamIssues(ctx, query, filter, func(issue *types.Issue) error {
+		if err := enc.Encode(issue); err != nil {
+			return fmt.Errorf("failed to write issue %s: %w", issue.ID, err)
+		}
+		n++
+		return nil
+	})
+	return n, err
+}
+
 // outputDotFormat outputs issues in Graphviz DOT format
 func outputDotFormat(ctx context.Context, store storage.Storage, issues []*types.Issue) error {
-	fmt.Println("digraph dependencies {")
-	fmt.Println("  rankdir=TB;")
-	fmt.Println("  node [shape=box, style=rounded];")
-	fmt.Println()
-
-	// Build map of all issues for quick lookup
-	issueMap := make(map[string]*types.Issue)
-	for _, issue := range issues {
-		issueMap[issue.ID] = issue
-	}
-
-	// Output nodes with labels including ID, type, priority, and status
-	for _, issue := range issues {
-		// Build label with ID, type, priority, and title (using actual newlines)
-		label := fmt.Sprintf("%s\n[%s P%d]\n%s\n(%s)",
-			issue.ID,
-			issue.IssueType,
-			issue.Priority,
-			issue.Title,
-			issue.Status)
-
-		// Color by status only - keep it simple
-		fillColor := "white"
-		fontColor := "black"
-
-		switch issue.Status {
-		case "closed":
-			fillColor = "lightgray"
-			fontColor = "dimgray"
-		case "in_progress":
-			fillColor = "lightyellow"
-		case "blocked":
-			fillColor = "lightcoral"
-		}
-
-		fmt.Printf("  %q [label=%q, style=\"rounded,filled\", fillcolor=%q, fontcolor=%q];\n",
-			issue.ID, label, fillColor, fontColor)
-	}
-	fmt.Println()
-
-	// Output edges with labels for dependency type
-	for _, issue := range issues {
-		deps, err := store.GetDependencyRecords(ctx, issue.ID)
-		if err != nil {
-			continue
-		}
-		for _, dep := range deps {
-			// Only output edges where both nodes are in the filtered list
-			if issueMap[dep.DependsOnID] != nil {
-				// Color code by dependency type
-				color := "black"
-				style := "solid"
-				switch dep.Type {
-				case "blocks":
-					color = "red"
-					style = "bold"
-				case "parent-child":
-					color = "blue"
-				case "discovered-from":
-					color = "green"
-					style = "dashed"
-				case "related":
-					color = "gray"
-					style = "dashed"
-				}
-				fmt.Printf("  %q -> %q [label=%q, color=%s, style=%s];\n",
-					issue.ID, dep.DependsOnID, dep.Type, color, style)
-			}
-		}
+	allDeps, err := store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get dependencies: %w", err)
 	}
-
-	fmt.Println("}")
+	writeDOTGraph(os.Stdout, issues, allDeps)
 	return nil
 }
 
@@ -1,13 +1,17 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/util"
@@ -273,7 +277,7 @@ func TestListQueryCapabilities(t *testing.T) {
 	}
 	
 	// Close issue3 to set closed_at timestamp
-	if err := st.CloseIssue(ctx, issue3.ID, "test-user", "Testing"); err != nil {
+	if err := st.CloseIssue(ctx, issue3.ID, storage.CloseOptions{Reason: "Testing"}, "test-user"); err != nil {
 		t.Fatalf("Failed to close issue3: %v", err)
 	}
 
@@ -354,6 +358,32 @@ func TestListQueryCapabilities(t *testing.T) {
 		}
 	})
 
+	t.Run("empty assignee matches unassigned", func(t *testing.T) {
+		unassigned := ""
+		results, err := st.SearchIssues(ctx, "", types.IssueFilter{
+			Assignee: &unassigned,
+		})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) != 1 {
+			t.Errorf("Expected 1 unassigned issue, got %d", len(results))
+		}
+		if len(results) > 0 && results[0].ID != issue2.ID {
+			t.Errorf("Expected issue2, got %s", results[0].ID)
+		}
+
+		ready, err := st.GetReadyWork(ctx, types.WorkFilter{Assignee: &unassigned})
+		if err != nil {
+			t.Fatalf("GetReadyWork failed: %v", err)
+		}
+		for _, issue := range ready {
+			if issue.Assignee != "" {
+				t.Errorf("GetReadyWork returned %s assigned to %q", issue.ID, issue.Assignee)
+			}
+		}
+	})
+
 	t.Run("no labels check", func(t *testing.T) {
 		results, err := st.SearchIssues(ctx, "", types.IssueFilter{
 			NoLabels: true,
@@ -464,6 +494,32 @@ func TestListQueryCapabilities(t *testing.T) {
 			t.Errorf("Expected 2 results matching combined filters, got %d", len(results))
 		}
 	})
+
+	t.Run("assignee excluding closed (--mine)", func(t *testing.T) {
+		bob := "bob"
+		results, err := st.SearchIssues(ctx, "", types.IssueFilter{
+			Assignee:      &bob,
+			ExcludeStatus: []types.Status{types.StatusClosed},
+		})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) != 0 {
+			t.Errorf("Expected bob's closed issue to be excluded, got %d results", len(results))
+		}
+
+		alice := "alice"
+		results, err = st.SearchIssues(ctx, "", types.IssueFilter{
+			Assignee:      &alice,
+			ExcludeStatus: []types.Status{types.StatusClosed},
+		})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) != 1 || results[0].ID != issue1.ID {
+			t.Errorf("Expected alice's open issue %s, got %v", issue1.ID, results)
+		}
+	})
 }
 
 func TestParseTimeFlag(t *testing.T) {
@@ -476,7 +532,12 @@ func TestParseTimeFlag(t *testing.T) {
 		{"Date only", "2023-01-15", false},
 		{"DateTime without zone", "2023-01-15T10:30:00", false},
 		{"DateTime with space", "2023-01-15 10:30:00", false},
+		{"Hours ago", "36h", false},
+		{"Days ago", "7d", false},
+		{"Weeks ago", "2w", false},
 		{"Invalid format", "January 15, 2023", true},
+		{"Unknown unit", "3y", true},
+		{"Negative duration", "-7d", true},
 		{"Empty string", "", true},
 	}
 
@@ -488,4 +549,53 @@ func TestParseTimeFlag(t *testing.T) {
 			}
 		})
 	}
+
+	got, err := parseTimeFlag("7d")
+	if err != nil {
+		t.Fatalf("parseTimeFlag(7d) failed: %v", err)
+	}
+	want := time.Now().Add(-7 * 24 * time.Hour)
+	if d := want.Sub(got); d < 0 || d > time.Second {
+		t.Errorf("parseTimeFlag(7d) = %v, want about %v", got, want)
+	}
+}
+
+func TestStreamIssuesNDJSON(t *testing.T) {
+	s := newTestStore(t, filepath.Join(t.TempDir(), ".beads", "beads.db"))
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		issue := &types.Issue{Title: fmt.Sprintf("Issue %d", i), Status: types.StatusOpen, Priority: i, IssueType: types.TypeTask}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.AddLabel(ctx, issue.ID, "backend", "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	n, err := streamIssuesNDJSON(ctx, s, "", types.IssueFilter{}, &buf)
+	if err != nil || n != 3 {
+		t.Fatalf("streamIssuesNDJSON = %d, %v; want 3, nil", n, err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %q", buf.String())
+	}
+	for i, line := range lines {
+		var issue types.Issue
+		if err := json.Unmarshal([]byte(line), &issue); err != nil {
+			t.Fatalf("Line %d is not an issue: %v", i, err)
+		}
+		if issue.Priority != i || len(issue.Labels) != 1 {
+			t.Errorf("Line %d: priority %d, labels %v; want priority %d, [backend]", i, issue.Priority, issue.Labels, i)
+		}
+	}
+
+	// A failed write stops the stream with an error, after the lines written
+	buf.Reset()
+	n, err = streamIssuesNDJSON(ctx, s, "", types.IssueFilter{}, &shortWriter{w: &buf, n: len(lines[0]) + 1})
+	if err == nil || n != 1 {
+		t.Errorf("Expected an error after 1 line, got %d, %v", n, err)
+	}
 }
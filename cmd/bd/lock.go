@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var lockCmd = &cobra.Command{
+	Use:   "lock [id...]",
+	Short: "Take an advisory lock on one or more issues",
+	Long: `Take an advisory lock on one or more issues to signal that you are editing them.
+
+Locks record the holder (the current actor) and expire automatically after a
+TTL. The TTL defaults to the lock.ttl config value, or 30m if unset:
+
+  bd config set lock.ttl 2h
+
+Running 'bd lock' again on an issue you already hold renews the lock.
+
+Locks are advisory: 'bd update' warns when editing an issue locked by someone
+else, and refuses with --respect-locks. Locks are local to this database and
+are not exported to JSONL.
+
+Examples:
+  bd lock bd-42              # Lock with the default TTL
+  bd lock bd-42 --ttl 15m    # Lock for 15 minutes`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ttl, _ := cmd.Flags().GetDuration("ttl")
+		if ttl < 0 {
+			fmt.Fprintf(os.Stderr, "Error: --ttl must be positive\n")
+			os.Exit(1)
+		}
+
+		ctx := context.Background()
+		resolvedIDs := resolveIssueIDs(ctx, args)
+
+		locks := []*types.IssueLock{}
+		for _, id := range resolvedIDs {
+			var lock *types.IssueLock
+			if daemonClient != nil {
+				resp, err := daemonClient.Lock(&rpc.LockArgs{ID: id, Holder: actor, TTLSeconds: int(ttl / time.Second)})
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error locking %s: %v\n", id, err)
+					continue
+				}
+				if err := json.Unmarshal(resp.Data, &lock); err != nil {
+					fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+					continue
+				}
+			} else {
+				var err error
+				lock, err = store.AcquireLock(ctx, id, actor, ttl)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error locking %s: %v\n", id, err)
+					continue
+				}
+			}
+
+			if jsonOutput {
+				locks = append(locks, lock)
+			} else {
+				green := color.New(color.FgGreen).SprintFunc()
+				fmt.Printf("%s Locked %s (holder: %s, expires %s)\n",
+					green("✓"), id, lock.Holder, lock.ExpiresAt.Local().Format("2006-01-02 15:04"))
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(locks)
+		}
+	},
+}
+
+var unlockCmd = &cobra.Command{
+	Use:   "unlock [id...]",
+	Short: "Release an advisory lock on one or more issues",
+	Long: `Release an advisory lock on one or more issues.
+
+Only the holder can release a live lock unless --force is given. Unlocking an
+issue that isn't locked is a no-op.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		force, _ := cmd.Flags().GetBool("force")
+
+		ctx := context.Background()
+		resolvedIDs := resolveIssueIDs(ctx, args)
+
+		unlocked := []string{}
+		for _, id := range resolvedIDs {
+			var err error
+			if daemonClient != nil {
+				_, err = daemonClient.Unlock(&rpc.UnlockArgs{ID: id, Holder: actor, Force: force})
+			} else {
+				err = store.ReleaseLock(ctx, id, actor, force)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error unlocking %s: %v\n", id, err)
+				continue
+			}
+
+			if jsonOutput {
+				unlocked = append(unlocked, id)
+			} else {
+				green := color.New(color.FgGreen).SprintFunc()
+				fmt.Printf("%s Unlocked %s\n", green("✓"), id)
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"unlocked": unlocked})
+		}
+	},
+}
+
+// resolveIssueIDs resolves partial IDs in either daemon or direct mode, exiting on failure
+func resolveIssueIDs(ctx context.Context, args []string) []string {
+	if daemonClient == nil {
+		resolvedIDs, err := utils.ResolvePartialIDs(ctx, store, args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return resolvedIDs
+	}
+
+	resolvedIDs := make([]string, 0, len(args))
+	for _, id := range args {
+		resp, err := daemonClient.ResolveID(&rpc.ResolveIDArgs{ID: id})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving ID %s: %v\n", id, err)
+			os.Exit(1)
+		}
+		var fullID string
+		if err := json.Unmarshal(resp.Data, &fullID); err != nil {
+			fmt.Fprintf(os.Stderr, "Error unmarshaling resolved ID: %v\n", err)
+			os.Exit(1)
+		}
+		resolvedIDs = append(resolvedIDs, fullID)
+	}
+	return resolvedIDs
+}
+
+// getIssueLock returns the live lock on an issue, or nil if it is unlocked
+func getIssueLock(ctx context.Context, id string) (*types.IssueLock, error) {
+	if daemonClient == nil {
+		return store.GetLock(ctx, id)
+	}
+	resp, err := daemonClient.LockStatus(&rpc.LockStatusArgs{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	var lock *types.IssueLock
+	if err := json.Unmarshal(resp.Data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lock status: %w", err)
+	}
+	return lock, nil
+}
+
+// checkEditLock reports whether an edit to id may proceed. If the issue is
+// locked by another actor it prints a warning and returns true, or prints an
+// error and returns false when respectLocks is set.
+func checkEditLock(ctx context.Context, id string, respectLocks bool) bool {
+	lock, err := getIssueLock(ctx, id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to check lock on %s: %v\n", id, err)
+		return !respectLocks
+	}
+	if lock == nil || lock.Holder == actor {
+		return true
+	}
+
+	expires := lock.ExpiresAt.Local().Format("2006-01-02 15:04")
+	if respectLocks {
+		fmt.Fprintf(os.Stderr, "Error: %s is locked by %s until %s (skipping due to --respect-locks)\n", id, lock.Holder, expires)
+		return false
+	}
+	yellow := color.New(color.FgYellow).SprintFunc()
+	fmt.Fprintf(os.Stderr, "%s %s is locked by %s until %s; editing anyway\n", yellow("⚠"), id, lock.Holder, expires)
+	return true
+}
+
+func init() {
+	lockCmd.Flags().Duration("ttl", 0, "How long the lock lasts, e.g. 30m or 2h (default: lock.ttl config or 30m)")
+	unlockCmd.Flags().Bool("force", false, "Release the lock even if held by someone else")
+	rootCmd.AddCommand(lockCmd)
+	rootCmd.AddCommand(unlockCmd)
+}
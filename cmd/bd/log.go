@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// logStatusFilter is the --type value selecting every status transition
+// (created, status_changed, closed, reopened)
+const logStatusFilter = "status"
+
+// logEventTypes lists the event types bd log --type accepts besides "status"
+var logEventTypes = []types.EventType{
+	types.EventCreated,
+	types.EventUpdated,
+	types.EventStatusChanged,
+	types.EventCommented,
+	types.EventClosed,
+	types.EventReopened,
+	types.EventDependencyAdded,
+	types.EventDependencyRemoved,
+	types.EventLabelAdded,
+	types.EventLabelRemoved,
+	types.EventCompacted,
+	types.EventPriorityChanged,
+	types.EventUndone,
+	types.EventMerged,
+	types.EventCommentEdited,
+	types.EventCommentDeleted,
+	types.EventFieldsCleared,
+}
+
+var logCmd = &cobra.Command{
+	Use:   "log <id>",
+	Short: "Show an issue's event history",
+	Long: `Show everything that happened to an issue, oldest first: when it was
+created, status changes, closes and reopens, comments, field edits, and
+label and dependency changes, each with its actor and timestamp.
+
+--type filters by event type and can be repeated or comma-separated.
+'--type status' shows only status transitions (created, status_changed,
+closed, reopened).
+
+Examples:
+  bd log bd-a3f8
+  bd log bd-a3f8 --type status
+  bd log bd-a3f8 --type commented,label_added --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		typeFilters, _ := cmd.Flags().GetStringSlice("type")
+		matches, err := parseLogTypeFilter(typeFilters)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		ctx := context.Background()
+
+		var id, issueURL string
+		var events []*types.Event
+		var comments []*types.Comment
+		if daemonClient != nil {
+			resp, err := daemonClient.ResolveID(&rpc.ResolveIDArgs{ID: args[0]})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error resolving ID %s: %v\n", args[0], err)
+				os.Exit(1)
+			}
+			id = string(resp.Data)
+			resp, err = daemonClient.Show(&rpc.ShowArgs{ID: id, History: true})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error fetching %s: %v\n", id, err)
+				os.Exit(1)
+			}
+			var details struct {
+				Events []*types.Event `json:"events"`
+				URL    string         `json:"url"`
+			}
+			if err := json.Unmarshal(resp.Data, &details); err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+				os.Exit(1)
+			}
+			events, issueURL = details.Events, details.URL
+			resp, err = daemonClient.ListComments(&rpc.CommentListArgs{ID: id})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting comments: %v\n", err)
+				os.Exit(1)
+			}
+			if err := json.Unmarshal(resp.Data, &comments); err != nil {
+				fmt.Fprintf(os.Stderr, "Error decoding comments: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			id, err = utils.ResolvePartialID(ctx, store, args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if events, err = store.GetEvents(ctx, id, 0); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to get events: %v\n", err)
+				os.Exit(1)
+			}
+			if comments, err = store.GetIssueComments(ctx, id); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to get comments: %v\n", err)
+				os.Exit(1)
+			}
+			issueURL = utils.IssueURL(ctx, store, id)
+		}
+
+		events = issueTimeline(events, comments, matches)
+		if jsonOutput {
+			outputJSON(events)
+			return
+		}
+		if len(events) == 0 {
+			fmt.Printf("No matching events for %s\n", id)
+			return
+		}
+		fmt.Printf("Log for %s (%d events):\n", id, len(events))
+		if issueURL != "" {
+			fmt.Printf("URL: %s\n", issueURL)
+		}
+		fmt.Println()
+		for _, event := range events {
+			line := fmt.Sprintf("%s  %-18s %s", event.CreatedAt.Local().Format("2006-01-02 15:04:05"), event.EventType, event.Actor)
+			if change := describeEventChange(event); change != "" {
+				line += "  " + change
+			}
+			if event.Comment != nil && *event.Comment != "" {
+				line += ": " + *event.Comment
+			}
+			fmt.Println(line)
+			if event.Note != nil && *event.Note != "" {
+				fmt.Printf("    note: %s\n", *event.Note)
+			}
+		}
+	},
+}
+
+// parseLogTypeFilter turns --type values into a predicate (nil matches
+// every event), rejecting unknown event types
+func parseLogTypeFilter(values []string) (func(types.EventType) bool, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	statuses := false
+	wanted := make(map[types.EventType]bool)
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == logStatusFilter {
+			statuses = true
+			continue
+		}
+		known := false
+		for _, t := range logEventTypes {
+			if string(t) == value {
+				known = true
+				break
+			}
+		}
+		if !known {
+			names := []string{logStatusFilter}
+			for _, t := range logEventTypes {
+				names = append(names, string(t))
+			}
+			return nil, fmt.Errorf("unknown event type %q (valid: %s)", value, strings.Join(names, ", "))
+		}
+		wanted[types.EventType(value)] = true
+	}
+	return func(t types.EventType) bool {
+		return wanted[t] || (statuses && t.IsStatusEvent())
+	}, nil
+}
+
+// issueTimeline merges an issue's events and comments into one list, oldest
+// first, keeping only entries matching matches (all if nil). Storage returns
+// events newest first, and comments don't record events of their own, so
+// each comment becomes a commented event.
+func issueTimeline(events []*types.Event, comments []*types.Comment, matches func(types.EventType) bool) []*types.Event {
+	timeline := make([]*types.Event, 0, len(events)+len(comments))
+	for i := len(events) - 1; i >= 0; i-- {
+		timeline = append(timeline, events[i])
+	}
+	for _, comment := range comments {
+		text := comment.FirstText() // Later edits and deletion have their own events
+		timeline = append(timeline, &types.Event{
+			IssueID:   comment.IssueID,
+			EventType: types.EventCommented,
+			Actor:     comment.Author,
+			Comment:   &text,
+			CreatedAt: comment.CreatedAt,
+		})
+	}
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].CreatedAt.Before(timeline[j].CreatedAt)
+	})
+
+	result := make([]*types.Event, 0, len(timeline))
+	for _, event := range timeline {
+		if matches == nil || matches(event.EventType) {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// describeEventChange summarizes what an update event changed, such as
+// "open → in_progress" for a status change or "title, priority" for an edit.
+// Update events store the old issue and the applied updates as JSON.
+func describeEventChange(event *types.Event) string {
+	// Created events hold the whole new issue, not updates
+	if event.NewValue == nil || event.EventType == types.EventCreated {
+		return ""
+	}
+	var updates map[string]interface{}
+	if err := json.Unmarshal([]byte(*event.NewValue), &updates); err != nil {
+		return ""
+	}
+	if newStatus, ok := updates["status"].(string); ok {
+		var old struct {
+			Status string `json:"status"`
+		}
+		if event.OldValue != nil && json.Unmarshal([]byte(*event.OldValue), &old) == nil && old.Status != "" {
+			return fmt.Sprintf("%s → %s", old.Status, newStatus)
+		}
+		return "→ " + newStatus
+	}
+	if event.EventType != types.EventUpdated && event.EventType != types.EventFieldsCleared {
+		return ""
+	}
+	fields := make([]string, 0, len(updates))
+	for field := range updates {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, ", ")
+}
+
+func init() {
+	logCmd.Flags().StringSlice("type", nil, "Only show these event types ('status' for status transitions)")
+	rootCmd.AddCommand(logCmd)
+}
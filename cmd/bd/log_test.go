@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIssueTimeline(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	strPtr := func(s string) *string { return &s }
+
+	// Newest first, as storage returns them
+	events := []*types.Event{
+		{ID: 4, EventType: types.EventReopened, Actor: "bob", OldValue: strPtr(`{"status":"closed"}`), NewValue: strPtr(`{"status":"open"}`), CreatedAt: base.Add(4 * time.Minute)},
+		{ID: 3, EventType: types.EventClosed, Actor: "alice", Comment: strPtr("done"), CreatedAt: base.Add(3 * time.Minute)},
+		{ID: 2, EventType: types.EventUpdated, Actor: "alice", NewValue: strPtr(`{"title":"x","priority":1}`), CreatedAt: base.Add(time.Minute)},
+		{ID: 1, EventType: types.EventCreated, Actor: "alice", NewValue: strPtr(`{"status":"open"}`), CreatedAt: base},
+	}
+	comments := []*types.Comment{
+		{ID: 1, IssueID: "bd-1", Author: "carol", Text: "looks good", CreatedAt: base.Add(2 * time.Minute)},
+	}
+
+	t.Run("all events oldest first with comments merged", func(t *testing.T) {
+		timeline := issueTimeline(events, comments, nil)
+		want := []types.EventType{types.EventCreated, types.EventUpdated, types.EventCommented, types.EventClosed, types.EventReopened}
+		if len(timeline) != len(want) {
+			t.Fatalf("got %d events, want %d", len(timeline), len(want))
+		}
+		for i, eventType := range want {
+			if timeline[i].EventType != eventType {
+				t.Errorf("event %d: got %s, want %s", i, timeline[i].EventType, eventType)
+			}
+		}
+		if c := timeline[2]; c.Actor != "carol" || c.Comment == nil || *c.Comment != "looks good" {
+			t.Errorf("comment event = %+v, want carol's comment", c)
+		}
+	})
+
+	t.Run("status filter", func(t *testing.T) {
+		matches, err := parseLogTypeFilter([]string{"status"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		timeline := issueTimeline(events, comments, matches)
+		want := []types.EventType{types.EventCreated, types.EventClosed, types.EventReopened}
+		if len(timeline) != len(want) {
+			t.Fatalf("got %d events, want %d", len(timeline), len(want))
+		}
+		for i, eventType := range want {
+			if timeline[i].EventType != eventType {
+				t.Errorf("event %d: got %s, want %s", i, timeline[i].EventType, eventType)
+			}
+		}
+	})
+
+	t.Run("explicit types", func(t *testing.T) {
+		matches, err := parseLogTypeFilter([]string{"commented", " updated"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := issueTimeline(events, comments, matches); len(got) != 2 {
+			t.Errorf("got %d events, want 2", len(got))
+		}
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		if _, err := parseLogTypeFilter([]string{"bogus"}); err == nil {
+			t.Error("expected error for unknown event type")
+		}
+	})
+
+	t.Run("change descriptions", func(t *testing.T) {
+		tests := map[*types.Event]string{
+			events[0]: "closed → open",
+			events[1]: "",
+			events[2]: "priority, title",
+			events[3]: "",
+		}
+		for event, want := range tests {
+			if got := describeEventChange(event); got != want {
+				t.Errorf("%s: got %q, want %q", event.EventType, got, want)
+			}
+		}
+	})
+}
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/memory"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
 )
 
@@ -49,9 +52,11 @@ const (
 
 var (
 	dbPath       string
+	workspaceDir string // --workspace
 	actor        string
 	store        storage.Storage
 	jsonOutput   bool
+	jsonPretty   bool         // Indent JSON output (--format json-pretty)
 	daemonStatus DaemonStatus // Tracks daemon connection state for current command
 
 	// Daemon mode
@@ -68,6 +73,7 @@ var (
 	storeActive       = false    // Tracks if store is available
 	flushFailureCount = 0        // Consecutive flush failures
 	lastFlushError    error      // Last flush error for debugging
+	stopShutdownFlush func()     // Stops flushOnShutdownSignal's handler
 
 	// Auto-import state
 	autoImportEnabled = true // Can be disabled with --no-auto-import
@@ -78,6 +84,7 @@ var (
 	noAutoImport bool
 	sandboxMode  bool
 	noDb         bool // Use --no-db mode: load from JSONL, write back after each command
+	readOnlyMode bool // --read-only: open the database read-only and refuse commands that write
 )
 
 func init() {
@@ -88,13 +95,15 @@ func init() {
 
 	// Register persistent flags
 	rootCmd.PersistentFlags().StringVar(&dbPath, "db", "", "Database path (default: auto-discover .beads/*.db)")
-	rootCmd.PersistentFlags().StringVar(&actor, "actor", "", "Actor name for audit trail (default: $BD_ACTOR or $USER)")
+	rootCmd.PersistentFlags().StringVar(&workspaceDir, "workspace", "", "Use the database of this workspace (a directory containing .beads) instead of discovering one")
+	rootCmd.PersistentFlags().StringVar(&actor, "actor", "", "Actor name for audit trail (default: $BD_ACTOR, $BEADS_ACTOR or $USER)")
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
 	rootCmd.PersistentFlags().BoolVar(&noDaemon, "no-daemon", false, "Force direct storage mode, bypass daemon if running")
 	rootCmd.PersistentFlags().BoolVar(&noAutoFlush, "no-auto-flush", false, "Disable automatic JSONL sync after CRUD operations")
 	rootCmd.PersistentFlags().BoolVar(&noAutoImport, "no-auto-import", false, "Disable automatic JSONL import when newer than DB")
 	rootCmd.PersistentFlags().BoolVar(&sandboxMode, "sandbox", false, "Sandbox mode: disables daemon and auto-sync")
 	rootCmd.PersistentFlags().BoolVar(&noDb, "no-db", false, "Use no-db mode: load from JSONL, no SQLite")
+	rootCmd.PersistentFlags().BoolVar(&readOnlyMode, "read-only", false, "Open the database read-only and refuse commands that change it")
 
 	// Add --version flag to root command (same behavior as version subcommand)
 	rootCmd.Flags().BoolP("version", "v", false, "Print version information")
@@ -121,6 +130,9 @@ var rootCmd = &cobra.Command{
 		// If flag wasn't explicitly set, use viper value
 		if !cmd.Flags().Changed("json") {
 			jsonOutput = config.GetBool("json")
+		} else {
+			// Commands with their own --json flag shadow the global one
+			jsonOutput, _ = cmd.Flags().GetBool("json")
 		}
 		if !cmd.Flags().Changed("no-daemon") {
 			noDaemon = config.GetBool("no-daemon")
@@ -134,12 +146,50 @@ var rootCmd = &cobra.Command{
 		if !cmd.Flags().Changed("no-db") {
 			noDb = config.GetBool("no-db")
 		}
+		if !cmd.Flags().Changed("read-only") {
+			readOnlyMode = config.GetBool("read-only")
+		}
 		if !cmd.Flags().Changed("db") && dbPath == "" {
 			dbPath = config.GetString("db")
 		}
 		if !cmd.Flags().Changed("actor") && actor == "" {
 			actor = config.GetString("actor")
 		}
+		// An actor given but blank would leave the audit trail unattributed
+		if cmd.Flags().Changed("actor") || actor != "" {
+			actor = strings.TrimSpace(actor)
+			if actor == "" {
+				fmt.Fprintf(os.Stderr, "Error: the actor set by --actor, BD_ACTOR or BEADS_ACTOR cannot be empty\n")
+				os.Exit(1)
+			}
+		}
+
+		if !cmd.Flags().Changed("workspace") && workspaceDir == "" {
+			workspaceDir = config.GetString("workspace")
+		}
+
+		// --workspace picks the database before anything goes looking for one
+		if workspaceDir != "" {
+			if cmd.Flags().Changed("db") {
+				fmt.Fprintf(os.Stderr, "Error: --workspace and --db can't be used together\n")
+				os.Exit(1)
+			}
+			if cmd.Name() == "init" {
+				fmt.Fprintf(os.Stderr, "Error: --workspace can't be used with init; run 'bd init' in %s instead\n", workspaceDir)
+				os.Exit(1)
+			}
+			if err := useWorkspace(workspaceDir, !noDb); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		// Refuse anything that writes before it can touch the database
+		if readOnlyMode {
+			if err := checkReadOnly(cmd); err != nil {
+				exitWithError(err, "Error: %v", err)
+			}
+		}
 
 		// Skip database initialization for commands that don't need a database
 		noDbCommands := []string{
@@ -154,13 +204,19 @@ var rootCmd = &cobra.Command{
 			"powershell",
 			"prime",
 			"quickstart",
+			"rebuild", // Its database may be too damaged to open
 			"setup",
 			"version",
+			"whoami",
 			"zsh",
 		}
 		if slices.Contains(noDbCommands, cmd.Name()) {
 			return
 		}
+		// bd daemon subcommands talk to the daemon themselves
+		if cmd.HasParent() && cmd.Parent().Name() == cmdDaemon {
+			return
+		}
 
 		// If sandbox mode is set, enable all sandbox flags
 		if sandboxMode {
@@ -181,6 +237,12 @@ var rootCmd = &cobra.Command{
 		// Set auto-import based on flag (invert no-auto-import)
 		autoImportEnabled = !noAutoImport
 
+		// Read-only mode neither imports into the database nor exports from it
+		if readOnlyMode {
+			autoFlushEnabled = false
+			autoImportEnabled = false
+		}
+
 		// Handle --no-db mode: load from JSONL, use in-memory storage
 		if noDb {
 			if err := initializeNoDbMode(); err != nil {
@@ -190,9 +252,7 @@ var rootCmd = &cobra.Command{
 
 			// Set actor for audit trail
 			if actor == "" {
-				if bdActor := os.Getenv("BD_ACTOR"); bdActor != "" {
-					actor = bdActor
-				} else if user := os.Getenv("USER"); user != "" {
+				if user := os.Getenv("USER"); user != "" {
 					actor = user
 				} else {
 					actor = "unknown"
@@ -214,9 +274,13 @@ var rootCmd = &cobra.Command{
 					// No database found - error out instead of falling back to ~/.beads
 					fmt.Fprintf(os.Stderr, "Error: no beads database found\n")
 					fmt.Fprintf(os.Stderr, "Hint: run 'bd init' to create a database in the current directory\n")
+					fmt.Fprintf(os.Stderr, "      or pass --workspace <dir> to use another workspace's database\n")
 					fmt.Fprintf(os.Stderr, "      or set BEADS_DIR to point to your .beads directory\n")
 					fmt.Fprintf(os.Stderr, "      or set BEADS_DB to point to your database file (deprecated)\n")
-					os.Exit(1)
+					if jsonOutput {
+						outputJSON(jsonError{Error: types.ErrCodeNoDatabase, Message: "no beads database found"})
+					}
+					os.Exit(exitNoDatabase)
 				}
 				// For import command, set default database path
 				dbPath = filepath.Join(".beads", beads.CanonicalDatabaseName)
@@ -224,10 +288,9 @@ var rootCmd = &cobra.Command{
 		}
 
 		// Set actor from flag, viper (env), or default
-		// Priority: --actor flag > viper (config + BD_ACTOR env) > USER env > "unknown"
-		// Note: Viper handles BD_ACTOR automatically via AutomaticEnv()
+		// Priority: --actor flag > viper (BD_ACTOR/BEADS_ACTOR env + config) > USER env > "unknown"
 		if actor == "" {
-			// Viper already populated from config file or BD_ACTOR env
+			// Viper already populated from config file or the actor env vars
 			// Fall back to USER env if still empty
 			if user := os.Getenv("USER"); user != "" {
 				actor = user
@@ -243,7 +306,7 @@ var rootCmd = &cobra.Command{
 			Connected:        false,
 			Degraded:         true,
 			SocketPath:       socketPath,
-			AutoStartEnabled: shouldAutoStartDaemon(),
+			AutoStartEnabled: shouldAutoStartDaemon() && !readOnlyMode, // A daemon would write
 			FallbackReason:   FallbackNone,
 		}
 
@@ -260,6 +323,8 @@ var rootCmd = &cobra.Command{
 					absDBPath, _ := filepath.Abs(dbPath)
 					client.SetDatabasePath(absDBPath)
 				}
+				client.SetActor(actor)
+				client.SetReadOnly(readOnlyMode)
 
 				// Perform health check
 				health, healthErr := client.Health()
@@ -279,6 +344,8 @@ var rootCmd = &cobra.Command{
 									absDBPath, _ := filepath.Abs(dbPath)
 									client.SetDatabasePath(absDBPath)
 								}
+								client.SetActor(actor)
+								client.SetReadOnly(readOnlyMode)
 								health, healthErr = client.Health()
 								if healthErr == nil && health.Status == statusHealthy {
 									daemonClient = client
@@ -344,6 +411,8 @@ var rootCmd = &cobra.Command{
 							absDBPath, _ := filepath.Abs(dbPath)
 							client.SetDatabasePath(absDBPath)
 						}
+						client.SetActor(actor)
+						client.SetReadOnly(readOnlyMode)
 
 						// Check health of auto-started daemon
 						health, healthErr := client.Health()
@@ -411,7 +480,7 @@ var rootCmd = &cobra.Command{
 
 		// Fall back to direct storage access
 		var err error
-		store, err = sqlite.New(dbPath)
+		store, err = openStore(dbPath)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
 			os.Exit(1)
@@ -422,9 +491,22 @@ var rootCmd = &cobra.Command{
 		storeActive = true
 		storeMutex.Unlock()
 
+		// SIGTERM/SIGINT flush pending changes instead of dropping them
+		stopShutdownFlush = flushOnShutdownSignal()
+
+		// The database's auto_flush config applies unless --no-auto-flush was given
+		if autoFlushEnabled && !cmd.Flags().Changed("no-auto-flush") && !readOnlyMode {
+			autoFlushEnabled = autoFlushFromConfig(context.Background(), store)
+		}
+
 		// Warn if multiple databases detected in directory hierarchy
 		warnMultipleDatabases(dbPath)
 
+		// Warn once when new IDs are about to grow a character
+		if sqliteStore, ok := store.(*sqlite.SQLiteStorage); ok && !readOnlyMode {
+			warnApproachingIDLength(context.Background(), sqliteStore)
+		}
+
 		// Auto-import if JSONL is newer than DB (e.g., after git pull)
 		// Skip for import command itself to avoid recursion
 		// Skip for delete command to prevent resurrection of deleted issues (bd-8kde)
@@ -445,7 +527,7 @@ var rootCmd = &cobra.Command{
 	},
 	PersistentPostRun: func(cmd *cobra.Command, args []string) {
 		// Handle --no-db mode: write memory storage back to JSONL
-		if noDb {
+		if noDb && !readOnlyMode {
 			if store != nil {
 				// Determine beads directory (respect BEADS_DIR)
 				var beadsDir string
@@ -479,22 +561,10 @@ var rootCmd = &cobra.Command{
 		}
 
 		// Otherwise, handle direct mode cleanup
-		// Flush any pending changes before closing
-		flushMutex.Lock()
-		needsFlush := isDirty && autoFlushEnabled
-		if needsFlush {
-			// Cancel timer and flush immediately
-			if flushTimer != nil {
-				flushTimer.Stop()
-				flushTimer = nil
-			}
-			// Don't clear isDirty or needsFullExport here - let flushToJSONL do it
-		}
-		flushMutex.Unlock()
-
-		if needsFlush {
-			// Call the shared flush function (handles both incremental and full export)
-			flushToJSONL()
+		// Flush any pending changes before closing (flushToJSONL reports failures)
+		_ = flushPending()
+		if stopShutdownFlush != nil {
+			stopShutdownFlush()
 		}
 
 		// Signal that store is closing (prevents background flush from accessing closed store)
@@ -516,4 +586,5 @@ func main() {
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
 	}
+	os.Exit(exitStatus)
 }
@@ -87,6 +87,33 @@ func TestAutoFlushDisabled(t *testing.T) {
 	autoFlushEnabled = true
 }
 
+// TestAutoFlushConfig tests that auto_flush=false in the database turns auto-flush off
+func TestAutoFlushConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	testStore := newTestStore(t, filepath.Join(tmpDir, ".beads", "beads.db"))
+	ctx := context.Background()
+
+	if !autoFlushFromConfig(ctx, testStore) {
+		t.Error("Expected auto-flush on when auto_flush is unset")
+	}
+	if err := testStore.SetConfig(ctx, autoFlushConfigKey, "false"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if autoFlushFromConfig(ctx, testStore) {
+		t.Error("Expected auto-flush off with auto_flush=false")
+	}
+	// A bad value keeps flushing rather than silently losing exports
+	if err := testStore.SetConfig(ctx, autoFlushConfigKey, "nope"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if !autoFlushFromConfig(ctx, testStore) {
+		t.Error("Expected auto-flush on with an invalid auto_flush")
+	}
+	if _, err := parseAutoFlush("nope"); err == nil {
+		t.Error("Expected parseAutoFlush to reject a non-boolean")
+	}
+}
+
 // TestAutoFlushDebounce tests that rapid operations result in a single flush
 func TestAutoFlushDebounce(t *testing.T) {
 	// FIXME(bd-159): Test needs fixing - config.Set doesn't override flush-debounce properly
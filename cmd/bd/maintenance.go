@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+var maintenanceCmd = &cobra.Command{
+	Use:   "maintenance",
+	Short: "Database maintenance tasks",
+}
+
+var compactEventsCmd = &cobra.Command{
+	Use:   "compact-events",
+	Short: "Collapse old audit trail events to a per-issue summary",
+	Long: `Collapse each issue's events from before a cutoff date to a summary: its
+first created event and its newest status event (created, status_changed,
+closed or reopened). Everything else before the cutoff is deleted in one
+transaction, then the database is vacuumed to give the space back.
+
+Events after the cutoff are never touched, so 'bd log' still shows recent
+history in full, and for older history when the issue was created and how it
+reached its current status. Unlike prune-events, the created event survives.
+
+It's safe to run while the daemon has the database open: the compaction and
+the vacuum wait for the daemon's writes to finish (up to sqlite.busy-timeout)
+rather than failing. Events aren't part of the JSONL export, so compaction
+only affects the local database.
+
+EXAMPLES:
+  bd maintenance compact-events --before 2025-01-01
+  bd maintenance compact-events --before 2025-01-01 --dry-run --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		beforeStr, _ := cmd.Flags().GetString("before")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		if beforeStr == "" {
+			fmt.Fprintf(os.Stderr, "Error: --before is required\n")
+			os.Exit(1)
+		}
+		before, err := parseTimeFlag(beforeStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing --before: %v\n", err)
+			os.Exit(1)
+		}
+		if before.After(time.Now()) {
+			fmt.Fprintf(os.Stderr, "Error: --before %s is in the future\n", beforeStr)
+			os.Exit(1)
+		}
+
+		if err := ensureDirectMode("maintenance compact-events requires direct database access"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		ctx := context.Background()
+		removed, err := store.CompactEvents(ctx, before, dryRun)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		var reclaimed int64
+		if !dryRun && removed > 0 {
+			if sqliteStore, ok := store.(*sqlite.SQLiteStorage); ok {
+				sizeBefore := databaseFileSize(dbPath)
+				if err := sqliteStore.Vacuum(ctx); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: events were compacted, but %v\n", err)
+					os.Exit(1)
+				}
+				reclaimed = max(sizeBefore-databaseFileSize(dbPath), 0)
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"removed_count":   removed,
+				"before":          before.Format(time.RFC3339),
+				"dry_run":         dryRun,
+				"bytes_reclaimed": reclaimed,
+			})
+			return
+		}
+
+		if dryRun {
+			fmt.Println(color.YellowString("DRY RUN - no changes will be made"))
+			fmt.Printf("Would remove %d event(s) created before %s\n", removed, before.Format("2006-01-02 15:04"))
+			return
+		}
+		fmt.Printf("%s Removed %d event(s) created before %s", color.GreenString("✓"), removed, before.Format("2006-01-02 15:04"))
+		if reclaimed > 0 {
+			fmt.Printf(", reclaimed %.1f KB", float64(reclaimed)/1024)
+		}
+		fmt.Println()
+	},
+}
+
+// databaseFileSize returns the size of the database and its WAL, or 0 if
+// they can't be read
+func databaseFileSize(path string) int64 {
+	var size int64
+	for _, file := range []string{path, path + "-wal"} {
+		if info, err := os.Stat(file); err == nil {
+			size += info.Size()
+		}
+	}
+	return size
+}
+
+func init() {
+	compactEventsCmd.Flags().String("before", "", "Compact events created before this date (2006-01-02 or RFC3339)")
+	compactEventsCmd.Flags().Bool("dry-run", false, "Report how many events would be removed without deleting them")
+	maintenanceCmd.AddCommand(compactEventsCmd)
+	rootCmd.AddCommand(maintenanceCmd)
+}
@@ -17,9 +17,11 @@ var mergeCmd = &cobra.Command{
 	Short: "3-way merge tool for beads JSONL issue files",
 	Long: `bd merge is a 3-way merge tool for beads issue tracker JSONL files.
 
-It intelligently merges issues based on identity (id + created_at + created_by),
-applies field-specific merge rules, combines dependencies, and outputs conflict
-markers for unresolvable conflicts.
+It matches issues by ID and merges them field by field: a field changed on
+both sides takes the version from the side with the newer updated_at, and
+labels, dependencies and comments are merged as sets. An issue deleted on one
+side and edited on the other is kept. bd sync resolves JSONL conflicts the
+same way.
 
 Designed to work as a git merge driver. Configure with:
 
@@ -30,12 +32,13 @@ Designed to work as a git merge driver. Configure with:
 Or use 'bd init' which automatically configures the merge driver.
 
 Exit codes:
-  0 - Merge successful (no conflicts)
-  1 - Merge completed with conflicts (conflict markers in output)
-  2 - Error (invalid arguments, file not found, etc.)
+  0 - Merge successful
+  2 - Error (invalid arguments, file not found, unparseable JSONL, etc.)
 
 Original tool by @neongreen: https://github.com/neongreen/mono/tree/main/beads-merge
-Vendored into bd with permission.`,
+Vendored into bd with permission.
+
+To fold a duplicate issue into another, use 'bd merge-issues'.`,
 	Args: cobra.ExactArgs(4),
 	// PreRun disables PersistentPreRun for this command (no database needed)
 	PreRun: func(cmd *cobra.Command, args []string) {},
@@ -47,14 +50,7 @@ Vendored into bd with permission.`,
 
 		err := merge.Merge3Way(outputPath, basePath, leftPath, rightPath, debugMerge)
 		if err != nil {
-			// Check if error is due to conflicts
-			if err.Error() == fmt.Sprintf("merge completed with %d conflicts", 1) || 
-			   err.Error() == fmt.Sprintf("merge completed with %d conflicts", 2) ||
-			   err.Error()[:len("merge completed with")] == "merge completed with" {
-				// Conflicts present - exit with 1 (standard for merge drivers)
-				os.Exit(1)
-			}
-			// Other errors - exit with 2
+			// Any non-zero exit tells git the merge failed
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(2)
 		}
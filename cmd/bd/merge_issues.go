@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var mergeIssuesCmd = &cobra.Command{
+	Use:   "merge-issues <duplicate-id> <target-id> | merge-issues <duplicate-id>... --into <target-id>",
+	Short: "Fold duplicate issues into a target issue",
+	Long: `Fold each duplicate into the target: its comments, events and labels move
+to the target, its dependencies are re-pointed at it, and references to the
+duplicate in every issue's text and comments are rewritten. Edges between the
+two issues, edges the target already has (such as a shared parent), and edges
+that would close a cycle are dropped. The duplicate is closed with resolution
+"duplicate" and a related dependency on the target. --dry-run reports what
+would move without changing anything.
+
+Examples:
+  bd merge-issues bd-42 bd-41
+  bd merge-issues bd-42 bd-43 --into bd-41 --dry-run`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if cmd.Flags().Changed("into") {
+			return cobra.MinimumNArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		into, _ := cmd.Flags().GetString("into")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		sources := args
+		if into == "" {
+			sources, into = args[:1], args[1]
+		}
+		if len(sources) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: no duplicate issues given to merge into %s\n", into)
+			os.Exit(1)
+		}
+
+		if err := ensureDirectMode("daemon does not support merging issues"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		targetID, err := utils.ResolvePartialID(ctx, store, into)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving target ID %s: %v\n", into, err)
+			os.Exit(1)
+		}
+		sourceIDs, err := utils.ResolvePartialIDs(ctx, store, sources)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		merges, err := performMerge(ctx, targetID, sourceIDs, dryRun)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if !dryRun {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(merges)
+			return
+		}
+		for _, merge := range merges {
+			printIssueMerge(merge, dryRun)
+		}
+	},
+}
+
+// performMerge merges each source into targetID, one transaction per source.
+// With dryRun nothing is changed, and each merge reports what it would move.
+func performMerge(ctx context.Context, targetID string, sourceIDs []string, dryRun bool) ([]*sqlite.IssueMerge, error) {
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok {
+		return nil, fmt.Errorf("merging issues requires SQLite storage")
+	}
+
+	merges := make([]*sqlite.IssueMerge, 0, len(sourceIDs))
+	for _, sourceID := range sourceIDs {
+		if sourceID == targetID {
+			continue // bd merge-issues bd-10 bd-11 --into bd-10 keeps the target
+		}
+		mapping := map[string]string{sourceID: targetID}
+		refPattern := idRefPattern(mapping, `[0-9a-z]+`)
+		rewrite := func(text string) string {
+			return replaceIDReferencesMatching(refPattern, text, mapping)
+		}
+		merge, err := sqliteStore.MergeIssue(ctx, sourceID, targetID, rewrite, dryRun, actor)
+		if err != nil {
+			return merges, fmt.Errorf("failed to merge %s into %s: %w", sourceID, targetID, err)
+		}
+		merges = append(merges, merge)
+	}
+	return merges, nil
+}
+
+// printIssueMerge summarizes a merge for humans
+func printIssueMerge(merge *sqlite.IssueMerge, dryRun bool) {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	verb := "Moved"
+	if dryRun {
+		verb = "Would move"
+		fmt.Printf("%s Dry run - would merge %s into %s\n", yellow("⚠"), merge.DuplicateID, merge.TargetID)
+	} else {
+		fmt.Printf("%s Merged %s into %s (closed as duplicate)\n", green("✓"), merge.DuplicateID, merge.TargetID)
+	}
+
+	fmt.Printf("  %s %d comment(s), %d event(s), %d label(s) and %d dependency(ies)\n",
+		verb, merge.Comments, merge.Events, len(merge.Labels), len(merge.Dependencies))
+	for _, dep := range merge.Dependencies {
+		fmt.Printf("    %s → %s (%s)\n", dep.IssueID, dep.DependsOnID, dep.Type)
+	}
+	if len(merge.Dropped) > 0 {
+		fmt.Printf("  Dropped %d dependency(ies) already on %s, between the two issues, or closing a cycle\n",
+			len(merge.Dropped), merge.TargetID)
+		for _, dep := range merge.Dropped {
+			fmt.Printf("    %s → %s (%s)\n", dep.IssueID, dep.DependsOnID, dep.Type)
+		}
+	}
+	if len(merge.RewrittenIssues) > 0 || merge.RewrittenComments > 0 {
+		fmt.Printf("  Rewrote references in %d issue(s) and %d comment(s)\n",
+			len(merge.RewrittenIssues), merge.RewrittenComments)
+	}
+}
+
+func init() {
+	mergeIssuesCmd.Flags().String("into", "", "Merge the given duplicate issues into this issue")
+	mergeIssuesCmd.Flags().Bool("dry-run", false, "Show what would move without changing anything")
+	rootCmd.AddCommand(mergeIssuesCmd)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// parseMetadataPairs parses key=value flag values into a metadata map
+func parseMetadataPairs(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	metadata := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok {
+			return nil, fmt.Errorf("invalid metadata %q (expected key=value)", pair)
+		}
+		if err := types.ValidateMetadataKey(key); err != nil {
+			return nil, err
+		}
+		metadata[key] = value
+	}
+	return metadata, nil
+}
+
+// printIssueMetadata prints an issue's custom fields sorted by key
+func printIssueMetadata(metadata map[string]string) {
+	if len(metadata) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fmt.Printf("Metadata:\n")
+	for _, key := range keys {
+		fmt.Printf("  %s: %s\n", key, metadata[key])
+	}
+}
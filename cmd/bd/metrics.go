@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// flowMetrics summarizes lead and cycle time for a set of closed issues
+type flowMetrics struct {
+	Group           string  `json:"group,omitempty"`
+	ClosedIssues    int     `json:"closed_issues"`
+	LeadTimeP50     float64 `json:"lead_time_p50_hours"`
+	LeadTimeP90     float64 `json:"lead_time_p90_hours"`
+	CycleTimeCount  int     `json:"cycle_time_issues"`
+	CycleTimeP50    float64 `json:"cycle_time_p50_hours"`
+	CycleTimeP90    float64 `json:"cycle_time_p90_hours"`
+	ReopenedSkipped int     `json:"reopened_skipped"`
+}
+
+// issueFlowTimes holds the measured durations for a single closed issue
+type issueFlowTimes struct {
+	IssueType types.IssueType
+	LeadTime  time.Duration
+	CycleTime *time.Duration // nil if the issue never entered in_progress
+	Reopened  bool
+}
+
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Show lead time and cycle time for closed issues",
+	Long: `Show lead time and cycle time percentiles for closed issues.
+
+Lead time is measured from creation to close. Cycle time is measured from the
+first transition to in_progress to close; issues that were never marked
+in_progress are excluded from cycle time.
+
+Issues that were reopened and closed again are skipped by default because
+their timestamps don't describe a single pass through the workflow. Use
+--include-reopened to measure them to their final close.
+
+Examples:
+  bd metrics                    # All closed issues
+  bd metrics --since 2025-01-01 # Issues closed since a date
+  bd metrics --by-type --json   # Per-type breakdown as JSON`,
+	Run: func(cmd *cobra.Command, args []string) {
+		sinceStr, _ := cmd.Flags().GetString("since")
+		byType, _ := cmd.Flags().GetBool("by-type")
+		includeReopened, _ := cmd.Flags().GetBool("include-reopened")
+
+		status := types.StatusClosed
+		filter := types.IssueFilter{Status: &status}
+		if sinceStr != "" {
+			since, err := parseTimeFlag(sinceStr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing --since: %v\n", err)
+				os.Exit(1)
+			}
+			filter.ClosedAfter = &since
+		}
+
+		// If daemon is running but doesn't support this command, use direct storage
+		if daemonClient != nil && store == nil {
+			var err error
+			store, err = openStore(dbPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+				os.Exit(1)
+			}
+			defer func() { _ = store.Close() }()
+		}
+
+		ctx := context.Background()
+		issues, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		var times []issueFlowTimes
+		for _, issue := range issues {
+			events, err := store.GetEvents(ctx, issue.ID, 0)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to get events for %s: %v\n", issue.ID, err)
+				os.Exit(1)
+			}
+			if ft, ok := computeFlowTimes(issue, events); ok {
+				times = append(times, ft)
+			}
+		}
+
+		var results []*flowMetrics
+		if byType {
+			groups := make(map[types.IssueType][]issueFlowTimes)
+			for _, ft := range times {
+				groups[ft.IssueType] = append(groups[ft.IssueType], ft)
+			}
+			var keys []string
+			for t := range groups {
+				keys = append(keys, string(t))
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				m := summarizeFlowTimes(groups[types.IssueType(k)], includeReopened)
+				m.Group = k
+				results = append(results, m)
+			}
+		} else {
+			results = append(results, summarizeFlowTimes(times, includeReopened))
+		}
+
+		if jsonOutput {
+			if results == nil {
+				results = []*flowMetrics{}
+			}
+			outputJSON(results)
+			return
+		}
+
+		cyan := color.New(color.FgCyan).SprintFunc()
+		fmt.Printf("\n%s Flow Metrics", cyan("⏱"))
+		if sinceStr != "" {
+			fmt.Printf(" (closed since %s)", sinceStr)
+		}
+		fmt.Printf(":\n\n")
+		if len(results) == 0 {
+			fmt.Printf("No closed issues found\n\n")
+			return
+		}
+		for _, m := range results {
+			if m.Group != "" {
+				fmt.Printf("%s:\n", m.Group)
+			}
+			fmt.Printf("  Closed issues: %d\n", m.ClosedIssues)
+			if m.ClosedIssues > 0 {
+				fmt.Printf("  Lead time:     p50 %s, p90 %s\n", formatHours(m.LeadTimeP50), formatHours(m.LeadTimeP90))
+			}
+			if m.CycleTimeCount > 0 {
+				fmt.Printf("  Cycle time:    p50 %s, p90 %s (%d issues)\n", formatHours(m.CycleTimeP50), formatHours(m.CycleTimeP90), m.CycleTimeCount)
+			} else {
+				fmt.Printf("  Cycle time:    n/a (no issues went through in_progress)\n")
+			}
+			if m.ReopenedSkipped > 0 {
+				fmt.Printf("  Skipped %d reopened issue(s) (use --include-reopened to count them)\n", m.ReopenedSkipped)
+			}
+			fmt.Println()
+		}
+	},
+}
+
+// computeFlowTimes derives lead time and cycle time for a closed issue from its
+// event history. Returns false if the issue has no closed_at timestamp.
+func computeFlowTimes(issue *types.Issue, events []*types.Event) (issueFlowTimes, bool) {
+	if issue.ClosedAt == nil {
+		return issueFlowTimes{}, false
+	}
+	ft := issueFlowTimes{
+		IssueType: issue.IssueType,
+		LeadTime:  issue.ClosedAt.Sub(issue.CreatedAt),
+	}
+
+	var firstInProgress *time.Time
+	for _, event := range events {
+		if event.EventType == types.EventReopened {
+			ft.Reopened = true
+		}
+		if event.EventType != types.EventStatusChanged || event.NewValue == nil {
+			continue
+		}
+		var updates map[string]interface{}
+		if err := json.Unmarshal([]byte(*event.NewValue), &updates); err != nil {
+			continue
+		}
+		if updates["status"] != string(types.StatusInProgress) {
+			continue
+		}
+		if firstInProgress == nil || event.CreatedAt.Before(*firstInProgress) {
+			t := event.CreatedAt
+			firstInProgress = &t
+		}
+	}
+	if firstInProgress != nil && !firstInProgress.After(*issue.ClosedAt) {
+		cycle := issue.ClosedAt.Sub(*firstInProgress)
+		ft.CycleTime = &cycle
+	}
+	return ft, true
+}
+
+// summarizeFlowTimes computes percentiles over the given issues
+func summarizeFlowTimes(times []issueFlowTimes, includeReopened bool) *flowMetrics {
+	m := &flowMetrics{}
+	var lead, cycle []float64
+	for _, ft := range times {
+		if ft.Reopened && !includeReopened {
+			m.ReopenedSkipped++
+			continue
+		}
+		lead = append(lead, ft.LeadTime.Hours())
+		if ft.CycleTime != nil {
+			cycle = append(cycle, ft.CycleTime.Hours())
+		}
+	}
+	m.ClosedIssues = len(lead)
+	m.CycleTimeCount = len(cycle)
+	m.LeadTimeP50 = percentile(lead, 50)
+	m.LeadTimeP90 = percentile(lead, 90)
+	m.CycleTimeP50 = percentile(cycle, 50)
+	m.CycleTimeP90 = percentile(cycle, 90)
+	return m
+}
+
+// percentile returns the p-th percentile of values using the nearest-rank method
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// formatHours renders a duration in hours as a short human-readable string
+func formatHours(hours float64) string {
+	if hours < 24 {
+		return fmt.Sprintf("%.1fh", hours)
+	}
+	return fmt.Sprintf("%.1fd", hours/24)
+}
+
+func init() {
+	metricsCmd.Flags().String("since", "", "Only include issues closed after this date (YYYY-MM-DD or RFC3339)")
+	metricsCmd.Flags().Bool("by-type", false, "Break down metrics by issue type")
+	metricsCmd.Flags().Bool("include-reopened", false, "Include issues that were reopened and closed again")
+	rootCmd.AddCommand(metricsCmd)
+}
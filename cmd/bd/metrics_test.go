@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestPercentile(t *testing.T) {
+	values := []float64{5, 1, 4, 2, 3, 6, 7, 8, 9, 10}
+	if got := percentile(values, 50); got != 5 {
+		t.Errorf("p50 = %v, want 5", got)
+	}
+	if got := percentile(values, 90); got != 9 {
+		t.Errorf("p90 = %v, want 9", got)
+	}
+	if got := percentile(nil, 50); got != 0 {
+		t.Errorf("p50 of empty = %v, want 0", got)
+	}
+}
+
+func TestComputeFlowTimes(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	started := created.Add(24 * time.Hour)
+	closed := created.Add(72 * time.Hour)
+	issue := &types.Issue{
+		ID:        "bd-1",
+		IssueType: types.TypeBug,
+		Status:    types.StatusClosed,
+		CreatedAt: created,
+		ClosedAt:  &closed,
+	}
+	inProgress := `{"status":"in_progress"}`
+	events := []*types.Event{
+		{EventType: types.EventClosed, CreatedAt: closed},
+		{EventType: types.EventStatusChanged, NewValue: &inProgress, CreatedAt: started},
+		{EventType: types.EventCreated, CreatedAt: created},
+	}
+
+	ft, ok := computeFlowTimes(issue, events)
+	if !ok {
+		t.Fatal("expected flow times for closed issue")
+	}
+	if ft.LeadTime != 72*time.Hour {
+		t.Errorf("lead time = %v, want 72h", ft.LeadTime)
+	}
+	if ft.CycleTime == nil || *ft.CycleTime != 48*time.Hour {
+		t.Errorf("cycle time = %v, want 48h", ft.CycleTime)
+	}
+	if ft.Reopened {
+		t.Error("issue should not be marked reopened")
+	}
+
+	// Reopened issues are skipped unless explicitly included
+	events = append(events, &types.Event{EventType: types.EventReopened, CreatedAt: started})
+	ft, _ = computeFlowTimes(issue, events)
+	if m := summarizeFlowTimes([]issueFlowTimes{ft}, false); m.ClosedIssues != 0 || m.ReopenedSkipped != 1 {
+		t.Errorf("expected reopened issue to be skipped, got %+v", m)
+	}
+	if m := summarizeFlowTimes([]issueFlowTimes{ft}, true); m.ClosedIssues != 1 {
+		t.Errorf("expected reopened issue to be included, got %+v", m)
+	}
+}
@@ -284,7 +284,7 @@ This command:
 			prefix, err := store.GetConfig(ctx, "issue_prefix")
 			if err != nil || prefix == "" {
 				// Get first issue to detect prefix
-				issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+				issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 				if err == nil && len(issues) > 0 {
 					detectedPrefix := utils.ExtractIssuePrefix(issues[0].ID)
 					if detectedPrefix != "" {
@@ -391,7 +391,7 @@ This command:
 			}
 			
 			ctx := context.Background()
-			issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+			issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 			if err != nil {
 			_ = store.Close()
 			if jsonOutput {
@@ -405,7 +405,7 @@ This command:
 				os.Exit(1)
 			}
 			
-			if len(issues) > 0 && !isHashID(issues[0].ID) {
+			if hasSequentialIDs(issues) {
 				// Create backup
 				if !dryRun {
 					backupPath := strings.TrimSuffix(targetPath, ".db") + ".backup-pre-hash-" + time.Now().Format("20060102-150405") + ".db"
@@ -426,7 +426,7 @@ This command:
 					}
 					}
 					
-					mapping, err := migrateToHashIDs(ctx, store, issues, dryRun)
+					mapping, collisions, err := migrateToHashIDs(ctx, store, issues, dryRun, false)
 					_ = store.Close()
 				
 				if err != nil {
@@ -447,6 +447,9 @@ This command:
 					} else {
 						color.Green("✓ Migrated %d issues to hash-based IDs\n", len(mapping))
 						}
+					if len(collisions) > 0 {
+						fmt.Printf("Resolved %d hash collision(s) by bumping the nonce\n", len(collisions))
+					}
 						}
 						} else {
 						_ = store.Close()
@@ -848,7 +851,7 @@ func handleInspect() {
 	if issueCount > 0 && prefix == "" {
 		// Detect prefix from first issue (efficient query for just 1 issue)
 		detectedPrefix := ""
-		if issues, err := store.SearchIssues(ctx, "", types.IssueFilter{}); err == nil && len(issues) > 0 {
+		if issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true}); err == nil && len(issues) > 0 {
 			detectedPrefix = utils.ExtractIssuePrefix(issues[0].ID)
 		}
 		warnings = append(warnings, fmt.Sprintf("issue_prefix config not set - may break commands after migration (detected: %s)", detectedPrefix))
@@ -5,17 +5,21 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -32,9 +36,50 @@ This command:
 - Creates mapping file for reference
 - Validates all relationships are intact
 
-Use --dry-run to preview changes before applying.`,
+Use --dry-run to preview changes before applying.
+
+Every ID change is applied in a single transaction, so a failure partway
+through leaves the database untouched instead of half-migrated. The backup is
+still made as a fallback. --atomic=false applies the changes one issue at a
+time instead.
+
+Use --emit-mapping to write the complete old → new ID mapping to stdout as
+JSON (works with --dry-run). Status messages, including --json output, are
+sent to stderr so stdout can be piped directly into other tools.
+
+Use --revert <mapping.json> to undo a migration, restoring the sequential IDs
+recorded in the mapping file (hash-id-mapping.json next to the database). Every
+hash ID in the file must still exist, and every hash ID in the database must be
+in the file, or nothing is changed. A backup is created first, as for the
+forward migration.`,
 	Run: func(cmd *cobra.Command, _ []string) {
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		emitMapping, _ := cmd.Flags().GetBool("emit-mapping")
+		atomic, _ := cmd.Flags().GetBool("atomic")
+		revertPath, _ := cmd.Flags().GetString("revert")
+
+		// Reserve stdout for the mapping document; everything else goes to out
+		out := io.Writer(os.Stdout)
+		if emitMapping {
+			out = os.Stderr
+		}
+
+		// Load the mapping up front so a bad file fails before the backup is made
+		var revertMapping map[string]string
+		if revertPath != "" {
+			var err error
+			if revertMapping, err = loadMappingFile(revertPath); err != nil {
+				if jsonOutput {
+					outputJSONTo(out, map[string]interface{}{
+						"error":   "mapping_load_failed",
+						"message": err.Error(),
+					})
+				} else {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				}
+				os.Exit(1)
+			}
+		}
 		
 		ctx := context.Background()
 		
@@ -42,7 +87,7 @@ Use --dry-run to preview changes before applying.`,
 		dbPath := beads.FindDatabasePath()
 		if dbPath == "" {
 			if jsonOutput {
-				outputJSON(map[string]interface{}{
+				outputJSONTo(out, map[string]interface{}{
 					"error":   "no_database",
 					"message": "No beads database found. Run 'bd init' first.",
 				})
@@ -58,7 +103,7 @@ Use --dry-run to preview changes before applying.`,
 			backupPath := strings.TrimSuffix(dbPath, ".db") + ".backup-" + time.Now().Format("20060102-150405") + ".db"
 			if err := copyFile(dbPath, backupPath); err != nil {
 				if jsonOutput {
-					outputJSON(map[string]interface{}{
+					outputJSONTo(out, map[string]interface{}{
 						"error":   "backup_failed",
 						"message": err.Error(),
 					})
@@ -68,7 +113,7 @@ Use --dry-run to preview changes before applying.`,
 				os.Exit(1)
 			}
 			if !jsonOutput {
-				color.Green("✓ Created backup: %s\n\n", filepath.Base(backupPath))
+				color.New(color.FgGreen).Fprintf(out, "✓ Created backup: %s\n\n", filepath.Base(backupPath))
 			}
 		}
 		
@@ -76,7 +121,7 @@ Use --dry-run to preview changes before applying.`,
 		store, err := sqlite.New(dbPath)
 		if err != nil {
 			if jsonOutput {
-				outputJSON(map[string]interface{}{
+				outputJSONTo(out, map[string]interface{}{
 					"error":   "open_failed",
 					"message": err.Error(),
 				})
@@ -88,10 +133,10 @@ Use --dry-run to preview changes before applying.`,
 		defer func() { _ = store.Close() }()
 		
 		// Get all issues using SearchIssues with empty query and no filters
-		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 		if err != nil {
 			if jsonOutput {
-				outputJSON(map[string]interface{}{
+				outputJSONTo(out, map[string]interface{}{
 					"error":   "list_failed",
 					"message": err.Error(),
 				})
@@ -101,36 +146,70 @@ Use --dry-run to preview changes before applying.`,
 			os.Exit(1)
 		}
 		
+		if revertMapping != nil {
+			restored, err := revertHashIDs(ctx, store, issues, revertMapping, dryRun, atomic)
+			if err != nil {
+				if jsonOutput {
+					outputJSONTo(out, map[string]interface{}{
+						"error":   "revert_failed",
+						"message": err.Error(),
+					})
+				} else {
+					fmt.Fprintf(os.Stderr, "Error: revert failed: %v\n", err)
+				}
+				os.Exit(1)
+			}
+			
+			if jsonOutput {
+				outputJSONTo(out, map[string]interface{}{
+					"status":          "success",
+					"dry_run":         dryRun,
+					"issues_restored": len(restored),
+					"mapping":         restored,
+				})
+			} else if dryRun {
+				fmt.Fprintln(out, "\nDry run complete - no changes made")
+				fmt.Fprintf(out, "Would restore %d sequential IDs\n", len(restored))
+			} else {
+				color.New(color.FgGreen).Fprintf(out, "\n✓ Revert complete!\n\n")
+				fmt.Fprintf(out, "Restored %d issues to sequential IDs\n", len(restored))
+				fmt.Fprintln(out, "\nNext steps:")
+				fmt.Fprintln(out, "  1. Run 'bd export' to update JSONL file")
+				fmt.Fprintln(out, "  2. Commit changes to git")
+			}
+			return
+		}
+		
 		if len(issues) == 0 {
 			if jsonOutput {
-				outputJSON(map[string]interface{}{
+				outputJSONTo(out, map[string]interface{}{
 					"status":  "no_issues",
 					"message": "No issues to migrate",
 				})
 			} else {
-				fmt.Println("No issues to migrate")
+				fmt.Fprintln(out, "No issues to migrate")
 			}
 			return
 		}
 		
-		// Check if already using hash IDs
-		if isHashID(issues[0].ID) {
+		// Check if already using hash IDs (every issue, so mixed databases still migrate)
+		if !hasSequentialIDs(issues) {
 			if jsonOutput {
-				outputJSON(map[string]interface{}{
+				outputJSONTo(out, map[string]interface{}{
 					"status":  "already_migrated",
 					"message": "Database already uses hash-based IDs",
 				})
 			} else {
-				fmt.Println("Database already uses hash-based IDs")
+				fmt.Fprintln(out, "Database already uses hash-based IDs")
 			}
 			return
 		}
 		
 		// Perform migration
-		mapping, err := migrateToHashIDs(ctx, store, issues, dryRun)
+		mapping, collisions, err := migrateToHashIDs(ctx, store, issues, dryRun, atomic)
 		if err != nil {
 			if jsonOutput {
-				outputJSON(map[string]interface{}{
+				outputJSONTo(out, map[string]interface{}{
 					"error":   "migration_failed",
 					"message": err.Error(),
 				})
@@ -140,54 +219,102 @@ Use --dry-run to preview changes before applying.`,
 			os.Exit(1)
 		}
 		
+		// Log each collision that needed a nonce bump
+		if !jsonOutput {
+			for _, c := range collisions {
+				fmt.Fprintf(os.Stderr, "%s Hash collision: %s → %s is taken by %s, retried with nonce %d → %s\n",
+					color.YellowString("↻"), c.OldID, c.HashID, c.TakenBy, c.Nonce, c.ResolvedID)
+			}
+		}
+		
+		// Count comment references the migration rewrites, so dry runs can be audited
+		commentRefs := 0
+		if dryRun {
+			commentRefs, err = countCommentIDReferences(ctx, store, issues, mapping)
+			if err != nil {
+				if jsonOutput {
+					outputJSONTo(out, map[string]interface{}{
+						"error":   "comment_scan_failed",
+						"message": err.Error(),
+					})
+				} else {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				}
+				os.Exit(1)
+			}
+		}
+		
 		// Save mapping to file
 		if !dryRun {
 			mappingPath := filepath.Join(filepath.Dir(dbPath), "hash-id-mapping.json")
 			if err := saveMappingFile(mappingPath, mapping); err != nil {
 				if !jsonOutput {
-					color.Yellow("Warning: failed to save mapping file: %v\n", err)
+					color.New(color.FgYellow).Fprintf(out, "Warning: failed to save mapping file: %v\n", err)
 				}
 			} else if !jsonOutput {
-				color.Green("✓ Saved mapping to: %s\n", filepath.Base(mappingPath))
+				color.New(color.FgGreen).Fprintf(out, "✓ Saved mapping to: %s\n", filepath.Base(mappingPath))
 			}
 		}
 		
+		if emitMapping {
+			if err := writeMappingJSON(os.Stdout, mapping, dryRun); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to emit mapping: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
 		// Output results
 		if jsonOutput {
-			outputJSON(map[string]interface{}{
+			result := map[string]interface{}{
 				"status":        "success",
 				"dry_run":       dryRun,
 				"issues_migrated": len(mapping),
 				"mapping":       mapping,
-			})
+			}
+			if dryRun {
+				result["comment_references"] = commentRefs
+			}
+			if len(collisions) > 0 {
+				result["collisions_resolved"] = collisions
+			}
+			outputJSONTo(out, result)
 		} else {
 			if dryRun {
-				fmt.Println("\nDry run complete - no changes made")
-				fmt.Printf("Would migrate %d issues\n\n", len(mapping))
-				fmt.Println("Preview of mapping (first 10):")
+				fmt.Fprintln(out, "\nDry run complete - no changes made")
+				fmt.Fprintf(out, "Would migrate %d issues\n", len(mapping))
+				fmt.Fprintf(out, "Would rewrite %d ID references in comments\n", commentRefs)
+				if len(collisions) > 0 {
+					fmt.Fprintf(out, "Resolved %d hash collision(s) by bumping the nonce\n", len(collisions))
+				}
+				fmt.Fprintln(out)
+				fmt.Fprintln(out, "Preview of mapping (first 10):")
 				count := 0
 				for old, new := range mapping {
 					if count >= 10 {
-						fmt.Printf("... and %d more\n", len(mapping)-10)
+						fmt.Fprintf(out, "... and %d more\n", len(mapping)-10)
 						break
 					}
-					fmt.Printf("  %s → %s\n", old, new)
+					fmt.Fprintf(out, "  %s → %s\n", old, new)
 					count++
 				}
 			} else {
-				color.Green("\n✓ Migration complete!\n\n")
-				fmt.Printf("Migrated %d issues to hash-based IDs\n", len(mapping))
-				fmt.Println("\nNext steps:")
-				fmt.Println("  1. Run 'bd export' to update JSONL file")
-				fmt.Println("  2. Commit changes to git")
-				fmt.Println("  3. Notify team members to pull and re-initialize")
+				color.New(color.FgGreen).Fprintf(out, "\n✓ Migration complete!\n\n")
+				fmt.Fprintf(out, "Migrated %d issues to hash-based IDs\n", len(mapping))
+				if len(collisions) > 0 {
+					fmt.Fprintf(out, "Resolved %d hash collision(s) by bumping the nonce\n", len(collisions))
+				}
+				fmt.Fprintln(out, "\nNext steps:")
+				fmt.Fprintln(out, "  1. Run 'bd export' to update JSONL file")
+				fmt.Fprintln(out, "  2. Commit changes to git")
+				fmt.Fprintln(out, "  3. Notify team members to pull and re-initialize")
 			}
 		}
 	},
 }
 
-// migrateToHashIDs performs the actual migration
-func migrateToHashIDs(ctx context.Context, store *sqlite.SQLiteStorage, issues []*types.Issue, dryRun bool) (map[string]string, error) {
+// migrateToHashIDs performs the actual migration. With atomic set, all ID
+// changes are applied in one transaction and rolled back together on failure.
+func migrateToHashIDs(ctx context.Context, store *sqlite.SQLiteStorage, issues []*types.Issue, dryRun bool, atomic bool) (map[string]string, []hashCollision, error) {
 	// Build dependency graph to determine top-level vs child issues
 	parentMap := make(map[string]string) // child ID → parent ID
 	
@@ -195,7 +322,7 @@ func migrateToHashIDs(ctx context.Context, store *sqlite.SQLiteStorage, issues [
 	for _, issue := range issues {
 		deps, err := store.GetDependencyRecords(ctx, issue.ID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get dependencies for %s: %w", issue.ID, err)
+			return nil, nil, fmt.Errorf("failed to get dependencies for %s: %w", issue.ID, err)
 		}
 		
 		for _, dep := range deps {
@@ -212,83 +339,324 @@ func migrateToHashIDs(ctx context.Context, store *sqlite.SQLiteStorage, issues [
 		prefix = "bd"
 	}
 	
-	// Generate mapping: old ID → new hash ID
+	// Sort issues by ID so collisions resolve the same way on every run
+	// (children are numbered by creation time, see buildHashIDMapping)
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].ID < issues[j].ID
+	})
+	
+	// IDs found taken only when applying, e.g. by an issue created meanwhile
+	reserved := make(map[string]bool)
+	for attempt := 0; ; attempt++ {
+		// Generate mapping: old ID → new hash ID
+		mapping, collisions, err := buildHashIDMapping(prefix, issues, parentMap, reserved)
+		if err != nil {
+			return nil, nil, err
+		}
+		
+		if dryRun {
+			return mapping, collisions, nil
+		}
+		
+		// Apply the migration
+		// UpdateIssueID handles updating the issue, dependencies, comments, events, labels, and dirty_issues
+		// We need to also update text references in descriptions, notes, design, acceptance criteria
+		
+		apply := func(tx storage.Transaction) error {
+			return applyHashIDMapping(ctx, tx, issues, mapping)
+		}
+		if atomic {
+			err = store.WithTx(ctx, apply)
+		} else {
+			err = apply(store)
+		}
+		
+		// UpdateIssueID refuses to rename onto an existing issue. The atomic
+		// migration was rolled back, so bump past the taken ID and retry.
+		var exists *storage.IDExistsError
+		if errors.As(err, &exists) && atomic && attempt < maxHashIDApplyRetries {
+			reserved[exists.ID] = true
+			continue
+		}
+		if err != nil {
+			if errors.As(err, &exists) && !atomic {
+				return nil, nil, fmt.Errorf("%w (hash ID taken since the migration started; run again to continue, or drop --atomic=false)", err)
+			}
+			return nil, nil, err
+		}
+		
+		return mapping, collisions, nil
+	}
+}
+
+// maxHashIDApplyRetries bounds how often an atomic migration is retried after
+// finding a generated ID taken
+const maxHashIDApplyRetries = 10
+
+// hashCollision records a generated hash ID that was already taken, and the
+// nonce that resolved it
+type hashCollision struct {
+	OldID      string `json:"old_id"`
+	HashID     string `json:"hash_id"`  // The colliding ID
+	TakenBy    string `json:"taken_by"` // Old ID of the issue that already has HashID
+	Nonce      int    `json:"nonce"`
+	ResolvedID string `json:"resolved_id"`
+}
+
+// buildHashIDMapping assigns hash IDs to top-level issues and hierarchical
+// IDs to their children. Top-level issues keep their own prefix, so issues
+// with per-type prefixes stay under them; prefix is the fallback for IDs
+// without one. A hash that is already taken (by another issue's hash
+// or an existing ID) is regenerated with the next nonce until it is unique.
+// Issues that already have hash IDs (in a partially migrated database) keep them.
+// IDs in reserved are treated as taken too.
+func buildHashIDMapping(prefix string, issues []*types.Issue, parentMap map[string]string, reserved map[string]bool) (map[string]string, []hashCollision, error) {
 	mapping := make(map[string]string)
 	childCounters := make(map[string]int) // parent hash ID → next child number
+	var collisions []hashCollision
+	
+	// Existing IDs are taken too: a non-atomic migration renames one issue at a time
+	takenBy := make(map[string]string, len(issues)) // new ID → old ID holding it
+	for id := range reserved {
+		takenBy[id] = id
+	}
+	for _, issue := range issues {
+		takenBy[issue.ID] = issue.ID
+		if !isHashID(issue.ID) {
+			continue
+		}
+		mapping[issue.ID] = issue.ID
+		// Number new children after existing ones
+		if dot := strings.LastIndex(issue.ID, "."); dot > 0 {
+			if n, err := strconv.Atoi(issue.ID[dot+1:]); err == nil && n > childCounters[issue.ID[:dot]] {
+				childCounters[issue.ID[:dot]] = n
+			}
+		}
+	}
 	
 	// First pass: generate hash IDs for top-level issues (no parent)
 	for _, issue := range issues {
-		if _, hasParent := parentMap[issue.ID]; !hasParent {
-			// Top-level issue - generate hash ID
-			hashID := generateHashIDForIssue(prefix, issue)
-			mapping[issue.ID] = hashID
+		if _, hasParent := parentMap[issue.ID]; hasParent {
+			continue
 		}
+		if _, mapped := mapping[issue.ID]; mapped {
+			continue
+		}
+		nonce := 0
+		issuePrefix := idPrefixOf(issue.ID, prefix)
+		hashID := generateHashIDForIssue(issuePrefix, issue, nonce)
+		for {
+			holder, taken := takenBy[hashID]
+			if !taken {
+				break
+			}
+			nonce++
+			resolved := generateHashIDForIssue(issuePrefix, issue, nonce)
+			collisions = append(collisions, hashCollision{
+				OldID:      issue.ID,
+				HashID:     hashID,
+				TakenBy:    holder,
+				Nonce:      nonce,
+				ResolvedID: resolved,
+			})
+			hashID = resolved
+		}
+		mapping[issue.ID] = hashID
+		takenBy[hashID] = issue.ID
 	}
 	
-	// Second pass: assign hierarchical IDs to child issues
+	// Second pass: assign hierarchical IDs to child issues, oldest first so
+	// .1 is the first child created. Each round maps the children whose
+	// parent already has an ID, so grandchildren follow their parents.
+	var children []*types.Issue
 	for _, issue := range issues {
-		if parentID, hasParent := parentMap[issue.ID]; hasParent {
+		if _, hasParent := parentMap[issue.ID]; !hasParent {
+			continue
+		}
+		if _, mapped := mapping[issue.ID]; !mapped {
+			children = append(children, issue)
+		}
+	}
+	sort.SliceStable(children, func(i, j int) bool {
+		if !children[i].CreatedAt.Equal(children[j].CreatedAt) {
+			return children[i].CreatedAt.Before(children[j].CreatedAt)
+		}
+		return children[i].ID < children[j].ID
+	})
+	for len(children) > 0 {
+		var pending []*types.Issue
+		for _, issue := range children {
 			// Child issue - use parent's hash ID + sequential number
-			parentHashID, ok := mapping[parentID]
+			parentHashID, ok := mapping[parentMap[issue.ID]]
 			if !ok {
-				return nil, fmt.Errorf("parent %s not yet mapped for child %s", parentID, issue.ID)
+				pending = append(pending, issue)
+				continue
 			}
 			
-			// Get next child number for this parent
-			childNum := childCounters[parentHashID] + 1
-			childCounters[parentHashID] = childNum
+			// Get next free child number for this parent
+			childID := ""
+			for {
+				childCounters[parentHashID]++
+				childID = fmt.Sprintf("%s.%d", parentHashID, childCounters[parentHashID])
+				if _, taken := takenBy[childID]; !taken {
+					break
+				}
+			}
 			
 			// Assign hierarchical ID
-			mapping[issue.ID] = fmt.Sprintf("%s.%d", parentHashID, childNum)
+			mapping[issue.ID] = childID
+			takenBy[childID] = issue.ID
+		}
+		if len(pending) == len(children) {
+			issue := pending[0]
+			return nil, nil, fmt.Errorf("parent %s not yet mapped for child %s", parentMap[issue.ID], issue.ID)
 		}
+		children = pending
 	}
 	
-	if dryRun {
-		return mapping, nil
+	// Only report issues whose ID actually changes
+	for oldID, newID := range mapping {
+		if oldID == newID {
+			delete(mapping, oldID)
+		}
 	}
 	
-	// Apply the migration
-	// UpdateIssueID handles updating the issue, dependencies, comments, events, labels, and dirty_issues
-	// We need to also update text references in descriptions, notes, design, acceptance criteria
-	
-	// Sort issues by ID to process parents before children
-	sort.Slice(issues, func(i, j int) bool {
-		return issues[i].ID < issues[j].ID
-	})
-	
+	return mapping, collisions, nil
+}
+
+// applyHashIDMapping renames each issue to its mapped ID, rewriting text
+// references to other mapped IDs along the way
+func applyHashIDMapping(ctx context.Context, tx storage.Transaction, issues []*types.Issue, mapping map[string]string) error {
+	return applyIDMapping(ctx, tx, issues, mapping, idRefPattern(mapping, `\d+`))
+}
+
+// applyIDMapping renames each issue to its mapped ID (if any), rewriting the
+// references matched by refPattern in its text fields and comments
+func applyIDMapping(ctx context.Context, tx storage.Transaction, issues []*types.Issue, mapping map[string]string, refPattern *regexp.Regexp) error {
 	// Update all issues
 	for _, issue := range issues {
-		newID := mapping[issue.ID]
+		newID, ok := mapping[issue.ID]
+		if !ok {
+			newID = issue.ID // Already a hash ID; still rewrite its references
+		}
 		
 		// Update text references in this issue
-		issue.Description = replaceIDReferences(issue.Description, mapping)
+		issue.Description = replaceIDReferencesMatching(refPattern, issue.Description, mapping)
 		if issue.Design != "" {
-			issue.Design = replaceIDReferences(issue.Design, mapping)
+			issue.Design = replaceIDReferencesMatching(refPattern, issue.Design, mapping)
 		}
 		if issue.Notes != "" {
-			issue.Notes = replaceIDReferences(issue.Notes, mapping)
+			issue.Notes = replaceIDReferencesMatching(refPattern, issue.Notes, mapping)
 		}
 		if issue.AcceptanceCriteria != "" {
-			issue.AcceptanceCriteria = replaceIDReferences(issue.AcceptanceCriteria, mapping)
+			issue.AcceptanceCriteria = replaceIDReferencesMatching(refPattern, issue.AcceptanceCriteria, mapping)
 		}
 		if issue.ExternalRef != nil {
-			updated := replaceIDReferences(*issue.ExternalRef, mapping)
+			updated := replaceExternalRefIDs(refPattern, *issue.ExternalRef, mapping)
 			issue.ExternalRef = &updated
 		}
 		
+		// Rewrite references in comment bodies (UpdateIssueID moves the
+		// comments to the new ID but leaves their text alone)
+		comments, err := tx.GetIssueComments(ctx, issue.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get comments for %s: %w", issue.ID, err)
+		}
+		for _, comment := range comments {
+			if updated := replaceIDReferencesMatching(refPattern, comment.Text, mapping); updated != comment.Text {
+				if _, err := tx.UpdateComment(ctx, comment.ID, updated); err != nil {
+					return fmt.Errorf("failed to update comment %d on %s: %w", comment.ID, issue.ID, err)
+				}
+			}
+		}
+		
 		// Use UpdateIssueID to change the primary key and cascade to all foreign keys
 		// This method handles dependencies, comments, events, labels, and dirty_issues
 		oldID := issue.ID
-		if err := store.UpdateIssueID(ctx, oldID, newID, issue, "migration"); err != nil {
-			return nil, fmt.Errorf("failed to update issue %s → %s: %w", oldID, newID, err)
+		if err := tx.UpdateIssueID(ctx, oldID, newID, issue, "migration"); err != nil {
+			return fmt.Errorf("failed to update issue %s → %s: %w", oldID, newID, err)
 		}
 	}
 	
-	return mapping, nil
+	return nil
+}
+
+// revertHashIDs restores the sequential IDs in a saved migration mapping
+// (old → new). Nothing is changed unless every hash ID in the mapping exists,
+// every hash ID in the database is in the mapping, and no sequential ID being
+// restored is taken. Returns the applied hash → sequential mapping.
+func revertHashIDs(ctx context.Context, store *sqlite.SQLiteStorage, issues []*types.Issue, mapping map[string]string, dryRun bool, atomic bool) (map[string]string, error) {
+	inverted := make(map[string]string, len(mapping))
+	for oldID, newID := range mapping {
+		if prev, dup := inverted[newID]; dup {
+			return nil, fmt.Errorf("mapping file maps both %s and %s to %s", prev, oldID, newID)
+		}
+		inverted[newID] = oldID
+	}
+	
+	existing := make(map[string]bool, len(issues))
+	var unmapped []string
+	for _, issue := range issues {
+		existing[issue.ID] = true
+		if _, ok := inverted[issue.ID]; !ok && isHashID(issue.ID) {
+			unmapped = append(unmapped, issue.ID)
+		}
+	}
+	if len(unmapped) > 0 {
+		sort.Strings(unmapped)
+		return nil, fmt.Errorf("database has %d hash ID(s) not in the mapping file (created after the migration?): %s", len(unmapped), strings.Join(unmapped, ", "))
+	}
+	
+	var missing, taken []string
+	for newID, oldID := range inverted {
+		if !existing[newID] {
+			missing = append(missing, newID)
+		}
+		if _, renamed := inverted[oldID]; existing[oldID] && !renamed {
+			taken = append(taken, oldID)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("%d hash ID(s) in the mapping file don't exist in the database: %s", len(missing), strings.Join(missing, ", "))
+	}
+	if len(taken) > 0 {
+		sort.Strings(taken)
+		return nil, fmt.Errorf("%d sequential ID(s) to restore are already in use: %s", len(taken), strings.Join(taken, ", "))
+	}
+	
+	if dryRun {
+		return inverted, nil
+	}
+	
+	// Parents before children: a child's ID extends its parent's with .N
+	sort.Slice(issues, func(i, j int) bool {
+		di, dj := strings.Count(issues[i].ID, "."), strings.Count(issues[j].ID, ".")
+		if di != dj {
+			return di < dj
+		}
+		return issues[i].ID < issues[j].ID
+	})
+	
+	apply := func(tx storage.Transaction) error {
+		return applyIDMapping(ctx, tx, issues, inverted, idRefPattern(inverted, `[0-9a-z]{3,8}`))
+	}
+	var err error
+	if atomic {
+		err = store.WithTx(ctx, apply)
+	} else {
+		err = apply(store)
+	}
+	if err != nil {
+		return nil, err
+	}
+	
+	return inverted, nil
 }
 
-// generateHashIDForIssue generates a hash-based ID for an issue
-func generateHashIDForIssue(prefix string, issue *types.Issue) string {
+// generateHashIDForIssue generates a hash-based ID for an issue. Bump nonce
+// to get a different ID for the same content.
+func generateHashIDForIssue(prefix string, issue *types.Issue, nonce int) string {
 	// Use the same algorithm as generateHashID in sqlite.go
 	// Use "system" as the actor for migration to ensure deterministic IDs
 	content := fmt.Sprintf("%s|%s|%s|%d|%d",
@@ -296,7 +664,7 @@ func generateHashIDForIssue(prefix string, issue *types.Issue) string {
 		issue.Description,
 		"system", // Use consistent actor for migration
 		issue.CreatedAt.UnixNano(),
-		0, // nonce
+		nonce,
 	)
 	
 	hash := sha256Hash(content)
@@ -311,12 +679,45 @@ func sha256Hash(content string) string {
 	return hex.EncodeToString(h[:4]) // 4 bytes = 8 hex chars
 }
 
+// idPrefixOf returns the prefix of an ID like "bd-123" or "my-proj-a3f8.1"
+// (everything before the last dash of the top-level part), or fallback if it
+// has no dash
+func idPrefixOf(id, fallback string) string {
+	if dot := strings.Index(id, "."); dot >= 0 {
+		id = id[:dot]
+	}
+	if dash := strings.LastIndex(id, "-"); dash > 0 {
+		return id[:dash]
+	}
+	return fallback
+}
+
+// idRefPattern matches references to IDs under any prefix of the mapping's
+// old IDs, like "bd-123" and "epic-45.1" for a sequential idSegment of `\d+`
+func idRefPattern(mapping map[string]string, idSegment string) *regexp.Regexp {
+	seen := map[string]bool{}
+	var prefixes []string
+	for oldID := range mapping {
+		if prefix := idPrefixOf(oldID, ""); prefix != "" && !seen[prefix] {
+			seen[prefix] = true
+			prefixes = append(prefixes, regexp.QuoteMeta(prefix))
+		}
+	}
+	if len(prefixes) == 0 {
+		prefixes = []string{"bd"}
+	}
+	sort.Strings(prefixes)
+	return regexp.MustCompile(`\b(?:` + strings.Join(prefixes, "|") + `)-` + idSegment + `(?:\.\d+)*\b`)
+}
+
 // replaceIDReferences replaces all old ID references with new hash IDs
 func replaceIDReferences(text string, mapping map[string]string) string {
-	// Match patterns like "bd-123" or "bd-123.4"
-	re := regexp.MustCompile(`\bbd-\d+(?:\.\d+)*\b`)
-	
-	return re.ReplaceAllStringFunc(text, func(match string) string {
+	return replaceIDReferencesMatching(idRefPattern(mapping, `\d+`), text, mapping)
+}
+
+// replaceIDReferencesMatching replaces the IDs matched by pattern that appear in mapping
+func replaceIDReferencesMatching(pattern *regexp.Regexp, text string, mapping map[string]string) string {
+	return pattern.ReplaceAllStringFunc(text, func(match string) string {
 		if newID, ok := mapping[match]; ok {
 			return newID
 		}
@@ -324,47 +725,118 @@ func replaceIDReferences(text string, mapping map[string]string) string {
 	})
 }
 
-// isHashID checks if an ID is hash-based (not sequential)
-func isHashID(id string) bool {
-	// Hash IDs contain hex letters (a-f), sequential IDs are only digits
-	// May have hierarchical suffix like .1 or .1.2
-	parts := strings.SplitN(id, "-", 2)
-	if len(parts) != 2 {
-		return false
+// replaceExternalRefIDs replaces mapped IDs in an external ref. Refs holding
+// a JSON object or array have only their string values rewritten, so
+// the structure survives; anything else is treated as plain text.
+func replaceExternalRefIDs(pattern *regexp.Regexp, ref string, mapping map[string]string) string {
+	trimmed := strings.TrimSpace(ref)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return replaceIDReferencesMatching(pattern, ref, mapping)
 	}
-	
-	suffix := parts[1]
-	// Strip hierarchical suffix like .1 or .1.2
-	baseSuffix := strings.Split(suffix, ".")[0]
-	
-	if len(baseSuffix) == 0 {
-		return false
+	decoder := json.NewDecoder(strings.NewReader(trimmed))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return replaceIDReferencesMatching(pattern, ref, mapping)
 	}
 	
-	// Must be valid Base36 (0-9, a-z)
-	if !regexp.MustCompile(`^[0-9a-z]+$`).MatchString(baseSuffix) {
-		return false
+	changed := false
+	var walk func(v interface{}) interface{}
+	walk = func(v interface{}) interface{} {
+		switch v := v.(type) {
+		case string:
+			updated := replaceIDReferencesMatching(pattern, v, mapping)
+			if updated != v {
+				changed = true
+			}
+			return updated
+		case map[string]interface{}:
+			for key, item := range v {
+				v[key] = walk(item)
+			}
+		case []interface{}:
+			for i, item := range v {
+				v[i] = walk(item)
+			}
+		}
+		return v
 	}
-	
-	// If it's 5+ characters long, it's almost certainly a hash ID
-	// (sequential IDs rarely exceed 9999 = 4 digits)
-	if len(baseSuffix) >= 5 {
-		return true
+	value = walk(value)
+	if !changed {
+		return ref // Keep the original formatting
 	}
 	
-	// For shorter IDs, check if it contains any letter (a-z)
-	// Sequential IDs are purely numeric
-	return regexp.MustCompile(`[a-z]`).MatchString(baseSuffix)
+	var buf strings.Builder
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return ref
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
 }
 
-// saveMappingFile saves the ID mapping to a JSON file
-func saveMappingFile(path string, mapping map[string]string) error {
-	// Convert to sorted array for readability
-	type mappingEntry struct {
-		OldID string `json:"old_id"`
-		NewID string `json:"new_id"`
+// countIDReferences counts the references to mapped IDs that pattern matches in text
+func countIDReferences(pattern *regexp.Regexp, text string, mapping map[string]string) int {
+	count := 0
+	for _, match := range pattern.FindAllString(text, -1) {
+		if _, ok := mapping[match]; ok {
+			count++
+		}
 	}
-	
+	return count
+}
+
+// countCommentIDReferences counts the ID references in the issues' comments
+// that the migration would rewrite, for the dry-run report
+func countCommentIDReferences(ctx context.Context, store storage.Storage, issues []*types.Issue, mapping map[string]string) (int, error) {
+	count := 0
+	pattern := idRefPattern(mapping, `\d+`)
+	for _, issue := range issues {
+		comments, err := store.GetIssueComments(ctx, issue.ID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get comments for %s: %w", issue.ID, err)
+		}
+		for _, comment := range comments {
+			count += countIDReferences(pattern, comment.Text, mapping)
+		}
+	}
+	return count, nil
+}
+
+// hashIDPattern matches the shape of a hash ID: prefix (which may contain
+// dashes), a dash, 3-8 base36 chars (the adaptive hash length range), and
+// optional hierarchical .N segments
+var hashIDPattern = regexp.MustCompile(`^(.+)-([0-9a-z]{3,8})((?:\.[0-9]+)*)$`)
+
+// isHashID checks if an ID is hash-based (not sequential). Only the segment
+// after the last dash is considered, so dashes or hex letters in the prefix
+// don't matter. Purely numeric segments are sequential.
+func isHashID(id string) bool {
+	m := hashIDPattern.FindStringSubmatch(id)
+	if m == nil {
+		return false
+	}
+	return strings.ContainsAny(m[2], "abcdefghijklmnopqrstuvwxyz")
+}
+
+// hasSequentialIDs reports whether any issue still has a sequential ID
+func hasSequentialIDs(issues []*types.Issue) bool {
+	for _, issue := range issues {
+		if !isHashID(issue.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+// mappingEntry is one old → new ID pair in the migration mapping
+type mappingEntry struct {
+	OldID string `json:"old_id"`
+	NewID string `json:"new_id"`
+}
+
+// sortedMappingEntries converts the mapping to an array sorted by old ID for readability
+func sortedMappingEntries(mapping map[string]string) []mappingEntry {
 	entries := make([]mappingEntry, 0, len(mapping))
 	for old, new := range mapping {
 		entries = append(entries, mappingEntry{
@@ -377,7 +849,24 @@ func saveMappingFile(path string, mapping map[string]string) error {
 	sort.Slice(entries, func(i, j int) bool {
 		return entries[i].OldID < entries[j].OldID
 	})
-	
+	return entries
+}
+
+// writeMappingJSON writes the complete ID mapping as a single JSON document
+func writeMappingJSON(w io.Writer, mapping map[string]string, dryRun bool) error {
+	entries := sortedMappingEntries(mapping)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]interface{}{
+		"dry_run": dryRun,
+		"count":   len(entries),
+		"mapping": entries,
+	})
+}
+
+// saveMappingFile saves the ID mapping to a JSON file
+func saveMappingFile(path string, mapping map[string]string) error {
+	entries := sortedMappingEntries(mapping)
 	data, err := json.MarshalIndent(map[string]interface{}{
 		"migrated_at": time.Now().Format(time.RFC3339),
 		"count":       len(entries),
@@ -391,6 +880,32 @@ func saveMappingFile(path string, mapping map[string]string) error {
 	return os.WriteFile(path, data, 0644)
 }
 
+// loadMappingFile reads a mapping saved by saveMappingFile, returning old → new IDs
+func loadMappingFile(path string) (map[string]string, error) {
+	// nolint:gosec // G304: path is provided by the user running the revert
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+	var doc struct {
+		Mapping []mappingEntry `json:"mapping"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file %s: %w", path, err)
+	}
+	if len(doc.Mapping) == 0 {
+		return nil, fmt.Errorf("mapping file %s has no entries", path)
+	}
+	mapping := make(map[string]string, len(doc.Mapping))
+	for _, entry := range doc.Mapping {
+		if entry.OldID == "" || entry.NewID == "" {
+			return nil, fmt.Errorf("mapping file %s has an incomplete entry: %+v", path, entry)
+		}
+		mapping[entry.OldID] = entry.NewID
+	}
+	return mapping, nil
+}
+
 // copyFile copies a file from src to dst
 func copyFile(src, dst string) error {
 	// nolint:gosec // G304: src is validated migration backup path
@@ -404,5 +919,8 @@ func copyFile(src, dst string) error {
 
 func init() {
 	migrateHashIDsCmd.Flags().Bool("dry-run", false, "Show what would be done without making changes")
+	migrateHashIDsCmd.Flags().Bool("emit-mapping", false, "Write the complete ID mapping to stdout as JSON (status messages go to stderr)")
+	migrateHashIDsCmd.Flags().Bool("atomic", true, "Apply all ID changes in a single transaction, rolling back on any failure (--atomic=false applies them one at a time)")
+	migrateHashIDsCmd.Flags().String("revert", "", "Restore sequential IDs from a saved mapping file (e.g. .beads/hash-id-mapping.json)")
 	rootCmd.AddCommand(migrateHashIDsCmd)
 }
@@ -1,11 +1,18 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -77,7 +84,7 @@ func TestMigrateHashIDs(t *testing.T) {
 		t.Fatalf("Failed to get issues: %v", err)
 	}
 
-	mapping, err := migrateToHashIDs(ctx, store, issues, true)
+	mapping, _, err := migrateToHashIDs(ctx, store, issues, true, false)
 	if err != nil {
 		t.Fatalf("Dry run failed: %v", err)
 	}
@@ -115,7 +122,7 @@ func TestMigrateHashIDs(t *testing.T) {
 		t.Fatalf("Failed to get issues: %v", err)
 	}
 
-	mapping, err = migrateToHashIDs(ctx, store, issues, false)
+	mapping, _, err = migrateToHashIDs(ctx, store, issues, false, false)
 	if err != nil {
 		t.Fatalf("Migration failed: %v", err)
 	}
@@ -227,7 +234,7 @@ func TestMigrateHashIDsWithParentChild(t *testing.T) {
 		t.Fatalf("Failed to get issues: %v", err)
 	}
 
-	mapping, err := migrateToHashIDs(ctx, store, issues, false)
+	mapping, _, err := migrateToHashIDs(ctx, store, issues, false, false)
 	if err != nil {
 		t.Fatalf("Migration failed: %v", err)
 	}
@@ -246,6 +253,134 @@ func TestMigrateHashIDsWithParentChild(t *testing.T) {
 	}
 }
 
+func TestMigrateHashIDsAtomic(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+
+	store, err := sqlite.New(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+	for _, id := range []string{"bd-1", "bd-2", "bd-3"} {
+		issue := &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("Failed to create %s: %v", id, err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("Failed to add dependency: %v", err)
+	}
+
+	var issues []*types.Issue
+	for _, id := range []string{"bd-1", "bd-2"} {
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			t.Fatalf("Failed to get %s: %v", id, err)
+		}
+		issues = append(issues, issue)
+	}
+
+	// bd-2 → bd-3 collides with an existing issue after bd-1 was already renamed
+	bad := map[string]string{"bd-1": "bd-aaaaaaaa", "bd-2": "bd-3"}
+	err = store.WithTx(ctx, func(tx storage.Transaction) error {
+		return applyHashIDMapping(ctx, tx, issues, bad)
+	})
+	if err == nil {
+		t.Fatal("Expected colliding rename to fail")
+	}
+	if issue, _ := store.GetIssue(ctx, "bd-1"); issue == nil {
+		t.Fatal("Expected bd-1 rename to be rolled back")
+	}
+	if issue, _ := store.GetIssue(ctx, "bd-aaaaaaaa"); issue != nil {
+		t.Error("Expected no partially migrated issues")
+	}
+
+	issues, err = store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("Failed to get issues: %v", err)
+	}
+	mapping, _, err := migrateToHashIDs(ctx, store, issues, false, true)
+	if err != nil {
+		t.Fatalf("Atomic migration failed: %v", err)
+	}
+	deps, err := store.GetDependencyRecords(ctx, mapping["bd-2"])
+	if err != nil {
+		t.Fatalf("Failed to get dependencies: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != mapping["bd-1"] {
+		t.Errorf("Expected dependency on %s, got %+v", mapping["bd-1"], deps)
+	}
+}
+
+func TestMigrateHashIDsRollsBackMidMigrationFailure(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+
+	store, err := sqlite.New(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+	for _, id := range []string{"bd-1", "bd-2", "bd-3"} {
+		issue := &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("Failed to create %s: %v", id, err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: "bd-3", DependsOnID: "bd-1", Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("Failed to add dependency: %v", err)
+	}
+
+	// Issues are renamed in ID order, so renaming bd-2 fails after bd-1 is done
+	if _, err := store.UnderlyingDB().ExecContext(ctx, `
+		CREATE TRIGGER fail_migration BEFORE UPDATE OF id ON issues
+		WHEN OLD.id = 'bd-2'
+		BEGIN SELECT RAISE(ABORT, 'injected failure'); END
+	`); err != nil {
+		t.Fatalf("Failed to create trigger: %v", err)
+	}
+
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("Failed to get issues: %v", err)
+	}
+	_, _, err = migrateToHashIDs(ctx, store, issues, false, true)
+	if err == nil || !strings.Contains(err.Error(), "injected failure") {
+		t.Fatalf("Expected the injected failure, got %v", err)
+	}
+
+	after, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("Failed to get issues: %v", err)
+	}
+	var ids []string
+	for _, issue := range after {
+		ids = append(ids, issue.ID)
+	}
+	sort.Strings(ids)
+	if want := []string{"bd-1", "bd-2", "bd-3"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("IDs after failed migration = %v, want %v unchanged", ids, want)
+	}
+	deps, err := store.GetDependencyRecords(ctx, "bd-3")
+	if err != nil {
+		t.Fatalf("Failed to get dependencies: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != "bd-1" {
+		t.Errorf("Expected bd-3 to still depend on bd-1, got %+v", deps)
+	}
+}
+
 func TestIsHashID(t *testing.T) {
 	tests := []struct {
 		id       string
@@ -258,6 +393,18 @@ func TestIsHashID(t *testing.T) {
 		{"bd-123abc", true},
 		{"bd-a3f8e9a2.1", true},
 		{"bd-a3f8e9a2.1.2", true},
+		{"bd-12345", false},
+		{"bd-12345678", false},
+		{"feat-12", false},
+		{"cafe-12.3", false},
+		{"my-proj-12", false},
+		{"my-proj-a3f8", true},
+		{"bd-ab", false},
+		{"bd-a3f8e9a2b", false},
+		{"bd-A3F8", false},
+		{"bd-a3f8.x", false},
+		{"bd-", false},
+		{"a3f8e9a2", false},
 	}
 
 	for _, tt := range tests {
@@ -294,3 +441,384 @@ func TestCopyFile(t *testing.T) {
 		t.Errorf("Content mismatch: got %s, want %s", copied, content)
 	}
 }
+
+func TestWriteMappingJSON(t *testing.T) {
+	mapping := map[string]string{
+		"bd-2": "bd-b2c3d4e5",
+		"bd-1": "bd-a1b2c3d4",
+		"bd-3": "bd-a1b2c3d4.1",
+	}
+
+	var buf bytes.Buffer
+	if err := writeMappingJSON(&buf, mapping, true); err != nil {
+		t.Fatalf("writeMappingJSON failed: %v", err)
+	}
+
+	var doc struct {
+		DryRun  bool           `json:"dry_run"`
+		Count   int            `json:"count"`
+		Mapping []mappingEntry `json:"mapping"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not a single JSON document: %v\n%s", err, buf.String())
+	}
+	if !doc.DryRun || doc.Count != 3 || len(doc.Mapping) != 3 {
+		t.Fatalf("unexpected document: %+v", doc)
+	}
+	if doc.Mapping[0].OldID != "bd-1" || doc.Mapping[0].NewID != "bd-a1b2c3d4" {
+		t.Errorf("expected entries sorted by old ID, got %+v", doc.Mapping)
+	}
+}
+
+func TestMigrateHashIDsRetriesTakenID(t *testing.T) {
+	store := newTestStore(t, filepath.Join(t.TempDir(), "test.db"))
+	defer store.Close()
+
+	ctx := context.Background()
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+	for _, id := range []string{"bd-1", "bd-2"} {
+		issue := &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("Failed to create %s: %v", id, err)
+		}
+	}
+	var issues []*types.Issue
+	for _, id := range []string{"bd-1", "bd-2"} {
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			t.Fatalf("Failed to get %s: %v", id, err)
+		}
+		issues = append(issues, issue)
+	}
+
+	// Another issue takes bd-1's planned hash ID after the issues were loaded
+	planned, _, err := buildHashIDMapping("bd", issues, map[string]string{}, nil)
+	if err != nil {
+		t.Fatalf("buildHashIDMapping failed: %v", err)
+	}
+	squatter := &types.Issue{ID: planned["bd-1"], Title: "Created meanwhile", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, squatter, "test"); err != nil {
+		t.Fatalf("Failed to create %s: %v", squatter.ID, err)
+	}
+
+	mapping, _, err := migrateToHashIDs(ctx, store, issues, false, true)
+	if err != nil {
+		t.Fatalf("Expected the atomic migration to retry past the taken ID, got %v", err)
+	}
+	if mapping["bd-1"] == planned["bd-1"] || !isHashID(mapping["bd-1"]) {
+		t.Errorf("Expected bd-1 to get a different hash ID than %s, got %s", planned["bd-1"], mapping["bd-1"])
+	}
+	if issue, _ := store.GetIssue(ctx, squatter.ID); issue == nil || issue.Title != "Created meanwhile" {
+		t.Errorf("Expected %s to be left alone, got %+v", squatter.ID, issue)
+	}
+	if issue, _ := store.GetIssue(ctx, mapping["bd-1"]); issue == nil || issue.Title != "Issue bd-1" {
+		t.Errorf("Expected bd-1 migrated to %s, got %+v", mapping["bd-1"], issue)
+	}
+}
+
+func TestMigrateHashIDsRewritesComments(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+
+	store, err := sqlite.New(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+	for _, id := range []string{"bd-1", "bd-2"} {
+		issue := &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("Failed to create %s: %v", id, err)
+		}
+	}
+	comment, err := store.AddIssueComment(ctx, "bd-2", "alice", "Duplicate of bd-1, see also bd-1 and bd-99")
+	if err != nil {
+		t.Fatalf("Failed to add comment: %v", err)
+	}
+
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("Failed to get issues: %v", err)
+	}
+
+	// Dry run counts the two bd-1 references but not the unmapped bd-99
+	mapping, _, err := migrateToHashIDs(ctx, store, issues, true, false)
+	if err != nil {
+		t.Fatalf("Dry run failed: %v", err)
+	}
+	refs, err := countCommentIDReferences(ctx, store, issues, mapping)
+	if err != nil {
+		t.Fatalf("Failed to count comment references: %v", err)
+	}
+	if refs != 2 {
+		t.Errorf("Expected 2 comment references, got %d", refs)
+	}
+
+	mapping, _, err = migrateToHashIDs(ctx, store, issues, false, false)
+	if err != nil {
+		t.Fatalf("Migration failed: %v", err)
+	}
+
+	comments, err := store.GetIssueComments(ctx, mapping["bd-2"])
+	if err != nil {
+		t.Fatalf("Failed to get comments: %v", err)
+	}
+	if len(comments) != 1 || comments[0].ID != comment.ID {
+		t.Fatalf("Expected the comment to move to %s, got %+v", mapping["bd-2"], comments)
+	}
+	want := "Duplicate of " + mapping["bd-1"] + ", see also " + mapping["bd-1"] + " and bd-99"
+	if comments[0].Text != want {
+		t.Errorf("Expected comment %q, got %q", want, comments[0].Text)
+	}
+}
+
+func TestBuildHashIDMappingResolvesCollisions(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Same", Description: "Same", CreatedAt: createdAt},
+		{ID: "bd-2", Title: "Same", Description: "Same", CreatedAt: createdAt},
+		{ID: "bd-3", Title: "Child", CreatedAt: createdAt},
+	}
+	parentMap := map[string]string{"bd-3": "bd-2"}
+
+	mapping, collisions, err := buildHashIDMapping("bd", issues, parentMap, nil)
+	if err != nil {
+		t.Fatalf("buildHashIDMapping failed: %v", err)
+	}
+	if mapping["bd-1"] == mapping["bd-2"] {
+		t.Fatalf("Expected distinct IDs for identical issues, both got %s", mapping["bd-1"])
+	}
+	if mapping["bd-3"] != mapping["bd-2"]+".1" {
+		t.Errorf("Expected child of the resolved ID, got %s", mapping["bd-3"])
+	}
+
+	if len(collisions) != 1 {
+		t.Fatalf("Expected 1 collision, got %+v", collisions)
+	}
+	c := collisions[0]
+	if c.OldID != "bd-2" || c.TakenBy != "bd-1" || c.HashID != mapping["bd-1"] || c.Nonce != 1 || c.ResolvedID != mapping["bd-2"] {
+		t.Errorf("Unexpected collision record: %+v", c)
+	}
+
+	// Resolution is deterministic
+	again, _, err := buildHashIDMapping("bd", issues, parentMap, nil)
+	if err != nil {
+		t.Fatalf("buildHashIDMapping failed: %v", err)
+	}
+	if again["bd-2"] != mapping["bd-2"] {
+		t.Errorf("Expected the same resolved ID on rerun, got %s and %s", mapping["bd-2"], again["bd-2"])
+	}
+}
+
+func TestBuildHashIDMappingMixedDatabase(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	issues := []*types.Issue{
+		{ID: "bd-a3f8e9a2", Title: "Migrated epic", CreatedAt: createdAt},
+		{ID: "bd-a3f8e9a2.1", Title: "Migrated child", CreatedAt: createdAt},
+		{ID: "bd-7", Title: "Sequential child", CreatedAt: createdAt},
+		{ID: "bd-8", Title: "Sequential top-level", CreatedAt: createdAt},
+	}
+	if !hasSequentialIDs(issues) {
+		t.Fatal("Expected mixed database to still need migration")
+	}
+	if hasSequentialIDs(issues[:2]) {
+		t.Error("Expected fully hashed issues to count as migrated")
+	}
+
+	parentMap := map[string]string{"bd-a3f8e9a2.1": "bd-a3f8e9a2", "bd-7": "bd-a3f8e9a2"}
+	mapping, _, err := buildHashIDMapping("bd", issues, parentMap, nil)
+	if err != nil {
+		t.Fatalf("buildHashIDMapping failed: %v", err)
+	}
+	if len(mapping) != 2 {
+		t.Errorf("Expected only the sequential issues in the mapping, got %v", mapping)
+	}
+	if mapping["bd-7"] != "bd-a3f8e9a2.2" {
+		t.Errorf("Expected sequential child numbered after the existing one, got %s", mapping["bd-7"])
+	}
+	if !isHashID(mapping["bd-8"]) {
+		t.Errorf("Expected a hash ID for bd-8, got %s", mapping["bd-8"])
+	}
+}
+
+func TestBuildHashIDMappingNumbersChildrenByCreation(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	// Lexically bd-10 < bd-2 < bd-9, but bd-9 was created first
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Epic", CreatedAt: base},
+		{ID: "bd-10", Title: "Third child", CreatedAt: base.Add(3 * time.Hour)},
+		{ID: "bd-2", Title: "Second child", CreatedAt: base.Add(2 * time.Hour)},
+		{ID: "bd-9", Title: "First child", CreatedAt: base.Add(time.Hour)},
+		{ID: "bd-3", Title: "Grandchild", CreatedAt: base.Add(time.Hour)},
+	}
+	parentMap := map[string]string{"bd-10": "bd-1", "bd-2": "bd-1", "bd-9": "bd-1", "bd-3": "bd-10"}
+
+	mapping, _, err := buildHashIDMapping("bd", issues, parentMap, nil)
+	if err != nil {
+		t.Fatalf("buildHashIDMapping failed: %v", err)
+	}
+	epic := mapping["bd-1"]
+	want := map[string]string{"bd-9": epic + ".1", "bd-2": epic + ".2", "bd-10": epic + ".3", "bd-3": epic + ".3.1"}
+	for oldID, newID := range want {
+		if mapping[oldID] != newID {
+			t.Errorf("mapping[%s] = %s, want %s", oldID, mapping[oldID], newID)
+		}
+	}
+}
+
+func TestBuildHashIDMappingPerTypePrefixes(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	issues := []*types.Issue{
+		{ID: "bd-1", Title: "Task", IssueType: types.TypeTask, CreatedAt: createdAt},
+		{ID: "epic-1", Title: "Epic", IssueType: types.TypeEpic, CreatedAt: createdAt},
+		{ID: "bd-2", Title: "Child of epic", IssueType: types.TypeTask, CreatedAt: createdAt},
+	}
+	parentMap := map[string]string{"bd-2": "epic-1"}
+
+	mapping, _, err := buildHashIDMapping("bd", issues, parentMap, nil)
+	if err != nil {
+		t.Fatalf("buildHashIDMapping failed: %v", err)
+	}
+	if !strings.HasPrefix(mapping["bd-1"], "bd-") || !strings.HasPrefix(mapping["epic-1"], "epic-") {
+		t.Errorf("Expected each issue to keep its prefix, got %v", mapping)
+	}
+	if mapping["bd-2"] != mapping["epic-1"]+".1" {
+		t.Errorf("Expected the child under its parent's prefix, got %s", mapping["bd-2"])
+	}
+
+	// References under every prefix are rewritten, and same-numbered IDs
+	// under different prefixes aren't confused
+	text := "Blocks epic-1 and bd-1; unrelated other-1"
+	want := "Blocks " + mapping["epic-1"] + " and " + mapping["bd-1"] + "; unrelated other-1"
+	if got := replaceIDReferences(text, mapping); got != want {
+		t.Errorf("replaceIDReferences = %q, want %q", got, want)
+	}
+}
+
+func TestReplaceExternalRefIDs(t *testing.T) {
+	mapping := map[string]string{"bd-1": "bd-a3f8", "bd-2": "bd-b7c2"}
+	pattern := idRefPattern(mapping, `\d+`)
+	tests := []struct {
+		name string
+		ref  string
+		want string
+	}{
+		{"plain text", "gh-12 tracks bd-1", "gh-12 tracks bd-a3f8"},
+		{"plain text without references", "gh-12", "gh-12"},
+		{"JSON values", `{"github":"#123","beads":"bd-1","others":["bd-2","bd-3"]}`,
+			`{"beads":"bd-a3f8","github":"#123","others":["bd-b7c2","bd-3"]}`},
+		{"JSON keys stay", `{"bd-2":"see bd-1 & more","count":3}`, `{"bd-2":"see bd-a3f8 & more","count":3}`},
+		{"unchanged JSON keeps formatting", `{ "github": "#123" }`, `{ "github": "#123" }`},
+		{"invalid JSON is text", `{bd-1`, `{bd-a3f8`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replaceExternalRefIDs(pattern, tt.ref, mapping); got != tt.want {
+				t.Errorf("replaceExternalRefIDs(%q) = %q, want %q", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRevertHashIDs(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+
+	store, err := sqlite.New(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+	epic := &types.Issue{ID: "bd-1", Title: "Epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic}
+	child := &types.Issue{ID: "bd-2", Title: "Child", Description: "Part of bd-1", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{epic, child} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("Failed to create %s: %v", issue.ID, err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepParentChild}, "test"); err != nil {
+		t.Fatalf("Failed to add dependency: %v", err)
+	}
+	if _, err := store.AddIssueComment(ctx, "bd-2", "alice", "Blocked on bd-1"); err != nil {
+		t.Fatalf("Failed to add comment: %v", err)
+	}
+
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("Failed to get issues: %v", err)
+	}
+	mapping, _, err := migrateToHashIDs(ctx, store, issues, false, false)
+	if err != nil {
+		t.Fatalf("Migration failed: %v", err)
+	}
+	mappingPath := filepath.Join(tmpDir, "hash-id-mapping.json")
+	if err := saveMappingFile(mappingPath, mapping); err != nil {
+		t.Fatalf("Failed to save mapping: %v", err)
+	}
+	loaded, err := loadMappingFile(mappingPath)
+	if err != nil {
+		t.Fatalf("Failed to load mapping: %v", err)
+	}
+
+	// A hash ID created after the migration blocks the revert
+	extra := &types.Issue{Title: "Created later", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, extra, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	issues, _ = store.SearchIssues(ctx, "", types.IssueFilter{})
+	if _, err := revertHashIDs(ctx, store, issues, loaded, false, false); err == nil {
+		t.Fatal("Expected revert to fail with an unmapped hash ID")
+	}
+	if err := store.DeleteIssue(ctx, extra.ID); err != nil {
+		t.Fatalf("Failed to delete issue: %v", err)
+	}
+
+	// A mapped hash ID that no longer exists also blocks it
+	withMissing := map[string]string{"bd-3": "bd-deadbeef"}
+	for k, v := range loaded {
+		withMissing[k] = v
+	}
+	issues, _ = store.SearchIssues(ctx, "", types.IssueFilter{})
+	if _, err := revertHashIDs(ctx, store, issues, withMissing, false, false); err == nil {
+		t.Fatal("Expected revert to fail with a missing hash ID")
+	}
+	if issue, _ := store.GetIssue(ctx, mapping["bd-1"]); issue == nil {
+		t.Fatal("Expected failed revert to leave the database untouched")
+	}
+
+	restored, err := revertHashIDs(ctx, store, issues, loaded, false, true)
+	if err != nil {
+		t.Fatalf("Revert failed: %v", err)
+	}
+	if len(restored) != 2 || restored[mapping["bd-2"]] != "bd-2" {
+		t.Errorf("Unexpected restored mapping: %v", restored)
+	}
+
+	got, err := store.GetIssue(ctx, "bd-2")
+	if err != nil || got == nil {
+		t.Fatalf("Expected bd-2 to be restored: %v", err)
+	}
+	if got.Description != "Part of bd-1" {
+		t.Errorf("Expected description reference restored, got %q", got.Description)
+	}
+	deps, err := store.GetDependencyRecords(ctx, "bd-2")
+	if err != nil || len(deps) != 1 || deps[0].DependsOnID != "bd-1" {
+		t.Errorf("Expected parent-child dependency on bd-1, got %+v (%v)", deps, err)
+	}
+	comments, err := store.GetIssueComments(ctx, "bd-2")
+	if err != nil || len(comments) != 1 || comments[0].Text != "Blocked on bd-1" {
+		t.Errorf("Expected comment reference restored, got %+v (%v)", comments, err)
+	}
+}
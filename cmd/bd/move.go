@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var moveCmd = &cobra.Command{
+	Use:   "move <child-id> <new-parent-id>",
+	Short: "Move a child issue under a different parent",
+	Long: `Move a child issue under a different parent.
+
+The old parent-child dependency is replaced by one on the new parent, and the
+issue is renamed to the new parent's next child ID (bd-a3f8.2 moved under
+bd-c91e becomes e.g. bd-c91e.3). Its own hierarchical children are renamed to
+match. Dependencies, comments, events and labels follow the renamed issues.
+
+Moves that would create a cycle, such as under one of the issue's own
+descendants, or exceed the maximum hierarchy depth are rejected.
+
+Example:
+  bd move bd-a3f8.2 bd-c91e`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+
+		// move renames issues, which the daemon doesn't support
+		if err := ensureDirectMode("daemon does not support move command"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: move requires SQLite storage\n")
+			os.Exit(1)
+		}
+
+		childID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving issue ID %s: %v\n", args[0], err)
+			os.Exit(1)
+		}
+		parentID, err := utils.ResolvePartialID(ctx, store, args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving parent ID %s: %v\n", args[1], err)
+			os.Exit(1)
+		}
+
+		renamed, err := sqliteStore.MoveIssue(ctx, childID, parentID, actor)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// IDs changed, so incremental export won't work
+		markDirtyAndScheduleFullExport()
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"old_id":    childID,
+				"new_id":    renamed[childID],
+				"parent_id": parentID,
+				"renamed":   renamed,
+			})
+			return
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Moved %s under %s as %s\n", green("✓"), childID, parentID, renamed[childID])
+		oldIDs := make([]string, 0, len(renamed))
+		for oldID := range renamed {
+			if oldID != childID {
+				oldIDs = append(oldIDs, oldID)
+			}
+		}
+		sort.Strings(oldIDs)
+		for _, oldID := range oldIDs {
+			fmt.Printf("  %s → %s\n", oldID, renamed[oldID])
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(moveCmd)
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
+)
+
+var nextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Show the single highest-priority ready issue",
+	Long: `Show the one open issue to work on next: the highest-priority ready issue
+(no open blockers, as in 'bd ready'), oldest first among equal priorities.
+In-progress issues are skipped since someone already has them.
+
+--claim also sets the issue's status to in_progress and its assignee to the
+current actor, so an agent can pick up work in a single call. If someone
+else claims the issue first, the next ready issue is claimed instead.
+
+--min-priority skips issues below a priority (0-4, P0-P4 or a configured
+priority name), and --assignee only considers issues assigned to someone
+("" for unassigned ones). Priority order still applies among the rest.
+
+With --json, the issue is printed as an object. When nothing is ready the
+output is {"nothing_ready": true} instead, and the exit code is still 0.
+
+Examples:
+  bd next
+  bd next --claim --json
+  bd next --type bug --label backend
+  bd next --min-priority 1 --assignee ""`,
+	Run: func(cmd *cobra.Command, args []string) {
+		claim, _ := cmd.Flags().GetBool("claim")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		issueType, _ := cmd.Flags().GetString("type")
+		// Use global jsonOutput set by PersistentPreRun
+
+		// --assignee "" means unassigned, as in bd ready
+		var assignee *string
+		if cmd.Flags().Changed("assignee") {
+			value, _ := cmd.Flags().GetString("assignee")
+			assignee = &value
+		}
+		var minPriority *int
+		if cmd.Flags().Changed("min-priority") {
+			minPriorityStr, _ := cmd.Flags().GetString("min-priority")
+			p := parsePriority(minPriorityStr)
+			if p == -1 {
+				fmt.Fprintf(os.Stderr, "Error: invalid --min-priority %q (expected 0-4, P0-P4 or %s)\n", minPriorityStr, strings.Join(priorityNames(), ", "))
+				os.Exit(1)
+			}
+			minPriority = &p
+		}
+
+		labels = util.NormalizeLabels(labels)
+		if issueType != "" && !types.IssueType(issueType).IsValid() {
+			fmt.Fprintf(os.Stderr, "Error: invalid --type '%s'. Valid values: bug, feature, task, epic, chore\n", issueType)
+			os.Exit(1)
+		}
+
+		filter := nextWorkFilter(issueType, labels, assignee, minPriority)
+		var issue *types.Issue
+		if daemonClient != nil {
+			var err error
+			issue, err = nextIssueViaDaemon(filter, claim)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			ctx := context.Background()
+			var err error
+			issue, err = nextIssue(ctx, store, filter, claim)
+			if err == nil && issue == nil && checkAndAutoImport(ctx, store) {
+				// Re-run the query after import
+				issue, err = nextIssue(ctx, store, filter, claim)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if issue != nil && claim {
+				markDirtyAndScheduleFlush()
+			}
+		}
+
+		if jsonOutput {
+			if issue == nil {
+				outputJSON(map[string]bool{"nothing_ready": true})
+				return
+			}
+			outputJSON(issue)
+			return
+		}
+		if issue == nil {
+			yellow := color.New(color.FgYellow).SprintFunc()
+			fmt.Printf("\n%s No ready work found (all issues have blocking dependencies)\n\n",
+				yellow("✨"))
+			return
+		}
+		if claim {
+			green := color.New(color.FgGreen).SprintFunc()
+			fmt.Printf("%s Claimed %s as %s\n", green("✓"), displayID(issue.ID), issue.Assignee)
+		}
+		fmt.Printf("[P%d] %s: %s\n", issue.Priority, displayID(issue.ID), issue.Title)
+		if issue.EstimatedMinutes != nil {
+			fmt.Printf("   Estimate: %d min\n", *issue.EstimatedMinutes)
+		}
+		if issue.Description != "" {
+			fmt.Printf("\n%s\n", issue.Description)
+		}
+	},
+}
+
+// nextWorkFilter is the ready-work query bd next runs: open issues only,
+// by priority and then age, one result. assignee and minPriority are
+// optional ("" assignee means unassigned).
+func nextWorkFilter(issueType string, labels []string, assignee *string, minPriority *int) types.WorkFilter {
+	return types.WorkFilter{
+		Status:      types.StatusOpen,
+		IssueType:   types.IssueType(issueType),
+		Labels:      labels,
+		Assignee:    assignee,
+		MinPriority: minPriority,
+		SortPolicy:  types.SortPolicyPriority,
+		Limit:       1,
+	}
+}
+
+// nextClaimAttempts bounds how many candidates --claim tries while other
+// claimers keep taking them first
+const nextClaimAttempts = 10
+
+// nextIssue returns the first issue filter selects, or nil if nothing is
+// ready. With claim set, the issue is moved to in_progress and assigned to
+// the current actor first, unless it changed since it was read: then someone
+// else claimed it, and the next candidate is tried instead.
+func nextIssue(ctx context.Context, s storage.Storage, filter types.WorkFilter, claim bool) (*types.Issue, error) {
+	for attempt := 1; ; attempt++ {
+		issues, err := s.GetReadyWork(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		if len(issues) == 0 {
+			return nil, nil
+		}
+		issue := issues[0]
+		if !claim {
+			return issue, nil
+		}
+		updates := map[string]interface{}{
+			"status":   string(types.StatusInProgress),
+			"assignee": actor,
+		}
+		err = s.UpdateIssueIfUnchanged(ctx, issue.ID, issue.UpdatedAt, updates, actor)
+		if types.ErrorCode(err) == types.ErrCodeConflict && attempt < nextClaimAttempts {
+			continue // Once claimed it's in progress, so the query moves past it
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to claim %s: %w", issue.ID, err)
+		}
+		return s.GetIssue(ctx, issue.ID)
+	}
+}
+
+// nextIssueViaDaemon is nextIssue over RPC
+func nextIssueViaDaemon(filter types.WorkFilter, claim bool) (*types.Issue, error) {
+	args := &rpc.ReadyArgs{
+		Status:      string(filter.Status),
+		IssueType:   string(filter.IssueType),
+		Labels:      filter.Labels,
+		MinPriority: filter.MinPriority,
+		SortPolicy:  string(filter.SortPolicy),
+		Limit:       filter.Limit,
+	}
+	if filter.Assignee != nil {
+		args.Assignee = *filter.Assignee
+		args.Unassigned = *filter.Assignee == ""
+	}
+	for attempt := 1; ; attempt++ {
+		resp, err := daemonClient.Ready(args)
+		if err != nil {
+			return nil, err
+		}
+		var issues []*types.Issue
+		if err := json.Unmarshal(resp.Data, &issues); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		if len(issues) == 0 {
+			return nil, nil
+		}
+		if !claim {
+			return issues[0], nil
+		}
+		status := string(types.StatusInProgress)
+		assignee := actor
+		updateResp, err := daemonClient.Update(&rpc.UpdateArgs{
+			ID:                issues[0].ID,
+			Status:            &status,
+			Assignee:          &assignee,
+			ExpectedUpdatedAt: &issues[0].UpdatedAt,
+		})
+		if types.ErrorCode(err) == types.ErrCodeConflict && attempt < nextClaimAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to claim %s: %w", issues[0].ID, err)
+		}
+		var issue types.Issue
+		if err := json.Unmarshal(updateResp.Data, &issue); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		return &issue, nil
+	}
+}
+
+func init() {
+	nextCmd.Flags().Bool("claim", false, "Set the issue to in_progress and assign it to the current actor")
+	nextCmd.Flags().StringP("type", "t", "", "Only consider issues of this type (bug, feature, task, epic, chore)")
+	nextCmd.Flags().StringSliceP("label", "l", []string{}, "Only consider issues with all of these labels (comma-separated)")
+	nextCmd.Flags().StringP("assignee", "a", "", "Only consider issues assigned to this person (\"\" for unassigned)")
+	nextCmd.Flags().String("min-priority", "", "Only consider issues at least this important (e.g. 1 or P1 considers P0 and P1)")
+	rootCmd.AddCommand(nextCmd)
+}
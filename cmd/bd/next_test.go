@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestNextIssue(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".beads", "beads.db")
+	sqliteStore := newTestStore(t, dbPath)
+	ctx := context.Background()
+
+	oldActor := actor
+	actor = "agent-1"
+	defer func() { actor = oldActor }()
+
+	// CreateIssue stamps created_at, so these are created oldest first
+	issues := []*types.Issue{
+		{ID: "test-older", Title: "Older P1", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+		{ID: "test-newer", Title: "Newer P1", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+		{ID: "test-low", Title: "P3", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask},
+		{ID: "test-blocked", Title: "Blocked P0", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask},
+		{ID: "test-taken", Title: "Taken P0", Status: types.StatusInProgress, Priority: 0, IssueType: types.TypeTask},
+	}
+	for _, issue := range issues {
+		if err := sqliteStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dep := &types.Dependency{IssueID: "test-blocked", DependsOnID: "test-low", Type: types.DepBlocks, CreatedAt: time.Now()}
+	if err := sqliteStore.AddDependency(ctx, dep, "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	filter := nextWorkFilter("", nil, nil, nil)
+
+	// Highest priority wins, oldest first; blocked and in-progress issues are skipped
+	issue, err := nextIssue(ctx, sqliteStore, filter, false)
+	if err != nil {
+		t.Fatalf("nextIssue failed: %v", err)
+	}
+	if issue == nil || issue.ID != "test-older" {
+		t.Fatalf("nextIssue = %v, want test-older", issue)
+	}
+	if issue.Status != types.StatusOpen {
+		t.Errorf("nextIssue without claim changed status to %s", issue.Status)
+	}
+
+	// Claiming moves the issue to in_progress, so the next call moves on
+	issue, err = nextIssue(ctx, sqliteStore, filter, true)
+	if err != nil {
+		t.Fatalf("nextIssue with claim failed: %v", err)
+	}
+	if issue.ID != "test-older" || issue.Status != types.StatusInProgress || issue.Assignee != "agent-1" {
+		t.Errorf("claimed %s: status %s, assignee %q; want test-older, in_progress, agent-1", issue.ID, issue.Status, issue.Assignee)
+	}
+	for _, want := range []string{"test-newer", "test-low"} {
+		issue, err = nextIssue(ctx, sqliteStore, filter, true)
+		if err != nil {
+			t.Fatalf("nextIssue with claim failed: %v", err)
+		}
+		if issue == nil || issue.ID != want {
+			t.Fatalf("nextIssue = %v, want %s", issue, want)
+		}
+	}
+
+	// test-blocked is still waiting on test-low, so nothing is ready
+	issue, err = nextIssue(ctx, sqliteStore, filter, true)
+	if err != nil {
+		t.Fatalf("nextIssue failed: %v", err)
+	}
+	if issue != nil {
+		t.Errorf("nextIssue = %s, want nothing ready", issue.ID)
+	}
+}
+
+// readBarrierStore holds its first GetReadyWork result until every claimer
+// sharing barrier has read, so they all pick the same candidate
+type readBarrierStore struct {
+	storage.Storage
+	barrier *sync.WaitGroup
+	read    bool
+}
+
+func (s *readBarrierStore) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
+	issues, err := s.Storage.GetReadyWork(ctx, filter)
+	if !s.read {
+		s.read = true
+		s.barrier.Done()
+		s.barrier.Wait()
+	}
+	return issues, err
+}
+
+func TestNextIssueRacingClaims(t *testing.T) {
+	tmpDir := t.TempDir()
+	sqliteStore := newTestStore(t, filepath.Join(tmpDir, ".beads", "beads.db"))
+	ctx := context.Background()
+
+	for _, issue := range []*types.Issue{
+		{ID: "test-1", Title: "First", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+		{ID: "test-2", Title: "Second", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	} {
+		if err := sqliteStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var barrier, done sync.WaitGroup
+	barrier.Add(2)
+	claimed := make([]*types.Issue, 2)
+	errs := make([]error, 2)
+	for i := range claimed {
+		done.Add(1)
+		go func(i int) {
+			defer done.Done()
+			s := &readBarrierStore{Storage: sqliteStore, barrier: &barrier}
+			claimed[i], errs[i] = nextIssue(ctx, s, nextWorkFilter("", nil, nil, nil), true)
+		}(i)
+	}
+	done.Wait()
+
+	// Both read test-1; the loser's claim conflicts and it takes test-2
+	got := make(map[string]bool)
+	for i, issue := range claimed {
+		if errs[i] != nil {
+			t.Fatalf("claim %d failed: %v", i, errs[i])
+		}
+		if issue == nil || issue.Status != types.StatusInProgress {
+			t.Fatalf("claim %d = %+v, want an in_progress issue", i, issue)
+		}
+		got[issue.ID] = true
+	}
+	if !got["test-1"] || !got["test-2"] {
+		t.Errorf("claims took %v, want test-1 and test-2 once each", got)
+	}
+}
+
+func TestNextIssueFilters(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".beads", "beads.db")
+	sqliteStore := newTestStore(t, dbPath)
+	ctx := context.Background()
+
+	issues := []*types.Issue{
+		{ID: "test-task", Title: "Task", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask},
+		{ID: "test-bug", Title: "Bug", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug},
+		{ID: "test-alice", Title: "Alice's", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask, Assignee: "alice"},
+	}
+	for _, issue := range issues {
+		if err := sqliteStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sqliteStore.AddLabel(ctx, "test-bug", "backend", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, filter := range []types.WorkFilter{nextWorkFilter("bug", nil, nil, nil), nextWorkFilter("", []string{"backend"}, nil, nil)} {
+		issue, err := nextIssue(ctx, sqliteStore, filter, false)
+		if err != nil {
+			t.Fatalf("nextIssue failed: %v", err)
+		}
+		if issue == nil || issue.ID != "test-bug" {
+			t.Errorf("nextIssue(%+v) = %v, want test-bug", filter, issue)
+		}
+	}
+
+	alice, unassigned := "alice", ""
+	p1, p2 := 1, 2
+	for _, tt := range []struct {
+		filter types.WorkFilter
+		want   string // "" for nothing ready
+	}{
+		{nextWorkFilter("", nil, &alice, nil), "test-alice"},
+		{nextWorkFilter("", nil, &alice, &p2), ""},
+		{nextWorkFilter("", nil, &unassigned, &p1), "test-task"},
+		{nextWorkFilter("bug", nil, nil, &p1), ""},
+	} {
+		issue, err := nextIssue(ctx, sqliteStore, tt.filter, false)
+		if err != nil {
+			t.Fatalf("nextIssue failed: %v", err)
+		}
+		got := ""
+		if issue != nil {
+			got = issue.ID
+		}
+		if got != tt.want {
+			t.Errorf("nextIssue(%+v) = %q, want %q", tt.filter, got, tt.want)
+		}
+	}
+}
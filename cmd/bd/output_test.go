@@ -95,3 +95,81 @@ func TestOutputJSONArray(t *testing.T) {
 // Note: createIssuesFromMarkdown is tested via cmd/bd/markdown_test.go which has
 // comprehensive tests for the markdown parsing functionality. We don't duplicate
 // those tests here since they require full DB setup.
+
+func TestOutputJSONFormats(t *testing.T) {
+	oldJSON, oldPretty := jsonOutput, jsonPretty
+	defer func() { jsonOutput, jsonPretty = oldJSON, oldPretty }()
+
+	capture := func() string {
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		outputJSON(map[string]interface{}{"id": "bd-1", "count": 42})
+
+		w.Close()
+		os.Stdout = oldStdout
+
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		return buf.String()
+	}
+
+	for _, format := range []string{"json", "json-compact"} {
+		jsonOutput, jsonPretty = false, false
+		if !applyJSONFormat(format) || !jsonOutput {
+			t.Fatalf("Expected %s to enable JSON output", format)
+		}
+		if got := capture(); got != "{\"count\":42,\"id\":\"bd-1\"}\n" {
+			t.Errorf("%s output = %q, want a single compact line", format, got)
+		}
+	}
+
+	jsonOutput, jsonPretty = false, false
+	if !applyJSONFormat("json-pretty") || !jsonOutput {
+		t.Fatal("Expected json-pretty to enable JSON output")
+	}
+	if got := capture(); got != "{\n  \"count\": 42,\n  \"id\": \"bd-1\"\n}\n" {
+		t.Errorf("json-pretty output = %q, want indented JSON", got)
+	}
+
+	jsonOutput, jsonPretty = false, false
+	if applyJSONFormat("dot") || jsonOutput {
+		t.Error("Expected non-JSON format to leave JSON output off")
+	}
+}
+
+func TestJSONArrayWriterMatchesOutputJSON(t *testing.T) {
+	defer func() { jsonPretty = false }()
+	elements := []interface{}{
+		map[string]interface{}{"id": "bd-1", "labels": []string{"a", "b"}},
+		map[string]interface{}{"id": "bd-2", "title": "<html> & co"},
+	}
+	for _, pretty := range []bool{false, true} {
+		jsonPretty = pretty
+		for _, n := range []int{0, 1, 2} {
+			var want bytes.Buffer
+			encoder := json.NewEncoder(&want)
+			if pretty {
+				encoder.SetIndent("", "  ")
+			}
+			if err := encoder.Encode(append([]interface{}{}, elements[:n]...)); err != nil {
+				t.Fatal(err)
+			}
+
+			var got bytes.Buffer
+			array := newJSONArrayWriter(&got)
+			for _, element := range elements[:n] {
+				if err := array.write(element); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := array.close(); err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != want.String() {
+				t.Errorf("pretty=%v n=%d: got %q, want %q", pretty, n, got.String(), want.String())
+			}
+		}
+	}
+}
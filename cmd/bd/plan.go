@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan <id>",
+	Short: "Show the work under an issue in dependency order",
+	Long: `Show the open work an issue needs, in the order it can be done.
+
+The plan covers the issue, its children (recursively) and everything they
+are transitively blocked by. It is grouped into waves: each wave only waits
+on earlier waves, so the issues in a wave can be worked on in parallel.
+Blockers come before what they block and children before their parent.
+Closed issues are already done and left out.
+
+bd plan fails, naming the cycle, if the dependencies loop.
+
+Examples:
+  bd plan bd-42
+  bd plan bd-42 --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := ensureDirectMode("plan requires direct database access"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		ctx := context.Background()
+		rootID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		waves, err := store.TopoSort(ctx, rootID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			if waves == nil {
+				waves = [][]*types.Issue{}
+			}
+			outputJSON(waves)
+			return
+		}
+
+		if len(waves) == 0 {
+			fmt.Printf("Nothing left to do for %s\n", rootID)
+			return
+		}
+		total := 0
+		for _, wave := range waves {
+			total += len(wave)
+		}
+		fmt.Printf("Plan for %s: %d issue(s) in %d wave(s)\n", rootID, total, len(waves))
+		for i, wave := range waves {
+			fmt.Printf("\nWave %d:\n", i+1)
+			for _, issue := range wave {
+				fmt.Printf("  %s %s %s (%s)\n", issue.ID, priorityTag(issue.Priority), issue.Title, issue.Status)
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// priorityNamesKey is the config.yaml setting renaming the priority levels,
+// a list of five names from P0 to P4
+const priorityNamesKey = "priority-names"
+
+// priorityNames returns the configured priority names, or the defaults when
+// priority-names is unset or invalid
+func priorityNames() types.PriorityNames {
+	names := config.GetStringSlice(priorityNamesKey)
+	// BD_PRIORITY_NAMES=a,b,c,d,e arrives as a single string
+	if len(names) == 1 {
+		names = strings.Split(names[0], ",")
+	}
+	if len(names) == 0 {
+		return types.DefaultPriorityNames
+	}
+	parsed, err := types.ParsePriorityNames(names)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring %s: %v\n", priorityNamesKey, err)
+		return types.DefaultPriorityNames
+	}
+	return parsed
+}
+
+// formatPriority renders a priority with its name, e.g. "P1 (high)"
+func formatPriority(p int) string {
+	if name := priorityNames().Name(p); name != "" {
+		return fmt.Sprintf("P%d (%s)", p, name)
+	}
+	return fmt.Sprintf("P%d", p)
+}
+
+// priorityTag renders a priority for one-line listings, e.g. "[P1 high]"
+func priorityTag(p int) string {
+	if name := priorityNames().Name(p); name != "" {
+		return fmt.Sprintf("[P%d %s]", p, name)
+	}
+	return fmt.Sprintf("[P%d]", p)
+}
+
+// getPriorityFlag reads a --priority flag given as 0-4, P0-P4 or a priority
+// name, exiting on a bad value
+func getPriorityFlag(cmd *cobra.Command) int {
+	value, _ := cmd.Flags().GetString("priority")
+	priority, err := priorityNames().Parse(value)
+	if err != nil {
+		exitInvalid("%v", err)
+	}
+	return priority
+}
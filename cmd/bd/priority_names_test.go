@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+func TestPriorityNamesConfig(t *testing.T) {
+	if err := config.Initialize(); err != nil {
+		t.Fatalf("Failed to initialize config: %v", err)
+	}
+	defer config.Set(priorityNamesKey, nil)
+
+	if got := formatPriority(1); got != "P1 (high)" {
+		t.Errorf("default formatPriority(1) = %q", got)
+	}
+
+	config.Set(priorityNamesKey, []string{"blocker", "urgent", "normal", "minor", "someday"})
+	if got := priorityTag(0); got != "[P0 blocker]" {
+		t.Errorf("priorityTag(0) = %q", got)
+	}
+	if p := parsePriority("minor"); p != 3 {
+		t.Errorf("parsePriority(minor) = %d", p)
+	}
+
+	// A comma-separated string, as from BD_PRIORITY_NAMES
+	config.Set(priorityNamesKey, "a,b,c,d,e")
+	if got := priorityNames().Name(4); got != "e" {
+		t.Errorf("Name(4) = %q", got)
+	}
+
+	// Invalid names fall back to the defaults
+	config.Set(priorityNamesKey, []string{"only", "two"})
+	if p := parsePriority("high"); p != 1 {
+		t.Errorf("parsePriority(high) with invalid config = %d", p)
+	}
+}
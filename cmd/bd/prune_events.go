@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// autoPruneInterval is how often the daemon applies events.retention_days
+const autoPruneInterval = 24 * time.Hour
+
+var pruneEventsCmd = &cobra.Command{
+	Use:   "prune-events",
+	Short: "Delete old events from the audit trail",
+	Long: `Delete events created before a cutoff date to keep the events table small.
+
+Two kinds of old events are always kept:
+- each issue's newest status event (created, status_changed, closed or
+  reopened), so the history still explains the issue's current status
+- each issue's N most recent events, with --keep-per-issue N
+
+Events newer than the cutoff are never touched, so history for recent
+operations stays intact. The cutoff can't be in the future.
+
+Events aren't part of the JSONL export, so pruning only affects the local
+database.
+
+AUTOMATIC PRUNING:
+The daemon prunes events once a day when events.retention_days is set:
+  bd config set events.retention_days 90
+  bd config set events.keep_per_issue 10
+
+EXAMPLES:
+  bd prune-events --before 2025-01-01
+  bd prune-events --before 2025-01-01 --keep-per-issue 20
+  bd prune-events --older-than 90 --dry-run`,
+	Run: func(cmd *cobra.Command, args []string) {
+		beforeStr, _ := cmd.Flags().GetString("before")
+		olderThanDays, _ := cmd.Flags().GetInt("older-than")
+		keepPerIssue, _ := cmd.Flags().GetInt("keep-per-issue")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		if (beforeStr == "") == (olderThanDays <= 0) {
+			fmt.Fprintf(os.Stderr, "Error: specify exactly one of --before or --older-than\n")
+			os.Exit(1)
+		}
+		if keepPerIssue < 0 {
+			fmt.Fprintf(os.Stderr, "Error: --keep-per-issue must not be negative\n")
+			os.Exit(1)
+		}
+
+		var before time.Time
+		if beforeStr != "" {
+			t, err := parseTimeFlag(beforeStr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error parsing --before: %v\n", err)
+				os.Exit(1)
+			}
+			if t.After(time.Now()) {
+				fmt.Fprintf(os.Stderr, "Error: --before %s is in the future\n", beforeStr)
+				os.Exit(1)
+			}
+			before = t
+		} else {
+			before = time.Now().AddDate(0, 0, -olderThanDays)
+		}
+
+		// Ensure we have storage
+		if daemonClient != nil {
+			if err := ensureDirectMode("daemon does not support prune-events command"); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		} else if store == nil {
+			if err := ensureStoreActive(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		ctx := context.Background()
+		removed, err := store.PruneEvents(ctx, before, keepPerIssue, dryRun)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"removed_count":  removed,
+				"before":         before.Format(time.RFC3339),
+				"keep_per_issue": keepPerIssue,
+				"dry_run":        dryRun,
+			})
+			return
+		}
+
+		if dryRun {
+			fmt.Println(color.YellowString("DRY RUN - no changes will be made"))
+			fmt.Printf("Would remove %d event(s) created before %s\n", removed, before.Format("2006-01-02 15:04"))
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Removed %d event(s) created before %s\n", green("✓"), removed, before.Format("2006-01-02 15:04"))
+	},
+}
+
+// autoPruneEvents applies the events.retention_days policy, at most once per
+// autoPruneInterval. Returns the number of events removed.
+func autoPruneEvents(ctx context.Context, store storage.Storage) (int, error) {
+	value, err := store.GetConfig(ctx, types.EventRetentionConfigKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", types.EventRetentionConfigKey, err)
+	}
+	days, err := types.ParseEventRetention(value)
+	if err != nil || days == 0 {
+		return 0, err
+	}
+	value, err = store.GetConfig(ctx, types.EventKeepPerIssueConfigKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", types.EventKeepPerIssueConfigKey, err)
+	}
+	keepPerIssue, err := types.ParseEventKeepPerIssue(value)
+	if err != nil {
+		return 0, err
+	}
+
+	lastPrune, err := store.GetMetadata(ctx, "last_event_prune")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read last_event_prune: %w", err)
+	}
+	if last, err := time.Parse(time.RFC3339, lastPrune); err == nil && time.Since(last) < autoPruneInterval {
+		return 0, nil
+	}
+
+	removed, err := store.PruneEvents(ctx, time.Now().AddDate(0, 0, -days), keepPerIssue, false)
+	if err != nil {
+		return 0, err
+	}
+	if err := store.SetMetadata(ctx, "last_event_prune", time.Now().Format(time.RFC3339)); err != nil {
+		return removed, fmt.Errorf("failed to update last_event_prune: %w", err)
+	}
+	return removed, nil
+}
+
+func init() {
+	pruneEventsCmd.Flags().String("before", "", "Delete events created before this date (2006-01-02 or RFC3339)")
+	pruneEventsCmd.Flags().Int("older-than", 0, "Delete events older than N days")
+	pruneEventsCmd.Flags().Int("keep-per-issue", 0, "Always keep each issue's N most recent events")
+	pruneEventsCmd.Flags().Bool("dry-run", false, "Report how many events would be removed without deleting them")
+	rootCmd.AddCommand(pruneEventsCmd)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// readOnlyCommands are the commands --read-only allows, by their path below
+// bd ("" is bd itself). Every other command may change the database and is
+// refused before it opens one.
+var readOnlyCommands = []string{
+	"",
+	"blocked",
+	"comments",
+	"completion bash",
+	"completion fish",
+	"completion powershell",
+	"completion zsh",
+	"config export",
+	"config get",
+	"config list",
+	"dep cycles",
+	"dep list",
+	"dep tree",
+	"dep why",
+	"diff",
+	"duplicates",
+	"epic status",
+	"export",
+	"help",
+	"id-info",
+	"info",
+	"label list",
+	"label list-all",
+	"list",
+	"log",
+	"metrics",
+	"next",
+	"onboard",
+	"plan",
+	"prime",
+	"quickstart",
+	"ready",
+	"search",
+	"show",
+	"stale",
+	"stats",
+	"status",
+	"template list",
+	"template show",
+	"validate",
+	"version",
+	"watch",
+	"whoami",
+}
+
+// readOnlyRefusedFlags are the flags that make one of readOnlyCommands
+// change something, so --read-only refuses them
+var readOnlyRefusedFlags = map[string][]string{
+	"blocked":    {"notify"},
+	"duplicates": {"auto-merge"},
+	"next":       {"claim"},
+	"search":     {"save", "delete"},
+	"validate":   {"fix-all"},
+}
+
+// readOnlyCommandPath returns cmd's path below bd, as readOnlyCommands
+// lists it
+func readOnlyCommandPath(cmd *cobra.Command) string {
+	return strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()))
+}
+
+// checkReadOnly returns a read_only error when cmd, run as it was, is
+// refused by --read-only
+func checkReadOnly(cmd *cobra.Command) error {
+	path := readOnlyCommandPath(cmd)
+	if !slices.Contains(readOnlyCommands, path) {
+		return types.WithCode(types.ErrCodeReadOnly, fmt.Errorf("'bd %s' is not allowed with --read-only, which only runs queries", path))
+	}
+	for _, flag := range readOnlyRefusedFlags[path] {
+		if cmd.Flags().Changed(flag) {
+			return types.WithCode(types.ErrCodeReadOnly, fmt.Errorf("'bd %s --%s' is not allowed with --read-only, which only runs queries", path, flag))
+		}
+	}
+	return nil
+}
+
+// openStore opens the database at path for a command, read-only under
+// --read-only
+func openStore(path string) (*sqlite.SQLiteStorage, error) {
+	if readOnlyMode {
+		return sqlite.NewReadOnly(path)
+	}
+	return sqlite.New(path)
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCheckReadOnly(t *testing.T) {
+	for _, path := range readOnlyCommands {
+		if path == "help" || strings.HasPrefix(path, "completion ") {
+			continue // Cobra only adds these when bd runs
+		}
+		cmd, _, err := rootCmd.Find(strings.Fields(path))
+		if err != nil || readOnlyCommandPath(cmd) != path {
+			t.Errorf("readOnlyCommands lists %q, which isn't a bd command", path)
+		}
+	}
+	for path, flags := range readOnlyRefusedFlags {
+		cmd, _, _ := rootCmd.Find(strings.Fields(path))
+		for _, flag := range flags {
+			if cmd.Flags().Lookup(flag) == nil {
+				t.Errorf("readOnlyRefusedFlags lists --%s, which 'bd %s' doesn't have", flag, path)
+			}
+		}
+	}
+
+	for _, path := range []string{"create", "dep add", "config set", "migrate"} {
+		cmd, _, _ := rootCmd.Find(strings.Fields(path))
+		if err := checkReadOnly(cmd); types.ErrorCode(err) != types.ErrCodeReadOnly {
+			t.Errorf("checkReadOnly(%s) = %v, want a %s error", path, err, types.ErrCodeReadOnly)
+		}
+	}
+	for _, path := range []string{"list", "dep tree", "config get"} {
+		cmd, _, _ := rootCmd.Find(strings.Fields(path))
+		if err := checkReadOnly(cmd); err != nil {
+			t.Errorf("checkReadOnly(%s) = %v, want nil", path, err)
+		}
+	}
+}
+
+func TestCLI_ReadOnly(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping slow CLI test in short mode")
+	}
+	bd := buildExitCodeBD(t)
+	env := []string{"BEADS_NO_DAEMON=1"}
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, "BEADS_DIR=") && !strings.HasPrefix(kv, "BEADS_DB=") && !strings.HasPrefix(kv, "BEADS_READONLY=") {
+			env = append(env, kv)
+		}
+	}
+	dir := createTempDirWithCleanup(t)
+	run := func(extraEnv []string, args ...string) (int, string) {
+		t.Helper()
+		cmd := exec.Command(bd, append([]string{"--no-daemon"}, args...)...)
+		cmd.Dir = dir
+		cmd.Env = append(append([]string{}, env...), extraEnv...)
+		out, err := cmd.Output()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), string(out)
+		}
+		if err != nil {
+			t.Fatalf("bd %v failed to run: %v", args, err)
+		}
+		return 0, string(out)
+	}
+
+	if code, out := run(nil, "init", "--prefix", "test", "--quiet"); code != 0 {
+		t.Fatalf("init failed with exit %d: %s", code, out)
+	}
+	for _, id := range []string{"test-1", "test-2"} {
+		if code, out := run(nil, "create", "Issue "+id, "--id", id); code != 0 {
+			t.Fatalf("create %s failed with exit %d: %s", id, code, out)
+		}
+	}
+	dbFile := filepath.Join(dir, ".beads", "beads.db")
+	before, err := os.ReadFile(dbFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reads := [][]string{
+		{"list"},
+		{"show", "test-1"},
+		{"ready"},
+		{"blocked"},
+		{"stats"},
+		{"export", "-o", filepath.Join(t.TempDir(), "out.jsonl")},
+		{"search", "Issue"},
+		{"dep", "tree", "test-1"},
+		{"log", "test-1"},
+		{"config", "get", "issue_prefix"},
+	}
+	for _, args := range reads {
+		if code, out := run(nil, append([]string{"--read-only"}, args...)...); code != 0 {
+			t.Errorf("bd --read-only %v: exit %d, want 0 (output %q)", args, code, out)
+		}
+	}
+
+	writes := [][]string{
+		{"create", "Refused"},
+		{"update", "test-1", "--title", "Renamed"},
+		{"close", "test-1"},
+		{"reopen", "test-1"},
+		{"dep", "add", "test-1", "test-2"},
+		{"import", "-i", filepath.Join(dir, ".beads", "issues.jsonl")},
+		{"migrate"},
+		{"config", "set", "issue_prefix", "other"},
+		{"next", "--claim"},
+	}
+	for _, args := range writes {
+		code, out := run(nil, append([]string{"--read-only", "--json"}, args...)...)
+		var payload jsonError
+		if code != exitReadOnly || json.Unmarshal([]byte(out), &payload) != nil || payload.Error != types.ErrCodeReadOnly {
+			t.Errorf("bd --read-only %v: exit %d, output %q; want %d with a %s error", args, code, out, exitReadOnly, types.ErrCodeReadOnly)
+		}
+	}
+	if code, _ := run([]string{"BEADS_READONLY=1"}, "create", "Refused"); code != exitReadOnly {
+		t.Errorf("BEADS_READONLY=1 bd create: exit %d, want %d", code, exitReadOnly)
+	}
+
+	after, err := os.ReadFile(dbFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Error("Expected read-only commands to leave the database file unchanged")
+	}
+}
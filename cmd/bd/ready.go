@@ -4,22 +4,56 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
-	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/util"
+	"github.com/steveyegge/beads/internal/utils"
 )
 var readyCmd = &cobra.Command{
 	Use:   "ready",
 	Short: "Show ready work (no blockers, open or in-progress)",
+	Long: `Show ready work (no blockers, open or in-progress).
+
+Use --min-priority to focus on important work: --min-priority 1 shows only
+P0 and P1 issues. The sort policy still orders issues within the filtered set.
+Filters combine with AND semantics (e.g. --min-priority with --assignee).
+
+An issue is blocked while anything it depends on through a 'blocks' edge
+is still open, and so are its parent-child descendants. Parent-child and
+related edges on their own never block.
+
+--format agent prints a compact JSON summary meant for scripts and agents,
+whose shape stays stable as issue fields change. "schema" names its version
+(currently bd.ready.v1); fields may be added, but none are renamed or removed
+without a new version:
+
+  {
+    "schema": "bd.ready.v1",
+    "counts": {"ready": 3, "shown": 2, "open": 2, "in_progress": 1, "blocked": 4},
+    "issues": [
+      {"id": "bd-a1", "title": "Fix login", "priority": 1, "type": "bug",
+       "status": "open", "why_ready": "open, all 2 blockers closed"}
+    ],
+    "claim_hint": "bd next --claim --json",
+    "claim_id": "bd-a1"
+  }
+
+counts cover all matching ready work, not only the --limit issues listed.
+claim_hint is the exact 'bd next --claim' command (with the --type, --label,
+--assignee and --min-priority filters given here) that takes claim_id, the highest-priority open
+issue it would pick now; both are omitted when there is none.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		limit, _ := cmd.Flags().GetInt("limit")
 		assignee, _ := cmd.Flags().GetString("assignee")
+		unassigned, _ := cmd.Flags().GetBool("unassigned")
 		sortPolicy, _ := cmd.Flags().GetString("sort")
 		labels, _ := cmd.Flags().GetStringSlice("label")
 		labelsAny, _ := cmd.Flags().GetStringSlice("label-any")
+		status, _ := cmd.Flags().GetString("status")
+		issueType, _ := cmd.Flags().GetString("type")
 		// Use global jsonOutput set by PersistentPreRun (respects config.yaml + env vars)
 		
 		// Normalize labels: trim, dedupe, remove empty
@@ -28,6 +62,8 @@ var readyCmd = &cobra.Command{
 		
 		filter := types.WorkFilter{
 			// Leave Status empty to get both 'open' and 'in_progress' (bd-165)
+			Status:     types.Status(status),
+			IssueType:  types.IssueType(issueType),
 			Limit:      limit,
 			SortPolicy: types.SortPolicy(sortPolicy),
 			Labels:     labels,
@@ -35,30 +71,77 @@ var readyCmd = &cobra.Command{
 		}
 		// Use Changed() to properly handle P0 (priority=0)
 		if cmd.Flags().Changed("priority") {
-			priority, _ := cmd.Flags().GetInt("priority")
+			priority := getPriorityFlag(cmd)
 			filter.Priority = &priority
 		}
-		if assignee != "" {
+		if cmd.Flags().Changed("min-priority") {
+			minPriorityStr, _ := cmd.Flags().GetString("min-priority")
+			minPriority := parsePriority(minPriorityStr)
+			if minPriority == -1 {
+				fmt.Fprintf(os.Stderr, "Error: invalid --min-priority %q (expected 0-4, P0-P4 or %s)\n", minPriorityStr, strings.Join(priorityNames(), ", "))
+				os.Exit(1)
+			}
+			filter.MinPriority = &minPriority
+		}
+		// --assignee "" means unassigned, like --unassigned
+		if cmd.Flags().Changed("assignee") && assignee == "" {
+			unassigned = true
+		}
+		if unassigned && assignee != "" {
+			fmt.Fprintf(os.Stderr, "Error: --unassigned cannot be combined with --assignee\n")
+			os.Exit(1)
+		}
+		if unassigned || assignee != "" {
 			filter.Assignee = &assignee
 		}
+		if status != "" && filter.Status != types.StatusOpen && filter.Status != types.StatusInProgress {
+			fmt.Fprintf(os.Stderr, "Error: invalid --status '%s'. Valid values: open, in_progress\n", status)
+			os.Exit(1)
+		}
+		if issueType != "" && !filter.IssueType.IsValid() {
+			fmt.Fprintf(os.Stderr, "Error: invalid --type '%s'. Valid values: bug, feature, task, epic, chore\n", issueType)
+			os.Exit(1)
+		}
 		// Validate sort policy
 		if !filter.SortPolicy.IsValid() {
 			fmt.Fprintf(os.Stderr, "Error: invalid sort policy '%s'. Valid values: hybrid, priority, oldest\n", sortPolicy)
 			os.Exit(1)
 		}
+		switch format, _ := cmd.Flags().GetString("format"); format {
+		case "":
+		case "agent":
+			if err := ensureDirectMode("ready --format agent reads dependencies directly"); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			out, err := buildReadyAgentOutput(context.Background(), store, filter, limit, issueType, labels)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			outputJSON(out)
+			return
+		default:
+			fmt.Fprintf(os.Stderr, "Error: unknown format %q (valid: agent)\n", format)
+			os.Exit(1)
+		}
 		// If daemon is running, use RPC
 		if daemonClient != nil {
 			readyArgs := &rpc.ReadyArgs{
+				Status:     status,
+				IssueType:  issueType,
 				Assignee:   assignee,
+				Unassigned: unassigned,
 				Limit:      limit,
 				SortPolicy: sortPolicy,
 				Labels:     labels,
 				LabelsAny:  labelsAny,
 			}
 			if cmd.Flags().Changed("priority") {
-				priority, _ := cmd.Flags().GetInt("priority")
+				priority := getPriorityFlag(cmd)
 				readyArgs.Priority = &priority
 			}
+			readyArgs.MinPriority = filter.MinPriority
 			resp, err := daemonClient.Ready(readyArgs)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -85,7 +168,7 @@ var readyCmd = &cobra.Command{
 			cyan := color.New(color.FgCyan).SprintFunc()
 			fmt.Printf("\n%s Ready work (%d issues with no blockers):\n\n", cyan("📋"), len(issues))
 			for i, issue := range issues {
-				fmt.Printf("%d. [P%d] %s: %s\n", i+1, issue.Priority, issue.ID, issue.Title)
+				fmt.Printf("%d. [P%d] %s: %s\n", i+1, issue.Priority, displayID(issue.ID), issue.Title)
 				if issue.EstimatedMinutes != nil {
 					fmt.Printf("   Estimate: %d min\n", *issue.EstimatedMinutes)
 				}
@@ -131,7 +214,7 @@ var readyCmd = &cobra.Command{
 		cyan := color.New(color.FgCyan).SprintFunc()
 		fmt.Printf("\n%s Ready work (%d issues with no blockers):\n\n", cyan("📋"), len(issues))
 		for i, issue := range issues {
-			fmt.Printf("%d. [P%d] %s: %s\n", i+1, issue.Priority, issue.ID, issue.Title)
+			fmt.Printf("%d. [P%d] %s: %s\n", i+1, issue.Priority, displayID(issue.ID), issue.Title)
 			if issue.EstimatedMinutes != nil {
 				fmt.Printf("   Estimate: %d min\n", *issue.EstimatedMinutes)
 			}
@@ -143,14 +226,49 @@ var readyCmd = &cobra.Command{
 	},
 }
 var blockedCmd = &cobra.Command{
-	Use:   "blocked",
+	Use:   "blocked [id...]",
 	Short: "Show blocked issues",
+	Long: `Show open issues that are waiting on other issues through 'blocks'
+dependencies, and which unclosed issues each one is waiting on.
+
+With --json, each entry includes blocked_by (blocker IDs) and blockers
+(ID, title and status of each).
+
+--why <id> explains one issue instead: it walks the issue's unclosed
+blockers recursively and prints each chain down to an issue nothing blocks,
+e.g. "bd-1 ← bd-2 ← bd-3 (open, no blockers)", then lists those actionable
+issues. An issue that reappears on its own chain is marked as a cycle.
+--depth limits how many levels are walked (0 for no limit). With --json,
+--why outputs the tree of blockers.
+
+--notify groups the unclosed blockers of every blocked issue (or of just the
+issues given as arguments) by assignee, and tells each owner what they are
+holding up: "You're blocking bd-1 via bd-2, bd-3". When webhook_url is set,
+one JSON notice per owner is POSTed to it (event "blocking", signed as
+daemon webhooks are); otherwise the notices are only listed, and --json
+outputs them for scripting. Nothing is changed in the database.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		whyID, _ := cmd.Flags().GetString("why")
+		depth, _ := cmd.Flags().GetInt("depth")
+		notify, _ := cmd.Flags().GetBool("notify")
+		if len(args) > 0 && !notify {
+			fmt.Fprintf(os.Stderr, "Error: issue arguments are only accepted with --notify\n")
+			os.Exit(1)
+		}
+		if notify && whyID != "" {
+			fmt.Fprintf(os.Stderr, "Error: --notify and --why cannot be combined\n")
+			os.Exit(1)
+		}
+		if depth < 0 {
+			fmt.Fprintf(os.Stderr, "Error: --depth must be 0 or more\n")
+			os.Exit(1)
+		}
+
 		// Use global jsonOutput set by PersistentPreRun (respects config.yaml + env vars)
 		// If daemon is running but doesn't support this command, use direct storage
 		if daemonClient != nil && store == nil {
 			var err error
-			store, err = sqlite.New(dbPath)
+			store, err = openStore(dbPath)
 			if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
 			os.Exit(1)
@@ -158,11 +276,29 @@ var blockedCmd = &cobra.Command{
 			defer func() { _ = store.Close() }()
 			}
 			ctx := context.Background()
+		if whyID != "" {
+			id, err := utils.ResolvePartialID(ctx, store, whyID)
+			if err == nil {
+				err = runBlockedWhy(ctx, id, depth)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
 		blocked, err := store.GetBlockedIssues(ctx)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+		if notify {
+			if err := runBlockedNotify(ctx, blocked, args); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
 		if jsonOutput {
 			// Always output array, even if empty
 			if blocked == nil {
@@ -179,21 +315,32 @@ var blockedCmd = &cobra.Command{
 		red := color.New(color.FgRed).SprintFunc()
 		fmt.Printf("\n%s Blocked issues (%d):\n\n", red("🚫"), len(blocked))
 		for _, issue := range blocked {
-			fmt.Printf("[P%d] %s: %s\n", issue.Priority, issue.ID, issue.Title)
-			blockedBy := issue.BlockedBy
-			if blockedBy == nil {
-				blockedBy = []string{}
-			}
-			fmt.Printf("  Blocked by %d open dependencies: %v\n",
-				issue.BlockedByCount, blockedBy)
-			fmt.Println()
+			fmt.Println(formatBlockedIssue(issue))
 		}
+		fmt.Println()
 	},
 }
+
+// formatBlockedIssue renders a blocked issue and what it's waiting on, e.g.
+// [P1] bd-a3f8: "Ship login" ← blocked by bd-b1c2 (open), bd-9dd0 (in_progress)
+func formatBlockedIssue(issue *types.BlockedIssue) string {
+	blockers := make([]string, 0, len(issue.Blockers))
+	for _, blocker := range issue.Blockers {
+		blockers = append(blockers, fmt.Sprintf("%s (%s)", displayID(blocker.ID), blocker.Status))
+	}
+	return fmt.Sprintf("[P%d] %s: %q ← blocked by %s", issue.Priority, displayID(issue.ID), issue.Title, strings.Join(blockers, ", "))
+}
 var statsCmd = &cobra.Command{
 	Use:   "stats",
 	Short: "Show statistics",
 	Run: func(cmd *cobra.Command, args []string) {
+		formatStr, _ := cmd.Flags().GetString("format")
+		applyJSONFormatFlag(formatStr)
+		if effort, _ := cmd.Flags().GetBool("effort"); effort {
+			runEffortStats()
+			return
+		}
+
 		// Use global jsonOutput set by PersistentPreRun (respects config.yaml + env vars)
 		// If daemon is running, use RPC
 		if daemonClient != nil {
@@ -270,12 +417,22 @@ var statsCmd = &cobra.Command{
 }
 func init() {
 	readyCmd.Flags().IntP("limit", "n", 10, "Maximum issues to show")
-	readyCmd.Flags().IntP("priority", "p", 0, "Filter by priority")
-	readyCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
+	readyCmd.Flags().StringP("priority", "p", "", "Filter by priority (0-4, P0-P4 or a name such as high)")
+	readyCmd.Flags().String("min-priority", "", "Only show issues at least this important (e.g. 1 or P1 shows P0 and P1)")
+	readyCmd.Flags().StringP("assignee", "a", "", "Filter by assignee (\"\" for unassigned)")
+	readyCmd.Flags().Bool("unassigned", false, "Only show issues with no assignee")
+	readyCmd.Flags().String("status", "", "Filter by status: open or in_progress (default: both)")
+	readyCmd.Flags().StringP("type", "t", "", "Filter by type (bug, feature, task, epic, chore)")
 	readyCmd.Flags().StringP("sort", "s", "hybrid", "Sort policy: hybrid (default), priority, oldest")
 	readyCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL). Can combine with --label-any")
 	readyCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE). Can combine with --label")
+	readyCmd.Flags().String("format", "", "Output format: 'agent' (compact, versioned JSON summary with a claim hint)")
 	rootCmd.AddCommand(readyCmd)
+	blockedCmd.Flags().String("why", "", "Explain the chains of blockers behind this issue")
+	blockedCmd.Flags().Int("depth", 0, "With --why, levels of blockers to walk (0 for no limit)")
+	blockedCmd.Flags().Bool("notify", false, "Tell the assignees of blockers what they hold up, via webhook_url when set")
 	rootCmd.AddCommand(blockedCmd)
+	statsCmd.Flags().String("format", "", "Output format: 'json' (compact, same as --json) or 'json-pretty'")
+	statsCmd.Flags().Bool("effort", false, "Show estimated, spent and remaining effort by status, type, assignee and epic")
 	rootCmd.AddCommand(statsCmd)
 }
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// readyAgentSchema versions the bd ready --format agent shape. Fields may be
+// added under the same version; renaming or removing one needs a new version.
+const readyAgentSchema = "bd.ready.v1"
+
+// readyAgentOutput is bd ready --format agent: a compact, stable summary of
+// ready work for scripts and agents, independent of types.Issue
+type readyAgentOutput struct {
+	Schema    string            `json:"schema"`
+	Counts    readyAgentCounts  `json:"counts"`
+	Issues    []readyAgentIssue `json:"issues"`
+	ClaimHint string            `json:"claim_hint,omitempty"` // bd next command that claims ClaimID
+	ClaimID   string            `json:"claim_id,omitempty"`   // Issue that command would take now
+}
+
+// readyAgentCounts are counts over all matching ready work, not only the
+// issues shown after --limit
+type readyAgentCounts struct {
+	Ready      int `json:"ready"`
+	Shown      int `json:"shown"`
+	Open       int `json:"open"`
+	InProgress int `json:"in_progress"`
+	Blocked    int `json:"blocked"` // Open issues waiting on blockers
+}
+
+// readyAgentIssue is one ready issue in bd ready --format agent
+type readyAgentIssue struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Priority int    `json:"priority"`
+	Type     string `json:"type"`
+	Status   string `json:"status"`
+	Assignee string `json:"assignee,omitempty"`
+	WhyReady string `json:"why_ready"`
+}
+
+// shellSafeArg matches arguments that need no quoting in the claim hint
+var shellSafeArg = regexp.MustCompile(`^[A-Za-z0-9._:/=,-]+$`)
+
+// buildReadyAgentOutput runs filter and summarizes the result. limit caps the
+// issues listed (0 for all); the counts cover every match. issueType and
+// labels are the filters bd next also accepts, used for the claim hint.
+func buildReadyAgentOutput(ctx context.Context, s storage.Storage, filter types.WorkFilter, limit int, issueType string, labels []string) (*readyAgentOutput, error) {
+	filter.Limit = 0
+	ready, err := s.GetReadyWork(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	blocked, err := s.GetBlockedIssues(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &readyAgentOutput{
+		Schema: readyAgentSchema,
+		Counts: readyAgentCounts{Ready: len(ready), Blocked: len(blocked)},
+		Issues: []readyAgentIssue{},
+	}
+	for _, issue := range ready {
+		if issue.Status == types.StatusInProgress {
+			out.Counts.InProgress++
+		} else {
+			out.Counts.Open++
+		}
+	}
+	shown := ready
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+	for _, issue := range shown {
+		why, err := whyReady(ctx, s, issue)
+		if err != nil {
+			return nil, err
+		}
+		out.Issues = append(out.Issues, readyAgentIssue{
+			ID:       issue.ID,
+			Title:    issue.Title,
+			Priority: issue.Priority,
+			Type:     string(issue.IssueType),
+			Status:   string(issue.Status),
+			Assignee: issue.Assignee,
+			WhyReady: why,
+		})
+	}
+	out.Counts.Shown = len(out.Issues)
+
+	next, err := nextIssue(ctx, s, nextWorkFilter(issueType, labels, filter.Assignee, filter.MinPriority), false)
+	if err != nil {
+		return nil, err
+	}
+	if next != nil {
+		out.ClaimHint = nextClaimCommand(issueType, labels, filter.Assignee, filter.MinPriority)
+		out.ClaimID = next.ID
+	}
+	return out, nil
+}
+
+// whyReady explains in a few words why nothing holds issue up, e.g.
+// "open, all 2 blockers closed" or "in progress by alice, no blockers"
+func whyReady(ctx context.Context, s storage.Storage, issue *types.Issue) (string, error) {
+	deps, err := s.GetDependencyRecords(ctx, issue.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get dependencies of %s: %w", issue.ID, err)
+	}
+	blockers := 0
+	for _, dep := range deps {
+		if dep.Type == types.DepBlocks {
+			blockers++
+		}
+	}
+
+	state := "open"
+	if issue.Status == types.StatusInProgress {
+		state = "in progress"
+		if issue.Assignee != "" {
+			state += " by " + issue.Assignee
+		}
+	}
+	switch blockers {
+	case 0:
+		return state + ", no blockers", nil
+	case 1:
+		return state + ", its blocker is closed", nil
+	default:
+		return fmt.Sprintf("%s, all %d blockers closed", state, blockers), nil
+	}
+}
+
+// nextClaimCommand is the bd next invocation that claims the top open issue
+// matching issueType, labels, assignee and minPriority
+func nextClaimCommand(issueType string, labels []string, assignee *string, minPriority *int) string {
+	args := []string{"bd", "next", "--claim"}
+	if issueType != "" {
+		args = append(args, "--type", issueType)
+	}
+	if len(labels) > 0 {
+		args = append(args, "--label", strings.Join(labels, ","))
+	}
+	if assignee != nil {
+		args = append(args, "--assignee", *assignee)
+	}
+	if minPriority != nil {
+		args = append(args, "--min-priority", strconv.Itoa(*minPriority))
+	}
+	args = append(args, "--json")
+	for i, arg := range args {
+		if !shellSafeArg.MatchString(arg) {
+			args[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+	}
+	return strings.Join(args, " ")
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildReadyAgentOutput(t *testing.T) {
+	tmpDir := t.TempDir()
+	testStore := newTestStore(t, filepath.Join(tmpDir, ".beads", "beads.db"))
+	ctx := context.Background()
+
+	for _, issue := range []*types.Issue{
+		{ID: "test-a", Title: "Unblocked by a closed issue", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug},
+		{ID: "test-b", Title: "Done", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{ID: "test-c", Title: "Taken", Status: types.StatusInProgress, Assignee: "alice", Priority: 0, IssueType: types.TypeTask},
+		{ID: "test-d", Title: "Waiting", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{ID: "test-e", Title: "Blocker", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask},
+	} {
+		if err := testStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue %s failed: %v", issue.ID, err)
+		}
+	}
+	for _, dep := range []*types.Dependency{
+		{IssueID: "test-a", DependsOnID: "test-b", Type: types.DepBlocks},
+		{IssueID: "test-d", DependsOnID: "test-e", Type: types.DepBlocks},
+	} {
+		if err := testStore.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	if err := testStore.CloseIssue(ctx, "test-b", storage.CloseOptions{Reason: "done"}, "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	filter := types.WorkFilter{SortPolicy: types.SortPolicyPriority}
+	out, err := buildReadyAgentOutput(ctx, testStore, filter, 2, "", nil)
+	if err != nil {
+		t.Fatalf("buildReadyAgentOutput failed: %v", err)
+	}
+
+	if out.Schema != readyAgentSchema {
+		t.Errorf("schema = %q, want %q", out.Schema, readyAgentSchema)
+	}
+	wantCounts := readyAgentCounts{Ready: 3, Shown: 2, Open: 2, InProgress: 1, Blocked: 1}
+	if out.Counts != wantCounts {
+		t.Errorf("counts = %+v, want %+v", out.Counts, wantCounts)
+	}
+	wantIssues := []readyAgentIssue{
+		{ID: "test-c", Title: "Taken", Priority: 0, Type: "task", Status: "in_progress", Assignee: "alice", WhyReady: "in progress by alice, no blockers"},
+		{ID: "test-a", Title: "Unblocked by a closed issue", Priority: 1, Type: "bug", Status: "open", WhyReady: "open, its blocker is closed"},
+	}
+	if len(out.Issues) != len(wantIssues) {
+		t.Fatalf("issues = %+v, want %+v", out.Issues, wantIssues)
+	}
+	for i := range wantIssues {
+		if out.Issues[i] != wantIssues[i] {
+			t.Errorf("issues[%d] = %+v, want %+v", i, out.Issues[i], wantIssues[i])
+		}
+	}
+
+	// bd next skips in-progress work, so the hint claims the top open issue
+	if out.ClaimHint != "bd next --claim --json" || out.ClaimID != "test-a" {
+		t.Errorf("claim = %q for %q, want \"bd next --claim --json\" for test-a", out.ClaimHint, out.ClaimID)
+	}
+}
+
+func TestNextClaimCommand(t *testing.T) {
+	got := nextClaimCommand("bug", []string{"backend", "needs review"}, nil, nil)
+	want := "bd next --claim --type bug --label 'backend,needs review' --json"
+	if got != want {
+		t.Errorf("nextClaimCommand = %q, want %q", got, want)
+	}
+
+	unassigned, minPriority := "", 1
+	got = nextClaimCommand("", nil, &unassigned, &minPriority)
+	want = "bd next --claim --assignee '' --min-priority 1 --json"
+	if got != want {
+		t.Errorf("nextClaimCommand = %q, want %q", got, want)
+	}
+}
@@ -239,3 +239,17 @@ func TestReadyWorkInProgress(t *testing.T) {
 		t.Error("In-progress issue should appear in ready work")
 	}
 }
+
+func TestFormatBlockedIssue(t *testing.T) {
+	issue := &types.BlockedIssue{
+		Issue: types.Issue{ID: "bd-a3f8", Title: "Ship login", Priority: 1},
+		Blockers: []*types.Blocker{
+			{ID: "bd-b1c2", Title: "Auth API", Status: types.StatusOpen},
+			{ID: "bd-9dd0", Title: "Login form", Status: types.StatusInProgress},
+		},
+	}
+	want := `[P1] bd-a3f8: "Ship login" ← blocked by bd-b1c2 (open), bd-9dd0 (in_progress)`
+	if got := formatBlockedIssue(issue); got != want {
+		t.Errorf("formatBlockedIssue() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+// rebuildResult reports what rebuildDatabase did
+type rebuildResult struct {
+	Database       string                  `json:"database"`
+	Source         string                  `json:"source"`
+	Backup         string                  `json:"backup,omitempty"` // Where the old database was moved
+	Imported       int                     `json:"imported"`
+	MalformedLines []int                   `json:"malformed_lines,omitempty"`
+	History        *sqlite.RestoredHistory `json:"history,omitempty"`
+	HistoryError   string                  `json:"history_error,omitempty"` // Why the old history couldn't be read
+	ChildCounters  int                     `json:"child_counters"`
+}
+
+var rebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Rebuild the database from the JSONL file",
+	Long: `Rebuild the SQLite database from the JSONL file, the source of truth.
+
+A fresh database is built next to the current one:
+  1. The JSONL is imported (malformed lines are reported and skipped)
+  2. If the old database can still be read, its event history and config
+     are copied over, so status history and settings survive. Use
+     --no-history to skip this, e.g. when the old file is badly damaged
+  3. Child counters are recomputed from the imported hierarchy, so new
+     child IDs don't collide with imported ones
+
+Only once this succeeds is the old database (with its -wal and -shm files)
+moved aside to a timestamped .bak file and the new one put in its place. On
+any failure the old database is left untouched.
+
+Stop the daemon before rebuilding.
+
+Examples:
+  bd rebuild
+  bd rebuild --from backup/issues.jsonl`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		from, _ := cmd.Flags().GetString("from")
+		noHistory, _ := cmd.Flags().GetBool("no-history")
+
+		target := dbPath
+		if target == "" {
+			target = beads.FindDatabasePath()
+		}
+		if target == "" {
+			beadsDir := beads.FindBeadsDir()
+			if beadsDir == "" {
+				fmt.Fprintf(os.Stderr, "Error: no .beads directory found\n")
+				fmt.Fprintf(os.Stderr, "Hint: run 'bd init' to create one\n")
+				os.Exit(1)
+			}
+			target = filepath.Join(beadsDir, beads.CanonicalDatabaseName)
+		}
+		if from == "" {
+			from = beads.FindJSONLPath(target)
+		}
+
+		pidFile := filepath.Join(filepath.Dir(target), "daemon.pid")
+		if running, pid := isDaemonRunning(pidFile); running {
+			fmt.Fprintf(os.Stderr, "Error: daemon is running (PID %d) and holds the database open\n", pid)
+			fmt.Fprintf(os.Stderr, "Hint: stop it first with 'bd daemon --stop'\n")
+			os.Exit(1)
+		}
+
+		result, err := rebuildDatabase(ctx, target, from, !noHistory)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			fmt.Fprintf(os.Stderr, "The existing database was not changed.\n")
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+		if len(result.MalformedLines) > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: skipped %d malformed line(s) in %s (lines %s)\n",
+				len(result.MalformedLines), result.Source, formatLineNumbers(result.MalformedLines))
+		}
+		if result.HistoryError != "" {
+			fmt.Fprintf(os.Stderr, "Warning: event history not restored: %s\n", result.HistoryError)
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Rebuilt %s from %s\n", green("✓"), result.Database, result.Source)
+		fmt.Printf("  Imported %d issue(s)\n", result.Imported)
+		if result.History != nil {
+			fmt.Printf("  Restored %d event(s) and %d config value(s) from the old database\n",
+				result.History.Events, result.History.Config)
+		}
+		fmt.Printf("  Recomputed %d child counter(s)\n", result.ChildCounters)
+		if result.Backup != "" {
+			fmt.Printf("  Old database moved to %s\n", result.Backup)
+		}
+	},
+}
+
+// rebuildDatabase builds a new database at target from the JSONL at source.
+// With withHistory, the event history and config of the existing database
+// are carried over when it can be read. The existing database is only moved
+// aside, to a timestamped backup, once the new one is complete.
+func rebuildDatabase(ctx context.Context, target, source string, withHistory bool) (*rebuildResult, error) {
+	issues, malformed, err := readJSONLLenient(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", source, err)
+	}
+	result := &rebuildResult{Database: target, Source: source, MalformedLines: malformed}
+
+	tempPath := target + ".rebuild"
+	removeDatabaseFiles(tempPath)
+	newStore, err := sqlite.New(tempPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database: %w", err)
+	}
+	built := false
+	defer func() {
+		_ = newStore.Close()
+		if !built {
+			removeDatabaseFiles(tempPath)
+		}
+	}()
+
+	// The old config, if it can be read, replaces this prefix afterwards
+	prefix := config.GetString("issue-prefix")
+	if prefix == "" {
+		prefix = detectPrefixFromIssues(issues)
+	}
+	if prefix == "" {
+		prefix = filepath.Base(filepath.Dir(filepath.Dir(target)))
+	}
+	if err := newStore.SetConfig(ctx, "issue_prefix", strings.TrimRight(prefix, "-")); err != nil {
+		return nil, fmt.Errorf("failed to set issue prefix: %w", err)
+	}
+
+	imported, err := importIssuesCore(ctx, tempPath, newStore, issues, ImportOptions{
+		SkipPrefixValidation: true,
+		OrphanHandling:       "allow",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("import failed: %w", err)
+	}
+	result.Imported = imported.Created + imported.Updated + imported.Unchanged
+
+	// The import recorded its own creation events; history from the old
+	// database replaces them for the issues it covers
+	_, statErr := os.Stat(target)
+	oldExists := statErr == nil
+	if withHistory && oldExists {
+		if result.History, err = newStore.RestoreHistoryFrom(ctx, target); err != nil {
+			result.HistoryError = err.Error()
+		}
+	}
+	if result.ChildCounters, err = newStore.SyncChildCounters(ctx); err != nil {
+		return nil, err
+	}
+	if err := newStore.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rebuilt database: %w", err)
+	}
+
+	if oldExists {
+		result.Backup = fmt.Sprintf("%s.%s.bak", target, time.Now().Format("20060102-150405"))
+		for _, suffix := range []string{"", "-wal", "-shm"} {
+			if err := os.Rename(target+suffix, result.Backup+suffix); err != nil && !os.IsNotExist(err) {
+				return nil, fmt.Errorf("failed to move old database aside: %w", err)
+			}
+		}
+	}
+	if err := os.Rename(tempPath, target); err != nil {
+		return nil, fmt.Errorf("failed to install rebuilt database (old one is at %s): %w", result.Backup, err)
+	}
+	built = true
+	return result, nil
+}
+
+// removeDatabaseFiles deletes a SQLite database and its -wal and -shm files
+func removeDatabaseFiles(path string) {
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		_ = os.Remove(path + suffix)
+	}
+}
+
+func init() {
+	rebuildCmd.Flags().String("from", "", "JSONL file to rebuild from (default: the workspace's JSONL)")
+	rebuildCmd.Flags().Bool("no-history", false, "Don't copy event history and config from the old database")
+	rebuildCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output JSON format")
+	rootCmd.AddCommand(rebuildCmd)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRebuildDatabase(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), ".beads")
+	dbFile := filepath.Join(dir, "beads.db")
+	jsonlFile := filepath.Join(dir, "issues.jsonl")
+
+	old := newTestStoreWithPrefix(t, dbFile, "rb")
+	issuesToCreate := []*types.Issue{
+		{ID: "rb-a", Title: "Epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic},
+		{ID: "rb-a.1", Title: "First", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{ID: "rb-a.2", Title: "Second", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	}
+	for _, issue := range issuesToCreate {
+		if err := old.CreateIssue(ctx, issue, "alice"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := old.UpdateIssue(ctx, "rb-a", map[string]interface{}{"status": string(types.StatusInProgress)}, "alice"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	issues, err := old.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeJSONL(jsonlFile, issues); err != nil {
+		t.Fatal(err)
+	}
+	if err := old.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := rebuildDatabase(ctx, dbFile, jsonlFile, true)
+	if err != nil {
+		t.Fatalf("rebuildDatabase failed: %v", err)
+	}
+	if result.Imported != 3 || result.History == nil || result.History.Events != 4 {
+		t.Errorf("result = %+v, want 3 imported and alice's 4 events restored", result)
+	}
+	if _, err := os.Stat(result.Backup); err != nil {
+		t.Errorf("expected the old database at %s: %v", result.Backup, err)
+	}
+
+	rebuilt, err := sqlite.New(dbFile)
+	if err != nil {
+		t.Fatalf("failed to open rebuilt database: %v", err)
+	}
+	defer rebuilt.Close()
+	if next, err := rebuilt.GetNextChildID(ctx, "rb-a"); err != nil || next != "rb-a.3" {
+		t.Errorf("next child = %s (%v), want rb-a.3", next, err)
+	}
+	events, err := rebuilt.GetEvents(ctx, "rb-a", 0)
+	if err != nil || len(events) != 2 || events[0].Actor != "alice" {
+		t.Errorf("events = %+v (%v), want alice's create and status change", events, err)
+	}
+}
+
+func TestRebuildDatabase_FailureLeavesOldDatabase(t *testing.T) {
+	dir := t.TempDir()
+	dbFile := filepath.Join(dir, "beads.db")
+	if err := os.WriteFile(dbFile, []byte("original"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := rebuildDatabase(context.Background(), dbFile, filepath.Join(dir, "missing.jsonl"), true); err == nil {
+		t.Fatal("expected an error for a missing JSONL")
+	}
+	if data, _ := os.ReadFile(dbFile); string(data) != "original" {
+		t.Errorf("old database changed: %q", data)
+	}
+	if _, err := os.Stat(dbFile + ".rebuild"); !os.IsNotExist(err) {
+		t.Errorf("expected no leftover rebuild database, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/util"
+)
+
+var relabelCmd = &cobra.Command{
+	Use:   "relabel <old> <new>",
+	Short: "Rename a label on every issue that has it",
+	Long: `Replace label <old> with <new> on every issue carrying it, in a single
+transaction. An issue that already has <new> just loses <old>, so no issue
+ends up with the label twice. Each change is recorded as label events.
+
+Labels are normalized (trimmed and lowercased) as 'bd label add' does, and
+<old> matches stored labels after normalization. 'bd relabel Bug bug' is
+therefore a valid relabel that fixes copies stored before normalization.
+
+Examples:
+  bd relabel frontnd frontend --dry-run
+  bd relabel frontnd frontend --json`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		oldLabel := util.NormalizeLabel(args[0])
+		newLabel := util.NormalizeLabel(args[1])
+		if oldLabel == "" || newLabel == "" {
+			fmt.Fprintf(os.Stderr, "Error: labels cannot be empty\n")
+			os.Exit(1)
+		}
+
+		if err := ensureDirectMode("relabel updates every issue in one transaction"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		ctx := context.Background()
+		changed, err := store.RenameLabel(ctx, oldLabel, newLabel, actor, dryRun)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if !dryRun && len(changed) > 0 {
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			if changed == nil {
+				changed = []string{}
+			}
+			outputJSON(map[string]interface{}{
+				"old":     oldLabel,
+				"new":     newLabel,
+				"issues":  changed,
+				"count":   len(changed),
+				"dry_run": dryRun,
+			})
+			return
+		}
+
+		if len(changed) == 0 {
+			fmt.Printf("No issues have label '%s'\n", oldLabel)
+			return
+		}
+		if dryRun {
+			fmt.Printf("Would relabel %d issues from '%s' to '%s':\n", len(changed), oldLabel, newLabel)
+			for _, id := range changed {
+				fmt.Printf("  %s\n", id)
+			}
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Relabeled %d issues from '%s' to '%s'\n", green("✓"), len(changed), oldLabel, newLabel)
+	},
+}
+
+func init() {
+	relabelCmd.Flags().Bool("dry-run", false, "Show the issues that would be relabeled without changing them")
+	rootCmd.AddCommand(relabelCmd)
+}
@@ -5,28 +5,41 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
 )
 
 var renamePrefixCmd = &cobra.Command{
-	Use:   "rename-prefix <new-prefix>",
+	Use:   "rename-prefix [old-prefix] <new-prefix>",
 	Short: "Rename the issue prefix for all issues",
 	Long: `Rename the issue prefix for all issues in the database.
 This will update all issue IDs and all text references across all fields.
 
+Each ID keeps its hash and hierarchical suffix (bd-a3f8.1 becomes
+proj-a3f8.1). Dependencies, comments, events and labels follow the renamed
+issues, references in titles, descriptions, design, notes, acceptance
+criteria and comments are rewritten, and issue_prefix is updated. All issues
+are renamed in one transaction, after a backup of the database is made.
+Issues under a prefix_by_type prefix keep their IDs.
+
+If old-prefix is given it must be the current issue_prefix.
+`+"`--dry-run`"+` prints the first --limit remappings without changing anything.
+
 Prefix validation rules:
 - Max length: 8 characters
-- Allowed characters: lowercase letters, numbers, hyphens
+- Allowed characters: lowercase letters and numbers (no hyphens or dots)
 - Must start with a letter
-- Must end with a hyphen (e.g., 'kw-', 'work-')
+- A trailing hyphen is ignored ('kw-' is the same as 'kw')
 - Cannot be empty or just a hyphen
 
 Multiple prefix detection and repair:
@@ -36,12 +49,14 @@ preserving issues that already have the correct prefix.
 
 Example:
   bd rename-prefix kw-         # Rename from 'knowledge-work-' to 'kw-'
+  bd rename-prefix bd proj --dry-run --limit 20
   bd rename-prefix mtg- --repair  # Consolidate multiple prefixes into 'mtg-'`,
-	Args: cobra.ExactArgs(1),
+	Args: cobra.RangeArgs(1, 2),
 	Run: func(cmd *cobra.Command, args []string) {
-		newPrefix := args[0]
+		newPrefix := args[len(args)-1]
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 		repair, _ := cmd.Flags().GetBool("repair")
+		limit, _ := cmd.Flags().GetInt("limit")
 
 		ctx := context.Background()
 
@@ -70,15 +85,28 @@ Example:
 		}
 
 		newPrefix = strings.TrimRight(newPrefix, "-")
+		oldPrefix = strings.TrimRight(oldPrefix, "-")
+		if len(args) == 2 && strings.TrimRight(args[0], "-") != oldPrefix {
+			fmt.Fprintf(os.Stderr, "Error: current prefix is %s, not %s\n", oldPrefix, strings.TrimRight(args[0], "-"))
+			os.Exit(1)
+		}
 
 		// Check for multiple prefixes first
-		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to list issues: %v\n", err)
 			os.Exit(1)
 		}
 
-		prefixes := detectPrefixes(issues)
+		// Per-type prefixes are intentional, not a second prefix to repair
+		idPrefixes := utils.GetIDPrefixes(ctx, store)
+		var defaultIssues []*types.Issue
+		for _, issue := range issues {
+			if match := idPrefixes.Match(issue.ID); match == "" || match == idPrefixes.Default {
+				defaultIssues = append(defaultIssues, issue)
+			}
+		}
+		prefixes := detectPrefixes(defaultIssues)
 
 		if len(prefixes) > 1 {
 			// Multiple prefixes detected - requires repair mode
@@ -111,8 +139,8 @@ Example:
 			os.Exit(1)
 		}
 
-		// issues already fetched above
-		if len(issues) == 0 {
+		mapping := buildPrefixRenameMapping(oldPrefix, newPrefix, issues, idPrefixes)
+		if len(mapping) == 0 {
 			fmt.Printf("No issues to rename. Updating prefix to %s\n", newPrefix)
 			if !dryRun {
 				if err := store.SetConfig(ctx, "issue_prefix", newPrefix); err != nil {
@@ -123,18 +151,35 @@ Example:
 			return
 		}
 
+		// Refuse up front rather than fail partway through the transaction
+		existing := make(map[string]bool, len(issues))
+		for _, issue := range issues {
+			existing[issue.ID] = true
+		}
+		for oldID, newID := range mapping {
+			if existing[newID] {
+				fmt.Fprintf(os.Stderr, "Error: cannot rename %s: %s already exists\n", oldID, newID)
+				os.Exit(1)
+			}
+		}
+
 		if dryRun {
+			if jsonOutput {
+				if err := writeMappingJSON(os.Stdout, mapping, true); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
 			cyan := color.New(color.FgCyan).SprintFunc()
-			fmt.Printf("DRY RUN: Would rename %d issues from prefix '%s' to '%s'\n\n", len(issues), oldPrefix, newPrefix)
-			fmt.Printf("Sample changes:\n")
-			for i, issue := range issues {
-				if i >= 5 {
-					fmt.Printf("... and %d more issues\n", len(issues)-5)
+			fmt.Printf("DRY RUN: Would rename %d issues from prefix '%s' to '%s'\n\n", len(mapping), oldPrefix, newPrefix)
+			fmt.Printf("Remappings:\n")
+			for i, entry := range sortedMappingEntries(mapping) {
+				if limit > 0 && i >= limit {
+					fmt.Printf("... and %d more issues\n", len(mapping)-limit)
 					break
 				}
-				oldID := fmt.Sprintf("%s-%s", oldPrefix, strings.TrimPrefix(issue.ID, oldPrefix+"-"))
-				newID := fmt.Sprintf("%s-%s", newPrefix, strings.TrimPrefix(issue.ID, oldPrefix+"-"))
-				fmt.Printf("  %s -> %s\n", cyan(oldID), cyan(newID))
+				fmt.Printf("  %s -> %s\n", cyan(entry.OldID), cyan(entry.NewID))
 			}
 			return
 		}
@@ -142,28 +187,40 @@ Example:
 		green := color.New(color.FgGreen).SprintFunc()
 		cyan := color.New(color.FgCyan).SprintFunc()
 
-		fmt.Printf("Renaming %d issues from prefix '%s' to '%s'...\n", len(issues), oldPrefix, newPrefix)
+		backupPath, err := backupDatabase(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to create backup: %v\n", err)
+			os.Exit(1)
+		}
+		if backupPath != "" && !jsonOutput {
+			color.Green("✓ Created backup: %s\n", filepath.Base(backupPath))
+		}
+
+		if !jsonOutput {
+			fmt.Printf("Renaming %d issues from prefix '%s' to '%s'...\n", len(mapping), oldPrefix, newPrefix)
+		}
 
 		if err := renamePrefixInDB(ctx, oldPrefix, newPrefix, issues); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to rename prefix: %v\n", err)
+			if backupPath != "" {
+				fmt.Fprintf(os.Stderr, "Backup: %s\n", backupPath)
+			}
 			os.Exit(1)
 		}
 
 		// Schedule full export (IDs changed, incremental won't work)
 		markDirtyAndScheduleFullExport()
 
-		fmt.Printf("%s Successfully renamed prefix from %s to %s\n", green("✓"), cyan(oldPrefix), cyan(newPrefix))
-
 		if jsonOutput {
-			result := map[string]interface{}{
+			outputJSON(map[string]interface{}{
 				"old_prefix":   oldPrefix,
 				"new_prefix":   newPrefix,
-				"issues_count": len(issues),
-			}
-			enc := json.NewEncoder(os.Stdout)
-			enc.SetIndent("", "  ")
-			_ = enc.Encode(result)
+				"issues_count": len(mapping),
+				"backup":       backupPath,
+			})
+			return
 		}
+		fmt.Printf("%s Successfully renamed prefix from %s to %s\n", green("✓"), cyan(oldPrefix), cyan(newPrefix))
 	},
 }
 
@@ -178,16 +235,8 @@ func validatePrefix(prefix string) error {
 		return fmt.Errorf("prefix too long (max 8 characters): %s", prefix)
 	}
 
-	matched, _ := regexp.MatchString(`^[a-z][a-z0-9-]*$`, prefix)
-	if !matched {
-		return fmt.Errorf("prefix must start with a lowercase letter and contain only lowercase letters, numbers, and hyphens: %s", prefix)
-	}
-
-	if strings.HasPrefix(prefix, "-") || strings.HasSuffix(prefix, "--") {
-		return fmt.Errorf("prefix has invalid hyphen placement: %s", prefix)
-	}
-
-	return nil
+	// The same rules bd init and bd config set issue_prefix apply
+	return types.ValidateIssuePrefix(prefix)
 }
 
 // detectPrefixes analyzes all issues and returns a map of prefix -> count
@@ -369,48 +418,38 @@ func repairPrefixes(ctx context.Context, st storage.Storage, actorName string, t
 	return nil
 }
 
-func renamePrefixInDB(ctx context.Context, oldPrefix, newPrefix string, issues []*types.Issue) error {
-	// NOTE: Each issue is updated in its own transaction. A failure mid-way could leave
-	// the database in a mixed state with some issues renamed and others not.
-	// For production use, consider implementing a single atomic RenamePrefix() method
-	// in the storage layer that wraps all updates in one transaction.
-
-	oldPrefixPattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(oldPrefix) + `-(\d+)\b`)
-
-	replaceFunc := func(match string) string {
-		return strings.Replace(match, oldPrefix+"-", newPrefix+"-", 1)
-	}
-
+// buildPrefixRenameMapping maps each ID under oldPrefix to the same hash and
+// hierarchical suffix under newPrefix. IDs under a longer prefix_by_type
+// prefix (like bd-epic-) are left out.
+func buildPrefixRenameMapping(oldPrefix, newPrefix string, issues []*types.Issue, idPrefixes types.IDPrefixes) map[string]string {
+	mapping := make(map[string]string)
 	for _, issue := range issues {
-		oldID := issue.ID
-		numPart := strings.TrimPrefix(oldID, oldPrefix+"-")
-		newID := fmt.Sprintf("%s-%s", newPrefix, numPart)
-
-		issue.ID = newID
-
-		issue.Title = oldPrefixPattern.ReplaceAllStringFunc(issue.Title, replaceFunc)
-		issue.Description = oldPrefixPattern.ReplaceAllStringFunc(issue.Description, replaceFunc)
-		if issue.Design != "" {
-			issue.Design = oldPrefixPattern.ReplaceAllStringFunc(issue.Design, replaceFunc)
-		}
-		if issue.AcceptanceCriteria != "" {
-			issue.AcceptanceCriteria = oldPrefixPattern.ReplaceAllStringFunc(issue.AcceptanceCriteria, replaceFunc)
-		}
-		if issue.Notes != "" {
-			issue.Notes = oldPrefixPattern.ReplaceAllStringFunc(issue.Notes, replaceFunc)
+		if match := idPrefixes.Match(issue.ID); match != "" && match != oldPrefix {
+			continue
 		}
-
-		if err := store.UpdateIssueID(ctx, oldID, newID, issue, actor); err != nil {
-			return fmt.Errorf("failed to update issue %s: %w", oldID, err)
+		if rest, ok := strings.CutPrefix(issue.ID, oldPrefix+"-"); ok {
+			mapping[issue.ID] = newPrefix + "-" + rest
 		}
 	}
+	return mapping
+}
 
-	if err := store.RenameDependencyPrefix(ctx, oldPrefix, newPrefix); err != nil {
-		return fmt.Errorf("failed to update dependencies: %w", err)
+// renamePrefixInDB renames every issue under oldPrefix to newPrefix in a single
+// transaction, rewriting references to them in all issues, then updates
+// issue_prefix
+func renamePrefixInDB(ctx context.Context, oldPrefix, newPrefix string, issues []*types.Issue) error {
+	mapping := buildPrefixRenameMapping(oldPrefix, newPrefix, issues, utils.GetIDPrefixes(ctx, store))
+	refPattern := idRefPattern(mapping, `[0-9a-z]+`)
+
+	// applyIDMapping leaves titles alone; rename-prefix rewrites them too
+	for _, issue := range issues {
+		issue.Title = replaceIDReferencesMatching(refPattern, issue.Title, mapping)
 	}
 
-	if err := store.RenameCounterPrefix(ctx, oldPrefix, newPrefix); err != nil {
-		return fmt.Errorf("failed to update counter: %w", err)
+	if err := store.WithTx(ctx, func(tx storage.Transaction) error {
+		return applyIDMapping(ctx, tx, issues, mapping, refPattern)
+	}); err != nil {
+		return err
 	}
 
 	if err := store.SetConfig(ctx, "issue_prefix", newPrefix); err != nil {
@@ -420,8 +459,28 @@ func renamePrefixInDB(ctx context.Context, oldPrefix, newPrefix string, issues [
 	return nil
 }
 
+// backupDatabase copies the database next to itself as
+// <name>.backup-<timestamp>.db, as migrate-hash-ids does, and returns the
+// backup path ("" when there is no database file to copy)
+func backupDatabase(ctx context.Context) (string, error) {
+	sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+	if !ok || dbPath == "" {
+		return "", nil
+	}
+	// Fold the WAL into the main file so the copy has every committed write
+	if err := sqliteStore.CheckpointWAL(ctx); err != nil {
+		return "", fmt.Errorf("failed to checkpoint WAL: %w", err)
+	}
+	backupPath := strings.TrimSuffix(dbPath, ".db") + ".backup-" + time.Now().Format("20060102-150405") + ".db"
+	if err := copyFile(dbPath, backupPath); err != nil {
+		return "", err
+	}
+	return backupPath, nil
+}
+
 func init() {
 	renamePrefixCmd.Flags().Bool("dry-run", false, "Preview changes without applying them")
+	renamePrefixCmd.Flags().Int("limit", 10, "Number of remappings --dry-run prints (0 = all)")
 	renamePrefixCmd.Flags().Bool("repair", false, "Repair database with multiple prefixes by consolidating them")
 	rootCmd.AddCommand(renamePrefixCmd)
 }
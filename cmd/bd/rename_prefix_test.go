@@ -18,7 +18,8 @@ func TestValidatePrefix(t *testing.T) {
 	}{
 		{"valid lowercase", "kw-", false},
 		{"valid with numbers", "work1-", false},
-		{"valid with hyphen", "my-work-", false},
+		{"inner hyphen", "my-work-", true},
+		{"dot", "my.work", true},
 		{"empty", "", true},
 		{"too long", "verylongprefix-", true},
 		{"starts with number", "1work-", true},
@@ -219,3 +220,80 @@ func TestRenamePrefixInDB(t *testing.T) {
 		t.Errorf("Expected ID 'new-1', got %q", newIssue.ID)
 	}
 }
+
+func TestRenamePrefixInDBHashIDs(t *testing.T) {
+	testStore := newTestStore(t, filepath.Join(t.TempDir(), "test.db"))
+	ctx := context.Background()
+	store = testStore
+	actor = "test"
+	defer func() {
+		store = nil
+		actor = ""
+	}()
+
+	if err := testStore.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("Failed to set config: %v", err)
+	}
+	if err := testStore.SetConfig(ctx, types.PrefixByTypeConfigKey, `{"epic":"bdepic"}`); err != nil {
+		t.Fatalf("Failed to set prefix_by_type: %v", err)
+	}
+
+	for _, issue := range []*types.Issue{
+		{ID: "bd-a3f8", Title: "Parent", Description: "Child is bd-a3f8.1", IssueType: types.TypeTask},
+		{ID: "bd-a3f8.1", Title: "Child of bd-a3f8", IssueType: types.TypeTask},
+		{ID: "bdepic-91cc", Title: "Epic over bd-a3f8", IssueType: types.TypeEpic},
+	} {
+		issue.Status = types.StatusOpen
+		issue.Priority = 2
+		if err := testStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("Failed to create %s: %v", issue.ID, err)
+		}
+	}
+	// Take child number 1 from the counter, as bd create --parent does
+	if childID, err := testStore.GetNextChildID(ctx, "bd-a3f8"); err != nil || childID != "bd-a3f8.1" {
+		t.Fatalf("GetNextChildID = %s, %v; want bd-a3f8.1", childID, err)
+	}
+	if _, err := testStore.AddIssueComment(ctx, "bd-a3f8", "test", "Split into bd-a3f8.1"); err != nil {
+		t.Fatalf("Failed to add comment: %v", err)
+	}
+
+	issues, err := testStore.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if err := renamePrefixInDB(ctx, "bd", "proj", issues); err != nil {
+		t.Fatalf("renamePrefixInDB failed: %v", err)
+	}
+
+	parent, err := testStore.GetIssue(ctx, "proj-a3f8")
+	if err != nil || parent == nil {
+		t.Fatalf("proj-a3f8 not found: %v", err)
+	}
+	if parent.Description != "Child is proj-a3f8.1" {
+		t.Errorf("description = %q", parent.Description)
+	}
+	child, err := testStore.GetIssue(ctx, "proj-a3f8.1")
+	if err != nil || child == nil || child.Title != "Child of proj-a3f8" {
+		t.Errorf("child = %+v, %v; want proj-a3f8.1 with rewritten title", child, err)
+	}
+
+	// The per-type prefix keeps its ID but its references are rewritten
+	epic, err := testStore.GetIssue(ctx, "bdepic-91cc")
+	if err != nil || epic == nil || epic.Title != "Epic over proj-a3f8" {
+		t.Errorf("epic = %+v, %v; want bdepic-91cc with rewritten title", epic, err)
+	}
+
+	comments, err := testStore.GetIssueComments(ctx, "proj-a3f8")
+	if err != nil || len(comments) != 1 || comments[0].Text != "Split into proj-a3f8.1" {
+		t.Errorf("comments = %+v, %v; want the comment moved and rewritten", comments, err)
+	}
+
+	// The child counter moved with the parent, so the next child is .2
+	nextID, err := testStore.GetNextChildID(ctx, "proj-a3f8")
+	if err != nil {
+		t.Fatalf("GetNextChildID failed: %v", err)
+	}
+	if nextID != "proj-a3f8.2" {
+		t.Errorf("next child = %s, want proj-a3f8.2", nextID)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
@@ -14,21 +16,84 @@ var reopenCmd = &cobra.Command{
 	Use:   "reopen [id...]",
 	Short: "Reopen one or more closed issues",
 	Long: `Reopen closed issues by setting status to 'open' and clearing the closed_at timestamp.
-This is more explicit than 'bd update --status open' and emits a Reopened event.`,
-	Args: cobra.MinimumNArgs(1),
+This is more explicit than 'bd update --status open' and emits a Reopened event.
+
+--reason is recorded as a comment (shown by 'bd comments', and included as
+reason_comment in --json output). --note is stored on the Reopened event itself
+without creating a comment, and is shown by 'bd show --history'. Both can be
+given together.
+
+Issues that aren't closed are skipped with an "already open" notice and no
+event is written, so reopening is safe to repeat. Use --force to reopen and
+record the Reopened event anyway.
+
+Instead of IDs, --label, --closed-after and --status select the issues to
+reopen (closed issues by default). The matching count is confirmed before
+anything changes unless --yes is given. --dry-run lists the issues that would
+be reopened without reopening them.
+
+--cascade also reopens every closed descendant in the parent-child hierarchy,
+parents before children, noting on each Reopened event which issue the
+cascade came from. Combine it with --dry-run to see what it would reach.
+
+Examples:
+  bd reopen bd-42 --reason "Regressed"
+  bd reopen --label release-1.4 --closed-after 2025-06-01 --dry-run
+  bd reopen --label release-1.4 --closed-after 3d --yes --note "Release reverted"
+  bd reopen bd-a3f8 --cascade --reason "Feature regressed"`,
 	Run: func(cmd *cobra.Command, args []string) {
 		reason, _ := cmd.Flags().GetString("reason")
+		note, _ := cmd.Flags().GetString("note")
+		force, _ := cmd.Flags().GetBool("force")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+		cascade, _ := cmd.Flags().GetBool("cascade")
 		// Use global jsonOutput set by PersistentPreRun
 		ctx := context.Background()
+		filterMode := cmd.Flags().Changed("label") || cmd.Flags().Changed("closed-after") || cmd.Flags().Changed("status")
+		if filterMode && len(args) > 0 {
+			exitInvalid("issue IDs cannot be combined with --label, --closed-after or --status")
+		}
+		if !filterMode && len(args) == 0 {
+			exitInvalid("requires at least one issue ID, or --label, --closed-after or --status")
+		}
 		// Resolve partial IDs first
 		var resolvedIDs []string
-		if daemonClient != nil {
+		if filterMode {
+			labels, _ := cmd.Flags().GetStringSlice("label")
+			closedAfterStr, _ := cmd.Flags().GetString("closed-after")
+			status, _ := cmd.Flags().GetString("status")
+			if !types.Status(status).IsValid() {
+				exitInvalid("invalid status %q", status)
+			}
+			var closedAfter *time.Time
+			if closedAfterStr != "" {
+				t, err := parseTimeFlag(closedAfterStr)
+				if err != nil {
+					exitInvalid("invalid --closed-after: %v", err)
+				}
+				closedAfter = &t
+			}
+			var err error
+			resolvedIDs, err = findReopenCandidates(ctx, types.Status(status), labels, closedAfter)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if len(resolvedIDs) == 0 {
+				if jsonOutput {
+					outputJSON([]reopenResult{})
+				} else {
+					fmt.Println("No issues match")
+				}
+				return
+			}
+		} else if daemonClient != nil {
 			for _, id := range args {
 				resolveArgs := &rpc.ResolveIDArgs{ID: id}
 				resp, err := daemonClient.ResolveID(resolveArgs)
 				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error resolving ID %s: %v\n", id, err)
-					os.Exit(1)
+					exitWithError(err, "Error resolving ID %s: %v", id, err)
 				}
 				var resolvedID string
 				if err := json.Unmarshal(resp.Data, &resolvedID); err != nil {
@@ -41,32 +106,134 @@ This is more explicit than 'bd update --status open' and emits a Reopened event.
 			var err error
 			resolvedIDs, err = utils.ResolvePartialIDs(ctx, store, args)
 			if err != nil {
+				exitWithError(err, "Error: %v", err)
+			}
+		}
+		// With --cascade, each issue's closed descendants follow it
+		plan := make([]reopenPlanItem, 0, len(resolvedIDs))
+		if cascade {
+			var children childLister
+			if daemonClient != nil {
+				children = daemonChildLister(daemonClient)
+			} else if store != nil {
+				var err error
+				if children, err = storeChildLister(ctx, store); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+			} else {
+				fmt.Fprintln(os.Stderr, "Error: database not initialized")
+				os.Exit(1)
+			}
+			var err error
+			if plan, err = buildReopenPlan(resolvedIDs, true, children); err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
 			}
+		} else {
+			for _, id := range resolvedIDs {
+				plan = append(plan, reopenPlanItem{ID: id})
+			}
+		}
+		if dryRun {
+			if jsonOutput {
+				ids := make([]string, len(plan))
+				cascadeFrom := make(map[string]string)
+				for i, item := range plan {
+					ids[i] = item.ID
+					if item.CascadeFrom != "" {
+						cascadeFrom[item.ID] = item.CascadeFrom
+					}
+				}
+				result := map[string]interface{}{
+					"dry_run":      true,
+					"count":        len(plan),
+					"would_reopen": ids,
+				}
+				if cascade {
+					result["cascade_from"] = cascadeFrom
+				}
+				outputJSON(result)
+			} else {
+				fmt.Printf("Would reopen %d issue(s):\n", len(plan))
+				for _, item := range plan {
+					if item.CascadeFrom != "" {
+						fmt.Printf("  %s (cascade from %s)\n", item.ID, item.CascadeFrom)
+					} else {
+						fmt.Printf("  %s\n", item.ID)
+					}
+				}
+			}
+			return
+		}
+		if filterMode && !yes {
+			// Prompt on stderr so --json output stays parseable
+			fmt.Fprintf(os.Stderr, "Reopen %d issue(s)? [y/N] ", len(plan))
+			var response string
+			_, _ = fmt.Scanln(&response)
+			if strings.ToLower(strings.TrimSpace(response)) != "y" {
+				fmt.Fprintln(os.Stderr, "Canceled.")
+				return
+			}
+		}
+		results := []reopenResult{}
+		var firstErr error
+		fail := func(id string, err error) {
+			fmt.Fprintf(os.Stderr, "Error reopening %s: %v\n", id, err)
+			if firstErr == nil {
+				firstErr = err
+			}
 		}
-		reopenedIssues := []*types.Issue{}
 		// If daemon is running, use RPC
 		if daemonClient != nil {
-			for _, id := range resolvedIDs {
-				openStatus := string(types.StatusOpen)
-				updateArgs := &rpc.UpdateArgs{
-					ID:     id,
-					Status: &openStatus,
+			for _, item := range plan {
+				id := item.ID
+				if !force {
+					showResp, err := daemonClient.Show(&rpc.ShowArgs{ID: id})
+					if err != nil {
+						fail(id, err)
+						continue
+					}
+					var current types.Issue
+					if err := json.Unmarshal(showResp.Data, &current); err != nil {
+						fmt.Fprintf(os.Stderr, "Error parsing %s: %v\n", id, err)
+						continue
+					}
+					if current.Status != types.StatusClosed {
+						results = append(results, skipReopen(&current))
+						continue
+					}
+				}
+				reopenArgs := &rpc.ReopenArgs{
+					ID:   id,
+					Note: reopenNote(item, note),
 				}
-				resp, err := daemonClient.Update(updateArgs)
+				resp, err := daemonClient.ReopenIssue(reopenArgs)
 				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error reopening %s: %v\n", id, err)
+					fail(id, err)
 					continue
 				}
-				// TODO: Add reason as a comment once RPC supports AddComment
+				// Add reason as a comment if provided
+				var comment *types.Comment
 				if reason != "" {
-					fmt.Fprintf(os.Stderr, "Warning: reason not supported in daemon mode yet\n")
+					commentResp, err := daemonClient.AddComment(&rpc.CommentAddArgs{
+						ID:     id,
+						Author: actor,
+						Text:   reason,
+					})
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "Warning: failed to add comment to %s: %v\n", id, err)
+					} else {
+						comment = &types.Comment{}
+						if err := json.Unmarshal(commentResp.Data, comment); err != nil {
+							comment = nil
+						}
+					}
 				}
 				if jsonOutput {
 					var issue types.Issue
 					if err := json.Unmarshal(resp.Data, &issue); err == nil {
-						reopenedIssues = append(reopenedIssues, &issue)
+						results = append(results, reopenResult{Issue: &issue, Comment: comment})
 					}
 				} else {
 					blue := color.New(color.FgBlue).SprintFunc()
@@ -74,12 +241,13 @@ This is more explicit than 'bd update --status open' and emits a Reopened event.
 					if reason != "" {
 						reasonMsg = ": " + reason
 					}
-					fmt.Printf("%s Reopened %s%s\n", blue("↻"), id, reasonMsg)
+					fmt.Printf("%s Reopened %s%s%s\n", blue("↻"), id, reasonMsg, cascadeSuffix(item))
 				}
 			}
-			if jsonOutput && len(reopenedIssues) > 0 {
-				outputJSON(reopenedIssues)
+			if jsonOutput && len(results) > 0 {
+				outputJSON(results)
 			}
+			exitIfFailed(firstErr, len(results) > 0)
 			return
 		}
 		// Fall back to direct storage access
@@ -87,30 +255,38 @@ This is more explicit than 'bd update --status open' and emits a Reopened event.
 			fmt.Fprintln(os.Stderr, "Error: database not initialized")
 			os.Exit(1)
 		}
-		for _, id := range args {
-			fullID, err := utils.ResolvePartialID(ctx, store, id)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", id, err)
-				continue
-			}
-			// UpdateIssue automatically clears closed_at when status changes from closed
-			updates := map[string]interface{}{
-				"status": string(types.StatusOpen),
+		reopened := 0
+		for _, item := range plan {
+			fullID := item.ID
+			var err error
+			if !force {
+				current, err := store.GetIssue(ctx, fullID)
+				if err != nil {
+					fail(fullID, err)
+					continue
+				}
+				if current != nil && current.Status != types.StatusClosed {
+					results = append(results, skipReopen(current))
+					continue
+				}
 			}
-			if err := store.UpdateIssue(ctx, fullID, updates, actor); err != nil {
-				fmt.Fprintf(os.Stderr, "Error reopening %s: %v\n", fullID, err)
+			// ReopenIssue clears closed_at and records the note on the Reopened event
+			if err := store.ReopenIssue(ctx, fullID, reopenNote(item, note), actor); err != nil {
+				fail(fullID, err)
 				continue
 			}
+			reopened++
 			// Add reason as a comment if provided
+			var comment *types.Comment
 			if reason != "" {
-				if err := store.AddComment(ctx, fullID, actor, reason); err != nil {
+				if comment, err = store.AddIssueComment(ctx, fullID, actor, reason); err != nil {
 					fmt.Fprintf(os.Stderr, "Warning: failed to add comment to %s: %v\n", fullID, err)
 				}
 			}
 			if jsonOutput {
 				issue, _ := store.GetIssue(ctx, fullID)
 				if issue != nil {
-					reopenedIssues = append(reopenedIssues, issue)
+					results = append(results, reopenResult{Issue: issue, Comment: comment})
 				}
 			} else {
 				blue := color.New(color.FgBlue).SprintFunc()
@@ -118,19 +294,100 @@ This is more explicit than 'bd update --status open' and emits a Reopened event.
 				if reason != "" {
 					reasonMsg = ": " + reason
 				}
-				fmt.Printf("%s Reopened %s%s\n", blue("↻"), fullID, reasonMsg)
+				fmt.Printf("%s Reopened %s%s%s\n", blue("↻"), fullID, reasonMsg, cascadeSuffix(item))
 			}
 		}
 		// Schedule auto-flush if any issues were reopened
-		if len(args) > 0 {
+		if reopened > 0 {
 			markDirtyAndScheduleFlush()
 		}
-		if jsonOutput && len(reopenedIssues) > 0 {
-			outputJSON(reopenedIssues)
+		if jsonOutput && len(results) > 0 {
+			outputJSON(results)
 		}
+		exitIfFailed(firstErr, len(results) > 0)
 	},
 }
+// findReopenCandidates returns the IDs of issues with the given status,
+// all of labels, and closed after closedAfter if set
+func findReopenCandidates(ctx context.Context, status types.Status, labels []string, closedAfter *time.Time) ([]string, error) {
+	var issues []*types.Issue
+	if daemonClient != nil {
+		listArgs := &rpc.ListArgs{Status: string(status), Labels: labels}
+		if closedAfter != nil {
+			listArgs.ClosedAfter = closedAfter.Format(time.RFC3339)
+		}
+		resp, err := daemonClient.List(listArgs)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(resp.Data, &issues); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+	} else {
+		if store == nil {
+			return nil, fmt.Errorf("database not initialized")
+		}
+		var err error
+		issues, err = store.SearchIssues(ctx, "", types.IssueFilter{
+			Status:      &status,
+			Labels:      labels,
+			ClosedAfter: closedAfter,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids, nil
+}
+// reopenNote is the note for item's Reopened event: --note, prefixed with
+// the cascade source for a descendant
+func reopenNote(item reopenPlanItem, note string) string {
+	if item.CascadeFrom != "" {
+		return cascadeReopenNote(item.CascadeFrom, note)
+	}
+	return note
+}
+// cascadeSuffix marks a descendant's line in the output with its cascade source
+func cascadeSuffix(item reopenPlanItem) string {
+	if item.CascadeFrom == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (cascade from %s)", item.CascadeFrom)
+}
+// reopenResult is one entry of 'bd reopen --json' output: the issue, marked
+// as skipped when it wasn't closed and --force wasn't given, with the comment
+// created from --reason
+type reopenResult struct {
+	*types.Issue
+	Comment    *types.Comment `json:"reason_comment,omitempty"`
+	Skipped    bool           `json:"skipped,omitempty"`
+	SkipReason string         `json:"skip_reason,omitempty"`
+}
+// skipReopen reports an issue that is already open and returns its result entry
+func skipReopen(issue *types.Issue) reopenResult {
+	reason := "already open"
+	if issue.Status != types.StatusOpen {
+		reason = fmt.Sprintf("not closed (status: %s)", issue.Status)
+	}
+	if !jsonOutput {
+		yellow := color.New(color.FgYellow).SprintFunc()
+		fmt.Printf("%s %s is %s (use --force to reopen anyway)\n", yellow("⚠"), issue.ID, reason)
+	}
+	return reopenResult{Issue: issue, Skipped: true, SkipReason: reason}
+}
 func init() {
 	reopenCmd.Flags().StringP("reason", "r", "", "Reason for reopening")
+	reopenCmd.Flags().String("note", "", "Note recorded on the Reopened event instead of as a comment")
+	reopenCmd.Flags().BoolP("force", "f", false, "Reopen and record the event even if the issue isn't closed")
+	reopenCmd.Flags().StringSliceP("label", "l", []string{}, "Reopen issues with all of these labels (instead of IDs)")
+	reopenCmd.Flags().String("closed-after", "", "Reopen issues closed after this date (YYYY-MM-DD, RFC3339, or a duration like 7d)")
+	reopenCmd.Flags().StringP("status", "s", string(types.StatusClosed), "Reopen issues with this status (with --label/--closed-after)")
+	reopenCmd.Flags().Bool("cascade", false, "Also reopen every closed descendant (parent-child children, their children, ...)")
+	reopenCmd.Flags().Bool("dry-run", false, "List the issues that would be reopened without reopening them")
+	reopenCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt when reopening by filter")
 	rootCmd.AddCommand(reopenCmd)
 }
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// reopenPlanItem is an issue bd reopen will reopen. CascadeFrom names the
+// issue given on the command line whose --cascade reached it.
+type reopenPlanItem struct {
+	ID          string `json:"id"`
+	CascadeFrom string `json:"cascade_from,omitempty"`
+}
+
+// childLister returns an issue's parent-child children
+type childLister func(id string) ([]*types.Issue, error)
+
+// buildReopenPlan returns the issues reopening ids will reopen: each one,
+// then with cascade its closed descendants, parents before children. Open
+// descendants are walked through but not reopened. An issue reachable from
+// several targets is reopened once, and each issue is visited once per
+// target, so a cycle imported around AddDependency's checks ends the walk.
+func buildReopenPlan(ids []string, cascade bool, children childLister) ([]reopenPlanItem, error) {
+	planned := make(map[string]bool)
+	var plan []reopenPlanItem
+	add := func(id, cascadeFrom string) {
+		if !planned[id] {
+			planned[id] = true
+			plan = append(plan, reopenPlanItem{ID: id, CascadeFrom: cascadeFrom})
+		}
+	}
+
+	for _, id := range ids {
+		add(id, "")
+		if !cascade {
+			continue
+		}
+		seen := map[string]bool{id: true}
+		queue := []string{id}
+		for len(queue) > 0 {
+			parentID := queue[0]
+			queue = queue[1:]
+			kids, err := children(parentID)
+			if err != nil {
+				return nil, err
+			}
+			for _, child := range kids {
+				if seen[child.ID] {
+					continue
+				}
+				seen[child.ID] = true
+				queue = append(queue, child.ID)
+				if child.Status == types.StatusClosed {
+					add(child.ID, id)
+				}
+			}
+		}
+	}
+	return plan, nil
+}
+
+// storeChildLister lists children from one read of every dependency in s
+func storeChildLister(ctx context.Context, s storage.Storage) (childLister, error) {
+	allDeps, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	children := parentChildChildren(allDeps)
+	return func(id string) ([]*types.Issue, error) {
+		var kids []*types.Issue
+		for _, childID := range children[id] {
+			child, err := s.GetIssue(ctx, childID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get %s: %w", childID, err)
+			}
+			if child != nil {
+				kids = append(kids, child)
+			}
+		}
+		return kids, nil
+	}, nil
+}
+
+// daemonChildLister lists children from the dependents the daemon's show
+// returns
+func daemonChildLister(client *rpc.Client) childLister {
+	return func(id string) ([]*types.Issue, error) {
+		resp, err := client.Show(&rpc.ShowArgs{ID: id})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", id, err)
+		}
+		var details struct {
+			Dependents []*types.IssueWithDependencyMetadata `json:"dependents"`
+		}
+		if err := json.Unmarshal(resp.Data, &details); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", id, err)
+		}
+		var kids []*types.Issue
+		for _, dependent := range details.Dependents {
+			if dependent.DependencyType == types.DepParentChild {
+				kids = append(kids, &dependent.Issue)
+			}
+		}
+		return kids, nil
+	}
+}
+
+// cascadeReopenNote is the note recorded on the Reopened event of an issue
+// reopened because its ancestor was, followed by any --note given
+func cascadeReopenNote(rootID, note string) string {
+	cascadeNote := fmt.Sprintf("Cascade-reopened from parent %s", rootID)
+	if note != "" {
+		cascadeNote += ": " + note
+	}
+	return cascadeNote
+}
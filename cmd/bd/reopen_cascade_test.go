@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildReopenPlan(t *testing.T) {
+	tmpDir := t.TempDir()
+	testStore := newTestStore(t, filepath.Join(tmpDir, ".beads", "beads.db"))
+	ctx := context.Background()
+
+	newIssue := func(id string, closed bool, parent string) {
+		t.Helper()
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := testStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		if parent != "" {
+			dep := &types.Dependency{IssueID: id, DependsOnID: parent, Type: types.DepParentChild}
+			if err := testStore.AddDependency(ctx, dep, "test"); err != nil {
+				t.Fatalf("AddDependency failed: %v", err)
+			}
+		}
+		if closed {
+			if err := testStore.CloseIssue(ctx, id, storage.CloseOptions{Reason: "done"}, "test"); err != nil {
+				t.Fatalf("CloseIssue failed: %v", err)
+			}
+		}
+	}
+	newIssue("test-1", true, "")
+	newIssue("test-1.1", true, "test-1")
+	newIssue("test-1.2", false, "test-1")
+	newIssue("test-1.2.1", true, "test-1.2") // Closed under an open child
+	newIssue("test-2", true, "")
+	if err := testStore.AddDependency(ctx, &types.Dependency{IssueID: "test-2", DependsOnID: "test-1.1", Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	children, err := storeChildLister(ctx, testStore)
+	if err != nil {
+		t.Fatalf("storeChildLister failed: %v", err)
+	}
+	plan, err := buildReopenPlan([]string{"test-1"}, true, children)
+	if err != nil {
+		t.Fatalf("buildReopenPlan failed: %v", err)
+	}
+	want := []reopenPlanItem{
+		{ID: "test-1"},
+		{ID: "test-1.1", CascadeFrom: "test-1"},
+		{ID: "test-1.2.1", CascadeFrom: "test-1"},
+	}
+	if len(plan) != len(want) {
+		t.Fatalf("plan = %+v, want %+v", plan, want)
+	}
+	for i := range want {
+		if plan[i] != want[i] {
+			t.Errorf("plan[%d] = %+v, want %+v", i, plan[i], want[i])
+		}
+	}
+
+	// Without --cascade only the issues named are planned
+	plan, err = buildReopenPlan([]string{"test-1"}, false, children)
+	if err != nil || len(plan) != 1 {
+		t.Errorf("plan without cascade = %+v, %v", plan, err)
+	}
+}
+
+func TestBuildReopenPlanCycle(t *testing.T) {
+	closed := func(id string) *types.Issue { return &types.Issue{ID: id, Status: types.StatusClosed} }
+	graph := map[string][]*types.Issue{
+		"bd-1": {closed("bd-2")},
+		"bd-2": {closed("bd-3")},
+		"bd-3": {closed("bd-1")},
+	}
+	calls := 0
+	plan, err := buildReopenPlan([]string{"bd-1"}, true, func(id string) ([]*types.Issue, error) {
+		calls++
+		return graph[id], nil
+	})
+	if err != nil {
+		t.Fatalf("buildReopenPlan failed: %v", err)
+	}
+	if len(plan) != 3 || plan[0].ID != "bd-1" || plan[1].CascadeFrom != "bd-1" || plan[2].ID != "bd-3" {
+		t.Errorf("plan = %+v, want bd-1 then bd-2, bd-3 from bd-1", plan)
+	}
+	if calls != 3 {
+		t.Errorf("children listed %d times, want each issue once", calls)
+	}
+}
+
+func TestCascadeReopenNote(t *testing.T) {
+	if got := cascadeReopenNote("bd-1", ""); got != "Cascade-reopened from parent bd-1" {
+		t.Errorf("got %q", got)
+	}
+	if got := reopenNote(reopenPlanItem{ID: "bd-1.1", CascadeFrom: "bd-1"}, "Regressed"); got != "Cascade-reopened from parent bd-1: Regressed" {
+		t.Errorf("got %q", got)
+	}
+	if got := reopenNote(reopenPlanItem{ID: "bd-1"}, "Regressed"); got != "Regressed" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -30,7 +32,7 @@ func (h *reopenTestHelper) createIssue(title string, issueType types.IssueType,
 }
 
 func (h *reopenTestHelper) closeIssue(issueID, reason string) {
-	if err := h.s.CloseIssue(h.ctx, issueID, "test-user", reason); err != nil {
+	if err := h.s.CloseIssue(h.ctx, issueID, storage.CloseOptions{Reason: reason}, "test-user"); err != nil {
 		h.t.Fatalf("Failed to close issue: %v", err)
 	}
 }
@@ -143,4 +145,38 @@ func TestReopenCommand(t *testing.T) {
 		h.assertStatus(issue.ID, types.StatusOpen)
 		h.assertClosedAtNil(issue.ID)
 	})
+
+	t.Run("filter selects matching closed issues", func(t *testing.T) {
+		oldStore, oldClient := store, daemonClient
+		defer func() { store, daemonClient = oldStore, oldClient }()
+		store, daemonClient = s, nil
+
+		labeled := h.createIssue("Filter Labeled", types.TypeBug, 1)
+		openLabeled := h.createIssue("Filter Open Labeled", types.TypeBug, 1)
+		unlabeled := h.createIssue("Filter Unlabeled", types.TypeBug, 1)
+		for _, id := range []string{labeled.ID, openLabeled.ID} {
+			if err := s.AddLabel(ctx, id, "release-1", "test-user"); err != nil {
+				t.Fatalf("Failed to add label: %v", err)
+			}
+		}
+		h.closeIssue(labeled.ID, "Done")
+		h.closeIssue(unlabeled.ID, "Done")
+
+		ids, err := findReopenCandidates(ctx, types.StatusClosed, []string{"release-1"}, nil)
+		if err != nil {
+			t.Fatalf("findReopenCandidates failed: %v", err)
+		}
+		if len(ids) != 1 || ids[0] != labeled.ID {
+			t.Errorf("label filter = %v, want [%s]", ids, labeled.ID)
+		}
+
+		future := time.Now().Add(time.Hour)
+		ids, err = findReopenCandidates(ctx, types.StatusClosed, nil, &future)
+		if err != nil {
+			t.Fatalf("findReopenCandidates failed: %v", err)
+		}
+		if len(ids) != 0 {
+			t.Errorf("closed-after filter in the future = %v, want none", ids)
+		}
+	})
 }
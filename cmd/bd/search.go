@@ -0,0 +1,402 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// savedSearchConfigPrefix namespaces saved searches in the config table
+const savedSearchConfigPrefix = "search."
+
+// savedSearch is a named query and filter set persisted in config
+type savedSearch struct {
+	Query     string   `json:"query,omitempty"`
+	Status    string   `json:"status,omitempty"`
+	Priority  *int     `json:"priority,omitempty"`
+	Assignee  string   `json:"assignee,omitempty"`
+	IssueType string   `json:"type,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	LabelsAny []string `json:"labels_any,omitempty"`
+	Limit     int      `json:"limit,omitempty"`
+
+	IncludeArchived bool `json:"include_archived,omitempty"`
+}
+
+// filter converts the saved search into an IssueFilter for SearchIssues
+func (s savedSearch) filter() types.IssueFilter {
+	filter := types.IssueFilter{
+		Priority:  s.Priority,
+		Labels:    s.Labels,
+		LabelsAny: s.LabelsAny,
+		Limit:     s.Limit,
+
+		IncludeArchived: s.IncludeArchived,
+	}
+	if s.Status != "" && s.Status != "all" {
+		status := types.Status(s.Status)
+		filter.Status = &status
+	}
+	if s.Assignee != "" {
+		assignee := s.Assignee
+		filter.Assignee = &assignee
+	}
+	if s.IssueType != "" {
+		issueType := types.IssueType(s.IssueType)
+		filter.IssueType = &issueType
+	}
+	return filter
+}
+
+// describe renders the saved search as the equivalent command line
+func (s savedSearch) describe() string {
+	var parts []string
+	if s.Query != "" {
+		parts = append(parts, fmt.Sprintf("%q", s.Query))
+	}
+	if s.Status != "" {
+		parts = append(parts, "--status "+s.Status)
+	}
+	if s.Priority != nil {
+		parts = append(parts, fmt.Sprintf("--priority %d", *s.Priority))
+	}
+	if s.Assignee != "" {
+		parts = append(parts, "--assignee "+s.Assignee)
+	}
+	if s.IssueType != "" {
+		parts = append(parts, "--type "+s.IssueType)
+	}
+	if len(s.Labels) > 0 {
+		parts = append(parts, "--label "+strings.Join(s.Labels, ","))
+	}
+	if len(s.LabelsAny) > 0 {
+		parts = append(parts, "--label-any "+strings.Join(s.LabelsAny, ","))
+	}
+	if s.Limit > 0 {
+		parts = append(parts, fmt.Sprintf("--limit %d", s.Limit))
+	}
+	if s.IncludeArchived {
+		parts = append(parts, "--include-archived")
+	}
+	return strings.Join(parts, " ")
+}
+
+// validateSearchName checks that a saved search name is usable as a config key suffix
+func validateSearchName(name string) error {
+	if name == "" {
+		return fmt.Errorf("saved search name cannot be empty")
+	}
+	if strings.ContainsAny(name, " \t\n") {
+		return fmt.Errorf("saved search name %q cannot contain whitespace", name)
+	}
+	return nil
+}
+
+// loadSavedSearch reads a saved search from config, returning nil if it doesn't exist
+func loadSavedSearch(ctx context.Context, name string) (*savedSearch, error) {
+	value, err := store.GetConfig(ctx, savedSearchConfigPrefix+name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read saved search %s: %w", name, err)
+	}
+	if value == "" {
+		return nil, nil
+	}
+	var search savedSearch
+	if err := json.Unmarshal([]byte(value), &search); err != nil {
+		return nil, fmt.Errorf("saved search %s is invalid: %w", name, err)
+	}
+	return &search, nil
+}
+
+// listSavedSearches returns all saved searches keyed by name
+func listSavedSearches(ctx context.Context) (map[string]savedSearch, error) {
+	config, err := store.GetAllConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	searches := make(map[string]savedSearch)
+	for key, value := range config {
+		if !strings.HasPrefix(key, savedSearchConfigPrefix) {
+			continue
+		}
+		var search savedSearch
+		if err := json.Unmarshal([]byte(value), &search); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping invalid saved search %s: %v\n", key, err)
+			continue
+		}
+		searches[strings.TrimPrefix(key, savedSearchConfigPrefix)] = search
+	}
+	return searches, nil
+}
+
+var searchCmd = &cobra.Command{
+	Use:   "search [query]",
+	Short: "Search issues, and save searches you run often",
+	Long: `Search issues by text and filters.
+
+The query matches issue titles, descriptions and IDs. Filters combine with AND,
+as in 'bd list'.
+
+Terms of the form field:value scope a match to one field instead:
+title:, description: (substring matches), status:, type:, assignee: and
+label: (repeatable; all must match). The rest of the query is matched as
+text. Quote values with spaces (title:"login page"), or a whole term to
+search for it as text ("status:open"). A query without field terms is
+searched as given.
+
+With the full-text index (SQLite FTS5), each word of the query also matches
+word prefixes in titles, descriptions, notes, design and acceptance criteria,
+and the best matches are listed first, title hits ahead of the rest.
+
+Searches you run repeatedly can be saved under a name and run later. Saved
+searches are stored in config under the search.* namespace, so they can be
+shared with 'bd config export --prefix search.' and 'bd config import'.
+
+Examples:
+  bd search login --status open                      # Run a search
+  bd search "title:login status:open label:urgent"   # Field-scoped terms
+  bd search --save triage --status open --priority 0 # Save a search
+  bd search --run triage                             # Run a saved search
+  bd search --list                                   # Show saved searches
+  bd search --delete triage                          # Remove a saved search`,
+	Run: func(cmd *cobra.Command, args []string) {
+		saveName, _ := cmd.Flags().GetString("save")
+		runName, _ := cmd.Flags().GetString("run")
+		deleteName, _ := cmd.Flags().GetString("delete")
+		listSaved, _ := cmd.Flags().GetBool("list")
+
+		// Saved searches live in config, which requires direct database access
+		if err := ensureDirectMode("search requires direct database access"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		ctx := context.Background()
+
+		modes := 0
+		for _, set := range []bool{saveName != "", runName != "", deleteName != "", listSaved} {
+			if set {
+				modes++
+			}
+		}
+		if modes > 1 {
+			fmt.Fprintf(os.Stderr, "Error: --save, --run, --delete and --list are mutually exclusive\n")
+			os.Exit(1)
+		}
+
+		if listSaved {
+			runSearchList(ctx)
+			return
+		}
+		if deleteName != "" {
+			runSearchDelete(ctx, deleteName)
+			return
+		}
+
+		var search savedSearch
+		if runName != "" {
+			if len(args) > 0 || searchFiltersChanged(cmd) {
+				fmt.Fprintf(os.Stderr, "Error: --run cannot be combined with a query or filters\n")
+				os.Exit(1)
+			}
+			saved, err := loadSavedSearch(ctx, runName)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if saved == nil {
+				fmt.Fprintf(os.Stderr, "Error: no saved search named %s (see 'bd search --list')\n", runName)
+				os.Exit(1)
+			}
+			search = *saved
+		} else {
+			search = searchFromFlags(cmd, args)
+		}
+
+		filter := search.filter()
+		text, err := utils.ParseSearchQuery(search.Query, &filter)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if saveName != "" {
+			runSearchSave(ctx, saveName, search)
+			return
+		}
+
+		issues, err := store.SearchIssues(ctx, text, filter)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			for _, issue := range issues {
+				issue.Labels, _ = store.GetLabels(ctx, issue.ID)
+			}
+			outputJSON(issues)
+			return
+		}
+
+		if len(issues) == 0 {
+			fmt.Println("No issues found")
+			return
+		}
+		for _, issue := range issues {
+			labels, _ := store.GetLabels(ctx, issue.ID)
+
+			labelsStr := ""
+			if len(labels) > 0 {
+				labelsStr = fmt.Sprintf(" %v", labels)
+			}
+			assigneeStr := ""
+			if issue.Assignee != "" {
+				assigneeStr = fmt.Sprintf(" @%s", issue.Assignee)
+			}
+			fmt.Printf("%s [P%d] [%s] %s%s%s - %s\n",
+				issue.ID, issue.Priority, issue.IssueType, issue.Status,
+				assigneeStr, labelsStr, issue.Title)
+		}
+	},
+}
+
+// searchFilterFlags are the flags that make up a saved search
+var searchFilterFlags = []string{"status", "priority", "assignee", "type", "label", "label-any", "limit", "include-archived"}
+
+// searchFiltersChanged reports whether any filter flag was given
+func searchFiltersChanged(cmd *cobra.Command) bool {
+	for _, name := range searchFilterFlags {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchFromFlags builds a search from the query arguments and filter flags
+func searchFromFlags(cmd *cobra.Command, args []string) savedSearch {
+	search := savedSearch{Query: strings.Join(args, " ")}
+	search.Status, _ = cmd.Flags().GetString("status")
+	search.Assignee, _ = cmd.Flags().GetString("assignee")
+	search.IssueType, _ = cmd.Flags().GetString("type")
+	search.Limit, _ = cmd.Flags().GetInt("limit")
+	search.IncludeArchived, _ = cmd.Flags().GetBool("include-archived")
+	labels, _ := cmd.Flags().GetStringSlice("label")
+	labelsAny, _ := cmd.Flags().GetStringSlice("label-any")
+	search.Labels = util.NormalizeLabels(labels)
+	search.LabelsAny = util.NormalizeLabels(labelsAny)
+	// Use Changed() to properly handle P0 (priority=0)
+	if cmd.Flags().Changed("priority") {
+		priority := getPriorityFlag(cmd)
+		search.Priority = &priority
+	}
+	return search
+}
+
+func runSearchSave(ctx context.Context, name string, search savedSearch) {
+	if err := validateSearchName(name); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if search.describe() == "" {
+		fmt.Fprintf(os.Stderr, "Error: nothing to save; give a query or at least one filter\n")
+		os.Exit(1)
+	}
+
+	data, err := json.Marshal(search)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding saved search: %v\n", err)
+		os.Exit(1)
+	}
+	if err := store.SetConfig(ctx, savedSearchConfigPrefix+name, string(data)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving search: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"name":   name,
+			"search": search,
+		})
+		return
+	}
+	green := color.New(color.FgGreen).SprintFunc()
+	fmt.Printf("%s Saved search %s: %s\n", green("✓"), name, search.describe())
+}
+
+func runSearchList(ctx context.Context) {
+	searches, err := listSavedSearches(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		outputJSON(searches)
+		return
+	}
+	if len(searches) == 0 {
+		fmt.Println("No saved searches")
+		return
+	}
+
+	names := make([]string, 0, len(searches))
+	for name := range searches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Println("\nSaved searches:")
+	for _, name := range names {
+		fmt.Printf("  %s: %s\n", name, searches[name].describe())
+	}
+}
+
+func runSearchDelete(ctx context.Context, name string) {
+	saved, err := loadSavedSearch(ctx, name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if saved == nil {
+		fmt.Fprintf(os.Stderr, "Error: no saved search named %s\n", name)
+		os.Exit(1)
+	}
+	if err := store.DeleteConfig(ctx, savedSearchConfigPrefix+name); err != nil {
+		fmt.Fprintf(os.Stderr, "Error deleting saved search: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]string{"deleted": name})
+		return
+	}
+	fmt.Printf("Deleted saved search %s\n", name)
+}
+
+func init() {
+	searchCmd.Flags().StringP("status", "s", "", "Filter by status (open, in_progress, blocked, closed)")
+	searchCmd.Flags().StringP("priority", "p", "", "Filter by priority (0-4, P0-P4 or a name: critical, high, medium, low, backlog)")
+	searchCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
+	searchCmd.Flags().StringP("type", "t", "", "Filter by type (bug, feature, task, epic, chore)")
+	searchCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL)")
+	searchCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE)")
+	searchCmd.Flags().IntP("limit", "n", 0, "Limit results")
+	searchCmd.Flags().Bool("include-archived", false, "Include archived issues")
+
+	// Saved searches
+	searchCmd.Flags().String("save", "", "Save the query and filters under this name instead of running them")
+	searchCmd.Flags().String("run", "", "Run the saved search with this name")
+	searchCmd.Flags().Bool("list", false, "List saved searches")
+	searchCmd.Flags().String("delete", "", "Delete the saved search with this name")
+	rootCmd.AddCommand(searchCmd)
+}
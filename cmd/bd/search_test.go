@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSavedSearchFilter(t *testing.T) {
+	priority := 0
+	search := savedSearch{
+		Query:     "login",
+		Status:    "open",
+		Priority:  &priority,
+		Assignee:  "alice",
+		IssueType: "bug",
+		Labels:    []string{"auth"},
+		LabelsAny: []string{"urgent", "critical"},
+		Limit:     10,
+	}
+
+	filter := search.filter()
+	if filter.Status == nil || *filter.Status != types.StatusOpen {
+		t.Errorf("Status = %v, want open", filter.Status)
+	}
+	if filter.Priority == nil || *filter.Priority != 0 {
+		t.Errorf("Priority = %v, want 0", filter.Priority)
+	}
+	if filter.Assignee == nil || *filter.Assignee != "alice" {
+		t.Errorf("Assignee = %v, want alice", filter.Assignee)
+	}
+	if filter.IssueType == nil || *filter.IssueType != types.TypeBug {
+		t.Errorf("IssueType = %v, want bug", filter.IssueType)
+	}
+	if !reflect.DeepEqual(filter.Labels, []string{"auth"}) || !reflect.DeepEqual(filter.LabelsAny, []string{"urgent", "critical"}) {
+		t.Errorf("Labels = %v, LabelsAny = %v", filter.Labels, filter.LabelsAny)
+	}
+	if filter.Limit != 10 {
+		t.Errorf("Limit = %d, want 10", filter.Limit)
+	}
+
+	want := `"login" --status open --priority 0 --assignee alice --type bug --label auth --label-any urgent,critical --limit 10`
+	if got := search.describe(); got != want {
+		t.Errorf("describe() = %q, want %q", got, want)
+	}
+
+	// "all" means no status filter, as in bd list
+	if (savedSearch{Status: "all"}).filter().Status != nil {
+		t.Error("Expected --status all to leave the status filter unset")
+	}
+}
+
+func TestSavedSearchRoundTrip(t *testing.T) {
+	priority := 0
+	search := savedSearch{Status: "open", Priority: &priority}
+
+	data, err := json.Marshal(search)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	// P0 must survive the round trip even though it is the zero value
+	if string(data) != `{"status":"open","priority":0}` {
+		t.Errorf("Marshal = %s", data)
+	}
+
+	var decoded savedSearch
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, search) {
+		t.Errorf("Round trip = %+v, want %+v", decoded, search)
+	}
+}
+
+func TestValidateSearchName(t *testing.T) {
+	for _, name := range []string{"triage", "my-bugs", "team.backend"} {
+		if err := validateSearchName(name); err != nil {
+			t.Errorf("validateSearchName(%q) failed: %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "two words"} {
+		if err := validateSearchName(name); err == nil {
+			t.Errorf("Expected error for name %q", name)
+		}
+	}
+}
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
 )
@@ -18,9 +20,54 @@ import (
 var showCmd = &cobra.Command{
 	Use:   "show [id...]",
 	Short: "Show issue details",
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Show issue details.
+
+--format md renders each issue as Markdown for pasting into a PR or doc: the
+title as a heading, a table of status, priority, type, assignee and labels,
+the description, design, acceptance criteria and notes as sections, and the
+issues it depends on and blocks.
+
+--with-comments embeds the comment thread: as a "comments" array with
+--json, and after the details otherwise. Without a daemon, the plain and
+JSON output always include it.
+If issue_url_template is configured (e.g. 'bd config set issue_url_template
+https://issues.example.com/{id}'), issue IDs in the output become links.
+
+An argument that can't be an ID, such as "login bug", is matched against
+titles: case-insensitively, as a substring, by words in any order, or with
+a typo. --title matches every argument as a title, for single words. If
+several issues match, the candidates are listed with their IDs.
+
+--tree adds the issue's parent-child hierarchy below the details, as in
+'bd dep tree --children': children recursively with their statuses, and
+each issue's blocking edges inline. --depth limits how many levels of
+children are shown; an issue reached twice is shown once and marked. With
+--json, each issue is a nested object with children, blocks and blocked_by.`,
+	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		jsonOutput, _ := cmd.Flags().GetBool("json")
+		showHistory, _ := cmd.Flags().GetBool("history")
+		formatStr, _ := cmd.Flags().GetString("format")
+		withComments, _ := cmd.Flags().GetBool("with-comments")
+		byTitle, _ := cmd.Flags().GetBool("title")
+		showTree, _ := cmd.Flags().GetBool("tree")
+		treeDepth, _ := cmd.Flags().GetInt("depth")
+		markdown := formatStr == "md" || formatStr == "markdown"
+		if showTree && markdown {
+			exitInvalid("--tree can't be combined with --format md")
+		}
+		if treeDepth < 1 {
+			exitInvalid("--depth must be >= 1")
+		}
+		if markdown {
+			formatStr = ""
+		}
+		if formatStr != "" && !applyJSONFormat(formatStr) {
+			exitInvalid("unknown format %q (valid: json, json-pretty, md)", formatStr)
+		}
+		if formatStr != "" {
+			jsonOutput = true // Local copy shadows the global set above
+		}
 		ctx := context.Background()
 		
 		// Resolve partial IDs first
@@ -28,29 +75,82 @@ var showCmd = &cobra.Command{
 		if daemonClient != nil {
 			// In daemon mode, resolve via RPC
 			for _, id := range args {
-				resolveArgs := &rpc.ResolveIDArgs{ID: id}
+				resolveArgs := &rpc.ResolveIDArgs{ID: id, Title: byTitle}
 				resp, err := daemonClient.ResolveID(resolveArgs)
 				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error resolving ID %s: %v\n", id, err)
+					exitWithError(err, "Error resolving ID %s: %v", id, err)
+				}
+				var resolvedID string
+				if err := json.Unmarshal(resp.Data, &resolvedID); err != nil {
+					fmt.Fprintf(os.Stderr, "Error unmarshaling resolved ID: %v\n", err)
 					os.Exit(1)
 				}
-				resolvedIDs = append(resolvedIDs, string(resp.Data))
+				resolvedIDs = append(resolvedIDs, resolvedID)
+			}
+		} else if byTitle {
+			for _, title := range args {
+				id, err := utils.ResolveTitle(ctx, store, title)
+				if err != nil {
+					exitWithError(err, "Error: %v", err)
+				}
+				resolvedIDs = append(resolvedIDs, id)
 			}
 		} else {
 			// In direct mode, resolve via storage
 			var err error
 			resolvedIDs, err = utils.ResolvePartialIDs(ctx, store, args)
 			if err != nil {
+				exitWithError(err, "Error: %v", err)
+			}
+		}
+
+		// The hierarchy is built from every issue and dependency at once
+		var treeIssues map[string]*types.Issue
+		var treeDeps map[string][]*types.Dependency
+		if showTree {
+			if err := ensureDirectMode("show --tree reads from the database directly"); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			var err error
+			treeIssues, treeDeps, err = loadChildTreeData(ctx)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		if markdown {
+			if err := ensureDirectMode("show --format md reads from the database directly"); err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
 			}
+			urlTemplate, _ := store.GetConfig(ctx, utils.IssueURLTemplateConfigKey)
+			prefix, _ := store.GetConfig(ctx, "issue_prefix")
+			for idx, id := range resolvedIDs {
+				md, err := loadMarkdownIssue(ctx, store, id, withComments)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error fetching %s: %v\n", id, err)
+					continue
+				}
+				if md == nil {
+					fmt.Fprintf(os.Stderr, "Issue %s not found\n", id)
+					continue
+				}
+				if idx > 0 {
+					fmt.Print("\n---\n\n")
+				}
+				ownPrefix := id[:max(strings.LastIndex(id, "-"), 0)]
+				newMarkdownRenderer(urlTemplate, prefix, ownPrefix).render(os.Stdout, md)
+			}
+			return
 		}
 		
 		// If daemon is running, use RPC
 		if daemonClient != nil {
 			allDetails := []interface{}{}
 			for idx, id := range resolvedIDs {
-				showArgs := &rpc.ShowArgs{ID: id}
+				showArgs := &rpc.ShowArgs{ID: id, History: showHistory, Comments: withComments}
 				resp, err := daemonClient.Show(showArgs)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Error fetching %s: %v\n", id, err)
@@ -60,9 +160,13 @@ var showCmd = &cobra.Command{
 				if jsonOutput {
 					type IssueDetails struct {
 						types.Issue
-						Labels       []string       `json:"labels,omitempty"`
-						Dependencies []*types.Issue `json:"dependencies,omitempty"`
-						Dependents   []*types.Issue `json:"dependents,omitempty"`
+						Labels       []string         `json:"labels,omitempty"`
+						Dependencies []*types.Issue   `json:"dependencies,omitempty"`
+						Dependents   []*types.Issue   `json:"dependents,omitempty"`
+						Comments     []*types.Comment `json:"comments,omitempty"`
+						Lock         *types.IssueLock `json:"lock,omitempty"`
+						Events       []*types.Event   `json:"events,omitempty"`
+						URL          string           `json:"url,omitempty"`
 					}
 					var details IssueDetails
 					if err := json.Unmarshal(resp.Data, &details); err == nil {
@@ -81,9 +185,13 @@ var showCmd = &cobra.Command{
 					// Parse response and use existing formatting code
 					type IssueDetails struct {
 						types.Issue
-						Labels       []string       `json:"labels,omitempty"`
-						Dependencies []*types.Issue `json:"dependencies,omitempty"`
-						Dependents   []*types.Issue `json:"dependents,omitempty"`
+						Labels       []string         `json:"labels,omitempty"`
+						Dependencies []*types.Issue   `json:"dependencies,omitempty"`
+						Dependents   []*types.Issue   `json:"dependents,omitempty"`
+						Comments     []*types.Comment `json:"comments,omitempty"`
+						Lock         *types.IssueLock `json:"lock,omitempty"`
+						Events       []*types.Event   `json:"events,omitempty"`
+						URL          string           `json:"url,omitempty"`
 					}
 					var details IssueDetails
 					if err := json.Unmarshal(resp.Data, &details); err != nil {
@@ -106,18 +214,38 @@ var showCmd = &cobra.Command{
 						statusSuffix = " (compacted L2)"
 					}
 
-					fmt.Printf("\n%s: %s%s\n", cyan(issue.ID), issue.Title, tierEmoji)
+					fmt.Printf("\n%s: %s%s\n", cyan(displayID(issue.ID)), issue.Title, tierEmoji)
 					fmt.Printf("Status: %s%s\n", issue.Status, statusSuffix)
-					fmt.Printf("Priority: P%d\n", issue.Priority)
+					if issue.Resolution != "" {
+						fmt.Printf("Resolution: %s\n", issue.Resolution)
+					}
+					if issue.ResolvedBy != "" {
+						fmt.Printf("Resolved by: %s\n", issue.ResolvedBy)
+					}
+					if issue.ArchivedAt != nil {
+						fmt.Printf("Archived: %s\n", issue.ArchivedAt.Format("2006-01-02 15:04"))
+					}
+					fmt.Printf("Priority: %s\n", formatPriority(issue.Priority))
 					fmt.Printf("Type: %s\n", issue.IssueType)
 					if issue.Assignee != "" {
 						fmt.Printf("Assignee: %s\n", issue.Assignee)
 					}
 					if issue.EstimatedMinutes != nil {
-						fmt.Printf("Estimated: %d minutes\n", *issue.EstimatedMinutes)
+						fmt.Printf("Estimated: %s\n", formatMinutes(*issue.EstimatedMinutes))
+					}
+					if issue.SpentMinutes != nil {
+						fmt.Printf("Spent: %s\n", formatMinutes(*issue.SpentMinutes))
 					}
 					fmt.Printf("Created: %s\n", issue.CreatedAt.Format("2006-01-02 15:04"))
 					fmt.Printf("Updated: %s\n", issue.UpdatedAt.Format("2006-01-02 15:04"))
+					if details.Lock != nil {
+						fmt.Printf("Locked: by %s until %s\n", details.Lock.Holder, details.Lock.ExpiresAt.Local().Format("2006-01-02 15:04"))
+					}
+					if details.URL != "" {
+						fmt.Printf("URL: %s\n", details.URL)
+					}
+					printIssueMetadata(issue.Metadata)
+					printIssueAttachments(issue.Attachments)
 
 					// Show compaction status
 					if issue.CompactionLevel > 0 {
@@ -162,17 +290,23 @@ var showCmd = &cobra.Command{
 					if len(details.Dependencies) > 0 {
 						fmt.Printf("\nDepends on (%d):\n", len(details.Dependencies))
 						for _, dep := range details.Dependencies {
-							fmt.Printf("  → %s: %s [P%d]\n", dep.ID, dep.Title, dep.Priority)
+							fmt.Printf("  → %s: %s [P%d]\n", displayID(dep.ID), dep.Title, dep.Priority)
 						}
 					}
 
 					if len(details.Dependents) > 0 {
 						fmt.Printf("\nBlocks (%d):\n", len(details.Dependents))
 						for _, dep := range details.Dependents {
-							fmt.Printf("  ← %s: %s [P%d]\n", dep.ID, dep.Title, dep.Priority)
+							fmt.Printf("  ← %s: %s [P%d]\n", displayID(dep.ID), dep.Title, dep.Priority)
 						}
 					}
 
+					printCommentThread(details.Comments)
+
+					if showHistory {
+						printEventHistory(details.Events)
+					}
+
 					fmt.Println()
 				}
 			}
@@ -196,6 +330,17 @@ var showCmd = &cobra.Command{
 				continue
 			}
 
+			var tree *childTreeNode
+			if showTree {
+				// Archived issues aren't in treeIssues but can still be shown
+				treeIssues[issue.ID] = issue
+				tree = buildChildTree(issue.ID, treeIssues, treeDeps, treeDepth)
+			}
+
+			if jsonOutput && showTree {
+				allDetails = append(allDetails, tree)
+				continue
+			}
 			if jsonOutput {
 				// Include labels, dependencies, and comments in JSON output
 				type IssueDetails struct {
@@ -204,12 +349,19 @@ var showCmd = &cobra.Command{
 					Dependencies []*types.Issue   `json:"dependencies,omitempty"`
 					Dependents   []*types.Issue   `json:"dependents,omitempty"`
 					Comments     []*types.Comment `json:"comments,omitempty"`
+					Lock         *types.IssueLock `json:"lock,omitempty"`
+					Events       []*types.Event   `json:"events,omitempty"`
+					URL          string           `json:"url,omitempty"`
 				}
-				details := &IssueDetails{Issue: issue}
+				details := &IssueDetails{Issue: issue, URL: utils.IssueURL(ctx, store, issue.ID)}
 				details.Labels, _ = store.GetLabels(ctx, issue.ID)
 				details.Dependencies, _ = store.GetDependencies(ctx, issue.ID)
 				details.Dependents, _ = store.GetDependents(ctx, issue.ID)
 				details.Comments, _ = store.GetIssueComments(ctx, issue.ID)
+				details.Lock, _ = store.GetLock(ctx, issue.ID)
+				if showHistory {
+					details.Events, _ = store.GetEvents(ctx, issue.ID, 0)
+				}
 				allDetails = append(allDetails, details)
 				continue
 			}
@@ -232,18 +384,38 @@ var showCmd = &cobra.Command{
 				statusSuffix = " (compacted L2)"
 			}
 
-			fmt.Printf("\n%s: %s%s\n", cyan(issue.ID), issue.Title, tierEmoji)
+			fmt.Printf("\n%s: %s%s\n", cyan(displayID(issue.ID)), issue.Title, tierEmoji)
 			fmt.Printf("Status: %s%s\n", issue.Status, statusSuffix)
-			fmt.Printf("Priority: P%d\n", issue.Priority)
+			if issue.Resolution != "" {
+				fmt.Printf("Resolution: %s\n", issue.Resolution)
+			}
+			if issue.ResolvedBy != "" {
+				fmt.Printf("Resolved by: %s\n", issue.ResolvedBy)
+			}
+			if issue.ArchivedAt != nil {
+				fmt.Printf("Archived: %s\n", issue.ArchivedAt.Format("2006-01-02 15:04"))
+			}
+			fmt.Printf("Priority: %s\n", formatPriority(issue.Priority))
 			fmt.Printf("Type: %s\n", issue.IssueType)
 			if issue.Assignee != "" {
 				fmt.Printf("Assignee: %s\n", issue.Assignee)
 			}
 			if issue.EstimatedMinutes != nil {
-				fmt.Printf("Estimated: %d minutes\n", *issue.EstimatedMinutes)
+				fmt.Printf("Estimated: %s\n", formatMinutes(*issue.EstimatedMinutes))
+			}
+			if issue.SpentMinutes != nil {
+				fmt.Printf("Spent: %s\n", formatMinutes(*issue.SpentMinutes))
 			}
 			fmt.Printf("Created: %s\n", issue.CreatedAt.Format("2006-01-02 15:04"))
 			fmt.Printf("Updated: %s\n", issue.UpdatedAt.Format("2006-01-02 15:04"))
+			if lock, _ := store.GetLock(ctx, issue.ID); lock != nil {
+				fmt.Printf("Locked: by %s until %s\n", lock.Holder, lock.ExpiresAt.Local().Format("2006-01-02 15:04"))
+			}
+			if url := utils.IssueURL(ctx, store, issue.ID); url != "" {
+				fmt.Printf("URL: %s\n", url)
+			}
+			printIssueMetadata(issue.Metadata)
+			printIssueAttachments(issue.Attachments)
 
 			// Show compaction status footer
 			if issue.CompactionLevel > 0 {
@@ -294,7 +466,7 @@ var showCmd = &cobra.Command{
 			if len(deps) > 0 {
 				fmt.Printf("\nDepends on (%d):\n", len(deps))
 				for _, dep := range deps {
-					fmt.Printf("  → %s: %s [P%d]\n", dep.ID, dep.Title, dep.Priority)
+					fmt.Printf("  → %s: %s [P%d]\n", displayID(dep.ID), dep.Title, dep.Priority)
 				}
 			}
 
@@ -303,17 +475,22 @@ var showCmd = &cobra.Command{
 			if len(dependents) > 0 {
 				fmt.Printf("\nBlocks (%d):\n", len(dependents))
 				for _, dep := range dependents {
-					fmt.Printf("  ← %s: %s [P%d]\n", dep.ID, dep.Title, dep.Priority)
+					fmt.Printf("  ← %s: %s [P%d]\n", displayID(dep.ID), dep.Title, dep.Priority)
 				}
 			}
 
 			// Show comments
 			comments, _ := store.GetIssueComments(ctx, issue.ID)
-			if len(comments) > 0 {
-				fmt.Printf("\nComments (%d):\n", len(comments))
-				for _, comment := range comments {
-					fmt.Printf("  [%s at %s]\n  %s\n\n", comment.Author, comment.CreatedAt.Format("2006-01-02 15:04"), comment.Text)
-				}
+			printCommentThread(comments)
+
+			if showHistory {
+				events, _ := store.GetEvents(ctx, issue.ID, 0)
+				printEventHistory(events)
+			}
+
+			if showTree {
+				fmt.Printf("\nHierarchy:\n")
+				renderChildTree(os.Stdout, tree, treeIssues)
 			}
 
 			fmt.Println()
@@ -328,7 +505,30 @@ var showCmd = &cobra.Command{
 var updateCmd = &cobra.Command{
 	Use:   "update [id...]",
 	Short: "Update one or more issues",
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Update fields on the given issues.
+
+Instead of IDs, --filter selects the issues to update: label=<name>,
+status=<status>, type=<type> or assignee=<name>, repeatable, all of which
+must match. The changes are applied to the whole set in one transaction, so
+either every issue is updated or none is, and each issue still gets its own
+events for 'bd log' and 'bd undo'. Updating more than 10 issues by filter
+needs --yes; --dry-run lists the issues that would be updated.
+
+Each issue given by ID is read and then written only if it hasn't changed
+in between. If another process updated it first, that issue is left as the
+other process wrote it and an error is reported; re-run to apply the change
+on top.
+
+--clear empties an optional field (repeatable): assignee, design, notes,
+acceptance, external-ref, estimate or spent. External refs and estimates are
+set to NULL, text to empty. An update that only clears fields is recorded as
+a fields_cleared event, which 'bd undo' can revert.
+
+Examples:
+  bd update bd-42 --status in_progress
+  bd update bd-42 --clear external-ref --clear estimate
+  bd update --filter label=sprint-3 --filter status=open --priority 1 --dry-run
+  bd update --filter assignee=alice --filter status=in_progress --assignee bob --yes`,
 	Run: func(cmd *cobra.Command, args []string) {
 		jsonOutput, _ := cmd.Flags().GetBool("json")
 		updates := make(map[string]interface{})
@@ -338,8 +538,7 @@ var updateCmd = &cobra.Command{
 			updates["status"] = status
 		}
 		if cmd.Flags().Changed("priority") {
-			priority, _ := cmd.Flags().GetInt("priority")
-			updates["priority"] = priority
+			updates["priority"] = getPriorityFlag(cmd)
 		}
 		if cmd.Flags().Changed("title") {
 			title, _ := cmd.Flags().GetString("title")
@@ -374,13 +573,65 @@ var updateCmd = &cobra.Command{
 			externalRef, _ := cmd.Flags().GetString("external-ref")
 			updates["external_ref"] = externalRef
 		}
+		for flag, field := range map[string]string{"estimate": "estimated_minutes", "spent": "spent_minutes"} {
+			if !cmd.Flags().Changed(flag) {
+				continue
+			}
+			value, _ := cmd.Flags().GetString(flag)
+			minutes, err := parseMinutes(value)
+			if err != nil {
+				exitInvalid("invalid --%s: %v", flag, err)
+			}
+			updates[field] = minutes
+		}
+		clearFlags, _ := cmd.Flags().GetStringSlice("clear")
+		for _, name := range clearFlags {
+			field, err := types.ParseClearField(name)
+			if err != nil {
+				exitInvalid("%v", err)
+			}
+			if value, ok := updates[field]; ok && value != types.ClearableFields[field] {
+				exitInvalid("--clear %s conflicts with the flag setting %s", name, field)
+			}
+			updates[field] = types.ClearableFields[field]
+		}
+		setFlags, _ := cmd.Flags().GetStringArray("set")
+		setMetadata, err := parseMetadataPairs(setFlags)
+		if err != nil {
+			exitInvalid("%v", err)
+		}
+		unsetMetadata, _ := cmd.Flags().GetStringSlice("unset")
+		for _, key := range unsetMetadata {
+			if err := types.ValidateMetadataKey(key); err != nil {
+				exitInvalid("%v", err)
+			}
+		}
+		changesMetadata := len(setMetadata) > 0 || len(unsetMetadata) > 0
 
-		if len(updates) == 0 {
+		if len(updates) == 0 && !changesMetadata {
 			fmt.Println("No updates specified")
 			return
 		}
+		respectLocks, _ := cmd.Flags().GetBool("respect-locks")
 
 		ctx := context.Background()
+
+		filterValues, _ := cmd.Flags().GetStringArray("filter")
+		if len(filterValues) > 0 {
+			if len(args) > 0 {
+				exitInvalid("issue IDs cannot be combined with --filter")
+			}
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			yes, _ := cmd.Flags().GetBool("yes")
+			if err := runBulkUpdate(ctx, filterValues, updates, setMetadata, unsetMetadata, respectLocks, dryRun, yes, jsonOutput); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+		if len(args) == 0 {
+			exitInvalid("requires at least one issue ID, or --filter")
+		}
 		
 		// Resolve partial IDs first
 		var resolvedIDs []string
@@ -389,58 +640,52 @@ var updateCmd = &cobra.Command{
 				resolveArgs := &rpc.ResolveIDArgs{ID: id}
 				resp, err := daemonClient.ResolveID(resolveArgs)
 				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error resolving ID %s: %v\n", id, err)
-					os.Exit(1)
+					exitWithError(err, "Error resolving ID %s: %v", id, err)
+				}
+				var resolvedID string
+				if err := json.Unmarshal(resp.Data, &resolvedID); err != nil {
+					exitWithError(err, "Error resolving ID %s: %v", id, err)
 				}
-				resolvedIDs = append(resolvedIDs, string(resp.Data))
+				resolvedIDs = append(resolvedIDs, resolvedID)
 			}
 		} else {
 			var err error
 			resolvedIDs, err = utils.ResolvePartialIDs(ctx, store, args)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				exitWithError(err, "Error: %v", err)
 			}
 		}
 		
 		// If daemon is running, use RPC
+		var firstErr error
+		fail := func(id string, err error) {
+			fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
 		if daemonClient != nil {
 			updatedIssues := []*types.Issue{}
 			for _, id := range resolvedIDs {
-				updateArgs := &rpc.UpdateArgs{ID: id}
-
-				// Map updates to RPC args
-				if status, ok := updates["status"].(string); ok {
-					updateArgs.Status = &status
-				}
-				if priority, ok := updates["priority"].(int); ok {
-					updateArgs.Priority = &priority
-				}
-				if title, ok := updates["title"].(string); ok {
-					updateArgs.Title = &title
-				}
-				if assignee, ok := updates["assignee"].(string); ok {
-					updateArgs.Assignee = &assignee
-				}
-				if description, ok := updates["description"].(string); ok {
-					updateArgs.Description = &description
-				}
-				if design, ok := updates["design"].(string); ok {
-					updateArgs.Design = &design
-				}
-				if notes, ok := updates["notes"].(string); ok {
-					updateArgs.Notes = &notes
+				if !checkEditLock(ctx, id, respectLocks) {
+					continue
 				}
-				if acceptanceCriteria, ok := updates["acceptance_criteria"].(string); ok {
-					updateArgs.AcceptanceCriteria = &acceptanceCriteria
+				showResp, err := daemonClient.Show(&rpc.ShowArgs{ID: id})
+				if err != nil {
+					fail(id, err)
+					continue
 				}
-				if externalRef, ok := updates["external_ref"].(string); ok {  // NEW: Map external_ref
-					updateArgs.ExternalRef = &externalRef
+				var existing types.Issue
+				if err := json.Unmarshal(showResp.Data, &existing); err != nil {
+					fail(id, err)
+					continue
 				}
+				updateArgs := updateArgsFrom(id, updates, setMetadata, unsetMetadata)
+				updateArgs.ExpectedUpdatedAt = &existing.UpdatedAt
 
 				resp, err := daemonClient.Update(updateArgs)
 				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
+					fail(id, err)
 					continue
 				}
 
@@ -458,18 +703,40 @@ var updateCmd = &cobra.Command{
 			if jsonOutput && len(updatedIssues) > 0 {
 				outputJSON(updatedIssues)
 			}
+			exitIfFailed(firstErr, len(updatedIssues) > 0)
 			return
 		}
 
-		// Direct mode
+		// Direct mode: read each issue and write back only if nothing else
+		// changed it in between, so a concurrent update isn't clobbered
 		updatedIssues := []*types.Issue{}
 		for _, id := range resolvedIDs {
-		 if err := store.UpdateIssue(ctx, id, updates, actor); err != nil {
-		 fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
-		 continue
-		}
+			if !checkEditLock(ctx, id, respectLocks) {
+				continue
+			}
+			existing, err := store.GetIssue(ctx, id)
+			if err != nil {
+				fail(id, err)
+				continue
+			}
+			if existing == nil {
+				fail(id, types.WithCode(types.ErrCodeNotFound, fmt.Errorf("issue not found")))
+				continue
+			}
+			issueUpdates := updates
+			if changesMetadata {
+				issueUpdates = make(map[string]interface{}, len(updates)+1)
+				for key, value := range updates {
+					issueUpdates[key] = value
+				}
+				issueUpdates["metadata"] = types.MergeMetadata(existing.Metadata, setMetadata, unsetMetadata)
+			}
+			if err := store.UpdateIssueIfUnchanged(ctx, id, existing.UpdatedAt, issueUpdates, actor); err != nil {
+				fail(id, err)
+				continue
+			}
 
-		if jsonOutput {
+			if jsonOutput {
 				issue, _ := store.GetIssue(ctx, id)
 				if issue != nil {
 					updatedIssues = append(updatedIssues, issue)
@@ -488,9 +755,58 @@ var updateCmd = &cobra.Command{
 		if jsonOutput && len(updatedIssues) > 0 {
 			outputJSON(updatedIssues)
 		}
+		exitIfFailed(firstErr, len(updatedIssues) > 0)
 	},
 }
 
+// updateArgsFrom maps bd update's field changes to RPC update args for id
+func updateArgsFrom(id string, updates map[string]interface{}, setMetadata map[string]string, unsetMetadata []string) *rpc.UpdateArgs {
+	updateArgs := &rpc.UpdateArgs{ID: id}
+	if status, ok := updates["status"].(string); ok {
+		updateArgs.Status = &status
+	}
+	if priority, ok := updates["priority"].(int); ok {
+		updateArgs.Priority = &priority
+	}
+	if title, ok := updates["title"].(string); ok {
+		updateArgs.Title = &title
+	}
+	if assignee, ok := updates["assignee"].(string); ok {
+		updateArgs.Assignee = &assignee
+	}
+	if description, ok := updates["description"].(string); ok {
+		updateArgs.Description = &description
+	}
+	if design, ok := updates["design"].(string); ok {
+		updateArgs.Design = &design
+	}
+	if notes, ok := updates["notes"].(string); ok {
+		updateArgs.Notes = &notes
+	}
+	if acceptanceCriteria, ok := updates["acceptance_criteria"].(string); ok {
+		updateArgs.AcceptanceCriteria = &acceptanceCriteria
+	}
+	if externalRef, ok := updates["external_ref"].(string); ok {
+		updateArgs.ExternalRef = &externalRef
+	}
+	if estimated, ok := updates["estimated_minutes"].(int); ok {
+		updateArgs.EstimatedMinutes = &estimated
+	}
+	if spent, ok := updates["spent_minutes"].(int); ok {
+		updateArgs.SpentMinutes = &spent
+	}
+	// Fields cleared to NULL have no pointer form
+	for field := range types.ClearableFields {
+		if value, ok := updates[field]; ok && value == nil {
+			updateArgs.Clear = append(updateArgs.Clear, field)
+		}
+	}
+	sort.Strings(updateArgs.Clear)
+	updateArgs.SetMetadata = setMetadata
+	updateArgs.UnsetMetadata = unsetMetadata
+	return updateArgs
+}
+
 var editCmd = &cobra.Command{
 	Use:   "edit [id]",
 	Short: "Edit an issue field in $EDITOR",
@@ -690,15 +1006,68 @@ Examples:
 var closeCmd = &cobra.Command{
 	Use:   "close [id...]",
 	Short: "Close one or more issues",
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Close one or more issues, recording a Closed event.
+
+Use --resolution to record why an issue was closed: fixed, wontfix,
+duplicate or obsolete. The resolution is kept on the issue (and exported
+with it) until the issue is reopened.
+
+--git-commit records the commit that resolved the issue. Any revision git
+understands (a SHA, HEAD, a tag) is accepted and stored as the full SHA,
+shown by 'bd show' until the issue is reopened. 'bd sync' records it too
+when it closes issues named by "fixes <id>" in commit messages (see
+commit_close.mode in docs/CONFIG.md).
+
+--cascade also closes every open descendant in the parent-child hierarchy,
+children before parents, noting on each that it was cascade-closed from the
+issue given. --dry-run lists what would be closed without closing anything.
+
+Examples:
+  bd close bd-42 --reason "Shipped in v1.2" --resolution fixed
+  bd close bd-42 --git-commit HEAD
+  bd close bd-7 bd-9 --resolution duplicate
+  bd close bd-a3f8 --cascade --dry-run`,
+	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		reason, _ := cmd.Flags().GetString("reason")
 		if reason == "" {
 			reason = "Closed"
 		}
+		note, _ := cmd.Flags().GetString("note")
+		resolutionStr, _ := cmd.Flags().GetString("resolution")
+		gitCommitRev, _ := cmd.Flags().GetString("git-commit")
+		cascade, _ := cmd.Flags().GetBool("cascade")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
 		jsonOutput, _ := cmd.Flags().GetBool("json")
 
+		resolution := types.Resolution(strings.ToLower(strings.TrimSpace(resolutionStr)))
+		if !resolution.IsValid() {
+			exitInvalid("invalid resolution %q (must be fixed, wontfix, duplicate or obsolete)", resolutionStr)
+		}
+		closedMessage := reason
+		if resolution != "" {
+			closedMessage = fmt.Sprintf("%s (%s)", reason, resolution)
+		}
+
 		ctx := context.Background()
+
+		resolvedBy, commitSuffix := "", ""
+		if gitCommitRev != "" {
+			sha, err := gitResolveCommit(ctx, gitCommitRev)
+			if err != nil {
+				exitInvalid("--git-commit: %v", err)
+			}
+			resolvedBy = sha
+			commitSuffix = " in " + sha[:7]
+		}
+
+		// Walking the hierarchy needs every dependency, which no RPC serves
+		if cascade || dryRun {
+			if err := ensureDirectMode("close --cascade and --dry-run read the issue hierarchy directly"); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
 		
 		// Resolve partial IDs first
 		var resolvedIDs []string
@@ -707,17 +1076,27 @@ var closeCmd = &cobra.Command{
 				resolveArgs := &rpc.ResolveIDArgs{ID: id}
 				resp, err := daemonClient.ResolveID(resolveArgs)
 				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error resolving ID %s: %v\n", id, err)
-					os.Exit(1)
+					exitWithError(err, "Error resolving ID %s: %v", id, err)
+				}
+				var resolvedID string
+				if err := json.Unmarshal(resp.Data, &resolvedID); err != nil {
+					exitWithError(err, "Error resolving ID %s: %v", id, err)
 				}
-				resolvedIDs = append(resolvedIDs, string(resp.Data))
+				resolvedIDs = append(resolvedIDs, resolvedID)
 			}
 		} else {
 			var err error
 			resolvedIDs, err = utils.ResolvePartialIDs(ctx, store, args)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				exitWithError(err, "Error: %v", err)
+			}
+		}
+
+		var firstErr error
+		fail := func(id string, err error) {
+			fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", id, err)
+			if firstErr == nil {
+				firstErr = err
 			}
 		}
 
@@ -726,12 +1105,15 @@ var closeCmd = &cobra.Command{
 			closedIssues := []*types.Issue{}
 			for _, id := range resolvedIDs {
 				closeArgs := &rpc.CloseArgs{
-					ID:     id,
-					Reason: reason,
+					ID:         id,
+					Reason:     reason,
+					Note:       note,
+					Resolution: string(resolution),
+					ResolvedBy: resolvedBy,
 				}
 				resp, err := daemonClient.CloseIssue(closeArgs)
 				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", id, err)
+					fail(id, err)
 					continue
 				}
 
@@ -742,21 +1124,57 @@ var closeCmd = &cobra.Command{
 					}
 				} else {
 					green := color.New(color.FgGreen).SprintFunc()
-					fmt.Printf("%s Closed %s: %s\n", green("✓"), id, reason)
+					fmt.Printf("%s Closed %s: %s%s\n", green("✓"), id, closedMessage, commitSuffix)
 				}
 			}
 
 			if jsonOutput && len(closedIssues) > 0 {
 				outputJSON(closedIssues)
 			}
+			exitIfFailed(firstErr, len(closedIssues) > 0)
 			return
 		}
 
 		// Direct mode
+		var plan []closePlanItem
+		if cascade || dryRun {
+			var err error
+			if plan, err = buildClosePlan(ctx, store, resolvedIDs, cascade); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			for _, id := range resolvedIDs {
+				plan = append(plan, closePlanItem{Issue: &types.Issue{ID: id}})
+			}
+		}
+		if dryRun {
+			if jsonOutput {
+				outputJSON(plan)
+				return
+			}
+			fmt.Println(color.YellowString("DRY RUN - no changes will be made"))
+			fmt.Printf("Would close %d issue(s):\n", len(plan))
+			for _, item := range plan {
+				line := fmt.Sprintf("  %s: %s [%s]", item.ID, item.Title, item.Status)
+				if item.CascadeFrom != "" {
+					line += " (cascade from " + item.CascadeFrom + ")"
+				}
+				fmt.Println(line)
+			}
+			return
+		}
+
 		closedIssues := []*types.Issue{}
-		for _, id := range resolvedIDs {
-			if err := store.CloseIssue(ctx, id, reason, actor); err != nil {
-				fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", id, err)
+		for _, item := range plan {
+			id := item.ID
+			opts := storage.CloseOptions{Reason: reason, Note: note, Resolution: resolution, Commit: resolvedBy}
+			if item.CascadeFrom != "" {
+				opts.Note = cascadeCloseNote(item.CascadeFrom, note)
+				opts.Commit = "" // The commit resolved the issue named, not its descendants
+			}
+			if err := store.CloseIssue(ctx, id, opts, actor); err != nil {
+				fail(id, err)
 				continue
 			}
 			if jsonOutput {
@@ -766,7 +1184,13 @@ var closeCmd = &cobra.Command{
 				}
 			} else {
 				green := color.New(color.FgGreen).SprintFunc()
-				fmt.Printf("%s Closed %s: %s\n", green("✓"), id, reason)
+				suffix := ""
+				if item.CascadeFrom != "" {
+					suffix = fmt.Sprintf(" (cascade from %s)", item.CascadeFrom)
+				} else {
+					suffix = commitSuffix
+				}
+				fmt.Printf("%s Closed %s: %s%s\n", green("✓"), id, closedMessage, suffix)
 			}
 		}
 
@@ -778,15 +1202,43 @@ var closeCmd = &cobra.Command{
 		if jsonOutput && len(closedIssues) > 0 {
 			outputJSON(closedIssues)
 		}
+		exitIfFailed(firstErr, len(closedIssues) > 0)
 	},
 }
 
+// printEventHistory prints an issue's events oldest first, including any
+// status-change notes recorded with close/reopen --note
+func printEventHistory(events []*types.Event) {
+	if len(events) == 0 {
+		return
+	}
+	fmt.Printf("\nHistory (%d):\n", len(events))
+	// Events are returned newest first
+	for i := len(events) - 1; i >= 0; i-- {
+		event := events[i]
+		line := fmt.Sprintf("  %s  %s by %s", event.CreatedAt.Local().Format("2006-01-02 15:04"), event.EventType, event.Actor)
+		if event.Comment != nil && *event.Comment != "" {
+			line += ": " + *event.Comment
+		}
+		fmt.Println(line)
+		if event.Note != nil && *event.Note != "" {
+			fmt.Printf("    note: %s\n", *event.Note)
+		}
+	}
+}
+
 func init() {
 	showCmd.Flags().Bool("json", false, "Output JSON format")
+	showCmd.Flags().Bool("history", false, "Show the event history, including close/reopen notes")
+	showCmd.Flags().String("format", "", "Output format: 'json' (compact, same as --json), 'json-pretty', or 'md' (Markdown)")
+	showCmd.Flags().Bool("with-comments", false, "Include the comment thread (--json, --format md, or with the daemon running)")
+	showCmd.Flags().Bool("title", false, "Match the arguments against issue titles instead of IDs")
+	showCmd.Flags().Bool("tree", false, "Show the issue's children recursively, with blocking edges inline")
+	showCmd.Flags().Int("depth", 50, "Levels of children to show with --tree")
 	rootCmd.AddCommand(showCmd)
 
 	updateCmd.Flags().StringP("status", "s", "", "New status")
-	updateCmd.Flags().IntP("priority", "p", 0, "New priority")
+	updateCmd.Flags().StringP("priority", "p", "", "New priority (0-4, P0-P4 or a name such as high)")
 	updateCmd.Flags().String("title", "", "New title")
 	updateCmd.Flags().StringP("assignee", "a", "", "New assignee")
 	updateCmd.Flags().StringP("description", "d", "", "Issue description")
@@ -796,6 +1248,15 @@ func init() {
 	updateCmd.Flags().String("acceptance-criteria", "", "DEPRECATED: use --acceptance")
 	_ = updateCmd.Flags().MarkHidden("acceptance-criteria")
 	updateCmd.Flags().String("external-ref", "", "External reference (e.g., 'gh-9', 'jira-ABC')")
+	updateCmd.Flags().String("estimate", "", "Estimated effort (e.g. '2h', '1h30m', or minutes)")
+	updateCmd.Flags().String("spent", "", "Time spent so far (e.g. '30m', '1h', or minutes)")
+	updateCmd.Flags().StringArray("set", nil, "Set a custom metadata field as key=value (repeatable)")
+	updateCmd.Flags().StringSlice("unset", nil, "Remove custom metadata fields by key (repeatable)")
+	updateCmd.Flags().StringSlice("clear", nil, "Empty an optional field: assignee, design, notes, acceptance, external-ref, estimate or spent (repeatable)")
+	updateCmd.Flags().Bool("respect-locks", false, "Skip issues locked by another actor instead of warning (see 'bd lock')")
+	updateCmd.Flags().StringArray("filter", nil, "Update the issues matching label=<name>, status=<status>, type=<type> or assignee=<name> instead of IDs (repeatable)")
+	updateCmd.Flags().Bool("dry-run", false, "With --filter, list the issues that would be updated without updating them")
+	updateCmd.Flags().BoolP("yes", "y", false, "With --filter, allow updating more than 10 issues")
 	updateCmd.Flags().Bool("json", false, "Output JSON format")
 	rootCmd.AddCommand(updateCmd)
 
@@ -807,6 +1268,24 @@ func init() {
 	rootCmd.AddCommand(editCmd)
 
 	closeCmd.Flags().StringP("reason", "r", "", "Reason for closing")
+	closeCmd.Flags().String("note", "", "Note recorded on the Closed event (shown by 'bd show --history')")
+	closeCmd.Flags().String("resolution", "", "Why the issue was closed: fixed, wontfix, duplicate or obsolete")
+	closeCmd.Flags().String("git-commit", "", "Record the commit that resolved the issue (a SHA or any git revision)")
+	closeCmd.Flags().Bool("cascade", false, "Also close every open descendant (parent-child children, their children, ...)")
+	closeCmd.Flags().Bool("dry-run", false, "List the issues that would be closed without closing them")
 	closeCmd.Flags().Bool("json", false, "Output JSON format")
 	rootCmd.AddCommand(closeCmd)
 }
+
+// printCommentThread prints an issue's comments as indented reply threads
+func printCommentThread(comments []*types.Comment) {
+	threads := threadComments(comments)
+	if len(threads) == 0 {
+		return
+	}
+	fmt.Printf("\nComments (%d):\n", len(threads))
+	for _, tc := range threads {
+		indent := strings.Repeat("  ", tc.Depth+1)
+		fmt.Printf("%s#%d [%s at %s]%s\n%s%s\n\n", indent, tc.ID, tc.Author, tc.CreatedAt.Format("2006-01-02 15:04"), formatThreadStatus(tc.Status), indent, tc.displayText())
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// markdownIssue is everything 'bd show --format md' renders for one issue
+type markdownIssue struct {
+	Issue        *types.Issue
+	Labels       []string
+	Dependencies []*types.Issue
+	Dependents   []*types.Issue
+	Comments     []*types.Comment // Only loaded with --with-comments
+}
+
+// loadMarkdownIssue reads an issue and what its Markdown rendering shows.
+// Returns nil if the issue doesn't exist.
+func loadMarkdownIssue(ctx context.Context, s storage.Storage, id string, withComments bool) (*markdownIssue, error) {
+	issue, err := s.GetIssue(ctx, id)
+	if err != nil || issue == nil {
+		return nil, err
+	}
+	md := &markdownIssue{Issue: issue}
+	if md.Labels, err = s.GetLabels(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+	if md.Dependencies, err = s.GetDependencies(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	if md.Dependents, err = s.GetDependents(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to get dependents: %w", err)
+	}
+	if withComments {
+		if md.Comments, err = s.GetIssueComments(ctx, id); err != nil {
+			return nil, fmt.Errorf("failed to get comments: %w", err)
+		}
+	}
+	return md, nil
+}
+
+// markdownRenderer renders issues as Markdown. With a URL template, issue IDs
+// in headings, dependency lists and text become links.
+type markdownRenderer struct {
+	urlTemplate string
+	refPattern  *regexp.Regexp // Issue IDs to link in text, nil without a template
+}
+
+// newMarkdownRenderer builds a renderer that links IDs with these prefixes
+func newMarkdownRenderer(urlTemplate string, prefixes ...string) *markdownRenderer {
+	r := &markdownRenderer{urlTemplate: urlTemplate}
+	var quoted []string
+	for _, prefix := range prefixes {
+		if prefix = strings.TrimRight(prefix, "-"); prefix != "" {
+			quoted = append(quoted, regexp.QuoteMeta(prefix))
+		}
+	}
+	if urlTemplate != "" && len(quoted) > 0 {
+		// The ID must start a word that isn't already part of a path or link
+		r.refPattern = regexp.MustCompile(`(^|[^\w\-/\[])((?:` + strings.Join(quoted, "|") + `)-[0-9a-z]+(?:\.[0-9]+)*)\b`)
+	}
+	return r
+}
+
+// link renders an issue ID, as a link when there is a URL template
+func (r *markdownRenderer) link(id string) string {
+	if u := utils.ExpandIssueURL(r.urlTemplate, id); u != "" {
+		return "[" + id + "](" + u + ")"
+	}
+	return id
+}
+
+// linkRefs turns issue IDs in text into links, leaving fenced code blocks
+// and inline code alone
+func (r *markdownRenderer) linkRefs(text string) string {
+	if r.refPattern == nil {
+		return text
+	}
+	lines := strings.Split(text, "\n")
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		// Even pieces are outside backticks
+		pieces := strings.Split(line, "`")
+		for j := 0; j < len(pieces); j += 2 {
+			pieces[j] = r.refPattern.ReplaceAllStringFunc(pieces[j], func(match string) string {
+				m := r.refPattern.FindStringSubmatch(match)
+				return m[1] + r.link(m[2])
+			})
+		}
+		lines[i] = strings.Join(pieces, "`")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// render writes one issue: its title as a heading, a metadata table, the
+// text fields as sections, attachments and dependency lists and, when
+// loaded, the comments
+func (r *markdownRenderer) render(w io.Writer, md *markdownIssue) {
+	issue := md.Issue
+	fmt.Fprintf(w, "# %s: %s\n\n", r.link(issue.ID), markdownEscapeInline(issue.Title))
+
+	fmt.Fprintf(w, "| Field | Value |\n|---|---|\n")
+	fmt.Fprintf(w, "| Status | %s |\n", issue.Status)
+	fmt.Fprintf(w, "| Priority | P%d |\n", issue.Priority)
+	fmt.Fprintf(w, "| Type | %s |\n", issue.IssueType)
+	if issue.Assignee != "" {
+		fmt.Fprintf(w, "| Assignee | %s |\n", markdownEscapeCell(issue.Assignee))
+	}
+	if len(md.Labels) > 0 {
+		fmt.Fprintf(w, "| Labels | %s |\n", markdownEscapeCell(strings.Join(md.Labels, ", ")))
+	}
+
+	for _, section := range []struct{ name, text string }{
+		{"Description", issue.Description},
+		{"Design", issue.Design},
+		{"Acceptance Criteria", issue.AcceptanceCriteria},
+		{"Notes", issue.Notes},
+	} {
+		if strings.TrimSpace(section.text) != "" {
+			fmt.Fprintf(w, "\n## %s\n\n%s\n", section.name, r.linkRefs(strings.TrimRight(section.text, "\n")))
+		}
+	}
+
+	if len(issue.Attachments) > 0 {
+		fmt.Fprintf(w, "\n## Attachments\n\n")
+		for _, ref := range issue.Attachments {
+			fmt.Fprintf(w, "- [%s](%s)\n", markdownEscapeInline(ref), markdownLinkTarget.Replace(ref))
+		}
+	}
+
+	r.renderIssueList(w, "Depends on", md.Dependencies)
+	r.renderIssueList(w, "Blocks", md.Dependents)
+
+	if threads := threadComments(md.Comments); len(threads) > 0 {
+		fmt.Fprintf(w, "\n## Comments\n\n")
+		for _, tc := range threads {
+			indent := strings.Repeat("  ", tc.Depth)
+			text := r.linkRefs(strings.TrimRight(tc.displayText(), "\n"))
+			// Continuation lines stay inside the list item
+			text = strings.ReplaceAll(text, "\n", "\n"+indent+"  ")
+			fmt.Fprintf(w, "%s- **%s** (%s)%s: %s\n", indent, markdownEscapeInline(tc.Author),
+				tc.CreatedAt.Format("2006-01-02 15:04"), formatThreadStatus(tc.Status), text)
+		}
+	}
+}
+
+// renderIssueList writes a section listing related issues, if there are any
+func (r *markdownRenderer) renderIssueList(w io.Writer, heading string, issues []*types.Issue) {
+	if len(issues) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n## %s\n\n", heading)
+	for _, dep := range issues {
+		fmt.Fprintf(w, "- %s: %s (%s, P%d)\n", r.link(dep.ID), markdownEscapeInline(dep.Title), dep.Status, dep.Priority)
+	}
+}
+
+// markdownInlineEscaper escapes characters that would start Markdown
+// formatting in a single-line value such as a title
+var markdownInlineEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`)
+
+func markdownEscapeInline(s string) string {
+	return markdownInlineEscaper.Replace(strings.Join(strings.Fields(s), " "))
+}
+
+// markdownLinkTarget escapes the characters that would end a link target
+var markdownLinkTarget = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E")
+
+// markdownEscapeCell escapes a value for a table cell, where | ends the cell
+func markdownEscapeCell(s string) string {
+	return strings.ReplaceAll(markdownEscapeInline(s), "|", `\|`)
+}
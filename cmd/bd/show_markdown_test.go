@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestMarkdownRendererRender(t *testing.T) {
+	created := time.Date(2025, 6, 1, 9, 30, 0, 0, time.UTC)
+	parent := int64(1)
+	md := &markdownIssue{
+		Issue: &types.Issue{
+			ID:                 "bd-a3f8",
+			Title:              "Fix *login* | again",
+			Description:        "Broken since bd-b2.1 landed.\nSee `bd-c9` and https://x/bd-d4.\n```\nbd-e5\n```",
+			AcceptanceCriteria: "Login works",
+			Status:             types.StatusInProgress,
+			Priority:           1,
+			IssueType:          types.TypeBug,
+			Assignee:           "alice",
+		},
+		Labels:       []string{"auth"},
+		Dependencies: []*types.Issue{{ID: "bd-b2.1", Title: "Session store", Status: types.StatusClosed, Priority: 2}},
+		Comments: []*types.Comment{
+			{ID: 1, Author: "bob", Text: "Repro in bd-c9\nsteps below", CreatedAt: created},
+			{ID: 2, Author: "alice", Text: "Thanks", CreatedAt: created, ParentCommentID: &parent},
+		},
+	}
+
+	var buf bytes.Buffer
+	newMarkdownRenderer("https://issues.example.com/{id}", "bd").render(&buf, md)
+	out := buf.String()
+	for _, want := range []string{
+		"# [bd-a3f8](https://issues.example.com/bd-a3f8): Fix \\*login\\* | again\n",
+		"| Status | in_progress |\n",
+		"| Assignee | alice |\n| Labels | auth |\n",
+		"## Description\n\nBroken since [bd-b2.1](https://issues.example.com/bd-b2.1) landed.\n",
+		"See `bd-c9` and https://x/bd-d4.\n```\nbd-e5\n```\n",
+		"## Acceptance Criteria\n\nLogin works\n",
+		"## Depends on\n\n- [bd-b2.1](https://issues.example.com/bd-b2.1): Session store (closed, P2)\n",
+		"- **bob** (2025-06-01 09:30) (unresolved): Repro in [bd-c9](https://issues.example.com/bd-c9)\n  steps below\n  - **alice** (2025-06-01 09:30): Thanks\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "## Design") || strings.Contains(out, "## Blocks") {
+		t.Errorf("empty sections should be left out:\n%s", out)
+	}
+
+	// Without a template, IDs stay plain text
+	buf.Reset()
+	newMarkdownRenderer("", "bd").render(&buf, md)
+	if out := buf.String(); strings.Contains(out, "](") || !strings.HasPrefix(out, "# bd-a3f8: ") {
+		t.Errorf("expected no links without a template:\n%s", out)
+	}
+}
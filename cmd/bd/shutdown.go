@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/steveyegge/beads/internal/debug"
+)
+
+// shutdownFlushTimeout bounds the final flush on SIGTERM/SIGINT, so a wedged
+// store can't keep the process from exiting
+const shutdownFlushTimeout = 10 * time.Second
+
+var (
+	// shutdownExit ends the process after a signal's final flush. Tests
+	// replace it to observe the exit code.
+	shutdownExit = os.Exit
+
+	// finalFlushMutex serializes flushPending, so a signal arriving while
+	// PersistentPostRun flushes waits for that flush instead of racing it
+	finalFlushMutex sync.Mutex
+)
+
+// flushPending cancels the scheduled auto-flush, if any, and runs it now.
+// It returns the flush's error, or nil when there was nothing to flush.
+func flushPending() error {
+	finalFlushMutex.Lock()
+	defer finalFlushMutex.Unlock()
+
+	flushMutex.Lock()
+	needsFlush := isDirty && autoFlushEnabled
+	if needsFlush && flushTimer != nil {
+		flushTimer.Stop()
+		flushTimer = nil
+	}
+	// Don't clear isDirty or needsFullExport here - let flushToJSONL do it
+	flushMutex.Unlock()
+
+	if !needsFlush {
+		return nil
+	}
+	flushToJSONL()
+
+	flushMutex.Lock()
+	defer flushMutex.Unlock()
+	return lastFlushError
+}
+
+// flushPendingWithTimeout is flushPending, giving up after timeout
+func flushPendingWithTimeout(timeout time.Duration) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- flushPending()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("timed out after %v", timeout)
+	}
+}
+
+// flushOnShutdownSignal makes SIGTERM and SIGINT flush pending dirty issues
+// to JSONL before the process exits, instead of losing a debounced flush.
+// The exit code is 0 unless that flush fails. The returned func stops
+// listening and is safe to call more than once.
+func flushOnShutdownSignal() (stop func()) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGTERM, os.Interrupt)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case sig := <-sigChan:
+			debug.Logf("received %v, flushing pending changes before exit", sig)
+			code := 0
+			if err := flushPendingWithTimeout(shutdownFlushTimeout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: final flush failed: %v\n", err)
+				code = 1
+			}
+			shutdownExit(code)
+		case <-done:
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(sigChan)
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFlushOnShutdownSignal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SIGTERM can't be sent to a process on Windows")
+	}
+
+	tmpDir := t.TempDir()
+	dbPath = filepath.Join(tmpDir, "test.db")
+	jsonlPath := filepath.Join(tmpDir, "issues.jsonl")
+	testStore := newTestStore(t, dbPath)
+
+	oldStore, oldAutoFlush, oldExit := store, autoFlushEnabled, shutdownExit
+	store = testStore
+	autoFlushEnabled = true
+	storeMutex.Lock()
+	storeActive = true
+	storeMutex.Unlock()
+	t.Setenv("BEADS_FLUSH_DEBOUNCE", "1h")
+
+	// Record the exit instead of exiting, along with whether the JSONL was
+	// already written at that point
+	exited := make(chan int, 1)
+	wasWritten := make(chan bool, 1)
+	shutdownExit = func(code int) {
+		data, err := os.ReadFile(jsonlPath)
+		wasWritten <- err == nil && strings.Contains(string(data), "test-shutdown")
+		exited <- code
+	}
+	stop := flushOnShutdownSignal()
+	t.Cleanup(func() {
+		stop()
+		clearAutoFlushState()
+		storeMutex.Lock()
+		storeActive = false
+		storeMutex.Unlock()
+		store, autoFlushEnabled, shutdownExit = oldStore, oldAutoFlush, oldExit
+	})
+
+	issue := &types.Issue{ID: "test-shutdown", Title: "Pending export", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := testStore.CreateIssue(context.Background(), issue, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	markDirtyAndScheduleFlush()
+
+	sendSIGTERM(t)
+
+	select {
+	case code := <-exited:
+		if code != 0 {
+			t.Errorf("exit code = %d, want 0 after a successful flush", code)
+		}
+		if !<-wasWritten {
+			t.Error("JSONL didn't contain the dirty issue when the process exited")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("SIGTERM didn't trigger a flush and exit")
+	}
+}
+
+func TestFlushOnShutdownSignalFailedFlush(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SIGTERM can't be sent to a process on Windows")
+	}
+
+	tmpDir := t.TempDir()
+	dbPath = filepath.Join(tmpDir, "test.db")
+	testStore := newTestStore(t, dbPath)
+
+	// A directory where the JSONL belongs makes the write fail
+	if err := os.Mkdir(filepath.Join(tmpDir, "issues.jsonl"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	oldStore, oldAutoFlush, oldExit := store, autoFlushEnabled, shutdownExit
+	store = testStore
+	autoFlushEnabled = true
+	storeMutex.Lock()
+	storeActive = true
+	storeMutex.Unlock()
+	t.Setenv("BEADS_FLUSH_DEBOUNCE", "1h")
+
+	exited := make(chan int, 1)
+	shutdownExit = func(code int) { exited <- code }
+	stop := flushOnShutdownSignal()
+	t.Cleanup(func() {
+		stop()
+		clearAutoFlushState()
+		storeMutex.Lock()
+		storeActive = false
+		storeMutex.Unlock()
+		store, autoFlushEnabled, shutdownExit = oldStore, oldAutoFlush, oldExit
+	})
+
+	issue := &types.Issue{ID: "test-shutdown", Title: "Pending export", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := testStore.CreateIssue(context.Background(), issue, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	markDirtyAndScheduleFlush()
+
+	sendSIGTERM(t)
+
+	select {
+	case code := <-exited:
+		if code == 0 {
+			t.Error("exit code = 0, want non-zero after a failed flush")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("SIGTERM didn't trigger a flush and exit")
+	}
+}
+
+// sendSIGTERM sends SIGTERM to the test process itself
+func sendSIGTERM(t *testing.T) {
+	t.Helper()
+	process, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := process.Signal(syscall.SIGTERM); err != nil {
+		t.Fatalf("Failed to send SIGTERM: %v", err)
+	}
+}
@@ -1,47 +1,83 @@
 package main
 
 import (
+	"context"
+	"path/filepath"
+	"reflect"
 	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
 )
 
 func TestParseLabelArgs(t *testing.T) {
+	s := newTestStoreWithPrefix(t, filepath.Join(t.TempDir(), ".beads", "beads.db"), "bd")
+	ctx := context.Background()
+	for _, id := range []string{"bd-1", "bd-2", "bd-3"} {
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := s.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("Failed to create %s: %v", id, err)
+		}
+	}
+	oldStore, oldClient := store, daemonClient
+	store, daemonClient = s, nil
+	defer func() { store, daemonClient = oldStore, oldClient }()
+
 	tests := []struct {
 		name         string
 		args         []string
 		expectIDs    int
-		expectLabel  string
+		expectLabels []string
 	}{
 		{
-			name:        "single ID single label",
-			args:        []string{"bd-1", "bug"},
-			expectIDs:   1,
-			expectLabel: "bug",
+			name:         "single ID single label",
+			args:         []string{"bd-1", "bug"},
+			expectIDs:    1,
+			expectLabels: []string{"bug"},
+		},
+		{
+			name:         "multiple IDs single label",
+			args:         []string{"bd-1", "bd-2", "critical"},
+			expectIDs:    2,
+			expectLabels: []string{"critical"},
 		},
 		{
-			name:        "multiple IDs single label",
-			args:        []string{"bd-1", "bd-2", "critical"},
-			expectIDs:   2,
-			expectLabel: "critical",
+			name:         "three IDs one label",
+			args:         []string{"bd-1", "bd-2", "bd-3", "bug"},
+			expectIDs:    3,
+			expectLabels: []string{"bug"},
 		},
 		{
-			name:        "three IDs one label",
-			args:        []string{"bd-1", "bd-2", "bd-3", "bug"},
-			expectIDs:   3,
-			expectLabel: "bug",
+			name:         "one ID several labels, normalized",
+			args:         []string{"bd-1", " Bug", "UI", "bug"},
+			expectIDs:    1,
+			expectLabels: []string{"bug", "ui"},
+		},
+		{
+			name:         "last argument is always a label",
+			args:         []string{"bd-1", "bd-2"},
+			expectIDs:    1,
+			expectLabels: []string{"bd-2"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ids, label := parseLabelArgs(tt.args)
+			ids, labels, err := parseLabelArgs(ctx, tt.args)
+			if err != nil {
+				t.Fatalf("parseLabelArgs failed: %v", err)
+			}
 
 			if len(ids) != tt.expectIDs {
 				t.Errorf("Expected %d IDs, got %d", tt.expectIDs, len(ids))
 			}
 
-			if label != tt.expectLabel {
-				t.Errorf("Expected label %q, got %q", tt.expectLabel, label)
+			if !reflect.DeepEqual(labels, tt.expectLabels) {
+				t.Errorf("Expected labels %v, got %v", tt.expectLabels, labels)
 			}
 		})
 	}
+
+	if _, _, err := parseLabelArgs(ctx, []string{"bd-1", " "}); err == nil {
+		t.Error("Expected error when no labels are given")
+	}
 }
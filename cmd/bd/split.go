@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// defaultSplitTemplate names children of bd split --count
+const defaultSplitTemplate = "{title} (part {n})"
+
+var splitCmd = &cobra.Command{
+	Use:   "split <id>",
+	Short: "Split an issue into child issues",
+	Long: `Split an issue into child issues.
+
+Child titles come from repeated --child flags, or from --count with a
+--template in which {title} is the original title and {n} the child's
+number. Each child gets the issue's next child ID (bd-a3f8.1, bd-a3f8.2, ...),
+a parent-child dependency on it, and its priority. Children inherit the
+issue's labels and assignee unless --no-inherit is given.
+
+The issue is promoted to an epic if it isn't one. With
+--move-description-from, its description from the first line containing the
+given text onward moves to the first child.
+
+Examples:
+  bd split bd-a3f8 --child "Backend API" --child "Frontend form"
+  bd split bd-a3f8 --count 3 --template "{title}: step {n}"
+  bd split bd-a3f8 --child "Migration" --move-description-from "## Migration"
+  bd split bd-a3f8 --child "Docs" --dry-run`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		titles, _ := cmd.Flags().GetStringArray("child")
+		count, _ := cmd.Flags().GetInt("count")
+		template, _ := cmd.Flags().GetString("template")
+		marker, _ := cmd.Flags().GetString("move-description-from")
+		noInherit, _ := cmd.Flags().GetBool("no-inherit")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		if len(titles) > 0 && count > 0 {
+			fmt.Fprintf(os.Stderr, "Error: cannot use both --child and --count\n")
+			os.Exit(1)
+		}
+		if len(titles) == 0 && count <= 0 {
+			fmt.Fprintf(os.Stderr, "Error: give child titles with --child, or a number of children with --count\n")
+			os.Exit(1)
+		}
+
+		// split allocates child IDs in one transaction, which the daemon doesn't support
+		if err := ensureDirectMode("daemon does not support split command"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: split requires SQLite storage\n")
+			os.Exit(1)
+		}
+
+		parentID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error resolving issue ID %s: %v\n", args[0], err)
+			os.Exit(1)
+		}
+		parent, err := store.GetIssue(ctx, parentID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if parent == nil {
+			fmt.Fprintf(os.Stderr, "Error: issue %s not found\n", parentID)
+			os.Exit(1)
+		}
+		if parent.Status == types.StatusClosed {
+			fmt.Fprintf(os.Stderr, "Error: cannot split closed issue %s\n", parentID)
+			os.Exit(1)
+		}
+
+		if count > 0 {
+			titles = splitTemplateTitles(template, parent.Title, count)
+		}
+		for _, title := range titles {
+			if strings.TrimSpace(title) == "" {
+				fmt.Fprintf(os.Stderr, "Error: child titles cannot be empty\n")
+				os.Exit(1)
+			}
+		}
+
+		if !noInherit {
+			labels, err := store.GetLabels(ctx, parentID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to get labels of %s: %v\n", parentID, err)
+				os.Exit(1)
+			}
+			parent.Labels = labels
+		}
+		children := buildSplitChildren(parent, titles, !noInherit)
+
+		var parentUpdates map[string]interface{}
+		if marker != "" {
+			kept, moved, found := splitDescription(parent.Description, marker)
+			if !found {
+				fmt.Fprintf(os.Stderr, "Error: %q not found in the description of %s\n", marker, parentID)
+				os.Exit(1)
+			}
+			children[0].Description = moved
+			parentUpdates = map[string]interface{}{"description": kept}
+		}
+		promote := parent.IssueType != types.TypeEpic
+
+		if dryRun {
+			if jsonOutput {
+				outputJSON(map[string]interface{}{
+					"parent_id":       parentID,
+					"promote_to_epic": promote,
+					"children":        children,
+				})
+				return
+			}
+			fmt.Println(color.YellowString("DRY RUN - no changes will be made"))
+			fmt.Printf("Would split %s into %d child issues:\n", parentID, len(children))
+			printSplitChildren(children)
+			if promote {
+				fmt.Printf("Would promote %s from %s to epic\n", parentID, parent.IssueType)
+			}
+			if marker != "" {
+				fmt.Printf("Would move %d characters of description to %q\n", len(children[0].Description), children[0].Title)
+			}
+			return
+		}
+
+		if err := sqliteStore.SplitIssue(ctx, parentID, children, parentUpdates, actor); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		markDirtyAndScheduleFlush()
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"parent_id": parentID,
+				"promoted":  promote,
+				"children":  children,
+			})
+			return
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Split %s into %d child issues\n", green("✓"), parentID, len(children))
+		printSplitChildren(children)
+		if promote {
+			fmt.Printf("  %s is now an epic\n", parentID)
+		}
+	},
+}
+
+// splitTemplateTitles expands template for children 1..count. {title} is the
+// parent's title and {n} the child's number.
+func splitTemplateTitles(template, title string, count int) []string {
+	if template == "" {
+		template = defaultSplitTemplate
+	}
+	titles := make([]string, count)
+	for i := range titles {
+		titles[i] = strings.NewReplacer("{title}", title, "{n}", strconv.Itoa(i+1)).Replace(template)
+	}
+	return titles
+}
+
+// buildSplitChildren returns the issues splitting parent creates, one per
+// title, with the parent's priority and, if inherit is set, its labels and
+// assignee. IDs are assigned by SplitIssue.
+func buildSplitChildren(parent *types.Issue, titles []string, inherit bool) []*types.Issue {
+	children := make([]*types.Issue, len(titles))
+	for i, title := range titles {
+		child := &types.Issue{
+			Title:     title,
+			Status:    types.StatusOpen,
+			Priority:  parent.Priority,
+			IssueType: types.TypeTask,
+		}
+		if inherit {
+			child.Assignee = parent.Assignee
+			child.Labels = append([]string(nil), parent.Labels...)
+		}
+		children[i] = child
+	}
+	return children
+}
+
+// splitDescription cuts description at the start of the first line
+// containing marker. The part before is kept and the rest moved, both
+// trimmed; found is false if no line contains marker.
+func splitDescription(description, marker string) (kept, moved string, found bool) {
+	lines := strings.SplitAfter(description, "\n")
+	offset := 0
+	for _, line := range lines {
+		if strings.Contains(line, marker) {
+			return strings.TrimSpace(description[:offset]), strings.TrimSpace(description[offset:]), true
+		}
+		offset += len(line)
+	}
+	return description, "", false
+}
+
+// printSplitChildren lists split children with what they inherited
+func printSplitChildren(children []*types.Issue) {
+	for _, child := range children {
+		id := child.ID
+		if id == "" {
+			id = "(new)"
+		}
+		line := fmt.Sprintf("  %s: %s", id, child.Title)
+		if child.Assignee != "" {
+			line += " @" + child.Assignee
+		}
+		if len(child.Labels) > 0 {
+			line += " [" + strings.Join(child.Labels, ", ") + "]"
+		}
+		fmt.Println(line)
+	}
+}
+
+func init() {
+	splitCmd.Flags().StringArray("child", nil, "Title of a child issue (repeatable)")
+	splitCmd.Flags().Int("count", 0, "Number of children to create from --template")
+	splitCmd.Flags().String("template", defaultSplitTemplate, "Title template for --count children ({title}, {n})")
+	splitCmd.Flags().String("move-description-from", "", "Move the description from the first line containing this text to the first child")
+	splitCmd.Flags().Bool("no-inherit", false, "Don't copy labels and assignee to the children")
+	splitCmd.Flags().Bool("dry-run", false, "Preview the split without making changes")
+	rootCmd.AddCommand(splitCmd)
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSplitTemplateTitles(t *testing.T) {
+	if got := strings.Join(splitTemplateTitles("", "Login", 2), "|"); got != "Login (part 1)|Login (part 2)" {
+		t.Errorf("default template gave %q", got)
+	}
+	if got := strings.Join(splitTemplateTitles("Step {n}: {title}", "Login", 1), "|"); got != "Step 1: Login" {
+		t.Errorf("custom template gave %q", got)
+	}
+}
+
+func TestBuildSplitChildren(t *testing.T) {
+	parent := &types.Issue{ID: "bd-a", Priority: 1, Assignee: "alice", Labels: []string{"backend"}}
+	children := buildSplitChildren(parent, []string{"One", "Two"}, true)
+	if len(children) != 2 || children[1].Title != "Two" || children[1].Priority != 1 || children[1].Assignee != "alice" || children[1].Labels[0] != "backend" {
+		t.Errorf("unexpected inherited children: %+v", children[1])
+	}
+	children = buildSplitChildren(parent, []string{"One"}, false)
+	if children[0].Assignee != "" || len(children[0].Labels) != 0 {
+		t.Errorf("expected nothing inherited with --no-inherit: %+v", children[0])
+	}
+}
+
+func TestSplitDescription(t *testing.T) {
+	kept, moved, found := splitDescription("Intro\n\n## Backend\nAPI work\n", "## Backend")
+	if !found || kept != "Intro" || moved != "## Backend\nAPI work" {
+		t.Errorf("got kept %q moved %q found %v", kept, moved, found)
+	}
+	if _, _, found := splitDescription("Intro", "## Backend"); found {
+		t.Error("expected a missing marker to be reported")
+	}
+}
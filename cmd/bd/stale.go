@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -17,11 +18,42 @@ var staleCmd = &cobra.Command{
 This helps identify:
 - In-progress issues with no recent activity (may be abandoned)
 - Open issues that have been forgotten
-- Issues that might be outdated or no longer relevant`,
+- Issues that might be outdated or no longer relevant
+
+An issue is stale when its updated_at (last change of any kind) is older than
+the threshold: --days, or --older-than with a duration such as 36h, 14d or 2w.
+Issues are listed oldest first with their age.
+
+Examples:
+  bd stale --in-progress --older-than 14d
+  bd stale --status open --older-than 90d --json`,
 	Run: func(cmd *cobra.Command, args []string) {
 		days, _ := cmd.Flags().GetInt("days")
 		status, _ := cmd.Flags().GetString("status")
 		limit, _ := cmd.Flags().GetInt("limit")
+		inProgress, _ := cmd.Flags().GetBool("in-progress")
+		olderThanStr, _ := cmd.Flags().GetString("older-than")
+		if inProgress {
+			if status != "" && status != string(types.StatusInProgress) {
+				fmt.Fprintf(os.Stderr, "Error: --in-progress conflicts with --status %s\n", status)
+				os.Exit(1)
+			}
+			status = string(types.StatusInProgress)
+		}
+		threshold := time.Duration(days) * 24 * time.Hour
+		var olderThan time.Duration
+		if olderThanStr != "" {
+			if cmd.Flags().Changed("days") {
+				fmt.Fprintf(os.Stderr, "Error: --older-than and --days are mutually exclusive\n")
+				os.Exit(1)
+			}
+			var err error
+			if olderThan, err = parseStaleThreshold(olderThanStr); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			threshold = olderThan
+		}
 		// Use global jsonOutput set by PersistentPreRun
 		// Validate status if provided
 		if status != "" && status != "open" && status != "in_progress" && status != "blocked" {
@@ -29,16 +61,18 @@ This helps identify:
 			os.Exit(1)
 		}
 		filter := types.StaleFilter{
-			Days:   days,
-			Status: status,
-			Limit:  limit,
+			Days:      days,
+			OlderThan: olderThan,
+			Status:    status,
+			Limit:     limit,
 		}
 		// If daemon is running, use RPC
 		if daemonClient != nil {
 			staleArgs := &rpc.StaleArgs{
-				Days:   days,
-				Status: status,
-				Limit:  limit,
+				Days:      days,
+				OlderThan: olderThan,
+				Status:    status,
+				Limit:     limit,
 			}
 			resp, err := daemonClient.Stale(staleArgs)
 			if err != nil {
@@ -57,7 +91,7 @@ This helps identify:
 				outputJSON(issues)
 				return
 			}
-			displayStaleIssues(issues, days)
+			displayStaleIssues(issues, threshold)
 			return
 		}
 		// Direct mode
@@ -74,22 +108,42 @@ This helps identify:
 			outputJSON(issues)
 			return
 		}
-		displayStaleIssues(issues, days)
+		displayStaleIssues(issues, threshold)
 	},
 }
-func displayStaleIssues(issues []*types.Issue, days int) {
+// parseStaleThreshold parses --older-than: a duration such as 36h, 14d or 2w,
+// or a plain number of days
+func parseStaleThreshold(s string) (time.Duration, error) {
+	if d, ok := parseRelativeDuration(s); ok && d > 0 {
+		return d, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n > 0 {
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return 0, fmt.Errorf("invalid --older-than %q (use a duration like 36h, 14d or 2w)", s)
+}
+
+// formatStaleAge renders how long ago an issue was updated: hours under a
+// day, days beyond
+func formatStaleAge(d time.Duration) string {
+	if d < 24*time.Hour {
+		return fmt.Sprintf("%d hours", int(d.Hours()))
+	}
+	return fmt.Sprintf("%d days", int(d.Hours()/24))
+}
+
+func displayStaleIssues(issues []*types.Issue, threshold time.Duration) {
 	if len(issues) == 0 {
 		green := color.New(color.FgGreen).SprintFunc()
 		fmt.Printf("\n%s No stale issues found (all active)\n\n", green("✨"))
 		return
 	}
 	yellow := color.New(color.FgYellow).SprintFunc()
-	fmt.Printf("\n%s Stale issues (%d not updated in %d+ days):\n\n", yellow("⏰"), len(issues), days)
+	fmt.Printf("\n%s Stale issues (%d not updated in %s+):\n\n", yellow("⏰"), len(issues), formatStaleAge(threshold))
 	now := time.Now()
 	for i, issue := range issues {
-		daysStale := int(now.Sub(issue.UpdatedAt).Hours() / 24)
-		fmt.Printf("%d. [P%d] %s: %s\n", i+1, issue.Priority, issue.ID, issue.Title)
-		fmt.Printf("   Status: %s, Last updated: %d days ago\n", issue.Status, daysStale)
+		fmt.Printf("%d. [P%d] %s: %s\n", i+1, issue.Priority, displayID(issue.ID), issue.Title)
+		fmt.Printf("   Status: %s, Last updated: %s ago\n", issue.Status, formatStaleAge(now.Sub(issue.UpdatedAt)))
 		if issue.Assignee != "" {
 			fmt.Printf("   Assignee: %s\n", issue.Assignee)
 		}
@@ -98,7 +152,9 @@ func displayStaleIssues(issues []*types.Issue, days int) {
 }
 func init() {
 	staleCmd.Flags().IntP("days", "d", 30, "Issues not updated in this many days")
+	staleCmd.Flags().String("older-than", "", "Issues not updated in this long (e.g. 36h, 14d, 2w); replaces --days")
 	staleCmd.Flags().StringP("status", "s", "", "Filter by status (open|in_progress|blocked)")
+	staleCmd.Flags().Bool("in-progress", false, "Only in_progress issues (same as --status in_progress)")
 	staleCmd.Flags().IntP("limit", "n", 50, "Maximum issues to show")
 	staleCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output JSON format")
 	rootCmd.AddCommand(staleCmd)
@@ -378,6 +378,61 @@ func TestStaleIssuesDifferentDaysThreshold(t *testing.T) {
 	}
 }
 
+func TestStaleIssuesOlderThan(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, ".beads", "beads.db")
+	sqliteStore := newTestStore(t, dbPath)
+	ctx := context.Background()
+
+	for _, id := range []string{"test-2d", "test-6h"} {
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusInProgress, Priority: 1, IssueType: types.TypeTask}
+		if err := sqliteStore.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db := sqliteStore.UnderlyingDB()
+	if _, err := db.ExecContext(ctx, "UPDATE issues SET updated_at = datetime('now', '-2 days') WHERE id = ?", "test-2d"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, "UPDATE issues SET updated_at = datetime('now', '-6 hours') WHERE id = ?", "test-6h"); err != nil {
+		t.Fatal(err)
+	}
+
+	// OlderThan overrides Days, so hours work
+	stale, err := sqliteStore.GetStaleIssues(ctx, types.StaleFilter{Days: 30, OlderThan: 3 * time.Hour, Status: "in_progress"})
+	if err != nil {
+		t.Fatalf("GetStaleIssues failed: %v", err)
+	}
+	if len(stale) != 2 || stale[0].ID != "test-2d" {
+		t.Errorf("Expected both issues oldest first, got %v", stale)
+	}
+	stale, err = sqliteStore.GetStaleIssues(ctx, types.StaleFilter{OlderThan: 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("GetStaleIssues failed: %v", err)
+	}
+	if len(stale) != 1 || stale[0].ID != "test-2d" {
+		t.Errorf("Expected only test-2d stale for 24h+, got %v", stale)
+	}
+}
+
+func TestParseStaleThreshold(t *testing.T) {
+	for arg, want := range map[string]time.Duration{
+		"14d": 14 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+		"36h": 36 * time.Hour,
+		"90":  90 * 24 * time.Hour,
+	} {
+		if got, err := parseStaleThreshold(arg); err != nil || got != want {
+			t.Errorf("parseStaleThreshold(%q) = %v, %v; want %v", arg, got, err, want)
+		}
+	}
+	for _, arg := range []string{"", "0d", "-3", "14x", "d"} {
+		if _, err := parseStaleThreshold(arg); err == nil {
+			t.Errorf("parseStaleThreshold(%q) should fail", arg)
+		}
+	}
+}
+
 func TestStaleCommandInit(t *testing.T) {
 	if staleCmd == nil {
 		t.Fatal("staleCmd should be initialized")
@@ -405,4 +460,7 @@ func TestStaleCommandInit(t *testing.T) {
 	if flags.Lookup("json") == nil {
 		t.Error("staleCmd should have --json flag")
 	}
+	if flags.Lookup("older-than") == nil || flags.Lookup("in-progress") == nil {
+		t.Error("staleCmd should have --older-than and --in-progress flags")
+	}
 }
@@ -2,18 +2,18 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"sort"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/debug"
 	"github.com/steveyegge/beads/internal/rpc"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
 var syncCmd = &cobra.Command{
@@ -28,6 +28,17 @@ var syncCmd = &cobra.Command{
 
 This command wraps the entire git-based sync workflow for multi-device use.
 
+If the pull conflicts only in the JSONL, the conflict is merged issue by issue:
+fields changed on one side are kept, fields changed on both sides take the
+newer update, and labels, dependencies, and comments are unioned.
+
+Use --dry-run to see what a sync would do without writing anything to git or
+the JSONL: the issues waiting to be exported, whether the JSONL differs from
+HEAD, the commit message, the upstream and how far the branch is ahead of and
+behind it as of the last fetch (nothing is fetched), whether the pull would
+merge or rebase and the push fast-forward, and any unmerged paths that would
+stop the sync. With --json the preview is printed as an object.
+
 Use --flush-only to just export pending changes to JSONL (useful for pre-commit hooks).
 Use --import-only to just import from JSONL (useful after git pull).
 Use --status to show diff between sync branch and main branch.
@@ -89,6 +100,8 @@ Use --merge to merge the sync branch back to main branch.`,
 		if flushOnly {
 			if dryRun {
 				fmt.Println("→ [DRY RUN] Would export pending changes to JSONL")
+			} else if jsonlCurrent(ctx, jsonlPath) {
+				debug.Logf("auto-flush already exported all changes, skipping flush")
 			} else {
 				if err := exportToJSONL(ctx, jsonlPath); err != nil {
 					fmt.Fprintf(os.Stderr, "Error exporting: %v\n", err)
@@ -105,6 +118,11 @@ Use --merge to merge the sync branch back to main branch.`,
 			os.Exit(1)
 		}
 
+		if dryRun {
+			runSyncDryRun(ctx, jsonlPath, message, noPull, noPush)
+			return
+		}
+
 		// Preflight: check for merge/rebase in progress
 		if inMerge, err := gitHasUnmergedPaths(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error checking git state: %v\n", err)
@@ -122,39 +140,47 @@ Use --merge to merge the sync branch back to main branch.`,
 			os.Exit(1)
 		}
 
-		// Step 1: Export pending changes
-		if dryRun {
-			fmt.Println("→ [DRY RUN] Would export pending changes to JSONL")
-		} else {
-			// Pre-export integrity checks
-			if err := ensureStoreActive(); err == nil && store != nil {
-				if err := validatePreExport(ctx, store, jsonlPath); err != nil {
-					fmt.Fprintf(os.Stderr, "Pre-export validation failed: %v\n", err)
-					os.Exit(1)
-				}
-				if err := checkDuplicateIDs(ctx, store); err != nil {
-					fmt.Fprintf(os.Stderr, "Database corruption detected: %v\n", err)
-					os.Exit(1)
-				}
-				if orphaned, err := checkOrphanedDeps(ctx, store); err != nil {
-					fmt.Fprintf(os.Stderr, "Warning: orphaned dependency check failed: %v\n", err)
-				} else if len(orphaned) > 0 {
-					fmt.Fprintf(os.Stderr, "Warning: found %d orphaned dependencies: %v\n", len(orphaned), orphaned)
-				}
+		retryPolicy := syncRetryPolicyFromConfig(ctx)
+
+		// Close issues named by "fixes <id>" in commits since the last sync,
+		// before exporting so the closures go out with this sync
+		if err := ensureStoreActive(); err == nil && store != nil {
+			actions, err := scanCommitsForClosures(ctx, store, false)
+			printCommitCloseActions(actions, false)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: commit scan failed: %v\n", err)
 			}
+		}
 
-			fmt.Println("→ Exporting pending changes to JSONL...")
-			if err := exportToJSONL(ctx, jsonlPath); err != nil {
-				fmt.Fprintf(os.Stderr, "Error exporting: %v\n", err)
+		// Step 1: Export pending changes
+		// Pre-export integrity checks
+		if err := ensureStoreActive(); err == nil && store != nil {
+			if err := validatePreExport(ctx, store, jsonlPath); err != nil {
+				fmt.Fprintf(os.Stderr, "Pre-export validation failed: %v\n", err)
 				os.Exit(1)
 			}
-
-			// Capture left snapshot (pre-pull state) for 3-way merge
-			// This is mandatory for deletion tracking integrity
-			if err := captureLeftSnapshot(jsonlPath); err != nil {
-				fmt.Fprintf(os.Stderr, "Error: failed to capture snapshot (required for deletion tracking): %v\n", err)
+			if err := checkDuplicateIDs(ctx, store); err != nil {
+				fmt.Fprintf(os.Stderr, "Database corruption detected: %v\n", err)
 				os.Exit(1)
 			}
+			if orphaned, err := checkOrphanedDeps(ctx, store); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: orphaned dependency check failed: %v\n", err)
+			} else if len(orphaned) > 0 {
+				fmt.Fprintf(os.Stderr, "Warning: found %d orphaned dependencies: %v\n", len(orphaned), orphaned)
+			}
+		}
+
+		fmt.Println("→ Exporting pending changes to JSONL...")
+		if err := exportToJSONL(ctx, jsonlPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error exporting: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Capture left snapshot (pre-pull state) for 3-way merge
+		// This is mandatory for deletion tracking integrity
+		if err := captureLeftSnapshot(jsonlPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to capture snapshot (required for deletion tracking): %v\n", err)
+			os.Exit(1)
 		}
 
 		// Step 2: Check if there are changes to commit
@@ -163,16 +189,19 @@ Use --merge to merge the sync branch back to main branch.`,
 			fmt.Fprintf(os.Stderr, "Error checking git status: %v\n", err)
 			os.Exit(1)
 		}
+		if templatesDir := syncTemplatesDir(jsonlPath); !hasChanges && templatesDir != "" {
+			hasChanges, err = gitHasChanges(ctx, templatesDir)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error checking git status: %v\n", err)
+				os.Exit(1)
+			}
+		}
 
 		if hasChanges {
-			if dryRun {
-				fmt.Println("→ [DRY RUN] Would commit changes to git")
-			} else {
-				fmt.Println("→ Committing changes to git...")
-				if err := gitCommit(ctx, jsonlPath, message); err != nil {
-					fmt.Fprintf(os.Stderr, "Error committing: %v\n", err)
-					os.Exit(1)
-				}
+			fmt.Println("→ Committing changes to git...")
+			if err := gitCommit(ctx, jsonlPath, message); err != nil {
+				fmt.Fprintf(os.Stderr, "Error committing: %v\n", err)
+				os.Exit(1)
 			}
 		} else {
 			fmt.Println("→ No changes to commit")
@@ -180,123 +209,129 @@ Use --merge to merge the sync branch back to main branch.`,
 
 		// Step 3: Pull from remote
 		if !noPull {
-			if dryRun {
-				fmt.Println("→ [DRY RUN] Would pull from remote")
-			} else {
-				fmt.Println("→ Pulling from remote...")
-				if err := gitPull(ctx); err != nil {
+			fmt.Println("→ Pulling from remote...")
+			if err := gitPullWithRetry(ctx, retryPolicy); err != nil {
+				// Conflicts confined to the JSONL are merged issue by issue
+				resolved, mergeErr := resolveJSONLMergeConflict(ctx, jsonlPath)
+				if mergeErr != nil {
+					fmt.Fprintf(os.Stderr, "Error merging %s: %v\n", filepath.Base(jsonlPath), mergeErr)
+				}
+				if !resolved {
 					fmt.Fprintf(os.Stderr, "Error pulling: %v\n", err)
 					fmt.Fprintf(os.Stderr, "Hint: resolve conflicts manually and run 'bd import' then 'bd sync' again\n")
 					os.Exit(1)
 				}
+				fmt.Println("→ Merged conflicting JSONL changes")
+				hasChanges = true // The merge commit needs pushing
+			}
 
-				// Count issues before import for validation
-				var beforeCount int
-				if err := ensureStoreActive(); err == nil && store != nil {
-					beforeCount, err = countDBIssues(ctx, store)
-					if err != nil {
-						fmt.Fprintf(os.Stderr, "Warning: failed to count issues before import: %v\n", err)
-					}
-				}
-
-				// Step 3.5: Perform 3-way merge and prune deletions
-				if err := ensureStoreActive(); err == nil && store != nil {
-					if err := applyDeletionsFromMerge(ctx, store, jsonlPath); err != nil {
-						fmt.Fprintf(os.Stderr, "Error during 3-way merge: %v\n", err)
-						os.Exit(1)
-					}
+			// Count issues before import for validation
+			var beforeCount int
+			if err := ensureStoreActive(); err == nil && store != nil {
+				beforeCount, err = countDBIssues(ctx, store)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to count issues before import: %v\n", err)
 				}
+			}
 
-				// Step 4: Import updated JSONL after pull
-				fmt.Println("→ Importing updated JSONL...")
-				if err := importFromJSONL(ctx, jsonlPath, renameOnImport); err != nil {
-					fmt.Fprintf(os.Stderr, "Error importing: %v\n", err)
+			// Step 3.5: Perform 3-way merge and prune deletions
+			if err := ensureStoreActive(); err == nil && store != nil {
+				if err := applyDeletionsFromMerge(ctx, store, jsonlPath); err != nil {
+					fmt.Fprintf(os.Stderr, "Error during 3-way merge: %v\n", err)
 					os.Exit(1)
 				}
+			}
 
-				// Validate import didn't cause data loss
-				if beforeCount > 0 {
-					if err := ensureStoreActive(); err == nil && store != nil {
-						afterCount, err := countDBIssues(ctx, store)
-						if err != nil {
-							fmt.Fprintf(os.Stderr, "Warning: failed to count issues after import: %v\n", err)
-						} else {
-							if err := validatePostImport(beforeCount, afterCount); err != nil {
-								fmt.Fprintf(os.Stderr, "Post-import validation failed: %v\n", err)
-								os.Exit(1)
-							}
-						}
-					}
-				}
-				
-				// Step 4.5: Check if DB needs re-export (only if DB differs from JSONL)
-				// This prevents the infinite loop: import → export → commit → dirty again
+			// Step 4: Import updated JSONL after pull
+			fmt.Println("→ Importing updated JSONL...")
+			if err := importFromJSONL(ctx, jsonlPath, renameOnImport); err != nil {
+				fmt.Fprintf(os.Stderr, "Error importing: %v\n", err)
+				os.Exit(1)
+			}
+
+			// Validate import didn't cause data loss
+			if beforeCount > 0 {
 				if err := ensureStoreActive(); err == nil && store != nil {
-					needsExport, err := dbNeedsExport(ctx, store, jsonlPath)
+					afterCount, err := countDBIssues(ctx, store)
 					if err != nil {
-						fmt.Fprintf(os.Stderr, "Warning: failed to check if export needed: %v\n", err)
-						// Conservative: assume export needed
-						needsExport = true
-					}
-
-					if needsExport {
-						fmt.Println("→ Re-exporting after import to sync DB changes...")
-						if err := exportToJSONL(ctx, jsonlPath); err != nil {
-							fmt.Fprintf(os.Stderr, "Error re-exporting after import: %v\n", err)
+						fmt.Fprintf(os.Stderr, "Warning: failed to count issues after import: %v\n", err)
+					} else {
+						if err := validatePostImport(beforeCount, afterCount); err != nil {
+							fmt.Fprintf(os.Stderr, "Post-import validation failed: %v\n", err)
 							os.Exit(1)
 						}
+					}
+				}
+			}
+			
+			// Step 4.5: Check if DB needs re-export (only if DB differs from JSONL)
+			// This prevents the infinite loop: import → export → commit → dirty again
+			if err := ensureStoreActive(); err == nil && store != nil {
+				needsExport, err := dbNeedsExport(ctx, store, jsonlPath)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to check if export needed: %v\n", err)
+					// Conservative: assume export needed
+					needsExport = true
+				}
+
+				if needsExport {
+					fmt.Println("→ Re-exporting after import to sync DB changes...")
+					if err := exportToJSONL(ctx, jsonlPath); err != nil {
+						fmt.Fprintf(os.Stderr, "Error re-exporting after import: %v\n", err)
+						os.Exit(1)
+					}
 
-						// Step 4.6: Commit the re-export if it created changes
-						hasPostImportChanges, err := gitHasChanges(ctx, jsonlPath)
-						if err != nil {
-							fmt.Fprintf(os.Stderr, "Error checking git status after re-export: %v\n", err)
+					// Step 4.6: Commit the re-export if it created changes
+					hasPostImportChanges, err := gitHasChanges(ctx, jsonlPath)
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "Error checking git status after re-export: %v\n", err)
+						os.Exit(1)
+					}
+					if hasPostImportChanges {
+						fmt.Println("→ Committing DB changes from import...")
+						if err := gitCommit(ctx, jsonlPath, "bd sync: apply DB changes after import"); err != nil {
+							fmt.Fprintf(os.Stderr, "Error committing post-import changes: %v\n", err)
 							os.Exit(1)
 						}
-						if hasPostImportChanges {
-							fmt.Println("→ Committing DB changes from import...")
-							if err := gitCommit(ctx, jsonlPath, "bd sync: apply DB changes after import"); err != nil {
-								fmt.Fprintf(os.Stderr, "Error committing post-import changes: %v\n", err)
-								os.Exit(1)
-							}
-							hasChanges = true // Mark that we have changes to push
-						}
-					} else {
-						fmt.Println("→ DB and JSONL in sync, skipping re-export")
+						hasChanges = true // Mark that we have changes to push
 					}
+				} else {
+					fmt.Println("→ DB and JSONL in sync, skipping re-export")
 				}
+			}
 
-				// Update base snapshot after successful import
-				if err := updateBaseSnapshot(jsonlPath); err != nil {
-					fmt.Fprintf(os.Stderr, "Warning: failed to update base snapshot: %v\n", err)
-				}
+			// Update base snapshot after successful import
+			if err := updateBaseSnapshot(jsonlPath); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to update base snapshot: %v\n", err)
+			}
 
-				// Clean up temporary snapshot files after successful merge
-				sm := NewSnapshotManager(jsonlPath)
-				if err := sm.Cleanup(); err != nil {
-					fmt.Fprintf(os.Stderr, "Warning: failed to clean up snapshots: %v\n", err)
-				}
+			// Clean up temporary snapshot files after successful merge
+			sm := NewSnapshotManager(jsonlPath)
+			if err := sm.Cleanup(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to clean up snapshots: %v\n", err)
 			}
 		}
 
 		// Step 5: Push to remote
 		if !noPush && hasChanges {
-			if dryRun {
-				fmt.Println("→ [DRY RUN] Would push to remote")
-			} else {
-				fmt.Println("→ Pushing to remote...")
-				if err := gitPush(ctx); err != nil {
-					fmt.Fprintf(os.Stderr, "Error pushing: %v\n", err)
-					fmt.Fprintf(os.Stderr, "Hint: pull may have brought new changes, run 'bd sync' again\n")
+			fmt.Println("→ Pushing to remote...")
+			rebased, err := gitPushWithRetry(ctx, jsonlPath, retryPolicy)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error pushing: %v\n", err)
+				fmt.Fprintf(os.Stderr, "Hint: pull may have brought new changes, run 'bd sync' again\n")
+				os.Exit(1)
+			}
+			if rebased {
+				// Retrying rebased onto changes someone else pushed meanwhile
+				fmt.Println("→ Importing JSONL changes pulled while retrying...")
+				if err := importFromJSONL(ctx, jsonlPath, renameOnImport); err != nil {
+					fmt.Fprintf(os.Stderr, "Error importing: %v\n", err)
 					os.Exit(1)
 				}
 			}
 		}
 
-		if dryRun {
-			fmt.Println("\n✓ Dry run complete (no changes made)")
-		} else {
-			fmt.Println("\n✓ Sync complete")
-		}
+		fmt.Println("\n✓ Sync complete")
 	},
 }
 
@@ -377,23 +412,46 @@ func gitHasChanges(ctx context.Context, filePath string) (bool, error) {
 	return len(strings.TrimSpace(string(output))) > 0, nil
 }
 
-// gitCommit commits the specified file
+// syncTemplatesDir returns the custom templates directory next to jsonlPath,
+// or "" if there is none
+func syncTemplatesDir(jsonlPath string) string {
+	dir := filepath.Join(filepath.Dir(jsonlPath), "templates")
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return ""
+	}
+	return dir
+}
+
+// gitCommit commits the specified file, along with the custom templates
+// beside it so they sync like the issues do
 func gitCommit(ctx context.Context, filePath string, message string) error {
 	// Stage the file
-	addCmd := exec.CommandContext(ctx, "git", "add", filePath)
+	addArgs := []string{"add", filePath}
+	if templatesDir := syncTemplatesDir(filePath); templatesDir != "" {
+		addArgs = append(addArgs, templatesDir)
+	}
+	addCmd := exec.CommandContext(ctx, "git", addArgs...)
 	if err := addCmd.Run(); err != nil {
 		return fmt.Errorf("git add failed: %w", err)
 	}
 
-	// Generate message if not provided
+	// Generate message from the configured template if not provided
 	if message == "" {
-		message = fmt.Sprintf("bd sync: %s", time.Now().Format("2006-01-02 15:04:05"))
+		message = syncCommitMessage(ctx, filePath)
 	}
 
-	// Commit
-	commitCmd := exec.CommandContext(ctx, "git", "commit", "-m", message)
+	// Commit, signed if sync_sign_commits is set
+	signArgs, err := syncCommitSigningArgs(ctx)
+	if err != nil {
+		return err
+	}
+	args := append(append([]string{"commit"}, signArgs...), "-m", message)
+	commitCmd := exec.CommandContext(ctx, "git", args...)
 	output, err := commitCmd.CombinedOutput()
 	if err != nil {
+		if signErr := syncSigningError(err, output, signArgs); signErr != nil {
+			return signErr
+		}
 		return fmt.Errorf("git commit failed: %w\n%s", err, output)
 	}
 
@@ -418,13 +476,30 @@ func gitPull(ctx context.Context) error {
 		return nil // Gracefully skip - local-only mode
 	}
 	
+	remote, branch, err := gitCurrentRemoteBranch(ctx)
+	if err != nil {
+		return err
+	}
+	
+	// Pull with explicit remote and branch
+	cmd := exec.CommandContext(ctx, "git", "pull", remote, branch)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git pull failed: %w\n%s", err, output)
+	}
+	return nil
+}
+
+// gitCurrentRemoteBranch returns the current branch and the remote it
+// tracks, defaulting to "origin"
+func gitCurrentRemoteBranch(ctx context.Context) (remote, branch string, err error) {
 	// Get current branch name
 	branchCmd := exec.CommandContext(ctx, "git", "rev-parse", "--abbrev-ref", "HEAD")
 	branchOutput, err := branchCmd.Output()
 	if err != nil {
-		return fmt.Errorf("failed to get current branch: %w", err)
+		return "", "", fmt.Errorf("failed to get current branch: %w", err)
 	}
-	branch := strings.TrimSpace(string(branchOutput))
+	branch = strings.TrimSpace(string(branchOutput))
 	
 	// Get remote name for current branch (usually "origin")
 	remoteCmd := exec.CommandContext(ctx, "git", "config", "--get", fmt.Sprintf("branch.%s.remote", branch))
@@ -433,15 +508,7 @@ func gitPull(ctx context.Context) error {
 		// If no remote configured, default to "origin"
 		remoteOutput = []byte("origin\n")
 	}
-	remote := strings.TrimSpace(string(remoteOutput))
-	
-	// Pull with explicit remote and branch
-	cmd := exec.CommandContext(ctx, "git", "pull", remote, branch)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("git pull failed: %w\n%s", err, output)
-	}
-	return nil
+	return strings.TrimSpace(string(remoteOutput)), branch, nil
 }
 
 // gitPush pushes to the current branch's upstream
@@ -460,6 +527,28 @@ func gitPush(ctx context.Context) error {
 	return nil
 }
 
+// jsonlCurrent reports whether auto-flush has already written every change
+// to jsonlPath, so a flush would rewrite the same content (e.g. from the
+// pre-commit hook). Only direct mode can tell; the daemon may still have a
+// debounced flush pending.
+func jsonlCurrent(ctx context.Context, jsonlPath string) bool {
+	if daemonClient != nil || !autoFlushEnabled {
+		return false
+	}
+	if _, err := os.Stat(jsonlPath); err != nil {
+		return false
+	}
+	if err := ensureStoreActive(); err != nil {
+		return false
+	}
+	dirtyIDs, err := store.GetDirtyIssues(ctx)
+	if err != nil {
+		debug.Logf("failed to read dirty issues: %v", err)
+		return false
+	}
+	return len(dirtyIDs) == 0
+}
+
 // exportToJSONL exports the database to JSONL format
 func exportToJSONL(ctx context.Context, jsonlPath string) error {
 	// If daemon is running, use RPC
@@ -484,7 +573,7 @@ func exportToJSONL(ctx context.Context, jsonlPath string) error {
 	}
 
 	// Get all issues
-	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 	if err != nil {
 		return fmt.Errorf("failed to get issues: %w", err)
 	}
@@ -512,11 +601,6 @@ func exportToJSONL(ctx context.Context, jsonlPath string) error {
 		}
 	}
 
-	// Sort by ID for consistent output
-	sort.Slice(issues, func(i, j int) bool {
-		return issues[i].ID < issues[j].ID
-	})
-
 	// Populate dependencies for all issues (avoid N+1)
 	allDeps, err := store.GetAllDependencyRecords(ctx)
 	if err != nil {
@@ -544,41 +628,16 @@ func exportToJSONL(ctx context.Context, jsonlPath string) error {
 		issue.Comments = comments
 	}
 
-	// Create temp file for atomic write
-	dir := filepath.Dir(jsonlPath)
-	base := filepath.Base(jsonlPath)
-	tempFile, err := os.CreateTemp(dir, base+".tmp.*")
-	if err != nil {
-		return fmt.Errorf("failed to create temp file: %w", err)
-	}
-	tempPath := tempFile.Name()
-	defer func() {
-		_ = tempFile.Close()
-		_ = os.Remove(tempPath)
-	}()
+	utils.SortIssuesForExport(issues)
 
-	// Write JSONL
-	encoder := json.NewEncoder(tempFile)
-	exportedIDs := make([]string, 0, len(issues))
-	for _, issue := range issues {
-		if err := encoder.Encode(issue); err != nil {
-			return fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
-		}
-		exportedIDs = append(exportedIDs, issue.ID)
-	}
-
-	// Close temp file before rename
-	_ = tempFile.Close()
-
-	// Atomic replace
-	if err := os.Rename(tempPath, jsonlPath); err != nil {
-		return fmt.Errorf("failed to replace JSONL file: %w", err)
-	}
-
-	// Set appropriate file permissions (0600: rw-------)
-	if err := os.Chmod(jsonlPath, 0600); err != nil {
-		// Non-fatal warning
-		fmt.Fprintf(os.Stderr, "Warning: failed to set file permissions: %v\n", err)
+	// Write to a temp file and rename it into place (0600: rw-------)
+	var exportedIDs []string
+	if err := writeFileAtomic(jsonlPath, 0600, func(w io.Writer) error {
+		var err error
+		exportedIDs, err = encodeIssuesJSONL(w, issues)
+		return err
+	}); err != nil {
+		return fmt.Errorf("failed to write JSONL file: %w", err)
 	}
 
 	// Clear dirty flags for exported issues
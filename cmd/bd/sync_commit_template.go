@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// syncCommitTemplateKey is the config key for the message bd sync commits
+// with when no --message is given
+const syncCommitTemplateKey = "sync_commit_template"
+
+// defaultSyncCommitTemplate reproduces the historical auto-commit message
+const defaultSyncCommitTemplate = "bd sync: {date}"
+
+// syncCommitPlaceholders are the placeholders a commit template may use
+var syncCommitPlaceholders = map[string]bool{
+	"count":    true, // Issues added or modified
+	"added":    true,
+	"modified": true,
+	"closed":   true, // Issues newly closed
+	"date":     true,
+}
+
+var syncCommitPlaceholderRe = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// syncCommitStats summarizes the JSONL changes being committed
+type syncCommitStats struct {
+	Added    int
+	Modified int
+	Closed   int
+}
+
+// validateSyncCommitTemplate rejects templates with unknown placeholders or
+// stray braces
+func validateSyncCommitTemplate(template string) error {
+	if strings.TrimSpace(template) == "" {
+		return fmt.Errorf("commit template cannot be empty")
+	}
+	for _, match := range syncCommitPlaceholderRe.FindAllStringSubmatch(template, -1) {
+		if !syncCommitPlaceholders[match[1]] {
+			return fmt.Errorf("unknown placeholder {%s} in commit template (valid: {count}, {added}, {modified}, {closed}, {date})", match[1])
+		}
+	}
+	if rest := syncCommitPlaceholderRe.ReplaceAllString(template, ""); strings.ContainsAny(rest, "{}") {
+		return fmt.Errorf("unbalanced brace in commit template %q", template)
+	}
+	return nil
+}
+
+// renderSyncCommitTemplate substitutes the placeholders in a template that
+// passed validateSyncCommitTemplate
+func renderSyncCommitTemplate(template string, stats syncCommitStats, now time.Time) string {
+	return syncCommitPlaceholderRe.ReplaceAllStringFunc(template, func(placeholder string) string {
+		switch strings.Trim(placeholder, "{}") {
+		case "count":
+			return strconv.Itoa(stats.Added + stats.Modified)
+		case "added":
+			return strconv.Itoa(stats.Added)
+		case "modified":
+			return strconv.Itoa(stats.Modified)
+		case "closed":
+			return strconv.Itoa(stats.Closed)
+		case "date":
+			return now.Format("2006-01-02 15:04:05")
+		}
+		return placeholder
+	})
+}
+
+// syncCommitMessage builds the auto-commit message for the staged JSONL at
+// filePath from the configured template. A missing or invalid template
+// falls back to the default.
+func syncCommitMessage(ctx context.Context, filePath string) string {
+	template := defaultSyncCommitTemplate
+	if err := ensureStoreActive(); err == nil && store != nil {
+		if configured, err := store.GetConfig(ctx, syncCommitTemplateKey); err == nil && configured != "" {
+			if err := validateSyncCommitTemplate(configured); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: ignoring %s: %v\n", syncCommitTemplateKey, err)
+			} else {
+				template = configured
+			}
+		}
+	}
+
+	var stats syncCommitStats
+	if template != defaultSyncCommitTemplate {
+		stats = jsonlCommitStats(ctx, filePath)
+	}
+	return renderSyncCommitTemplate(template, stats, time.Now())
+}
+
+// jsonlCommitStats compares the JSONL at filePath with its version in HEAD.
+// Counts are zero if either version can't be read.
+func jsonlCommitStats(ctx context.Context, filePath string) syncCommitStats {
+	var stats syncCommitStats
+	current, err := os.ReadFile(filePath) // #nosec G304 - path from findJSONLPath
+	if err != nil {
+		return stats
+	}
+	var previous []byte
+	output, err := exec.CommandContext(ctx, "git", "ls-tree", "HEAD", "--", filePath).Output()
+	if err == nil {
+		// <mode> blob <object>\t<path>
+		if fields := strings.Fields(string(output)); len(fields) >= 3 {
+			if previous, err = exec.CommandContext(ctx, "git", "cat-file", "blob", fields[2]).Output(); err != nil {
+				return stats
+			}
+		}
+	}
+	return diffJSONLStats(previous, current)
+}
+
+// diffJSONLStats counts the issues added, modified and newly closed going
+// from the previous JSONL to the current one
+func diffJSONLStats(previous, current []byte) syncCommitStats {
+	var stats syncCommitStats
+	before, err := parseJSONLLines(previous)
+	if err != nil {
+		return stats
+	}
+	after, err := parseJSONLLines(current)
+	if err != nil {
+		return stats
+	}
+	for id, line := range after {
+		old, existed := before[id]
+		switch {
+		case !existed:
+			stats.Added++
+		case !bytes.Equal(old.raw, line.raw):
+			stats.Modified++
+		default:
+			continue
+		}
+		if line.issue.Status == types.StatusClosed && (!existed || old.issue.Status != types.StatusClosed) {
+			stats.Closed++
+		}
+	}
+	return stats
+}
+
+// jsonlLine is one issue of a JSONL export: its compacted line, and the
+// decoded issue
+type jsonlLine struct {
+	raw   []byte
+	issue types.Issue
+}
+
+// parseJSONLLines reads a JSONL export into its lines keyed by issue ID
+func parseJSONLLines(data []byte) (map[string]jsonlLine, error) {
+	lines := make(map[string]jsonlLine)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var parsed jsonlLine
+		if err := json.Unmarshal(line, &parsed.issue); err != nil {
+			return nil, err
+		}
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, line); err != nil {
+			return nil, err
+		}
+		parsed.raw = compacted.Bytes()
+		lines[parsed.issue.ID] = parsed
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestValidateSyncCommitTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		wantErr  bool
+	}{
+		{defaultSyncCommitTemplate, false},
+		{"PROJ-1: bd sync {count} issues (+{added} ~{modified}, {closed} closed) {date}", false},
+		{"no placeholders", false},
+		{"", true},
+		{"bd sync: {dat}", true},
+		{"bd sync: {}", true},
+		{"bd sync: {count", true},
+		{"bd sync: count}", true},
+	}
+	for _, tt := range tests {
+		err := validateSyncCommitTemplate(tt.template)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateSyncCommitTemplate(%q) error = %v, wantErr %v", tt.template, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRenderSyncCommitTemplate(t *testing.T) {
+	now := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	stats := syncCommitStats{Added: 2, Modified: 3, Closed: 1}
+
+	if got, want := renderSyncCommitTemplate(defaultSyncCommitTemplate, stats, now), "bd sync: 2025-03-04 05:06:07"; got != want {
+		t.Errorf("default template = %q, want %q", got, want)
+	}
+	got := renderSyncCommitTemplate("PROJ-1 {count} (+{added} ~{modified} x{closed})", stats, now)
+	if want := "PROJ-1 5 (+2 ~3 x1)"; got != want {
+		t.Errorf("custom template = %q, want %q", got, want)
+	}
+}
+
+func TestDiffJSONLStats(t *testing.T) {
+	closed := mergeTestIssue("bd-2", "Closing", 5)
+	closed.Status = types.StatusClosed
+	previous := toJSONL(t, mergeTestIssue("bd-1", "Unchanged", 0), mergeTestIssue("bd-2", "Closing", 0),
+		mergeTestIssue("bd-3", "Deleted", 0))
+	current := toJSONL(t, mergeTestIssue("bd-1", "Unchanged", 0), closed, mergeTestIssue("bd-4", "New", 5))
+
+	stats := diffJSONLStats(previous, current)
+	if want := (syncCommitStats{Added: 1, Modified: 1, Closed: 1}); stats != want {
+		t.Errorf("diffJSONLStats = %+v, want %+v", stats, want)
+	}
+
+	// Everything is new when there is no previous version
+	if stats := diffJSONLStats(nil, current); stats.Added != 3 || stats.Closed != 1 {
+		t.Errorf("diffJSONLStats(nil) = %+v, want 3 added, 1 closed", stats)
+	}
+}
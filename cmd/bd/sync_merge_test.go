@@ -185,6 +185,56 @@ func TestDBNeedsExport_CountMismatch(t *testing.T) {
 	}
 }
 
+// TestDBNeedsExport_CorruptedJSONL verifies dbNeedsExport reports a JSONL with
+// malformed lines as needing export instead of failing
+func TestDBNeedsExport_CorruptedJSONL(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "beads.db")
+	jsonlPath := filepath.Join(tmpDir, "beads.jsonl")
+
+	store := setupTestStore(t, dbPath)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Test Issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeBug,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	if err := exportToJSONLWithStore(ctx, store, jsonlPath); err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+
+	// Append a torn line, so the valid-line count still matches the DB
+	f, err := os.OpenFile(jsonlPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("Failed to open JSONL: %v", err)
+	}
+	if _, err := f.WriteString(`{"id":"bd-torn","title":`); err != nil {
+		t.Fatalf("Failed to append to JSONL: %v", err)
+	}
+	f.Close()
+
+	time.Sleep(10 * time.Millisecond)
+	now := time.Now().Add(1 * time.Hour)
+	if err := os.Chtimes(jsonlPath, now, now); err != nil {
+		t.Fatalf("Failed to touch JSONL: %v", err)
+	}
+
+	needsExport, err := dbNeedsExport(ctx, store, jsonlPath)
+	if err != nil {
+		t.Fatalf("dbNeedsExport failed: %v", err)
+	}
+	if !needsExport {
+		t.Errorf("Expected needsExport=true (corrupted JSONL), got false")
+	}
+}
+
 // TestDBNeedsExport_NoJSONL verifies dbNeedsExport returns true when JSONL doesn't exist
 func TestDBNeedsExport_NoJSONL(t *testing.T) {
 	tmpDir := t.TempDir()
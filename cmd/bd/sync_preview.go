@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// syncPreview is what bd sync --dry-run found: what the real sync would
+// commit, pull and push, and anything that would stop it
+type syncPreview struct {
+	Branch          string   `json:"branch"`
+	Upstream        string   `json:"upstream,omitempty"` // Tracked remote branch, e.g. origin/main
+	Ahead           int      `json:"ahead"`              // Local commits the upstream lacks, as of the last fetch
+	Behind          int      `json:"behind"`             // Upstream commits not yet pulled, as of the last fetch
+	PendingExport   int      `json:"pending_export"`     // Changed issues not yet written to the JSONL
+	HasChanges      bool     `json:"has_changes"`        // JSONL or templates differ from HEAD
+	WouldCommit     bool     `json:"would_commit"`
+	CommitMessage   string   `json:"commit_message,omitempty"`
+	Pull            string   `json:"pull"` // merge, rebase, none or skipped
+	Push            string   `json:"push"` // fast-forward, after-pull, rejected, unknown, none or skipped
+	UnmergedPaths   []string `json:"unmerged_paths,omitempty"`
+	MergeInProgress bool     `json:"merge_in_progress,omitempty"` // Unmerged paths or MERGE_HEAD present
+	Blockers        []string `json:"blockers,omitempty"`          // Why the real sync would stop
+}
+
+// previewSync works out what bd sync would do without changing anything:
+// pending changes are counted rather than exported, and the upstream is
+// compared as last fetched rather than fetched again
+func previewSync(ctx context.Context, jsonlPath, message string, noPull, noPush bool) (*syncPreview, error) {
+	// git status may refresh the index; only read it
+	if err := os.Setenv("GIT_OPTIONAL_LOCKS", "0"); err != nil {
+		return nil, err
+	}
+
+	branch, err := getCurrentBranch(ctx)
+	if err != nil {
+		// No commits yet, so HEAD only names the branch
+		output, symErr := exec.CommandContext(ctx, "git", "symbolic-ref", "--short", "HEAD").Output()
+		if symErr != nil {
+			return nil, err
+		}
+		branch = strings.TrimSpace(string(output))
+	}
+	preview := &syncPreview{Branch: branch}
+
+	if preview.UnmergedPaths, err = gitUnmergedPaths(ctx); err != nil {
+		return nil, err
+	}
+	if preview.MergeInProgress, err = gitHasUnmergedPaths(); err != nil {
+		return nil, err
+	}
+	if preview.MergeInProgress {
+		preview.Blockers = append(preview.Blockers, "unmerged paths or merge in progress; resolve them first")
+	}
+	hasUpstream := gitHasUpstream()
+	if !noPull && !hasUpstream {
+		preview.Blockers = append(preview.Blockers, "no upstream configured for "+branch+"; run git push -u origin "+branch)
+	}
+
+	if err := ensureStoreActive(); err == nil && store != nil {
+		dirtyIDs, err := store.GetDirtyIssues(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pending changes: %w", err)
+		}
+		preview.PendingExport = len(dirtyIDs)
+	}
+	if preview.HasChanges, err = gitHasChanges(ctx, jsonlPath); err != nil {
+		return nil, err
+	}
+	if templatesDir := syncTemplatesDir(jsonlPath); !preview.HasChanges && templatesDir != "" {
+		if preview.HasChanges, err = gitHasChanges(ctx, templatesDir); err != nil {
+			return nil, err
+		}
+	}
+	preview.WouldCommit = preview.HasChanges || preview.PendingExport > 0
+	if preview.WouldCommit {
+		preview.CommitMessage = message
+		if message == "" {
+			preview.CommitMessage = syncCommitMessage(ctx, jsonlPath)
+		}
+	}
+
+	behindKnown := false
+	if hasUpstream {
+		preview.Upstream, preview.Ahead, preview.Behind, err = gitUpstreamDivergence(ctx)
+		behindKnown = err == nil
+	}
+	switch {
+	case noPull:
+		preview.Pull = "skipped"
+	case behindKnown && preview.Behind == 0:
+		preview.Pull = "none"
+	case gitConfigBool(ctx, "pull.rebase"):
+		preview.Pull = "rebase"
+	default:
+		preview.Pull = "merge"
+	}
+	switch {
+	case noPush:
+		preview.Push = "skipped"
+	case !preview.WouldCommit:
+		preview.Push = "none" // bd sync only pushes what it commits
+	case !hasUpstream || !behindKnown:
+		preview.Push = "unknown"
+	case preview.Behind == 0:
+		preview.Push = "fast-forward"
+	case noPull:
+		preview.Push = "rejected"
+	default:
+		preview.Push = "after-pull"
+	}
+	return preview, nil
+}
+
+// runSyncDryRun is bd sync --dry-run: it lists the closures the commit scan
+// would make and previews the git steps, exiting on failure
+func runSyncDryRun(ctx context.Context, jsonlPath, message string, noPull, noPush bool) {
+	if err := ensureStoreActive(); err == nil && store != nil {
+		actions, err := scanCommitsForClosures(ctx, store, true)
+		if !jsonOutput {
+			printCommitCloseActions(actions, true)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: commit scan failed: %v\n", err)
+		}
+	}
+
+	preview, err := previewSync(ctx, jsonlPath, message, noPull, noPush)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if jsonOutput {
+		outputJSON(preview)
+		return
+	}
+	printSyncPreview(preview)
+	fmt.Println("\n✓ Dry run complete (no changes made)")
+}
+
+// printSyncPreview reports a preview as bd sync's steps
+func printSyncPreview(p *syncPreview) {
+	if p.Upstream != "" {
+		fmt.Printf("→ [DRY RUN] On %s tracking %s: %d ahead, %d behind (as of the last fetch)\n", p.Branch, p.Upstream, p.Ahead, p.Behind)
+	} else {
+		fmt.Printf("→ [DRY RUN] On %s, no upstream\n", p.Branch)
+	}
+	if len(p.UnmergedPaths) > 0 {
+		fmt.Printf("→ [DRY RUN] Unmerged paths: %s\n", strings.Join(p.UnmergedPaths, ", "))
+	}
+	if p.PendingExport > 0 {
+		fmt.Printf("→ [DRY RUN] Would export %d changed issue(s) to JSONL\n", p.PendingExport)
+	}
+	if p.WouldCommit {
+		fmt.Printf("→ [DRY RUN] Would commit changes to git: %q\n", p.CommitMessage)
+	} else {
+		fmt.Println("→ No changes to commit")
+	}
+	switch p.Pull {
+	case "merge", "rebase":
+		fmt.Printf("→ [DRY RUN] Would pull from remote (%s)\n", p.Pull)
+	case "none":
+		fmt.Println("→ [DRY RUN] Would pull from remote (nothing new as of the last fetch)")
+	}
+	switch p.Push {
+	case "fast-forward":
+		fmt.Println("→ [DRY RUN] Would push to remote (fast-forward)")
+	case "after-pull":
+		fmt.Printf("→ [DRY RUN] Would push to remote after the %s brings in %d commit(s)\n", p.Pull, p.Behind)
+	case "rejected":
+		fmt.Printf("→ [DRY RUN] Push would be rejected: the remote has %d commit(s) and --no-pull skips them\n", p.Behind)
+	case "unknown":
+		if p.Upstream == "" {
+			fmt.Println("→ [DRY RUN] Would push to remote (no upstream to tell if it fast-forwards)")
+		} else {
+			fmt.Println("→ [DRY RUN] Would push to remote (upstream not fetched yet, can't tell if it fast-forwards)")
+		}
+	}
+	for _, blocker := range p.Blockers {
+		fmt.Printf("✗ bd sync would stop: %s\n", blocker)
+	}
+}
+
+// gitUpstreamDivergence compares HEAD with its upstream's remote-tracking
+// branch, without fetching
+func gitUpstreamDivergence(ctx context.Context) (upstream string, ahead, behind int, err error) {
+	output, err := exec.CommandContext(ctx, "git", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}").Output()
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("failed to resolve upstream: %w", err)
+	}
+	upstream = strings.TrimSpace(string(output))
+	output, err = exec.CommandContext(ctx, "git", "rev-list", "--left-right", "--count", "HEAD...@{u}").Output()
+	if err != nil {
+		return upstream, 0, 0, fmt.Errorf("failed to compare with %s: %w", upstream, err)
+	}
+	counts := strings.Fields(string(output))
+	if len(counts) != 2 {
+		return upstream, 0, 0, fmt.Errorf("unexpected git rev-list output %q", output)
+	}
+	ahead, _ = strconv.Atoi(counts[0])
+	behind, _ = strconv.Atoi(counts[1])
+	return upstream, ahead, behind, nil
+}
+
+// gitConfigBool reads a boolean git config value, false if unset. Values
+// like pull.rebase=merges count as true.
+func gitConfigBool(ctx context.Context, key string) bool {
+	output, err := exec.CommandContext(ctx, "git", "config", "--get", key).Output()
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(string(output))) {
+	case "", "false", "no", "off", "0":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestPreviewSync(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("GIT_OPTIONAL_LOCKS", "") // Restored after previewSync sets it
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	remoteDir := filepath.Join(tmpDir, "remote.git")
+	runGitCmd(t, tmpDir, "init", "-q", "--bare", "-b", "main", remoteDir)
+
+	clone := func(name string) string {
+		dir := filepath.Join(tmpDir, name)
+		runGitCmd(t, tmpDir, "clone", "-q", remoteDir, dir)
+		runGitCmd(t, dir, "config", "user.email", "test@test.com")
+		runGitCmd(t, dir, "config", "user.name", "Test User")
+		runGitCmd(t, dir, "checkout", "-q", "-B", "main")
+		return dir
+	}
+	ours := clone("ours")
+	if err := os.WriteFile(filepath.Join(ours, ".gitignore"), []byte(".beads/beads.db*\n"), 0644); err != nil {
+		t.Fatalf("failed to write .gitignore: %v", err)
+	}
+	runGitCmd(t, ours, "add", ".gitignore")
+	runGitCmd(t, ours, "commit", "-q", "-m", "initial")
+	runGitCmd(t, ours, "push", "-q", "-u", "origin", "main")
+
+	theirs := clone("theirs")
+	runGitCmd(t, theirs, "pull", "-q", "origin", "main")
+	if err := os.WriteFile(filepath.Join(theirs, "theirs.txt"), []byte("theirs\n"), 0644); err != nil {
+		t.Fatalf("failed to write theirs.txt: %v", err)
+	}
+	runGitCmd(t, theirs, "add", "theirs.txt")
+	runGitCmd(t, theirs, "commit", "-q", "-m", "theirs")
+	runGitCmd(t, theirs, "push", "-q", "origin", "main")
+	runGitCmd(t, ours, "fetch", "-q")
+
+	// One issue changed since the last export
+	testStore := newTestStore(t, filepath.Join(ours, ".beads", "beads.db"))
+	issue := &types.Issue{Title: "Pending", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := testStore.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	oldStore := store
+	store = testStore
+	storeMutex.Lock()
+	storeActive = true
+	storeMutex.Unlock()
+	defer func() {
+		store = oldStore
+		storeMutex.Lock()
+		storeActive = false
+		storeMutex.Unlock()
+	}()
+
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(ours)
+
+	gitState := func() string {
+		t.Helper()
+		out, err := exec.Command("git", "for-each-ref", "--format=%(refname) %(objectname)").Output()
+		if err != nil {
+			t.Fatalf("git for-each-ref failed: %v", err)
+		}
+		status, err := exec.Command("git", "status", "--porcelain").Output()
+		if err != nil {
+			t.Fatalf("git status failed: %v", err)
+		}
+		return string(out) + string(status)
+	}
+	before := gitState()
+
+	jsonlPath := filepath.Join(ours, ".beads", "issues.jsonl")
+	preview, err := previewSync(ctx, jsonlPath, "sync issues", false, false)
+	if err != nil {
+		t.Fatalf("previewSync failed: %v", err)
+	}
+	if preview.Branch != "main" || preview.Upstream != "origin/main" || preview.Ahead != 0 || preview.Behind != 1 {
+		t.Errorf("branch state = %+v, want main tracking origin/main, 0 ahead, 1 behind", preview)
+	}
+	if preview.PendingExport != 1 || preview.HasChanges || !preview.WouldCommit || preview.CommitMessage != "sync issues" {
+		t.Errorf("commit preview = %+v, want one pending export committed as \"sync issues\"", preview)
+	}
+	if preview.Pull != "merge" || preview.Push != "after-pull" {
+		t.Errorf("pull, push = %q, %q; want merge, after-pull", preview.Pull, preview.Push)
+	}
+	if len(preview.UnmergedPaths) > 0 || preview.MergeInProgress || len(preview.Blockers) > 0 {
+		t.Errorf("unexpected blockers: %+v", preview)
+	}
+
+	// Skipping the pull leaves the remote commit in the way of the push
+	preview, err = previewSync(ctx, jsonlPath, "sync issues", true, false)
+	if err != nil {
+		t.Fatalf("previewSync failed: %v", err)
+	}
+	if preview.Pull != "skipped" || preview.Push != "rejected" {
+		t.Errorf("with --no-pull: pull, push = %q, %q; want skipped, rejected", preview.Pull, preview.Push)
+	}
+
+	if after := gitState(); after != before {
+		t.Errorf("previewSync changed git state:\nbefore:\n%s\nafter:\n%s", before, after)
+	}
+}
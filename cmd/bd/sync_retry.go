@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config keys bounding how bd sync retries transient git failures
+const (
+	syncPushRetriesConfigKey = "sync_push_retries"
+	syncPushBackoffConfigKey = "sync_push_backoff_ms"
+)
+
+// Defaults used when the retry config keys are unset or invalid
+const (
+	defaultSyncPushRetries = 3
+	defaultSyncPushBackoff = 500 * time.Millisecond
+)
+
+// syncRetryPolicy is how many times a failed pull or push is retried, and
+// the delay before the first retry. The delay doubles for each retry after.
+type syncRetryPolicy struct {
+	Retries int
+	Backoff time.Duration
+}
+
+// delay returns how long to wait before the given retry (0-based)
+func (p syncRetryPolicy) delay(retry int) time.Duration {
+	return p.Backoff << uint(retry)
+}
+
+// syncRetryPolicyFromConfig reads sync_push_retries and sync_push_backoff_ms,
+// falling back to the defaults for unset or invalid values
+func syncRetryPolicyFromConfig(ctx context.Context) syncRetryPolicy {
+	policy := syncRetryPolicy{Retries: defaultSyncPushRetries, Backoff: defaultSyncPushBackoff}
+	if err := ensureStoreActive(); err != nil || store == nil {
+		return policy
+	}
+	readInt := func(key string) (int, bool) {
+		value, err := store.GetConfig(ctx, key)
+		if err != nil || value == "" {
+			return 0, false
+		}
+		if err := validateSyncRetryConfig(key, value); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring %s: %v\n", key, err)
+			return 0, false
+		}
+		n, _ := strconv.Atoi(value)
+		return n, true
+	}
+	if n, ok := readInt(syncPushRetriesConfigKey); ok {
+		policy.Retries = n
+	}
+	if n, ok := readInt(syncPushBackoffConfigKey); ok {
+		policy.Backoff = time.Duration(n) * time.Millisecond
+	}
+	return policy
+}
+
+// validateSyncRetryConfig checks a sync_push_retries or sync_push_backoff_ms
+// value
+func validateSyncRetryConfig(key, value string) error {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return fmt.Errorf("%s must be a non-negative integer, got %q", key, value)
+	}
+	if key == syncPushRetriesConfigKey && n > 10 {
+		return fmt.Errorf("%s must be at most 10, got %d", key, n)
+	}
+	return nil
+}
+
+// transientGitErrors are git output fragments for failures that may succeed
+// on retry: a push that lost a race with another pusher, or a flaky network
+var transientGitErrors = []string{
+	"[rejected]",
+	"fetch first",
+	"non-fast-forward",
+	"cannot lock ref",
+	"failed to update ref",
+	"incorrect old value provided",
+	"connection reset",
+	"connection refused",
+	"timed out",
+	"could not resolve host",
+	"the remote end hung up",
+	"early eof",
+	"rpc failed",
+	"temporarily unavailable",
+	"502 bad gateway",
+	"503 service unavailable",
+}
+
+// permanentGitErrors are failures retrying can't fix. They take precedence
+// over transientGitErrors since git often reports both (e.g. a declined
+// push is also [rejected]).
+var permanentGitErrors = []string{
+	"authentication failed",
+	"permission denied",
+	"could not read username",
+	"could not read password",
+	"invalid username or password",
+	"access denied",
+	"repository not found",
+	"host key verification failed",
+	"hook declined",
+	"protected branch",
+	"conflict",
+}
+
+// isTransientGitError reports whether a failed git network operation is
+// worth retrying
+func isTransientGitError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, s := range permanentGitErrors {
+		if strings.Contains(msg, s) {
+			return false
+		}
+	}
+	for _, s := range transientGitErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// gitPullWithRetry runs gitPull, retrying transient failures with backoff
+func gitPullWithRetry(ctx context.Context, policy syncRetryPolicy) error {
+	for retry := 0; ; retry++ {
+		err := gitPull(ctx)
+		if err == nil || retry >= policy.Retries || !isTransientGitError(err) {
+			return err
+		}
+		delay := policy.delay(retry)
+		fmt.Fprintf(os.Stderr, "→ Pull failed, retrying in %v (%d/%d)...\n", delay, retry+1, policy.Retries)
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// gitPushWithRetry runs gitPush, retrying transient failures with backoff.
+// Before each retry the local commits are rebased onto the new remote head,
+// since the usual cause is another push landing first. Reports whether a
+// rebase pulled in remote changes, in which case the JSONL needs
+// re-importing.
+func gitPushWithRetry(ctx context.Context, jsonlPath string, policy syncRetryPolicy) (rebased bool, err error) {
+	for retry := 0; ; retry++ {
+		err := gitPush(ctx)
+		if err == nil || retry >= policy.Retries || !isTransientGitError(err) {
+			return rebased, err
+		}
+		delay := policy.delay(retry)
+		fmt.Fprintf(os.Stderr, "→ Push failed, retrying in %v (%d/%d)...\n", delay, retry+1, policy.Retries)
+		if err := sleepContext(ctx, delay); err != nil {
+			return rebased, err
+		}
+		if err := gitPullRebase(ctx, jsonlPath); err != nil {
+			return rebased, err
+		}
+		rebased = true
+	}
+}
+
+// gitPullRebase fetches the current branch's upstream and rebases local
+// commits onto it. JSONL conflicts are merged with
+// resolveJSONLMergeConflict; any other conflict aborts the rebase.
+func gitPullRebase(ctx context.Context, jsonlPath string) error {
+	remote, branch, err := gitCurrentRemoteBranch(ctx)
+	if err != nil {
+		return err
+	}
+	output, err := exec.CommandContext(ctx, "git", "pull", "--rebase", remote, branch).CombinedOutput()
+	if err == nil {
+		return nil
+	}
+	pullErr := fmt.Errorf("git pull --rebase failed: %w\n%s", err, output)
+	if !gitRebaseInProgress(ctx) {
+		return pullErr
+	}
+	resolved, mergeErr := resolveJSONLMergeConflict(ctx, jsonlPath)
+	if resolved && !gitRebaseInProgress(ctx) {
+		return nil
+	}
+	if mergeErr != nil {
+		pullErr = mergeErr
+	}
+	if abortOutput, err := exec.CommandContext(ctx, "git", "rebase", "--abort").CombinedOutput(); err != nil {
+		return fmt.Errorf("%v (and git rebase --abort failed: %v\n%s)", pullErr, err, abortOutput)
+	}
+	return pullErr
+}
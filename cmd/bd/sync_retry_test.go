@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIsTransientGitError(t *testing.T) {
+	tests := []struct {
+		output string
+		want   bool
+	}{
+		{" ! [rejected]        main -> main (fetch first)\nerror: failed to push some refs", true},
+		{" ! [rejected]        main -> main (non-fast-forward)", true},
+		{"fatal: unable to access 'https://example.com/r.git/': Could not resolve host: example.com", true},
+		{"fatal: the remote end hung up unexpectedly", true},
+		{"fatal: Authentication failed for 'https://example.com/r.git/'", false},
+		{"git@example.com: Permission denied (publickey).", false},
+		{" ! [remote rejected] main -> main (pre-receive hook declined)", false},
+		{"CONFLICT (content): Merge conflict in .beads/issues.jsonl", false},
+		{"fatal: not a git repository", false},
+	}
+	for _, tt := range tests {
+		if got := isTransientGitError(errors.New(tt.output)); got != tt.want {
+			t.Errorf("isTransientGitError(%q) = %v, want %v", tt.output, got, tt.want)
+		}
+	}
+	if isTransientGitError(nil) {
+		t.Error("nil error reported as transient")
+	}
+}
+
+func TestSyncRetryPolicyDelay(t *testing.T) {
+	policy := syncRetryPolicy{Retries: 3, Backoff: 100 * time.Millisecond}
+	for retry, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond} {
+		if got := policy.delay(retry); got != want {
+			t.Errorf("delay(%d) = %v, want %v", retry, got, want)
+		}
+	}
+}
+
+func TestValidateSyncRetryConfig(t *testing.T) {
+	for _, value := range []string{"0", "3", "10"} {
+		if err := validateSyncRetryConfig(syncPushRetriesConfigKey, value); err != nil {
+			t.Errorf("retries %q rejected: %v", value, err)
+		}
+	}
+	for _, value := range []string{"-1", "11", "three", ""} {
+		if err := validateSyncRetryConfig(syncPushRetriesConfigKey, value); err == nil {
+			t.Errorf("retries %q accepted", value)
+		}
+	}
+	if err := validateSyncRetryConfig(syncPushBackoffConfigKey, "2500"); err != nil {
+		t.Errorf("backoff rejected: %v", err)
+	}
+}
+
+func TestGitPushWithRetry_RebasesOntoRacingPush(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	remoteDir := filepath.Join(tmpDir, "remote.git")
+	runGitCmd(t, tmpDir, "init", "-q", "--bare", "-b", "main", remoteDir)
+
+	clone := func(name string) string {
+		dir := filepath.Join(tmpDir, name)
+		runGitCmd(t, tmpDir, "clone", "-q", remoteDir, dir)
+		runGitCmd(t, dir, "config", "user.email", "test@test.com")
+		runGitCmd(t, dir, "config", "user.name", "Test User")
+		runGitCmd(t, dir, "checkout", "-q", "-B", "main")
+		return dir
+	}
+	commitFile := func(dir, name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		runGitCmd(t, dir, "add", name)
+		runGitCmd(t, dir, "commit", "-q", "-m", "add "+name)
+	}
+
+	ours := clone("ours")
+	commitFile(ours, "README", "base\n")
+	runGitCmd(t, ours, "push", "-q", "-u", "origin", "main")
+
+	theirs := clone("theirs")
+	runGitCmd(t, theirs, "pull", "-q", "origin", "main")
+	commitFile(theirs, "theirs.txt", "racing push\n")
+	runGitCmd(t, theirs, "push", "-q", "origin", "main")
+
+	commitFile(ours, "ours.txt", "our change\n")
+
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	os.Chdir(ours)
+
+	rebased, err := gitPushWithRetry(ctx, filepath.Join(ours, "issues.jsonl"), syncRetryPolicy{Retries: 2, Backoff: time.Millisecond})
+	if err != nil {
+		t.Fatalf("gitPushWithRetry failed: %v", err)
+	}
+	if !rebased {
+		t.Error("expected the push to be retried after a rebase")
+	}
+
+	log, err := exec.Command("git", "--git-dir", remoteDir, "log", "--format=%s", "main").Output()
+	if err != nil {
+		t.Fatalf("git log failed: %v", err)
+	}
+	if got := strings.Fields(strings.ReplaceAll(string(log), "add ", "")); strings.Join(got, ",") != "ours.txt,theirs.txt,README" {
+		t.Errorf("remote history = %v, want ours rebased on theirs", got)
+	}
+
+	// Without retries the rejection is returned as is
+	commitFile(theirs, "theirs2.txt", "another race\n")
+	runGitCmd(t, theirs, "pull", "-q", "--rebase", "origin", "main")
+	runGitCmd(t, theirs, "push", "-q", "origin", "main")
+	commitFile(ours, "ours2.txt", "another change\n")
+	if _, err := gitPushWithRetry(ctx, filepath.Join(ours, "issues.jsonl"), syncRetryPolicy{}); err == nil || !isTransientGitError(err) {
+		t.Errorf("expected the rejected push error, got %v", err)
+	}
+}
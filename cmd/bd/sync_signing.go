@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// syncSignCommitsKey is the config key that makes bd sync and the daemon
+// sign the commits they make
+const syncSignCommitsKey = "sync_sign_commits"
+
+// syncSigningKeyKey optionally names the key to sign with, overriding git's
+// user.signingkey: a GPG key ID, or for gpg.format=ssh a public key file or
+// literal key
+const syncSigningKeyKey = "sync_signing_key"
+
+// syncSigningFailures are fragments of git's output when it can't sign a
+// commit, for GPG, SSH and X.509 signing
+var syncSigningFailures = []string{
+	"gpg failed to sign",
+	"cannot run gpg",
+	"failed to write commit object",
+	"signing failed",
+	"no signing key",
+	"couldn't load public key",
+	"ssh-keygen",
+	"gpgsm",
+}
+
+// syncCommitSigningArgs returns the git commit flags for the configured
+// signing: nothing when sync_sign_commits is off, otherwise -S, or
+// --gpg-sign=<key> with sync_signing_key. git signs with whatever gpg.format
+// says, so the same flag covers GPG and SSH signing. gitArgs are passed
+// before "config" to read git config from the repository being committed in.
+//
+// SSH signing has no default key, so a missing one is reported here rather
+// than by git. An unreadable sync_sign_commits value is an error too: it's
+// better to fail the sync than to commit unsigned.
+func syncCommitSigningArgs(ctx context.Context, gitArgs ...string) ([]string, error) {
+	if err := ensureStoreActive(); err != nil || store == nil {
+		return nil, nil
+	}
+	value, err := store.GetConfig(ctx, syncSignCommitsKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", syncSignCommitsKey, err)
+	}
+	if sign, err := parseSyncSignCommits(value); err != nil || !sign {
+		return nil, err
+	}
+
+	key, err := store.GetConfig(ctx, syncSigningKeyKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", syncSigningKeyKey, err)
+	}
+	key = strings.TrimSpace(key)
+	if gitConfigValue(ctx, gitArgs, "gpg.format") == "ssh" && key == "" && gitConfigValue(ctx, gitArgs, "user.signingkey") == "" {
+		return nil, fmt.Errorf("%s is enabled with gpg.format=ssh, but there is no signing key (set %s or git config user.signingkey)",
+			syncSignCommitsKey, syncSigningKeyKey)
+	}
+	if key != "" {
+		return []string{"--gpg-sign=" + key}, nil
+	}
+	return []string{"-S"}, nil
+}
+
+// signingConfigArgs turns syncCommitSigningArgs' flags into git -c options,
+// for commands like rebase --continue that commit without taking -S
+func signingConfigArgs(signArgs []string) []string {
+	if len(signArgs) == 0 {
+		return nil
+	}
+	args := []string{"-c", "commit.gpgsign=true"}
+	if key, ok := strings.CutPrefix(signArgs[0], "--gpg-sign="); ok {
+		args = append(args, "-c", "user.signingkey="+key)
+	}
+	return args
+}
+
+// parseSyncSignCommits reads a sync_sign_commits value; unset means false
+func parseSyncSignCommits(value string) (bool, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return false, nil
+	}
+	sign, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q (expected true or false)", syncSignCommitsKey, value)
+	}
+	return sign, nil
+}
+
+// gitConfigValue returns a git config value, or "" if it isn't set
+func gitConfigValue(ctx context.Context, gitArgs []string, name string) string {
+	args := append(append([]string{}, gitArgs...), "config", "--get", name)
+	output, err := exec.CommandContext(ctx, "git", args...).Output() // #nosec G204 - args are fixed git config lookups
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// syncSigningError explains a git commit that failed because it couldn't be
+// signed, or returns nil if signing wasn't the problem. git doesn't fall
+// back to an unsigned commit, so nothing was committed.
+func syncSigningError(err error, output []byte, signArgs []string) error {
+	if len(signArgs) == 0 {
+		return nil
+	}
+	lower := strings.ToLower(string(output))
+	for _, failure := range syncSigningFailures {
+		if strings.Contains(lower, failure) {
+			return fmt.Errorf("git commit failed: could not sign the commit (%s is enabled; check the signing key and agent), nothing was committed: %w\n%s",
+				syncSignCommitsKey, err, output)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// fakeGPG stands in for gpg as gpg.program: it logs its arguments and
+// prints a dummy signature, or fails like gpg does without a secret key
+const fakeGPG = `#!/bin/sh
+echo "$@" >> "$FAKE_GPG_LOG"
+cat > /dev/null
+if [ -n "$FAKE_GPG_FAIL" ]; then
+	echo "gpg: signing failed: No secret key" >&2
+	exit 2
+fi
+echo "[GNUPG:] SIG_CREATED D 1 8 00 0 0" >&2
+echo "-----BEGIN PGP SIGNATURE-----"
+echo "fake"
+echo "-----END PGP SIGNATURE-----"
+`
+
+func TestGitCommit_SignsCommits(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake gpg is a shell script")
+	}
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+
+	testStore := newTestStore(t, filepath.Join(tmpDir, ".beads", "beads.db"))
+	store = testStore
+	storeMutex.Lock()
+	storeActive = true
+	storeMutex.Unlock()
+	defer func() {
+		storeMutex.Lock()
+		storeActive = false
+		storeMutex.Unlock()
+	}()
+
+	repo := filepath.Join(tmpDir, "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.Chdir(repo)
+	gpgPath := filepath.Join(tmpDir, "fake-gpg")
+	if err := os.WriteFile(gpgPath, []byte(fakeGPG), 0755); err != nil {
+		t.Fatal(err)
+	}
+	gpgLog := filepath.Join(tmpDir, "gpg.log")
+	t.Setenv("FAKE_GPG_LOG", gpgLog)
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@test.com"},
+		{"config", "user.name", "Test User"},
+		{"config", "gpg.program", gpgPath},
+		{"config", "commit.gpgsign", "false"},
+	} {
+		if err := exec.Command("git", args...).Run(); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+
+	// Off by default
+	if args, err := syncCommitSigningArgs(ctx); err != nil || len(args) != 0 {
+		t.Errorf("syncCommitSigningArgs() = %v, %v without config, want no flags", args, err)
+	}
+
+	if err := store.SetConfig(ctx, syncSignCommitsKey, "true"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if args, err := syncCommitSigningArgs(ctx); err != nil || strings.Join(args, " ") != "-S" {
+		t.Errorf("syncCommitSigningArgs() = %v, %v, want [-S]", args, err)
+	}
+	os.WriteFile("signed.txt", []byte("content"), 0644)
+	if err := gitCommit(ctx, "signed.txt", "signed commit"); err != nil {
+		t.Fatalf("gitCommit() error = %v", err)
+	}
+	commit, err := exec.Command("git", "cat-file", "-p", "HEAD").Output()
+	if err != nil {
+		t.Fatalf("git cat-file failed: %v", err)
+	}
+	if !strings.Contains(string(commit), "gpgsig -----BEGIN PGP SIGNATURE-----") {
+		t.Errorf("expected a signed commit, got:\n%s", commit)
+	}
+
+	// A configured key is passed through to the signer
+	if err := store.SetConfig(ctx, syncSigningKeyKey, "ABCD1234"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if args, _ := syncCommitSigningArgs(ctx); strings.Join(args, " ") != "--gpg-sign=ABCD1234" {
+		t.Errorf("syncCommitSigningArgs() = %v, want [--gpg-sign=ABCD1234]", args)
+	}
+	os.WriteFile("keyed.txt", []byte("content"), 0644)
+	if err := gitCommit(ctx, "keyed.txt", "keyed commit"); err != nil {
+		t.Fatalf("gitCommit() error = %v", err)
+	}
+	if log, _ := os.ReadFile(gpgLog); !strings.Contains(string(log), "ABCD1234") {
+		t.Errorf("expected the signer to get key ABCD1234, got %q", log)
+	}
+
+	// A signing failure is an error, not an unsigned commit
+	t.Setenv("FAKE_GPG_FAIL", "1")
+	head, _ := exec.Command("git", "rev-parse", "HEAD").Output()
+	os.WriteFile("unsigned.txt", []byte("content"), 0644)
+	err = gitCommit(ctx, "unsigned.txt", "should not commit")
+	if err == nil || !strings.Contains(err.Error(), "could not sign the commit") {
+		t.Errorf("expected a signing error, got %v", err)
+	}
+	if after, _ := exec.Command("git", "rev-parse", "HEAD").Output(); string(after) != string(head) {
+		t.Error("expected nothing to be committed when signing fails")
+	}
+
+	// SSH signing needs a key
+	if err := store.SetConfig(ctx, syncSigningKeyKey, ""); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	exec.Command("git", "config", "gpg.format", "ssh").Run()
+	exec.Command("git", "config", "user.signingkey", "").Run() // Shadow any global key
+	if _, err := syncCommitSigningArgs(ctx); err == nil || !strings.Contains(err.Error(), "no signing key") {
+		t.Errorf("expected a missing SSH key error, got %v", err)
+	}
+
+	if err := store.SetConfig(ctx, syncSignCommitsKey, "sometimes"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if _, err := syncCommitSigningArgs(ctx); err == nil {
+		t.Error("expected an invalid sync_sign_commits value to be an error")
+	}
+}
@@ -172,6 +172,38 @@ func TestGitCommit_Success(t *testing.T) {
 	}
 }
 
+func TestGitCommit_StagesTemplates(t *testing.T) {
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+
+	// Create a git repo
+	os.Chdir(tmpDir)
+	exec.Command("git", "init").Run()
+	exec.Command("git", "config", "user.email", "test@test.com").Run()
+	exec.Command("git", "config", "user.name", "Test User").Run()
+
+	// A JSONL and a custom template beside it
+	os.MkdirAll(filepath.Join(".beads", "templates"), 0755)
+	jsonlPath := filepath.Join(".beads", "issues.jsonl")
+	templatePath := filepath.Join(".beads", "templates", "incident.yaml")
+	os.WriteFile(jsonlPath, []byte(`{"id":"test-1"}`+"\n"), 0644)
+	os.WriteFile(templatePath, []byte("name: incident\n"), 0644)
+
+	if err := gitCommit(ctx, jsonlPath, "test commit"); err != nil {
+		t.Fatalf("gitCommit() error = %v", err)
+	}
+
+	hasChanges, err := gitHasChanges(ctx, templatePath)
+	if err != nil {
+		t.Fatalf("gitHasChanges() error = %v", err)
+	}
+	if hasChanges {
+		t.Error("expected the template to be committed with the JSONL")
+	}
+}
+
 func TestGitCommit_AutoMessage(t *testing.T) {
 	ctx := context.Background()
 	tmpDir := t.TempDir()
@@ -270,6 +302,43 @@ not valid json
 	}
 }
 
+func TestCountIssuesInJSONLLenient(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonlPath := filepath.Join(tmpDir, "mixed.jsonl")
+	// Line 5 is cut short, as by an interrupted write with no trailing newline
+	content := `{"id":"bd-1"}
+not valid json
+{"id":"bd-2"}
+
+{"id":"bd-3","title":"trunc`
+	os.WriteFile(jsonlPath, []byte(content), 0644)
+
+	count, badLines, err := countIssuesInJSONLLenient(jsonlPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("count = %d, want 2", count)
+	}
+	if len(badLines) != 2 || badLines[0] != 2 || badLines[1] != 5 {
+		t.Errorf("badLines = %v, want [2 5]", badLines)
+	}
+
+	if _, _, err := countIssuesInJSONLLenient(filepath.Join(tmpDir, "missing.jsonl")); !os.IsNotExist(err) {
+		t.Errorf("expected not-exist error for missing file, got %v", err)
+	}
+}
+
+func TestFormatLineNumbers(t *testing.T) {
+	if got := formatLineNumbers([]int{3, 7}); got != "3, 7" {
+		t.Errorf("formatLineNumbers = %q", got)
+	}
+	lines := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	if got := formatLineNumbers(lines); got != "1, 2, 3, 4, 5, 6, 7, 8, 9, 10, and 2 more" {
+		t.Errorf("formatLineNumbers = %q", got)
+	}
+}
+
 func TestGetCurrentBranch(t *testing.T) {
 	ctx := context.Background()
 	tmpDir := t.TempDir()
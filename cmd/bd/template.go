@@ -3,12 +3,15 @@ package main
 import (
 	"embed"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
 	"gopkg.in/yaml.v3"
 )
 
@@ -32,12 +35,17 @@ var templateCmd = &cobra.Command{
 	Long: `Manage issue templates for streamlined issue creation.
 
 Templates can be built-in (epic, bug, feature) or custom templates
-stored in .beads/templates/ directory.`,
+stored in .beads/templates/ directory. Custom templates are committed by
+bd sync along with the JSONL, so the whole team gets them.
+
+Descriptions, design notes and acceptance criteria may use {{title}} and
+{{date}} (YYYY-MM-DD), filled in when an issue is created from the template.`,
 }
 
 var templateListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List available templates",
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List available templates",
 	Run: func(cmd *cobra.Command, args []string) {
 		templates, err := loadAllTemplates()
 		if err != nil {
@@ -192,10 +200,53 @@ the default values for your common issue types.`,
 	},
 }
 
+var templateSetCmd = &cobra.Command{
+	Use:   "set <template-name>",
+	Short: "Create or replace a custom template from YAML",
+	Long: `Store a custom template in .beads/templates/, replacing any existing one
+with the same name. The template is read as YAML (or JSON) from --file, or
+from stdin without it. Fields left out are empty, except priority, which
+defaults to 2.
+
+Examples:
+  bd template set incident --file incident.yaml
+  cat rfc.yaml | bd template set rfc`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		file, _ := cmd.Flags().GetString("file")
+		var r io.Reader = os.Stdin
+		if file != "" && file != "-" {
+			// #nosec G304 - the user names the file to read
+			f, err := os.Open(file)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			defer f.Close()
+			r = f
+		}
+
+		tmpl, path, err := setCustomTemplate(args[0], r)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if jsonOutput {
+			outputJSON(tmpl)
+			return
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Saved template: %s\n", green("✓"), path)
+	},
+}
+
 func init() {
+	templateSetCmd.Flags().StringP("file", "f", "", "Read the template from this file instead of stdin")
 	templateCmd.AddCommand(templateListCmd)
 	templateCmd.AddCommand(templateShowCmd)
 	templateCmd.AddCommand(templateCreateCmd)
+	templateCmd.AddCommand(templateSetCmd)
 	rootCmd.AddCommand(templateCmd)
 }
 
@@ -300,6 +351,56 @@ func loadCustomTemplate(name string) (*Template, error) {
 	return &tmpl, nil
 }
 
+// setCustomTemplate parses the YAML template in r, validates it and writes
+// it to .beads/templates/<name>.yaml, returning the template and its path
+func setCustomTemplate(name string, r io.Reader) (*Template, string, error) {
+	if err := sanitizeTemplateName(name); err != nil {
+		return nil, "", err
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading template: %w", err)
+	}
+
+	// Priority 0 is P0, so a template without one gets the usual default
+	tmpl := Template{Priority: 2}
+	if err := yaml.Unmarshal(data, &tmpl); err != nil {
+		return nil, "", fmt.Errorf("parsing template: %w", err)
+	}
+	tmpl.Name = name
+	if tmpl.Type != "" && !types.IssueType(tmpl.Type).IsValid() {
+		return nil, "", fmt.Errorf("invalid type %q in template", tmpl.Type)
+	}
+	if tmpl.Priority < 0 || tmpl.Priority > 4 {
+		return nil, "", fmt.Errorf("invalid priority %d in template (expected 0-4)", tmpl.Priority)
+	}
+
+	templatesDir := filepath.Join(".beads", "templates")
+	if err := os.MkdirAll(templatesDir, 0755); err != nil {
+		return nil, "", fmt.Errorf("creating templates directory: %w", err)
+	}
+	out, err := yaml.Marshal(tmpl)
+	if err != nil {
+		return nil, "", fmt.Errorf("encoding template: %w", err)
+	}
+	path := filepath.Join(templatesDir, name+".yaml")
+	if err := os.WriteFile(path, out, 0600); err != nil {
+		return nil, "", fmt.Errorf("writing template: %w", err)
+	}
+	return &tmpl, path, nil
+}
+
+// expandPlaceholders returns a copy of tmpl with {{title}} and {{date}}
+// replaced in its description, design and acceptance criteria
+func (tmpl *Template) expandPlaceholders(title string, now time.Time) *Template {
+	r := strings.NewReplacer("{{title}}", title, "{{date}}", now.Format("2006-01-02"))
+	expanded := *tmpl
+	expanded.Description = r.Replace(tmpl.Description)
+	expanded.Design = r.Replace(tmpl.Design)
+	expanded.AcceptanceCriteria = r.Replace(tmpl.AcceptanceCriteria)
+	return &expanded
+}
+
 // isBuiltinTemplate checks if a template name is a built-in template
 func isBuiltinTemplate(name string) bool {
 	builtins := map[string]bool{
@@ -3,7 +3,9 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoadBuiltinTemplate(t *testing.T) {
@@ -191,3 +193,72 @@ func TestIsBuiltinTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestSetCustomTemplate(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
+	body := `description: "## Impact\n\n{{title}} started {{date}}"
+type: bug
+labels:
+  - incident
+acceptance_criteria: Postmortem written
+`
+	tmpl, path, err := setCustomTemplate("incident", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("setCustomTemplate() error = %v", err)
+	}
+	if path != filepath.Join(".beads", "templates", "incident.yaml") {
+		t.Errorf("path = %q", path)
+	}
+	if tmpl.Name != "incident" || tmpl.Priority != 2 {
+		t.Errorf("Name = %q, Priority = %d, want incident and the default 2", tmpl.Name, tmpl.Priority)
+	}
+
+	// Setting again replaces it, and loadTemplate finds the stored copy
+	if _, _, err := setCustomTemplate("incident", strings.NewReader("type: task\npriority: 0\n")); err != nil {
+		t.Fatalf("setCustomTemplate() replace error = %v", err)
+	}
+	loaded, err := loadTemplate("incident")
+	if err != nil {
+		t.Fatalf("loadTemplate() error = %v", err)
+	}
+	if loaded.Type != "task" || loaded.Priority != 0 || len(loaded.Labels) != 0 {
+		t.Errorf("loaded = %+v, want the replacement", loaded)
+	}
+
+	for _, bad := range []string{"type: nonsense\n", "priority: 7\n", "labels: [\n"} {
+		if _, _, err := setCustomTemplate("bad", strings.NewReader(bad)); err == nil {
+			t.Errorf("setCustomTemplate(%q) succeeded, want error", bad)
+		}
+	}
+	if _, _, err := setCustomTemplate("../escape", strings.NewReader("type: task\n")); err == nil {
+		t.Error("expected error for a name with a path separator")
+	}
+}
+
+func TestTemplateExpandPlaceholders(t *testing.T) {
+	tmpl := &Template{
+		Name:               "incident",
+		Description:        "{{title}} on {{date}}",
+		Design:             "Fix {{title}}",
+		AcceptanceCriteria: "Closed by {{date}}, {unchanged}",
+	}
+	now := time.Date(2025, 3, 9, 15, 0, 0, 0, time.UTC)
+	got := tmpl.expandPlaceholders("DB down", now)
+
+	if got.Description != "DB down on 2025-03-09" {
+		t.Errorf("Description = %q", got.Description)
+	}
+	if got.Design != "Fix DB down" {
+		t.Errorf("Design = %q", got.Design)
+	}
+	if got.AcceptanceCriteria != "Closed by 2025-03-09, {unchanged}" {
+		t.Errorf("AcceptanceCriteria = %q", got.AcceptanceCriteria)
+	}
+	if tmpl.Description != "{{title}} on {{date}}" {
+		t.Error("expandPlaceholders modified the stored template")
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+)
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Revert your most recent operation",
+	Long: `Revert the most recent operation by the current actor, such as a
+mistaken bulk 'bd close'. An operation is a burst of your events no more than
+a couple of seconds apart.
+
+Closed issues are reopened, and edited fields and statuses are put back to
+their previous values. Each reverted event gets an 'undone' event in the
+issue's history; nothing is deleted. Running undo again reverts the
+operation before that.
+
+Only operations younger than --max-age are considered, so an undo can't
+silently roll back something old. Creations, comments, and label and
+dependency changes aren't reverted, and neither are issues someone else has
+changed since; these are listed as skipped.
+
+Examples:
+  bd undo --dry-run
+  bd undo
+  bd undo --max-age 24h`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		maxAge, _ := cmd.Flags().GetDuration("max-age")
+		if maxAge <= 0 {
+			fmt.Fprintf(os.Stderr, "Error: --max-age must be positive\n")
+			os.Exit(1)
+		}
+		if err := ensureDirectMode("undo requires direct database access"); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		sqliteStore, ok := store.(*sqlite.SQLiteStorage)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: undo requires SQLite storage\n")
+			os.Exit(1)
+		}
+		ctx := context.Background()
+
+		plan, err := sqliteStore.PlanUndo(ctx, actor, maxAge)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if !dryRun && len(plan.Steps) > 0 {
+			if err := sqliteStore.ApplyUndo(ctx, plan, actor); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			markDirtyAndScheduleFlush()
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"dry_run": dryRun,
+				"actor":   plan.Actor,
+				"steps":   plan.Steps,
+				"skipped": plan.Skipped,
+			})
+			return
+		}
+		printUndoPlan(plan, dryRun, maxAge)
+	},
+}
+
+func printUndoPlan(plan *sqlite.UndoPlan, dryRun bool, maxAge time.Duration) {
+	if len(plan.Steps) == 0 && len(plan.Skipped) == 0 {
+		fmt.Printf("Nothing to undo for %s in the last %s\n", plan.Actor, maxAge)
+		return
+	}
+	verb := "Undid"
+	if dryRun {
+		verb = "Would undo"
+	}
+	if len(plan.Steps) > 0 {
+		fmt.Printf("%s %d event(s) by %s:\n", verb, len(plan.Steps), plan.Actor)
+		for _, step := range plan.Steps {
+			fmt.Printf("  %s  %s #%d (%s) → %s\n", step.IssueID, step.Event.EventType, step.Event.ID,
+				step.Event.CreatedAt.Local().Format("2006-01-02 15:04:05"), formatUndoRestore(step.Restore))
+		}
+	}
+	if len(plan.Steps) == 0 {
+		fmt.Printf("Nothing in %s's most recent operation can be undone\n", plan.Actor)
+	}
+	if len(plan.Skipped) > 0 {
+		fmt.Printf("%s Skipped %d event(s):\n", color.YellowString("!"), len(plan.Skipped))
+		for _, skip := range plan.Skipped {
+			fmt.Printf("  %s  %s #%d: %s\n", skip.Event.IssueID, skip.Event.EventType, skip.Event.ID, skip.Reason)
+		}
+	}
+	if dryRun && len(plan.Steps) > 0 {
+		fmt.Println("\nRun without --dry-run to apply")
+	}
+}
+
+// formatUndoRestore renders restored fields as "status=open, title=..."
+// leaving out closed_at, which follows status
+func formatUndoRestore(restore map[string]interface{}) string {
+	parts := make([]string, 0, len(restore))
+	for field, value := range restore {
+		if field == "closed_at" {
+			continue
+		}
+		if value == nil || value == "" {
+			value = "(none)"
+		}
+		parts = append(parts, fmt.Sprintf("%s=%v", field, value))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+func init() {
+	undoCmd.Flags().Bool("dry-run", false, "Preview what would be reverted without changing anything")
+	undoCmd.Flags().Duration("max-age", sqlite.DefaultUndoMaxAge, "Only undo an operation younger than this")
+	rootCmd.AddCommand(undoCmd)
+}
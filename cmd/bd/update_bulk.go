@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// bulkUpdateConfirmThreshold is the most issues bd update --filter changes
+// without --yes
+const bulkUpdateConfirmThreshold = 10
+
+// parseUpdateFilters reads bd update --filter values of the form
+// label=<name>, status=<status>, type=<type> or assignee=<name>. Labels may
+// be repeated and must all match; the other keys may be given once.
+func parseUpdateFilters(values []string) (types.IssueFilter, error) {
+	var filter types.IssueFilter
+	for _, value := range values {
+		key, arg, ok := strings.Cut(value, "=")
+		key, arg = strings.TrimSpace(key), strings.TrimSpace(arg)
+		if !ok || arg == "" {
+			return filter, fmt.Errorf("invalid filter %q (expected label=, status=, type= or assignee=<value>)", value)
+		}
+		switch key {
+		case "label":
+			filter.Labels = append(filter.Labels, arg)
+		case "status":
+			status := types.Status(arg)
+			if !status.IsValid() {
+				return filter, fmt.Errorf("invalid status %q in filter", arg)
+			}
+			if filter.Status != nil {
+				return filter, fmt.Errorf("status filter given more than once")
+			}
+			filter.Status = &status
+		case "type":
+			issueType := types.IssueType(arg)
+			if !issueType.IsValid() {
+				return filter, fmt.Errorf("invalid type %q in filter", arg)
+			}
+			if filter.IssueType != nil {
+				return filter, fmt.Errorf("type filter given more than once")
+			}
+			filter.IssueType = &issueType
+		case "assignee":
+			if filter.Assignee != nil {
+				return filter, fmt.Errorf("assignee filter given more than once")
+			}
+			filter.Assignee = &arg
+		default:
+			return filter, fmt.Errorf("unknown filter %q (expected label, status, type or assignee)", key)
+		}
+	}
+	return filter, nil
+}
+
+// findBulkUpdateIssues returns the IDs of the issues matching filter
+func findBulkUpdateIssues(ctx context.Context, filter types.IssueFilter) ([]string, error) {
+	var issues []*types.Issue
+	if daemonClient != nil {
+		listArgs := &rpc.ListArgs{Labels: filter.Labels}
+		if filter.Status != nil {
+			listArgs.Status = string(*filter.Status)
+		}
+		if filter.IssueType != nil {
+			listArgs.IssueType = string(*filter.IssueType)
+		}
+		if filter.Assignee != nil {
+			listArgs.Assignee = *filter.Assignee
+		}
+		resp, err := daemonClient.List(listArgs)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(resp.Data, &issues); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+	} else {
+		var err error
+		if issues, err = store.SearchIssues(ctx, "", filter); err != nil {
+			return nil, err
+		}
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids, nil
+}
+
+// applyBulkUpdate applies updates to every issue in ids in one transaction,
+// returning the updated issues. Each issue gets its own events.
+func applyBulkUpdate(ctx context.Context, ids []string, updates map[string]interface{}, setMetadata map[string]string, unsetMetadata []string) ([]*types.Issue, error) {
+	var issues []*types.Issue
+	if daemonClient != nil {
+		resp, err := daemonClient.UpdateMany(&rpc.UpdateManyArgs{
+			IDs:    ids,
+			Update: *updateArgsFrom("", updates, setMetadata, unsetMetadata),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(resp.Data, &issues); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		return issues, nil
+	}
+
+	changesMetadata := len(setMetadata) > 0 || len(unsetMetadata) > 0
+	err := store.WithTx(ctx, func(tx storage.Transaction) error {
+		for _, id := range ids {
+			issueUpdates := updates
+			if changesMetadata {
+				existing, err := tx.GetIssue(ctx, id)
+				if err != nil {
+					return fmt.Errorf("failed to get %s: %w", id, err)
+				}
+				if existing == nil {
+					return fmt.Errorf("issue %s not found", id)
+				}
+				issueUpdates = make(map[string]interface{}, len(updates)+1)
+				for key, value := range updates {
+					issueUpdates[key] = value
+				}
+				issueUpdates["metadata"] = types.MergeMetadata(existing.Metadata, setMetadata, unsetMetadata)
+			}
+			if err := tx.UpdateIssue(ctx, id, issueUpdates, actor); err != nil {
+				return fmt.Errorf("failed to update %s: %w", id, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	markDirtyAndScheduleFlush()
+
+	for _, id := range ids {
+		if issue, err := store.GetIssue(ctx, id); err == nil && issue != nil {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// runBulkUpdate is bd update --filter: it applies updates to every issue
+// the filters match, skipping issues locked by someone else with
+// respectLocks, and prints how many were updated and which
+func runBulkUpdate(ctx context.Context, filterValues []string, updates map[string]interface{}, setMetadata map[string]string, unsetMetadata []string, respectLocks, dryRun, yes, asJSON bool) error {
+	filter, err := parseUpdateFilters(filterValues)
+	if err != nil {
+		return err
+	}
+	matched, err := findBulkUpdateIssues(ctx, filter)
+	if err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(matched))
+	for _, id := range matched {
+		if checkEditLock(ctx, id, respectLocks) {
+			ids = append(ids, id)
+		}
+	}
+
+	if dryRun {
+		if asJSON {
+			outputJSON(map[string]interface{}{
+				"dry_run":      true,
+				"count":        len(ids),
+				"would_update": ids,
+			})
+			return nil
+		}
+		fmt.Println(color.YellowString("DRY RUN - no changes will be made"))
+		fmt.Printf("Would update %d issue(s):\n", len(ids))
+		for _, id := range ids {
+			fmt.Printf("  %s\n", id)
+		}
+		return nil
+	}
+	if len(ids) == 0 {
+		if asJSON {
+			outputJSON(map[string]interface{}{"count": 0, "updated": []string{}})
+		} else {
+			fmt.Println("No issues match")
+		}
+		return nil
+	}
+	if len(ids) > bulkUpdateConfirmThreshold && !yes {
+		return fmt.Errorf("the filter matches %d issues; pass --yes to update more than %d at once (or --dry-run to list them)", len(ids), bulkUpdateConfirmThreshold)
+	}
+
+	issues, err := applyBulkUpdate(ctx, ids, updates, setMetadata, unsetMetadata)
+	if err != nil {
+		return fmt.Errorf("no issues were updated: %w", err)
+	}
+
+	if asJSON {
+		outputJSON(map[string]interface{}{
+			"count":   len(ids),
+			"updated": ids,
+			"issues":  issues,
+		})
+		return nil
+	}
+	fmt.Printf("%s Updated %d issue(s):\n", color.GreenString("✓"), len(ids))
+	for _, id := range ids {
+		fmt.Printf("  %s\n", id)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseUpdateFilters(t *testing.T) {
+	filter, err := parseUpdateFilters([]string{"label=backend", "status=open", "type=bug", "assignee=alice", "label= urgent "})
+	if err != nil {
+		t.Fatalf("parseUpdateFilters failed: %v", err)
+	}
+	if len(filter.Labels) != 2 || filter.Labels[0] != "backend" || filter.Labels[1] != "urgent" {
+		t.Errorf("labels = %v, want [backend urgent]", filter.Labels)
+	}
+	if filter.Status == nil || *filter.Status != types.StatusOpen {
+		t.Errorf("status = %v, want open", filter.Status)
+	}
+	if filter.IssueType == nil || *filter.IssueType != types.TypeBug {
+		t.Errorf("type = %v, want bug", filter.IssueType)
+	}
+	if filter.Assignee == nil || *filter.Assignee != "alice" {
+		t.Errorf("assignee = %v, want alice", filter.Assignee)
+	}
+
+	for _, bad := range [][]string{
+		{"label"},
+		{"status="},
+		{"status=bogus"},
+		{"type=bogus"},
+		{"status=open", "status=closed"},
+		{"priority=1"},
+	} {
+		if _, err := parseUpdateFilters(bad); err == nil {
+			t.Errorf("expected an error for %v", bad)
+		}
+	}
+}
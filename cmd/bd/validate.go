@@ -51,7 +51,7 @@ Example:
 			}
 		}
 		if needsIssues {
-			allIssues, err = store.SearchIssues(ctx, "", types.IssueFilter{})
+			allIssues, err = store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error fetching issues: %v\n", err)
 				os.Exit(1)
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// watchChange is one change bd watch reports
+type watchChange struct {
+	Time    time.Time    `json:"time"`
+	Kind    string       `json:"kind"`             // created, updated, closed, reopened or deleted
+	Detail  string       `json:"detail,omitempty"` // What kind of update, when known
+	IssueID string       `json:"issue_id"`
+	Issue   *types.Issue `json:"issue,omitempty"` // Its state after the change; the last known state if deleted
+}
+
+// watchFilter picks the changes bd watch shows: an issue must have one of
+// statuses (any if empty) and all of labels
+type watchFilter struct {
+	statuses []types.Status
+	labels   []string
+}
+
+// parseWatchFilters reads --filter values of the form label=<name> or
+// status=<status>
+func parseWatchFilters(values []string) (watchFilter, error) {
+	var filter watchFilter
+	for _, value := range values {
+		key, arg, ok := strings.Cut(value, "=")
+		if !ok || arg == "" {
+			return filter, fmt.Errorf("invalid filter %q (expected label=<name> or status=<status>)", value)
+		}
+		switch strings.TrimSpace(key) {
+		case "label":
+			filter.labels = append(filter.labels, strings.TrimSpace(arg))
+		case "status":
+			status := types.Status(strings.TrimSpace(arg))
+			if !status.IsValid() {
+				return filter, fmt.Errorf("invalid status %q in filter", arg)
+			}
+			filter.statuses = append(filter.statuses, status)
+		default:
+			return filter, fmt.Errorf("unknown filter %q (expected label or status)", key)
+		}
+	}
+	return filter, nil
+}
+
+func (f watchFilter) matches(issue *types.Issue) bool {
+	if len(f.statuses) == 0 && len(f.labels) == 0 {
+		return true
+	}
+	if issue == nil {
+		return false
+	}
+	if len(f.statuses) > 0 && !slices.Contains(f.statuses, issue.Status) {
+		return false
+	}
+	for _, label := range f.labels {
+		if !slices.Contains(issue.Labels, label) {
+			return false
+		}
+	}
+	return true
+}
+
+// watchChangeFromEvent turns an event from the daemon's watch stream into
+// the change it reports
+func watchChangeFromEvent(event rpc.WatchEvent) watchChange {
+	change := watchChange{
+		Time:    event.Event.CreatedAt,
+		Kind:    "updated",
+		IssueID: event.Event.IssueID,
+		Issue:   event.Issue,
+	}
+	switch event.Event.EventType {
+	case types.EventCreated:
+		change.Kind = "created"
+	case types.EventClosed:
+		change.Kind = "closed"
+	case types.EventReopened:
+		change.Kind = "reopened"
+	case types.EventUpdated:
+	case types.EventStatusChanged:
+		change.Detail = "status"
+		if event.Event.OldValue != nil && event.Event.NewValue != nil {
+			change.Detail = fmt.Sprintf("status %s → %s", *event.Event.OldValue, *event.Event.NewValue)
+		}
+	default:
+		change.Detail = strings.ReplaceAll(string(event.Event.EventType), "_", " ")
+	}
+	if event.Issue == nil && change.Kind != "created" {
+		change.Kind = "deleted"
+		change.Detail = ""
+	}
+	return change
+}
+
+// diffWatchSnapshots compares two reads of the JSONL file, reporting issues
+// that appeared, disappeared, closed, reopened or otherwise changed, in
+// file order
+func diffWatchSnapshots(before, after []*types.Issue, now time.Time) []watchChange {
+	old := make(map[string]*types.Issue, len(before))
+	for _, issue := range before {
+		old[issue.ID] = issue
+	}
+	var changes []watchChange
+	seen := make(map[string]bool, len(after))
+	for _, issue := range after {
+		seen[issue.ID] = true
+		change := watchChange{Time: issue.UpdatedAt, IssueID: issue.ID, Issue: issue}
+		prev, ok := old[issue.ID]
+		switch {
+		case !ok:
+			change.Kind = "created"
+		case prev.Status != types.StatusClosed && issue.Status == types.StatusClosed:
+			change.Kind = "closed"
+		case prev.Status == types.StatusClosed && issue.Status != types.StatusClosed:
+			change.Kind = "reopened"
+		case !prev.UpdatedAt.Equal(issue.UpdatedAt) || prev.ContentHash != issue.ContentHash:
+			change.Kind = "updated"
+			if prev.Status != issue.Status {
+				change.Detail = fmt.Sprintf("status %s → %s", prev.Status, issue.Status)
+			}
+		default:
+			continue
+		}
+		if change.Time.IsZero() {
+			change.Time = now
+		}
+		changes = append(changes, change)
+	}
+	for _, issue := range before {
+		if !seen[issue.ID] {
+			changes = append(changes, watchChange{Time: now, Kind: "deleted", IssueID: issue.ID, Issue: issue})
+		}
+	}
+	return changes
+}
+
+// formatWatchChange renders a change as one line of bd watch output
+func formatWatchChange(change watchChange) string {
+	var kind string
+	padded := fmt.Sprintf("%-8s", change.Kind)
+	switch change.Kind {
+	case "created":
+		kind = color.GreenString(padded)
+	case "closed":
+		kind = color.CyanString(padded)
+	case "reopened":
+		kind = color.YellowString(padded)
+	case "deleted":
+		kind = color.RedString(padded)
+	default:
+		kind = color.BlueString(padded)
+	}
+
+	line := fmt.Sprintf("%s %s %s", color.HiBlackString(change.Time.Local().Format("15:04:05")), kind, change.IssueID)
+	if change.Issue != nil {
+		line += fmt.Sprintf(" %s %s", priorityTag(change.Issue.Priority), change.Issue.Title)
+	}
+	if change.Detail != "" {
+		line += " " + color.HiBlackString("(%s)", change.Detail)
+	}
+	return line
+}
+
+// printWatchChanges prints the changes filter lets through, as lines or,
+// with --json, one JSON object per line
+func printWatchChanges(changes []watchChange, filter watchFilter) {
+	for _, change := range changes {
+		if !filter.matches(change.Issue) {
+			continue
+		}
+		if jsonOutput {
+			data, err := json.Marshal(change)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to encode change: %v\n", err)
+				continue
+			}
+			fmt.Println(string(data))
+			continue
+		}
+		fmt.Println(formatWatchChange(change))
+	}
+}
+
+// watchDaemon follows the daemon's watch stream until ctx is done
+func watchDaemon(ctx context.Context, filter watchFilter) error {
+	return daemonClient.Watch(ctx, &rpc.WatchArgs{}, func(events []rpc.WatchEvent) error {
+		changes := make([]watchChange, 0, len(events))
+		for _, event := range events {
+			changes = append(changes, watchChangeFromEvent(event))
+		}
+		printWatchChanges(changes, filter)
+		return nil
+	})
+}
+
+// watchJSONL follows the JSONL file until ctx is done, diffing each new
+// version against the last one. Without a daemon this is the only record
+// other processes and git pulls leave of their changes.
+func watchJSONL(ctx context.Context, filter watchFilter) error {
+	jsonlPath := findJSONLPath()
+	snapshot, _, err := readJSONLLenient(jsonlPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", jsonlPath, err)
+	}
+
+	changed := make(chan struct{}, 1)
+	fw, err := NewFileWatcher(jsonlPath, func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch %s: %w", jsonlPath, err)
+	}
+	defer func() { _ = fw.Close() }()
+	fw.Start(ctx, daemonLogger{logFunc: func(format string, args ...interface{}) {
+		debugLog(format, args...)
+	}})
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+			next, _, err := readJSONLLenient(jsonlPath)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				fmt.Fprintf(os.Stderr, "Warning: failed to read %s: %v\n", jsonlPath, err)
+				continue
+			}
+			printWatchChanges(diffWatchSnapshots(snapshot, next, time.Now()), filter)
+			snapshot = next
+		}
+	}
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Show issue changes as they happen",
+	Long: `Print issues as they are created, updated, closed and reopened, until
+interrupted with Ctrl-C.
+
+With a daemon running, changes stream from it as they are made, whoever
+makes them. Without one, bd watch follows the JSONL file instead and
+reports what changed each time it is rewritten (by an auto-flush, an export
+or a git pull), so quick successive edits may show as one update.
+
+Filters apply to the issue's state after the change: --filter status=<status>
+may be repeated to match any of several statuses, and every
+--filter label=<name> must be on the issue.
+
+With --json, each change is printed as one JSON object per line.
+
+Examples:
+  bd watch
+  bd watch --filter label=backend
+  bd watch --filter status=open --filter status=in_progress --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		filterValues, _ := cmd.Flags().GetStringSlice("filter")
+		filter, err := parseWatchFilters(filterValues)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		if !jsonOutput {
+			source := "daemon"
+			if daemonClient == nil {
+				source = findJSONLPath()
+			}
+			fmt.Fprintf(os.Stderr, "Watching %s for changes (Ctrl-C to stop)\n", source)
+		}
+		if daemonClient != nil {
+			err = watchDaemon(ctx, filter)
+		} else {
+			err = watchJSONL(ctx, filter)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	watchCmd.Flags().StringSlice("filter", nil, "Only show issues matching label=<name> or status=<status> (repeatable)")
+	rootCmd.AddCommand(watchCmd)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDiffWatchSnapshots(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	before := []*types.Issue{
+		{ID: "bd-1", Title: "Unchanged", Status: types.StatusOpen, UpdatedAt: t0},
+		{ID: "bd-2", Title: "Will close", Status: types.StatusOpen, UpdatedAt: t0},
+		{ID: "bd-3", Title: "Will reopen", Status: types.StatusClosed, UpdatedAt: t0},
+		{ID: "bd-4", Title: "Will change", Status: types.StatusOpen, UpdatedAt: t0},
+		{ID: "bd-5", Title: "Will go", Status: types.StatusOpen, UpdatedAt: t0},
+	}
+	after := []*types.Issue{
+		{ID: "bd-1", Title: "Unchanged", Status: types.StatusOpen, UpdatedAt: t0},
+		{ID: "bd-2", Title: "Will close", Status: types.StatusClosed, UpdatedAt: t1},
+		{ID: "bd-3", Title: "Will reopen", Status: types.StatusOpen, UpdatedAt: t1},
+		{ID: "bd-4", Title: "Will change", Status: types.StatusInProgress, UpdatedAt: t1},
+		{ID: "bd-6", Title: "New", Status: types.StatusOpen, UpdatedAt: t1},
+	}
+
+	var got []string
+	for _, change := range diffWatchSnapshots(before, after, t1) {
+		got = append(got, fmt.Sprintf("%s %s %s", change.Kind, change.IssueID, change.Detail))
+	}
+	want := []string{
+		"closed bd-2 ",
+		"reopened bd-3 ",
+		"updated bd-4 status open → in_progress",
+		"created bd-6 ",
+		"deleted bd-5 ",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected changes:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestWatchFilters(t *testing.T) {
+	if _, err := parseWatchFilters([]string{"owner=alice"}); err == nil {
+		t.Error("expected an unknown filter key to fail")
+	}
+	if _, err := parseWatchFilters([]string{"status=done"}); err == nil {
+		t.Error("expected an invalid status to fail")
+	}
+
+	filter, err := parseWatchFilters([]string{"status=open", "status=blocked", "label=ui"})
+	if err != nil {
+		t.Fatalf("parseWatchFilters failed: %v", err)
+	}
+	for _, tc := range []struct {
+		issue *types.Issue
+		want  bool
+	}{
+		{&types.Issue{Status: types.StatusOpen, Labels: []string{"ui", "api"}}, true},
+		{&types.Issue{Status: types.StatusBlocked, Labels: []string{"ui"}}, true},
+		{&types.Issue{Status: types.StatusClosed, Labels: []string{"ui"}}, false},
+		{&types.Issue{Status: types.StatusOpen, Labels: []string{"api"}}, false},
+		{nil, false},
+	} {
+		if got := filter.matches(tc.issue); got != tc.want {
+			t.Errorf("matches(%+v) = %v, want %v", tc.issue, got, tc.want)
+		}
+	}
+	if !(watchFilter{}).matches(nil) {
+		t.Error("expected no filter to match everything, deleted issues included")
+	}
+}
+
+func TestWatchChangeFromEvent(t *testing.T) {
+	oldColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = oldColor }()
+
+	at := time.Date(2025, 1, 1, 9, 30, 0, 0, time.Local)
+	issue := &types.Issue{ID: "bd-1", Title: "Fix login", Priority: 1, Status: types.StatusOpen}
+	from, to := "open", "in_progress"
+	for _, tc := range []struct {
+		event *types.Event
+		issue *types.Issue
+		want  string
+	}{
+		{&types.Event{IssueID: "bd-1", EventType: types.EventCreated, CreatedAt: at}, issue, "09:30:00 created  bd-1 [P1 high] Fix login"},
+		{&types.Event{IssueID: "bd-1", EventType: types.EventStatusChanged, OldValue: &from, NewValue: &to, CreatedAt: at}, issue, "09:30:00 updated  bd-1 [P1 high] Fix login (status open → in_progress)"},
+		{&types.Event{IssueID: "bd-1", EventType: types.EventLabelAdded, CreatedAt: at}, issue, "09:30:00 updated  bd-1 [P1 high] Fix login (label added)"},
+		{&types.Event{IssueID: "bd-1", EventType: types.EventUpdated, CreatedAt: at}, nil, "09:30:00 deleted  bd-1"},
+	} {
+		change := watchChangeFromEvent(rpc.WatchEvent{Event: tc.event, Issue: tc.issue})
+		if got := formatWatchChange(change); got != tc.want {
+			t.Errorf("got %q, want %q", got, tc.want)
+		}
+	}
+}
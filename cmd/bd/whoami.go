@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var whoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "Show the actor bd records in the audit trail",
+	Long: `Show the actor bd records in the audit trail and where it came from.
+
+The actor is resolved in this order:
+  1. --actor flag
+  2. BD_ACTOR or BEADS_ACTOR environment variable, or 'actor' in .beads/config.yaml
+  3. $USER
+
+The same actor is used by 'bd list --mine'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		name, source := resolveActorSource(cmd)
+
+		if jsonOutput {
+			outputJSON(map[string]string{
+				"actor":  name,
+				"source": source,
+			})
+			return
+		}
+
+		if source == "none" {
+			fmt.Printf("No actor configured (recorded as %q)\n", name)
+			fmt.Printf("Hint: set BD_ACTOR, pass --actor, or add 'actor: <name>' to .beads/config.yaml\n")
+			return
+		}
+		fmt.Printf("%s (from %s)\n", name, source)
+	},
+}
+
+// resolveActorSource returns the resolved actor and which source supplied it.
+// whoami skips database initialization, so the $USER fallback from
+// PersistentPreRun is applied here as well.
+func resolveActorSource(cmd *cobra.Command) (string, string) {
+	switch {
+	case cmd.Flags().Changed("actor") && actor != "":
+		return actor, "--actor flag"
+	case actor != "" && os.Getenv("BD_ACTOR") == actor:
+		return actor, "BD_ACTOR"
+	case actor != "" && os.Getenv("BEADS_ACTOR") == actor:
+		return actor, "BEADS_ACTOR"
+	case actor != "":
+		return actor, "config"
+	}
+	if user := os.Getenv("USER"); user != "" {
+		return user, "$USER"
+	}
+	return "unknown", "none"
+}
+
+func init() {
+	rootCmd.AddCommand(whoamiCmd)
+}
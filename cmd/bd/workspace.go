@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/beads"
+)
+
+// workspaceBeadsDir returns the .beads directory of a workspace given as
+// its root (the directory containing .beads) or as the .beads directory
+// itself
+func workspaceBeadsDir(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("invalid workspace %s: %w", dir, err)
+	}
+	if info, err := os.Stat(absDir); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("workspace %s is not a directory", dir)
+	}
+	candidates := []string{filepath.Join(absDir, ".beads")}
+	if filepath.Base(absDir) == ".beads" {
+		candidates = append([]string{absDir}, candidates...)
+	}
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("workspace %s has no .beads directory (run 'bd init' there first)", dir)
+}
+
+// useWorkspace points bd at the workspace in dir for --workspace: dbPath
+// becomes its database and BEADS_DIR its .beads directory, so everything
+// that discovers paths (--no-db mode, the JSONL file, a daemon started from
+// here) finds the same workspace. needDB fails when there's no database.
+func useWorkspace(dir string, needDB bool) error {
+	beadsDir, err := workspaceBeadsDir(dir)
+	if err != nil {
+		return err
+	}
+	if err := os.Setenv("BEADS_DIR", beadsDir); err != nil {
+		return fmt.Errorf("failed to set BEADS_DIR: %w", err)
+	}
+	if !needDB {
+		return nil
+	}
+	db := beads.FindDatabaseInBeadsDir(beadsDir)
+	if db == "" {
+		return fmt.Errorf("workspace %s has no database in %s (run 'bd init' there first)", dir, beadsDir)
+	}
+	dbPath = db
+	return nil
+}
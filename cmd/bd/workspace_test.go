@@ -0,0 +1,50 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUseWorkspace(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir()) // Paths come back canonical
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := filepath.Join(root, "services", "auth")
+	newTestStore(t, filepath.Join(auth, ".beads", "beads.db"))
+	if err := os.MkdirAll(filepath.Join(root, "services", "web", ".beads"), 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	oldDBPath := dbPath
+	defer func() { dbPath = oldDBPath }()
+	t.Setenv("BEADS_DIR", "")
+
+	// The workspace root and its .beads directory both work
+	for _, dir := range []string{auth, filepath.Join(auth, ".beads")} {
+		dbPath = ""
+		if err := useWorkspace(dir, true); err != nil {
+			t.Fatalf("useWorkspace(%s) failed: %v", dir, err)
+		}
+		if want := filepath.Join(auth, ".beads", "beads.db"); dbPath != want {
+			t.Errorf("dbPath = %s, want %s", dbPath, want)
+		}
+		if got := os.Getenv("BEADS_DIR"); got != filepath.Join(auth, ".beads") {
+			t.Errorf("BEADS_DIR = %s", got)
+		}
+	}
+
+	// A workspace without a database fails, unless none is needed (--no-db)
+	web := filepath.Join(root, "services", "web")
+	if err := useWorkspace(web, true); err == nil || !strings.Contains(err.Error(), "has no database") {
+		t.Errorf("expected a missing database error, got %v", err)
+	}
+	if err := useWorkspace(web, false); err != nil {
+		t.Errorf("expected --no-db to accept a workspace without a database, got %v", err)
+	}
+	if err := useWorkspace(filepath.Join(root, "services"), true); err == nil || !strings.Contains(err.Error(), "no .beads directory") {
+		t.Errorf("expected a missing .beads error, got %v", err)
+	}
+}
@@ -107,11 +107,15 @@ const (
 	EventLabelAdded        = types.EventLabelAdded
 	EventLabelRemoved      = types.EventLabelRemoved
 	EventCompacted         = types.EventCompacted
+	EventPriorityChanged   = types.EventPriorityChanged
 )
 
 // Storage provides the minimal interface for extension orchestration
 type Storage = storage.Storage
 
+// CloseOptions describes how Storage.CloseIssue closes an issue
+type CloseOptions = storage.CloseOptions
+
 // NewSQLiteStorage opens a bd SQLite database for programmatic access.
 // Most extensions should use this to query ready work and update issue status.
 func NewSQLiteStorage(dbPath string) (Storage, error) {
@@ -127,37 +131,8 @@ func NewSQLiteStorage(dbPath string) (Storage, error) {
 func FindDatabasePath() string {
 	// 1. Check BEADS_DIR environment variable (preferred)
 	if beadsDir := os.Getenv("BEADS_DIR"); beadsDir != "" {
-		// Canonicalize the path to prevent nested .beads directories
-		absBeadsDir := utils.CanonicalizePath(beadsDir)
-
-		// Check for config.json first (single source of truth)
-		if cfg, err := configfile.Load(absBeadsDir); err == nil && cfg != nil {
-			dbPath := cfg.DatabasePath(absBeadsDir)
-			if _, err := os.Stat(dbPath); err == nil {
-				return dbPath
-			}
-		}
-
-		// Fall back to canonical beads.db for backward compatibility
-		canonicalDB := filepath.Join(absBeadsDir, CanonicalDatabaseName)
-		if _, err := os.Stat(canonicalDB); err == nil {
-			return canonicalDB
-		}
-
-		// Look for any .db file in the beads directory
-		matches, err := filepath.Glob(filepath.Join(absBeadsDir, "*.db"))
-		if err == nil && len(matches) > 0 {
-			// Filter out backup files only
-			var validDBs []string
-			for _, match := range matches {
-				baseName := filepath.Base(match)
-				if !strings.Contains(baseName, ".backup") {
-					validDBs = append(validDBs, match)
-				}
-			}
-			if len(validDBs) > 0 {
-				return validDBs[0]
-			}
+		if dbPath := FindDatabaseInBeadsDir(beadsDir); dbPath != "" {
+			return dbPath
 		}
 
 		// BEADS_DIR is set but no database found - this is OK for --no-db mode
@@ -192,6 +167,40 @@ func FindDatabasePath() string {
 	return ""
 }
 
+// FindDatabaseInBeadsDir returns the database in the given .beads directory:
+// the one metadata.json names, else beads.db, else any other non-backup
+// .db file. Returns empty string if the directory has none.
+func FindDatabaseInBeadsDir(beadsDir string) string {
+	// Canonicalize the path to prevent nested .beads directories
+	absBeadsDir := utils.CanonicalizePath(beadsDir)
+
+	// Check for config.json first (single source of truth)
+	if cfg, err := configfile.Load(absBeadsDir); err == nil && cfg != nil {
+		dbPath := cfg.DatabasePath(absBeadsDir)
+		if _, err := os.Stat(dbPath); err == nil {
+			return dbPath
+		}
+	}
+
+	// Fall back to canonical beads.db for backward compatibility
+	canonicalDB := filepath.Join(absBeadsDir, CanonicalDatabaseName)
+	if _, err := os.Stat(canonicalDB); err == nil {
+		return canonicalDB
+	}
+
+	// Look for any .db file in the beads directory
+	matches, err := filepath.Glob(filepath.Join(absBeadsDir, "*.db"))
+	if err == nil && len(matches) > 0 {
+		// Filter out backup files only
+		for _, match := range matches {
+			if !strings.Contains(filepath.Base(match), ".backup") {
+				return match
+			}
+		}
+	}
+	return ""
+}
+
 // FindBeadsDir finds the .beads/ directory in the current directory tree
 // Returns empty string if not found. Supports both database and JSONL-only mode.
 // This is useful for commands that need to detect beads projects without requiring a database.
@@ -367,7 +376,7 @@ func FindAllDatabases() []DatabaseInfo {
 				store, err := sqlite.New(dbPath)
 				if err == nil {
 					ctx := context.Background()
-					if issues, err := store.SearchIssues(ctx, "", types.IssueFilter{}); err == nil {
+					if issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true}); err == nil {
 						issueCount = len(issues)
 					}
 					_ = store.Close()
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/storage"
 )
 
 // integrationTestHelper provides common test setup and assertion methods
@@ -67,7 +68,7 @@ func (h *integrationTestHelper) updateIssue(id string, updates map[string]interf
 }
 
 func (h *integrationTestHelper) closeIssue(id string, reason string) {
-	if err := h.store.CloseIssue(h.ctx, id, reason, "test-actor"); err != nil {
+	if err := h.store.CloseIssue(h.ctx, id, storage.CloseOptions{Reason: reason}, "test-actor"); err != nil {
 		h.t.Fatalf("CloseIssue failed: %v", err)
 	}
 }
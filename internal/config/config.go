@@ -80,7 +80,9 @@ func Initialize() error {
 	v.SetDefault("no-auto-flush", false)
 	v.SetDefault("no-auto-import", false)
 	v.SetDefault("no-db", false)
+	v.SetDefault("read-only", false)
 	v.SetDefault("db", "")
+	v.SetDefault("workspace", "")
 	v.SetDefault("actor", "")
 	v.SetDefault("issue-prefix", "")
 	
@@ -88,10 +90,17 @@ func Initialize() error {
 	// These are bound explicitly for backward compatibility
 	_ = v.BindEnv("flush-debounce", "BEADS_FLUSH_DEBOUNCE")
 	_ = v.BindEnv("auto-start-daemon", "BEADS_AUTO_START_DAEMON")
+	_ = v.BindEnv("actor", "BD_ACTOR", "BEADS_ACTOR")
+	_ = v.BindEnv("read-only", "BD_READ_ONLY", "BEADS_READONLY")
 	
 	// Set defaults for additional settings
 	v.SetDefault("flush-debounce", "30s")
 	v.SetDefault("auto-start-daemon", true)
+
+	// SQLite connection tuning (see internal/storage/sqlite/connection.go)
+	v.SetDefault("sqlite.busy-timeout", "30s")
+	v.SetDefault("sqlite.synchronous", "NORMAL")
+	v.SetDefault("sqlite.max-open-conns", 8)
 	
 	// Routing configuration defaults
 	v.SetDefault("routing.mode", "auto")
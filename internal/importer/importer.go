@@ -10,6 +10,7 @@ import (
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 	"github.com/steveyegge/beads/internal/utils"
 )
 
@@ -36,6 +37,8 @@ type Options struct {
 	SkipPrefixValidation       bool           // Skip prefix validation (for auto-import)
 	OrphanHandling             OrphanHandling // How to handle missing parent issues (default: allow)
 	ClearDuplicateExternalRefs bool           // Clear duplicate external_ref values instead of erroring
+	Merge                      bool           // Upsert keyed on issue ID instead of matching on content
+	MergeStrategy              MergeStrategy  // Which version wins in merge mode (default: theirs)
 }
 
 // Result contains statistics about the import operation
@@ -76,6 +79,10 @@ func ImportIssues(ctx context.Context, dbPath string, store storage.Storage, iss
 		MismatchPrefixes: make(map[string]int),
 	}
 
+	// A JSONL built from appended deltas (bd export --since --append) can
+	// repeat an issue; the last record is the newest
+	issues, result.Skipped = dedupeByIDKeepLast(issues)
+
 	// Compute content hashes for all incoming issues (bd-95)
 	// Always recompute to avoid stale/incorrect JSONL hashes (bd-1231)
 	for _, issue := range issues {
@@ -114,28 +121,35 @@ func ImportIssues(ctx context.Context, dbPath string, store storage.Storage, iss
 		return result, err
 	}
 
-	// Detect and resolve collisions
-	issues, err = detectUpdates(ctx, sqliteStore, issues, opts, result)
-	if err != nil {
-		return result, err
-	}
-	if opts.DryRun && result.Collisions == 0 {
-		return result, nil
-	}
+	if opts.Merge {
+		// Upsert keyed on ID, reconciling labels and dependencies
+		if err := mergeIssues(ctx, sqliteStore, issues, opts, result); err != nil {
+			return nil, err
+		}
+	} else {
+		// Detect and resolve collisions
+		issues, err = detectUpdates(ctx, sqliteStore, issues, opts, result)
+		if err != nil {
+			return result, err
+		}
+		if opts.DryRun && result.Collisions == 0 {
+			return result, nil
+		}
 
-	// Upsert issues (create new or update existing)
-	if err := upsertIssues(ctx, sqliteStore, issues, opts, result); err != nil {
-		return nil, err
-	}
+		// Upsert issues (create new or update existing)
+		if err := upsertIssues(ctx, sqliteStore, issues, opts, result); err != nil {
+			return nil, err
+		}
 
-	// Import dependencies
-	if err := importDependencies(ctx, sqliteStore, issues, opts); err != nil {
-		return nil, err
-	}
+		// Import dependencies
+		if err := importDependencies(ctx, sqliteStore, issues, opts); err != nil {
+			return nil, err
+		}
 
-	// Import labels
-	if err := importLabels(ctx, sqliteStore, issues, opts); err != nil {
-		return nil, err
+		// Import labels
+		if err := importLabels(ctx, sqliteStore, issues, opts); err != nil {
+			return nil, err
+		}
 	}
 
 	// Import comments
@@ -191,10 +205,20 @@ func handlePrefixMismatch(ctx context.Context, sqliteStore *sqlite.SQLiteStorage
 
 	result.ExpectedPrefix = configuredPrefix
 
+	// Issues may also use the per-type prefixes
+	byType, err := sqliteStore.GetConfig(ctx, types.PrefixByTypeConfigKey)
+	if err != nil {
+		return fmt.Errorf("failed to get %s config: %w", types.PrefixByTypeConfigKey, err)
+	}
+	prefixes := types.IDPrefixes{Default: configuredPrefix}
+	if prefixes.ByType, err = types.ParsePrefixByType(byType); err != nil {
+		return err
+	}
+
 	// Analyze prefixes in imported issues
 	for _, issue := range issues {
 		prefix := utils.ExtractIssuePrefix(issue.ID)
-		if prefix != configuredPrefix {
+		if prefix != configuredPrefix && (len(prefixes.ByType) == 0 || prefixes.Match(issue.ID) == "") {
 			result.PrefixMismatch = true
 			result.MismatchPrefixes[prefix]++
 		}
@@ -307,6 +331,7 @@ func handleRename(ctx context.Context, s *sqlite.SQLiteStorage, existing *types.
 				"priority":            incoming.Priority,
 				"issue_type":          incoming.IssueType,
 				"assignee":            incoming.Assignee,
+				"resolution":          string(incoming.Resolution),
 			}
 			if err := s.UpdateIssue(ctx, existing.ID, updates, "importer"); err != nil {
 				return "", fmt.Errorf("failed to update issue %s: %w", existing.ID, err)
@@ -412,7 +437,7 @@ func handleRename(ctx context.Context, s *sqlite.SQLiteStorage, existing *types.
 // upsertIssues creates new issues or updates existing ones using content-first matching
 func upsertIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options, result *Result) error {
 	// Get all DB issues once
-	dbIssues, err := sqliteStore.SearchIssues(ctx, "", types.IssueFilter{})
+	dbIssues, err := sqliteStore.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 	if err != nil {
 		return fmt.Errorf("failed to get DB issues: %w", err)
 	}
@@ -471,6 +496,13 @@ func upsertIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues
 					updates["acceptance_criteria"] = incoming.AcceptanceCriteria
 					updates["notes"] = incoming.Notes
 					updates["closed_at"] = incoming.ClosedAt
+					updates["resolution"] = string(incoming.Resolution)
+					updates["resolved_by"] = incoming.ResolvedBy
+					updates["estimated_minutes"] = optionalMinutes(incoming.EstimatedMinutes)
+					updates["spent_minutes"] = optionalMinutes(incoming.SpentMinutes)
+					updates["metadata"] = incoming.Metadata
+					updates["attachments"] = incoming.Attachments
+					updates["archived_at"] = incoming.ArchivedAt
 					
 					if incoming.Assignee != "" {
 					 updates["assignee"] = incoming.Assignee
@@ -549,6 +581,13 @@ func upsertIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues
 				updates["acceptance_criteria"] = incoming.AcceptanceCriteria
 				updates["notes"] = incoming.Notes
 			updates["closed_at"] = incoming.ClosedAt
+			updates["resolution"] = string(incoming.Resolution)
+			updates["resolved_by"] = incoming.ResolvedBy
+			updates["estimated_minutes"] = optionalMinutes(incoming.EstimatedMinutes)
+			updates["spent_minutes"] = optionalMinutes(incoming.SpentMinutes)
+			updates["metadata"] = incoming.Metadata
+			updates["attachments"] = incoming.Attachments
+			updates["archived_at"] = incoming.ArchivedAt
 
 				if incoming.Assignee != "" {
 				 updates["assignee"] = incoming.Assignee
@@ -601,7 +640,12 @@ if len(newIssues) > 0 {
 				}
 			}
 			if len(batchForDepth) > 0 {
-				if err := sqliteStore.CreateIssuesWithOptions(ctx, batchForDepth, "import", opts.OrphanHandling); err != nil {
+				// Labels go in with the issues; importLabels then finds nothing to add
+				batchOpts := sqlite.BatchOptions{
+					BatchSize:      sqliteStore.GetImportBatchSize(ctx),
+					OrphanHandling: opts.OrphanHandling,
+				}
+				if err := sqliteStore.CreateIssuesBatchWithOptions(ctx, batchForDepth, "import", batchOpts); err != nil {
 					return fmt.Errorf("error creating depth-%d issues: %w", depth, err)
 				}
 				result.Created += len(batchForDepth)
@@ -609,8 +653,31 @@ if len(newIssues) > 0 {
 		}
 	}
 
-	// REMOVED (bd-c7af): Counter sync after import - no longer needed with hash IDs
+	// Batch inserts bypass GetNextChildID, so raise the counters of parents
+	// that gained children
+	return syncChildCounters(ctx, sqliteStore, newIssues)
+}
 
+// syncChildCounters raises the child counters of the parents of created
+// hierarchical issues to their highest existing child, so the next
+// GetNextChildID doesn't collide with an imported child
+func syncChildCounters(ctx context.Context, s *sqlite.SQLiteStorage, created []*types.Issue) error {
+	seen := make(map[string]bool)
+	var parents []string
+	for _, issue := range created {
+		dot := strings.LastIndex(issue.ID, ".")
+		if dot <= 0 || seen[issue.ID[:dot]] {
+			continue
+		}
+		seen[issue.ID[:dot]] = true
+		parents = append(parents, issue.ID[:dot])
+	}
+	if len(parents) == 0 {
+		return nil
+	}
+	if _, err := s.SyncChildCounters(ctx, parents...); err != nil {
+		return fmt.Errorf("failed to sync child counters: %w", err)
+	}
 	return nil
 }
 
@@ -672,16 +739,19 @@ func importLabels(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues
 			currentLabelSet[label] = true
 		}
 
-		// Add missing labels
+		// Add missing labels, compared as stored (normalized)
 		for _, label := range issue.Labels {
-			if !currentLabelSet[label] {
-				if err := sqliteStore.AddLabel(ctx, issue.ID, label, "import"); err != nil {
-					if opts.Strict {
-						return fmt.Errorf("error adding label %s to %s: %w", label, issue.ID, err)
-					}
-					continue
+			normalized := util.NormalizeLabel(label)
+			if normalized == "" || currentLabelSet[normalized] {
+				continue
+			}
+			if err := sqliteStore.AddLabel(ctx, issue.ID, normalized, "import"); err != nil {
+				if opts.Strict {
+					return fmt.Errorf("error adding label %s to %s: %w", label, issue.ID, err)
 				}
+				continue
 			}
+			currentLabelSet[normalized] = true
 		}
 	}
 
@@ -701,23 +771,57 @@ func importComments(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issu
 			return fmt.Errorf("error getting comments for %s: %w", issue.ID, err)
 		}
 
-		// Build a set of existing comments (by author+normalized text)
-		existingComments := make(map[string]bool)
+		// Build a set of existing comments (by author+normalized text as
+		// first written, so edits don't make a comment look new)
+		existingComments := make(map[string]*types.Comment)
 		for _, c := range currentComments {
-			key := fmt.Sprintf("%s:%s", c.Author, strings.TrimSpace(c.Text))
-			existingComments[key] = true
+			key := fmt.Sprintf("%s:%s", c.Author, strings.TrimSpace(c.FirstText()))
+			existingComments[key] = c
 		}
 
+		// Comment IDs are local to each database, so map imported IDs to local
+		// ones to attach replies to the right parent. Comments are exported in
+		// creation order, so parents are seen before their replies.
+		localIDs := make(map[int64]int64)
+
 		// Add missing comments
 		for _, comment := range issue.Comments {
-			key := fmt.Sprintf("%s:%s", comment.Author, strings.TrimSpace(comment.Text))
-			if !existingComments[key] {
-				if _, err := sqliteStore.AddIssueComment(ctx, issue.ID, comment.Author, comment.Text); err != nil {
+			key := fmt.Sprintf("%s:%s", comment.Author, strings.TrimSpace(comment.FirstText()))
+			local, exists := existingComments[key]
+			if !exists {
+				var err error
+				if parentID, ok := importedParentID(comment, localIDs); ok {
+					local, err = sqliteStore.AddCommentReply(ctx, issue.ID, parentID, comment.Author, comment.FirstText())
+				} else {
+					local, err = sqliteStore.AddIssueComment(ctx, issue.ID, comment.Author, comment.FirstText())
+				}
+				if err != nil {
 					if opts.Strict {
 						return fmt.Errorf("error adding comment to %s: %w", issue.ID, err)
 					}
 					continue
 				}
+				existingComments[key] = local
+			}
+			localIDs[comment.ID] = local.ID
+
+			// Resolution only moves forward on import, so an older JSONL can't
+			// reopen a thread that was resolved locally
+			if comment.Resolved && !local.Resolved {
+				if _, err := sqliteStore.ResolveComment(ctx, local.ID, true); err != nil {
+					if opts.Strict {
+						return fmt.Errorf("error resolving comment on %s: %w", issue.ID, err)
+					}
+					continue
+				}
+				local.Resolved = true
+			}
+
+			if err := importCommentHistory(ctx, sqliteStore, comment, local); err != nil {
+				if opts.Strict {
+					return fmt.Errorf("error updating comment on %s: %w", issue.ID, err)
+				}
+				continue
 			}
 		}
 	}
@@ -725,8 +829,46 @@ func importComments(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issu
 	return nil
 }
 
+// importCommentHistory brings local up to date with an imported comment's
+// edits and deletion. The newer edit wins, and deletion only moves forward,
+// so an older JSONL can't resurrect a comment deleted locally.
+func importCommentHistory(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, comment, local *types.Comment) error {
+	if local.IsDeleted() {
+		return nil
+	}
+	if comment.EditedAt != nil && comment.Text != local.Text &&
+		(local.EditedAt == nil || comment.EditedAt.After(*local.EditedAt)) {
+		if _, err := sqliteStore.EditComment(ctx, local.ID, comment.Text, "import"); err != nil {
+			return err
+		}
+	}
+	if comment.IsDeleted() {
+		if _, err := sqliteStore.DeleteComment(ctx, local.ID, "import"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importedParentID returns the local ID of an imported reply's parent, if known
+func importedParentID(comment *types.Comment, localIDs map[int64]int64) (int64, bool) {
+	if comment.ParentCommentID == nil {
+		return 0, false
+	}
+	parentID, ok := localIDs[*comment.ParentCommentID]
+	return parentID, ok
+}
+
 // Helper functions
 
+// optionalMinutes converts an optional minutes field to an update value
+func optionalMinutes(minutes *int) interface{} {
+	if minutes == nil {
+		return nil
+	}
+	return *minutes
+}
+
 func GetPrefixList(prefixes map[string]int) []string {
 	var result []string
 	keys := make([]string, 0, len(prefixes))
@@ -742,6 +884,27 @@ func GetPrefixList(prefixes map[string]int) []string {
 	return result
 }
 
+// dedupeByIDKeepLast drops all but the last record for each issue ID, keeping
+// the survivors in the order of their first appearance. Returns the number
+// of records dropped.
+func dedupeByIDKeepLast(issues []*types.Issue) ([]*types.Issue, int) {
+	last := make(map[string]int, len(issues))
+	for i, issue := range issues {
+		last[issue.ID] = i
+	}
+	if len(last) == len(issues) {
+		return issues, 0
+	}
+	deduped := make([]*types.Issue, 0, len(last))
+	for _, issue := range issues {
+		if j := last[issue.ID]; j >= 0 {
+			deduped = append(deduped, issues[j])
+			last[issue.ID] = -1
+		}
+	}
+	return deduped, len(issues) - len(deduped)
+}
+
 func validateNoDuplicateExternalRefs(issues []*types.Issue, clearDuplicates bool, result *Result) error {
 	seen := make(map[string][]string)
 	
@@ -5,6 +5,7 @@ package importer
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -605,6 +606,77 @@ func TestImportIssues_Basic(t *testing.T) {
 	}
 }
 
+func TestImportIssues_SyncsChildCounters(t *testing.T) {
+	for _, merge := range []bool{false, true} {
+		t.Run(fmt.Sprintf("merge=%v", merge), func(t *testing.T) {
+			ctx := context.Background()
+			tmpDB := t.TempDir() + "/test.db"
+			store, err := sqlite.New(tmpDB)
+			if err != nil {
+				t.Fatalf("Failed to create store: %v", err)
+			}
+			defer store.Close()
+			if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+				t.Fatalf("Failed to set prefix: %v", err)
+			}
+
+			// Children written to the JSONL by another clone, never allocated here
+			issue := func(id string) *types.Issue {
+				return &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+			}
+			issues := []*types.Issue{issue("test-abc"), issue("test-abc.1"), issue("test-abc.4"), issue("test-abc.4.2")}
+			if _, err := ImportIssues(ctx, tmpDB, store, issues, Options{Merge: merge}); err != nil {
+				t.Fatalf("Import failed: %v", err)
+			}
+
+			for parent, want := range map[string]string{"test-abc": "test-abc.5", "test-abc.4": "test-abc.4.3"} {
+				if next, err := store.GetNextChildID(ctx, parent); err != nil || next != want {
+					t.Errorf("next child of %s = %s (%v), want %s", parent, next, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestImportIssues_RepeatedIDKeepsLastRecord(t *testing.T) {
+	ctx := context.Background()
+	tmpDB := t.TempDir() + "/test.db"
+	store, err := sqlite.New(tmpDB)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	// A full export followed by an appended delta for the same issue
+	ref := "gh-7"
+	record := func(title string, status types.Status) *types.Issue {
+		return &types.Issue{ID: "test-abc123", Title: title, Status: status, Priority: 1, IssueType: types.TypeTask, ExternalRef: &ref}
+	}
+	issues := []*types.Issue{
+		record("Original", types.StatusOpen),
+		{ID: "test-def456", Title: "Other", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		record("Renamed", types.StatusInProgress),
+	}
+
+	result, err := ImportIssues(ctx, tmpDB, store, issues, Options{})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if result.Created != 2 || result.Skipped != 1 {
+		t.Errorf("created %d, skipped %d; want 2 created and the stale record skipped", result.Created, result.Skipped)
+	}
+	retrieved, err := store.GetIssue(ctx, "test-abc123")
+	if err != nil {
+		t.Fatalf("Failed to retrieve issue: %v", err)
+	}
+	if retrieved.Title != "Renamed" || retrieved.Status != types.StatusInProgress {
+		t.Errorf("got %q/%s, want the last record", retrieved.Title, retrieved.Status)
+	}
+}
+
 func TestImportIssues_Update(t *testing.T) {
 	ctx := context.Background()
 	
@@ -802,6 +874,119 @@ func TestImportIssues_Labels(t *testing.T) {
 	}
 }
 
+func TestImportIssues_CommentThreading(t *testing.T) {
+	ctx := context.Background()
+
+	tmpDB := t.TempDir() + "/test.db"
+	store, err := sqlite.New(tmpDB)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	// Comment IDs come from another clone's database
+	parentID := int64(40)
+	issues := []*types.Issue{
+		{
+			ID:        "test-abc123",
+			Title:     "Test Issue",
+			Status:    types.StatusOpen,
+			Priority:  1,
+			IssueType: types.TypeTask,
+			Comments: []*types.Comment{
+				{ID: 40, IssueID: "test-abc123", Author: "alice", Text: "Question", Resolved: true},
+				{ID: 41, IssueID: "test-abc123", ParentCommentID: &parentID, Author: "bob", Text: "Answer"},
+			},
+		},
+	}
+
+	if _, err := ImportIssues(ctx, tmpDB, store, issues, Options{}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	comments, err := store.GetIssueComments(ctx, "test-abc123")
+	if err != nil {
+		t.Fatalf("Failed to get comments: %v", err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("Expected 2 comments, got %d", len(comments))
+	}
+	if !comments[0].Resolved {
+		t.Error("Expected imported thread to be resolved")
+	}
+	if comments[1].ParentCommentID == nil || *comments[1].ParentCommentID != comments[0].ID {
+		t.Errorf("Expected reply to point at local comment %d, got %v", comments[0].ID, comments[1].ParentCommentID)
+	}
+
+	// Re-importing is idempotent
+	if _, err := ImportIssues(ctx, tmpDB, store, issues, Options{}); err != nil {
+		t.Fatalf("Re-import failed: %v", err)
+	}
+	comments, _ = store.GetIssueComments(ctx, "test-abc123")
+	if len(comments) != 2 {
+		t.Errorf("Expected re-import to keep 2 comments, got %d", len(comments))
+	}
+}
+
+func TestImportIssues_CommentEditsAndDeletions(t *testing.T) {
+	ctx := context.Background()
+
+	tmpDB := t.TempDir() + "/test.db"
+	store, err := sqlite.New(tmpDB)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	issue := &types.Issue{ID: "test-abc123", Title: "Test Issue", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	typo, _ := store.AddIssueComment(ctx, issue.ID, "alice", "Fixed in abc123")
+	wrong, _ := store.AddIssueComment(ctx, issue.ID, "bob", "Wrong issue")
+	exported, _ := store.GetIssueComments(ctx, issue.ID)
+
+	// Another clone edited one comment and deleted the other after this export
+	editedAt := time.Now().Add(time.Minute)
+	incoming := *issue
+	incoming.Comments = []*types.Comment{
+		{ID: 7, IssueID: issue.ID, Author: "alice", Text: "Fixed in abc1234", OriginalText: "Fixed in abc123", EditedAt: &editedAt},
+		{ID: 8, IssueID: issue.ID, Author: "bob", Text: "Wrong issue", DeletedAt: &editedAt},
+	}
+	if _, err := ImportIssues(ctx, tmpDB, store, []*types.Issue{&incoming}, Options{}); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	comments, _ := store.GetIssueComments(ctx, issue.ID)
+	if len(comments) != 2 {
+		t.Fatalf("Expected the edit not to add a comment, got %d", len(comments))
+	}
+	if comments[0].ID != typo.ID || comments[0].Text != "Fixed in abc1234" || comments[0].OriginalText != "Fixed in abc123" {
+		t.Errorf("Expected the edit to be applied, got %+v", comments[0])
+	}
+	if comments[1].ID != wrong.ID || !comments[1].IsDeleted() {
+		t.Errorf("Expected the deletion to be applied, got %+v", comments[1])
+	}
+
+	// Re-importing the older export neither reverts the edit nor resurrects
+	// the deleted comment
+	incoming.Comments = exported
+	if _, err := ImportIssues(ctx, tmpDB, store, []*types.Issue{&incoming}, Options{}); err != nil {
+		t.Fatalf("Re-import failed: %v", err)
+	}
+	comments, _ = store.GetIssueComments(ctx, issue.ID)
+	if len(comments) != 2 || comments[0].Text != "Fixed in abc1234" || !comments[1].IsDeleted() {
+		t.Errorf("Expected the older export to change nothing, got %+v %+v", comments[0], comments[1])
+	}
+}
+
 func TestGetOrCreateStore_ExistingStore(t *testing.T) {
 	ctx := context.Background()
 	
@@ -1067,3 +1252,88 @@ func TestConcurrentExternalRefImports(t *testing.T) {
 		}
 	})
 }
+
+func TestImportIssues_Merge(t *testing.T) {
+	ctx := context.Background()
+
+	tmpDB := t.TempDir() + "/test.db"
+	store, err := sqlite.New(tmpDB)
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	existing := &types.Issue{ID: "test-old", Title: "Local title", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, existing, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	if err := store.AddLabel(ctx, "test-old", "stale", "test"); err != nil {
+		t.Fatalf("Failed to add label: %v", err)
+	}
+
+	incoming := func(updatedAt time.Time) []*types.Issue {
+		return []*types.Issue{
+			{
+				ID: "test-old", Title: "Incoming title", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask,
+				UpdatedAt: updatedAt, Labels: []string{"fresh"},
+				Dependencies: []*types.Dependency{{IssueID: "test-old", DependsOnID: "test-new", Type: types.DepBlocks}},
+			},
+			// Comes after test-old, which depends on it
+			{ID: "test-new", Title: "New issue", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		}
+	}
+
+	// ours keeps the local issue but still inserts new IDs
+	result, err := ImportIssues(ctx, tmpDB, store, incoming(time.Now().Add(time.Hour)), Options{Merge: true, MergeStrategy: MergeOurs})
+	if err != nil {
+		t.Fatalf("Import (ours) failed: %v", err)
+	}
+	if result.Created != 1 || result.Updated != 0 || result.Skipped != 1 {
+		t.Errorf("ours: created=%d updated=%d skipped=%d, want 1/0/1", result.Created, result.Updated, result.Skipped)
+	}
+
+	// newest skips incoming records older than the local ones
+	result, err = ImportIssues(ctx, tmpDB, store, incoming(time.Now().Add(-time.Hour)), Options{Merge: true, MergeStrategy: MergeNewest})
+	if err != nil {
+		t.Fatalf("Import (newest) failed: %v", err)
+	}
+	if result.Created != 0 || result.Updated != 0 || result.Skipped != 2 {
+		t.Errorf("newest: created=%d updated=%d skipped=%d, want 0/0/2", result.Created, result.Updated, result.Skipped)
+	}
+
+	// theirs takes the incoming record, labels and dependencies included
+	result, err = ImportIssues(ctx, tmpDB, store, incoming(time.Now()), Options{Merge: true})
+	if err != nil {
+		t.Fatalf("Import (theirs) failed: %v", err)
+	}
+	if result.Created != 0 || result.Updated != 1 {
+		t.Errorf("theirs: created=%d updated=%d, want 0/1", result.Created, result.Updated)
+	}
+	got, err := store.GetIssue(ctx, "test-old")
+	if err != nil {
+		t.Fatalf("Failed to get issue: %v", err)
+	}
+	if got.Title != "Incoming title" || len(got.Labels) != 1 || got.Labels[0] != "fresh" {
+		t.Errorf("got title %q labels %v, want incoming title and [fresh]", got.Title, got.Labels)
+	}
+	deps, err := store.GetDependencyRecords(ctx, "test-old")
+	if err != nil {
+		t.Fatalf("Failed to get dependencies: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != "test-new" {
+		t.Errorf("dependencies = %+v, want one on test-new", deps)
+	}
+
+	// Re-importing the same file changes nothing
+	result, err = ImportIssues(ctx, tmpDB, store, incoming(time.Now()), Options{Merge: true})
+	if err != nil {
+		t.Fatalf("Re-import failed: %v", err)
+	}
+	if result.Created != 0 || result.Updated != 0 || result.Unchanged != 2 {
+		t.Errorf("re-import: created=%d updated=%d unchanged=%d, want 0/0/2", result.Created, result.Updated, result.Unchanged)
+	}
+}
@@ -0,0 +1,128 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// MergeStrategy decides which version wins when a merge import finds an
+// issue whose ID already exists
+type MergeStrategy string
+
+const (
+	// MergeTheirs takes the incoming version (default)
+	MergeTheirs MergeStrategy = "theirs"
+	// MergeOurs keeps the database version
+	MergeOurs MergeStrategy = "ours"
+	// MergeNewest takes whichever version has the later updated_at
+	MergeNewest MergeStrategy = "newest"
+)
+
+// ParseMergeStrategy validates a --merge-strategy value ("" means theirs)
+func ParseMergeStrategy(value string) (MergeStrategy, error) {
+	switch MergeStrategy(value) {
+	case "":
+		return MergeTheirs, nil
+	case MergeTheirs, MergeOurs, MergeNewest:
+		return MergeStrategy(value), nil
+	default:
+		return "", fmt.Errorf("invalid merge strategy %q (must be ours, theirs, or newest)", value)
+	}
+}
+
+// takeIncoming reports whether strategy picks incoming over current
+func takeIncoming(strategy MergeStrategy, current, incoming *types.Issue) bool {
+	switch strategy {
+	case MergeOurs:
+		return false
+	case MergeNewest:
+		return incoming.UpdatedAt.After(current.UpdatedAt)
+	default:
+		return true
+	}
+}
+
+// mergeIssues upserts issues keyed on ID: new IDs are inserted, and existing
+// ones are made to match the incoming record (fields, labels, dependencies)
+// when the merge strategy picks it, or skipped otherwise.
+//
+// Issues are upserted without dependencies first, so every dependency target
+// in the file exists by the time dependencies are reconciled.
+func mergeIssues(ctx context.Context, sqliteStore *sqlite.SQLiteStorage, issues []*types.Issue, opts Options, result *Result) error {
+	dbIssues, err := sqliteStore.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
+	if err != nil {
+		return fmt.Errorf("failed to get DB issues: %w", err)
+	}
+	dbByID := buildIDMap(dbIssues)
+
+	// Parents before children, so hierarchical IDs find their parent
+	sorted := make([]*types.Issue, len(issues))
+	copy(sorted, issues)
+	SortByDepth(sorted)
+
+	seen := make(map[string]bool)
+	var inserted, existing []*types.Issue
+	for _, incoming := range sorted {
+		if seen[incoming.ID] {
+			result.Skipped++ // Duplicate ID within the file: first one wins
+			continue
+		}
+		seen[incoming.ID] = true
+
+		current, exists := dbByID[incoming.ID]
+		if !exists {
+			withoutDeps := *incoming
+			withoutDeps.Dependencies = nil
+			if _, err := sqliteStore.UpsertIssue(ctx, &withoutDeps, "import"); err != nil {
+				return fmt.Errorf("error inserting issue %s: %w", incoming.ID, err)
+			}
+			result.Created++
+			inserted = append(inserted, incoming)
+			continue
+		}
+		if !takeIncoming(opts.MergeStrategy, current, incoming) {
+			result.Skipped++
+			continue
+		}
+		existing = append(existing, incoming)
+	}
+
+	if err := syncChildCounters(ctx, sqliteStore, inserted); err != nil {
+		return err
+	}
+
+	// New issues only need their dependencies added
+	for _, incoming := range inserted {
+		if len(incoming.Dependencies) == 0 {
+			continue
+		}
+		if _, err := sqliteStore.UpsertIssue(ctx, incoming, "import"); err != nil {
+			if opts.Strict {
+				return fmt.Errorf("error adding dependencies of %s: %w", incoming.ID, err)
+			}
+			fmt.Fprintf(os.Stderr, "Warning: failed to add dependencies of %s: %v\n", incoming.ID, err)
+		}
+	}
+
+	for _, incoming := range existing {
+		outcome, err := sqliteStore.UpsertIssue(ctx, incoming, "import")
+		if err != nil {
+			if opts.Strict {
+				return fmt.Errorf("error updating issue %s: %w", incoming.ID, err)
+			}
+			fmt.Fprintf(os.Stderr, "Warning: skipped %s: %v\n", incoming.ID, err)
+			result.Skipped++
+			continue
+		}
+		if outcome == sqlite.UpsertUpdated {
+			result.Updated++
+		} else {
+			result.Unchanged++
+		}
+	}
+	return nil
+}
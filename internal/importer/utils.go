@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/utils"
@@ -112,6 +113,65 @@ func (fc *fieldComparator) equalPriority(existing int, newVal interface{}) bool
 	return ok && int64(existing) == newPriority
 }
 
+func (fc *fieldComparator) equalPtrInt(existing *int, newVal interface{}) bool {
+	if newVal == nil {
+		return existing == nil
+	}
+	n, ok := fc.intFrom(newVal)
+	return ok && existing != nil && int64(*existing) == n
+}
+
+// equalPtrTime compares an optional timestamp such as archived_at
+func (fc *fieldComparator) equalPtrTime(existing *time.Time, newVal interface{}) bool {
+	incoming, _ := newVal.(*time.Time)
+	if existing == nil || incoming == nil {
+		return existing == nil && incoming == nil
+	}
+	return existing.Equal(*incoming)
+}
+
+// equalAttachments compares attachment references in order, treating nil
+// and empty alike
+func (fc *fieldComparator) equalAttachments(existing []string, newVal interface{}) bool {
+	var incoming []string
+	if newVal != nil {
+		refs, ok := newVal.([]string)
+		if !ok {
+			return false
+		}
+		incoming = refs
+	}
+	if len(existing) != len(incoming) {
+		return false
+	}
+	for i := range incoming {
+		if existing[i] != incoming[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (fc *fieldComparator) equalMetadata(existing map[string]string, newVal interface{}) bool {
+	var incoming map[string]string
+	if newVal != nil {
+		m, ok := newVal.(map[string]string)
+		if !ok {
+			return false
+		}
+		incoming = m
+	}
+	if len(existing) != len(incoming) {
+		return false
+	}
+	for key, value := range incoming {
+		if got, ok := existing[key]; !ok || got != value {
+			return false
+		}
+	}
+	return true
+}
+
 func (fc *fieldComparator) checkFieldChanged(key string, existing *types.Issue, newVal interface{}) bool {
 	switch key {
 	case "title":
@@ -134,6 +194,20 @@ func (fc *fieldComparator) checkFieldChanged(key string, existing *types.Issue,
 		return !fc.equalStr(existing.Assignee, newVal)
 	case "external_ref":
 		return !fc.equalPtrStr(existing.ExternalRef, newVal)
+	case "resolution":
+		return !fc.equalStr(string(existing.Resolution), newVal)
+	case "resolved_by":
+		return !fc.equalStr(existing.ResolvedBy, newVal)
+	case "estimated_minutes":
+		return !fc.equalPtrInt(existing.EstimatedMinutes, newVal)
+	case "spent_minutes":
+		return !fc.equalPtrInt(existing.SpentMinutes, newVal)
+	case "metadata":
+		return !fc.equalMetadata(existing.Metadata, newVal)
+	case "attachments":
+		return !fc.equalAttachments(existing.Attachments, newVal)
+	case "archived_at":
+		return !fc.equalPtrTime(existing.ArchivedAt, newVal)
 	default:
 		return false
 	}
@@ -197,6 +271,7 @@ func RenameImportedIssuePrefixes(issues []*types.Issue, targetPrefix string) err
 		// Update comment references
 		for i := range issue.Comments {
 			issue.Comments[i].Text = replaceIDReferences(issue.Comments[i].Text, idMapping)
+			issue.Comments[i].OriginalText = replaceIDReferences(issue.Comments[i].OriginalText, idMapping)
 		}
 	}
 
@@ -29,48 +29,22 @@ package merge
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
-	"time"
+	"sort"
+	"strings"
 
-	"github.com/google/go-cmp/cmp"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
-// Issue represents a beads issue with all possible fields
-type Issue struct {
-	ID           string       `json:"id"`
-	Title        string       `json:"title,omitempty"`
-	Description  string       `json:"description,omitempty"`
-	Notes        string       `json:"notes,omitempty"`
-	Status       string       `json:"status,omitempty"`
-	Priority     int          `json:"priority,omitempty"`
-	IssueType    string       `json:"issue_type,omitempty"`
-	CreatedAt    string       `json:"created_at,omitempty"`
-	UpdatedAt    string       `json:"updated_at,omitempty"`
-	ClosedAt     string       `json:"closed_at,omitempty"`
-	CreatedBy    string       `json:"created_by,omitempty"`
-	Dependencies []Dependency `json:"dependencies,omitempty"`
-	RawLine      string       `json:"-"` // Store original line for conflict output
-}
-
-// Dependency represents an issue dependency
-type Dependency struct {
-	IssueID     string `json:"issue_id"`
-	DependsOnID string `json:"depends_on_id"`
-	Type        string `json:"type"`
-	CreatedAt   string `json:"created_at"`
-	CreatedBy   string `json:"created_by"`
-}
-
-// IssueKey uniquely identifies an issue for matching
-type IssueKey struct {
-	ID        string
-	CreatedAt string
-	CreatedBy string
-}
-
-// Merge3Way performs a 3-way merge of JSONL issue files
+// Merge3Way merges the JSONL issue files at leftPath (ours) and rightPath
+// (theirs) against their common ancestor at basePath with MergeJSONL, and
+// writes the result to outputPath. Differing edits are resolved rather than
+// left as conflict markers; with debug set, each resolution is reported on
+// stderr.
 func Merge3Way(outputPath, basePath, leftPath, rightPath string, debug bool) error {
 	if debug {
 		fmt.Fprintf(os.Stderr, "=== DEBUG MODE ===\n")
@@ -82,64 +56,36 @@ func Merge3Way(outputPath, basePath, leftPath, rightPath string, debug bool) err
 	}
 
 	// Read all three files
-	baseIssues, err := readIssues(basePath)
+	base, err := os.ReadFile(basePath)
 	if err != nil {
 		return fmt.Errorf("error reading base file: %w", err)
 	}
-	if debug {
-		fmt.Fprintf(os.Stderr, "Base issues read: %d\n", len(baseIssues))
-	}
-
-	leftIssues, err := readIssues(leftPath)
+	left, err := os.ReadFile(leftPath)
 	if err != nil {
 		return fmt.Errorf("error reading left file: %w", err)
 	}
-	if debug {
-		fmt.Fprintf(os.Stderr, "Left issues read: %d\n", len(leftIssues))
-	}
-
-	rightIssues, err := readIssues(rightPath)
+	right, err := os.ReadFile(rightPath)
 	if err != nil {
 		return fmt.Errorf("error reading right file: %w", err)
 	}
-	if debug {
-		fmt.Fprintf(os.Stderr, "Right issues read: %d\n", len(rightIssues))
-		fmt.Fprintf(os.Stderr, "\n")
-	}
-
-	// Perform 3-way merge
-	result, conflicts := merge3Way(baseIssues, leftIssues, rightIssues)
-
-	if debug {
-		fmt.Fprintf(os.Stderr, "Merge complete:\n")
-		fmt.Fprintf(os.Stderr, "  Merged issues: %d\n", len(result))
-		fmt.Fprintf(os.Stderr, "  Conflicts: %d\n", len(conflicts))
-		fmt.Fprintf(os.Stderr, "\n")
-	}
 
-	// Open output file for writing
-	outFile, err := os.Create(outputPath)
+	merged, notes, err := MergeJSONL(base, left, right)
 	if err != nil {
-		return fmt.Errorf("error creating output file: %w", err)
+		return err
 	}
-	defer outFile.Close()
 
-	// Write merged result to output file
-	for _, issue := range result {
-		line, err := json.Marshal(issue)
-		if err != nil {
-			return fmt.Errorf("error marshaling issue %s: %w", issue.ID, err)
-		}
-		if _, err := fmt.Fprintln(outFile, string(line)); err != nil {
-			return fmt.Errorf("error writing merged issue: %w", err)
+	if debug {
+		fmt.Fprintf(os.Stderr, "Merge complete:\n")
+		fmt.Fprintf(os.Stderr, "  Merged issues: %d\n", len(splitLines(string(merged))))
+		fmt.Fprintf(os.Stderr, "  Resolved edits: %d\n", len(notes))
+		for _, note := range notes {
+			fmt.Fprintf(os.Stderr, "    %s\n", note)
 		}
+		fmt.Fprintf(os.Stderr, "\n")
 	}
 
-	// Write conflicts to output file
-	for _, conflict := range conflicts {
-		if _, err := fmt.Fprintln(outFile, conflict); err != nil {
-			return fmt.Errorf("error writing conflict: %w", err)
-		}
+	if err := os.WriteFile(outputPath, merged, 0644); err != nil {
+		return fmt.Errorf("error writing output file: %w", err)
 	}
 
 	if debug {
@@ -147,34 +93,17 @@ func Merge3Way(outputPath, basePath, leftPath, rightPath string, debug bool) err
 		fmt.Fprintf(os.Stderr, "\n")
 
 		// Show first few lines of output for debugging
-		if err := outFile.Sync(); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to sync output file: %v\n", err)
-		}
-		if content, err := os.ReadFile(outputPath); err == nil {
-			lines := 0
-			fmt.Fprintf(os.Stderr, "Output file preview (first 10 lines):\n")
-			for _, line := range splitLines(string(content)) {
-				if lines >= 10 {
-					fmt.Fprintf(os.Stderr, "... (%d more lines)\n", len(splitLines(string(content)))-10)
-					break
-				}
-				fmt.Fprintf(os.Stderr, "  %s\n", line)
-				lines++
+		lines := splitLines(string(merged))
+		fmt.Fprintf(os.Stderr, "Output file preview (first 10 lines):\n")
+		for i, line := range lines {
+			if i >= 10 {
+				fmt.Fprintf(os.Stderr, "... (%d more lines)\n", len(lines)-10)
+				break
 			}
+			fmt.Fprintf(os.Stderr, "  %s\n", line)
 		}
 		fmt.Fprintf(os.Stderr, "\n")
-	}
-
-	// Return error if there were conflicts (caller can check this)
-	if len(conflicts) > 0 {
-		if debug {
-			fmt.Fprintf(os.Stderr, "Merge completed with %d conflicts\n", len(conflicts))
-		}
-		return fmt.Errorf("merge completed with %d conflicts", len(conflicts))
-	}
-
-	if debug {
-		fmt.Fprintf(os.Stderr, "Merge completed successfully with no conflicts\n")
+		fmt.Fprintf(os.Stderr, "Merge completed successfully\n")
 	}
 	return nil
 }
@@ -194,318 +123,389 @@ func splitLines(s string) []string {
 	return lines
 }
 
-func readIssues(path string) ([]Issue, error) {
-	file, err := os.Open(path)
+// setFields are issue fields merged element by element rather than as
+// whole values
+var setFields = map[string]bool{"labels": true, "dependencies": true, "comments": true}
+
+// issueVersion is one side's version of an issue: its raw fields for the
+// field-by-field merge, and the decoded issue for labels, dependencies and
+// comments
+type issueVersion struct {
+	fields map[string]json.RawMessage
+	issue  *types.Issue
+}
+
+// MergeJSONL three-way merges JSONL exports: base is the common ancestor
+// (nil if there is none), ours and theirs the two edited versions. Issues are
+// matched by ID and merged field by field. A field changed on only one side
+// takes that change; a field changed differently on both sides takes the
+// version from the side whose updated_at is newer. Labels, dependencies and
+// comments are merged as sets, keeping additions from both sides and
+// dropping elements either side removed. An issue deleted on one side and
+// edited on the other is kept, so no edit is lost.
+//
+// Returns the merged JSONL in export order and one note per decision that
+// had to pick a side.
+func MergeJSONL(base, ours, theirs []byte) ([]byte, []string, error) {
+	baseIssues, err := parseIssues(base)
+	if err != nil {
+		return nil, nil, fmt.Errorf("base: %w", err)
+	}
+	ourIssues, err := parseIssues(ours)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
+		return nil, nil, fmt.Errorf("ours: %w", err)
+	}
+	theirIssues, err := parseIssues(theirs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("theirs: %w", err)
+	}
+
+	ids := make(map[string]bool)
+	for _, m := range []map[string]*issueVersion{baseIssues, ourIssues, theirIssues} {
+		for id := range m {
+			ids[id] = true
+		}
+	}
+
+	var merged []*types.Issue
+	var notes []string
+	for id := range ids {
+		b, o, t := baseIssues[id], ourIssues[id], theirIssues[id]
+		switch {
+		case o == nil && t == nil:
+			// Deleted on both sides
+		case t == nil:
+			switch {
+			case b == nil:
+				merged = append(merged, o.issue) // Added on our side
+			case sameIssue(b, o):
+				// Unchanged on our side, deleted on theirs
+			default:
+				notes = append(notes, fmt.Sprintf("%s: deleted on their side but edited on ours; keeping it", id))
+				merged = append(merged, o.issue)
+			}
+		case o == nil:
+			switch {
+			case b == nil:
+				merged = append(merged, t.issue) // Added on their side
+			case sameIssue(b, t):
+				// Unchanged on their side, deleted on ours
+			default:
+				notes = append(notes, fmt.Sprintf("%s: deleted on our side but edited on theirs; keeping it", id))
+				merged = append(merged, t.issue)
+			}
+		default:
+			issue, issueNotes, err := mergeIssue(id, b, o, t)
+			if err != nil {
+				return nil, nil, err
+			}
+			merged = append(merged, issue)
+			notes = append(notes, issueNotes...)
+		}
+	}
+
+	utils.SortIssuesForExport(merged)
+	sort.Strings(notes)
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, issue := range merged {
+		if err := encoder.Encode(issue); err != nil {
+			return nil, nil, fmt.Errorf("failed to encode issue %s: %w", issue.ID, err)
+		}
 	}
-	defer file.Close()
+	return buf.Bytes(), notes, nil
+}
 
-	var issues []Issue
-	scanner := bufio.NewScanner(file)
+// parseIssues reads a JSONL export into issues keyed by ID
+func parseIssues(data []byte) (map[string]*issueVersion, error) {
+	issues := make(map[string]*issueVersion)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 1024), 64*1024*1024)
 	lineNum := 0
 	for scanner.Scan() {
 		lineNum++
-		line := scanner.Text()
-		if line == "" {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
 			continue
 		}
-
-		var issue Issue
-		if err := json.Unmarshal([]byte(line), &issue); err != nil {
-			return nil, fmt.Errorf("failed to parse line %d: %w", lineNum, err)
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(line, &fields); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
 		}
-		issue.RawLine = line
-		issues = append(issues, issue)
+		var issue types.Issue
+		if err := json.Unmarshal(line, &issue); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		if issue.ID == "" {
+			return nil, fmt.Errorf("line %d: issue has no id", lineNum)
+		}
+		issues[issue.ID] = &issueVersion{fields: fields, issue: &issue}
 	}
-
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading file: %w", err)
+		return nil, err
 	}
-
 	return issues, nil
 }
 
-func makeKey(issue Issue) IssueKey {
-	return IssueKey{
-		ID:        issue.ID,
-		CreatedAt: issue.CreatedAt,
-		CreatedBy: issue.CreatedBy,
+// mergeIssue merges an issue present on both sides. base is nil when
+// both sides added the issue independently.
+func mergeIssue(id string, base, ours, theirs *issueVersion) (*types.Issue, []string, error) {
+	baseFields := map[string]json.RawMessage{}
+	var baseIssue *types.Issue
+	if base != nil {
+		baseFields = base.fields
+		baseIssue = base.issue
+	}
+	theirsNewer := theirs.issue.UpdatedAt.After(ours.issue.UpdatedAt)
+
+	keys := make(map[string]bool)
+	for _, m := range []map[string]json.RawMessage{baseFields, ours.fields, theirs.fields} {
+		for key := range m {
+			keys[key] = true
+		}
 	}
-}
 
-func merge3Way(base, left, right []Issue) ([]Issue, []string) {
-	// Build maps for quick lookup
-	baseMap := make(map[IssueKey]Issue)
-	for _, issue := range base {
-		baseMap[makeKey(issue)] = issue
+	var notes []string
+	fields := make(map[string]json.RawMessage)
+	statusFromTheirs := false
+	for key := range keys {
+		// updated_at is recomputed and closed_at follows status below;
+		// content_hash is recomputed from the merged content
+		if setFields[key] || key == "updated_at" || key == "closed_at" || key == "content_hash" {
+			continue
+		}
+		b, o, t := baseFields[key], ours.fields[key], theirs.fields[key]
+		fromTheirs := false
+		switch {
+		case sameJSON(o, t), sameJSON(b, t):
+		case sameJSON(b, o):
+			fromTheirs = true
+		default:
+			fromTheirs = theirsNewer
+			side := "ours"
+			if fromTheirs {
+				side = "theirs"
+			}
+			notes = append(notes, fmt.Sprintf("%s: %s changed on both sides; kept %s (newer)", id, key, side))
+		}
+		value := o
+		if fromTheirs {
+			value = t
+		}
+		if value != nil {
+			fields[key] = value
+		}
+		if key == "status" {
+			statusFromTheirs = fromTheirs
+		}
 	}
 
-	leftMap := make(map[IssueKey]Issue)
-	for _, issue := range left {
-		leftMap[makeKey(issue)] = issue
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to merge %s: %w", id, err)
 	}
-
-	rightMap := make(map[IssueKey]Issue)
-	for _, issue := range right {
-		rightMap[makeKey(issue)] = issue
+	var issue types.Issue
+	if err := json.Unmarshal(data, &issue); err != nil {
+		return nil, nil, fmt.Errorf("failed to merge %s: %w", id, err)
 	}
 
-	// Track which issues we've processed
-	processed := make(map[IssueKey]bool)
-	var result []Issue
-	var conflicts []string
-
-	// Process all unique keys
-	allKeys := make(map[IssueKey]bool)
-	for k := range baseMap {
-		allKeys[k] = true
-	}
-	for k := range leftMap {
-		allKeys[k] = true
+	issue.UpdatedAt = ours.issue.UpdatedAt
+	if theirsNewer {
+		issue.UpdatedAt = theirs.issue.UpdatedAt
 	}
-	for k := range rightMap {
-		allKeys[k] = true
+	issue.ClosedAt = ours.issue.ClosedAt
+	if statusFromTheirs {
+		issue.ClosedAt = theirs.issue.ClosedAt
 	}
 
-	for key := range allKeys {
-		if processed[key] {
-			continue
-		}
-		processed[key] = true
-
-		baseIssue, inBase := baseMap[key]
-		leftIssue, inLeft := leftMap[key]
-		rightIssue, inRight := rightMap[key]
-
-		// Handle different scenarios
-		if inBase && inLeft && inRight {
-			// All three present - merge
-			merged, conflict := mergeIssue(baseIssue, leftIssue, rightIssue)
-			if conflict != "" {
-				conflicts = append(conflicts, conflict)
-			} else {
-				result = append(result, merged)
-			}
-		} else if !inBase && inLeft && inRight {
-			// Added in both - check if identical
-			if issuesEqual(leftIssue, rightIssue) {
-				result = append(result, leftIssue)
-			} else {
-				conflicts = append(conflicts, makeConflict(leftIssue.RawLine, rightIssue.RawLine))
-			}
-		} else if inBase && inLeft && !inRight {
-			// Deleted in right, maybe modified in left
-			if issuesEqual(baseIssue, leftIssue) {
-				// Deleted in right, unchanged in left - accept deletion
-				continue
-			} else {
-				// Modified in left, deleted in right - conflict
-				conflicts = append(conflicts, makeConflictWithBase(baseIssue.RawLine, leftIssue.RawLine, ""))
-			}
-		} else if inBase && !inLeft && inRight {
-			// Deleted in left, maybe modified in right
-			if issuesEqual(baseIssue, rightIssue) {
-				// Deleted in left, unchanged in right - accept deletion
-				continue
-			} else {
-				// Modified in right, deleted in left - conflict
-				conflicts = append(conflicts, makeConflictWithBase(baseIssue.RawLine, "", rightIssue.RawLine))
-			}
-		} else if !inBase && inLeft && !inRight {
-			// Added only in left
-			result = append(result, leftIssue)
-		} else if !inBase && !inLeft && inRight {
-			// Added only in right
-			result = append(result, rightIssue)
-		}
+	issue.Labels = mergeLabelSets(baseIssue, ours.issue, theirs.issue)
+	issue.Dependencies = mergeDependencySets(baseIssue, ours.issue, theirs.issue)
+	issue.Comments = mergeCommentSets(baseIssue, ours.issue, theirs.issue)
+	if ours.issue.ContentHash != "" || theirs.issue.ContentHash != "" {
+		issue.ContentHash = issue.ComputeContentHash()
 	}
-
-	return result, conflicts
+	return &issue, notes, nil
 }
 
-func mergeIssue(base, left, right Issue) (Issue, string) {
-	result := Issue{
-		ID:        base.ID,
-		CreatedAt: base.CreatedAt,
-		CreatedBy: base.CreatedBy,
-	}
-
-	// Merge title
-	result.Title = mergeField(base.Title, left.Title, right.Title)
-
-	// Merge description
-	result.Description = mergeField(base.Description, left.Description, right.Description)
-
-	// Merge notes
-	result.Notes = mergeField(base.Notes, left.Notes, right.Notes)
-
-	// Merge status
-	result.Status = mergeField(base.Status, left.Status, right.Status)
-
-	// Merge priority (as int)
-	if base.Priority == left.Priority && base.Priority != right.Priority {
-		result.Priority = right.Priority
-	} else if base.Priority == right.Priority && base.Priority != left.Priority {
-		result.Priority = left.Priority
-	} else if left.Priority == right.Priority {
-		result.Priority = left.Priority
-	} else {
-		// Conflict - take left for now
-		result.Priority = left.Priority
+// sameJSON reports whether two raw JSON values are equal, treating absent
+// and null alike
+func sameJSON(a, b json.RawMessage) bool {
+	if isNullJSON(a) || isNullJSON(b) {
+		return isNullJSON(a) && isNullJSON(b)
 	}
-
-	// Merge issue_type
-	result.IssueType = mergeField(base.IssueType, left.IssueType, right.IssueType)
-
-	// Merge updated_at - take the max
-	result.UpdatedAt = maxTime(left.UpdatedAt, right.UpdatedAt)
-
-	// Merge closed_at - take the max
-	result.ClosedAt = maxTime(left.ClosedAt, right.ClosedAt)
-
-	// Merge dependencies - combine and deduplicate
-	result.Dependencies = mergeDependencies(left.Dependencies, right.Dependencies)
-
-	// Check if we have a real conflict
-	if hasConflict(base, left, right) {
-		return result, makeConflictWithBase(base.RawLine, left.RawLine, right.RawLine)
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return bytes.Equal(a, b)
 	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
 
-	return result, ""
+func isNullJSON(v json.RawMessage) bool {
+	return len(v) == 0 || string(bytes.TrimSpace(v)) == "null"
 }
 
-func mergeField(base, left, right string) string {
-	if base == left && base != right {
-		return right
+// sameIssue reports whether two versions of an issue have identical fields
+func sameIssue(a, b *issueVersion) bool {
+	if len(a.fields) != len(b.fields) {
+		return false
 	}
-	if base == right && base != left {
-		return left
+	for key, value := range a.fields {
+		if !sameJSON(value, b.fields[key]) {
+			return false
+		}
 	}
-	// Both changed to same value or no change
-	return left
+	return true
 }
 
-func maxTime(t1, t2 string) string {
-	if t1 == "" && t2 == "" {
-		return ""
+// mergeSetKeys returns the keys to keep from a three-way set merge: every key
+// on either side, except those in base that one side removed
+func mergeSetKeys(base, ours, theirs []string) map[string]bool {
+	inBase := make(map[string]bool)
+	for _, k := range base {
+		inBase[k] = true
 	}
-	if t1 == "" {
-		return t2
+	inOurs := make(map[string]bool)
+	for _, k := range ours {
+		inOurs[k] = true
 	}
-	if t2 == "" {
-		return t1
+	inTheirs := make(map[string]bool)
+	for _, k := range theirs {
+		inTheirs[k] = true
 	}
-
-	// Try RFC3339Nano first (supports fractional seconds), fall back to RFC3339
-	time1, err1 := time.Parse(time.RFC3339Nano, t1)
-	if err1 != nil {
-		time1, err1 = time.Parse(time.RFC3339, t1)
-	}
-
-	time2, err2 := time.Parse(time.RFC3339Nano, t2)
-	if err2 != nil {
-		time2, err2 = time.Parse(time.RFC3339, t2)
+	keep := make(map[string]bool)
+	for _, k := range append(append([]string(nil), ours...), theirs...) {
+		if inBase[k] && !(inOurs[k] && inTheirs[k]) {
+			continue
+		}
+		keep[k] = true
 	}
+	return keep
+}
 
-	// If both fail to parse, return t2 as fallback
-	if err1 != nil && err2 != nil {
-		return t2
+func mergeLabelSets(base, ours, theirs *types.Issue) []string {
+	var baseLabels []string
+	if base != nil {
+		baseLabels = base.Labels
 	}
-	// If only t1 failed to parse, return t2
-	if err1 != nil {
-		return t2
+	keep := mergeSetKeys(baseLabels, ours.Labels, theirs.Labels)
+	var labels []string
+	for label := range keep {
+		labels = append(labels, label)
 	}
-	// If only t2 failed to parse, return t1
-	if err2 != nil {
-		return t1
-	}
-
-	if time1.After(time2) {
-		return t1
-	}
-	return t2
+	return labels
 }
 
-func mergeDependencies(left, right []Dependency) []Dependency {
-	seen := make(map[string]bool)
-	var result []Dependency
+func dependencyKey(dep *types.Dependency) string {
+	return dep.DependsOnID + "\x00" + string(dep.Type)
+}
 
-	for _, dep := range left {
-		key := fmt.Sprintf("%s:%s:%s", dep.IssueID, dep.DependsOnID, dep.Type)
-		if !seen[key] {
-			seen[key] = true
-			result = append(result, dep)
+func mergeDependencySets(base, ours, theirs *types.Issue) []*types.Dependency {
+	keysOf := func(issue *types.Issue) []string {
+		if issue == nil {
+			return nil
+		}
+		var keys []string
+		for _, dep := range issue.Dependencies {
+			keys = append(keys, dependencyKey(dep))
 		}
+		return keys
 	}
+	keep := mergeSetKeys(keysOf(base), keysOf(ours), keysOf(theirs))
 
-	for _, dep := range right {
-		key := fmt.Sprintf("%s:%s:%s", dep.IssueID, dep.DependsOnID, dep.Type)
-		if !seen[key] {
+	var deps []*types.Dependency
+	seen := make(map[string]bool)
+	for _, dep := range append(append([]*types.Dependency(nil), ours.Dependencies...), theirs.Dependencies...) {
+		key := dependencyKey(dep)
+		if keep[key] && !seen[key] {
 			seen[key] = true
-			result = append(result, dep)
+			deps = append(deps, dep)
 		}
 	}
-
-	return result
+	return deps
 }
 
-func hasConflict(base, left, right Issue) bool {
-	// Check if any field has conflicting changes
-	if base.Title != left.Title && base.Title != right.Title && left.Title != right.Title {
-		return true
-	}
-	if base.Description != left.Description && base.Description != right.Description && left.Description != right.Description {
-		return true
-	}
-	if base.Notes != left.Notes && base.Notes != right.Notes && left.Notes != right.Notes {
-		return true
-	}
-	if base.Status != left.Status && base.Status != right.Status && left.Status != right.Status {
-		return true
-	}
-	if base.Priority != left.Priority && base.Priority != right.Priority && left.Priority != right.Priority {
-		return true
-	}
-	if base.IssueType != left.IssueType && base.IssueType != right.IssueType && left.IssueType != right.IssueType {
-		return true
-	}
-	return false
+// commentKey identifies a comment across databases, as the importer does:
+// comment IDs are local to each database
+func commentKey(c *types.Comment) string {
+	return c.Author + "\x00" + strings.TrimSpace(c.FirstText())
 }
 
-func issuesEqual(a, b Issue) bool {
-	// Use go-cmp for deep equality comparison, ignoring RawLine field
-	return cmp.Equal(a, b, cmp.FilterPath(func(p cmp.Path) bool {
-		return p.String() == "RawLine"
-	}, cmp.Ignore()))
-}
-
-func makeConflict(left, right string) string {
-	conflict := "<<<<<<< left\n"
-	if left != "" {
-		conflict += left + "\n"
-	}
-	conflict += "=======\n"
-	if right != "" {
-		conflict += right + "\n"
+// mergeCommentSets unions comments from both sides. A thread resolved on
+// either side stays resolved, a comment deleted on either side stays deleted,
+// and the newer edit of a comment wins. Comments only on their side are renumbered if
+// their ID is taken on ours, so replies stay attached to the right parent.
+func mergeCommentSets(base, ours, theirs *types.Issue) []*types.Comment {
+	keysOf := func(issue *types.Issue) []string {
+		if issue == nil {
+			return nil
+		}
+		var keys []string
+		for _, c := range issue.Comments {
+			keys = append(keys, commentKey(c))
+		}
+		return keys
 	}
-	conflict += ">>>>>>> right\n"
-	return conflict
-}
+	keep := mergeSetKeys(keysOf(base), keysOf(ours), keysOf(theirs))
 
-func makeConflictWithBase(base, left, right string) string {
-	conflict := "<<<<<<< left\n"
-	if left != "" {
-		conflict += left + "\n"
+	var comments []*types.Comment
+	byKey := make(map[string]*types.Comment)
+	usedIDs := make(map[int64]bool)
+	var maxID int64
+	for _, c := range ours.Comments {
+		key := commentKey(c)
+		if !keep[key] || byKey[key] != nil {
+			continue
+		}
+		copied := *c
+		byKey[key] = &copied
+		comments = append(comments, &copied)
+		usedIDs[c.ID] = true
+		if c.ID > maxID {
+			maxID = c.ID
+		}
 	}
-	conflict += "||||||| base\n"
-	if base != "" {
-		conflict += base + "\n"
+	for _, c := range theirs.Comments {
+		if c.ID > maxID {
+			maxID = c.ID
+		}
 	}
-	conflict += "=======\n"
-	if right != "" {
-		conflict += right + "\n"
+
+	// Their comment IDs as they appear in the merged output
+	remap := make(map[int64]int64)
+	var added []*types.Comment
+	for _, c := range theirs.Comments {
+		key := commentKey(c)
+		if !keep[key] {
+			continue
+		}
+		if existing := byKey[key]; existing != nil {
+			remap[c.ID] = existing.ID
+			existing.Resolved = existing.Resolved || c.Resolved
+			if c.EditedAt != nil && (existing.EditedAt == nil || c.EditedAt.After(*existing.EditedAt)) {
+				existing.Text, existing.OriginalText, existing.EditedAt = c.Text, c.OriginalText, c.EditedAt
+			}
+			if existing.DeletedAt == nil {
+				existing.DeletedAt = c.DeletedAt
+			}
+			continue
+		}
+		copied := *c
+		if usedIDs[copied.ID] {
+			maxID++
+			copied.ID = maxID
+		}
+		usedIDs[copied.ID] = true
+		remap[c.ID] = copied.ID
+		byKey[key] = &copied
+		added = append(added, &copied)
+	}
+	for _, c := range added {
+		if c.ParentCommentID != nil {
+			if parent, ok := remap[*c.ParentCommentID]; ok {
+				c.ParentCommentID = &parent
+			}
+		}
 	}
-	conflict += ">>>>>>> right\n"
-	return conflict
+	return append(comments, added...)
 }
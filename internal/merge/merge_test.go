@@ -1,700 +1,243 @@
 package merge
 
 import (
+	"bytes"
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
-)
+	"time"
 
-// TestMergeField tests the basic field merging logic
-func TestMergeField(t *testing.T) {
-	tests := []struct {
-		name     string
-		base     string
-		left     string
-		right    string
-		expected string
-	}{
-		{
-			name:     "no changes",
-			base:     "original",
-			left:     "original",
-			right:    "original",
-			expected: "original",
-		},
-		{
-			name:     "left changed",
-			base:     "original",
-			left:     "left-changed",
-			right:    "original",
-			expected: "left-changed",
-		},
-		{
-			name:     "right changed",
-			base:     "original",
-			left:     "original",
-			right:    "right-changed",
-			expected: "right-changed",
-		},
-		{
-			name:     "both changed to same value",
-			base:     "original",
-			left:     "both-changed",
-			right:    "both-changed",
-			expected: "both-changed",
-		},
-		{
-			name:     "both changed to different values - prefers left",
-			base:     "original",
-			left:     "left-value",
-			right:    "right-value",
-			expected: "left-value",
-		},
-	}
+	"github.com/steveyegge/beads/internal/types"
+)
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := mergeField(tt.base, tt.left, tt.right)
-			if result != tt.expected {
-				t.Errorf("mergeField() = %q, want %q", result, tt.expected)
-			}
-		})
+var mergeT0 = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// mergeTestIssue returns an issue last updated minutes after mergeT0
+func mergeTestIssue(id, title string, minutes int) *types.Issue {
+	return &types.Issue{
+		ID:        id,
+		Title:     title,
+		Status:    types.StatusOpen,
+		Priority:  2,
+		IssueType: types.TypeTask,
+		CreatedAt: mergeT0,
+		UpdatedAt: mergeT0.Add(time.Duration(minutes) * time.Minute),
 	}
 }
 
-// TestMergeDependencies tests dependency union and deduplication
-func TestMergeDependencies(t *testing.T) {
-	tests := []struct {
-		name     string
-		left     []Dependency
-		right    []Dependency
-		expected []Dependency
-	}{
-		{
-			name:     "empty both sides",
-			left:     []Dependency{},
-			right:    []Dependency{},
-			expected: []Dependency{},
-		},
-		{
-			name: "only left has deps",
-			left: []Dependency{
-				{IssueID: "bd-1", DependsOnID: "bd-2", Type: "blocks", CreatedAt: "2024-01-01T00:00:00Z"},
-			},
-			right: []Dependency{},
-			expected: []Dependency{
-				{IssueID: "bd-1", DependsOnID: "bd-2", Type: "blocks", CreatedAt: "2024-01-01T00:00:00Z"},
-			},
-		},
-		{
-			name: "only right has deps",
-			left: []Dependency{},
-			right: []Dependency{
-				{IssueID: "bd-1", DependsOnID: "bd-3", Type: "related", CreatedAt: "2024-01-01T00:00:00Z"},
-			},
-			expected: []Dependency{
-				{IssueID: "bd-1", DependsOnID: "bd-3", Type: "related", CreatedAt: "2024-01-01T00:00:00Z"},
-			},
-		},
-		{
-			name: "union of different deps",
-			left: []Dependency{
-				{IssueID: "bd-1", DependsOnID: "bd-2", Type: "blocks", CreatedAt: "2024-01-01T00:00:00Z"},
-			},
-			right: []Dependency{
-				{IssueID: "bd-1", DependsOnID: "bd-3", Type: "related", CreatedAt: "2024-01-01T00:00:00Z"},
-			},
-			expected: []Dependency{
-				{IssueID: "bd-1", DependsOnID: "bd-2", Type: "blocks", CreatedAt: "2024-01-01T00:00:00Z"},
-				{IssueID: "bd-1", DependsOnID: "bd-3", Type: "related", CreatedAt: "2024-01-01T00:00:00Z"},
-			},
-		},
-		{
-			name: "deduplication of identical deps",
-			left: []Dependency{
-				{IssueID: "bd-1", DependsOnID: "bd-2", Type: "blocks", CreatedAt: "2024-01-01T00:00:00Z"},
-			},
-			right: []Dependency{
-				{IssueID: "bd-1", DependsOnID: "bd-2", Type: "blocks", CreatedAt: "2024-01-02T00:00:00Z"}, // Different timestamp but same logical dep
-			},
-			expected: []Dependency{
-				{IssueID: "bd-1", DependsOnID: "bd-2", Type: "blocks", CreatedAt: "2024-01-01T00:00:00Z"},
-			},
-		},
-		{
-			name: "multiple deps with dedup",
-			left: []Dependency{
-				{IssueID: "bd-1", DependsOnID: "bd-2", Type: "blocks", CreatedAt: "2024-01-01T00:00:00Z"},
-				{IssueID: "bd-1", DependsOnID: "bd-3", Type: "related", CreatedAt: "2024-01-01T00:00:00Z"},
-			},
-			right: []Dependency{
-				{IssueID: "bd-1", DependsOnID: "bd-2", Type: "blocks", CreatedAt: "2024-01-02T00:00:00Z"},
-				{IssueID: "bd-1", DependsOnID: "bd-4", Type: "blocks", CreatedAt: "2024-01-01T00:00:00Z"},
-			},
-			expected: []Dependency{
-				{IssueID: "bd-1", DependsOnID: "bd-2", Type: "blocks", CreatedAt: "2024-01-01T00:00:00Z"},
-				{IssueID: "bd-1", DependsOnID: "bd-3", Type: "related", CreatedAt: "2024-01-01T00:00:00Z"},
-				{IssueID: "bd-1", DependsOnID: "bd-4", Type: "blocks", CreatedAt: "2024-01-01T00:00:00Z"},
-			},
-		},
+func toJSONL(t *testing.T, issues ...*types.Issue) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, issue := range issues {
+		if err := encoder.Encode(issue); err != nil {
+			t.Fatalf("failed to encode %s: %v", issue.ID, err)
+		}
 	}
+	return buf.Bytes()
+}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := mergeDependencies(tt.left, tt.right)
-			if len(result) != len(tt.expected) {
-				t.Errorf("mergeDependencies() returned %d deps, want %d", len(result), len(tt.expected))
-				return
-			}
-			// Check each expected dep is present
-			for _, exp := range tt.expected {
-				found := false
-				for _, res := range result {
-					if res.IssueID == exp.IssueID &&
-						res.DependsOnID == exp.DependsOnID &&
-						res.Type == exp.Type {
-						found = true
-						break
-					}
-				}
-				if !found {
-					t.Errorf("expected dependency %+v not found in result", exp)
-				}
-			}
-		})
+func fromJSONL(t *testing.T, data []byte) map[string]*types.Issue {
+	t.Helper()
+	issues := make(map[string]*types.Issue)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		var issue types.Issue
+		if err := json.Unmarshal([]byte(line), &issue); err != nil {
+			t.Fatalf("merged output has invalid line %q: %v", line, err)
+		}
+		issues[issue.ID] = &issue
 	}
+	return issues
 }
 
-// TestMaxTime tests timestamp merging (max wins)
-func TestMaxTime(t *testing.T) {
-	tests := []struct {
-		name     string
-		t1       string
-		t2       string
-		expected string
-	}{
-		{
-			name:     "both empty",
-			t1:       "",
-			t2:       "",
-			expected: "",
-		},
-		{
-			name:     "t1 empty",
-			t1:       "",
-			t2:       "2024-01-02T00:00:00Z",
-			expected: "2024-01-02T00:00:00Z",
-		},
-		{
-			name:     "t2 empty",
-			t1:       "2024-01-01T00:00:00Z",
-			t2:       "",
-			expected: "2024-01-01T00:00:00Z",
-		},
-		{
-			name:     "t1 newer",
-			t1:       "2024-01-02T00:00:00Z",
-			t2:       "2024-01-01T00:00:00Z",
-			expected: "2024-01-02T00:00:00Z",
-		},
-		{
-			name:     "t2 newer",
-			t1:       "2024-01-01T00:00:00Z",
-			t2:       "2024-01-02T00:00:00Z",
-			expected: "2024-01-02T00:00:00Z",
-		},
-		{
-			name:     "identical timestamps",
-			t1:       "2024-01-01T00:00:00Z",
-			t2:       "2024-01-01T00:00:00Z",
-			expected: "2024-01-01T00:00:00Z",
-		},
-		{
-			name:     "with fractional seconds (RFC3339Nano)",
-			t1:       "2024-01-01T00:00:00.123456Z",
-			t2:       "2024-01-01T00:00:00.123455Z",
-			expected: "2024-01-01T00:00:00.123456Z",
-		},
-		{
-			name:     "invalid timestamps - returns t2 as fallback",
-			t1:       "invalid",
-			t2:       "also-invalid",
-			expected: "also-invalid",
-		},
+func TestMergeJSONL_AddAdd(t *testing.T) {
+	base := toJSONL(t, mergeTestIssue("bd-1", "Shared", 0))
+	sameOurs := mergeTestIssue("bd-2", "Same on both", 5)
+	sameTheirs := mergeTestIssue("bd-2", "Same on both", 5)
+	ours := toJSONL(t, mergeTestIssue("bd-1", "Shared", 0), sameOurs, mergeTestIssue("bd-3", "Only ours", 5))
+	theirs := toJSONL(t, mergeTestIssue("bd-1", "Shared", 0), sameTheirs, mergeTestIssue("bd-4", "Only theirs", 5))
+
+	merged, notes, err := MergeJSONL(base, ours, theirs)
+	if err != nil {
+		t.Fatalf("MergeJSONL failed: %v", err)
+	}
+	got := fromJSONL(t, merged)
+	if len(got) != 4 {
+		t.Fatalf("expected 4 issues, got %d:\n%s", len(got), merged)
+	}
+	if len(notes) != 0 {
+		t.Errorf("expected no notes for non-overlapping adds, got %v", notes)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := maxTime(tt.t1, tt.t2)
-			if result != tt.expected {
-				t.Errorf("maxTime() = %q, want %q", result, tt.expected)
-			}
-		})
+	// The same ID added on both sides with different content merges per field
+	ours = toJSONL(t, mergeTestIssue("bd-5", "Our title", 1))
+	theirsIssue := mergeTestIssue("bd-5", "Their title", 9)
+	theirsIssue.Priority = 0
+	theirs = toJSONL(t, theirsIssue)
+	merged, notes, err = MergeJSONL(nil, ours, theirs)
+	if err != nil {
+		t.Fatalf("MergeJSONL failed: %v", err)
+	}
+	issue := fromJSONL(t, merged)["bd-5"]
+	if issue.Title != "Their title" || issue.Priority != 0 {
+		t.Errorf("expected the newer side's fields, got %q P%d", issue.Title, issue.Priority)
+	}
+	if len(notes) != 2 {
+		t.Errorf("expected a note per conflicting field, got %v", notes)
 	}
 }
 
-// TestMerge3Way_SimpleUpdates tests simple field update scenarios
-func TestMerge3Way_SimpleUpdates(t *testing.T) {
-	base := []Issue{
-		{
-			ID:        "bd-abc123",
-			Title:     "Original title",
-			Status:    "open",
-			Priority:  2,
-			CreatedAt: "2024-01-01T00:00:00Z",
-			UpdatedAt: "2024-01-01T00:00:00Z",
-			CreatedBy: "user1",
-			RawLine:   `{"id":"bd-abc123","title":"Original title","status":"open","priority":2,"created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-		},
+func TestMergeJSONL_EditDifferentFields(t *testing.T) {
+	baseIssue := mergeTestIssue("bd-1", "Original", 0)
+	baseIssue.Labels = []string{"backend", "old"}
+	base := toJSONL(t, baseIssue)
+
+	ourIssue := mergeTestIssue("bd-1", "Renamed", 10) // ours is newer
+	ourIssue.Labels = []string{"backend", "ours"}
+	ourIssue.Comments = []*types.Comment{{ID: 1, IssueID: "bd-1", Author: "alice", Text: "from ours", CreatedAt: mergeT0}}
+
+	closedAt := mergeT0.Add(5 * time.Minute)
+	theirIssue := mergeTestIssue("bd-1", "Original", 5)
+	theirIssue.Status = types.StatusClosed
+	theirIssue.ClosedAt = &closedAt
+	theirIssue.Assignee = "bob"
+	theirIssue.Labels = []string{"backend", "old", "theirs"}
+	theirIssue.Dependencies = []*types.Dependency{{IssueID: "bd-1", DependsOnID: "bd-9", Type: types.DepBlocks, CreatedAt: mergeT0}}
+	parent := int64(1)
+	theirIssue.Comments = []*types.Comment{
+		{ID: 1, IssueID: "bd-1", Author: "bob", Text: "from theirs", CreatedAt: mergeT0.Add(time.Minute)},
+		{ID: 2, IssueID: "bd-1", ParentCommentID: &parent, Author: "bob", Text: "reply", CreatedAt: mergeT0.Add(2 * time.Minute)},
 	}
 
-	t.Run("left updates title", func(t *testing.T) {
-		left := []Issue{
-			{
-				ID:        "bd-abc123",
-				Title:     "Updated title",
-				Status:    "open",
-				Priority:  2,
-				CreatedAt: "2024-01-01T00:00:00Z",
-				UpdatedAt: "2024-01-02T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","title":"Updated title","status":"open","priority":2,"created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-02T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-		right := base
-
-		result, conflicts := merge3Way(base, left, right)
-		if len(conflicts) != 0 {
-			t.Errorf("unexpected conflicts: %v", conflicts)
-		}
-		if len(result) != 1 {
-			t.Fatalf("expected 1 issue, got %d", len(result))
-		}
-		if result[0].Title != "Updated title" {
-			t.Errorf("expected title 'Updated title', got %q", result[0].Title)
-		}
-	})
-
-	t.Run("right updates status", func(t *testing.T) {
-		left := base
-		right := []Issue{
-			{
-				ID:        "bd-abc123",
-				Title:     "Original title",
-				Status:    "in_progress",
-				Priority:  2,
-				CreatedAt: "2024-01-01T00:00:00Z",
-				UpdatedAt: "2024-01-02T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","title":"Original title","status":"in_progress","priority":2,"created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-02T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-
-		result, conflicts := merge3Way(base, left, right)
-		if len(conflicts) != 0 {
-			t.Errorf("unexpected conflicts: %v", conflicts)
-		}
-		if len(result) != 1 {
-			t.Fatalf("expected 1 issue, got %d", len(result))
-		}
-		if result[0].Status != "in_progress" {
-			t.Errorf("expected status 'in_progress', got %q", result[0].Status)
-		}
-	})
+	merged, notes, err := MergeJSONL(base, toJSONL(t, ourIssue), toJSONL(t, theirIssue))
+	if err != nil {
+		t.Fatalf("MergeJSONL failed: %v", err)
+	}
+	if len(notes) != 0 {
+		t.Errorf("expected no notes for edits to different fields, got %v", notes)
+	}
+	issue := fromJSONL(t, merged)["bd-1"]
 
-	t.Run("both update different fields", func(t *testing.T) {
-		left := []Issue{
-			{
-				ID:        "bd-abc123",
-				Title:     "Updated title",
-				Status:    "open",
-				Priority:  2,
-				CreatedAt: "2024-01-01T00:00:00Z",
-				UpdatedAt: "2024-01-02T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","title":"Updated title","status":"open","priority":2,"created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-02T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-		right := []Issue{
-			{
-				ID:        "bd-abc123",
-				Title:     "Original title",
-				Status:    "in_progress",
-				Priority:  2,
-				CreatedAt: "2024-01-01T00:00:00Z",
-				UpdatedAt: "2024-01-02T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","title":"Original title","status":"in_progress","priority":2,"created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-02T00:00:00Z","created_by":"user1"}`,
-			},
-		}
+	if issue.Title != "Renamed" {
+		t.Errorf("title = %q, want our rename", issue.Title)
+	}
+	if issue.Status != types.StatusClosed || issue.ClosedAt == nil || !issue.ClosedAt.Equal(closedAt) {
+		t.Errorf("expected their close with its closed_at, got %s %v", issue.Status, issue.ClosedAt)
+	}
+	if issue.Assignee != "bob" {
+		t.Errorf("assignee = %q, want bob", issue.Assignee)
+	}
+	if !issue.UpdatedAt.Equal(ourIssue.UpdatedAt) {
+		t.Errorf("updated_at = %v, want the newer %v", issue.UpdatedAt, ourIssue.UpdatedAt)
+	}
+	// "old" was removed on our side, so it stays removed
+	if got := strings.Join(issue.Labels, ","); got != "backend,ours,theirs" {
+		t.Errorf("labels = %s, want backend,ours,theirs", got)
+	}
+	if len(issue.Dependencies) != 1 || issue.Dependencies[0].DependsOnID != "bd-9" {
+		t.Errorf("expected their dependency, got %+v", issue.Dependencies)
+	}
 
-		result, conflicts := merge3Way(base, left, right)
-		if len(conflicts) != 0 {
-			t.Errorf("unexpected conflicts: %v", conflicts)
-		}
-		if len(result) != 1 {
-			t.Fatalf("expected 1 issue, got %d", len(result))
-		}
-		if result[0].Title != "Updated title" {
-			t.Errorf("expected title 'Updated title', got %q", result[0].Title)
-		}
-		if result[0].Status != "in_progress" {
-			t.Errorf("expected status 'in_progress', got %q", result[0].Status)
-		}
-	})
+	if len(issue.Comments) != 3 {
+		t.Fatalf("expected comments from both sides, got %+v", issue.Comments)
+	}
+	byText := make(map[string]*types.Comment)
+	for _, c := range issue.Comments {
+		byText[c.Text] = c
+	}
+	theirRoot, reply := byText["from theirs"], byText["reply"]
+	if theirRoot.ID == byText["from ours"].ID {
+		t.Errorf("their comment kept colliding ID %d", theirRoot.ID)
+	}
+	if reply.ParentCommentID == nil || *reply.ParentCommentID != theirRoot.ID {
+		t.Errorf("reply parent = %v, want renumbered %d", reply.ParentCommentID, theirRoot.ID)
+	}
 }
 
-// TestMerge3Way_Conflicts tests conflict detection
-func TestMerge3Way_Conflicts(t *testing.T) {
-	t.Run("conflicting title changes", func(t *testing.T) {
-		base := []Issue{
-			{
-				ID:        "bd-abc123",
-				Title:     "Original",
-				CreatedAt: "2024-01-01T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","title":"Original","created_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-		left := []Issue{
-			{
-				ID:        "bd-abc123",
-				Title:     "Left version",
-				CreatedAt: "2024-01-01T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","title":"Left version","created_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-		right := []Issue{
-			{
-				ID:        "bd-abc123",
-				Title:     "Right version",
-				CreatedAt: "2024-01-01T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","title":"Right version","created_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-
-		result, conflicts := merge3Way(base, left, right)
-		if len(conflicts) == 0 {
-			t.Error("expected conflict for divergent title changes")
-		}
-		if len(result) != 0 {
-			t.Errorf("expected no merged issues with conflict, got %d", len(result))
-		}
-		if len(conflicts) > 0 {
-			if conflicts[0] == "" {
-				t.Error("conflict marker should not be empty")
-			}
-		}
-	})
+func TestMergeJSONL_EditSameField(t *testing.T) {
+	base := toJSONL(t, mergeTestIssue("bd-1", "Original", 0))
+	ours := toJSONL(t, mergeTestIssue("bd-1", "Our title", 3))
+	theirs := toJSONL(t, mergeTestIssue("bd-1", "Their title", 7))
 
-	t.Run("conflicting priority changes", func(t *testing.T) {
-		base := []Issue{
-			{
-				ID:        "bd-abc123",
-				Priority:  2,
-				CreatedAt: "2024-01-01T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","priority":2,"created_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-		left := []Issue{
-			{
-				ID:        "bd-abc123",
-				Priority:  0,
-				CreatedAt: "2024-01-01T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","priority":0,"created_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-		right := []Issue{
-			{
-				ID:        "bd-abc123",
-				Priority:  1,
-				CreatedAt: "2024-01-01T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","priority":1,"created_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-
-		result, conflicts := merge3Way(base, left, right)
-		if len(conflicts) == 0 {
-			t.Error("expected conflict for divergent priority changes")
-		}
-		if len(result) != 0 {
-			t.Errorf("expected no merged issues with conflict, got %d", len(result))
-		}
-	})
+	merged, notes, err := MergeJSONL(base, ours, theirs)
+	if err != nil {
+		t.Fatalf("MergeJSONL failed: %v", err)
+	}
+	if got := fromJSONL(t, merged)["bd-1"].Title; got != "Their title" {
+		t.Errorf("title = %q, want the newer edit", got)
+	}
+	if len(notes) != 1 || !strings.Contains(notes[0], "title changed on both sides; kept theirs") {
+		t.Errorf("notes = %v", notes)
+	}
 }
 
-// TestMerge3Way_Deletions tests deletion detection scenarios
-func TestMerge3Way_Deletions(t *testing.T) {
-	t.Run("deleted in left, unchanged in right", func(t *testing.T) {
-		base := []Issue{
-			{
-				ID:        "bd-abc123",
-				Title:     "Will be deleted",
-				CreatedAt: "2024-01-01T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","title":"Will be deleted","created_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-		left := []Issue{} // Deleted in left
-		right := base     // Unchanged in right
-
-		result, conflicts := merge3Way(base, left, right)
-		if len(conflicts) != 0 {
-			t.Errorf("unexpected conflicts: %v", conflicts)
-		}
-		if len(result) != 0 {
-			t.Errorf("expected deletion to be accepted, got %d issues", len(result))
-		}
-	})
-
-	t.Run("deleted in right, unchanged in left", func(t *testing.T) {
-		base := []Issue{
-			{
-				ID:        "bd-abc123",
-				Title:     "Will be deleted",
-				CreatedAt: "2024-01-01T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","title":"Will be deleted","created_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-		left := base     // Unchanged in left
-		right := []Issue{} // Deleted in right
-
-		result, conflicts := merge3Way(base, left, right)
-		if len(conflicts) != 0 {
-			t.Errorf("unexpected conflicts: %v", conflicts)
-		}
-		if len(result) != 0 {
-			t.Errorf("expected deletion to be accepted, got %d issues", len(result))
-		}
-	})
-
-	t.Run("deleted in left, modified in right - conflict", func(t *testing.T) {
-		base := []Issue{
-			{
-				ID:        "bd-abc123",
-				Title:     "Original",
-				Status:    "open",
-				CreatedAt: "2024-01-01T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","title":"Original","status":"open","created_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-		left := []Issue{} // Deleted in left
-		right := []Issue{ // Modified in right
-			{
-				ID:        "bd-abc123",
-				Title:     "Modified",
-				Status:    "in_progress",
-				CreatedAt: "2024-01-01T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","title":"Modified","status":"in_progress","created_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-
-		result, conflicts := merge3Way(base, left, right)
-		if len(conflicts) == 0 {
-			t.Error("expected conflict for delete vs modify")
-		}
-		if len(result) != 0 {
-			t.Errorf("expected no merged issues with conflict, got %d", len(result))
-		}
-	})
+func TestMergeJSONL_EditDelete(t *testing.T) {
+	base := toJSONL(t, mergeTestIssue("bd-1", "Edited then deleted", 0), mergeTestIssue("bd-2", "Deleted", 0))
+	ours := toJSONL(t, mergeTestIssue("bd-1", "Edited on our side", 4), mergeTestIssue("bd-2", "Deleted", 0))
+	theirs := []byte{} // Both deleted on their side
 
-	t.Run("deleted in right, modified in left - conflict", func(t *testing.T) {
-		base := []Issue{
-			{
-				ID:        "bd-abc123",
-				Title:     "Original",
-				Status:    "open",
-				CreatedAt: "2024-01-01T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","title":"Original","status":"open","created_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-		left := []Issue{ // Modified in left
-			{
-				ID:        "bd-abc123",
-				Title:     "Modified",
-				Status:    "in_progress",
-				CreatedAt: "2024-01-01T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","title":"Modified","status":"in_progress","created_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-		right := []Issue{} // Deleted in right
-
-		result, conflicts := merge3Way(base, left, right)
-		if len(conflicts) == 0 {
-			t.Error("expected conflict for modify vs delete")
-		}
-		if len(result) != 0 {
-			t.Errorf("expected no merged issues with conflict, got %d", len(result))
-		}
-	})
+	merged, notes, err := MergeJSONL(base, ours, theirs)
+	if err != nil {
+		t.Fatalf("MergeJSONL failed: %v", err)
+	}
+	got := fromJSONL(t, merged)
+	if issue, ok := got["bd-1"]; !ok || issue.Title != "Edited on our side" {
+		t.Errorf("expected the edited issue to survive its deletion, got %+v", got)
+	}
+	if _, ok := got["bd-2"]; ok {
+		t.Errorf("expected the unchanged issue's deletion to be accepted")
+	}
+	if len(notes) != 1 || !strings.Contains(notes[0], "bd-1: deleted on their side but edited on ours") {
+		t.Errorf("notes = %v", notes)
+	}
 }
 
-// TestMerge3Way_Additions tests issue addition scenarios
-func TestMerge3Way_Additions(t *testing.T) {
-	t.Run("added only in left", func(t *testing.T) {
-		base := []Issue{}
-		left := []Issue{
-			{
-				ID:        "bd-abc123",
-				Title:     "New issue",
-				CreatedAt: "2024-01-01T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","title":"New issue","created_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-		right := []Issue{}
-
-		result, conflicts := merge3Way(base, left, right)
-		if len(conflicts) != 0 {
-			t.Errorf("unexpected conflicts: %v", conflicts)
-		}
-		if len(result) != 1 {
-			t.Fatalf("expected 1 issue, got %d", len(result))
-		}
-		if result[0].Title != "New issue" {
-			t.Errorf("expected title 'New issue', got %q", result[0].Title)
-		}
-	})
-
-	t.Run("added only in right", func(t *testing.T) {
-		base := []Issue{}
-		left := []Issue{}
-		right := []Issue{
-			{
-				ID:        "bd-abc123",
-				Title:     "New issue",
-				CreatedAt: "2024-01-01T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","title":"New issue","created_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-
-		result, conflicts := merge3Way(base, left, right)
-		if len(conflicts) != 0 {
-			t.Errorf("unexpected conflicts: %v", conflicts)
-		}
-		if len(result) != 1 {
-			t.Fatalf("expected 1 issue, got %d", len(result))
-		}
-		if result[0].Title != "New issue" {
-			t.Errorf("expected title 'New issue', got %q", result[0].Title)
-		}
-	})
-
-	t.Run("added in both with identical content", func(t *testing.T) {
-		base := []Issue{}
-		issueData := Issue{
-			ID:        "bd-abc123",
-			Title:     "New issue",
-			Status:    "open",
-			Priority:  2,
-			CreatedAt: "2024-01-01T00:00:00Z",
-			CreatedBy: "user1",
-			RawLine:   `{"id":"bd-abc123","title":"New issue","status":"open","priority":2,"created_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-		}
-		left := []Issue{issueData}
-		right := []Issue{issueData}
-
-		result, conflicts := merge3Way(base, left, right)
-		if len(conflicts) != 0 {
-			t.Errorf("unexpected conflicts: %v", conflicts)
-		}
-		if len(result) != 1 {
-			t.Fatalf("expected 1 issue, got %d", len(result))
-		}
-	})
-
-	t.Run("added in both with different content - conflict", func(t *testing.T) {
-		base := []Issue{}
-		left := []Issue{
-			{
-				ID:        "bd-abc123",
-				Title:     "Left version",
-				CreatedAt: "2024-01-01T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","title":"Left version","created_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-		right := []Issue{
-			{
-				ID:        "bd-abc123",
-				Title:     "Right version",
-				CreatedAt: "2024-01-01T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-abc123","title":"Right version","created_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-			},
-		}
-
-		result, conflicts := merge3Way(base, left, right)
-		if len(conflicts) == 0 {
-			t.Error("expected conflict for different additions")
-		}
-		if len(result) != 0 {
-			t.Errorf("expected no merged issues with conflict, got %d", len(result))
-		}
-	})
+func TestMergeJSONL_InvalidInput(t *testing.T) {
+	if _, _, err := MergeJSONL(nil, []byte("<<<<<<< HEAD\n"), nil); err == nil {
+		t.Error("expected an error for a JSONL with conflict markers")
+	}
 }
 
 // TestMerge3Way_ResurrectionPrevention tests bd-hv01 regression
 func TestMerge3Way_ResurrectionPrevention(t *testing.T) {
 	t.Run("bd-hv01 regression: closed issue not resurrected", func(t *testing.T) {
 		// Base: issue is open
-		base := []Issue{
-			{
-				ID:        "bd-hv01",
-				Title:     "Test issue",
-				Status:    "open",
-				ClosedAt:  "",
-				CreatedAt: "2024-01-01T00:00:00Z",
-				UpdatedAt: "2024-01-01T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-hv01","title":"Test issue","status":"open","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z","created_by":"user1"}`,
-			},
-		}
+		baseIssue := mergeTestIssue("bd-hv01", "Test issue", 0)
 		// Left: issue is closed (newer)
-		left := []Issue{
-			{
-				ID:        "bd-hv01",
-				Title:     "Test issue",
-				Status:    "closed",
-				ClosedAt:  "2024-01-02T00:00:00Z",
-				CreatedAt: "2024-01-01T00:00:00Z",
-				UpdatedAt: "2024-01-02T00:00:00Z",
-				CreatedBy: "user1",
-				RawLine:   `{"id":"bd-hv01","title":"Test issue","status":"closed","closed_at":"2024-01-02T00:00:00Z","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-02T00:00:00Z","created_by":"user1"}`,
-			},
-		}
+		closedAt := mergeT0.Add(24 * time.Hour)
+		leftIssue := mergeTestIssue("bd-hv01", "Test issue", 24*60)
+		leftIssue.Status = types.StatusClosed
+		leftIssue.ClosedAt = &closedAt
 		// Right: issue is still open (stale)
-		right := base
+		base := toJSONL(t, baseIssue)
 
-		result, conflicts := merge3Way(base, left, right)
-		if len(conflicts) != 0 {
-			t.Errorf("unexpected conflicts: %v", conflicts)
+		merged, notes, err := MergeJSONL(base, toJSONL(t, leftIssue), base)
+		if err != nil {
+			t.Fatalf("MergeJSONL failed: %v", err)
 		}
+		if len(notes) != 0 {
+			t.Errorf("unexpected notes: %v", notes)
+		}
+		result := fromJSONL(t, merged)
 		if len(result) != 1 {
 			t.Fatalf("expected 1 issue, got %d", len(result))
 		}
+		issue := result["bd-hv01"]
 		// Issue should remain closed (left's version)
-		if result[0].Status != "closed" {
-			t.Errorf("expected status 'closed', got %q - issue was resurrected!", result[0].Status)
+		if issue.Status != types.StatusClosed {
+			t.Errorf("expected status 'closed', got %q - issue was resurrected!", issue.Status)
 		}
-		if result[0].ClosedAt == "" {
-			t.Error("expected closed_at to be set, got empty string")
+		if issue.ClosedAt == nil {
+			t.Error("expected closed_at to be set")
 		}
 		// UpdatedAt should be the max (left's newer timestamp)
-		if result[0].UpdatedAt != "2024-01-02T00:00:00Z" {
-			t.Errorf("expected updated_at '2024-01-02T00:00:00Z', got %q", result[0].UpdatedAt)
+		if !issue.UpdatedAt.Equal(leftIssue.UpdatedAt) {
+			t.Errorf("expected updated_at %v, got %v", leftIssue.UpdatedAt, issue.UpdatedAt)
 		}
 	})
 }
@@ -712,7 +255,7 @@ func TestMerge3Way_Integration(t *testing.T) {
 
 		// Base: two issues
 		baseData := `{"id":"bd-1","title":"Issue 1","status":"open","priority":2,"created_at":"2024-01-01T00:00:00Z","created_by":"user1"}
-{"id":"bd-2","title":"Issue 2","status":"open","priority":2,"created_at":"2024-01-01T00:00:00Z","created_by":"user1"}
+{"id":"bd-2","title":"Issue 2","status":"open","priority":2,"assignee":"alice","created_at":"2024-01-01T00:00:00Z","created_by":"user1"}
 `
 		if err := os.WriteFile(baseFile, []byte(baseData), 0644); err != nil {
 			t.Fatalf("failed to write base file: %v", err)
@@ -720,7 +263,7 @@ func TestMerge3Way_Integration(t *testing.T) {
 
 		// Left: update bd-1 title, add bd-3
 		leftData := `{"id":"bd-1","title":"Updated Issue 1","status":"open","priority":2,"created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-02T00:00:00Z","created_by":"user1"}
-{"id":"bd-2","title":"Issue 2","status":"open","priority":2,"created_at":"2024-01-01T00:00:00Z","created_by":"user1"}
+{"id":"bd-2","title":"Issue 2","status":"open","priority":2,"assignee":"alice","created_at":"2024-01-01T00:00:00Z","created_by":"user1"}
 {"id":"bd-3","title":"New Issue 3","status":"open","priority":1,"created_at":"2024-01-02T00:00:00Z","created_by":"user1"}
 `
 		if err := os.WriteFile(leftFile, []byte(leftData), 0644); err != nil {
@@ -729,7 +272,7 @@ func TestMerge3Way_Integration(t *testing.T) {
 
 		// Right: update bd-2 status, add bd-4
 		rightData := `{"id":"bd-1","title":"Issue 1","status":"open","priority":2,"created_at":"2024-01-01T00:00:00Z","created_by":"user1"}
-{"id":"bd-2","title":"Issue 2","status":"in_progress","priority":2,"created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-02T00:00:00Z","created_by":"user1"}
+{"id":"bd-2","title":"Issue 2","status":"in_progress","priority":2,"assignee":"alice","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-02T00:00:00Z","created_by":"user1"}
 {"id":"bd-4","title":"New Issue 4","status":"open","priority":3,"created_at":"2024-01-02T00:00:00Z","created_by":"user1"}
 `
 		if err := os.WriteFile(rightFile, []byte(rightData), 0644); err != nil {
@@ -749,17 +292,7 @@ func TestMerge3Way_Integration(t *testing.T) {
 		}
 
 		// Parse result
-		var results []Issue
-		for _, line := range splitLines(string(content)) {
-			if line == "" {
-				continue
-			}
-			var issue Issue
-			if err := json.Unmarshal([]byte(line), &issue); err != nil {
-				t.Fatalf("failed to parse output line: %v", err)
-			}
-			results = append(results, issue)
-		}
+		results := fromJSONL(t, content)
 
 		// Should have 4 issues: bd-1 (updated), bd-2 (updated), bd-3 (new), bd-4 (new)
 		if len(results) != 4 {
@@ -767,31 +300,17 @@ func TestMerge3Way_Integration(t *testing.T) {
 		}
 
 		// Verify bd-1 has updated title from left
-		found1 := false
-		for _, issue := range results {
-			if issue.ID == "bd-1" {
-				found1 = true
-				if issue.Title != "Updated Issue 1" {
-					t.Errorf("bd-1 title: expected 'Updated Issue 1', got %q", issue.Title)
-				}
-			}
-		}
-		if !found1 {
+		if issue, ok := results["bd-1"]; !ok {
 			t.Error("bd-1 not found in results")
+		} else if issue.Title != "Updated Issue 1" {
+			t.Errorf("bd-1 title: expected 'Updated Issue 1', got %q", issue.Title)
 		}
 
-		// Verify bd-2 has updated status from right
-		found2 := false
-		for _, issue := range results {
-			if issue.ID == "bd-2" {
-				found2 = true
-				if issue.Status != "in_progress" {
-					t.Errorf("bd-2 status: expected 'in_progress', got %q", issue.Status)
-				}
-			}
-		}
-		if !found2 {
+		// Verify bd-2 has updated status from right and keeps its assignee
+		if issue, ok := results["bd-2"]; !ok {
 			t.Error("bd-2 not found in results")
+		} else if issue.Status != types.StatusInProgress || issue.Assignee != "alice" {
+			t.Errorf("bd-2: expected in_progress assigned to alice, got %q assigned to %q", issue.Status, issue.Assignee)
 		}
 	})
 }
@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/steveyegge/beads/internal/debug"
 	"github.com/steveyegge/beads/internal/lockfile"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
 // rpcDebugEnabled returns true if BD_RPC_DEBUG environment variable is set
@@ -37,6 +40,8 @@ type Client struct {
 	socketPath string
 	timeout    time.Duration
 	dbPath     string // Expected database path for validation
+	actor      string // Recorded by the daemon as the actor of each request
+	readOnly   bool   // Refuse operations that change the database
 }
 
 // TryConnect attempts to connect to the daemon socket
@@ -143,6 +148,18 @@ func (c *Client) SetDatabasePath(dbPath string) {
 	c.dbPath = dbPath
 }
 
+// SetActor sets the actor the daemon attributes this client's changes to.
+// Without one the daemon records "daemon".
+func (c *Client) SetActor(actor string) {
+	c.actor = actor
+}
+
+// SetReadOnly makes the client refuse, without contacting the daemon, every
+// operation IsReadOnlyOperation doesn't allow
+func (c *Client) SetReadOnly(readOnly bool) {
+	c.readOnly = readOnly
+}
+
 // Execute sends an RPC request and waits for a response
 func (c *Client) Execute(operation string, args interface{}) (*Response, error) {
 	return c.ExecuteWithCwd(operation, args, "")
@@ -150,9 +167,21 @@ func (c *Client) Execute(operation string, args interface{}) (*Response, error)
 
 // ExecuteWithCwd sends an RPC request with an explicit cwd (or current dir if empty string)
 func (c *Client) ExecuteWithCwd(operation string, args interface{}, cwd string) (*Response, error) {
+	if err := c.writeRequest(operation, args, cwd); err != nil {
+		return nil, err
+	}
+	return c.readResponse(bufio.NewReader(c.conn))
+}
+
+// writeRequest sends an RPC request, starting the request timeout
+func (c *Client) writeRequest(operation string, args interface{}, cwd string) error {
+	if c.readOnly && !IsReadOnlyOperation(operation) {
+		return types.WithCode(types.ErrCodeReadOnly, fmt.Errorf("%s would change the database, which read-only mode forbids", operation))
+	}
+
 	argsJSON, err := json.Marshal(args)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal args: %w", err)
+		return fmt.Errorf("failed to marshal args: %w", err)
 	}
 
 	// Use provided cwd, or get current working directory for database routing
@@ -163,6 +192,7 @@ func (c *Client) ExecuteWithCwd(operation string, args interface{}, cwd string)
 	req := Request{
 		Operation:     operation,
 		Args:          argsJSON,
+		Actor:         c.actor,
 		ClientVersion: ClientVersion,
 		Cwd:           cwd,
 		ExpectedDB:    c.dbPath, // Send expected database path for validation
@@ -170,28 +200,46 @@ func (c *Client) ExecuteWithCwd(operation string, args interface{}, cwd string)
 
 	reqJSON, err := json.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	if c.timeout > 0 {
 		deadline := time.Now().Add(c.timeout)
 		if err := c.conn.SetDeadline(deadline); err != nil {
-			return nil, fmt.Errorf("failed to set deadline: %w", err)
+			return fmt.Errorf("failed to set deadline: %w", err)
 		}
 	}
 
 	writer := bufio.NewWriter(c.conn)
 	if _, err := writer.Write(reqJSON); err != nil {
-		return nil, fmt.Errorf("failed to write request: %w", err)
+		return fmt.Errorf("failed to write request: %w", err)
 	}
 	if err := writer.WriteByte('\n'); err != nil {
-		return nil, fmt.Errorf("failed to write newline: %w", err)
+		return fmt.Errorf("failed to write newline: %w", err)
 	}
 	if err := writer.Flush(); err != nil {
-		return nil, fmt.Errorf("failed to flush: %w", err)
+		return fmt.Errorf("failed to flush: %w", err)
 	}
+	return nil
+}
 
-	reader := bufio.NewReader(c.conn)
+// ResponseError is a failed daemon response. Code carries the server's
+// classification of the failure (a types.ErrCode* constant), if it had one.
+type ResponseError struct {
+	Message string
+	Code    string
+}
+
+func (e *ResponseError) Error() string {
+	return "operation failed: " + e.Message
+}
+
+// ErrorCode returns the code the daemon sent
+func (e *ResponseError) ErrorCode() string { return e.Code }
+
+// readResponse reads one response frame. A failed response is returned
+// along with its error.
+func (c *Client) readResponse(reader *bufio.Reader) (*Response, error) {
 	respLine, err := reader.ReadBytes('\n')
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response: %w", err)
@@ -203,7 +251,7 @@ func (c *Client) ExecuteWithCwd(operation string, args interface{}, cwd string)
 	}
 
 	if !resp.Success {
-		return &resp, fmt.Errorf("operation failed: %s", resp.Error)
+		return &resp, &ResponseError{Message: resp.Error, Code: resp.Code}
 	}
 
 	return &resp, nil
@@ -284,24 +332,158 @@ func (c *Client) Update(args *UpdateArgs) (*Response, error) {
 	return c.Execute(OpUpdate, args)
 }
 
+// UpdateMany applies one update to several issues atomically via the daemon.
+// The response data is the list of updated issues.
+func (c *Client) UpdateMany(args *UpdateManyArgs) (*Response, error) {
+	return c.Execute(OpUpdateMany, args)
+}
+
 // CloseIssue marks an issue as closed via the daemon.
 func (c *Client) CloseIssue(args *CloseArgs) (*Response, error) {
 	return c.Execute(OpClose, args)
 }
 
+// ReopenIssue reopens a closed issue via the daemon.
+func (c *Client) ReopenIssue(args *ReopenArgs) (*Response, error) {
+	return c.Execute(OpReopen, args)
+}
+
 // List lists issues via the daemon
 func (c *Client) List(args *ListArgs) (*Response, error) {
 	return c.Execute(OpList, args)
 }
 
+// Count counts the issues a List with args would match via the daemon
+func (c *Client) Count(args *ListArgs) (*Response, error) {
+	return c.Execute(OpCount, args)
+}
+
+// ListStream lists issues via the daemon, calling fn with each chunk as it
+// arrives rather than waiting for the whole result. Each chunk restarts the
+// request timeout. If fn fails, the rest of the stream is read and dropped so
+// the connection stays usable, and fn's error is returned. A daemon without
+// list_stream is sent a plain list request, whose result is one chunk.
+//
+// Returns the number of matching issues before the limit and offset apply,
+// or -1 if the daemon can't say.
+func (c *Client) ListStream(args *ListStreamArgs, fn func([]*types.IssueWithCounts) error) (int, error) {
+	if err := c.writeRequest(OpListStream, args, ""); err != nil {
+		return 0, err
+	}
+	reader := bufio.NewReader(c.conn)
+	var fnErr error
+	for {
+		resp, err := c.readResponse(reader)
+		if err != nil {
+			if resp != nil && resp.Error == "unknown operation: "+OpListStream {
+				return c.listUnstreamed(&args.ListArgs, fn)
+			}
+			return 0, err
+		}
+		var chunk ListChunk
+		if err := json.Unmarshal(resp.Data, &chunk); err != nil {
+			return 0, fmt.Errorf("failed to unmarshal list chunk: %w", err)
+		}
+		if fnErr == nil {
+			fnErr = fn(chunk.Issues)
+		}
+		if !chunk.More {
+			return chunk.Total, fnErr
+		}
+		if c.timeout > 0 {
+			if err := c.conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+				return 0, fmt.Errorf("failed to set deadline: %w", err)
+			}
+		}
+	}
+}
+
+// Watch streams changes recorded after args.AfterEventID to fn, a batch at a
+// time, until ctx is cancelled (which returns nil) or the daemon stops. The
+// stream has no deadline, and cancelling ctx closes the connection, so the
+// client can't be used after Watch returns.
+func (c *Client) Watch(ctx context.Context, args *WatchArgs, fn func([]WatchEvent) error) error {
+	if err := c.writeRequest(OpWatch, args, ""); err != nil {
+		return err
+	}
+	if err := c.conn.SetDeadline(time.Time{}); err != nil {
+		return fmt.Errorf("failed to clear deadline: %w", err)
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = c.conn.Close()
+		case <-done:
+		}
+	}()
+
+	reader := bufio.NewReader(c.conn)
+	for {
+		resp, err := c.readResponse(reader)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			if resp != nil && resp.Error == "unknown operation: "+OpWatch {
+				return fmt.Errorf("the running daemon doesn't support watch; restart it with 'bd daemon --stop' or use --no-daemon")
+			}
+			return err
+		}
+		var frame WatchFrame
+		if err := json.Unmarshal(resp.Data, &frame); err != nil {
+			return fmt.Errorf("failed to unmarshal watch frame: %w", err)
+		}
+		if len(frame.Events) > 0 {
+			if err := fn(frame.Events); err != nil {
+				return err
+			}
+		}
+		if !frame.More {
+			return nil
+		}
+	}
+}
+
+// listUnstreamed is ListStream's fallback for daemons that predate it. Those
+// daemons ignore an offset, so one is refused rather than returning the
+// wrong page.
+func (c *Client) listUnstreamed(args *ListArgs, fn func([]*types.IssueWithCounts) error) (int, error) {
+	if args.Offset > 0 {
+		return 0, fmt.Errorf("the running daemon doesn't support offsets; restart it with 'bd daemon --stop' or use --no-daemon")
+	}
+	resp, err := c.List(args)
+	if err != nil {
+		return 0, err
+	}
+	var issues []*types.IssueWithCounts
+	if err := json.Unmarshal(resp.Data, &issues); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal list response: %w", err)
+	}
+	total := len(issues)
+	if args.Limit > 0 {
+		total = -1
+	}
+	return total, fn(issues)
+}
+
 // Show shows an issue via the daemon
 func (c *Client) Show(args *ShowArgs) (*Response, error) {
 	return c.Execute(OpShow, args)
 }
 
 // ResolveID resolves a partial issue ID to a full ID via the daemon
+// (an ambiguous ID returns a *utils.AmbiguousIDError listing the candidates)
 func (c *Client) ResolveID(args *ResolveIDArgs) (*Response, error) {
-	return c.Execute(OpResolveID, args)
+	resp, err := c.Execute(OpResolveID, args)
+	if err != nil && resp != nil && len(resp.Data) > 0 {
+		var ambiguous utils.AmbiguousIDError
+		if json.Unmarshal(resp.Data, &ambiguous) == nil && len(ambiguous.Candidates) > 0 {
+			return resp, &ambiguous
+		}
+	}
+	return resp, err
 }
 
 // Ready gets ready work via the daemon
@@ -354,6 +536,36 @@ func (c *Client) AddComment(args *CommentAddArgs) (*Response, error) {
 	return c.Execute(OpCommentAdd, args)
 }
 
+// ResolveComment marks a comment thread resolved or unresolved via the daemon
+func (c *Client) ResolveComment(args *CommentResolveArgs) (*Response, error) {
+	return c.Execute(OpCommentResolve, args)
+}
+
+// EditComment edits a comment's text via the daemon
+func (c *Client) EditComment(args *CommentEditArgs) (*Response, error) {
+	return c.Execute(OpCommentEdit, args)
+}
+
+// DeleteComment deletes a comment via the daemon
+func (c *Client) DeleteComment(args *CommentDeleteArgs) (*Response, error) {
+	return c.Execute(OpCommentDelete, args)
+}
+
+// Lock acquires an advisory lock on an issue via the daemon
+func (c *Client) Lock(args *LockArgs) (*Response, error) {
+	return c.Execute(OpLock, args)
+}
+
+// Unlock releases an advisory lock on an issue via the daemon
+func (c *Client) Unlock(args *UnlockArgs) (*Response, error) {
+	return c.Execute(OpUnlock, args)
+}
+
+// LockStatus retrieves the advisory lock on an issue via the daemon
+func (c *Client) LockStatus(args *LockStatusArgs) (*Response, error) {
+	return c.Execute(OpLockStatus, args)
+}
+
 // Batch executes multiple operations atomically
 func (c *Client) Batch(args *BatchArgs) (*Response, error) {
 	return c.Execute(OpBatch, args)
@@ -66,3 +66,45 @@ func TestCommentOperationsViaRPC(t *testing.T) {
 		t.Fatalf("expected comment text 'first comment', got %q", comments[0].Text)
 	}
 }
+
+func TestShowWithComments(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	createResp, err := client.Create(&CreateArgs{Title: "Show comments", IssueType: "task", Priority: 2})
+	if err != nil {
+		t.Fatalf("create issue failed: %v", err)
+	}
+	var created types.Issue
+	if err := json.Unmarshal(createResp.Data, &created); err != nil {
+		t.Fatalf("failed to decode create response: %v", err)
+	}
+	if _, err := client.AddComment(&CommentAddArgs{ID: created.ID, Author: "tester", Text: "looks good"}); err != nil {
+		t.Fatalf("add comment failed: %v", err)
+	}
+
+	var details struct {
+		Comments []*types.Comment `json:"comments"`
+	}
+	showResp, err := client.Show(&ShowArgs{ID: created.ID})
+	if err != nil {
+		t.Fatalf("show failed: %v", err)
+	}
+	if err := json.Unmarshal(showResp.Data, &details); err != nil {
+		t.Fatalf("failed to decode show response: %v", err)
+	}
+	if len(details.Comments) != 0 {
+		t.Fatalf("expected no comments without Comments, got %d", len(details.Comments))
+	}
+
+	showResp, err = client.Show(&ShowArgs{ID: created.ID, Comments: true})
+	if err != nil {
+		t.Fatalf("show failed: %v", err)
+	}
+	if err := json.Unmarshal(showResp.Data, &details); err != nil {
+		t.Fatalf("failed to decode show response: %v", err)
+	}
+	if len(details.Comments) != 1 || details.Comments[0].Text != "looks good" || details.Comments[0].Author != "tester" {
+		t.Fatalf("expected the embedded thread, got %+v", details.Comments)
+	}
+}
@@ -0,0 +1,188 @@
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestListStream(t *testing.T) {
+	_, client, store, cleanup := setupTestServerWithStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	for i := 0; i < 7; i++ {
+		issue := &types.Issue{Title: fmt.Sprintf("Issue %d", i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+		if i == 0 {
+			if err := store.AddLabel(ctx, issue.ID, "first", "test"); err != nil {
+				t.Fatalf("failed to add label: %v", err)
+			}
+		}
+	}
+
+	var sizes []int
+	seen := make(map[string]bool)
+	labeled := 0
+	total, err := client.ListStream(&ListStreamArgs{ChunkSize: 3}, func(issues []*types.IssueWithCounts) error {
+		sizes = append(sizes, len(issues))
+		for _, issue := range issues {
+			seen[issue.ID] = true
+			if len(issue.Labels) > 0 {
+				labeled++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ListStream failed: %v", err)
+	}
+	if fmt.Sprint(sizes) != "[3 3 1]" {
+		t.Errorf("expected chunks of [3 3 1], got %v", sizes)
+	}
+	if len(seen) != 7 || labeled != 1 || total != 7 {
+		t.Errorf("expected 7 distinct issues of 7 with 1 labeled, got %d of %d and %d", len(seen), total, labeled)
+	}
+
+	// A page reports the total it was taken from
+	var page []string
+	total, err = client.ListStream(&ListStreamArgs{ListArgs: ListArgs{Limit: 2, Offset: 6}}, func(issues []*types.IssueWithCounts) error {
+		for _, issue := range issues {
+			page = append(page, issue.ID)
+		}
+		return nil
+	})
+	if err != nil || len(page) != 1 || total != 7 {
+		t.Errorf("expected 1 issue of 7 on the last page, got %v of %d (err %v)", page, total, err)
+	}
+
+	// Filters apply as in list
+	labeled = 0
+	_, err = client.ListStream(&ListStreamArgs{ListArgs: ListArgs{Labels: []string{"first"}}}, func(issues []*types.IssueWithCounts) error {
+		labeled += len(issues)
+		return nil
+	})
+	if err != nil || labeled != 1 {
+		t.Errorf("expected 1 issue labeled first, got %d (err %v)", labeled, err)
+	}
+
+	// A failing callback stops being called, and the connection stays usable
+	calls := 0
+	stop := errors.New("stop")
+	_, err = client.ListStream(&ListStreamArgs{ChunkSize: 2}, func(issues []*types.IssueWithCounts) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("expected the callback's error after 1 call, got %v after %d", err, calls)
+	}
+	if err := client.Ping(); err != nil {
+		t.Errorf("connection unusable after an abandoned stream: %v", err)
+	}
+}
+
+func TestCount(t *testing.T) {
+	_, client, store, cleanup := setupTestServerWithStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	var ids []string
+	for i := 0; i < 4; i++ {
+		issue := &types.Issue{Title: fmt.Sprintf("Issue %d", i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	if err := store.AddLabel(ctx, ids[0], "backend", "test"); err != nil {
+		t.Fatalf("failed to add label: %v", err)
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: ids[1], DependsOnID: ids[0], Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("failed to add dependency: %v", err)
+	}
+
+	// Each count matches what list returns for the same args
+	for _, args := range []ListArgs{
+		{},
+		{Labels: []string{"backend"}},
+		{Ready: true},
+		{Blocked: true},
+		{Query: "Issue 3"},
+		{Limit: 1},
+	} {
+		resp, err := client.Count(&args)
+		if err != nil {
+			t.Fatalf("Count(%+v) failed: %v", args, err)
+		}
+		var result CountResult
+		if err := json.Unmarshal(resp.Data, &result); err != nil {
+			t.Fatalf("failed to parse count: %v", err)
+		}
+		listed := 0
+		total, err := client.ListStream(&ListStreamArgs{ListArgs: args}, func(issues []*types.IssueWithCounts) error {
+			listed += len(issues)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("ListStream(%+v) failed: %v", args, err)
+		}
+		if args.Limit == 0 && result.Count != listed {
+			t.Errorf("Count(%+v) = %d, list returned %d", args, result.Count, listed)
+		}
+		if result.Count != total {
+			t.Errorf("Count(%+v) = %d, want the list total %d", args, result.Count, total)
+		}
+	}
+}
+
+func TestListStreamFallsBackToList(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	// An older daemon: no list_stream, so the client retries with list
+	var ops []string
+	go func() {
+		reader := bufio.NewReader(serverConn)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if err != nil {
+				return
+			}
+			var req Request
+			_ = json.Unmarshal(line, &req)
+			ops = append(ops, req.Operation)
+			resp := Response{Success: false, Error: "unknown operation: " + req.Operation}
+			if req.Operation == OpList {
+				data, _ := json.Marshal([]*types.IssueWithCounts{{Issue: &types.Issue{ID: "bd-1"}}, {Issue: &types.Issue{ID: "bd-2"}}})
+				resp = Response{Success: true, Data: data}
+			}
+			data, _ := json.Marshal(resp)
+			_, _ = serverConn.Write(append(data, '\n'))
+		}
+	}()
+
+	client := &Client{conn: clientConn, timeout: 5 * time.Second}
+	var got []string
+	total, err := client.ListStream(&ListStreamArgs{ChunkSize: 1}, func(issues []*types.IssueWithCounts) error {
+		for _, issue := range issues {
+			got = append(got, issue.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ListStream failed: %v", err)
+	}
+	if fmt.Sprint(got) != "[bd-1 bd-2]" || fmt.Sprint(ops) != "[list_stream list]" || total != 2 {
+		t.Errorf("expected list_stream then list returning [bd-1 bd-2], got ops %v issues %v (total %d)", ops, got, total)
+	}
+}
@@ -18,11 +18,19 @@ type Metrics struct {
 	requestErrors  map[string]int64           // operation -> error count
 	requestLatency map[string][]time.Duration // operation -> latency samples (bounded slice)
 	maxSamples     int
+	// Latency histograms over every request, for Prometheus
+	latencyBuckets map[string][]int64 // operation -> count per latencyBucketBounds entry (not cumulative)
+	latencySum     map[string]time.Duration
 
 	// Connection metrics
 	totalConns    int64
 	rejectedConns int64
 
+	// Daemon activity
+	droppedEvents int64 // Mutation events dropped because the channel was full
+	imports       int64 // JSONL imports run after a file change
+	reloads       int64 // Reload signals processed
+
 	// System start time (for uptime calculation)
 	startTime time.Time
 }
@@ -34,6 +42,8 @@ func NewMetrics() *Metrics {
 		requestErrors:  make(map[string]int64),
 		requestLatency: make(map[string][]time.Duration),
 		maxSamples:     1000, // Keep last 1000 samples per operation
+		latencyBuckets: make(map[string][]int64),
+		latencySum:     make(map[string]time.Duration),
 		startTime:      time.Now(),
 	}
 }
@@ -53,6 +63,19 @@ func (m *Metrics) RecordRequest(operation string, latency time.Duration) {
 	}
 	samples = append(samples, latency)
 	m.requestLatency[operation] = samples
+
+	buckets := m.latencyBuckets[operation]
+	if buckets == nil {
+		buckets = make([]int64, len(latencyBucketBounds))
+		m.latencyBuckets[operation] = buckets
+	}
+	for i, bound := range latencyBucketBounds {
+		if latency <= bound {
+			buckets[i]++
+			break
+		}
+	}
+	m.latencySum[operation] += latency
 }
 
 // RecordError records a failed request
@@ -73,6 +96,21 @@ func (m *Metrics) RecordRejectedConnection() {
 	atomic.AddInt64(&m.rejectedConns, 1)
 }
 
+// RecordDroppedEvent records a mutation event dropped for a full channel
+func (m *Metrics) RecordDroppedEvent() {
+	atomic.AddInt64(&m.droppedEvents, 1)
+}
+
+// RecordImport records a JSONL import run after a file change
+func (m *Metrics) RecordImport() {
+	atomic.AddInt64(&m.imports, 1)
+}
+
+// RecordReload records a processed reload signal
+func (m *Metrics) RecordReload() {
+	atomic.AddInt64(&m.reloads, 1)
+}
+
 // Snapshot returns a point-in-time snapshot of all metrics
 func (m *Metrics) Snapshot(activeConns int) MetricsSnapshot {
 	// Copy data under a short critical section
@@ -0,0 +1,173 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// latencyBucketBounds are the upper bounds of the request latency histogram,
+// Prometheus' default buckets from 5ms to 10s
+var latencyBucketBounds = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// PrometheusContentType is the content type of WritePrometheus output
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// RecordImport counts a JSONL import the daemon ran after a file change
+func (s *Server) RecordImport() {
+	s.metrics.RecordImport()
+}
+
+// RecordReload counts a reload signal the daemon processed
+func (s *Server) RecordReload() {
+	s.metrics.RecordReload()
+}
+
+// promWriter writes metrics in the Prometheus text exposition format,
+// remembering the first write error
+type promWriter struct {
+	w   io.Writer
+	err error
+}
+
+// family writes a metric family's HELP and TYPE lines
+func (p *promWriter) family(name, kind, help string) {
+	p.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// sample writes one sample; labels alternate names and values
+func (p *promWriter) sample(name string, value float64, labels ...string) {
+	p.printf("%s", name)
+	if len(labels) > 0 {
+		p.printf("{")
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				p.printf(",")
+			}
+			p.printf("%s=%s", labels[i], strconv.Quote(labels[i+1]))
+		}
+		p.printf("}")
+	}
+	p.printf(" %s\n", strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+func (p *promWriter) printf(format string, args ...interface{}) {
+	if p.err == nil {
+		_, p.err = fmt.Fprintf(p.w, format, args...)
+	}
+}
+
+// WritePrometheus writes the daemon's metrics in the Prometheus text format:
+// RPC request counts, errors and latency histograms, connections, dropped
+// mutation events, imports and reloads, the watch mode, and issue counts by
+// status read from storage.
+func (s *Server) WritePrometheus(ctx context.Context, w io.Writer) error {
+	m := s.metrics
+	p := &promWriter{w: w}
+
+	m.mu.RLock()
+	ops := make([]string, 0, len(m.requestCounts)+len(m.requestErrors))
+	seen := make(map[string]bool)
+	for op := range m.requestCounts {
+		ops, seen[op] = append(ops, op), true
+	}
+	for op := range m.requestErrors {
+		if !seen[op] {
+			ops = append(ops, op)
+		}
+	}
+	sort.Strings(ops)
+	counts := make(map[string]int64, len(ops))
+	errors := make(map[string]int64, len(ops))
+	buckets := make(map[string][]int64, len(ops))
+	sums := make(map[string]time.Duration, len(ops))
+	for _, op := range ops {
+		counts[op], errors[op] = m.requestCounts[op], m.requestErrors[op]
+		buckets[op] = append([]int64(nil), m.latencyBuckets[op]...)
+		sums[op] = m.latencySum[op]
+	}
+	m.mu.RUnlock()
+
+	p.family("beads_rpc_requests_total", "counter", "RPC requests handled, by operation.")
+	for _, op := range ops {
+		p.sample("beads_rpc_requests_total", float64(counts[op]), "operation", op)
+	}
+	p.family("beads_rpc_request_errors_total", "counter", "RPC requests that failed, by operation.")
+	for _, op := range ops {
+		p.sample("beads_rpc_request_errors_total", float64(errors[op]), "operation", op)
+	}
+	p.family("beads_rpc_request_duration_seconds", "histogram", "RPC request latency, by operation.")
+	for _, op := range ops {
+		if len(buckets[op]) == 0 {
+			continue
+		}
+		var cumulative int64
+		for i, bound := range latencyBucketBounds {
+			cumulative += buckets[op][i]
+			p.sample("beads_rpc_request_duration_seconds_bucket", float64(cumulative), "operation", op, "le", strconv.FormatFloat(bound.Seconds(), 'g', -1, 64))
+		}
+		p.sample("beads_rpc_request_duration_seconds_bucket", float64(counts[op]), "operation", op, "le", "+Inf")
+		p.sample("beads_rpc_request_duration_seconds_sum", sums[op].Seconds(), "operation", op)
+		p.sample("beads_rpc_request_duration_seconds_count", float64(counts[op]), "operation", op)
+	}
+
+	p.family("beads_rpc_connections_total", "counter", "RPC connections accepted.")
+	p.sample("beads_rpc_connections_total", float64(atomic.LoadInt64(&m.totalConns)))
+	p.family("beads_rpc_connections_rejected_total", "counter", "RPC connections rejected at the connection limit.")
+	p.sample("beads_rpc_connections_rejected_total", float64(atomic.LoadInt64(&m.rejectedConns)))
+	p.family("beads_rpc_active_connections", "gauge", "RPC connections currently open.")
+	p.sample("beads_rpc_active_connections", float64(atomic.LoadInt32(&s.activeConns)))
+
+	p.family("beads_mutation_events_dropped_total", "counter", "Mutation events dropped because the daemon's event channel was full.")
+	p.sample("beads_mutation_events_dropped_total", float64(atomic.LoadInt64(&m.droppedEvents)))
+	p.family("beads_jsonl_imports_total", "counter", "JSONL imports run after the watcher saw a file change.")
+	p.sample("beads_jsonl_imports_total", float64(atomic.LoadInt64(&m.imports)))
+	p.family("beads_reloads_total", "counter", "Reload signals processed.")
+	p.sample("beads_reloads_total", float64(atomic.LoadInt64(&m.reloads)))
+
+	watch, _ := s.watchState.Load().(WatchState)
+	polling := 0.0
+	if watch.PollingMode {
+		polling = 1
+	}
+	p.family("beads_watcher_polling", "gauge", "1 when JSONL changes are found by polling instead of file system events.")
+	p.sample("beads_watcher_polling", polling, "backend", watch.WatchBackend)
+
+	if s.storage != nil {
+		p.family("beads_issues", "gauge", "Issues in the database, by status.")
+		for _, status := range []types.Status{types.StatusOpen, types.StatusInProgress, types.StatusBlocked, types.StatusClosed} {
+			status := status
+			count, err := s.storage.CountIssues(ctx, "", types.IssueFilter{Status: &status})
+			if err != nil {
+				return fmt.Errorf("failed to count %s issues: %w", status, err)
+			}
+			p.sample("beads_issues", float64(count), "status", string(status))
+		}
+	}
+
+	p.family("beads_uptime_seconds", "gauge", "Seconds since the daemon started.")
+	p.sample("beads_uptime_seconds", time.Since(m.startTime).Seconds())
+	p.family("beads_goroutines", "gauge", "Goroutines in the daemon process.")
+	p.sample("beads_goroutines", float64(runtime.NumGoroutine()))
+
+	return p.err
+}
@@ -1,8 +1,14 @@
 package rpc
 
 import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/steveyegge/beads/internal/types"
 )
 
 func TestMetricsRecording(t *testing.T) {
@@ -236,3 +242,47 @@ func TestMinHelper(t *testing.T) {
 		t.Error("min(7, 7) should be 7")
 	}
 }
+
+func TestWritePrometheus(t *testing.T) {
+	store := newTestStore(t, filepath.Join(t.TempDir(), "test.db"))
+	defer func() { _ = store.Close() }()
+	ctx := context.Background()
+	issue := &types.Issue{Title: "Open one", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	server := NewServer(filepath.Join(t.TempDir(), "bd.sock"), store, t.TempDir(), "")
+	server.metrics.RecordRequest("create", 3*time.Millisecond)
+	server.metrics.RecordRequest("create", 30*time.Millisecond)
+	server.metrics.RecordRequest("create", time.Minute)
+	server.metrics.RecordError("create")
+	server.RecordImport()
+	server.SetWatchState(WatchState{WatchBackend: WatchBackendPolling, PollingMode: true})
+
+	var buf bytes.Buffer
+	if err := server.WritePrometheus(ctx, &buf); err != nil {
+		t.Fatalf("WritePrometheus failed: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# TYPE beads_rpc_requests_total counter\n",
+		`beads_rpc_requests_total{operation="create"} 3` + "\n",
+		`beads_rpc_request_errors_total{operation="create"} 1` + "\n",
+		"# TYPE beads_rpc_request_duration_seconds histogram\n",
+		`beads_rpc_request_duration_seconds_bucket{operation="create",le="0.005"} 1` + "\n",
+		`beads_rpc_request_duration_seconds_bucket{operation="create",le="0.05"} 2` + "\n",
+		`beads_rpc_request_duration_seconds_bucket{operation="create",le="10"} 2` + "\n",
+		`beads_rpc_request_duration_seconds_bucket{operation="create",le="+Inf"} 3` + "\n",
+		`beads_rpc_request_duration_seconds_count{operation="create"} 3` + "\n",
+		"beads_jsonl_imports_total 1\n",
+		"beads_mutation_events_dropped_total 0\n",
+		`beads_watcher_polling{backend="polling"} 1` + "\n",
+		`beads_issues{status="open"} 1` + "\n",
+		`beads_issues{status="closed"} 0` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -2,6 +2,9 @@ package rpc
 
 import (
 	"encoding/json"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
 )
 
 // Operation constants for all bd commands
@@ -12,8 +15,12 @@ const (
 	OpMetrics         = "metrics"
 	OpCreate          = "create"
 	OpUpdate          = "update"
+	OpUpdateMany      = "update_many"
 	OpClose           = "close"
+	OpReopen          = "reopen"
 	OpList            = "list"
+	OpListStream      = "list_stream"
+	OpCount           = "count"
 	OpShow            = "show"
 	OpReady           = "ready"
 	OpStale           = "stale"
@@ -25,8 +32,14 @@ const (
 	OpLabelRemove     = "label_remove"
 	OpCommentList     = "comment_list"
 	OpCommentAdd      = "comment_add"
+	OpCommentResolve  = "comment_resolve"
+	OpCommentEdit     = "comment_edit"
+	OpCommentDelete   = "comment_delete"
 	OpBatch           = "batch"
 	OpResolveID       = "resolve_id"
+	OpLock            = "lock"
+	OpUnlock          = "unlock"
+	OpLockStatus      = "lock_status"
 
 	OpCompact         = "compact"
 	OpCompactStats    = "compact_stats"
@@ -34,9 +47,39 @@ const (
 	OpImport          = "import"
 	OpEpicStatus      = "epic_status"
 	OpGetMutations    = "get_mutations"
+	OpWatch           = "watch"
 	OpShutdown        = "shutdown"
 )
 
+// readOnlyOperations are the operations that never change the database, the
+// only ones a read-only client sends
+var readOnlyOperations = map[string]bool{
+	OpPing:         true,
+	OpStatus:       true,
+	OpHealth:       true,
+	OpMetrics:      true,
+	OpList:         true,
+	OpListStream:   true,
+	OpCount:        true,
+	OpShow:         true,
+	OpReady:        true,
+	OpStale:        true,
+	OpStats:        true,
+	OpDepTree:      true,
+	OpCommentList:  true,
+	OpResolveID:    true,
+	OpLockStatus:   true,
+	OpCompactStats: true,
+	OpEpicStatus:   true,
+	OpGetMutations: true,
+	OpWatch:        true,
+}
+
+// IsReadOnlyOperation reports whether operation leaves the database as it is
+func IsReadOnlyOperation(operation string) bool {
+	return readOnlyOperations[operation]
+}
+
 // Request represents an RPC request from client to daemon
 type Request struct {
 	Operation     string          `json:"operation"`
@@ -46,6 +89,10 @@ type Request struct {
 	Cwd           string          `json:"cwd,omitempty"`            // Working directory for database discovery
 	ClientVersion string          `json:"client_version,omitempty"` // Client version for compatibility checks
 	ExpectedDB    string          `json:"expected_db,omitempty"`    // Expected database path for validation (absolute)
+
+	// stream sends an intermediate frame of a multi-frame response ahead of
+	// the handler's final one. Nil where replies can't stream (e.g. in a batch).
+	stream func(Response) error
 }
 
 // Response represents an RPC response from daemon to client
@@ -53,12 +100,14 @@ type Response struct {
 	Success bool            `json:"success"`
 	Data    json.RawMessage `json:"data,omitempty"`
 	Error   string          `json:"error,omitempty"`
+	Code    string          `json:"code,omitempty"` // types.ErrCode* classifying Error, if known
 }
 
 // CreateArgs represents arguments for the create operation
 type CreateArgs struct {
 	ID                 string   `json:"id,omitempty"`
 	Parent             string   `json:"parent,omitempty"` // Parent ID for hierarchical issues
+	IDLength           int      `json:"id_length,omitempty"` // Hash length for a generated ID (0 = adaptive)
 	Title              string   `json:"title"`
 	Description        string   `json:"description,omitempty"`
 	IssueType          string   `json:"issue_type"`
@@ -69,6 +118,18 @@ type CreateArgs struct {
 	ExternalRef        string   `json:"external_ref,omitempty"`  // Link to external issue trackers
 	Labels             []string `json:"labels,omitempty"`
 	Dependencies       []string `json:"dependencies,omitempty"`
+	// CheckDuplicates refuses to create the issue if an open issue has a
+	// similar title, unless AllowDuplicates is also set; then it's created
+	// and the matches are returned in CreatedIssue.PossibleDuplicates
+	CheckDuplicates bool `json:"check_duplicates,omitempty"`
+	AllowDuplicates bool `json:"allow_duplicates,omitempty"`
+}
+
+// CreatedIssue is the create operation's response: the new issue, plus the
+// open issues with a similar title when CheckDuplicates found any
+type CreatedIssue struct {
+	*types.Issue
+	PossibleDuplicates []string `json:"possible_duplicates,omitempty"`
 }
 
 // UpdateArgs represents arguments for the update operation
@@ -83,26 +144,54 @@ type UpdateArgs struct {
 	Notes              *string `json:"notes,omitempty"`
 	Assignee           *string `json:"assignee,omitempty"`
 	ExternalRef        *string `json:"external_ref,omitempty"` // Link to external issue trackers
+	EstimatedMinutes   *int    `json:"estimated_minutes,omitempty"`
+	SpentMinutes       *int    `json:"spent_minutes,omitempty"`
+	SetMetadata        map[string]string `json:"set_metadata,omitempty"`   // Metadata keys to set, keeping the rest
+	UnsetMetadata      []string          `json:"unset_metadata,omitempty"` // Metadata keys to remove
+	Archived           *bool             `json:"archived,omitempty"`       // true archives the issue now, false unarchives it
+	AddAttachments     []string          `json:"add_attachments,omitempty"`    // Attachment references to add, keeping the rest
+	RemoveAttachments  []string          `json:"remove_attachments,omitempty"` // Attachment references to remove
+	Clear              []string          `json:"clear,omitempty"`              // Optional fields to empty (see types.ClearableFields)
+	ExpectedUpdatedAt  *time.Time        `json:"expected_updated_at,omitempty"` // Fail instead of updating if the issue changed since this UpdatedAt
+}
+
+// UpdateManyArgs applies one update to several issues in a single
+// transaction: either all of them change or none do. Update.ID is ignored.
+type UpdateManyArgs struct {
+	IDs    []string   `json:"ids"`
+	Update UpdateArgs `json:"update"`
 }
 
 // CloseArgs represents arguments for the close operation
 type CloseArgs struct {
-	ID     string `json:"id"`
-	Reason string `json:"reason,omitempty"`
+	ID         string `json:"id"`
+	Reason     string `json:"reason,omitempty"`
+	Note       string `json:"note,omitempty"`       // Stored on the Closed event, not as a comment
+	Resolution string `json:"resolution,omitempty"` // fixed, wontfix, duplicate or obsolete
+	ResolvedBy string `json:"resolved_by,omitempty"` // SHA of the commit that resolved the issue
+}
+
+// ReopenArgs represents arguments for the reopen operation
+type ReopenArgs struct {
+	ID   string `json:"id"`
+	Note string `json:"note,omitempty"` // Stored on the Reopened event, not as a comment
 }
 
 // ListArgs represents arguments for the list operation
 type ListArgs struct {
-	Query     string   `json:"query,omitempty"`
-	Status    string   `json:"status,omitempty"`
-	Priority  *int     `json:"priority,omitempty"`
-	IssueType string   `json:"issue_type,omitempty"`
-	Assignee  string   `json:"assignee,omitempty"`
-	Label     string   `json:"label,omitempty"`      // Deprecated: use Labels
-	Labels    []string `json:"labels,omitempty"`     // AND semantics
-	LabelsAny []string `json:"labels_any,omitempty"` // OR semantics
-	IDs       []string `json:"ids,omitempty"`        // Filter by specific issue IDs
-	Limit     int      `json:"limit,omitempty"`
+	Query         string   `json:"query,omitempty"`
+	Status        string   `json:"status,omitempty"`
+	ExcludeStatus []string `json:"exclude_status,omitempty"` // Exclude these statuses
+	Priority      *int     `json:"priority,omitempty"`
+	IssueType     string   `json:"issue_type,omitempty"`
+	Assignee      string   `json:"assignee,omitempty"`
+	Label         string   `json:"label,omitempty"`      // Deprecated: use Labels
+	Labels        []string `json:"labels,omitempty"`     // AND semantics
+	LabelsAny     []string `json:"labels_any,omitempty"` // OR semantics
+	IDs           []string `json:"ids,omitempty"`        // Filter by specific issue IDs
+	Limit         int      `json:"limit,omitempty"`
+	Offset        int      `json:"offset,omitempty"` // Skip this many matches before the first returned
+	Sort          string   `json:"sort,omitempty"`   // Sort keys, e.g. "priority:asc,created:desc"
 	
 	// Pattern matching
 	TitleContains       string `json:"title_contains,omitempty"`
@@ -122,36 +211,76 @@ type ListArgs struct {
 	NoAssignee       bool `json:"no_assignee,omitempty"`
 	NoLabels         bool `json:"no_labels,omitempty"`
 	
+	// Dependency state (mutually exclusive)
+	Ready   bool `json:"ready,omitempty"`
+	Blocked bool `json:"blocked,omitempty"`
+	
+	// Custom metadata, exact match on every key
+	Metadata map[string]string `json:"metadata,omitempty"`
+	
+	IncludeArchived bool `json:"include_archived,omitempty"`
+	
 	// Priority range
 	PriorityMin *int `json:"priority_min,omitempty"`
 	PriorityMax *int `json:"priority_max,omitempty"`
 }
 
+// ListStreamArgs represents arguments for the list_stream operation
+type ListStreamArgs struct {
+	ListArgs
+	ChunkSize int `json:"chunk_size,omitempty"` // Issues per frame (default 500)
+}
+
+// defaultListChunkSize is the list_stream frame size when none is requested
+const defaultListChunkSize = 500
+
+// ListChunk is one frame of a list_stream response. Every frame but the last
+// has More set; the last may carry no issues, and carries Total.
+type ListChunk struct {
+	Issues []*types.IssueWithCounts `json:"issues"`
+	More   bool                     `json:"more,omitempty"`
+	Total  int                      `json:"total"` // Matches ignoring limit and offset
+}
+
+// CountResult is the response to a count request: how many issues a list
+// with the same ListArgs matches, ignoring limit and offset
+type CountResult struct {
+	Count int `json:"count"`
+}
+
 // ShowArgs represents arguments for the show operation
 type ShowArgs struct {
-	ID string `json:"id"`
+	ID       string `json:"id"`
+	History  bool   `json:"history,omitempty"`  // Include the event history
+	Comments bool   `json:"comments,omitempty"` // Include the comment thread
 }
 
 // ResolveIDArgs represents arguments for the resolve_id operation
 type ResolveIDArgs struct {
-	ID string `json:"id"`
+	ID    string `json:"id"`
+	Title bool   `json:"title,omitempty"` // Match ID against titles, even if it looks like an ID
 }
 
 // ReadyArgs represents arguments for the ready operation
 type ReadyArgs struct {
-	Assignee   string   `json:"assignee,omitempty"`
-	Priority   *int     `json:"priority,omitempty"`
-	Limit      int      `json:"limit,omitempty"`
-	SortPolicy string   `json:"sort_policy,omitempty"`
-	Labels     []string `json:"labels,omitempty"`
-	LabelsAny  []string `json:"labels_any,omitempty"`
+	Status      string   `json:"status,omitempty"`
+	IssueType   string   `json:"issue_type,omitempty"`
+	Assignee    string   `json:"assignee,omitempty"`
+	Unassigned  bool     `json:"unassigned,omitempty"` // Only issues with no assignee
+	Priority    *int     `json:"priority,omitempty"`
+	MinPriority *int     `json:"min_priority,omitempty"`
+	Limit       int      `json:"limit,omitempty"`
+	SortPolicy  string   `json:"sort_policy,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	LabelsAny   []string `json:"labels_any,omitempty"`
 }
 
 // StaleArgs represents arguments for the stale command
 type StaleArgs struct {
-	Days   int    `json:"days,omitempty"`
-	Status string `json:"status,omitempty"`
-	Limit  int    `json:"limit,omitempty"`
+	Days      int           `json:"days,omitempty"`
+	OlderThan time.Duration `json:"older_than,omitempty"` // Overrides Days when set
+	Status    string        `json:"status,omitempty"`
+	Limit     int           `json:"limit,omitempty"`
 }
 
 // DepAddArgs represents arguments for adding a dependency
@@ -159,6 +288,7 @@ type DepAddArgs struct {
 	FromID  string `json:"from_id"`
 	ToID    string `json:"to_id"`
 	DepType string `json:"dep_type"`
+	Weight  *int   `json:"weight,omitempty"` // Priority propagation levels for blocks deps; nil uses priority_propagation config
 }
 
 // DepRemoveArgs represents arguments for removing a dependency
@@ -193,9 +323,46 @@ type CommentListArgs struct {
 
 // CommentAddArgs represents arguments for adding a comment to an issue
 type CommentAddArgs struct {
+	ID       string `json:"id"`
+	Author   string `json:"author"`
+	Text     string `json:"text"`
+	ParentID int64  `json:"parent_id,omitempty"` // Reply to this comment, which must belong to ID
+}
+
+// CommentResolveArgs represents arguments for resolving a comment thread
+type CommentResolveArgs struct {
+	CommentID int64 `json:"comment_id"`
+	Resolved  bool  `json:"resolved"`
+}
+
+// CommentEditArgs represents arguments for editing a comment's text
+type CommentEditArgs struct {
+	CommentID int64  `json:"comment_id"`
+	Text      string `json:"text"`
+}
+
+// CommentDeleteArgs represents arguments for deleting a comment
+type CommentDeleteArgs struct {
+	CommentID int64 `json:"comment_id"`
+}
+
+// LockArgs represents arguments for acquiring an advisory lock on an issue
+type LockArgs struct {
+	ID         string `json:"id"`
+	Holder     string `json:"holder"`
+	TTLSeconds int    `json:"ttl_seconds,omitempty"` // 0 uses the configured default
+}
+
+// UnlockArgs represents arguments for releasing an advisory lock on an issue
+type UnlockArgs struct {
 	ID     string `json:"id"`
-	Author string `json:"author"`
-	Text   string `json:"text"`
+	Holder string `json:"holder"`
+	Force  bool   `json:"force,omitempty"` // Release even if held by another actor
+}
+
+// LockStatusArgs represents arguments for querying the lock on an issue
+type LockStatusArgs struct {
+	ID string `json:"id"`
 }
 
 // EpicStatusArgs represents arguments for the epic status operation
@@ -220,6 +387,24 @@ type StatusResponse struct {
 	LastActivityTime     string  `json:"last_activity_time"`       // ISO 8601 timestamp of last request
 	ExclusiveLockActive  bool    `json:"exclusive_lock_active"`    // Whether an exclusive lock is held
 	ExclusiveLockHolder  string  `json:"exclusive_lock_holder,omitempty"` // Lock holder name if active
+	PendingFlushCount    int     `json:"pending_flush_count"`             // Issues changed since the last JSONL export
+	WatchState                   // How the daemon notices JSONL changes
+}
+
+// Watch backends reported in WatchState
+const (
+	WatchBackendFSNotify = "fsnotify" // Native OS file events (inotify, FSEvents, ReadDirectoryChangesW)
+	WatchBackendPolling  = "polling"  // fsnotify unavailable; files are stat-ed every watch_poll_ms
+	WatchBackendInterval = "interval" // No file watch; the daemon checks on a fixed interval
+)
+
+// WatchState describes how the daemon watches its JSONL files. The daemon
+// reports it with Server.SetWatchState; it is empty until then.
+type WatchState struct {
+	WatchedPaths   []string `json:"watched_paths"`              // JSONL files being watched
+	WatchBackend   string   `json:"watch_backend,omitempty"`    // One of the WatchBackend constants
+	PollingMode    bool     `json:"polling_mode"`               // True unless changes arrive as fsnotify events
+	LastReloadTime string   `json:"last_reload_time,omitempty"` // ISO 8601 timestamp of the last reload signal
 }
 
 // HealthResponse is the response for a health check operation
@@ -257,6 +442,7 @@ type BatchResult struct {
 	Success bool            `json:"success"`
 	Data    json.RawMessage `json:"data,omitempty"`
 	Error   string          `json:"error,omitempty"`
+	Code    string          `json:"code,omitempty"`
 }
 
 // CompactArgs represents arguments for the compact operation
@@ -323,3 +509,22 @@ type ImportArgs struct {
 type GetMutationsArgs struct {
 	Since int64 `json:"since"` // Unix timestamp in milliseconds (0 for all recent)
 }
+
+// WatchArgs represents arguments for the watch operation
+type WatchArgs struct {
+	AfterEventID int64 `json:"after_event_id,omitempty"` // Stream events after this one (0 starts after the newest)
+}
+
+// WatchEvent is one change in a watch stream: the event and the issue as it
+// is when the event is sent
+type WatchEvent struct {
+	Event *types.Event `json:"event"`
+	Issue *types.Issue `json:"issue,omitempty"` // Nil if the issue no longer exists
+}
+
+// WatchFrame is one frame of a watch response. The stream goes on, with More
+// set, until the daemon stops; a frame without events is a heartbeat.
+type WatchFrame struct {
+	Events []WatchEvent `json:"events,omitempty"`
+	More   bool         `json:"more,omitempty"`
+}
@@ -105,7 +105,9 @@ func TestAllOperations(t *testing.T) {
 		OpPing,
 		OpCreate,
 		OpUpdate,
+		OpUpdateMany,
 		OpClose,
+		OpReopen,
 		OpList,
 		OpShow,
 		OpReady,
@@ -3,6 +3,7 @@ package rpc
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,6 +12,7 @@ import (
 
 	sqlitestorage "github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
 func setupTestServer(t *testing.T) (*Server, *Client, func()) {
@@ -262,6 +264,189 @@ func TestUpdateIssue(t *testing.T) {
 	}
 }
 
+func TestUpdateIssueExpectedUpdatedAt(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	createResp, err := client.Create(&CreateArgs{Title: "Original", IssueType: "task", Priority: 2})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	var issue types.Issue
+	json.Unmarshal(createResp.Data, &issue)
+
+	first := "First"
+	updateResp, err := client.Update(&UpdateArgs{ID: issue.ID, Title: &first, ExpectedUpdatedAt: &issue.UpdatedAt})
+	if err != nil {
+		t.Fatalf("Update with the current updated_at failed: %v", err)
+	}
+	var updated types.Issue
+	json.Unmarshal(updateResp.Data, &updated)
+
+	// Writing from the stale read is refused and leaves the first change
+	second := "Second"
+	_, err = client.Update(&UpdateArgs{ID: issue.ID, Title: &second, ExpectedUpdatedAt: &issue.UpdatedAt})
+	if err == nil || !strings.Contains(err.Error(), "was changed at") {
+		t.Fatalf("Expected a conflict for a stale updated_at, got %v", err)
+	}
+	if code := types.ErrorCode(err); code != types.ErrCodeConflict {
+		t.Errorf("Conflict error code = %q, want %q", code, types.ErrCodeConflict)
+	}
+	showResp, err := client.Show(&ShowArgs{ID: issue.ID})
+	if err != nil {
+		t.Fatalf("Show failed: %v", err)
+	}
+	var shown types.Issue
+	json.Unmarshal(showResp.Data, &shown)
+	if shown.Title != first {
+		t.Errorf("Title after conflict = %q, want %q", shown.Title, first)
+	}
+
+	if _, err := client.Update(&UpdateArgs{ID: issue.ID, Title: &second, ExpectedUpdatedAt: &updated.UpdatedAt}); err != nil {
+		t.Errorf("Update after re-reading failed: %v", err)
+	}
+}
+
+func TestResponseErrorCodes(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	_, err := client.ResolveID(&ResolveIDArgs{ID: "nonexistent"})
+	if code := types.ErrorCode(err); code != types.ErrCodeNotFound {
+		t.Errorf("ResolveID of a missing issue: code = %q, want %q (err %v)", code, types.ErrCodeNotFound, err)
+	}
+	_, err = client.Show(&ShowArgs{ID: "test-missing"})
+	if code := types.ErrorCode(err); code != types.ErrCodeNotFound {
+		t.Errorf("Show of a missing issue: code = %q, want %q (err %v)", code, types.ErrCodeNotFound, err)
+	}
+
+	createResp, err := client.Create(&CreateArgs{Title: "Coded", IssueType: "task", Priority: 2})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	var issue types.Issue
+	json.Unmarshal(createResp.Data, &issue)
+	status := "bogus"
+	_, err = client.Update(&UpdateArgs{ID: issue.ID, Status: &status})
+	if code := types.ErrorCode(err); code != types.ErrCodeValidation {
+		t.Errorf("Update with an invalid status: code = %q, want %q (err %v)", code, types.ErrCodeValidation, err)
+	}
+}
+
+func TestClientActorRecordedOnEvents(t *testing.T) {
+	_, client, store, cleanup := setupTestServerWithStore(t)
+	defer cleanup()
+	client.SetActor("ci-bot")
+
+	createResp, err := client.Create(&CreateArgs{Title: "Attributed", IssueType: "task", Priority: 2})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	var issue types.Issue
+	if err := json.Unmarshal(createResp.Data, &issue); err != nil {
+		t.Fatalf("Failed to parse issue: %v", err)
+	}
+	if _, err := client.CloseIssue(&CloseArgs{ID: issue.ID, Reason: "done"}); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	events, err := store.GetEvents(context.Background(), issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(events) < 2 {
+		t.Fatalf("Expected create and close events, got %d", len(events))
+	}
+	for _, event := range events {
+		if event.Actor != "ci-bot" {
+			t.Errorf("Event %s recorded actor %q, want ci-bot", event.EventType, event.Actor)
+		}
+	}
+}
+
+func TestClientReadOnly(t *testing.T) {
+	_, client, store, cleanup := setupTestServerWithStore(t)
+	defer cleanup()
+
+	createResp, err := client.Create(&CreateArgs{Title: "Before read-only", IssueType: "task", Priority: 2})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	var issue types.Issue
+	if err := json.Unmarshal(createResp.Data, &issue); err != nil {
+		t.Fatalf("Failed to parse issue: %v", err)
+	}
+
+	client.SetReadOnly(true)
+	if _, err := client.List(&ListArgs{}); err != nil {
+		t.Errorf("List failed on a read-only client: %v", err)
+	}
+	if _, err := client.Show(&ShowArgs{ID: issue.ID}); err != nil {
+		t.Errorf("Show failed on a read-only client: %v", err)
+	}
+	if _, err := client.Stats(); err != nil {
+		t.Errorf("Stats failed on a read-only client: %v", err)
+	}
+
+	title := "Renamed"
+	if _, err := client.Update(&UpdateArgs{ID: issue.ID, Title: &title}); types.ErrorCode(err) != types.ErrCodeReadOnly {
+		t.Errorf("Update on a read-only client: got %v, want a %s error", err, types.ErrCodeReadOnly)
+	}
+	// The client refuses by operation, before the args matter
+	for _, op := range []string{OpCreate, OpUpdateMany, OpClose, OpReopen, OpDepAdd, OpDepRemove, OpLabelAdd, OpCommentAdd, OpImport, OpExport, OpBatch, OpCompact} {
+		if _, err := client.Execute(op, struct{}{}); types.ErrorCode(err) != types.ErrCodeReadOnly {
+			t.Errorf("%s on a read-only client: got %v, want a %s error", op, err, types.ErrCodeReadOnly)
+		}
+	}
+
+	got, err := store.GetIssue(context.Background(), issue.ID)
+	if err != nil || got == nil || got.Title != "Before read-only" || got.Status != types.StatusOpen {
+		t.Errorf("Expected the issue untouched, got %+v (%v)", got, err)
+	}
+}
+
+func TestUpdateClearFields(t *testing.T) {
+	_, client, store, cleanup := setupTestServerWithStore(t)
+	defer cleanup()
+
+	createResp, err := client.Create(&CreateArgs{Title: "Clearable", IssueType: "task", Priority: 2, Assignee: "alice", ExternalRef: "gh-7"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	var issue types.Issue
+	if err := json.Unmarshal(createResp.Data, &issue); err != nil {
+		t.Fatalf("Failed to parse issue: %v", err)
+	}
+	estimate := 30
+	if _, err := client.Update(&UpdateArgs{ID: issue.ID, EstimatedMinutes: &estimate}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	if _, err := client.Update(&UpdateArgs{ID: issue.ID, Clear: []string{"assignee", "external_ref", "estimated_minutes"}}); err != nil {
+		t.Fatalf("Update with Clear failed: %v", err)
+	}
+	ctx := context.Background()
+	cleared, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cleared.Assignee != "" || cleared.ExternalRef != nil || cleared.EstimatedMinutes != nil {
+		t.Errorf("Expected assignee, external_ref and estimate cleared, got %q, %v, %v", cleared.Assignee, cleared.ExternalRef, cleared.EstimatedMinutes)
+	}
+	events, err := store.GetEvents(ctx, issue.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].EventType != types.EventFieldsCleared {
+		t.Errorf("Expected a %s event, got %+v", types.EventFieldsCleared, events)
+	}
+
+	_, err = client.Update(&UpdateArgs{ID: issue.ID, Clear: []string{"title"}})
+	if code := types.ErrorCode(err); code != types.ErrCodeValidation {
+		t.Errorf("Clearing title: code = %q, want %q (err %v)", code, types.ErrCodeValidation, err)
+	}
+}
+
 func TestCloseIssue(t *testing.T) {
 	_, client, cleanup := setupTestServer(t)
 	defer cleanup()
@@ -658,6 +843,50 @@ func TestCreate_WithParentAndIDConflict(t *testing.T) {
 	}
 }
 
+func TestCreate_CheckDuplicates(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	first, err := client.Create(&CreateArgs{Title: "Fix login bug", IssueType: "bug", Priority: 1})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	var original types.Issue
+	if err := json.Unmarshal(first.Data, &original); err != nil {
+		t.Fatalf("Failed to unmarshal issue: %v", err)
+	}
+
+	// A similar title is refused
+	resp, err := client.Create(&CreateArgs{Title: "fix: login bug", IssueType: "bug", Priority: 1, CheckDuplicates: true})
+	if err == nil {
+		t.Fatal("Expected a duplicate to be refused")
+	}
+	if !strings.Contains(resp.Error, original.ID) {
+		t.Errorf("Expected error to name %s, got: %s", original.ID, resp.Error)
+	}
+
+	// AllowDuplicates creates it and reports the match
+	resp, err = client.Create(&CreateArgs{Title: "fix: login bug", IssueType: "bug", Priority: 1, CheckDuplicates: true, AllowDuplicates: true})
+	if err != nil {
+		t.Fatalf("Create with AllowDuplicates failed: %v", err)
+	}
+	var created CreatedIssue
+	if err := json.Unmarshal(resp.Data, &created); err != nil {
+		t.Fatalf("Failed to unmarshal issue: %v", err)
+	}
+	if created.Issue == nil || created.ID == original.ID {
+		t.Fatalf("Expected a new issue, got %+v", created.Issue)
+	}
+	if len(created.PossibleDuplicates) != 1 || created.PossibleDuplicates[0] != original.ID {
+		t.Errorf("Expected possible_duplicates [%s], got %v", original.ID, created.PossibleDuplicates)
+	}
+
+	// Without CheckDuplicates nothing is checked
+	if _, err := client.Create(&CreateArgs{Title: "Fix login bug", IssueType: "bug", Priority: 1}); err != nil {
+		t.Errorf("Create without CheckDuplicates failed: %v", err)
+	}
+}
+
 func TestCreate_DiscoveredFromInheritsSourceRepo(t *testing.T) {
 	_, client, cleanup := setupTestServer(t)
 	defer cleanup()
@@ -832,3 +1061,125 @@ func TestRPCUpdateWithExternalRef(t *testing.T) {
 
 	_ = server // Silence unused warning
 }
+
+func TestRPCUpdateMetadata(t *testing.T) {
+	_, client, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	resp, err := client.Create(&CreateArgs{Title: "Metadata issue", IssueType: "task", Priority: 2})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	var issue types.Issue
+	if err := json.Unmarshal(resp.Data, &issue); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	update := func(args *UpdateArgs) types.Issue {
+		t.Helper()
+		args.ID = issue.ID
+		resp, err := client.Update(args)
+		if err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+		var updated types.Issue
+		if err := json.Unmarshal(resp.Data, &updated); err != nil {
+			t.Fatalf("Failed to unmarshal update response: %v", err)
+		}
+		return updated
+	}
+
+	update(&UpdateArgs{SetMetadata: map[string]string{"sprint": "42", "customer": "acme"}})
+	updated := update(&UpdateArgs{SetMetadata: map[string]string{"sprint": "43"}, UnsetMetadata: []string{"customer"}})
+	if len(updated.Metadata) != 1 || updated.Metadata["sprint"] != "43" {
+		t.Errorf("Metadata = %v, want only sprint=43", updated.Metadata)
+	}
+
+	resp, err = client.List(&ListArgs{Metadata: map[string]string{"sprint": "43"}})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	var issues []*types.Issue
+	if err := json.Unmarshal(resp.Data, &issues); err != nil {
+		t.Fatalf("Failed to unmarshal list response: %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != issue.ID {
+		t.Errorf("List by metadata returned %d issues, want %s", len(issues), issue.ID)
+	}
+
+	if _, err := client.Update(&UpdateArgs{ID: issue.ID, SetMetadata: map[string]string{"resolution": "x"}}); err == nil {
+		t.Error("Expected an error setting a reserved metadata key")
+	}
+}
+
+func TestRPCUpdateMany(t *testing.T) {
+	_, client, store, cleanup := setupTestServerWithStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	var ids []string
+	for _, title := range []string{"First", "Second"} {
+		resp, err := client.Create(&CreateArgs{Title: title, IssueType: "task", Priority: 2})
+		if err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		var issue types.Issue
+		if err := json.Unmarshal(resp.Data, &issue); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+
+	status := string(types.StatusInProgress)
+	resp, err := client.UpdateMany(&UpdateManyArgs{IDs: ids, Update: UpdateArgs{Status: &status}})
+	if err != nil {
+		t.Fatalf("UpdateMany failed: %v", err)
+	}
+	var updated []*types.Issue
+	if err := json.Unmarshal(resp.Data, &updated); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(updated) != 2 || updated[0].Status != types.StatusInProgress || updated[1].Status != types.StatusInProgress {
+		t.Errorf("expected both issues in progress, got %+v", updated)
+	}
+	for _, id := range ids {
+		events, err := store.GetEvents(ctx, id, 10)
+		if err != nil {
+			t.Fatalf("GetEvents failed: %v", err)
+		}
+		if len(events) == 0 || events[0].EventType != types.EventStatusChanged {
+			t.Errorf("expected a status_changed event on %s, got %+v", id, events)
+		}
+	}
+
+	// One bad ID rolls the whole batch back
+	title := "Renamed"
+	if _, err := client.UpdateMany(&UpdateManyArgs{IDs: []string{ids[0], "bd-missing"}, Update: UpdateArgs{Title: &title}}); err == nil {
+		t.Fatal("expected an error updating a missing issue")
+	}
+	if issue, _ := store.GetIssue(ctx, ids[0]); issue.Title != "First" {
+		t.Errorf("expected the batch rolled back, got title %q", issue.Title)
+	}
+}
+
+func TestResolveIDAmbiguousCandidates(t *testing.T) {
+	_, client, store, cleanup := setupTestServerWithStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	for _, id := range []string{"bd-a3f8e9a2", "bd-a3f1b0cc"} {
+		issue := &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	_, err := client.ResolveID(&ResolveIDArgs{ID: "a3f"})
+	var ambiguous *utils.AmbiguousIDError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("expected *utils.AmbiguousIDError, got %v", err)
+	}
+	if len(ambiguous.Candidates) != 2 || ambiguous.Candidates[0].Title != "Issue bd-a3f1b0cc" {
+		t.Errorf("candidates = %+v, want both a3f issues with titles", ambiguous.Candidates)
+	}
+}
@@ -36,6 +36,7 @@ type Server struct {
 	// Health and metrics
 	startTime        time.Time
 	lastActivityTime atomic.Value // time.Time - last request timestamp
+	watchState       atomic.Value // WatchState - reported by the daemon's event loop
 	metrics          *Metrics
 	// Connection limiting
 	maxConns      int
@@ -54,6 +55,9 @@ type Server struct {
 	recentMutations   []MutationEvent
 	recentMutationsMu sync.RWMutex
 	maxMutationBuffer int
+	// Open watch streams, woken on every mutation
+	watchers   map[chan struct{}]struct{}
+	watchersMu sync.Mutex
 }
 
 // Mutation event types
@@ -113,11 +117,20 @@ func NewServer(socketPath string, store storage.Storage, workspacePath string, d
 		mutationChan:      make(chan MutationEvent, mutationBufferSize), // Configurable buffer
 		recentMutations:   make([]MutationEvent, 0, 100),
 		maxMutationBuffer: 100,
+		watchers:          make(map[chan struct{}]struct{}),
 	}
 	s.lastActivityTime.Store(time.Now())
+	s.watchState.Store(WatchState{})
 	return s
 }
 
+// SetWatchState records how the daemon is watching its JSONL files, for the
+// status endpoint
+func (s *Server) SetWatchState(state WatchState) {
+	state.WatchedPaths = append([]string{}, state.WatchedPaths...)
+	s.watchState.Store(state)
+}
+
 // emitMutation sends a mutation event to the daemon's event-driven loop.
 // Non-blocking: drops event if channel is full (sync will happen eventually).
 // Also stores in recent mutations buffer for polling.
@@ -135,6 +148,7 @@ func (s *Server) emitMutation(eventType, issueID string) {
 	default:
 		// Channel full, increment dropped events counter
 		s.droppedEvents.Add(1)
+		s.metrics.RecordDroppedEvent()
 	}
 
 	// Store in recent mutations buffer for polling
@@ -145,6 +159,30 @@ func (s *Server) emitMutation(eventType, issueID string) {
 		s.recentMutations = s.recentMutations[1:]
 	}
 	s.recentMutationsMu.Unlock()
+
+	// Wake watch streams; a stream that's already awake will see this event too
+	s.watchersMu.Lock()
+	for wake := range s.watchers {
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	}
+	s.watchersMu.Unlock()
+}
+
+// subscribeMutations returns a channel woken after each mutation, and a
+// function to stop that
+func (s *Server) subscribeMutations() (<-chan struct{}, func()) {
+	wake := make(chan struct{}, 1)
+	s.watchersMu.Lock()
+	s.watchers[wake] = struct{}{}
+	s.watchersMu.Unlock()
+	return wake, func() {
+		s.watchersMu.Lock()
+		delete(s.watchers, wake)
+		s.watchersMu.Unlock()
+	}
 }
 
 // MutationChan returns the mutation event channel for the daemon to consume
@@ -34,7 +34,7 @@ func (s *Server) handleExport(req *Request) Response {
 	ctx := s.reqCtx(req)
 
 	// Get all issues
-	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 	if err != nil {
 		return Response{
 			Success: false,
@@ -366,7 +366,7 @@ func (s *Server) triggerExport(ctx context.Context, store storage.Storage, dbPat
 	}
 
 	// Export to JSONL (this will update the file with remapped IDs)
-	allIssues, err := sqliteStore.SearchIssues(ctx, "", types.IssueFilter{})
+	allIssues, err := sqliteStore.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 	if err != nil {
 		return fmt.Errorf("failed to fetch issues for export: %w", err)
 	}
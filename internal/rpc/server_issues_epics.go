@@ -1,11 +1,14 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/sqlite"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/util"
@@ -69,9 +72,40 @@ func updatesFromArgs(a UpdateArgs) map[string]interface{} {
 	if a.ExternalRef != nil {
 		u["external_ref"] = *a.ExternalRef
 	}
+	if a.EstimatedMinutes != nil {
+		u["estimated_minutes"] = *a.EstimatedMinutes
+	}
+	if a.SpentMinutes != nil {
+		u["spent_minutes"] = *a.SpentMinutes
+	}
+	for _, field := range a.Clear {
+		if value, ok := types.ClearableFields[field]; ok {
+			u[field] = value
+		}
+	}
+	if a.Archived != nil {
+		if *a.Archived {
+			u["archived_at"] = time.Now()
+		} else {
+			u["archived_at"] = nil
+		}
+	}
 	return u
 }
 
+// validateClearFields checks that every field named in UpdateArgs.Clear can
+// be cleared
+func validateClearFields(fields []string) error {
+	for _, field := range fields {
+		if parsed, err := types.ParseClearField(field); err != nil {
+			return err
+		} else if parsed != field {
+			return fmt.Errorf("can't clear %q (use the field name %s)", field, parsed)
+		}
+	}
+	return nil
+}
+
 func (s *Server) handleCreate(req *Request) Response {
 	var createArgs CreateArgs
 	if err := json.Unmarshal(req.Args, &createArgs); err != nil {
@@ -98,19 +132,6 @@ func (s *Server) handleCreate(req *Request) Response {
 	}
 	ctx := s.reqCtx(req)
 
-	// If parent is specified, generate child ID
-	issueID := createArgs.ID
-	if createArgs.Parent != "" {
-		childID, err := store.GetNextChildID(ctx, createArgs.Parent)
-		if err != nil {
-			return Response{
-				Success: false,
-				Error:   fmt.Sprintf("failed to generate child ID: %v", err),
-			}
-		}
-		issueID = childID
-	}
-
 	var design, acceptance, assignee, externalRef *string
 	if createArgs.Design != "" {
 		design = &createArgs.Design
@@ -126,7 +147,7 @@ func (s *Server) handleCreate(req *Request) Response {
 	}
 
 	issue := &types.Issue{
-		ID:                 issueID,
+		ID:                 createArgs.ID,
 		Title:              createArgs.Title,
 		Description:        createArgs.Description,
 		IssueType:          types.IssueType(createArgs.IssueType),
@@ -136,6 +157,7 @@ func (s *Server) handleCreate(req *Request) Response {
 		Assignee:           strValue(assignee),
 		ExternalRef:        externalRef,
 		Status:             types.StatusOpen,
+		IDLength:           createArgs.IDLength,
 	}
 	
 	// Check if any dependencies are discovered-from type
@@ -172,8 +194,34 @@ func (s *Server) handleCreate(req *Request) Response {
 		}
 		// If error getting parent or parent has no source_repo, continue with default
 	}
+
+	var similar []*types.Issue
+	if createArgs.CheckDuplicates {
+		var err error
+		similar, err = utils.FindSimilarOpenIssues(ctx, store, issue.Title)
+		if err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("failed to check for duplicates: %v", err),
+			}
+		}
+		if len(similar) > 0 && !createArgs.AllowDuplicates {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("possible duplicate of open issues with a similar title: %s", strings.Join(utils.IssueIDs(similar), ", ")),
+			}
+		}
+	}
 	
-	if err := store.CreateIssue(ctx, issue, s.reqActor(req)); err != nil {
+	// A child gets its parent's next hierarchical ID and the parent-child
+	// dependency in the same transaction
+	var err error
+	if createArgs.Parent != "" {
+		err = store.CreateChildIssue(ctx, issue, createArgs.Parent, s.reqActor(req))
+	} else {
+		err = store.CreateIssue(ctx, issue, s.reqActor(req))
+	}
+	if err != nil {
 		return Response{
 			Success: false,
 			Error:   fmt.Sprintf("failed to create issue: %v", err),
@@ -238,7 +286,7 @@ func (s *Server) handleCreate(req *Request) Response {
 	// Emit mutation event for event-driven daemon
 	s.emitMutation(MutationCreate, issue.ID)
 
-	data, _ := json.Marshal(issue)
+	data, _ := json.Marshal(CreatedIssue{Issue: issue, PossibleDuplicates: utils.IssueIDs(similar)})
 	return Response{
 		Success: true,
 		Data:    data,
@@ -262,16 +310,50 @@ func (s *Server) handleUpdate(req *Request) Response {
 		}
 	}
 
+	if err := validateClearFields(updateArgs.Clear); err != nil {
+		return Response{Success: false, Error: err.Error(), Code: types.ErrCodeValidation}
+	}
 	ctx := s.reqCtx(req)
 	updates := updatesFromArgs(updateArgs)
+	changesMetadata := len(updateArgs.SetMetadata) > 0 || len(updateArgs.UnsetMetadata) > 0
+	changesAttachments := len(updateArgs.AddAttachments) > 0 || len(updateArgs.RemoveAttachments) > 0
+	if changesMetadata || changesAttachments {
+		existing, err := store.GetIssue(ctx, updateArgs.ID)
+		if err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("failed to get issue: %v", err),
+			}
+		}
+		if existing == nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("issue %s not found", updateArgs.ID),
+				Code:    types.ErrCodeNotFound,
+			}
+		}
+		if changesMetadata {
+			updates["metadata"] = types.MergeMetadata(existing.Metadata, updateArgs.SetMetadata, updateArgs.UnsetMetadata)
+		}
+		if changesAttachments {
+			updates["attachments"] = types.MergeAttachments(existing.Attachments, updateArgs.AddAttachments, updateArgs.RemoveAttachments)
+		}
+	}
 	if len(updates) == 0 {
 		return Response{Success: true}
 	}
 
-	if err := store.UpdateIssue(ctx, updateArgs.ID, updates, s.reqActor(req)); err != nil {
+	var err error
+	if updateArgs.ExpectedUpdatedAt != nil {
+		err = store.UpdateIssueIfUnchanged(ctx, updateArgs.ID, *updateArgs.ExpectedUpdatedAt, updates, s.reqActor(req))
+	} else {
+		err = store.UpdateIssue(ctx, updateArgs.ID, updates, s.reqActor(req))
+	}
+	if err != nil {
 		return Response{
 			Success: false,
 			Error:   fmt.Sprintf("failed to update issue: %v", err),
+			Code:    types.ErrorCode(err),
 		}
 	}
 
@@ -293,6 +375,79 @@ func (s *Server) handleUpdate(req *Request) Response {
 	}
 }
 
+func (s *Server) handleUpdateMany(req *Request) Response {
+	var updateManyArgs UpdateManyArgs
+	if err := json.Unmarshal(req.Args, &updateManyArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid update_many args: %v", err),
+		}
+	}
+
+	store := s.storage
+	if store == nil {
+		return Response{
+			Success: false,
+			Error:   "storage not available (global daemon deprecated - use local daemon instead with 'bd daemon' in your project)",
+		}
+	}
+
+	if err := validateClearFields(updateManyArgs.Update.Clear); err != nil {
+		return Response{Success: false, Error: err.Error(), Code: types.ErrCodeValidation}
+	}
+	ctx := s.reqCtx(req)
+	updates := updatesFromArgs(updateManyArgs.Update)
+	changesMetadata := len(updateManyArgs.Update.SetMetadata) > 0 || len(updateManyArgs.Update.UnsetMetadata) > 0
+	if len(updates) == 0 && !changesMetadata {
+		return Response{Success: true, Data: json.RawMessage("[]")}
+	}
+
+	actor := s.reqActor(req)
+	err := store.WithTx(ctx, func(tx storage.Transaction) error {
+		for _, id := range updateManyArgs.IDs {
+			issueUpdates := updates
+			if changesMetadata {
+				existing, err := tx.GetIssue(ctx, id)
+				if err != nil {
+					return fmt.Errorf("failed to get issue %s: %w", id, err)
+				}
+				if existing == nil {
+					return fmt.Errorf("issue %s not found", id)
+				}
+				issueUpdates = make(map[string]interface{}, len(updates)+1)
+				for key, value := range updates {
+					issueUpdates[key] = value
+				}
+				issueUpdates["metadata"] = types.MergeMetadata(existing.Metadata, updateManyArgs.Update.SetMetadata, updateManyArgs.Update.UnsetMetadata)
+			}
+			if err := tx.UpdateIssue(ctx, id, issueUpdates, actor); err != nil {
+				return fmt.Errorf("failed to update %s: %w", id, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+
+	issues := make([]*types.Issue, 0, len(updateManyArgs.IDs))
+	for _, id := range updateManyArgs.IDs {
+		s.emitMutation(MutationUpdate, id)
+		if issue, err := store.GetIssue(ctx, id); err == nil && issue != nil {
+			issues = append(issues, issue)
+		}
+	}
+
+	data, _ := json.Marshal(issues)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
 func (s *Server) handleClose(req *Request) Response {
 	var closeArgs CloseArgs
 	if err := json.Unmarshal(req.Args, &closeArgs); err != nil {
@@ -311,10 +466,17 @@ func (s *Server) handleClose(req *Request) Response {
 	}
 
 	ctx := s.reqCtx(req)
-	if err := store.CloseIssue(ctx, closeArgs.ID, closeArgs.Reason, s.reqActor(req)); err != nil {
+	opts := storage.CloseOptions{
+		Reason:     closeArgs.Reason,
+		Note:       closeArgs.Note,
+		Resolution: types.Resolution(closeArgs.Resolution),
+		Commit:     closeArgs.ResolvedBy,
+	}
+	if err := store.CloseIssue(ctx, closeArgs.ID, opts, s.reqActor(req)); err != nil {
 		return Response{
 			Success: false,
 			Error:   fmt.Sprintf("failed to close issue: %v", err),
+			Code:    types.ErrorCode(err),
 		}
 	}
 
@@ -329,6 +491,43 @@ func (s *Server) handleClose(req *Request) Response {
 	}
 }
 
+func (s *Server) handleReopen(req *Request) Response {
+	var reopenArgs ReopenArgs
+	if err := json.Unmarshal(req.Args, &reopenArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid reopen args: %v", err),
+		}
+	}
+
+	store := s.storage
+	if store == nil {
+		return Response{
+			Success: false,
+			Error:   "storage not available (global daemon deprecated - use local daemon instead with 'bd daemon' in your project)",
+		}
+	}
+
+	ctx := s.reqCtx(req)
+	if err := store.ReopenIssue(ctx, reopenArgs.ID, reopenArgs.Note, s.reqActor(req)); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to reopen issue: %v", err),
+			Code:    types.ErrorCode(err),
+		}
+	}
+
+	// Emit mutation event for event-driven daemon
+	s.emitMutation(MutationUpdate, reopenArgs.ID)
+
+	issue, _ := store.GetIssue(ctx, reopenArgs.ID)
+	data, _ := json.Marshal(issue)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
 func (s *Server) handleList(req *Request) Response {
 	var listArgs ListArgs
 	if err := json.Unmarshal(req.Args, &listArgs); err != nil {
@@ -346,15 +545,160 @@ func (s *Server) handleList(req *Request) Response {
 		}
 	}
 
+	filter, err := listFilter(&listArgs)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+
+	ctx := s.reqCtx(req)
+	issues, err := store.SearchIssues(ctx, listArgs.Query, filter)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to list issues: %v", err),
+		}
+	}
+
+	data, _ := json.Marshal(issuesWithCounts(ctx, store, issues))
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+// handleListStream lists issues like handleList, but sends them in frames of
+// ChunkSize issues as they are prepared. Without a stream (e.g. inside a
+// batch) every issue goes in the single final frame. The final frame also
+// carries the number of matches before limit and offset.
+func (s *Server) handleListStream(req *Request) Response {
+	var args ListStreamArgs
+	if err := json.Unmarshal(req.Args, &args); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid list_stream args: %v", err),
+		}
+	}
+
+	store := s.storage
+	if store == nil {
+		return Response{
+			Success: false,
+			Error:   "storage not available (global daemon deprecated - use local daemon instead with 'bd daemon' in your project)",
+		}
+	}
+
+	filter, err := listFilter(&args.ListArgs)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+
+	ctx := s.reqCtx(req)
+	issues, err := store.SearchIssues(ctx, args.Query, filter)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to list issues: %v", err),
+		}
+	}
+	total := len(issues)
+	if filter.Limit > 0 || filter.Offset > 0 {
+		if total, err = store.CountIssues(ctx, args.Query, filter); err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("failed to count issues: %v", err),
+			}
+		}
+	}
+
+	chunkSize := args.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultListChunkSize
+	}
+	for req.stream != nil && len(issues) > chunkSize {
+		data, _ := json.Marshal(ListChunk{Issues: issuesWithCounts(ctx, store, issues[:chunkSize]), More: true})
+		if err := req.stream(Response{Success: true, Data: data}); err != nil {
+			return Response{
+				Success: false,
+				Error:   fmt.Sprintf("failed to send issues: %v", err),
+			}
+		}
+		issues = issues[chunkSize:]
+	}
+
+	data, _ := json.Marshal(ListChunk{Issues: issuesWithCounts(ctx, store, issues), Total: total})
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+// handleCount counts the issues a list with the same args matches, without
+// reading them
+func (s *Server) handleCount(req *Request) Response {
+	var listArgs ListArgs
+	if err := json.Unmarshal(req.Args, &listArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid count args: %v", err),
+		}
+	}
+
+	store := s.storage
+	if store == nil {
+		return Response{
+			Success: false,
+			Error:   "storage not available (global daemon deprecated - use local daemon instead with 'bd daemon' in your project)",
+		}
+	}
+
+	filter, err := listFilter(&listArgs)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   err.Error(),
+		}
+	}
+
+	count, err := store.CountIssues(s.reqCtx(req), listArgs.Query, filter)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to count issues: %v", err),
+		}
+	}
+	data, _ := json.Marshal(CountResult{Count: count})
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+// listFilter converts list arguments into a storage filter
+func listFilter(listArgs *ListArgs) (types.IssueFilter, error) {
 	filter := types.IssueFilter{
-		Limit: listArgs.Limit,
+		Limit:  listArgs.Limit,
+		Offset: listArgs.Offset,
+	}
+	sortBy, err := types.ParseSortKeys(listArgs.Sort)
+	if err != nil {
+		return filter, err
 	}
+	filter.SortBy = sortBy
 	
 	// Normalize status: treat "" or "all" as unset (no filter)
 	if listArgs.Status != "" && listArgs.Status != "all" {
 		status := types.Status(listArgs.Status)
 		filter.Status = &status
 	}
+	for _, status := range listArgs.ExcludeStatus {
+		filter.ExcludeStatus = append(filter.ExcludeStatus, types.Status(status))
+	}
 	
 	if listArgs.IssueType != "" {
 		issueType := types.IssueType(listArgs.IssueType)
@@ -395,60 +739,42 @@ func (s *Server) handleList(req *Request) Response {
 	if listArgs.CreatedAfter != "" {
 		t, err := parseTimeRPC(listArgs.CreatedAfter)
 		if err != nil {
-			return Response{
-				Success: false,
-				Error:   fmt.Sprintf("invalid --created-after date: %v", err),
-			}
+			return filter, fmt.Errorf("invalid --created-after date: %v", err)
 		}
 		filter.CreatedAfter = &t
 	}
 	if listArgs.CreatedBefore != "" {
 		t, err := parseTimeRPC(listArgs.CreatedBefore)
 		if err != nil {
-			return Response{
-				Success: false,
-				Error:   fmt.Sprintf("invalid --created-before date: %v", err),
-			}
+			return filter, fmt.Errorf("invalid --created-before date: %v", err)
 		}
 		filter.CreatedBefore = &t
 	}
 	if listArgs.UpdatedAfter != "" {
 		t, err := parseTimeRPC(listArgs.UpdatedAfter)
 		if err != nil {
-			return Response{
-				Success: false,
-				Error:   fmt.Sprintf("invalid --updated-after date: %v", err),
-			}
+			return filter, fmt.Errorf("invalid --updated-after date: %v", err)
 		}
 		filter.UpdatedAfter = &t
 	}
 	if listArgs.UpdatedBefore != "" {
 		t, err := parseTimeRPC(listArgs.UpdatedBefore)
 		if err != nil {
-			return Response{
-				Success: false,
-				Error:   fmt.Sprintf("invalid --updated-before date: %v", err),
-			}
+			return filter, fmt.Errorf("invalid --updated-before date: %v", err)
 		}
 		filter.UpdatedBefore = &t
 	}
 	if listArgs.ClosedAfter != "" {
 		t, err := parseTimeRPC(listArgs.ClosedAfter)
 		if err != nil {
-			return Response{
-				Success: false,
-				Error:   fmt.Sprintf("invalid --closed-after date: %v", err),
-			}
+			return filter, fmt.Errorf("invalid --closed-after date: %v", err)
 		}
 		filter.ClosedAfter = &t
 	}
 	if listArgs.ClosedBefore != "" {
 		t, err := parseTimeRPC(listArgs.ClosedBefore)
 		if err != nil {
-			return Response{
-				Success: false,
-				Error:   fmt.Sprintf("invalid --closed-before date: %v", err),
-			}
+			return filter, fmt.Errorf("invalid --closed-before date: %v", err)
 		}
 		filter.ClosedBefore = &t
 	}
@@ -457,6 +783,10 @@ func (s *Server) handleList(req *Request) Response {
 	filter.EmptyDescription = listArgs.EmptyDescription
 	filter.NoAssignee = listArgs.NoAssignee
 	filter.NoLabels = listArgs.NoLabels
+	filter.Ready = listArgs.Ready
+	filter.Blocked = listArgs.Blocked
+	filter.Metadata = listArgs.Metadata
+	filter.IncludeArchived = listArgs.IncludeArchived
 	
 	// Priority range
 	filter.PriorityMin = listArgs.PriorityMin
@@ -465,21 +795,13 @@ func (s *Server) handleList(req *Request) Response {
 	// Guard against excessive ID lists to avoid SQLite parameter limits
 	const maxIDs = 1000
 	if len(filter.IDs) > maxIDs {
-		return Response{
-			Success: false,
-			Error:   fmt.Sprintf("--id flag supports at most %d issue IDs, got %d", maxIDs, len(filter.IDs)),
-		}
-	}
-
-	ctx := s.reqCtx(req)
-	issues, err := store.SearchIssues(ctx, listArgs.Query, filter)
-	if err != nil {
-		return Response{
-			Success: false,
-			Error:   fmt.Sprintf("failed to list issues: %v", err),
-		}
+		return filter, fmt.Errorf("--id flag supports at most %d issue IDs, got %d", maxIDs, len(filter.IDs))
 	}
+	return filter, nil
+}
 
+// issuesWithCounts attaches labels and dependency counts to issues
+func issuesWithCounts(ctx context.Context, store storage.Storage, issues []*types.Issue) []*types.IssueWithCounts {
 	// Populate labels for each issue
 	for _, issue := range issues {
 		labels, _ := store.GetLabels(ctx, issue.ID)
@@ -494,24 +816,19 @@ func (s *Server) handleList(req *Request) Response {
 	depCounts, _ := store.GetDependencyCounts(ctx, issueIDs)
 
 	// Build response with counts
-	issuesWithCounts := make([]*types.IssueWithCounts, len(issues))
+	withCounts := make([]*types.IssueWithCounts, len(issues))
 	for i, issue := range issues {
 		counts := depCounts[issue.ID]
 		if counts == nil {
 			counts = &types.DependencyCounts{DependencyCount: 0, DependentCount: 0}
 		}
-		issuesWithCounts[i] = &types.IssueWithCounts{
+		withCounts[i] = &types.IssueWithCounts{
 			Issue:           issue,
 			DependencyCount: counts.DependencyCount,
 			DependentCount:  counts.DependentCount,
 		}
 	}
-
-	data, _ := json.Marshal(issuesWithCounts)
-	return Response{
-		Success: true,
-		Data:    data,
-	}
+	return withCounts
 }
 
 func (s *Server) handleResolveID(req *Request) Response {
@@ -531,12 +848,25 @@ func (s *Server) handleResolveID(req *Request) Response {
 	}
 
 	ctx := s.reqCtx(req)
-	resolvedID, err := utils.ResolvePartialID(ctx, s.storage, args.ID)
+	var resolvedID string
+	var err error
+	if args.Title {
+		resolvedID, err = utils.ResolveTitle(ctx, s.storage, args.ID)
+	} else {
+		resolvedID, err = utils.ResolvePartialID(ctx, s.storage, args.ID)
+	}
 	if err != nil {
-		return Response{
+		resp := Response{
 			Success: false,
 			Error:   fmt.Sprintf("failed to resolve ID: %v", err),
+			Code:    types.ErrorCode(err),
 		}
+		// Send the candidates along so the client can rebuild the typed error
+		var ambiguous *utils.AmbiguousIDError
+		if errors.As(err, &ambiguous) {
+			resp.Data, _ = json.Marshal(ambiguous)
+		}
+		return resp
 	}
 
 	data, _ := json.Marshal(resolvedID)
@@ -575,6 +905,7 @@ func (s *Server) handleShow(req *Request) Response {
 		return Response{
 			Success: false,
 			Error:   fmt.Sprintf("issue not found: %s", showArgs.ID),
+			Code:    types.ErrCodeNotFound,
 		}
 	}
 
@@ -605,12 +936,18 @@ func (s *Server) handleShow(req *Request) Response {
 		}
 	}
 
+	lock, _ := store.GetLock(ctx, issue.ID)
+
 	// Create detailed response with related data
 	type IssueDetails struct {
 		*types.Issue
 		Labels       []string                              `json:"labels,omitempty"`
 		Dependencies []*types.IssueWithDependencyMetadata `json:"dependencies,omitempty"`
 		Dependents   []*types.IssueWithDependencyMetadata `json:"dependents,omitempty"`
+		Comments     []*types.Comment                      `json:"comments,omitempty"`
+		Lock         *types.IssueLock                      `json:"lock,omitempty"`
+		Events       []*types.Event                        `json:"events,omitempty"`
+		URL          string                                `json:"url,omitempty"`
 	}
 
 	details := &IssueDetails{
@@ -618,6 +955,14 @@ func (s *Server) handleShow(req *Request) Response {
 		Labels:       labels,
 		Dependencies: deps,
 		Dependents:   dependents,
+		Lock:         lock,
+		URL:          utils.IssueURL(ctx, store, issue.ID),
+	}
+	if showArgs.History {
+		details.Events, _ = store.GetEvents(ctx, issue.ID, 0)
+	}
+	if showArgs.Comments {
+		details.Comments, _ = store.GetIssueComments(ctx, issue.ID)
 	}
 
 	data, _ := json.Marshal(details)
@@ -645,14 +990,24 @@ func (s *Server) handleReady(req *Request) Response {
 	}
 
 	wf := types.WorkFilter{
-		Status:     types.StatusOpen,
-		Priority:   readyArgs.Priority,
-		Limit:      readyArgs.Limit,
-		SortPolicy: types.SortPolicy(readyArgs.SortPolicy),
-		Labels:     util.NormalizeLabels(readyArgs.Labels),
-		LabelsAny:  util.NormalizeLabels(readyArgs.LabelsAny),
-	}
-	if readyArgs.Assignee != "" {
+		Status:      types.StatusOpen,
+		Priority:    readyArgs.Priority,
+		MinPriority: readyArgs.MinPriority,
+		Limit:       readyArgs.Limit,
+		SortPolicy:  types.SortPolicy(readyArgs.SortPolicy),
+		Labels:      util.NormalizeLabels(readyArgs.Labels),
+		LabelsAny:   util.NormalizeLabels(readyArgs.LabelsAny),
+	}
+	if readyArgs.Status != "" {
+		wf.Status = types.Status(readyArgs.Status)
+	}
+	if readyArgs.IssueType != "" {
+		wf.IssueType = types.IssueType(readyArgs.IssueType)
+	}
+	if readyArgs.Unassigned {
+		unassigned := ""
+		wf.Assignee = &unassigned
+	} else if readyArgs.Assignee != "" {
 		wf.Assignee = &readyArgs.Assignee
 	}
 
@@ -690,9 +1045,10 @@ func (s *Server) handleStale(req *Request) Response {
 	}
 
 	filter := types.StaleFilter{
-		Days:   staleArgs.Days,
-		Status: staleArgs.Status,
-		Limit:  staleArgs.Limit,
+		Days:      staleArgs.Days,
+		OlderThan: staleArgs.OlderThan,
+		Status:    staleArgs.Status,
+		Limit:     staleArgs.Limit,
 	}
 
 	ctx := s.reqCtx(req)
@@ -43,7 +43,25 @@ func (s *Server) handleDepAdd(req *Request) Response {
 	// Emit mutation event for event-driven daemon
 	s.emitMutation(MutationUpdate, depArgs.FromID)
 
-	return Response{Success: true}
+	if dep.Type != types.DepBlocks {
+		return Response{Success: true}
+	}
+	weight := -1
+	if depArgs.Weight != nil {
+		weight = *depArgs.Weight
+	}
+	change, err := store.PropagatePriority(ctx, depArgs.FromID, depArgs.ToID, weight, s.reqActor(req))
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("dependency added but priority propagation failed: %v", err),
+		}
+	}
+	if change == nil {
+		return Response{Success: true}
+	}
+	data, _ := json.Marshal(change)
+	return Response{Success: true, Data: data}
 }
 
 // Generic handler for simple store operations with standard error handling
@@ -138,7 +156,13 @@ func (s *Server) handleCommentAdd(req *Request) Response {
 	store := s.storage
 
 	ctx := s.reqCtx(req)
-	comment, err := store.AddIssueComment(ctx, commentArgs.ID, commentArgs.Author, commentArgs.Text)
+	var comment *types.Comment
+	var err error
+	if commentArgs.ParentID != 0 {
+		comment, err = store.AddCommentReply(ctx, commentArgs.ID, commentArgs.ParentID, commentArgs.Author, commentArgs.Text)
+	} else {
+		comment, err = store.AddIssueComment(ctx, commentArgs.ID, commentArgs.Author, commentArgs.Text)
+	}
 	if err != nil {
 		return Response{
 			Success: false,
@@ -147,7 +171,97 @@ func (s *Server) handleCommentAdd(req *Request) Response {
 	}
 
 	// Emit mutation event for event-driven daemon
-	s.emitMutation(MutationComment, commentArgs.ID)
+	s.emitMutation(MutationComment, comment.IssueID)
+
+	data, _ := json.Marshal(comment)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+func (s *Server) handleCommentResolve(req *Request) Response {
+	var resolveArgs CommentResolveArgs
+	if err := json.Unmarshal(req.Args, &resolveArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid comment resolve args: %v", err),
+		}
+	}
+
+	store := s.storage
+
+	ctx := s.reqCtx(req)
+	root, err := store.ResolveComment(ctx, resolveArgs.CommentID, resolveArgs.Resolved)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to resolve comment: %v", err),
+		}
+	}
+
+	// Emit mutation event for event-driven daemon
+	s.emitMutation(MutationComment, root.IssueID)
+
+	data, _ := json.Marshal(root)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+func (s *Server) handleCommentEdit(req *Request) Response {
+	var editArgs CommentEditArgs
+	if err := json.Unmarshal(req.Args, &editArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid comment edit args: %v", err),
+		}
+	}
+
+	store := s.storage
+
+	ctx := s.reqCtx(req)
+	comment, err := store.EditComment(ctx, editArgs.CommentID, editArgs.Text, s.reqActor(req))
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to edit comment: %v", err),
+		}
+	}
+
+	// Emit mutation event for event-driven daemon
+	s.emitMutation(MutationComment, comment.IssueID)
+
+	data, _ := json.Marshal(comment)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+func (s *Server) handleCommentDelete(req *Request) Response {
+	var deleteArgs CommentDeleteArgs
+	if err := json.Unmarshal(req.Args, &deleteArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid comment delete args: %v", err),
+		}
+	}
+
+	store := s.storage
+
+	ctx := s.reqCtx(req)
+	comment, err := store.DeleteComment(ctx, deleteArgs.CommentID, s.reqActor(req))
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to delete comment: %v", err),
+		}
+	}
+
+	// Emit mutation event for event-driven daemon
+	s.emitMutation(MutationComment, comment.IssueID)
 
 	data, _ := json.Marshal(comment)
 	return Response{
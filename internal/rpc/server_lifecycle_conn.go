@@ -219,16 +219,31 @@ func (s *Server) handleConnection(conn net.Conn) {
 			return
 		}
 
+		// Each frame of a streamed response gets its own write deadline
+		req.stream = func(frame Response) error {
+			if err := conn.SetWriteDeadline(time.Now().Add(s.requestTimeout)); err != nil {
+				return err
+			}
+			return s.writeResponse(writer, frame)
+		}
+
 		resp := s.handleRequest(&req)
+		if err := conn.SetWriteDeadline(time.Now().Add(s.requestTimeout)); err != nil {
+			return
+		}
 		s.writeResponse(writer, resp)
 	}
 }
 
-func (s *Server) writeResponse(writer *bufio.Writer, resp Response) {
+func (s *Server) writeResponse(writer *bufio.Writer, resp Response) error {
 	data, _ := json.Marshal(resp)
-	_, _ = writer.Write(data)
-	_ = writer.WriteByte('\n')
-	_ = writer.Flush()
+	if _, err := writer.Write(data); err != nil {
+		return err
+	}
+	if err := writer.WriteByte('\n'); err != nil {
+		return err
+	}
+	return writer.Flush()
 }
 
 func (s *Server) handleShutdown(_ *Request) Response {
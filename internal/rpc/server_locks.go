@@ -0,0 +1,116 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Advisory locks are local coordination state and are not exported to JSONL,
+// so lock operations don't emit mutation events.
+
+func (s *Server) handleLock(req *Request) Response {
+	var lockArgs LockArgs
+	if err := json.Unmarshal(req.Args, &lockArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid lock args: %v", err),
+		}
+	}
+
+	store := s.storage
+	if store == nil {
+		return Response{
+			Success: false,
+			Error:   "storage not available (global daemon deprecated - use local daemon instead with 'bd daemon' in your project)",
+		}
+	}
+
+	holder := lockArgs.Holder
+	if holder == "" {
+		holder = s.reqActor(req)
+	}
+
+	ctx := s.reqCtx(req)
+	ttl := time.Duration(lockArgs.TTLSeconds) * time.Second
+	lock, err := store.AcquireLock(ctx, lockArgs.ID, holder, ttl)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to lock issue: %v", err),
+		}
+	}
+
+	data, _ := json.Marshal(lock)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
+
+func (s *Server) handleUnlock(req *Request) Response {
+	var unlockArgs UnlockArgs
+	if err := json.Unmarshal(req.Args, &unlockArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid unlock args: %v", err),
+		}
+	}
+
+	store := s.storage
+	if store == nil {
+		return Response{
+			Success: false,
+			Error:   "storage not available (global daemon deprecated - use local daemon instead with 'bd daemon' in your project)",
+		}
+	}
+
+	holder := unlockArgs.Holder
+	if holder == "" {
+		holder = s.reqActor(req)
+	}
+
+	ctx := s.reqCtx(req)
+	if err := store.ReleaseLock(ctx, unlockArgs.ID, holder, unlockArgs.Force); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to unlock issue: %v", err),
+		}
+	}
+
+	return Response{Success: true}
+}
+
+func (s *Server) handleLockStatus(req *Request) Response {
+	var statusArgs LockStatusArgs
+	if err := json.Unmarshal(req.Args, &statusArgs); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid lock status args: %v", err),
+		}
+	}
+
+	store := s.storage
+	if store == nil {
+		return Response{
+			Success: false,
+			Error:   "storage not available (global daemon deprecated - use local daemon instead with 'bd daemon' in your project)",
+		}
+	}
+
+	ctx := s.reqCtx(req)
+	lock, err := store.GetLock(ctx, statusArgs.ID)
+	if err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("failed to get lock: %v", err),
+		}
+	}
+
+	// Data is "null" when the issue is unlocked
+	data, _ := json.Marshal(lock)
+	return Response{
+		Success: true,
+		Data:    data,
+	}
+}
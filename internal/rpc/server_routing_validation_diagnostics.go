@@ -174,10 +174,18 @@ func (s *Server) handleRequest(req *Request) Response {
 		resp = s.handleCreate(req)
 	case OpUpdate:
 		resp = s.handleUpdate(req)
+	case OpUpdateMany:
+		resp = s.handleUpdateMany(req)
 	case OpClose:
 		resp = s.handleClose(req)
+	case OpReopen:
+		resp = s.handleReopen(req)
 	case OpList:
 		resp = s.handleList(req)
+	case OpListStream:
+		resp = s.handleListStream(req)
+	case OpCount:
+		resp = s.handleCount(req)
 	case OpShow:
 		resp = s.handleShow(req)
 	case OpResolveID:
@@ -200,8 +208,20 @@ func (s *Server) handleRequest(req *Request) Response {
 		resp = s.handleCommentList(req)
 	case OpCommentAdd:
 		resp = s.handleCommentAdd(req)
+	case OpCommentResolve:
+		resp = s.handleCommentResolve(req)
+	case OpCommentEdit:
+		resp = s.handleCommentEdit(req)
+	case OpCommentDelete:
+		resp = s.handleCommentDelete(req)
 	case OpBatch:
 		resp = s.handleBatch(req)
+	case OpLock:
+		resp = s.handleLock(req)
+	case OpUnlock:
+		resp = s.handleUnlock(req)
+	case OpLockStatus:
+		resp = s.handleLockStatus(req)
 	
 	case OpCompact:
 		resp = s.handleCompact(req)
@@ -215,6 +235,8 @@ func (s *Server) handleRequest(req *Request) Response {
 		resp = s.handleEpicStatus(req)
 	case OpGetMutations:
 		resp = s.handleGetMutations(req)
+	case OpWatch:
+		resp = s.handleWatch(req)
 	case OpShutdown:
 		resp = s.handleShutdown(req)
 	default:
@@ -272,6 +294,16 @@ func (s *Server) handleStatus(_ *Request) Response {
 		}
 	}
 	
+	// Dirty issues are the ones the next export will write
+	pendingFlush := 0
+	if s.storage != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+		defer cancel()
+		if dirty, err := s.storage.GetDirtyIssues(ctx); err == nil {
+			pendingFlush = len(dirty)
+		}
+	}
+	
 	statusResp := StatusResponse{
 		Version:             ServerVersion,
 		WorkspacePath:       s.workspacePath,
@@ -282,6 +314,8 @@ func (s *Server) handleStatus(_ *Request) Response {
 		LastActivityTime:    lastActivity.Format(time.RFC3339),
 		ExclusiveLockActive: lockActive,
 		ExclusiveLockHolder: lockHolder,
+		PendingFlushCount:   pendingFlush,
+		WatchState:          s.watchState.Load().(WatchState),
 	}
 	
 	data, _ := json.Marshal(statusResp)
@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// Watch stream timings. Mutations made through the daemon wake the stream
+// at once; the poll catches changes that bypass it, such as auto-imports.
+const (
+	watchPollInterval      = time.Second
+	watchHeartbeatInterval = 10 * time.Second
+	watchEventsPageSize    = 100
+)
+
+// handleWatch streams every event recorded after the requested one, each
+// with its issue's current state, until the daemon stops or the client goes
+// away. Heartbeat frames are sent while nothing happens, so a closed
+// connection is noticed and the stream ends.
+func (s *Server) handleWatch(req *Request) Response {
+	var args WatchArgs
+	if err := json.Unmarshal(req.Args, &args); err != nil {
+		return Response{
+			Success: false,
+			Error:   fmt.Sprintf("invalid watch args: %v", err),
+		}
+	}
+	if req.stream == nil {
+		return Response{
+			Success: false,
+			Error:   "watch needs its own connection",
+		}
+	}
+	store := s.storage
+	if store == nil {
+		return Response{
+			Success: false,
+			Error:   "storage not available (global daemon deprecated - use local daemon instead with 'bd daemon' in your project)",
+		}
+	}
+
+	ctx := s.reqCtx(req)
+	lastID := args.AfterEventID
+	if lastID == 0 {
+		// Start after the newest event, so only new changes are streamed
+		for {
+			events, err := store.GetEventsSince(ctx, lastID, 1000)
+			if err != nil {
+				return Response{
+					Success: false,
+					Error:   fmt.Sprintf("failed to read events: %v", err),
+				}
+			}
+			if len(events) == 0 {
+				break
+			}
+			lastID = events[len(events)-1].ID
+		}
+	}
+
+	wake, unsubscribe := s.subscribeMutations()
+	defer unsubscribe()
+	poll := time.NewTicker(watchPollInterval)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(watchHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	send := func(frame WatchFrame) error {
+		frame.More = true
+		data, _ := json.Marshal(frame)
+		return req.stream(Response{Success: true, Data: data})
+	}
+	for {
+		select {
+		case <-wake:
+		case <-poll.C:
+		case <-heartbeat.C:
+			// Keep an idle daemon alive while someone watches it
+			s.lastActivityTime.Store(time.Now())
+			if err := send(WatchFrame{}); err != nil {
+				return Response{Success: false, Error: fmt.Sprintf("watch stream closed: %v", err)}
+			}
+			continue
+		case <-s.shutdownChan:
+			data, _ := json.Marshal(WatchFrame{})
+			return Response{Success: true, Data: data}
+		}
+
+		for {
+			events, err := watchEventsAfter(s, store, req, lastID)
+			if err != nil {
+				return Response{Success: false, Error: err.Error()}
+			}
+			if len(events) == 0 {
+				break
+			}
+			lastID = events[len(events)-1].Event.ID
+			if err := send(WatchFrame{Events: events}); err != nil {
+				return Response{Success: false, Error: fmt.Sprintf("watch stream closed: %v", err)}
+			}
+		}
+	}
+}
+
+// watchEventsAfter reads the next page of events after afterID, attaching
+// each issue's current state
+func watchEventsAfter(s *Server, store storage.Storage, req *Request, afterID int64) ([]WatchEvent, error) {
+	ctx := s.reqCtx(req)
+	events, err := store.GetEventsSince(ctx, afterID, watchEventsPageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
+	watchEvents := make([]WatchEvent, 0, len(events))
+	for _, event := range events {
+		issue, err := store.GetIssue(ctx, event.IssueID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", event.IssueID, err)
+		}
+		watchEvents = append(watchEvents, WatchEvent{Event: event, Issue: issue})
+	}
+	return watchEvents, nil
+}
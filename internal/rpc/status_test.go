@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/storage/sqlite"
+	"github.com/steveyegge/beads/internal/types"
 )
 
 func TestStatusEndpoint(t *testing.T) {
@@ -83,3 +84,62 @@ func TestStatusEndpoint(t *testing.T) {
 		t.Errorf("last activity time too old: %v", lastActivity)
 	}
 }
+
+func TestStatusEndpointWatchState(t *testing.T) {
+	tmpDir := t.TempDir()
+	dbPath := filepath.Join(tmpDir, "test.db")
+	socketPath := filepath.Join(tmpDir, "test.sock")
+
+	store := newTestStore(t, dbPath)
+	defer store.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	issue := &types.Issue{Title: "Unexported", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	server := NewServer(socketPath, store, tmpDir, dbPath)
+	go func() {
+		_ = server.Start(ctx)
+	}()
+	<-server.WaitReady()
+	defer server.Stop()
+
+	client, err := TryConnect(socketPath)
+	if err != nil || client == nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer client.Close()
+
+	// Nothing reported yet
+	status, err := client.Status()
+	if err != nil {
+		t.Fatalf("status call failed: %v", err)
+	}
+	if status.WatchBackend != "" || len(status.WatchedPaths) != 0 {
+		t.Errorf("expected no watch state before the daemon reports one, got %+v", status.WatchState)
+	}
+	if status.PendingFlushCount != 1 {
+		t.Errorf("expected 1 pending flush, got %d", status.PendingFlushCount)
+	}
+
+	jsonlPath := filepath.Join(tmpDir, "issues.jsonl")
+	server.SetWatchState(WatchState{
+		WatchedPaths:   []string{jsonlPath},
+		WatchBackend:   WatchBackendPolling,
+		PollingMode:    true,
+		LastReloadTime: "2025-01-02T03:04:05Z",
+	})
+	status, err = client.Status()
+	if err != nil {
+		t.Fatalf("status call failed: %v", err)
+	}
+	if len(status.WatchedPaths) != 1 || status.WatchedPaths[0] != jsonlPath {
+		t.Errorf("expected watched paths [%s], got %v", jsonlPath, status.WatchedPaths)
+	}
+	if status.WatchBackend != WatchBackendPolling || !status.PollingMode || status.LastReloadTime != "2025-01-02T03:04:05Z" {
+		t.Errorf("unexpected watch state: %+v", status.WatchState)
+	}
+}
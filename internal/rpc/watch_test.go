@@ -0,0 +1,80 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWatch(t *testing.T) {
+	server, client, store, cleanup := setupTestServerWithStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	before := &types.Issue{Title: "Before the watch", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, before, "test"); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events := make(chan WatchEvent, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- client.Watch(watchCtx, &WatchArgs{}, func(batch []WatchEvent) error {
+			for _, event := range batch {
+				events <- event
+			}
+			return nil
+		})
+	}()
+	next := func() WatchEvent {
+		t.Helper()
+		select {
+		case event := <-events:
+			return event
+		case err := <-done:
+			t.Fatalf("watch ended early: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a watch event")
+		}
+		return WatchEvent{}
+	}
+
+	// Mutations through the daemon wake the stream
+	time.Sleep(100 * time.Millisecond)
+	writer, err := TryConnect(server.socketPath)
+	if err != nil || writer == nil {
+		t.Fatalf("failed to connect a second client: %v", err)
+	}
+	defer writer.Close()
+	if _, err := writer.Create(&CreateArgs{Title: "During the watch", IssueType: "task", Priority: 1}); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	event := next()
+	if event.Event.EventType != types.EventCreated || event.Issue == nil || event.Issue.Title != "During the watch" {
+		t.Errorf("expected the new issue's created event, got %+v", event)
+	}
+
+	// So do changes made straight to the database, on the next poll
+	if err := store.CloseIssue(ctx, before.ID, storage.CloseOptions{Reason: "done"}, "test"); err != nil {
+		t.Fatalf("failed to close issue: %v", err)
+	}
+	event = next()
+	if event.Event.EventType != types.EventClosed || event.Issue.ID != before.ID || event.Issue.Status != types.StatusClosed {
+		t.Errorf("expected %s's closed event, got %+v", before.ID, event)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected a cancelled watch to return nil, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch didn't return after cancel")
+	}
+}
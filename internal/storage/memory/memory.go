@@ -4,20 +4,26 @@
 package memory
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 )
 
 // MemoryStorage implements the Storage interface using in-memory data structures
 type MemoryStorage struct {
-	mu sync.RWMutex // Protects all maps
+	mu   sync.RWMutex // Protects all maps
+	txMu sync.Mutex   // Serializes WithTx transactions
 
 	// Core data
 	issues       map[string]*types.Issue       // ID -> Issue
@@ -28,6 +34,8 @@ type MemoryStorage struct {
 	config       map[string]string             // Config key-value pairs
 	metadata     map[string]string             // Metadata key-value pairs
 	counters     map[string]int                // Prefix -> Last ID
+	locks        map[string]*types.IssueLock   // IssueID -> advisory lock
+	lastEventID  int64                         // Last ID given to an event
 
 	// For tracking
 	dirty map[string]bool // IssueIDs that have been modified
@@ -47,6 +55,7 @@ func New(jsonlPath string) *MemoryStorage {
 		config:       make(map[string]string),
 		metadata:     make(map[string]string),
 		counters:     make(map[string]int),
+		locks:        make(map[string]*types.IssueLock),
 		dirty:        make(map[string]bool),
 		jsonlPath:    jsonlPath,
 	}
@@ -71,9 +80,11 @@ func (m *MemoryStorage) LoadFromIssues(issues []*types.Issue) error {
 			m.dependencies[issue.ID] = issue.Dependencies
 		}
 
-		// Store labels
-		if len(issue.Labels) > 0 {
-			m.labels[issue.ID] = issue.Labels
+		// Store labels, normalized as AddLabel does
+		for _, label := range issue.Labels {
+			if label = util.NormalizeLabel(label); label != "" && !slices.Contains(m.labels[issue.ID], label) {
+				m.labels[issue.ID] = append(m.labels[issue.ID], label)
+			}
 		}
 
 		// Store comments
@@ -143,6 +154,21 @@ func extractPrefixAndNumber(id string) (string, int) {
 	return parts[0], num
 }
 
+// idPrefixes returns the issue_prefix and prefix_by_type config. Caller
+// must hold the lock.
+func (m *MemoryStorage) idPrefixes() (types.IDPrefixes, error) {
+	prefixes := types.IDPrefixes{Default: m.config["issue_prefix"]}
+	if prefixes.Default == "" {
+		prefixes.Default = "bd" // Default fallback
+	}
+	byType, err := types.ParsePrefixByType(m.config[types.PrefixByTypeConfigKey])
+	if err != nil {
+		return prefixes, err
+	}
+	prefixes.ByType = byType
+	return prefixes, nil
+}
+
 // CreateIssue creates a new issue
 func (m *MemoryStorage) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
 	m.mu.Lock()
@@ -160,10 +186,11 @@ func (m *MemoryStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 
 	// Generate ID if not set
 	if issue.ID == "" {
-		prefix := m.config["issue_prefix"]
-		if prefix == "" {
-			prefix = "bd" // Default fallback
+		prefixes, err := m.idPrefixes()
+		if err != nil {
+			return err
 		}
+		prefix := prefixes.ForType(issue.IssueType)
 
 		// Get next ID
 		m.counters[prefix]++
@@ -186,7 +213,7 @@ func (m *MemoryStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 		Actor:     actor,
 		CreatedAt: now,
 	}
-	m.events[issue.ID] = append(m.events[issue.ID], event)
+	m.recordEvent(event)
 
 	return nil
 }
@@ -204,9 +231,9 @@ func (m *MemoryStorage) CreateIssues(ctx context.Context, issues []*types.Issue,
 	}
 
 	now := time.Now()
-	prefix := m.config["issue_prefix"]
-	if prefix == "" {
-		prefix = "bd"
+	prefixes, err := m.idPrefixes()
+	if err != nil {
+		return err
 	}
 
 	// Track IDs in this batch to detect duplicates within batch
@@ -218,6 +245,7 @@ func (m *MemoryStorage) CreateIssues(ctx context.Context, issues []*types.Issue,
 		issue.UpdatedAt = now
 
 		if issue.ID == "" {
+			prefix := prefixes.ForType(issue.IssueType)
 			m.counters[prefix]++
 			issue.ID = fmt.Sprintf("%s-%d", prefix, m.counters[prefix])
 		}
@@ -246,7 +274,7 @@ func (m *MemoryStorage) CreateIssues(ctx context.Context, issues []*types.Issue,
 			Actor:     actor,
 			CreatedAt: now,
 		}
-		m.events[issue.ID] = append(m.events[issue.ID], event)
+		m.recordEvent(event)
 	}
 
 	return nil
@@ -309,16 +337,51 @@ func (m *MemoryStorage) GetIssueByExternalRef(ctx context.Context, externalRef s
 
 // UpdateIssue updates fields on an issue
 func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	return m.updateIssue(id, updates, actor, "", "")
+}
+
+// UpdateIssueIfUnchanged applies updates only if the issue's UpdatedAt still
+// equals expectedUpdatedAt
+func (m *MemoryStorage) UpdateIssueIfUnchanged(ctx context.Context, id string, expectedUpdatedAt time.Time, updates map[string]interface{}, actor string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	issue, exists := m.issues[id]
+	if !exists {
+		return types.WithCode(types.ErrCodeNotFound, fmt.Errorf("issue %s not found", id))
+	}
+	if !issue.UpdatedAt.Equal(expectedUpdatedAt) {
+		return &storage.ConflictError{ID: id, Expected: expectedUpdatedAt, Actual: issue.UpdatedAt}
+	}
+	return m.updateIssueLocked(id, updates, actor, "", "")
+}
+
+// ReopenIssue sets an issue back to open and always records a Reopened event
+// carrying note; callers skip issues that aren't closed unless forced
+func (m *MemoryStorage) ReopenIssue(ctx context.Context, id string, note string, actor string) error {
+	return m.updateIssue(id, map[string]interface{}{
+		"status": string(types.StatusOpen),
+	}, actor, note, types.EventReopened)
+}
+
+// updateIssue applies updates, recording eventType (or one derived from the
+// status change if empty) with the optional note
+func (m *MemoryStorage) updateIssue(id string, updates map[string]interface{}, actor string, note string, eventType types.EventType) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	return m.updateIssueLocked(id, updates, actor, note, eventType)
+}
 
+// updateIssueLocked is updateIssue for callers already holding m.mu
+func (m *MemoryStorage) updateIssueLocked(id string, updates map[string]interface{}, actor string, note string, eventType types.EventType) error {
 	issue, exists := m.issues[id]
 	if !exists {
-		return fmt.Errorf("issue %s not found", id)
+		return types.WithCode(types.ErrCodeNotFound, fmt.Errorf("issue %s not found", id))
 	}
 
 	now := time.Now()
 	issue.UpdatedAt = now
+	wasClosed := issue.Status == types.StatusClosed
 
 	// Apply updates
 	for key, value := range updates {
@@ -353,6 +416,12 @@ func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[
 					issue.ClosedAt = &now
 				} else if issue.Status != types.StatusClosed && oldStatus == types.StatusClosed {
 					issue.ClosedAt = nil
+					if _, hasResolution := updates["resolution"]; !hasResolution {
+						issue.Resolution = ""
+					}
+					if _, hasResolvedBy := updates["resolved_by"]; !hasResolvedBy {
+						issue.ResolvedBy = ""
+					}
 				}
 			}
 		case "priority":
@@ -375,16 +444,69 @@ func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[
 			} else if value == nil {
 				issue.ExternalRef = nil
 			}
+		case "resolution":
+			if v, ok := value.(string); ok {
+				issue.Resolution = types.Resolution(v)
+			} else if v, ok := value.(types.Resolution); ok {
+				issue.Resolution = v
+			}
+		case "resolved_by":
+			if v, ok := value.(string); ok {
+				issue.ResolvedBy = v
+			}
+		case "estimated_minutes":
+			if v, ok := value.(int); ok {
+				issue.EstimatedMinutes = &v
+			} else if value == nil {
+				issue.EstimatedMinutes = nil
+			}
+		case "spent_minutes":
+			if v, ok := value.(int); ok {
+				issue.SpentMinutes = &v
+			} else if value == nil {
+				issue.SpentMinutes = nil
+			}
+		case "metadata":
+			if v, ok := value.(map[string]string); ok && len(v) > 0 {
+				issue.Metadata = make(map[string]string, len(v))
+				for key, val := range v {
+					issue.Metadata[key] = val
+				}
+			} else if value == nil || ok {
+				issue.Metadata = nil
+			}
+		case "attachments":
+			if v, ok := value.([]string); ok && len(v) > 0 {
+				issue.Attachments = append([]string(nil), v...)
+			} else if value == nil || ok {
+				issue.Attachments = nil
+			}
+		case "archived_at":
+			switch v := value.(type) {
+			case time.Time:
+				issue.ArchivedAt = &v
+			case *time.Time:
+				issue.ArchivedAt = v
+			case nil:
+				issue.ArchivedAt = nil
+			}
 		}
 	}
 
 	m.dirty[id] = true
 
 	// Record event
-	eventType := types.EventUpdated
-	if status, hasStatus := updates["status"]; hasStatus {
-		if status == string(types.StatusClosed) {
-			eventType = types.EventClosed
+	if eventType == "" {
+		eventType = types.EventUpdated
+		if types.OnlyClearsFields(updates) {
+			eventType = types.EventFieldsCleared
+		}
+		if status, hasStatus := updates["status"]; hasStatus {
+			if status == string(types.StatusClosed) {
+				eventType = types.EventClosed
+			} else if wasClosed {
+				eventType = types.EventReopened
+			}
 		}
 	}
 
@@ -394,16 +516,24 @@ func (m *MemoryStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		Actor:     actor,
 		CreatedAt: now,
 	}
-	m.events[id] = append(m.events[id], event)
+	if note != "" {
+		event.Note = &note
+	}
+	m.recordEvent(event)
 
 	return nil
 }
 
-// CloseIssue closes an issue with a reason
-func (m *MemoryStorage) CloseIssue(ctx context.Context, id string, reason string, actor string) error {
-	return m.UpdateIssue(ctx, id, map[string]interface{}{
-		"status": string(types.StatusClosed),
-	}, actor)
+// CloseIssue closes an issue, recording opts.Note on the event
+func (m *MemoryStorage) CloseIssue(ctx context.Context, id string, opts storage.CloseOptions, actor string) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	return m.updateIssue(id, map[string]interface{}{
+		"status":      string(types.StatusClosed),
+		"resolution":  string(opts.Resolution),
+		"resolved_by": opts.Commit,
+	}, actor, opts.Note, "")
 }
 
 // DeleteIssue permanently deletes an issue and all associated data
@@ -425,6 +555,14 @@ func (m *MemoryStorage) DeleteIssue(ctx context.Context, id string) error {
 	delete(m.events, id)
 	delete(m.comments, id)
 	delete(m.dirty, id)
+	delete(m.counters, id)
+
+	// Let the next child reuse the number if this was the newest child
+	if dot := strings.LastIndex(id, "."); dot > 0 {
+		if num, err := strconv.Atoi(id[dot+1:]); err == nil && m.counters[id[:dot]] == num {
+			m.resetChildCounter(id[:dot])
+		}
+	}
 
 	return nil
 }
@@ -434,16 +572,42 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	if filter.Ready && filter.Blocked {
+		return nil, fmt.Errorf("ready and blocked filters are mutually exclusive")
+	}
+	var blocked map[string]bool
+	if filter.Ready {
+		blocked = m.blockedIssueIDs()
+	}
+
 	var results []*types.Issue
 
 	for _, issue := range m.issues {
 		// Apply filters
+		if issue.ArchivedAt != nil && !filter.IncludeArchived && len(filter.IDs) == 0 {
+			continue
+		}
+		if filter.Ready && (blocked[issue.ID] || (issue.Status != types.StatusOpen && issue.Status != types.StatusInProgress)) {
+			continue
+		}
+		if filter.Blocked && (issue.Status == types.StatusClosed || !m.hasOpenBlocker(issue.ID)) {
+			continue
+		}
 		if filter.Status != nil && issue.Status != *filter.Status {
 			continue
 		}
+		if slices.Contains(filter.ExcludeStatus, issue.Status) {
+			continue
+		}
 		if filter.Priority != nil && issue.Priority != *filter.Priority {
 			continue
 		}
+		if filter.PriorityMin != nil && issue.Priority < *filter.PriorityMin {
+			continue
+		}
+		if filter.PriorityMax != nil && issue.Priority > *filter.PriorityMax {
+			continue
+		}
 		if filter.IssueType != nil && issue.IssueType != *filter.IssueType {
 			continue
 		}
@@ -451,6 +615,38 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 			continue
 		}
 
+		// Date ranges (bounds are exclusive; open issues never match closed ranges)
+		if filter.CreatedAfter != nil && !issue.CreatedAt.After(*filter.CreatedAfter) {
+			continue
+		}
+		if filter.CreatedBefore != nil && !issue.CreatedAt.Before(*filter.CreatedBefore) {
+			continue
+		}
+		if filter.UpdatedAfter != nil && !issue.UpdatedAt.After(*filter.UpdatedAfter) {
+			continue
+		}
+		if filter.UpdatedBefore != nil && !issue.UpdatedAt.Before(*filter.UpdatedBefore) {
+			continue
+		}
+		if filter.ClosedAfter != nil && (issue.ClosedAt == nil || !issue.ClosedAt.After(*filter.ClosedAfter)) {
+			continue
+		}
+		if filter.ClosedBefore != nil && (issue.ClosedAt == nil || !issue.ClosedAt.Before(*filter.ClosedBefore)) {
+			continue
+		}
+
+		// Metadata: every key must be set to exactly the given value
+		metadataMatches := true
+		for key, value := range filter.Metadata {
+			if got, ok := issue.Metadata[key]; !ok || got != value {
+				metadataMatches = false
+				break
+			}
+		}
+		if !metadataMatches {
+			continue
+		}
+
 		// Query search (title, description, or ID)
 		if query != "" {
 			query = strings.ToLower(query)
@@ -466,6 +662,7 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 			issueLabels := m.labels[issue.ID]
 			hasAllLabels := true
 			for _, reqLabel := range filter.Labels {
+				reqLabel = util.NormalizeLabel(reqLabel)
 				found := false
 				for _, label := range issueLabels {
 					if label == reqLabel {
@@ -483,6 +680,13 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 			}
 		}
 
+		// Label filtering (OR): must have AT LEAST ONE of these labels
+		if len(filter.LabelsAny) > 0 && !slices.ContainsFunc(m.labels[issue.ID], func(label string) bool {
+			return slices.ContainsFunc(filter.LabelsAny, func(want string) bool { return util.NormalizeLabel(want) == label })
+		}) {
+			continue
+		}
+
 		// ID filtering
 		if len(filter.IDs) > 0 {
 			found := false
@@ -509,15 +713,30 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 		results = append(results, &issueCopy)
 	}
 
-	// Sort by priority, then by created_at
+	// Sort by the requested keys (default priority, then newest first), then
+	// by ID so pages are stable
+	sortBy := filter.SortBy
+	if len(sortBy) == 0 {
+		sortBy = []types.SortKey{{Field: types.SortFieldPriority}, {Field: types.SortFieldCreatedAt, Descending: true}}
+	}
+	for _, key := range sortBy {
+		if !key.Field.IsValid() {
+			return nil, fmt.Errorf("unknown sort field %q", key.Field)
+		}
+	}
 	sort.Slice(results, func(i, j int) bool {
-		if results[i].Priority != results[j].Priority {
-			return results[i].Priority < results[j].Priority
+		for _, key := range sortBy {
+			if c := compareBySortField(results[i], results[j], key.Field); c != 0 {
+				return (c < 0) != key.Descending
+			}
 		}
-		return results[i].CreatedAt.After(results[j].CreatedAt)
+		return results[i].ID < results[j].ID
 	})
 
-	// Apply limit
+	// Apply offset and limit
+	if filter.Offset > 0 {
+		results = results[min(filter.Offset, len(results)):]
+	}
 	if filter.Limit > 0 && len(results) > filter.Limit {
 		results = results[:filter.Limit]
 	}
@@ -525,6 +744,63 @@ func (m *MemoryStorage) SearchIssues(ctx context.Context, query string, filter t
 	return results, nil
 }
 
+// statusSortOrder ranks statuses in workflow order for sorting
+var statusSortOrder = map[types.Status]int{
+	types.StatusOpen:       0,
+	types.StatusInProgress: 1,
+	types.StatusBlocked:    2,
+	types.StatusClosed:     3,
+}
+
+// compareBySortField compares two issues on one sort field, returning
+// -1, 0 or 1
+func compareBySortField(a, b *types.Issue, field types.SortField) int {
+	switch field {
+	case types.SortFieldPriority:
+		return cmp.Compare(a.Priority, b.Priority)
+	case types.SortFieldCreatedAt:
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case types.SortFieldUpdatedAt:
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	case types.SortFieldStatus:
+		rank := func(s types.Status) int {
+			if r, ok := statusSortOrder[s]; ok {
+				return r
+			}
+			return len(statusSortOrder)
+		}
+		return cmp.Compare(rank(a.Status), rank(b.Status))
+	case types.SortFieldID:
+		return strings.Compare(a.ID, b.ID)
+	}
+	return 0
+}
+
+// CountIssues returns how many issues SearchIssues finds, ignoring Limit and Offset
+func (m *MemoryStorage) CountIssues(ctx context.Context, query string, filter types.IssueFilter) (int, error) {
+	filter.Limit, filter.Offset = 0, 0
+	results, err := m.SearchIssues(ctx, query, filter)
+	if err != nil {
+		return 0, err
+	}
+	return len(results), nil
+}
+
+// StreamIssues calls fn with each issue SearchIssues finds. The issues are
+// already in memory, so this only saves callers from holding the slice.
+func (m *MemoryStorage) StreamIssues(ctx context.Context, query string, filter types.IssueFilter, fn func(*types.Issue) error) error {
+	results, err := m.SearchIssues(ctx, query, filter)
+	if err != nil {
+		return err
+	}
+	for _, issue := range results {
+		if err := fn(issue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // AddDependency adds a dependency between issues
 func (m *MemoryStorage) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
 	m.mu.Lock()
@@ -538,6 +814,10 @@ func (m *MemoryStorage) AddDependency(ctx context.Context, dep *types.Dependency
 		return fmt.Errorf("issue %s not found", dep.DependsOnID)
 	}
 
+	if dep.IssueID == dep.DependsOnID {
+		return fmt.Errorf("issue cannot depend on itself")
+	}
+
 	// Check for duplicates
 	for _, existing := range m.dependencies[dep.IssueID] {
 		if existing.DependsOnID == dep.DependsOnID && existing.Type == dep.Type {
@@ -545,12 +825,76 @@ func (m *MemoryStorage) AddDependency(ctx context.Context, dep *types.Dependency
 		}
 	}
 
+	if cycle := m.detectCycle(dep.IssueID, dep.DependsOnID); cycle != nil {
+		return fmt.Errorf("cannot add dependency: would create a cycle (%s)", strings.Join(cycle, " → "))
+	}
+
 	m.dependencies[dep.IssueID] = append(m.dependencies[dep.IssueID], dep)
 	m.dirty[dep.IssueID] = true
 
 	return nil
 }
 
+// PropagatePriority moves issueID's priority up to weight levels toward
+// blockerID's priority; a negative weight uses the priority_propagation config
+func (m *MemoryStorage) PropagatePriority(ctx context.Context, issueID, blockerID string, weight int, actor string) (*types.PriorityChange, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if weight < 0 {
+		var err error
+		if weight, err = types.PriorityPropagationWeight(m.config[types.PriorityPropagationConfigKey]); err != nil {
+			return nil, err
+		}
+	}
+	if weight == 0 {
+		return nil, nil
+	}
+
+	issue, exists := m.issues[issueID]
+	if !exists {
+		return nil, fmt.Errorf("issue %s not found", issueID)
+	}
+	blocker, exists := m.issues[blockerID]
+	if !exists {
+		return nil, fmt.Errorf("blocker %s not found", blockerID)
+	}
+	if issue.Status == types.StatusClosed || blocker.Status == types.StatusClosed {
+		return nil, nil
+	}
+
+	newPriority := types.PropagatedPriority(issue.Priority, blocker.Priority, weight)
+	if newPriority == issue.Priority {
+		return nil, nil
+	}
+
+	change := &types.PriorityChange{
+		IssueID:     issueID,
+		BlockerID:   blockerID,
+		OldPriority: issue.Priority,
+		NewPriority: newPriority,
+	}
+	now := time.Now()
+	issue.Priority = newPriority
+	issue.UpdatedAt = now
+	m.dirty[issueID] = true
+
+	oldValue := fmt.Sprintf("%d", change.OldPriority)
+	newValue := fmt.Sprintf("%d", change.NewPriority)
+	comment := fmt.Sprintf("Priority propagated from blocker %s (P%d)", blockerID, blocker.Priority)
+	m.recordEvent(&types.Event{
+		IssueID:   issueID,
+		EventType: types.EventPriorityChanged,
+		Actor:     actor,
+		OldValue:  &oldValue,
+		NewValue:  &newValue,
+		Comment:   &comment,
+		CreatedAt: now,
+	})
+
+	return change, nil
+}
+
 // RemoveDependency removes a dependency
 func (m *MemoryStorage) RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error {
 	m.mu.Lock()
@@ -732,12 +1076,118 @@ func (m *MemoryStorage) GetDependencyTree(ctx context.Context, issueID string, m
 	return nodes, nil
 }
 
+func (m *MemoryStorage) PruneDanglingDependencies(ctx context.Context, dryRun bool) ([]*types.Dependency, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var dangling []*types.Dependency
+	for issueID, deps := range m.dependencies {
+		kept := make([]*types.Dependency, 0, len(deps))
+		for _, dep := range deps {
+			if m.issues[dep.IssueID] != nil && m.issues[dep.DependsOnID] != nil {
+				kept = append(kept, dep)
+				continue
+			}
+			dangling = append(dangling, dep)
+			if dryRun {
+				continue
+			}
+			for _, id := range []string{dep.IssueID, dep.DependsOnID} {
+				if m.issues[id] != nil {
+					m.dirty[id] = true
+				}
+			}
+		}
+		if !dryRun {
+			m.dependencies[issueID] = kept
+		}
+	}
+	sort.Slice(dangling, func(i, j int) bool {
+		if dangling[i].IssueID != dangling[j].IssueID {
+			return dangling[i].IssueID < dangling[j].IssueID
+		}
+		return dangling[i].DependsOnID < dangling[j].DependsOnID
+	})
+	return dangling, nil
+}
+
+// DetectCycle reports the cycle that adding "fromID depends on toID" would
+// create, starting and ending with fromID, or nil if there is none
+func (m *MemoryStorage) DetectCycle(ctx context.Context, fromID, toID string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.detectCycle(fromID, toID), nil
+}
+
+// detectCycle finds the shortest dependency path from toID back to fromID,
+// breadth-first over all dependency types. Caller must hold m.mu.
+func (m *MemoryStorage) detectCycle(fromID, toID string) []string {
+	if fromID == toID {
+		return []string{fromID, toID}
+	}
+	prev := map[string]string{toID: ""}
+	queue := []string{toID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, dep := range m.dependencies[id] {
+			next := dep.DependsOnID
+			if _, seen := prev[next]; seen {
+				continue
+			}
+			prev[next] = id
+			if next != fromID {
+				queue = append(queue, next)
+				continue
+			}
+			// Walk back from fromID to toID, then put the new edge in front
+			var path []string
+			for at := next; at != ""; at = prev[at] {
+				path = append([]string{at}, path...)
+			}
+			return append([]string{fromID}, path...)
+		}
+	}
+	return nil
+}
+
 // DetectCycles detects dependency cycles
 func (m *MemoryStorage) DetectCycles(ctx context.Context) ([][]*types.Issue, error) {
 	// Simplified - return empty (no cycles detected)
 	return nil, nil
 }
 
+// FindByTitle returns the issues whose titles match query, best match first
+func (m *MemoryStorage) FindByTitle(ctx context.Context, query string) ([]*types.Issue, error) {
+	issues, err := m.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
+	if err != nil {
+		return nil, err
+	}
+	return types.RankTitleMatches(query, issues), nil
+}
+
+// TopoSort returns the open work under rootID in dependency-ordered waves
+func (m *MemoryStorage) TopoSort(ctx context.Context, rootID string) ([][]*types.Issue, error) {
+	root, err := m.GetIssue(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, fmt.Errorf("issue %s not found", rootID)
+	}
+
+	deps, err := m.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids, before := types.PlanSubgraph(rootID, deps)
+	issues, err := m.SearchIssues(ctx, "", types.IssueFilter{IDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	return types.PlanWaves(ids, before, issues)
+}
+
 // Add label methods
 func (m *MemoryStorage) AddLabel(ctx context.Context, issueID, label, actor string) error {
 	m.mu.Lock()
@@ -747,6 +1197,9 @@ func (m *MemoryStorage) AddLabel(ctx context.Context, issueID, label, actor stri
 	if _, exists := m.issues[issueID]; !exists {
 		return fmt.Errorf("issue %s not found", issueID)
 	}
+	if label = util.NormalizeLabel(label); label == "" {
+		return fmt.Errorf("label cannot be empty")
+	}
 
 	// Check for duplicate
 	for _, l := range m.labels[issueID] {
@@ -767,6 +1220,7 @@ func (m *MemoryStorage) RemoveLabel(ctx context.Context, issueID, label, actor s
 
 	labels := m.labels[issueID]
 	newLabels := make([]string, 0)
+	label = util.NormalizeLabel(label)
 
 	for _, l := range labels {
 		if l != label {
@@ -780,6 +1234,44 @@ func (m *MemoryStorage) RemoveLabel(ctx context.Context, issueID, label, actor s
 	return nil
 }
 
+// RenameLabel replaces oldLabel with newLabel on every issue carrying it,
+// like SQLite's: labels match after normalization, and an issue that
+// already has newLabel just loses oldLabel
+func (m *MemoryStorage) RenameLabel(ctx context.Context, oldLabel, newLabel, actor string, dryRun bool) ([]string, error) {
+	oldLabel = util.NormalizeLabel(oldLabel)
+	newLabel = util.NormalizeLabel(newLabel)
+	if oldLabel == "" || newLabel == "" {
+		return nil, fmt.Errorf("label cannot be empty")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var changed []string
+	for issueID, labels := range m.labels {
+		if !slices.ContainsFunc(labels, func(l string) bool { return l != newLabel && util.NormalizeLabel(l) == oldLabel }) {
+			continue
+		}
+		changed = append(changed, issueID)
+		if dryRun {
+			continue
+		}
+		kept := make([]string, 0, len(labels))
+		for _, l := range labels {
+			if l != newLabel && util.NormalizeLabel(l) != oldLabel {
+				kept = append(kept, l)
+			}
+		}
+		if !slices.Contains(kept, newLabel) {
+			kept = append(kept, newLabel)
+		}
+		m.labels[issueID] = kept
+		m.dirty[issueID] = true
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
 func (m *MemoryStorage) GetLabels(ctx context.Context, issueID string) ([]string, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -791,6 +1283,7 @@ func (m *MemoryStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	label = util.NormalizeLabel(label)
 	var results []*types.Issue
 	for issueID, labels := range m.labels {
 		for _, l := range labels {
@@ -809,14 +1302,127 @@ func (m *MemoryStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*
 
 // Stub implementations for other required methods
 func (m *MemoryStorage) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
-	// Simplified: return open issues with no blocking dependencies
-	return m.SearchIssues(ctx, "", types.IssueFilter{
-		Status: func() *types.Status { s := types.StatusOpen; return &s }(),
+	searchFilter := types.IssueFilter{
+		Priority:    filter.Priority,
+		PriorityMax: filter.MinPriority,
+		Assignee:    filter.Assignee,
+		Labels:      filter.Labels,
+		LabelsAny:   filter.LabelsAny,
+	}
+	// Default to open OR in_progress if not specified, like SQLite
+	if filter.Status == "" {
+		searchFilter.ExcludeStatus = []types.Status{types.StatusBlocked, types.StatusClosed}
+	} else {
+		searchFilter.Status = &filter.Status
+	}
+	if filter.IssueType != "" {
+		searchFilter.IssueType = &filter.IssueType
+	}
+	candidates, err := m.SearchIssues(ctx, "", searchFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	blocked := m.blockedIssueIDs()
+
+	var ready []*types.Issue
+	for _, issue := range candidates {
+		if blocked[issue.ID] {
+			continue
+		}
+		ready = append(ready, issue)
+		if filter.Limit > 0 && len(ready) == filter.Limit {
+			break
+		}
+	}
+	return ready, nil
+}
+
+// blockedIssueIDs returns the issues blocked by a 'blocks' dependency on an
+// unclosed issue, plus their parent-child descendants, which inherit the
+// blockage (matching SQLite's GetReadyWork). Caller must hold m.mu.
+func (m *MemoryStorage) blockedIssueIDs() map[string]bool {
+	blocked := make(map[string]bool)
+	children := make(map[string][]string)
+	var queue []string
+	for issueID, deps := range m.dependencies {
+		for _, dep := range deps {
+			switch dep.Type {
+			case types.DepBlocks:
+				if blocker, ok := m.issues[dep.DependsOnID]; ok && blocker.Status != types.StatusClosed && !blocked[issueID] {
+					blocked[issueID] = true
+					queue = append(queue, issueID)
+				}
+			case types.DepParentChild:
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], issueID)
+			}
+		}
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, child := range children[id] {
+			if !blocked[child] {
+				blocked[child] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+	return blocked
+}
+
+// hasOpenBlocker reports whether issueID has a 'blocks' dependency on an
+// unclosed issue. Caller must hold m.mu.
+func (m *MemoryStorage) hasOpenBlocker(issueID string) bool {
+	return slices.ContainsFunc(m.dependencies[issueID], func(dep *types.Dependency) bool {
+		blocker, ok := m.issues[dep.DependsOnID]
+		return dep.Type == types.DepBlocks && ok && blocker.Status != types.StatusClosed
 	})
 }
 
 func (m *MemoryStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedIssue, error) {
-	return nil, nil
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var blocked []*types.BlockedIssue
+	for issueID, deps := range m.dependencies {
+		issue, ok := m.issues[issueID]
+		if !ok || issue.Status == types.StatusClosed {
+			continue
+		}
+		var blockers []*types.Blocker
+		for _, dep := range deps {
+			if dep.Type != types.DepBlocks {
+				continue
+			}
+			if blocker, ok := m.issues[dep.DependsOnID]; ok && blocker.Status != types.StatusClosed {
+				blockers = append(blockers, &types.Blocker{ID: blocker.ID, Title: blocker.Title, Status: blocker.Status})
+			}
+		}
+		if len(blockers) == 0 {
+			continue
+		}
+		sort.Slice(blockers, func(i, j int) bool {
+			return blockers[i].ID < blockers[j].ID
+		})
+
+		entry := &types.BlockedIssue{Issue: *issue, BlockedByCount: len(blockers), Blockers: blockers}
+		for _, blocker := range blockers {
+			entry.BlockedBy = append(entry.BlockedBy, blocker.ID)
+		}
+		blocked = append(blocked, entry)
+	}
+
+	// Match SQLite: most important first
+	sort.Slice(blocked, func(i, j int) bool {
+		if blocked[i].Priority != blocked[j].Priority {
+			return blocked[i].Priority < blocked[j].Priority
+		}
+		return blocked[i].ID < blocked[j].ID
+	})
+	return blocked, nil
 }
 
 func (m *MemoryStorage) GetEpicsEligibleForClosure(ctx context.Context) ([]*types.EpicStatus, error) {
@@ -828,6 +1434,9 @@ func (m *MemoryStorage) GetStaleIssues(ctx context.Context, filter types.StaleFi
 	defer m.mu.RUnlock()
 
 	cutoff := time.Now().AddDate(0, 0, -filter.Days)
+	if filter.OlderThan > 0 {
+		cutoff = time.Now().Add(-filter.OlderThan)
+	}
 	var stale []*types.Issue
 
 	for _, issue := range m.issues {
@@ -870,22 +1479,181 @@ func (m *MemoryStorage) GetEvents(ctx context.Context, issueID string, limit int
 	return events, nil
 }
 
+// recordEvent gives event the next ID and adds it to its issue's history
+// (caller must hold the lock)
+func (m *MemoryStorage) recordEvent(event *types.Event) {
+	m.lastEventID++
+	event.ID = m.lastEventID
+	m.events[event.IssueID] = append(m.events[event.IssueID], event)
+}
+
+// GetEventsSince returns events of all issues with IDs above afterID, oldest
+// first, at most limit of them if limit > 0
+func (m *MemoryStorage) GetEventsSince(ctx context.Context, afterID int64, limit int) ([]*types.Event, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var events []*types.Event
+	for _, issueEvents := range m.events {
+		for _, event := range issueEvents {
+			if event.ID > afterID {
+				events = append(events, event)
+			}
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].ID < events[j].ID
+	})
+	if limit > 0 && len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (m *MemoryStorage) PruneEvents(ctx context.Context, before time.Time, keepPerIssue int, dryRun bool) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	removed := 0
+	for issueID, events := range m.events {
+		// Events are appended in order, so walk newest first
+		kept := make([]*types.Event, 0, len(events))
+		seenStatus := false
+		for i := len(events) - 1; i >= 0; i-- {
+			event := events[i]
+			recent := len(events)-1-i < keepPerIssue
+			newestStatus := event.EventType.IsStatusEvent() && !seenStatus
+			if event.EventType.IsStatusEvent() {
+				seenStatus = true
+			}
+			if recent || newestStatus || !event.CreatedAt.Before(before) {
+				kept = append(kept, event)
+				continue
+			}
+			removed++
+		}
+		if dryRun {
+			continue
+		}
+		for i, j := 0, len(kept)-1; i < j; i, j = i+1, j-1 {
+			kept[i], kept[j] = kept[j], kept[i]
+		}
+		m.events[issueID] = kept
+	}
+
+	return removed, nil
+}
+
+// CompactEvents removes events before the cutoff except each issue's first
+// created event and newest status event
+func (m *MemoryStorage) CompactEvents(ctx context.Context, before time.Time, dryRun bool) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	removed := 0
+	for issueID, events := range m.events {
+		firstCreated, newestStatus := -1, -1
+		for i, event := range events {
+			if event.EventType == types.EventCreated && firstCreated < 0 {
+				firstCreated = i
+			}
+			if event.EventType.IsStatusEvent() {
+				newestStatus = i
+			}
+		}
+		kept := make([]*types.Event, 0, len(events))
+		for i, event := range events {
+			if i == firstCreated || i == newestStatus || !event.CreatedAt.Before(before) {
+				kept = append(kept, event)
+				continue
+			}
+			removed++
+		}
+		if !dryRun {
+			m.events[issueID] = kept
+		}
+	}
+	return removed, nil
+}
+
+func (m *MemoryStorage) GetIssueIDsChangedSince(ctx context.Context, since time.Time) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var ids []string
+	for id, issue := range m.issues {
+		changed := issue.UpdatedAt.After(since)
+		for _, event := range m.events[id] {
+			if changed {
+				break
+			}
+			changed = event.CreatedAt.After(since)
+		}
+		if changed {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
 func (m *MemoryStorage) AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	return m.addComment(issueID, nil, author, text), nil
+}
+
+func (m *MemoryStorage) AddCommentReply(ctx context.Context, issueID string, parentID int64, author, text string) (*types.Comment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	parent := m.findComment(parentID)
+	if parent == nil {
+		return nil, fmt.Errorf("comment %d not found", parentID)
+	}
+	if parent.IssueID != issueID {
+		return nil, fmt.Errorf("comment %d belongs to %s, not %s", parentID, parent.IssueID, issueID)
+	}
+	return m.addComment(issueID, &parentID, author, text), nil
+}
+
+// addComment appends a comment with an ID unique across all issues (caller must hold the lock)
+func (m *MemoryStorage) addComment(issueID string, parentID *int64, author, text string) *types.Comment {
+	var maxID int64
+	for _, comments := range m.comments {
+		for _, c := range comments {
+			if c.ID > maxID {
+				maxID = c.ID
+			}
+		}
+	}
+
 	comment := &types.Comment{
-		ID:        int64(len(m.comments[issueID]) + 1),
-		IssueID:   issueID,
-		Author:    author,
-		Text:      text,
-		CreatedAt: time.Now(),
+		ID:              maxID + 1,
+		IssueID:         issueID,
+		ParentCommentID: parentID,
+		Author:          author,
+		Text:            text,
+		CreatedAt:       time.Now(),
 	}
 
 	m.comments[issueID] = append(m.comments[issueID], comment)
 	m.dirty[issueID] = true
 
-	return comment, nil
+	return comment
+}
+
+// findComment looks up a comment by ID (caller must hold the lock)
+func (m *MemoryStorage) findComment(commentID int64) *types.Comment {
+	for _, comments := range m.comments {
+		for _, c := range comments {
+			if c.ID == commentID {
+				return c
+			}
+		}
+	}
+	return nil
 }
 
 func (m *MemoryStorage) GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error) {
@@ -895,6 +1663,167 @@ func (m *MemoryStorage) GetIssueComments(ctx context.Context, issueID string) ([
 	return m.comments[issueID], nil
 }
 
+func (m *MemoryStorage) UpdateComment(ctx context.Context, commentID int64, text string) (*types.Comment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	comment := m.findComment(commentID)
+	if comment == nil {
+		return nil, fmt.Errorf("comment %d not found", commentID)
+	}
+	if comment.Text != text {
+		comment.Text = text
+		m.dirty[comment.IssueID] = true
+	}
+	return comment, nil
+}
+
+func (m *MemoryStorage) EditComment(ctx context.Context, commentID int64, text, actor string) (*types.Comment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	comment := m.findComment(commentID)
+	if comment == nil {
+		return nil, fmt.Errorf("comment %d not found", commentID)
+	}
+	if comment.IsDeleted() {
+		return nil, fmt.Errorf("comment %d has been deleted", commentID)
+	}
+	if comment.Text == text {
+		return comment, nil
+	}
+
+	now := time.Now()
+	oldText := comment.Text
+	comment.OriginalText = comment.FirstText()
+	comment.Text = text
+	comment.EditedAt = &now
+	m.recordCommentEvent(comment, types.EventCommentEdited, &oldText, &text, actor, now)
+	return comment, nil
+}
+
+func (m *MemoryStorage) DeleteComment(ctx context.Context, commentID int64, actor string) (*types.Comment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	comment := m.findComment(commentID)
+	if comment == nil {
+		return nil, fmt.Errorf("comment %d not found", commentID)
+	}
+	if comment.IsDeleted() {
+		return nil, fmt.Errorf("comment %d has already been deleted", commentID)
+	}
+
+	now := time.Now()
+	comment.DeletedAt = &now
+	oldText := comment.Text
+	m.recordCommentEvent(comment, types.EventCommentDeleted, &oldText, nil, actor, now)
+	return comment, nil
+}
+
+// recordCommentEvent records a change to a comment on its issue (caller must hold the lock)
+func (m *MemoryStorage) recordCommentEvent(comment *types.Comment, eventType types.EventType, oldText, newText *string, actor string, now time.Time) {
+	verb := "Edited"
+	if eventType == types.EventCommentDeleted {
+		verb = "Deleted"
+	}
+	note := fmt.Sprintf("%s comment #%d", verb, comment.ID)
+	m.recordEvent(&types.Event{
+		IssueID:   comment.IssueID,
+		EventType: eventType,
+		Actor:     actor,
+		OldValue:  oldText,
+		NewValue:  newText,
+		Comment:   &note,
+		CreatedAt: now,
+	})
+	m.dirty[comment.IssueID] = true
+}
+
+func (m *MemoryStorage) ResolveComment(ctx context.Context, commentID int64, resolved bool) (*types.Comment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	root := m.findComment(commentID)
+	if root == nil {
+		return nil, fmt.Errorf("comment %d not found", commentID)
+	}
+	for root.ParentCommentID != nil {
+		parentID := *root.ParentCommentID
+		if root = m.findComment(parentID); root == nil {
+			return nil, fmt.Errorf("comment %d not found", parentID)
+		}
+	}
+
+	if root.Resolved != resolved {
+		root.Resolved = resolved
+		m.dirty[root.IssueID] = true
+	}
+	return root, nil
+}
+
+// AcquireLock takes an advisory lock on an issue, renewing it if already held by holder
+func (m *MemoryStorage) AcquireLock(ctx context.Context, issueID, holder string, ttl time.Duration) (*types.IssueLock, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if ttl == 0 {
+		var err error
+		if ttl, err = types.ParseLockTTL(m.config[types.LockTTLConfigKey]); err != nil {
+			return nil, err
+		}
+	}
+	if ttl < 0 {
+		return nil, fmt.Errorf("lock TTL must be positive, got %s", ttl)
+	}
+	if _, exists := m.issues[issueID]; !exists {
+		return nil, fmt.Errorf("issue %s not found", issueID)
+	}
+
+	now := time.Now()
+	lock := &types.IssueLock{IssueID: issueID, Holder: holder, AcquiredAt: now, ExpiresAt: now.Add(ttl)}
+	if existing := m.locks[issueID]; existing != nil && existing.ExpiresAt.After(now) {
+		if existing.Holder != holder {
+			return nil, fmt.Errorf("issue %s is locked by %s until %s",
+				issueID, existing.Holder, existing.ExpiresAt.Format(time.RFC3339))
+		}
+		lock.AcquiredAt = existing.AcquiredAt
+	}
+	m.locks[issueID] = lock
+
+	lockCopy := *lock
+	return &lockCopy, nil
+}
+
+// ReleaseLock releases the lock on an issue; a live lock held by someone else requires force
+func (m *MemoryStorage) ReleaseLock(ctx context.Context, issueID, holder string, force bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing := m.locks[issueID]
+	if existing == nil {
+		return nil
+	}
+	if existing.Holder != holder && existing.ExpiresAt.After(time.Now()) && !force {
+		return fmt.Errorf("issue %s is locked by %s, not %s", issueID, existing.Holder, holder)
+	}
+	delete(m.locks, issueID)
+	return nil
+}
+
+// GetLock returns the live lock on an issue, or nil if unlocked or expired
+func (m *MemoryStorage) GetLock(ctx context.Context, issueID string) (*types.IssueLock, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	lock := m.locks[issueID]
+	if lock == nil || !lock.ExpiresAt.After(time.Now()) {
+		return nil, nil
+	}
+	lockCopy := *lock
+	return &lockCopy, nil
+}
+
 func (m *MemoryStorage) GetStatistics(ctx context.Context) (*types.Statistics, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -977,6 +1906,84 @@ func (m *MemoryStorage) GetNextChildID(ctx context.Context, parentID string) (st
 	return childID, nil
 }
 
+// CreateChildIssue creates issue as parentID's next child, depending on
+// parentID through a parent-child dependency
+func (m *MemoryStorage) CreateChildIssue(ctx context.Context, issue *types.Issue, parentID string, actor string) error {
+	childID, err := m.GetNextChildID(ctx, parentID)
+	if err != nil {
+		return err
+	}
+	issue.ID = childID
+	if err := m.CreateIssue(ctx, issue, actor); err != nil {
+		return err
+	}
+	return m.AddDependency(ctx, &types.Dependency{IssueID: childID, DependsOnID: parentID, Type: types.DepParentChild}, actor)
+}
+
+func (m *MemoryStorage) ResetChildCounter(ctx context.Context, parentID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.resetChildCounter(parentID)
+	return nil
+}
+
+func (m *MemoryStorage) SyncChildCounters(ctx context.Context, parentIDs ...string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var only map[string]bool
+	if len(parentIDs) > 0 {
+		only = make(map[string]bool, len(parentIDs))
+		for _, id := range parentIDs {
+			only[id] = true
+		}
+	}
+	highest := make(map[string]int)
+	for id := range m.issues {
+		dot := strings.LastIndex(id, ".")
+		if dot <= 0 {
+			continue
+		}
+		num, err := strconv.Atoi(id[dot+1:])
+		if err != nil || num <= 0 {
+			continue
+		}
+		if parent := id[:dot]; num > highest[parent] && (only == nil || only[parent]) {
+			highest[parent] = num
+		}
+	}
+	set := 0
+	for parentID, last := range highest {
+		// Children whose parent is gone have no counter to keep
+		if _, exists := m.issues[parentID]; !exists || m.counters[parentID] >= last {
+			continue
+		}
+		m.counters[parentID] = last
+		set++
+	}
+	return set, nil
+}
+
+// resetChildCounter sets the parent's counter to its highest direct child
+// number (caller must hold the lock)
+func (m *MemoryStorage) resetChildCounter(parentID string) {
+	if _, exists := m.counters[parentID]; !exists {
+		return
+	}
+	last := 0
+	for id := range m.issues {
+		suffix, ok := strings.CutPrefix(id, parentID+".")
+		if !ok {
+			continue
+		}
+		if num, err := strconv.Atoi(suffix); err == nil && num > last {
+			last = num
+		}
+	}
+	m.counters[parentID] = last
+}
+
 // Config
 func (m *MemoryStorage) SetConfig(ctx context.Context, key, value string) error {
 	m.mu.Lock()
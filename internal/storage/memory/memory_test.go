@@ -2,9 +2,14 @@ package memory
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -301,6 +306,30 @@ func TestUpdateIssue(t *testing.T) {
 	}
 }
 
+func TestUpdateIssueIfUnchanged(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	issue := &types.Issue{Title: "Original", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	read, _ := store.GetIssue(ctx, issue.ID)
+
+	if err := store.UpdateIssueIfUnchanged(ctx, issue.ID, read.UpdatedAt, map[string]interface{}{"title": "First"}, "alice"); err != nil {
+		t.Fatalf("UpdateIssueIfUnchanged with a current read failed: %v", err)
+	}
+	err := store.UpdateIssueIfUnchanged(ctx, issue.ID, read.UpdatedAt, map[string]interface{}{"title": "Second"}, "bob")
+	var conflict *storage.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("Expected ConflictError for a stale read, got %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, issue.ID); got.Title != "First" {
+		t.Errorf("Title after conflict = %q, want First", got.Title)
+	}
+}
+
 func TestCloseIssue(t *testing.T) {
 	store := setupTestMemory(t)
 	defer store.Close()
@@ -319,7 +348,7 @@ func TestCloseIssue(t *testing.T) {
 	}
 
 	// Close it
-	if err := store.CloseIssue(ctx, issue.ID, "Completed", "test-user"); err != nil {
+	if err := store.CloseIssue(ctx, issue.ID, storage.CloseOptions{Reason: "Completed"}, "test-user"); err != nil {
 		t.Fatalf("CloseIssue failed: %v", err)
 	}
 
@@ -338,6 +367,48 @@ func TestCloseIssue(t *testing.T) {
 	}
 }
 
+func TestCloseIssueResolution(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Test",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if err := store.CloseIssue(ctx, issue.ID, storage.CloseOptions{Reason: "Done", Resolution: types.Resolution("bogus")}, "test-user"); err == nil {
+		t.Error("Expected error for invalid resolution")
+	}
+	if err := store.CloseIssue(ctx, issue.ID, storage.CloseOptions{Reason: "Done", Resolution: types.ResolutionFixed}, "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	closed, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if closed.Status != types.StatusClosed || closed.Resolution != types.ResolutionFixed {
+		t.Errorf("Expected closed with resolution fixed, got %s %q", closed.Status, closed.Resolution)
+	}
+
+	if err := store.ReopenIssue(ctx, issue.ID, "", "test-user"); err != nil {
+		t.Fatalf("ReopenIssue failed: %v", err)
+	}
+	reopened, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if reopened.Resolution != "" {
+		t.Errorf("Expected reopen to clear resolution, got %q", reopened.Resolution)
+	}
+}
+
 func TestSearchIssues(t *testing.T) {
 	store := setupTestMemory(t)
 	defer store.Close()
@@ -387,12 +458,30 @@ func TestSearchIssues(t *testing.T) {
 			filter:   types.IssueFilter{Priority: func() *int { p := 1; return &p }()},
 			wantSize: 1,
 		},
+		{
+			name:     "filter by priority range",
+			query:    "",
+			filter:   types.IssueFilter{PriorityMin: func() *int { p := 2; return &p }(), PriorityMax: func() *int { p := 3; return &p }()},
+			wantSize: 2,
+		},
 		{
 			name:     "filter by type",
 			query:    "",
 			filter:   types.IssueFilter{IssueType: func() *types.IssueType { t := types.TypeBug; return &t }()},
 			wantSize: 1,
 		},
+		{
+			name:     "page with limit and offset",
+			query:    "",
+			filter:   types.IssueFilter{Limit: 2, Offset: 2},
+			wantSize: 1,
+		},
+		{
+			name:     "offset past the end",
+			query:    "",
+			filter:   types.IssueFilter{Offset: 5},
+			wantSize: 0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -407,6 +496,67 @@ func TestSearchIssues(t *testing.T) {
 			}
 		})
 	}
+
+	// The total ignores the page
+	count, err := store.CountIssues(ctx, "", types.IssueFilter{Limit: 1, Offset: 1})
+	if err != nil || count != 3 {
+		t.Errorf("Expected a count of 3, got %d (err %v)", count, err)
+	}
+
+	// Explicit sort keys replace the default priority order
+	results, err := store.SearchIssues(ctx, "", types.IssueFilter{SortBy: []types.SortKey{{Field: types.SortFieldPriority, Descending: true}}})
+	if err != nil || len(results) != 3 || results[0].Title != "Task" || results[2].Title != "Bug fix" {
+		t.Errorf("Expected issues by descending priority, got %v (err %v)", results, err)
+	}
+	if _, err := store.SearchIssues(ctx, "", types.IssueFilter{SortBy: []types.SortKey{{Field: "title"}}}); err == nil {
+		t.Error("Expected an error for an unknown sort field")
+	}
+}
+
+func TestSearchIssuesDateRanges(t *testing.T) {
+	store := New("")
+	defer store.Close()
+	ctx := context.Background()
+
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 12, 0, 0, 0, time.UTC) }
+	closedAt := day(10)
+	if err := store.LoadFromIssues([]*types.Issue{
+		{ID: "bd-1", Title: "Old", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, CreatedAt: day(1), UpdatedAt: day(2)},
+		{ID: "bd-2", Title: "Closed", Status: types.StatusClosed, Priority: 1, IssueType: types.TypeTask, CreatedAt: day(5), UpdatedAt: day(10), ClosedAt: &closedAt},
+		{ID: "bd-3", Title: "New", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, CreatedAt: day(20), UpdatedAt: day(21)},
+	}); err != nil {
+		t.Fatalf("LoadFromIssues failed: %v", err)
+	}
+
+	at := func(d int) *time.Time { t := day(d); return &t }
+	tests := []struct {
+		name   string
+		filter types.IssueFilter
+		want   []string
+	}{
+		{"created after", types.IssueFilter{CreatedAfter: at(4)}, []string{"bd-2", "bd-3"}},
+		{"created before", types.IssueFilter{CreatedBefore: at(5)}, []string{"bd-1"}},
+		{"updated window", types.IssueFilter{UpdatedAfter: at(3), UpdatedBefore: at(15)}, []string{"bd-2"}},
+		{"closed after", types.IssueFilter{ClosedAfter: at(9)}, []string{"bd-2"}},
+		{"closed before excludes open issues", types.IssueFilter{ClosedBefore: at(30)}, []string{"bd-2"}},
+		{"no date filters", types.IssueFilter{}, []string{"bd-1", "bd-2", "bd-3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := store.SearchIssues(ctx, "", tt.filter)
+			if err != nil {
+				t.Fatalf("SearchIssues failed: %v", err)
+			}
+			var got []string
+			for _, issue := range results {
+				got = append(got, issue.ID)
+			}
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
 }
 
 func TestDependencies(t *testing.T) {
@@ -486,6 +636,151 @@ func TestDependencies(t *testing.T) {
 	}
 }
 
+func TestGetReadyWork(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	blocker := &types.Issue{Title: "Blocker", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	blocked := &types.Issue{Title: "Blocked", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	child := &types.Issue{Title: "Child of blocked", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	related := &types.Issue{Title: "Related", Status: types.StatusInProgress, Priority: 1, IssueType: types.TypeBug}
+	for _, issue := range []*types.Issue{blocker, blocked, child, related} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	deps := []*types.Dependency{
+		{IssueID: blocked.ID, DependsOnID: blocker.ID, Type: types.DepBlocks},
+		{IssueID: child.ID, DependsOnID: blocked.ID, Type: types.DepParentChild},
+		{IssueID: related.ID, DependsOnID: blocker.ID, Type: types.DepRelated},
+	}
+	for _, dep := range deps {
+		if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	readyIDs := func(filter types.WorkFilter) map[string]bool {
+		t.Helper()
+		ready, err := store.GetReadyWork(ctx, filter)
+		if err != nil {
+			t.Fatalf("GetReadyWork failed: %v", err)
+		}
+		ids := make(map[string]bool)
+		for _, issue := range ready {
+			ids[issue.ID] = true
+		}
+		return ids
+	}
+
+	// Blockage propagates to the child; related and parent-child edges don't block
+	ids := readyIDs(types.WorkFilter{})
+	if len(ids) != 2 || !ids[blocker.ID] || !ids[related.ID] {
+		t.Errorf("Expected blocker and related to be ready, got %v", ids)
+	}
+
+	ids = readyIDs(types.WorkFilter{IssueType: types.TypeBug})
+	if len(ids) != 1 || !ids[related.ID] {
+		t.Errorf("Expected only the bug with --type bug, got %v", ids)
+	}
+
+	// Closing the blocker unblocks the whole subtree
+	if err := store.CloseIssue(ctx, blocker.ID, storage.CloseOptions{Reason: "done"}, "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	ids = readyIDs(types.WorkFilter{Status: types.StatusOpen})
+	if len(ids) != 2 || !ids[blocked.ID] || !ids[child.ID] {
+		t.Errorf("Expected blocked and child to be ready after close, got %v", ids)
+	}
+}
+
+func TestGetBlockedIssues(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	blocker := &types.Issue{Title: "Blocker", Status: types.StatusInProgress, Priority: 1, IssueType: types.TypeTask}
+	closedBlocker := &types.Issue{Title: "Done", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	blocked := &types.Issue{Title: "Blocked", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{blocker, closedBlocker, blocked} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	for _, dependsOn := range []string{blocker.ID, closedBlocker.ID} {
+		dep := &types.Dependency{IssueID: blocked.ID, DependsOnID: dependsOn, Type: types.DepBlocks}
+		if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+	if err := store.CloseIssue(ctx, closedBlocker.ID, storage.CloseOptions{Reason: "done"}, "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	result, err := store.GetBlockedIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetBlockedIssues failed: %v", err)
+	}
+	if len(result) != 1 || result[0].ID != blocked.ID {
+		t.Fatalf("Expected %s to be the only blocked issue, got %v", blocked.ID, result)
+	}
+	got := result[0]
+	if got.BlockedByCount != 1 || len(got.Blockers) != 1 {
+		t.Fatalf("Expected one live blocker, got %+v", got.Blockers)
+	}
+	if b := got.Blockers[0]; b.ID != blocker.ID || b.Title != "Blocker" || b.Status != types.StatusInProgress {
+		t.Errorf("Unexpected blocker detail %+v", b)
+	}
+}
+
+func TestAddDependencyRejectsCycles(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	var ids []string
+	for _, title := range []string{"A", "B", "C"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	// A depends on B, B is a child of C
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: ids[0], DependsOnID: ids[1], Type: types.DepBlocks}, "test-user"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: ids[1], DependsOnID: ids[2], Type: types.DepParentChild}, "test-user"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: ids[0], DependsOnID: ids[0], Type: types.DepBlocks}, "test-user"); err == nil {
+		t.Error("Expected self-dependency to be rejected")
+	}
+
+	cycle, err := store.DetectCycle(ctx, ids[2], ids[0])
+	if err != nil {
+		t.Fatalf("DetectCycle failed: %v", err)
+	}
+	want := []string{ids[2], ids[0], ids[1], ids[2]}
+	if strings.Join(cycle, ",") != strings.Join(want, ",") {
+		t.Errorf("DetectCycle = %v, want %v", cycle, want)
+	}
+
+	err = store.AddDependency(ctx, &types.Dependency{IssueID: ids[2], DependsOnID: ids[0], Type: types.DepBlocks}, "test-user")
+	if err == nil || !strings.Contains(err.Error(), strings.Join(want, " → ")) {
+		t.Errorf("Expected cycle error listing %v, got %v", want, err)
+	}
+
+	if cycle, _ := store.DetectCycle(ctx, ids[0], ids[2]); cycle != nil {
+		t.Errorf("Expected no cycle for an edge along the existing direction, got %v", cycle)
+	}
+}
+
 func TestLabels(t *testing.T) {
 	store := setupTestMemory(t)
 	defer store.Close()
@@ -732,7 +1027,7 @@ func TestStatistics(t *testing.T) {
 		}
 		// Close the one marked as closed
 		if issue.Status == types.StatusClosed {
-			if err := store.CloseIssue(ctx, issue.ID, "Done", "test-user"); err != nil {
+			if err := store.CloseIssue(ctx, issue.ID, storage.CloseOptions{Reason: "Done"}, "test-user"); err != nil {
 				t.Fatalf("CloseIssue failed: %v", err)
 			}
 		}
@@ -879,3 +1174,249 @@ func TestClose(t *testing.T) {
 		t.Error("Store should be closed")
 	}
 }
+
+func TestWithTxRollback(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	existing := &types.Issue{Title: "Existing", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, existing, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	var created *types.Issue
+	err := store.WithTx(ctx, func(tx storage.Transaction) error {
+		created = &types.Issue{Title: "Rolled back", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := tx.CreateIssue(ctx, created, "test"); err != nil {
+			return err
+		}
+		if err := tx.UpdateIssue(ctx, existing.ID, map[string]interface{}{"title": "Changed"}, "test"); err != nil {
+			return err
+		}
+		if err := tx.AddLabel(ctx, existing.ID, "gone", "test"); err != nil {
+			return err
+		}
+		return fmt.Errorf("boom")
+	})
+	if err == nil {
+		t.Fatal("Expected callback error")
+	}
+
+	if got, _ := store.GetIssue(ctx, created.ID); got != nil {
+		t.Errorf("Expected created issue to be rolled back, got %+v", got)
+	}
+	got, _ := store.GetIssue(ctx, existing.ID)
+	if got == nil || got.Title != "Existing" {
+		t.Errorf("Expected title update to be rolled back, got %+v", got)
+	}
+	if labels, _ := store.GetLabels(ctx, existing.ID); len(labels) != 0 {
+		t.Errorf("Expected label to be rolled back, got %v", labels)
+	}
+
+	// A successful transaction keeps its writes
+	err = store.WithTx(ctx, func(tx storage.Transaction) error {
+		return tx.UpdateIssue(ctx, existing.ID, map[string]interface{}{"title": "Committed"}, "test")
+	})
+	if err != nil {
+		t.Fatalf("WithTx failed: %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, existing.ID); got.Title != "Committed" {
+		t.Errorf("Expected committed title, got %q", got.Title)
+	}
+}
+
+func TestPruneEvents(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Long history",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": fmt.Sprintf("Old title %d", i)}, "test-user"); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+	}
+	for _, event := range store.events[issue.ID] {
+		event.CreatedAt = time.Now().AddDate(-1, 0, 0)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"title": "Recent title"}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -1)
+	removed, err := store.PruneEvents(ctx, cutoff, 2, true)
+	if err != nil {
+		t.Fatalf("PruneEvents dry run failed: %v", err)
+	}
+	if removed != 2 || len(store.events[issue.ID]) != 5 {
+		t.Errorf("Expected dry run to report 2 and keep all events, got %d (%d left)", removed, len(store.events[issue.ID]))
+	}
+
+	removed, err = store.PruneEvents(ctx, cutoff, 0, false)
+	if err != nil {
+		t.Fatalf("PruneEvents failed: %v", err)
+	}
+	if removed != 3 {
+		t.Errorf("Expected the 3 old updates removed, got %d", removed)
+	}
+
+	// The created event is the newest status event, so it survives
+	events, _ := store.GetEvents(ctx, issue.ID, 0)
+	if len(events) != 2 || events[0].EventType != types.EventCreated || events[1].EventType != types.EventUpdated {
+		t.Errorf("Expected created and recent update to survive, got %d events", len(events))
+	}
+}
+
+func TestDeleteChildReclaimsCounter(t *testing.T) {
+	store := setupTestMemory(t)
+	defer store.Close()
+
+	ctx := context.Background()
+	parent := &types.Issue{ID: "bd-af78e9a2", Title: "Parent", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic}
+	if err := store.CreateIssue(ctx, parent, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		childID, err := store.GetNextChildID(ctx, parent.ID)
+		if err != nil {
+			t.Fatalf("GetNextChildID failed: %v", err)
+		}
+		child := &types.Issue{ID: childID, Title: "Child", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, child, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	if err := store.DeleteIssue(ctx, "bd-af78e9a2.3"); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	if next, _ := store.GetNextChildID(ctx, parent.ID); next != "bd-af78e9a2.3" {
+		t.Errorf("Expected .3 to be reused, got %s", next)
+	}
+
+	if err := store.DeleteIssue(ctx, "bd-af78e9a2.2"); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	if err := store.ResetChildCounter(ctx, parent.ID); err != nil {
+		t.Fatalf("ResetChildCounter failed: %v", err)
+	}
+	if next, _ := store.GetNextChildID(ctx, parent.ID); next != "bd-af78e9a2.2" {
+		t.Errorf("Expected .2 after reset, got %s", next)
+	}
+}
+
+func TestSyncChildCounters(t *testing.T) {
+	store := New("")
+	defer store.Close()
+	ctx := context.Background()
+
+	// Loaded as a JSONL import would, without allocating child numbers
+	var issues []*types.Issue
+	for _, id := range []string{"bd-a", "bd-a.2", "bd-a.5", "bd-b", "bd-b.1", "bd-gone.3"} {
+		issues = append(issues, &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask})
+	}
+	if err := store.LoadFromIssues(issues); err != nil {
+		t.Fatalf("LoadFromIssues failed: %v", err)
+	}
+
+	set, err := store.SyncChildCounters(ctx, "bd-a", "bd-gone")
+	if err != nil || set != 1 {
+		t.Fatalf("SyncChildCounters = %d, %v; want 1 (bd-gone has no parent issue)", set, err)
+	}
+	if next, _ := store.GetNextChildID(ctx, "bd-a"); next != "bd-a.6" {
+		t.Errorf("next child of bd-a = %s, want bd-a.6", next)
+	}
+	// bd-b wasn't asked for until now
+	if set, _ := store.SyncChildCounters(ctx); set != 1 {
+		t.Errorf("SyncChildCounters() set %d, want 1 (bd-b)", set)
+	}
+	if next, _ := store.GetNextChildID(ctx, "bd-b"); next != "bd-b.2" {
+		t.Errorf("next child of bd-b = %s, want bd-b.2", next)
+	}
+}
+
+func TestSearchIssuesReadyAndBlocked(t *testing.T) {
+	store := New("")
+	defer store.Close()
+	ctx := context.Background()
+
+	closedAt := time.Now()
+	if err := store.LoadFromIssues([]*types.Issue{
+		{ID: "bd-1", Title: "Blocker", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+		{ID: "bd-2", Title: "Blocked", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask,
+			Dependencies: []*types.Dependency{{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepBlocks}}},
+		{ID: "bd-3", Title: "Child of blocked", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask,
+			Dependencies: []*types.Dependency{{IssueID: "bd-3", DependsOnID: "bd-2", Type: types.DepParentChild}}},
+		{ID: "bd-4", Title: "Closed blocker", Status: types.StatusClosed, Priority: 1, IssueType: types.TypeTask, ClosedAt: &closedAt},
+		{ID: "bd-5", Title: "Unblocked", Status: types.StatusInProgress, Priority: 1, IssueType: types.TypeTask,
+			Dependencies: []*types.Dependency{{IssueID: "bd-5", DependsOnID: "bd-4", Type: types.DepBlocks}}},
+	}); err != nil {
+		t.Fatalf("LoadFromIssues failed: %v", err)
+	}
+
+	for _, tt := range []struct {
+		name   string
+		filter types.IssueFilter
+		want   []string
+	}{
+		{"ready", types.IssueFilter{Ready: true}, []string{"bd-1", "bd-5"}},
+		{"blocked", types.IssueFilter{Blocked: true}, []string{"bd-2"}},
+	} {
+		results, err := store.SearchIssues(ctx, "", tt.filter)
+		if err != nil {
+			t.Fatalf("%s: SearchIssues failed: %v", tt.name, err)
+		}
+		var got []string
+		for _, issue := range results {
+			got = append(got, issue.ID)
+		}
+		sort.Strings(got)
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := store.SearchIssues(ctx, "", types.IssueFilter{Ready: true, Blocked: true}); err == nil {
+		t.Error("expected an error for Ready and Blocked together")
+	}
+}
+
+func TestRenameLabel(t *testing.T) {
+	store := New("")
+	defer store.Close()
+	ctx := context.Background()
+
+	if err := store.LoadFromIssues([]*types.Issue{
+		{ID: "bd-1", Title: "Typo", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Labels: []string{"frontnd", "ui"}},
+		{ID: "bd-2", Title: "Both", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Labels: []string{"frontnd", "frontend"}},
+		{ID: "bd-3", Title: "Other", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Labels: []string{"ui"}},
+	}); err != nil {
+		t.Fatalf("LoadFromIssues failed: %v", err)
+	}
+
+	changed, err := store.RenameLabel(ctx, "frontnd", "frontend", "test", false)
+	if err != nil {
+		t.Fatalf("RenameLabel failed: %v", err)
+	}
+	if strings.Join(changed, ",") != "bd-1,bd-2" {
+		t.Errorf("changed = %v, want [bd-1 bd-2]", changed)
+	}
+	for id, want := range map[string]string{"bd-1": "frontend,ui", "bd-2": "frontend", "bd-3": "ui"} {
+		labels, _ := store.GetLabels(ctx, id)
+		sort.Strings(labels)
+		if strings.Join(labels, ",") != want {
+			t.Errorf("labels of %s = %v, want %s", id, labels, want)
+		}
+	}
+}
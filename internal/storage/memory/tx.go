@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// memorySnapshot is a deep copy of the mutable state, taken at the start of
+// WithTx so a failed transaction can be rolled back
+type memorySnapshot struct {
+	issues       map[string]*types.Issue
+	dependencies map[string][]*types.Dependency
+	labels       map[string][]string
+	events       map[string][]*types.Event
+	comments     map[string][]*types.Comment
+	config       map[string]string
+	metadata     map[string]string
+	counters     map[string]int
+	locks        map[string]*types.IssueLock
+	dirty        map[string]bool
+}
+
+// WithTx runs fn against the store and restores the state from before the
+// call if fn returns an error (or panics). Transactions are serialized with
+// each other; writes made outside WithTx while fn runs are discarded on
+// rollback, since --no-db mode has a single writer.
+func (m *MemoryStorage) WithTx(ctx context.Context, fn func(tx storage.Transaction) error) error {
+	m.txMu.Lock()
+	defer m.txMu.Unlock()
+
+	snap := m.snapshot()
+	committed := false
+	defer func() {
+		if !committed {
+			m.restore(snap)
+		}
+	}()
+
+	if err := fn(m); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+func (m *MemoryStorage) snapshot() *memorySnapshot {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	snap := &memorySnapshot{
+		issues:       make(map[string]*types.Issue, len(m.issues)),
+		dependencies: make(map[string][]*types.Dependency, len(m.dependencies)),
+		labels:       make(map[string][]string, len(m.labels)),
+		events:       make(map[string][]*types.Event, len(m.events)),
+		comments:     make(map[string][]*types.Comment, len(m.comments)),
+		config:       make(map[string]string, len(m.config)),
+		metadata:     make(map[string]string, len(m.metadata)),
+		counters:     make(map[string]int, len(m.counters)),
+		locks:        make(map[string]*types.IssueLock, len(m.locks)),
+		dirty:        make(map[string]bool, len(m.dirty)),
+	}
+	for id, issue := range m.issues {
+		issueCopy := *issue
+		issueCopy.Labels = append([]string(nil), issue.Labels...)
+		issueCopy.Dependencies = append([]*types.Dependency(nil), issue.Dependencies...)
+		snap.issues[id] = &issueCopy
+	}
+	for id, deps := range m.dependencies {
+		copied := make([]*types.Dependency, len(deps))
+		for i, dep := range deps {
+			depCopy := *dep
+			copied[i] = &depCopy
+		}
+		snap.dependencies[id] = copied
+	}
+	for id, labels := range m.labels {
+		snap.labels[id] = append([]string(nil), labels...)
+	}
+	for id, events := range m.events {
+		snap.events[id] = append([]*types.Event(nil), events...)
+	}
+	for id, comments := range m.comments {
+		copied := make([]*types.Comment, len(comments))
+		for i, comment := range comments {
+			commentCopy := *comment
+			copied[i] = &commentCopy
+		}
+		snap.comments[id] = copied
+	}
+	for k, v := range m.config {
+		snap.config[k] = v
+	}
+	for k, v := range m.metadata {
+		snap.metadata[k] = v
+	}
+	for k, v := range m.counters {
+		snap.counters[k] = v
+	}
+	for id, lock := range m.locks {
+		lockCopy := *lock
+		snap.locks[id] = &lockCopy
+	}
+	for id, dirty := range m.dirty {
+		snap.dirty[id] = dirty
+	}
+	return snap
+}
+
+func (m *MemoryStorage) restore(snap *memorySnapshot) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.issues = snap.issues
+	m.dependencies = snap.dependencies
+	m.labels = snap.labels
+	m.events = snap.events
+	m.comments = snap.comments
+	m.config = snap.config
+	m.metadata = snap.metadata
+	m.counters = snap.counters
+	m.locks = snap.locks
+	m.dirty = snap.dirty
+}
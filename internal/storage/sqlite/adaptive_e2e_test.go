@@ -163,3 +163,171 @@ func TestAdaptiveIDLength_CustomConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestAdaptiveIDLength_EnvOverride(t *testing.T) {
+	// Env vars win over the stored config
+	t.Setenv(types.IssuePrefixEnvVar, "ci")
+	t.Setenv(MaxCollisionProbEnvVar, "0.01")
+	t.Setenv(MinHashLengthEnvVar, "6")
+
+	// Create in-memory database
+	db, err := New(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+
+	if err := db.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+	if err := db.SetConfig(ctx, "min_hash_length", "3"); err != nil {
+		t.Fatalf("Failed to set min_hash_length: %v", err)
+	}
+
+	for i := 0; i < 20; i++ {
+		issue := &types.Issue{
+			Title:       formatTitle("Issue %d", i),
+			Description: "Test",
+			Status:      "open",
+			Priority:    1,
+			IssueType:   "task",
+		}
+
+		if err := db.CreateIssue(ctx, issue, "test@example.com"); err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+
+		if !strings.HasPrefix(issue.ID, "ci-") {
+			t.Fatalf("Issue %d ID = %s, want BEADS_ISSUE_PREFIX prefix ci-", i, issue.ID)
+		}
+		hashPart := strings.TrimPrefix(issue.ID, "ci-")
+		if len(hashPart) < 6 {
+			t.Errorf("Issue %d with BEADS_MIN_HASH_LENGTH=6: hash length = %d, want >= 6", i, len(hashPart))
+		}
+	}
+
+	info, err := db.GetAdaptiveIDInfo(ctx, "ci")
+	if err != nil {
+		t.Fatalf("GetAdaptiveIDInfo failed: %v", err)
+	}
+	if info.MaxCollisionProbability != 0.01 || info.MinLength != 6 {
+		t.Errorf("GetAdaptiveIDInfo = %+v, want max_collision_prob 0.01 and min_hash_length 6 from env", info)
+	}
+}
+
+func TestAdaptiveIDLength_EnvWithoutStoredConfig(t *testing.T) {
+	// A throwaway database never written to config still creates issues
+	t.Setenv(types.IssuePrefixEnvVar, "ci")
+	t.Setenv(MinHashLengthEnvVar, "5")
+
+	db, err := New(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "No config", Status: "open", Priority: 1, IssueType: "task"}
+	if err := db.CreateIssue(ctx, issue, "test@example.com"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	if hashPart := strings.TrimPrefix(issue.ID, "ci-"); hashPart == issue.ID || len(hashPart) < 5 {
+		t.Errorf("ID = %s, want ci- prefix and hash of at least 5 chars", issue.ID)
+	}
+
+	// An explicit ID still has to use the env prefix
+	explicit := &types.Issue{ID: "other-abc", Title: "Explicit", Status: "open", Priority: 1, IssueType: "task"}
+	if err := db.CreateIssue(ctx, explicit, "test@example.com"); err == nil {
+		t.Errorf("CreateIssue with ID %s should fail under BEADS_ISSUE_PREFIX=ci", explicit.ID)
+	}
+}
+
+func TestAdaptiveIDLength_InvalidEnvFallsBack(t *testing.T) {
+	t.Setenv(MinHashLengthEnvVar, "99")
+	t.Setenv(MaxCollisionProbEnvVar, "2")
+
+	db, err := New(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := db.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+	if err := db.SetConfig(ctx, "min_hash_length", "4"); err != nil {
+		t.Fatalf("Failed to set min_hash_length: %v", err)
+	}
+
+	// Invalid env values are ignored: stored min_hash_length, default probability
+	info, err := db.GetAdaptiveIDInfo(ctx, "test")
+	if err != nil {
+		t.Fatalf("GetAdaptiveIDInfo failed: %v", err)
+	}
+	if info.MinLength != 4 || info.MaxCollisionProbability != DefaultAdaptiveConfig().MaxCollisionProbability {
+		t.Errorf("GetAdaptiveIDInfo = %+v, want stored min_hash_length 4 and default max_collision_prob", info)
+	}
+
+	// An invalid prefix is an error rather than a silently wrong ID
+	t.Setenv(types.IssuePrefixEnvVar, "Bad-Prefix")
+	issue := &types.Issue{Title: "Bad prefix", Status: "open", Priority: 1, IssueType: "task"}
+	if err := db.CreateIssue(ctx, issue, "test@example.com"); err == nil || !strings.Contains(err.Error(), types.IssuePrefixEnvVar) {
+		t.Errorf("CreateIssue error = %v, want one naming %s", err, types.IssuePrefixEnvVar)
+	}
+}
+
+func TestCreateIssue_IDLengthOverride(t *testing.T) {
+	db, err := New(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+
+	if err := db.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	newIssue := func(idLength int) *types.Issue {
+		return &types.Issue{Title: "Issue", Status: "open", Priority: 1, IssueType: "task", IDLength: idLength}
+	}
+
+	// The override applies to this issue only
+	issue := newIssue(7)
+	if err := db.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	if hashPart := strings.TrimPrefix(issue.ID, "test-"); len(hashPart) != 7 {
+		t.Errorf("Issue with IDLength 7: ID = %s, want a 7-char hash", issue.ID)
+	}
+	issue = newIssue(0)
+	if err := db.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	if hashPart := strings.TrimPrefix(issue.ID, "test-"); len(hashPart) != 3 {
+		t.Errorf("Next issue: ID = %s, want the adaptive 3-char hash", issue.ID)
+	}
+
+	// min_hash_length is still a floor
+	if err := db.SetConfig(ctx, "min_hash_length", "5"); err != nil {
+		t.Fatalf("Failed to set min_hash_length: %v", err)
+	}
+	issue = newIssue(4)
+	if err := db.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	if hashPart := strings.TrimPrefix(issue.ID, "test-"); len(hashPart) != 5 {
+		t.Errorf("Issue with IDLength 4 and min_hash_length 5: ID = %s, want a 5-char hash", issue.ID)
+	}
+
+	for _, idLength := range []int{2, 9} {
+		if err := db.CreateIssue(ctx, newIssue(idLength), "test"); err == nil {
+			t.Errorf("CreateIssue with IDLength %d succeeded, want error", idLength)
+		}
+	}
+}
@@ -3,7 +3,9 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"math"
+	"os"
 	"strconv"
 )
 
@@ -59,33 +61,95 @@ func computeAdaptiveLength(numIssues int, config AdaptiveIDConfig) int {
 	return config.MaxLength
 }
 
-// getAdaptiveConfig reads adaptive ID config from database, returns defaults if not set
+// maxIssuesAtLength returns the most issues idLength keeps under maxProb,
+// inverting collisionProbability: n = sqrt(-2N ln(1-p))
+func maxIssuesAtLength(idLength int, maxProb float64) int {
+	totalPossibilities := math.Pow(36.0, float64(idLength))
+	n := int(math.Sqrt(-2.0 * totalPossibilities * math.Log(1.0-maxProb)))
+	// Correct for rounding so the result agrees with collisionProbability
+	for collisionProbability(n+1, idLength) <= maxProb {
+		n++
+	}
+	for n > 0 && collisionProbability(n, idLength) > maxProb {
+		n--
+	}
+	return n
+}
+
+// forecastLengthIncrease returns the length computeAdaptiveLength moves to
+// after length, and how many more issues can be created first. ok is false
+// when length is already config.MaxLength.
+func forecastLengthIncrease(numIssues, length int, config AdaptiveIDConfig) (nextLength, remaining int, ok bool) {
+	if length >= config.MaxLength {
+		return 0, 0, false
+	}
+	threshold := maxIssuesAtLength(length, config.MaxCollisionProbability)
+	remaining = threshold + 1 - numIssues
+	if remaining < 1 {
+		remaining = 1
+	}
+	return computeAdaptiveLength(threshold+1, config), remaining, true
+}
+
+// Config keys overriding DefaultAdaptiveConfig
+const (
+	MaxCollisionProbConfigKey = "max_collision_prob"
+	MinHashLengthConfigKey    = "min_hash_length"
+	MaxHashLengthConfigKey    = "max_hash_length"
+)
+
+// Environment variables overriding the stored max_collision_prob and
+// min_hash_length, for throwaway databases that skip writing config
+const (
+	MaxCollisionProbEnvVar = "BEADS_MAX_COLLISION_PROB"
+	MinHashLengthEnvVar    = "BEADS_MIN_HASH_LENGTH"
+)
+
+// ParseMaxCollisionProb parses a max_collision_prob value, which must be a
+// probability between 0 and 1 (exclusive)
+func ParseMaxCollisionProb(value string) (float64, error) {
+	prob, err := strconv.ParseFloat(value, 64)
+	if err != nil || prob <= 0 || prob >= 1 {
+		return 0, fmt.Errorf("%s must be a number between 0 and 1, got %q", MaxCollisionProbConfigKey, value)
+	}
+	return prob, nil
+}
+
+// ParseHashLength parses a min_hash_length or max_hash_length value, which
+// must be a length from 3 to 8 (the hash lengths IDs can be generated with)
+func ParseHashLength(key, value string) (int, error) {
+	length, err := strconv.Atoi(value)
+	if err != nil || length < 3 || length > 8 {
+		return 0, fmt.Errorf("%s must be an integer from 3 to 8, got %q", key, value)
+	}
+	return length, nil
+}
+
+// getAdaptiveConfig reads adaptive ID config from database, returns defaults
+// for values that are unset or invalid. BEADS_MAX_COLLISION_PROB and
+// BEADS_MIN_HASH_LENGTH take precedence over the stored values when valid.
 func getAdaptiveConfig(ctx context.Context, conn *sql.Conn) AdaptiveIDConfig {
 	config := DefaultAdaptiveConfig()
+	read := func(key string) string {
+		var value string
+		_ = conn.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, key).Scan(&value)
+		return value
+	}
 	
-	// Read max_collision_prob
-	var probStr string
-	err := conn.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, "max_collision_prob").Scan(&probStr)
-	if err == nil && probStr != "" {
-		if prob, err := strconv.ParseFloat(probStr, 64); err == nil {
+	for _, value := range []string{os.Getenv(MaxCollisionProbEnvVar), read(MaxCollisionProbConfigKey)} {
+		if prob, err := ParseMaxCollisionProb(value); err == nil {
 			config.MaxCollisionProbability = prob
+			break
 		}
 	}
-	
-	// Read min_hash_length
-	var minLenStr string
-	err = conn.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, "min_hash_length").Scan(&minLenStr)
-	if err == nil && minLenStr != "" {
-		if minLen, err := strconv.Atoi(minLenStr); err == nil {
+	for _, value := range []string{os.Getenv(MinHashLengthEnvVar), read(MinHashLengthConfigKey)} {
+		if minLen, err := ParseHashLength(MinHashLengthConfigKey, value); err == nil {
 			config.MinLength = minLen
+			break
 		}
 	}
-	
-	// Read max_hash_length
-	var maxLenStr string
-	err = conn.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, "max_hash_length").Scan(&maxLenStr)
-	if err == nil && maxLenStr != "" {
-		if maxLen, err := strconv.Atoi(maxLenStr); err == nil {
+	if value := read(MaxHashLengthConfigKey); value != "" {
+		if maxLen, err := ParseHashLength(MaxHashLengthConfigKey, value); err == nil {
 			config.MaxLength = maxLen
 		}
 	}
@@ -125,3 +189,49 @@ func GetAdaptiveIDLength(ctx context.Context, conn *sql.Conn, prefix string) (in
 	
 	return length, nil
 }
+
+// AdaptiveIDInfo reports the inputs and outcome of the adaptive length choice
+// for new IDs with a prefix
+type AdaptiveIDInfo struct {
+	Prefix                  string  `json:"prefix"`
+	IssueCount              int     `json:"issue_count"` // Top-level issues only
+	MaxCollisionProbability float64 `json:"max_collision_prob"`
+	MinLength               int     `json:"min_hash_length"`
+	MaxLength               int     `json:"max_hash_length"`
+	Length                  int     `json:"hash_length"`
+	CollisionProbability    float64 `json:"collision_probability"` // At Length
+	// The length new IDs grow to next and how many more top-level issues
+	// can be created before they do; unset at MaxLength
+	NextLength            int `json:"next_hash_length,omitempty"`
+	IssuesUntilNextLength int `json:"issues_until_next_length,omitempty"`
+}
+
+// GetAdaptiveIDInfo explains the hash length GenerateIssueID would start
+// from for a new issue with the given prefix
+func (s *SQLiteStorage) GetAdaptiveIDInfo(ctx context.Context, prefix string) (*AdaptiveIDInfo, error) {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	numIssues, err := countTopLevelIssues(ctx, conn, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count issues: %w", err)
+	}
+	config := getAdaptiveConfig(ctx, conn)
+	length := computeAdaptiveLength(numIssues, config)
+	info := &AdaptiveIDInfo{
+		Prefix:                  prefix,
+		IssueCount:              numIssues,
+		MaxCollisionProbability: config.MaxCollisionProbability,
+		MinLength:               config.MinLength,
+		MaxLength:               config.MaxLength,
+		Length:                  length,
+		CollisionProbability:    collisionProbability(numIssues, length),
+	}
+	if next, remaining, ok := forecastLengthIncrease(numIssues, length, config); ok {
+		info.NextLength, info.IssuesUntilNextLength = next, remaining
+	}
+	return info, nil
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/steveyegge/beads/internal/types"
 )
 
 func TestCollisionProbability(t *testing.T) {
@@ -111,8 +113,6 @@ func TestGenerateHashID_VariableLengths(t *testing.T) {
 	prefix := "bd"
 	title := "Test issue"
 	description := "Test description"
-	creator := "test@example.com"
-	timestamp, _ := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
 	
 	tests := []struct {
 		length       int
@@ -128,7 +128,7 @@ func TestGenerateHashID_VariableLengths(t *testing.T) {
 	
 	for _, tt := range tests {
 		t.Run(fmt.Sprintf("length_%d", tt.length), func(t *testing.T) {
-			id := generateHashID(prefix, title, description, creator, timestamp, tt.length, 0)
+			id := generateHashID(prefix, title, description, tt.length, 0)
 			
 			// Format: "bd-xxxx" where xxxx is the hash
 			if !strings.HasPrefix(id, prefix+"-") {
@@ -187,3 +187,101 @@ func TestGetAdaptiveIDLength_Integration(t *testing.T) {
 		t.Errorf("With min_hash_length=5, got %d", length)
 	}
 }
+
+func TestGetAdaptiveIDInfo(t *testing.T) {
+	db := newTestStore(t, "")
+	defer db.Close()
+	ctx := context.Background()
+
+	if err := db.SetConfig(ctx, "max_collision_prob", "0.01"); err != nil {
+		t.Fatalf("Failed to set max_collision_prob: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		issue := &types.Issue{Title: fmt.Sprintf("Issue %d", i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := db.CreateIssue(ctx, issue, "test-actor"); err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+	}
+
+	info, err := db.GetAdaptiveIDInfo(ctx, "bd")
+	if err != nil {
+		t.Fatalf("GetAdaptiveIDInfo failed: %v", err)
+	}
+	if info.IssueCount != 3 || info.MaxCollisionProbability != 0.01 || info.MinLength != 3 || info.MaxLength != 8 {
+		t.Errorf("unexpected info: %+v", info)
+	}
+	if info.Length != computeAdaptiveLength(3, AdaptiveIDConfig{MaxCollisionProbability: 0.01, MinLength: 3, MaxLength: 8}) {
+		t.Errorf("Length = %d, want the computed adaptive length", info.Length)
+	}
+	if info.CollisionProbability != collisionProbability(3, info.Length) || info.CollisionProbability > 0.01 {
+		t.Errorf("CollisionProbability = %v at length %d", info.CollisionProbability, info.Length)
+	}
+	wantNext := computeAdaptiveLength(3+info.IssuesUntilNextLength, AdaptiveIDConfig{MaxCollisionProbability: 0.01, MinLength: 3, MaxLength: 8})
+	if info.NextLength != info.Length+1 || wantNext != info.NextLength {
+		t.Errorf("NextLength = %d after %d more issues, want %d", info.NextLength, info.IssuesUntilNextLength, wantNext)
+	}
+}
+
+func TestForecastLengthIncrease(t *testing.T) {
+	config := DefaultAdaptiveConfig()
+	for _, numIssues := range []int{0, 50, 161, 500, 5000} {
+		length := computeAdaptiveLength(numIssues, config)
+		next, remaining, ok := forecastLengthIncrease(numIssues, length, config)
+		if !ok {
+			t.Fatalf("forecastLengthIncrease(%d, %d) reported no next length", numIssues, length)
+		}
+		// One issue short of the forecast keeps the length; reaching it grows it
+		if got := computeAdaptiveLength(numIssues+remaining-1, config); got != length {
+			t.Errorf("%d issues: length %d after %d more, want it to stay %d", numIssues, got, remaining-1, length)
+		}
+		if got := computeAdaptiveLength(numIssues+remaining, config); got != next || next != length+1 {
+			t.Errorf("%d issues: length %d after %d more, want %d", numIssues, got, remaining, next)
+		}
+	}
+	if _, _, ok := forecastLengthIncrease(10, 8, config); ok {
+		t.Error("Expected no forecast at max_hash_length")
+	}
+}
+
+func TestDeriveHashID(t *testing.T) {
+	db := newTestStore(t, "")
+	defer db.Close()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Derive me", Description: "desc", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := db.CreateIssue(ctx, issue, "alice"); err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+	stored, err := db.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+
+	if stored.IDNonce == nil {
+		t.Fatalf("expected %s to store its id_nonce", issue.ID)
+	}
+
+	// The creator doesn't matter for IDs with a stored nonce
+	d, ok := DeriveHashID(stored, "bd", "")
+	if !ok {
+		t.Fatalf("expected %s to reproduce from its stored fields, got %+v", issue.ID, d)
+	}
+	wantContent := fmt.Sprintf("Derive me|desc|%d", *stored.IDNonce)
+	if d.Content != wantContent || d.Nonce != *stored.IDNonce || d.Length != len(issue.ID)-len("bd-") {
+		t.Errorf("derivation = %+v, want content %q", d, wantContent)
+	}
+	if len(d.Digest) != 64 {
+		t.Errorf("digest %q is not hex SHA-256", d.Digest)
+	}
+
+	// Legacy IDs without a nonce hashed the creator and creation time
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	legacy := &types.Issue{Title: "Old", Description: "desc", CreatedAt: createdAt}
+	legacy.ID = hashIDFromContent("bd", legacyHashIDContent(legacy.Title, legacy.Description, "alice", createdAt, 1), 5)
+	if d, ok := DeriveHashID(legacy, "bd", "alice"); !ok || d.Nonce != 1 || d.Length != 5 {
+		t.Errorf("expected legacy %s to reproduce at nonce 1, got %+v", legacy.ID, d)
+	}
+	if d, ok := DeriveHashID(legacy, "bd", "bob"); ok || d.ID == legacy.ID {
+		t.Errorf("expected a different creator not to reproduce %s, got %+v", legacy.ID, d)
+	}
+}
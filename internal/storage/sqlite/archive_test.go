@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestArchivedIssues(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, id := range []string{"bd-1", "bd-2"} {
+		issue := &types.Issue{ID: id, Title: "Issue " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	before, _ := store.GetIssue(ctx, "bd-1")
+
+	if err := store.UpdateIssue(ctx, "bd-1", map[string]interface{}{"archived_at": time.Now()}, "test"); err != nil {
+		t.Fatalf("archiving failed: %v", err)
+	}
+	archived, err := store.GetIssue(ctx, "bd-1")
+	if err != nil || archived == nil || archived.ArchivedAt == nil {
+		t.Fatalf("expected bd-1 to be archived and still readable by ID, got %+v, %v", archived, err)
+	}
+	if archived.ContentHash == before.ContentHash {
+		t.Error("expected archiving to change the content hash so imports pick it up")
+	}
+
+	assertIDs := func(name string, issues []*types.Issue, want ...string) {
+		t.Helper()
+		if len(issues) != len(want) {
+			t.Fatalf("%s: expected %v, got %d issues", name, want, len(issues))
+		}
+		for i, issue := range issues {
+			if issue.ID != want[i] {
+				t.Errorf("%s: expected %v, got %s at %d", name, want, issue.ID, i)
+			}
+		}
+	}
+	sortByID := []types.SortKey{{Field: types.SortFieldID}}
+
+	issues, _ := store.SearchIssues(ctx, "", types.IssueFilter{SortBy: sortByID})
+	assertIDs("default search", issues, "bd-2")
+	issues, _ = store.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true, SortBy: sortByID})
+	assertIDs("with IncludeArchived", issues, "bd-1", "bd-2")
+	issues, _ = store.SearchIssues(ctx, "", types.IssueFilter{IDs: []string{"bd-1"}})
+	assertIDs("by ID", issues, "bd-1")
+	issues, _ = store.GetReadyWork(ctx, types.WorkFilter{})
+	assertIDs("ready work", issues, "bd-2")
+
+	if err := store.UpdateIssue(ctx, "bd-1", map[string]interface{}{"archived_at": nil}, "test"); err != nil {
+		t.Fatalf("unarchiving failed: %v", err)
+	}
+	issues, _ = store.SearchIssues(ctx, "", types.IssueFilter{SortBy: sortByID})
+	assertIDs("after unarchive", issues, "bd-1", "bd-2")
+	if restored, _ := store.GetIssue(ctx, "bd-1"); restored.ContentHash != before.ContentHash {
+		t.Error("expected unarchiving to restore the original content hash")
+	}
+
+	if err := store.UpdateIssue(ctx, "bd-1", map[string]interface{}{"archived_at": "yesterday"}, "test"); err == nil {
+		t.Error("expected a non-time archived_at to be rejected")
+	}
+}
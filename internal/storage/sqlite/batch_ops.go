@@ -4,9 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 )
 
 // validateBatchIssues validates all issues in a batch and sets timestamps if not provided
@@ -34,18 +37,14 @@ func validateBatchIssues(issues []*types.Issue) error {
 
 // generateBatchIDs generates IDs for all issues that need them atomically
 func (s *SQLiteStorage) generateBatchIDs(ctx context.Context, conn *sql.Conn, issues []*types.Issue, actor string, orphanHandling OrphanHandling) error {
-	// Get prefix from config (needed for both generation and validation)
-	var prefix string
-	err := conn.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, "issue_prefix").Scan(&prefix)
-	if err == sql.ErrNoRows || prefix == "" {
-		// CRITICAL: Reject operation if issue_prefix config is missing (bd-166)
-		return fmt.Errorf("database not initialized: issue_prefix config is missing (run 'bd init --prefix <prefix>' first)")
-	} else if err != nil {
-		return fmt.Errorf("failed to get config: %w", err)
+	// Get prefixes from config (needed for both generation and validation)
+	prefixes, err := getIDPrefixes(ctx, conn)
+	if err != nil {
+		return err
 	}
 
 	// Generate or validate IDs for all issues
-	if err := EnsureIDs(ctx, conn, prefix, issues, actor, orphanHandling); err != nil {
+	if err := EnsureIDs(ctx, conn, prefixes, issues, orphanHandling); err != nil {
 		return err
 	}
 	
@@ -137,6 +136,12 @@ func (s *SQLiteStorage) CreateIssuesWithOptions(ctx context.Context, issues []*t
 		return err
 	}
 
+	return s.createIssuesTx(ctx, issues, actor, orphanHandling, false)
+}
+
+// createIssuesTx runs phases 2-7 of a batch create in one transaction.
+// With withLabels, each issue's Labels are inserted in the same transaction.
+func (s *SQLiteStorage) createIssuesTx(ctx context.Context, issues []*types.Issue, actor string, orphanHandling OrphanHandling, withLabels bool) error {
 	// Phase 2: Acquire connection and start transaction
 	conn, err := s.db.Conn(ctx)
 	if err != nil {
@@ -170,6 +175,13 @@ func (s *SQLiteStorage) CreateIssuesWithOptions(ctx context.Context, issues []*t
 		return err
 	}
 
+	// Phase 5b: Insert labels (and their label_added events)
+	if withLabels {
+		if err := bulkInsertLabels(ctx, conn, issues, actor); err != nil {
+			return err
+		}
+	}
+
 	// Phase 6: Mark issues dirty for incremental export
 	if err := bulkMarkDirty(ctx, conn, issues); err != nil {
 		return err
@@ -182,3 +194,125 @@ func (s *SQLiteStorage) CreateIssuesWithOptions(ctx context.Context, issues []*t
 	committed = true
 	return nil
 }
+
+// ImportBatchSizeConfigKey sets how many issues CreateIssuesBatch (and so
+// bd import) inserts per transaction
+const ImportBatchSizeConfigKey = "import.batch_size"
+
+// DefaultImportBatchSize is the batch size used when import.batch_size is unset
+const DefaultImportBatchSize = 1000
+
+// ParseImportBatchSize parses an import.batch_size value, which must be a
+// positive integer
+func ParseImportBatchSize(value string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer, got %q", ImportBatchSizeConfigKey, value)
+	}
+	return n, nil
+}
+
+// GetImportBatchSize gets the import.batch_size config value
+// Returns DefaultImportBatchSize if not set or if value is invalid
+func (s *SQLiteStorage) GetImportBatchSize(ctx context.Context) int {
+	value, err := s.GetConfig(ctx, ImportBatchSizeConfigKey)
+	if err != nil || value == "" {
+		return DefaultImportBatchSize
+	}
+	n, err := ParseImportBatchSize(value)
+	if err != nil {
+		return DefaultImportBatchSize
+	}
+	return n
+}
+
+// BatchOptions configures CreateIssuesBatchWithOptions
+type BatchOptions struct {
+	BatchSize      int            // Issues per transaction (<= 0 uses DefaultImportBatchSize)
+	OrphanHandling OrphanHandling // How to handle hierarchical issues with missing parents
+}
+
+// CreateIssuesBatch creates issues together with their labels, committing
+// once per import.batch_size issues instead of once per issue.
+//
+// Each batch runs in a single transaction that generates IDs, inserts the
+// issues, their labels, and creation/label events, and marks them dirty.
+// Hash IDs are unique within a batch (GenerateBatchIssueIDs tracks every ID
+// it hands out) and across batches (earlier batches are committed, so the
+// existence check sees them).
+//
+// Batches are atomic, the whole call is not: if batch N fails, batches
+// before it stay committed. Re-running an import is safe because existing
+// issues are matched by content hash and ID.
+func (s *SQLiteStorage) CreateIssuesBatch(ctx context.Context, issues []*types.Issue, actor string) error {
+	return s.CreateIssuesBatchWithOptions(ctx, issues, actor, BatchOptions{
+		BatchSize:      s.GetImportBatchSize(ctx),
+		OrphanHandling: OrphanResurrect,
+	})
+}
+
+// CreateIssuesBatchWithOptions is CreateIssuesBatch with an explicit batch
+// size and orphan handling
+func (s *SQLiteStorage) CreateIssuesBatchWithOptions(ctx context.Context, issues []*types.Issue, actor string, opts BatchOptions) error {
+	if len(issues) == 0 {
+		return nil
+	}
+
+	// Validate everything up front so a bad row fails before any batch commits
+	if err := validateBatchIssues(issues); err != nil {
+		return err
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultImportBatchSize
+	}
+	for start := 0; start < len(issues); start += batchSize {
+		end := start + batchSize
+		if end > len(issues) {
+			end = len(issues)
+		}
+		if err := s.createIssuesTx(ctx, issues[start:end], actor, opts.OrphanHandling, true); err != nil {
+			return fmt.Errorf("batch %d-%d: %w", start+1, end, err)
+		}
+	}
+	return nil
+}
+
+// bulkInsertLabels inserts every issue's labels with one prepared statement
+// per table, recording a label_added event for each as AddLabel does
+func bulkInsertLabels(ctx context.Context, conn *sql.Conn, issues []*types.Issue, actor string) error {
+	labelStmt, err := conn.PrepareContext(ctx, `INSERT OR IGNORE INTO labels (issue_id, label) VALUES (?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare label statement: %w", err)
+	}
+	defer func() { _ = labelStmt.Close() }()
+
+	eventStmt, err := conn.PrepareContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare label event statement: %w", err)
+	}
+	defer func() { _ = eventStmt.Close() }()
+
+	for _, issue := range issues {
+		for _, label := range issue.Labels {
+			if label = util.NormalizeLabel(label); label == "" {
+				continue
+			}
+			result, err := labelStmt.ExecContext(ctx, issue.ID, label)
+			if err != nil {
+				return fmt.Errorf("failed to add label %s to %s: %w", label, issue.ID, err)
+			}
+			if n, _ := result.RowsAffected(); n == 0 {
+				continue // Duplicate label in the input
+			}
+			if _, err := eventStmt.ExecContext(ctx, issue.ID, types.EventLabelAdded, actor, fmt.Sprintf("Added label: %s", label)); err != nil {
+				return fmt.Errorf("failed to record label event for %s: %w", issue.ID, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// benchImportSize is the number of issues each import benchmark iteration creates
+const benchImportSize = 500
+
+func benchImportIssues(n int) []*types.Issue {
+	issues := make([]*types.Issue, n)
+	for i := range issues {
+		issues[i] = &types.Issue{
+			Title:       fmt.Sprintf("Imported issue %d", i),
+			Description: "Benchmark import row",
+			Status:      types.StatusOpen,
+			Priority:    2,
+			IssueType:   types.TypeTask,
+			Labels:      []string{"imported", "backlog"},
+		}
+	}
+	return issues
+}
+
+func setupImportBenchDB(b *testing.B) (*SQLiteStorage, func()) {
+	b.Helper()
+	store, err := New(b.TempDir() + "/bench.db")
+	if err != nil {
+		b.Fatalf("Failed to create storage: %v", err)
+	}
+	if err := store.SetConfig(context.Background(), "issue_prefix", "bd"); err != nil {
+		b.Fatalf("Failed to set issue_prefix: %v", err)
+	}
+	return store, func() { store.Close() }
+}
+
+// BenchmarkImportPerIssue is the old import path: one transaction per
+// issue plus one per label
+func BenchmarkImportPerIssue(b *testing.B) {
+	ctx := context.Background()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		store, cleanup := setupImportBenchDB(b)
+		issues := benchImportIssues(benchImportSize)
+		b.StartTimer()
+
+		for _, issue := range issues {
+			if err := store.CreateIssue(ctx, issue, "import"); err != nil {
+				b.Fatalf("CreateIssue failed: %v", err)
+			}
+			for _, label := range issue.Labels {
+				if err := store.AddLabel(ctx, issue.ID, label, "import"); err != nil {
+					b.Fatalf("AddLabel failed: %v", err)
+				}
+			}
+		}
+
+		b.StopTimer()
+		cleanup()
+		b.StartTimer()
+	}
+}
+
+// BenchmarkImportBatch creates the same issues and labels with CreateIssuesBatch
+func BenchmarkImportBatch(b *testing.B) {
+	ctx := context.Background()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		store, cleanup := setupImportBenchDB(b)
+		issues := benchImportIssues(benchImportSize)
+		b.StartTimer()
+
+		if err := store.CreateIssuesBatch(ctx, issues, "import"); err != nil {
+			b.Fatalf("CreateIssuesBatch failed: %v", err)
+		}
+
+		b.StopTimer()
+		cleanup()
+		b.StartTimer()
+	}
+}
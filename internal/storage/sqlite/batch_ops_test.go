@@ -462,3 +462,92 @@ func TestBulkOperations(t *testing.T) {
 		}
 	})
 }
+
+func TestCreateIssuesBatch(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	t.Run("creates issues and labels across batches", func(t *testing.T) {
+		// Identical content and timestamp give every issue the same first
+		// hash candidate, so IDs are only unique if the batch tracks them
+		createdAt := time.Now()
+		var issues []*types.Issue
+		for i := 0; i < 5; i++ {
+			issues = append(issues, &types.Issue{
+				Title: "Same title", Priority: 2, IssueType: "task", Status: "open",
+				CreatedAt: createdAt, Labels: []string{"imported", "imported", "batch"},
+			})
+		}
+
+		err := s.CreateIssuesBatchWithOptions(ctx, issues, "test-actor", BatchOptions{BatchSize: 2, OrphanHandling: OrphanAllow})
+		if err != nil {
+			t.Fatalf("CreateIssuesBatchWithOptions failed: %v", err)
+		}
+
+		seen := make(map[string]bool)
+		for i, issue := range issues {
+			if issue.ID == "" || seen[issue.ID] {
+				t.Fatalf("issue %d got empty or duplicate ID %q", i, issue.ID)
+			}
+			seen[issue.ID] = true
+
+			labels, err := s.GetLabels(ctx, issue.ID)
+			if err != nil {
+				t.Fatalf("GetLabels(%s) failed: %v", issue.ID, err)
+			}
+			if strings.Join(labels, ",") != "batch,imported" {
+				t.Errorf("issue %s labels = %v, want [batch imported]", issue.ID, labels)
+			}
+
+			events, err := s.GetEvents(ctx, issue.ID, 0)
+			if err != nil {
+				t.Fatalf("GetEvents(%s) failed: %v", issue.ID, err)
+			}
+			counts := make(map[types.EventType]int)
+			for _, e := range events {
+				counts[e.EventType]++
+			}
+			if counts[types.EventCreated] != 1 || counts[types.EventLabelAdded] != 2 {
+				t.Errorf("issue %s events = %v, want 1 created and 2 label_added", issue.ID, counts)
+			}
+		}
+	})
+
+	t.Run("validates every batch before committing any", func(t *testing.T) {
+		issues := []*types.Issue{
+			{Title: "First batch", Priority: 1, IssueType: "task", Status: "open"},
+			{Title: "", Priority: 1, IssueType: "task", Status: "open"}, // invalid: empty title
+		}
+
+		err := s.CreateIssuesBatchWithOptions(ctx, issues, "test-actor", BatchOptions{BatchSize: 1})
+		if err == nil {
+			t.Fatal("expected validation error")
+		}
+		if issues[0].ID != "" {
+			t.Errorf("first batch should not have been created, got ID %s", issues[0].ID)
+		}
+	})
+}
+
+func TestImportBatchSize(t *testing.T) {
+	s, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	if got := s.GetImportBatchSize(ctx); got != DefaultImportBatchSize {
+		t.Errorf("unset batch size = %d, want %d", got, DefaultImportBatchSize)
+	}
+	if err := s.SetConfig(ctx, ImportBatchSizeConfigKey, "250"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if got := s.GetImportBatchSize(ctx); got != 250 {
+		t.Errorf("batch size = %d, want 250", got)
+	}
+
+	for _, value := range []string{"0", "-5", "lots"} {
+		if _, err := ParseImportBatchSize(value); err == nil {
+			t.Errorf("ParseImportBatchSize(%q) should fail", value)
+		}
+	}
+}
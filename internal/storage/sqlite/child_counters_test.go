@@ -231,3 +231,116 @@ func TestGetNextChildNumber_NestedHierarchy(t *testing.T) {
 		}
 	}
 }
+
+func TestDeleteChildReclaimsCounter(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	parentID := "bd-af78e9a2"
+
+	parent := &types.Issue{ID: parentID, Title: "Parent epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic}
+	if err := store.CreateIssue(ctx, parent, "test-user"); err != nil {
+		t.Fatalf("failed to create parent issue: %v", err)
+	}
+	createChild := func() string {
+		t.Helper()
+		childID, err := store.GetNextChildID(ctx, parentID)
+		if err != nil {
+			t.Fatalf("GetNextChildID failed: %v", err)
+		}
+		child := &types.Issue{ID: childID, Title: "Child " + childID, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, child, "test-user"); err != nil {
+			t.Fatalf("failed to create child %s: %v", childID, err)
+		}
+		return childID
+	}
+	for i := 0; i < 5; i++ {
+		createChild()
+	}
+
+	// Deleting a middle child leaves a gap rather than renumbering
+	if err := store.DeleteIssue(ctx, parentID+".3"); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	// Deleting the newest child frees its number
+	if err := store.DeleteIssue(ctx, parentID+".5"); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	if got := createChild(); got != parentID+".5" {
+		t.Errorf("expected next child to reuse .5, got %s", got)
+	}
+
+	// Batch deletes reclaim too
+	if _, err := store.DeleteIssues(ctx, []string{parentID + ".4", parentID + ".5"}, false, true, false); err != nil {
+		t.Fatalf("DeleteIssues failed: %v", err)
+	}
+	if got := createChild(); got != parentID+".3" {
+		t.Errorf("expected next child after batch delete to be .3, got %s", got)
+	}
+
+	// A number allocated but not yet created is never handed out again
+	inFlight, err := store.getNextChildNumber(ctx, parentID)
+	if err != nil {
+		t.Fatalf("getNextChildNumber failed: %v", err)
+	}
+	if err := store.DeleteIssue(ctx, parentID+".3"); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	next, err := store.getNextChildNumber(ctx, parentID)
+	if err != nil {
+		t.Fatalf("getNextChildNumber failed: %v", err)
+	}
+	if next != inFlight+1 {
+		t.Errorf("expected %d after in-flight allocation %d, got %d", inFlight+1, inFlight, next)
+	}
+}
+
+func TestResetChildCounter(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	parentID := "bd-af78e9a2"
+
+	parent := &types.Issue{ID: parentID, Title: "Parent epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic}
+	if err := store.CreateIssue(ctx, parent, "test-user"); err != nil {
+		t.Fatalf("failed to create parent issue: %v", err)
+	}
+	for _, id := range []string{parentID + ".2", parentID + ".2.1"} {
+		child := &types.Issue{ID: id, Title: "Child " + id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, child, "test-user"); err != nil {
+			t.Fatalf("failed to create child %s: %v", id, err)
+		}
+	}
+	for i := 0; i < 7; i++ {
+		if _, err := store.getNextChildNumber(ctx, parentID); err != nil {
+			t.Fatalf("getNextChildNumber failed: %v", err)
+		}
+	}
+
+	// Grandchildren don't count as direct children
+	if err := store.ResetChildCounter(ctx, parentID); err != nil {
+		t.Fatalf("ResetChildCounter failed: %v", err)
+	}
+	next, err := store.getNextChildNumber(ctx, parentID)
+	if err != nil {
+		t.Fatalf("getNextChildNumber failed: %v", err)
+	}
+	if next != 3 {
+		t.Errorf("expected next child number 3 after reset, got %d", next)
+	}
+
+	if err := store.DeleteIssue(ctx, parentID+".2.1"); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	if err := store.DeleteIssue(ctx, parentID+".2"); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	if err := store.ResetChildCounter(ctx, parentID); err != nil {
+		t.Fatalf("ResetChildCounter failed: %v", err)
+	}
+	if next, _ := store.getNextChildNumber(ctx, parentID); next != 1 {
+		t.Errorf("expected numbering to restart at 1 with no children, got %d", next)
+	}
+}
@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -203,3 +204,57 @@ func TestCreateIssue_HierarchicalID_ParentNotExists(t *testing.T) {
 		t.Errorf("unexpected error message: got %q, want %q", err.Error(), expectedErr)
 	}
 }
+
+func TestCreateChildIssue(t *testing.T) {
+	tmpFile := t.TempDir() + "/test.db"
+	defer os.Remove(tmpFile)
+	store := newTestStore(t, tmpFile)
+	defer store.Close()
+	ctx := context.Background()
+
+	parent := &types.Issue{ID: "bd-a3f8e9", Title: "Parent Epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic}
+	if err := store.CreateIssue(ctx, parent, "test"); err != nil {
+		t.Fatalf("failed to create parent: %v", err)
+	}
+	// A child created with an explicit ID takes .1 without advancing the counter
+	explicit := &types.Issue{ID: "bd-a3f8e9.1", Title: "Explicit", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, explicit, "test"); err != nil {
+		t.Fatalf("failed to create explicit child: %v", err)
+	}
+
+	child := &types.Issue{Title: "Child", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateChildIssue(ctx, child, parent.ID, "test"); err != nil {
+		t.Fatalf("CreateChildIssue failed: %v", err)
+	}
+	if child.ID != "bd-a3f8e9.2" {
+		t.Errorf("expected bd-a3f8e9.2 (skipping the taken .1), got %s", child.ID)
+	}
+
+	deps, err := store.GetDependencyRecords(ctx, child.ID)
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != parent.ID || deps[0].Type != types.DepParentChild {
+		t.Errorf("expected a parent-child dependency on %s, got %+v", parent.ID, deps)
+	}
+
+	events, err := store.GetEvents(ctx, child.ID, 10)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	var recorded bool
+	for _, event := range events {
+		if event.EventType == types.EventCreated && event.NewValue != nil {
+			recorded = strings.Contains(*event.NewValue, parent.ID)
+		}
+	}
+	if !recorded {
+		t.Errorf("expected the created event to record parent %s, got %+v", parent.ID, events)
+	}
+
+	// A missing parent fails without creating anything
+	orphan := &types.Issue{Title: "Orphan", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateChildIssue(ctx, orphan, "bd-nonexistent", "test"); err == nil {
+		t.Errorf("expected error for a missing parent, got nil")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -172,5 +174,28 @@ func hashIssueContent(issue *types.Issue) string {
 	if issue.ExternalRef != nil {
 		_, _ = fmt.Fprintf(h, "external_ref:%s\n", *issue.ExternalRef)
 	}
+	if issue.Resolution != "" {
+		_, _ = fmt.Fprintf(h, "resolution:%s\n", issue.Resolution)
+	}
+	if issue.ResolvedBy != "" {
+		_, _ = fmt.Fprintf(h, "resolved_by:%s\n", issue.ResolvedBy)
+	}
+	if issue.SpentMinutes != nil {
+		_, _ = fmt.Fprintf(h, "spent_minutes:%d\n", *issue.SpentMinutes)
+	}
+	if issue.ArchivedAt != nil {
+		_, _ = fmt.Fprintf(h, "archived_at:%s\n", issue.ArchivedAt.UTC().Format(time.RFC3339))
+	}
+	keys := make([]string, 0, len(issue.Metadata))
+	for key := range issue.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(h, "metadata:%s=%s\n", key, issue.Metadata[key])
+	}
+	for _, ref := range issue.Attachments {
+		_, _ = fmt.Fprintf(h, "attachment:%s\n", ref)
+	}
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
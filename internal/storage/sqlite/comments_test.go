@@ -366,3 +366,219 @@ func TestGetIssueCommentsMultipleIssues(t *testing.T) {
 		}
 	}
 }
+
+func TestCommentReplyAndResolve(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Test issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	other := &types.Issue{
+		Title:     "Other issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.CreateIssue(ctx, other, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	root, err := store.AddIssueComment(ctx, issue.ID, "alice", "Should this retry?")
+	if err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	if root.ParentCommentID != nil || root.Resolved {
+		t.Errorf("Expected new comment to be an unresolved root, got %+v", root)
+	}
+
+	reply, err := store.AddCommentReply(ctx, issue.ID, root.ID, "bob", "Yes, three times")
+	if err != nil {
+		t.Fatalf("AddCommentReply failed: %v", err)
+	}
+	if reply.ParentCommentID == nil || *reply.ParentCommentID != root.ID {
+		t.Errorf("Expected reply parent %d, got %v", root.ID, reply.ParentCommentID)
+	}
+
+	// Replies must stay on the parent's issue
+	if _, err := store.AddCommentReply(ctx, other.ID, root.ID, "bob", "Wrong issue"); err == nil {
+		t.Error("Expected error replying to a comment on another issue")
+	}
+	if _, err := store.AddCommentReply(ctx, issue.ID, 9999, "bob", "No parent"); err == nil {
+		t.Error("Expected error replying to a nonexistent comment")
+	}
+
+	// Resolving a reply resolves the thread root
+	resolved, err := store.ResolveComment(ctx, reply.ID, true)
+	if err != nil {
+		t.Fatalf("ResolveComment failed: %v", err)
+	}
+	if resolved.ID != root.ID || !resolved.Resolved {
+		t.Errorf("Expected resolved root %d, got %+v", root.ID, resolved)
+	}
+
+	comments, err := store.GetIssueComments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("Expected 2 comments, got %d", len(comments))
+	}
+	if !comments[0].Resolved || comments[1].Resolved {
+		t.Errorf("Expected only the root to be resolved, got %v and %v", comments[0].Resolved, comments[1].Resolved)
+	}
+	if comments[1].ParentCommentID == nil || *comments[1].ParentCommentID != root.ID {
+		t.Errorf("Expected threading to round-trip, got parent %v", comments[1].ParentCommentID)
+	}
+
+	if _, err := store.ResolveComment(ctx, root.ID, false); err != nil {
+		t.Fatalf("ResolveComment(false) failed: %v", err)
+	}
+	comments, _ = store.GetIssueComments(ctx, issue.ID)
+	if comments[0].Resolved {
+		t.Error("Expected thread to be unresolved")
+	}
+}
+
+// TestUpdateComment tests replacing a comment's text
+func TestUpdateComment(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Test issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	comment, err := store.AddIssueComment(ctx, issue.ID, "alice", "original")
+	if err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	if err := store.ClearDirtyIssuesByID(ctx, []string{issue.ID}); err != nil {
+		t.Fatalf("ClearDirtyIssuesByID failed: %v", err)
+	}
+
+	updated, err := store.UpdateComment(ctx, comment.ID, "edited")
+	if err != nil {
+		t.Fatalf("UpdateComment failed: %v", err)
+	}
+	if updated.Text != "edited" || updated.Author != "alice" {
+		t.Errorf("Expected edited comment by alice, got %+v", updated)
+	}
+
+	comments, err := store.GetIssueComments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Text != "edited" {
+		t.Errorf("Expected stored text to be edited, got %+v", comments)
+	}
+
+	var dirty bool
+	err = store.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM dirty_issues WHERE issue_id = ?)`, issue.ID).Scan(&dirty)
+	if err != nil {
+		t.Fatalf("Failed to check dirty flag: %v", err)
+	}
+	if !dirty {
+		t.Error("Expected issue to be marked dirty after editing comment")
+	}
+
+	if _, err := store.UpdateComment(ctx, 9999, "nope"); err == nil {
+		t.Error("Expected error updating nonexistent comment")
+	}
+}
+
+// TestEditAndDeleteComment tests that edits and deletions keep the history
+func TestEditAndDeleteComment(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Test issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	comment, err := store.AddIssueComment(ctx, issue.ID, "alice", "Fixed in abc123")
+	if err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+
+	for _, text := range []string{"Fixed in abc1234", "Fixed in abc12345"} {
+		if _, err := store.EditComment(ctx, comment.ID, text, "alice"); err != nil {
+			t.Fatalf("EditComment failed: %v", err)
+		}
+	}
+	comments, err := store.GetIssueComments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	edited := comments[0]
+	if edited.Text != "Fixed in abc12345" || edited.OriginalText != "Fixed in abc123" || edited.EditedAt == nil {
+		t.Errorf("Expected the latest text, the original kept and an edit time, got %+v", edited)
+	}
+
+	deleted, err := store.DeleteComment(ctx, comment.ID, "bob")
+	if err != nil {
+		t.Fatalf("DeleteComment failed: %v", err)
+	}
+	if !deleted.IsDeleted() {
+		t.Error("Expected the returned comment to be deleted")
+	}
+	comments, _ = store.GetIssueComments(ctx, issue.ID)
+	if len(comments) != 1 || comments[0].DeletedAt == nil || comments[0].Text != "Fixed in abc12345" {
+		t.Errorf("Expected the comment to stay as a tombstone, got %+v", comments)
+	}
+
+	if _, err := store.EditComment(ctx, comment.ID, "again", "alice"); err == nil {
+		t.Error("Expected error editing a deleted comment")
+	}
+	if _, err := store.DeleteComment(ctx, comment.ID, "bob"); err == nil {
+		t.Error("Expected error deleting a comment twice")
+	}
+	if _, err := store.DeleteComment(ctx, 9999, "bob"); err == nil {
+		t.Error("Expected error deleting nonexistent comment")
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	var edits, deletes int
+	for _, event := range events {
+		switch event.EventType {
+		case types.EventCommentEdited:
+			edits++
+			if event.OldValue == nil || event.NewValue == nil {
+				t.Errorf("Expected edit event to hold both texts, got %+v", event)
+			}
+		case types.EventCommentDeleted:
+			deletes++
+			if event.Actor != "bob" || event.OldValue == nil || *event.OldValue != "Fixed in abc12345" {
+				t.Errorf("Expected bob's delete event to hold the deleted text, got %+v", event)
+			}
+		}
+	}
+	if edits != 2 || deletes != 1 {
+		t.Errorf("Expected 2 edit events and 1 delete event, got %d and %d", edits, deletes)
+	}
+}
@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+// Config keys tuning SQLite connections. They're read from config.yaml or
+// the matching BD_SQLITE_* environment variables when a database is opened.
+const (
+	ConfigBusyTimeout  = "sqlite.busy-timeout"   // How long a write waits for the lock, e.g. "10s"
+	ConfigSynchronous  = "sqlite.synchronous"    // OFF, NORMAL, FULL or EXTRA
+	ConfigMaxOpenConns = "sqlite.max-open-conns" // Connection pool size; 0 means unlimited
+)
+
+// Connection defaults, used when the config keys aren't set. NORMAL is
+// durable in WAL mode except for the last transactions before a power loss.
+const (
+	DefaultBusyTimeout  = 5 * time.Second
+	DefaultSynchronous  = "NORMAL"
+	DefaultMaxOpenConns = 8
+)
+
+// connectionSettings holds the resolved connection tuning
+type connectionSettings struct {
+	busyTimeout  time.Duration
+	synchronous  string
+	maxOpenConns int
+}
+
+// loadConnectionSettings reads connection tuning from config, falling back
+// to the defaults for unset keys
+func loadConnectionSettings() (connectionSettings, error) {
+	settings := connectionSettings{
+		busyTimeout:  DefaultBusyTimeout,
+		synchronous:  DefaultSynchronous,
+		maxOpenConns: DefaultMaxOpenConns,
+	}
+	if raw := config.GetString(ConfigBusyTimeout); raw != "" {
+		timeout, err := time.ParseDuration(raw)
+		if err != nil || timeout < 0 {
+			return settings, fmt.Errorf("invalid %s %q: must be a duration like 5s", ConfigBusyTimeout, raw)
+		}
+		settings.busyTimeout = timeout
+	}
+	if raw := config.GetString(ConfigSynchronous); raw != "" {
+		mode := strings.ToUpper(raw)
+		switch mode {
+		case "OFF", "NORMAL", "FULL", "EXTRA":
+			settings.synchronous = mode
+		default:
+			return settings, fmt.Errorf("invalid %s %q: must be OFF, NORMAL, FULL or EXTRA", ConfigSynchronous, raw)
+		}
+	}
+	if config.GetString(ConfigMaxOpenConns) != "" {
+		conns := config.GetInt(ConfigMaxOpenConns)
+		if conns < 0 {
+			return settings, fmt.Errorf("invalid %s %d: must not be negative", ConfigMaxOpenConns, conns)
+		}
+		settings.maxOpenConns = conns
+	}
+	return settings, nil
+}
+
+// pragmas returns the connection string parameters applying settings to
+// every connection in the pool
+func (c connectionSettings) pragmas() string {
+	return fmt.Sprintf("_pragma=foreign_keys(ON)&_pragma=busy_timeout(%d)&_pragma=synchronous(%s)&_time_format=sqlite",
+		c.busyTimeout.Milliseconds(), c.synchronous)
+}
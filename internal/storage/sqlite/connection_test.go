@@ -0,0 +1,135 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestConnectionSettings(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		store, cleanup := setupTestDB(t)
+		defer cleanup()
+
+		var journalMode string
+		var synchronous, busyTimeout int
+		if err := store.db.QueryRow("PRAGMA journal_mode").Scan(&journalMode); err != nil {
+			t.Fatalf("failed to read journal_mode: %v", err)
+		}
+		if err := store.db.QueryRow("PRAGMA synchronous").Scan(&synchronous); err != nil {
+			t.Fatalf("failed to read synchronous: %v", err)
+		}
+		if err := store.db.QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout); err != nil {
+			t.Fatalf("failed to read busy_timeout: %v", err)
+		}
+		// synchronous=NORMAL reads back as 1
+		if journalMode != "wal" || synchronous != 1 || busyTimeout != int(DefaultBusyTimeout.Milliseconds()) {
+			t.Errorf("got journal_mode=%s synchronous=%d busy_timeout=%d, want wal, 1, %d",
+				journalMode, synchronous, busyTimeout, DefaultBusyTimeout.Milliseconds())
+		}
+		if got := store.db.Stats().MaxOpenConnections; got != DefaultMaxOpenConns {
+			t.Errorf("MaxOpenConnections = %d, want %d", got, DefaultMaxOpenConns)
+		}
+	})
+
+	t.Run("from environment", func(t *testing.T) {
+		t.Setenv("BD_SQLITE_BUSY_TIMEOUT", "2s")
+		t.Setenv("BD_SQLITE_SYNCHRONOUS", "full")
+		t.Setenv("BD_SQLITE_MAX_OPEN_CONNS", "3")
+		if err := config.Initialize(); err != nil {
+			t.Fatalf("failed to initialize config: %v", err)
+		}
+		defer func() { _ = config.Initialize() }()
+
+		settings, err := loadConnectionSettings()
+		if err != nil {
+			t.Fatalf("loadConnectionSettings failed: %v", err)
+		}
+		if settings.busyTimeout != 2*time.Second || settings.synchronous != "FULL" || settings.maxOpenConns != 3 {
+			t.Errorf("got %+v, want 2s, FULL, 3", settings)
+		}
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		for key, value := range map[string]string{
+			"BD_SQLITE_BUSY_TIMEOUT":   "soon",
+			"BD_SQLITE_SYNCHRONOUS":    "SOMETIMES",
+			"BD_SQLITE_MAX_OPEN_CONNS": "-1",
+		} {
+			t.Setenv(key, value)
+			if err := config.Initialize(); err != nil {
+				t.Fatalf("failed to initialize config: %v", err)
+			}
+			if _, err := loadConnectionSettings(); err == nil {
+				t.Errorf("%s=%s: expected an error", key, value)
+			}
+			t.Setenv(key, "")
+		}
+		_ = config.Initialize()
+	})
+}
+
+// TestConcurrentWritersAcrossConnections mimics the daemon and a direct-mode
+// CLI writing to the same database through separate connection pools
+func TestConcurrentWritersAcrossConnections(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "shared.db")
+	ctx := context.Background()
+
+	first, err := New(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open first store: %v", err)
+	}
+	defer first.Close()
+	if err := first.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("failed to set prefix: %v", err)
+	}
+	second, err := New(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open second store: %v", err)
+	}
+	defer second.Close()
+
+	const perWriter = 25
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*perWriter)
+	for w, store := range []*SQLiteStorage{first, second} {
+		wg.Add(1)
+		go func(w int, store *SQLiteStorage) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				issue := &types.Issue{
+					Title:     fmt.Sprintf("writer %d issue %d", w, i),
+					Status:    types.StatusOpen,
+					Priority:  2,
+					IssueType: types.TypeTask,
+				}
+				if err := store.CreateIssue(ctx, issue, fmt.Sprintf("writer-%d", w)); err != nil {
+					errs <- err
+					continue
+				}
+				if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"priority": 1}, fmt.Sprintf("writer-%d", w)); err != nil {
+					errs <- err
+				}
+			}
+		}(w, store)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("concurrent write failed: %v", err)
+	}
+
+	issues, err := second.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 2*perWriter {
+		t.Errorf("got %d issues, want %d", len(issues), 2*perWriter)
+	}
+}
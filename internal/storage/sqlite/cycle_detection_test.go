@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -504,3 +505,66 @@ func TestDetectCyclesMixedTypes(t *testing.T) {
 		t.Errorf("Expected cycle of length 3, got %d", len(cycle))
 	}
 }
+
+// TestDetectCycle tests the single-edge check used by AddDependency
+func TestDetectCycle(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// epic ← task (parent-child), task ← a ← b (blocks); c is unrelated
+	epic := &types.Issue{Title: "Epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic}
+	task := &types.Issue{Title: "Task", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	a := &types.Issue{Title: "A", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	b := &types.Issue{Title: "B", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	c := &types.Issue{Title: "C", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{epic, task, a, b, c} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	deps := []*types.Dependency{
+		{IssueID: task.ID, DependsOnID: epic.ID, Type: types.DepParentChild},
+		{IssueID: a.ID, DependsOnID: task.ID, Type: types.DepBlocks},
+		{IssueID: b.ID, DependsOnID: a.ID, Type: types.DepBlocks},
+	}
+	for _, dep := range deps {
+		if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     []string
+	}{
+		{"self-loop", a.ID, a.ID, []string{a.ID, a.ID}},
+		{"two-hop", task.ID, a.ID, []string{task.ID, a.ID, task.ID}},
+		{"multi-hop through parent-child", epic.ID, b.ID, []string{epic.ID, b.ID, a.ID, task.ID, epic.ID}},
+		{"same direction", b.ID, epic.ID, nil},
+		{"unrelated", c.ID, b.ID, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.DetectCycle(ctx, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("DetectCycle failed: %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("DetectCycle(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+
+	// AddDependency rejects the edge and names the cycle
+	err := store.AddDependency(ctx, &types.Dependency{IssueID: epic.ID, DependsOnID: b.ID, Type: types.DepRelated}, "test-user")
+	if err == nil {
+		t.Fatal("Expected AddDependency to reject a cycle")
+	}
+	wantPath := strings.Join([]string{epic.ID, b.ID, a.ID, task.ID, epic.ID}, " → ")
+	if !strings.Contains(err.Error(), wantPath) {
+		t.Errorf("Expected error to list cycle %q, got %v", wantPath, err)
+	}
+}
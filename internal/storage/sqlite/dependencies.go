@@ -19,13 +19,20 @@ const (
 
 // AddDependency adds a dependency between issues with cycle prevention
 func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return addDependencyIn(ctx, tx, dep, actor)
+	})
+}
+
+// addDependencyIn validates and adds a dependency through tx
+func addDependencyIn(ctx context.Context, tx dbExecutor, dep *types.Dependency, actor string) error {
 	// Validate dependency type
 	if !dep.Type.IsValid() {
 		return fmt.Errorf("invalid dependency type: %s (must be blocks, related, parent-child, or discovered-from)", dep.Type)
 	}
 
 	// Validate that both issues exist
-	issueExists, err := s.GetIssue(ctx, dep.IssueID)
+	issueExists, err := getIssue(ctx, tx, dep.IssueID)
 	if err != nil {
 		return fmt.Errorf("failed to check issue %s: %w", dep.IssueID, err)
 	}
@@ -33,7 +40,7 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 		return fmt.Errorf("issue %s not found", dep.IssueID)
 	}
 
-	dependsOnExists, err := s.GetIssue(ctx, dep.DependsOnID)
+	dependsOnExists, err := getIssue(ctx, tx, dep.DependsOnID)
 	if err != nil {
 		return fmt.Errorf("failed to check dependency %s: %w", dep.DependsOnID, err)
 	}
@@ -68,8 +75,7 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 		dep.CreatedBy = actor
 	}
 
-	return s.withTx(ctx, func(tx *sql.Tx) error {
-		// Cycle Detection and Prevention
+	// Cycle Detection and Prevention
 	//
 	// We prevent cycles across ALL dependency types (blocks, related, parent-child, discovered-from)
 	// to maintain a directed acyclic graph (DAG). This is critical for:
@@ -90,39 +96,12 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 	//
 	// The traversal is depth-limited to maxDependencyDepth (100) to prevent infinite loops
 	// and excessive query cost. We check before inserting to avoid unnecessary write on failure.
-	var cycleExists bool
-	err = tx.QueryRowContext(ctx, `
-		WITH RECURSIVE paths AS (
-			SELECT
-				issue_id,
-				depends_on_id,
-				1 as depth
-			FROM dependencies
-			WHERE issue_id = ?
-
-			UNION ALL
-
-			SELECT
-				d.issue_id,
-				d.depends_on_id,
-				p.depth + 1
-			FROM dependencies d
-			JOIN paths p ON d.issue_id = p.depends_on_id
-			WHERE p.depth < ?
-		)
-		SELECT EXISTS(
-			SELECT 1 FROM paths
-			WHERE depends_on_id = ?
-		)
-	`, dep.DependsOnID, maxDependencyDepth, dep.IssueID).Scan(&cycleExists)
-
+	cycle, err := detectCycleIn(ctx, tx, dep.IssueID, dep.DependsOnID)
 	if err != nil {
 		return fmt.Errorf("failed to check for cycles: %w", err)
 	}
-
-	if cycleExists {
-		return fmt.Errorf("cannot add dependency: would create a cycle (%s → %s → ... → %s)",
-			dep.IssueID, dep.DependsOnID, dep.IssueID)
+	if cycle != nil {
+		return fmt.Errorf("cannot add dependency: would create a cycle (%s)", strings.Join(cycle, " → "))
 	}
 
 	// Insert dependency
@@ -144,60 +123,141 @@ func (s *SQLiteStorage) AddDependency(ctx context.Context, dep *types.Dependency
 		return fmt.Errorf("failed to record event: %w", err)
 	}
 
-		// Mark both issues as dirty for incremental export
-		// (dependencies are exported with each issue, so both need updating)
-		if err := markIssuesDirtyTx(ctx, tx, []string{dep.IssueID, dep.DependsOnID}); err != nil {
-			return err
-		}
+	// Mark both issues as dirty for incremental export
+	// (dependencies are exported with each issue, so both need updating)
+	if err := markIssuesDirtyTx(ctx, tx, []string{dep.IssueID, dep.DependsOnID}); err != nil {
+		return err
+	}
 
-		return nil
-	})
+	return nil
 }
 
-// RemoveDependency removes a dependency
-func (s *SQLiteStorage) RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error {
-	return s.withTx(ctx, func(tx *sql.Tx) error {
-		result, err := tx.ExecContext(ctx, `
-			DELETE FROM dependencies WHERE issue_id = ? AND depends_on_id = ?
-		`, issueID, dependsOnID)
+// PropagatePriority moves issueID's priority up to weight levels toward the
+// priority of blockerID, recording a PriorityChanged event. A negative weight
+// uses the priority_propagation config value. Returns nil if nothing changed,
+// including when either issue is closed.
+func (s *SQLiteStorage) PropagatePriority(ctx context.Context, issueID, blockerID string, weight int, actor string) (*types.PriorityChange, error) {
+	if weight < 0 {
+		mode, err := s.GetConfig(ctx, types.PriorityPropagationConfigKey)
 		if err != nil {
-			return fmt.Errorf("failed to remove dependency: %w", err)
+			return nil, fmt.Errorf("failed to read %s: %w", types.PriorityPropagationConfigKey, err)
 		}
-
-		// Check if dependency existed
-		rowsAffected, err := result.RowsAffected()
+		weight, err = types.PriorityPropagationWeight(mode)
 		if err != nil {
-			return fmt.Errorf("failed to check rows affected: %w", err)
+			return nil, err
 		}
-		if rowsAffected == 0 {
-			return fmt.Errorf("dependency from %s to %s does not exist", issueID, dependsOnID)
+	}
+	if weight == 0 {
+		return nil, nil
+	}
+
+	issue, err := s.GetIssue(ctx, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue %s: %w", issueID, err)
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue %s not found", issueID)
+	}
+	blocker, err := s.GetIssue(ctx, blockerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocker %s: %w", blockerID, err)
+	}
+	if blocker == nil {
+		return nil, fmt.Errorf("blocker %s not found", blockerID)
+	}
+	if issue.Status == types.StatusClosed || blocker.Status == types.StatusClosed {
+		return nil, nil
+	}
+
+	newPriority := types.PropagatedPriority(issue.Priority, blocker.Priority, weight)
+	if newPriority == issue.Priority {
+		return nil, nil
+	}
+
+	change := &types.PriorityChange{
+		IssueID:     issueID,
+		BlockerID:   blockerID,
+		OldPriority: issue.Priority,
+		NewPriority: newPriority,
+	}
+	updated := *issue
+	updated.Priority = newPriority
+
+	err = s.withTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE issues SET priority = ?, content_hash = ?, updated_at = ? WHERE id = ?
+		`, newPriority, updated.ComputeContentHash(), time.Now(), issueID)
+		if err != nil {
+			return fmt.Errorf("failed to update priority: %w", err)
 		}
 
 		_, err = tx.ExecContext(ctx, `
-			INSERT INTO events (issue_id, event_type, actor, comment)
-			VALUES (?, ?, ?, ?)
-		`, issueID, types.EventDependencyRemoved, actor,
-			fmt.Sprintf("Removed dependency on %s", dependsOnID))
+			INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, issueID, types.EventPriorityChanged, actor,
+			fmt.Sprintf("%d", change.OldPriority), fmt.Sprintf("%d", change.NewPriority),
+			fmt.Sprintf("Priority propagated from blocker %s (P%d)", blockerID, blocker.Priority))
 		if err != nil {
 			return fmt.Errorf("failed to record event: %w", err)
 		}
 
-		// Mark both issues as dirty for incremental export
-		if err := markIssuesDirtyTx(ctx, tx, []string{issueID, dependsOnID}); err != nil {
-			return err
-		}
+		return markIssuesDirtyTx(ctx, tx, []string{issueID})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return change, nil
+}
 
-		return nil
+// RemoveDependency removes a dependency
+func (s *SQLiteStorage) RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return removeDependencyIn(ctx, tx, issueID, dependsOnID, actor)
 	})
 }
 
+// removeDependencyIn removes a dependency through tx
+func removeDependencyIn(ctx context.Context, tx dbExecutor, issueID, dependsOnID string, actor string) error {
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM dependencies WHERE issue_id = ? AND depends_on_id = ?
+	`, issueID, dependsOnID)
+	if err != nil {
+		return fmt.Errorf("failed to remove dependency: %w", err)
+	}
+
+	// Check if dependency existed
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("dependency from %s to %s does not exist", issueID, dependsOnID)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, issueID, types.EventDependencyRemoved, actor,
+		fmt.Sprintf("Removed dependency on %s", dependsOnID))
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	// Mark both issues as dirty for incremental export
+	if err := markIssuesDirtyTx(ctx, tx, []string{issueID, dependsOnID}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // GetDependenciesWithMetadata returns issues that this issue depends on, including dependency type
 func (s *SQLiteStorage) GetDependenciesWithMetadata(ctx context.Context, issueID string) ([]*types.IssueWithDependencyMetadata, error) {
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo,
-		       d.type
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo, i.resolution,
+		       i.spent_minutes, i.metadata, i.archived_at, i.id_nonce, i.attachments, i.resolved_by, d.type
 		FROM issues i
 		JOIN dependencies d ON i.id = d.depends_on_id
 		WHERE d.issue_id = ?
@@ -216,8 +276,8 @@ func (s *SQLiteStorage) GetDependentsWithMetadata(ctx context.Context, issueID s
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo,
-		       d.type
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo, i.resolution,
+		       i.spent_minutes, i.metadata, i.archived_at, i.id_nonce, i.attachments, i.resolved_by, d.type
 		FROM issues i
 		JOIN dependencies d ON i.id = d.issue_id
 		WHERE d.depends_on_id = ?
@@ -337,10 +397,26 @@ func (s *SQLiteStorage) GetDependencyCounts(ctx context.Context, issueIDs []stri
 
 // GetDependencyRecords returns raw dependency records for an issue
 func (s *SQLiteStorage) GetDependencyRecords(ctx context.Context, issueID string) ([]*types.Dependency, error) {
-	rows, err := s.db.QueryContext(ctx, `
+	return getDependencyRecords(ctx, s.db, issueID)
+}
+
+// getDependencyRecords returns raw dependency records for an issue through q
+func getDependencyRecords(ctx context.Context, q dbExecutor, issueID string) ([]*types.Dependency, error) {
+	return queryDependencyRecords(ctx, q, "issue_id", issueID)
+}
+
+// getDependentRecords returns the raw records of dependencies on an issue through q
+func getDependentRecords(ctx context.Context, q dbExecutor, issueID string) ([]*types.Dependency, error) {
+	return queryDependencyRecords(ctx, q, "depends_on_id", issueID)
+}
+
+// queryDependencyRecords returns the dependency records whose column (issue_id
+// or depends_on_id) is issueID, oldest first
+func queryDependencyRecords(ctx context.Context, q dbExecutor, column, issueID string) ([]*types.Dependency, error) {
+	rows, err := q.QueryContext(ctx, `
 		SELECT issue_id, depends_on_id, type, created_at, created_by
 		FROM dependencies
-		WHERE issue_id = ?
+		WHERE `+column+` = ?
 		ORDER BY created_at ASC
 	`, issueID)
 	if err != nil {
@@ -400,6 +476,96 @@ func (s *SQLiteStorage) GetAllDependencyRecords(ctx context.Context) (map[string
 	return depsMap, nil
 }
 
+// danglingDependenciesQuery selects dependency records, parent-child edges
+// included, whose issue or depends_on issue no longer exists
+const danglingDependenciesQuery = `
+	SELECT d.issue_id, d.depends_on_id, d.type, d.created_at, d.created_by
+	FROM dependencies d
+	WHERE NOT EXISTS (SELECT 1 FROM issues WHERE id = d.issue_id)
+	   OR NOT EXISTS (SELECT 1 FROM issues WHERE id = d.depends_on_id)
+	ORDER BY d.issue_id, d.depends_on_id
+`
+
+// PruneDanglingDependencies returns the dependency records that reference a
+// missing issue on either end, sorted by issue and then depends_on ID.
+// Unless dryRun, they are deleted in one transaction and the issue left on
+// the other end of each is marked dirty, so the JSONL loses the edge too.
+func (s *SQLiteStorage) PruneDanglingDependencies(ctx context.Context, dryRun bool) ([]*types.Dependency, error) {
+	if dryRun {
+		return scanDanglingDependencies(ctx, s.db)
+	}
+
+	var dangling []*types.Dependency
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		dangling, err = scanDanglingDependencies(ctx, tx)
+		if err != nil {
+			return err
+		}
+		var dirtyIDs []string
+		for _, dep := range dangling {
+			if _, err := tx.ExecContext(ctx, `
+				DELETE FROM dependencies WHERE issue_id = ? AND depends_on_id = ?
+			`, dep.IssueID, dep.DependsOnID); err != nil {
+				return fmt.Errorf("failed to remove dependency %s -> %s: %w", dep.IssueID, dep.DependsOnID, err)
+			}
+			dirtyIDs = append(dirtyIDs, dep.IssueID, dep.DependsOnID)
+		}
+		// Only issues that exist can be marked dirty
+		existing, err := existingIssueIDs(ctx, tx, dirtyIDs)
+		if err != nil {
+			return err
+		}
+		return markIssuesDirtyTx(ctx, tx, existing)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dangling, nil
+}
+
+// scanDanglingDependencies runs danglingDependenciesQuery through q
+func scanDanglingDependencies(ctx context.Context, q dbExecutor) ([]*types.Dependency, error) {
+	rows, err := q.QueryContext(ctx, danglingDependenciesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find dangling dependencies: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var dangling []*types.Dependency
+	for rows.Next() {
+		var dep types.Dependency
+		if err := rows.Scan(&dep.IssueID, &dep.DependsOnID, &dep.Type, &dep.CreatedAt, &dep.CreatedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency: %w", err)
+		}
+		dangling = append(dangling, &dep)
+	}
+	return dangling, rows.Err()
+}
+
+// existingIssueIDs returns the IDs in ids that name an existing issue, once
+// each, in their first-seen order
+func existingIssueIDs(ctx context.Context, q dbExecutor, ids []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var existing []string
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		var found int
+		err := q.QueryRowContext(ctx, `SELECT 1 FROM issues WHERE id = ?`, id).Scan(&found)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to check issue %s: %w", id, err)
+		}
+		existing = append(existing, id)
+	}
+	return existing, nil
+}
+
 // GetDependencyTree returns the full dependency tree with optional deduplication
 // When showAllPaths is false (default), nodes appearing via multiple paths (diamond dependencies)
 // appear only once at their shallowest depth in the tree.
@@ -567,6 +733,65 @@ func (s *SQLiteStorage) GetDependencyTree(ctx context.Context, issueID string, m
 	return nodes, nil
 }
 
+// DetectCycle reports the cycle that adding "fromID depends on toID" would
+// create, as the issue IDs along it (starting and ending with fromID), or nil
+// if the new edge would keep the graph acyclic. All dependency types count.
+func (s *SQLiteStorage) DetectCycle(ctx context.Context, fromID, toID string) ([]string, error) {
+	return detectCycleIn(ctx, s.db, fromID, toID)
+}
+
+func detectCycleIn(ctx context.Context, q dbExecutor, fromID, toID string) ([]string, error) {
+	if fromID == toID {
+		return []string{fromID, toID}, nil
+	}
+	path, err := findDependencyPathIn(ctx, q, toID, fromID)
+	if err != nil || path == nil {
+		return nil, err
+	}
+	return append([]string{fromID}, path...), nil
+}
+
+// findDependencyPathIn returns the shortest chain of dependencies leading from
+// fromID to toID (fromID first, toID last), or nil if toID isn't reachable
+// within maxDependencyDepth hops. Paths never revisit an issue, so existing
+// cycles elsewhere in the graph can't make the traversal loop.
+func findDependencyPathIn(ctx context.Context, q dbExecutor, fromID, toID string) ([]string, error) {
+	var path string
+	err := q.QueryRowContext(ctx, `
+		WITH RECURSIVE paths AS (
+			SELECT
+				depends_on_id,
+				issue_id || '→' || depends_on_id as path,
+				1 as depth
+			FROM dependencies
+			WHERE issue_id = ?
+
+			UNION ALL
+
+			SELECT
+				d.depends_on_id,
+				p.path || '→' || d.depends_on_id,
+				p.depth + 1
+			FROM dependencies d
+			JOIN paths p ON d.issue_id = p.depends_on_id
+			WHERE p.depth < ?
+			  AND p.depends_on_id != ?
+			  AND ('→' || p.path || '→') NOT LIKE '%→' || d.depends_on_id || '→%'
+		)
+		SELECT path FROM paths
+		WHERE depends_on_id = ?
+		ORDER BY depth
+		LIMIT 1
+	`, fromID, maxDependencyDepth, toID, toID).Scan(&path)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(path, "→"), nil
+}
+
 // DetectCycles finds circular dependencies and returns the actual cycle paths
 func (s *SQLiteStorage) DetectCycles(ctx context.Context) ([][]*types.Issue, error) {
 	// Use recursive CTE to find cycles with full paths
@@ -647,6 +872,82 @@ func (s *SQLiteStorage) DetectCycles(ctx context.Context) ([][]*types.Issue, err
 	return cycles, nil
 }
 
+// scanIssueRow scans one row of the issue columns SearchIssues selects, in
+// order, followed by any extra columns into extra
+func scanIssueRow(rows *sql.Rows, extra ...interface{}) (*types.Issue, error) {
+	var issue types.Issue
+	var contentHash sql.NullString
+	var closedAt sql.NullTime
+	var estimatedMinutes sql.NullInt64
+	var assignee sql.NullString
+	var externalRef sql.NullString
+	var sourceRepo sql.NullString
+	var resolution sql.NullString
+	var spentMinutes sql.NullInt64
+	var metadata sql.NullString
+	var attachments sql.NullString
+	var resolvedBy sql.NullString
+	var archivedAt sql.NullTime
+	var idNonce sql.NullInt64
+
+	dest := []interface{}{
+		&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
+		&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
+		&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
+		&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef, &sourceRepo, &resolution,
+		&spentMinutes, &metadata, &archivedAt, &idNonce, &attachments, &resolvedBy,
+	}
+	err := rows.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan issue: %w", err)
+	}
+
+	if contentHash.Valid {
+		issue.ContentHash = contentHash.String
+	}
+	if closedAt.Valid {
+		issue.ClosedAt = &closedAt.Time
+	}
+	if estimatedMinutes.Valid {
+		mins := int(estimatedMinutes.Int64)
+		issue.EstimatedMinutes = &mins
+	}
+	if assignee.Valid {
+		issue.Assignee = assignee.String
+	}
+	if externalRef.Valid {
+		issue.ExternalRef = &externalRef.String
+	}
+	if sourceRepo.Valid {
+		issue.SourceRepo = sourceRepo.String
+	}
+	if resolution.Valid {
+		issue.Resolution = types.Resolution(resolution.String)
+	}
+	if spentMinutes.Valid {
+		mins := int(spentMinutes.Int64)
+		issue.SpentMinutes = &mins
+	}
+	if issue.Metadata, err = decodeIssueMetadata(metadata); err != nil {
+		return nil, fmt.Errorf("issue %s: %w", issue.ID, err)
+	}
+	if issue.Attachments, err = decodeIssueAttachments(attachments); err != nil {
+		return nil, fmt.Errorf("issue %s: %w", issue.ID, err)
+	}
+	if resolvedBy.Valid {
+		issue.ResolvedBy = resolvedBy.String
+	}
+	if archivedAt.Valid {
+		issue.ArchivedAt = &archivedAt.Time
+	}
+	if idNonce.Valid {
+		nonce := int(idNonce.Int64)
+		issue.IDNonce = &nonce
+	}
+
+	return &issue, nil
+}
+
 // Helper function to scan issues from rows
 func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*types.Issue, error) {
 	var issues []*types.Issue
@@ -654,45 +955,11 @@ func (s *SQLiteStorage) scanIssues(ctx context.Context, rows *sql.Rows) ([]*type
 	
 	// First pass: scan all issues
 	for rows.Next() {
-		var issue types.Issue
-		var contentHash sql.NullString
-		var closedAt sql.NullTime
-		var estimatedMinutes sql.NullInt64
-		var assignee sql.NullString
-		var externalRef sql.NullString
-		var sourceRepo sql.NullString
-
-		err := rows.Scan(
-			&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
-			&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
-			&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
-			&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef, &sourceRepo,
-		)
+		issue, err := scanIssueRow(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan issue: %w", err)
-		}
-
-		if contentHash.Valid {
-			issue.ContentHash = contentHash.String
-		}
-		if closedAt.Valid {
-			issue.ClosedAt = &closedAt.Time
-		}
-		if estimatedMinutes.Valid {
-			mins := int(estimatedMinutes.Int64)
-			issue.EstimatedMinutes = &mins
-		}
-		if assignee.Valid {
-			issue.Assignee = assignee.String
-		}
-		if externalRef.Valid {
-			issue.ExternalRef = &externalRef.String
-		}
-		if sourceRepo.Valid {
-			issue.SourceRepo = sourceRepo.String
+			return nil, err
 		}
-
-		issues = append(issues, &issue)
+		issues = append(issues, issue)
 		issueIDs = append(issueIDs, issue.ID)
 	}
 
@@ -723,14 +990,21 @@ func (s *SQLiteStorage) scanIssuesWithDependencyType(ctx context.Context, rows *
 		var assignee sql.NullString
 		var externalRef sql.NullString
 		var sourceRepo sql.NullString
+		var resolution sql.NullString
+		var spentMinutes sql.NullInt64
+		var metadata sql.NullString
+		var attachments sql.NullString
+		var resolvedBy sql.NullString
+		var archivedAt sql.NullTime
+		var idNonce sql.NullInt64
 		var depType types.DependencyType
 
 		err := rows.Scan(
 			&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
 			&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
 			&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
-			&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef, &sourceRepo,
-			&depType,
+			&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef, &sourceRepo, &resolution,
+			&spentMinutes, &metadata, &archivedAt, &idNonce, &attachments, &resolvedBy, &depType,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan issue with dependency type: %w", err)
@@ -755,6 +1029,29 @@ func (s *SQLiteStorage) scanIssuesWithDependencyType(ctx context.Context, rows *
 		if sourceRepo.Valid {
 			issue.SourceRepo = sourceRepo.String
 		}
+		if resolution.Valid {
+			issue.Resolution = types.Resolution(resolution.String)
+		}
+		if spentMinutes.Valid {
+			mins := int(spentMinutes.Int64)
+			issue.SpentMinutes = &mins
+		}
+		if issue.Metadata, err = decodeIssueMetadata(metadata); err != nil {
+			return nil, fmt.Errorf("issue %s: %w", issue.ID, err)
+		}
+		if issue.Attachments, err = decodeIssueAttachments(attachments); err != nil {
+			return nil, fmt.Errorf("issue %s: %w", issue.ID, err)
+		}
+		if resolvedBy.Valid {
+			issue.ResolvedBy = resolvedBy.String
+		}
+		if archivedAt.Valid {
+			issue.ArchivedAt = &archivedAt.Time
+		}
+		if idNonce.Valid {
+			nonce := int(idNonce.Int64)
+			issue.IDNonce = &nonce
+		}
 
 		// Fetch labels for this issue
 		labels, err := s.GetLabels(ctx, issue.ID)
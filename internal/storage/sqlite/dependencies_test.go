@@ -3,6 +3,8 @@ package sqlite
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -1215,3 +1217,188 @@ func TestGetDependenciesWithMetadataMultipleTypes(t *testing.T) {
 		t.Errorf("Expected discovered dependency type 'discovered-from', got %s", typeMap[discovered.ID])
 	}
 }
+
+func TestPropagatePriority(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	blocker := &types.Issue{Title: "Urgent blocker", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeBug}
+	dependent := &types.Issue{Title: "Dependent", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{blocker, dependent} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	// Off by default
+	change, err := store.PropagatePriority(ctx, dependent.ID, blocker.ID, -1, "test-user")
+	if err != nil {
+		t.Fatalf("PropagatePriority failed: %v", err)
+	}
+	if change != nil {
+		t.Fatalf("Expected no change with propagation off, got %+v", change)
+	}
+
+	if err := store.SetConfig(ctx, types.PriorityPropagationConfigKey, types.PriorityPropagationBump); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	change, err = store.PropagatePriority(ctx, dependent.ID, blocker.ID, -1, "test-user")
+	if err != nil {
+		t.Fatalf("PropagatePriority failed: %v", err)
+	}
+	if change == nil || change.OldPriority != 3 || change.NewPriority != 2 {
+		t.Fatalf("Expected bump P3 → P2, got %+v", change)
+	}
+
+	// Explicit weight overrides the config
+	change, err = store.PropagatePriority(ctx, dependent.ID, blocker.ID, 4, "test-user")
+	if err != nil {
+		t.Fatalf("PropagatePriority failed: %v", err)
+	}
+	if change == nil || change.OldPriority != 2 || change.NewPriority != 0 {
+		t.Fatalf("Expected inherit P2 → P0, got %+v", change)
+	}
+
+	got, err := store.GetIssue(ctx, dependent.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Priority != 0 {
+		t.Errorf("Expected priority 0, got %d", got.Priority)
+	}
+	if got.ContentHash != got.ComputeContentHash() {
+		t.Error("Expected content hash to be recomputed")
+	}
+
+	events, err := store.GetEvents(ctx, dependent.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	priorityEvents := 0
+	for _, event := range events {
+		if event.EventType != types.EventPriorityChanged {
+			continue
+		}
+		priorityEvents++
+		if event.Comment == nil || !strings.Contains(*event.Comment, blocker.ID) {
+			t.Errorf("Expected event comment to mention blocker, got %v", event.Comment)
+		}
+	}
+	if priorityEvents != 2 {
+		t.Errorf("Expected 2 priority_changed events, got %d", priorityEvents)
+	}
+
+	// Already as urgent as the blocker: nothing to do
+	change, err = store.PropagatePriority(ctx, dependent.ID, blocker.ID, 4, "test-user")
+	if err != nil {
+		t.Fatalf("PropagatePriority failed: %v", err)
+	}
+	if change != nil {
+		t.Errorf("Expected no change at equal priority, got %+v", change)
+	}
+
+	if err := store.SetConfig(ctx, types.PriorityPropagationConfigKey, "sometimes"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if _, err := store.PropagatePriority(ctx, dependent.ID, blocker.ID, -1, "test-user"); err == nil {
+		t.Error("Expected error for invalid priority_propagation config")
+	}
+}
+
+func TestPruneDanglingDependencies(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue1 := &types.Issue{Title: "First", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	issue2 := &types.Issue{Title: "Second", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{issue1, issue2} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: issue2.ID, DependsOnID: issue1.ID, Type: types.DepBlocks}, "test-user"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	// Dangling edges can only be written with foreign keys off, as an
+	// import or merge might leave them
+	conn, err := store.UnderlyingConn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`); err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range [][3]string{
+		{issue2.ID, "bd-missing", "blocks"},
+		{issue1.ID, "bd-gone", "parent-child"},
+		{"bd-ghost", issue1.ID, "blocks"},
+	} {
+		if _, err := conn.ExecContext(ctx, `INSERT INTO dependencies (issue_id, depends_on_id, type, created_by) VALUES (?, ?, ?, 'test')`, row[0], row[1], row[2]); err != nil {
+			t.Fatalf("failed to insert dangling dependency: %v", err)
+		}
+	}
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = ON`); err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+	if err := store.ClearDirtyIssues(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"bd-ghost->" + issue1.ID, issue1.ID + "->bd-gone", issue2.ID + "->bd-missing"}
+	sort.Strings(want)
+	edges := func(deps []*types.Dependency) []string {
+		var result []string
+		for _, dep := range deps {
+			result = append(result, dep.IssueID+"->"+dep.DependsOnID)
+		}
+		return result
+	}
+
+	// A dry run only reports
+	dangling, err := store.PruneDanglingDependencies(ctx, true)
+	if err != nil {
+		t.Fatalf("PruneDanglingDependencies dry run failed: %v", err)
+	}
+	if got := edges(dangling); !reflect.DeepEqual(got, want) {
+		t.Errorf("dry run found %v, want %v", got, want)
+	}
+	if dangling, _ := store.PruneDanglingDependencies(ctx, true); len(dangling) != len(want) {
+		t.Errorf("dry run removed edges: %d left, want %d", len(dangling), len(want))
+	}
+
+	dangling, err = store.PruneDanglingDependencies(ctx, false)
+	if err != nil {
+		t.Fatalf("PruneDanglingDependencies failed: %v", err)
+	}
+	if got := edges(dangling); !reflect.DeepEqual(got, want) {
+		t.Errorf("pruned %v, want %v", got, want)
+	}
+	if dangling, _ := store.PruneDanglingDependencies(ctx, true); len(dangling) != 0 {
+		t.Errorf("%d dangling edges left after pruning", len(dangling))
+	}
+
+	// The valid edge survives, and the existing ends are dirty for export
+	records, err := store.GetDependencyRecords(ctx, issue2.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].DependsOnID != issue1.ID {
+		t.Errorf("issue2 dependencies after pruning = %v, want only %s", edges(records), issue1.ID)
+	}
+	dirty, err := store.GetDirtyIssues(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(dirty)
+	wantDirty := []string{issue1.ID, issue2.ID}
+	sort.Strings(wantDirty)
+	if !reflect.DeepEqual(dirty, wantDirty) {
+		t.Errorf("dirty issues after pruning = %v, want %v", dirty, wantDirty)
+	}
+}
@@ -141,7 +141,7 @@ func (s *SQLiteStorage) GetDirtyIssueCount(ctx context.Context) (int, error) {
 
 // markIssuesDirtyTx marks multiple issues as dirty within an existing transaction
 // This is a helper for operations that need to mark issues dirty as part of a larger transaction
-func markIssuesDirtyTx(ctx context.Context, tx *sql.Tx, issueIDs []string) error {
+func markIssuesDirtyTx(ctx context.Context, tx dbExecutor, issueIDs []string) error {
 	if len(issueIDs) == 0 {
 		return nil
 	}
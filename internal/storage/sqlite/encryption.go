@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+)
+
+// DBKeyEnvVar is the environment variable holding the database encryption key
+const DBKeyEnvVar = "BEADS_DB_KEY"
+
+// encryptedVFS is the go-sqlite3 VFS that encrypts database pages at rest.
+// SQLCipher itself needs cgo and a different driver, so encryption uses the
+// Adiantum VFS shipped with the driver we already embed. Files are not
+// compatible with SQLCipher tooling.
+const encryptedVFS = "adiantum"
+
+// sqliteHeader is the magic string at the start of every unencrypted SQLite file
+const sqliteHeader = "SQLite format 3\x00"
+
+// encryptionParams returns the connection URI parameters that open path with
+// the encrypting VFS, or "" when no key is configured. It fails rather than
+// letting SQLite report a corrupt file when the key and the file disagree.
+func encryptionParams(path string) (string, error) {
+	key := os.Getenv(DBKeyEnvVar)
+	encrypted, err := isEncryptedDatabase(path)
+	if err != nil {
+		return "", err
+	}
+
+	if key == "" {
+		if encrypted {
+			return "", fmt.Errorf("database %s is encrypted: set %s to open it", path, DBKeyEnvVar)
+		}
+		return "", nil
+	}
+	if !encryptionSupported {
+		return "", fmt.Errorf("%s is set but this bd was built without encryption support (rebuild with -tags encryption)", DBKeyEnvVar)
+	}
+	if hasPlainSQLiteHeader(path) {
+		return "", fmt.Errorf("%s is set but database %s is not encrypted (see docs/ENCRYPTION.md to convert it)", DBKeyEnvVar, path)
+	}
+
+	// Keep temp files in memory so they don't pay the encryption cost
+	return "&vfs=" + encryptedVFS + "&textkey=" + url.QueryEscape(key) + "&_pragma=temp_store(memory)", nil
+}
+
+// isEncryptedDatabase reports whether path holds a database without the
+// plaintext SQLite header. Missing and empty files are not encrypted.
+func isEncryptedDatabase(path string) (bool, error) {
+	header, err := readDBHeader(path)
+	if err != nil {
+		return false, err
+	}
+	return len(header) > 0 && string(header) != sqliteHeader, nil
+}
+
+// hasPlainSQLiteHeader reports whether path starts with the plaintext SQLite header
+func hasPlainSQLiteHeader(path string) bool {
+	header, err := readDBHeader(path)
+	return err == nil && string(header) == sqliteHeader
+}
+
+// readDBHeader returns up to the first 16 bytes of path, or nil if it doesn't exist
+func readDBHeader(path string) ([]byte, error) {
+	f, err := os.Open(path) // #nosec G304 - controlled path from config
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read database header: %w", err)
+	}
+	defer f.Close()
+
+	header := make([]byte, len(sqliteHeader))
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, fmt.Errorf("failed to read database header: %w", err)
+	}
+	return header[:n], nil
+}
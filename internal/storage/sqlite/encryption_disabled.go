@@ -0,0 +1,6 @@
+//go:build !encryption
+
+package sqlite
+
+// encryptionSupported reports whether this build can open encrypted databases
+const encryptionSupported = false
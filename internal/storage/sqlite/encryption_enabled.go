@@ -0,0 +1,11 @@
+//go:build encryption
+
+package sqlite
+
+import (
+	// Registers the "adiantum" encrypting VFS
+	_ "github.com/ncruces/go-sqlite3/vfs/adiantum"
+)
+
+// encryptionSupported reports whether this build can open encrypted databases
+const encryptionSupported = true
@@ -0,0 +1,62 @@
+//go:build encryption
+
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEncryptedDatabaseRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "beads.db")
+
+	t.Setenv(DBKeyEnvVar, "correct horse battery staple")
+	store, err := New(dbPath)
+	if err != nil {
+		t.Fatalf("New with a key failed: %v", err)
+	}
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	issue := &types.Issue{Title: "Secret", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	store.Close()
+
+	if hasPlainSQLiteHeader(dbPath) {
+		t.Fatal("expected the database file to be encrypted, but it has a plaintext SQLite header")
+	}
+
+	// The same key opens it again
+	store, err = New(dbPath)
+	if err != nil {
+		t.Fatalf("reopening with the same key failed: %v", err)
+	}
+	got, err := store.GetIssue(ctx, issue.ID)
+	store.Close()
+	if err != nil || got == nil || got.Title != "Secret" {
+		t.Fatalf("GetIssue after reopening = %+v, %v; want the issue back", got, err)
+	}
+
+	// A wrong key fails instead of reading garbage
+	t.Setenv(DBKeyEnvVar, "wrong key")
+	if store, err := New(dbPath); err == nil {
+		store.Close()
+		t.Fatal("expected error opening the database with the wrong key")
+	} else if !strings.Contains(err.Error(), DBKeyEnvVar) {
+		t.Errorf("expected the wrong-key error to mention %s, got: %v", DBKeyEnvVar, err)
+	}
+
+	// So does no key at all
+	t.Setenv(DBKeyEnvVar, "")
+	if store, err := New(dbPath); err == nil {
+		store.Close()
+		t.Fatal("expected error opening the encrypted database without a key")
+	}
+}
@@ -0,0 +1,90 @@
+package sqlite
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsEncryptedDatabase(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	plainPath := filepath.Join(tmpDir, "plain.db")
+	store, err := New(plainPath)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	store.Close()
+
+	encryptedPath := filepath.Join(tmpDir, "encrypted.db")
+	if err := os.WriteFile(encryptedPath, []byte("\x8f\x02random-ciphertext-bytes"), 0600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	emptyPath := filepath.Join(tmpDir, "empty.db")
+	if err := os.WriteFile(emptyPath, nil, 0600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{"plain database", plainPath, false},
+		{"encrypted database", encryptedPath, true},
+		{"empty file", emptyPath, false},
+		{"missing file", filepath.Join(tmpDir, "missing.db"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := isEncryptedDatabase(tt.path)
+			if err != nil {
+				t.Fatalf("isEncryptedDatabase failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("isEncryptedDatabase() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewEncryptedWithoutKey(t *testing.T) {
+	t.Setenv(DBKeyEnvVar, "")
+
+	dbPath := filepath.Join(t.TempDir(), "beads.db")
+	if err := os.WriteFile(dbPath, []byte("\x8f\x02random-ciphertext-bytes"), 0600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	_, err := New(dbPath)
+	if err == nil {
+		t.Fatal("expected error opening encrypted database without a key")
+	}
+	if !strings.Contains(err.Error(), DBKeyEnvVar) {
+		t.Errorf("expected error to mention %s, got: %v", DBKeyEnvVar, err)
+	}
+}
+
+func TestNewWithKeyOnPlainDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "beads.db")
+	store, err := New(dbPath)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	store.Close()
+
+	t.Setenv(DBKeyEnvVar, "correct horse battery staple")
+	_, err = New(dbPath)
+	if err == nil {
+		t.Fatal("expected error opening plaintext database with a key")
+	}
+	if encryptionSupported {
+		if !strings.Contains(err.Error(), "not encrypted") {
+			t.Errorf("expected 'not encrypted' error, got: %v", err)
+		}
+	} else if !strings.Contains(err.Error(), "without encryption support") {
+		t.Errorf("expected 'without encryption support' error, got: %v", err)
+	}
+}
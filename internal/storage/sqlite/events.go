@@ -11,38 +11,54 @@ import (
 
 const limitClause = " LIMIT ?"
 
+// eventColumns is the column list scanEvents expects
+const eventColumns = "id, issue_id, event_type, actor, old_value, new_value, comment, note, created_at"
+
 // AddComment adds a comment to an issue
 func (s *SQLiteStorage) AddComment(ctx context.Context, issueID, actor, comment string) error {
 	return s.withTx(ctx, func(tx *sql.Tx) error {
-		_, err := tx.ExecContext(ctx, `
-			INSERT INTO events (issue_id, event_type, actor, comment)
-			VALUES (?, ?, ?, ?)
-		`, issueID, types.EventCommented, actor, comment)
-		if err != nil {
-			return fmt.Errorf("failed to add comment: %w", err)
-		}
+		return addCommentIn(ctx, tx, issueID, actor, comment)
+	})
+}
 
-		// Update issue updated_at timestamp
-		now := time.Now()
-		_, err = tx.ExecContext(ctx, `
-			UPDATE issues SET updated_at = ? WHERE id = ?
-		`, now, issueID)
-		if err != nil {
-			return fmt.Errorf("failed to update timestamp: %w", err)
-		}
+// addCommentIn adds a comment through tx
+func addCommentIn(ctx context.Context, tx dbExecutor, issueID, actor, comment string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, issueID, types.EventCommented, actor, comment)
+	if err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
 
-		// Mark issue as dirty for incremental export
-		_, err = tx.ExecContext(ctx, `
-			INSERT INTO dirty_issues (issue_id, marked_at)
-			VALUES (?, ?)
-			ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
-		`, issueID, now)
-		if err != nil {
-			return fmt.Errorf("failed to mark issue dirty: %w", err)
-		}
+	// Update issue updated_at timestamp
+	now := time.Now()
+	_, err = tx.ExecContext(ctx, `
+		UPDATE issues SET updated_at = ? WHERE id = ?
+	`, now, issueID)
+	if err != nil {
+		return fmt.Errorf("failed to update timestamp: %w", err)
+	}
+
+	// Mark issue as dirty for incremental export
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO dirty_issues (issue_id, marked_at)
+		VALUES (?, ?)
+		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+	`, issueID, now)
+	if err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
 
+	return nil
+}
+
+// eventNote converts an optional event note to a nullable column value
+func eventNote(note string) interface{} {
+	if note == "" {
 		return nil
-	})
+	}
+	return note
 }
 
 // GetEvents returns the event history for an issue
@@ -56,10 +72,39 @@ func (s *SQLiteStorage) GetEvents(ctx context.Context, issueID string, limit int
 
 	// #nosec G201 - safe SQL with controlled formatting
 	query := fmt.Sprintf(`
-		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		SELECT `+eventColumns+`
 		FROM events
 		WHERE issue_id = ?
-		ORDER BY created_at DESC
+		ORDER BY created_at DESC, id DESC
+		%s
+	`, limitSQL)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanEvents(rows)
+}
+
+// GetEventsSince returns events of all issues with IDs above afterID, oldest
+// first, at most limit of them if limit > 0. Event IDs only grow, so callers can
+// follow new events by passing the last ID they saw.
+func (s *SQLiteStorage) GetEventsSince(ctx context.Context, afterID int64, limit int) ([]*types.Event, error) {
+	args := []interface{}{afterID}
+	limitSQL := ""
+	if limit > 0 {
+		limitSQL = limitClause
+		args = append(args, limit)
+	}
+
+	// #nosec G201 - safe SQL with controlled formatting
+	query := fmt.Sprintf(`
+		SELECT `+eventColumns+`
+		FROM events
+		WHERE id > ?
+		ORDER BY id ASC
 		%s
 	`, limitSQL)
 
@@ -69,14 +114,19 @@ func (s *SQLiteStorage) GetEvents(ctx context.Context, issueID string, limit int
 	}
 	defer func() { _ = rows.Close() }()
 
+	return scanEvents(rows)
+}
+
+// scanEvents reads event rows selected in eventColumns order
+func scanEvents(rows *sql.Rows) ([]*types.Event, error) {
 	var events []*types.Event
 	for rows.Next() {
 		var event types.Event
-		var oldValue, newValue, comment sql.NullString
+		var oldValue, newValue, comment, note sql.NullString
 
 		err := rows.Scan(
 			&event.ID, &event.IssueID, &event.EventType, &event.Actor,
-			&oldValue, &newValue, &comment, &event.CreatedAt,
+			&oldValue, &newValue, &comment, &note, &event.CreatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan event: %w", err)
@@ -91,13 +141,165 @@ func (s *SQLiteStorage) GetEvents(ctx context.Context, issueID string, limit int
 		if comment.Valid {
 			event.Comment = &comment.String
 		}
+		if note.Valid {
+			event.Note = &note.String
+		}
 
 		events = append(events, &event)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
 
 	return events, nil
 }
 
+// prunableEventsQuery selects the IDs of events created before the cutoff,
+// skipping each issue's keepPerIssue most recent events and its newest status
+// event (so the audit trail still explains the current status)
+const prunableEventsQuery = `
+	SELECT id FROM (
+		SELECT id, issue_id, event_type, created_at,
+			ROW_NUMBER() OVER (PARTITION BY issue_id ORDER BY created_at DESC, id DESC) AS recent_rank,
+			ROW_NUMBER() OVER (
+				PARTITION BY issue_id, event_type IN ('created', 'status_changed', 'closed', 'reopened')
+				ORDER BY created_at DESC, id DESC
+			) AS kind_rank
+		FROM events
+	)
+	WHERE datetime(created_at) < datetime(?)
+	  AND recent_rank > ?
+	  AND NOT (event_type IN ('created', 'status_changed', 'closed', 'reopened') AND kind_rank = 1)
+`
+
+// PruneEvents deletes events created before the cutoff, keeping each issue's
+// keepPerIssue most recent events and its newest status event. With dryRun
+// nothing is deleted. Returns the number of events removed (or that would be).
+func (s *SQLiteStorage) PruneEvents(ctx context.Context, before time.Time, keepPerIssue int, dryRun bool) (int, error) {
+	if keepPerIssue < 0 {
+		keepPerIssue = 0
+	}
+	cutoff := before.UTC().Format("2006-01-02 15:04:05")
+
+	if dryRun {
+		var count int
+		err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM (`+prunableEventsQuery+`)`, cutoff, keepPerIssue).Scan(&count)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count prunable events: %w", err)
+		}
+		return count, nil
+	}
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM events WHERE id IN (`+prunableEventsQuery+`)`, cutoff, keepPerIssue)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune events: %w", err)
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return int(removed), nil
+}
+
+// compactableEventsQuery selects the IDs of events created before the
+// cutoff except each issue's summary: its first created event and its
+// newest status event
+const compactableEventsQuery = `
+	SELECT id FROM (
+		SELECT id, event_type, created_at,
+			ROW_NUMBER() OVER (
+				PARTITION BY issue_id, event_type = 'created'
+				ORDER BY created_at, id
+			) AS first_rank,
+			ROW_NUMBER() OVER (
+				PARTITION BY issue_id, event_type IN ('created', 'status_changed', 'closed', 'reopened')
+				ORDER BY created_at DESC, id DESC
+			) AS status_rank
+		FROM events
+	)
+	WHERE datetime(created_at) < datetime(?)
+	  AND NOT (event_type = 'created' AND first_rank = 1)
+	  AND NOT (event_type IN ('created', 'status_changed', 'closed', 'reopened') AND status_rank = 1)
+`
+
+// CompactEvents collapses each issue's history before the cutoff to a
+// summary, its first created event and newest status event, deleting the
+// rest in one transaction. Events after the cutoff are kept. With dryRun
+// nothing is deleted. Returns the number of events removed (or that would
+// be). Call Vacuum afterwards to give the space back to the filesystem.
+func (s *SQLiteStorage) CompactEvents(ctx context.Context, before time.Time, dryRun bool) (int, error) {
+	cutoff := before.UTC().Format("2006-01-02 15:04:05")
+
+	if dryRun {
+		var count int
+		err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM (`+compactableEventsQuery+`)`, cutoff).Scan(&count)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count compactable events: %w", err)
+		}
+		return count, nil
+	}
+
+	var removed int64
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `DELETE FROM events WHERE id IN (`+compactableEventsQuery+`)`, cutoff)
+		if err != nil {
+			return fmt.Errorf("failed to compact events: %w", err)
+		}
+		removed, err = result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(removed), nil
+}
+
+// Vacuum rebuilds the database file to return the space freed by deletes,
+// then truncates the WAL. Like any write it waits out other connections'
+// locks for up to busy_timeout, so it's safe to run while a daemon has the
+// database open.
+func (s *SQLiteStorage) Vacuum(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, "VACUUM"); err != nil {
+		return fmt.Errorf("failed to vacuum database: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return fmt.Errorf("failed to checkpoint WAL: %w", err)
+	}
+	return nil
+}
+
+// GetIssueIDsChangedSince returns the IDs of issues updated, or with an event
+// recorded, after since. Events catch changes that don't touch updated_at,
+// such as labels and comments. IDs are sorted and only include issues that
+// still exist.
+func (s *SQLiteStorage) GetIssueIDsChangedSince(ctx context.Context, since time.Time) ([]string, error) {
+	cutoff := since.UTC().Format(time.RFC3339Nano)
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id FROM issues WHERE julianday(updated_at) > julianday(?)
+		UNION
+		SELECT e.issue_id FROM events e JOIN issues i ON i.id = e.issue_id
+		WHERE julianday(e.created_at) > julianday(?)
+		ORDER BY 1
+	`, cutoff, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan issue ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
 // GetStatistics returns aggregate statistics
 func (s *SQLiteStorage) GetStatistics(ctx context.Context) (*types.Statistics, error) {
 	var stats types.Statistics
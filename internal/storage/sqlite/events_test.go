@@ -2,8 +2,12 @@ package sqlite
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -191,6 +195,46 @@ func TestGetEventsEmpty(t *testing.T) {
 	}
 }
 
+func TestGetEventsSince(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		issue := &types.Issue{Title: fmt.Sprintf("Issue %d", i), Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, testUserAlice); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	if err := store.AddComment(ctx, ids[0], testUserAlice, "first"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	// Events of every issue, oldest first
+	events, err := store.GetEventsSince(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("GetEventsSince failed: %v", err)
+	}
+	if len(events) != 3 || events[0].IssueID != ids[0] || events[1].IssueID != ids[1] || events[2].EventType != types.EventCommented {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	// Following on from the last ID seen, with a limit
+	page, err := store.GetEventsSince(ctx, events[0].ID, 1)
+	if err != nil {
+		t.Fatalf("GetEventsSince failed: %v", err)
+	}
+	if len(page) != 1 || page[0].ID != events[1].ID {
+		t.Errorf("expected only event %d, got %+v", events[1].ID, page)
+	}
+	if rest, _ := store.GetEventsSince(ctx, events[2].ID, 0); len(rest) != 0 {
+		t.Errorf("expected no events after the last one, got %d", len(rest))
+	}
+}
+
 func TestAddCommentMarksDirty(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -305,7 +349,7 @@ func TestEventTypesInHistory(t *testing.T) {
 		t.Fatalf("AddLabel failed: %v", err)
 	}
 
-	err = store.CloseIssue(ctx, issue.ID, "Done", "test-user")
+	err = store.CloseIssue(ctx, issue.ID, storage.CloseOptions{Reason: "Done"}, "test-user")
 	if err != nil {
 		t.Fatalf("CloseIssue failed: %v", err)
 	}
@@ -339,3 +383,440 @@ func TestEventTypesInHistory(t *testing.T) {
 		t.Error("Expected EventClosed in history")
 	}
 }
+
+func TestStatusChangeNotes(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Test issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if err := store.CloseIssue(ctx, issue.ID, storage.CloseOptions{Reason: "Fixed", Note: "verified on staging"}, "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	if err := store.ReopenIssue(ctx, issue.ID, "regressed in prod", "test-user"); err != nil {
+		t.Fatalf("ReopenIssue failed: %v", err)
+	}
+
+	reopened, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if reopened.Status != types.StatusOpen || reopened.ClosedAt != nil {
+		t.Errorf("Expected open issue with no closed_at, got status=%s closed_at=%v", reopened.Status, reopened.ClosedAt)
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+
+	var closed, reopenedEvent *types.Event
+	for _, event := range events {
+		switch event.EventType {
+		case types.EventClosed:
+			closed = event
+		case types.EventReopened:
+			reopenedEvent = event
+		case types.EventCreated:
+			if event.Note != nil {
+				t.Errorf("Expected no note on created event, got %q", *event.Note)
+			}
+		}
+	}
+
+	if closed == nil {
+		t.Fatal("Closed event not found")
+	}
+	if closed.Comment == nil || *closed.Comment != "Fixed" {
+		t.Errorf("Expected close reason 'Fixed' as comment, got %v", closed.Comment)
+	}
+	if closed.Note == nil || *closed.Note != "verified on staging" {
+		t.Errorf("Expected close note 'verified on staging', got %v", closed.Note)
+	}
+
+	if reopenedEvent == nil {
+		t.Fatal("Reopened event not found")
+	}
+	if reopenedEvent.Note == nil || *reopenedEvent.Note != "regressed in prod" {
+		t.Errorf("Expected reopen note 'regressed in prod', got %v", reopenedEvent.Note)
+	}
+
+	// Notes are event metadata, not comments
+	comments, err := store.GetIssueComments(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssueComments failed: %v", err)
+	}
+	if len(comments) != 0 {
+		t.Errorf("Expected notes not to create comments, got %d", len(comments))
+	}
+}
+
+func TestReopenIssueAlwaysRecordsReopened(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Already open",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	// 'bd reopen --force' on an open issue still records a Reopened event
+	if err := store.ReopenIssue(ctx, issue.ID, "forced", "test-user"); err != nil {
+		t.Fatalf("ReopenIssue failed: %v", err)
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 1)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(events) != 1 || events[0].EventType != types.EventReopened {
+		t.Fatalf("Expected latest event to be reopened, got %+v", events)
+	}
+	if events[0].Note == nil || *events[0].Note != "forced" {
+		t.Errorf("Expected note 'forced', got %v", events[0].Note)
+	}
+
+	// Plain status updates still derive the event type
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	events, err = store.GetEvents(ctx, issue.ID, 1)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(events) != 1 || events[0].EventType != types.EventStatusChanged {
+		t.Errorf("Expected status_changed event, got %+v", events)
+	}
+}
+
+func TestPruneEvents(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Long history",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(types.StatusInProgress)}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := store.AddComment(ctx, issue.ID, "test-user", fmt.Sprintf("old comment %d", i)); err != nil {
+			t.Fatalf("AddComment failed: %v", err)
+		}
+	}
+
+	// Backdate everything so far, then add one recent event
+	if _, err := store.db.ExecContext(ctx, `UPDATE events SET created_at = '2020-01-01 00:00:00'`); err != nil {
+		t.Fatalf("failed to backdate events: %v", err)
+	}
+	if err := store.AddComment(ctx, issue.ID, "test-user", "recent comment"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -1)
+
+	// Dry run counts created + 3 old comments; the status change is the newest status event
+	removed, err := store.PruneEvents(ctx, cutoff, 0, true)
+	if err != nil {
+		t.Fatalf("PruneEvents dry run failed: %v", err)
+	}
+	if removed != 4 {
+		t.Errorf("Expected dry run to report 4 events, got %d", removed)
+	}
+	events, _ := store.GetEvents(ctx, issue.ID, 0)
+	if len(events) != 6 {
+		t.Fatalf("Expected dry run to keep all 6 events, got %d", len(events))
+	}
+
+	// --keep-per-issue 3 keeps the recent comment and the two newest old comments
+	removed, err = store.PruneEvents(ctx, cutoff, 3, false)
+	if err != nil {
+		t.Fatalf("PruneEvents failed: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 events removed with keepPerIssue=3, got %d", removed)
+	}
+
+	removed, err = store.PruneEvents(ctx, cutoff, 0, false)
+	if err != nil {
+		t.Fatalf("PruneEvents failed: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 more events removed, got %d", removed)
+	}
+
+	events, err = store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events left, got %d: %+v", len(events), events)
+	}
+	if events[0].EventType != types.EventCommented || events[1].EventType != types.EventStatusChanged {
+		t.Errorf("Expected recent comment and status change to survive, got %s and %s", events[0].EventType, events[1].EventType)
+	}
+}
+
+func TestCompactEvents(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Long history",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	for _, status := range []types.Status{types.StatusInProgress, types.StatusBlocked} {
+		if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(status)}, "test-user"); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if err := store.AddComment(ctx, issue.ID, "test-user", fmt.Sprintf("old comment %d", i)); err != nil {
+			t.Fatalf("AddComment failed: %v", err)
+		}
+	}
+
+	// Backdate everything so far, then add one recent event
+	if _, err := store.db.ExecContext(ctx, `UPDATE events SET created_at = '2020-01-01 00:00:00'`); err != nil {
+		t.Fatalf("failed to backdate events: %v", err)
+	}
+	if err := store.AddComment(ctx, issue.ID, "test-user", "recent comment"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -1)
+
+	// The first status change and the 3 old comments go; created and the
+	// newest status change are the summary
+	removed, err := store.CompactEvents(ctx, cutoff, true)
+	if err != nil {
+		t.Fatalf("CompactEvents dry run failed: %v", err)
+	}
+	if removed != 4 {
+		t.Errorf("Expected dry run to report 4 events, got %d", removed)
+	}
+	if events, _ := store.GetEvents(ctx, issue.ID, 0); len(events) != 7 {
+		t.Fatalf("Expected dry run to keep all 7 events, got %d", len(events))
+	}
+
+	removed, err = store.CompactEvents(ctx, cutoff, false)
+	if err != nil {
+		t.Fatalf("CompactEvents failed: %v", err)
+	}
+	if removed != 4 {
+		t.Errorf("Expected 4 events removed, got %d", removed)
+	}
+	if err := store.Vacuum(ctx); err != nil {
+		t.Fatalf("Vacuum failed: %v", err)
+	}
+
+	events, err := store.GetEvents(ctx, issue.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	var kinds []types.EventType
+	for _, event := range events {
+		kinds = append(kinds, event.EventType)
+	}
+	if fmt.Sprint(kinds) != "[commented status_changed created]" {
+		t.Errorf("Expected the recent comment, newest status change and created event to survive, got %v", kinds)
+	}
+	if !strings.Contains(*events[1].NewValue, string(types.StatusBlocked)) {
+		t.Errorf("Expected the newest status change kept, got %s", *events[1].NewValue)
+	}
+
+	// Nothing left to compact
+	if removed, err := store.CompactEvents(ctx, cutoff, false); err != nil || removed != 0 {
+		t.Errorf("Expected a second compaction to remove nothing, got %d (err %v)", removed, err)
+	}
+}
+
+func TestCloseIssueResolution(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Test issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if err := store.CloseIssue(ctx, issue.ID, storage.CloseOptions{Reason: "Dup", Resolution: types.Resolution("not-a-resolution")}, "test-user"); err == nil {
+		t.Error("Expected error for invalid resolution")
+	}
+	if err := store.CloseIssue(ctx, issue.ID, storage.CloseOptions{Reason: "Dup of bd-1", Resolution: types.ResolutionDuplicate}, "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+
+	closed, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if closed.Status != types.StatusClosed || closed.ClosedAt == nil || closed.Resolution != types.ResolutionDuplicate {
+		t.Errorf("Expected closed duplicate, got status=%s closed_at=%v resolution=%q", closed.Status, closed.ClosedAt, closed.Resolution)
+	}
+
+	// Export reads issues through SearchIssues
+	found, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(found) != 1 || found[0].Resolution != types.ResolutionDuplicate {
+		t.Errorf("Expected resolution in search results, got %+v", found)
+	}
+
+	if err := store.ReopenIssue(ctx, issue.ID, "", "test-user"); err != nil {
+		t.Fatalf("ReopenIssue failed: %v", err)
+	}
+	reopened, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if reopened.Resolution != "" {
+		t.Errorf("Expected reopen to clear resolution, got %q", reopened.Resolution)
+	}
+
+	// Imported issues keep their resolution
+	closedAt := time.Now()
+	imported := &types.Issue{
+		ID:         "bd-imported",
+		Title:      "Imported",
+		Status:     types.StatusClosed,
+		Priority:   2,
+		IssueType:  types.TypeBug,
+		ClosedAt:   &closedAt,
+		Resolution: types.ResolutionWontFix,
+	}
+	if err := store.CreateIssues(ctx, []*types.Issue{imported}, "import"); err != nil {
+		t.Fatalf("CreateIssues failed: %v", err)
+	}
+	got, err := store.GetIssue(ctx, imported.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Resolution != types.ResolutionWontFix {
+		t.Errorf("Expected wontfix, got %q", got.Resolution)
+	}
+}
+
+func TestCloseIssueCommit(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Test issue", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if err := store.CloseIssue(ctx, issue.ID, storage.CloseOptions{Reason: "Fixed", Resolution: types.ResolutionFixed, Commit: "HEAD~1"}, "test-user"); err == nil {
+		t.Error("Expected error for a revision that isn't a SHA")
+	}
+	sha := "0123456789abcdef0123456789abcdef01234567"
+	if err := store.CloseIssue(ctx, issue.ID, storage.CloseOptions{Reason: "Fixed", Resolution: types.ResolutionFixed, Commit: sha}, "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	closed, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if closed.Status != types.StatusClosed || closed.ResolvedBy != sha {
+		t.Errorf("Expected closed with resolved_by %s, got status=%s resolved_by=%q", sha, closed.Status, closed.ResolvedBy)
+	}
+	found, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(found) != 1 || found[0].ResolvedBy != sha {
+		t.Errorf("Expected resolved_by in search results, got %+v", found)
+	}
+
+	if err := store.ReopenIssue(ctx, issue.ID, "", "test-user"); err != nil {
+		t.Fatalf("ReopenIssue failed: %v", err)
+	}
+	reopened, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if reopened.ResolvedBy != "" {
+		t.Errorf("Expected reopen to clear resolved_by, got %q", reopened.ResolvedBy)
+	}
+}
+
+func TestGetIssueIDsChangedSince(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, id := range []string{"bd-1", "bd-2", "bd-3"} {
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	// Age everything, then touch bd-1's fields and only bd-2's labels
+	if _, err := store.db.ExecContext(ctx, `UPDATE issues SET updated_at = '2020-01-01T00:00:00Z'`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.db.ExecContext(ctx, `UPDATE events SET created_at = '2020-01-01 00:00:00'`); err != nil {
+		t.Fatal(err)
+	}
+	cutoff := time.Now().Add(-time.Minute)
+	if err := store.UpdateIssue(ctx, "bd-1", map[string]interface{}{"title": "Renamed"}, "test"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := store.AddLabel(ctx, "bd-2", "ui", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	ids, err := store.GetIssueIDsChangedSince(ctx, cutoff)
+	if err != nil {
+		t.Fatalf("GetIssueIDsChangedSince failed: %v", err)
+	}
+	if fmt.Sprint(ids) != "[bd-1 bd-2]" {
+		t.Errorf("ids = %v, want [bd-1 bd-2]", ids)
+	}
+
+	ids, err = store.GetIssueIDsChangedSince(ctx, time.Now().Add(time.Hour))
+	if err != nil || len(ids) != 0 {
+		t.Errorf("ids = %v (%v), want none after a future cutoff", ids, err)
+	}
+}
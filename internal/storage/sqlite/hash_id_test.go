@@ -2,6 +2,9 @@ package sqlite
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -61,15 +64,106 @@ func TestHashIDDeterministic(t *testing.T) {
 	prefix := "bd"
 	title := "Test Issue"
 	description := "Test description"
-	actor := "test-actor"
-	timestamp := time.Now()
 
-	id1 := generateHashID(prefix, title, description, actor, timestamp, 6, 0)
-	id2 := generateHashID(prefix, title, description, actor, timestamp, 6, 0)
+	id1 := generateHashID(prefix, title, description, 6, 0)
+	id2 := generateHashID(prefix, title, description, 6, 0)
 
 	if id1 != id2 {
 		t.Errorf("Expected same hash for same inputs, got %s and %s", id1, id2)
 	}
+	if id3 := generateHashID(prefix, title, description, 6, 1); id3 == id1 {
+		t.Errorf("Expected a different nonce to give a different hash, got %s twice", id1)
+	}
+}
+
+func TestHashIDReproducibleAcrossStores(t *testing.T) {
+	ctx := context.Background()
+	jsonl := `{"title":"First","description":"one","status":"open","priority":2,"issue_type":"task"}
+{"title":"Second","status":"open","priority":1,"issue_type":"bug"}
+{"title":"First","description":"one","status":"open","priority":2,"issue_type":"task"}
+`
+	importJSONL := func(store *SQLiteStorage, data string) []*types.Issue {
+		t.Helper()
+		var issues []*types.Issue
+		for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+			var issue types.Issue
+			if err := json.Unmarshal([]byte(line), &issue); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			issues = append(issues, &issue)
+		}
+		if err := store.CreateIssuesBatch(ctx, issues, "importer"); err != nil {
+			t.Fatalf("CreateIssuesBatch failed: %v", err)
+		}
+		return issues
+	}
+
+	// Different actors and wall clocks, same IDs
+	store1 := newTestStore(t, "")
+	defer store1.Close()
+	store2 := newTestStore(t, "")
+	defer store2.Close()
+	issues1 := importJSONL(store1, jsonl)
+	time.Sleep(2 * time.Millisecond)
+	issues2 := importJSONL(store2, jsonl)
+	for i := range issues1 {
+		if issues1[i].ID != issues2[i].ID {
+			t.Errorf("record %d got %s and %s", i, issues1[i].ID, issues2[i].ID)
+		}
+		if issues1[i].IDNonce == nil || issues2[i].IDNonce == nil || *issues1[i].IDNonce != *issues2[i].IDNonce {
+			t.Errorf("record %d got nonces %v and %v", i, issues1[i].IDNonce, issues2[i].IDNonce)
+		}
+		want := generateHashID("bd", issues1[i].Title, issues1[i].Description, len(issues1[i].ID)-len("bd-"), *issues1[i].IDNonce)
+		if issues1[i].ID != want {
+			t.Errorf("record %d: %s doesn't derive from its title, description and nonce (want %s)", i, issues1[i].ID, want)
+		}
+	}
+	if issues1[0].ID == issues1[2].ID {
+		t.Errorf("identical records got the same ID %s", issues1[0].ID)
+	}
+
+	// The nonce round-trips through the database and the next issue gets
+	// the same nonce in both stores
+	stored, err := store1.GetIssue(ctx, issues1[1].ID)
+	if err != nil || stored == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if stored.IDNonce == nil || *stored.IDNonce != *issues1[1].IDNonce {
+		t.Errorf("stored id_nonce = %v, want %d", stored.IDNonce, *issues1[1].IDNonce)
+	}
+	next1 := &types.Issue{Title: "Later", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	next2 := &types.Issue{Title: "Later", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store1.CreateIssue(ctx, next1, "alice"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if err := store2.CreateIssue(ctx, next2, "bob"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	if next1.ID != next2.ID {
+		t.Errorf("next issue got %s and %s", next1.ID, next2.ID)
+	}
+
+	// An exported nonce reproduces the ID even where the counter has moved on
+	store3 := newTestStore(t, "")
+	defer store3.Close()
+	if err := store3.CreateIssue(ctx, &types.Issue{Title: "Unrelated", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}, "carol"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var exported map[string]interface{}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	delete(exported, "id")
+	if data, err = json.Marshal(exported); err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if reimported := importJSONL(store3, string(data)); reimported[0].ID != stored.ID {
+		t.Errorf("re-importing with id_nonce %d got %s, want %s", *stored.IDNonce, reimported[0].ID, stored.ID)
+	}
 }
 
 func TestHashIDCollisionHandling(t *testing.T) {
@@ -191,3 +285,43 @@ func TestHashIDBatchCreation(t *testing.T) {
 		}
 	}
 }
+
+func TestHashIDBlocklist(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	if err := store.SetConfig(ctx, IDBlocklistConfigKey, "A, 1"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	assertAllowed := func(id string) {
+		t.Helper()
+		hash := strings.TrimPrefix(id, "bd-")
+		if strings.Contains(hash, "a") || strings.Contains(hash, "1") {
+			t.Errorf("generated ID %s contains a blocked sequence", id)
+		}
+	}
+
+	for i := 0; i < 200; i++ {
+		issue := &types.Issue{Title: fmt.Sprintf("Issue %d", i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		assertAllowed(issue.ID)
+	}
+
+	batch := make([]*types.Issue, 100)
+	for i := range batch {
+		batch[i] = &types.Issue{Title: fmt.Sprintf("Batch %d", i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	}
+	if err := store.CreateIssues(ctx, batch, "test"); err != nil {
+		t.Fatalf("CreateIssues failed: %v", err)
+	}
+	for _, issue := range batch {
+		assertAllowed(issue.ID)
+	}
+
+	if _, err := ParseIDBlocklist("ok,no-dash"); err == nil {
+		t.Error("expected an error for a sequence that can't appear in a hash")
+	}
+}
@@ -2,15 +2,24 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
 )
 
 // getNextChildNumber atomically increments and returns the next child counter for a parent issue.
 // Uses INSERT...ON CONFLICT to ensure atomicity without explicit locking.
 func (s *SQLiteStorage) getNextChildNumber(ctx context.Context, parentID string) (int, error) {
+	return nextChildNumberIn(ctx, s.db, parentID)
+}
+
+// nextChildNumberIn is getNextChildNumber through q
+func nextChildNumberIn(ctx context.Context, q dbExecutor, parentID string) (int, error) {
 	var nextChild int
-	err := s.db.QueryRowContext(ctx, `
+	err := q.QueryRowContext(ctx, `
 		INSERT INTO child_counters (parent_id, last_child)
 		VALUES (?, 1)
 		ON CONFLICT(parent_id) DO UPDATE SET
@@ -23,6 +32,52 @@ func (s *SQLiteStorage) getNextChildNumber(ctx context.Context, parentID string)
 	return nextChild, nil
 }
 
+// childCounterResetSQL sets a parent's counter to its highest existing direct
+// child number (0 if none). ?1 is the parent ID; with ?2 > 0 the counter is
+// only changed if it is still ?2. Computing and updating in one statement keeps
+// it atomic with getNextChildNumber.
+const childCounterResetSQL = `
+	UPDATE child_counters
+	SET last_child = (
+		SELECT COALESCE(MAX(CAST(substr(id, length(?1) + 2) AS INTEGER)), 0)
+		FROM issues
+		WHERE substr(id, 1, length(?1) + 1) = ?1 || '.'
+		  AND substr(id, length(?1) + 2) != ''
+		  AND substr(id, length(?1) + 2) NOT GLOB '*[^0-9]*'
+	)
+	WHERE parent_id = ?1 AND (?2 <= 0 OR last_child = ?2)
+`
+
+// ResetChildCounter recomputes a parent's child counter from its highest
+// existing child, so the next child reuses numbers freed by deleting the
+// newest children. A number allocated by GetNextChildID but not yet used by a
+// created issue can be handed out again, so only call this when no child
+// creation is in flight.
+func (s *SQLiteStorage) ResetChildCounter(ctx context.Context, parentID string) error {
+	if _, err := s.db.ExecContext(ctx, childCounterResetSQL, parentID, 0); err != nil {
+		return fmt.Errorf("failed to reset child counter for %s: %w", parentID, err)
+	}
+	return nil
+}
+
+// reclaimChildNumber lowers the parent's counter after childID was deleted,
+// but only if childID holds the parent's last allocated number. A counter that
+// moved past it means another child is being created, and is left alone.
+func reclaimChildNumber(ctx context.Context, q dbExecutor, childID string) error {
+	dot := strings.LastIndex(childID, ".")
+	if dot <= 0 {
+		return nil
+	}
+	num, err := strconv.Atoi(childID[dot+1:])
+	if err != nil || num <= 0 {
+		return nil
+	}
+	if _, err := q.ExecContext(ctx, childCounterResetSQL, childID[:dot], num); err != nil {
+		return fmt.Errorf("failed to reclaim child number for %s: %w", childID, err)
+	}
+	return nil
+}
+
 // GetNextChildID generates the next hierarchical child ID for a given parent
 // Returns formatted ID as parentID.{counter} (e.g., bd-a3f8e9.1 or bd-a3f8e9.1.5)
 // Works at any depth (max 3 levels)
@@ -54,4 +109,46 @@ func (s *SQLiteStorage) GetNextChildID(ctx context.Context, parentID string) (st
 	return childID, nil
 }
 
+// CreateChildIssue creates issue as the next hierarchical child of
+// parentID (parentID.N) with a parent-child dependency on it, in one
+// transaction, so the counter, the issue and the edge can't get out of step.
+// IDs already taken by children created with explicit IDs are skipped. The
+// created event lists the parent among the issue's dependencies.
+func (s *SQLiteStorage) CreateChildIssue(ctx context.Context, issue *types.Issue, parentID string, actor string) error {
+	return s.withConnTx(ctx, func(conn *sql.Conn) error {
+		parent, err := getIssue(ctx, conn, parentID)
+		if err != nil {
+			return fmt.Errorf("failed to get issue %s: %w", parentID, err)
+		}
+		if parent == nil {
+			return fmt.Errorf("parent issue %s does not exist", parentID)
+		}
+		if strings.Count(parentID, ".") >= 3 {
+			return fmt.Errorf("maximum hierarchy depth (3) exceeded for parent %s", parentID)
+		}
+
+		for {
+			num, err := nextChildNumberIn(ctx, conn, parentID)
+			if err != nil {
+				return err
+			}
+			issue.ID = fmt.Sprintf("%s.%d", parentID, num)
+			existing, err := getIssue(ctx, conn, issue.ID)
+			if err != nil {
+				return fmt.Errorf("failed to check for existing issue %s: %w", issue.ID, err)
+			}
+			if existing == nil {
+				break
+			}
+		}
+
+		dep := &types.Dependency{IssueID: issue.ID, DependsOnID: parentID, Type: types.DepParentChild}
+		issue.Dependencies = append(issue.Dependencies, dep)
+		if err := s.createIssueIn(ctx, conn, issue, actor); err != nil {
+			return err
+		}
+		return addDependencyIn(ctx, conn, dep, actor)
+	})
+}
+
 // generateHashID moved to ids.go (bd-0702)
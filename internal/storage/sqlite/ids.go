@@ -6,6 +6,8 @@ import (
 	"database/sql"
 	"fmt"
 	"math/big"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -83,93 +85,273 @@ func ValidateIssueIDPrefix(id, prefix string) error {
 	return nil
 }
 
-// GenerateIssueID generates a unique hash-based ID for an issue
-// Uses adaptive length based on database size and tries multiple nonces on collision
-func GenerateIssueID(ctx context.Context, conn *sql.Conn, prefix string, issue *types.Issue, actor string) (string, error) {
+// getIDPrefixes reads the issue_prefix and prefix_by_type config through conn.
+// BEADS_ISSUE_PREFIX, if set, takes the place of the stored issue_prefix.
+func getIDPrefixes(ctx context.Context, conn *sql.Conn) (types.IDPrefixes, error) {
+	var prefixes types.IDPrefixes
+	var err error
+	if envPrefix := os.Getenv(types.IssuePrefixEnvVar); envPrefix != "" {
+		if err := types.ValidateIssuePrefix(envPrefix); err != nil {
+			return prefixes, fmt.Errorf("%s: %w", types.IssuePrefixEnvVar, err)
+		}
+		prefixes.Default = envPrefix
+	} else {
+		err = conn.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, "issue_prefix").Scan(&prefixes.Default)
+	}
+	if err == sql.ErrNoRows || prefixes.Default == "" {
+		// CRITICAL: Reject operation if issue_prefix config is missing (bd-166)
+		// This prevents duplicate issues with wrong prefix
+		return prefixes, fmt.Errorf("database not initialized: issue_prefix config is missing (run 'bd init --prefix <prefix>' first)")
+	} else if err != nil {
+		return prefixes, fmt.Errorf("failed to get config: %w", err)
+	}
+
+	var byType string
+	err = conn.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, types.PrefixByTypeConfigKey).Scan(&byType)
+	if err != nil && err != sql.ErrNoRows {
+		return prefixes, fmt.Errorf("failed to get config: %w", err)
+	}
+	if prefixes.ByType, err = types.ParsePrefixByType(byType); err != nil {
+		return prefixes, err
+	}
+	return prefixes, nil
+}
+
+// validateIssueIDPrefixes validates that an explicit ID starts with the
+// default prefix or one of the per-type prefixes. The per-type prefix doesn't
+// have to match the issue's type: child IDs keep their parent's prefix, and
+// an issue keeps its ID when its type changes.
+func validateIssueIDPrefixes(id string, prefixes types.IDPrefixes) error {
+	if len(prefixes.ByType) == 0 {
+		return ValidateIssueIDPrefix(id, prefixes.Default)
+	}
+	if prefixes.Match(id) == "" {
+		return fmt.Errorf("issue ID '%s' does not match any configured prefix (%s)", id, strings.Join(prefixes.All(), ", "))
+	}
+	return nil
+}
+
+// IDBlocklistConfigKey lists comma-separated sequences that generated hash
+// IDs must not contain. A candidate containing one is skipped like a
+// collision, so the next nonce is tried.
+const IDBlocklistConfigKey = "id_blocklist"
+
+// ParseIDBlocklist parses an id_blocklist value into lowercase sequences.
+// Hashes are base36, so sequences may only use 0-9 and a-z.
+func ParseIDBlocklist(value string) ([]string, error) {
+	var blocklist []string
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if !isValidBase36(entry) {
+			return nil, fmt.Errorf("invalid %s entry %q: hash IDs only use 0-9 and a-z", IDBlocklistConfigKey, entry)
+		}
+		blocklist = append(blocklist, entry)
+	}
+	return blocklist, nil
+}
+
+// getIDBlocklist reads the id_blocklist config through conn
+func getIDBlocklist(ctx context.Context, conn *sql.Conn) ([]string, error) {
+	var value string
+	err := conn.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, IDBlocklistConfigKey).Scan(&value)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	return ParseIDBlocklist(value)
+}
+
+// hashBlocked reports whether the hash part of candidate, after prefix and
+// its dash, contains a blocked sequence
+func hashBlocked(candidate, prefix string, blocklist []string) bool {
+	hash := strings.TrimPrefix(candidate, prefix+"-")
+	for _, blocked := range blocklist {
+		if strings.Contains(hash, blocked) {
+			return true
+		}
+	}
+	return false
+}
+
+// IDNonceMetadataKey holds the next nonce to derive a hash ID from. It only
+// ever grows, so each nonce is used at most once per database; every
+// candidate ID tried, including collisions and blocked ones, consumes one.
+const IDNonceMetadataKey = "next_id_nonce"
+
+// minHashIDLength and maxHashIDLength bound the hash lengths
+// GenerateIssueID generates
+const (
+	minHashIDLength = 3
+	maxHashIDLength = 8
+)
+
+// hashIDGenerator hands out hash IDs using the database's nonce counter. The
+// counter is read when it's created and written back by save, so both must
+// happen on the conn and in the transaction the issues are inserted in.
+type hashIDGenerator struct {
+	conn        *sql.Conn
+	blocklist   []string
+	nextNonce   int
+	baseLengths map[string]int // Each prefix has its own ID space
+}
+
+func newHashIDGenerator(ctx context.Context, conn *sql.Conn) (*hashIDGenerator, error) {
+	blocklist, err := getIDBlocklist(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	g := &hashIDGenerator{conn: conn, blocklist: blocklist, baseLengths: make(map[string]int)}
+
+	var value string
+	err = conn.QueryRowContext(ctx, `SELECT value FROM metadata WHERE key = ?`, IDNonceMetadataKey).Scan(&value)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get %s: %w", IDNonceMetadataKey, err)
+	}
+	if value != "" {
+		if g.nextNonce, err = strconv.Atoi(value); err != nil || g.nextNonce < 0 {
+			return nil, fmt.Errorf("invalid %s %q in metadata", IDNonceMetadataKey, value)
+		}
+	}
+	return g, nil
+}
+
+// observe advances the counter past a nonce an issue already carries, so
+// it isn't handed out again
+func (g *hashIDGenerator) observe(nonce *int) {
+	if nonce != nil && *nonce >= g.nextNonce {
+		g.nextNonce = *nonce + 1
+	}
+}
+
+// save writes the counter back to metadata
+func (g *hashIDGenerator) save(ctx context.Context) error {
+	_, err := g.conn.ExecContext(ctx, `
+		INSERT INTO metadata (key, value) VALUES (?, ?)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value
+	`, IDNonceMetadataKey, strconv.Itoa(g.nextNonce))
+	if err != nil {
+		return fmt.Errorf("failed to save %s: %w", IDNonceMetadataKey, err)
+	}
+	return nil
+}
+
+func (g *hashIDGenerator) baseLength(ctx context.Context, prefix string) int {
+	if length, ok := g.baseLengths[prefix]; ok {
+		return length
+	}
 	// Get adaptive base length based on current database size
-	baseLength, err := GetAdaptiveIDLength(ctx, conn, prefix)
+	length, err := GetAdaptiveIDLength(ctx, g.conn, prefix)
 	if err != nil {
 		// Fallback to 6 on error
-		baseLength = 6
+		length = 6
 	}
-	
-	// Try baseLength, baseLength+1, baseLength+2, up to max of 8
-	maxLength := 8
-	if baseLength > maxLength {
-		baseLength = maxLength
+	length = min(length, maxHashIDLength)
+	g.baseLengths[prefix] = length
+	return length
+}
+
+// available reports whether candidate is unblocked and unused, in usedIDs
+// or in the database
+func (g *hashIDGenerator) available(ctx context.Context, candidate, prefix string, usedIDs map[string]bool) (bool, error) {
+	if usedIDs[candidate] || hashBlocked(candidate, prefix, g.blocklist) {
+		return false, nil
 	}
-	
-	for length := baseLength; length <= maxLength; length++ {
-		// Try up to 10 nonces at each length
-		for nonce := 0; nonce < 10; nonce++ {
-			candidate := generateHashID(prefix, issue.Title, issue.Description, actor, issue.CreatedAt, length, nonce)
-			
-			// Check if this ID already exists
-			var count int
-			err = conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id = ?`, candidate).Scan(&count)
+	var count int
+	err := g.conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id = ?`, candidate).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check for ID collision: %w", err)
+	}
+	return count == 0, nil
+}
+
+// generate sets issue.ID and issue.IDNonce. An issue that already carries a
+// nonce (from another database's export) first gets its ID from that nonce
+// if it's free. Otherwise each length from the adaptive base length up to 8
+// is tried with up to 10 fresh nonces from the counter. issue.IDLength, if
+// set, replaces the adaptive base length for this issue alone, but never
+// goes below min_hash_length.
+func (g *hashIDGenerator) generate(ctx context.Context, prefix string, issue *types.Issue, usedIDs map[string]bool) error {
+	baseLength := g.baseLength(ctx, prefix)
+	if issue.IDLength != 0 {
+		if issue.IDLength < minHashIDLength || issue.IDLength > maxHashIDLength {
+			return fmt.Errorf("invalid ID length %d: must be from %d to %d", issue.IDLength, minHashIDLength, maxHashIDLength)
+		}
+		baseLength = max(issue.IDLength, getAdaptiveConfig(ctx, g.conn).MinLength)
+	}
+	if issue.IDNonce != nil {
+		g.observe(issue.IDNonce)
+		for length := baseLength; length <= maxHashIDLength; length++ {
+			candidate := generateHashID(prefix, issue.Title, issue.Description, length, *issue.IDNonce)
+			ok, err := g.available(ctx, candidate, prefix, usedIDs)
 			if err != nil {
-				return "", fmt.Errorf("failed to check for ID collision: %w", err)
+				return err
 			}
-			
-			if count == 0 {
-				return candidate, nil
+			if ok {
+				issue.ID = candidate
+				return nil
 			}
 		}
 	}
-	
-	return "", fmt.Errorf("failed to generate unique ID after trying lengths %d-%d with 10 nonces each", baseLength, maxLength)
+
+	for length := baseLength; length <= maxHashIDLength; length++ {
+		for attempt := 0; attempt < 10; attempt++ {
+			nonce := g.nextNonce
+			g.nextNonce++
+			candidate := generateHashID(prefix, issue.Title, issue.Description, length, nonce)
+			ok, err := g.available(ctx, candidate, prefix, usedIDs)
+			if err != nil {
+				return err
+			}
+			if ok {
+				issue.ID = candidate
+				issue.IDNonce = &nonce
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("failed to generate unique ID after trying lengths %d-%d with 10 nonces each", baseLength, maxHashIDLength)
 }
 
-// GenerateBatchIssueIDs generates unique IDs for multiple issues in a single batch
-// Tracks used IDs to prevent intra-batch collisions
-func GenerateBatchIssueIDs(ctx context.Context, conn *sql.Conn, prefix string, issues []*types.Issue, actor string, usedIDs map[string]bool) error {
-	// Get adaptive base length based on current database size
-	baseLength, err := GetAdaptiveIDLength(ctx, conn, prefix)
+// GenerateIssueID generates a unique hash-based ID for an issue and sets
+// issue.IDNonce to the nonce it was derived from. Uses adaptive length based
+// on database size and tries more nonces on collision or when the hash
+// contains an id_blocklist sequence.
+func GenerateIssueID(ctx context.Context, conn *sql.Conn, prefix string, issue *types.Issue) (string, error) {
+	g, err := newHashIDGenerator(ctx, conn)
 	if err != nil {
-		// Fallback to 6 on error
-		baseLength = 6
+		return "", err
 	}
-	
-	// Try baseLength, baseLength+1, baseLength+2, up to max of 8
-	maxLength := 8
-	if baseLength > maxLength {
-		baseLength = maxLength
+	if err := g.generate(ctx, prefix, issue, nil); err != nil {
+		return "", err
+	}
+	if err := g.save(ctx); err != nil {
+		return "", err
+	}
+	return issue.ID, nil
+}
+
+// GenerateBatchIssueIDs generates unique IDs for multiple issues in a single batch,
+// each with the prefix for its type, in slice order so the same batch gets
+// the same IDs and nonces in any database with the same counter.
+// Tracks used IDs to prevent intra-batch collisions
+func GenerateBatchIssueIDs(ctx context.Context, conn *sql.Conn, prefixes types.IDPrefixes, issues []*types.Issue, usedIDs map[string]bool) error {
+	g, err := newHashIDGenerator(ctx, conn)
+	if err != nil {
+		return err
 	}
-	
 	for i := range issues {
-		if issues[i].ID == "" {
-			var generated bool
-			// Try lengths from baseLength to maxLength with progressive fallback
-			for length := baseLength; length <= maxLength && !generated; length++ {
-				for nonce := 0; nonce < 10; nonce++ {
-					candidate := generateHashID(prefix, issues[i].Title, issues[i].Description, actor, issues[i].CreatedAt, length, nonce)
-					
-					// Check if this ID is already used in this batch or in the database
-					if usedIDs[candidate] {
-						continue
-					}
-					
-					var count int
-					err := conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id = ?`, candidate).Scan(&count)
-					if err != nil {
-						return fmt.Errorf("failed to check for ID collision: %w", err)
-					}
-					
-					if count == 0 {
-						issues[i].ID = candidate
-						usedIDs[candidate] = true
-						generated = true
-						break
-					}
-				}
-			}
-			
-			if !generated {
-				return fmt.Errorf("failed to generate unique ID for issue %d after trying lengths %d-%d with 10 nonces each", i, baseLength, maxLength)
-			}
+		if issues[i].ID != "" {
+			g.observe(issues[i].IDNonce)
+			continue
+		}
+		if err := g.generate(ctx, prefixes.ForType(issues[i].IssueType), issues[i], usedIDs); err != nil {
+			return fmt.Errorf("issue %d: %w", i, err)
 		}
+		usedIDs[issues[i].ID] = true
 	}
-	return nil
+	return g.save(ctx)
 }
 
 // tryResurrectParent attempts to find and resurrect a deleted parent issue from the import batch
@@ -195,16 +377,16 @@ const (
 
 // EnsureIDs generates or validates IDs for issues
 // For issues with empty IDs, generates unique hash-based IDs
-// For issues with existing IDs, validates they match the prefix and parent exists (if hierarchical)
+// For issues with existing IDs, validates they match a configured prefix and parent exists (if hierarchical)
 // For hierarchical IDs with missing parents, behavior depends on orphanHandling mode
-func EnsureIDs(ctx context.Context, conn *sql.Conn, prefix string, issues []*types.Issue, actor string, orphanHandling OrphanHandling) error {
+func EnsureIDs(ctx context.Context, conn *sql.Conn, prefixes types.IDPrefixes, issues []*types.Issue, orphanHandling OrphanHandling) error {
 	usedIDs := make(map[string]bool)
 	
 	// First pass: record explicitly provided IDs
 	for i := range issues {
 		if issues[i].ID != "" {
-			// Validate that explicitly provided ID matches the configured prefix (bd-177)
-			if err := ValidateIssueIDPrefix(issues[i].ID, prefix); err != nil {
+			// Validate that explicitly provided ID matches a configured prefix (bd-177)
+			if err := validateIssueIDPrefixes(issues[i].ID, prefixes); err != nil {
 				return err
 			}
 			
@@ -246,20 +428,21 @@ func EnsureIDs(ctx context.Context, conn *sql.Conn, prefix string, issues []*typ
 	}
 	
 	// Second pass: generate IDs for issues that need them
-	return GenerateBatchIssueIDs(ctx, conn, prefix, issues, actor, usedIDs)
+	return GenerateBatchIssueIDs(ctx, conn, prefixes, issues, usedIDs)
 }
 
 // generateHashID creates a hash-based ID for a top-level issue.
 // For child issues, use the parent ID with a numeric suffix (e.g., "bd-x7k9p.1").
 // Supports adaptive length from 3-8 chars based on database size.
-// Includes a nonce parameter to handle same-length collisions.
+// The nonce comes from the database's counter (see IDNonceMetadataKey).
 // Uses base36 encoding (0-9, a-z) for better information density than hex.
-func generateHashID(prefix, title, description, creator string, timestamp time.Time, length, nonce int) string {
-	// Combine inputs into a stable content string
-	// Include nonce to handle hash collisions
-	content := fmt.Sprintf("%s|%s|%s|%d|%d", title, description, creator, timestamp.UnixNano(), nonce)
+func generateHashID(prefix, title, description string, length, nonce int) string {
+	return hashIDFromContent(prefix, hashIDContent(title, description, nonce), length)
+}
 
-	// Hash the content
+// hashIDFromContent takes the first bytes of content's SHA-256 and base36
+// encodes them into a hash of length chars
+func hashIDFromContent(prefix, content string, length int) string {
 	hash := sha256.Sum256([]byte(content))
 
 	// Use base36 encoding with variable length (3-8 chars)
@@ -286,3 +469,67 @@ func generateHashID(prefix, title, description, creator string, timestamp time.T
 
 	return fmt.Sprintf("%s-%s", prefix, shortHash)
 }
+
+// hashIDContent combines generateHashID's inputs into a stable content string:
+// "<title>|<description>|<nonce>". Nothing else goes in, so given the same
+// title, description and nonce any machine derives the same ID.
+func hashIDContent(title, description string, nonce int) string {
+	return fmt.Sprintf("%s|%s|%d", title, description, nonce)
+}
+
+// legacyHashIDContent is the content IDs were derived from before the nonce
+// was stored: "<title>|<description>|<creator>|<created_at ns>|<nonce>",
+// with the nonce starting from 0 for each issue. Issues without an id_nonce
+// have IDs made this way.
+func legacyHashIDContent(title, description, creator string, timestamp time.Time, nonce int) string {
+	return fmt.Sprintf("%s|%s|%s|%d|%d", title, description, creator, timestamp.UnixNano(), nonce)
+}
+
+// HashIDDerivation is the content fed to generateHashID and what came out
+type HashIDDerivation struct {
+	Content string `json:"content"`
+	Digest  string `json:"digest"` // Hex SHA-256 of Content
+	Length  int    `json:"length"`
+	Nonce   int    `json:"nonce"`
+	ID      string `json:"id"`
+}
+
+// DeriveHashID reproduces how issue's ID was generated. With a stored
+// id_nonce that's the content from its title, description and nonce at
+// whichever length matches. Without one the ID predates stored nonces, so
+// the legacy content from the creator and creation time is tried with the
+// lengths and nonces GenerateIssueID used to try. If nothing produces the ID
+// (the issue was renamed or edited since), it returns the derivation at the
+// ID's length, from the stored nonce or nonce 0, and false.
+func DeriveHashID(issue *types.Issue, prefix, creator string) (*HashIDDerivation, bool) {
+	derive := func(content string, length, nonce int) *HashIDDerivation {
+		return &HashIDDerivation{
+			Content: content,
+			Digest:  fmt.Sprintf("%x", sha256.Sum256([]byte(content))),
+			Length:  length,
+			Nonce:   nonce,
+			ID:      hashIDFromContent(prefix, content, length),
+		}
+	}
+	idLength := len(strings.TrimPrefix(issue.ID, prefix+"-"))
+
+	if issue.IDNonce != nil {
+		content := hashIDContent(issue.Title, issue.Description, *issue.IDNonce)
+		for length := 3; length <= maxHashIDLength; length++ {
+			if d := derive(content, length, *issue.IDNonce); d.ID == issue.ID {
+				return d, true
+			}
+		}
+		return derive(content, idLength, *issue.IDNonce), false
+	}
+
+	for length := 3; length <= maxHashIDLength; length++ {
+		for nonce := 0; nonce < 10; nonce++ {
+			content := legacyHashIDContent(issue.Title, issue.Description, creator, issue.CreatedAt, nonce)
+			if d := derive(content, length, nonce); d.ID == issue.ID {
+				return d, true
+			}
+		}
+	}
+	return derive(legacyHashIDContent(issue.Title, issue.Description, creator, issue.CreatedAt, 0), idLength, 0), false
+}
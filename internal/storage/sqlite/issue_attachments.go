@@ -0,0 +1,56 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// encodeIssueAttachments returns attachments as the JSON array stored in the
+// issues.attachments column, or nil when there are none
+func encodeIssueAttachments(attachments []string) interface{} {
+	if len(attachments) == 0 {
+		return nil
+	}
+	// A slice of strings always marshals
+	data, _ := json.Marshal(attachments)
+	return string(data)
+}
+
+// decodeIssueAttachments parses the issues.attachments column
+func decodeIssueAttachments(raw sql.NullString) ([]string, error) {
+	if !raw.Valid || raw.String == "" {
+		return nil, nil
+	}
+	var attachments []string
+	if err := json.Unmarshal([]byte(raw.String), &attachments); err != nil {
+		return nil, fmt.Errorf("invalid attachments JSON: %w", err)
+	}
+	if len(attachments) == 0 {
+		return nil, nil
+	}
+	return attachments, nil
+}
+
+// attachmentsUpdateValue converts the value of an "attachments" update, which
+// replaces all of an issue's attachments, to a slice. nil clears them.
+func attachmentsUpdateValue(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		return v, nil
+	case []interface{}:
+		// Values decoded from JSON, e.g. restored by undo
+		attachments := make([]string, 0, len(v))
+		for _, val := range v {
+			s, ok := val.(string)
+			if !ok {
+				return nil, fmt.Errorf("attachment must be a string, got %T", val)
+			}
+			attachments = append(attachments, s)
+		}
+		return attachments, nil
+	}
+	return nil, fmt.Errorf("attachments must be a list of strings, got %T", value)
+}
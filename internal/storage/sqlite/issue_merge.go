@@ -0,0 +1,317 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// IssueMerge reports what MergeIssue moved from a duplicate onto its target
+type IssueMerge struct {
+	DuplicateID       string              `json:"duplicate_id"`
+	TargetID          string              `json:"target_id"`
+	Comments          int                 `json:"comments_moved"`
+	Events            int                 `json:"events_moved"`
+	Labels            []string            `json:"labels_moved,omitempty"`
+	Dependencies      []*types.Dependency `json:"dependencies_moved,omitempty"`   // As re-pointed at the target
+	Dropped           []*types.Dependency `json:"dependencies_dropped,omitempty"` // Edges between the two, already on the target, or closing a cycle
+	RewrittenIssues   []string            `json:"rewritten_issues,omitempty"`     // Issues whose text referenced the duplicate
+	RewrittenComments int                 `json:"rewritten_comments"`
+}
+
+// errMergeDryRun rolls back a dry-run merge once it has been reported
+var errMergeDryRun = errors.New("dry run")
+
+// MergeIssue folds duplicateID into targetID in one transaction. The
+// duplicate's comments, events and labels move to the target, and its
+// dependencies are re-pointed at it, dropping edges that would become
+// self-loops or duplicates or close a cycle. rewrite, if not nil, is applied
+// to the text fields and comments of every issue to replace references to
+// the duplicate. The duplicate is then closed as a duplicate with a related
+// dependency on the target. With dryRun, the merge is reported and rolled back.
+func (s *SQLiteStorage) MergeIssue(ctx context.Context, duplicateID, targetID string, rewrite func(string) string, dryRun bool, actor string) (*IssueMerge, error) {
+	var merge *IssueMerge
+	err := s.withConnTx(ctx, func(conn *sql.Conn) error {
+		var err error
+		if merge, err = mergeIssueIn(ctx, conn, duplicateID, targetID, rewrite, actor); err != nil {
+			return err
+		}
+		if dryRun {
+			return errMergeDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errMergeDryRun) {
+		return nil, err
+	}
+	return merge, nil
+}
+
+func mergeIssueIn(ctx context.Context, tx dbExecutor, duplicateID, targetID string, rewrite func(string) string, actor string) (*IssueMerge, error) {
+	if duplicateID == targetID {
+		return nil, fmt.Errorf("cannot merge %s into itself", duplicateID)
+	}
+	for _, id := range []string{duplicateID, targetID} {
+		issue, err := getIssue(ctx, tx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue %s: %w", id, err)
+		}
+		if issue == nil {
+			return nil, fmt.Errorf("issue %s not found", id)
+		}
+	}
+	merge := &IssueMerge{DuplicateID: duplicateID, TargetID: targetID}
+
+	// Comments and history move wholesale, keeping authors and timestamps
+	result, err := tx.ExecContext(ctx, `UPDATE comments SET issue_id = ? WHERE issue_id = ?`, targetID, duplicateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move comments: %w", err)
+	}
+	comments, _ := result.RowsAffected()
+	merge.Comments = int(comments)
+	result, err = tx.ExecContext(ctx, `UPDATE events SET issue_id = ? WHERE issue_id = ?`, targetID, duplicateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move events: %w", err)
+	}
+	events, _ := result.RowsAffected()
+	merge.Events = int(events)
+
+	if err := mergeLabelsIn(ctx, tx, merge, actor); err != nil {
+		return nil, err
+	}
+	if err := mergeDependenciesIn(ctx, tx, merge, actor); err != nil {
+		return nil, err
+	}
+	if rewrite != nil {
+		if err := rewriteReferencesIn(ctx, tx, merge, rewrite, actor); err != nil {
+			return nil, err
+		}
+	}
+
+	// Close the duplicate with a link back to what it duplicates
+	if err := addDependencyIn(ctx, tx, &types.Dependency{
+		IssueID:     duplicateID,
+		DependsOnID: targetID,
+		Type:        types.DepRelated,
+	}, actor); err != nil {
+		return nil, fmt.Errorf("failed to link %s to %s: %w", duplicateID, targetID, err)
+	}
+	if err := closeIssueIn(ctx, tx, duplicateID, storage.CloseOptions{
+		Reason:     fmt.Sprintf("Merged into %s", targetID),
+		Resolution: types.ResolutionDuplicate,
+	}, actor); err != nil {
+		return nil, err
+	}
+	if err := recordMergeEventsIn(ctx, tx, merge, actor); err != nil {
+		return nil, err
+	}
+	return merge, nil
+}
+
+// mergeLabelsIn moves the duplicate's labels to the target
+func mergeLabelsIn(ctx context.Context, tx dbExecutor, merge *IssueMerge, actor string) error {
+	labels, err := getLabels(ctx, tx, merge.DuplicateID)
+	if err != nil {
+		return err
+	}
+	existing, err := getLabels(ctx, tx, merge.TargetID)
+	if err != nil {
+		return err
+	}
+	onTarget := make(map[string]bool, len(existing))
+	for _, label := range existing {
+		onTarget[label] = true
+	}
+	for _, label := range labels {
+		if !onTarget[label] {
+			if err := addLabelIn(ctx, tx, merge.TargetID, label, actor); err != nil {
+				return err
+			}
+			merge.Labels = append(merge.Labels, label)
+		}
+		if err := removeLabelIn(ctx, tx, merge.DuplicateID, label, actor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeDependenciesIn re-points the duplicate's dependencies, in both
+// directions, at the target
+func mergeDependenciesIn(ctx context.Context, tx dbExecutor, merge *IssueMerge, actor string) error {
+	dupID, targetID := merge.DuplicateID, merge.TargetID
+	outgoing, err := getDependencyRecords(ctx, tx, dupID)
+	if err != nil {
+		return err
+	}
+	incoming, err := getDependentRecords(ctx, tx, dupID)
+	if err != nil {
+		return err
+	}
+	targetDeps, err := getDependencyRecords(ctx, tx, targetID)
+	if err != nil {
+		return err
+	}
+	targetDependents, err := getDependentRecords(ctx, tx, targetID)
+	if err != nil {
+		return err
+	}
+	dependsOn := make(map[string]bool)
+	hasParent := false
+	for _, dep := range targetDeps {
+		dependsOn[dep.DependsOnID] = true
+		if dep.Type == types.DepParentChild {
+			hasParent = true
+		}
+	}
+	dependedOnBy := make(map[string]bool)
+	for _, dep := range targetDependents {
+		dependedOnBy[dep.IssueID] = true
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM dependencies WHERE issue_id = ? OR depends_on_id = ?`, dupID, dupID); err != nil {
+		return fmt.Errorf("failed to remove dependencies of %s: %w", dupID, err)
+	}
+
+	var moved []*types.Dependency
+	for _, dep := range outgoing {
+		// An edge to the target would become a self-loop; a second parent
+		// would conflict with the target's own
+		if dep.DependsOnID == targetID || dependsOn[dep.DependsOnID] || (dep.Type == types.DepParentChild && hasParent) {
+			merge.Dropped = append(merge.Dropped, dep)
+			continue
+		}
+		moved = append(moved, &types.Dependency{IssueID: targetID, DependsOnID: dep.DependsOnID, Type: dep.Type, CreatedAt: dep.CreatedAt, CreatedBy: dep.CreatedBy})
+	}
+	for _, dep := range incoming {
+		if dep.IssueID == targetID || dependedOnBy[dep.IssueID] {
+			merge.Dropped = append(merge.Dropped, dep)
+			continue
+		}
+		moved = append(moved, &types.Dependency{IssueID: dep.IssueID, DependsOnID: targetID, Type: dep.Type, CreatedAt: dep.CreatedAt, CreatedBy: dep.CreatedBy})
+	}
+
+	for _, dep := range moved {
+		cycle, err := detectCycleIn(ctx, tx, dep.IssueID, dep.DependsOnID)
+		if err != nil {
+			return fmt.Errorf("failed to check for cycles: %w", err)
+		}
+		if cycle != nil {
+			merge.Dropped = append(merge.Dropped, dep)
+			continue
+		}
+		if err := addDependencyIn(ctx, tx, dep, actor); err != nil {
+			return err
+		}
+		merge.Dependencies = append(merge.Dependencies, dep)
+	}
+
+	related := make([]string, 0, len(outgoing)+len(incoming)+1)
+	related = append(related, dupID)
+	for _, dep := range outgoing {
+		related = append(related, dep.DependsOnID)
+	}
+	for _, dep := range incoming {
+		related = append(related, dep.IssueID)
+	}
+	return markIssuesDirtyTx(ctx, tx, related)
+}
+
+// rewriteReferencesIn applies rewrite to every issue's text fields and comments
+func rewriteReferencesIn(ctx context.Context, tx dbExecutor, merge *IssueMerge, rewrite func(string) string, actor string) error {
+	type issueText struct {
+		id                                            string
+		title, description, design, acceptance, notes string
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT id, title, description, design, acceptance_criteria, notes FROM issues`)
+	if err != nil {
+		return fmt.Errorf("failed to read issues: %w", err)
+	}
+	var texts []issueText
+	for rows.Next() {
+		var t issueText
+		if err := rows.Scan(&t.id, &t.title, &t.description, &t.design, &t.acceptance, &t.notes); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan issue: %w", err)
+		}
+		texts = append(texts, t)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, t := range texts {
+		updates := make(map[string]interface{})
+		for field, text := range map[string]string{
+			"title":               t.title,
+			"description":         t.description,
+			"design":              t.design,
+			"acceptance_criteria": t.acceptance,
+			"notes":               t.notes,
+		} {
+			if updated := rewrite(text); updated != text {
+				updates[field] = updated
+			}
+		}
+		if len(updates) == 0 {
+			continue
+		}
+		if err := updateIssueIn(ctx, tx, t.id, updates, actor, "", ""); err != nil {
+			return fmt.Errorf("failed to rewrite references in %s: %w", t.id, err)
+		}
+		merge.RewrittenIssues = append(merge.RewrittenIssues, t.id)
+	}
+
+	type commentText struct {
+		id   int64
+		text string
+	}
+	rows, err = tx.QueryContext(ctx, `SELECT id, text FROM comments`)
+	if err != nil {
+		return fmt.Errorf("failed to read comments: %w", err)
+	}
+	var comments []commentText
+	for rows.Next() {
+		var c commentText
+		if err := rows.Scan(&c.id, &c.text); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comments = append(comments, c)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, c := range comments {
+		if updated := rewrite(c.text); updated != c.text {
+			if _, err := updateCommentIn(ctx, tx, c.id, updated); err != nil {
+				return fmt.Errorf("failed to rewrite references in comment %d: %w", c.id, err)
+			}
+			merge.RewrittenComments++
+		}
+	}
+	return nil
+}
+
+// recordMergeEventsIn records the merge on both issues
+func recordMergeEventsIn(ctx context.Context, tx dbExecutor, merge *IssueMerge, actor string) error {
+	for _, event := range []struct{ issueID, comment string }{
+		{merge.TargetID, fmt.Sprintf("Merged %s into this issue", merge.DuplicateID)},
+		{merge.DuplicateID, fmt.Sprintf("Merged into %s", merge.TargetID)},
+	} {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, event.issueID, types.EventMerged, actor, merge.DuplicateID, merge.TargetID, event.comment)
+		if err != nil {
+			return fmt.Errorf("failed to record merge event: %w", err)
+		}
+	}
+	return markIssuesDirtyTx(ctx, tx, []string{merge.TargetID, merge.DuplicateID})
+}
@@ -0,0 +1,133 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestMergeIssue(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	create := func(id, description string) {
+		t.Helper()
+		issue := &types.Issue{ID: id, Title: id, Description: description, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("failed to create %s: %v", id, err)
+		}
+	}
+	depend := func(from, to string, depType types.DependencyType) {
+		t.Helper()
+		if err := store.AddDependency(ctx, &types.Dependency{IssueID: from, DependsOnID: to, Type: depType}, "test"); err != nil {
+			t.Fatalf("failed to add %s → %s: %v", from, to, err)
+		}
+	}
+	create("bd-parent", "")
+	create("bd-target", "")
+	create("bd-dup", "")
+	create("bd-user", "Waiting on bd-dup")
+	create("bd-loop", "")
+	depend("bd-target", "bd-parent", types.DepParentChild)
+	depend("bd-dup", "bd-parent", types.DepParentChild) // Shared parent
+	depend("bd-dup", "bd-target", types.DepBlocks)      // Would be a self-loop
+	depend("bd-user", "bd-dup", types.DepBlocks)        // Re-pointed at the target
+	depend("bd-dup", "bd-loop", types.DepRelated)       // Would close bd-loop → bd-target
+	depend("bd-loop", "bd-target", types.DepBlocks)
+	if err := store.AddLabel(ctx, "bd-dup", "ui", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := store.AddComment(ctx, "bd-dup", "alice", "Repro on bd-dup"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if _, err := store.AddIssueComment(ctx, "bd-dup", "alice", "Repro steps for bd-dup"); err != nil {
+		t.Fatalf("AddIssueComment failed: %v", err)
+	}
+	rewrite := func(text string) string { return strings.ReplaceAll(text, "bd-dup", "bd-target") }
+
+	t.Run("dry run changes nothing", func(t *testing.T) {
+		merge, err := store.MergeIssue(ctx, "bd-dup", "bd-target", rewrite, true, "test")
+		if err != nil {
+			t.Fatalf("MergeIssue failed: %v", err)
+		}
+		if len(merge.Dependencies) != 1 || len(merge.Dropped) != 3 || merge.Comments != 1 {
+			t.Errorf("merge = %+v, want 1 moved and 3 dropped dependencies and 1 comment", merge)
+		}
+		dup, err := store.GetIssue(ctx, "bd-dup")
+		if err != nil || dup.Status != types.StatusOpen {
+			t.Fatalf("expected bd-dup to stay open, got %+v (%v)", dup, err)
+		}
+		if comments, _ := store.GetIssueComments(ctx, "bd-dup"); len(comments) != 1 {
+			t.Errorf("expected the comment to stay on bd-dup, got %d", len(comments))
+		}
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		merge, err := store.MergeIssue(ctx, "bd-dup", "bd-target", rewrite, false, "test")
+		if err != nil {
+			t.Fatalf("MergeIssue failed: %v", err)
+		}
+		if len(merge.Labels) != 1 || merge.Labels[0] != "ui" || merge.RewrittenComments != 1 {
+			t.Errorf("merge = %+v, want the ui label moved and 1 comment rewritten", merge)
+		}
+
+		dup, err := store.GetIssue(ctx, "bd-dup")
+		if err != nil {
+			t.Fatalf("GetIssue failed: %v", err)
+		}
+		if dup.Status != types.StatusClosed || dup.Resolution != types.ResolutionDuplicate {
+			t.Errorf("dup = %s/%s, want closed as duplicate", dup.Status, dup.Resolution)
+		}
+		dupDeps, _ := store.GetDependencyRecords(ctx, "bd-dup")
+		if len(dupDeps) != 1 || dupDeps[0].DependsOnID != "bd-target" || dupDeps[0].Type != types.DepRelated {
+			t.Errorf("dup deps = %+v, want only the related back-link", dupDeps)
+		}
+
+		targetDeps, _ := store.GetDependencyRecords(ctx, "bd-target")
+		if len(targetDeps) != 1 || targetDeps[0].DependsOnID != "bd-parent" {
+			t.Errorf("target deps = %+v, want only its own parent", targetDeps)
+		}
+		userDeps, _ := store.GetDependencyRecords(ctx, "bd-user")
+		if len(userDeps) != 1 || userDeps[0].DependsOnID != "bd-target" {
+			t.Errorf("user deps = %+v, want bd-user → bd-target", userDeps)
+		}
+		if labels, _ := store.GetLabels(ctx, "bd-target"); len(labels) != 1 || labels[0] != "ui" {
+			t.Errorf("target labels = %v, want [ui]", labels)
+		}
+
+		comments, _ := store.GetIssueComments(ctx, "bd-target")
+		if len(comments) != 1 || comments[0].Author != "alice" || comments[0].Text != "Repro steps for bd-target" {
+			t.Errorf("target comments = %+v, want alice's comment, rewritten", comments)
+		}
+		user, _ := store.GetIssue(ctx, "bd-user")
+		if user.Description != "Waiting on bd-target" {
+			t.Errorf("user description = %q, want the reference rewritten", user.Description)
+		}
+
+		events, err := store.GetEvents(ctx, "bd-target", 0)
+		if err != nil {
+			t.Fatalf("GetEvents failed: %v", err)
+		}
+		var created, merged int
+		for _, event := range events {
+			switch event.EventType {
+			case types.EventCreated:
+				created++
+			case types.EventMerged:
+				merged++
+			}
+		}
+		if created != 2 || merged != 1 {
+			t.Errorf("target has %d created and %d merged events, want the dup's history and one merge", created, merged)
+		}
+	})
+
+	t.Run("rejects merging into itself", func(t *testing.T) {
+		if _, err := store.MergeIssue(ctx, "bd-target", "bd-target", nil, false, "test"); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// encodeIssueMetadata returns metadata as the JSON object stored in the
+// issues.metadata column, or nil when there is none
+func encodeIssueMetadata(metadata map[string]string) interface{} {
+	if len(metadata) == 0 {
+		return nil
+	}
+	// A map of strings always marshals
+	data, _ := json.Marshal(metadata)
+	return string(data)
+}
+
+// decodeIssueMetadata parses the issues.metadata column
+func decodeIssueMetadata(raw sql.NullString) (map[string]string, error) {
+	if !raw.Valid || raw.String == "" {
+		return nil, nil
+	}
+	var metadata map[string]string
+	if err := json.Unmarshal([]byte(raw.String), &metadata); err != nil {
+		return nil, fmt.Errorf("invalid metadata JSON: %w", err)
+	}
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	return metadata, nil
+}
+
+// metadataUpdateValue converts the value of a "metadata" update, which
+// replaces all of an issue's metadata, to a map. nil clears it.
+func metadataUpdateValue(value interface{}) (map[string]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case map[string]string:
+		return v, nil
+	case map[string]interface{}:
+		// Values decoded from JSON, e.g. restored by undo
+		metadata := make(map[string]string, len(v))
+		for key, val := range v {
+			s, ok := val.(string)
+			if !ok {
+				return nil, fmt.Errorf("metadata value for %q must be a string, got %T", key, val)
+			}
+			metadata[key] = s
+		}
+		return metadata, nil
+	}
+	return nil, fmt.Errorf("metadata must be a map of strings, got %T", value)
+}
+
+// metadataJSONPath returns the JSON path selecting key in the metadata column
+func metadataJSONPath(key string) string {
+	return fmt.Sprintf(`$."%s"`, key)
+}
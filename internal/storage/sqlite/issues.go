@@ -19,14 +19,16 @@ func insertIssue(ctx context.Context, conn *sql.Conn, issue *types.Issue) error
 		INSERT INTO issues (
 			id, content_hash, title, description, design, acceptance_criteria, notes,
 			status, priority, issue_type, assignee, estimated_minutes,
-			created_at, updated_at, closed_at, external_ref, source_repo
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			created_at, updated_at, closed_at, external_ref, source_repo, resolution,
+			spent_minutes, metadata, archived_at, id_nonce, attachments, resolved_by
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
 		issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
 		issue.AcceptanceCriteria, issue.Notes, issue.Status,
 		issue.Priority, issue.IssueType, issue.Assignee,
 		issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
-		issue.ClosedAt, issue.ExternalRef, sourceRepo,
+		issue.ClosedAt, issue.ExternalRef, sourceRepo, issue.Resolution,
+		issue.SpentMinutes, encodeIssueMetadata(issue.Metadata), issue.ArchivedAt, issue.IDNonce, encodeIssueAttachments(issue.Attachments), issue.ResolvedBy,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert issue: %w", err)
@@ -40,8 +42,9 @@ func insertIssues(ctx context.Context, conn *sql.Conn, issues []*types.Issue) er
 		INSERT INTO issues (
 			id, content_hash, title, description, design, acceptance_criteria, notes,
 			status, priority, issue_type, assignee, estimated_minutes,
-			created_at, updated_at, closed_at, external_ref, source_repo
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			created_at, updated_at, closed_at, external_ref, source_repo, resolution,
+			spent_minutes, metadata, archived_at, id_nonce, attachments, resolved_by
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
@@ -59,7 +62,8 @@ func insertIssues(ctx context.Context, conn *sql.Conn, issues []*types.Issue) er
 			issue.AcceptanceCriteria, issue.Notes, issue.Status,
 			issue.Priority, issue.IssueType, issue.Assignee,
 			issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
-			issue.ClosedAt, issue.ExternalRef, sourceRepo,
+			issue.ClosedAt, issue.ExternalRef, sourceRepo, issue.Resolution,
+			issue.SpentMinutes, encodeIssueMetadata(issue.Metadata), issue.ArchivedAt, issue.IDNonce, encodeIssueAttachments(issue.Attachments), issue.ResolvedBy,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to insert issue %s: %w", issue.ID, err)
@@ -7,11 +7,13 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 )
 
-// executeLabelOperation executes a label operation (add or remove) within a transaction
-func (s *SQLiteStorage) executeLabelOperation(
+// executeLabelOperation executes a label operation (add or remove) through tx
+func executeLabelOperation(
 	ctx context.Context,
+	tx dbExecutor,
 	issueID, actor string,
 	labelSQL string,
 	labelSQLArgs []interface{},
@@ -19,38 +21,48 @@ func (s *SQLiteStorage) executeLabelOperation(
 	eventComment string,
 	operationError string,
 ) error {
-	return s.withTx(ctx, func(tx *sql.Tx) error {
-		_, err := tx.ExecContext(ctx, labelSQL, labelSQLArgs...)
-		if err != nil {
-			return fmt.Errorf("%s: %w", operationError, err)
-		}
+	_, err := tx.ExecContext(ctx, labelSQL, labelSQLArgs...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", operationError, err)
+	}
 
-		_, err = tx.ExecContext(ctx, `
-			INSERT INTO events (issue_id, event_type, actor, comment)
-			VALUES (?, ?, ?, ?)
-		`, issueID, eventType, actor, eventComment)
-		if err != nil {
-			return fmt.Errorf("failed to record event: %w", err)
-		}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, issueID, eventType, actor, eventComment)
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
 
-		// Mark issue as dirty for incremental export
-		_, err = tx.ExecContext(ctx, `
-			INSERT INTO dirty_issues (issue_id, marked_at)
-			VALUES (?, ?)
-			ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
-		`, issueID, time.Now())
-		if err != nil {
-			return fmt.Errorf("failed to mark issue dirty: %w", err)
-		}
+	// Mark issue as dirty for incremental export
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO dirty_issues (issue_id, marked_at)
+		VALUES (?, ?)
+		ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+	`, issueID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
 
-		return nil
-	})
+	return nil
 }
 
 // AddLabel adds a label to an issue
 func (s *SQLiteStorage) AddLabel(ctx context.Context, issueID, label, actor string) error {
-	return s.executeLabelOperation(
-		ctx, issueID, actor,
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return addLabelIn(ctx, tx, issueID, label, actor)
+	})
+}
+
+// addLabelIn adds a label through tx. Labels are normalized with
+// util.NormalizeLabel, so adding "Bug" to an issue labeled "bug" is a no-op.
+func addLabelIn(ctx context.Context, tx dbExecutor, issueID, label, actor string) error {
+	label = util.NormalizeLabel(label)
+	if label == "" {
+		return fmt.Errorf("label cannot be empty")
+	}
+	return executeLabelOperation(
+		ctx, tx, issueID, actor,
 		`INSERT OR IGNORE INTO labels (issue_id, label) VALUES (?, ?)`,
 		[]interface{}{issueID, label},
 		types.EventLabelAdded,
@@ -61,8 +73,16 @@ func (s *SQLiteStorage) AddLabel(ctx context.Context, issueID, label, actor stri
 
 // RemoveLabel removes a label from an issue
 func (s *SQLiteStorage) RemoveLabel(ctx context.Context, issueID, label, actor string) error {
-	return s.executeLabelOperation(
-		ctx, issueID, actor,
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return removeLabelIn(ctx, tx, issueID, label, actor)
+	})
+}
+
+// removeLabelIn removes a label through tx
+func removeLabelIn(ctx context.Context, tx dbExecutor, issueID, label, actor string) error {
+	label = util.NormalizeLabel(label)
+	return executeLabelOperation(
+		ctx, tx, issueID, actor,
 		`DELETE FROM labels WHERE issue_id = ? AND label = ?`,
 		[]interface{}{issueID, label},
 		types.EventLabelRemoved,
@@ -71,9 +91,88 @@ func (s *SQLiteStorage) RemoveLabel(ctx context.Context, issueID, label, actor s
 	)
 }
 
+// RenameLabel replaces oldLabel with newLabel on every issue carrying it, in
+// one transaction. Stored labels match oldLabel after normalization, so
+// renaming "Bug" to "bug" fixes copies written before labels were
+// normalized. An issue that already has newLabel just loses oldLabel.
+// Returns the IDs of the issues changed; with dryRun nothing is written.
+func (s *SQLiteStorage) RenameLabel(ctx context.Context, oldLabel, newLabel, actor string, dryRun bool) ([]string, error) {
+	oldLabel = util.NormalizeLabel(oldLabel)
+	newLabel = util.NormalizeLabel(newLabel)
+	if oldLabel == "" || newLabel == "" {
+		return nil, fmt.Errorf("label cannot be empty")
+	}
+
+	var changed []string
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		// Labels are compared normalized in Go, since SQLite's lower() only
+		// folds ASCII
+		rows, err := tx.QueryContext(ctx, `SELECT issue_id, label FROM labels ORDER BY issue_id, label`)
+		if err != nil {
+			return fmt.Errorf("failed to get labels: %w", err)
+		}
+		stale := make(map[string][]string) // Stored labels to replace, by issue
+		hasNew := make(map[string]bool)
+		for rows.Next() {
+			var issueID, label string
+			if err := rows.Scan(&issueID, &label); err != nil {
+				_ = rows.Close()
+				return err
+			}
+			if label == newLabel {
+				hasNew[issueID] = true
+				continue
+			}
+			if util.NormalizeLabel(label) == oldLabel {
+				if _, seen := stale[issueID]; !seen {
+					changed = append(changed, issueID)
+				}
+				stale[issueID] = append(stale[issueID], label)
+			}
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to iterate labels: %w", err)
+		}
+		if dryRun {
+			return nil
+		}
+
+		for _, issueID := range changed {
+			for _, label := range stale[issueID] {
+				if err := executeLabelOperation(
+					ctx, tx, issueID, actor,
+					`DELETE FROM labels WHERE issue_id = ? AND label = ?`,
+					[]interface{}{issueID, label},
+					types.EventLabelRemoved,
+					fmt.Sprintf("Removed label: %s", label),
+					"failed to remove label",
+				); err != nil {
+					return err
+				}
+			}
+			if !hasNew[issueID] {
+				if err := addLabelIn(ctx, tx, issueID, newLabel, actor); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
 // GetLabels returns all labels for an issue
 func (s *SQLiteStorage) GetLabels(ctx context.Context, issueID string) ([]string, error) {
-	rows, err := s.db.QueryContext(ctx, `
+	return getLabels(ctx, s.db, issueID)
+}
+
+// getLabels reads an issue's labels through q
+func getLabels(ctx context.Context, q dbExecutor, issueID string) ([]string, error) {
+	rows, err := q.QueryContext(ctx, `
 		SELECT label FROM labels WHERE issue_id = ? ORDER BY label
 	`, issueID)
 	if err != nil {
@@ -148,12 +247,13 @@ func (s *SQLiteStorage) GetIssuesByLabel(ctx context.Context, label string) ([]*
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		       i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo
+		       i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo, i.resolution,
+		       i.spent_minutes, i.metadata, i.archived_at, i.id_nonce, i.attachments, i.resolved_by
 		FROM issues i
 		JOIN labels l ON i.id = l.issue_id
 		WHERE l.label = ?
 		ORDER BY i.priority ASC, i.created_at DESC
-	`, label)
+	`, util.NormalizeLabel(label))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get issues by label: %w", err)
 	}
@@ -2,6 +2,8 @@ package sqlite
 
 import (
 	"context"
+	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -133,6 +135,58 @@ func TestAddDuplicateLabel(t *testing.T) {
 	}
 }
 
+// TestLabelNormalization tests that labels differing only in case or
+// surrounding whitespace are the same label
+func TestLabelNormalization(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Test issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	for _, label := range []string{"Bug", " bug ", "BUG"} {
+		if err := store.AddLabel(ctx, issue.ID, label, "test-user"); err != nil {
+			t.Fatalf("AddLabel(%q) failed: %v", label, err)
+		}
+	}
+	labels, err := store.GetLabels(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetLabels failed: %v", err)
+	}
+	if len(labels) != 1 || labels[0] != "bug" {
+		t.Errorf("Expected [bug], got %v", labels)
+	}
+	if err := store.AddLabel(ctx, issue.ID, "  ", "test-user"); err == nil {
+		t.Error("Expected error adding an empty label")
+	}
+
+	// Filters match regardless of case
+	found, err := store.SearchIssues(ctx, "", types.IssueFilter{Labels: []string{"BUG"}})
+	if err != nil || len(found) != 1 {
+		t.Errorf("Expected --label BUG to match, got %d issues (%v)", len(found), err)
+	}
+	found, err = store.SearchIssues(ctx, "", types.IssueFilter{LabelsAny: []string{"ui", "Bug"}})
+	if err != nil || len(found) != 1 {
+		t.Errorf("Expected --label-any ui,Bug to match, got %d issues (%v)", len(found), err)
+	}
+
+	if err := store.RemoveLabel(ctx, issue.ID, "Bug", "test-user"); err != nil {
+		t.Fatalf("RemoveLabel failed: %v", err)
+	}
+	if labels, _ := store.GetLabels(ctx, issue.ID); len(labels) != 0 {
+		t.Errorf("Expected RemoveLabel to match regardless of case, got %v", labels)
+	}
+}
+
 func TestRemoveLabel(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -392,3 +446,97 @@ func TestLabelMarksDirty(t *testing.T) {
 		t.Error("Expected issue to be marked dirty after removing label")
 	}
 }
+
+func TestRenameLabel(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// typo: labeled frontnd; merged: labeled frontnd and frontend; other: labeled ui
+	var ids []string
+	for _, labels := range [][]string{{"frontnd", "ui"}, {"frontnd", "frontend"}, {"ui"}} {
+		issue := &types.Issue{Title: "Labeled", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		for _, label := range labels {
+			if err := store.AddLabel(ctx, issue.ID, label, "test-user"); err != nil {
+				t.Fatalf("AddLabel failed: %v", err)
+			}
+		}
+		ids = append(ids, issue.ID)
+	}
+	typo, merged, other := ids[0], ids[1], ids[2]
+	wantChanged := []string{typo, merged}
+	sort.Strings(wantChanged)
+
+	// A dry run reports the issues but changes nothing
+	changed, err := store.RenameLabel(ctx, "Frontnd", "frontend", "test-user", true)
+	if err != nil {
+		t.Fatalf("RenameLabel dry run failed: %v", err)
+	}
+	if !reflect.DeepEqual(changed, wantChanged) {
+		t.Errorf("dry run changed = %v, want %v", changed, wantChanged)
+	}
+	if labels, _ := store.GetLabels(ctx, typo); !reflect.DeepEqual(labels, []string{"frontnd", "ui"}) {
+		t.Errorf("dry run changed labels to %v", labels)
+	}
+
+	changed, err = store.RenameLabel(ctx, "Frontnd", "frontend", "test-user", false)
+	if err != nil {
+		t.Fatalf("RenameLabel failed: %v", err)
+	}
+	if !reflect.DeepEqual(changed, wantChanged) {
+		t.Errorf("changed = %v, want %v", changed, wantChanged)
+	}
+	for id, want := range map[string][]string{typo: {"frontend", "ui"}, merged: {"frontend"}, other: {"ui"}} {
+		labels, err := store.GetLabels(ctx, id)
+		if err != nil {
+			t.Fatalf("GetLabels failed: %v", err)
+		}
+		if !reflect.DeepEqual(labels, want) {
+			t.Errorf("labels of %s = %v, want %v", id, labels, want)
+		}
+	}
+
+	// The merged issue only records the removal, since it had frontend already
+	events, err := store.GetEvents(ctx, merged, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	var removed, added int
+	for _, event := range events {
+		if event.Comment == nil {
+			continue
+		}
+		switch *event.Comment {
+		case "Removed label: frontnd":
+			removed++
+		case "Added label: frontend":
+			added++
+		}
+	}
+	if removed != 1 || added != 1 {
+		t.Errorf("merged issue has %d removal and %d addition events, want 1 and 1 (the addition from setup)", removed, added)
+	}
+
+	// Labels stored before normalization are renamed to their normalized form
+	if _, err := store.db.Exec(`INSERT INTO labels (issue_id, label) VALUES (?, 'Bug')`, other); err != nil {
+		t.Fatalf("failed to insert label: %v", err)
+	}
+	changed, err = store.RenameLabel(ctx, "Bug", "bug", "test-user", false)
+	if err != nil {
+		t.Fatalf("RenameLabel failed: %v", err)
+	}
+	if !reflect.DeepEqual(changed, []string{other}) {
+		t.Errorf("changed = %v, want [%s]", changed, other)
+	}
+	if labels, _ := store.GetLabels(ctx, other); !reflect.DeepEqual(labels, []string{"bug", "ui"}) {
+		t.Errorf("labels = %v, want [bug ui]", labels)
+	}
+
+	if _, err := store.RenameLabel(ctx, "ui", " ", "test-user", false); err == nil {
+		t.Error("expected an error renaming to an empty label")
+	}
+}
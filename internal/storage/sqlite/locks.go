@@ -0,0 +1,128 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AcquireLock takes an advisory lock on an issue for holder, expiring after ttl.
+// A ttl of 0 uses the lock.ttl config value, falling back to types.DefaultLockTTL.
+// Re-acquiring a lock already held by holder renews its expiry. A lock held by
+// someone else is only replaced once it has expired.
+func (s *SQLiteStorage) AcquireLock(ctx context.Context, issueID, holder string, ttl time.Duration) (*types.IssueLock, error) {
+	if ttl == 0 {
+		configured, err := s.GetConfig(ctx, types.LockTTLConfigKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", types.LockTTLConfigKey, err)
+		}
+		ttl, err = types.ParseLockTTL(configured)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if ttl < 0 {
+		return nil, fmt.Errorf("lock TTL must be positive, got %s", ttl)
+	}
+
+	now := time.Now()
+	lock := &types.IssueLock{
+		IssueID:    issueID,
+		Holder:     holder,
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(ttl),
+	}
+
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		existing, err := getLock(ctx, tx, issueID)
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.ExpiresAt.After(now) {
+			if existing.Holder != holder {
+				return fmt.Errorf("issue %s is locked by %s until %s",
+					issueID, existing.Holder, existing.ExpiresAt.Format(time.RFC3339))
+			}
+			// Renewal keeps the original acquisition time
+			lock.AcquiredAt = existing.AcquiredAt
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO locks (issue_id, holder, acquired_at, expires_at)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT (issue_id) DO UPDATE SET
+				holder = excluded.holder,
+				acquired_at = excluded.acquired_at,
+				expires_at = excluded.expires_at
+		`, lock.IssueID, lock.Holder, lock.AcquiredAt, lock.ExpiresAt)
+		if err != nil {
+			return fmt.Errorf("failed to acquire lock: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lock, nil
+}
+
+// ReleaseLock releases the lock on an issue. Releasing a live lock held by
+// someone else requires force. Releasing an issue that isn't locked is a no-op.
+func (s *SQLiteStorage) ReleaseLock(ctx context.Context, issueID, holder string, force bool) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		existing, err := getLock(ctx, tx, issueID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return nil
+		}
+		if existing.Holder != holder && existing.ExpiresAt.After(time.Now()) && !force {
+			return fmt.Errorf("issue %s is locked by %s, not %s", issueID, existing.Holder, holder)
+		}
+
+		_, err = tx.ExecContext(ctx, `DELETE FROM locks WHERE issue_id = ?`, issueID)
+		if err != nil {
+			return fmt.Errorf("failed to release lock: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetLock returns the live lock on an issue, or nil if it is unlocked or the
+// lock has expired
+func (s *SQLiteStorage) GetLock(ctx context.Context, issueID string) (*types.IssueLock, error) {
+	lock, err := getLock(ctx, s.db, issueID)
+	if err != nil || lock == nil {
+		return nil, err
+	}
+	if !lock.ExpiresAt.After(time.Now()) {
+		return nil, nil
+	}
+	return lock, nil
+}
+
+// rowQuerier is satisfied by both *sql.DB and *sql.Tx
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// getLock reads the lock row for an issue, including expired locks
+func getLock(ctx context.Context, q rowQuerier, issueID string) (*types.IssueLock, error) {
+	var lock types.IssueLock
+	err := q.QueryRowContext(ctx, `
+		SELECT issue_id, holder, acquired_at, expires_at
+		FROM locks
+		WHERE issue_id = ?
+	`, issueID).Scan(&lock.IssueID, &lock.Holder, &lock.AcquiredAt, &lock.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lock: %w", err)
+	}
+	return &lock, nil
+}
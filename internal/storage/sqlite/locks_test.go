@@ -0,0 +1,155 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestAcquireAndReleaseLock(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Test issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	lock, err := store.AcquireLock(ctx, issue.ID, "alice", time.Hour)
+	if err != nil {
+		t.Fatalf("AcquireLock failed: %v", err)
+	}
+	if lock.Holder != "alice" {
+		t.Errorf("Expected holder alice, got %s", lock.Holder)
+	}
+
+	// Another actor can't take a live lock
+	if _, err := store.AcquireLock(ctx, issue.ID, "bob", time.Hour); err == nil {
+		t.Error("Expected error acquiring lock held by another actor")
+	}
+
+	// Renewing keeps the original acquisition time
+	renewed, err := store.AcquireLock(ctx, issue.ID, "alice", 2*time.Hour)
+	if err != nil {
+		t.Fatalf("Renewing lock failed: %v", err)
+	}
+	if !renewed.AcquiredAt.Equal(lock.AcquiredAt) {
+		t.Errorf("Expected renewal to keep acquired_at %v, got %v", lock.AcquiredAt, renewed.AcquiredAt)
+	}
+	if !renewed.ExpiresAt.After(lock.ExpiresAt) {
+		t.Error("Expected renewal to extend expiry")
+	}
+
+	got, err := store.GetLock(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetLock failed: %v", err)
+	}
+	if got == nil || got.Holder != "alice" {
+		t.Fatalf("Expected lock held by alice, got %+v", got)
+	}
+
+	// Only the holder can release without force
+	if err := store.ReleaseLock(ctx, issue.ID, "bob", false); err == nil {
+		t.Error("Expected error releasing lock held by another actor")
+	}
+	if err := store.ReleaseLock(ctx, issue.ID, "bob", true); err != nil {
+		t.Fatalf("Forced ReleaseLock failed: %v", err)
+	}
+
+	got, err = store.GetLock(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetLock failed: %v", err)
+	}
+	if got != nil {
+		t.Errorf("Expected no lock after release, got %+v", got)
+	}
+
+	// Releasing an unlocked issue is a no-op
+	if err := store.ReleaseLock(ctx, issue.ID, "alice", false); err != nil {
+		t.Errorf("ReleaseLock on unlocked issue failed: %v", err)
+	}
+}
+
+func TestLockExpiry(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Test issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if _, err := store.AcquireLock(ctx, issue.ID, "alice", 50*time.Millisecond); err != nil {
+		t.Fatalf("AcquireLock failed: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	got, err := store.GetLock(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetLock failed: %v", err)
+	}
+	if got != nil {
+		t.Errorf("Expected expired lock to be ignored, got %+v", got)
+	}
+
+	// An expired lock can be taken over
+	lock, err := store.AcquireLock(ctx, issue.ID, "bob", time.Hour)
+	if err != nil {
+		t.Fatalf("Taking over expired lock failed: %v", err)
+	}
+	if lock.Holder != "bob" {
+		t.Errorf("Expected holder bob, got %s", lock.Holder)
+	}
+}
+
+func TestAcquireLockConfiguredTTL(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issue := &types.Issue{
+		Title:     "Test issue",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	if err := store.SetConfig(ctx, types.LockTTLConfigKey, "2h"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+
+	lock, err := store.AcquireLock(ctx, issue.ID, "alice", 0)
+	if err != nil {
+		t.Fatalf("AcquireLock failed: %v", err)
+	}
+	if ttl := lock.ExpiresAt.Sub(lock.AcquiredAt); ttl != 2*time.Hour {
+		t.Errorf("Expected configured TTL of 2h, got %s", ttl)
+	}
+
+	if err := store.SetConfig(ctx, types.LockTTLConfigKey, "soon"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if _, err := store.AcquireLock(ctx, issue.ID, "alice", 0); err == nil {
+		t.Error("Expected error for invalid lock.ttl config")
+	}
+}
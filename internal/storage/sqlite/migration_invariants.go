@@ -4,8 +4,11 @@ package sqlite
 import (
 	"database/sql"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
 )
 
 // Snapshot captures database state before migrations for validation
@@ -119,6 +122,11 @@ func checkRequiredConfig(db *sql.DB, snapshot *Snapshot) error {
 		return nil
 	}
 
+	// BEADS_ISSUE_PREFIX stands in for the stored prefix
+	if os.Getenv(types.IssuePrefixEnvVar) != "" {
+		return nil
+	}
+
 	// Check for required config keys
 	var value string
 	err = db.QueryRow("SELECT value FROM config WHERE key = 'issue_prefix'").Scan(&value)
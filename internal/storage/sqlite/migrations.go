@@ -31,6 +31,19 @@ var migrationsList = []Migration{
 	{"source_repo_column", migrations.MigrateSourceRepoColumn},
 	{"repo_mtimes_table", migrations.MigrateRepoMtimesTable},
 	{"child_counters_table", migrations.MigrateChildCountersTable},
+	{"locks_table", migrations.MigrateLocksTable},
+	{"comment_threading", migrations.MigrateCommentThreading},
+	{"event_note_column", migrations.MigrateEventNoteColumn},
+	{"issues_fts", migrations.MigrateIssuesFTS},
+	{"resolution_column", migrations.MigrateResolutionColumn},
+	{"spent_minutes_column", migrations.MigrateSpentMinutesColumn},
+	{"issue_metadata_column", migrations.MigrateIssueMetadataColumn},
+	{"comment_history_columns", migrations.MigrateCommentHistoryColumns},
+	{"normalize_labels", migrations.MigrateNormalizeLabels},
+	{"archived_at_column", migrations.MigrateArchivedAtColumn},
+	{"id_nonce_column", migrations.MigrateIDNonceColumn},
+	{"attachments_column", migrations.MigrateAttachmentsColumn},
+	{"resolved_by_column", migrations.MigrateResolvedByColumn},
 }
 
 // MigrationInfo contains metadata about a migration for inspection
@@ -69,6 +82,19 @@ func getMigrationDescription(name string) string {
 		"source_repo_column":           "Adds source_repo column for multi-repo support",
 		"repo_mtimes_table":            "Adds repo_mtimes table for multi-repo hydration caching",
 		"child_counters_table":         "Adds child_counters table for hierarchical ID generation with ON DELETE CASCADE",
+		"locks_table":                  "Adds locks table for advisory issue locking",
+		"comment_threading":            "Adds parent_comment_id and resolved columns to comments for threaded discussions",
+		"event_note_column":            "Adds note column to events for status-change notes",
+		"issues_fts":                   "Adds FTS5 full-text index over issue text fields (skipped if FTS5 is unavailable)",
+		"resolution_column":            "Adds resolution column recording why an issue was closed",
+		"spent_minutes_column":         "Adds spent_minutes column recording time spent on an issue",
+		"issue_metadata_column":        "Adds metadata column holding custom issue fields as JSON",
+		"comment_history_columns":      "Adds original_text, edited_at and deleted_at columns to comments for edit history and tombstones",
+		"normalize_labels":             "Trims and lowercases labels, merging case-only duplicates",
+		"archived_at_column":           "Adds archived_at column marking issues hidden by bd archive",
+		"id_nonce_column":              "Adds id_nonce column recording the nonce an issue's hash ID was derived from",
+		"attachments_column":           "Adds attachments column holding issue attachment references as JSON",
+		"resolved_by_column":           "Adds resolved_by column recording the commit that resolved a closed issue",
 	}
 	
 	if desc, ok := descriptions[name]; ok {
@@ -31,6 +31,9 @@ func MigrateExternalRefColumn(db *sql.DB) error {
 	if err := rows.Err(); err != nil {
 		return fmt.Errorf("error reading column info: %w", err)
 	}
+	// Release the connection before issuing DDL; :memory: databases are
+	// limited to a single connection and would otherwise deadlock here
+	_ = rows.Close()
 
 	if !columnExists {
 		_, err := db.Exec(`ALTER TABLE issues ADD COLUMN external_ref TEXT`)
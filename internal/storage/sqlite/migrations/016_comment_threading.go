@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateCommentThreading adds parent_comment_id and resolved columns to
+// comments so discussions can be threaded and marked resolved
+func MigrateCommentThreading(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('comments')
+		WHERE name = 'parent_comment_id'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check parent_comment_id column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE comments ADD COLUMN parent_comment_id INTEGER REFERENCES comments(id) ON DELETE CASCADE`)
+	if err != nil {
+		return fmt.Errorf("failed to add parent_comment_id column: %w", err)
+	}
+
+	_, err = db.Exec(`ALTER TABLE comments ADD COLUMN resolved INTEGER NOT NULL DEFAULT 0`)
+	if err != nil {
+		return fmt.Errorf("failed to add resolved column: %w", err)
+	}
+
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_comments_parent ON comments(parent_comment_id)`)
+	if err != nil {
+		return fmt.Errorf("failed to create parent_comment_id index: %w", err)
+	}
+
+	return nil
+}
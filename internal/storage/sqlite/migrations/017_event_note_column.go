@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateEventNoteColumn adds a note column to events for status-change notes
+// that are recorded on the event rather than as a comment
+func MigrateEventNoteColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('events')
+		WHERE name = 'note'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check note column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE events ADD COLUMN note TEXT`)
+	if err != nil {
+		return fmt.Errorf("failed to add note column: %w", err)
+	}
+
+	return nil
+}
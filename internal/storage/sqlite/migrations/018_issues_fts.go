@@ -0,0 +1,116 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// issuesFTSTriggers keep issues_fts in step with the issues table. The index
+// stores its own copy of the text keyed by issue ID rather than pointing at
+// issues' rowid, which VACUUM may renumber.
+var issuesFTSTriggers = []string{
+	`CREATE TRIGGER IF NOT EXISTS issues_fts_insert AFTER INSERT ON issues BEGIN
+		INSERT INTO issues_fts (id, title, description, notes, design, acceptance_criteria)
+		VALUES (new.id, new.title, new.description, new.notes, new.design, new.acceptance_criteria);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS issues_fts_delete AFTER DELETE ON issues BEGIN
+		DELETE FROM issues_fts WHERE id = old.id;
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS issues_fts_update
+	AFTER UPDATE OF id, title, description, notes, design, acceptance_criteria ON issues BEGIN
+		DELETE FROM issues_fts WHERE id = old.id;
+		INSERT INTO issues_fts (id, title, description, notes, design, acceptance_criteria)
+		VALUES (new.id, new.title, new.description, new.notes, new.design, new.acceptance_criteria);
+	END`,
+}
+
+// MigrateIssuesFTS adds the issues_fts full-text index over issue text
+// fields, backfilled from existing rows. SQLite builds without FTS5 are left
+// unchanged, and search keeps using substring matching.
+func MigrateIssuesFTS(db *sql.DB) error {
+	var tableName string
+	err := db.QueryRow(`
+		SELECT name FROM sqlite_master
+		WHERE type='table' AND name='issues_fts'
+	`).Scan(&tableName)
+
+	if err == sql.ErrNoRows {
+		tx, err := db.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		_, err = tx.Exec(`
+			CREATE VIRTUAL TABLE issues_fts USING fts5(
+				id UNINDEXED, title, description, notes, design, acceptance_criteria
+			)
+		`)
+		if err != nil {
+			if strings.Contains(err.Error(), "no such module") {
+				return nil
+			}
+			return fmt.Errorf("failed to create issues_fts table: %w", err)
+		}
+
+		if err := backfillIssuesFTS(tx); err != nil {
+			return err
+		}
+
+		for _, trigger := range issuesFTSTriggers {
+			if _, err := tx.Exec(trigger); err != nil {
+				return fmt.Errorf("failed to create issues_fts trigger: %w", err)
+			}
+		}
+		return tx.Commit()
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to check for issues_fts table: %w", err)
+	}
+
+	// Triggers go away with the issues table if it is ever rebuilt; the
+	// index may have missed writes since, so start it over
+	var triggerCount int
+	err = db.QueryRow(`
+		SELECT COUNT(*) FROM sqlite_master
+		WHERE type='trigger' AND name LIKE 'issues_fts_%'
+	`).Scan(&triggerCount)
+	if err != nil {
+		return fmt.Errorf("failed to check issues_fts triggers: %w", err)
+	}
+	if triggerCount == len(issuesFTSTriggers) {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if err := backfillIssuesFTS(tx); err != nil {
+		return err
+	}
+	for _, trigger := range issuesFTSTriggers {
+		if _, err := tx.Exec(trigger); err != nil {
+			return fmt.Errorf("failed to create issues_fts trigger: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// backfillIssuesFTS replaces the index contents with the current issues
+func backfillIssuesFTS(tx *sql.Tx) error {
+	if _, err := tx.Exec(`DELETE FROM issues_fts`); err != nil {
+		return fmt.Errorf("failed to clear issues_fts: %w", err)
+	}
+	_, err := tx.Exec(`
+		INSERT INTO issues_fts (id, title, description, notes, design, acceptance_criteria)
+		SELECT id, title, description, notes, design, acceptance_criteria FROM issues
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to backfill issues_fts: %w", err)
+	}
+	return nil
+}
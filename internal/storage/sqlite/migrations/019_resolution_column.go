@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateResolutionColumn adds the resolution column recording why an issue
+// was closed (fixed, wontfix, duplicate, obsolete)
+func MigrateResolutionColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'resolution'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check resolution column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN resolution TEXT NOT NULL DEFAULT ''`)
+	if err != nil {
+		return fmt.Errorf("failed to add resolution column: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateSpentMinutesColumn adds the spent_minutes column recording time
+// spent on an issue alongside its estimated_minutes
+func MigrateSpentMinutesColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'spent_minutes'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check spent_minutes column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN spent_minutes INTEGER`)
+	if err != nil {
+		return fmt.Errorf("failed to add spent_minutes column: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateIssueMetadataColumn adds the metadata column holding an issue's
+// custom fields as a JSON object
+func MigrateIssueMetadataColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'metadata'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check metadata column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN metadata TEXT`)
+	if err != nil {
+		return fmt.Errorf("failed to add metadata column: %w", err)
+	}
+
+	return nil
+}
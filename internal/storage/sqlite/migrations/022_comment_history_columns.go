@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateCommentHistoryColumns adds original_text, edited_at and deleted_at
+// columns to comments so edits keep the text as first written and deletions
+// leave a tombstone that sync won't resurrect
+func MigrateCommentHistoryColumns(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('comments')
+		WHERE name = 'deleted_at'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check deleted_at column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	for _, column := range []string{"original_text TEXT", "edited_at DATETIME", "deleted_at DATETIME"} {
+		if _, err := db.Exec(`ALTER TABLE comments ADD COLUMN ` + column); err != nil {
+			return fmt.Errorf("failed to add comments column %s: %w", column, err)
+		}
+	}
+
+	return nil
+}
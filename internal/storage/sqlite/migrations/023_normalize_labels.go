@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/util"
+)
+
+// MigrateNormalizeLabels trims and lowercases existing labels, merging
+// duplicates such as "Bug" and "bug". Issues whose labels changed are marked
+// dirty so the next export picks them up.
+func MigrateNormalizeLabels(db *sql.DB) error {
+	// SQLite's LOWER only folds ASCII, so also check any label with
+	// non-ASCII characters in Go
+	rows, err := db.Query(`
+		SELECT issue_id, label FROM labels
+		WHERE label != LOWER(TRIM(label)) OR label GLOB '*[^ -~]*'
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to query labels: %w", err)
+	}
+	type label struct{ issueID, label, normalized string }
+	var changed []label
+	for rows.Next() {
+		var l label
+		if err := rows.Scan(&l.issueID, &l.label); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan label: %w", err)
+		}
+		if l.normalized = util.NormalizeLabel(l.label); l.normalized != l.label {
+			changed = append(changed, l)
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read labels: %w", err)
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now()
+	for _, l := range changed {
+		if l.normalized != "" {
+			if _, err := tx.Exec(`INSERT OR IGNORE INTO labels (issue_id, label) VALUES (?, ?)`, l.issueID, l.normalized); err != nil {
+				return fmt.Errorf("failed to normalize label %q on %s: %w", l.label, l.issueID, err)
+			}
+		}
+		if _, err := tx.Exec(`DELETE FROM labels WHERE issue_id = ? AND label = ?`, l.issueID, l.label); err != nil {
+			return fmt.Errorf("failed to remove label %q from %s: %w", l.label, l.issueID, err)
+		}
+		_, err := tx.Exec(`
+			INSERT INTO dirty_issues (issue_id, marked_at) VALUES (?, ?)
+			ON CONFLICT (issue_id) DO UPDATE SET marked_at = excluded.marked_at
+		`, l.issueID, now)
+		if err != nil {
+			return fmt.Errorf("failed to mark %s dirty: %w", l.issueID, err)
+		}
+	}
+
+	return tx.Commit()
+}
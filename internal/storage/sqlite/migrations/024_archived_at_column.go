@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateArchivedAtColumn adds the archived_at column set by bd archive, with
+// an index since every default search filters on it
+func MigrateArchivedAtColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'archived_at'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check archived_at column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN archived_at DATETIME`)
+	if err != nil {
+		return fmt.Errorf("failed to add archived_at column: %w", err)
+	}
+
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_issues_archived_at ON issues(archived_at)`)
+	if err != nil {
+		return fmt.Errorf("failed to create archived_at index: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateIDNonceColumn adds the id_nonce column recording the nonce an
+// issue's hash ID was derived from. Issues created before it keep a NULL
+// nonce: their IDs came from the older title, description, creator and
+// timestamp derivation.
+func MigrateIDNonceColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'id_nonce'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check id_nonce column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN id_nonce INTEGER`)
+	if err != nil {
+		return fmt.Errorf("failed to add id_nonce column: %w", err)
+	}
+
+	return nil
+}
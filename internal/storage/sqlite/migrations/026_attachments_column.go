@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateAttachmentsColumn adds the attachments column holding an issue's
+// attachment references (URLs or repo-relative paths) as a JSON array
+func MigrateAttachmentsColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'attachments'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check attachments column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN attachments TEXT`)
+	if err != nil {
+		return fmt.Errorf("failed to add attachments column: %w", err)
+	}
+
+	return nil
+}
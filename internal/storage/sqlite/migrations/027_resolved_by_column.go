@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateResolvedByColumn adds the resolved_by column recording the SHA of
+// the commit that resolved a closed issue
+func MigrateResolvedByColumn(db *sql.DB) error {
+	var columnExists bool
+	err := db.QueryRow(`
+		SELECT COUNT(*) > 0
+		FROM pragma_table_info('issues')
+		WHERE name = 'resolved_by'
+	`).Scan(&columnExists)
+	if err != nil {
+		return fmt.Errorf("failed to check resolved_by column: %w", err)
+	}
+
+	if columnExists {
+		return nil
+	}
+
+	_, err = db.Exec(`ALTER TABLE issues ADD COLUMN resolved_by TEXT NOT NULL DEFAULT ''`)
+	if err != nil {
+		return fmt.Errorf("failed to add resolved_by column: %w", err)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -401,6 +402,48 @@ func TestMigrateRepoMtimesTable(t *testing.T) {
 	})
 }
 
+func TestMigrateNormalizeLabels(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	db := store.db
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Labeled", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	// Labels written before normalization, bypassing AddLabel
+	for _, label := range []string{"Bug", "bug", " UI", "Énorme"} {
+		if _, err := db.Exec(`INSERT INTO labels (issue_id, label) VALUES (?, ?)`, issue.ID, label); err != nil {
+			t.Fatalf("failed to insert label %q: %v", label, err)
+		}
+	}
+	if err := store.ClearDirtyIssuesByID(ctx, []string{issue.ID}); err != nil {
+		t.Fatalf("ClearDirtyIssuesByID failed: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := migrations.MigrateNormalizeLabels(db); err != nil {
+			t.Fatalf("migration run %d failed: %v", i+1, err)
+		}
+	}
+
+	labels, err := store.GetLabels(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetLabels failed: %v", err)
+	}
+	if want := []string{"bug", "ui", "énorme"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("labels = %v, want %v", labels, want)
+	}
+	dirty, err := store.GetDirtyIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetDirtyIssues failed: %v", err)
+	}
+	if len(dirty) != 1 || dirty[0] != issue.ID {
+		t.Errorf("dirty = %v, want the relabeled issue", dirty)
+	}
+}
+
 func TestMigrateContentHashColumn(t *testing.T) {
 	t.Run("adds content_hash column if missing", func(t *testing.T) {
 		s, cleanup := setupTestDB(t)
@@ -478,9 +521,16 @@ func TestMigrateContentHashColumn(t *testing.T) {
 				original_size INTEGER,
 				compacted_at_commit TEXT,
 				source_repo TEXT DEFAULT '.',
+				resolution TEXT NOT NULL DEFAULT '',
+				spent_minutes INTEGER,
+				metadata TEXT,
+				archived_at DATETIME,
+				id_nonce INTEGER,
+				attachments TEXT,
+				resolved_by TEXT NOT NULL DEFAULT '',
 				CHECK ((status = 'closed') = (closed_at IS NOT NULL))
 			);
-			INSERT INTO issues SELECT id, title, description, design, acceptance_criteria, notes, status, priority, issue_type, assignee, estimated_minutes, created_at, updated_at, closed_at, external_ref, compaction_level, compacted_at, original_size, compacted_at_commit, source_repo FROM issues_backup;
+			INSERT INTO issues SELECT id, title, description, design, acceptance_criteria, notes, status, priority, issue_type, assignee, estimated_minutes, created_at, updated_at, closed_at, external_ref, compaction_level, compacted_at, original_size, compacted_at_commit, source_repo, resolution, spent_minutes, metadata, archived_at, id_nonce, attachments, resolved_by FROM issues_backup;
 			DROP TABLE issues_backup;
 		`)
 		if err != nil {
@@ -502,3 +552,79 @@ func TestMigrateContentHashColumn(t *testing.T) {
 		}
 	})
 }
+
+func TestMigrateIssuesFTS(t *testing.T) {
+	ctx := context.Background()
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	db := store.db
+	if !store.hasFTS {
+		t.Skip("SQLite built without FTS5")
+	}
+
+	issue := &types.Issue{Title: "Flaky websocket reconnect", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	countMatches := func() int {
+		t.Helper()
+		var n int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM issues_fts WHERE issues_fts MATCH 'websocket'`).Scan(&n); err != nil {
+			t.Fatalf("failed to query issues_fts: %v", err)
+		}
+		return n
+	}
+
+	t.Run("backfills existing issues", func(t *testing.T) {
+		for _, stmt := range []string{
+			"DROP TRIGGER IF EXISTS issues_fts_insert",
+			"DROP TRIGGER IF EXISTS issues_fts_delete",
+			"DROP TRIGGER IF EXISTS issues_fts_update",
+			"DROP TABLE IF EXISTS issues_fts",
+		} {
+			if _, err := db.Exec(stmt); err != nil {
+				t.Fatalf("%s: %v", stmt, err)
+			}
+		}
+
+		if err := migrations.MigrateIssuesFTS(db); err != nil {
+			t.Fatalf("failed to migrate issues_fts: %v", err)
+		}
+		if n := countMatches(); n != 1 {
+			t.Errorf("expected backfilled index to match 1 issue, got %d", n)
+		}
+	})
+
+	t.Run("rebuilds the index when triggers are missing", func(t *testing.T) {
+		if _, err := db.Exec("DROP TRIGGER issues_fts_update"); err != nil {
+			t.Fatalf("failed to drop trigger: %v", err)
+		}
+		if _, err := db.Exec(`UPDATE issues SET title = 'Flaky websocket heartbeat' WHERE id = ?`, issue.ID); err != nil {
+			t.Fatalf("failed to update issue: %v", err)
+		}
+
+		if err := migrations.MigrateIssuesFTS(db); err != nil {
+			t.Fatalf("failed to migrate issues_fts: %v", err)
+		}
+		var n int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM issues_fts WHERE issues_fts MATCH 'heartbeat'`).Scan(&n); err != nil {
+			t.Fatalf("failed to query issues_fts: %v", err)
+		}
+		if n != 1 {
+			t.Errorf("expected rebuilt index to match the new title, got %d", n)
+		}
+		if n := countMatches(); n != 1 {
+			t.Errorf("expected one index row per issue, got %d", n)
+		}
+	})
+
+	t.Run("is idempotent", func(t *testing.T) {
+		if err := migrations.MigrateIssuesFTS(db); err != nil {
+			t.Fatalf("second migration failed: %v", err)
+		}
+		if n := countMatches(); n != 1 {
+			t.Errorf("expected index unchanged, got %d matches", n)
+		}
+	})
+}
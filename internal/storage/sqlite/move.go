@@ -0,0 +1,170 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// MoveIssue reparents childID under newParentID in one transaction: the old
+// parent-child dependency is replaced, the child is renamed to the next child
+// ID of the new parent, and its hierarchical descendants (childID.1, ...) are
+// renamed to match. Returns the old → new ID of every renamed issue.
+func (s *SQLiteStorage) MoveIssue(ctx context.Context, childID, newParentID string, actor string) (map[string]string, error) {
+	var renamed map[string]string
+	err := s.withConnTx(ctx, func(conn *sql.Conn) error {
+		// Rows briefly point at old IDs while issues are renamed
+		if _, err := conn.ExecContext(ctx, `PRAGMA defer_foreign_keys = ON`); err != nil {
+			return fmt.Errorf("failed to defer foreign keys: %w", err)
+		}
+		var err error
+		renamed, err = moveIssueIn(ctx, conn, childID, newParentID, actor)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return renamed, nil
+}
+
+func moveIssueIn(ctx context.Context, tx dbExecutor, childID, newParentID string, actor string) (map[string]string, error) {
+	if childID == newParentID {
+		return nil, fmt.Errorf("cannot move %s under itself", childID)
+	}
+	child, err := getIssue(ctx, tx, childID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue %s: %w", childID, err)
+	}
+	if child == nil {
+		return nil, fmt.Errorf("issue %s not found", childID)
+	}
+	parent, err := getIssue(ctx, tx, newParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent %s: %w", newParentID, err)
+	}
+	if parent == nil {
+		return nil, fmt.Errorf("parent issue %s not found", newParentID)
+	}
+
+	// A new parent nested under the child, or depending on it, would close a cycle
+	if strings.HasPrefix(newParentID, childID+".") {
+		return nil, fmt.Errorf("cannot move %s under its own descendant %s", childID, newParentID)
+	}
+	cycle, err := detectCycleIn(ctx, tx, childID, newParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for cycles: %w", err)
+	}
+	if cycle != nil {
+		return nil, fmt.Errorf("cannot move %s under %s: would create a cycle (%s)", childID, newParentID, strings.Join(cycle, " → "))
+	}
+
+	// Hierarchical descendants are renamed along with the child, so the
+	// deepest of them must still fit under the new parent
+	descendants, err := hierarchicalDescendantsIn(ctx, tx, childID)
+	if err != nil {
+		return nil, err
+	}
+	deepest := 0
+	for _, id := range descendants {
+		if depth := strings.Count(id[len(childID):], "."); depth > deepest {
+			deepest = depth
+		}
+	}
+	if strings.Count(newParentID, ".")+1+deepest > 3 {
+		return nil, fmt.Errorf("maximum hierarchy depth (3) exceeded moving %s under %s", childID, newParentID)
+	}
+
+	// Replace the parent-child edge
+	deps, err := getDependencyRecords(ctx, tx, childID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependencies for %s: %w", childID, err)
+	}
+	for _, dep := range deps {
+		if dep.Type != types.DepParentChild {
+			continue
+		}
+		if dep.DependsOnID == newParentID {
+			return nil, fmt.Errorf("%s is already a child of %s", childID, newParentID)
+		}
+		if err := removeDependencyIn(ctx, tx, childID, dep.DependsOnID, actor); err != nil {
+			return nil, err
+		}
+	}
+	if err := addDependencyIn(ctx, tx, &types.Dependency{
+		IssueID:     childID,
+		DependsOnID: newParentID,
+		Type:        types.DepParentChild,
+	}, actor); err != nil {
+		return nil, err
+	}
+
+	// Skip numbers held by children created with explicit IDs
+	var newID string
+	for {
+		num, err := nextChildNumberIn(ctx, tx, newParentID)
+		if err != nil {
+			return nil, err
+		}
+		newID = fmt.Sprintf("%s.%d", newParentID, num)
+		existing, err := getIssue(ctx, tx, newID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check for existing issue %s: %w", newID, err)
+		}
+		if existing == nil {
+			break
+		}
+	}
+
+	renamed := map[string]string{childID: newID}
+	for _, id := range descendants {
+		renamed[id] = newID + id[len(childID):]
+	}
+	ids := append([]string{childID}, descendants...)
+	for _, oldID := range ids {
+		issue := child
+		if oldID != childID {
+			if issue, err = getIssue(ctx, tx, oldID); err != nil {
+				return nil, fmt.Errorf("failed to get issue %s: %w", oldID, err)
+			}
+		}
+		if err := updateIssueIDIn(ctx, tx, oldID, renamed[oldID], issue, actor); err != nil {
+			return nil, err
+		}
+	}
+
+	// Free the child's old number if it was the old parent's newest
+	if err := reclaimChildNumber(ctx, tx, childID); err != nil {
+		return nil, err
+	}
+	return renamed, nil
+}
+
+// hierarchicalDescendantsIn returns the IDs nested under id (id.1, id.1.2, ...),
+// sorted
+func hierarchicalDescendantsIn(ctx context.Context, q dbExecutor, id string) ([]string, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT id FROM issues WHERE substr(id, 1, length(?1) + 1) = ?1 || '.'
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find descendants of %s: %w", id, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var descendant string
+		if err := rows.Scan(&descendant); err != nil {
+			return nil, fmt.Errorf("failed to scan descendant of %s: %w", id, err)
+		}
+		ids = append(ids, descendant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
@@ -0,0 +1,111 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestMoveIssue(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	create := func(id string, issueType types.IssueType, parentID string) {
+		t.Helper()
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 2, IssueType: issueType}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("failed to create %s: %v", id, err)
+		}
+		if parentID != "" {
+			dep := &types.Dependency{IssueID: id, DependsOnID: parentID, Type: types.DepParentChild}
+			if err := store.AddDependency(ctx, dep, "test"); err != nil {
+				t.Fatalf("failed to add parent of %s: %v", id, err)
+			}
+		}
+	}
+	create("bd-a", types.TypeEpic, "")
+	create("bd-b", types.TypeEpic, "")
+	create("bd-b.1", types.TypeTask, "bd-b")
+	create("bd-a.1", types.TypeTask, "bd-a")
+	create("bd-a.1.1", types.TypeTask, "bd-a.1")
+	if err := store.AddLabel(ctx, "bd-a.1.1", "moved", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+
+	t.Run("rejects a descendant as parent", func(t *testing.T) {
+		if _, err := store.MoveIssue(ctx, "bd-a.1", "bd-a.1.1", "test"); err == nil || !strings.Contains(err.Error(), "descendant") {
+			t.Fatalf("expected descendant error, got %v", err)
+		}
+
+		// A child by dependency only still closes a cycle
+		create("bd-x", types.TypeTask, "bd-a.1")
+		if _, err := store.MoveIssue(ctx, "bd-a.1", "bd-x", "test"); err == nil || !strings.Contains(err.Error(), "cycle") {
+			t.Fatalf("expected cycle error, got %v", err)
+		}
+		assertParent(t, store, "bd-a.1", "bd-a")
+	})
+
+	t.Run("rejects a missing parent", func(t *testing.T) {
+		if _, err := store.MoveIssue(ctx, "bd-a.1", "bd-zzz", "test"); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Fatalf("expected not found error, got %v", err)
+		}
+	})
+
+	t.Run("renames the child and its descendants", func(t *testing.T) {
+		renamed, err := store.MoveIssue(ctx, "bd-a.1", "bd-b", "test")
+		if err != nil {
+			t.Fatalf("MoveIssue failed: %v", err)
+		}
+		if renamed["bd-a.1"] != "bd-b.2" || renamed["bd-a.1.1"] != "bd-b.2.1" || len(renamed) != 2 {
+			t.Fatalf("renamed = %v, want bd-a.1 → bd-b.2 and bd-a.1.1 → bd-b.2.1", renamed)
+		}
+		for oldID := range renamed {
+			if issue, _ := store.GetIssue(ctx, oldID); issue != nil {
+				t.Errorf("expected %s to be gone", oldID)
+			}
+		}
+		assertParent(t, store, "bd-b.2", "bd-b")
+		assertParent(t, store, "bd-b.2.1", "bd-b.2")
+		labels, err := store.GetLabels(ctx, "bd-b.2.1")
+		if err != nil || len(labels) != 1 || labels[0] != "moved" {
+			t.Errorf("labels = %v (%v), want the label to follow the rename", labels, err)
+		}
+
+		// The old parent's counter was reclaimed, the new one advanced
+		nextA, err := store.GetNextChildID(ctx, "bd-a")
+		if err != nil || nextA != "bd-a.1" {
+			t.Errorf("next child of bd-a = %s (%v), want bd-a.1", nextA, err)
+		}
+		nextB, err := store.GetNextChildID(ctx, "bd-b")
+		if err != nil || nextB != "bd-b.3" {
+			t.Errorf("next child of bd-b = %s (%v), want bd-b.3", nextB, err)
+		}
+	})
+
+	t.Run("rejects the current parent", func(t *testing.T) {
+		if _, err := store.MoveIssue(ctx, "bd-b.1", "bd-b", "test"); err == nil {
+			t.Fatal("expected an error moving under the current parent")
+		}
+	})
+}
+
+// assertParent checks that id's only parent-child dependency is on parentID
+func assertParent(t *testing.T, store *SQLiteStorage, id, parentID string) {
+	t.Helper()
+	deps, err := store.GetDependencyRecords(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetDependencyRecords(%s) failed: %v", id, err)
+	}
+	var parents []string
+	for _, dep := range deps {
+		if dep.Type == types.DepParentChild {
+			parents = append(parents, dep.DependsOnID)
+		}
+	}
+	if len(parents) != 1 || parents[0] != parentID {
+		t.Errorf("parents of %s = %v, want [%s]", id, parents, parentID)
+	}
+}
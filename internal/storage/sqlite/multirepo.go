@@ -14,6 +14,7 @@ import (
 
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 )
 
 // HydrateFromMultiRepo loads issues from all configured repositories into the database.
@@ -133,7 +134,7 @@ func (s *SQLiteStorage) importJSONLFile(ctx context.Context, jsonlPath, sourceRe
 	lineNum := 0
 
 	// Begin transaction for bulk import
-	tx, err := s.db.BeginTx(ctx, nil)
+	tx, err := s.db.BeginTx(ctx, writeTxOptions)
 	if err != nil {
 		return 0, fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -198,14 +199,16 @@ func (s *SQLiteStorage) upsertIssueInTx(ctx context.Context, tx *sql.Tx, issue *
 			INSERT INTO issues (
 				id, content_hash, title, description, design, acceptance_criteria, notes,
 				status, priority, issue_type, assignee, estimated_minutes,
-				created_at, updated_at, closed_at, external_ref, source_repo
-			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				created_at, updated_at, closed_at, external_ref, source_repo, resolution,
+				spent_minutes, metadata, archived_at, id_nonce, attachments, resolved_by
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`,
 			issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design,
 			issue.AcceptanceCriteria, issue.Notes, issue.Status,
 			issue.Priority, issue.IssueType, issue.Assignee,
 			issue.EstimatedMinutes, issue.CreatedAt, issue.UpdatedAt,
-			issue.ClosedAt, issue.ExternalRef, issue.SourceRepo,
+			issue.ClosedAt, issue.ExternalRef, issue.SourceRepo, issue.Resolution,
+			issue.SpentMinutes, encodeIssueMetadata(issue.Metadata), issue.ArchivedAt, issue.IDNonce, encodeIssueAttachments(issue.Attachments), issue.ResolvedBy,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to insert issue: %w", err)
@@ -227,14 +230,15 @@ func (s *SQLiteStorage) upsertIssueInTx(ctx context.Context, tx *sql.Tx, issue *
 					content_hash = ?, title = ?, description = ?, design = ?,
 					acceptance_criteria = ?, notes = ?, status = ?, priority = ?,
 					issue_type = ?, assignee = ?, estimated_minutes = ?,
-					updated_at = ?, closed_at = ?, external_ref = ?, source_repo = ?
+					updated_at = ?, closed_at = ?, external_ref = ?, source_repo = ?,
+					resolution = ?, spent_minutes = ?, metadata = ?, archived_at = ?, id_nonce = ?, attachments = ?, resolved_by = ?
 				WHERE id = ?
 			`,
 				issue.ContentHash, issue.Title, issue.Description, issue.Design,
 				issue.AcceptanceCriteria, issue.Notes, issue.Status, issue.Priority,
 				issue.IssueType, issue.Assignee, issue.EstimatedMinutes,
 				issue.UpdatedAt, issue.ClosedAt, issue.ExternalRef, issue.SourceRepo,
-				issue.ID,
+				issue.Resolution, issue.SpentMinutes, encodeIssueMetadata(issue.Metadata), issue.ArchivedAt, issue.IDNonce, encodeIssueAttachments(issue.Attachments), issue.ResolvedBy, issue.ID,
 			)
 			if err != nil {
 				return fmt.Errorf("failed to update issue: %w", err)
@@ -255,6 +259,9 @@ func (s *SQLiteStorage) upsertIssueInTx(ctx context.Context, tx *sql.Tx, issue *
 
 	// Import labels if present
 	for _, label := range issue.Labels {
+		if label = util.NormalizeLabel(label); label == "" {
+			continue
+		}
 		_, err = tx.ExecContext(ctx, `
 			INSERT OR IGNORE INTO labels (issue_id, label)
 			VALUES (?, ?)
@@ -267,9 +274,10 @@ func (s *SQLiteStorage) upsertIssueInTx(ctx context.Context, tx *sql.Tx, issue *
 	// Import comments if present
 	for _, comment := range issue.Comments {
 		_, err = tx.ExecContext(ctx, `
-			INSERT OR IGNORE INTO comments (id, issue_id, author, text, created_at)
-			VALUES (?, ?, ?, ?, ?)
-		`, comment.ID, comment.IssueID, comment.Author, comment.Text, comment.CreatedAt)
+			INSERT OR IGNORE INTO comments (id, issue_id, parent_comment_id, author, text, resolved, created_at, original_text, edited_at, deleted_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, comment.ID, comment.IssueID, comment.ParentCommentID, comment.Author, comment.Text, comment.Resolved, comment.CreatedAt,
+			sql.NullString{String: comment.OriginalText, Valid: comment.OriginalText != ""}, comment.EditedAt, comment.DeletedAt)
 		if err != nil {
 			return fmt.Errorf("failed to import comment: %w", err)
 		}
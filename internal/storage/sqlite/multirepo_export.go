@@ -7,11 +7,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
 
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/debug"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
 )
 
 // ExportToMultiRepo writes issues to their respective JSONL files based on source_repo.
@@ -27,7 +27,7 @@ func (s *SQLiteStorage) ExportToMultiRepo(ctx context.Context) (map[string]int,
 	}
 
 	// Get all issues
-	allIssues, err := s.SearchIssues(ctx, "", types.IssueFilter{})
+	allIssues, err := s.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query issues: %w", err)
 	}
@@ -121,10 +121,7 @@ func (s *SQLiteStorage) exportToRepo(ctx context.Context, repoPath string, issue
 		return 0, fmt.Errorf("failed to create .beads directory: %w", err)
 	}
 
-	// Sort issues by ID for consistent output
-	sort.Slice(issues, func(i, j int) bool {
-		return issues[i].ID < issues[j].ID
-	})
+	utils.SortIssuesForExport(issues)
 
 	// Write atomically using temp file + rename
 	tempPath := fmt.Sprintf("%s.tmp.%d", jsonlPath, os.Getpid())
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -163,3 +164,64 @@ func TestPrefixValidationBatch(t *testing.T) {
 		})
 	}
 }
+
+func TestPrefixByType(t *testing.T) {
+	store := newTestStore(t, "")
+	defer store.Close()
+	ctx := context.Background()
+
+	if err := store.SetConfig(ctx, types.PrefixByTypeConfigKey, `{"epic":"epic","bug":"bug-"}`); err != nil {
+		t.Fatalf("failed to set prefix_by_type: %v", err)
+	}
+
+	newIssue := func(id, title string, issueType types.IssueType) *types.Issue {
+		return &types.Issue{ID: id, Title: title, Status: types.StatusOpen, Priority: 1, IssueType: issueType}
+	}
+	epic := newIssue("", "Epic", types.TypeEpic)
+	task := newIssue("", "Task", types.TypeTask)
+	for _, issue := range []*types.Issue{epic, task} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if !strings.HasPrefix(epic.ID, "epic-") {
+		t.Errorf("epic ID = %s, want epic- prefix", epic.ID)
+	}
+	if !strings.HasPrefix(task.ID, "bd-") {
+		t.Errorf("unmapped task ID = %s, want the issue_prefix", task.ID)
+	}
+
+	// Batches use the prefix for each issue's type
+	batch := []*types.Issue{newIssue("", "Batch bug", types.TypeBug), newIssue("", "Batch chore", types.TypeChore)}
+	if err := store.CreateIssues(ctx, batch, "test-user"); err != nil {
+		t.Fatalf("CreateIssues failed: %v", err)
+	}
+	if !strings.HasPrefix(batch[0].ID, "bug-") || !strings.HasPrefix(batch[1].ID, "bd-") {
+		t.Errorf("batch IDs = %s, %s, want bug- and bd-", batch[0].ID, batch[1].ID)
+	}
+
+	// A child keeps its parent's prefix whatever its own type
+	childID, err := store.GetNextChildID(ctx, epic.ID)
+	if err != nil {
+		t.Fatalf("GetNextChildID failed: %v", err)
+	}
+	if err := store.CreateIssue(ctx, newIssue(childID, "Child task", types.TypeTask), "test-user"); err != nil {
+		t.Errorf("child %s of %s rejected: %v", childID, epic.ID, err)
+	}
+
+	// Explicit IDs may use any configured prefix, but no other
+	if err := store.CreateIssue(ctx, newIssue("epic-abc1", "Explicit", types.TypeTask), "test-user"); err != nil {
+		t.Errorf("explicit ID with a per-type prefix rejected: %v", err)
+	}
+	if err := store.CreateIssue(ctx, newIssue("wrong-abc1", "Wrong", types.TypeTask), "test-user"); err == nil {
+		t.Error("expected an unconfigured prefix to be rejected")
+	}
+
+	// An invalid mapping fails creation instead of being ignored
+	if err := store.SetConfig(ctx, types.PrefixByTypeConfigKey, `{"epic":""}`); err != nil {
+		t.Fatalf("failed to set prefix_by_type: %v", err)
+	}
+	if err := store.CreateIssue(ctx, newIssue("", "Epic 2", types.TypeEpic), "test-user"); err == nil {
+		t.Error("expected an invalid prefix_by_type to fail creation")
+	}
+}
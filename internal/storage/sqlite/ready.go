@@ -5,10 +5,41 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 )
 
+// blockedDirectlySQL selects the issues with a 'blocks' dependency on an
+// issue that isn't closed
+const blockedDirectlySQL = `
+	SELECT DISTINCT d.issue_id
+	FROM dependencies d
+	JOIN issues blocker ON d.depends_on_id = blocker.id
+	WHERE d.type = 'blocks'
+	  AND blocker.status IN ('open', 'in_progress', 'blocked')`
+
+// blockedTransitivelySQL selects the issues that aren't ready work: those
+// blocked directly, plus all their descendants via parent-child links, which
+// inherit the blockage
+const blockedTransitivelySQL = `
+	WITH RECURSIVE blocked_transitively AS (
+	  -- Base case: directly blocked issues
+	  SELECT issue_id, 0 AS depth
+	  FROM (` + blockedDirectlySQL + `)
+
+	  UNION ALL
+
+	  -- Recursive case: children of blocked issues inherit blockage
+	  SELECT d.issue_id, bt.depth + 1
+	  FROM blocked_transitively bt
+	  JOIN dependencies d ON d.depends_on_id = bt.issue_id
+	  WHERE d.type = 'parent-child'
+	    AND bt.depth < 50
+	)
+	SELECT issue_id FROM blocked_transitively`
+
 // GetReadyWork returns issues with no open blockers
 // By default, shows both 'open' and 'in_progress' issues so epics/tasks
 // ready to close are visible (bd-165)
@@ -16,6 +47,9 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 	whereClauses := []string{}
 	args := []interface{}{}
 
+	// Archived issues are never ready work
+	whereClauses = append(whereClauses, "i.archived_at IS NULL")
+
 	// Default to open OR in_progress if not specified (bd-165)
 	if filter.Status == "" {
 		whereClauses = append(whereClauses, "i.status IN ('open', 'in_progress')")
@@ -24,14 +58,29 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 		args = append(args, filter.Status)
 	}
 
+	if filter.IssueType != "" {
+		whereClauses = append(whereClauses, "i.issue_type = ?")
+		args = append(args, filter.IssueType)
+	}
+
 	if filter.Priority != nil {
 		whereClauses = append(whereClauses, "i.priority = ?")
 		args = append(args, *filter.Priority)
 	}
 
+	// Lower numbers are more important, so the threshold is an upper bound
+	if filter.MinPriority != nil {
+		whereClauses = append(whereClauses, "i.priority <= ?")
+		args = append(args, *filter.MinPriority)
+	}
+
 	if filter.Assignee != nil {
-		whereClauses = append(whereClauses, "i.assignee = ?")
-		args = append(args, *filter.Assignee)
+		if *filter.Assignee == "" {
+			whereClauses = append(whereClauses, "(i.assignee IS NULL OR i.assignee = '')")
+		} else {
+			whereClauses = append(whereClauses, "i.assignee = ?")
+			args = append(args, *filter.Assignee)
+		}
 	}
 
 	// Label filtering (AND semantics)
@@ -43,7 +92,7 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 					WHERE issue_id = i.id AND label = ?
 				)
 			`)
-			args = append(args, label)
+			args = append(args, util.NormalizeLabel(label))
 		}
 	}
 
@@ -60,7 +109,7 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 			)
 		`, strings.Join(placeholders, ",")))
 		for _, label := range filter.LabelsAny {
-			args = append(args, label)
+			args = append(args, util.NormalizeLabel(label))
 		}
 	}
 
@@ -81,51 +130,19 @@ func (s *SQLiteStorage) GetReadyWork(ctx context.Context, filter types.WorkFilte
 	}
 	orderBySQL := buildOrderByClause(sortPolicy)
 
-	// Query with recursive CTE to propagate blocking through parent-child hierarchy
-	// Algorithm:
-	// 1. Find issues directly blocked by 'blocks' dependencies
-	// 2. Recursively propagate blockage to all descendants via 'parent-child' links
-	// 3. Exclude all blocked issues (both direct and transitive) from ready work
+	// Exclude issues blocked directly or through a parent (see blockedTransitivelySQL)
 	// #nosec G201 - safe SQL with controlled formatting
 	query := fmt.Sprintf(`
-		WITH RECURSIVE
-		  -- Step 1: Find issues blocked directly by dependencies
-		  blocked_directly AS (
-		    SELECT DISTINCT d.issue_id
-		    FROM dependencies d
-		    JOIN issues blocker ON d.depends_on_id = blocker.id
-		    WHERE d.type = 'blocks'
-		      AND blocker.status IN ('open', 'in_progress', 'blocked')
-		  ),
-
-		  -- Step 2: Propagate blockage to all descendants via parent-child
-		  blocked_transitively AS (
-		    -- Base case: directly blocked issues
-		    SELECT issue_id, 0 as depth
-		    FROM blocked_directly
-
-		    UNION ALL
-
-		    -- Recursive case: children of blocked issues inherit blockage
-		    SELECT d.issue_id, bt.depth + 1
-		    FROM blocked_transitively bt
-		    JOIN dependencies d ON d.depends_on_id = bt.issue_id
-		    WHERE d.type = 'parent-child'
-		      AND bt.depth < 50
-		  )
-
-		-- Step 3: Select ready issues (excluding all blocked)
 		SELECT i.id, i.content_hash, i.title, i.description, i.design, i.acceptance_criteria, i.notes,
 		i.status, i.priority, i.issue_type, i.assignee, i.estimated_minutes,
-		i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo
+		i.created_at, i.updated_at, i.closed_at, i.external_ref, i.source_repo, i.resolution,
+		i.spent_minutes, i.metadata, i.archived_at, i.id_nonce, i.attachments, i.resolved_by
 		FROM issues i
 		WHERE %s
-		AND NOT EXISTS (
-		SELECT 1 FROM blocked_transitively WHERE issue_id = i.id
-		)
+		AND i.id NOT IN (%s)
 		%s
 		%s
-	`, whereSQL, orderBySQL, limitSQL)
+	`, whereSQL, blockedTransitivelySQL, orderBySQL, limitSQL)
 
 	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -147,10 +164,14 @@ func (s *SQLiteStorage) GetStaleIssues(ctx context.Context, filter types.StaleFi
 			compaction_level, compacted_at, compacted_at_commit, original_size
 		FROM issues
 		WHERE status != 'closed'
-		  AND datetime(updated_at) < datetime('now', '-' || ? || ' days')
+		  AND datetime(updated_at) < datetime('now', '-' || ? || ' seconds')
 	`
 	
-	args := []interface{}{filter.Days}
+	threshold := filter.OlderThan
+	if threshold <= 0 {
+		threshold = time.Duration(filter.Days) * 24 * time.Hour
+	}
+	args := []interface{}{int64(threshold / time.Second)}
 	
 	// Add optional status filter
 	if filter.Status != "" {
@@ -304,10 +325,55 @@ func (s *SQLiteStorage) GetBlockedIssues(ctx context.Context) ([]*types.BlockedI
 
 		blocked = append(blocked, &issue)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate blocked issues: %w", err)
+	}
 
+	if err := s.attachBlockers(ctx, blocked); err != nil {
+		return nil, err
+	}
 	return blocked, nil
 }
 
+// attachBlockers fills in Blockers for each blocked issue in a single query,
+// ordering both Blockers and BlockedBy by blocker ID
+func (s *SQLiteStorage) attachBlockers(ctx context.Context, blocked []*types.BlockedIssue) error {
+	if len(blocked) == 0 {
+		return nil
+	}
+	byID := make(map[string]*types.BlockedIssue, len(blocked))
+	for _, issue := range blocked {
+		byID[issue.ID] = issue
+		issue.BlockedBy = nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT d.issue_id, blocker.id, blocker.title, blocker.status
+		FROM dependencies d
+		JOIN issues blocker ON d.depends_on_id = blocker.id
+		WHERE d.type = 'blocks'
+		  AND blocker.status IN ('open', 'in_progress', 'blocked')
+		ORDER BY d.issue_id, blocker.id
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to get blockers: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var issueID string
+		var blocker types.Blocker
+		if err := rows.Scan(&issueID, &blocker.ID, &blocker.Title, &blocker.Status); err != nil {
+			return fmt.Errorf("failed to scan blocker: %w", err)
+		}
+		if issue, ok := byID[issueID]; ok {
+			issue.Blockers = append(issue.Blockers, &blocker)
+			issue.BlockedBy = append(issue.BlockedBy, blocker.ID)
+		}
+	}
+	return rows.Err()
+}
+
 // buildOrderByClause generates the ORDER BY clause based on sort policy
 func buildOrderByClause(policy types.SortPolicy) string {
 	switch policy {
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -31,7 +32,7 @@ func TestGetReadyWork(t *testing.T) {
 	store.CreateIssue(ctx, issue2, "test-user")
 	store.CreateIssue(ctx, issue3, "test-user")
 	store.CreateIssue(ctx, issue4, "test-user")
-	store.CloseIssue(ctx, issue4.ID, "Done", "test-user")
+	store.CloseIssue(ctx, issue4.ID, storage.CloseOptions{Reason: "Done"}, "test-user")
 	store.CreateIssue(ctx, issue5, "test-user")
 
 	// Add dependencies
@@ -137,6 +138,51 @@ func TestGetReadyWorkWithPriorityFilter(t *testing.T) {
 	}
 }
 
+func TestGetReadyWorkWithMinPriority(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	issueP0 := &types.Issue{Title: "P0", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask, Assignee: "alice"}
+	issueP1 := &types.Issue{Title: "P1", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Assignee: "bob"}
+	issueP1Alice := &types.Issue{Title: "P1 alice", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Assignee: "alice"}
+	issueP3 := &types.Issue{Title: "P3", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask, Assignee: "alice"}
+
+	store.CreateIssue(ctx, issueP0, "test-user")
+	store.CreateIssue(ctx, issueP1, "test-user")
+	store.CreateIssue(ctx, issueP1Alice, "test-user")
+	store.CreateIssue(ctx, issueP3, "test-user")
+
+	// P1 threshold keeps P0 and P1, in priority order
+	minPriority := 1
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen, MinPriority: &minPriority, SortPolicy: types.SortPolicyPriority})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 3 {
+		t.Fatalf("Expected 3 issues at P1 or above, got %d", len(ready))
+	}
+	if ready[0].ID != issueP0.ID {
+		t.Errorf("Expected P0 issue first, got %s (P%d)", ready[0].ID, ready[0].Priority)
+	}
+
+	// Composes with assignee filter
+	assignee := "alice"
+	ready, err = store.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen, MinPriority: &minPriority, Assignee: &assignee})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+	if len(ready) != 2 {
+		t.Fatalf("Expected 2 of alice's issues at P1 or above, got %d", len(ready))
+	}
+	for _, issue := range ready {
+		if issue.Priority > 1 || issue.Assignee != "alice" {
+			t.Errorf("Unexpected issue %s (P%d, %s)", issue.ID, issue.Priority, issue.Assignee)
+		}
+	}
+}
+
 func TestGetReadyWorkWithAssigneeFilter(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -168,6 +214,31 @@ func TestGetReadyWorkWithAssigneeFilter(t *testing.T) {
 	}
 }
 
+func TestGetReadyWorkWithTypeFilter(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	bug := &types.Issue{Title: "Bug", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	task := &types.Issue{Title: "Task", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	blockedBug := &types.Issue{Title: "Blocked bug", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+
+	store.CreateIssue(ctx, bug, "test-user")
+	store.CreateIssue(ctx, task, "test-user")
+	store.CreateIssue(ctx, blockedBug, "test-user")
+	store.AddDependency(ctx, &types.Dependency{IssueID: blockedBug.ID, DependsOnID: task.ID, Type: types.DepBlocks}, "test-user")
+
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{IssueType: types.TypeBug})
+	if err != nil {
+		t.Fatalf("GetReadyWork failed: %v", err)
+	}
+
+	if len(ready) != 1 || ready[0].ID != bug.ID {
+		t.Fatalf("Expected only the unblocked bug %s, got %v", bug.ID, ready)
+	}
+}
+
 func TestGetReadyWorkWithLimit(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -272,6 +343,31 @@ func TestGetBlockedIssues(t *testing.T) {
 	if len(issue3Blocked.BlockedBy) != 2 {
 		t.Errorf("Expected 2 blocker IDs, got %d", len(issue3Blocked.BlockedBy))
 	}
+	if len(issue3Blocked.Blockers) != 2 {
+		t.Fatalf("Expected 2 blocker details, got %d", len(issue3Blocked.Blockers))
+	}
+	titles := map[string]string{issue1.ID: "Foundation", issue2.ID: "Blocked by 1"}
+	for _, blocker := range issue3Blocked.Blockers {
+		if blocker.Title != titles[blocker.ID] || blocker.Status != types.StatusOpen {
+			t.Errorf("Unexpected blocker detail %+v", blocker)
+		}
+	}
+
+	// Closed blockers drop out: closing issue1 frees issue2 and leaves issue3
+	// waiting on issue2 alone
+	if err := store.CloseIssue(ctx, issue1.ID, storage.CloseOptions{Reason: "done"}, "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	blocked, err = store.GetBlockedIssues(ctx)
+	if err != nil {
+		t.Fatalf("GetBlockedIssues failed: %v", err)
+	}
+	if len(blocked) != 1 || blocked[0].ID != issue3.ID {
+		t.Fatalf("Expected only issue3 to stay blocked, got %v", blocked)
+	}
+	if len(blocked[0].Blockers) != 1 || blocked[0].Blockers[0].ID != issue2.ID || len(blocked[0].BlockedBy) != 1 {
+		t.Errorf("Expected issue3 blocked by issue2 only, got %+v", blocked[0].Blockers)
+	}
 }
 
 // TestParentBlockerBlocksChildren tests that children inherit blockage from parents
@@ -482,7 +578,7 @@ func TestBlockerClosedUnblocksChildren(t *testing.T) {
 	}
 
 	// Close the blocker
-	store.CloseIssue(ctx, blocker.ID, "Done", "test-user")
+	store.CloseIssue(ctx, blocker.ID, storage.CloseOptions{Reason: "Done"}, "test-user")
 
 	// Now epic1 and task1 should be ready
 	ready, err = store.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen})
@@ -729,7 +825,7 @@ func TestDeepHierarchyBlocking(t *testing.T) {
 	}
 
 	// Now close the blocker and verify all levels become ready
-	store.CloseIssue(ctx, blocker.ID, "Done", "test-user")
+	store.CloseIssue(ctx, blocker.ID, storage.CloseOptions{Reason: "Done"}, "test-user")
 
 	ready, err = store.GetReadyWork(ctx, types.WorkFilter{Status: types.StatusOpen})
 	if err != nil {
@@ -778,7 +874,7 @@ func TestGetReadyWorkIncludesInProgress(t *testing.T) {
 	store.UpdateIssue(ctx, issue3.ID, map[string]interface{}{"status": types.StatusInProgress}, "test-user")
 	store.CreateIssue(ctx, issue4, "test-user")
 	store.CreateIssue(ctx, issue5, "test-user")
-	store.CloseIssue(ctx, issue5.ID, "Done", "test-user")
+	store.CloseIssue(ctx, issue5.ID, storage.CloseOptions{Reason: "Done"}, "test-user")
 
 	// Add dependency: issue3 blocks on issue4
 	store.AddDependency(ctx, &types.Dependency{IssueID: issue3.ID, DependsOnID: issue4.ID, Type: types.DepBlocks}, "test-user")
@@ -1094,3 +1190,75 @@ func TestSortPolicyDefault(t *testing.T) {
 		t.Errorf("Expected P2 second, got P%d", ready[1].Priority)
 	}
 }
+
+func TestSearchIssuesReadyAndBlocked(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// blocker: open → ready
+	// blocked: open, blocked by blocker → blocked
+	// epic: open, blocked by blocker → blocked; child: parent is blocked → neither
+	// free: in_progress, no blockers → ready
+	// done: closed → neither
+	blocker := &types.Issue{Title: "Blocker", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	blocked := &types.Issue{Title: "Blocked", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	epic := &types.Issue{Title: "Epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic}
+	child := &types.Issue{Title: "Child", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	free := &types.Issue{Title: "Free", Status: types.StatusInProgress, Priority: 1, IssueType: types.TypeBug}
+	done := &types.Issue{Title: "Done", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{blocker, blocked, epic, child, free, done} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.CloseIssue(ctx, done.ID, storage.CloseOptions{Reason: "Done"}, "test-user"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	for _, dep := range []*types.Dependency{
+		{IssueID: blocked.ID, DependsOnID: blocker.ID, Type: types.DepBlocks},
+		{IssueID: epic.ID, DependsOnID: blocker.ID, Type: types.DepBlocks},
+		{IssueID: child.ID, DependsOnID: epic.ID, Type: types.DepParentChild},
+	} {
+		if err := store.AddDependency(ctx, dep, "test-user"); err != nil {
+			t.Fatalf("AddDependency failed: %v", err)
+		}
+	}
+
+	bug := types.TypeBug
+	tests := []struct {
+		name   string
+		filter types.IssueFilter
+		want   []string
+	}{
+		{"ready", types.IssueFilter{Ready: true}, []string{blocker.ID, free.ID}},
+		{"blocked", types.IssueFilter{Blocked: true}, []string{blocked.ID, epic.ID}},
+		{"ready bugs", types.IssueFilter{Ready: true, IssueType: &bug}, []string{free.ID}},
+		{"blocked bugs", types.IssueFilter{Blocked: true, IssueType: &bug}, []string{blocked.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := store.SearchIssues(ctx, "", tt.filter)
+			if err != nil {
+				t.Fatalf("SearchIssues failed: %v", err)
+			}
+			got := make(map[string]bool)
+			for _, issue := range results {
+				got[issue.ID] = true
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("got %d issues, want %v", len(got), tt.want)
+			}
+			for _, id := range tt.want {
+				if !got[id] {
+					t.Errorf("expected %s in results", id)
+				}
+			}
+		})
+	}
+
+	if _, err := store.SearchIssues(ctx, "", types.IssueFilter{Ready: true, Blocked: true}); err == nil {
+		t.Error("expected an error for Ready and Blocked together")
+	}
+}
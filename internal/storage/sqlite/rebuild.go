@@ -0,0 +1,138 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SyncChildCounters raises each parent's child counter to its highest
+// existing direct child number, so GetNextChildID doesn't hand out an ID that
+// is already taken. Imports create hierarchical children without touching the
+// counters. With parentIDs only those parents are synced. Counters already
+// past the highest child are left alone. Returns the number of parents whose
+// counter was set.
+func (s *SQLiteStorage) SyncChildCounters(ctx context.Context, parentIDs ...string) (int, error) {
+	var only map[string]bool
+	if len(parentIDs) > 0 {
+		only = make(map[string]bool, len(parentIDs))
+		for _, id := range parentIDs {
+			only[id] = true
+		}
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT id FROM issues WHERE id LIKE '%.%'`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read issue IDs: %w", err)
+	}
+	highest := make(map[string]int)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return 0, fmt.Errorf("failed to scan issue ID: %w", err)
+		}
+		dot := strings.LastIndex(id, ".")
+		num, err := strconv.Atoi(id[dot+1:])
+		if err != nil || num <= 0 {
+			continue
+		}
+		if parent := id[:dot]; num > highest[parent] && (only == nil || only[parent]) {
+			highest[parent] = num
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	set := 0
+	err = s.withTx(ctx, func(tx *sql.Tx) error {
+		for parentID, last := range highest {
+			// Children whose parent is gone have no counter to keep
+			result, err := tx.ExecContext(ctx, `
+				INSERT INTO child_counters (parent_id, last_child)
+				SELECT ?1, ?2 WHERE EXISTS (SELECT 1 FROM issues WHERE id = ?1)
+				ON CONFLICT(parent_id) DO UPDATE SET last_child = excluded.last_child
+				WHERE last_child < excluded.last_child
+			`, parentID, last)
+			if err != nil {
+				return fmt.Errorf("failed to set child counter for %s: %w", parentID, err)
+			}
+			if n, _ := result.RowsAffected(); n > 0 {
+				set++
+			}
+		}
+		return nil
+	})
+	return set, err
+}
+
+// RestoredHistory reports what RestoreHistoryFrom copied
+type RestoredHistory struct {
+	Events int `json:"events"`
+	Config int `json:"config"`
+}
+
+// RestoreHistoryFrom copies the event history and config of the database at
+// path, opened read-only, into this one. Each issue that has events there
+// gets exactly that history in place of its current events; history for
+// issues that don't exist here is skipped. Config values there replace
+// those set here.
+func (s *SQLiteStorage) RestoreHistoryFrom(ctx context.Context, path string) (*RestoredHistory, error) {
+	// ATTACH can't run inside a transaction and only applies to one connection
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	encParams, err := encryptionParams(path)
+	if err != nil {
+		return nil, err
+	}
+	source := "file:" + filepath.ToSlash(path) + "?mode=ro" + encParams
+	if _, err := conn.ExecContext(ctx, `ATTACH DATABASE ? AS source`, source); err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() { _, _ = conn.ExecContext(context.Background(), `DETACH DATABASE source`) }()
+
+	tx, err := conn.BeginTx(ctx, writeTxOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var restored RestoredHistory
+	result, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO config (key, value) SELECT key, value FROM source.config`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy config: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	restored.Config = int(n)
+
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM events WHERE issue_id IN (SELECT issue_id FROM source.events)
+	`); err != nil {
+		return nil, fmt.Errorf("failed to clear events being restored: %w", err)
+	}
+	result, err = tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment, created_at)
+		SELECT issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM source.events
+		WHERE issue_id IN (SELECT id FROM issues)
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy events: %w", err)
+	}
+	n, _ = result.RowsAffected()
+	restored.Events = int(n)
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit restored history: %w", err)
+	}
+	return &restored, nil
+}
@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSyncChildCounters(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Imported children, as if from JSONL: the counters are never advanced
+	for _, id := range []string{"bd-a", "bd-a.1", "bd-a.3", "bd-a.3.1"} {
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue(%s) failed: %v", id, err)
+		}
+	}
+	if _, err := store.db.ExecContext(ctx, `DELETE FROM child_counters`); err != nil {
+		t.Fatal(err)
+	}
+
+	set, err := store.SyncChildCounters(ctx)
+	if err != nil {
+		t.Fatalf("SyncChildCounters failed: %v", err)
+	}
+	if set != 2 {
+		t.Errorf("set %d counters, want 2 (bd-a and bd-a.3)", set)
+	}
+	for parent, want := range map[string]string{"bd-a": "bd-a.4", "bd-a.3": "bd-a.3.2"} {
+		if next, err := store.GetNextChildID(ctx, parent); err != nil || next != want {
+			t.Errorf("next child of %s = %s (%v), want %s", parent, next, err, want)
+		}
+	}
+}
+
+func TestRestoreHistoryFrom(t *testing.T) {
+	ctx := context.Background()
+	sourcePath := filepath.Join(t.TempDir(), "old.db")
+	source, err := New(sourcePath)
+	if err != nil {
+		t.Fatalf("failed to create source: %v", err)
+	}
+	if err := source.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"bd-1", "bd-gone"} {
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := source.CreateIssue(ctx, issue, "alice"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := source.UpdateIssue(ctx, "bd-1", map[string]interface{}{"status": string(types.StatusInProgress)}, "alice"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if err := source.SetConfig(ctx, "issue_prefix", "old"); err != nil {
+		t.Fatal(err)
+	}
+	if err := source.SetConfig(ctx, "custom.key", "kept"); err != nil {
+		t.Fatal(err)
+	}
+	if err := source.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	issue := &types.Issue{ID: "bd-1", Title: "bd-1", Status: types.StatusInProgress, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "import"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	restored, err := store.RestoreHistoryFrom(ctx, sourcePath)
+	if err != nil {
+		t.Fatalf("RestoreHistoryFrom failed: %v", err)
+	}
+	if restored.Events != 2 {
+		t.Errorf("restored %d events, want bd-1's 2 (bd-gone skipped)", restored.Events)
+	}
+	events, err := store.GetEvents(ctx, "bd-1", 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(events) != 2 || events[0].Actor != "alice" || events[1].Actor != "alice" {
+		t.Errorf("events = %+v, want alice's history in place of the import's", events)
+	}
+	if value, _ := store.GetConfig(ctx, "custom.key"); value != "kept" {
+		t.Errorf("custom.key = %q, want it copied", value)
+	}
+	if prefix, _ := store.GetConfig(ctx, "issue_prefix"); prefix != "old" {
+		t.Errorf("issue_prefix = %q, want the source's value", prefix)
+	}
+}
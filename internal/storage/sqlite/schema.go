@@ -30,6 +30,8 @@ CREATE INDEX IF NOT EXISTS idx_issues_status ON issues(status);
 CREATE INDEX IF NOT EXISTS idx_issues_priority ON issues(priority);
 CREATE INDEX IF NOT EXISTS idx_issues_assignee ON issues(assignee);
 CREATE INDEX IF NOT EXISTS idx_issues_created_at ON issues(created_at);
+CREATE INDEX IF NOT EXISTS idx_issues_updated_at ON issues(updated_at);
+CREATE INDEX IF NOT EXISTS idx_issues_closed_at ON issues(closed_at);
 -- Note: idx_issues_external_ref is created in migrations/002_external_ref_column.go
 
 -- Dependencies table
@@ -65,11 +67,18 @@ CREATE TABLE IF NOT EXISTS comments (
     author TEXT NOT NULL,
     text TEXT NOT NULL,
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+    parent_comment_id INTEGER,
+    resolved INTEGER NOT NULL DEFAULT 0,
+    original_text TEXT,
+    edited_at DATETIME,
+    deleted_at DATETIME,
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_comment_id) REFERENCES comments(id) ON DELETE CASCADE
 );
 
 CREATE INDEX IF NOT EXISTS idx_comments_issue ON comments(issue_id);
 CREATE INDEX IF NOT EXISTS idx_comments_created_at ON comments(created_at);
+-- Note: idx_comments_parent is created in migrations/016_comment_threading.go
 
 -- Events table (audit trail)
 CREATE TABLE IF NOT EXISTS events (
@@ -80,6 +89,7 @@ CREATE TABLE IF NOT EXISTS events (
     old_value TEXT,
     new_value TEXT,
     comment TEXT,
+    note TEXT,
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
 );
@@ -138,6 +148,16 @@ CREATE TABLE IF NOT EXISTS child_counters (
     FOREIGN KEY (parent_id) REFERENCES issues(id) ON DELETE CASCADE
 );
 
+-- Advisory locks table (for coordinating concurrent edits)
+-- Locks are local coordination state and are not exported to JSONL
+CREATE TABLE IF NOT EXISTS locks (
+    issue_id TEXT PRIMARY KEY,
+    holder TEXT NOT NULL,
+    acquired_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL,
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
 -- Issue snapshots table (for compaction)
 CREATE TABLE IF NOT EXISTS issue_snapshots (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
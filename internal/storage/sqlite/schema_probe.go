@@ -18,16 +18,18 @@ var expectedSchema = map[string][]string{
 		"status", "priority", "issue_type", "assignee", "estimated_minutes",
 		"created_at", "updated_at", "closed_at", "content_hash", "external_ref",
 		"compaction_level", "compacted_at", "compacted_at_commit", "original_size",
+		"resolution", "spent_minutes", "metadata", "archived_at", "id_nonce", "attachments", "resolved_by",
 	},
 	"dependencies": {"issue_id", "depends_on_id", "type", "created_at", "created_by"},
 	"labels":       {"issue_id", "label"},
-	"comments":     {"id", "issue_id", "author", "text", "created_at"},
-	"events":       {"id", "issue_id", "event_type", "actor", "old_value", "new_value", "comment", "created_at"},
+	"comments":     {"id", "issue_id", "author", "text", "created_at", "parent_comment_id", "resolved", "original_text", "edited_at", "deleted_at"},
+	"events":       {"id", "issue_id", "event_type", "actor", "old_value", "new_value", "comment", "note", "created_at"},
 	"config":       {"key", "value"},
 	"metadata":     {"key", "value"},
 	"dirty_issues": {"issue_id", "marked_at"},
 	"export_hashes": {"issue_id", "content_hash", "exported_at"},
 	"child_counters": {"parent_id", "last_child"},
+	"locks": {"issue_id", "holder", "acquired_at", "expires_at"},
 	"issue_snapshots": {"id", "issue_id", "snapshot_time", "compaction_level", "original_size", "compressed_size", "original_content", "archived_events"},
 	"compaction_snapshots": {"id", "issue_id", "compaction_level", "snapshot_json", "created_at"},
 	"repo_mtimes": {"repo_path", "jsonl_path", "mtime_ns", "last_checked"},
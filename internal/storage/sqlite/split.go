@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SplitIssue creates children under parentID in one transaction. Each child
+// is given the parent's next child ID, a parent-child dependency on the
+// parent and its Labels. The parent is promoted to an epic if it isn't one,
+// and parentUpdates (such as a trimmed description) are applied to it in the
+// same update.
+func (s *SQLiteStorage) SplitIssue(ctx context.Context, parentID string, children []*types.Issue, parentUpdates map[string]interface{}, actor string) error {
+	return s.withConnTx(ctx, func(conn *sql.Conn) error {
+		return s.splitIssueIn(ctx, conn, parentID, children, parentUpdates, actor)
+	})
+}
+
+func (s *SQLiteStorage) splitIssueIn(ctx context.Context, conn *sql.Conn, parentID string, children []*types.Issue, parentUpdates map[string]interface{}, actor string) error {
+	if len(children) == 0 {
+		return fmt.Errorf("no child issues to create")
+	}
+	parent, err := getIssue(ctx, conn, parentID)
+	if err != nil {
+		return fmt.Errorf("failed to get issue %s: %w", parentID, err)
+	}
+	if parent == nil {
+		return fmt.Errorf("issue %s not found", parentID)
+	}
+	if parent.Status == types.StatusClosed {
+		return fmt.Errorf("cannot split closed issue %s", parentID)
+	}
+	if strings.Count(parentID, ".") >= 3 {
+		return fmt.Errorf("maximum hierarchy depth (3) exceeded for parent %s", parentID)
+	}
+
+	updates := make(map[string]interface{}, len(parentUpdates)+1)
+	for key, value := range parentUpdates {
+		updates[key] = value
+	}
+	if parent.IssueType != types.TypeEpic {
+		updates["issue_type"] = string(types.TypeEpic)
+	}
+	if len(updates) > 0 {
+		if err := updateIssueIn(ctx, conn, parentID, updates, actor, "", ""); err != nil {
+			return fmt.Errorf("failed to update %s: %w", parentID, err)
+		}
+	}
+
+	for _, child := range children {
+		// Skip numbers held by children created with explicit IDs
+		for {
+			num, err := nextChildNumberIn(ctx, conn, parentID)
+			if err != nil {
+				return err
+			}
+			child.ID = fmt.Sprintf("%s.%d", parentID, num)
+			existing, err := getIssue(ctx, conn, child.ID)
+			if err != nil {
+				return fmt.Errorf("failed to check for existing issue %s: %w", child.ID, err)
+			}
+			if existing == nil {
+				break
+			}
+		}
+		if err := s.createIssueIn(ctx, conn, child, actor); err != nil {
+			return fmt.Errorf("failed to create %s: %w", child.ID, err)
+		}
+		if err := addDependencyIn(ctx, conn, &types.Dependency{
+			IssueID:     child.ID,
+			DependsOnID: parentID,
+			Type:        types.DepParentChild,
+		}, actor); err != nil {
+			return err
+		}
+		for _, label := range child.Labels {
+			if err := addLabelIn(ctx, conn, child.ID, label, actor); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSplitIssue(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	parent := &types.Issue{ID: "bd-a", Title: "Big task", Description: "Intro\n## Part two\nDetails", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, parent, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	// An explicitly numbered child holds .1 already
+	taken := &types.Issue{ID: "bd-a.1", Title: "Existing", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, taken, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	children := []*types.Issue{
+		{Title: "First", Description: "## Part two\nDetails", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask, Assignee: "alice", Labels: []string{"backend"}},
+		{Title: "Second", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+	}
+	if err := store.SplitIssue(ctx, "bd-a", children, map[string]interface{}{"description": "Intro"}, "test"); err != nil {
+		t.Fatalf("SplitIssue failed: %v", err)
+	}
+	if children[0].ID != "bd-a.2" || children[1].ID != "bd-a.3" {
+		t.Fatalf("expected bd-a.2 and bd-a.3, got %s and %s", children[0].ID, children[1].ID)
+	}
+	for _, child := range children {
+		assertParent(t, store, child.ID, "bd-a")
+	}
+
+	first, err := store.GetIssue(ctx, "bd-a.2")
+	if err != nil || first == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if first.Assignee != "alice" || first.Description != "## Part two\nDetails" || strings.Join(first.Labels, ",") != "backend" {
+		t.Errorf("unexpected first child: %+v", first)
+	}
+	updated, err := store.GetIssue(ctx, "bd-a")
+	if err != nil || updated == nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if updated.IssueType != types.TypeEpic || updated.Description != "Intro" {
+		t.Errorf("expected the parent to become an epic keeping the intro, got %s %q", updated.IssueType, updated.Description)
+	}
+	events, err := store.GetEvents(ctx, "bd-a.3", 10)
+	if err != nil || len(events) == 0 || events[len(events)-1].EventType != types.EventCreated {
+		t.Errorf("expected a created event for bd-a.3, got %+v (err %v)", events, err)
+	}
+
+	t.Run("rejects a closed parent", func(t *testing.T) {
+		if err := store.CloseIssue(ctx, "bd-a.1", storage.CloseOptions{Reason: "done"}, "test"); err != nil {
+			t.Fatalf("CloseIssue failed: %v", err)
+		}
+		err := store.SplitIssue(ctx, "bd-a.1", []*types.Issue{{Title: "Late", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}}, nil, "test")
+		if err == nil || !strings.Contains(err.Error(), "closed") {
+			t.Fatalf("expected closed error, got %v", err)
+		}
+	})
+
+	t.Run("rolls back on an invalid child", func(t *testing.T) {
+		err := store.SplitIssue(ctx, "bd-a", []*types.Issue{
+			{Title: "Fine", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+			{Title: "", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		}, nil, "test")
+		if err == nil {
+			t.Fatal("expected a validation error")
+		}
+		if issue, _ := store.GetIssue(ctx, "bd-a.4"); issue != nil {
+			t.Errorf("expected bd-a.4 to be rolled back, got %+v", issue)
+		}
+	})
+}
@@ -8,12 +8,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync/atomic"
 	"time"
+	"unicode"
 
 	// Import SQLite driver
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
 	_ "github.com/ncruces/go-sqlite3/driver"
 	_ "github.com/ncruces/go-sqlite3/embed"
 )
@@ -23,32 +27,61 @@ type SQLiteStorage struct {
 	db     *sql.DB
 	dbPath string
 	closed atomic.Bool // Tracks whether Close() has been called
+	hasFTS bool        // issues_fts exists (SQLite was built with FTS5)
 }
 
 // New creates a new SQLite storage backend
 func New(path string) (*SQLiteStorage, error) {
+	return open(path, false)
+}
+
+// NewReadOnly opens the existing database file at path read-only: SQLite
+// refuses every write, and the schema is checked but never created or
+// migrated, so a database from an older bd must be migrated first
+func NewReadOnly(path string) (*SQLiteStorage, error) {
+	return open(path, true)
+}
+
+func open(path string, readOnly bool) (*SQLiteStorage, error) {
+	settings, err := loadConnectionSettings()
+	if err != nil {
+		return nil, err
+	}
+
 	// Build connection string with proper URI syntax
 	// For :memory: databases, use shared cache so multiple connections see the same data
-	var connStr string
+	var connStr, encParams string
 	if path == ":memory:" {
 		// Use shared in-memory database with a named identifier
 		// Note: WAL mode doesn't work with shared in-memory databases, so use DELETE mode
 		// The name "memdb" is required for cache=shared to work properly across connections
-		connStr = "file:memdb?mode=memory&cache=shared&_pragma=journal_mode(DELETE)&_pragma=foreign_keys(ON)&_pragma=busy_timeout(30000)&_time_format=sqlite"
+		connStr = "file:memdb?mode=memory&cache=shared&_pragma=journal_mode(DELETE)&" + settings.pragmas()
 	} else if strings.HasPrefix(path, "file:") {
 		// Already a URI - append our pragmas if not present
 		connStr = path
 		if !strings.Contains(path, "_pragma=foreign_keys") {
-			connStr += "&_pragma=foreign_keys(ON)&_pragma=busy_timeout(30000)&_time_format=sqlite"
+			connStr += "&" + settings.pragmas()
 		}
 	} else {
-		// Ensure directory exists for file-based databases
-		dir := filepath.Dir(path)
-		if err := os.MkdirAll(dir, 0o750); err != nil {
-			return nil, fmt.Errorf("failed to create directory: %w", err)
+		if readOnly {
+			// The journal mode is stored in the file, so it's left as it is
+			connStr = "file:" + path + "?mode=ro&" + settings.pragmas()
+		} else {
+			// Ensure directory exists for file-based databases
+			dir := filepath.Dir(path)
+			if err := os.MkdirAll(dir, 0o750); err != nil {
+				return nil, fmt.Errorf("failed to create directory: %w", err)
+			}
+			// Use file URI with pragmas
+			connStr = "file:" + path + "?_pragma=journal_mode(WAL)&" + settings.pragmas()
+		}
+
+		// Encrypt at rest when BEADS_DB_KEY is set
+		encParams, err = encryptionParams(path)
+		if err != nil {
+			return nil, err
 		}
-		// Use file URI with pragmas
-		connStr = "file:" + path + "?_pragma=journal_mode(WAL)&_pragma=foreign_keys(ON)&_pragma=busy_timeout(30000)&_time_format=sqlite"
+		connStr += encParams
 	}
 
 	db, err := sql.Open("sqlite3", connStr)
@@ -61,15 +94,28 @@ func New(path string) (*SQLiteStorage, error) {
 	// Without this, different connections in the pool can't see each other's writes (bd-b121).
 	if path == ":memory:" {
 		db.SetMaxOpenConns(1)
+	} else {
+		db.SetMaxOpenConns(settings.maxOpenConns)
 	}
 
 	// Test connection
 	if err := db.Ping(); err != nil {
+		if encParams != "" {
+			// A wrong key makes the file look corrupt
+			return nil, fmt.Errorf("failed to open encrypted database (wrong %s?): %w", DBKeyEnvVar, err)
+		}
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	if readOnly {
+		return openedReadOnly(db, path, encParams)
+	}
+
 	// Initialize schema
 	if _, err := db.Exec(schema); err != nil {
+		if encParams != "" {
+			return nil, fmt.Errorf("failed to open encrypted database (wrong %s?): %w", DBKeyEnvVar, err)
+		}
 		return nil, fmt.Errorf("failed to initialize schema: %w", err)
 	}
 
@@ -103,9 +149,15 @@ func New(path string) (*SQLiteStorage, error) {
 		}
 	}
 
+	var ftsTables int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='issues_fts'`).Scan(&ftsTables); err != nil {
+		return nil, fmt.Errorf("failed to check for full-text index: %w", err)
+	}
+
 	storage := &SQLiteStorage{
 		db:     db,
 		dbPath: absPath,
+		hasFTS: ftsTables > 0,
 	}
 
 	// Hydrate from multi-repo config if configured (bd-307)
@@ -121,6 +173,27 @@ func New(path string) (*SQLiteStorage, error) {
 	return storage, nil
 }
 
+// openedReadOnly finishes NewReadOnly once db is connected, checking the
+// schema where New would create and migrate it
+func openedReadOnly(db *sql.DB, path, encParams string) (*SQLiteStorage, error) {
+	if err := verifySchemaCompatibility(db); err != nil {
+		if encParams != "" {
+			return nil, fmt.Errorf("failed to open encrypted database (wrong %s?): %w", DBKeyEnvVar, err)
+		}
+		return nil, fmt.Errorf("%w (open it read-write once to migrate it)", err)
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	var ftsTables int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='issues_fts'`).Scan(&ftsTables); err != nil {
+		return nil, fmt.Errorf("failed to check for full-text index: %w", err)
+	}
+	// Multi-repo hydration imports, so a read-only store skips it
+	return &SQLiteStorage{db: db, dbPath: absPath, hasFTS: ftsTables > 0}, nil
+}
+
 // REMOVED (bd-8e05): getNextIDForPrefix and AllocateNextID - sequential ID generation
 // no longer needed with hash-based IDs
 // Migration functions moved to migrations.go (bd-fc2d, bd-b245)
@@ -137,21 +210,6 @@ func New(path string) (*SQLiteStorage, error) {
 
 // CreateIssue creates a new issue
 func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
-	// Validate issue before creating
-	if err := issue.Validate(); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
-	}
-
-	// Set timestamps
-	now := time.Now()
-	issue.CreatedAt = now
-	issue.UpdatedAt = now
-
-	// Compute content hash (bd-95)
-	if issue.ContentHash == "" {
-		issue.ContentHash = issue.ComputeContentHash()
-	}
-
 	// Acquire a dedicated connection for the transaction.
 	// This is necessary because we need to execute raw SQL ("BEGIN IMMEDIATE", "COMMIT")
 	// on the same connection, and database/sql's connection pool would otherwise
@@ -181,28 +239,54 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 		}
 	}()
 
-	// Get prefix from config (needed for both ID generation and validation)
-	var prefix string
-	err = conn.QueryRowContext(ctx, `SELECT value FROM config WHERE key = ?`, "issue_prefix").Scan(&prefix)
-	if err == sql.ErrNoRows || prefix == "" {
-		// CRITICAL: Reject operation if issue_prefix config is missing (bd-166)
-		// This prevents duplicate issues with wrong prefix
-		return fmt.Errorf("database not initialized: issue_prefix config is missing (run 'bd init --prefix <prefix>' first)")
-	} else if err != nil {
-		return fmt.Errorf("failed to get config: %w", err)
+	if err := s.createIssueIn(ctx, conn, issue, actor); err != nil {
+		return err
+	}
+
+	// Commit the transaction
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	committed = true
+	return nil
+}
+
+// createIssueIn validates and inserts issue using conn, which must already be
+// inside a transaction
+func (s *SQLiteStorage) createIssueIn(ctx context.Context, conn *sql.Conn, issue *types.Issue, actor string) error {
+	// Validate issue before creating
+	if err := issue.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	// Set timestamps
+	now := time.Now()
+	issue.CreatedAt = now
+	issue.UpdatedAt = now
+
+	// Compute content hash (bd-95)
+	if issue.ContentHash == "" {
+		issue.ContentHash = issue.ComputeContentHash()
+	}
+
+	// Get prefixes from config (needed for both ID generation and validation)
+	prefixes, err := getIDPrefixes(ctx, conn)
+	if err != nil {
+		return err
 	}
 
 	// Generate or validate ID
 	if issue.ID == "" {
-		// Generate hash-based ID with adaptive length based on database size (bd-ea2a13)
-		generatedID, err := GenerateIssueID(ctx, conn, prefix, issue, actor)
+		// Generate hash-based ID with adaptive length based on database size (bd-ea2a13),
+		// using the prefix_by_type prefix for the issue's type if there is one
+		generatedID, err := GenerateIssueID(ctx, conn, prefixes.ForType(issue.IssueType), issue)
 		if err != nil {
 			return err
 		}
 		issue.ID = generatedID
 	} else {
-		// Validate that explicitly provided ID matches the configured prefix (bd-177)
-		if err := ValidateIssueIDPrefix(issue.ID, prefix); err != nil {
+		// Validate that explicitly provided ID matches a configured prefix (bd-177)
+		if err := validateIssueIDPrefixes(issue.ID, prefixes); err != nil {
 			return err
 		}
 		
@@ -238,11 +322,6 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 		return err
 	}
 
-	// Commit the transaction
-	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
-	committed = true
 	return nil
 }
 
@@ -251,6 +330,11 @@ func (s *SQLiteStorage) CreateIssue(ctx context.Context, issue *types.Issue, act
 
 // GetIssue retrieves an issue by ID
 func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	return getIssue(ctx, s.db, id)
+}
+
+// getIssue reads an issue and its labels through q, returning nil if not found
+func getIssue(ctx context.Context, q dbExecutor, id string) (*types.Issue, error) {
 	var issue types.Issue
 	var closedAt sql.NullTime
 	var estimatedMinutes sql.NullInt64
@@ -259,14 +343,22 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	var compactedAt sql.NullTime
 	var originalSize sql.NullInt64
 	var sourceRepo sql.NullString
+	var resolution sql.NullString
+	var spentMinutes sql.NullInt64
+	var metadata sql.NullString
+	var attachments sql.NullString
+	var resolvedBy sql.NullString
+	var archivedAt sql.NullTime
+	var idNonce sql.NullInt64
 
 	var contentHash sql.NullString
 	var compactedAtCommit sql.NullString
-	err := s.db.QueryRowContext(ctx, `
+	err := q.QueryRowContext(ctx, `
 		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
 		       status, priority, issue_type, assignee, estimated_minutes,
 		       created_at, updated_at, closed_at, external_ref,
-		       compaction_level, compacted_at, compacted_at_commit, original_size, source_repo
+		       compaction_level, compacted_at, compacted_at_commit, original_size, source_repo,
+		       resolution, spent_minutes, metadata, archived_at, id_nonce, attachments, resolved_by
 		FROM issues
 		WHERE id = ?
 	`, id).Scan(
@@ -275,6 +367,7 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 		&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
 		&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRef,
 		&issue.CompactionLevel, &compactedAt, &compactedAtCommit, &originalSize, &sourceRepo,
+		&resolution, &spentMinutes, &metadata, &archivedAt, &idNonce, &attachments, &resolvedBy,
 	)
 
 	if err == sql.ErrNoRows {
@@ -312,9 +405,32 @@ func (s *SQLiteStorage) GetIssue(ctx context.Context, id string) (*types.Issue,
 	if sourceRepo.Valid {
 		issue.SourceRepo = sourceRepo.String
 	}
+	if resolution.Valid {
+		issue.Resolution = types.Resolution(resolution.String)
+	}
+	if spentMinutes.Valid {
+		mins := int(spentMinutes.Int64)
+		issue.SpentMinutes = &mins
+	}
+	if issue.Metadata, err = decodeIssueMetadata(metadata); err != nil {
+		return nil, fmt.Errorf("issue %s: %w", issue.ID, err)
+	}
+	if issue.Attachments, err = decodeIssueAttachments(attachments); err != nil {
+		return nil, fmt.Errorf("issue %s: %w", issue.ID, err)
+	}
+	if resolvedBy.Valid {
+		issue.ResolvedBy = resolvedBy.String
+	}
+	if archivedAt.Valid {
+		issue.ArchivedAt = &archivedAt.Time
+	}
+	if idNonce.Valid {
+		nonce := int(idNonce.Int64)
+		issue.IDNonce = &nonce
+	}
 
 	// Fetch labels for this issue
-	labels, err := s.GetLabels(ctx, issue.ID)
+	labels, err := getLabels(ctx, q, issue.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get labels: %w", err)
 	}
@@ -334,12 +450,20 @@ func (s *SQLiteStorage) GetIssueByExternalRef(ctx context.Context, externalRef s
 	var originalSize sql.NullInt64
 	var contentHash sql.NullString
 	var compactedAtCommit sql.NullString
+	var resolution sql.NullString
+	var spentMinutes sql.NullInt64
+	var metadata sql.NullString
+	var attachments sql.NullString
+	var resolvedBy sql.NullString
+	var archivedAt sql.NullTime
+	var idNonce sql.NullInt64
 
 	err := s.db.QueryRowContext(ctx, `
 		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
 		       status, priority, issue_type, assignee, estimated_minutes,
 		       created_at, updated_at, closed_at, external_ref,
-		       compaction_level, compacted_at, compacted_at_commit, original_size
+		       compaction_level, compacted_at, compacted_at_commit, original_size, resolution,
+		       spent_minutes, metadata, archived_at, id_nonce, attachments, resolved_by
 		FROM issues
 		WHERE external_ref = ?
 	`, externalRef).Scan(
@@ -347,7 +471,8 @@ func (s *SQLiteStorage) GetIssueByExternalRef(ctx context.Context, externalRef s
 		&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
 		&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
 		&issue.CreatedAt, &issue.UpdatedAt, &closedAt, &externalRefCol,
-		&issue.CompactionLevel, &compactedAt, &compactedAtCommit, &originalSize,
+		&issue.CompactionLevel, &compactedAt, &compactedAtCommit, &originalSize, &resolution,
+		&spentMinutes, &metadata, &archivedAt, &idNonce, &attachments, &resolvedBy,
 	)
 
 	if err == sql.ErrNoRows {
@@ -382,6 +507,29 @@ func (s *SQLiteStorage) GetIssueByExternalRef(ctx context.Context, externalRef s
 	if originalSize.Valid {
 		issue.OriginalSize = int(originalSize.Int64)
 	}
+	if resolution.Valid {
+		issue.Resolution = types.Resolution(resolution.String)
+	}
+	if spentMinutes.Valid {
+		mins := int(spentMinutes.Int64)
+		issue.SpentMinutes = &mins
+	}
+	if issue.Metadata, err = decodeIssueMetadata(metadata); err != nil {
+		return nil, fmt.Errorf("issue %s: %w", issue.ID, err)
+	}
+	if issue.Attachments, err = decodeIssueAttachments(attachments); err != nil {
+		return nil, fmt.Errorf("issue %s: %w", issue.ID, err)
+	}
+	if resolvedBy.Valid {
+		issue.ResolvedBy = resolvedBy.String
+	}
+	if archivedAt.Valid {
+		issue.ArchivedAt = &archivedAt.Time
+	}
+	if idNonce.Valid {
+		nonce := int(idNonce.Int64)
+		issue.IDNonce = &nonce
+	}
 
 	// Fetch labels for this issue
 	labels, err := s.GetLabels(ctx, issue.ID)
@@ -405,8 +553,14 @@ var allowedUpdateFields = map[string]bool{
 	"notes":               true,
 	"issue_type":          true,
 	"estimated_minutes":   true,
+	"spent_minutes":       true,
 	"external_ref":        true,
 	"closed_at":           true,
+	"resolution":          true,
+	"resolved_by":         true,
+	"metadata":            true,
+	"attachments":         true,
+	"archived_at":         true,
 }
 
 // validatePriority validates a priority value
@@ -416,6 +570,9 @@ var allowedUpdateFields = map[string]bool{
 func determineEventType(oldIssue *types.Issue, updates map[string]interface{}) types.EventType {
 	statusVal, hasStatus := updates["status"]
 	if !hasStatus {
+		if types.OnlyClearsFields(updates) {
+			return types.EventFieldsCleared
+		}
 		return types.EventUpdated
 	}
 
@@ -470,6 +627,18 @@ func manageClosedAt(oldIssue *types.Issue, updates map[string]interface{}, setCl
 		updates["closed_at"] = nil
 		setClauses = append(setClauses, "closed_at = ?")
 		args = append(args, nil)
+
+		// and the resolution, which only describes the close
+		if _, hasResolution := updates["resolution"]; !hasResolution && oldIssue.Resolution != "" {
+			updates["resolution"] = ""
+			setClauses = append(setClauses, "resolution = ?")
+			args = append(args, "")
+		}
+		if _, hasResolvedBy := updates["resolved_by"]; !hasResolvedBy && oldIssue.ResolvedBy != "" {
+			updates["resolved_by"] = ""
+			setClauses = append(setClauses, "resolved_by = ?")
+			args = append(args, "")
+		}
 	}
 
 	return setClauses, args
@@ -477,13 +646,59 @@ func manageClosedAt(oldIssue *types.Issue, updates map[string]interface{}, setCl
 
 // UpdateIssue updates fields on an issue
 func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	return s.updateIssue(ctx, id, updates, actor, "", "")
+}
+
+// UpdateIssueIfUnchanged applies updates only if the issue's updated_at still
+// equals expectedUpdatedAt, checking and writing in one transaction. Every
+// write to an issue's fields, closing and reopening included, moves
+// updated_at, so it serves as the issue's version.
+func (s *SQLiteStorage) UpdateIssueIfUnchanged(ctx context.Context, id string, expectedUpdatedAt time.Time, updates map[string]interface{}, actor string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		current, err := getIssue(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return types.WithCode(types.ErrCodeNotFound, fmt.Errorf("issue %s not found", id))
+		}
+		if !current.UpdatedAt.Equal(expectedUpdatedAt) {
+			return &storage.ConflictError{ID: id, Expected: expectedUpdatedAt, Actual: current.UpdatedAt}
+		}
+		return updateIssueIn(ctx, tx, id, updates, actor, "", "")
+	})
+}
+
+// ReopenIssue sets an issue back to open and always records a Reopened event
+// carrying note; callers skip issues that aren't closed unless forced
+func (s *SQLiteStorage) ReopenIssue(ctx context.Context, id string, note string, actor string) error {
+	return s.updateIssue(ctx, id, map[string]interface{}{
+		"status": string(types.StatusOpen),
+	}, actor, note, types.EventReopened)
+}
+
+// updateIssue applies updates and records eventType (or one derived from the
+// status change if empty) carrying the optional note
+func (s *SQLiteStorage) updateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string, note string, eventType types.EventType) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return updateIssueIn(ctx, tx, id, updates, actor, note, eventType)
+	})
+}
+
+// updateIssueIn applies updates and records the event through tx
+func updateIssueIn(ctx context.Context, tx dbExecutor, id string, updates map[string]interface{}, actor string, note string, eventType types.EventType) error {
+	return updateIssueWithCommentIn(ctx, tx, id, updates, actor, note, eventType, "")
+}
+
+// updateIssueWithCommentIn is updateIssueIn that also sets the event's comment
+func updateIssueWithCommentIn(ctx context.Context, tx dbExecutor, id string, updates map[string]interface{}, actor string, note string, eventType types.EventType, comment string) error {
 	// Get old issue for event
-	oldIssue, err := s.GetIssue(ctx, id)
+	oldIssue, err := getIssue(ctx, tx, id)
 	if err != nil {
 		return err
 	}
 	if oldIssue == nil {
-		return fmt.Errorf("issue %s not found", id)
+		return types.WithCode(types.ErrCodeNotFound, fmt.Errorf("issue %s not found", id))
 	}
 
 	// Build update query with validated field names
@@ -493,12 +708,33 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 	for key, value := range updates {
 		// Prevent SQL injection by validating field names
 		if !allowedUpdateFields[key] {
-			return fmt.Errorf("invalid field for update: %s", key)
+			return types.WithCode(types.ErrCodeValidation, fmt.Errorf("invalid field for update: %s", key))
 		}
 
 		// Validate field values
 		if err := validateFieldUpdate(key, value); err != nil {
-			return err
+			return types.WithCode(types.ErrCodeValidation, err)
+		}
+
+		if key == "metadata" {
+			metadata, err := metadataUpdateValue(value)
+			if err != nil {
+				return err
+			}
+			// Store the normalized map so the hash below and the event see it
+			updates[key] = metadata
+			value = encodeIssueMetadata(metadata)
+		}
+		if key == "attachments" {
+			attachments, err := attachmentsUpdateValue(value)
+			if err != nil {
+				return err
+			}
+			if len(attachments) == 0 {
+				attachments = nil
+			}
+			updates[key] = attachments
+			value = encodeIssueAttachments(attachments)
 		}
 
 		setClauses = append(setClauses, fmt.Sprintf("%s = ?", key))
@@ -510,7 +746,7 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 
 	// Recompute content_hash if any content fields changed (bd-95)
 	contentChanged := false
-	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref"}
+	contentFields := []string{"title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "external_ref", "resolution", "resolved_by", "spent_minutes", "metadata", "archived_at", "attachments"}
 	for _, field := range contentFields {
 		if _, exists := updates[field]; exists {
 			contentChanged = true
@@ -568,6 +804,27 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 						return fmt.Errorf("external_ref must be string or *string, got %T", value)
 					}
 				}
+			case "resolution":
+				// Handle both string and types.Resolution
+				if r, ok := value.(types.Resolution); ok {
+					updatedIssue.Resolution = r
+				} else {
+					updatedIssue.Resolution = types.Resolution(value.(string))
+				}
+			case "resolved_by":
+				updatedIssue.ResolvedBy, _ = value.(string)
+			case "spent_minutes":
+				if mins, ok := value.(int); ok {
+					updatedIssue.SpentMinutes = &mins
+				} else {
+					updatedIssue.SpentMinutes = nil
+				}
+			case "metadata":
+				updatedIssue.Metadata = value.(map[string]string)
+			case "attachments":
+				updatedIssue.Attachments = value.([]string)
+			case "archived_at":
+				updatedIssue.ArchivedAt = archivedAtUpdateValue(value)
 			}
 		}
 		newHash := updatedIssue.ComputeContentHash()
@@ -577,13 +834,6 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 
 	args = append(args, id)
 
-	// Start transaction
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer func() { _ = tx.Rollback() }()
-
 	// Update issue
 	query := fmt.Sprintf("UPDATE issues SET %s WHERE id = ?", strings.Join(setClauses, ", ")) // #nosec G201 - safe SQL with controlled column names
 	_, err = tx.ExecContext(ctx, query, args...)
@@ -605,12 +855,14 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 	oldDataStr := string(oldData)
 	newDataStr := string(newData)
 
-	eventType := determineEventType(oldIssue, updates)
+	if eventType == "" {
+		eventType = determineEventType(oldIssue, updates)
+	}
 
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, old_value, new_value)
-		VALUES (?, ?, ?, ?, ?)
-	`, id, eventType, actor, oldDataStr, newDataStr)
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment, note)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, id, eventType, actor, oldDataStr, newDataStr, eventNote(comment), eventNote(note))
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
@@ -625,7 +877,7 @@ func (s *SQLiteStorage) UpdateIssue(ctx context.Context, id string, updates map[
 		return fmt.Errorf("failed to mark issue dirty: %w", err)
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 // UpdateIssueID updates an issue ID and all its text fields in a single transaction
@@ -643,13 +895,34 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 		return fmt.Errorf("failed to disable foreign keys: %w", err)
 	}
 
-	tx, err := conn.BeginTx(ctx, nil)
+	tx, err := conn.BeginTx(ctx, writeTxOptions)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	_, err = tx.ExecContext(ctx, `
+	if err := updateIssueIDIn(ctx, tx, oldID, newID, issue, actor); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// updateIssueIDIn renames an issue and every row referencing it through tx.
+// Foreign keys must be disabled or deferred, since rows briefly point at the
+// old ID. Renaming onto another issue's ID fails with *storage.IDExistsError
+// before anything is changed.
+func updateIssueIDIn(ctx context.Context, tx dbExecutor, oldID, newID string, issue *types.Issue, actor string) error {
+	if newID != oldID {
+		var exists int
+		if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id = ?`, newID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check for existing issue %s: %w", newID, err)
+		}
+		if exists > 0 {
+			return &storage.IDExistsError{ID: newID}
+		}
+	}
+
+	_, err := tx.ExecContext(ctx, `
 		UPDATE issues
 		SET id = ?, title = ?, description = ?, design = ?, acceptance_criteria = ?, notes = ?, updated_at = ?
 		WHERE id = ?
@@ -700,6 +973,11 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 		return fmt.Errorf("failed to update compaction_snapshots: %w", err)
 	}
 
+	_, err = tx.ExecContext(ctx, `UPDATE child_counters SET parent_id = ? WHERE parent_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update child_counters: %w", err)
+	}
+
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO dirty_issues (issue_id, marked_at)
 		VALUES (?, ?)
@@ -717,7 +995,7 @@ func (s *SQLiteStorage) UpdateIssueID(ctx context.Context, oldID, newID string,
 		return fmt.Errorf("failed to record rename event: %w", err)
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 // RenameDependencyPrefix updates the prefix in all dependency records
@@ -739,29 +1017,52 @@ func (s *SQLiteStorage) ResetCounter(ctx context.Context, prefix string) error {
 	return nil
 }
 
-// CloseIssue closes an issue with a reason
-func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string, actor string) error {
-	now := time.Now()
+// CloseIssue closes an issue, recording opts on the Closed event
+func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, opts storage.CloseOptions, actor string) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return closeIssueIn(ctx, tx, id, opts, actor)
+	})
+}
 
-	// Update with special event handling
-	tx, err := s.db.BeginTx(ctx, nil)
+// closeIssueIn closes an issue and records the Closed event through tx
+func closeIssueIn(ctx context.Context, tx dbExecutor, id string, opts storage.CloseOptions, actor string) error {
+	// Record the prior state like update events do, so the close can be undone
+	var oldValue interface{}
+	if oldIssue, err := getIssue(ctx, tx, id); err == nil && oldIssue != nil {
+		if data, err := json.Marshal(oldIssue); err == nil {
+			oldValue = string(data)
+		}
+	}
+	newValue := map[string]interface{}{
+		"status":     types.StatusClosed,
+		"resolution": opts.Resolution,
+	}
+	if opts.Commit != "" {
+		newValue["resolved_by"] = opts.Commit
+	}
+	newData, err := json.Marshal(newValue)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return fmt.Errorf("failed to encode event: %w", err)
 	}
-	defer func() { _ = tx.Rollback() }()
 
+	now := time.Now()
+
+	// Update with special event handling
 	_, err = tx.ExecContext(ctx, `
-		UPDATE issues SET status = ?, closed_at = ?, updated_at = ?
+		UPDATE issues SET status = ?, closed_at = ?, updated_at = ?, resolution = ?, resolved_by = ?
 		WHERE id = ?
-	`, types.StatusClosed, now, now, id)
+	`, types.StatusClosed, now, now, opts.Resolution, opts.Commit, id)
 	if err != nil {
 		return fmt.Errorf("failed to close issue: %w", err)
 	}
 
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO events (issue_id, event_type, actor, comment)
-		VALUES (?, ?, ?, ?)
-	`, id, types.EventClosed, actor, reason)
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment, note)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, id, types.EventClosed, actor, oldValue, string(newData), opts.Reason, eventNote(opts.Note))
 	if err != nil {
 		return fmt.Errorf("failed to record event: %w", err)
 	}
@@ -776,19 +1077,21 @@ func (s *SQLiteStorage) CloseIssue(ctx context.Context, id string, reason string
 		return fmt.Errorf("failed to mark issue dirty: %w", err)
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 // DeleteIssue permanently removes an issue from the database
 func (s *SQLiteStorage) DeleteIssue(ctx context.Context, id string) error {
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer func() { _ = tx.Rollback() }()
+	// REMOVED (bd-c7af): Counter sync after deletion - no longer needed with hash IDs
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return deleteIssueIn(ctx, tx, id)
+	})
+}
 
+// deleteIssueIn removes an issue and its dependencies, events and dirty marker through tx
+func deleteIssueIn(ctx context.Context, tx dbExecutor, id string) error {
 	// Delete dependencies (both directions)
-	_, err = tx.ExecContext(ctx, `DELETE FROM dependencies WHERE issue_id = ? OR depends_on_id = ?`, id, id)
+	_, err := tx.ExecContext(ctx, `DELETE FROM dependencies WHERE issue_id = ? OR depends_on_id = ?`, id, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete dependencies: %w", err)
 	}
@@ -819,12 +1122,8 @@ func (s *SQLiteStorage) DeleteIssue(ctx context.Context, id string) error {
 		return fmt.Errorf("issue not found: %s", id)
 	}
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
-
-	// REMOVED (bd-c7af): Counter sync after deletion - no longer needed with hash IDs
-	return nil
+	// Let the next child reuse the number if this was the newest child
+	return reclaimChildNumber(ctx, tx, id)
 }
 
 // DeleteIssuesResult contains statistics about a batch deletion operation
@@ -834,6 +1133,7 @@ type DeleteIssuesResult struct {
 	LabelsCount       int
 	EventsCount       int
 	OrphanedIssues    []string
+	DeletedIDs        []string // ids plus the dependents cascade added
 }
 
 // DeleteIssues deletes multiple issues in a single transaction
@@ -846,12 +1146,28 @@ func (s *SQLiteStorage) DeleteIssues(ctx context.Context, ids []string, cascade
 		return &DeleteIssuesResult{}, nil
 	}
 
-	tx, err := s.db.BeginTx(ctx, nil)
+	tx, err := s.db.BeginTx(ctx, writeTxOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	result, err := s.deleteIssuesIn(ctx, tx, ids, cascade, force, dryRun)
+	if err != nil || dryRun {
+		return result, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// REMOVED (bd-c7af): Counter sync after deletion - no longer needed with hash IDs
+
+	return result, nil
+}
+
+// deleteIssuesIn is DeleteIssues through tx, which the caller commits
+func (s *SQLiteStorage) deleteIssuesIn(ctx context.Context, tx dbExecutor, ids []string, cascade bool, force bool, dryRun bool) (*DeleteIssuesResult, error) {
 	idSet := buildIDSet(ids)
 	result := &DeleteIssuesResult{}
 
@@ -859,6 +1175,7 @@ func (s *SQLiteStorage) DeleteIssues(ctx context.Context, ids []string, cascade
 	if err != nil {
 		return nil, err
 	}
+	result.DeletedIDs = expandedIDs
 
 	inClause, args := buildSQLInClause(expandedIDs)
 	if err := s.populateDeleteStats(ctx, tx, inClause, args, result); err != nil {
@@ -872,13 +1189,6 @@ func (s *SQLiteStorage) DeleteIssues(ctx context.Context, ids []string, cascade
 	if err := s.executeDelete(ctx, tx, inClause, args, result); err != nil {
 		return nil, err
 	}
-
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
-	// REMOVED (bd-c7af): Counter sync after deletion - no longer needed with hash IDs
-
 	return result, nil
 }
 
@@ -890,7 +1200,7 @@ func buildIDSet(ids []string) map[string]bool {
 	return idSet
 }
 
-func (s *SQLiteStorage) resolveDeleteSet(ctx context.Context, tx *sql.Tx, ids []string, idSet map[string]bool, cascade bool, force bool, result *DeleteIssuesResult) ([]string, error) {
+func (s *SQLiteStorage) resolveDeleteSet(ctx context.Context, tx dbExecutor, ids []string, idSet map[string]bool, cascade bool, force bool, result *DeleteIssuesResult) ([]string, error) {
 	if cascade {
 		return s.expandWithDependents(ctx, tx, ids, idSet)
 	}
@@ -900,7 +1210,7 @@ func (s *SQLiteStorage) resolveDeleteSet(ctx context.Context, tx *sql.Tx, ids []
 	return ids, s.trackOrphanedIssues(ctx, tx, ids, idSet, result)
 }
 
-func (s *SQLiteStorage) expandWithDependents(ctx context.Context, tx *sql.Tx, ids []string, _ map[string]bool) ([]string, error) {
+func (s *SQLiteStorage) expandWithDependents(ctx context.Context, tx dbExecutor, ids []string, _ map[string]bool) ([]string, error) {
 	allToDelete, err := s.findAllDependentsRecursive(ctx, tx, ids)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find dependents: %w", err)
@@ -912,7 +1222,7 @@ func (s *SQLiteStorage) expandWithDependents(ctx context.Context, tx *sql.Tx, id
 	return expandedIDs, nil
 }
 
-func (s *SQLiteStorage) validateNoDependents(ctx context.Context, tx *sql.Tx, ids []string, idSet map[string]bool, result *DeleteIssuesResult) error {
+func (s *SQLiteStorage) validateNoDependents(ctx context.Context, tx dbExecutor, ids []string, idSet map[string]bool, result *DeleteIssuesResult) error {
 	for _, id := range ids {
 		if err := s.checkSingleIssueValidation(ctx, tx, id, idSet, result); err != nil {
 			return err
@@ -921,7 +1231,7 @@ func (s *SQLiteStorage) validateNoDependents(ctx context.Context, tx *sql.Tx, id
 	return nil
 }
 
-func (s *SQLiteStorage) checkSingleIssueValidation(ctx context.Context, tx *sql.Tx, id string, idSet map[string]bool, result *DeleteIssuesResult) error {
+func (s *SQLiteStorage) checkSingleIssueValidation(ctx context.Context, tx dbExecutor, id string, idSet map[string]bool, result *DeleteIssuesResult) error {
 	var depCount int
 	err := tx.QueryRowContext(ctx,
 		`SELECT COUNT(*) FROM dependencies WHERE depends_on_id = ?`, id).Scan(&depCount)
@@ -961,7 +1271,7 @@ func (s *SQLiteStorage) checkSingleIssueValidation(ctx context.Context, tx *sql.
 	return nil
 }
 
-func (s *SQLiteStorage) trackOrphanedIssues(ctx context.Context, tx *sql.Tx, ids []string, idSet map[string]bool, result *DeleteIssuesResult) error {
+func (s *SQLiteStorage) trackOrphanedIssues(ctx context.Context, tx dbExecutor, ids []string, idSet map[string]bool, result *DeleteIssuesResult) error {
 	orphanSet := make(map[string]bool)
 	for _, id := range ids {
 		if err := s.collectOrphansForID(ctx, tx, id, idSet, orphanSet); err != nil {
@@ -974,7 +1284,7 @@ func (s *SQLiteStorage) trackOrphanedIssues(ctx context.Context, tx *sql.Tx, ids
 	return nil
 }
 
-func (s *SQLiteStorage) collectOrphansForID(ctx context.Context, tx *sql.Tx, id string, idSet map[string]bool, orphanSet map[string]bool) error {
+func (s *SQLiteStorage) collectOrphansForID(ctx context.Context, tx dbExecutor, id string, idSet map[string]bool, orphanSet map[string]bool) error {
 	rows, err := tx.QueryContext(ctx,
 		`SELECT issue_id FROM dependencies WHERE depends_on_id = ?`, id)
 	if err != nil {
@@ -1004,7 +1314,7 @@ func buildSQLInClause(ids []string) (string, []interface{}) {
 	return strings.Join(placeholders, ","), args
 }
 
-func (s *SQLiteStorage) populateDeleteStats(ctx context.Context, tx *sql.Tx, inClause string, args []interface{}, result *DeleteIssuesResult) error {
+func (s *SQLiteStorage) populateDeleteStats(ctx context.Context, tx dbExecutor, inClause string, args []interface{}, result *DeleteIssuesResult) error {
 	counts := []struct {
 		query string
 		dest  *int
@@ -1028,7 +1338,7 @@ func (s *SQLiteStorage) populateDeleteStats(ctx context.Context, tx *sql.Tx, inC
 	return nil
 }
 
-func (s *SQLiteStorage) executeDelete(ctx context.Context, tx *sql.Tx, inClause string, args []interface{}, result *DeleteIssuesResult) error {
+func (s *SQLiteStorage) executeDelete(ctx context.Context, tx dbExecutor, inClause string, args []interface{}, result *DeleteIssuesResult) error {
 	deletes := []struct {
 		query string
 		args  []interface{}
@@ -1053,11 +1363,20 @@ func (s *SQLiteStorage) executeDelete(ctx context.Context, tx *sql.Tx, inClause
 			result.DeletedCount = int(rowsAffected)
 		}
 	}
+
+	// Let new children reuse the numbers of deleted newest children
+	for _, arg := range args {
+		if id, ok := arg.(string); ok {
+			if err := reclaimChildNumber(ctx, tx, id); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
 // findAllDependentsRecursive finds all issues that depend on the given issues, recursively
-func (s *SQLiteStorage) findAllDependentsRecursive(ctx context.Context, tx *sql.Tx, ids []string) (map[string]bool, error) {
+func (s *SQLiteStorage) findAllDependentsRecursive(ctx context.Context, tx dbExecutor, ids []string) (map[string]bool, error) {
 	result := make(map[string]bool)
 	for _, id := range ids {
 		result[id] = true
@@ -1097,14 +1416,134 @@ func (s *SQLiteStorage) findAllDependentsRecursive(ctx context.Context, tx *sql.
 	return result, nil
 }
 
-// SearchIssues finds issues matching query and filters
+// SearchIssues finds issues matching query and filters. A non-empty query is
+// matched against the full-text index when available, ranked by bm25, unless
+// filter.SortBy orders the results. Ties break by ID, so pages taken with
+// Limit and Offset neither skip nor repeat issues while the matches stay the
+// same.
 func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	querySQL, args, err := s.searchSQL(query, filter, "")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return s.scanIssues(ctx, rows)
+}
+
+// StreamIssues calls fn with each issue SearchIssues finds, in the same order
+// and with labels, as rows are read instead of after collecting them. fn runs
+// while the query is open, so it must not use the store. An error from fn
+// stops the stream and is returned unwrapped.
+func (s *SQLiteStorage) StreamIssues(ctx context.Context, query string, filter types.IssueFilter, fn func(*types.Issue) error) error {
+	// Labels come from the same query: a second one would need another
+	// connection while this one is busy
+	querySQL, args, err := s.searchSQL(query, filter,
+		", (SELECT group_concat(label, char(31)) FROM labels WHERE labels.issue_id = issues.id)")
+	if err != nil {
+		return err
+	}
+	rows, err := s.db.QueryContext(ctx, querySQL, args...)
+	if err != nil {
+		return fmt.Errorf("failed to search issues: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var labels sql.NullString
+		issue, err := scanIssueRow(rows, &labels)
+		if err != nil {
+			return err
+		}
+		if labels.Valid && labels.String != "" {
+			issue.Labels = strings.Split(labels.String, "\x1f")
+			sort.Strings(issue.Labels)
+		}
+		if err := fn(issue); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read issues: %w", err)
+	}
+	return nil
+}
+
+// searchSQL builds the SELECT for a search, with extraColumns (starting with
+// a comma) after the issue columns
+func (s *SQLiteStorage) searchSQL(query string, filter types.IssueFilter, extraColumns string) (string, []interface{}, error) {
+	fromSQL, orderSQL, args, err := s.searchClauses(query, filter)
+	if err != nil {
+		return "", nil, err
+	}
+
+	limitSQL := ""
+	if filter.Limit > 0 || filter.Offset > 0 {
+		// OFFSET needs a LIMIT; -1 means no limit
+		limit := filter.Limit
+		if limit <= 0 {
+			limit = -1
+		}
+		limitSQL = " LIMIT ? OFFSET ?"
+		args = append(args, limit, max(filter.Offset, 0))
+	}
+
+	// #nosec G201 - safe SQL with controlled formatting
+	querySQL := fmt.Sprintf(`
+		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, assignee, estimated_minutes,
+		       created_at, updated_at, closed_at, external_ref, source_repo, resolution,
+		       spent_minutes, metadata, archived_at, id_nonce, attachments, resolved_by%s
+		FROM issues
+		%s
+		ORDER BY %s
+		%s
+	`, extraColumns, fromSQL, orderSQL, limitSQL)
+	return querySQL, args, nil
+}
+
+// CountIssues returns how many issues SearchIssues finds for query and
+// filter, ignoring Limit and Offset
+func (s *SQLiteStorage) CountIssues(ctx context.Context, query string, filter types.IssueFilter) (int, error) {
+	fromSQL, _, args, err := s.searchClauses(query, filter)
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	// #nosec G201 - safe SQL with controlled formatting
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM issues "+fromSQL, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count issues: %w", err)
+	}
+	return count, nil
+}
+
+// searchClauses builds the joins and WHERE clause that follow FROM issues for
+// a search, with its ORDER BY terms and the arguments for both
+func (s *SQLiteStorage) searchClauses(query string, filter types.IssueFilter) (string, string, []interface{}, error) {
 	whereClauses := []string{}
 	args := []interface{}{}
+	joinSQL := ""
+	orderSQL := "priority ASC, created_at DESC, id ASC"
 
 	if query != "" {
-		whereClauses = append(whereClauses, "(title LIKE ? OR description LIKE ? OR id LIKE ?)")
 		pattern := "%" + query + "%"
+		if match := ftsMatchQuery(query); s.hasFTS && match != "" {
+			// Ranked full-text matches first (title hits weigh most), then
+			// issues that only match as a substring, e.g. part of an ID
+			joinSQL = `LEFT JOIN (
+				SELECT id AS fts_id, bm25(issues_fts, 0, 10.0, 2.0, 1.0, 1.0, 1.0) AS fts_rank
+				FROM issues_fts WHERE issues_fts MATCH ?
+			) ON fts_id = issues.id`
+			args = append(args, match)
+			whereClauses = append(whereClauses, "(fts_id IS NOT NULL OR title LIKE ? OR description LIKE ? OR id LIKE ?)")
+			orderSQL = "fts_rank IS NULL, fts_rank, " + orderSQL
+		} else {
+			whereClauses = append(whereClauses, "(title LIKE ? OR description LIKE ? OR id LIKE ?)")
+		}
 		args = append(args, pattern, pattern, pattern)
 	}
 
@@ -1133,6 +1572,15 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		args = append(args, *filter.Status)
 	}
 
+	if len(filter.ExcludeStatus) > 0 {
+		placeholders := make([]string, len(filter.ExcludeStatus))
+		for i, status := range filter.ExcludeStatus {
+			placeholders[i] = "?"
+			args = append(args, status)
+		}
+		whereClauses = append(whereClauses, fmt.Sprintf("status NOT IN (%s)", strings.Join(placeholders, ", ")))
+	}
+
 	if filter.Priority != nil {
 		whereClauses = append(whereClauses, "priority = ?")
 		args = append(args, *filter.Priority)
@@ -1154,8 +1602,12 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 	}
 
 	if filter.Assignee != nil {
-		whereClauses = append(whereClauses, "assignee = ?")
-		args = append(args, *filter.Assignee)
+		if *filter.Assignee == "" {
+			whereClauses = append(whereClauses, "(assignee IS NULL OR assignee = '')")
+		} else {
+			whereClauses = append(whereClauses, "assignee = ?")
+			args = append(args, *filter.Assignee)
+		}
 	}
 
 	// Date ranges
@@ -1195,11 +1647,36 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		whereClauses = append(whereClauses, "id NOT IN (SELECT DISTINCT issue_id FROM labels)")
 	}
 
+	// Dependency state, using the same blocker queries as bd ready and bd blocked
+	if filter.Ready && filter.Blocked {
+		return "", "", nil, fmt.Errorf("ready and blocked filters are mutually exclusive")
+	}
+	if filter.Ready {
+		whereClauses = append(whereClauses, "status IN ('open', 'in_progress')", "id NOT IN ("+blockedTransitivelySQL+")")
+	}
+	if filter.Blocked {
+		whereClauses = append(whereClauses, "status != 'closed'", "id IN ("+blockedDirectlySQL+")")
+	}
+
+	// Archived issues only show up when asked for, by flag or by ID
+	if !filter.IncludeArchived && len(filter.IDs) == 0 {
+		whereClauses = append(whereClauses, "archived_at IS NULL")
+	}
+
+	// Metadata filtering: every key must be set to exactly the given value
+	for key, value := range filter.Metadata {
+		if err := types.ValidateMetadataKey(key); err != nil {
+			return "", "", nil, err
+		}
+		whereClauses = append(whereClauses, "json_extract(metadata, ?) = ?")
+		args = append(args, metadataJSONPath(key), value)
+	}
+
 	// Label filtering: issue must have ALL specified labels
 	if len(filter.Labels) > 0 {
 		for _, label := range filter.Labels {
 			whereClauses = append(whereClauses, "id IN (SELECT issue_id FROM labels WHERE label = ?)")
-			args = append(args, label)
+			args = append(args, util.NormalizeLabel(label))
 		}
 	}
 
@@ -1208,7 +1685,7 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		placeholders := make([]string, len(filter.LabelsAny))
 		for i, label := range filter.LabelsAny {
 			placeholders[i] = "?"
-			args = append(args, label)
+			args = append(args, util.NormalizeLabel(label))
 		}
 		whereClauses = append(whereClauses, fmt.Sprintf("id IN (SELECT issue_id FROM labels WHERE label IN (%s))", strings.Join(placeholders, ", ")))
 	}
@@ -1228,30 +1705,60 @@ func (s *SQLiteStorage) SearchIssues(ctx context.Context, query string, filter t
 		whereSQL = "WHERE " + strings.Join(whereClauses, " AND ")
 	}
 
-	limitSQL := ""
-	if filter.Limit > 0 {
-		limitSQL = " LIMIT ?"
-		args = append(args, filter.Limit)
+	// An explicit order replaces the default, relevance ranking included
+	if len(filter.SortBy) > 0 {
+		var err error
+		if orderSQL, err = sortOrderSQL(filter.SortBy); err != nil {
+			return "", "", nil, err
+		}
 	}
+	return joinSQL + "\n" + whereSQL, orderSQL, args, nil
+}
 
-	// #nosec G201 - safe SQL with controlled formatting
-	querySQL := fmt.Sprintf(`
-		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
-		       status, priority, issue_type, assignee, estimated_minutes,
-		       created_at, updated_at, closed_at, external_ref, source_repo
-		FROM issues
-		%s
-		ORDER BY priority ASC, created_at DESC
-		%s
-	`, whereSQL, limitSQL)
-
-	rows, err := s.db.QueryContext(ctx, querySQL, args...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search issues: %w", err)
+// sortOrderSQL translates sort keys into ORDER BY terms. Unless the keys
+// include the ID, it is appended so the order is total.
+func sortOrderSQL(keys []types.SortKey) (string, error) {
+	terms := make([]string, 0, len(keys)+1)
+	hasID := false
+	for _, key := range keys {
+		var term string
+		switch key.Field {
+		case types.SortFieldPriority, types.SortFieldCreatedAt, types.SortFieldUpdatedAt:
+			term = string(key.Field)
+		case types.SortFieldStatus:
+			term = "CASE status WHEN 'open' THEN 0 WHEN 'in_progress' THEN 1 WHEN 'blocked' THEN 2 WHEN 'closed' THEN 3 ELSE 4 END"
+		case types.SortFieldID:
+			term = "id"
+			hasID = true
+		default:
+			return "", fmt.Errorf("unknown sort field %q", key.Field)
+		}
+		if key.Descending {
+			term += " DESC"
+		} else {
+			term += " ASC"
+		}
+		terms = append(terms, term)
 	}
-	defer func() { _ = rows.Close() }()
+	if !hasID {
+		terms = append(terms, "id ASC")
+	}
+	return strings.Join(terms, ", "), nil
+}
 
-	return s.scanIssues(ctx, rows)
+// ftsMatchQuery turns free-form search text into an FTS5 MATCH expression:
+// every word must appear, as a prefix of an indexed token. Words are quoted so
+// FTS5 operators and punctuation in the query are taken literally. Returns ""
+// if the query has nothing to match on.
+func ftsMatchQuery(query string) string {
+	var terms []string
+	for _, word := range strings.Fields(query) {
+		if !strings.ContainsFunc(word, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) {
+			continue
+		}
+		terms = append(terms, `"`+strings.ReplaceAll(word, `"`, `""`)+`"*`)
+	}
+	return strings.Join(terms, " ")
 }
 
 // SetConfig sets a configuration value
@@ -1298,20 +1805,32 @@ func (s *SQLiteStorage) DeleteConfig(ctx context.Context, key string) error {
 	return err
 }
 
+// OrphanHandlingConfigKey is the config key selecting how imports treat
+// hierarchical issues whose parent is missing
+const OrphanHandlingConfigKey = "import.orphan_handling"
+
+// ParseOrphanHandling parses an import.orphan_handling value
+func ParseOrphanHandling(value string) (OrphanHandling, error) {
+	switch OrphanHandling(value) {
+	case OrphanStrict, OrphanResurrect, OrphanSkip, OrphanAllow:
+		return OrphanHandling(value), nil
+	}
+	return "", fmt.Errorf("%s must be strict, resurrect, skip or allow, got %q", OrphanHandlingConfigKey, value)
+}
+
 // GetOrphanHandling gets the import.orphan_handling config value
 // Returns OrphanAllow (the default) if not set or if value is invalid
 func (s *SQLiteStorage) GetOrphanHandling(ctx context.Context) OrphanHandling {
-	value, err := s.GetConfig(ctx, "import.orphan_handling")
+	value, err := s.GetConfig(ctx, OrphanHandlingConfigKey)
 	if err != nil || value == "" {
 		return OrphanAllow // Default
 	}
 	
-	switch OrphanHandling(value) {
-	case OrphanStrict, OrphanResurrect, OrphanSkip, OrphanAllow:
-		return OrphanHandling(value)
-	default:
+	mode, err := ParseOrphanHandling(value)
+	if err != nil {
 		return OrphanAllow // Invalid value, use default
 	}
+	return mode
 }
 
 // SetMetadata sets a metadata value (for internal state like import hashes)
@@ -1345,11 +1864,27 @@ func (s *SQLiteStorage) AddIssueComment(ctx context.Context, issueID, author, te
 		return nil, fmt.Errorf("issue %s not found", issueID)
 	}
 
-	// Insert comment
+	return s.insertComment(ctx, issueID, nil, author, text)
+}
+
+// AddCommentReply adds a reply to an existing comment on the same issue
+func (s *SQLiteStorage) AddCommentReply(ctx context.Context, issueID string, parentID int64, author, text string) (*types.Comment, error) {
+	parent, err := s.getComment(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent.IssueID != issueID {
+		return nil, fmt.Errorf("comment %d belongs to %s, not %s", parentID, parent.IssueID, issueID)
+	}
+	return s.insertComment(ctx, issueID, &parentID, author, text)
+}
+
+// insertComment inserts a comment and marks its issue dirty
+func (s *SQLiteStorage) insertComment(ctx context.Context, issueID string, parentID *int64, author, text string) (*types.Comment, error) {
 	result, err := s.db.ExecContext(ctx, `
-		INSERT INTO comments (issue_id, author, text, created_at)
-		VALUES (?, ?, ?, CURRENT_TIMESTAMP)
-	`, issueID, author, text)
+		INSERT INTO comments (issue_id, parent_comment_id, author, text, created_at)
+		VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, issueID, parentID, author, text)
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert comment: %w", err)
 	}
@@ -1361,11 +1896,7 @@ func (s *SQLiteStorage) AddIssueComment(ctx context.Context, issueID, author, te
 	}
 
 	// Fetch the complete comment
-	comment := &types.Comment{}
-	err = s.db.QueryRowContext(ctx, `
-		SELECT id, issue_id, author, text, created_at
-		FROM comments WHERE id = ?
-	`, commentID).Scan(&comment.ID, &comment.IssueID, &comment.Author, &comment.Text, &comment.CreatedAt)
+	comment, err := s.getComment(ctx, commentID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch comment: %w", err)
 	}
@@ -1378,13 +1909,59 @@ func (s *SQLiteStorage) AddIssueComment(ctx context.Context, issueID, author, te
 	return comment, nil
 }
 
+const commentColumns = `id, issue_id, parent_comment_id, author, text, resolved, created_at, original_text, edited_at, deleted_at`
+
+// scanComment scans a row selected with commentColumns
+func scanComment(row interface{ Scan(dest ...interface{}) error }) (*types.Comment, error) {
+	comment := &types.Comment{}
+	var parentID sql.NullInt64
+	var originalText sql.NullString
+	var editedAt, deletedAt sql.NullTime
+	if err := row.Scan(&comment.ID, &comment.IssueID, &parentID, &comment.Author, &comment.Text, &comment.Resolved, &comment.CreatedAt,
+		&originalText, &editedAt, &deletedAt); err != nil {
+		return nil, err
+	}
+	if parentID.Valid {
+		comment.ParentCommentID = &parentID.Int64
+	}
+	comment.OriginalText = originalText.String
+	if editedAt.Valid {
+		comment.EditedAt = &editedAt.Time
+	}
+	if deletedAt.Valid {
+		comment.DeletedAt = &deletedAt.Time
+	}
+	return comment, nil
+}
+
+// getComment retrieves a single comment by ID
+func (s *SQLiteStorage) getComment(ctx context.Context, commentID int64) (*types.Comment, error) {
+	return getCommentIn(ctx, s.db, commentID)
+}
+
+func getCommentIn(ctx context.Context, q dbExecutor, commentID int64) (*types.Comment, error) {
+	row := q.QueryRowContext(ctx, `SELECT `+commentColumns+` FROM comments WHERE id = ?`, commentID)
+	comment, err := scanComment(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("comment %d not found", commentID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment %d: %w", commentID, err)
+	}
+	return comment, nil
+}
+
 // GetIssueComments retrieves all comments for an issue
 func (s *SQLiteStorage) GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error) {
-	rows, err := s.db.QueryContext(ctx, `
-		SELECT id, issue_id, author, text, created_at
+	return getIssueComments(ctx, s.db, issueID)
+}
+
+func getIssueComments(ctx context.Context, q dbExecutor, issueID string) ([]*types.Comment, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT `+commentColumns+`
 		FROM comments
 		WHERE issue_id = ?
-		ORDER BY created_at ASC
+		ORDER BY created_at ASC, id ASC
 	`, issueID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query comments: %w", err)
@@ -1393,8 +1970,7 @@ func (s *SQLiteStorage) GetIssueComments(ctx context.Context, issueID string) ([
 
 	var comments []*types.Comment
 	for rows.Next() {
-		comment := &types.Comment{}
-		err := rows.Scan(&comment.ID, &comment.IssueID, &comment.Author, &comment.Text, &comment.CreatedAt)
+		comment, err := scanComment(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan comment: %w", err)
 		}
@@ -1408,6 +1984,163 @@ func (s *SQLiteStorage) GetIssueComments(ctx context.Context, issueID string) ([
 	return comments, nil
 }
 
+// UpdateComment replaces a comment's text and marks its issue dirty, without
+// recording an edit. It is meant for mechanical rewrites such as ID renames;
+// use EditComment for edits made by people.
+func (s *SQLiteStorage) UpdateComment(ctx context.Context, commentID int64, text string) (*types.Comment, error) {
+	return updateCommentIn(ctx, s.db, commentID, text)
+}
+
+func updateCommentIn(ctx context.Context, q dbExecutor, commentID int64, text string) (*types.Comment, error) {
+	comment, err := getCommentIn(ctx, q, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Text == text {
+		return comment, nil
+	}
+	if _, err := q.ExecContext(ctx, `UPDATE comments SET text = ? WHERE id = ?`, text, commentID); err != nil {
+		return nil, fmt.Errorf("failed to update comment %d: %w", commentID, err)
+	}
+	comment.Text = text
+
+	// Mark issue as dirty for JSONL export
+	if err := markIssuesDirtyTx(ctx, q, []string{comment.IssueID}); err != nil {
+		return nil, fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+
+	return comment, nil
+}
+
+// EditComment replaces a comment's text on behalf of actor. Unlike
+// UpdateComment, the edit is part of the history: the text as first written
+// is kept in OriginalText and the change is recorded as an event on the issue.
+func (s *SQLiteStorage) EditComment(ctx context.Context, commentID int64, text, actor string) (*types.Comment, error) {
+	var comment *types.Comment
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		comment, err = editCommentIn(ctx, tx, commentID, text, actor)
+		return err
+	})
+	return comment, err
+}
+
+func editCommentIn(ctx context.Context, tx dbExecutor, commentID int64, text, actor string) (*types.Comment, error) {
+	comment, err := getCommentIn(ctx, tx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.IsDeleted() {
+		return nil, fmt.Errorf("comment %d has been deleted", commentID)
+	}
+	if comment.Text == text {
+		return comment, nil
+	}
+
+	now := time.Now()
+	_, err = tx.ExecContext(ctx, `
+		UPDATE comments SET original_text = COALESCE(original_text, text), text = ?, edited_at = ?
+		WHERE id = ?
+	`, text, now, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to edit comment %d: %w", commentID, err)
+	}
+	oldText := comment.Text
+	comment.OriginalText = comment.FirstText()
+	comment.Text = text
+	comment.EditedAt = &now
+
+	if err := recordCommentEventIn(ctx, tx, comment, types.EventCommentEdited, &oldText, &text, actor); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// DeleteComment deletes a comment on behalf of actor. The comment stays as a
+// tombstone, with its text, so that importing a JSONL exported before the
+// deletion doesn't bring it back and replies keep their place in the thread.
+func (s *SQLiteStorage) DeleteComment(ctx context.Context, commentID int64, actor string) (*types.Comment, error) {
+	var comment *types.Comment
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		comment, err = deleteCommentIn(ctx, tx, commentID, actor)
+		return err
+	})
+	return comment, err
+}
+
+func deleteCommentIn(ctx context.Context, tx dbExecutor, commentID int64, actor string) (*types.Comment, error) {
+	comment, err := getCommentIn(ctx, tx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.IsDeleted() {
+		return nil, fmt.Errorf("comment %d has already been deleted", commentID)
+	}
+
+	now := time.Now()
+	if _, err := tx.ExecContext(ctx, `UPDATE comments SET deleted_at = ? WHERE id = ?`, now, commentID); err != nil {
+		return nil, fmt.Errorf("failed to delete comment %d: %w", commentID, err)
+	}
+	comment.DeletedAt = &now
+
+	if err := recordCommentEventIn(ctx, tx, comment, types.EventCommentDeleted, &comment.Text, nil, actor); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// recordCommentEventIn records a change to a comment on its issue and marks
+// the issue dirty
+func recordCommentEventIn(ctx context.Context, tx dbExecutor, comment *types.Comment, eventType types.EventType, oldText, newText *string, actor string) error {
+	verb := "Edited"
+	if eventType == types.EventCommentDeleted {
+		verb = "Deleted"
+	}
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, comment.IssueID, eventType, actor, oldText, newText, fmt.Sprintf("%s comment #%d", verb, comment.ID))
+	if err != nil {
+		return fmt.Errorf("failed to record %s event: %w", eventType, err)
+	}
+
+	// Mark issue as dirty for JSONL export
+	if err := markIssuesDirtyTx(ctx, tx, []string{comment.IssueID}); err != nil {
+		return fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+	return nil
+}
+
+// ResolveComment marks the thread containing commentID as resolved (or
+// unresolved). Resolution is stored on the thread root, which is returned.
+func (s *SQLiteStorage) ResolveComment(ctx context.Context, commentID int64, resolved bool) (*types.Comment, error) {
+	root, err := s.getComment(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	for root.ParentCommentID != nil {
+		if root, err = s.getComment(ctx, *root.ParentCommentID); err != nil {
+			return nil, err
+		}
+	}
+
+	if root.Resolved == resolved {
+		return root, nil
+	}
+	if _, err := s.db.ExecContext(ctx, `UPDATE comments SET resolved = ? WHERE id = ?`, resolved, root.ID); err != nil {
+		return nil, fmt.Errorf("failed to update comment %d: %w", root.ID, err)
+	}
+	root.Resolved = resolved
+
+	// Mark issue as dirty for JSONL export
+	if err := s.MarkIssueDirty(ctx, root.IssueID); err != nil {
+		return nil, fmt.Errorf("failed to mark issue dirty: %w", err)
+	}
+
+	return root, nil
+}
+
 // Close closes the database connection
 func (s *SQLiteStorage) Close() error {
 	s.closed.Store(true)
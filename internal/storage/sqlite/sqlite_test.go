@@ -2,11 +2,17 @@ package sqlite
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	_ "github.com/ncruces/go-sqlite3/driver"
 	_ "github.com/ncruces/go-sqlite3/embed"
@@ -585,6 +591,8 @@ func TestUpdateIssue(t *testing.T) {
 		"status":   string(types.StatusInProgress),
 		"priority": 1,
 		"assignee": "bob",
+		"estimated_minutes": 120,
+		"spent_minutes":     30,
 	}
 
 	err = store.UpdateIssue(ctx, issue.ID, updates, "test-user")
@@ -613,6 +621,162 @@ func TestUpdateIssue(t *testing.T) {
 	if updated.Assignee != "bob" {
 		t.Errorf("Assignee not updated: got %v, want bob", updated.Assignee)
 	}
+
+	if updated.EstimatedMinutes == nil || *updated.EstimatedMinutes != 120 {
+		t.Errorf("EstimatedMinutes not updated: got %v, want 120", updated.EstimatedMinutes)
+	}
+
+	if updated.SpentMinutes == nil || *updated.SpentMinutes != 30 {
+		t.Errorf("SpentMinutes not updated: got %v, want 30", updated.SpentMinutes)
+	}
+}
+
+func TestIssueMetadata(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	tagged := &types.Issue{Title: "Tagged", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+		Metadata: map[string]string{"sprint": "42", "customer": "acme"}}
+	plain := &types.Issue{Title: "Plain", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{tagged, plain} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	got, err := store.GetIssue(ctx, tagged.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if len(got.Metadata) != 2 || got.Metadata["customer"] != "acme" {
+		t.Errorf("Metadata = %v, want sprint and customer", got.Metadata)
+	}
+
+	search := func(metadata map[string]string) []*types.Issue {
+		t.Helper()
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{Metadata: metadata})
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		return issues
+	}
+	if issues := search(map[string]string{"sprint": "42", "customer": "acme"}); len(issues) != 1 || issues[0].ID != tagged.ID {
+		t.Errorf("search by both keys returned %d issues, want %s", len(issues), tagged.ID)
+	}
+	if issues := search(map[string]string{"sprint": "43"}); len(issues) != 0 {
+		t.Errorf("search by another value returned %d issues, want 0", len(issues))
+	}
+
+	if err := store.UpdateIssue(ctx, plain.ID, map[string]interface{}{"metadata": map[string]string{"sprint": "42"}}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if issues := search(map[string]string{"sprint": "42"}); len(issues) != 2 {
+		t.Errorf("search after update returned %d issues, want 2", len(issues))
+	}
+
+	if err := store.UpdateIssue(ctx, tagged.ID, map[string]interface{}{"metadata": nil}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, tagged.ID); got.Metadata != nil {
+		t.Errorf("Metadata after clearing = %v, want nil", got.Metadata)
+	}
+
+	if err := store.UpdateIssue(ctx, plain.ID, map[string]interface{}{"metadata": map[string]string{"status": "x"}}, "test-user"); err == nil {
+		t.Error("Expected an error for a reserved metadata key")
+	}
+}
+
+func TestIssueAttachments(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	issue := &types.Issue{Title: "Attached", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+		Attachments: []string{"https://example.com/spec.pdf", "docs/design.png"}}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if !reflect.DeepEqual(got.Attachments, issue.Attachments) {
+		t.Errorf("Attachments = %v, want %v", got.Attachments, issue.Attachments)
+	}
+
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"attachments": []string{"docs/design.png"}}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	if len(issues) != 1 || !reflect.DeepEqual(issues[0].Attachments, []string{"docs/design.png"}) {
+		t.Errorf("Attachments after update = %v, want [docs/design.png]", issues[0].Attachments)
+	}
+	if issues[0].ContentHash == got.ContentHash {
+		t.Error("Expected the content hash to change with the attachments")
+	}
+
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"attachments": []string{"../outside.md"}}, "test-user"); err == nil {
+		t.Error("Expected an error attaching a path outside the repository")
+	}
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"attachments": nil}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, issue.ID); got.Attachments != nil {
+		t.Errorf("Attachments after clearing = %v, want nil", got.Attachments)
+	}
+}
+
+func TestUpdateIssueIfUnchanged(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	issue := &types.Issue{Title: "Original", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+	read, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+
+	// The first writer's read is current, so its update applies
+	if err := store.UpdateIssueIfUnchanged(ctx, issue.ID, read.UpdatedAt, map[string]interface{}{"title": "First"}, "alice"); err != nil {
+		t.Fatalf("UpdateIssueIfUnchanged with a current read failed: %v", err)
+	}
+
+	// A second writer holding the same read conflicts and changes nothing
+	err = store.UpdateIssueIfUnchanged(ctx, issue.ID, read.UpdatedAt, map[string]interface{}{"title": "Second"}, "bob")
+	var conflict *storage.ConflictError
+	if !errors.As(err, &conflict) || conflict.ID != issue.ID || !conflict.Expected.Equal(read.UpdatedAt) {
+		t.Fatalf("Expected ConflictError for a stale read, got %v", err)
+	}
+	if got, _ := store.GetIssue(ctx, issue.ID); got.Title != "First" {
+		t.Errorf("Title after conflict = %q, want First", got.Title)
+	}
+
+	// Closing and reopening move the version too
+	for _, mutate := range []func(id string) error{
+		func(id string) error { return store.CloseIssue(ctx, id, storage.CloseOptions{Reason: "done"}, "alice") },
+		func(id string) error { return store.ReopenIssue(ctx, id, "", "alice") },
+	} {
+		before, _ := store.GetIssue(ctx, issue.ID)
+		if err := mutate(issue.ID); err != nil {
+			t.Fatalf("mutation failed: %v", err)
+		}
+		err := store.UpdateIssueIfUnchanged(ctx, issue.ID, before.UpdatedAt, map[string]interface{}{"priority": 0}, "bob")
+		if !errors.As(err, &conflict) {
+			t.Errorf("Expected ConflictError after the issue was closed or reopened, got %v", err)
+		}
+	}
+
+	if err := store.UpdateIssueIfUnchanged(ctx, "bd-missing", read.UpdatedAt, map[string]interface{}{"title": "x"}, "bob"); err == nil || errors.As(err, &conflict) {
+		t.Errorf("Expected a not-found error for a missing issue, got %v", err)
+	}
 }
 
 func TestUpdateIssueValidation(t *testing.T) {
@@ -686,7 +850,7 @@ func TestCloseIssue(t *testing.T) {
 		t.Fatalf("CreateIssue failed: %v", err)
 	}
 
-	err = store.CloseIssue(ctx, issue.ID, "Done", "test-user")
+	err = store.CloseIssue(ctx, issue.ID, storage.CloseOptions{Reason: "Done"}, "test-user")
 	if err != nil {
 		t.Fatalf("CloseIssue failed: %v", err)
 	}
@@ -759,7 +923,7 @@ func TestClosedAtInvariant(t *testing.T) {
 		}
 
 		// Close the issue
-		err = store.CloseIssue(ctx, issue.ID, "Done", "test-user")
+		err = store.CloseIssue(ctx, issue.ID, storage.CloseOptions{Reason: "Done"}, "test-user")
 		if err != nil {
 			t.Fatalf("CloseIssue failed: %v", err)
 		}
@@ -845,7 +1009,7 @@ func TestSearchIssues(t *testing.T) {
 		}
 		// Close the third issue
 		if issue.Title == "Another bug" {
-			err = store.CloseIssue(ctx, issue.ID, "Done", "test-user")
+			err = store.CloseIssue(ctx, issue.ID, storage.CloseOptions{Reason: "Done"}, "test-user")
 			if err != nil {
 				t.Fatalf("CloseIssue failed: %v", err)
 			}
@@ -970,6 +1134,275 @@ func TestSearchIssues(t *testing.T) {
 	}
 }
 
+func TestStreamIssues(t *testing.T) {
+	// One connection, as for :memory:, so the stream can't lean on a second
+	db, err := New(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+	if err := db.SetConfig(ctx, "issue_prefix", "test"); err != nil {
+		t.Fatalf("Failed to set prefix: %v", err)
+	}
+
+	for i, title := range []string{"Bug in login", "Login page slow", "Login copy", "Unrelated"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: i % 3, IssueType: types.TypeTask}
+		if err := db.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		for _, label := range []string{"zeta", "alpha"}[:i%3] {
+			if err := db.AddLabel(ctx, issue.ID, label, "test-user"); err != nil {
+				t.Fatalf("AddLabel failed: %v", err)
+			}
+		}
+	}
+
+	filter := types.IssueFilter{Limit: 2, Offset: 1}
+	want, err := db.SearchIssues(ctx, "login", filter)
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	var got []*types.Issue
+	if err := db.StreamIssues(ctx, "login", filter, func(issue *types.Issue) error {
+		got = append(got, issue)
+		return nil
+	}); err != nil {
+		t.Fatalf("StreamIssues failed: %v", err)
+	}
+	if len(got) != len(want) || len(got) != 2 {
+		t.Fatalf("Expected the 2 issues SearchIssues finds, got %d (want %d)", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].ID != want[i].ID || !reflect.DeepEqual(got[i].Labels, want[i].Labels) {
+			t.Errorf("Issue %d: got %s %v, want %s %v", i, got[i].ID, got[i].Labels, want[i].ID, want[i].Labels)
+		}
+	}
+
+	// An error from the callback ends the stream and comes back as is
+	stop := fmt.Errorf("stop")
+	calls := 0
+	err = db.StreamIssues(ctx, "", types.IssueFilter{}, func(*types.Issue) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("Expected the callback's error after 1 call, got %v after %d", err, calls)
+	}
+}
+
+func TestSearchIssuesFullText(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	if !store.hasFTS {
+		t.Skip("SQLite built without FTS5")
+	}
+
+	inDescription := &types.Issue{Title: "Session handling", Description: "Tokens expire during login", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeBug}
+	inTitle := &types.Issue{Title: "Login page redesign", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeFeature}
+	inNotes := &types.Issue{Title: "Audit", Notes: "check the webhook retries", Design: "use exponential backoff", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{inDescription, inTitle, inNotes} {
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	search := func(query string) []string {
+		t.Helper()
+		results, err := store.SearchIssues(ctx, query, types.IssueFilter{})
+		if err != nil {
+			t.Fatalf("SearchIssues(%q) failed: %v", query, err)
+		}
+		var ids []string
+		for _, issue := range results {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+
+	// A title hit outranks a description hit despite its lower priority
+	if got := search("login"); len(got) != 2 || got[0] != inTitle.ID || got[1] != inDescription.ID {
+		t.Errorf("search login = %v, want [%s %s]", got, inTitle.ID, inDescription.ID)
+	}
+	// Notes and design are indexed; words match as prefixes and in any order
+	if got := search("backoff webhook"); len(got) != 1 || got[0] != inNotes.ID {
+		t.Errorf("search backoff webhook = %v, want [%s]", got, inNotes.ID)
+	}
+	if got := search("retr"); len(got) != 1 || got[0] != inNotes.ID {
+		t.Errorf("search retr = %v, want [%s]", got, inNotes.ID)
+	}
+	// FTS5 syntax in the query is taken literally
+	if got := search(`login OR "audit`); len(got) != 0 {
+		t.Errorf("search with operators = %v, want no results", got)
+	}
+	// Substring matches on IDs still work
+	if got := search(inNotes.ID[len(inNotes.ID)-3:]); len(got) == 0 {
+		t.Errorf("expected ID substring to match %s", inNotes.ID)
+	}
+
+	// The index follows updates and deletes
+	if err := store.UpdateIssue(ctx, inTitle.ID, map[string]interface{}{"title": "Signup page redesign"}, "test-user"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	if got := search("signup"); len(got) != 1 || got[0] != inTitle.ID {
+		t.Errorf("search signup after update = %v, want [%s]", got, inTitle.ID)
+	}
+	if err := store.DeleteIssue(ctx, inNotes.ID); err != nil {
+		t.Fatalf("DeleteIssue failed: %v", err)
+	}
+	var indexed int
+	if err := store.db.QueryRow(`SELECT COUNT(*) FROM issues_fts WHERE id = ?`, inNotes.ID).Scan(&indexed); err != nil {
+		t.Fatalf("failed to query issues_fts: %v", err)
+	}
+	if indexed != 0 {
+		t.Errorf("expected deleted issue to leave the index, found %d rows", indexed)
+	}
+
+	// Without the index, search falls back to substring matching
+	store.hasFTS = false
+	if got := search("Tokens expire"); len(got) != 1 || got[0] != inDescription.ID {
+		t.Errorf("fallback search = %v, want [%s]", got, inDescription.ID)
+	}
+}
+
+func TestSearchIssuesPagination(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for i := 0; i < 7; i++ {
+		issue := &types.Issue{Title: fmt.Sprintf("Issue %d", i), Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	// Identical sort keys, as after a bulk import, leave only the ID to order by
+	if _, err := store.db.ExecContext(ctx, `UPDATE issues SET created_at = ?`, time.Now().Format(time.RFC3339Nano)); err != nil {
+		t.Fatalf("failed to reset created_at: %v", err)
+	}
+
+	all, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues failed: %v", err)
+	}
+	var want, got []string
+	for _, issue := range all {
+		want = append(want, issue.ID)
+	}
+	if !sort.StringsAreSorted(want) {
+		t.Errorf("expected ties to break by ID, got %v", want)
+	}
+	for offset := 0; offset < 9; offset += 3 {
+		page, err := store.SearchIssues(ctx, "", types.IssueFilter{Limit: 3, Offset: offset})
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		for _, issue := range page {
+			got = append(got, issue.ID)
+		}
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("pages %v don't add up to %v", got, want)
+	}
+
+	// An offset alone skips without limiting
+	rest, err := store.SearchIssues(ctx, "", types.IssueFilter{Offset: 5})
+	if err != nil || len(rest) != 2 {
+		t.Errorf("expected 2 issues after offset 5, got %d (err %v)", len(rest), err)
+	}
+
+	count, err := store.CountIssues(ctx, "", types.IssueFilter{Limit: 3, Offset: 3})
+	if err != nil || count != 7 {
+		t.Errorf("expected a count of 7 ignoring limit and offset, got %d (err %v)", count, err)
+	}
+	count, err = store.CountIssues(ctx, "", types.IssueFilter{TitleContains: "Issue 3"})
+	if err != nil || count != 1 {
+		t.Errorf("expected a count of 1 matching the filter, got %d (err %v)", count, err)
+	}
+}
+
+func TestSearchIssuesSortBy(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	specs := []struct {
+		title    string
+		priority int
+		status   types.Status
+	}{
+		{"a", 1, types.StatusClosed},
+		{"b", 0, types.StatusOpen},
+		{"c", 1, types.StatusInProgress},
+		{"d", 0, types.StatusBlocked},
+	}
+	base := time.Now().Add(-time.Hour)
+	for i, spec := range specs {
+		issue := &types.Issue{Title: spec.title, Status: types.StatusOpen, Priority: spec.priority, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test-user"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+		if spec.status == types.StatusClosed {
+			if err := store.CloseIssue(ctx, issue.ID, storage.CloseOptions{Reason: "done"}, "test-user"); err != nil {
+				t.Fatalf("CloseIssue failed: %v", err)
+			}
+		} else if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": string(spec.status)}, "test-user"); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+		// Created in title order, a minute apart
+		if _, err := store.db.ExecContext(ctx, `UPDATE issues SET created_at = ? WHERE id = ?`,
+			base.Add(time.Duration(i)*time.Minute).Format(time.RFC3339Nano), issue.ID); err != nil {
+			t.Fatalf("failed to set created_at: %v", err)
+		}
+	}
+
+	titles := func(sortBy ...types.SortKey) string {
+		t.Helper()
+		results, err := store.SearchIssues(ctx, "", types.IssueFilter{SortBy: sortBy})
+		if err != nil {
+			t.Fatalf("SearchIssues failed: %v", err)
+		}
+		var got []string
+		for _, issue := range results {
+			got = append(got, issue.Title)
+		}
+		return strings.Join(got, "")
+	}
+
+	if got := titles(); got != "dbca" {
+		t.Errorf("default order = %s, want dbca (priority, newest first)", got)
+	}
+	if got := titles(types.SortKey{Field: types.SortFieldPriority}, types.SortKey{Field: types.SortFieldCreatedAt}); got != "bdac" {
+		t.Errorf("priority, oldest first = %s, want bdac", got)
+	}
+	if got := titles(types.SortKey{Field: types.SortFieldStatus}); got != "bcda" {
+		t.Errorf("status order = %s, want bcda (open, in_progress, blocked, closed)", got)
+	}
+	if got := titles(types.SortKey{Field: types.SortFieldCreatedAt, Descending: true}); got != "dcba" {
+		t.Errorf("newest first = %s, want dcba", got)
+	}
+	if _, err := store.SearchIssues(ctx, "", types.IssueFilter{SortBy: []types.SortKey{{Field: "title"}}}); err == nil {
+		t.Error("expected an error for an unknown sort field")
+	}
+}
+
+func TestFTSMatchQuery(t *testing.T) {
+	tests := []struct {
+		query, want string
+	}{
+		{"login bug", `"login"* "bug"*`},
+		{`say "hi"`, `"say"* """hi"""*`},
+		{"NOT -- *", `"NOT"*`},
+		{"  ", ""},
+	}
+	for _, tt := range tests {
+		if got := ftsMatchQuery(tt.query); got != tt.want {
+			t.Errorf("ftsMatchQuery(%q) = %s, want %s", tt.query, got, tt.want)
+		}
+	}
+}
+
 func TestGetStatistics(t *testing.T) {
 	store, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -1010,7 +1443,7 @@ func TestGetStatistics(t *testing.T) {
 		}
 		// Close the one that should be closed
 		if issue.Title == "Closed task" {
-			err = store.CloseIssue(ctx, issue.ID, "Done", "test-user")
+			err = store.CloseIssue(ctx, issue.ID, storage.CloseOptions{Reason: "Done"}, "test-user")
 			if err != nil {
 				t.Fatalf("CloseIssue failed: %v", err)
 			}
@@ -1513,3 +1946,43 @@ func TestIsClosed(t *testing.T) {
 		t.Error("Store should be closed after calling Close()")
 	}
 }
+
+func TestNewReadOnly(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	store, err := New(dbPath)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	ctx := context.Background()
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatal(err)
+	}
+	issue := &types.Issue{Title: "Existing", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	ro, err := NewReadOnly(dbPath)
+	if err != nil {
+		t.Fatalf("NewReadOnly failed: %v", err)
+	}
+	defer ro.Close()
+	if got, err := ro.GetIssue(ctx, issue.ID); err != nil || got == nil || got.Title != "Existing" {
+		t.Errorf("GetIssue on a read-only store = %+v, %v", got, err)
+	}
+	if err := ro.CreateIssue(ctx, &types.Issue{Title: "New", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}, "test"); err == nil {
+		t.Error("Expected CreateIssue to fail on a read-only store")
+	}
+	if err := ro.SetConfig(ctx, "issue_prefix", "other"); err == nil {
+		t.Error("Expected SetConfig to fail on a read-only store")
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.db")
+	if _, err := NewReadOnly(missing); err == nil {
+		t.Error("Expected NewReadOnly to fail for a missing database")
+	}
+	if _, err := os.Stat(missing); !os.IsNotExist(err) {
+		t.Errorf("Expected NewReadOnly not to create %s", missing)
+	}
+}
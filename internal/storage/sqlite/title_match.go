@@ -0,0 +1,23 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// FindByTitle returns the issues, archived ones included, whose titles match
+// query case-insensitively: exact titles first, then prefixes, substrings,
+// titles with all of query's words, and titles within a typo of them. See
+// types.RankTitleMatches for the ordering.
+func (s *SQLiteStorage) FindByTitle(ctx context.Context, query string) ([]*types.Issue, error) {
+	if types.NormalizeTitle(query) == "" {
+		return nil, nil
+	}
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{IncludeArchived: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+	return types.RankTitleMatches(query, issues), nil
+}
@@ -0,0 +1,34 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// TopoSort returns the open work under rootID in dependency order: rootID,
+// its parent-child descendants and their transitive blockers, grouped into
+// waves whose issues only wait on earlier waves. Blockers come before what
+// they block and children before their parent. Fails naming the cycle if
+// the dependencies between them loop.
+func (s *SQLiteStorage) TopoSort(ctx context.Context, rootID string) ([][]*types.Issue, error) {
+	root, err := s.GetIssue(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, fmt.Errorf("issue %s not found", rootID)
+	}
+
+	deps, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	ids, before := types.PlanSubgraph(rootID, deps)
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{IDs: ids})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issues: %w", err)
+	}
+	return types.PlanWaves(ids, before, issues)
+}
@@ -0,0 +1,81 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestTopoSort(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, issue := range []*types.Issue{
+		{ID: "bd-epic", Title: "Epic", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic},
+		{ID: "bd-a", Title: "A", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeTask},
+		{ID: "bd-b", Title: "B", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{ID: "bd-c", Title: "C", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{ID: "bd-d", Title: "D", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask},
+		{ID: "bd-done", Title: "Done", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask},
+		{ID: "bd-other", Title: "Unrelated", Status: types.StatusOpen, Priority: 0, IssueType: types.TypeTask},
+	} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.CloseIssue(ctx, "bd-done", storage.CloseOptions{Reason: "done"}, "test"); err != nil {
+		t.Fatalf("CloseIssue failed: %v", err)
+	}
+	addDep := func(issueID, dependsOnID string, depType types.DependencyType) {
+		t.Helper()
+		dep := &types.Dependency{IssueID: issueID, DependsOnID: dependsOnID, Type: depType}
+		if err := store.AddDependency(ctx, dep, "test"); err != nil {
+			t.Fatalf("AddDependency(%s, %s) failed: %v", issueID, dependsOnID, err)
+		}
+	}
+	// The epic has children a, b, d and done; a waits on b, b on c (outside the epic)
+	for _, child := range []string{"bd-a", "bd-b", "bd-d", "bd-done"} {
+		addDep(child, "bd-epic", types.DepParentChild)
+	}
+	addDep("bd-a", "bd-b", types.DepBlocks)
+	addDep("bd-b", "bd-c", types.DepBlocks)
+	addDep("bd-other", "bd-done", types.DepBlocks)
+
+	waves, err := store.TopoSort(ctx, "bd-epic")
+	if err != nil {
+		t.Fatalf("TopoSort failed: %v", err)
+	}
+	var got []string
+	for _, wave := range waves {
+		var ids []string
+		for _, issue := range wave {
+			ids = append(ids, issue.ID)
+		}
+		got = append(got, strings.Join(ids, ","))
+	}
+	want := []string{"bd-d,bd-c", "bd-b", "bd-a", "bd-epic"}
+	if strings.Join(got, " | ") != strings.Join(want, " | ") {
+		t.Errorf("expected waves %v, got %v", want, got)
+	}
+
+	if _, err := store.TopoSort(ctx, "bd-missing"); err == nil {
+		t.Error("expected an error for a missing root")
+	}
+
+	// c waiting on the epic is no stored cycle, but the epic can't finish
+	// before its child a, which waits on c
+	addDep("bd-c", "bd-epic", types.DepBlocks)
+	_, err = store.TopoSort(ctx, "bd-epic")
+	if err == nil || !strings.Contains(err.Error(), "dependency cycle") {
+		t.Fatalf("expected a dependency cycle error, got %v", err)
+	}
+	for _, id := range []string{"bd-epic", "bd-a", "bd-b", "bd-c"} {
+		if !strings.Contains(err.Error(), id) {
+			t.Errorf("expected cycle error to name %s, got %v", id, err)
+		}
+	}
+}
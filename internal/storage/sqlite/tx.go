@@ -0,0 +1,136 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// WithTx runs fn inside a single IMMEDIATE transaction. All writes made through
+// tx are committed when fn returns nil and rolled back if it returns an error
+// (or panics). fn must not call methods on s directly: the transaction holds
+// the write lock, so a write on another connection would wait on it.
+func (s *SQLiteStorage) WithTx(ctx context.Context, fn func(tx storage.Transaction) error) error {
+	return s.withConnTx(ctx, func(conn *sql.Conn) error {
+		return fn(&sqliteTx{s: s, conn: conn})
+	})
+}
+
+// withConnTx runs fn inside a single IMMEDIATE transaction on a dedicated
+// connection, committing if fn returns nil
+func (s *SQLiteStorage) withConnTx(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	// Dedicated connection so BEGIN IMMEDIATE/COMMIT run on the same connection
+	// (see CreateIssue)
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return fmt.Errorf("failed to begin immediate transaction: %w", err)
+	}
+
+	// Use context.Background() for ROLLBACK to ensure cleanup happens even if ctx is canceled
+	committed := false
+	defer func() {
+		if !committed {
+			_, _ = conn.ExecContext(context.Background(), "ROLLBACK")
+		}
+	}()
+
+	if err := fn(conn); err != nil {
+		return err
+	}
+
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	committed = true
+	return nil
+}
+
+// sqliteTx implements storage.Transaction on a connection with an open transaction
+type sqliteTx struct {
+	s    *SQLiteStorage
+	conn *sql.Conn
+}
+
+func (t *sqliteTx) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
+	return t.s.createIssueIn(ctx, t.conn, issue, actor)
+}
+
+func (t *sqliteTx) GetIssue(ctx context.Context, id string) (*types.Issue, error) {
+	return getIssue(ctx, t.conn, id)
+}
+
+func (t *sqliteTx) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
+	return updateIssueIn(ctx, t.conn, id, updates, actor, "", "")
+}
+
+func (t *sqliteTx) CloseIssue(ctx context.Context, id string, opts storage.CloseOptions, actor string) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	return closeIssueIn(ctx, t.conn, id, opts, actor)
+}
+
+func (t *sqliteTx) DeleteIssue(ctx context.Context, id string) error {
+	return deleteIssueIn(ctx, t.conn, id)
+}
+
+// DeleteIssues is SQLiteStorage.DeleteIssues inside the transaction. It isn't
+// part of storage.Transaction, since DeleteIssuesResult is SQLite's.
+func (t *sqliteTx) DeleteIssues(ctx context.Context, ids []string, cascade bool, force bool) (*DeleteIssuesResult, error) {
+	if len(ids) == 0 {
+		return &DeleteIssuesResult{}, nil
+	}
+	return t.s.deleteIssuesIn(ctx, t.conn, ids, cascade, force, false)
+}
+
+// UpdateIssueID defers foreign key checks to commit, since PRAGMA foreign_keys
+// can't be changed inside a transaction
+func (t *sqliteTx) UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error {
+	if _, err := t.conn.ExecContext(ctx, `PRAGMA defer_foreign_keys = ON`); err != nil {
+		return fmt.Errorf("failed to defer foreign keys: %w", err)
+	}
+	return updateIssueIDIn(ctx, t.conn, oldID, newID, issue, actor)
+}
+
+func (t *sqliteTx) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
+	return addDependencyIn(ctx, t.conn, dep, actor)
+}
+
+func (t *sqliteTx) RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error {
+	return removeDependencyIn(ctx, t.conn, issueID, dependsOnID, actor)
+}
+
+func (t *sqliteTx) AddLabel(ctx context.Context, issueID, label, actor string) error {
+	return addLabelIn(ctx, t.conn, issueID, label, actor)
+}
+
+func (t *sqliteTx) RemoveLabel(ctx context.Context, issueID, label, actor string) error {
+	return removeLabelIn(ctx, t.conn, issueID, label, actor)
+}
+
+func (t *sqliteTx) AddComment(ctx context.Context, issueID, actor, comment string) error {
+	return addCommentIn(ctx, t.conn, issueID, actor, comment)
+}
+
+func (t *sqliteTx) GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error) {
+	return getIssueComments(ctx, t.conn, issueID)
+}
+
+func (t *sqliteTx) UpdateComment(ctx context.Context, commentID int64, text string) (*types.Comment, error) {
+	return updateCommentIn(ctx, t.conn, commentID, text)
+}
+
+func (t *sqliteTx) ResetChildCounter(ctx context.Context, parentID string) error {
+	if _, err := t.conn.ExecContext(ctx, childCounterResetSQL, parentID, 0); err != nil {
+		return fmt.Errorf("failed to reset child counter for %s: %w", parentID, err)
+	}
+	return nil
+}
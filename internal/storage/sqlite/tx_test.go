@@ -0,0 +1,227 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWithTxCommit(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	parent := &types.Issue{Title: "Parent", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeEpic}
+	child := &types.Issue{Title: "Child", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+
+	err := store.WithTx(ctx, func(tx storage.Transaction) error {
+		if err := tx.CreateIssue(ctx, parent, "test"); err != nil {
+			return err
+		}
+		if err := tx.CreateIssue(ctx, child, "test"); err != nil {
+			return err
+		}
+		if err := tx.AddDependency(ctx, &types.Dependency{IssueID: child.ID, DependsOnID: parent.ID, Type: types.DepParentChild}, "test"); err != nil {
+			return err
+		}
+		if err := tx.AddLabel(ctx, child.ID, "split", "test"); err != nil {
+			return err
+		}
+		if err := tx.UpdateIssue(ctx, child.ID, map[string]interface{}{"assignee": "alice"}, "test"); err != nil {
+			return err
+		}
+
+		// Reads inside the transaction see its own writes
+		got, err := tx.GetIssue(ctx, child.ID)
+		if err != nil {
+			return err
+		}
+		if got == nil || got.Assignee != "alice" || len(got.Labels) != 1 {
+			t.Errorf("Expected uncommitted changes to be visible in tx, got %+v", got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTx failed: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, child.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got == nil || got.Assignee != "alice" {
+		t.Fatalf("Expected committed child with assignee, got %+v", got)
+	}
+	deps, err := store.GetDependencyRecords(ctx, child.ID)
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != parent.ID {
+		t.Errorf("Expected parent-child dependency on %s, got %+v", parent.ID, deps)
+	}
+}
+
+func TestWithTxRollback(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	existing := &types.Issue{Title: "Existing", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, existing, "test"); err != nil {
+		t.Fatalf("CreateIssue failed: %v", err)
+	}
+
+	var created *types.Issue
+	errBoom := errors.New("boom")
+	err := store.WithTx(ctx, func(tx storage.Transaction) error {
+		created = &types.Issue{Title: "Rolled back", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := tx.CreateIssue(ctx, created, "test"); err != nil {
+			return err
+		}
+		if err := tx.CloseIssue(ctx, existing.ID, storage.CloseOptions{Reason: "Done"}, "test"); err != nil {
+			return err
+		}
+		if err := tx.AddComment(ctx, existing.ID, "test", "should vanish"); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("Expected callback error, got %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got != nil {
+		t.Errorf("Expected created issue to be rolled back, got %+v", got)
+	}
+
+	got, err = store.GetIssue(ctx, existing.ID)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Status != types.StatusOpen || got.ClosedAt != nil {
+		t.Errorf("Expected close to be rolled back, got status=%s closed_at=%v", got.Status, got.ClosedAt)
+	}
+
+	events, err := store.GetEvents(ctx, existing.ID, 0)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(events) != 1 || events[0].EventType != types.EventCreated {
+		t.Errorf("Expected only the created event, got %d events", len(events))
+	}
+
+	// The store is usable again once the transaction is released
+	if err := store.AddLabel(ctx, existing.ID, "after", "test"); err != nil {
+		t.Fatalf("AddLabel after rollback failed: %v", err)
+	}
+}
+
+func TestWithTxUpdateIssueID(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	a := &types.Issue{ID: "bd-1", Title: "A", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	b := &types.Issue{ID: "bd-2", Title: "B", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{a, b} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: b.ID, DependsOnID: a.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	err := store.WithTx(ctx, func(tx storage.Transaction) error {
+		if err := tx.UpdateIssueID(ctx, "bd-1", "bd-aaa", a, "test"); err != nil {
+			return err
+		}
+		return tx.UpdateIssueID(ctx, "bd-2", "bd-bbb", b, "test")
+	})
+	if err != nil {
+		t.Fatalf("WithTx failed: %v", err)
+	}
+
+	deps, err := store.GetDependencyRecords(ctx, "bd-bbb")
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != "bd-aaa" {
+		t.Errorf("Expected dependency bd-bbb → bd-aaa, got %+v", deps)
+	}
+	if old, _ := store.GetIssue(ctx, "bd-1"); old != nil {
+		t.Error("Expected bd-1 to be renamed")
+	}
+}
+
+func TestUpdateIssueIDRejectsExistingID(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	a := &types.Issue{ID: "bd-1", Title: "A", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	b := &types.Issue{ID: "bd-2", Title: "B", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{a, b} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	if err := store.AddLabel(ctx, a.ID, "from-a", "test"); err != nil {
+		t.Fatalf("AddLabel failed: %v", err)
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: a.ID, DependsOnID: b.ID, Type: types.DepBlocks}, "test"); err != nil {
+		t.Fatalf("AddDependency failed: %v", err)
+	}
+
+	assertUnchanged := func() {
+		t.Helper()
+		gotA, err := store.GetIssue(ctx, "bd-1")
+		if err != nil || gotA == nil || gotA.Title != "A" {
+			t.Fatalf("bd-1 changed: %+v, %v", gotA, err)
+		}
+		gotB, err := store.GetIssue(ctx, "bd-2")
+		if err != nil || gotB == nil || gotB.Title != "B" {
+			t.Fatalf("bd-2 changed: %+v, %v", gotB, err)
+		}
+		if labels, _ := store.GetLabels(ctx, "bd-2"); len(labels) != 0 {
+			t.Errorf("bd-1's labels leaked onto bd-2: %v", labels)
+		}
+		if deps, _ := store.GetDependencyRecords(ctx, "bd-1"); len(deps) != 1 || deps[0].DependsOnID != "bd-2" {
+			t.Errorf("bd-1's dependencies changed: %+v", deps)
+		}
+	}
+
+	renamed := *a
+	renamed.Title = "A renamed"
+	var exists *storage.IDExistsError
+	err := store.UpdateIssueID(ctx, "bd-1", "bd-2", &renamed, "test")
+	if !errors.As(err, &exists) || exists.ID != "bd-2" {
+		t.Fatalf("Expected IDExistsError for bd-2, got %v", err)
+	}
+	assertUnchanged()
+
+	// The same check applies inside a transaction
+	err = store.WithTx(ctx, func(tx storage.Transaction) error {
+		return tx.UpdateIssueID(ctx, "bd-1", "bd-2", &renamed, "test")
+	})
+	if !errors.As(err, &exists) {
+		t.Fatalf("Expected IDExistsError from the transaction, got %v", err)
+	}
+	assertUnchanged()
+
+	// Renaming to the same ID only rewrites the fields
+	if err := store.UpdateIssueID(ctx, "bd-1", "bd-1", &renamed, "test"); err != nil {
+		t.Fatalf("UpdateIssueID to the same ID failed: %v", err)
+	}
+}
@@ -0,0 +1,281 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DefaultUndoMaxAge is how far back bd undo looks for an operation to revert
+const DefaultUndoMaxAge = time.Hour
+
+// undoGroupGap is the largest gap between one actor's events that still
+// counts as one operation, such as a bulk bd close. Event timestamps only
+// have one-second resolution.
+const undoGroupGap = 2 * time.Second
+
+// undoCommentFormat is the comment on undone events, naming the event reverted
+const undoCommentFormat = "Undo of %s event #%d"
+
+// UndoStep reverts one event by putting fields back to their prior values
+type UndoStep struct {
+	Event   *types.Event           `json:"event"`
+	IssueID string                 `json:"issue_id"`
+	Restore map[string]interface{} `json:"restore"`
+}
+
+// UndoSkip is an event in the operation that undo leaves alone
+type UndoSkip struct {
+	Event  *types.Event `json:"event"`
+	Reason string       `json:"reason"`
+}
+
+// UndoPlan is what undoing an actor's most recent operation would do
+type UndoPlan struct {
+	Actor   string      `json:"actor"`
+	Steps   []*UndoStep `json:"steps"` // Newest first, the order they're applied
+	Skipped []*UndoSkip `json:"skipped,omitempty"`
+}
+
+// PlanUndo finds actor's most recent operation that hasn't been undone and
+// works out how to revert it. An operation is a run of actor's events no more
+// than a couple of seconds apart; only operations newer than maxAge are
+// considered. Field edits, status changes, closes, reopens and priority
+// changes can be reverted. Other events, and events on issues someone else
+// has changed since, are listed in Skipped. The plan is empty when there's
+// nothing to undo.
+func (s *SQLiteStorage) PlanUndo(ctx context.Context, actor string, maxAge time.Duration) (*UndoPlan, error) {
+	cutoff := time.Now().Add(-maxAge).UTC().Format("2006-01-02 15:04:05")
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+eventColumns+`
+		FROM events
+		WHERE actor = ? AND datetime(created_at) >= datetime(?)
+		ORDER BY id DESC
+	`, actor, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	events, err := scanEvents(rows)
+	_ = rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// Undone events are never themselves undone, so repeated undos walk back
+	// through earlier operations
+	reverted := revertedEventIDs(events)
+	var operation []*types.Event
+	for _, event := range events {
+		if event.EventType == types.EventUndone || reverted[event.ID] {
+			continue
+		}
+		if len(operation) > 0 && operation[len(operation)-1].CreatedAt.Sub(event.CreatedAt) > undoGroupGap {
+			break
+		}
+		operation = append(operation, event)
+	}
+
+	inOperation := make(map[int64]bool, len(operation))
+	for _, event := range operation {
+		inOperation[event.ID] = true
+	}
+	plan := &UndoPlan{Actor: actor, Steps: []*UndoStep{}}
+	for _, event := range operation {
+		restore, reason := undoRestore(event)
+		if reason == "" {
+			if reason, err = s.undoConflict(ctx, event, inOperation); err != nil {
+				return nil, err
+			}
+		}
+		if reason != "" {
+			plan.Skipped = append(plan.Skipped, &UndoSkip{Event: event, Reason: reason})
+			continue
+		}
+		plan.Steps = append(plan.Steps, &UndoStep{Event: event, IssueID: event.IssueID, Restore: restore})
+	}
+	return plan, nil
+}
+
+// ApplyUndo carries out plan in one transaction. Each step records an undone
+// event naming the event it reverted; history is never deleted.
+func (s *SQLiteStorage) ApplyUndo(ctx context.Context, plan *UndoPlan, actor string) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		for _, step := range plan.Steps {
+			comment := fmt.Sprintf(undoCommentFormat, step.Event.EventType, step.Event.ID)
+			if err := updateIssueWithCommentIn(ctx, tx, step.IssueID, step.Restore, actor, "", types.EventUndone, comment); err != nil {
+				return fmt.Errorf("failed to undo event #%d on %s: %w", step.Event.ID, step.IssueID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// revertedEventIDs returns the IDs of events reverted by the undone events
+// among events
+func revertedEventIDs(events []*types.Event) map[int64]bool {
+	reverted := make(map[int64]bool)
+	for _, event := range events {
+		if event.EventType != types.EventUndone || event.Comment == nil {
+			continue
+		}
+		var eventType string
+		var id int64
+		if _, err := fmt.Sscanf(*event.Comment, undoCommentFormat, &eventType, &id); err == nil {
+			reverted[id] = true
+		}
+	}
+	return reverted
+}
+
+// undoConflict returns why event can't be undone safely because its issue
+// has been deleted or changed since outside the operation, or "" if it can
+func (s *SQLiteStorage) undoConflict(ctx context.Context, event *types.Event, inOperation map[int64]bool) (string, error) {
+	issue, err := s.GetIssue(ctx, event.IssueID)
+	if err != nil {
+		return "", err
+	}
+	if issue == nil {
+		return "issue no longer exists", nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+eventColumns+`
+		FROM events
+		WHERE issue_id = ? AND id > ?
+		ORDER BY id
+	`, event.IssueID, event.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get events: %w", err)
+	}
+	later, err := scanEvents(rows)
+	_ = rows.Close()
+	if err != nil {
+		return "", err
+	}
+	// Changes that were undone since cancel out
+	reverted := revertedEventIDs(later)
+	for _, e := range later {
+		if inOperation[e.ID] || reverted[e.ID] || e.EventType == types.EventUndone {
+			continue
+		}
+		return fmt.Sprintf("changed since by %s (%s event #%d)", e.Actor, e.EventType, e.ID), nil
+	}
+	return "", nil
+}
+
+// undoRestore works out the field values that revert event, or why it can't
+// be reverted
+func undoRestore(event *types.Event) (map[string]interface{}, string) {
+	switch event.EventType {
+	case types.EventUpdated, types.EventStatusChanged, types.EventClosed, types.EventReopened, types.EventFieldsCleared:
+	case types.EventPriorityChanged:
+		// Priority propagation stores bare priorities
+		if event.OldValue != nil {
+			if priority, err := strconv.Atoi(*event.OldValue); err == nil {
+				return map[string]interface{}{"priority": priority}, ""
+			}
+		}
+		return nil, "no prior priority recorded"
+	case types.EventCreated:
+		return nil, "creating an issue can't be undone (use bd delete)"
+	default:
+		return nil, fmt.Sprintf("%s events can't be undone", event.EventType)
+	}
+
+	var old types.Issue
+	if event.OldValue == nil || json.Unmarshal([]byte(*event.OldValue), &old) != nil {
+		if event.EventType == types.EventClosed {
+			// Closes recorded before close events kept prior state
+			return map[string]interface{}{"status": string(types.StatusOpen), "closed_at": nil, "resolution": "", "resolved_by": ""}, ""
+		}
+		return nil, "no prior state recorded"
+	}
+	var updates map[string]interface{}
+	if event.NewValue == nil || json.Unmarshal([]byte(*event.NewValue), &updates) != nil {
+		return nil, "no changes recorded"
+	}
+
+	restore := make(map[string]interface{}, len(updates))
+	for field := range updates {
+		if value, ok := issueFieldValue(&old, field); ok {
+			restore[field] = value
+		}
+	}
+	if _, ok := restore["status"]; ok {
+		// Status carries closed_at, resolution and resolved_by with it
+		restore["closed_at"], _ = issueFieldValue(&old, "closed_at")
+		restore["resolution"], _ = issueFieldValue(&old, "resolution")
+		restore["resolved_by"], _ = issueFieldValue(&old, "resolved_by")
+	}
+	if len(restore) == 0 {
+		return nil, "no changes recorded"
+	}
+	return restore, ""
+}
+
+// issueFieldValue returns issue's value for an updatable field in the form
+// UpdateIssue expects
+func issueFieldValue(issue *types.Issue, field string) (interface{}, bool) {
+	switch field {
+	case "title":
+		return issue.Title, true
+	case "description":
+		return issue.Description, true
+	case "design":
+		return issue.Design, true
+	case "acceptance_criteria":
+		return issue.AcceptanceCriteria, true
+	case "notes":
+		return issue.Notes, true
+	case "status":
+		return string(issue.Status), true
+	case "priority":
+		return issue.Priority, true
+	case "issue_type":
+		return string(issue.IssueType), true
+	case "resolution":
+		return string(issue.Resolution), true
+	case "resolved_by":
+		return issue.ResolvedBy, true
+	case "assignee":
+		if issue.Assignee == "" {
+			return nil, true
+		}
+		return issue.Assignee, true
+	case "estimated_minutes":
+		if issue.EstimatedMinutes == nil {
+			return nil, true
+		}
+		return *issue.EstimatedMinutes, true
+	case "spent_minutes":
+		if issue.SpentMinutes == nil {
+			return nil, true
+		}
+		return *issue.SpentMinutes, true
+	case "metadata":
+		return issue.Metadata, true
+	case "attachments":
+		return issue.Attachments, true
+	case "external_ref":
+		if issue.ExternalRef == nil || *issue.ExternalRef == "" {
+			return nil, true
+		}
+		return *issue.ExternalRef, true
+	case "closed_at":
+		if issue.ClosedAt == nil {
+			return nil, true
+		}
+		return *issue.ClosedAt, true
+	case "archived_at":
+		if issue.ArchivedAt == nil {
+			return nil, true
+		}
+		return *issue.ArchivedAt, true
+	}
+	return nil, false
+}
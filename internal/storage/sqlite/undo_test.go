@@ -0,0 +1,150 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestUndo(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// backdate moves every event recorded so far into the past, so the
+	// next mutations form a separate operation
+	backdate := func(age string) {
+		t.Helper()
+		if _, err := store.db.ExecContext(ctx, `UPDATE events SET created_at = datetime('now', ?)`, age); err != nil {
+			t.Fatalf("failed to backdate events: %v", err)
+		}
+	}
+	getIssue := func(id string) *types.Issue {
+		t.Helper()
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil || issue == nil {
+			t.Fatalf("GetIssue(%s) = %v, %v", id, issue, err)
+		}
+		return issue
+	}
+	planAndApply := func(actor string) *UndoPlan {
+		t.Helper()
+		plan, err := store.PlanUndo(ctx, actor, DefaultUndoMaxAge)
+		if err != nil {
+			t.Fatalf("PlanUndo failed: %v", err)
+		}
+		if err := store.ApplyUndo(ctx, plan, actor); err != nil {
+			t.Fatalf("ApplyUndo failed: %v", err)
+		}
+		return plan
+	}
+
+	a := &types.Issue{Title: "Issue A", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	b := &types.Issue{Title: "Issue B", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{a, b} {
+		if err := store.CreateIssue(ctx, issue, "alice"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+	backdate("-10 minutes")
+
+	if err := store.UpdateIssue(ctx, a.ID, map[string]interface{}{"title": "Renamed A", "status": string(types.StatusInProgress)}, "alice"); err != nil {
+		t.Fatalf("UpdateIssue failed: %v", err)
+	}
+	backdate("-5 minutes")
+
+	for _, issue := range []*types.Issue{a, b} {
+		if err := store.CloseIssue(ctx, issue.ID, storage.CloseOptions{Reason: "oops", Resolution: types.ResolutionFixed}, "alice"); err != nil {
+			t.Fatalf("CloseIssue failed: %v", err)
+		}
+	}
+
+	t.Run("dry run plans the bulk close only", func(t *testing.T) {
+		plan, err := store.PlanUndo(ctx, "alice", DefaultUndoMaxAge)
+		if err != nil {
+			t.Fatalf("PlanUndo failed: %v", err)
+		}
+		if len(plan.Steps) != 2 || len(plan.Skipped) != 0 {
+			t.Fatalf("got %d steps and %d skipped, want 2 and 0", len(plan.Steps), len(plan.Skipped))
+		}
+		for _, step := range plan.Steps {
+			if step.Event.EventType != types.EventClosed {
+				t.Errorf("step reverts %s, want closed", step.Event.EventType)
+			}
+		}
+		if getIssue(a.ID).Status != types.StatusClosed {
+			t.Error("planning changed the issue")
+		}
+	})
+
+	t.Run("undo reopens to the prior status", func(t *testing.T) {
+		planAndApply("alice")
+		gotA, gotB := getIssue(a.ID), getIssue(b.ID)
+		if gotA.Status != types.StatusInProgress || gotA.ClosedAt != nil || gotA.Resolution != "" {
+			t.Errorf("A = %s closed_at=%v resolution=%q, want in_progress, nil, empty", gotA.Status, gotA.ClosedAt, gotA.Resolution)
+		}
+		if gotB.Status != types.StatusOpen || gotB.ClosedAt != nil {
+			t.Errorf("B = %s closed_at=%v, want open, nil", gotB.Status, gotB.ClosedAt)
+		}
+
+		events, err := store.GetEvents(ctx, a.ID, 1)
+		if err != nil || len(events) != 1 {
+			t.Fatalf("GetEvents = %v, %v", events, err)
+		}
+		if events[0].EventType != types.EventUndone || events[0].Comment == nil || !strings.Contains(*events[0].Comment, "closed event") {
+			t.Errorf("newest event = %s %v, want an undone event naming the close", events[0].EventType, events[0].Comment)
+		}
+	})
+
+	t.Run("undo again reverts the earlier edit", func(t *testing.T) {
+		plan := planAndApply("alice")
+		if len(plan.Steps) != 1 || plan.Steps[0].Event.EventType != types.EventStatusChanged {
+			t.Fatalf("got steps %+v, want the status change", plan.Steps)
+		}
+		if got := getIssue(a.ID); got.Title != "Issue A" || got.Status != types.StatusOpen {
+			t.Errorf("A = %q %s, want %q open", got.Title, got.Status, "Issue A")
+		}
+	})
+
+	t.Run("creations are skipped", func(t *testing.T) {
+		plan := planAndApply("alice")
+		if len(plan.Steps) != 0 || len(plan.Skipped) != 2 {
+			t.Fatalf("got %d steps and %d skipped, want 0 and 2", len(plan.Steps), len(plan.Skipped))
+		}
+	})
+
+	t.Run("issues changed by someone else are skipped", func(t *testing.T) {
+		backdate("-1 minutes")
+		if err := store.CloseIssue(ctx, b.ID, storage.CloseOptions{Reason: "done"}, "alice"); err != nil {
+			t.Fatalf("CloseIssue failed: %v", err)
+		}
+		if err := store.UpdateIssue(ctx, b.ID, map[string]interface{}{"priority": 0}, "bob"); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+		plan := planAndApply("alice")
+		if len(plan.Steps) != 0 || len(plan.Skipped) != 1 || !strings.Contains(plan.Skipped[0].Reason, "bob") {
+			t.Fatalf("got steps %+v skipped %+v, want the close skipped because of bob", plan.Steps, plan.Skipped)
+		}
+		if getIssue(b.ID).Status != types.StatusClosed {
+			t.Error("B was reopened despite bob's change")
+		}
+	})
+
+	t.Run("max age caps how far back undo goes", func(t *testing.T) {
+		if err := store.UpdateIssue(ctx, a.ID, map[string]interface{}{"priority": 1}, "carol"); err != nil {
+			t.Fatalf("UpdateIssue failed: %v", err)
+		}
+		backdate("-2 hours")
+		plan, err := store.PlanUndo(ctx, "carol", time.Hour)
+		if err != nil {
+			t.Fatalf("PlanUndo failed: %v", err)
+		}
+		if len(plan.Steps) != 0 || len(plan.Skipped) != 0 {
+			t.Errorf("got %d steps and %d skipped, want nothing to undo", len(plan.Steps), len(plan.Skipped))
+		}
+	})
+}
@@ -0,0 +1,240 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
+)
+
+// UpsertOutcome reports what UpsertIssue did with an issue
+type UpsertOutcome string
+
+const (
+	UpsertInserted  UpsertOutcome = "inserted"  // The ID didn't exist, so the issue was created
+	UpsertUpdated   UpsertOutcome = "updated"   // The existing issue was changed to match
+	UpsertUnchanged UpsertOutcome = "unchanged" // The existing issue already matched
+)
+
+// UpsertIssue creates issue if its ID doesn't exist yet, and otherwise makes
+// the existing issue match it: fields are overwritten with issue's, and
+// labels and dependencies are reconciled to issue.Labels and
+// issue.Dependencies (adding missing ones and removing extras). Everything
+// happens in one transaction.
+//
+// Dependency targets must already exist, so importers that upsert issues
+// referring to each other should upsert them without dependencies first.
+// An issue with an empty ID gets a generated one and is always inserted.
+func (s *SQLiteStorage) UpsertIssue(ctx context.Context, issue *types.Issue, actor string) (UpsertOutcome, error) {
+	if err := validateBatchIssues([]*types.Issue{issue}); err != nil {
+		return "", err
+	}
+	issue.ContentHash = issue.ComputeContentHash()
+
+	var outcome UpsertOutcome
+	err := s.withConnTx(ctx, func(conn *sql.Conn) error {
+		var existing *types.Issue
+		if issue.ID != "" {
+			var err error
+			if existing, err = getIssue(ctx, conn, issue.ID); err != nil {
+				return fmt.Errorf("failed to check issue %s: %w", issue.ID, err)
+			}
+		}
+
+		if existing == nil {
+			outcome = UpsertInserted
+			return s.insertUpsertedIssue(ctx, conn, issue, actor)
+		}
+
+		changed := false
+		if upsertFieldsDiffer(existing, issue) {
+			if err := updateIssueIn(ctx, conn, issue.ID, upsertUpdates(issue), actor, "", ""); err != nil {
+				return err
+			}
+			changed = true
+		}
+		labelsChanged, err := reconcileLabels(ctx, conn, issue, actor)
+		if err != nil {
+			return err
+		}
+		depsChanged, err := reconcileDependencies(ctx, conn, issue, actor)
+		if err != nil {
+			return err
+		}
+
+		outcome = UpsertUnchanged
+		if changed || labelsChanged || depsChanged {
+			outcome = UpsertUpdated
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return outcome, nil
+}
+
+// insertUpsertedIssue inserts a new issue with its labels and dependencies,
+// keeping its timestamps (unlike createIssueIn, which resets them)
+func (s *SQLiteStorage) insertUpsertedIssue(ctx context.Context, conn *sql.Conn, issue *types.Issue, actor string) error {
+	issues := []*types.Issue{issue}
+	if err := s.generateBatchIDs(ctx, conn, issues, actor, OrphanAllow); err != nil {
+		return err
+	}
+	if err := insertIssues(ctx, conn, issues); err != nil {
+		return err
+	}
+	if err := recordCreatedEvents(ctx, conn, issues, actor); err != nil {
+		return err
+	}
+	if err := bulkInsertLabels(ctx, conn, issues, actor); err != nil {
+		return err
+	}
+	if err := markDirtyBatch(ctx, conn, issues); err != nil {
+		return err
+	}
+	_, err := reconcileDependencies(ctx, conn, issue, actor)
+	return err
+}
+
+// upsertFieldsDiffer reports whether any field UpsertIssue overwrites differs
+func upsertFieldsDiffer(existing, incoming *types.Issue) bool {
+	if existing.ComputeContentHash() != incoming.ContentHash {
+		return true
+	}
+	if (existing.EstimatedMinutes == nil) != (incoming.EstimatedMinutes == nil) ||
+		(existing.EstimatedMinutes != nil && *existing.EstimatedMinutes != *incoming.EstimatedMinutes) {
+		return true
+	}
+	if (existing.SpentMinutes == nil) != (incoming.SpentMinutes == nil) ||
+		(existing.SpentMinutes != nil && *existing.SpentMinutes != *incoming.SpentMinutes) {
+		return true
+	}
+	if (existing.ClosedAt == nil) != (incoming.ClosedAt == nil) ||
+		(existing.ClosedAt != nil && !existing.ClosedAt.Equal(*incoming.ClosedAt)) {
+		return true
+	}
+	return false
+}
+
+// upsertUpdates builds the updates that make an issue's fields match incoming
+func upsertUpdates(incoming *types.Issue) map[string]interface{} {
+	updates := map[string]interface{}{
+		"title":               incoming.Title,
+		"description":         incoming.Description,
+		"design":              incoming.Design,
+		"acceptance_criteria": incoming.AcceptanceCriteria,
+		"notes":               incoming.Notes,
+		"status":              string(incoming.Status),
+		"priority":            incoming.Priority,
+		"issue_type":          string(incoming.IssueType),
+		"closed_at":           incoming.ClosedAt,
+		"resolution":          string(incoming.Resolution),
+		"resolved_by":         incoming.ResolvedBy,
+		"estimated_minutes":   nil,
+		"spent_minutes":       nil,
+		"assignee":            nil,
+		"external_ref":        nil,
+		"metadata":            incoming.Metadata,
+		"attachments":         incoming.Attachments,
+		"archived_at":         incoming.ArchivedAt,
+	}
+	if incoming.EstimatedMinutes != nil {
+		updates["estimated_minutes"] = *incoming.EstimatedMinutes
+	}
+	if incoming.SpentMinutes != nil {
+		updates["spent_minutes"] = *incoming.SpentMinutes
+	}
+	if incoming.Assignee != "" {
+		updates["assignee"] = incoming.Assignee
+	}
+	if incoming.ExternalRef != nil && *incoming.ExternalRef != "" {
+		updates["external_ref"] = *incoming.ExternalRef
+	}
+	return updates
+}
+
+// reconcileLabels makes the issue's labels match issue.Labels
+func reconcileLabels(ctx context.Context, conn *sql.Conn, issue *types.Issue, actor string) (bool, error) {
+	current, err := getLabels(ctx, conn, issue.ID)
+	if err != nil {
+		return false, err
+	}
+	want := make(map[string]bool, len(issue.Labels))
+	for _, label := range issue.Labels {
+		if label = util.NormalizeLabel(label); label != "" {
+			want[label] = true
+		}
+	}
+	have := make(map[string]bool, len(current))
+	for _, label := range current {
+		have[label] = true
+	}
+
+	changed := false
+	for _, label := range current {
+		if !want[label] {
+			if err := removeLabelIn(ctx, conn, issue.ID, label, actor); err != nil {
+				return false, err
+			}
+			changed = true
+		}
+	}
+	// Sorted so label events are recorded in a stable order
+	missing := make([]string, 0, len(want))
+	for label := range want {
+		if !have[label] {
+			missing = append(missing, label)
+		}
+	}
+	sort.Strings(missing)
+	for _, label := range missing {
+		if err := addLabelIn(ctx, conn, issue.ID, label, actor); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// reconcileDependencies makes the issue's outgoing dependencies match
+// issue.Dependencies. A dependency whose type changed is removed and re-added.
+func reconcileDependencies(ctx context.Context, conn *sql.Conn, issue *types.Issue, actor string) (bool, error) {
+	current, err := getDependencyRecords(ctx, conn, issue.ID)
+	if err != nil {
+		return false, err
+	}
+	want := make(map[string]*types.Dependency, len(issue.Dependencies))
+	for _, dep := range issue.Dependencies {
+		want[dep.DependsOnID] = dep
+	}
+
+	changed := false
+	have := make(map[string]bool, len(current))
+	for _, dep := range current {
+		if w, ok := want[dep.DependsOnID]; ok && w.Type == dep.Type {
+			have[dep.DependsOnID] = true
+			continue
+		}
+		if err := removeDependencyIn(ctx, conn, issue.ID, dep.DependsOnID, actor); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	for _, dep := range issue.Dependencies {
+		if have[dep.DependsOnID] {
+			continue
+		}
+		add := *dep
+		add.IssueID = issue.ID
+		if err := addDependencyIn(ctx, conn, &add, actor); err != nil {
+			return false, fmt.Errorf("issue %s: %w", issue.ID, err)
+		}
+		have[dep.DependsOnID] = true
+		changed = true
+	}
+	return changed, nil
+}
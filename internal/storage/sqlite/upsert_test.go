@@ -0,0 +1,80 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestUpsertIssue(t *testing.T) {
+	store, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	blocker := &types.Issue{ID: "bd-blk", Title: "Blocker", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	other := &types.Issue{ID: "bd-oth", Title: "Other", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{blocker, other} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	issue := &types.Issue{
+		ID: "bd-ups", Title: "Original", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask,
+		Labels:       []string{"keep", "drop"},
+		Dependencies: []*types.Dependency{{DependsOnID: "bd-blk", Type: types.DepBlocks}},
+	}
+	outcome, err := store.UpsertIssue(ctx, issue, "test")
+	if err != nil {
+		t.Fatalf("UpsertIssue (insert) failed: %v", err)
+	}
+	if outcome != UpsertInserted {
+		t.Errorf("outcome = %s, want %s", outcome, UpsertInserted)
+	}
+
+	// Same record again is a no-op
+	again := *issue
+	if outcome, err = store.UpsertIssue(ctx, &again, "test"); err != nil || outcome != UpsertUnchanged {
+		t.Errorf("UpsertIssue (same) = %s, %v; want %s", outcome, err, UpsertUnchanged)
+	}
+
+	changed := &types.Issue{
+		ID: "bd-ups", Title: "Changed", Status: types.StatusInProgress, Priority: 0, IssueType: types.TypeBug,
+		Labels:       []string{"keep", "new"},
+		Dependencies: []*types.Dependency{{DependsOnID: "bd-oth", Type: types.DepRelated}},
+	}
+	if outcome, err = store.UpsertIssue(ctx, changed, "test"); err != nil || outcome != UpsertUpdated {
+		t.Fatalf("UpsertIssue (changed) = %s, %v; want %s", outcome, err, UpsertUpdated)
+	}
+
+	got, err := store.GetIssue(ctx, "bd-ups")
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if got.Title != "Changed" || got.Status != types.StatusInProgress || got.Priority != 0 || got.IssueType != types.TypeBug {
+		t.Errorf("fields not updated: %+v", got)
+	}
+	if strings.Join(got.Labels, ",") != "keep,new" {
+		t.Errorf("labels = %v, want [keep new]", got.Labels)
+	}
+	deps, err := store.GetDependencyRecords(ctx, "bd-ups")
+	if err != nil {
+		t.Fatalf("GetDependencyRecords failed: %v", err)
+	}
+	if len(deps) != 1 || deps[0].DependsOnID != "bd-oth" || deps[0].Type != types.DepRelated {
+		t.Errorf("dependencies = %+v, want only related on bd-oth", deps)
+	}
+
+	// A missing dependency target rolls the whole upsert back
+	bad := *changed
+	bad.Title = "Should not stick"
+	bad.Dependencies = []*types.Dependency{{DependsOnID: "bd-missing", Type: types.DepBlocks}}
+	if _, err := store.UpsertIssue(ctx, &bad, "test"); err == nil {
+		t.Fatal("expected error for missing dependency target")
+	}
+	if got, _ := store.GetIssue(ctx, "bd-ups"); got.Title != "Changed" {
+		t.Errorf("title = %q after failed upsert, want %q", got.Title, "Changed")
+	}
+}
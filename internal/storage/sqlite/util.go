@@ -12,17 +12,32 @@ func (s *SQLiteStorage) QueryContext(ctx context.Context, query string, args ...
 	return s.db.QueryContext(ctx, query, args...)
 }
 
+// writeTxOptions begins write transactions IMMEDIATE (the driver maps
+// serializable isolation to it). In WAL mode a deferred transaction that has
+// read can't wait out another writer when it starts writing, so it fails with
+// "database is locked" regardless of busy_timeout.
+var writeTxOptions = &sql.TxOptions{Isolation: sql.LevelSerializable}
+
 // BeginTx starts a new database transaction
 // This is used by commands that need to perform multiple operations atomically
 func (s *SQLiteStorage) BeginTx(ctx context.Context) (*sql.Tx, error) {
-	return s.db.BeginTx(ctx, nil)
+	return s.db.BeginTx(ctx, writeTxOptions)
+}
+
+// dbExecutor is satisfied by *sql.DB, *sql.Conn and *sql.Tx, so the same
+// write helpers can run in their own transaction or inside WithTx
+type dbExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
 }
 
 // withTx executes a function within a database transaction.
 // If the function returns an error, the transaction is rolled back.
 // Otherwise, the transaction is committed.
 func (s *SQLiteStorage) withTx(ctx context.Context, fn func(*sql.Tx) error) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+	tx, err := s.db.BeginTx(ctx, writeTxOptions)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -56,6 +57,97 @@ func validateEstimatedMinutes(value interface{}) error {
 	return nil
 }
 
+// validateSpentMinutes validates a spent_minutes value
+func validateSpentMinutes(value interface{}) error {
+	if mins, ok := value.(int); ok {
+		if mins < 0 {
+			return fmt.Errorf("spent_minutes cannot be negative")
+		}
+	}
+	return nil
+}
+
+// validateResolvedBy validates a resolved_by value: a commit SHA, or empty
+// to clear it
+func validateResolvedBy(value interface{}) error {
+	sha, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("resolved_by must be a string, got %T", value)
+	}
+	if sha == "" {
+		return nil
+	}
+	return types.ValidateCommitSHA(sha)
+}
+
+// validateResolution validates a resolution value
+func validateResolution(value interface{}) error {
+	var resolution types.Resolution
+	switch v := value.(type) {
+	case string:
+		resolution = types.Resolution(v)
+	case types.Resolution:
+		resolution = v
+	default:
+		return nil
+	}
+	if !resolution.IsValid() {
+		return fmt.Errorf("invalid resolution: %s (must be fixed, wontfix, duplicate or obsolete)", resolution)
+	}
+	return nil
+}
+
+// validateMetadata validates a metadata value, which replaces all of an
+// issue's custom fields
+func validateMetadata(value interface{}) error {
+	metadata, err := metadataUpdateValue(value)
+	if err != nil {
+		return err
+	}
+	for key := range metadata {
+		if err := types.ValidateMetadataKey(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateAttachments validates an attachments value, which replaces all of
+// an issue's attachment references
+func validateAttachments(value interface{}) error {
+	attachments, err := attachmentsUpdateValue(value)
+	if err != nil {
+		return err
+	}
+	for _, ref := range attachments {
+		if err := types.ValidateAttachment(ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateArchivedAt validates an archived_at value: a time, or nil to
+// unarchive
+func validateArchivedAt(value interface{}) error {
+	switch value.(type) {
+	case nil, time.Time, *time.Time:
+		return nil
+	}
+	return fmt.Errorf("archived_at must be a time or nil, got %T", value)
+}
+
+// archivedAtUpdateValue converts a validated archived_at update value
+func archivedAtUpdateValue(value interface{}) *time.Time {
+	switch v := value.(type) {
+	case time.Time:
+		return &v
+	case *time.Time:
+		return v
+	}
+	return nil
+}
+
 // fieldValidators maps field names to their validation functions
 var fieldValidators = map[string]func(interface{}) error{
 	"priority":          validatePriority,
@@ -63,6 +155,12 @@ var fieldValidators = map[string]func(interface{}) error{
 	"issue_type":        validateIssueType,
 	"title":             validateTitle,
 	"estimated_minutes": validateEstimatedMinutes,
+	"spent_minutes":     validateSpentMinutes,
+	"resolution":        validateResolution,
+	"resolved_by":       validateResolvedBy,
+	"metadata":          validateMetadata,
+	"archived_at":       validateArchivedAt,
+	"attachments":       validateAttachments,
 }
 
 // validateFieldUpdate validates a field update value
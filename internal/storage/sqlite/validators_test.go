@@ -137,6 +137,8 @@ func TestValidateFieldUpdate(t *testing.T) {
 		{"invalid priority", "priority", 5, true},
 		{"valid status", "status", string(types.StatusOpen), false},
 		{"invalid status", "status", "invalid", true},
+		{"valid spent minutes", "spent_minutes", 30, false},
+		{"negative spent minutes", "spent_minutes", -5, true},
 		{"unknown field", "unknown_field", "any value", false},
 	}
 
@@ -4,21 +4,58 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/steveyegge/beads/internal/types"
 )
 
+// Transaction is the subset of Storage available inside WithTx. Every write
+// made through it commits together when the callback returns nil, and is
+// rolled back if it returns an error.
+type Transaction interface {
+	CreateIssue(ctx context.Context, issue *types.Issue, actor string) error
+	GetIssue(ctx context.Context, id string) (*types.Issue, error)
+	UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error
+	CloseIssue(ctx context.Context, id string, opts CloseOptions, actor string) error
+	DeleteIssue(ctx context.Context, id string) error
+	UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error
+
+	AddDependency(ctx context.Context, dep *types.Dependency, actor string) error
+	RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error
+
+	AddLabel(ctx context.Context, issueID, label, actor string) error
+	RemoveLabel(ctx context.Context, issueID, label, actor string) error
+
+	AddComment(ctx context.Context, issueID, actor, comment string) error
+	GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error)
+	UpdateComment(ctx context.Context, commentID int64, text string) (*types.Comment, error)
+
+	ResetChildCounter(ctx context.Context, parentID string) error
+}
+
 // Storage defines the interface for issue storage backends
 type Storage interface {
+	// Transactions: fn must make all its calls through tx, not the Storage
+	// itself, or it may block waiting on its own write lock
+	WithTx(ctx context.Context, fn func(tx Transaction) error) error
+
 	// Issues
 	CreateIssue(ctx context.Context, issue *types.Issue, actor string) error
 	CreateIssues(ctx context.Context, issues []*types.Issue, actor string) error
 	GetIssue(ctx context.Context, id string) (*types.Issue, error)
 	GetIssueByExternalRef(ctx context.Context, externalRef string) (*types.Issue, error)
 	UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error
-	CloseIssue(ctx context.Context, id string, reason string, actor string) error
+	// UpdateIssueIfUnchanged is UpdateIssue that fails with *ConflictError if the issue's UpdatedAt is no longer expectedUpdatedAt
+	UpdateIssueIfUnchanged(ctx context.Context, id string, expectedUpdatedAt time.Time, updates map[string]interface{}, actor string) error
+	CloseIssue(ctx context.Context, id string, opts CloseOptions, actor string) error
+	ReopenIssue(ctx context.Context, id string, note string, actor string) error // note is stored on the Reopened event
 	DeleteIssue(ctx context.Context, id string) error
 	SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error)
+	CountIssues(ctx context.Context, query string, filter types.IssueFilter) (int, error) // Matches for SearchIssues, ignoring Limit and Offset
+	// StreamIssues calls fn with each issue SearchIssues would return, in order, as it is read; fn must not use the store
+	StreamIssues(ctx context.Context, query string, filter types.IssueFilter, fn func(*types.Issue) error) error
+	FindByTitle(ctx context.Context, query string) ([]*types.Issue, error)                // Issues whose titles fuzzily match query, best first
 
 	// Dependencies
 	AddDependency(ctx context.Context, dep *types.Dependency, actor string) error
@@ -30,12 +67,17 @@ type Storage interface {
 	GetDependencyCounts(ctx context.Context, issueIDs []string) (map[string]*types.DependencyCounts, error)
 	GetDependencyTree(ctx context.Context, issueID string, maxDepth int, showAllPaths bool, reverse bool) ([]*types.TreeNode, error)
 	DetectCycles(ctx context.Context) ([][]*types.Issue, error)
+	TopoSort(ctx context.Context, rootID string) ([][]*types.Issue, error) // Open work under rootID in waves, blockers and children first
+	DetectCycle(ctx context.Context, fromID, toID string) ([]string, error) // Cycle that "fromID depends on toID" would close, or nil
+	PruneDanglingDependencies(ctx context.Context, dryRun bool) ([]*types.Dependency, error) // Records whose issue or target is missing; deleted unless dryRun
+	PropagatePriority(ctx context.Context, issueID, blockerID string, weight int, actor string) (*types.PriorityChange, error) // weight < 0 uses priority_propagation config
 
 	// Labels
 	AddLabel(ctx context.Context, issueID, label, actor string) error
 	RemoveLabel(ctx context.Context, issueID, label, actor string) error
 	GetLabels(ctx context.Context, issueID string) ([]string, error)
 	GetIssuesByLabel(ctx context.Context, label string) ([]*types.Issue, error)
+	RenameLabel(ctx context.Context, oldLabel, newLabel, actor string, dryRun bool) ([]string, error) // IDs of the issues relabeled; nothing changes if dryRun
 
 	// Ready Work & Blocking
 	GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error)
@@ -46,10 +88,24 @@ type Storage interface {
 	// Events
 	AddComment(ctx context.Context, issueID, actor, comment string) error
 	GetEvents(ctx context.Context, issueID string, limit int) ([]*types.Event, error)
+	GetEventsSince(ctx context.Context, afterID int64, limit int) ([]*types.Event, error) // All issues' events with IDs above afterID, oldest first
+	PruneEvents(ctx context.Context, before time.Time, keepPerIssue int, dryRun bool) (int, error) // Keeps each issue's newest status event; returns the number (to be) removed
+	CompactEvents(ctx context.Context, before time.Time, dryRun bool) (int, error)                 // Keeps each issue's first created and newest status event; returns the number (to be) removed
+	GetIssueIDsChangedSince(ctx context.Context, since time.Time) ([]string, error)                // Updated or with events after since, sorted
 
 	// Comments
 	AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error)
+	AddCommentReply(ctx context.Context, issueID string, parentID int64, author, text string) (*types.Comment, error)
 	GetIssueComments(ctx context.Context, issueID string) ([]*types.Comment, error)
+	UpdateComment(ctx context.Context, commentID int64, text string) (*types.Comment, error) // Replaces the text; returns the updated comment
+	EditComment(ctx context.Context, commentID int64, text, actor string) (*types.Comment, error) // Like UpdateComment, but keeps the original text and records an event
+	DeleteComment(ctx context.Context, commentID int64, actor string) (*types.Comment, error) // Leaves a tombstone; returns the deleted comment
+	ResolveComment(ctx context.Context, commentID int64, resolved bool) (*types.Comment, error) // Applies to the thread root; returns it
+
+	// Advisory locks (a ttl of 0 uses the lock.ttl config key, then types.DefaultLockTTL)
+	AcquireLock(ctx context.Context, issueID, holder string, ttl time.Duration) (*types.IssueLock, error)
+	ReleaseLock(ctx context.Context, issueID, holder string, force bool) error
+	GetLock(ctx context.Context, issueID string) (*types.IssueLock, error) // nil if unlocked or expired
 
 	// Statistics
 	GetStatistics(ctx context.Context) (*types.Statistics, error)
@@ -71,6 +127,9 @@ type Storage interface {
 
 	// ID Generation
 	GetNextChildID(ctx context.Context, parentID string) (string, error)
+	CreateChildIssue(ctx context.Context, issue *types.Issue, parentID string, actor string) error // Creates parentID.N with its parent-child dependency in one transaction
+	ResetChildCounter(ctx context.Context, parentID string) error // Recomputes the counter from the highest existing child
+	SyncChildCounters(ctx context.Context, parentIDs ...string) (int, error) // Raises counters behind their highest existing child (all parents, or just parentIDs)
 
 	// Config
 	SetConfig(ctx context.Context, key, value string) error
@@ -106,6 +165,52 @@ type Storage interface {
 	UnderlyingConn(ctx context.Context) (*sql.Conn, error)
 }
 
+// CloseOptions describes how CloseIssue closes an issue. Only Reason is
+// needed; the rest are empty for none.
+type CloseOptions struct {
+	Reason     string           // Stored as the Closed event's comment
+	Note       string           // Stored as the Closed event's note
+	Resolution types.Resolution // Why the issue was closed
+	Commit     string           // SHA of the commit that resolved it
+}
+
+// Validate rejects an unknown resolution or a malformed commit SHA
+func (o CloseOptions) Validate() error {
+	if !o.Resolution.IsValid() {
+		return fmt.Errorf("invalid resolution: %s (must be fixed, wontfix, duplicate or obsolete)", o.Resolution)
+	}
+	if o.Commit != "" {
+		return types.ValidateCommitSHA(o.Commit)
+	}
+	return nil
+}
+
+// IDExistsError is returned by UpdateIssueID when the new ID already belongs
+// to another issue. Nothing is changed.
+type IDExistsError struct {
+	ID string
+}
+
+func (e *IDExistsError) Error() string {
+	return fmt.Sprintf("issue %s already exists", e.ID)
+}
+
+// ConflictError is returned by UpdateIssueIfUnchanged when the issue was
+// changed after the caller read it. Nothing is changed; callers can re-read
+// the issue and retry.
+type ConflictError struct {
+	ID       string
+	Expected time.Time // UpdatedAt the caller read
+	Actual   time.Time // UpdatedAt now stored
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("issue %s was changed at %s, after it was read at %s; not updated, re-run to apply the change on top", e.ID, e.Actual.Format(time.RFC3339Nano), e.Expected.Format(time.RFC3339Nano))
+}
+
+// ErrorCode classifies the error as types.ErrCodeConflict
+func (e *ConflictError) ErrorCode() string { return types.ErrCodeConflict }
+
 // Config holds database configuration
 type Config struct {
 	Backend string // "sqlite" or "postgres"
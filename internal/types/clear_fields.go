@@ -0,0 +1,71 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ClearableFields are the optional issue fields 'bd update --clear' empties,
+// keyed by update field name, with the value clearing stores: NULL for
+// external_ref and the minute counts, an empty string for text
+var ClearableFields = map[string]interface{}{
+	"assignee":            "",
+	"design":              "",
+	"notes":               "",
+	"acceptance_criteria": "",
+	"external_ref":        nil,
+	"estimated_minutes":   nil,
+	"spent_minutes":       nil,
+}
+
+// clearFieldAliases maps bd update flag names to their fields
+var clearFieldAliases = map[string]string{
+	"external-ref":        "external_ref",
+	"acceptance":          "acceptance_criteria",
+	"acceptance-criteria": "acceptance_criteria",
+	"estimate":            "estimated_minutes",
+	"spent":               "spent_minutes",
+}
+
+// ParseClearField returns the update field named by name, a field name or
+// the bd update flag that sets it (e.g. external-ref or estimate)
+func ParseClearField(name string) (string, error) {
+	field := strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := clearFieldAliases[field]; ok {
+		field = alias
+	}
+	if _, ok := ClearableFields[field]; !ok {
+		valid := make([]string, 0, len(ClearableFields))
+		for f := range ClearableFields {
+			valid = append(valid, f)
+		}
+		sort.Strings(valid)
+		return "", fmt.Errorf("can't clear %q (clearable: %s)", name, strings.Join(valid, ", "))
+	}
+	return field, nil
+}
+
+// OnlyClearsFields reports whether updates does nothing but clear
+// ClearableFields, which storage records as an EventFieldsCleared
+func OnlyClearsFields(updates map[string]interface{}) bool {
+	if len(updates) == 0 {
+		return false
+	}
+	for field, value := range updates {
+		cleared, ok := ClearableFields[field]
+		if !ok {
+			return false
+		}
+		switch v := value.(type) {
+		case nil:
+		case string:
+			if v != "" || cleared != "" {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package types
+
+import "testing"
+
+func TestParseClearField(t *testing.T) {
+	tests := map[string]string{
+		"notes":               "notes",
+		"external-ref":        "external_ref",
+		"external_ref":        "external_ref",
+		"Acceptance":          "acceptance_criteria",
+		"acceptance-criteria": "acceptance_criteria",
+		"estimate":            "estimated_minutes",
+		"spent":               "spent_minutes",
+	}
+	for name, want := range tests {
+		if got, err := ParseClearField(name); err != nil || got != want {
+			t.Errorf("ParseClearField(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	for _, name := range []string{"title", "status", ""} {
+		if _, err := ParseClearField(name); err == nil {
+			t.Errorf("ParseClearField(%q) should fail", name)
+		}
+	}
+}
+
+func TestOnlyClearsFields(t *testing.T) {
+	tests := []struct {
+		updates map[string]interface{}
+		want    bool
+	}{
+		{map[string]interface{}{"notes": "", "external_ref": nil}, true},
+		{map[string]interface{}{"assignee": nil}, true},
+		{map[string]interface{}{"notes": "text"}, false},
+		{map[string]interface{}{"external_ref": ""}, false}, // An empty ref, not NULL
+		{map[string]interface{}{"estimated_minutes": 30}, false},
+		{map[string]interface{}{"notes": "", "title": ""}, false},
+		{map[string]interface{}{}, false},
+	}
+	for _, tt := range tests {
+		if got := OnlyClearsFields(tt.updates); got != tt.want {
+			t.Errorf("OnlyClearsFields(%v) = %v, want %v", tt.updates, got, tt.want)
+		}
+	}
+}
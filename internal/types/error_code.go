@@ -0,0 +1,49 @@
+package types
+
+import "errors"
+
+// Error codes classify failures scripts can act on. bd exits with a status
+// for each, and --json error output carries the code.
+const (
+	ErrCodeNoDatabase = "no_database"
+	ErrCodeNotFound   = "not_found"
+	ErrCodeAmbiguous  = "ambiguous_id"
+	ErrCodeConflict   = "conflict"
+	ErrCodeValidation = "validation"
+	ErrCodeReadOnly   = "read_only"
+)
+
+// CodedError is an error classified by one of the ErrCode constants
+type CodedError interface {
+	error
+	ErrorCode() string
+}
+
+// codeError attaches a code to an error without changing its message
+type codeError struct {
+	code string
+	err  error
+}
+
+func (e *codeError) Error() string     { return e.err.Error() }
+func (e *codeError) Unwrap() error     { return e.err }
+func (e *codeError) ErrorCode() string { return e.code }
+
+// WithCode classifies err as code, keeping its message. Returns nil for a
+// nil err.
+func WithCode(code string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &codeError{code: code, err: err}
+}
+
+// ErrorCode returns the code of the first CodedError in err's chain, or ""
+// if it has none
+func ErrorCode(err error) string {
+	var coded CodedError
+	if errors.As(err, &coded) {
+		return coded.ErrorCode()
+	}
+	return ""
+}
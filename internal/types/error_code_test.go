@@ -0,0 +1,29 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorCode(t *testing.T) {
+	base := errors.New("issue bd-1 not found")
+	coded := WithCode(ErrCodeNotFound, base)
+	if coded.Error() != base.Error() {
+		t.Errorf("WithCode changed the message to %q", coded.Error())
+	}
+	if !errors.Is(coded, base) {
+		t.Error("WithCode hid the wrapped error from errors.Is")
+	}
+
+	wrapped := fmt.Errorf("failed to update issue: %w", coded)
+	if code := ErrorCode(wrapped); code != ErrCodeNotFound {
+		t.Errorf("ErrorCode(wrapped) = %q, want %q", code, ErrCodeNotFound)
+	}
+	if code := ErrorCode(base); code != "" {
+		t.Errorf("ErrorCode(uncoded) = %q, want \"\"", code)
+	}
+	if WithCode(ErrCodeConflict, nil) != nil {
+		t.Error("WithCode(nil) should be nil")
+	}
+}
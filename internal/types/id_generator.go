@@ -3,7 +3,10 @@ package types
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -90,3 +93,103 @@ func ParseHierarchicalID(id string) (rootID, parentID string, depth int) {
 // MaxHierarchyDepth is the maximum nesting level for hierarchical IDs.
 // Prevents over-decomposition and keeps IDs manageable.
 const MaxHierarchyDepth = 3
+
+// PrefixByTypeConfigKey is the config key for per-type ID prefixes: a JSON
+// object mapping issue types to prefixes, e.g. {"epic":"epic","bug":"bug"}
+const PrefixByTypeConfigKey = "prefix_by_type"
+
+// IssuePrefixEnvVar names the environment variable that overrides the
+// stored issue_prefix for new and resolved IDs, so throwaway databases (e.g.
+// in CI) can pick a prefix without writing config first
+const IssuePrefixEnvVar = "BEADS_ISSUE_PREFIX"
+
+// ValidateIssuePrefix checks an issue_prefix value, which must match
+// [a-z][a-z0-9]*. IDs are split at their first dash and hierarchical children
+// add .N suffixes, so a prefix holding either would make IDs ambiguous.
+func ValidateIssuePrefix(prefix string) error {
+	switch {
+	case prefix == "":
+		return fmt.Errorf("prefix cannot be empty")
+	case strings.Contains(prefix, "-"):
+		return fmt.Errorf("invalid prefix %q: cannot contain '-', which separates the prefix from the hash in IDs", prefix)
+	case strings.Contains(prefix, "."):
+		return fmt.Errorf("invalid prefix %q: cannot contain '.', which separates hierarchical child numbers in IDs", prefix)
+	case strings.ContainsAny(prefix, " \t\r\n"):
+		return fmt.Errorf("invalid prefix %q: cannot contain whitespace", prefix)
+	case prefix[0] < 'a' || prefix[0] > 'z':
+		return fmt.Errorf("invalid prefix %q: must start with a lowercase letter", prefix)
+	}
+	for _, c := range prefix {
+		if !((c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')) {
+			return fmt.Errorf("invalid prefix %q: may only contain lowercase letters and digits", prefix)
+		}
+	}
+	return nil
+}
+
+// IDPrefixes are the prefixes new top-level issue IDs are generated with
+type IDPrefixes struct {
+	Default string               // issue_prefix, for unmapped types
+	ByType  map[IssueType]string // prefix_by_type
+}
+
+// ForType returns the prefix for a new top-level issue of the given type.
+// Child IDs extend their parent's ID, so they keep the parent's prefix.
+func (p IDPrefixes) ForType(t IssueType) string {
+	if prefix, ok := p.ByType[t]; ok {
+		return prefix
+	}
+	return p.Default
+}
+
+// All returns the default prefix followed by the other per-type prefixes,
+// sorted and without duplicates
+func (p IDPrefixes) All() []string {
+	all := []string{p.Default}
+	seen := map[string]bool{p.Default: true}
+	var extra []string
+	for _, prefix := range p.ByType {
+		if !seen[prefix] {
+			seen[prefix] = true
+			extra = append(extra, prefix)
+		}
+	}
+	sort.Strings(extra)
+	return append(all, extra...)
+}
+
+// Match returns the configured prefix id starts with (followed by a dash),
+// preferring the longest, or "" if none matches
+func (p IDPrefixes) Match(id string) string {
+	match := ""
+	for _, prefix := range p.All() {
+		if strings.HasPrefix(id, prefix+"-") && len(prefix) > len(match) {
+			match = prefix
+		}
+	}
+	return match
+}
+
+// ParsePrefixByType parses a prefix_by_type config value. An empty value
+// means no per-type prefixes. A trailing dash on a prefix is dropped.
+func ParsePrefixByType(value string) (map[IssueType]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var raw map[string]string
+	if err := json.Unmarshal([]byte(value), &raw); err != nil {
+		return nil, fmt.Errorf("%s must be a JSON object mapping issue types to prefixes: %w", PrefixByTypeConfigKey, err)
+	}
+	byType := make(map[IssueType]string, len(raw))
+	for t, prefix := range raw {
+		if !IssueType(t).IsValid() {
+			return nil, fmt.Errorf("%s: invalid issue type %q", PrefixByTypeConfigKey, t)
+		}
+		prefix = strings.TrimRight(prefix, "-")
+		if err := ValidateIssuePrefix(prefix); err != nil {
+			return nil, fmt.Errorf("%s: prefix for %s: %w", PrefixByTypeConfigKey, t, err)
+		}
+		byType[IssueType(t)] = prefix
+	}
+	return byType, nil
+}
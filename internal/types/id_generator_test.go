@@ -1,6 +1,7 @@
 package types
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -209,3 +210,73 @@ func BenchmarkGenerateChildID(b *testing.B) {
 		GenerateChildID("bd-af78e9a2", 42)
 	}
 }
+
+func TestParsePrefixByType(t *testing.T) {
+	byType, err := ParsePrefixByType(`{"epic":"epic","bug":"bug-"}`)
+	if err != nil {
+		t.Fatalf("ParsePrefixByType failed: %v", err)
+	}
+	if byType[TypeEpic] != "epic" || byType[TypeBug] != "bug" || len(byType) != 2 {
+		t.Errorf("ParsePrefixByType = %v", byType)
+	}
+	if byType, err := ParsePrefixByType(""); err != nil || byType != nil {
+		t.Errorf("empty value = %v, %v; want no mapping", byType, err)
+	}
+	for _, value := range []string{`["epic"]`, `{"story":"st"}`, `{"epic":""}`, `{"epic":"Epic"}`, `{"epic":"1ep"}`, `{"epic":"e--p"}`, `{"epic":"my-epic"}`, `{"epic":"e.p"}`} {
+		if _, err := ParsePrefixByType(value); err == nil {
+			t.Errorf("ParsePrefixByType(%s) accepted", value)
+		}
+	}
+}
+
+func TestIDPrefixes(t *testing.T) {
+	prefixes := IDPrefixes{Default: "bd", ByType: map[IssueType]string{TypeEpic: "epic", TypeBug: "bd-bug", TypeChore: "bd"}}
+	if got := prefixes.ForType(TypeEpic); got != "epic" {
+		t.Errorf("ForType(epic) = %s", got)
+	}
+	if got := prefixes.ForType(TypeTask); got != "bd" {
+		t.Errorf("ForType(task) = %s, want the default", got)
+	}
+	if got := prefixes.All(); len(got) != 3 || got[0] != "bd" || got[1] != "bd-bug" || got[2] != "epic" {
+		t.Errorf("All() = %v", got)
+	}
+	tests := map[string]string{
+		"epic-a3f":    "epic",
+		"epic-a3f.1":  "epic",
+		"bd-bug-91cc": "bd-bug", // Longest prefix wins
+		"bd-91cc":     "bd",
+		"epica3f":     "",
+		"other-a3f":   "",
+	}
+	for id, want := range tests {
+		if got := prefixes.Match(id); got != want {
+			t.Errorf("Match(%s) = %q, want %q", id, got, want)
+		}
+	}
+}
+
+func TestValidateIssuePrefix(t *testing.T) {
+	for _, prefix := range []string{"bd", "a", "proj2", "x1y2"} {
+		if err := ValidateIssuePrefix(prefix); err != nil {
+			t.Errorf("ValidateIssuePrefix(%q) = %v", prefix, err)
+		}
+	}
+	rejected := map[string]string{
+		"":        "empty",
+		"my-proj": "'-'",
+		"bd.v2":   "'.'",
+		"my proj": "whitespace",
+		"\tbd":    "whitespace",
+		"1bd":     "lowercase letter",
+		"Bd":      "lowercase letter",
+		"bD":      "lowercase letters and digits",
+		"bd_x":    "lowercase letters and digits",
+		"bé":      "lowercase letters and digits",
+	}
+	for prefix, want := range rejected {
+		err := ValidateIssuePrefix(prefix)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidateIssuePrefix(%q) = %v, want an error mentioning %s", prefix, err, want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PriorityNames names the priority levels, indexed by priority (0 = highest).
+// Storage and JSONL keep the integer; names are for input and display.
+type PriorityNames []string
+
+// DefaultPriorityNames are the names used when priority-names isn't configured
+var DefaultPriorityNames = PriorityNames{"critical", "high", "medium", "low", "backlog"}
+
+// ParsePriorityNames checks a configured list of priority names: one per
+// priority 0-4, lowercase-insensitive and unique, and not themselves
+// readable as a priority number ("3" or "p3")
+func ParsePriorityNames(names []string) (PriorityNames, error) {
+	if len(names) != len(DefaultPriorityNames) {
+		return nil, fmt.Errorf("priority names must name all %d priorities 0-%d, got %d", len(DefaultPriorityNames), len(DefaultPriorityNames)-1, len(names))
+	}
+	parsed := make(PriorityNames, len(names))
+	seen := make(map[string]bool, len(names))
+	for i, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			return nil, fmt.Errorf("priority name for P%d cannot be empty", i)
+		}
+		if _, ok := parsePriorityNumber(name); ok {
+			return nil, fmt.Errorf("priority name %q for P%d looks like a priority number", name, i)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("priority name %q is used twice", name)
+		}
+		seen[name] = true
+		parsed[i] = name
+	}
+	return parsed, nil
+}
+
+// Name returns the name of priority p, or "" if p is out of range
+func (n PriorityNames) Name(p int) string {
+	if p < 0 || p >= len(n) {
+		return ""
+	}
+	return n[p]
+}
+
+// Parse reads a priority given as 0-4, P0-P4 or one of the names, ignoring case
+func (n PriorityNames) Parse(s string) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if p, ok := parsePriorityNumber(s); ok {
+		if p < 0 || p >= len(n) {
+			return 0, fmt.Errorf("invalid priority %q (expected 0-%d, P0-P%d or %s)", s, len(n)-1, len(n)-1, strings.Join(n, ", "))
+		}
+		return p, nil
+	}
+	for p, name := range n {
+		if s == name {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid priority %q (expected 0-%d, P0-P%d or %s)", s, len(n)-1, len(n)-1, strings.Join(n, ", "))
+}
+
+// parsePriorityNumber reads "3" or "p3"
+func parsePriorityNumber(s string) (int, bool) {
+	p, err := strconv.Atoi(strings.TrimPrefix(s, "p"))
+	return p, err == nil
+}
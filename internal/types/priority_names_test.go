@@ -0,0 +1,49 @@
+package types
+
+import "testing"
+
+func TestPriorityNamesParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"0", 0, false},
+		{"P3", 3, false},
+		{"p1", 1, false},
+		{"high", 1, false},
+		{" Backlog ", 4, false},
+		{"5", 0, true},
+		{"urgent", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := DefaultPriorityNames.Parse(tt.input)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+			t.Errorf("Parse(%q) = %d, %v; want %d (error %v)", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+	if DefaultPriorityNames.Name(2) != "medium" || DefaultPriorityNames.Name(7) != "" {
+		t.Errorf("unexpected names: %q, %q", DefaultPriorityNames.Name(2), DefaultPriorityNames.Name(7))
+	}
+}
+
+func TestParsePriorityNames(t *testing.T) {
+	names, err := ParsePriorityNames([]string{"Blocker", "urgent", "normal", "minor", "someday"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p, err := names.Parse("URGENT"); err != nil || p != 1 {
+		t.Errorf("Parse(URGENT) = %d, %v", p, err)
+	}
+	for _, bad := range [][]string{
+		{"a", "b", "c", "d"},
+		{"a", "b", "c", "d", "a"},
+		{"a", "b", "", "d", "e"},
+		{"a", "b", "p2", "d", "e"},
+	} {
+		if _, err := ParsePriorityNames(bad); err == nil {
+			t.Errorf("expected error for %v", bad)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package types
+
+import (
+	"sort"
+	"strings"
+)
+
+// Title match quality, best first
+const (
+	titleMatchExact     = iota // The whole title, ignoring case and spacing
+	titleMatchPrefix           // The title starts with the query
+	titleMatchSubstring        // The query appears in the title
+	titleMatchWords            // Every query word appears in the title, in any order
+	titleMatchFuzzy            // Every query word is close to a title word (typos)
+	titleNoMatch
+)
+
+// NormalizeTitle lowercases s and collapses its whitespace, the form titles
+// are compared in
+func NormalizeTitle(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+// RankTitleMatches returns the issues whose titles match query, best match
+// first: the exact title, then titles starting with it, containing it,
+// containing all its words, and finally titles whose words are within a
+// typo or two of the query's. Ties put unclosed issues first, then shorter
+// titles, then higher priority, then ID.
+func RankTitleMatches(query string, issues []*Issue) []*Issue {
+	query = NormalizeTitle(query)
+	if query == "" {
+		return nil
+	}
+	queryWords := strings.Fields(query)
+
+	type match struct {
+		issue *Issue
+		score int
+		title string
+	}
+	var matches []match
+	for _, issue := range issues {
+		title := NormalizeTitle(issue.Title)
+		if score := titleMatchScore(query, queryWords, title); score != titleNoMatch {
+			matches = append(matches, match{issue: issue, score: score, title: title})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.score != b.score {
+			return a.score < b.score
+		}
+		if aClosed, bClosed := a.issue.Status == StatusClosed, b.issue.Status == StatusClosed; aClosed != bClosed {
+			return bClosed
+		}
+		if len(a.title) != len(b.title) {
+			return len(a.title) < len(b.title)
+		}
+		if a.issue.Priority != b.issue.Priority {
+			return a.issue.Priority < b.issue.Priority
+		}
+		return a.issue.ID < b.issue.ID
+	})
+
+	ranked := make([]*Issue, len(matches))
+	for i, m := range matches {
+		ranked[i] = m.issue
+	}
+	return ranked
+}
+
+func titleMatchScore(query string, queryWords []string, title string) int {
+	switch {
+	case title == query:
+		return titleMatchExact
+	case strings.HasPrefix(title, query):
+		return titleMatchPrefix
+	case strings.Contains(title, query):
+		return titleMatchSubstring
+	}
+
+	allWords := true
+	for _, word := range queryWords {
+		if !strings.Contains(title, word) {
+			allWords = false
+			break
+		}
+	}
+	if allWords {
+		return titleMatchWords
+	}
+
+	titleWords := strings.Fields(title)
+	for _, word := range queryWords {
+		if !fuzzyWordMatch(word, titleWords) {
+			return titleNoMatch
+		}
+	}
+	return titleMatchFuzzy
+}
+
+// fuzzyWordMatch reports whether word is a typo away from one of words:
+// one edit for words of 4 or more letters, two from 8. Shorter words must
+// match exactly, or every short word would match.
+func fuzzyWordMatch(word string, words []string) bool {
+	allowed := 0
+	switch n := len([]rune(word)); {
+	case n >= 8:
+		allowed = 2
+	case n >= 4:
+		allowed = 1
+	}
+	for _, candidate := range words {
+		if candidate == word || (allowed > 0 && editDistance(word, candidate) <= allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// editDistance counts the insertions, deletions, substitutions and swaps of
+// adjacent letters that turn a into b (optimal string alignment distance)
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
@@ -0,0 +1,35 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRankTitleMatches(t *testing.T) {
+	issues := []*Issue{
+		{ID: "bd-1", Title: "Fix the login   page", Status: StatusOpen},
+		{ID: "bd-2", Title: "Login page", Status: StatusClosed},
+		{ID: "bd-3", Title: "login page", Status: StatusOpen},
+		{ID: "bd-4", Title: "Login page redesign", Status: StatusOpen},
+		{ID: "bd-5", Title: "Page for login", Status: StatusOpen},
+		{ID: "bd-6", Title: "Logni pgae", Status: StatusOpen},
+		{ID: "bd-7", Title: "Logout button", Status: StatusOpen},
+	}
+
+	var ids []string
+	for _, issue := range RankTitleMatches("LOGIN  page", issues) {
+		ids = append(ids, issue.ID)
+	}
+	// Exact (unclosed first), prefix, substring, all words, then typos
+	if got := strings.Join(ids, " "); got != "bd-3 bd-2 bd-4 bd-1 bd-5 bd-6" {
+		t.Errorf("ranking = %s", got)
+	}
+
+	if got := RankTitleMatches("  ", issues); got != nil {
+		t.Errorf("expected no matches for a blank query, got %v", got)
+	}
+	// Short words need an exact match
+	if got := RankTitleMatches("lgo", issues); len(got) != 0 {
+		t.Errorf("expected no fuzzy match for a short word, got %v", got)
+	}
+}
@@ -0,0 +1,171 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PlanSubgraph collects rootID and everything it waits on: its parent-child
+// descendants and, transitively, the blockers of each (blocks edges). deps
+// maps each issue ID to its dependency records, as GetAllDependencyRecords
+// returns them. It returns the IDs found, rootID first, and for each ID the
+// IDs that must be done before it: its blockers and its children.
+func PlanSubgraph(rootID string, deps map[string][]*Dependency) ([]string, map[string][]string) {
+	children := make(map[string][]string)
+	for _, records := range deps {
+		for _, dep := range records {
+			if dep.Type == DepParentChild {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], dep.IssueID)
+			}
+		}
+	}
+
+	ids := []string{rootID}
+	seen := map[string]bool{rootID: true}
+	before := make(map[string][]string)
+	for i := 0; i < len(ids); i++ {
+		id := ids[i]
+		var prereqs []string
+		for _, dep := range deps[id] {
+			if dep.Type == DepBlocks {
+				prereqs = append(prereqs, dep.DependsOnID)
+			}
+		}
+		prereqs = append(prereqs, children[id]...)
+		for _, prereq := range prereqs {
+			if !seen[prereq] {
+				seen[prereq] = true
+				ids = append(ids, prereq)
+			}
+		}
+		before[id] = prereqs
+	}
+	return ids, before
+}
+
+// TopoWaves orders ids so that every ID comes after the IDs before lists for
+// it, grouped into waves: each wave holds the IDs whose prerequisites are all
+// in earlier waves, so a wave's issues can be worked on in parallel. IDs
+// within a wave are sorted. Prerequisites not in ids are ignored. If the
+// graph has a cycle, the error names it.
+func TopoWaves(ids []string, before map[string][]string) ([][]string, error) {
+	inSet := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		inSet[id] = true
+	}
+	remaining := make(map[string]int, len(ids))
+	after := make(map[string][]string)
+	for _, id := range ids {
+		for _, prereq := range uniqueStrings(before[id]) {
+			if inSet[prereq] && prereq != id {
+				remaining[id]++
+				after[prereq] = append(after[prereq], id)
+			} else if prereq == id {
+				return nil, fmt.Errorf("dependency cycle: %s → %s", id, id)
+			}
+		}
+	}
+
+	var waves [][]string
+	var wave []string
+	for _, id := range ids {
+		if remaining[id] == 0 {
+			wave = append(wave, id)
+		}
+	}
+	placed := 0
+	for len(wave) > 0 {
+		sort.Strings(wave)
+		waves = append(waves, wave)
+		placed += len(wave)
+		var next []string
+		for _, id := range wave {
+			for _, dependent := range after[id] {
+				remaining[dependent]--
+				if remaining[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		wave = next
+	}
+
+	if placed < len(ids) {
+		return nil, fmt.Errorf("dependency cycle: %s", strings.Join(findCycle(ids, before, remaining), " → "))
+	}
+	return waves, nil
+}
+
+// findCycle returns a cycle among the IDs TopoWaves couldn't place, written
+// so each ID waits on the next. Every unplaced ID waits on another unplaced
+// one, so following those edges must come back around.
+func findCycle(ids []string, before map[string][]string, remaining map[string]int) []string {
+	var start string
+	for _, id := range ids {
+		if remaining[id] > 0 {
+			start = id
+			break
+		}
+	}
+	index := make(map[string]int)
+	var path []string
+	for id := start; ; {
+		if i, ok := index[id]; ok {
+			return append(path[i:], id)
+		}
+		index[id] = len(path)
+		path = append(path, id)
+		prereqs := append([]string(nil), before[id]...)
+		sort.Strings(prereqs)
+		for _, prereq := range prereqs {
+			if remaining[prereq] > 0 {
+				id = prereq
+				break
+			}
+		}
+	}
+}
+
+// uniqueStrings returns values without repeats, keeping the first of each
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := values[:0:0]
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// PlanWaves layers the open issues among ids (from PlanSubgraph) into
+// TopoWaves, sorting each wave by priority, then ID. Closed issues are
+// already done and drop out of the plan; so do IDs missing from issues.
+func PlanWaves(ids []string, before map[string][]string, issues []*Issue) ([][]*Issue, error) {
+	byID := make(map[string]*Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	var open []string
+	for _, id := range ids {
+		if issue, ok := byID[id]; ok && issue.Status != StatusClosed {
+			open = append(open, id)
+		}
+	}
+	idWaves, err := TopoWaves(open, before)
+	if err != nil {
+		return nil, err
+	}
+	waves := make([][]*Issue, len(idWaves))
+	for i, wave := range idWaves {
+		for _, id := range wave {
+			waves[i] = append(waves[i], byID[id])
+		}
+		sort.SliceStable(waves[i], func(a, b int) bool {
+			return waves[i][a].Priority < waves[i][b].Priority
+		})
+	}
+	return waves, nil
+}
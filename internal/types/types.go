@@ -4,12 +4,19 @@ package types
 import (
 	"crypto/sha256"
 	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // Issue represents a trackable work item
 type Issue struct {
 	ID                 string         `json:"id"`
+	IDNonce            *int           `json:"id_nonce,omitempty"`     // Nonce the hash ID was derived from; nil for explicit, child and older IDs
 	ContentHash        string         `json:"content_hash,omitempty"` // SHA256 hash of canonical content (excludes ID, timestamps)
 	Title              string         `json:"title"`
 	Description        string         `json:"description"`
@@ -21,18 +28,25 @@ type Issue struct {
 	IssueType          IssueType      `json:"issue_type"`
 	Assignee           string         `json:"assignee,omitempty"`
 	EstimatedMinutes   *int           `json:"estimated_minutes,omitempty"`
+	SpentMinutes       *int           `json:"spent_minutes,omitempty"` // Time spent so far
 	CreatedAt          time.Time      `json:"created_at"`
 	UpdatedAt          time.Time      `json:"updated_at"`
 	ClosedAt           *time.Time     `json:"closed_at,omitempty"`
+	Resolution         Resolution     `json:"resolution,omitempty"` // Why a closed issue was closed
+	ResolvedBy         string         `json:"resolved_by,omitempty"` // SHA of the commit that resolved a closed issue
+	ArchivedAt         *time.Time     `json:"archived_at,omitempty"` // Set by bd archive; hides the issue from normal views
 	ExternalRef        *string        `json:"external_ref,omitempty"` // e.g., "gh-9", "jira-ABC"
 	CompactionLevel    int            `json:"compaction_level,omitempty"`
 	CompactedAt        *time.Time     `json:"compacted_at,omitempty"`
 	CompactedAtCommit  *string        `json:"compacted_at_commit,omitempty"` // Git commit hash when compacted
 	OriginalSize       int            `json:"original_size,omitempty"`
 	SourceRepo         string         `json:"source_repo,omitempty"` // Which repo owns this issue (multi-repo support)
+	Metadata           map[string]string `json:"metadata,omitempty"` // Custom fields such as sprint or customer
+	Attachments        []string       `json:"attachments,omitempty"` // URLs or repo-relative paths of related files
 	Labels             []string       `json:"labels,omitempty"` // Populated only for export/import
 	Dependencies       []*Dependency  `json:"dependencies,omitempty"` // Populated only for export/import
 	Comments           []*Comment     `json:"comments,omitempty"`     // Populated only for export/import
+	IDLength           int            `json:"-"`                      // Hash length to generate the ID with (bd create --id-length); not stored
 }
 
 // ComputeContentHash creates a deterministic hash of the issue's content.
@@ -64,6 +78,38 @@ func (i *Issue) ComputeContentHash() string {
 	if i.ExternalRef != nil {
 		h.Write([]byte(*i.ExternalRef))
 	}
+	// Only hashed when set, so issues without one keep their existing hashes
+	if i.Resolution != "" {
+		h.Write([]byte{0})
+		h.Write([]byte(i.Resolution))
+	}
+	if i.ResolvedBy != "" {
+		h.Write([]byte{0})
+		h.Write([]byte("resolved_by:" + i.ResolvedBy))
+	}
+	if i.SpentMinutes != nil {
+		h.Write([]byte{0})
+		h.Write([]byte(fmt.Sprintf("spent:%d", *i.SpentMinutes)))
+	}
+	if i.ArchivedAt != nil {
+		h.Write([]byte{0})
+		h.Write([]byte(fmt.Sprintf("archived:%s", i.ArchivedAt.UTC().Format(time.RFC3339))))
+	}
+	if len(i.Metadata) > 0 {
+		keys := make([]string, 0, len(i.Metadata))
+		for key := range i.Metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			h.Write([]byte{0})
+			h.Write([]byte(fmt.Sprintf("meta:%s=%s", key, i.Metadata[key])))
+		}
+	}
+	for _, ref := range i.Attachments {
+		h.Write([]byte{0})
+		h.Write([]byte("attachment:" + ref))
+	}
 	
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
@@ -85,9 +131,30 @@ func (i *Issue) Validate() error {
 	if !i.IssueType.IsValid() {
 		return fmt.Errorf("invalid issue type: %s", i.IssueType)
 	}
+	if !i.Resolution.IsValid() {
+		return fmt.Errorf("invalid resolution: %s (must be fixed, wontfix, duplicate or obsolete)", i.Resolution)
+	}
 	if i.EstimatedMinutes != nil && *i.EstimatedMinutes < 0 {
 		return fmt.Errorf("estimated_minutes cannot be negative")
 	}
+	if i.SpentMinutes != nil && *i.SpentMinutes < 0 {
+		return fmt.Errorf("spent_minutes cannot be negative")
+	}
+	for key := range i.Metadata {
+		if err := ValidateMetadataKey(key); err != nil {
+			return err
+		}
+	}
+	for _, ref := range i.Attachments {
+		if err := ValidateAttachment(ref); err != nil {
+			return err
+		}
+	}
+	if i.ResolvedBy != "" {
+		if err := ValidateCommitSHA(i.ResolvedBy); err != nil {
+			return err
+		}
+	}
 	// Enforce closed_at invariant: closed_at should be set if and only if status is closed
 	if i.Status == StatusClosed && i.ClosedAt == nil {
 		return fmt.Errorf("closed issues must have closed_at timestamp")
@@ -98,6 +165,117 @@ func (i *Issue) Validate() error {
 	return nil
 }
 
+// ReservedMetadataKeys are the issue field names bd uses itself. They can't
+// be metadata keys, so a custom field never shadows a built-in one in exports
+// or filters.
+var ReservedMetadataKeys = []string{
+	"id", "content_hash", "title", "description", "design", "acceptance_criteria",
+	"notes", "status", "priority", "issue_type", "assignee", "estimated_minutes",
+	"spent_minutes", "created_at", "updated_at", "closed_at", "resolution", "resolved_by", "archived_at",
+	"external_ref", "compaction_level", "compacted_at", "compacted_at_commit",
+	"original_size", "source_repo", "labels", "dependencies", "comments", "metadata",
+	"attachments",
+}
+
+// metadataKeyPattern limits metadata keys to identifier-like names
+var metadataKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]{0,63}$`)
+
+// ValidateMetadataKey checks that key can name a custom metadata field
+func ValidateMetadataKey(key string) error {
+	if !metadataKeyPattern.MatchString(key) {
+		return fmt.Errorf("invalid metadata key %q (must start with a letter and contain only letters, digits, '_', '-' or '.', up to 64 characters)", key)
+	}
+	for _, reserved := range ReservedMetadataKeys {
+		if key == reserved {
+			return fmt.Errorf("metadata key %q is reserved for the built-in %s field", key, key)
+		}
+	}
+	return nil
+}
+
+// MergeMetadata returns a copy of existing with set applied and the keys in
+// unset removed, or nil if nothing is left
+func MergeMetadata(existing map[string]string, set map[string]string, unset []string) map[string]string {
+	merged := make(map[string]string, len(existing)+len(set))
+	for key, value := range existing {
+		merged[key] = value
+	}
+	for key, value := range set {
+		merged[key] = value
+	}
+	for _, key := range unset {
+		delete(merged, key)
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return merged
+}
+
+// commitSHAPattern matches an abbreviated or full git commit SHA (SHA-1 or
+// SHA-256), lowercase
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-f]{7,64}$`)
+
+// ValidateCommitSHA checks that sha can be an issue's resolved_by commit
+func ValidateCommitSHA(sha string) error {
+	if !commitSHAPattern.MatchString(sha) {
+		return fmt.Errorf("invalid commit SHA %q (must be 7 to 64 lowercase hex digits)", sha)
+	}
+	return nil
+}
+
+// IsAttachmentURL reports whether an attachment reference is a URL, such as
+// https://example.com/spec.pdf, rather than a path within the repository.
+// A one-letter scheme is a Windows drive letter, not a URL.
+func IsAttachmentURL(ref string) bool {
+	u, err := url.Parse(ref)
+	return err == nil && len(u.Scheme) > 1 && (u.Host != "" || u.Opaque != "")
+}
+
+// ValidateAttachment checks that ref is a URL or a relative, forward-slash
+// path that stays within the repository. Absolute paths are rejected so the
+// shared JSONL never leaks a machine's directory layout.
+func ValidateAttachment(ref string) error {
+	if ref == "" || strings.TrimSpace(ref) != ref || strings.ContainsAny(ref, "\n\r") {
+		return fmt.Errorf("invalid attachment %q: must be a URL or a path without surrounding whitespace", ref)
+	}
+	if IsAttachmentURL(ref) {
+		if u, _ := url.Parse(ref); strings.EqualFold(u.Scheme, "file") {
+			return fmt.Errorf("invalid attachment %q: file URLs are absolute paths; use a path relative to the repository root", ref)
+		}
+		return nil
+	}
+	if strings.Contains(ref, `\`) {
+		return fmt.Errorf("invalid attachment %q: paths must use forward slashes", ref)
+	}
+	if path.IsAbs(ref) || (len(ref) >= 2 && ref[1] == ':') {
+		return fmt.Errorf("invalid attachment %q: paths must be relative to the repository root", ref)
+	}
+	if cleaned := path.Clean(ref); cleaned != ref || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return fmt.Errorf("invalid attachment %q: paths must be clean and stay within the repository", ref)
+	}
+	return nil
+}
+
+// MergeAttachments returns existing with the refs in add appended, unless
+// already present, and those in remove dropped, or nil if nothing is left
+func MergeAttachments(existing, add, remove []string) []string {
+	removed := make(map[string]bool, len(remove))
+	for _, ref := range remove {
+		removed[ref] = true
+	}
+	var merged []string
+	seen := make(map[string]bool, len(existing)+len(add))
+	for _, ref := range append(append([]string(nil), existing...), add...) {
+		if removed[ref] || seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		merged = append(merged, ref)
+	}
+	return merged
+}
+
 // Status represents the current state of an issue
 type Status string
 
@@ -118,6 +296,26 @@ func (s Status) IsValid() bool {
 	return false
 }
 
+// Resolution records why an issue was closed
+type Resolution string
+
+// Resolution constants
+const (
+	ResolutionFixed     Resolution = "fixed"
+	ResolutionWontFix   Resolution = "wontfix"
+	ResolutionDuplicate Resolution = "duplicate"
+	ResolutionObsolete  Resolution = "obsolete"
+)
+
+// IsValid checks if the resolution value is valid. Empty means unset.
+func (r Resolution) IsValid() bool {
+	switch r {
+	case "", ResolutionFixed, ResolutionWontFix, ResolutionDuplicate, ResolutionObsolete:
+		return true
+	}
+	return false
+}
+
 // IssueType categorizes the kind of work
 type IssueType string
 
@@ -196,11 +394,103 @@ type Label struct {
 
 // Comment represents a comment on an issue
 type Comment struct {
-	ID        int64     `json:"id"`
-	IssueID   string    `json:"issue_id"`
-	Author    string    `json:"author"`
-	Text      string    `json:"text"`
-	CreatedAt time.Time `json:"created_at"`
+	ID              int64      `json:"id"`
+	IssueID         string     `json:"issue_id"`
+	ParentCommentID *int64     `json:"parent_comment_id,omitempty"` // Set on replies; nil for thread roots
+	Author          string     `json:"author"`
+	Text            string     `json:"text"`
+	Resolved        bool       `json:"resolved,omitempty"` // Only meaningful on thread roots
+	CreatedAt       time.Time  `json:"created_at"`
+	OriginalText    string     `json:"original_text,omitempty"` // Text as first written; set once the comment is edited
+	EditedAt        *time.Time `json:"edited_at,omitempty"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"` // Tombstone: deleted comments stay so sync doesn't bring them back
+}
+
+// FirstText returns the comment's text as first written, before any edits.
+// It identifies the comment across databases, where IDs differ.
+func (c *Comment) FirstText() string {
+	if c.OriginalText != "" {
+		return c.OriginalText
+	}
+	return c.Text
+}
+
+// IsDeleted reports whether the comment has been deleted
+func (c *Comment) IsDeleted() bool {
+	return c.DeletedAt != nil
+}
+
+// DefaultLockTTL is how long an advisory lock lasts when no TTL is given and
+// the lock.ttl config key is unset
+const DefaultLockTTL = 30 * time.Minute
+
+// LockTTLConfigKey is the config key holding the default lock TTL (a Go duration)
+const LockTTLConfigKey = "lock.ttl"
+
+// ParseLockTTL parses a configured lock TTL, returning DefaultLockTTL if value is empty
+func ParseLockTTL(value string) (time.Duration, error) {
+	if value == "" {
+		return DefaultLockTTL, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a positive duration like 30m or 2h", LockTTLConfigKey, value)
+	}
+	return ttl, nil
+}
+
+// IssueLock represents an advisory lock held on an issue
+type IssueLock struct {
+	IssueID    string    `json:"issue_id"`
+	Holder     string    `json:"holder"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// PriorityPropagationConfigKey is the config key selecting how adding a
+// blocking dependency adjusts the dependent's priority
+const PriorityPropagationConfigKey = "priority_propagation"
+
+// Priority propagation modes
+const (
+	PriorityPropagationOff     = "off"     // Default: priorities are left alone
+	PriorityPropagationBump    = "bump"    // Move the dependent one level toward the blocker
+	PriorityPropagationInherit = "inherit" // Raise the dependent to the blocker's priority
+)
+
+// PriorityPropagationWeight returns how many priority levels a configured
+// propagation mode moves a dependent, treating an empty value as off
+func PriorityPropagationWeight(mode string) (int, error) {
+	switch mode {
+	case "", PriorityPropagationOff:
+		return 0, nil
+	case PriorityPropagationBump:
+		return 1, nil
+	case PriorityPropagationInherit:
+		return 4, nil // Spans the whole P0-P4 range
+	default:
+		return 0, fmt.Errorf("invalid %s %q: must be off, bump or inherit", PriorityPropagationConfigKey, mode)
+	}
+}
+
+// PropagatedPriority returns the dependent's priority after moving it up to
+// weight levels toward a more urgent blocker. It never lowers priority.
+func PropagatedPriority(dependent, blocker, weight int) int {
+	if blocker >= dependent || weight <= 0 {
+		return dependent
+	}
+	if dependent-blocker <= weight {
+		return blocker
+	}
+	return dependent - weight
+}
+
+// PriorityChange records a priority adjusted by propagation from a blocker
+type PriorityChange struct {
+	IssueID     string `json:"issue_id"`
+	BlockerID   string `json:"blocker_id"`
+	OldPriority int    `json:"old_priority"`
+	NewPriority int    `json:"new_priority"`
 }
 
 // Event represents an audit trail entry
@@ -212,6 +502,7 @@ type Event struct {
 	OldValue  *string    `json:"old_value,omitempty"`
 	NewValue  *string    `json:"new_value,omitempty"`
 	Comment   *string    `json:"comment,omitempty"`
+	Note      *string    `json:"note,omitempty"` // Status-change note from close/reopen --note
 	CreatedAt time.Time  `json:"created_at"`
 }
 
@@ -231,13 +522,71 @@ const (
 	EventLabelAdded        EventType = "label_added"
 	EventLabelRemoved      EventType = "label_removed"
 	EventCompacted         EventType = "compacted"
+	EventPriorityChanged   EventType = "priority_changed"
+	EventUndone            EventType = "undone" // bd undo reverted an earlier event
+	EventMerged            EventType = "merged" // A duplicate was folded into another issue
+	EventCommentEdited     EventType = "comment_edited"
+	EventCommentDeleted    EventType = "comment_deleted"
+	EventFieldsCleared     EventType = "fields_cleared" // Optional fields were emptied (bd update --clear)
 )
 
+// IsStatusEvent reports whether an event records a change to an issue's
+// status. Event pruning always keeps the newest one per issue.
+func (t EventType) IsStatusEvent() bool {
+	switch t {
+	case EventCreated, EventStatusChanged, EventClosed, EventReopened:
+		return true
+	}
+	return false
+}
+
+// EventRetentionConfigKey is the config key holding how many days of events
+// the daemon keeps before auto-pruning older ones (empty or 0 keeps everything)
+const EventRetentionConfigKey = "events.retention_days"
+
+// EventKeepPerIssueConfigKey is the config key holding how many of each
+// issue's most recent events auto-pruning always keeps
+const EventKeepPerIssueConfigKey = "events.keep_per_issue"
+
+// ParseEventRetention parses a configured event retention in days, returning 0
+// (keep everything) if value is empty
+func ParseEventRetention(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a number of days (0 disables pruning)", EventRetentionConfigKey, value)
+	}
+	return days, nil
+}
+
+// ParseEventKeepPerIssue parses a configured per-issue event minimum,
+// returning 0 if value is empty
+func ParseEventKeepPerIssue(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be a non-negative number", EventKeepPerIssueConfigKey, value)
+	}
+	return n, nil
+}
+
 // BlockedIssue extends Issue with blocking information
 type BlockedIssue struct {
 	Issue
-	BlockedByCount int      `json:"blocked_by_count"`
-	BlockedBy      []string `json:"blocked_by"`
+	BlockedByCount int        `json:"blocked_by_count"`
+	BlockedBy      []string   `json:"blocked_by"`
+	Blockers       []*Blocker `json:"blockers"` // Same issues as BlockedBy, with title and status
+}
+
+// Blocker is an unclosed issue that a blocked issue is waiting on
+type Blocker struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status Status `json:"status"`
 }
 
 // TreeNode represents a node in a dependency tree
@@ -262,15 +611,18 @@ type Statistics struct {
 
 // IssueFilter is used to filter issue queries
 type IssueFilter struct {
-	Status      *Status
-	Priority    *int
-	IssueType   *IssueType
-	Assignee    *string
-	Labels      []string  // AND semantics: issue must have ALL these labels
-	LabelsAny   []string  // OR semantics: issue must have AT LEAST ONE of these labels
-	TitleSearch string
-	IDs         []string  // Filter by specific issue IDs
-	Limit       int
+	Status        *Status
+	ExcludeStatus []Status // Exclude issues with any of these statuses
+	Priority      *int
+	IssueType     *IssueType
+	Assignee      *string  // An empty assignee matches unassigned issues
+	Labels        []string // AND semantics: issue must have ALL these labels
+	LabelsAny     []string // OR semantics: issue must have AT LEAST ONE of these labels
+	TitleSearch   string
+	IDs           []string // Filter by specific issue IDs
+	Limit         int
+	Offset        int       // Skip this many matches; with Limit, pages through results
+	SortBy        []SortKey // Result order; empty keeps the default (priority, newest first)
 	
 	// Pattern matching
 	TitleContains       string
@@ -290,11 +642,99 @@ type IssueFilter struct {
 	NoAssignee       bool
 	NoLabels         bool
 	
+	// Dependency state, as bd ready and bd blocked see it: Ready matches open
+	// and in-progress issues with no open blocker, directly or through a
+	// parent; Blocked matches unclosed issues with at least one open blocker.
+	// They are mutually exclusive.
+	Ready   bool
+	Blocked bool
+	
+	// Metadata matches issues having every key set to exactly the given value
+	Metadata map[string]string
+	
+	// Archived issues are left out unless IncludeArchived is set or they're
+	// named in IDs
+	IncludeArchived bool
+	
 	// Numeric ranges
 	PriorityMin *int
 	PriorityMax *int
 }
 
+// SortField names an issue field that search results can be ordered by
+type SortField string
+
+// Sort field constants
+const (
+	SortFieldPriority  SortField = "priority"
+	SortFieldCreatedAt SortField = "created_at"
+	SortFieldUpdatedAt SortField = "updated_at"
+	SortFieldStatus    SortField = "status" // Workflow order: open, in_progress, blocked, closed
+	SortFieldID        SortField = "id"
+)
+
+// sortFieldAliases maps the names accepted by ParseSortKeys to fields
+var sortFieldAliases = map[string]SortField{
+	"priority":   SortFieldPriority,
+	"created":    SortFieldCreatedAt,
+	"created_at": SortFieldCreatedAt,
+	"updated":    SortFieldUpdatedAt,
+	"updated_at": SortFieldUpdatedAt,
+	"status":     SortFieldStatus,
+	"id":         SortFieldID,
+}
+
+// IsValid checks if the sort field is one results can be ordered by
+func (f SortField) IsValid() bool {
+	switch f {
+	case SortFieldPriority, SortFieldCreatedAt, SortFieldUpdatedAt, SortFieldStatus, SortFieldID:
+		return true
+	}
+	return false
+}
+
+// SortKey orders search results by one field
+type SortKey struct {
+	Field      SortField
+	Descending bool
+}
+
+// String formats the key as ParseSortKeys accepts it, e.g. "priority:asc"
+func (k SortKey) String() string {
+	if k.Descending {
+		return string(k.Field) + ":desc"
+	}
+	return string(k.Field) + ":asc"
+}
+
+// ParseSortKeys parses a comma-separated list of field[:asc|desc] keys, e.g.
+// "priority:asc,created:desc". Direction defaults to ascending; "created"
+// and "updated" are short for created_at and updated_at.
+func ParseSortKeys(spec string) ([]SortKey, error) {
+	var keys []SortKey
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, direction, _ := strings.Cut(part, ":")
+		field, ok := sortFieldAliases[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown sort field %q (valid: priority, created, updated, status, id)", name)
+		}
+		key := SortKey{Field: field}
+		switch strings.ToLower(strings.TrimSpace(direction)) {
+		case "", "asc":
+		case "desc":
+			key.Descending = true
+		default:
+			return nil, fmt.Errorf("invalid sort direction %q for %s (use asc or desc)", direction, name)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
 // SortPolicy determines how ready work is ordered
 type SortPolicy string
 
@@ -325,20 +765,23 @@ func (s SortPolicy) IsValid() bool {
 
 // WorkFilter is used to filter ready work queries
 type WorkFilter struct {
-	Status     Status
-	Priority   *int
-	Assignee   *string
-	Labels     []string   // AND semantics: issue must have ALL these labels
-	LabelsAny  []string   // OR semantics: issue must have AT LEAST ONE of these labels
-	Limit      int
-	SortPolicy SortPolicy
+	Status      Status
+	IssueType   IssueType  // Empty = all types
+	Priority    *int
+	MinPriority *int       // Exclude issues less important than this (priority value > MinPriority)
+	Assignee    *string    // An empty assignee matches unassigned issues
+	Labels      []string   // AND semantics: issue must have ALL these labels
+	LabelsAny   []string   // OR semantics: issue must have AT LEAST ONE of these labels
+	Limit       int
+	SortPolicy  SortPolicy
 }
 
 // StaleFilter is used to filter stale issue queries
 type StaleFilter struct {
-	Days   int    // Issues not updated in this many days
-	Status string // Filter by status (open|in_progress|blocked), empty = all non-closed
-	Limit  int    // Maximum issues to return
+	Days      int           // Issues not updated in this many days
+	OlderThan time.Duration // Issues not updated in this long; overrides Days when set
+	Status    string        // Filter by status (open|in_progress|blocked), empty = all non-closed
+	Limit     int           // Maximum issues to return
 }
 
 // EpicStatus represents an epic with its completion status
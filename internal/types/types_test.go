@@ -1,6 +1,7 @@
 package types
 
 import (
+	"fmt"
 	"testing"
 	"time"
 )
@@ -215,6 +216,110 @@ func TestStatusIsValid(t *testing.T) {
 	}
 }
 
+func TestResolutionIsValid(t *testing.T) {
+	tests := []struct {
+		resolution Resolution
+		valid      bool
+	}{
+		{ResolutionFixed, true},
+		{ResolutionWontFix, true},
+		{ResolutionDuplicate, true},
+		{ResolutionObsolete, true},
+		{Resolution(""), true},
+		{Resolution("invalid"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.resolution), func(t *testing.T) {
+			if got := tt.resolution.IsValid(); got != tt.valid {
+				t.Errorf("Resolution(%q).IsValid() = %v, want %v", tt.resolution, got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestContentHashResolution(t *testing.T) {
+	issue := Issue{Title: "Test", Status: StatusClosed, Priority: 2, IssueType: TypeTask}
+	before := issue.ComputeContentHash()
+	issue.Resolution = ResolutionFixed
+	if issue.ComputeContentHash() == before {
+		t.Error("Expected resolution to change the content hash")
+	}
+	issue.Resolution = ""
+	if issue.ComputeContentHash() != before {
+		t.Error("Expected an unset resolution to leave the content hash unchanged")
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	for key, valid := range map[string]bool{
+		"sprint": true, "customer.tier": true, "jira-epic": true,
+		"": false, "1st": false, "has space": false, `quote"d`: false,
+		"resolution": false, "external_ref": false,
+	} {
+		if err := ValidateMetadataKey(key); (err == nil) != valid {
+			t.Errorf("ValidateMetadataKey(%q) error = %v, want valid=%v", key, err, valid)
+		}
+	}
+
+	existing := map[string]string{"sprint": "42", "customer": "acme"}
+	merged := MergeMetadata(existing, map[string]string{"sprint": "43"}, []string{"customer"})
+	if len(merged) != 1 || merged["sprint"] != "43" {
+		t.Errorf("MergeMetadata = %v, want only sprint=43", merged)
+	}
+	if existing["sprint"] != "42" {
+		t.Error("MergeMetadata modified the existing map")
+	}
+	if got := MergeMetadata(existing, nil, []string{"sprint", "customer"}); got != nil {
+		t.Errorf("MergeMetadata removing every key = %v, want nil", got)
+	}
+
+	issue := Issue{Title: "Test", Status: StatusOpen, Priority: 2, IssueType: TypeTask}
+	before := issue.ComputeContentHash()
+	issue.Metadata = map[string]string{"sprint": "42"}
+	if issue.ComputeContentHash() == before {
+		t.Error("Expected metadata to change the content hash")
+	}
+	issue.Metadata["priority"] = "high"
+	if err := issue.Validate(); err == nil {
+		t.Error("Expected Validate to reject a reserved metadata key")
+	}
+}
+
+func TestAttachments(t *testing.T) {
+	for ref, valid := range map[string]bool{
+		"https://example.com/spec.pdf": true, "mailto:team@example.com": true,
+		"docs/design.png": true, "notes/a b.md": true, "README.md": true,
+		"": false, " docs/a.md": false, "/etc/passwd": false, "C:/Users/me/a.png": false,
+		`docs\a.png`: false, "../outside.md": false, "docs/../../x": false, "./docs/a.md": false,
+		"file:///home/me/a.png": false, ".": false,
+	} {
+		if err := ValidateAttachment(ref); (err == nil) != valid {
+			t.Errorf("ValidateAttachment(%q) error = %v, want valid=%v", ref, err, valid)
+		}
+	}
+
+	existing := []string{"a.md", "b.md"}
+	merged := MergeAttachments(existing, []string{"c.md", "a.md"}, []string{"b.md"})
+	if len(merged) != 2 || merged[0] != "a.md" || merged[1] != "c.md" {
+		t.Errorf("MergeAttachments = %v, want [a.md c.md]", merged)
+	}
+	if got := MergeAttachments(existing, nil, existing); got != nil {
+		t.Errorf("MergeAttachments removing everything = %v, want nil", got)
+	}
+
+	issue := Issue{Title: "Test", Status: StatusOpen, Priority: 2, IssueType: TypeTask}
+	before := issue.ComputeContentHash()
+	issue.Attachments = []string{"docs/a.md"}
+	if issue.ComputeContentHash() == before {
+		t.Error("Expected attachments to change the content hash")
+	}
+	issue.Attachments = append(issue.Attachments, "/tmp/secret.txt")
+	if err := issue.Validate(); err == nil {
+		t.Error("Expected Validate to reject an absolute attachment path")
+	}
+}
+
 func TestIssueTypeIsValid(t *testing.T) {
 	tests := []struct {
 		issueType IssueType
@@ -332,6 +437,68 @@ func TestTreeNodeEmbedding(t *testing.T) {
 	}
 }
 
+func TestPropagatedPriority(t *testing.T) {
+	tests := []struct {
+		name      string
+		dependent int
+		blocker   int
+		weight    int
+		want      int
+	}{
+		{"bump one level", 3, 0, 1, 2},
+		{"inherit", 3, 0, 4, 0},
+		{"weight reaches blocker", 3, 2, 2, 2},
+		{"blocker less urgent", 1, 3, 4, 1},
+		{"equal priority", 2, 2, 4, 2},
+		{"zero weight", 4, 0, 0, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PropagatedPriority(tt.dependent, tt.blocker, tt.weight); got != tt.want {
+				t.Errorf("PropagatedPriority(%d, %d, %d) = %d, want %d", tt.dependent, tt.blocker, tt.weight, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPriorityPropagationWeight(t *testing.T) {
+	for mode, want := range map[string]int{"": 0, "off": 0, "bump": 1, "inherit": 4} {
+		got, err := PriorityPropagationWeight(mode)
+		if err != nil {
+			t.Errorf("PriorityPropagationWeight(%q) failed: %v", mode, err)
+		}
+		if got != want {
+			t.Errorf("PriorityPropagationWeight(%q) = %d, want %d", mode, got, want)
+		}
+	}
+	if _, err := PriorityPropagationWeight("always"); err == nil {
+		t.Error("Expected error for invalid mode")
+	}
+}
+
+func TestParseSortKeys(t *testing.T) {
+	keys, err := ParseSortKeys(" priority:asc, created:DESC,status,id:desc ")
+	if err != nil {
+		t.Fatalf("ParseSortKeys failed: %v", err)
+	}
+	var got []string
+	for _, key := range keys {
+		got = append(got, key.String())
+	}
+	if want := "[priority:asc created_at:desc status:asc id:desc]"; fmt.Sprint(got) != want {
+		t.Errorf("ParseSortKeys = %v, want %v", got, want)
+	}
+
+	if keys, err := ParseSortKeys(""); err != nil || len(keys) != 0 {
+		t.Errorf("expected no keys for an empty spec, got %v (err %v)", keys, err)
+	}
+	for _, spec := range []string{"title", "priority:up", "created,bogus:asc"} {
+		if _, err := ParseSortKeys(spec); err == nil {
+			t.Errorf("expected an error for %q", spec)
+		}
+	}
+}
+
 // Helper functions
 
 func intPtr(i int) *int {
@@ -2,6 +2,12 @@ package util
 
 import "strings"
 
+// NormalizeLabel trims whitespace from a label and lowercases it, so that
+// "Bug" and "bug " are the same label
+func NormalizeLabel(label string) string {
+	return strings.ToLower(strings.TrimSpace(label))
+}
+
 // NormalizeLabels trims whitespace, removes empty strings, and deduplicates labels
 // while preserving order.
 func NormalizeLabels(ss []string) []string {
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DisplayHidePrefixConfigKey turns on showing issue IDs without the
+// issue_prefix in human-readable output, e.g. "a3f8" for "bd-a3f8"
+const DisplayHidePrefixConfigKey = "display_hide_prefix"
+
+// ParseDisplayHidePrefix parses display_hide_prefix, defaulting to false
+func ParseDisplayHidePrefix(value string) (bool, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return false, nil
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: must be true or false", DisplayHidePrefixConfigKey, value)
+	}
+	return enabled, nil
+}
+
+// DisplayID shortens id for display by dropping prefix and its hyphen.
+// IDs under another prefix, or that would be left empty, come back whole.
+func DisplayID(id, prefix string) string {
+	prefix = strings.TrimRight(prefix, "-")
+	if prefix == "" {
+		return id
+	}
+	if short, ok := strings.CutPrefix(id, prefix+"-"); ok && short != "" {
+		return short
+	}
+	return id
+}
@@ -0,0 +1,35 @@
+package utils
+
+import "testing"
+
+func TestDisplayID(t *testing.T) {
+	tests := []struct {
+		id     string
+		prefix string
+		want   string
+	}{
+		{"bd-a3f8", "bd", "a3f8"},
+		{"bd-a3f8.1.2", "bd-", "a3f8.1.2"},
+		{"epic-a3f8", "bd", "epic-a3f8"},
+		{"bdx-a3f8", "bd", "bdx-a3f8"},
+		{"bd-", "bd", "bd-"},
+		{"bd-a3f8", "", "bd-a3f8"},
+	}
+	for _, tt := range tests {
+		if got := DisplayID(tt.id, tt.prefix); got != tt.want {
+			t.Errorf("DisplayID(%q, %q) = %q, want %q", tt.id, tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestParseDisplayHidePrefix(t *testing.T) {
+	for value, want := range map[string]bool{"": false, "true": true, " false ": false, "1": true} {
+		got, err := ParseDisplayHidePrefix(value)
+		if err != nil || got != want {
+			t.Errorf("ParseDisplayHidePrefix(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	if _, err := ParseDisplayHidePrefix("yes please"); err == nil {
+		t.Error("ParseDisplayHidePrefix accepted an invalid value")
+	}
+}
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DuplicateCheckConfigKey turns off the similar-title check bd create runs
+// against open issues when set to false
+const DuplicateCheckConfigKey = "duplicate_check"
+
+// similarTitleThreshold is the share of distinct words two titles must have
+// in common to count as similar
+const similarTitleThreshold = 0.75
+
+// ParseDuplicateCheck parses a duplicate_check value; empty means enabled
+func ParseDuplicateCheck(value string) (bool, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return true, nil
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: must be true or false", DuplicateCheckConfigKey, value)
+	}
+	return enabled, nil
+}
+
+// titleWords splits a title into its lowercased words, ignoring punctuation
+func titleWords(title string) []string {
+	return strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// SimilarTitles reports whether two titles are the same ignoring case and
+// punctuation, or share at least 75% of their distinct words
+func SimilarTitles(a, b string) bool {
+	wordsA, wordsB := titleWords(a), titleWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return false
+	}
+	if strings.Join(wordsA, " ") == strings.Join(wordsB, " ") {
+		return true
+	}
+
+	setA := make(map[string]bool, len(wordsA))
+	for _, w := range wordsA {
+		setA[w] = true
+	}
+	setB := make(map[string]bool, len(wordsB))
+	for _, w := range wordsB {
+		setB[w] = true
+	}
+	shared := 0
+	for w := range setA {
+		if setB[w] {
+			shared++
+		}
+	}
+	union := len(setA) + len(setB) - shared
+	return float64(shared)/float64(union) >= similarTitleThreshold
+}
+
+// FindSimilarOpenIssues returns the issues that aren't closed and have a
+// title similar to title, or nil when duplicate_check is off
+func FindSimilarOpenIssues(ctx context.Context, s storage.Storage, title string) ([]*types.Issue, error) {
+	value, err := s.GetConfig(ctx, DuplicateCheckConfigKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", DuplicateCheckConfigKey, err)
+	}
+	// An invalid stored value leaves the check on
+	if enabled, err := ParseDuplicateCheck(value); err == nil && !enabled {
+		return nil, nil
+	}
+
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{ExcludeStatus: []types.Status{types.StatusClosed}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+	var similar []*types.Issue
+	for _, issue := range issues {
+		if SimilarTitles(issue.Title, title) {
+			similar = append(similar, issue)
+		}
+	}
+	return similar, nil
+}
+
+// IssueIDs returns the IDs of issues, in order
+func IssueIDs(issues []*types.Issue) []string {
+	ids := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	return ids
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/memory"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSimilarTitles(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"Fix login bug", "fix login bug", true},
+		{"Fix: login bug!", "fix login-bug", true},
+		{"Add retry to sync push", "Add retry to the sync push", true},
+		{"Fix login bug", "Fix logout bug", false},
+		{"Add tests", "Add docs", false},
+		{"", "", false},
+		{"...", "!!!", false},
+	}
+	for _, tt := range tests {
+		if got := SimilarTitles(tt.a, tt.b); got != tt.want {
+			t.Errorf("SimilarTitles(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestParseDuplicateCheck(t *testing.T) {
+	for value, want := range map[string]bool{"": true, "true": true, " false ": false, "0": false} {
+		got, err := ParseDuplicateCheck(value)
+		if err != nil || got != want {
+			t.Errorf("ParseDuplicateCheck(%q) = %v, %v; want %v", value, got, err, want)
+		}
+	}
+	if _, err := ParseDuplicateCheck("sometimes"); err == nil {
+		t.Error("ParseDuplicateCheck(sometimes) = nil error, want an error")
+	}
+}
+
+func TestFindSimilarOpenIssues(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New("")
+	closedAt := time.Now()
+	for _, issue := range []*types.Issue{
+		{ID: "bd-1", Title: "Fix login bug", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug},
+		{ID: "bd-2", Title: "fix the login bug", Status: types.StatusInProgress, Priority: 2, IssueType: types.TypeBug},
+		{ID: "bd-3", Title: "Fix login bug", Status: types.StatusClosed, Priority: 2, IssueType: types.TypeBug, ClosedAt: &closedAt},
+		{ID: "bd-4", Title: "Write release notes", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	} {
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatalf("CreateIssue failed: %v", err)
+		}
+	}
+
+	similar, err := FindSimilarOpenIssues(ctx, store, "Fix login bug")
+	if err != nil {
+		t.Fatalf("FindSimilarOpenIssues failed: %v", err)
+	}
+	ids := IssueIDs(similar)
+	if len(ids) == 2 && ids[0] > ids[1] {
+		ids[0], ids[1] = ids[1], ids[0]
+	}
+	if want := []string{"bd-1", "bd-2"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("FindSimilarOpenIssues = %v, want %v", ids, want)
+	}
+
+	if err := store.SetConfig(ctx, DuplicateCheckConfigKey, "false"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	similar, err = FindSimilarOpenIssues(ctx, store, "Fix login bug")
+	if err != nil {
+		t.Fatalf("FindSimilarOpenIssues failed: %v", err)
+	}
+	if len(similar) != 0 {
+		t.Errorf("FindSimilarOpenIssues with %s=false = %v, want none", DuplicateCheckConfigKey, IssueIDs(similar))
+	}
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// CompareIssueIDs orders issue IDs so children follow their parent in
+// numeric order: bd-a3f8, bd-a3f8.1, bd-a3f8.1.1, bd-a3f8.2, bd-a3f8.10.
+// The part before the first dot compares as a plain string, so top-level
+// IDs keep their existing byte order. Returns -1, 0 or 1.
+func CompareIssueIDs(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+		if i > 0 {
+			an, aErr := strconv.Atoi(as[i])
+			bn, bErr := strconv.Atoi(bs[i])
+			if aErr == nil && bErr == nil && an != bn {
+				if an < bn {
+					return -1
+				}
+				return 1
+			}
+		}
+		return strings.Compare(as[i], bs[i])
+	}
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	}
+	return 0
+}
+
+// SortIssuesForExport puts issues and their attached dependencies, labels and
+// comments in a canonical order, so exporting the same data always produces
+// byte-identical JSONL regardless of the order storage returned it in. Issues
+// sort by CompareIssueIDs (children right after their parent), dependencies
+// by target and then type, labels alphabetically and comments by creation
+// time and then ID. Every JSONL writer calls it just before encoding.
+func SortIssuesForExport(issues []*types.Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		return CompareIssueIDs(issues[i].ID, issues[j].ID) < 0
+	})
+	for _, issue := range issues {
+		deps := issue.Dependencies
+		sort.SliceStable(deps, func(i, j int) bool {
+			if deps[i].DependsOnID != deps[j].DependsOnID {
+				return CompareIssueIDs(deps[i].DependsOnID, deps[j].DependsOnID) < 0
+			}
+			return deps[i].Type < deps[j].Type
+		})
+		sort.Strings(issue.Labels)
+		comments := issue.Comments
+		sort.SliceStable(comments, func(i, j int) bool {
+			if !comments[i].CreatedAt.Equal(comments[j].CreatedAt) {
+				return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+			}
+			return comments[i].ID < comments[j].ID
+		})
+	}
+}
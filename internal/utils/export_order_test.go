@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCompareIssueIDs(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"bd-a3f8", "bd-a3f8", 0},
+		{"bd-a3f8", "bd-a3f8.1", -1},
+		{"bd-a3f8.2", "bd-a3f8.10", -1},
+		{"bd-a3f8.1.5", "bd-a3f8.2", -1},
+		{"bd-a3f8.10", "bd-a3f9", -1},
+		{"bd-10", "bd-2", -1}, // top-level IDs keep byte order
+		{"bd-a3f8.x", "bd-a3f8.1", 1},
+	}
+	for _, tt := range tests {
+		if got := CompareIssueIDs(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareIssueIDs(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := CompareIssueIDs(tt.b, tt.a); got != -tt.want {
+			t.Errorf("CompareIssueIDs(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+func TestSortIssuesForExport(t *testing.T) {
+	now := time.Now()
+	issues := []*types.Issue{
+		{ID: "bd-a1.10"},
+		{
+			ID:     "bd-a1",
+			Labels: []string{"zeta", "alpha"},
+			Dependencies: []*types.Dependency{
+				{IssueID: "bd-a1", DependsOnID: "bd-c3", Type: types.DepRelated},
+				{IssueID: "bd-a1", DependsOnID: "bd-b2", Type: types.DepRelated},
+				{IssueID: "bd-a1", DependsOnID: "bd-b2", Type: types.DepBlocks},
+			},
+			Comments: []*types.Comment{
+				{ID: 3, CreatedAt: now.Add(time.Minute)},
+				{ID: 2, CreatedAt: now},
+				{ID: 1, CreatedAt: now},
+			},
+		},
+		{ID: "bd-a1.2"},
+	}
+	SortIssuesForExport(issues)
+
+	var ids []string
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	if got := strings.Join(ids, ","); got != "bd-a1,bd-a1.2,bd-a1.10" {
+		t.Errorf("issue order = %s", got)
+	}
+
+	parent := issues[0]
+	if got := strings.Join(parent.Labels, ","); got != "alpha,zeta" {
+		t.Errorf("label order = %s", got)
+	}
+	var deps []string
+	for _, dep := range parent.Dependencies {
+		deps = append(deps, dep.DependsOnID+"/"+string(dep.Type))
+	}
+	if got := strings.Join(deps, ","); got != "bd-b2/blocks,bd-b2/related,bd-c3/related" {
+		t.Errorf("dependency order = %s", got)
+	}
+	if c := parent.Comments; c[0].ID != 1 || c[1].ID != 2 || c[2].ID != 3 {
+		t.Errorf("comment order = %d,%d,%d, want 1,2,3", c[0].ID, c[1].ID, c[2].ID)
+	}
+}
@@ -4,6 +4,8 @@ package utils
 import (
 	"context"
 	"fmt"
+	"os"
+	"sort"
 	"strings"
 
 	"github.com/steveyegge/beads/internal/storage"
@@ -26,82 +28,240 @@ func ParseIssueID(input string, prefix string) string {
 	return prefix + input
 }
 
+// GetIDPrefixes returns the configured issue_prefix (default "bd") and any
+// prefix_by_type prefixes, without trailing hyphens. BEADS_ISSUE_PREFIX
+// replaces the stored issue_prefix, as when creating issues. An invalid
+// prefix_by_type is ignored here; creating an issue reports it.
+func GetIDPrefixes(ctx context.Context, store storage.Storage) types.IDPrefixes {
+	prefixes := types.IDPrefixes{Default: "bd"}
+	if prefix := os.Getenv(types.IssuePrefixEnvVar); prefix != "" {
+		prefixes.Default = prefix
+	} else if prefix, err := store.GetConfig(ctx, "issue_prefix"); err == nil && strings.TrimRight(prefix, "-") != "" {
+		prefixes.Default = strings.TrimRight(prefix, "-")
+	}
+	if byType, err := store.GetConfig(ctx, types.PrefixByTypeConfigKey); err == nil {
+		prefixes.ByType, _ = types.ParsePrefixByType(byType)
+	}
+	return prefixes
+}
+
+// AmbiguousIDError is returned by ResolvePartialID when an input matches
+// more than one issue. Candidates are sorted by ID, or best match first for
+// a title.
+type AmbiguousIDError struct {
+	Input      string         `json:"input"`
+	Candidates []*types.Issue `json:"candidates"`
+	// ExactMatch is set when the input is a complete hash that exists under
+	// several prefix_by_type prefixes, rather than a prefix of several hashes
+	ExactMatch bool `json:"exact_match,omitempty"`
+	// ByTitle is set when the input was matched against titles
+	ByTitle bool `json:"by_title,omitempty"`
+	// Total counts all the matches when Candidates holds only the best ones
+	Total int `json:"total,omitempty"`
+}
+
+func (e *AmbiguousIDError) Error() string {
+	var b strings.Builder
+	if e.ByTitle {
+		if e.Total > len(e.Candidates) {
+			fmt.Fprintf(&b, "title %q matches %d issues, best %d:\n", e.Input, e.Total, len(e.Candidates))
+		} else {
+			fmt.Fprintf(&b, "title %q matches %d issues:\n", e.Input, len(e.Candidates))
+		}
+	} else {
+		fmt.Fprintf(&b, "ambiguous ID %q matches %d issues:\n", e.Input, len(e.Candidates))
+	}
+	width := 0
+	for _, issue := range e.Candidates {
+		if len(issue.ID) > width {
+			width = len(issue.ID)
+		}
+	}
+	for _, issue := range e.Candidates {
+		fmt.Fprintf(&b, "  %-*s  %s\n", width, issue.ID, issue.Title)
+	}
+	switch {
+	case e.ByTitle:
+		b.WriteString("Use one of the IDs or a more specific title")
+	case e.ExactMatch:
+		b.WriteString("Use the full ID with its prefix")
+	default:
+		b.WriteString("Use more characters to disambiguate")
+	}
+	return b.String()
+}
+
+// ErrorCode classifies the error as types.ErrCodeAmbiguous
+func (e *AmbiguousIDError) ErrorCode() string { return types.ErrCodeAmbiguous }
+
+func newAmbiguousIDError(input string, candidates []*types.Issue, exact bool) *AmbiguousIDError {
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+	return &AmbiguousIDError{Input: input, Candidates: candidates, ExactMatch: exact}
+}
+
 // ResolvePartialID resolves a potentially partial issue ID to a full ID.
 // Supports:
 // - Full IDs: "bd-a3f8e9" or "a3f8e9" → "bd-a3f8e9"
 // - Without hyphen: "bda3f8e9" or "wya3f8e9" → "bd-a3f8e9"
 // - Partial IDs: "a3f8" → "bd-a3f8e9" (if unique match)
 // - Hierarchical: "a3f8e9.1" → "bd-a3f8e9.1"
+// - Partial hierarchical: "a3f8.2" → "bd-a3f8e9.2"; the hash before the
+//   first dot matches as a partial ID would and the child path must match
+//   exactly, so a bare "a3f8" never matches the epic's children
+// - Per-type prefixes (prefix_by_type): "epic-a3f8" matches only epic- IDs,
+//   while a bare "a3f8" matches the hash under any configured prefix
+// - Titles: input that can't be an ID ("login bug") is matched against
+//   titles instead, as in ResolveTitle
 //
 // Returns an error if:
 // - No issue found matching the ID
-// - Multiple issues match (ambiguous prefix)
+// - Multiple issues match (ambiguous prefix); the error is an *AmbiguousIDError
 func ResolvePartialID(ctx context.Context, store storage.Storage, input string) (string, error) {
-	// Get the configured prefix
-	prefix, err := store.GetConfig(ctx, "issue_prefix")
-	if err != nil || prefix == "" {
-		prefix = "bd"
-	}
-	
-	// Ensure prefix has hyphen for ID format
-	prefixWithHyphen := prefix
-	if !strings.HasSuffix(prefix, "-") {
-		prefixWithHyphen = prefix + "-"
+	if !LooksLikeID(input) {
+		return ResolveTitle(ctx, store, input)
 	}
+
+	// Get the configured prefixes
+	prefixes := GetIDPrefixes(ctx, store)
 	
 	// Normalize input:
-	// 1. If it has the full prefix with hyphen (bd-a3f8e9), use as-is
-	// 2. Otherwise, add prefix with hyphen (handles both bare hashes and prefix-without-hyphen cases)
+	// 1. If it has a configured prefix with hyphen (bd-a3f8e9), use as-is
+	// 2. Otherwise, add each prefix with hyphen (handles both bare hashes and prefix-without-hyphen cases)
 	
-	var normalizedID string
+	inputPrefix := prefixes.Match(input)
+	var candidates []string
+	var hashPart string
 	
-	if strings.HasPrefix(input, prefixWithHyphen) {
+	if inputPrefix != "" {
 		// Already has prefix with hyphen: "bd-a3f8e9"
-		normalizedID = input
+		candidates = []string{input}
+		hashPart = strings.TrimPrefix(input, inputPrefix+"-")
 	} else {
 		// Bare hash or prefix without hyphen: "a3f8e9", "07b8c8", "bda3f8e9" → all get prefix with hyphen added
-		normalizedID = prefixWithHyphen + input
+		for _, prefix := range prefixes.All() {
+			candidates = append(candidates, prefix+"-"+input)
+		}
+		hashPart = input
 	}
 	
 	// First try exact match
-	issue, err := store.GetIssue(ctx, normalizedID)
-	if err == nil && issue != nil {
-		return normalizedID, nil
+	var exact []*types.Issue
+	for _, candidate := range candidates {
+		issue, err := store.GetIssue(ctx, candidate)
+		if err == nil && issue != nil {
+			exact = append(exact, issue)
+		}
+	}
+	if len(exact) == 1 {
+		return exact[0].ID, nil
+	}
+	if len(exact) > 1 {
+		return "", newAmbiguousIDError(input, exact, true)
 	}
 	
-	// If exact match failed, try substring search
-	filter := types.IssueFilter{}
+	// If exact match failed, try substring search, archived issues included
+	filter := types.IssueFilter{IncludeArchived: true}
 	
 	issues, err := store.SearchIssues(ctx, "", filter)
 	if err != nil {
 		return "", fmt.Errorf("failed to search issues: %w", err)
 	}
 	
-	// Extract the hash part for substring matching
-	hashPart := strings.TrimPrefix(normalizedID, prefixWithHyphen)
-
-	var matches []string
+	hashRoot, childPath := splitChildPath(hashPart)
+	var matches []*types.Issue
 	for _, issue := range issues {
-		issueHash := strings.TrimPrefix(issue.ID, prefixWithHyphen)
-		// Check if the issue hash contains the input hash as substring
-		if strings.Contains(issueHash, hashPart) {
-			matches = append(matches, issue.ID)
+		issuePrefix := prefixes.Match(issue.ID)
+		if inputPrefix != "" && issuePrefix != inputPrefix {
+			continue
+		}
+		issueHash := issue.ID
+		if issuePrefix != "" {
+			issueHash = strings.TrimPrefix(issue.ID, issuePrefix+"-")
+		}
+		// Check if the issue hash contains the input hash as substring,
+		// at the same place in the hierarchy
+		issueRoot, issueChildPath := splitChildPath(issueHash)
+		if issueChildPath == childPath && strings.Contains(issueRoot, hashRoot) {
+			matches = append(matches, issue)
 		}
 	}
 	
 	if len(matches) == 0 {
-		return "", fmt.Errorf("no issue found matching %q", input)
+		return "", types.WithCode(types.ErrCodeNotFound, fmt.Errorf("no issue found matching %q", input))
 	}
 	
 	if len(matches) > 1 {
-		return "", fmt.Errorf("ambiguous ID %q matches %d issues: %v\nUse more characters to disambiguate", input, len(matches), matches)
+		return "", newAmbiguousIDError(input, matches, false)
 	}
 	
-	return matches[0], nil
+	return matches[0].ID, nil
+}
+
+// splitChildPath splits a hierarchical hash like "a3f8e9.1.2" into its
+// root hash "a3f8e9" and child path ".1.2". The path is empty for a
+// top-level hash.
+func splitChildPath(hash string) (root, childPath string) {
+	if i := strings.Index(hash, "."); i >= 0 {
+		return hash[:i], hash[i:]
+	}
+	return hash, ""
+}
+
+// LooksLikeID reports whether input has the shape of a (possibly partial)
+// issue ID: letters, digits, hyphens, dots and underscores only
+func LooksLikeID(input string) bool {
+	if input == "" {
+		return false
+	}
+	for _, r := range input {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '.' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// maxTitleCandidates caps the candidates an ambiguous title lists
+const maxTitleCandidates = 10
+
+// ResolveTitle resolves a title, or part of one, to an issue ID using
+// store.FindByTitle. An issue whose whole title matches (ignoring case)
+// wins if it's the only one; otherwise the query must match exactly one
+// issue. When several match, the error is an *AmbiguousIDError listing the
+// best candidates.
+func ResolveTitle(ctx context.Context, store storage.Storage, query string) (string, error) {
+	matches, err := store.FindByTitle(ctx, query)
+	if err != nil {
+		return "", fmt.Errorf("failed to search titles: %w", err)
+	}
+	switch len(matches) {
+	case 0:
+		return "", types.WithCode(types.ErrCodeNotFound, fmt.Errorf("no issue found matching %q", query))
+	case 1:
+		return matches[0].ID, nil
+	}
+
+	normalized := types.NormalizeTitle(query)
+	var exact []*types.Issue
+	for _, issue := range matches {
+		if types.NormalizeTitle(issue.Title) == normalized {
+			exact = append(exact, issue)
+		}
+	}
+	if len(exact) == 1 {
+		return exact[0].ID, nil
+	}
+	ambiguous := &AmbiguousIDError{Input: query, Candidates: matches, ByTitle: true}
+	if len(matches) > maxTitleCandidates {
+		ambiguous.Candidates = matches[:maxTitleCandidates]
+		ambiguous.Total = len(matches)
+	}
+	return "", ambiguous
 }
 
 // ResolvePartialIDs resolves multiple potentially partial issue IDs.
-// Returns the resolved IDs and any errors encountered.
+// Returns the resolved IDs, or the first error encountered (an
+// *AmbiguousIDError if an input matched several issues).
 func ResolvePartialIDs(ctx context.Context, store storage.Storage, inputs []string) ([]string, error) {
 	var resolved []string
 	for _, input := range inputs {
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/steveyegge/beads/internal/storage/memory"
@@ -415,3 +417,209 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestResolvePartialID_PrefixByType(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New("")
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetConfig(ctx, types.PrefixByTypeConfigKey, `{"epic":"epic"}`); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"bd-a3f8", "epic-91cc", "epic-91cc.1", "bd-7e2d", "epic-7e2d"} {
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+		errorMsg string
+	}{
+		{input: "epic-91cc", expected: "epic-91cc"},
+		{input: "91cc", expected: "epic-91cc"},          // Bare hash under a per-type prefix
+		{input: "91cc.1", expected: "epic-91cc.1"},      // Hierarchical child keeps the parent's prefix
+		{input: "a3f", expected: "bd-a3f8"},             // Partial under the default prefix
+		{input: "epic-a3f", errorMsg: "no issue found"}, // A prefixed input only matches that prefix
+		{input: "7e2d", errorMsg: "ambiguous"},          // Same hash under two prefixes
+		{input: "bd-7e2d", expected: "bd-7e2d"},
+	}
+	for _, tt := range tests {
+		result, err := ResolvePartialID(ctx, store, tt.input)
+		if tt.errorMsg != "" {
+			if err == nil || !contains(err.Error(), tt.errorMsg) {
+				t.Errorf("ResolvePartialID(%q) = %q, %v; want error containing %q", tt.input, result, err, tt.errorMsg)
+			}
+			continue
+		}
+		if err != nil || result != tt.expected {
+			t.Errorf("ResolvePartialID(%q) = %q, %v; want %q", tt.input, result, err, tt.expected)
+		}
+	}
+}
+
+func TestResolvePartialID_AmbiguousCandidates(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New("")
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatal(err)
+	}
+	titles := map[string]string{"bd-a3f8e9a2": "Fix login", "bd-a3f1b0cc": "Refactor parser", "bd-a3fdead1": "Dead code", "bd-b000": "Unrelated"}
+	for id, title := range titles {
+		issue := &types.Issue{ID: id, Title: title, Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := ResolvePartialIDs(ctx, store, []string{"b000", "a3f"})
+	var ambiguous *AmbiguousIDError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("expected *AmbiguousIDError, got %v", err)
+	}
+	if ambiguous.Input != "a3f" || ambiguous.ExactMatch {
+		t.Errorf("Input = %q, ExactMatch = %v; want \"a3f\", false", ambiguous.Input, ambiguous.ExactMatch)
+	}
+	var ids []string
+	for _, issue := range ambiguous.Candidates {
+		ids = append(ids, issue.ID)
+	}
+	if strings.Join(ids, " ") != "bd-a3f1b0cc bd-a3f8e9a2 bd-a3fdead1" {
+		t.Errorf("candidates = %v, want sorted a3f matches", ids)
+	}
+	for _, want := range []string{"bd-a3f8e9a2  Fix login", "bd-a3f1b0cc  Refactor parser", "bd-a3fdead1  Dead code"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should list %q", err.Error(), want)
+		}
+	}
+}
+
+func TestResolvePartialID_Hierarchical(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New("")
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatal(err)
+	}
+	ids := []string{
+		"bd-a3f8e9a2", "bd-a3f8e9a2.1", "bd-a3f8e9a2.2", "bd-a3f8e9a2.2.1", "bd-a3f8e9a2.2.10",
+		"bd-b7c1d2e3", "bd-b7c1d2e3.1", "bd-b7c9f0a1", "bd-b7c9f0a1.1",
+	}
+	for _, id := range ids {
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+		errorMsg string
+	}{
+		{input: "a3f8", expected: "bd-a3f8e9a2"},           // Children don't make the epic ambiguous
+		{input: "a3f8.2", expected: "bd-a3f8e9a2.2"},       // Child
+		{input: "bd-a3f8.1", expected: "bd-a3f8e9a2.1"},    // Child, with prefix
+		{input: "a3f8.2.1", expected: "bd-a3f8e9a2.2.1"},   // Grandchild
+		{input: "a3f8.2.10", expected: "bd-a3f8e9a2.2.10"}, // Child path matches exactly, not by prefix
+		{input: "a3f8.3", errorMsg: "no issue found"},      // No such child
+		{input: "a3f8.2.2", errorMsg: "no issue found"},    // No such grandchild
+		{input: "b7c1.1", expected: "bd-b7c1d2e3.1"},
+		{input: "b7c.1", errorMsg: "ambiguous"},          // Hash prefix alone is ambiguous
+		{input: "a3f8e9a2.2", expected: "bd-a3f8e9a2.2"}, // Full hierarchical ID
+	}
+	for _, tt := range tests {
+		result, err := ResolvePartialID(ctx, store, tt.input)
+		if tt.errorMsg != "" {
+			if err == nil || !contains(err.Error(), tt.errorMsg) {
+				t.Errorf("ResolvePartialID(%q) = %q, %v; want error containing %q", tt.input, result, err, tt.errorMsg)
+			}
+			continue
+		}
+		if err != nil || result != tt.expected {
+			t.Errorf("ResolvePartialID(%q) = %q, %v; want %q", tt.input, result, err, tt.expected)
+		}
+	}
+
+	_, err := ResolvePartialID(ctx, store, "b7c.1")
+	var ambiguous *AmbiguousIDError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("expected *AmbiguousIDError, got %v", err)
+	}
+	var got []string
+	for _, issue := range ambiguous.Candidates {
+		got = append(got, issue.ID)
+	}
+	if strings.Join(got, " ") != "bd-b7c1d2e3.1 bd-b7c9f0a1.1" {
+		t.Errorf("candidates = %v, want both .1 children", got)
+	}
+}
+
+func TestResolveTitle(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New("")
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatal(err)
+	}
+	titles := map[string]string{"bd-1": "Login bug", "bd-2": "Login bug on Safari", "bd-3": "Refactor parser", "bd-4": "Parser crashes on emoji"}
+	for id, title := range titles {
+		issue := &types.Issue{ID: id, Title: title, Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+		errorMsg string
+	}{
+		{"login bug", "bd-1", ""},      // The only whole-title match beats the longer one
+		{"on safari", "bd-2", ""},      // Substring
+		{"refactr parser", "bd-3", ""}, // Typo
+		{"no such issue", "", "no issue found"},
+		{"bd-3", "bd-3", ""}, // IDs still resolve as IDs
+	}
+	for _, tt := range tests {
+		result, err := ResolvePartialID(ctx, store, tt.input)
+		if tt.errorMsg != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("ResolvePartialID(%q) = %q, %v; want error containing %q", tt.input, result, err, tt.errorMsg)
+			}
+			continue
+		}
+		if err != nil || result != tt.expected {
+			t.Errorf("ResolvePartialID(%q) = %q, %v; want %q", tt.input, result, err, tt.expected)
+		}
+	}
+
+	// Single words look like IDs, so they only match titles through ResolveTitle
+	if _, err := ResolvePartialID(ctx, store, "parser"); err == nil {
+		t.Error("expected a single word to be resolved as an ID")
+	}
+	_, err := ResolveTitle(ctx, store, "parser")
+	var ambiguous *AmbiguousIDError
+	if !errors.As(err, &ambiguous) || !ambiguous.ByTitle {
+		t.Fatalf("expected a title *AmbiguousIDError, got %v", err)
+	}
+	if len(ambiguous.Candidates) != 2 || ambiguous.Candidates[0].ID != "bd-4" {
+		t.Errorf("expected bd-4 (a prefix match) ranked before bd-3, got %+v", ambiguous.Candidates)
+	}
+	if !strings.Contains(err.Error(), `title "parser" matches 2 issues`) || !strings.Contains(err.Error(), "bd-4  Parser crashes on emoji") {
+		t.Errorf("unexpected error %q", err.Error())
+	}
+}
+
+func TestGetIDPrefixes_EnvOverride(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New("")
+	if err := store.SetConfig(ctx, "issue_prefix", "bd"); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(types.IssuePrefixEnvVar, "ci")
+	if got := GetIDPrefixes(ctx, store).Default; got != "ci" {
+		t.Errorf("GetIDPrefixes().Default = %q, want %q from %s", got, "ci", types.IssuePrefixEnvVar)
+	}
+}
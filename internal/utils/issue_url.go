@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// IssueURLTemplateConfigKey holds a URL template for linking issues, with
+// {id} standing for the issue ID, e.g. https://issues.example.com/{id}
+const IssueURLTemplateConfigKey = "issue_url_template"
+
+// ExpandIssueURL fills an issue URL template in with id. Returns "" when no
+// template is configured.
+func ExpandIssueURL(template, id string) string {
+	if template == "" {
+		return ""
+	}
+	return strings.ReplaceAll(template, "{id}", url.PathEscape(id))
+}
+
+// ValidateIssueURLTemplate checks that template contains {id} and expands to
+// an absolute URL
+func ValidateIssueURLTemplate(template string) error {
+	if !strings.Contains(template, "{id}") {
+		return fmt.Errorf("issue URL template must contain {id}, e.g. https://issues.example.com/{id}")
+	}
+	u, err := url.Parse(ExpandIssueURL(template, "bd-1"))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("issue URL template %q is not an absolute URL, e.g. https://issues.example.com/{id}", template)
+	}
+	return nil
+}
+
+// IssueURL returns the link for an issue under the store's configured URL
+// template, or "" when none is set
+func IssueURL(ctx context.Context, s storage.Storage, id string) string {
+	template, err := s.GetConfig(ctx, IssueURLTemplateConfigKey)
+	if err != nil {
+		return ""
+	}
+	return ExpandIssueURL(template, id)
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/memory"
+)
+
+func TestExpandIssueURL(t *testing.T) {
+	tests := []struct {
+		template string
+		id       string
+		want     string
+	}{
+		{"https://issues.example.com/{id}", "bd-a3f8", "https://issues.example.com/bd-a3f8"},
+		{"https://x.test/browse?q={id}&ref={id}", "bd-a3f8.1", "https://x.test/browse?q=bd-a3f8.1&ref=bd-a3f8.1"},
+		{"https://x.test/{id}", "odd id/1", "https://x.test/odd%20id%2F1"},
+		{"", "bd-1", ""},
+	}
+	for _, tt := range tests {
+		if got := ExpandIssueURL(tt.template, tt.id); got != tt.want {
+			t.Errorf("ExpandIssueURL(%q, %q) = %q, want %q", tt.template, tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestValidateIssueURLTemplate(t *testing.T) {
+	for _, template := range []string{
+		"https://issues.example.com/{id}",
+		"http://localhost:8080/issues/{id}/view",
+	} {
+		if err := ValidateIssueURLTemplate(template); err != nil {
+			t.Errorf("ValidateIssueURLTemplate(%q) = %v, want nil", template, err)
+		}
+	}
+	for _, template := range []string{
+		"",
+		"https://issues.example.com/",
+		"issues/{id}",
+		"https://issues.example.com/{ID}",
+	} {
+		if err := ValidateIssueURLTemplate(template); err == nil {
+			t.Errorf("ValidateIssueURLTemplate(%q) = nil, want an error", template)
+		}
+	}
+}
+
+func TestIssueURL(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New("")
+	if got := IssueURL(ctx, store, "bd-1"); got != "" {
+		t.Errorf("expected no URL without a template, got %q", got)
+	}
+	if err := store.SetConfig(ctx, IssueURLTemplateConfigKey, "https://issues.example.com/{id}"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if got := IssueURL(ctx, store, "bd-1"); got != "https://issues.example.com/bd-1" {
+		t.Errorf("IssueURL = %q, want https://issues.example.com/bd-1", got)
+	}
+}
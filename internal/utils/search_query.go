@@ -0,0 +1,220 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/util"
+)
+
+// SearchQueryFields are the fields a search query term can be scoped to
+var SearchQueryFields = []string{"assignee", "description", "label", "status", "title", "type"}
+
+// searchTerm is one whitespace-separated term of a search query
+type searchTerm struct {
+	field string // Lowercased; empty for free text
+	value string // Unquoted
+}
+
+// ParseSearchQuery splits a search query into field:value terms, which it
+// adds to filter, and the free text left over, which it returns for the
+// text match. The grammar:
+//
+//	query := term { whitespace term }
+//	term  := field ":" value | text
+//	field := assignee | description | label | status | title | type
+//
+// Values and text may be double-quoted to include spaces (title:"login page")
+// and \" inside quotes is a literal quote. A quoted term ("status:open") is
+// always text. label: may repeat, and all labels must match; the other fields
+// may be given once. A term ending in a colon or whose value starts with //
+// (as in a URL) is text too.
+//
+// A query without field terms is returned exactly as given.
+func ParseSearchQuery(query string, filter *types.IssueFilter) (string, error) {
+	terms, err := splitSearchQuery(query)
+	if err != nil {
+		if !strings.Contains(query, ":") {
+			return query, nil // Plain text with a stray quote
+		}
+		return "", err
+	}
+	scoped := false
+	for _, term := range terms {
+		if term.field != "" {
+			scoped = true
+			break
+		}
+	}
+	if !scoped {
+		return query, nil
+	}
+
+	var text []string
+	seen := make(map[string]string)
+	for _, term := range terms {
+		if term.field == "" {
+			text = append(text, term.value)
+			continue
+		}
+		if term.field != "label" {
+			if prev, ok := seen[term.field]; ok && prev != term.value {
+				return "", fmt.Errorf("%s: given more than once (%q and %q)", term.field, prev, term.value)
+			}
+			seen[term.field] = term.value
+		}
+		if err := applySearchTerm(term, filter); err != nil {
+			return "", err
+		}
+	}
+	return strings.Join(text, " "), nil
+}
+
+// applySearchTerm sets the filter field a field:value term scopes to,
+// refusing to override a different value the filter already has
+func applySearchTerm(term searchTerm, filter *types.IssueFilter) error {
+	conflict := func(existing string) error {
+		return fmt.Errorf("%s:%s conflicts with the %s filter %q already given", term.field, term.value, term.field, existing)
+	}
+	switch term.field {
+	case "title":
+		if filter.TitleContains != "" && filter.TitleContains != term.value {
+			return conflict(filter.TitleContains)
+		}
+		filter.TitleContains = term.value
+	case "description":
+		if filter.DescriptionContains != "" && filter.DescriptionContains != term.value {
+			return conflict(filter.DescriptionContains)
+		}
+		filter.DescriptionContains = term.value
+	case "status":
+		status := types.Status(strings.ToLower(term.value))
+		if !status.IsValid() {
+			return fmt.Errorf("invalid status:%s (must be open, in_progress, blocked or closed)", term.value)
+		}
+		if filter.Status != nil && *filter.Status != status {
+			return conflict(string(*filter.Status))
+		}
+		filter.Status = &status
+	case "type":
+		issueType := types.IssueType(strings.ToLower(term.value))
+		if !issueType.IsValid() {
+			return fmt.Errorf("invalid type:%s (must be bug, feature, task, epic or chore)", term.value)
+		}
+		if filter.IssueType != nil && *filter.IssueType != issueType {
+			return conflict(string(*filter.IssueType))
+		}
+		filter.IssueType = &issueType
+	case "assignee":
+		if filter.Assignee != nil && *filter.Assignee != term.value {
+			return conflict(*filter.Assignee)
+		}
+		assignee := term.value
+		filter.Assignee = &assignee
+	case "label":
+		filter.Labels = util.NormalizeLabels(append(filter.Labels, term.value))
+	default:
+		return unknownSearchFieldError(term.field)
+	}
+	return nil
+}
+
+// splitSearchQuery breaks a query into terms, unquoting values and
+// recognizing field:value terms
+func splitSearchQuery(query string) ([]searchTerm, error) {
+	var terms []searchTerm
+	var buf strings.Builder
+	inToken, inQuotes, quoted := false, false, false
+	colon := -1 // Offset in buf of the first unquoted colon, before any quote
+
+	flush := func() {
+		if !inToken {
+			return
+		}
+		term := searchTerm{value: buf.String()}
+		if colon > 0 && colon < buf.Len()-1 || colon > 0 && quoted {
+			field, value := term.value[:colon], term.value[colon+1:]
+			if isSearchFieldName(field) && !strings.HasPrefix(value, "//") {
+				term = searchTerm{field: strings.ToLower(field), value: value}
+			}
+		}
+		terms = append(terms, term)
+		buf.Reset()
+		inToken, quoted, colon = false, false, -1
+	}
+
+	runes := []rune(query)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case inQuotes && r == '\\' && i+1 < len(runes) && runes[i+1] == '"':
+			buf.WriteRune('"')
+			i++
+		case r == '"':
+			inToken = true
+			inQuotes = !inQuotes
+			quoted = true
+		case !inQuotes && (r == ' ' || r == '\t' || r == '\n'):
+			flush()
+		default:
+			if r == ':' && !inQuotes && !quoted && colon < 0 {
+				colon = buf.Len()
+			}
+			inToken = true
+			buf.WriteRune(r)
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote in search query %q", query)
+	}
+	flush()
+	return terms, nil
+}
+
+// isSearchFieldName reports whether s looks like a field name: letters and
+// underscores
+func isSearchFieldName(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && r != '_' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// unknownSearchFieldError names the known fields closest to field
+func unknownSearchFieldError(field string) error {
+	var suggestions []string
+	for _, known := range SearchQueryFields {
+		if strings.HasPrefix(known, field) || strings.HasPrefix(field, known) || editDistance(field, known) <= 2 {
+			suggestions = append(suggestions, known+":")
+		}
+	}
+	msg := fmt.Sprintf("unknown search field %q", field+":")
+	if len(suggestions) > 0 {
+		msg += fmt.Sprintf(" (did you mean %s?)", strings.Join(suggestions, " or "))
+	}
+	return fmt.Errorf("%s; fields are %s:, or quote the term to search for it as text", msg, strings.Join(SearchQueryFields, ":, "))
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	open, closed := types.StatusOpen, types.StatusClosed
+	bug := types.TypeBug
+	alice, bob := "alice", "bob smith"
+	tests := []struct {
+		query  string
+		text   string
+		filter types.IssueFilter
+	}{
+		// Plain queries pass through untouched
+		{"login page", "login page", types.IssueFilter{}},
+		{`  "exact phrase"  `, `  "exact phrase"  `, types.IssueFilter{}},
+		{`say "hi`, `say "hi`, types.IssueFilter{}},
+		{"Note: flaky test", "Note: flaky test", types.IssueFilter{}},
+		{"see https://example.com/x", "see https://example.com/x", types.IssueFilter{}},
+
+		{"title:login status:open label:urgent", "", types.IssueFilter{TitleContains: "login", Status: &open, Labels: []string{"urgent"}}},
+		{"crash Type:BUG assignee:alice on save", "crash on save", types.IssueFilter{IssueType: &bug, Assignee: &alice}},
+		{`title:"login page" description:"null pointer" timeout`, "timeout", types.IssueFilter{TitleContains: "login page", DescriptionContains: "null pointer"}},
+		{`assignee:"bob smith" status:closed`, "", types.IssueFilter{Assignee: &bob, Status: &closed}},
+		{"label:a label:b label:a", "", types.IssueFilter{Labels: []string{"a", "b"}}},
+		{`label:x "status:open" "two words"`, "status:open two words", types.IssueFilter{Labels: []string{"x"}}},
+		{`title:"say \"hi\""`, "", types.IssueFilter{TitleContains: `say "hi"`}},
+		{"status:open status:open", "", types.IssueFilter{Status: &open}},
+	}
+	for _, tt := range tests {
+		var filter types.IssueFilter
+		text, err := ParseSearchQuery(tt.query, &filter)
+		if err != nil {
+			t.Errorf("ParseSearchQuery(%q) failed: %v", tt.query, err)
+			continue
+		}
+		if text != tt.text || !reflect.DeepEqual(filter, tt.filter) {
+			t.Errorf("ParseSearchQuery(%q) = %q, %+v; want %q, %+v", tt.query, text, filter, tt.text, tt.filter)
+		}
+	}
+}
+
+func TestParseSearchQueryMergesFilter(t *testing.T) {
+	open := types.StatusOpen
+	filter := types.IssueFilter{Status: &open, Labels: []string{"backend"}}
+	if _, err := ParseSearchQuery("status:open label:urgent", &filter); err != nil {
+		t.Fatalf("ParseSearchQuery failed: %v", err)
+	}
+	if !reflect.DeepEqual(filter.Labels, []string{"backend", "urgent"}) {
+		t.Errorf("Expected labels to accumulate, got %v", filter.Labels)
+	}
+	if _, err := ParseSearchQuery("status:closed", &filter); err == nil || !strings.Contains(err.Error(), "conflicts") {
+		t.Errorf("Expected a conflict with the existing status, got %v", err)
+	}
+}
+
+func TestParseSearchQueryErrors(t *testing.T) {
+	for query, want := range map[string]string{
+		"titel:login":              `did you mean title:?`,
+		"desc:crash":               `did you mean description:?`,
+		"priority:1":               `unknown search field "priority:"`,
+		"status:done":              "invalid status:done",
+		"type:story":               "invalid type:story",
+		"title:a title:b":          "given more than once",
+		`title:"login page status`: "unterminated quote",
+	} {
+		var filter types.IssueFilter
+		_, err := ParseSearchQuery(query, &filter)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseSearchQuery(%q) error = %v, want it to mention %q", query, err, want)
+		}
+	}
+}